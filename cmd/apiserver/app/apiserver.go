@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -17,6 +18,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/clusterpedia-io/clusterpedia/cmd/apiserver/app/options"
+	"github.com/clusterpedia-io/clusterpedia/pkg/componentconfig"
 	_ "github.com/clusterpedia-io/clusterpedia/pkg/metrics"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	"github.com/clusterpedia-io/clusterpedia/pkg/version/verflag"
@@ -30,11 +32,24 @@ func NewClusterPediaServerCommand(ctx context.Context) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			verflag.PrintAndExitIfRequested()
 
+			// The configuration file is applied before the flags are used,
+			// the flags set on the command line take precedence.
+			if err := opts.ComponentConfig.ApplyTo(&componentconfig.APIServerConfiguration{}, cmd.Flags()); err != nil {
+				return err
+			}
+
 			// Activate logging as soon as possible, after that
 			// show flags with the final logging configuration.
 			if err := logsapi.ValidateAndApply(opts.Logs, utilfeature.DefaultFeatureGate); err != nil {
 				return err
 			}
+			if opts.ComponentConfig.ValidateOnly {
+				if err := opts.Validate(); err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), "The configuration is valid")
+				return nil
+			}
 			cliflag.PrintFlags(cmd.Flags())
 
 			config, err := opts.Config()
@@ -73,6 +88,8 @@ func NewClusterPediaServerCommand(ctx context.Context) *cobra.Command {
 
 	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
 	cliflag.SetUsageAndHelpFunc(cmd, namedFlagSets, cols)
+
+	cmd.AddCommand(newCheckCommand(ctx))
 	return cmd
 }
 
@@ -8,7 +8,7 @@ import (
 	"strings"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
-	openapinamer "k8s.io/apiserver/pkg/endpoints/openapi"
+	"k8s.io/apiserver/pkg/authorization/union"
 	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
@@ -21,8 +21,17 @@ import (
 	"k8s.io/component-base/metrics"
 
 	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver"
-	generatedopenapi "github.com/clusterpedia-io/clusterpedia/pkg/generated/openapi"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/explorer"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/externalmetrics"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/federation"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/listpolicy"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/openapi"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/publicaccess"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/shutdown"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/slo"
+	"github.com/clusterpedia-io/clusterpedia/pkg/componentconfig"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/configuration"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	storageoptions "github.com/clusterpedia-io/clusterpedia/pkg/storage/options"
 )
@@ -50,6 +59,17 @@ type ClusterPediaServerOptions struct {
 
 	Storage        *storageoptions.StorageOptions
 	ResourceServer *kubeapiserver.Options
+	APIExplorer    *explorer.Options
+
+	QueryDegradation *slo.Options
+	ListPolicy       *listpolicy.Options
+	ExternalMetrics  *externalmetrics.Options
+	Federation       *federation.Options
+	Configuration    *configuration.Options
+	PublicAccess     *publicaccess.Options
+	Shutdown         *shutdown.Options
+
+	ComponentConfig *componentconfig.Options
 }
 
 func NewServerOptions() *ClusterPediaServerOptions {
@@ -81,6 +101,17 @@ func NewServerOptions() *ClusterPediaServerOptions {
 
 		Storage:        storageoptions.NewStorageOptions(),
 		ResourceServer: kubeapiserver.NewOptions(),
+		APIExplorer:    explorer.NewOptions(),
+
+		QueryDegradation: slo.NewOptions(),
+		ListPolicy:       listpolicy.NewOptions(),
+		ExternalMetrics:  externalmetrics.NewOptions(),
+		Federation:       federation.NewOptions(),
+		Configuration:    configuration.NewOptions(),
+		PublicAccess:     publicaccess.NewOptions(),
+		Shutdown:         shutdown.NewOptions(),
+
+		ComponentConfig: componentconfig.NewOptions(),
 	}
 }
 
@@ -89,6 +120,16 @@ func (o *ClusterPediaServerOptions) Validate() error {
 	errors = append(errors, o.validateGenericOptions()...)
 	errors = append(errors, o.Storage.Validate()...)
 	errors = append(errors, o.Metrics.Validate()...)
+	errors = append(errors, o.APIExplorer.Validate()...)
+	errors = append(errors, o.QueryDegradation.Validate()...)
+	errors = append(errors, o.ListPolicy.Validate()...)
+	errors = append(errors, o.ExternalMetrics.Validate()...)
+	errors = append(errors, o.Federation.Validate()...)
+	errors = append(errors, o.PublicAccess.Validate()...)
+	errors = append(errors, o.Shutdown.Validate()...)
+	if o.PublicAccess.Config() != nil && o.Authentication.Anonymous != nil && !o.Authentication.Anonymous.Enabled {
+		errors = append(errors, fmt.Errorf("--anonymous-auth must not be disabled to enable the public access"))
+	}
 
 	return utilerrors.NewAggregate(errors)
 }
@@ -104,6 +145,11 @@ func (o *ClusterPediaServerOptions) Config() (*apiserver.Config, error) {
 		return nil, err
 	}
 
+	federation, err := o.Federation.Config()
+	if err != nil {
+		return nil, err
+	}
+
 	resourceServerConfig, err := o.ResourceServer.Config()
 	if err != nil {
 		return nil, err
@@ -121,28 +167,39 @@ func (o *ClusterPediaServerOptions) Config() (*apiserver.Config, error) {
 
 	genericConfig := genericapiserver.NewRecommendedConfig(apiserver.Codecs)
 
-	genericConfig.OpenAPIConfig = genericapiserver.DefaultOpenAPIConfig(generatedopenapi.GetOpenAPIDefinitions, openapinamer.NewDefinitionNamer(apiserver.Scheme))
-	genericConfig.OpenAPIConfig.Info.Title = "clusterpedia apiserver"
-	genericConfig.OpenAPIConfig.Info.Version = ""
-
-	genericConfig.OpenAPIV3Config = genericapiserver.DefaultOpenAPIV3Config(generatedopenapi.GetOpenAPIDefinitions, openapinamer.NewDefinitionNamer(apiserver.Scheme))
-	genericConfig.OpenAPIV3Config.Info.Title = "clusterpedia apiserver"
-	genericConfig.OpenAPIV3Config.Info.Version = ""
+	genericConfig.OpenAPIConfig = openapi.NewConfig(apiserver.Scheme)
+	genericConfig.OpenAPIV3Config = openapi.NewV3Config(apiserver.Scheme)
 
 	// todo
 	// support watch to LongRunningFunc
 	genericConfig.LongRunningFunc = func(r *http.Request, requestInfo *genericrequest.RequestInfo) bool {
-		return strings.Contains(r.RequestURI, "watch")
+		return strings.Contains(r.RequestURI, "watch") || strings.HasSuffix(r.URL.Path, kubeapiserver.SubscriptionPath)
 	}
 
 	if err := o.genericOptionsApplyTo(genericConfig); err != nil {
 		return nil, err
 	}
+	o.Shutdown.ApplyTo(&genericConfig.Config)
+
+	publicAccess := o.PublicAccess.Config()
+	if publicAccess != nil && genericConfig.Authorization.Authorizer != nil {
+		genericConfig.Authorization.Authorizer = union.New(publicaccess.NewAuthorizer(), genericConfig.Authorization.Authorizer)
+	}
 
 	return &apiserver.Config{
 		GenericConfig:  genericConfig,
 		StorageFactory: storage,
 		ExtraConfig:    resourceServerConfig,
+		APIExplorer:    o.APIExplorer.Config(),
+
+		QueryDegradation: o.QueryDegradation.Config(),
+		ListPolicy:       o.ListPolicy.Config(),
+		ExternalMetrics:  o.ExternalMetrics.Config(),
+		Federation:       federation,
+		PublicAccess:     publicAccess,
+		Shutdown:         o.Shutdown.Config(),
+
+		ConfigurationName: o.Configuration.Name,
 	}, nil
 }
 
@@ -201,6 +258,15 @@ func (o *ClusterPediaServerOptions) Flags() cliflag.NamedFlagSets {
 
 	o.Storage.AddFlags(fss.FlagSet("storage"))
 	o.ResourceServer.AddFlags(fss.FlagSet("resource server"))
+	o.APIExplorer.AddFlags(fss.FlagSet("api explorer"))
+	o.QueryDegradation.AddFlags(fss.FlagSet("query degradation"))
+	o.ListPolicy.AddFlags(fss.FlagSet("list policy"))
+	o.ExternalMetrics.AddFlags(fss.FlagSet("external metrics"))
+	o.Federation.AddFlags(fss.FlagSet("federation"))
+	o.Configuration.AddFlags(fss.FlagSet("configuration"))
+	o.PublicAccess.AddFlags(fss.FlagSet("public access"))
+	o.Shutdown.AddFlags(fss.FlagSet("shutdown"))
+	o.ComponentConfig.AddFlags(fss.FlagSet("component config"))
 	return fss
 }
 
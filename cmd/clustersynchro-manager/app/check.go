@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/term"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/cmd/clustersynchro-manager/app/options"
+	"github.com/clusterpedia-io/clusterpedia/pkg/preflight"
+)
+
+var checkComponent = preflight.Component{
+	Name:     "clustersynchro-manager",
+	Writable: true,
+	Permissions: []authorizationv1.ResourceAttributes{
+		{Verb: "list", Group: clusterv1alpha2.GroupName, Resource: "pediaclusters"},
+		{Verb: "watch", Group: clusterv1alpha2.GroupName, Resource: "pediaclusters"},
+		{Verb: "update", Group: clusterv1alpha2.GroupName, Resource: "pediaclusters", Subresource: "status"},
+		{Verb: "list", Group: clusterv1alpha2.GroupName, Resource: "clustersyncresources"},
+		{Verb: "watch", Group: clusterv1alpha2.GroupName, Resource: "clustersyncresources"},
+		{Verb: "list", Resource: "secrets", Namespace: "-"},
+		{Verb: "watch", Resource: "secrets", Namespace: "-"},
+		{Verb: "create", Group: "coordination.k8s.io", Resource: "leases", Namespace: "-"},
+		{Verb: "update", Group: "coordination.k8s.io", Resource: "leases", Namespace: "-"},
+		{Verb: "create", Resource: "events"},
+	},
+}
+
+func newCheckCommand(ctx context.Context, namespace string) *cobra.Command {
+	opts := preflight.NewOptions(namespace)
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check whether the clustersynchro manager is ready to roll out",
+		Long: `Check the connectivity, the schema and the privileges of the storage,
+the rbac of the clustersynchro manager in the hub cluster and the reachability of the PediaClusters,
+and print the readiness report.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := opts.Config(checkComponent, options.ClusterSynchroManagerUserAgent)
+			if err != nil {
+				return err
+			}
+
+			report := preflight.Run(ctx, config)
+			if err := report.Print(cmd.OutOrStdout(), opts.Output); err != nil {
+				return err
+			}
+			if !report.Ready {
+				return errors.New("clustersynchro-manager is not ready")
+			}
+			return nil
+		},
+	}
+
+	namedFlagSets := opts.Flags()
+	fs := cmd.Flags()
+	for _, f := range namedFlagSets.FlagSets {
+		fs.AddFlagSet(f)
+	}
+
+	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
+	cliflag.SetUsageAndHelpFunc(cmd, namedFlagSets, cols)
+	return cmd
+}
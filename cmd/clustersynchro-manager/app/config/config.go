@@ -10,6 +10,8 @@ import (
 	kubestatemetrics "github.com/clusterpedia-io/clusterpedia/pkg/kube_state_metrics"
 	metricsserver "github.com/clusterpedia-io/clusterpedia/pkg/metrics/server"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/gc"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/retention"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/clustersynchro"
 )
 
@@ -27,6 +29,12 @@ type Config struct {
 	StorageFactory          storage.StorageFactory
 	ClusterSyncConfig       clustersynchro.ClusterSyncConfig
 
+	// Retention is nil if no retention policies are set
+	Retention *retention.Config
+
+	// OrphanGC is nil if the orphan garbage collector is disabled
+	OrphanGC *gc.Config
+
 	LeaderElection   componentbaseconfig.LeaderElectionConfiguration
 	ClientConnection componentbaseconfig.ClientConnectionConfiguration
 }
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/term"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/cmd/clustersynchro-manager/app/options"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func newInitStorageCommand(ctx context.Context) *cobra.Command {
+	opts := options.NewInitStorageOptions()
+	cmd := &cobra.Command{
+		Use:   "init-storage",
+		Short: "Migrate the storage and grant the least privileges to the database users of the components",
+		Long: `Migrate the storage with the user in the storage config, which is allowed to change the tables,
+and grant the reader user of the apiserver and the writer user of the clustersynchro manager.
+
+The apiserver and the clustersynchro manager should use their own users and skip the migration at startup.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			cliflag.PrintFlags(cmd.Flags())
+
+			factory, err := storage.NewStorageFactory(opts.Storage.Name, opts.Storage.ConfigPath)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := factory.Shutdown(); err != nil {
+					klog.ErrorS(err, "Failed to shutdown storage factory")
+				}
+			}()
+
+			granter, ok := factory.(storage.PrivilegeGranter)
+			if !ok {
+				return fmt.Errorf("storage %s doesn't support granting privileges", opts.Storage.Name)
+			}
+			statements, err := granter.GrantPrivileges(ctx, opts.DatabaseUsers(), opts.PrintOnly)
+			if err != nil {
+				return err
+			}
+			for _, statement := range statements {
+				fmt.Fprintln(cmd.OutOrStdout(), statement+";")
+			}
+			return nil
+		},
+	}
+
+	namedFlagSets := opts.Flags()
+	fs := cmd.Flags()
+	for _, f := range namedFlagSets.FlagSets {
+		fs.AddFlagSet(f)
+	}
+
+	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
+	cliflag.SetUsageAndHelpFunc(cmd, namedFlagSets, cols)
+	return cmd
+}
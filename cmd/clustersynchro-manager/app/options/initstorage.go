@@ -0,0 +1,52 @@
+package options
+
+import (
+	"errors"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	cliflag "k8s.io/component-base/cli/flag"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	storageoptions "github.com/clusterpedia-io/clusterpedia/pkg/storage/options"
+)
+
+// InitStorageOptions are the options of the init-storage command,
+// which migrates the storage and grants the least privileges to the database users of the components.
+type InitStorageOptions struct {
+	Storage *storageoptions.StorageOptions
+
+	ReaderUser string
+	WriterUser string
+	PrintOnly  bool
+}
+
+func NewInitStorageOptions() *InitStorageOptions {
+	return &InitStorageOptions{Storage: storageoptions.NewStorageOptions()}
+}
+
+func (o *InitStorageOptions) Flags() cliflag.NamedFlagSets {
+	var fss cliflag.NamedFlagSets
+
+	o.Storage.AddFlags(fss.FlagSet("storage"))
+
+	fs := fss.FlagSet("privileges")
+	fs.StringVar(&o.ReaderUser, "reader-user", o.ReaderUser, "The database user of the apiserver, which is granted to read the resources.")
+	fs.StringVar(&o.WriterUser, "writer-user", o.WriterUser, "The database user of the clustersynchro manager, which is granted to write the resources.")
+	fs.BoolVar(&o.PrintOnly, "print-only", o.PrintOnly, "Print the grant statements instead of executing them, the storage is still migrated.")
+	return fss
+}
+
+func (o *InitStorageOptions) Validate() error {
+	errs := o.Storage.Validate()
+	if o.ReaderUser == "" && o.WriterUser == "" {
+		errs = append(errs, errors.New("at least one of --reader-user and --writer-user is required"))
+	}
+	if o.ReaderUser != "" && o.ReaderUser == o.WriterUser {
+		errs = append(errs, errors.New("--reader-user and --writer-user must be different users"))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (o *InitStorageOptions) DatabaseUsers() storage.DatabaseUsers {
+	return storage.DatabaseUsers{Reader: o.ReaderUser, Writer: o.WriterUser}
+}
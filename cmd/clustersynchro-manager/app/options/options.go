@@ -3,9 +3,12 @@ package options
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -22,10 +25,16 @@ import (
 	logsapi "k8s.io/component-base/logs/api/v1"
 
 	"github.com/clusterpedia-io/clusterpedia/cmd/clustersynchro-manager/app/config"
+	"github.com/clusterpedia-io/clusterpedia/pkg/componentconfig"
 	crdclientset "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
 	kubestatemetrics "github.com/clusterpedia-io/clusterpedia/pkg/kube_state_metrics"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/configuration"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/gc"
 	storageoptions "github.com/clusterpedia-io/clusterpedia/pkg/storage/options"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/retention"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/cdc"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/cdc/backup"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/clustersynchro"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/resourcesynchro"
 )
@@ -47,11 +56,31 @@ type Options struct {
 	Storage          *storageoptions.StorageOptions
 	Metrics          *MetricsOptions
 	KubeStateMetrics *kubestatemetrics.Options
-
-	RunInNamespace          string
-	WorkerNumber            int // WorkerNumber is the number of worker goroutines
-	PageSizeForResourceSync int64
-	ShardingName            string
+	Configuration    *configuration.Options
+	Retention        *retention.Options
+	OrphanGC         *gc.Options
+	CDC              *cdc.Options
+	Backup           *backup.Options
+	ComponentConfig  *componentconfig.Options
+
+	RunInNamespace             string
+	WorkerNumber               int // WorkerNumber is the number of worker goroutines
+	PageSizeForResourceSync    int64
+	TerminatedPodsTTL          time.Duration
+	CompletedJobsTTL           time.Duration
+	MassDeletion               resourcesynchro.MassDeletionConfig
+	StorageTimeout             time.Duration
+	VerificationRelistInterval time.Duration
+	WatchBookmarkInterval      time.Duration
+	DriftCheckInterval         time.Duration
+	DriftCheckResources        []string
+	DriftCheckRepair           bool
+	ShardingName               string
+
+	HealthCheckStrategy      string
+	HealthCheckProbeResource string
+
+	Transport clustersynchro.TransportConfig
 }
 
 func NewClusterSynchroManagerOptions() (*Options, error) {
@@ -87,8 +116,19 @@ func NewClusterSynchroManagerOptions() (*Options, error) {
 	options.Storage = storageoptions.NewStorageOptions()
 	options.Metrics = NewMetricsOptions()
 	options.KubeStateMetrics = kubestatemetrics.NewOptions()
+	options.Configuration = configuration.NewOptions()
+	options.Retention = retention.NewOptions()
+	options.OrphanGC = gc.NewOptions()
+	options.CDC = cdc.NewOptions()
+	options.Backup = backup.NewOptions()
+	options.ComponentConfig = componentconfig.NewOptions()
 
 	options.WorkerNumber = 5
+	options.MassDeletion = resourcesynchro.MassDeletionConfig{Window: time.Minute, MinDeletions: 100}
+	options.StorageTimeout = resourcesynchro.DefaultStorageTimeout
+	options.WatchBookmarkInterval = time.Minute
+	options.HealthCheckStrategy = string(clustersynchro.ReadyzHealthCheck)
+	options.HealthCheckProbeResource = "v1/namespaces"
 	return &options, nil
 }
 
@@ -104,6 +144,30 @@ func (o *Options) Flags() cliflag.NamedFlagSets {
 
 	syncfs := fss.FlagSet("resource sync")
 	syncfs.Int64Var(&o.PageSizeForResourceSync, "page-size", o.PageSizeForResourceSync, "The requested chunk size of initial and resync watch lists for resource sync")
+	syncfs.DurationVar(&o.TerminatedPodsTTL, "terminated-pods-ttl", o.TerminatedPodsTTL, "The duration for which the succeeded or failed pods are kept in the storage after they are terminated, 0 keeps them until they are deleted from the cluster")
+	syncfs.Float64Var(&o.MassDeletion.Threshold, "mass-deletion-threshold", o.MassDeletion.Threshold, "The percentage of the stored objects of a resource deleted within the mass deletion window to freeze the deletions to the storage until they are confirmed, 0 disables the guard")
+	syncfs.DurationVar(&o.MassDeletion.Window, "mass-deletion-window", o.MassDeletion.Window, "The window to count the deletions of a resource for the mass deletion guard")
+	syncfs.IntVar(&o.MassDeletion.MinDeletions, "mass-deletion-min-deletions", o.MassDeletion.MinDeletions, "The minimum number of the deletions within the window to freeze the deletions, so the resources with a few objects are not frozen")
+	syncfs.DurationVar(&o.VerificationRelistInterval, "verification-relist-interval", o.VerificationRelistInterval, "The jittered interval of the full relists of each resource to repair the divergence from the missed watch events, the relists never overlap with the initial syncs of the cluster, 0 disables the verification relists, e.g. 168h")
+	syncfs.DurationVar(&o.WatchBookmarkInterval, "watch-bookmark-interval", o.WatchBookmarkInterval, "The interval to save the handled resource versions of the watches as the bookmarks, the watches are resumed from the bookmarks after the restarts instead of the relists, it takes effect only if the storage persists the watch bookmarks, 0 disables the bookmarks")
+	syncfs.DurationVar(&o.DriftCheckInterval, "drift-check-interval", o.DriftCheckInterval, "The jittered interval of the checks which compare the stored objects of each resource with a live list of the member cluster and report the phantom, the missing and the stale objects, 0 disables the drift checks, e.g. 24h")
+	syncfs.StringSliceVar(&o.DriftCheckResources, "drift-check-resources", o.DriftCheckResources, "The resources checked by the drift checks in the format of <resource>.<group>, e.g. deployments.apps,pods, all resources are checked if it is empty")
+	syncfs.BoolVar(&o.DriftCheckRepair, "drift-check-repair", o.DriftCheckRepair, "Repair the drifts found by the drift checks with the verification relists of the resources, otherwise the drifts are only reported")
+	syncfs.DurationVar(&o.CompletedJobsTTL, "completed-jobs-ttl", o.CompletedJobsTTL, "The duration for which the complete or failed jobs are kept in the storage after they are finished, 0 keeps them until they are deleted from the cluster")
+
+	healthfs := fss.FlagSet("cluster health check")
+	healthfs.StringVar(&o.HealthCheckStrategy, "health-check-strategy", o.HealthCheckStrategy, "The strategy to check the health of the clusters, one of readyz and resource-probe, resource-probe also lists and watches the probe resource to verify the credentials and the watches")
+	healthfs.StringVar(&o.HealthCheckProbeResource, "health-check-probe-resource", o.HealthCheckProbeResource, "The resource listed and watched by the resource-probe health check, in the format of [<group>/]<version>/<resource>")
+
+	transportfs := fss.FlagSet("cluster transport")
+	transportfs.DurationVar(&o.Transport.HTTP2ReadIdleTimeout, "cluster-http2-read-idle-timeout", o.Transport.HTTP2ReadIdleTimeout, "The idle time after which a health check ping is sent on the HTTP/2 connection to the member cluster, 0 keeps the default of client-go")
+	transportfs.DurationVar(&o.Transport.HTTP2PingTimeout, "cluster-http2-ping-timeout", o.Transport.HTTP2PingTimeout, "The time after which the HTTP/2 connection to the member cluster is closed if the health check ping is not answered, 0 keeps the default of client-go")
+	transportfs.DurationVar(&o.Transport.IdleConnTimeout, "cluster-idle-conn-timeout", o.Transport.IdleConnTimeout, "The max time an idle connection to the member cluster is kept in the connection pool, 0 keeps the default of client-go")
+	transportfs.DurationVar(&o.Transport.TCPKeepAliveIdle, "cluster-tcp-keepalive-idle", o.Transport.TCPKeepAliveIdle, "The idle time of the connection to the member cluster before the first TCP keepalive probe, 0 keeps the default of the system")
+	transportfs.DurationVar(&o.Transport.TCPKeepAliveInterval, "cluster-tcp-keepalive-interval", o.Transport.TCPKeepAliveInterval, "The interval of the TCP keepalive probes of the connection to the member cluster, 0 keeps the default of the system")
+	transportfs.IntVar(&o.Transport.TCPKeepAliveCount, "cluster-tcp-keepalive-count", o.Transport.TCPKeepAliveCount, "The number of unanswered TCP keepalive probes after which the connection to the member cluster is closed, 0 keeps the default of the system")
+	transportfs.DurationVar(&o.Transport.WatchIdleTimeout, "cluster-watch-idle-timeout", o.Transport.WatchIdleTimeout, "Close the watches to the member cluster which receive nothing within the timeout, the informers restart the closed watches. "+
+		"It should be longer than the interval of the bookmarks, which is about 1m, 0 disables it")
 
 	options.BindLeaderElectionFlags(&o.LeaderElection, genericfs)
 
@@ -113,9 +177,18 @@ func (o *Options) Flags() cliflag.NamedFlagSets {
 
 	logsapi.AddFlags(o.Logs, fss.FlagSet("logs"))
 
-	o.Storage.AddFlags(fss.FlagSet("storage"))
+	storagefs := fss.FlagSet("storage")
+	o.Storage.AddFlags(storagefs)
+	storagefs.DurationVar(&o.StorageTimeout, "storage-timeout", o.StorageTimeout, "The timeout of each write of the resources to the storage, the in-flight writes are canceled when the cluster synchro is shutdown")
+
 	o.Metrics.AddFlags(fss.FlagSet("metrics server"))
 	o.KubeStateMetrics.AddFlags(fss.FlagSet("kube state metrics"))
+	o.Configuration.AddFlags(fss.FlagSet("configuration"))
+	o.Retention.AddFlags(fss.FlagSet("retention"))
+	o.OrphanGC.AddFlags(fss.FlagSet("orphan gc"))
+	o.CDC.AddFlags(fss.FlagSet("change data capture"))
+	o.Backup.AddFlags(fss.FlagSet("backup"))
+	o.ComponentConfig.AddFlags(fss.FlagSet("component config"))
 	return fss
 }
 
@@ -124,10 +197,66 @@ func (o *Options) Validate() error {
 	errs = append(errs, o.Storage.Validate()...)
 	errs = append(errs, o.Metrics.Validate()...)
 	errs = append(errs, o.KubeStateMetrics.Validate()...)
+	errs = append(errs, o.Retention.Validate()...)
+	errs = append(errs, o.OrphanGC.Validate()...)
+	errs = append(errs, o.CDC.Validate()...)
+	errs = append(errs, o.Backup.Validate()...)
 
 	if o.WorkerNumber <= 0 {
 		errs = append(errs, fmt.Errorf("worker-number must be greater than 0"))
 	}
+	if o.TerminatedPodsTTL < 0 {
+		errs = append(errs, fmt.Errorf("terminated-pods-ttl must not be negative"))
+	}
+	if o.CompletedJobsTTL < 0 {
+		errs = append(errs, fmt.Errorf("completed-jobs-ttl must not be negative"))
+	}
+	if o.MassDeletion.Threshold < 0 || o.MassDeletion.Threshold > 100 {
+		errs = append(errs, fmt.Errorf("mass-deletion-threshold must be between 0 and 100"))
+	}
+	if o.MassDeletion.Threshold > 0 && o.MassDeletion.Window <= 0 {
+		errs = append(errs, fmt.Errorf("mass-deletion-window must be greater than 0"))
+	}
+	if o.StorageTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("storage-timeout must be greater than 0"))
+	}
+	if o.VerificationRelistInterval < 0 {
+		errs = append(errs, fmt.Errorf("verification-relist-interval must not be negative"))
+	}
+	if o.WatchBookmarkInterval < 0 {
+		errs = append(errs, fmt.Errorf("watch-bookmark-interval must not be negative"))
+	}
+	if o.DriftCheckInterval < 0 {
+		errs = append(errs, fmt.Errorf("drift-check-interval must not be negative"))
+	}
+	for _, resource := range o.DriftCheckResources {
+		if schema.ParseGroupResource(resource).Resource == "" {
+			errs = append(errs, fmt.Errorf("invalid drift-check-resources: %q", resource))
+		}
+	}
+	switch clustersynchro.HealthCheckStrategy(o.HealthCheckStrategy) {
+	case clustersynchro.ReadyzHealthCheck, clustersynchro.ResourceProbeHealthCheck:
+	default:
+		errs = append(errs, fmt.Errorf("health-check-strategy must be one of readyz and resource-probe"))
+	}
+	if _, err := parseProbeResource(o.HealthCheckProbeResource); err != nil {
+		errs = append(errs, fmt.Errorf("invalid health-check-probe-resource: %w", err))
+	}
+	for name, timeout := range map[string]time.Duration{
+		"cluster-http2-read-idle-timeout": o.Transport.HTTP2ReadIdleTimeout,
+		"cluster-http2-ping-timeout":      o.Transport.HTTP2PingTimeout,
+		"cluster-idle-conn-timeout":       o.Transport.IdleConnTimeout,
+		"cluster-tcp-keepalive-idle":      o.Transport.TCPKeepAliveIdle,
+		"cluster-tcp-keepalive-interval":  o.Transport.TCPKeepAliveInterval,
+		"cluster-watch-idle-timeout":      o.Transport.WatchIdleTimeout,
+	} {
+		if timeout < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", name))
+		}
+	}
+	if o.Transport.TCPKeepAliveCount < 0 {
+		errs = append(errs, fmt.Errorf("cluster-tcp-keepalive-count must not be negative"))
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
@@ -175,6 +304,21 @@ func (o *Options) Config() (*config.Config, error) {
 		resourcesynchro.DefaultMetricsWrapperFactory = resourcesynchro.NewMetricsWrapperFactory(config)
 	}
 
+	probeResource, err := parseProbeResource(o.HealthCheckProbeResource)
+	if err != nil {
+		return nil, err
+	}
+
+	retentionConfig, err := o.Retention.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, err := newPublisher(o.CDC.Config(), o.Backup.Config())
+	if err != nil {
+		return nil, err
+	}
+
 	if o.ShardingName != "" {
 		o.LeaderElection.ResourceName = fmt.Sprintf("%s-%s", o.LeaderElection.ResourceName, o.ShardingName)
 	}
@@ -196,12 +340,58 @@ func (o *Options) Config() (*config.Config, error) {
 		ClusterSyncConfig: clustersynchro.ClusterSyncConfig{
 			MetricsStoreBuilder:     metricsStoreBuilder,
 			PageSizeForResourceSync: o.PageSizeForResourceSync,
+			TerminatedPodsTTL:       o.TerminatedPodsTTL,
+			CompletedJobsTTL:        o.CompletedJobsTTL,
+			MassDeletion:            o.MassDeletion,
+			EventRecorder:           eventRecorder,
+			StorageTimeout:          o.StorageTimeout,
+
+			VerificationRelistInterval: o.VerificationRelistInterval,
+			WatchBookmarkInterval:      o.WatchBookmarkInterval,
+			DriftCheck:                 o.driftCheckConfig(),
+			HealthCheck: clustersynchro.HealthCheckConfig{
+				Strategy:      clustersynchro.HealthCheckStrategy(o.HealthCheckStrategy),
+				ProbeResource: probeResource,
+			},
+			Transport:         o.Transport,
+			ConfigurationName: o.Configuration.Name,
+			Publisher:         publisher,
 		},
+		Retention: retentionConfig,
+		OrphanGC:  o.OrphanGC.Config(),
 
 		LeaderElection: o.LeaderElection,
 	}, nil
 }
 
+// newPublisher returns the publisher of the change events to the sink and the backup, nil if both are disabled
+func newPublisher(cdcConfig *cdc.Config, backupConfig *backup.Config) (cdc.Publisher, error) {
+	var publishers cdc.Publishers
+	sink, err := cdc.NewPublisher(cdcConfig)
+	if err != nil {
+		return nil, err
+	}
+	if sink != nil {
+		publishers = append(publishers, sink)
+	}
+
+	writer, err := backup.NewPublisher(backupConfig)
+	if err != nil {
+		return nil, err
+	}
+	if writer != nil {
+		publishers = append(publishers, writer)
+	}
+
+	switch len(publishers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return publishers[0], nil
+	}
+	return publishers, nil
+}
+
 const (
 	defaultNamespace       = "clusterpedia-system"
 	inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
@@ -227,3 +417,23 @@ func getDefaultNamespace() (string, error) {
 	}
 	return string(namespace), nil
 }
+
+// parseProbeResource parses the resource in the format of [<group>/]<version>/<resource>
+func parseProbeResource(resource string) (schema.GroupVersionResource, error) {
+	parts := strings.Split(resource, "/")
+	if len(parts) == 2 {
+		parts = append([]string{""}, parts...)
+	}
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("%q is not in the format of [<group>/]<version>/<resource>", resource)
+	}
+	return schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]}, nil
+}
+
+func (o *Options) driftCheckConfig() resourcesynchro.DriftCheckConfig {
+	config := resourcesynchro.DriftCheckConfig{Interval: o.DriftCheckInterval, Repair: o.DriftCheckRepair}
+	for _, resource := range o.DriftCheckResources {
+		config.Resources = append(config.Resources, schema.ParseGroupResource(resource))
+	}
+	return config
+}
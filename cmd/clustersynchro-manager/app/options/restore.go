@@ -0,0 +1,69 @@
+package options
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	cliflag "k8s.io/component-base/cli/flag"
+
+	storageoptions "github.com/clusterpedia-io/clusterpedia/pkg/storage/options"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/cdc/backup"
+)
+
+// RestoreOptions are the options of the restore command,
+// which replays the change events of the incremental backup in the storage.
+type RestoreOptions struct {
+	Storage *storageoptions.StorageOptions
+	Backup  backup.S3Options
+
+	From  string
+	Until string
+}
+
+func NewRestoreOptions() *RestoreOptions {
+	return &RestoreOptions{Storage: storageoptions.NewStorageOptions(), Backup: backup.S3Options{Prefix: backup.DefaultPrefix}}
+}
+
+func (o *RestoreOptions) Flags() cliflag.NamedFlagSets {
+	var fss cliflag.NamedFlagSets
+
+	o.Storage.AddFlags(fss.FlagSet("storage"))
+	o.Backup.AddFlags(fss.FlagSet("backup"))
+
+	fs := fss.FlagSet("restore")
+	fs.StringVar(&o.From, "from", o.From, "Skip the events before the time in RFC3339, e.g. the time of the full dump which the storage is restored from.")
+	fs.StringVar(&o.Until, "until", o.Until, "The point in time in RFC3339 to recover the storage to, the events after the time are skipped. All the events are replayed if it is empty.")
+	return fss
+}
+
+func (o *RestoreOptions) Validate() error {
+	errs := o.Storage.Validate()
+	errs = append(errs, o.Backup.Validate()...)
+	if _, err := o.RestoreOptions(); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (o *RestoreOptions) RestoreOptions() (backup.RestoreOptions, error) {
+	var opts backup.RestoreOptions
+	for flag, value := range map[string]struct {
+		value string
+		into  *time.Time
+	}{"from": {o.From, &opts.From}, "until": {o.Until, &opts.Until}} {
+		if value.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value.value)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --%s: %w", flag, err)
+		}
+		*value.into = t
+	}
+	if !opts.From.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.From) {
+		return opts, errors.New("--until must not be before --from")
+	}
+	return opts, nil
+}
@@ -0,0 +1,68 @@
+package options
+
+import (
+	"errors"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	cliflag "k8s.io/component-base/cli/flag"
+
+	crdclientset "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/resourcesynchro"
+)
+
+// ResyncOptions are the options of the resync command,
+// which requests the clustersynchro manager to relist a resource of a cluster by the annotation of the PediaCluster.
+type ResyncOptions struct {
+	Master     string
+	Kubeconfig string
+
+	Cluster   string
+	Resource  string
+	Namespace string
+}
+
+func NewResyncOptions() *ResyncOptions {
+	return &ResyncOptions{}
+}
+
+func (o *ResyncOptions) Flags() cliflag.NamedFlagSets {
+	var fss cliflag.NamedFlagSets
+
+	fs := fss.FlagSet("resync")
+	fs.StringVar(&o.Cluster, "cluster", o.Cluster, "The name of the PediaCluster whose resource is resynced.")
+	fs.StringVar(&o.Resource, "resource", o.Resource, "The resynced resource in the format of <resource>[.<group>], e.g. deployments.apps,pods.")
+	fs.StringVar(&o.Namespace, "namespace", o.Namespace, "Only rewrite the stored objects of the namespace, all stored objects of the resource are rewritten if it is empty.")
+
+	misc := fss.FlagSet("misc")
+	misc.StringVar(&o.Master, "master", o.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig).")
+	misc.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to kubeconfig file with authorization and master location information.")
+	return fss
+}
+
+func (o *ResyncOptions) Validate() error {
+	var errs []error
+	if o.Cluster == "" {
+		errs = append(errs, errors.New("--cluster is required"))
+	}
+	if o.Resource == "" {
+		errs = append(errs, errors.New("--resource is required"))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (o *ResyncOptions) Client() (crdclientset.Interface, error) {
+	kubeconfig, err := clientcmd.BuildConfigFromFlags(o.Master, o.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return crdclientset.NewForConfig(restclient.AddUserAgent(kubeconfig, ClusterSynchroManagerUserAgent))
+}
+
+// Request returns the resync request at the time, which is the value of the annotation of the PediaCluster.
+func (o *ResyncOptions) Request(requestedAt time.Time) resourcesynchro.ResyncRequest {
+	return resourcesynchro.ResyncRequest{Resource: schema.ParseGroupResource(o.Resource), Namespace: o.Namespace, RequestedAt: requestedAt}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/term"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/cmd/clustersynchro-manager/app/options"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/cdc/backup"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/objectstore"
+)
+
+func newRestoreCommand(ctx context.Context) *cobra.Command {
+	opts := options.NewRestoreOptions()
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Recover the storage to a point in time by replaying the change events of the incremental backup",
+		Long: `Replay the change events shipped to the object storage by the backup of the clustersynchro manager
+in the storage in order, until the point in time of --until.
+
+The events are replayed idempotently, so the storage can be restored from a full dump, in which case --from
+skips the events before the dump. The backup has no base snapshot, only the changes since the backup is enabled
+are shipped, so the storage should be restored from a full dump taken after the backup is enabled, unless the
+storage was empty when the backup was enabled. Stop the clustersynchro manager before restoring.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			restoreOpts, err := opts.RestoreOptions()
+			if err != nil {
+				return err
+			}
+
+			store, err := objectstore.NewS3Store(opts.Backup.Config())
+			if err != nil {
+				return err
+			}
+			factory, err := storage.NewStorageFactory(opts.Storage.Name, opts.Storage.ConfigPath)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := factory.Shutdown(); err != nil {
+					klog.ErrorS(err, "Failed to shutdown storage factory")
+				}
+			}()
+
+			if marker, err := backup.ReadMarker(ctx, store, opts.Backup.Prefix); err != nil {
+				return err
+			} else if marker != nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "The last segment %s is shipped with the events until %s\n", marker.LastSegment, marker.LastEventTime.Format(time.RFC3339))
+			}
+
+			result, err := backup.Restore(ctx, store, opts.Backup.Prefix, factory, restoreOpts)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d events are replayed", result.Events)
+			if result.Events != 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), ", the storage is recovered to %s", result.LastEventTime.Format(time.RFC3339))
+			}
+			fmt.Fprintln(cmd.OutOrStdout())
+			return nil
+		},
+	}
+
+	namedFlagSets := opts.Flags()
+	fs := cmd.Flags()
+	for _, f := range namedFlagSets.FlagSets {
+		fs.AddFlagSet(f)
+	}
+
+	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
+	cliflag.SetUsageAndHelpFunc(cmd, namedFlagSets, cols)
+	return cmd
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/term"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/cmd/clustersynchro-manager/app/options"
+)
+
+func newResyncCommand(ctx context.Context) *cobra.Command {
+	opts := options.NewResyncOptions()
+	cmd := &cobra.Command{
+		Use:   "resync",
+		Short: "Relist a resource of a cluster and rewrite its stored objects to repair the drift of the storage",
+		Long: `Request the clustersynchro manager which synchronizes the cluster to relist the resource by updating
+the annotation cluster.clusterpedia.io/resync of the PediaCluster, so the request is authorized by the rbac
+of the PediaClusters, and the clustersynchro manager doesn't need to be restarted.
+
+The stored objects of the resource, or only the objects of --namespace, are rewritten with the objects
+of the cluster, and the stored objects missing from the cluster are deleted. The relist is delayed
+until the initial syncs of the cluster are finished.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			client, err := opts.Client()
+			if err != nil {
+				return err
+			}
+
+			request := opts.Request(time.Now())
+			patch, err := json.Marshal(map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{clusterv1alpha2.ResyncAnnotation: request.String()},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := client.ClusterV1alpha2().PediaClusters().Patch(ctx, opts.Cluster, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "The resync of %s of cluster %s is requested: %s\n", request.Resource, opts.Cluster, request)
+			return nil
+		},
+	}
+
+	namedFlagSets := opts.Flags()
+	fs := cmd.Flags()
+	for _, f := range namedFlagSets.FlagSets {
+		fs.AddFlagSet(f)
+	}
+
+	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
+	cliflag.SetUsageAndHelpFunc(cmd, namedFlagSets, cols)
+	return cmd
+}
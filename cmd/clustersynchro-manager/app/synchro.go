@@ -20,9 +20,12 @@ import (
 
 	"github.com/clusterpedia-io/clusterpedia/cmd/clustersynchro-manager/app/config"
 	"github.com/clusterpedia-io/clusterpedia/cmd/clustersynchro-manager/app/options"
+	"github.com/clusterpedia-io/clusterpedia/pkg/componentconfig"
 	kubestatemetrics "github.com/clusterpedia-io/clusterpedia/pkg/kube_state_metrics"
 	metricsserver "github.com/clusterpedia-io/clusterpedia/pkg/metrics/server"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/gc"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/retention"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager"
 	clusterpediafeature "github.com/clusterpedia-io/clusterpedia/pkg/utils/feature"
 	"github.com/clusterpedia-io/clusterpedia/pkg/version/verflag"
@@ -50,11 +53,24 @@ func NewClusterSynchroManagerCommand(ctx context.Context) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			verflag.PrintAndExitIfRequested()
 
+			// The configuration file is applied before the flags are used,
+			// the flags set on the command line take precedence.
+			if err := opts.ComponentConfig.ApplyTo(&componentconfig.ClusterSynchroManagerConfiguration{}, cmd.Flags()); err != nil {
+				return err
+			}
+
 			// Activate logging as soon as possible, after that
 			// show flags with the final logging configuration.
 			if err := logsapi.ValidateAndApply(opts.Logs, clusterpediafeature.MutableFeatureGate); err != nil {
 				return err
 			}
+			if opts.ComponentConfig.ValidateOnly {
+				if err := opts.Validate(); err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), "The configuration is valid")
+				return nil
+			}
 			cliflag.PrintFlags(cmd.Flags())
 
 			config, err := opts.Config()
@@ -88,12 +104,46 @@ func NewClusterSynchroManagerCommand(ctx context.Context) *cobra.Command {
 
 	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
 	cliflag.SetUsageAndHelpFunc(cmd, namedFlagSets, cols)
+
+	cmd.AddCommand(newInitStorageCommand(ctx))
+	cmd.AddCommand(newCheckCommand(ctx, opts.RunInNamespace))
+	cmd.AddCommand(newUpgradePlanCommand(ctx))
+	cmd.AddCommand(newRestoreCommand(ctx))
+	cmd.AddCommand(newResyncCommand(ctx))
 	return cmd
 }
 
 func Run(ctx context.Context, c *config.Config) error {
 	synchromanager := synchromanager.NewManager(c.Client, c.CRDClient, c.StorageFactory, c.ClusterSyncConfig, c.ShardingName, c.Namespace)
 
+	go synchromanager.MonitorStorageHealth(ctx)
+
+	var janitor *retention.Janitor
+	if c.Retention != nil {
+		var err error
+		if janitor, err = retention.NewJanitor(c.StorageFactory, c.Retention); err != nil {
+			return err
+		}
+	}
+
+	var collector *gc.Collector
+	if c.OrphanGC != nil {
+		var err error
+		if collector, err = gc.NewCollector(c.StorageFactory, synchromanager.ListClusterStorageResources, c.OrphanGC); err != nil {
+			return err
+		}
+	}
+
+	if publisher := c.ClusterSyncConfig.Publisher; publisher != nil {
+		defer func() {
+			if err := publisher.Close(); err != nil {
+				klog.ErrorS(err, "Failed to close the change event publisher")
+			}
+		}()
+	}
+
+	c.MetricsServerConfig.HealthChecks = append(c.MetricsServerConfig.HealthChecks, synchromanager.StorageHealthz())
+	c.MetricsServerConfig.ReadyChecks = append(c.MetricsServerConfig.ReadyChecks, synchromanager.StorageReadyz())
 	go func() {
 		metricsserver.Run(c.MetricsServerConfig)
 	}()
@@ -105,6 +155,12 @@ func Run(ctx context.Context, c *config.Config) error {
 	}
 
 	if !c.LeaderElection.LeaderElect {
+		if janitor != nil {
+			go janitor.Run(ctx)
+		}
+		if collector != nil {
+			go collector.Run(ctx)
+		}
 		synchromanager.Run(c.WorkerNumber, ctx.Done())
 		return nil
 	}
@@ -144,6 +200,12 @@ func Run(ctx context.Context, c *config.Config) error {
 				done = make(chan struct{})
 				defer close(done)
 
+				if janitor != nil {
+					go janitor.Run(ctx)
+				}
+				if collector != nil {
+					go collector.Run(ctx)
+				}
 				stopCh := ctx.Done()
 				synchromanager.Run(c.WorkerNumber, stopCh)
 			},
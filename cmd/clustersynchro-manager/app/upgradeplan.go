@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/term"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/upgrade"
+)
+
+func newUpgradePlanCommand(ctx context.Context) *cobra.Command {
+	opts := upgrade.NewOptions()
+	cmd := &cobra.Command{
+		Use:   "upgrade-plan",
+		Short: "Report the incompatibilities of the storage and the CRDs with this version and plan the migration",
+		Long: `Inspect the storage schema and the installed CRDs, report the incompatibilities with this version of clusterpedia,
+and print the steps to migrate them before upgrading the components.
+
+Neither the storage nor the CRDs are changed, run this command with the image of the target version.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := opts.Config()
+			if err != nil {
+				return err
+			}
+
+			plan, err := upgrade.Run(ctx, config)
+			if err != nil {
+				return err
+			}
+			return plan.Print(cmd.OutOrStdout(), opts.Output)
+		},
+	}
+
+	namedFlagSets := opts.Flags()
+	fs := cmd.Flags()
+	for _, f := range namedFlagSets.FlagSets {
+		fs.AddFlagSet(f)
+	}
+
+	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
+	cliflag.SetUsageAndHelpFunc(cmd, namedFlagSets, cols)
+	return cmd
+}
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/util/workqueue"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/cli/globalflag"
+	"k8s.io/component-base/logs"
+	logsapi "k8s.io/component-base/logs/api/v1"
+	"k8s.io/component-base/term"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/cmd/operator/app/config"
+	"github.com/clusterpedia-io/clusterpedia/cmd/operator/app/options"
+	"github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions"
+	"github.com/clusterpedia-io/clusterpedia/pkg/operator"
+	clusterpediafeature "github.com/clusterpedia-io/clusterpedia/pkg/utils/feature"
+	"github.com/clusterpedia-io/clusterpedia/pkg/version/verflag"
+)
+
+func init() {
+	utilruntime.Must(logsapi.AddFeatureGates(clusterpediafeature.MutableFeatureGate))
+}
+
+func NewOperatorCommand() *cobra.Command {
+	opts, _ := options.NewOperatorOptions()
+	cmd := &cobra.Command{
+		Use: "operator",
+		Long: `The clusterpedia operator manages the installations of clusterpedia declared by the Clusterpedia resources,
+including the deployments of the components, the storage config and the upgrades of the components.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verflag.PrintAndExitIfRequested()
+
+			// Activate logging as soon as possible, after that
+			// show flags with the final logging configuration.
+			if err := logsapi.ValidateAndApply(opts.Logs, clusterpediafeature.MutableFeatureGate); err != nil {
+				return err
+			}
+			cliflag.PrintFlags(cmd.Flags())
+
+			config, err := opts.Config()
+			if err != nil {
+				return err
+			}
+
+			if err := Run(config); err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+
+	namedFlagSets := opts.Flags()
+	verflag.AddFlags(namedFlagSets.FlagSet("global"))
+	globalflag.AddGlobalFlags(namedFlagSets.FlagSet("global"), cmd.Name(), logs.SkipLoggingConfigurationFlags())
+	clusterpediafeature.MutableFeatureGate.AddFlag(namedFlagSets.FlagSet("mutable feature gate"))
+
+	fs := cmd.Flags()
+	for _, f := range namedFlagSets.FlagSets {
+		fs.AddFlagSet(f)
+	}
+
+	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
+	cliflag.SetUsageAndHelpFunc(cmd, namedFlagSets, cols)
+	return cmd
+}
+
+func Run(c *config.Config) error {
+	if !c.LeaderElection.LeaderElect {
+		return run(c, wait.NeverStop)
+	}
+
+	id, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	id += "_" + string(uuid.NewUUID())
+
+	rl, err := resourcelock.NewFromKubeconfig(
+		c.LeaderElection.ResourceLock,
+		c.LeaderElection.ResourceNamespace,
+		c.LeaderElection.ResourceName,
+		resourcelock.ResourceLockConfig{
+			Identity:      id,
+			EventRecorder: c.EventRecorder,
+		},
+		c.Kubeconfig,
+		c.LeaderElection.RenewDeadline.Duration,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create resource lock: %w", err)
+	}
+
+	leaderelection.RunOrDie(context.TODO(), leaderelection.LeaderElectionConfig{
+		Name: c.LeaderElection.ResourceName,
+
+		Lock:          rl,
+		LeaseDuration: c.LeaderElection.LeaseDuration.Duration,
+		RenewDeadline: c.LeaderElection.RenewDeadline.Duration,
+		RetryPeriod:   c.LeaderElection.RetryPeriod.Duration,
+
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				if err := run(c, ctx.Done()); err != nil {
+					klog.ErrorS(err, "failed to run operator")
+				}
+			},
+			OnStoppedLeading: func() {
+				klog.Info("leaderelection lost")
+			},
+		},
+	})
+	return nil
+}
+
+func run(c *config.Config, stopCh <-chan struct{}) error {
+	queue := workqueue.NewNamedRateLimitingQueue(
+		workqueue.NewItemExponentialFailureRateLimiter(2*time.Second, time.Minute),
+		"clusterpedia",
+	)
+
+	informerFactory := externalversions.NewSharedInformerFactory(c.CRDClient, c.ResyncPeriod)
+	// only the objects managed by the operator are watched
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(c.Client, 0,
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = operator.ClusterpediaLabel
+		}),
+	)
+	controller, err := operator.NewController(
+		c.CRDClient,
+		c.Client,
+		informerFactory.Operator().V1alpha1().Clusterpedias(),
+		kubeInformerFactory.Apps().V1().Deployments(),
+		kubeInformerFactory.Core().V1().Services(),
+		kubeInformerFactory.Core().V1().Secrets(),
+		kubeInformerFactory.Core().V1().ServiceAccounts(),
+		queue,
+	)
+	if err != nil {
+		return err
+	}
+
+	informerFactory.Start(stopCh)
+	kubeInformerFactory.Start(stopCh)
+
+	klog.Info("wait for cache sync...")
+	informersByStarted := make(map[bool][]string)
+	for informerType, started := range informerFactory.WaitForCacheSync(stopCh) {
+		informersByStarted[started] = append(informersByStarted[started], informerType.String())
+	}
+	for informerType, started := range kubeInformerFactory.WaitForCacheSync(stopCh) {
+		informersByStarted[started] = append(informersByStarted[started], informerType.String())
+	}
+	if notStarted := informersByStarted[false]; len(notStarted) != 0 {
+		klog.Errorf("%d informers not started yet: %v", len(notStarted), notStarted)
+		return fmt.Errorf("informers not started")
+	}
+	klog.Infof("informer caches is synced: %v", informersByStarted[true])
+
+	go controller.Run(c.WorkerNumber, stopCh)
+
+	<-stopCh
+	return nil
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"os"
+
+	"k8s.io/component-base/cli"
+	_ "k8s.io/component-base/logs/json/register" // for JSON log format registration
+
+	"github.com/clusterpedia-io/clusterpedia/cmd/operator/app"
+)
+
+func main() {
+	command := app.NewOperatorCommand()
+	code := cli.Run(command)
+	os.Exit(code)
+}
@@ -0,0 +1,138 @@
+// benchmark seeds synthetic clusters into the storage layer and measures the sync throughput
+// and the latency percentiles of the typical queries, so the performance regressions of the
+// storage layer and its query builder can be caught before release.
+//
+// The storage is the same as the one used by the apiserver, for example:
+//
+//	go run ./hack/benchmark --storage-config=./storage-config.yaml --clusters=10 --objects=10000
+//
+// The seeded clusters are cleaned after the benchmark unless --keep-data is set.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	_ "github.com/clusterpedia-io/clusterpedia/pkg/storage/internalstorage"
+	_ "github.com/clusterpedia-io/clusterpedia/pkg/storage/memorystorage"
+)
+
+type options struct {
+	StorageName   string
+	StorageConfig string
+
+	Clusters      int
+	Objects       int
+	Namespaces    int
+	SyncWorkers   int
+	ClusterPrefix string
+
+	Queries      int
+	QueryWorkers int
+	PageSize     int64
+
+	Output   string
+	KeepData bool
+}
+
+func (o *options) addFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.StorageName, "storage-name", "internal", "The name of the storage layer.")
+	fs.StringVar(&o.StorageConfig, "storage-config", "", "The config file path of the storage layer.")
+
+	fs.IntVar(&o.Clusters, "clusters", 5, "The number of the synthetic clusters.")
+	fs.IntVar(&o.Objects, "objects", 1000, "The number of the pods seeded into each cluster.")
+	fs.IntVar(&o.Namespaces, "namespaces", 10, "The number of the namespaces in each cluster.")
+	fs.IntVar(&o.SyncWorkers, "sync-workers", 8, "The number of the workers that seed the pods concurrently.")
+	fs.StringVar(&o.ClusterPrefix, "cluster-prefix", "benchmark-", "The name prefix of the synthetic clusters.")
+
+	fs.IntVar(&o.Queries, "queries", 200, "The number of the requests of each query class.")
+	fs.IntVar(&o.QueryWorkers, "query-workers", 4, "The number of the workers that query concurrently.")
+	fs.Int64Var(&o.PageSize, "page-size", 100, "The limit of the list queries.")
+
+	fs.StringVar(&o.Output, "output", "text", "The output format of the report, text or json.")
+	fs.BoolVar(&o.KeepData, "keep-data", false, "Keep the seeded clusters in the storage after the benchmark.")
+}
+
+func (o *options) validate() error {
+	if o.Clusters <= 0 || o.Objects <= 0 || o.Namespaces <= 0 {
+		return fmt.Errorf("--clusters, --objects and --namespaces must be greater than 0")
+	}
+	if o.SyncWorkers <= 0 || o.QueryWorkers <= 0 {
+		return fmt.Errorf("--sync-workers and --query-workers must be greater than 0")
+	}
+	if o.Queries < 0 || o.PageSize <= 0 {
+		return fmt.Errorf("--queries must not be negative and --page-size must be greater than 0")
+	}
+	if o.Output != "text" && o.Output != "json" {
+		return fmt.Errorf("--output must be text or json")
+	}
+	return nil
+}
+
+func main() {
+	opts := &options{}
+	opts.addFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := run(context.Background(), opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, opts *options) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	factory, err := storage.NewStorageFactory(opts.StorageName, opts.StorageConfig)
+	if err != nil {
+		return err
+	}
+	defer factory.Shutdown()
+
+	clusters := make([]string, 0, opts.Clusters)
+	for i := 0; i < opts.Clusters; i++ {
+		clusters = append(clusters, fmt.Sprintf("%s%d", opts.ClusterPrefix, i))
+	}
+	if !opts.KeepData {
+		defer func() {
+			for _, cluster := range clusters {
+				if err := factory.CleanCluster(context.Background(), cluster); err != nil {
+					fmt.Fprintf(os.Stderr, "failed to clean cluster %s: %v\n", cluster, err)
+				}
+			}
+		}()
+	}
+
+	// the same order as the cluster synchro, the clusters are prepared before creating the resource storage
+	for _, cluster := range clusters {
+		if err := factory.PrepareCluster(cluster); err != nil {
+			return err
+		}
+	}
+	resourceStorage, err := newPodStorage(factory)
+	if err != nil {
+		return err
+	}
+
+	report := &Report{StorageName: opts.StorageName, Clusters: opts.Clusters, ObjectsPerCluster: opts.Objects}
+	if report.Sync, err = seed(ctx, resourceStorage, clusters, opts); err != nil {
+		return err
+	}
+	if report.Queries, err = query(ctx, resourceStorage, clusters, opts); err != nil {
+		return err
+	}
+
+	if opts.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	report.Print(os.Stdout)
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/labels"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func limit(limit int64) metainternal.ListOptions {
+	return metainternal.ListOptions{Limit: limit}
+}
+
+type queryClass struct {
+	name string
+	do   func(ctx context.Context, r *rand.Rand) error
+}
+
+func queryClasses(resourceStorage storage.ResourceStorage, clusters []string, opts *options) []queryClass {
+	withTrue := true
+	list := func(ctx context.Context, options *internal.ListOptions) error {
+		return resourceStorage.List(ctx, &corev1.PodList{}, options)
+	}
+	randomCluster := func(r *rand.Rand) string {
+		return clusters[r.Intn(len(clusters))]
+	}
+
+	return []queryClass{
+		{
+			name: "get",
+			do: func(ctx context.Context, r *rand.Rand) error {
+				i := r.Intn(opts.Objects)
+				return resourceStorage.Get(ctx, randomCluster(r), podNamespace(i, opts.Namespaces), podName(i), &corev1.Pod{})
+			},
+		},
+		{
+			name: "list-cluster",
+			do: func(ctx context.Context, r *rand.Rand) error {
+				return list(ctx, &internal.ListOptions{ClusterNames: []string{randomCluster(r)}, ListOptions: limit(opts.PageSize)})
+			},
+		},
+		{
+			name: "list-namespace",
+			do: func(ctx context.Context, r *rand.Rand) error {
+				return list(ctx, &internal.ListOptions{
+					ClusterNames: []string{randomCluster(r)},
+					Namespaces:   []string{podNamespace(r.Intn(opts.Namespaces), opts.Namespaces)},
+					ListOptions:  limit(opts.PageSize),
+				})
+			},
+		},
+		{
+			name: "list-label-selector",
+			do: func(ctx context.Context, r *rand.Rand) error {
+				options := &internal.ListOptions{ListOptions: limit(opts.PageSize)}
+				options.LabelSelector = labels.SelectorFromSet(labels.Set{"app": fmt.Sprintf("app-%d", r.Intn(10))})
+				return list(ctx, options)
+			},
+		},
+		{
+			name: "list-fleet-orderby",
+			do: func(ctx context.Context, _ *rand.Rand) error {
+				return list(ctx, &internal.ListOptions{
+					OrderBy:     []internal.OrderBy{{Field: "created_at", Desc: true}},
+					ListOptions: limit(opts.PageSize),
+				})
+			},
+		},
+		{
+			name: "list-deep-page",
+			do: func(ctx context.Context, r *rand.Rand) error {
+				options := &internal.ListOptions{
+					OrderBy:      []internal.OrderBy{{Field: "name"}},
+					WithContinue: &withTrue,
+					ListOptions:  limit(opts.PageSize),
+				}
+				// the offset is in the last tenth of the fleet
+				total := opts.Objects * len(clusters)
+				options.Continue = strconv.Itoa(total*9/10 + r.Intn(total/10+1))
+				return list(ctx, options)
+			},
+		},
+		{
+			name: "list-remaining-count",
+			do: func(ctx context.Context, _ *rand.Rand) error {
+				return list(ctx, &internal.ListOptions{WithRemainingCount: &withTrue, ListOptions: limit(opts.PageSize)})
+			},
+		},
+	}
+}
+
+// query runs the requests of each query class with the workers, and measures the latency of each request.
+func query(ctx context.Context, resourceStorage storage.ResourceStorage, clusters []string, opts *options) ([]QueryResult, error) {
+	var results []QueryResult
+	for _, class := range queryClasses(resourceStorage, clusters, opts) {
+		requests := make(chan struct{})
+		latencies := make([][]time.Duration, opts.QueryWorkers)
+		errs := make([]int, opts.QueryWorkers)
+
+		var wg sync.WaitGroup
+		start := time.Now()
+		for w := 0; w < opts.QueryWorkers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(int64(w)))
+				for range requests {
+					begin := time.Now()
+					if err := class.do(ctx, r); err != nil {
+						errs[w]++
+						continue
+					}
+					latencies[w] = append(latencies[w], time.Since(begin))
+				}
+			}(w)
+		}
+		for i := 0; i < opts.Queries; i++ {
+			requests <- struct{}{}
+		}
+		close(requests)
+		wg.Wait()
+		elapsed := time.Since(start)
+
+		result := QueryResult{Name: class.name, Duration: elapsed}
+		var all []time.Duration
+		for w := range latencies {
+			all = append(all, latencies[w]...)
+			result.Errors += errs[w]
+		}
+		result.Requests = len(all) + result.Errors
+		result.QPS = float64(result.Requests) / elapsed.Seconds()
+		result.Latency = newPercentiles(all)
+		results = append(results, result)
+	}
+	return results, nil
+}
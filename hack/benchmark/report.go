@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+type Report struct {
+	StorageName       string        `json:"storageName"`
+	Clusters          int           `json:"clusters"`
+	ObjectsPerCluster int           `json:"objectsPerCluster"`
+	Sync              *SyncResult   `json:"sync"`
+	Queries           []QueryResult `json:"queries"`
+}
+
+type SyncResult struct {
+	Objects    int           `json:"objects"`
+	Duration   time.Duration `json:"duration"`
+	Throughput float64       `json:"throughput"`
+	Latency    Percentiles   `json:"latency"`
+}
+
+type QueryResult struct {
+	Name     string        `json:"name"`
+	Requests int           `json:"requests"`
+	Errors   int           `json:"errors"`
+	Duration time.Duration `json:"duration"`
+	QPS      float64       `json:"qps"`
+	Latency  Percentiles   `json:"latency"`
+}
+
+type Percentiles struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+func newPercentiles(latencies []time.Duration) Percentiles {
+	if len(latencies) == 0 {
+		return Percentiles{}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p float64) time.Duration {
+		return latencies[int(math.Ceil(p*float64(len(latencies))))-1]
+	}
+	return Percentiles{
+		P50: percentile(0.5),
+		P90: percentile(0.9),
+		P99: percentile(0.99),
+		Max: latencies[len(latencies)-1],
+	}
+}
+
+func (r *Report) Print(out io.Writer) {
+	fmt.Fprintf(out, "storage: %s, clusters: %d, objects per cluster: %d\n\n", r.StorageName, r.Clusters, r.ObjectsPerCluster)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SYNC\tOBJECTS\tDURATION\tOBJECTS/S\tP50\tP90\tP99\tMAX")
+	fmt.Fprintf(w, "create\t%d\t%s\t%.1f\t%s\n", r.Sync.Objects, r.Sync.Duration.Round(time.Millisecond), r.Sync.Throughput, r.Sync.Latency)
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "QUERY\tREQUESTS\tERRORS\tQPS\tP50\tP90\tP99\tMAX")
+	for _, query := range r.Queries {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f\t%s\n", query.Name, query.Requests, query.Errors, query.QPS, query.Latency)
+	}
+	w.Flush()
+}
+
+func (p Percentiles) String() string {
+	round := func(d time.Duration) time.Duration { return d.Round(10 * time.Microsecond) }
+	return fmt.Sprintf("%s\t%s\t%s\t%s", round(p.P50), round(p.P90), round(p.P99), round(p.Max))
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func newPodStorage(factory storage.StorageFactory) (storage.ResourceStorage, error) {
+	config, err := resourceconfigfactory.New().NewConfig(podsGVR, true)
+	if err != nil {
+		return nil, err
+	}
+	return factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+}
+
+func podName(i int) string {
+	return fmt.Sprintf("pod-%d", i)
+}
+
+func podNamespace(i, namespaces int) string {
+	return fmt.Sprintf("namespace-%d", i%namespaces)
+}
+
+func newPod(cluster string, i int, namespaces int, createdAt time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         podNamespace(i, namespaces),
+			Name:              podName(i),
+			UID:               types.UID(fmt.Sprintf("%s-%d", cluster, i)),
+			ResourceVersion:   strconv.Itoa(i + 1),
+			CreationTimestamp: metav1.NewTime(createdAt.Add(time.Duration(i) * time.Second)),
+			Labels: map[string]string{
+				"app":  fmt.Sprintf("app-%d", i%10),
+				"tier": []string{"frontend", "backend", "cache"}[i%3],
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: fmt.Sprintf("node-%d", i%50),
+			Containers: []corev1.Container{
+				{Name: "app", Image: "registry.example.com/app:v1"},
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+// seed creates the pods of the clusters with the workers, and measures the latency of each creation.
+func seed(ctx context.Context, resourceStorage storage.ResourceStorage, clusters []string, opts *options) (*SyncResult, error) {
+	type task struct {
+		cluster string
+		index   int
+	}
+	tasks := make(chan task)
+	createdAt := time.Now().Add(-time.Duration(opts.Objects) * time.Second)
+
+	var (
+		wg        sync.WaitGroup
+		errOnce   sync.Once
+		seedErr   error
+		failed    atomic.Bool
+		latencies = make([][]time.Duration, opts.SyncWorkers)
+	)
+	start := time.Now()
+	for w := 0; w < opts.SyncWorkers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for t := range tasks {
+				if failed.Load() {
+					continue
+				}
+
+				begin := time.Now()
+				if err := resourceStorage.Create(ctx, t.cluster, newPod(t.cluster, t.index, opts.Namespaces, createdAt)); err != nil {
+					errOnce.Do(func() { seedErr = fmt.Errorf("failed to create %s/%s: %w", t.cluster, podName(t.index), err) })
+					failed.Store(true)
+					continue
+				}
+				latencies[w] = append(latencies[w], time.Since(begin))
+			}
+		}(w)
+	}
+
+	for i := 0; i < opts.Objects; i++ {
+		for _, cluster := range clusters {
+			tasks <- task{cluster: cluster, index: i}
+		}
+	}
+	close(tasks)
+	wg.Wait()
+	if seedErr != nil {
+		return nil, seedErr
+	}
+
+	var all []time.Duration
+	for _, l := range latencies {
+		all = append(all, l...)
+	}
+	elapsed := time.Since(start)
+	return &SyncResult{
+		Objects:    len(all),
+		Duration:   elapsed,
+		Throughput: float64(len(all)) / elapsed.Seconds(),
+		Latency:    newPercentiles(all),
+	}, nil
+}
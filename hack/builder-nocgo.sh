@@ -21,8 +21,9 @@ function build_component() {
         LDFLAGS+=" $(extra_ldflags)"
     fi
 
+    # sqlite requires cgo, so its driver is excluded from the cgo-free components
     set -x
-	CGO_ENABLED=0 go build -ldflags "${LDFLAGS}" -o ${OUTPUT_DIR}/bin/$1 ./cmd/$1
+	CGO_ENABLED=0 go build -tags "no_sqlite ${BUILD_TAGS:-}" -ldflags "${LDFLAGS}" -o ${OUTPUT_DIR}/bin/$1 ./cmd/$1
     set +x
 }
 
@@ -8,7 +8,7 @@ function usage() {
     cat <<EOF
 
 Build components: 
-    $0 <apiserver|binding-apiserver|clustersynchro-manager|controller-manager>
+    $0 <apiserver|binding-apiserver|clustersynchro-manager|controller-manager|operator>
 
 Build plugins:
     $0 plugins <plugin_name>
@@ -23,6 +23,9 @@ ENV:
     OUTPUT_DIR: default is the root of the repository or pwd,
                 the component binary will be output in \$OUTPUT_DIR/bin, the plugins will be output in \$OUTPUT_DIR/plugins.
             eg. OUTPUT_DIR=.
+
+    BUILD_TAGS: the extra build tags of the components, the storage layers and the database drivers are excluded by the tags.
+            eg. BUILD_TAGS="no_mysql no_etcdstorage"
 EOF
 }
 
@@ -102,7 +105,7 @@ function build_component() {
     set -x
     cd $TMP_CLUSTERPEDIA
     GOPATH=$TMP_GOPATH GO111MODULE=off CGO_ENABLED=1 CC_FOR_TARGET=$CC_FOR_TARGET CC=$CC \
-        go build -tags "json1 $GOOS" -ldflags "${LDFLAGS}" -o $OUTPUT_DIR/bin/$1 ./cmd/$1
+        go build -tags "json1 $GOOS ${BUILD_TAGS:-}" -ldflags "${LDFLAGS}" -o $OUTPUT_DIR/bin/$1 ./cmd/$1
     set +x
 }
 
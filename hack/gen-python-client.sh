@@ -0,0 +1,23 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+# Generates the python client of the clusterpedia apis from the OpenAPI V2 spec with openapi-generator,
+# the spec is updated by 'make openapi-spec'.
+
+REPO_ROOT=$(git rev-parse --show-toplevel)
+OUTPUT_DIR=${OUTPUT_DIR:-"_output/clients/python"}
+PACKAGE_NAME=${PACKAGE_NAME:-"clusterpedia"}
+OPENAPI_GENERATOR_IMAGE=${OPENAPI_GENERATOR_IMAGE:-"openapitools/openapi-generator-cli:v7.10.0"}
+
+cd "${REPO_ROOT}"
+mkdir -p "${OUTPUT_DIR}"
+
+echo "Generating python client into ${OUTPUT_DIR}"
+docker run --rm -u "$(id -u):$(id -g)" -v "${REPO_ROOT}:/local" "${OPENAPI_GENERATOR_IMAGE}" generate \
+    -i /local/api/openapi-spec/swagger.json \
+    -g python \
+    -o "/local/${OUTPUT_DIR}" \
+    --package-name "${PACKAGE_NAME}"
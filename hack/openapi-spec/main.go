@@ -0,0 +1,107 @@
+// openapi-spec generates the OpenAPI specs of the clusterpedia apis without running the apiserver,
+// the specs are used to generate the non-Go clients.
+//
+// The resources of the member clusters are served dynamically by the apiserver, so they are not included.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apiserver/pkg/registry/rest"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	clientrest "k8s.io/client-go/rest"
+	"k8s.io/component-base/version"
+	"k8s.io/kube-openapi/pkg/builder"
+	"k8s.io/kube-openapi/pkg/builder3"
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/common/restfuladapter"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/openapi"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/registry/clusterpedia/collectionresources"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/registry/clusterpedia/resources"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/memorystorage"
+)
+
+func main() {
+	outputDir := flag.String("output-dir", "api/openapi-spec", "The directory to write the OpenAPI specs to.")
+	flag.Parse()
+
+	if err := run(*outputDir); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(outputDir string) error {
+	config := genericapiserver.NewRecommendedConfig(apiserver.Codecs)
+	config.OpenAPIConfig = openapi.NewConfig(apiserver.Scheme)
+	config.OpenAPIV3Config = openapi.NewV3Config(apiserver.Scheme)
+	config.ExternalAddress = "localhost:443"
+	config.LoopbackClientConfig = &clientrest.Config{}
+	config.EffectiveVersion = version.DefaultBuildEffectiveVersion()
+
+	server, err := config.Complete().New("clusterpedia-openapi-spec", genericapiserver.NewEmptyDelegate())
+	if err != nil {
+		return err
+	}
+
+	// the storage is only used to list the collection resources, which are not part of the spec
+	storageFactory, err := memorystorage.NewStorageFactory("")
+	if err != nil {
+		return err
+	}
+
+	v1beta1storage := map[string]rest.Storage{}
+	v1beta1storage["resources"] = resources.NewREST(http.NotFoundHandler(), nil)
+	v1beta1storage["collectionresources"] = collectionresources.NewREST(apiserver.Codecs, storageFactory, nil)
+
+	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(internal.GroupName, apiserver.Scheme, apiserver.ParameterCodec, apiserver.Codecs)
+	apiGroupInfo.VersionedResourcesStorageMap[v1beta1.SchemeGroupVersion.Version] = v1beta1storage
+	if err := server.InstallAPIGroup(&apiGroupInfo); err != nil {
+		return err
+	}
+
+	var routes []common.RouteContainer
+	groupVersionPath := "/apis/" + v1beta1.SchemeGroupVersion.String()
+	for _, ws := range restfuladapter.AdaptWebServices(server.Handler.GoRestfulContainer.RegisteredWebServices()) {
+		if strings.HasPrefix(ws.RootPath(), groupVersionPath) {
+			routes = append(routes, ws)
+		}
+	}
+
+	swagger, err := builder.BuildOpenAPISpecFromRoutes(routes, config.OpenAPIConfig)
+	if err != nil {
+		return fmt.Errorf("failed to build the OpenAPI V2 spec: %w", err)
+	}
+	if err := writeJSON(filepath.Join(outputDir, "swagger.json"), swagger); err != nil {
+		return err
+	}
+
+	v3, err := builder3.BuildOpenAPISpecFromRoutes(routes, config.OpenAPIV3Config)
+	if err != nil {
+		return fmt.Errorf("failed to build the OpenAPI V3 spec: %w", err)
+	}
+	// the same file name as the one published by kubernetes for a group version
+	name := strings.ReplaceAll(strings.TrimPrefix(groupVersionPath, "/"), "/", "__") + "_openapi.json"
+	return writeJSON(filepath.Join(outputDir, "v3", name), v3)
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
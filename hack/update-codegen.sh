@@ -35,10 +35,22 @@ deepcopy-gen \
     --go-header-file="${REPO_ROOT}/hack/boilerplate.go.txt" \
     --bounding-dirs="policy/v1alpha1" \
     --output-file="zz_generated.deepcopy.go"
+deepcopy-gen \
+    --go-header-file="${REPO_ROOT}/hack/boilerplate.go.txt" \
+    --bounding-dirs="operator/v1alpha1" \
+    --output-file="zz_generated.deepcopy.go"
+deepcopy-gen \
+    --go-header-file="${REPO_ROOT}/hack/boilerplate.go.txt" \
+    --bounding-dirs="config/v1alpha1" \
+    --output-file="zz_generated.deepcopy.go"
 deepcopy-gen \
     --go-header-file="${REPO_ROOT}/hack/boilerplate.go.txt" \
     --bounding-dirs="clusterpedia/v1beta1" \
     --output-file="zz_generated.deepcopy.go"
+deepcopy-gen \
+    --go-header-file="${REPO_ROOT}/hack/boilerplate.go.txt" \
+    --bounding-dirs="clusterpedia/v1beta2" \
+    --output-file="zz_generated.deepcopy.go"
 deepcopy-gen \
     --go-header-file="${REPO_ROOT}/hack/boilerplate.go.txt" \
     --bounding-dirs="clusterpedia" \
@@ -49,6 +61,10 @@ conversion-gen \
     --go-header-file="${REPO_ROOT}/hack/boilerplate.go.txt" \
     --base-peer-dirs="./clusterpedia/v1beta1" \
     --output-file="zz_generated.conversion.go"
+conversion-gen \
+    --go-header-file="${REPO_ROOT}/hack/boilerplate.go.txt" \
+    --base-peer-dirs="./clusterpedia/v1beta2" \
+    --output-file="zz_generated.conversion.go"
 
 echo "change directory: ${REPO_ROOT}"
 cd "${REPO_ROOT}"
@@ -73,7 +89,7 @@ echo "Generating with client-gen"
 client-gen \
     --go-header-file="hack/boilerplate.go.txt" \
     --input-base="github.com/clusterpedia-io/api" \
-    --input="cluster/v1alpha2,policy/v1alpha1" \
+    --input="cluster/v1alpha2,policy/v1alpha1,operator/v1alpha1,config/v1alpha1" \
     --output-dir="pkg/generated/clientset" \
     --output-pkg="github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset" \
     --clientset-name="versioned" \
@@ -85,7 +101,7 @@ lister-gen \
     --output-dir="pkg/generated/listers" \
     --output-pkg="github.com/clusterpedia-io/clusterpedia/pkg/generated/listers" \
     --plural-exceptions="ClusterSyncResources:ClusterSyncResources" \
-    github.com/clusterpedia-io/api/cluster/v1alpha2 github.com/clusterpedia-io/api/policy/v1alpha1
+    github.com/clusterpedia-io/api/cluster/v1alpha2 github.com/clusterpedia-io/api/policy/v1alpha1 github.com/clusterpedia-io/api/operator/v1alpha1 github.com/clusterpedia-io/api/config/v1alpha1
 
 
 echo "Generating with informer-gen"
@@ -96,7 +112,7 @@ informer-gen \
     --output-dir="pkg/generated/informers" \
     --output-pkg="github.com/clusterpedia-io/clusterpedia/pkg/generated/informers" \
     --plural-exceptions="ClusterSyncResources:ClusterSyncResources" \
-    github.com/clusterpedia-io/api/cluster/v1alpha2 github.com/clusterpedia-io/api/policy/v1alpha1
+    github.com/clusterpedia-io/api/cluster/v1alpha2 github.com/clusterpedia-io/api/policy/v1alpha1 github.com/clusterpedia-io/api/operator/v1alpha1 github.com/clusterpedia-io/api/config/v1alpha1
 
 echo "Generating with openapi-gen"
 openapi-gen \
@@ -104,5 +120,5 @@ openapi-gen \
     --output-dir="pkg/generated/openapi" \
     --output-pkg="github.com/clusterpedia-io/clusterpedia/pkg/generated/openapi" \
     --output-file="zz_generated.openapi.go" \
-    github.com/clusterpedia-io/api/cluster/v1alpha2 github.com/clusterpedia-io/api/policy/v1alpha1 github.com/clusterpedia-io/api/clusterpedia/v1beta1 \
+    github.com/clusterpedia-io/api/cluster/v1alpha2 github.com/clusterpedia-io/api/policy/v1alpha1 github.com/clusterpedia-io/api/clusterpedia/v1beta1 github.com/clusterpedia-io/api/clusterpedia/v1beta2 github.com/clusterpedia-io/api/operator/v1alpha1 github.com/clusterpedia-io/api/config/v1alpha1 \
     k8s.io/apimachinery/pkg/apis/meta/v1 k8s.io/apimachinery/pkg/runtime k8s.io/apimachinery/pkg/version
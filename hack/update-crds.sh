@@ -16,3 +16,5 @@ export PATH=$PATH:$GOPATH/bin
 cd "${API_ROOT}"
 controller-gen crd paths=./cluster/... output:crd:dir="${REPO_ROOT}/kustomize/crds"
 controller-gen crd paths=./policy/... output:crd:dir="${REPO_ROOT}/kustomize/crds"
+controller-gen crd paths=./operator/... output:crd:dir="${REPO_ROOT}/kustomize/crds"
+controller-gen crd paths=./config/... output:crd:dir="${REPO_ROOT}/kustomize/crds"
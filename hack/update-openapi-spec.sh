@@ -0,0 +1,12 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+REPO_ROOT=$(git rev-parse --show-toplevel)
+
+echo "Generating OpenAPI specs"
+cd "${REPO_ROOT}"
+rm -rf api/openapi-spec
+go run ./hack/openapi-spec --output-dir api/openapi-spec
@@ -0,0 +1,17 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+# the protoc, protoc-gen-go and protoc-gen-go-grpc are required in the PATH:
+#   go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.35.1
+#   go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.4.0
+
+REPO_ROOT=$(git rev-parse --show-toplevel)
+
+echo "Generating the storage plugin protocol"
+cd "${REPO_ROOT}"
+protoc --go_out=. --go_opt=paths=source_relative \
+    --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+    pkg/storage/grpcstorage/storagepb/storage.proto
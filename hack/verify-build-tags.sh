@@ -0,0 +1,43 @@
+#!/usr/bin/env bash
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT=$(dirname "${BASH_SOURCE[0]}")/..
+cd "${SCRIPT_ROOT}"
+
+# each check is `<build tags>|<excluded package pattern>`,
+# the components built with the tags must not depend on the packages matched by the pattern.
+CHECKS=(
+  "no_archivestorage|/pkg/storage/archivestorage$"
+  "no_clickhousestorage|/pkg/storage/clickhousestorage$"
+  "no_dualstorage|/pkg/storage/dualstorage$"
+  "no_etcdstorage|/pkg/storage/etcdstorage$"
+  "no_grpcstorage|/pkg/storage/grpcstorage$"
+  "no_memorystorage|/pkg/storage/memorystorage$"
+  "no_shardingstorage|/pkg/storage/shardingstorage$"
+  "no_internalstorage|/pkg/storage/internalstorage$"
+  "no_kvstorage|/pkg/storage/kvstorage$|^go.etcd.io/bbolt$"
+  "no_cachestorage|/pkg/storage/cachestorage$|^github.com/redis/go-redis/"
+  "no_internalstorage,no_shardingstorage|^gorm.io/|^github.com/go-sql-driver/mysql$|^github.com/jackc/|^github.com/mattn/go-sqlite3$"
+  "no_mysql|^github.com/go-sql-driver/mysql$|^gorm.io/driver/mysql$"
+  "no_postgres|^github.com/jackc/pgx/|^gorm.io/driver/postgres$"
+  "no_sqlite|^github.com/mattn/go-sqlite3$|^gorm.io/driver/sqlite$"
+)
+
+ret=0
+for check in "${CHECKS[@]}"; do
+  tags=${check%%|*}
+  pattern=${check#*|}
+  if deps=$(go list -deps -tags "${tags}" ./cmd/... | grep -E "${pattern}"); then
+    echo "!!! the components built with the tags ${tags} depend on the excluded packages:" >&2
+    echo "${deps}" >&2
+    ret=1
+  fi
+done
+
+if [[ $ret -ne 0 ]]; then
+  exit 1
+fi
+echo "the build tags exclude the storage layers and the database drivers."
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,16 +18,29 @@ import (
 	clientrest "k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/component-base/version"
+	"k8s.io/klog/v2"
 
 	internal "github.com/clusterpedia-io/api/clusterpedia"
 	"github.com/clusterpedia-io/api/clusterpedia/install"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/explorer"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/externalmetrics"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/federation"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/listpolicy"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/publicaccess"
 	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/registry/clusterpedia/collectionresources"
 	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/registry/clusterpedia/resources"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/shutdown"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/slo"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/wasmfilter"
 	"github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
 	informers "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/configuration"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	"github.com/clusterpedia-io/clusterpedia/pkg/utils/filters"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/masking"
 )
 
 var (
@@ -65,10 +79,28 @@ type Config struct {
 
 	StorageFactory storage.StorageFactory
 	ExtraConfig    *kubeapiserver.ExtraConfig
+	APIExplorer    *explorer.Config
+
+	QueryDegradation *slo.Config
+	ListPolicy       *listpolicy.Policy
+	ExternalMetrics  *externalmetrics.Config
+	Federation       *federation.Federation
+
+	// PublicAccess is the scope of the anonymous read-only requests, nil if the public access is disabled
+	PublicAccess *publicaccess.Scope
+
+	// Shutdown is the graceful shutdown of the server, the in-flight requests are not tracked if it is nil
+	Shutdown *shutdown.Config
+
+	// ConfigurationName is the ClusterpediaConfiguration whose list settings override the ListPolicy at runtime,
+	// and whose masking settings mask the served objects
+	ConfigurationName string
 }
 
 type ClusterPediaServer struct {
 	GenericAPIServer *genericapiserver.GenericAPIServer
+
+	shutdownTracker *shutdown.Tracker
 }
 
 type completedConfig struct {
@@ -77,6 +109,16 @@ type completedConfig struct {
 	ClientConfig   *clientrest.Config
 	StorageFactory storage.StorageFactory
 	ExtraConfig    *kubeapiserver.ExtraConfig
+	APIExplorer    *explorer.Config
+
+	QueryDegradation *slo.Config
+	ListPolicy       *listpolicy.Policy
+	ExternalMetrics  *externalmetrics.Config
+	Federation       *federation.Federation
+	PublicAccess     *publicaccess.Scope
+	Shutdown         *shutdown.Config
+
+	ConfigurationName string
 }
 
 // CompletedConfig embeds a private pointer that cannot be instantiated outside of this package.
@@ -93,6 +135,14 @@ func (cfg *Config) Complete() CompletedConfig {
 		cfg.GenericConfig.ClientConfig,
 		cfg.StorageFactory,
 		cfg.ExtraConfig,
+		cfg.APIExplorer,
+		cfg.QueryDegradation,
+		cfg.ListPolicy,
+		cfg.ExternalMetrics,
+		cfg.Federation,
+		cfg.PublicAccess,
+		cfg.Shutdown,
+		cfg.ConfigurationName,
 	}
 	return CompletedConfig{&c}
 }
@@ -120,6 +170,47 @@ func (config completedConfig) New() (*ClusterPediaServer, error) {
 	}
 	clusterpediaInformerFactory := informers.NewSharedInformerFactory(crdclient, 0)
 
+	var masker *masking.Masker
+	var filterFunctions *wasmfilter.Registry
+	if config.ConfigurationName != "" {
+		if config.ListPolicy == nil {
+			config.ListPolicy = &listpolicy.Policy{}
+		}
+		listPolicy := config.ListPolicy
+		watcher := configuration.NewWatcher(config.ConfigurationName, clusterpediaInformerFactory.Config().V1alpha1().ClusterpediaConfigurations())
+		watcher.AddHandler(func(spec *configv1alpha1.ClusterpediaConfigurationSpec) {
+			var list *configv1alpha1.ListConfiguration
+			if spec != nil {
+				list = spec.List
+			}
+			if err := listPolicy.Override(list); err != nil {
+				klog.ErrorS(err, "Failed to apply the list settings of the ClusterpediaConfiguration", "name", config.ConfigurationName)
+			}
+		})
+
+		masker = &masking.Masker{}
+		watcher.AddHandler(func(spec *configv1alpha1.ClusterpediaConfigurationSpec) {
+			var maskingConfig *configv1alpha1.MaskingConfiguration
+			if spec != nil {
+				maskingConfig = spec.Masking
+			}
+			if err := masker.Update(maskingConfig); err != nil {
+				klog.ErrorS(err, "Failed to apply the masking settings of the ClusterpediaConfiguration, keep the previous masking rules", "name", config.ConfigurationName)
+			}
+		})
+
+		filterFunctions = &wasmfilter.Registry{}
+		watcher.AddHandler(func(spec *configv1alpha1.ClusterpediaConfigurationSpec) {
+			var filterConfig *configv1alpha1.FilterConfiguration
+			if spec != nil {
+				filterConfig = spec.Filters
+			}
+			if err := filterFunctions.Update(filterConfig); err != nil {
+				klog.ErrorS(err, "Failed to apply the filter functions of the ClusterpediaConfiguration, keep the previous functions", "name", config.ConfigurationName)
+			}
+		})
+	}
+
 	resourceServerConfig := kubeapiserver.NewDefaultConfig()
 	resourceServerConfig.GenericConfig.ExternalAddress = config.GenericConfig.ExternalAddress
 	resourceServerConfig.GenericConfig.LoopbackClientConfig = config.GenericConfig.LoopbackClientConfig
@@ -128,16 +219,36 @@ func (config completedConfig) New() (*ClusterPediaServer, error) {
 	resourceServerConfig.InformerFactory = clusterpediaInformerFactory
 	resourceServerConfig.StorageFactory = config.StorageFactory
 	resourceServerConfig.InitialAPIGroupResources = initialAPIGroupResources
+	resourceServerConfig.ListPolicy = config.ListPolicy
+	resourceServerConfig.ExternalMetrics = config.ExternalMetrics
+	resourceServerConfig.Federation = config.Federation
+	resourceServerConfig.Masker = masker
+	resourceServerConfig.Filters = filterFunctions
 	resourceServerConfig.ExtraConfig = config.ExtraConfig
 	kubeResourceAPIServer, methods, err := resourceServerConfig.Complete().New(genericapiserver.NewEmptyDelegate())
 	if err != nil {
 		return nil, err
 	}
 
+	var shutdownTracker *shutdown.Tracker
+	if config.Shutdown != nil {
+		shutdownTracker = shutdown.NewTracker(*config.Shutdown)
+	}
+
 	handlerChainFunc := config.GenericConfig.BuildHandlerChainFunc
 	config.GenericConfig.BuildHandlerChainFunc = func(apiHandler http.Handler, c *genericapiserver.Config) http.Handler {
+		if shutdownTracker != nil {
+			apiHandler = shutdownTracker.WithInFlightTracking(apiHandler, c.LongRunningFunc)
+		}
+		if config.QueryDegradation != nil {
+			apiHandler = slo.WithQueryDegradation(apiHandler, slo.NewTracker(*config.QueryDegradation), c.Serializer)
+		}
+		if config.PublicAccess != nil {
+			apiHandler = publicaccess.WithPublicAccess(apiHandler, config.PublicAccess, c.Serializer)
+		}
 		handler := handlerChainFunc(apiHandler, c)
 		handler = filters.WithRequestQuery(handler)
+		handler = filters.WithFederatedRequest(handler)
 		handler = filters.WithAcceptHeader(handler)
 		return handler
 	}
@@ -146,17 +257,39 @@ func (config completedConfig) New() (*ClusterPediaServer, error) {
 	if err != nil {
 		return nil, err
 	}
+	if config.Shutdown != nil {
+		config.Shutdown.ApplyToServer(genericServer)
+	}
 
 	v1beta1storage := map[string]rest.Storage{}
 	v1beta1storage["resources"] = resources.NewREST(kubeResourceAPIServer.Handler, methods)
-	v1beta1storage["collectionresources"] = collectionresources.NewREST(config.GenericConfig.Serializer, config.StorageFactory)
+	v1beta1storage["collectionresources"] = collectionresources.NewREST(config.GenericConfig.Serializer, config.StorageFactory, config.ListPolicy)
 
 	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(internal.GroupName, Scheme, ParameterCodec, Codecs)
 	apiGroupInfo.VersionedResourcesStorageMap["v1beta1"] = v1beta1storage
+	apiGroupInfo.VersionedResourcesStorageMap["v1beta2"] = map[string]rest.Storage{
+		"resources":           v1beta1storage["resources"],
+		"collectionresources": v1beta1storage["collectionresources"],
+	}
 	if err := genericServer.InstallAPIGroup(&apiGroupInfo); err != nil {
 		return nil, err
 	}
 
+	if config.APIExplorer != nil {
+		handler, err := explorer.NewHandler(config.APIExplorer, v1beta1.SchemeGroupVersion.String())
+		if err != nil {
+			return nil, err
+		}
+		genericServer.Handler.NonGoRestfulMux.Handle(strings.TrimSuffix(explorer.DefaultPath, "/"), http.RedirectHandler(explorer.DefaultPath, http.StatusMovedPermanently))
+		genericServer.Handler.NonGoRestfulMux.HandlePrefix(explorer.DefaultPath, handler)
+	}
+
+	if config.ExternalMetrics != nil {
+		// the external metrics are served by the resource server
+		genericServer.Handler.NonGoRestfulMux.Handle(externalmetrics.Path, kubeResourceAPIServer.Handler)
+		genericServer.Handler.NonGoRestfulMux.HandlePrefix(externalmetrics.Path+"/", kubeResourceAPIServer.Handler)
+	}
+
 	genericServer.AddPostStartHookOrDie("start-clusterpedia-informers", func(context genericapiserver.PostStartHookContext) error {
 		clusterpediaInformerFactory.Start(context.Done())
 		clusterpediaInformerFactory.WaitForCacheSync(context.Done())
@@ -166,11 +299,18 @@ func (config completedConfig) New() (*ClusterPediaServer, error) {
 
 	return &ClusterPediaServer{
 		GenericAPIServer: genericServer,
+		shutdownTracker:  shutdownTracker,
 	}, nil
 }
 
+// Run runs the server until the ctx is done and the in-flight requests are drained,
+// the storage must be shut down by the caller after Run returns.
 func (server *ClusterPediaServer) Run(ctx context.Context) error {
-	return server.GenericAPIServer.PrepareRun().RunWithContext(ctx)
+	prepared := server.GenericAPIServer.PrepareRun()
+	if server.shutdownTracker == nil {
+		return prepared.RunWithContext(ctx)
+	}
+	return server.shutdownTracker.Run(ctx, prepared.RunWithContext)
 }
 
 type hooksDelegate struct {
@@ -0,0 +1,88 @@
+package explorer
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+type Config struct {
+	AssetsURL string
+}
+
+// The page discovers the OpenAPI V3 documents served by the apiserver at load time,
+// so the explorer always reflects the live schema of the installed group versions.
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>Clusterpedia API Explorer</title>
+  <link rel="stylesheet" href="{{ .AssetsURL }}/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{ .AssetsURL }}/swagger-ui-bundle.js" crossorigin></script>
+  <script src="{{ .AssetsURL }}/swagger-ui-standalone-preset.js" crossorigin></script>
+  <script>
+    window.onload = async () => {
+      const urls = [];
+      try {
+        const resp = await fetch({{ .OpenAPIV3Path }}, { credentials: "same-origin" });
+        const discovery = await resp.json();
+        for (const [path, ref] of Object.entries(discovery.paths || {})) {
+          urls.push({ name: path, url: ref.serverRelativeURL });
+        }
+      } catch (e) {
+        console.error("failed to discover openapi v3 documents", e);
+      }
+      urls.sort((a, b) => a.name.localeCompare(b.name));
+
+      window.ui = SwaggerUIBundle({
+        urls: urls,
+        "urls.primaryName": {{ .PrimaryName }},
+        dom_id: "#swagger-ui",
+        deepLinking: true,
+        presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+        layout: "StandaloneLayout",
+      });
+    };
+  </script>
+</body>
+</html>
+`))
+
+type indexData struct {
+	AssetsURL     string
+	OpenAPIV3Path string
+	PrimaryName   string
+}
+
+// NewHandler returns the handler of the api explorer page,
+// primaryGroupVersion is the group version selected by default, eg. "clusterpedia.io/v1beta1".
+func NewHandler(config *Config, primaryGroupVersion string) (http.Handler, error) {
+	var buffer bytes.Buffer
+	if err := indexTemplate.Execute(&buffer, indexData{
+		AssetsURL:     strings.TrimSuffix(config.AssetsURL, "/"),
+		OpenAPIV3Path: "/openapi/v3",
+		PrimaryName:   "apis/" + primaryGroupVersion,
+	}); err != nil {
+		return nil, err
+	}
+	page := buffer.Bytes()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		if _, err := w.Write(page); err != nil {
+			klog.ErrorS(err, "Failed to write api explorer page")
+		}
+	}), nil
+}
@@ -0,0 +1,48 @@
+package explorer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	handler, err := NewHandler(&Config{AssetsURL: "https://assets.example.com/swagger-ui/"}, "clusterpedia.io/v1beta1")
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		expectedCode int
+	}{
+		{"get", http.MethodGet, http.StatusOK},
+		{"head", http.MethodHead, http.StatusOK},
+		{"post", http.MethodPost, http.StatusMethodNotAllowed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(test.method, DefaultPath, nil))
+			if recorder.Code != test.expectedCode {
+				t.Fatalf("expected status code %d, but got %d", test.expectedCode, recorder.Code)
+			}
+			if test.expectedCode != http.StatusOK {
+				return
+			}
+
+			body := recorder.Body.String()
+			for _, expected := range []string{
+				`href="https://assets.example.com/swagger-ui/swagger-ui.css"`,
+				`"urls.primaryName": "apis/clusterpedia.io/v1beta1"`,
+				`fetch("/openapi/v3"`,
+			} {
+				if !strings.Contains(body, expected) {
+					t.Errorf("expected page to contain %q", expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package explorer
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	DefaultPath      = "/explorer/"
+	DefaultAssetsURL = "https://unpkg.com/swagger-ui-dist@5"
+)
+
+type Options struct {
+	EnableAPIExplorer bool
+
+	// AssetsURL is the base URL from which the browser loads the Swagger UI bundle,
+	// it can be pointed to a mirror in air-gapped environments.
+	AssetsURL string
+}
+
+func NewOptions() *Options {
+	return &Options{
+		AssetsURL: DefaultAssetsURL,
+	}
+}
+
+func (o *Options) Validate() []error {
+	if o == nil || !o.EnableAPIExplorer {
+		return nil
+	}
+
+	var errs []error
+	if o.AssetsURL == "" {
+		errs = append(errs, fmt.Errorf("--api-explorer-assets-url is required when the api explorer is enabled"))
+	} else if _, err := url.Parse(o.AssetsURL); err != nil {
+		errs = append(errs, fmt.Errorf("--api-explorer-assets-url is invalid: %w", err))
+	}
+	return errs
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.EnableAPIExplorer, "enable-api-explorer", o.EnableAPIExplorer, ""+
+		fmt.Sprintf("Serve a Swagger UI at %q to explore the clusterpedia apis with the live OpenAPI schema.", DefaultPath))
+	fs.StringVar(&o.AssetsURL, "api-explorer-assets-url", o.AssetsURL, ""+
+		"The base URL of the swagger-ui-dist assets loaded by the api explorer page.")
+}
+
+func (o *Options) Config() *Config {
+	if !o.EnableAPIExplorer {
+		return nil
+	}
+	return &Config{AssetsURL: o.AssetsURL}
+}
@@ -0,0 +1,115 @@
+package externalmetrics
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"github.com/clusterpedia-io/api/clusterpedia/fields"
+)
+
+// Config is the external metrics served by the clusterpedia apiserver.
+type Config struct {
+	Metrics []Metric `json:"metrics"`
+}
+
+// Metric is an external metric aggregated from the resources in clusterpedia,
+// the value of the metric is the number of the objects that match the metric
+// and the label selector of the metric request.
+//
+// For example, the fleet-wide pending pods:
+//
+//	name: fleet-pending-pods
+//	version: v1
+//	resource: pods
+//	fieldSelector: status.phase=Pending
+//	allNamespaces: true
+type Metric struct {
+	Name string `json:"name"`
+
+	Group    string `json:"group,omitempty"`
+	Version  string `json:"version"`
+	Resource string `json:"resource"`
+
+	// Clusters limits the objects to the clusters, the objects of all clusters are counted by default.
+	Clusters []string `json:"clusters,omitempty"`
+
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// FieldSelector supports the enhanced field selector of clusterpedia, e.g. `status.phase=Pending`.
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// AllNamespaces counts the objects in all namespaces instead of the namespace of the metric request.
+	AllNamespaces bool `json:"allNamespaces,omitempty"`
+}
+
+func (m Metric) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: m.Group, Version: m.Version, Resource: m.Resource}
+}
+
+// Selectors returns the parsed label and field selectors of the metric.
+func (m Metric) Selectors() (labels.Selector, fields.Selector, error) {
+	labelSelector, err := labels.Parse(m.LabelSelector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	var fieldSelector fields.Selector
+	if m.FieldSelector != "" {
+		if fieldSelector, err = fields.Parse(m.FieldSelector); err != nil {
+			return nil, nil, fmt.Errorf("invalid field selector: %w", err)
+		}
+	}
+	return labelSelector, fieldSelector, nil
+}
+
+// Get returns the metric by the name.
+func (c *Config) Get(name string) (Metric, bool) {
+	for _, metric := range c.Metrics {
+		if metric.Name == name {
+			return metric, true
+		}
+	}
+	return Metric{}, false
+}
+
+func (c *Config) Validate() error {
+	names := make(map[string]struct{}, len(c.Metrics))
+	for i, metric := range c.Metrics {
+		if metric.Name == "" {
+			return fmt.Errorf("metrics[%d]: name is required", i)
+		}
+		if _, ok := names[metric.Name]; ok {
+			return fmt.Errorf("metrics[%d]: duplicate name %q", i, metric.Name)
+		}
+		names[metric.Name] = struct{}{}
+
+		if metric.Version == "" || metric.Resource == "" {
+			return fmt.Errorf("metric %q: version and resource are required", metric.Name)
+		}
+		if _, _, err := metric.Selectors(); err != nil {
+			return fmt.Errorf("metric %q: %w", metric.Name, err)
+		}
+	}
+	return nil
+}
+
+// LoadConfig loads and validates the external metrics from the file.
+func LoadConfig(file string) (*Config, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
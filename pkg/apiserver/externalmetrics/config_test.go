@@ -0,0 +1,85 @@
+package externalmetrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			content: `
+metrics:
+- name: fleet-pending-pods
+  version: v1
+  resource: pods
+  fieldSelector: status.phase=Pending
+  allNamespaces: true
+- name: nginx-deployments
+  group: apps
+  version: v1
+  resource: deployments
+  clusters: [cluster-1]
+  labelSelector: app=nginx
+`,
+		},
+		{
+			name: "duplicate name",
+			content: `
+metrics:
+- {name: pods, version: v1, resource: pods}
+- {name: pods, version: v1, resource: pods}
+`,
+			wantErr: true,
+		},
+		{
+			name:    "missing resource",
+			content: `metrics: [{name: pods, version: v1}]`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid label selector",
+			content: `metrics: [{name: pods, version: v1, resource: pods, labelSelector: "app in"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			content: `metrics: [{name: pods, version: v1, resource: pods, namespace: default}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "metrics.yaml")
+			if err := os.WriteFile(file, []byte(test.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := LoadConfig(file)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("LoadConfig() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			metric, ok := config.Get("fleet-pending-pods")
+			if !ok {
+				t.Fatal("metric fleet-pending-pods is not found")
+			}
+			if _, fieldSelector, _ := metric.Selectors(); fieldSelector == nil || fieldSelector.String() != "status.phase=Pending" {
+				t.Errorf("field selector = %v, want status.phase=Pending", fieldSelector)
+			}
+			if _, ok := config.Get("unknown"); ok {
+				t.Error("Get() should not return the unknown metric")
+			}
+		})
+	}
+}
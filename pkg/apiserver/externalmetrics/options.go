@@ -0,0 +1,44 @@
+package externalmetrics
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+type Options struct {
+	// ConfigFile is the file of the external metrics,
+	// the external metrics api is served only when it is set.
+	ConfigFile string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Validate() []error {
+	if o == nil || o.ConfigFile == "" {
+		return nil
+	}
+
+	if _, err := LoadConfig(o.ConfigFile); err != nil {
+		return []error{fmt.Errorf("--external-metrics-config: %w", err)}
+	}
+	return nil
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ConfigFile, "external-metrics-config", o.ConfigFile, ""+
+		fmt.Sprintf("The file of the external metrics aggregated from the resources in clusterpedia, the metrics are served at %q ", Path)+
+		"for the HPA or KEDA when an APIService of the external metrics points to the clusterpedia apiserver.")
+}
+
+func (o *Options) Config() *Config {
+	if o.ConfigFile == "" {
+		return nil
+	}
+
+	// the config file has been validated
+	config, _ := LoadConfig(o.ConfigFile)
+	return config
+}
@@ -0,0 +1,35 @@
+package externalmetrics
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The types are the same as k8s.io/metrics/pkg/apis/external_metrics/v1beta1,
+// they are encoded in json for the HPA controller and KEDA.
+
+// Path is the path of the external metrics api
+const Path = "/apis/external.metrics.k8s.io/v1beta1"
+
+var SchemeGroupVersion = schema.GroupVersion{Group: "external.metrics.k8s.io", Version: "v1beta1"}
+
+// ExternalMetricValueList is a list of values for a given metric for some set labels
+type ExternalMetricValueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ExternalMetricValue `json:"items"`
+}
+
+// ExternalMetricValue is a metric value for external metric
+type ExternalMetricValue struct {
+	metav1.TypeMeta `json:",inline"`
+
+	MetricName   string            `json:"metricName"`
+	MetricLabels map[string]string `json:"metricLabels"`
+
+	Timestamp     metav1.Time       `json:"timestamp"`
+	WindowSeconds *int64            `json:"window,omitempty"`
+	Value         resource.Quantity `json:"value"`
+}
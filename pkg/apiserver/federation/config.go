@@ -0,0 +1,84 @@
+package federation
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Config is the remote clusterpedia instances federated by the clusterpedia apiserver.
+//
+// For example, the clusterpedia instances of the other regions:
+//
+//	endpoints:
+//	- name: region-a
+//	  kubeconfig: /etc/clusterpedia/federation/region-a.kubeconfig
+//	  impersonate: true
+//	- name: region-b
+//	  kubeconfig: /etc/clusterpedia/federation/region-b.kubeconfig
+//	  timeout: 5s
+type Config struct {
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// Endpoint is a remote clusterpedia instance, which is accessed by the kubeconfig
+// of the kube-apiserver aggregating the clusterpedia apiserver of the instance.
+type Endpoint struct {
+	Name       string `json:"name"`
+	Kubeconfig string `json:"kubeconfig"`
+
+	// Impersonate lists the resources of the instance as the user of the request,
+	// otherwise the resources are listed as the user of the kubeconfig.
+	// The user of the kubeconfig must be allowed to impersonate the users.
+	Impersonate bool `json:"impersonate,omitempty"`
+
+	// Timeout is the timeout of the requests to the instance, defaults to 10s.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+func (c *Config) Validate() error {
+	if len(c.Endpoints) == 0 {
+		return fmt.Errorf("endpoints is required")
+	}
+
+	names := make(map[string]struct{}, len(c.Endpoints))
+	for i, endpoint := range c.Endpoints {
+		if endpoint.Name == "" {
+			return fmt.Errorf("endpoints[%d]: name is required", i)
+		}
+		if _, ok := names[endpoint.Name]; ok {
+			return fmt.Errorf("endpoints[%d]: duplicate name %q", i, endpoint.Name)
+		}
+		names[endpoint.Name] = struct{}{}
+
+		if endpoint.Kubeconfig == "" {
+			return fmt.Errorf("endpoint %q: kubeconfig is required", endpoint.Name)
+		}
+		if endpoint.Timeout.Duration < 0 {
+			return fmt.Errorf("endpoint %q: timeout can not be negative", endpoint.Name)
+		}
+	}
+	return nil
+}
+
+// LoadConfig loads and validates the federation config from the file.
+func LoadConfig(file string) (*Config, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
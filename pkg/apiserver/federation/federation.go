@@ -0,0 +1,192 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/warning"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/transport"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+// Federation lists the resources from the remote clusterpedia instances,
+// which are merged with the resources in the local storage as a global search plane
+// without centralizing the resources of the instances.
+type Federation struct {
+	endpoints []*endpoint
+}
+
+type endpoint struct {
+	name        string
+	client      rest.Interface
+	impersonate bool
+	timeout     time.Duration
+}
+
+func New(config *Config) (*Federation, error) {
+	federation := &Federation{}
+	for _, e := range config.Endpoints {
+		restConfig, err := clientcmd.BuildConfigFromFlags("", e.Kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %q: %w", e.Name, err)
+		}
+		restConfig.NegotiatedSerializer = clientgoscheme.Codecs.WithoutConversion()
+
+		client, err := rest.UnversionedRESTClientFor(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %q: %w", e.Name, err)
+		}
+
+		timeout := e.Timeout.Duration
+		if timeout == 0 {
+			timeout = defaultTimeout
+		}
+		federation.endpoints = append(federation.endpoints, &endpoint{
+			name:        e.Name,
+			client:      client,
+			impersonate: e.Impersonate,
+			timeout:     timeout,
+		})
+	}
+	return federation, nil
+}
+
+// ResourcesPath returns the path of the clusterpedia resources api for the resource path,
+// e.g. /apis/clusterpedia.io/v1beta1/resources/apis/apps/v1/deployments
+func ResourcesPath(version string, path string) string {
+	return "/apis/" + internal.GroupName + "/" + version + "/resources" + path
+}
+
+// Enabled returns whether the request should be federated,
+// the requests federated by the other clusterpedia apiserver are not federated again.
+func (f *Federation) Enabled(ctx context.Context) bool {
+	return f != nil && len(f.endpoints) != 0 && !request.IsFederated(ctx)
+}
+
+// ListInto lists the resources from the remote instances by the query and appends them to the items of the list,
+// the list of each instance is decoded into the list created by newList.
+//
+// The continue token is only valid in the local storage, so the remote instances always return their first page.
+// The instances which fail to list are skipped with a warning.
+func (f *Federation) ListInto(ctx context.Context, path string, query url.Values, list runtime.Object, newList func() runtime.Object) error {
+	var items []runtime.Object
+	for _, remote := range f.list(ctx, path, query, newList) {
+		if remote.err != nil {
+			warning.AddWarning(ctx, "", fmt.Sprintf("failed to list from the federated clusterpedia %q: %v", remote.endpoint, remote.err))
+			continue
+		}
+
+		objs, err := meta.ExtractList(remote.list)
+		if err != nil {
+			return err
+		}
+		items = append(items, objs...)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	return meta.SetList(list, append(objs, items...))
+}
+
+// Count returns the sum of the remaining item counts of the count-only lists from the remote instances.
+func (f *Federation) Count(ctx context.Context, path string, query url.Values, newList func() runtime.Object) int64 {
+	var count int64
+	for _, remote := range f.list(ctx, path, query, newList) {
+		if remote.err != nil {
+			warning.AddWarning(ctx, "", fmt.Sprintf("failed to count from the federated clusterpedia %q: %v", remote.endpoint, remote.err))
+			continue
+		}
+
+		if listMeta, err := meta.ListAccessor(remote.list); err == nil && listMeta.GetRemainingItemCount() != nil {
+			count += *listMeta.GetRemainingItemCount()
+		}
+	}
+	return count
+}
+
+type remoteList struct {
+	endpoint string
+	list     runtime.Object
+	err      error
+}
+
+// list requests the remote instances concurrently, the lists are returned in the order of the endpoints.
+func (f *Federation) list(ctx context.Context, path string, query url.Values, newList func() runtime.Object) []remoteList {
+	lists := make([]remoteList, len(f.endpoints))
+
+	var wg sync.WaitGroup
+	for i, e := range f.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+
+			list := newList()
+			lists[i] = remoteList{endpoint: e.name, list: list, err: e.listInto(ctx, path, query, list)}
+		}(i, e)
+	}
+	wg.Wait()
+	return lists
+}
+
+func (e *endpoint) listInto(ctx context.Context, path string, query url.Values, into runtime.Object) error {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	req := e.client.Get().AbsPath(path).SetHeader(request.FederatedRequestHeader, "true")
+	for key, values := range query {
+		if key == "continue" {
+			continue
+		}
+		for _, value := range values {
+			req.Param(key, value)
+		}
+	}
+
+	if e.impersonate {
+		user, ok := genericrequest.UserFrom(ctx)
+		if !ok {
+			return errors.New("missing the user to impersonate")
+		}
+		req.SetHeader(transport.ImpersonateUserHeader, user.GetName())
+		if uid := user.GetUID(); uid != "" {
+			req.SetHeader(transport.ImpersonateUIDHeader, uid)
+		}
+		if groups := user.GetGroups(); len(groups) != 0 {
+			req.SetHeader(transport.ImpersonateGroupHeader, groups...)
+		}
+		for key, values := range user.GetExtra() {
+			req.SetHeader(transport.ImpersonateUserExtraHeaderPrefix+url.PathEscape(key), values...)
+		}
+	}
+
+	data, err := req.DoRaw(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := into.(*unstructured.UnstructuredList); ok {
+		_, _, err = unstructured.UnstructuredJSONScheme.Decode(data, nil, into)
+		return err
+	}
+	_, _, err = scheme.LegacyResourceCodecs.UniversalDecoder().Decode(data, nil, into)
+	return err
+}
@@ -0,0 +1,165 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/warning"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/kubernetes/pkg/apis/core"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			content: `
+endpoints:
+- name: region-a
+  kubeconfig: /etc/region-a.kubeconfig
+  impersonate: true
+- name: region-b
+  kubeconfig: /etc/region-b.kubeconfig
+  timeout: 5s
+`,
+		},
+		{
+			name:    "no endpoints",
+			content: `endpoints: []`,
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			content: `
+endpoints:
+- {name: region-a, kubeconfig: /etc/region-a.kubeconfig}
+- {name: region-a, kubeconfig: /etc/region-b.kubeconfig}
+`,
+			wantErr: true,
+		},
+		{
+			name:    "missing kubeconfig",
+			content: `endpoints: [{name: region-a}]`,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "federation.yaml")
+			if err := os.WriteFile(file, []byte(test.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadConfig(file); (err != nil) != test.wantErr {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func writeKubeconfig(t *testing.T, server string) string {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["remote"] = &clientcmdapi.Cluster{Server: server}
+	config.AuthInfos["remote"] = &clientcmdapi.AuthInfo{Token: "token"}
+	config.Contexts["remote"] = &clientcmdapi.Context{Cluster: "remote", AuthInfo: "remote"}
+	config.CurrentContext = "remote"
+
+	file := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := clientcmd.WriteToFile(*config, file); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+type warningRecorder []string
+
+func (r *warningRecorder) AddWarning(_, text string) { *r = append(*r, text) }
+
+func TestListInto(t *testing.T) {
+	const path = "/apis/clusterpedia.io/v1beta1/resources/api/v1/pods"
+
+	var requests []*http.Request
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req)
+		if req.URL.Path != path {
+			http.NotFound(w, req)
+			return
+		}
+
+		remaining := int64(2)
+		list := &corev1.PodList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"},
+			ListMeta: metav1.ListMeta{RemainingItemCount: &remaining},
+			Items:    []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "remote-pod"}}},
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	defer remote.Close()
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	federation, err := New(&Config{Endpoints: []Endpoint{
+		{Name: "region-a", Kubeconfig: writeKubeconfig(t, remote.URL), Impersonate: true},
+		{Name: "region-b", Kubeconfig: writeKubeconfig(t, unavailable.URL)},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &warningRecorder{}
+	ctx := warning.WithWarningRecorder(context.Background(), recorder)
+	ctx = genericrequest.WithUser(ctx, &user.DefaultInfo{Name: "alice", Groups: []string{"dev"}})
+	if !federation.Enabled(ctx) {
+		t.Fatal("expected the federation is enabled")
+	}
+	if federation.Enabled(request.WithFederated(ctx)) {
+		t.Error("expected the federated request is not federated again")
+	}
+
+	list := &core.PodList{Items: []core.Pod{{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "local-pod"}}}}
+	query := url.Values{"limit": []string{"10"}, "continue": []string{"token"}}
+	newList := func() runtime.Object { return &core.PodList{} }
+	if err := federation.ListInto(ctx, path, query, list, newList); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 || list.Items[0].Name != "local-pod" || list.Items[1].Name != "remote-pod" {
+		t.Errorf("expected the remote pods are appended, got %v", list.Items)
+	}
+	if len(*recorder) != 1 {
+		t.Errorf("expected the warning of the unavailable endpoint, got %v", *recorder)
+	}
+
+	req := requests[0]
+	if req.URL.Query().Get("limit") != "10" || req.URL.Query().Has("continue") {
+		t.Errorf("expected the query is forwarded without the continue token, got %v", req.URL.Query())
+	}
+	if req.Header.Get(request.FederatedRequestHeader) == "" {
+		t.Error("expected the request is marked as federated")
+	}
+	if req.Header.Get("Impersonate-User") != "alice" || req.Header.Get("Impersonate-Group") != "dev" {
+		t.Errorf("expected the user of the request is impersonated, got %v", req.Header)
+	}
+
+	if count := federation.Count(ctx, path, query, newList); count != 2 {
+		t.Errorf("expected the remaining item count of the remote list, got %d", count)
+	}
+}
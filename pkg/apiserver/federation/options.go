@@ -0,0 +1,44 @@
+package federation
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+type Options struct {
+	// ConfigFile is the file of the remote clusterpedia instances,
+	// the lists are federated only when it is set.
+	ConfigFile string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Validate() []error {
+	if o == nil || o.ConfigFile == "" {
+		return nil
+	}
+
+	if _, err := LoadConfig(o.ConfigFile); err != nil {
+		return []error{fmt.Errorf("--federation-config: %w", err)}
+	}
+	return nil
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ConfigFile, "federation-config", o.ConfigFile, ""+
+		"The file of the remote clusterpedia instances, whose resources are listed and merged with the resources "+
+		"in the local storage when the resources of all clusters are listed.")
+}
+
+func (o *Options) Config() (*Federation, error) {
+	if o.ConfigFile == "" {
+		return nil, nil
+	}
+
+	// the config file has been validated
+	config, _ := LoadConfig(o.ConfigFile)
+	return New(config)
+}
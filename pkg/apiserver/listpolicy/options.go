@@ -0,0 +1,104 @@
+package listpolicy
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+)
+
+// CoreGroup is used in the flags to specify the core group of kubernetes
+const CoreGroup = "core"
+
+type Options struct {
+	DefaultLimit int64
+	MaxLimit     int64
+
+	// DefaultOrderBy has the same format as the `orderby` query, e.g. 'namespace,name desc'
+	DefaultOrderBy string
+
+	// ClusterRequiredGroups are the api groups whose list requests must specify the clusters
+	ClusterRequiredGroups []string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) Validate() []error {
+	if o == nil {
+		return nil
+	}
+
+	var errs []error
+	if o.DefaultLimit < 0 {
+		errs = append(errs, fmt.Errorf("--list-default-limit must be greater than or equal to 0"))
+	}
+	if o.MaxLimit < 0 {
+		errs = append(errs, fmt.Errorf("--list-max-limit must be greater than or equal to 0"))
+	}
+	if o.MaxLimit > 0 && o.DefaultLimit > o.MaxLimit {
+		errs = append(errs, fmt.Errorf("--list-default-limit must be less than or equal to --list-max-limit"))
+	}
+	if _, err := parseOrderBy(o.DefaultOrderBy); err != nil {
+		errs = append(errs, fmt.Errorf("--list-default-orderby: %w", err))
+	}
+	return errs
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.Int64Var(&o.DefaultLimit, "list-default-limit", o.DefaultLimit, ""+
+		"The limit of the list requests that don't specify the limit, 0 means no default limit.")
+	fs.Int64Var(&o.MaxLimit, "list-max-limit", o.MaxLimit, ""+
+		"The maximum limit of the list requests, a larger limit is lowered to it and the clients page with the continue token. "+
+		"The list requests without limit are also limited by it, 0 means no maximum limit.")
+	fs.StringVar(&o.DefaultOrderBy, "list-default-orderby", o.DefaultOrderBy, ""+
+		"The order of the list requests that don't specify the orderby, formatted as the orderby query, e.g. 'namespace,name desc'.")
+	fs.StringSliceVar(&o.ClusterRequiredGroups, "list-cluster-required-groups", o.ClusterRequiredGroups, ""+
+		"List of api groups whose resources can only be listed with the clusters specified, "+
+		fmt.Sprintf("use '%s' for the core group.", CoreGroup))
+}
+
+func (o *Options) Config() *Policy {
+	orderby, _ := parseOrderBy(o.DefaultOrderBy)
+	if o.DefaultLimit == 0 && o.MaxLimit == 0 && len(orderby) == 0 && len(o.ClusterRequiredGroups) == 0 {
+		return nil
+	}
+
+	return &Policy{
+		DefaultLimit:          o.DefaultLimit,
+		MaxLimit:              o.MaxLimit,
+		DefaultOrderBy:        orderby,
+		ClusterRequiredGroups: parseGroups(o.ClusterRequiredGroups),
+	}
+}
+
+func parseGroups(groups []string) sets.Set[string] {
+	set := sets.New[string]()
+	for _, group := range groups {
+		group = strings.TrimSpace(group)
+		if group == CoreGroup {
+			group = ""
+		}
+		set.Insert(group)
+	}
+	return set
+}
+
+func parseOrderBy(orderby string) ([]internal.OrderBy, error) {
+	if strings.TrimSpace(orderby) == "" {
+		return nil, nil
+	}
+
+	var options internal.ListOptions
+	if err := scheme.ParameterCodec.DecodeParameters(url.Values{"orderby": []string{orderby}}, v1beta1.SchemeGroupVersion, &options); err != nil {
+		return nil, err
+	}
+	return options.OrderBy, nil
+}
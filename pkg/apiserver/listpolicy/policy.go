@@ -0,0 +1,112 @@
+package listpolicy
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+)
+
+// Policy enforces the server-side defaults and limits on the list options,
+// so the clients can't run unbounded queries against the storage.
+type Policy struct {
+	DefaultLimit   int64
+	MaxLimit       int64
+	DefaultOrderBy []internal.OrderBy
+
+	ClusterRequiredGroups sets.Set[string]
+
+	// overridden is the policy with the settings of the ClusterpediaConfiguration, it is used instead of the policy if it is set
+	overridden atomic.Pointer[Policy]
+}
+
+// Override overrides the policy at runtime with the list configuration, the unset fields keep the values of the policy,
+// and a nil configuration restores the policy.
+func (p *Policy) Override(config *configv1alpha1.ListConfiguration) error {
+	if config == nil {
+		p.overridden.Store(nil)
+		return nil
+	}
+
+	overridden := &Policy{
+		DefaultLimit:          p.DefaultLimit,
+		MaxLimit:              p.MaxLimit,
+		DefaultOrderBy:        p.DefaultOrderBy,
+		ClusterRequiredGroups: p.ClusterRequiredGroups,
+	}
+	if config.DefaultLimit != nil {
+		overridden.DefaultLimit = *config.DefaultLimit
+	}
+	if config.MaxLimit != nil {
+		overridden.MaxLimit = *config.MaxLimit
+	}
+	if config.DefaultOrderBy != nil {
+		orderby, err := parseOrderBy(*config.DefaultOrderBy)
+		if err != nil {
+			return fmt.Errorf("defaultOrderBy: %w", err)
+		}
+		overridden.DefaultOrderBy = orderby
+	}
+	if config.ClusterRequiredGroups != nil {
+		overridden.ClusterRequiredGroups = parseGroups(config.ClusterRequiredGroups)
+	}
+
+	if overridden.DefaultLimit < 0 || overridden.MaxLimit < 0 {
+		return fmt.Errorf("the limits must be greater than or equal to 0")
+	}
+	if overridden.MaxLimit > 0 && overridden.DefaultLimit > overridden.MaxLimit {
+		return fmt.Errorf("the default limit must be less than or equal to the max limit")
+	}
+	p.overridden.Store(overridden)
+	return nil
+}
+
+func (p *Policy) current() *Policy {
+	if overridden := p.overridden.Load(); overridden != nil {
+		return overridden
+	}
+	return p
+}
+
+// Apply fills in the defaults of the list options of the resources in the group,
+// and returns a BadRequest error if the options are not allowed.
+// A nil Policy leaves the options unchanged.
+func (p *Policy) Apply(group string, options *internal.ListOptions) error {
+	if p == nil {
+		return nil
+	}
+	p = p.current()
+
+	if p.ClusterRequiredGroups.Has(group) && len(options.ClusterNames) == 0 {
+		if group == "" {
+			group = CoreGroup
+		}
+		return apierrors.NewBadRequest(fmt.Sprintf("the clusters must be specified when listing the resources of the %q group", group))
+	}
+
+	p.ApplyLimits(options)
+	return nil
+}
+
+// ApplyLimits fills in the default limit and orderby, and lowers the limit to the max limit,
+// it is used by the lists across groups, such as the collection resources.
+func (p *Policy) ApplyLimits(options *internal.ListOptions) {
+	if p == nil {
+		return
+	}
+	p = p.current()
+
+	if options.Limit == 0 {
+		options.Limit = p.DefaultLimit
+	}
+	if p.MaxLimit > 0 && (options.Limit == 0 || options.Limit > p.MaxLimit) {
+		options.Limit = p.MaxLimit
+	}
+	if len(options.OrderBy) == 0 && len(p.DefaultOrderBy) != 0 {
+		options.OrderBy = append([]internal.OrderBy(nil), p.DefaultOrderBy...)
+	}
+}
@@ -0,0 +1,150 @@
+package listpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+)
+
+func TestOptionsConfig(t *testing.T) {
+	if policy := NewOptions().Config(); policy != nil {
+		t.Errorf("Config() = %v, want nil for the default options", policy)
+	}
+
+	options := &Options{DefaultLimit: 10, MaxLimit: 5, DefaultOrderBy: "name name"}
+	if errs := options.Validate(); len(errs) != 2 {
+		t.Errorf("Validate() = %v, want 2 errors", errs)
+	}
+
+	options = &Options{DefaultOrderBy: "namespace,name desc", ClusterRequiredGroups: []string{"core", "apps"}}
+	policy := options.Config()
+	expected := []internal.OrderBy{{Field: "namespace"}, {Field: "name", Desc: true}}
+	if !reflect.DeepEqual(policy.DefaultOrderBy, expected) {
+		t.Errorf("DefaultOrderBy = %v, want %v", policy.DefaultOrderBy, expected)
+	}
+	if !policy.ClusterRequiredGroups.Has("") || !policy.ClusterRequiredGroups.Has("apps") {
+		t.Errorf("ClusterRequiredGroups = %v, want core and apps", policy.ClusterRequiredGroups.UnsortedList())
+	}
+}
+
+func TestPolicyApply(t *testing.T) {
+	policy := (&Options{
+		DefaultLimit:          100,
+		MaxLimit:              500,
+		DefaultOrderBy:        "name",
+		ClusterRequiredGroups: []string{"core"},
+	}).Config()
+
+	tests := []struct {
+		name            string
+		group           string
+		options         internal.ListOptions
+		expectedLimit   int64
+		expectedOrderBy []internal.OrderBy
+		badRequest      bool
+	}{
+		{
+			name:            "defaults",
+			group:           "apps",
+			expectedLimit:   100,
+			expectedOrderBy: []internal.OrderBy{{Field: "name"}},
+		},
+		{
+			name:  "max limit",
+			group: "apps",
+			options: internal.ListOptions{
+				ListOptions: metainternal.ListOptions{Limit: 1000},
+				OrderBy:     []internal.OrderBy{{Field: "namespace", Desc: true}},
+			},
+			expectedLimit:   500,
+			expectedOrderBy: []internal.OrderBy{{Field: "namespace", Desc: true}},
+		},
+		{
+			name:       "missing clusters",
+			group:      "",
+			badRequest: true,
+		},
+		{
+			name:            "with clusters",
+			group:           "",
+			options:         internal.ListOptions{ClusterNames: []string{"cluster-1"}},
+			expectedLimit:   100,
+			expectedOrderBy: []internal.OrderBy{{Field: "name"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := test.options
+
+			err := policy.Apply(test.group, &options)
+			if test.badRequest {
+				if !apierrors.IsBadRequest(err) {
+					t.Errorf("Apply() error = %v, want bad request", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if options.Limit != test.expectedLimit {
+				t.Errorf("Limit = %d, want %d", options.Limit, test.expectedLimit)
+			}
+			if !reflect.DeepEqual(options.OrderBy, test.expectedOrderBy) {
+				t.Errorf("OrderBy = %v, want %v", options.OrderBy, test.expectedOrderBy)
+			}
+		})
+	}
+
+	var nilPolicy *Policy
+	options := internal.ListOptions{}
+	if err := nilPolicy.Apply("", &options); err != nil || options.Limit != 0 {
+		t.Errorf("nil Policy should not change the options")
+	}
+}
+
+func TestPolicyOverride(t *testing.T) {
+	policy := (&Options{DefaultLimit: 100, MaxLimit: 500, DefaultOrderBy: "name"}).Config()
+
+	maxLimit, orderby := int64(50), "namespace"
+	if err := policy.Override(&configv1alpha1.ListConfiguration{MaxLimit: &maxLimit}); err == nil {
+		t.Errorf("Override() should reject the default limit greater than the max limit")
+	}
+
+	defaultLimit := int64(20)
+	if err := policy.Override(&configv1alpha1.ListConfiguration{
+		DefaultLimit:          &defaultLimit,
+		MaxLimit:              &maxLimit,
+		DefaultOrderBy:        &orderby,
+		ClusterRequiredGroups: []string{"core"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	options := internal.ListOptions{}
+	if err := policy.Apply("", &options); !apierrors.IsBadRequest(err) {
+		t.Errorf("Apply() error = %v, want bad request", err)
+	}
+	options = internal.ListOptions{ListOptions: metainternal.ListOptions{Limit: 1000}}
+	if err := policy.Apply("apps", &options); err != nil {
+		t.Fatal(err)
+	}
+	if options.Limit != 50 || !reflect.DeepEqual(options.OrderBy, []internal.OrderBy{{Field: "namespace"}}) {
+		t.Errorf("overridden policy is not applied, got limit %d and orderby %v", options.Limit, options.OrderBy)
+	}
+
+	if err := policy.Override(nil); err != nil {
+		t.Fatal(err)
+	}
+	options = internal.ListOptions{}
+	if err := policy.Apply("", &options); err != nil {
+		t.Fatal(err)
+	}
+	if options.Limit != 100 || !reflect.DeepEqual(options.OrderBy, []internal.OrderBy{{Field: "name"}}) {
+		t.Errorf("policy is not restored, got limit %d and orderby %v", options.Limit, options.OrderBy)
+	}
+}
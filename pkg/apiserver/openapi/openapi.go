@@ -0,0 +1,198 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	openapinamer "k8s.io/apiserver/pkg/endpoints/openapi"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	generatedopenapi "github.com/clusterpedia-io/clusterpedia/pkg/generated/openapi"
+)
+
+const (
+	Title = "clusterpedia apiserver"
+
+	// SearchLabelDefinition is the name of the definition that enumerates the search labels
+	SearchLabelDefinition = "io.clusterpedia.v1beta1.SearchLabel"
+
+	// SearchLabelsExtension is the vendor extension that documents the type and values of each search label
+	SearchLabelsExtension = "x-clusterpedia-search-labels"
+
+	listOptionsDefinition  = "github.com/clusterpedia-io/api/clusterpedia/v1beta1.ListOptions"
+	collectionResourcePath = "/apis/clusterpedia.io/v1beta1/collectionresources/{name}"
+)
+
+// unsupportedQueries are the fields of ListOptions that are not supported by searching the collection resources
+var unsupportedQueries = sets.New("kind", "apiVersion", "watch", "allowWatchBookmarks", "resourceVersion", "resourceVersionMatch", "timeoutSeconds", "sendInitialEvents")
+
+// NewConfig returns the OpenAPI V2 config of the clusterpedia apiserver,
+// the spec is post-processed to document the search labels.
+func NewConfig(scheme *runtime.Scheme) *common.Config {
+	config := genericapiserver.DefaultOpenAPIConfig(generatedopenapi.GetOpenAPIDefinitions, openapinamer.NewDefinitionNamer(scheme))
+	config.Info.Title = Title
+	config.Info.Version = ""
+	config.PostProcessSpec = PostProcessSpec
+	return config
+}
+
+// NewV3Config returns the OpenAPI V3 config of the clusterpedia apiserver,
+// the spec is post-processed to document the search labels.
+func NewV3Config(scheme *runtime.Scheme) *common.OpenAPIV3Config {
+	config := genericapiserver.DefaultOpenAPIV3Config(generatedopenapi.GetOpenAPIDefinitions, openapinamer.NewDefinitionNamer(scheme))
+	config.Info.Title = Title
+	config.Info.Version = ""
+	config.PostProcessSpec = PostProcessSpecV3
+	return config
+}
+
+// PostProcessSpec adds the search label definition to the OpenAPI V2 spec,
+// and documents the query parameters of searching the collection resources.
+func PostProcessSpec(swagger *spec.Swagger) (*spec.Swagger, error) {
+	if swagger.Paths == nil {
+		return swagger, nil
+	}
+	item, ok := swagger.Paths.Paths[collectionResourcePath]
+	if !ok {
+		return swagger, nil
+	}
+
+	if swagger.Definitions == nil {
+		swagger.Definitions = spec.Definitions{}
+	}
+	swagger.Definitions[SearchLabelDefinition] = SearchLabelSchema()
+
+	if item.Get != nil {
+		for _, property := range searchQueryProperties() {
+			item.Get.Parameters = append(item.Get.Parameters, spec.Parameter{
+				CommonValidations: spec.CommonValidations{UniqueItems: true},
+				SimpleSchema:      spec.SimpleSchema{Type: property.schema.Type[0], Format: property.schema.Format},
+				ParamProps: spec.ParamProps{
+					Name:        property.name,
+					In:          "query",
+					Description: property.schema.Description,
+				},
+			})
+		}
+		swagger.Paths.Paths[collectionResourcePath] = item
+	}
+	return swagger, nil
+}
+
+// PostProcessSpecV3 adds the search label schema to the OpenAPI V3 spec of the clusterpedia group,
+// and documents the query parameters of searching the collection resources.
+func PostProcessSpecV3(openapi *spec3.OpenAPI) (*spec3.OpenAPI, error) {
+	if openapi.Paths == nil {
+		return openapi, nil
+	}
+	item, ok := openapi.Paths.Paths[collectionResourcePath]
+	if !ok || item == nil {
+		return openapi, nil
+	}
+
+	if openapi.Components == nil {
+		openapi.Components = &spec3.Components{}
+	}
+	if openapi.Components.Schemas == nil {
+		openapi.Components.Schemas = map[string]*spec.Schema{}
+	}
+	schema := SearchLabelSchema()
+	openapi.Components.Schemas[SearchLabelDefinition] = &schema
+
+	if item.Get != nil {
+		for _, property := range searchQueryProperties() {
+			item.Get.Parameters = append(item.Get.Parameters, &spec3.Parameter{
+				ParameterProps: spec3.ParameterProps{
+					Name:        property.name,
+					In:          "query",
+					Description: property.schema.Description,
+					Schema: &spec.Schema{
+						SchemaProps: spec.SchemaProps{
+							Type:        property.schema.Type,
+							Format:      property.schema.Format,
+							UniqueItems: true,
+						},
+					},
+				},
+			})
+		}
+	}
+	return openapi, nil
+}
+
+// SearchLabelSchema returns a string schema that enumerates the keys of the search labels,
+// the description and values of each label are documented in the `x-clusterpedia-search-labels` extension.
+func SearchLabelSchema() spec.Schema {
+	searchLabels := allSearchLabels()
+	keys := make([]interface{}, 0, len(searchLabels))
+	labels := make([]interface{}, 0, len(searchLabels))
+	var description strings.Builder
+	description.WriteString("SearchLabel is the key of a label in the label selector that controls the search of clusterpedia.")
+	for _, label := range searchLabels {
+		keys = append(keys, label.Key)
+
+		doc := map[string]interface{}{
+			"key":         label.Key,
+			"description": label.Description,
+			"type":        label.Type,
+		}
+		if label.Format != "" {
+			doc["format"] = label.Format
+		}
+		if len(label.Enum) != 0 {
+			doc["enum"] = label.Enum
+		}
+		labels = append(labels, doc)
+
+		description.WriteString("\n - `" + label.Key + "`: " + label.Description)
+	}
+
+	return spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Description: description.String(),
+			Type:        []string{"string"},
+			Enum:        keys,
+		},
+		VendorExtensible: spec.VendorExtensible{
+			Extensions: spec.Extensions{SearchLabelsExtension: labels},
+		},
+	}
+}
+
+type queryProperty struct {
+	name   string
+	schema spec.Schema
+}
+
+// searchQueryProperties returns the query parameters supported by searching the collection resources,
+// the collection resources REST is a rest.Getter, so the installer does not add the list options to the spec.
+func searchQueryProperties() []queryProperty {
+	definitions := generatedopenapi.GetOpenAPIDefinitions(func(path string) spec.Ref {
+		return spec.MustCreateRef("#/definitions/" + common.EscapeJsonPointer(path))
+	})
+
+	var properties []queryProperty
+	for name, schema := range definitions[listOptionsDefinition].Schema.Properties {
+		// only the primitive fields can be passed by the query
+		if unsupportedQueries.Has(name) || len(schema.Type) != 1 || schema.Type[0] == "object" || schema.Type[0] == "array" {
+			continue
+		}
+
+		switch name {
+		case "labelSelector":
+			schema.Description += " The search labels are documented by the `" + SearchLabelDefinition + "` definition."
+		case "orderby":
+			schema.Description += " Supported fields: " + strings.Join(OrderByFields, ", ") + "."
+		}
+		properties = append(properties, queryProperty{name, schema})
+	}
+	sort.Slice(properties, func(i, j int) bool {
+		return properties[i].name < properties[j].name
+	})
+	return properties
+}
@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"testing"
+
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+func TestSearchLabelSchema(t *testing.T) {
+	schema := SearchLabelSchema()
+	if len(schema.Enum) != len(SearchLabels) {
+		t.Fatalf("enum length = %d, want %d", len(schema.Enum), len(SearchLabels))
+	}
+
+	keys := make(map[string]bool)
+	for _, label := range SearchLabels {
+		if keys[label.Key] {
+			t.Errorf("duplicate search label %q", label.Key)
+		}
+		keys[label.Key] = true
+
+		if label.Description == "" || label.Type == "" {
+			t.Errorf("search label %q is not documented", label.Key)
+		}
+	}
+
+	if _, ok := schema.Extensions[SearchLabelsExtension]; !ok {
+		t.Errorf("missing extension %q", SearchLabelsExtension)
+	}
+}
+
+func TestPostProcessSpec(t *testing.T) {
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Paths: &spec.Paths{
+				Paths: map[string]spec.PathItem{
+					collectionResourcePath: {PathItemProps: spec.PathItemProps{Get: &spec.Operation{}}},
+				},
+			},
+		},
+	}
+
+	swagger, err := PostProcessSpec(swagger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := swagger.Definitions[SearchLabelDefinition]; !ok {
+		t.Errorf("missing definition %q", SearchLabelDefinition)
+	}
+
+	parameters := make(map[string]spec.Parameter)
+	for _, parameter := range swagger.Paths.Paths[collectionResourcePath].Get.Parameters {
+		parameters[parameter.Name] = parameter
+	}
+	for _, name := range []string{"clusters", "namespaces", "names", "orderby", "ownerUID", "since", "labelSelector", "limit", "continue"} {
+		parameter, ok := parameters[name]
+		if !ok {
+			t.Errorf("missing query parameter %q", name)
+			continue
+		}
+		if parameter.In != "query" || parameter.Description == "" {
+			t.Errorf("query parameter %q is not documented", name)
+		}
+	}
+	for name := range unsupportedQueries {
+		if _, ok := parameters[name]; ok {
+			t.Errorf("unsupported query parameter %q is added", name)
+		}
+	}
+	if _, ok := parameters["urlQuery"]; ok {
+		t.Error("unexported field is added as a query parameter")
+	}
+}
+
+func TestPostProcessSpecV3(t *testing.T) {
+	openapi := &spec3.OpenAPI{
+		Paths: &spec3.Paths{
+			Paths: map[string]*spec3.Path{
+				collectionResourcePath: {PathProps: spec3.PathProps{Get: &spec3.Operation{}}},
+			},
+		},
+	}
+
+	openapi, err := PostProcessSpecV3(openapi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := openapi.Components.Schemas[SearchLabelDefinition]; !ok {
+		t.Errorf("missing schema %q", SearchLabelDefinition)
+	}
+	if got, want := len(openapi.Paths.Paths[collectionResourcePath].Get.Parameters), len(searchQueryProperties()); got != want {
+		t.Errorf("query parameters = %d, want %d", got, want)
+	}
+
+	// other groups are not changed
+	other := &spec3.OpenAPI{Paths: &spec3.Paths{Paths: map[string]*spec3.Path{"/apis/apps/v1/deployments": {}}}}
+	if other, _ = PostProcessSpecV3(other); other.Components != nil {
+		t.Error("the spec of other groups is changed")
+	}
+}
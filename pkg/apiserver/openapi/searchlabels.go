@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+// SearchLabel documents a label that can be used in the label selector to control the search.
+type SearchLabel struct {
+	Key         string
+	Description string
+
+	// Type and Format are the OpenAPI type and format of the label value
+	Type   string
+	Format string
+
+	// Enum is the allowed values of the label, empty means any value.
+	Enum []string
+}
+
+var booleanValues = []string{"true", "false"}
+
+// OrderByFields are the fields supported by the `search.clusterpedia.io/orderby` label and the `orderby` query,
+// a field can be suffixed with `_desc` in the label or ` desc` in the query to be sorted in descending order.
+var OrderByFields = []string{"cluster", "namespace", "name", "created_at", "resource_version"}
+
+// SearchLabels are all of the search labels supported by clusterpedia, in the order of the documentation.
+var SearchLabels = []SearchLabel{
+	{
+		Key:         internal.SearchLabelClusters,
+		Description: "Member clusters to search in, supports the `=` and `in` operators.",
+		Type:        "string",
+	},
+	{
+		Key:         internal.SearchLabelNamespaces,
+		Description: "Namespaces to search in, supports the `=` and `in` operators.",
+		Type:        "string",
+	},
+	{
+		Key:         internal.SearchLabelNames,
+		Description: "Resource names to match, supports the `=` and `in` operators.",
+		Type:        "string",
+	},
+	{
+		Key:         internal.SearchLabelOrderBy,
+		Description: "Fields to sort the resources by, a field suffixed with `_desc` is sorted in descending order. The order of the fields in a label selector is not preserved, use the `orderby` query to sort by multiple fields.",
+		Type:        "string",
+		Enum:        orderByLabelValues(),
+	},
+	{
+		Key:         internal.SearchLabelOwnerUID,
+		Description: "Only the resources owned by the resource with this uid, requires exactly one cluster.",
+		Type:        "string",
+	},
+	{
+		Key:         internal.SearchLabelOwnerName,
+		Description: "Only the resources owned by the resource with this name, requires exactly one cluster. It is ignored if `owner-uid` is set.",
+		Type:        "string",
+	},
+	{
+		Key:         internal.SearchLabelOwnerGroupResource,
+		Description: "The group resource of the owner specified by `owner-name`, eg. `deployments.apps`.",
+		Type:        "string",
+	},
+	{
+		Key:         internal.SearchLabelOwnerSeniority,
+		Description: "The number of ownership levels between the owner and the resources, eg. `1` searches the pods of a deployment through its replicasets.",
+		Type:        "integer",
+		Format:      "int32",
+	},
+	{
+		Key:         internal.SearchLabelSince,
+		Description: "Only the resources created at or after this time, in RFC3339, `2006-01-02 15:04:05`, `2006-01-02` or unix timestamp(s or ms) format.",
+		Type:        "string",
+	},
+	{
+		Key:         internal.SearchLabelBefore,
+		Description: "Only the resources created before this time, in the same formats as `since`.",
+		Type:        "string",
+	},
+	{
+		Key:         internal.SearchLabelLimit,
+		Description: "The maximum number of resources to return, the `limit` query takes precedence over it.",
+		Type:        "integer",
+		Format:      "int64",
+	},
+	{
+		Key:         internal.SearchLabelOffset,
+		Description: "The number of resources to skip, the `continue` query takes precedence over it.",
+		Type:        "integer",
+		Format:      "int64",
+	},
+	{
+		Key:         internal.SearchLabelWithContinue,
+		Description: "Return the continue token for the next page when the limit is reached.",
+		Type:        "boolean",
+		Enum:        booleanValues,
+	},
+	{
+		Key:         internal.SearchLabelWithRemainingCount,
+		Description: "Return the count of the remaining resources.",
+		Type:        "boolean",
+		Enum:        booleanValues,
+	},
+	{
+		Key:         internal.SearchLabelInjectEvents,
+		Description: "Inject the events of the resources into the annotation `shadow.clusterpedia.io/events`.",
+		Type:        "boolean",
+		Enum:        booleanValues,
+	},
+	{
+		Key:         internal.SearchLabelDedup,
+		Description: "Group the identical resources replicated to multiple clusters into a single result, the clusters are set into the annotation `shadow.clusterpedia.io/dedup-clusters`.",
+		Type:        "boolean",
+		Enum:        booleanValues,
+	},
+	{
+		Key:         internal.SearchLabelCountOnly,
+		Description: "Only return the count of the matched resources as the `remainingItemCount` of an empty list, the limit and the continue are ignored.",
+		Type:        "boolean",
+		Enum:        booleanValues,
+	},
+	{
+		Key:         internal.SearchLabelForwardRequest,
+		Description: "Forward the request to the member cluster instead of searching the storage, it takes effect when the label exists and the `AllowProxyRequestToClusters` feature gate is enabled.",
+		Type:        "string",
+	},
+}
+
+// allSearchLabels returns the search labels with the custom search labels registered by the extensions
+func allSearchLabels() []SearchLabel {
+	labels := append([]SearchLabel{}, SearchLabels...)
+	for _, label := range internal.CustomSearchLabels() {
+		labels = append(labels, SearchLabel{Key: label.Key, Description: label.Description, Type: "string"})
+	}
+	return labels
+}
+
+func orderByLabelValues() []string {
+	values := make([]string, 0, len(OrderByFields)*2)
+	for _, field := range OrderByFields {
+		values = append(values, field, field+"_desc")
+	}
+	return values
+}
@@ -0,0 +1,61 @@
+package publicaccess
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type Options struct {
+	// Clusters, Resources and Namespaces are the scope of the anonymous read-only requests,
+	// the public access is disabled if they are not set.
+	Clusters   []string
+	Resources  []string
+	Namespaces []string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) enabled() bool {
+	return len(o.Clusters) != 0 || len(o.Resources) != 0 || len(o.Namespaces) != 0
+}
+
+func (o *Options) Validate() []error {
+	if o == nil || !o.enabled() {
+		return nil
+	}
+
+	var errs []error
+	if len(o.Clusters) == 0 {
+		errs = append(errs, fmt.Errorf("--public-access-clusters is required to enable the public access"))
+	}
+	if len(o.Resources) == 0 {
+		errs = append(errs, fmt.Errorf("--public-access-resources is required to enable the public access"))
+	}
+	for _, resource := range o.Resources {
+		if gr := schema.ParseGroupResource(resource); gr.Resource == "" {
+			errs = append(errs, fmt.Errorf("--public-access-resources: invalid resource %q", resource))
+		}
+	}
+	return errs
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&o.Clusters, "public-access-clusters", o.Clusters, ""+
+		"The clusters which can be read by the anonymous requests, e.g. the community clusters of a public inventory. "+
+		"The public access is read-only and is enabled with --public-access-resources, the anonymous authentication must not be disabled.")
+	fs.StringSliceVar(&o.Resources, "public-access-resources", o.Resources, ""+
+		"The resources which can be read by the anonymous requests, formatted as <resource>.<group>, e.g. pods,deployments.apps.")
+	fs.StringSliceVar(&o.Namespaces, "public-access-namespaces", o.Namespaces, ""+
+		"The namespaces which can be read by the anonymous requests, all namespaces and the cluster-scoped resources can be read if it is not set.")
+}
+
+func (o *Options) Config() *Scope {
+	if !o.enabled() {
+		return nil
+	}
+	return NewScope(o.Clusters, o.Resources, o.Namespaces)
+}
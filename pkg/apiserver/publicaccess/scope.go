@@ -0,0 +1,221 @@
+package publicaccess
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+const resourcesPrefix = "/apis/clusterpedia.io/"
+
+// publicPaths are the paths which can be read by any anonymous request,
+// the discovery of the clusterpedia api and the health checks.
+var publicPaths = sets.New(
+	"/api", "/apis", "/apis/clusterpedia.io", "/apis/clusterpedia.io/v1beta1", "/apis/clusterpedia.io/v1beta2",
+	"/version", "/healthz", "/livez", "/readyz",
+)
+
+// Scope is the scope of the anonymous read-only requests.
+//
+// The anonymous requests can only get, list and watch the resources of the clusters in the scope
+// by the resources api, and the namespaces of the objects must be in the scope if the namespaces are set.
+// The resources of all clusters must be searched with the clusters in the scope by the `clusters` query
+// or the clusters search label, and the resources of all namespaces must be searched in the same way
+// if the namespaces are set.
+type Scope struct {
+	clusters   sets.Set[string]
+	resources  sets.Set[schema.GroupResource]
+	namespaces sets.Set[string]
+}
+
+func NewScope(clusters, resources, namespaces []string) *Scope {
+	scope := &Scope{
+		clusters:   sets.New(clusters...),
+		resources:  sets.New[schema.GroupResource](),
+		namespaces: sets.New(namespaces...),
+	}
+	for _, resource := range resources {
+		scope.resources.Insert(schema.ParseGroupResource(resource))
+	}
+	return scope
+}
+
+func isAnonymous(u user.Info) bool {
+	if u == nil {
+		return false
+	}
+	if u.GetName() == user.Anonymous {
+		return true
+	}
+	for _, group := range u.GetGroups() {
+		if group == user.AllUnauthenticated {
+			return true
+		}
+	}
+	return false
+}
+
+type anonymousAuthorizer struct{}
+
+// NewAuthorizer returns the authorizer which allows the read-only anonymous requests and denies the others,
+// it has no opinion on the authenticated requests. The scope of the allowed requests is checked by WithPublicAccess.
+func NewAuthorizer() authorizer.Authorizer {
+	return anonymousAuthorizer{}
+}
+
+func (anonymousAuthorizer) Authorize(_ context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+	if !isAnonymous(attrs.GetUser()) {
+		return authorizer.DecisionNoOpinion, "", nil
+	}
+	if attrs.IsReadOnly() {
+		return authorizer.DecisionAllow, "", nil
+	}
+	return authorizer.DecisionDeny, "the public access is read-only", nil
+}
+
+// WithPublicAccess rejects the anonymous requests out of the scope with 403,
+// it must be installed after the authentication.
+func WithPublicAccess(handler http.Handler, scope *Scope, serializer runtime.NegotiatedSerializer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		u, _ := genericrequest.UserFrom(req.Context())
+		if !isAnonymous(u) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		if err := scope.allows(req); err != nil {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewForbidden(schema.GroupResource{}, "", err), serializer, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+func (s *Scope) allows(req *http.Request) error {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return fmt.Errorf("the public access is read-only")
+	}
+
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	if publicPaths.Has(path) {
+		return nil
+	}
+	if !strings.HasPrefix(path, resourcesPrefix) {
+		return fmt.Errorf("the path %q is out of the public access", req.URL.Path)
+	}
+
+	// /apis/clusterpedia.io/<version>/resources[/clusters/<cluster>]/<kube api path>
+	parts := strings.Split(strings.TrimPrefix(path, resourcesPrefix), "/")
+	if len(parts) < 3 || parts[1] != "resources" {
+		return fmt.Errorf("the path %q is out of the public access", req.URL.Path)
+	}
+	parts = parts[2:]
+
+	allClusters := true
+	if parts[0] == "clusters" {
+		if len(parts) < 3 || !s.clusters.Has(parts[1]) {
+			return fmt.Errorf("the cluster is out of the public access")
+		}
+		parts, allClusters = parts[2:], false
+	}
+	return s.allowsKubePath(parts, req.URL.Query(), allClusters)
+}
+
+// allowsKubePath checks the resource, the namespace and the searched clusters of the kube api path,
+// the discovery of the kube api is allowed.
+func (s *Scope) allowsKubePath(parts []string, query url.Values, allClusters bool) error {
+	var gv schema.GroupVersion
+	switch {
+	case parts[0] == "api" && len(parts) <= 2:
+		return nil
+	case parts[0] == "api":
+		gv, parts = schema.GroupVersion{Version: parts[1]}, parts[2:]
+	case parts[0] == "apis" && len(parts) <= 3:
+		return nil
+	case parts[0] == "apis":
+		gv, parts = schema.GroupVersion{Group: parts[1], Version: parts[2]}, parts[3:]
+	default:
+		return fmt.Errorf("the api is out of the public access")
+	}
+
+	var namespace string
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		namespace, parts = parts[1], parts[2:]
+	}
+	if len(parts) > 2 {
+		return fmt.Errorf("the subresources are out of the public access")
+	}
+
+	if gr := gv.WithResource(parts[0]).GroupResource(); !s.resources.Has(gr) {
+		return fmt.Errorf("the resource %q is out of the public access", gr)
+	}
+	if allClusters {
+		if err := s.allowsSearched(query, "clusters", internal.SearchLabelClusters, s.clusters); err != nil {
+			return err
+		}
+	}
+	if s.namespaces.Len() == 0 {
+		return nil
+	}
+	if namespace != "" {
+		if !s.namespaces.Has(namespace) {
+			return fmt.Errorf("the namespace %q is out of the public access", namespace)
+		}
+		return nil
+	}
+	return s.allowsSearched(query, "namespaces", internal.SearchLabelNamespaces, s.namespaces)
+}
+
+// allowsSearched checks that the values searched by the query or the search label are in the scope.
+func (s *Scope) allowsSearched(query url.Values, key, label string, scope sets.Set[string]) error {
+	var values []string
+	for _, value := range query[key] {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		if selector, err := labels.Parse(query.Get("labelSelector")); err == nil {
+			requirements, _ := selector.Requirements()
+			for _, requirement := range requirements {
+				if requirement.Key() != label {
+					continue
+				}
+				switch requirement.Operator() {
+				case selection.In, selection.Equals, selection.DoubleEquals:
+					values = append(values, requirement.Values().UnsortedList()...)
+				}
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		return fmt.Errorf("the %s must be searched by the %q query in the public access", key, key)
+	}
+	for _, value := range values {
+		if !scope.Has(value) {
+			return fmt.Errorf("the %s %q is out of the public access", strings.TrimSuffix(key, "s"), value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package publicaccess
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestScopeAllows(t *testing.T) {
+	scope := NewScope([]string{"community-1", "community-2"}, []string{"pods", "deployments.apps"}, []string{"public"})
+
+	tests := []struct {
+		name    string
+		method  string
+		url     string
+		allowed bool
+	}{
+		{name: "discovery", url: "/apis", allowed: true},
+		{name: "kube discovery of all clusters", url: "/apis/clusterpedia.io/v1beta1/resources/apis/apps/v1", allowed: true},
+		{name: "pods of the cluster", url: "/apis/clusterpedia.io/v1beta1/resources/clusters/community-1/api/v1/namespaces/public/pods", allowed: true},
+		{name: "pod of the cluster", url: "/apis/clusterpedia.io/v1beta1/resources/clusters/community-1/api/v1/namespaces/public/pods/web", allowed: true},
+		{
+			name:    "deployments of the searched clusters and namespaces",
+			url:     "/apis/clusterpedia.io/v1beta1/resources/apis/apps/v1/deployments?clusters=community-1,community-2&namespaces=public",
+			allowed: true,
+		},
+		{
+			name:    "deployments searched by the labels",
+			url:     "/apis/clusterpedia.io/v1beta1/resources/apis/apps/v1/deployments?labelSelector=search.clusterpedia.io/clusters%3Dcommunity-1,search.clusterpedia.io/namespaces%20in%20(public)",
+			allowed: true,
+		},
+		{name: "write", method: http.MethodDelete, url: "/apis/clusterpedia.io/v1beta1/resources/clusters/community-1/api/v1/namespaces/public/pods/web"},
+		{name: "private cluster", url: "/apis/clusterpedia.io/v1beta1/resources/clusters/private/api/v1/namespaces/public/pods"},
+		{name: "private namespace", url: "/apis/clusterpedia.io/v1beta1/resources/clusters/community-1/api/v1/namespaces/kube-system/pods"},
+		{name: "private resource", url: "/apis/clusterpedia.io/v1beta1/resources/clusters/community-1/api/v1/namespaces/public/secrets"},
+		{name: "subresource", url: "/apis/clusterpedia.io/v1beta1/resources/clusters/community-1/api/v1/namespaces/public/pods/web/log"},
+		{name: "all namespaces", url: "/apis/clusterpedia.io/v1beta1/resources/clusters/community-1/api/v1/pods"},
+		{name: "all clusters", url: "/apis/clusterpedia.io/v1beta1/resources/api/v1/pods?namespaces=public"},
+		{name: "searched private cluster", url: "/apis/clusterpedia.io/v1beta1/resources/api/v1/pods?clusters=community-1,private&namespaces=public"},
+		{name: "collection resources", url: "/apis/clusterpedia.io/v1beta1/collectionresources/workloads?clusters=community-1"},
+		{name: "other apis", url: "/apis/cluster.clusterpedia.io/v1alpha2/pediaclusters"},
+		{name: "metrics", url: "/metrics"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			err := scope.allows(httptest.NewRequest(method, test.url, nil))
+			if allowed := err == nil; allowed != test.allowed {
+				t.Errorf("allows() = %v, want allowed %v", err, test.allowed)
+			}
+		})
+	}
+}
+
+func TestWithPublicAccess(t *testing.T) {
+	scheme := runtime.NewScheme()
+	metav1.AddToGroupVersion(scheme, schema.GroupVersion{Version: "v1"})
+	handler := WithPublicAccess(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), NewScope([]string{"community"}, []string{"pods"}, nil), serializer.NewCodecFactory(scheme))
+
+	anonymous := &user.DefaultInfo{Name: user.Anonymous, Groups: []string{user.AllUnauthenticated}}
+	for _, test := range []struct {
+		user user.Info
+		url  string
+		code int
+	}{
+		{user: anonymous, url: "/apis/clusterpedia.io/v1beta1/resources/clusters/community/api/v1/pods", code: http.StatusOK},
+		{user: anonymous, url: "/apis/clusterpedia.io/v1beta1/resources/clusters/private/api/v1/pods", code: http.StatusForbidden},
+		{user: &user.DefaultInfo{Name: "admin"}, url: "/apis/clusterpedia.io/v1beta1/resources/clusters/private/api/v1/pods", code: http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, test.url, nil)
+		req = req.WithContext(genericrequest.WithUser(req.Context(), test.user))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		if recorder.Code != test.code {
+			t.Errorf("%s %s: code = %d, want %d", test.user.GetName(), test.url, recorder.Code, test.code)
+		}
+	}
+}
+
+func TestAuthorizer(t *testing.T) {
+	anonymous := &user.DefaultInfo{Name: user.Anonymous, Groups: []string{user.AllUnauthenticated}}
+	for _, test := range []struct {
+		attrs    authorizer.AttributesRecord
+		decision authorizer.Decision
+	}{
+		{attrs: authorizer.AttributesRecord{User: anonymous, Verb: "list"}, decision: authorizer.DecisionAllow},
+		{attrs: authorizer.AttributesRecord{User: anonymous, Verb: "delete"}, decision: authorizer.DecisionDeny},
+		{attrs: authorizer.AttributesRecord{User: &user.DefaultInfo{Name: "admin"}, Verb: "list"}, decision: authorizer.DecisionNoOpinion},
+	} {
+		if decision, _, _ := NewAuthorizer().Authorize(context.Background(), test.attrs); decision != test.decision {
+			t.Errorf("Authorize(%s %s) = %v, want %v", test.attrs.User.GetName(), test.attrs.Verb, decision, test.decision)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package collectionresources
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/duration"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
 	genericfeatures "k8s.io/apiserver/pkg/features"
 	"k8s.io/apiserver/pkg/registry/rest"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
@@ -20,6 +22,7 @@ import (
 	internal "github.com/clusterpedia-io/api/clusterpedia"
 	"github.com/clusterpedia-io/api/clusterpedia/scheme"
 	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/listpolicy"
 	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
@@ -29,6 +32,7 @@ import (
 
 type REST struct {
 	serializer runtime.NegotiatedSerializer
+	listPolicy *listpolicy.Policy
 
 	list     *internal.CollectionResourceList
 	storages map[string]storage.CollectionResourceStorage
@@ -40,11 +44,21 @@ var _ rest.Getter = &REST{}
 var _ rest.Storage = &REST{}
 var _ rest.SingularNameProvider = &REST{}
 
-func NewREST(serializer runtime.NegotiatedSerializer, factory storage.StorageFactory) *REST {
-	crs, err := factory.GetCollectionResources(context.TODO())
+func NewREST(serializer runtime.NegotiatedSerializer, factory storage.StorageFactory, listPolicy *listpolicy.Policy) *REST {
+	list, storages, err := NewStorages(factory)
 	if err != nil {
 		klog.Fatal(err)
 	}
+	return &REST{serializer, listPolicy, list, storages}
+}
+
+// NewStorages returns the collection resources of the storage and their storages keyed by the name,
+// the resource types of the collection resources are resolved to the storage resources.
+func NewStorages(factory storage.StorageFactory) (*internal.CollectionResourceList, map[string]storage.CollectionResourceStorage, error) {
+	crs, err := factory.GetCollectionResources(context.TODO())
+	if err != nil {
+		return nil, nil, err
+	}
 
 	list := &internal.CollectionResourceList{}
 	storages := make(map[string]storage.CollectionResourceStorage, len(crs))
@@ -73,8 +87,7 @@ func NewREST(serializer runtime.NegotiatedSerializer, factory storage.StorageFac
 		storages[cr.Name] = storage
 		list.Items = append(list.Items, *cr)
 	}
-
-	return &REST{serializer, list, storages}
+	return list, storages, nil
 }
 
 func (s *REST) New() runtime.Object {
@@ -104,7 +117,11 @@ func (s *REST) List(ctx context.Context, options *metainternal.ListOptions) (run
 func (s *REST) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
 	var opts internal.ListOptions
 	query := request.RequestQueryFrom(ctx)
-	if err := scheme.ParameterCodec.DecodeParameters(query, v1beta1.SchemeGroupVersion, &opts); err != nil {
+	version := v1beta1.SchemeGroupVersion
+	if info, ok := genericrequest.RequestInfoFrom(ctx); ok && info.APIVersion != "" {
+		version.Version = info.APIVersion
+	}
+	if err := scheme.DecodeListOptions(query, version, &opts); err != nil {
 		return nil, err
 	}
 
@@ -122,6 +139,26 @@ func (s *REST) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runt
 		}
 	}
 
+	switch opts.ResourceScope {
+	case "", internal.ResourceScopeCluster, internal.ResourceScopeNamespaced:
+	default:
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported resourceScope %q, the supported values are %q and %q",
+			opts.ResourceScope, internal.ResourceScopeCluster, internal.ResourceScopeNamespaced))
+	}
+	switch opts.GroupBy {
+	case "":
+	case internal.GroupByCluster:
+		// the items of a cluster and a namespace are close to each other in the pages
+		if len(opts.OrderBy) == 0 {
+			opts.OrderBy = []internal.OrderBy{{Field: "cluster"}, {Field: "namespace"}}
+		}
+	default:
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported groupBy %q, the supported value is %q", opts.GroupBy, internal.GroupByCluster))
+	}
+
+	// the collection resource lists the resources across groups, so only the limits of the policy are applied
+	s.listPolicy.ApplyLimits(&opts)
+
 	storage, ok := s.storages[name]
 	if !ok {
 		return nil, apierrors.NewNotFound(
@@ -129,7 +166,70 @@ func (s *REST) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runt
 			name,
 		)
 	}
-	return storage.Get(ctx, &opts)
+	collection, err := storage.Get(ctx, &opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.GroupBy == internal.GroupByCluster {
+		if err := groupByCluster(collection); err != nil {
+			return nil, err
+		}
+	}
+	return collection, nil
+}
+
+// groupByCluster moves the items of the collection resource into the groups of their clusters,
+// the groups and the namespaces are in the order of their first items.
+func groupByCluster(collection *internal.CollectionResource) error {
+	clusters := make(map[string]int)
+	namespaces := make(map[[2]string]int)
+	for _, obj := range collection.Items {
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+
+		cluster := utils.ExtractClusterName(obj)
+		i, ok := clusters[cluster]
+		if !ok {
+			i = len(collection.Groups)
+			clusters[cluster] = i
+			collection.Groups = append(collection.Groups, internal.CollectionResourceGroup{Cluster: cluster})
+		}
+		group := &collection.Groups[i]
+
+		namespace := m.GetNamespace()
+		if namespace == "" {
+			group.Items = append(group.Items, obj)
+			continue
+		}
+		key := [2]string{cluster, namespace}
+		j, ok := namespaces[key]
+		if !ok {
+			j = len(group.Namespaces)
+			namespaces[key] = j
+			group.Namespaces = append(group.Namespaces, internal.CollectionResourceNamespaceGroup{Namespace: namespace})
+		}
+		group.Namespaces[j].Items = append(group.Namespaces[j].Items, obj)
+	}
+	collection.Items = nil
+	return nil
+}
+
+// items returns the items of the collection resource and its groups
+func items(collection *internal.CollectionResource) []runtime.Object {
+	if len(collection.Groups) == 0 {
+		return collection.Items
+	}
+
+	var items []runtime.Object
+	for _, group := range collection.Groups {
+		items = append(items, group.Items...)
+		for _, namespace := range group.Namespaces {
+			items = append(items, namespace.Items...)
+		}
+	}
+	return items
 }
 
 func (s *REST) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
@@ -152,7 +252,7 @@ func (s *REST) ConvertToTable(ctx context.Context, object runtime.Object, tableO
 	switch obj := object.(type) {
 	case *internal.CollectionResource:
 		var rows []metav1.TableRow
-		for _, obj := range obj.Items {
+		for _, obj := range items(obj) {
 			m, err := meta.Accessor(obj)
 			if err != nil {
 				return nil, err
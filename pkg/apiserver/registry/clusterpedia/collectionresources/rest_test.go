@@ -0,0 +1,54 @@
+package collectionresources
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+)
+
+func newObject(cluster, namespace, name string) runtime.Object {
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	utils.InjectClusterName(obj, cluster)
+	return obj
+}
+
+func TestGroupByCluster(t *testing.T) {
+	collection := &internal.CollectionResource{
+		Items: []runtime.Object{
+			newObject("cluster-1", "", "node-1"),
+			newObject("cluster-1", "default", "web"),
+			newObject("cluster-2", "default", "web"),
+			newObject("cluster-1", "kube-system", "dns"),
+			newObject("cluster-1", "default", "db"),
+		},
+	}
+	all := items(collection)
+	if err := groupByCluster(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if collection.Items != nil {
+		t.Errorf("the items should be moved into the groups, got %d items", len(collection.Items))
+	}
+	if len(collection.Groups) != 2 || collection.Groups[0].Cluster != "cluster-1" || collection.Groups[1].Cluster != "cluster-2" {
+		t.Fatalf("groups = %+v, want cluster-1 and cluster-2", collection.Groups)
+	}
+
+	group := collection.Groups[0]
+	if len(group.Items) != 1 {
+		t.Errorf("cluster-1 should have 1 cluster-scoped item, got %d", len(group.Items))
+	}
+	if len(group.Namespaces) != 2 || group.Namespaces[0].Namespace != "default" || len(group.Namespaces[0].Items) != 2 ||
+		group.Namespaces[1].Namespace != "kube-system" || len(group.Namespaces[1].Items) != 1 {
+		t.Errorf("namespaces of cluster-1 = %+v, want default with 2 items and kube-system with 1 item", group.Namespaces)
+	}
+	if got := len(items(collection)); got != len(all) {
+		t.Errorf("items() of the groups = %d, want %d", got, len(all))
+	}
+}
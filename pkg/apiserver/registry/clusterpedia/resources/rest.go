@@ -77,6 +77,7 @@ func (r *REST) Connect(ctx context.Context, prefixPath string, _ runtime.Object,
 	return http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
 		// not copy request context
 		req = req.Clone(req.Context())
+		req = req.WithContext(request.WithVersion(req.Context(), schema.GroupVersion{Group: info.APIGroup, Version: info.APIVersion}))
 
 		paths := []string{info.APIPrefix, info.APIGroup, info.APIVersion, info.Resource}
 		if prefixPath == "clusters" {
@@ -90,7 +91,7 @@ func (r *REST) Connect(ctx context.Context, prefixPath string, _ runtime.Object,
 
 			clustername := info.Parts[2]
 			paths = append(paths, "clusters", clustername)
-			req = req.WithContext(request.WithClusterName(ctx, clustername))
+			req = req.WithContext(request.WithClusterName(req.Context(), clustername))
 		}
 
 		serverPrefix := "/" + path.Join(paths...)
@@ -0,0 +1,69 @@
+package shutdown
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+)
+
+type Options struct {
+	// DelayDuration is the time in which the /readyz fails and the requests are still accepted,
+	// so that the load balancers stop sending new requests to the server before it stops listening.
+	DelayDuration time.Duration
+
+	// GracePeriod is the maximum time to drain the in-flight requests after the server stops accepting new requests,
+	// the requests that are still in flight after it are interrupted.
+	GracePeriod time.Duration
+
+	// WatchGracePeriod is the maximum time to drain the active watches, the watches are not drained if it is 0.
+	WatchGracePeriod time.Duration
+}
+
+func NewOptions() *Options {
+	return &Options{
+		GracePeriod: 60 * time.Second,
+	}
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.DelayDuration < 0 {
+		errs = append(errs, fmt.Errorf("--shutdown-delay-duration can not be negative value"))
+	}
+	if o.GracePeriod <= 0 {
+		errs = append(errs, fmt.Errorf("--shutdown-grace-period must be greater than 0"))
+	}
+	if o.WatchGracePeriod < 0 {
+		errs = append(errs, fmt.Errorf("--shutdown-watch-grace-period can not be negative value"))
+	}
+	return errs
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&o.DelayDuration, "shutdown-delay-duration", o.DelayDuration, ""+
+		"Time to delay the termination after the shutdown is initiated. During the delay, the /readyz fails "+
+		"and the new requests are still accepted, so that the load balancers can stop sending requests to the server.")
+	fs.DurationVar(&o.GracePeriod, "shutdown-grace-period", o.GracePeriod, ""+
+		"The maximum time to drain the in-flight requests, such as the long list queries, after the server stops accepting new requests. "+
+		"The requests that are still in flight after the grace period are interrupted. "+
+		"It should be less than the terminationGracePeriodSeconds of the pod minus the --shutdown-delay-duration.")
+	fs.DurationVar(&o.WatchGracePeriod, "shutdown-watch-grace-period", o.WatchGracePeriod, ""+
+		"The maximum time to drain the active watches, the watches are closed immediately if it is 0.")
+}
+
+// ApplyTo applies the shutdown delay and the watch grace period to the generic server config,
+// the grace period of the in-flight requests is applied to the generic server by Config.ApplyToServer.
+func (o *Options) ApplyTo(config *genericapiserver.Config) {
+	config.ShutdownDelayDuration = o.DelayDuration
+	config.ShutdownWatchTerminationGracePeriod = o.WatchGracePeriod
+}
+
+func (o *Options) Config() *Config {
+	return &Config{
+		DelayDuration:    o.DelayDuration,
+		GracePeriod:      o.GracePeriod,
+		WatchGracePeriod: o.WatchGracePeriod,
+	}
+}
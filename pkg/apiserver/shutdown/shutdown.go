@@ -0,0 +1,153 @@
+package shutdown
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+)
+
+var (
+	shutdownInProgress = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "apiserver",
+			Name:           "shutdown_in_progress",
+			Help:           "1 if the graceful shutdown of the apiserver is in progress, the metric can be scraped during the shutdown delay.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	shutdownInFlightRequests = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "apiserver",
+			Name:           "shutdown_inflight_requests",
+			Help:           "The number of the in-flight non long-running requests which are being drained by the graceful shutdown.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	shutdownInterruptedRequests = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "apiserver",
+			Name:           "shutdown_interrupted_requests_total",
+			Help:           "The number of the in-flight requests which were not drained within the shutdown grace period.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(shutdownInProgress, shutdownInFlightRequests, shutdownInterruptedRequests)
+}
+
+// drainCheckInterval is the interval to check whether the in-flight requests are drained
+var drainCheckInterval = 200 * time.Millisecond
+
+type Config struct {
+	DelayDuration    time.Duration
+	GracePeriod      time.Duration
+	WatchGracePeriod time.Duration
+}
+
+// ApplyToServer bounds the time to drain the in-flight requests by the grace period,
+// the generic server shuts the http server down with the timeout after it stops accepting new requests.
+func (c *Config) ApplyToServer(server *genericapiserver.GenericAPIServer) {
+	server.ShutdownTimeout = c.GracePeriod
+}
+
+// Tracker tracks the in-flight non long-running requests, and records the draining of them
+// during the graceful shutdown.
+//
+// The shutdown of the generic server is sequenced as:
+//
+//  1. the shutdown is initiated, the /readyz fails and the requests are still accepted for the DelayDuration
+//  2. the server stops accepting new requests and drains the in-flight requests within the GracePeriod,
+//     the active watches are drained within the WatchGracePeriod
+//  3. the server stops, and then the storage is shut down by the caller
+type Tracker struct {
+	config   Config
+	inFlight atomic.Int64
+}
+
+func NewTracker(config Config) *Tracker {
+	return &Tracker{config: config}
+}
+
+// InFlight returns the number of the in-flight non long-running requests
+func (t *Tracker) InFlight() int64 {
+	return t.inFlight.Load()
+}
+
+// WithInFlightTracking counts the in-flight non long-running requests,
+// the filter must be installed after the request info is resolved.
+func (t *Tracker) WithInFlightTracking(handler http.Handler, longRunning genericrequest.LongRunningRequestCheck) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if info, ok := genericrequest.RequestInfoFrom(req.Context()); ok && longRunning != nil && longRunning(req, info) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		t.inFlight.Add(1)
+		defer t.inFlight.Add(-1)
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// Run runs the server until it is stopped, and records the draining of the in-flight requests
+// after the ctx is done.
+func (t *Tracker) Run(ctx context.Context, run func(context.Context) error) error {
+	stopped := make(chan struct{})
+	defer close(stopped)
+
+	go t.recordShutdown(ctx, stopped)
+	return run(ctx)
+}
+
+func (t *Tracker) recordShutdown(ctx context.Context, stopped <-chan struct{}) {
+	select {
+	case <-ctx.Done():
+	case <-stopped:
+		return
+	}
+
+	start := time.Now()
+	shutdownInProgress.Set(1)
+	klog.InfoS("Shutdown is initiated, the server stops accepting new requests after the delay",
+		"delay", t.config.DelayDuration, "gracePeriod", t.config.GracePeriod, "inFlightRequests", t.InFlight())
+
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+
+	deadline := start.Add(t.config.DelayDuration + t.config.GracePeriod)
+	for {
+		inFlight := t.InFlight()
+		shutdownInFlightRequests.Set(float64(inFlight))
+
+		elapsed := time.Since(start)
+		if inFlight == 0 && elapsed >= t.config.DelayDuration {
+			klog.InfoS("The in-flight requests are drained", "duration", elapsed)
+			return
+		}
+		if !time.Now().Before(deadline) {
+			shutdownInterruptedRequests.Add(float64(inFlight))
+			klog.InfoS("The shutdown grace period is expired, the in-flight requests are interrupted",
+				"gracePeriod", t.config.GracePeriod, "interruptedRequests", inFlight)
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stopped:
+			return
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package shutdown
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestWithInFlightTracking(t *testing.T) {
+	tracker := NewTracker(Config{})
+
+	release := make(chan struct{})
+	handler := tracker.WithInFlightTracking(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	}), func(req *http.Request, info *genericrequest.RequestInfo) bool {
+		return info.Verb == "watch"
+	})
+
+	serve := func(verb string) chan struct{} {
+		done := make(chan struct{})
+		req := httptest.NewRequest(http.MethodGet, "/apis/clusterpedia.io/v1beta1/resources/apis/apps/v1/deployments", nil)
+		req = req.WithContext(genericrequest.WithRequestInfo(req.Context(), &genericrequest.RequestInfo{IsResourceRequest: true, Verb: verb}))
+		go func() {
+			defer close(done)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}()
+		return done
+	}
+
+	list, watch := serve("list"), serve("watch")
+	if err := waitFor(func() bool { return tracker.InFlight() == 1 }); err != nil {
+		t.Fatalf("in-flight requests = %d, want 1, the watch must not be tracked", tracker.InFlight())
+	}
+
+	close(release)
+	<-list
+	<-watch
+	if tracker.InFlight() != 0 {
+		t.Errorf("in-flight requests = %d, want 0", tracker.InFlight())
+	}
+}
+
+func TestRecordShutdown(t *testing.T) {
+	drainCheckInterval = 10 * time.Millisecond
+
+	tests := []struct {
+		name            string
+		requestDuration time.Duration
+		wantInterrupted float64
+	}{
+		{name: "drained", requestDuration: 20 * time.Millisecond, wantInterrupted: 0},
+		{name: "interrupted", requestDuration: time.Second, wantInterrupted: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shutdownInterruptedRequests.Reset()
+			tracker := NewTracker(Config{DelayDuration: 10 * time.Millisecond, GracePeriod: 100 * time.Millisecond})
+			tracker.inFlight.Add(1)
+			time.AfterFunc(test.requestDuration, func() { tracker.inFlight.Add(-1) })
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			recorded := make(chan struct{})
+			go func() {
+				defer close(recorded)
+				tracker.recordShutdown(ctx, make(chan struct{}))
+			}()
+
+			select {
+			case <-recorded:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the shutdown is not recorded")
+			}
+
+			interrupted, err := testutil.GetCounterMetricValue(shutdownInterruptedRequests)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if interrupted != test.wantInterrupted {
+				t.Errorf("interrupted requests = %v, want %v", interrupted, test.wantInterrupted)
+			}
+		})
+	}
+}
+
+func waitFor(condition func() bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for !condition() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return nil
+}
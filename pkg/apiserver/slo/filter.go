@@ -0,0 +1,177 @@
+package slo
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/warning"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const (
+	// groupPrefix is followed by the version and the `resources/` or `collectionresources/` of the search requests
+	groupPrefix = "/apis/" + internal.GroupName + "/"
+
+	// retryAfterSeconds is the Retry-After of the rejected queries
+	retryAfterSeconds = 10
+)
+
+// WithQueryDegradation tracks the latencies of the queries by their class,
+// and degrades the expensive features of the queries when the storage is overloaded,
+// so that the ordinary queries can still be served instead of all queries timing out.
+//
+// * the remaining item count is not returned, the clients that explicitly request it are warned
+// * the fuzzy searches and the deep paginations are rejected with 429
+//
+// The clients are notified of the degradation by the Warning headers.
+// The filter must be installed after filters.WithRequestQuery.
+func WithQueryDegradation(handler http.Handler, tracker *Tracker, serializer runtime.NegotiatedSerializer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !isSearchRequest(req) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		query := request.RequestQueryFrom(req.Context())
+		if query == nil {
+			query = req.URL.Query()
+		}
+		search := parseSearch(query, tracker.config.DeepPageOffset)
+
+		for _, class := range []QueryClass{QueryClassFuzzy, QueryClassDeepPage} {
+			if search.has(class) && tracker.Degraded(class) {
+				warning.AddWarning(req.Context(), "", fmt.Sprintf("the storage is overloaded, %s queries are temporarily disabled", class))
+
+				err := apierrors.NewTooManyRequests(fmt.Sprintf("%s queries are temporarily disabled due to the storage overload, please retry later", class), retryAfterSeconds)
+				responsewriters.ErrorNegotiated(err, serializer, schema.GroupVersion{}, w, req)
+				return
+			}
+		}
+
+		class := search.class()
+		switch {
+		case class == QueryClassCount && tracker.Degraded(QueryClassCount):
+			warning.AddWarning(req.Context(), "", "the storage is overloaded, the remaining item count is temporarily disabled")
+			disableRemainingCount(req, query)
+			class = QueryClassOrdinary
+		case search.defaultCount && tracker.Overloaded():
+			disableRemainingCount(req, query)
+		}
+
+		start := time.Now()
+		handler.ServeHTTP(w, req)
+		tracker.Observe(class, time.Since(start))
+	})
+}
+
+func isSearchRequest(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+	if watch, _ := strconv.ParseBool(req.URL.Query().Get("watch")); watch {
+		return false
+	}
+
+	path, ok := strings.CutPrefix(req.URL.Path, groupPrefix)
+	if !ok {
+		return false
+	}
+	// the searches are served by all versions of the clusterpedia api
+	if _, path, ok = strings.Cut(path, "/"); !ok {
+		return false
+	}
+	return strings.HasPrefix(path, "resources/") || strings.HasPrefix(path, "collectionresources/")
+}
+
+// disableRemainingCount sets the withRemainingCount query,
+// which takes precedence over the search label.
+func disableRemainingCount(req *http.Request, query url.Values) {
+	query.Set("withRemainingCount", "false")
+	req.URL.RawQuery = query.Encode()
+}
+
+type search struct {
+	// classes are sorted from the most to the least expensive
+	classes []QueryClass
+
+	// defaultCount is true if the remaining item count is not specified by the client,
+	// it may be enabled by default with the RemainingItemCount feature gate.
+	defaultCount bool
+}
+
+// class returns the most expensive class of the search
+func (s search) class() QueryClass {
+	if len(s.classes) == 0 {
+		return QueryClassOrdinary
+	}
+	return s.classes[0]
+}
+
+func (s search) has(class QueryClass) bool {
+	for _, c := range s.classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func parseSearch(query url.Values, deepPageOffset int64) search {
+	var (
+		s              search
+		offset         = first(query["continue"])
+		remainingCount = first(query["withRemainingCount"])
+		fuzzy          bool
+	)
+
+	if selector, err := labels.Parse(first(query["labelSelector"])); err == nil {
+		requirements, _ := selector.Requirements()
+		for _, requirement := range requirements {
+			values := requirement.Values().List()
+			switch requirement.Key() {
+			case internal.SearchLabelFuzzyName:
+				fuzzy = true
+			case internal.SearchLabelOffset:
+				if offset == "" && len(values) != 0 {
+					offset = values[0]
+				}
+			case internal.SearchLabelWithRemainingCount:
+				if remainingCount == "" && len(values) != 0 {
+					remainingCount = values[0]
+				}
+			}
+		}
+	}
+
+	if fuzzy {
+		s.classes = append(s.classes, QueryClassFuzzy)
+	}
+	if offset, err := strconv.ParseInt(offset, 10, 64); err == nil && offset >= deepPageOffset {
+		s.classes = append(s.classes, QueryClassDeepPage)
+	}
+
+	if remainingCount == "" {
+		s.defaultCount = true
+	} else if count, err := strconv.ParseBool(remainingCount); err == nil && count {
+		s.classes = append(s.classes, QueryClassCount)
+	}
+	return s
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
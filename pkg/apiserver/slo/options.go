@@ -0,0 +1,72 @@
+package slo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type Options struct {
+	EnableQueryDegradation bool
+
+	// LatencySLO is the target of the 90th percentile latency of a query class
+	LatencySLO time.Duration
+
+	// Window is the period in which the latencies are tracked,
+	// a degraded query class is probed again after the window.
+	Window time.Duration
+
+	// DeepPageOffset is the offset from which a list request is classified as deep pagination
+	DeepPageOffset int64
+}
+
+func NewOptions() *Options {
+	return &Options{
+		LatencySLO:     2 * time.Second,
+		Window:         time.Minute,
+		DeepPageOffset: 10000,
+	}
+}
+
+func (o *Options) Validate() []error {
+	if o == nil || !o.EnableQueryDegradation {
+		return nil
+	}
+
+	var errs []error
+	if o.LatencySLO <= 0 {
+		errs = append(errs, fmt.Errorf("--query-latency-slo must be greater than 0"))
+	}
+	if o.Window <= 0 {
+		errs = append(errs, fmt.Errorf("--query-latency-window must be greater than 0"))
+	}
+	if o.DeepPageOffset <= 0 {
+		errs = append(errs, fmt.Errorf("--query-deep-page-offset must be greater than 0"))
+	}
+	return errs
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.EnableQueryDegradation, "enable-query-degradation", o.EnableQueryDegradation, ""+
+		"Track the latency of the query classes, and degrade the expensive queries when the storage is overloaded. "+
+		"The remaining item count is disabled, the fuzzy searches and deep paginations are rejected with 429, "+
+		"and the clients are notified with Warning headers.")
+	fs.DurationVar(&o.LatencySLO, "query-latency-slo", o.LatencySLO, ""+
+		"The target of the 90th percentile latency of the queries, the storage is overloaded when the latency of the ordinary queries exceeds it.")
+	fs.DurationVar(&o.Window, "query-latency-window", o.Window, ""+
+		"The period in which the latencies of the queries are tracked.")
+	fs.Int64Var(&o.DeepPageOffset, "query-deep-page-offset", o.DeepPageOffset, ""+
+		"The offset from which a list request is classified as deep pagination.")
+}
+
+func (o *Options) Config() *Config {
+	if !o.EnableQueryDegradation {
+		return nil
+	}
+	return &Config{
+		LatencySLO:     o.LatencySLO,
+		Window:         o.Window,
+		DeepPageOffset: o.DeepPageOffset,
+	}
+}
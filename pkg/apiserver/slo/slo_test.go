@@ -0,0 +1,159 @@
+package slo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/filters"
+)
+
+func newTestTracker() (*Tracker, *time.Time) {
+	now := time.Now()
+	tracker := NewTracker(Config{LatencySLO: time.Second, Window: time.Minute, DeepPageOffset: 1000})
+	tracker.now = func() time.Time { return now }
+	return tracker, &now
+}
+
+func observe(tracker *Tracker, class QueryClass, latency time.Duration) {
+	for i := 0; i < minSamples; i++ {
+		tracker.Observe(class, latency)
+	}
+}
+
+func TestTracker(t *testing.T) {
+	tracker, now := newTestTracker()
+
+	tracker.Observe(QueryClassFuzzy, 2*time.Second)
+	if tracker.Degraded(QueryClassFuzzy) {
+		t.Error("the class should not be degraded without enough samples")
+	}
+
+	observe(tracker, QueryClassFuzzy, 2*time.Second)
+	if !tracker.Degraded(QueryClassFuzzy) {
+		t.Error("the class exceeding the SLO should be degraded")
+	}
+	if tracker.Degraded(QueryClassCount) || tracker.Overloaded() {
+		t.Error("the other classes should not be degraded")
+	}
+
+	observe(tracker, QueryClassOrdinary, 2*time.Second)
+	if !tracker.Overloaded() || !tracker.Degraded(QueryClassCount) {
+		t.Error("all classes should be degraded when the storage is overloaded")
+	}
+	if tracker.Degraded(QueryClassOrdinary) {
+		t.Error("the ordinary queries should never be degraded")
+	}
+
+	*now = now.Add(2 * time.Minute)
+	if tracker.Overloaded() || tracker.Degraded(QueryClassFuzzy) {
+		t.Error("the classes should recover after the samples expire")
+	}
+}
+
+func TestWithQueryDegradation(t *testing.T) {
+	tracker, _ := newTestTracker()
+	observe(tracker, QueryClassOrdinary, 2*time.Second)
+
+	var served *http.Request
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		served = req
+	})
+	scheme := runtime.NewScheme()
+	metav1.AddToGroupVersion(scheme, schema.GroupVersion{Version: "v1"})
+	codecs := serializer.NewCodecFactory(scheme)
+	filter := filters.WithRequestQuery(WithQueryDegradation(handler, tracker, codecs))
+
+	tests := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedQuery  string
+	}{
+		{
+			name:           "get",
+			url:            "/apis/clusterpedia.io/v1beta1/resources/clusters/cluster-1/api/v1/namespaces/default/pods/pod-1?withRemainingCount=false",
+			expectedStatus: http.StatusOK,
+			expectedQuery:  "withRemainingCount=false",
+		},
+		{
+			name:           "default count",
+			url:            "/apis/clusterpedia.io/v1beta1/resources/api/v1/pods",
+			expectedStatus: http.StatusOK,
+			expectedQuery:  "withRemainingCount=false",
+		},
+		{
+			name:           "explicit count",
+			url:            "/apis/clusterpedia.io/v1beta1/resources/api/v1/pods?labelSelector=search.clusterpedia.io/with-remaining-count%3Dtrue",
+			expectedStatus: http.StatusOK,
+			expectedQuery:  "labelSelector=search.clusterpedia.io%2Fwith-remaining-count%3Dtrue&withRemainingCount=false",
+		},
+		{
+			name:           "fuzzy",
+			url:            "/apis/clusterpedia.io/v1beta1/resources/api/v1/pods?labelSelector=internalstorage.clusterpedia.io/fuzzy-name%3Dnginx&withRemainingCount=false",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "deep page",
+			url:            "/apis/clusterpedia.io/v1beta1/collectionresources/workloads?continue=5000&withRemainingCount=false",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "shallow page",
+			url:            "/apis/clusterpedia.io/v1beta1/collectionresources/workloads?continue=500&withRemainingCount=false",
+			expectedStatus: http.StatusOK,
+			expectedQuery:  "continue=500&withRemainingCount=false",
+		},
+		{
+			name:           "other version",
+			url:            "/apis/clusterpedia.io/v1beta2/resources/api/v1/pods?labelSelector=internalstorage.clusterpedia.io/fuzzy-name%3Dnginx",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "other version collection",
+			url:            "/apis/clusterpedia.io/v1beta2/collectionresources/workloads?continue=5000",
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:           "other group",
+			url:            "/apis/apps/v1/resources/deployments?labelSelector=internalstorage.clusterpedia.io/fuzzy-name%3Dnginx",
+			expectedStatus: http.StatusOK,
+			expectedQuery:  "labelSelector=internalstorage.clusterpedia.io/fuzzy-name%3Dnginx",
+		},
+		{
+			name:           "not search",
+			url:            "/apis/clusterpedia.io/v1beta1/collectionresources?labelSelector=internalstorage.clusterpedia.io/fuzzy-name%3Dnginx",
+			expectedStatus: http.StatusOK,
+			expectedQuery:  "labelSelector=internalstorage.clusterpedia.io/fuzzy-name%3Dnginx",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			served = nil
+			w := httptest.NewRecorder()
+			filter.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.url, nil))
+
+			if w.Code != test.expectedStatus {
+				t.Fatalf("status = %d, want %d", w.Code, test.expectedStatus)
+			}
+			if test.expectedStatus != http.StatusOK {
+				if served != nil {
+					t.Error("the degraded query should not be served")
+				}
+				if w.Header().Get("Retry-After") == "" {
+					t.Error("the rejected query should have Retry-After header")
+				}
+				return
+			}
+			if served.URL.RawQuery != test.expectedQuery {
+				t.Errorf("query = %q, want %q", served.URL.RawQuery, test.expectedQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package slo
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+type QueryClass string
+
+const (
+	// QueryClassOrdinary is the queries without the expensive features, it is never degraded,
+	// and its latency indicates whether the storage is overloaded.
+	QueryClassOrdinary QueryClass = "ordinary"
+
+	QueryClassCount    QueryClass = "count"
+	QueryClassFuzzy    QueryClass = "fuzzy"
+	QueryClassDeepPage QueryClass = "deep-page"
+)
+
+type Config struct {
+	LatencySLO     time.Duration
+	Window         time.Duration
+	DeepPageOffset int64
+}
+
+const (
+	// minSamples is the number of the samples required to evaluate the latency of a query class
+	minSamples = 10
+
+	maxSamples = 1024
+)
+
+type sample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+// window keeps the latest samples of a query class
+type window struct {
+	samples []sample
+	next    int
+}
+
+func (w *window) add(s sample) {
+	if len(w.samples) < maxSamples {
+		w.samples = append(w.samples, s)
+		return
+	}
+	w.samples[w.next] = s
+	w.next = (w.next + 1) % maxSamples
+}
+
+// percentile returns the percentile of the latencies sampled after the time,
+// false is returned if the samples are not enough.
+func (w *window) percentile(p float64, after time.Time) (time.Duration, bool) {
+	latencies := make([]time.Duration, 0, len(w.samples))
+	for _, s := range w.samples {
+		if s.at.After(after) {
+			latencies = append(latencies, s.latency)
+		}
+	}
+	if len(latencies) < minSamples {
+		return 0, false
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return latencies[int(math.Ceil(p*float64(len(latencies))))-1], true
+}
+
+// Tracker tracks the latencies of the query classes in the window.
+type Tracker struct {
+	config Config
+	now    func() time.Time
+
+	lock    sync.Mutex
+	windows map[QueryClass]*window
+}
+
+func NewTracker(config Config) *Tracker {
+	return &Tracker{
+		config:  config,
+		now:     time.Now,
+		windows: make(map[QueryClass]*window),
+	}
+}
+
+func (t *Tracker) Observe(class QueryClass, latency time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	w, ok := t.windows[class]
+	if !ok {
+		w = &window{}
+		t.windows[class] = w
+	}
+	w.add(sample{at: t.now(), latency: latency})
+}
+
+// Overloaded returns true if the latency of the ordinary queries exceeds the SLO.
+func (t *Tracker) Overloaded() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.exceeded(QueryClassOrdinary)
+}
+
+// Degraded returns true if the query class should be degraded, either the storage is overloaded
+// or the latency of the class itself exceeds the SLO. The ordinary queries are never degraded.
+//
+// A degraded class is no longer sampled, so it recovers after its samples expire from the window.
+func (t *Tracker) Degraded(class QueryClass) bool {
+	if class == QueryClassOrdinary {
+		return false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.exceeded(QueryClassOrdinary) || t.exceeded(class)
+}
+
+func (t *Tracker) exceeded(class QueryClass) bool {
+	w, ok := t.windows[class]
+	if !ok {
+		return false
+	}
+	p90, ok := w.percentile(0.9, t.now().Add(-t.config.Window))
+	return ok && p90 > t.config.LatencySLO
+}
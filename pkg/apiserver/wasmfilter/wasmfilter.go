@@ -0,0 +1,336 @@
+package wasmfilter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+)
+
+// SearchLabelFilter selects the filter function of the list request by its name
+const SearchLabelFilter = internal.SearchLabelPrefix + "filter"
+
+const (
+	DefaultTimeout     = time.Second
+	DefaultMemoryLimit = 16 << 20
+
+	pageSize       = 64 << 10
+	maxMemoryPages = 1 << 16
+)
+
+func init() {
+	if err := internal.RegisterSearchLabel(internal.CustomSearchLabel{
+		Key:         SearchLabelFilter,
+		Description: "Filter and transform the listed objects by the WASM filter function of the ClusterpediaConfiguration, e.g. 'search.clusterpedia.io/filter=without-system-pods'",
+		Parse: func(operator selection.Operator, values []string) ([]string, error) {
+			if (operator != selection.Equals && operator != selection.DoubleEquals) || len(values) != 1 {
+				return nil, errors.New("only one filter function can be specified by '='")
+			}
+			return values, nil
+		},
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// FilterName returns the name of the filter function selected by the list options
+func FilterName(options *internal.ListOptions) string {
+	if options.ExtraLabelSelector == nil {
+		return ""
+	}
+
+	requirements, _ := options.ExtraLabelSelector.Requirements()
+	for _, requirement := range requirements {
+		if requirement.Key() == SearchLabelFilter {
+			name, _ := requirement.Values().PopAny()
+			return name
+		}
+	}
+	return ""
+}
+
+// Registry holds the compiled filter functions which are replaced at runtime, a nil Registry has no functions.
+type Registry struct {
+	lock      sync.RWMutex
+	functions map[string]*function
+}
+
+// Update compiles the filter functions of the configuration, a nil configuration removes the functions.
+// The previous functions are kept if any function is invalid, and the unchanged functions are not compiled again.
+func (r *Registry) Update(config *configv1alpha1.FilterConfiguration) error {
+	r.lock.RLock()
+	previous := r.functions
+	r.lock.RUnlock()
+
+	functions := make(map[string]*function)
+	var err error
+	if config != nil {
+		for _, fn := range config.Functions {
+			if _, ok := functions[fn.Name]; ok {
+				err = fmt.Errorf("filters.functions[%s]: duplicate name", fn.Name)
+				break
+			}
+
+			var compiled *function
+			if compiled, err = compile(fn, previous[fn.Name]); err != nil {
+				err = fmt.Errorf("filters.functions[%s]: %w", fn.Name, err)
+				break
+			}
+			functions[fn.Name] = compiled
+		}
+	}
+	if err != nil {
+		for name, fn := range functions {
+			if fn != previous[name] {
+				go fn.close()
+			}
+		}
+		return err
+	}
+
+	r.lock.Lock()
+	r.functions = functions
+	r.lock.Unlock()
+
+	for name, fn := range previous {
+		if functions[name] != fn {
+			go fn.close()
+		}
+	}
+	return nil
+}
+
+func (r *Registry) function(name string) *function {
+	if r == nil {
+		return nil
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.functions[name]
+}
+
+// Filter runs the filter function over the items of the list, the dropped items are removed from the list
+// and the transformed items are replaced. The typed items are converted to the version by the convertor to be encoded
+// as the input of the function, because the internal types have no json tags.
+func (r *Registry) Filter(ctx context.Context, name string, list runtime.Object, convertor runtime.ObjectConvertor, version schema.GroupVersion) error {
+	fn := r.function(name)
+	if fn == nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("the filter function %q is not found", name))
+	}
+
+	fn.lock.RLock()
+	defer fn.lock.RUnlock()
+	if fn.closed {
+		return apierrors.NewServiceUnavailable(fmt.Sprintf("the filter function %q is being updated", name))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fn.timeout)
+	defer cancel()
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	instance, err := fn.runtime.InstantiateModule(ctx, fn.module, wazero.NewModuleConfig().WithName("").WithStartFunctions())
+	if err != nil {
+		return fn.error(ctx, err)
+	}
+	defer instance.Close(context.Background())
+
+	filtered := items[:0]
+	for _, item := range items {
+		keep, err := filterObject(ctx, instance, item, convertor, version)
+		if err != nil {
+			return fn.error(ctx, err)
+		}
+		if keep {
+			filtered = append(filtered, item)
+		}
+	}
+	return meta.SetList(list, filtered)
+}
+
+// filterObject calls the function with the JSON of the object, and replaces the object by the transformed object if it is returned
+func filterObject(ctx context.Context, instance api.Module, obj runtime.Object, convertor runtime.ObjectConvertor, version schema.GroupVersion) (bool, error) {
+	if u, ok := obj.(runtime.Unstructured); ok {
+		input, err := json.Marshal(u.UnstructuredContent())
+		if err != nil {
+			return false, err
+		}
+		output, keep, err := call(ctx, instance, input)
+		if err != nil || output == nil {
+			return keep, err
+		}
+
+		content := make(map[string]interface{})
+		if err := json.Unmarshal(output, &content); err != nil {
+			return false, fmt.Errorf("invalid transformed object: %w", err)
+		}
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		u.SetUnstructuredContent(content)
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		return true, nil
+	}
+
+	versioned, err := convertor.ConvertToVersion(obj.DeepCopyObject(), version)
+	if err != nil {
+		return false, err
+	}
+	input, err := json.Marshal(versioned)
+	if err != nil {
+		return false, err
+	}
+	output, keep, err := call(ctx, instance, input)
+	if err != nil || output == nil {
+		return keep, err
+	}
+
+	transformed := reflect.New(reflect.TypeOf(versioned).Elem()).Interface().(runtime.Object)
+	if err := json.Unmarshal(output, transformed); err != nil {
+		return false, fmt.Errorf("invalid transformed object: %w", err)
+	}
+	if reflect.TypeOf(transformed) == reflect.TypeOf(obj) {
+		reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(transformed).Elem())
+		return true, nil
+	}
+	return true, convertor.Convert(transformed, obj, nil)
+}
+
+// call writes the input into the memory allocated by the `alloc` function and calls the `filter` function,
+// it returns the output if the object is transformed.
+func call(ctx context.Context, instance api.Module, input []byte) ([]byte, bool, error) {
+	results, err := instance.ExportedFunction("alloc").Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, false, err
+	}
+	ptr := uint32(results[0])
+	if !instance.Memory().Write(ptr, input) {
+		return nil, false, fmt.Errorf("the allocated input [%d, %d) is out of the memory", ptr, uint64(ptr)+uint64(len(input)))
+	}
+
+	results, err = instance.ExportedFunction("filter").Call(ctx, uint64(ptr), uint64(len(input)))
+	if err != nil {
+		return nil, false, err
+	}
+	switch result := results[0]; result {
+	case 0:
+		return nil, false, nil
+	case 1:
+		return nil, true, nil
+	default:
+		ptr, size := uint32(result>>32), uint32(result)
+		output, ok := instance.Memory().Read(ptr, size)
+		if !ok {
+			return nil, false, fmt.Errorf("the transformed object [%d, %d) is out of the memory", ptr, uint64(ptr)+uint64(size))
+		}
+		return bytes.Clone(output), true, nil
+	}
+}
+
+type function struct {
+	name    string
+	hash    string
+	timeout time.Duration
+
+	runtime wazero.Runtime
+	module  wazero.CompiledModule
+
+	// lock is held by the running filters, so the runtime is closed after they are finished
+	lock   sync.RWMutex
+	closed bool
+}
+
+// compile compiles the module of the filter function in a runtime limited by the memory limit,
+// the previous function is reused if neither the module nor the limits are changed.
+func compile(config configv1alpha1.FilterFunction, previous *function) (*function, error) {
+	timeout := DefaultTimeout
+	if config.Timeout != nil {
+		timeout = config.Timeout.Duration
+	}
+	if timeout <= 0 {
+		return nil, errors.New("timeout must be greater than 0")
+	}
+
+	memoryLimit := int64(DefaultMemoryLimit)
+	if config.MemoryLimit != nil {
+		memoryLimit = config.MemoryLimit.Value()
+	}
+	pages := (memoryLimit + pageSize - 1) / pageSize
+	if pages <= 0 || pages > maxMemoryPages {
+		return nil, fmt.Errorf("memoryLimit must be in (0, 4Gi]")
+	}
+
+	hash := fmt.Sprintf("%x/%s/%d", sha256.Sum256(config.Module), timeout, pages)
+	if previous != nil && previous.hash == hash {
+		return previous, nil
+	}
+
+	ctx := context.Background()
+	runtimeConfig := wazero.NewRuntimeConfig().WithMemoryLimitPages(uint32(pages)).WithCloseOnContextDone(true)
+	fn := &function{name: config.Name, hash: hash, timeout: timeout, runtime: wazero.NewRuntimeWithConfig(ctx, runtimeConfig)}
+	module, err := fn.runtime.CompileModule(ctx, config.Module)
+	if err == nil {
+		err = validateModule(module)
+	}
+	if err != nil {
+		fn.runtime.Close(ctx)
+		return nil, err
+	}
+	fn.module = module
+	return fn, nil
+}
+
+// validateModule checks the module is sandboxed and exports the functions of the filter
+func validateModule(module wazero.CompiledModule) error {
+	if len(module.ImportedFunctions()) != 0 || len(module.ImportedMemories()) != 0 {
+		return errors.New("the module can't import any host function or memory")
+	}
+	if _, ok := module.ExportedMemories()["memory"]; !ok {
+		return errors.New("the module must export the `memory`")
+	}
+
+	functions := module.ExportedFunctions()
+	for name, signature := range map[string][2][]api.ValueType{
+		"alloc":  {{api.ValueTypeI32}, {api.ValueTypeI32}},
+		"filter": {{api.ValueTypeI32, api.ValueTypeI32}, {api.ValueTypeI64}},
+	} {
+		fn, ok := functions[name]
+		if !ok || !bytes.Equal(fn.ParamTypes(), signature[0]) || !bytes.Equal(fn.ResultTypes(), signature[1]) {
+			return errors.New("the module must export the `alloc(size i32) i32` and `filter(ptr i32, size i32) i64` functions")
+		}
+	}
+	return nil
+}
+
+func (fn *function) error(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return apierrors.NewTimeoutError(fmt.Sprintf("the filter function %q exceeded its timeout %s", fn.name, fn.timeout), 0)
+	}
+	return apierrors.NewInternalError(fmt.Errorf("the filter function %q failed: %w", fn.name, err))
+}
+
+func (fn *function) close() {
+	fn.lock.Lock()
+	defer fn.lock.Unlock()
+
+	fn.closed = true
+	fn.runtime.Close(context.Background())
+}
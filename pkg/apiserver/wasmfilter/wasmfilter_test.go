@@ -0,0 +1,202 @@
+package wasmfilter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	"k8s.io/kubernetes/pkg/apis/core"
+	_ "k8s.io/kubernetes/pkg/apis/core/install"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+)
+
+const transformed = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"transformed"}}`
+
+// module assembles a module which exports the memory of one page, the `alloc` function returning 1024
+// and the `filter` function of the body, the data is placed at the offset 16.
+func module(body []byte, data string) []byte {
+	section := func(id byte, content ...byte) []byte {
+		return append(append([]byte{id}, uleb(uint64(len(content)))...), content...)
+	}
+	name := func(name string) []byte {
+		return append([]byte{byte(len(name))}, name...)
+	}
+
+	bin := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	bin = append(bin, section(1, 0x02, 0x60, 0x01, 0x7f, 0x01, 0x7f, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e)...)
+	bin = append(bin, section(3, 0x02, 0x00, 0x01)...)
+	bin = append(bin, section(5, 0x01, 0x00, 0x01)...)
+
+	exports := []byte{0x03}
+	exports = append(append(exports, name("memory")...), 0x02, 0x00)
+	exports = append(append(exports, name("alloc")...), 0x00, 0x00)
+	exports = append(append(exports, name("filter")...), 0x00, 0x01)
+	bin = append(bin, section(7, exports...)...)
+
+	alloc := []byte{0x00, 0x41, 0x80, 0x08, 0x0b}
+	code := append([]byte{0x02, byte(len(alloc))}, alloc...)
+	code = append(append(code, uleb(uint64(len(body)))...), body...)
+	bin = append(bin, section(10, code...)...)
+
+	if data != "" {
+		segment := append([]byte{0x01, 0x00, 0x41, 0x10, 0x0b}, uleb(uint64(len(data)))...)
+		bin = append(bin, section(11, append(segment, data...)...)...)
+	}
+	return bin
+}
+
+func uleb(v uint64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		if v >>= 7; v != 0 {
+			b = append(b, c|0x80)
+			continue
+		}
+		return append(b, c)
+	}
+}
+
+func sleb(v int64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+var (
+	// keepShort keeps the objects whose JSON is shorter than 200 bytes
+	keepShort = []byte{0x00, 0x20, 0x01, 0x41, 0xc8, 0x01, 0x49, 0x04, 0x7e, 0x42, 0x01, 0x05, 0x42, 0x00, 0x0b, 0x0b}
+
+	// transform returns the object placed at the offset 16
+	transform = append(append([]byte{0x00, 0x42}, sleb(16<<32|int64(len(transformed)))...), 0x0b)
+
+	// spin never returns
+	spin = []byte{0x00, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x42, 0x00, 0x0b}
+)
+
+func newRegistry(t *testing.T, functions ...configv1alpha1.FilterFunction) *Registry {
+	registry := &Registry{}
+	if err := registry.Update(&configv1alpha1.FilterConfiguration{Functions: functions}); err != nil {
+		t.Fatal(err)
+	}
+	return registry
+}
+
+func configMaps(dataSizes ...int) *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	for i, size := range dataSizes {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetName(string(rune('a' + i)))
+		_ = unstructured.SetNestedField(obj.Object, string(make([]byte, size)), "data", "value")
+		list.Items = append(list.Items, obj)
+	}
+	return list
+}
+
+func TestFilterName(t *testing.T) {
+	selector, err := labels.Parse(SearchLabelFilter + "=short")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := FilterName(&internal.ListOptions{ExtraLabelSelector: selector}); name != "short" {
+		t.Errorf("FilterName() = %q, want %q", name, "short")
+	}
+	if name := FilterName(&internal.ListOptions{}); name != "" {
+		t.Errorf("FilterName() = %q, want empty", name)
+	}
+
+	label, ok := internal.LookupSearchLabel(SearchLabelFilter)
+	if !ok {
+		t.Fatal("the filter search label should be registered")
+	}
+	selector, _ = labels.Parse(SearchLabelFilter + " in (a,b)")
+	requirements, _ := selector.Requirements()
+	if _, err := label.ParseRequirement(requirements[0]); err == nil {
+		t.Error("only one filter function can be specified")
+	}
+}
+
+func TestRegistryUpdate(t *testing.T) {
+	registry := newRegistry(t, configv1alpha1.FilterFunction{Name: "short", Module: module(keepShort, "")})
+	short := registry.function("short")
+
+	for name, functions := range map[string][]configv1alpha1.FilterFunction{
+		"invalid module":   {{Name: "short", Module: []byte("wasm")}},
+		"missing exports":  {{Name: "short", Module: []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}}},
+		"duplicate names":  {{Name: "short", Module: module(keepShort, "")}, {Name: "short", Module: module(spin, "")}},
+		"negative timeout": {{Name: "short", Module: module(keepShort, ""), Timeout: &metav1.Duration{Duration: -time.Second}}},
+	} {
+		if err := registry.Update(&configv1alpha1.FilterConfiguration{Functions: functions}); err == nil {
+			t.Errorf("%s: Update() should fail", name)
+		}
+	}
+	if registry.function("short") != short {
+		t.Error("the functions should be kept after the invalid configuration")
+	}
+
+	if err := registry.Update(&configv1alpha1.FilterConfiguration{Functions: []configv1alpha1.FilterFunction{
+		{Name: "short", Module: module(keepShort, "")},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if registry.function("short") != short {
+		t.Error("the unchanged function should not be compiled again")
+	}
+
+	if err := registry.Update(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Filter(context.TODO(), "short", configMaps(1), nil, corev1.SchemeGroupVersion); !apierrors.IsBadRequest(err) {
+		t.Errorf("the removed function should not be found, got %v", err)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	registry := newRegistry(t,
+		configv1alpha1.FilterFunction{Name: "short", Module: module(keepShort, "")},
+		configv1alpha1.FilterFunction{Name: "transform", Module: module(transform, transformed)},
+		configv1alpha1.FilterFunction{Name: "spin", Module: module(spin, ""), Timeout: &metav1.Duration{Duration: 100 * time.Millisecond}},
+	)
+
+	list := configMaps(1, 500, 2)
+	if err := registry.Filter(context.TODO(), "short", list, nil, corev1.SchemeGroupVersion); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 || list.Items[0].GetName() != "a" || list.Items[1].GetName() != "c" {
+		t.Errorf("the long object should be dropped, got %v", list.Items)
+	}
+
+	// the internal objects are converted to the version to be encoded
+	typed := &core.ConfigMapList{Items: []core.ConfigMap{{ObjectMeta: metav1.ObjectMeta{Name: "a"}}}}
+	if err := registry.Filter(context.TODO(), "transform", typed, legacyscheme.Scheme, corev1.SchemeGroupVersion); err != nil {
+		t.Fatal(err)
+	}
+	if name := typed.Items[0].Name; name != "transformed" {
+		t.Errorf("the object should be transformed, got %q", name)
+	}
+
+	start := time.Now()
+	err := registry.Filter(context.TODO(), "spin", configMaps(1), nil, corev1.SchemeGroupVersion)
+	if !apierrors.IsTimeout(err) {
+		t.Errorf("the spinning function should time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("the spinning function should be stopped by the timeout, elapsed %s", elapsed)
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"path"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+)
+
+// ResourcesPath is the path prefix of the kubernetes-compatible resources api provided by clusterpedia.
+var ResourcesPath = path.Join("/apis", v1beta1.SchemeGroupVersion.String(), "resources")
+
+// ConfigFor returns a copy of the config whose requests are served by clusterpedia's resources api,
+// any kube client created with this config searches the resources of all clusters.
+func ConfigFor(config *rest.Config) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.Host += ResourcesPath
+	return config
+}
+
+// ConfigForCluster returns a copy of the config whose requests are served by clusterpedia's resources api,
+// and are scoped to the specified cluster.
+func ConfigForCluster(config *rest.Config, cluster string) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.Host += path.Join(ResourcesPath, "clusters", cluster)
+	return config
+}
@@ -0,0 +1,65 @@
+package ctrlreader
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterpediaclient "github.com/clusterpedia-io/clusterpedia/pkg/client"
+)
+
+// Search returns a list option that lists with the search options of clusterpedia,
+// the label selector of the search options is merged with the label requirements of the other list options.
+//
+// The invalid search options are returned as an error by Reader.List.
+func Search(options *clusterpediaclient.SearchOptions) client.ListOption {
+	listOptions, err := options.ListOptions()
+	if err == nil && listOptions.LabelSelector != "" {
+		_, err = labels.Parse(listOptions.LabelSelector)
+	}
+	return &searchOption{listOptions: listOptions, err: err}
+}
+
+type searchOption struct {
+	listOptions metav1.ListOptions
+	err         error
+}
+
+func (o *searchOption) ApplyToList(opts *client.ListOptions) {
+	if o.err != nil {
+		return
+	}
+
+	if selector := o.listOptions.LabelSelector; selector != "" {
+		search, _ := labels.Parse(selector)
+		if opts.LabelSelector != nil {
+			requirements, _ := opts.LabelSelector.Requirements()
+			search = search.Add(requirements...)
+		}
+		opts.LabelSelector = search
+	}
+
+	// the field selector of clusterpedia supports more syntax than fields.Selector, so it is passed by the raw options
+	if selector := o.listOptions.FieldSelector; selector != "" && opts.FieldSelector == nil {
+		if opts.Raw == nil {
+			opts.Raw = &metav1.ListOptions{}
+		}
+		opts.Raw.FieldSelector = selector
+	}
+
+	if o.listOptions.Limit != 0 {
+		opts.Limit = o.listOptions.Limit
+	}
+	if o.listOptions.Continue != "" {
+		opts.Continue = o.listOptions.Continue
+	}
+}
+
+func searchError(opts []client.ListOption) error {
+	for _, opt := range opts {
+		if search, ok := opt.(*searchOption); ok && search.err != nil {
+			return search.err
+		}
+	}
+	return nil
+}
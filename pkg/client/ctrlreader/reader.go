@@ -0,0 +1,138 @@
+// Package ctrlreader provides a controller-runtime client.Reader that reads the resources from clusterpedia,
+// so multi-cluster controllers can consume the state of the fleet without watching the member clusters themselves.
+//
+// The Reader can be used as the cache reader of a controller-runtime client:
+//
+//	reader, _ := ctrlreader.New(config, client.Options{Scheme: scheme})
+//	c, _ := client.New(config, client.Options{Scheme: scheme, Cache: &client.CacheOptions{Reader: reader}})
+package ctrlreader
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	clusterpediaclient "github.com/clusterpedia-io/clusterpedia/pkg/client"
+)
+
+// ErrMissingCluster is returned by Get when the cluster is not specified by the context,
+// clusterpedia can only get an object from a specified cluster.
+var ErrMissingCluster = errors.New("missing cluster, use WithCluster to specify the cluster of the object")
+
+type clusterKey struct{}
+
+// WithCluster returns a context that scopes the reads of the Reader to the cluster.
+func WithCluster(ctx context.Context, cluster string) context.Context {
+	return context.WithValue(ctx, clusterKey{}, cluster)
+}
+
+// ClusterFrom returns the cluster specified by WithCluster.
+func ClusterFrom(ctx context.Context) string {
+	cluster, _ := ctx.Value(clusterKey{}).(string)
+	return cluster
+}
+
+// Reader reads the resources of all clusters from clusterpedia,
+// the reads are scoped to a cluster by WithCluster or by the reader returned by ForCluster.
+//
+// The objects are annotated with their cluster, use clusterpediaclient.ClusterName to get it.
+type Reader struct {
+	config  *rest.Config
+	options client.Options
+
+	fleet client.Reader
+
+	lock     sync.Mutex
+	clusters map[string]client.Reader
+}
+
+var _ client.Reader = &Reader{}
+
+// New returns a Reader for the clusterpedia apiserver specified by the config.
+// The http client and the rest mapper of the options are shared by all of the clusters,
+// by default the rest mapper discovers the resources of all clusters from clusterpedia.
+func New(config *rest.Config, options client.Options) (*Reader, error) {
+	if config == nil {
+		return nil, errors.New("must provide non-nil rest.Config to ctrlreader.New")
+	}
+
+	var err error
+	if options.HTTPClient == nil {
+		if options.HTTPClient, err = rest.HTTPClientFor(config); err != nil {
+			return nil, err
+		}
+	}
+
+	fleetConfig := clusterpediaclient.ConfigFor(config)
+	if options.Mapper == nil {
+		if options.Mapper, err = apiutil.NewDynamicRESTMapper(fleetConfig, options.HTTPClient); err != nil {
+			return nil, err
+		}
+	}
+
+	// the reader itself is the cache, clients of the reader always read from clusterpedia
+	options.Cache = nil
+	fleet, err := client.New(fleetConfig, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{
+		config:   config,
+		options:  options,
+		fleet:    fleet,
+		clusters: make(map[string]client.Reader),
+	}, nil
+}
+
+// Get gets the object from the cluster specified by WithCluster.
+func (r *Reader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	cluster := ClusterFrom(ctx)
+	if cluster == "" {
+		return ErrMissingCluster
+	}
+
+	reader, err := r.ForCluster(cluster)
+	if err != nil {
+		return err
+	}
+	return reader.Get(ctx, key, obj, opts...)
+}
+
+// List lists the objects of all clusters, or of the cluster specified by WithCluster.
+// Use Search to pass the search options of clusterpedia.
+func (r *Reader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := searchError(opts); err != nil {
+		return err
+	}
+
+	if cluster := ClusterFrom(ctx); cluster != "" {
+		reader, err := r.ForCluster(cluster)
+		if err != nil {
+			return err
+		}
+		return reader.List(ctx, list, opts...)
+	}
+	return r.fleet.List(ctx, list, opts...)
+}
+
+// ForCluster returns a reader that only reads the resources of the cluster.
+func (r *Reader) ForCluster(cluster string) (client.Reader, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if reader, ok := r.clusters[cluster]; ok {
+		return reader, nil
+	}
+
+	reader, err := client.New(clusterpediaclient.ConfigForCluster(r.config, cluster), r.options)
+	if err != nil {
+		return nil, err
+	}
+	r.clusters[cluster] = reader
+	return reader, nil
+}
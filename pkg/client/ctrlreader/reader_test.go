@@ -0,0 +1,163 @@
+package ctrlreader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediaclient "github.com/clusterpedia-io/clusterpedia/pkg/client"
+)
+
+type recorder struct {
+	lock     sync.Mutex
+	requests []*http.Request
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	r.requests = append(r.requests, req)
+	r.lock.Unlock()
+
+	var obj interface{}
+	if strings.HasSuffix(req.URL.Path, "/pods") {
+		obj = &corev1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}}
+	} else {
+		obj = &corev1.Pod{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func (r *recorder) last() *http.Request {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.requests[len(r.requests)-1]
+}
+
+func newTestReader(t *testing.T) (*Reader, *recorder) {
+	handler := &recorder{}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+
+	reader, err := New(&rest.Config{Host: server.URL}, client.Options{Scheme: scheme.Scheme, Mapper: mapper})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reader, handler
+}
+
+func TestReaderGet(t *testing.T) {
+	reader, handler := newTestReader(t)
+
+	pod := &corev1.Pod{}
+	if err := reader.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "pod-1"}, pod); !errors.Is(err, ErrMissingCluster) {
+		t.Fatalf("Get() without cluster error = %v, want %v", err, ErrMissingCluster)
+	}
+
+	ctx := WithCluster(context.TODO(), "cluster-1")
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: "default", Name: "pod-1"}, pod); err != nil {
+		t.Fatal(err)
+	}
+	expected := "/apis/clusterpedia.io/v1beta1/resources/clusters/cluster-1/api/v1/namespaces/default/pods/pod-1"
+	if path := handler.last().URL.Path; path != expected {
+		t.Errorf("Get() path = %q, want %q", path, expected)
+	}
+}
+
+func TestReaderList(t *testing.T) {
+	reader, handler := newTestReader(t)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		opts         []client.ListOption
+		expectedPath string
+		expected     map[string]string
+	}{
+		{
+			name:         "fleet",
+			ctx:          context.TODO(),
+			opts:         []client.ListOption{client.InNamespace("default")},
+			expectedPath: "/apis/clusterpedia.io/v1beta1/resources/api/v1/namespaces/default/pods",
+		},
+		{
+			name:         "cluster",
+			ctx:          WithCluster(context.TODO(), "cluster-1"),
+			expectedPath: "/apis/clusterpedia.io/v1beta1/resources/clusters/cluster-1/api/v1/pods",
+		},
+		{
+			name: "search",
+			ctx:  context.TODO(),
+			opts: []client.ListOption{
+				client.MatchingLabels{"app": "nginx"},
+				Search(clusterpediaclient.NewSearchOptions().Clusters("cluster-1", "cluster-2").FieldSelector("status.phase=Running").Limit(10)),
+			},
+			expectedPath: "/apis/clusterpedia.io/v1beta1/resources/api/v1/pods",
+			expected: map[string]string{
+				"app":                        "nginx",
+				internal.SearchLabelClusters: "cluster-1,cluster-2",
+				internal.SearchLabelLimit:    "10",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := reader.List(test.ctx, &corev1.PodList{}, test.opts...); err != nil {
+				t.Fatal(err)
+			}
+
+			req := handler.last()
+			if req.URL.Path != test.expectedPath {
+				t.Errorf("List() path = %q, want %q", req.URL.Path, test.expectedPath)
+			}
+			if test.expected == nil {
+				return
+			}
+
+			selector, err := labels.Parse(req.URL.Query().Get("labelSelector"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			requirements, _ := selector.Requirements()
+			got := make(map[string]string, len(requirements))
+			for _, requirement := range requirements {
+				got[requirement.Key()] = strings.Join(requirement.Values().List(), ",")
+			}
+			for key, value := range test.expected {
+				if got[key] != value {
+					t.Errorf("label %q = %q, want %q", key, got[key], value)
+				}
+			}
+			if fieldSelector := req.URL.Query().Get("fieldSelector"); fieldSelector != "status.phase=Running" {
+				t.Errorf("List() fieldSelector = %q, want %q", fieldSelector, "status.phase=Running")
+			}
+		})
+	}
+}
+
+func TestReaderListInvalidSearch(t *testing.T) {
+	reader, _ := newTestReader(t)
+
+	search := Search(clusterpediaclient.NewSearchOptions().OrderBy("name", false).OrderBy("cluster", true))
+	if err := reader.List(context.TODO(), &corev1.PodList{}, search); err == nil {
+		t.Error("List() with invalid search options should return an error")
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+// ClusterName returns the member cluster of the object returned by clusterpedia.
+func ClusterName(obj metav1.Object) string {
+	return obj.GetAnnotations()[internal.ShadowAnnotationClusterName]
+}
+
+// ClusterObjectKey identifies an object in the fleet.
+type ClusterObjectKey struct {
+	Cluster   string
+	Namespace string
+	Name      string
+}
+
+func (key ClusterObjectKey) String() string {
+	if key.Namespace == "" {
+		return key.Cluster + "/" + key.Name
+	}
+	return key.Cluster + "/" + key.Namespace + "/" + key.Name
+}
+
+func KeyOf(obj metav1.Object) ClusterObjectKey {
+	return ClusterObjectKey{Cluster: ClusterName(obj), Namespace: obj.GetNamespace(), Name: obj.GetName()}
+}
+
+// GroupByCluster splits the items of the list by member cluster.
+func GroupByCluster(list runtime.Object) (map[string][]runtime.Object, error) {
+	groups := make(map[string][]runtime.Object)
+	err := meta.EachListItem(list, func(obj runtime.Object) error {
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		cluster := ClusterName(m)
+		groups[cluster] = append(groups[cluster], obj)
+		return nil
+	})
+	return groups, err
+}
+
+// Clusters returns the sorted names of the member clusters that the items of the list come from.
+func Clusters(list runtime.Object) ([]string, error) {
+	groups, err := GroupByCluster(list)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]string, 0, len(groups))
+	for cluster := range groups {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+	return clusters, nil
+}
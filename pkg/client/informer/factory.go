@@ -0,0 +1,134 @@
+// Package informer provides informers over the clusterpedia watch api,
+// the informers watch a resource of all clusters with a single watch and keep the objects per cluster.
+//
+// The dynamic client should be created by the config returned by client.ConfigFor,
+// and the resource must be watchable by the storage layer of clusterpedia.
+package informer
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	clusterpediaclient "github.com/clusterpedia-io/clusterpedia/pkg/client"
+)
+
+// SharedInformerFactory provides shared informers for the resources of all clusters.
+type SharedInformerFactory interface {
+	// ForResource returns the shared informer of the resource.
+	ForResource(gvr schema.GroupVersionResource) (Informer, error)
+
+	// Start starts the informers that are not started, it is non-blocking.
+	Start(stopCh <-chan struct{})
+
+	// WaitForCacheSync blocks until the caches of the started informers are synced.
+	WaitForCacheSync(stopCh <-chan struct{}) map[schema.GroupVersionResource]bool
+
+	// Shutdown waits for the started informers to stop.
+	Shutdown()
+}
+
+type sharedInformerFactory struct {
+	client       dynamic.Interface
+	resyncPeriod time.Duration
+	search       *clusterpediaclient.SearchOptions
+
+	lock      sync.Mutex
+	informers map[schema.GroupVersionResource]Informer
+	started   map[schema.GroupVersionResource]bool
+
+	wg           sync.WaitGroup
+	shuttingDown bool
+}
+
+func NewSharedInformerFactory(client dynamic.Interface, resyncPeriod time.Duration) SharedInformerFactory {
+	return NewFilteredSharedInformerFactory(client, resyncPeriod, nil)
+}
+
+// NewFilteredSharedInformerFactory returns a factory whose informers are filtered by the search options,
+// eg. only watching the resources of some clusters.
+func NewFilteredSharedInformerFactory(client dynamic.Interface, resyncPeriod time.Duration, search *clusterpediaclient.SearchOptions) SharedInformerFactory {
+	if search != nil {
+		search = search.Clone()
+	}
+	return &sharedInformerFactory{
+		client:       client,
+		resyncPeriod: resyncPeriod,
+		search:       search,
+		informers:    make(map[schema.GroupVersionResource]Informer),
+		started:      make(map[schema.GroupVersionResource]bool),
+	}
+}
+
+func (f *sharedInformerFactory) ForResource(gvr schema.GroupVersionResource) (Informer, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if informer, ok := f.informers[gvr]; ok {
+		return informer, nil
+	}
+
+	informer, err := NewFilteredInformer(f.client, gvr, f.resyncPeriod, f.search)
+	if err != nil {
+		return nil, err
+	}
+	f.informers[gvr] = informer
+	return informer, nil
+}
+
+func (f *sharedInformerFactory) Start(stopCh <-chan struct{}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.shuttingDown {
+		return
+	}
+
+	for gvr, informer := range f.informers {
+		if f.started[gvr] {
+			continue
+		}
+
+		klog.V(4).InfoS("Start informer", "resource", gvr)
+		f.wg.Add(1)
+		informer := informer
+		go func() {
+			defer f.wg.Done()
+			informer.Run(stopCh)
+		}()
+		f.started[gvr] = true
+	}
+}
+
+func (f *sharedInformerFactory) WaitForCacheSync(stopCh <-chan struct{}) map[schema.GroupVersionResource]bool {
+	informers := func() map[schema.GroupVersionResource]Informer {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		informers := make(map[schema.GroupVersionResource]Informer)
+		for gvr, informer := range f.informers {
+			if f.started[gvr] {
+				informers[gvr] = informer
+			}
+		}
+		return informers
+	}()
+
+	res := make(map[schema.GroupVersionResource]bool, len(informers))
+	for gvr, informer := range informers {
+		res[gvr] = cache.WaitForCacheSync(stopCh, informer.HasSynced)
+	}
+	return res
+}
+
+func (f *sharedInformerFactory) Shutdown() {
+	f.lock.Lock()
+	f.shuttingDown = true
+	f.lock.Unlock()
+
+	f.wg.Wait()
+}
@@ -0,0 +1,251 @@
+package informer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+
+	clusterpediaclient "github.com/clusterpedia-io/clusterpedia/pkg/client"
+)
+
+const (
+	// ClusterIndex indexes the objects by their cluster
+	ClusterIndex = "cluster"
+
+	// ClusterNamespaceIndex indexes the objects by their cluster and namespace
+	ClusterNamespaceIndex = "cluster-namespace"
+)
+
+// ClusterEventHandler handles the notifications of the objects in the fleet,
+// the notifications carry the cluster of the object.
+type ClusterEventHandler interface {
+	OnAdd(cluster string, obj interface{}, isInInitialList bool)
+	OnUpdate(cluster string, oldObj, newObj interface{})
+	OnDelete(cluster string, obj interface{})
+}
+
+// ClusterEventHandlerFuncs is an adaptor to let you easily specify as many or
+// as few of the notification functions as you want while still implementing ClusterEventHandler.
+type ClusterEventHandlerFuncs struct {
+	AddFunc    func(cluster string, obj interface{})
+	UpdateFunc func(cluster string, oldObj, newObj interface{})
+	DeleteFunc func(cluster string, obj interface{})
+}
+
+func (r ClusterEventHandlerFuncs) OnAdd(cluster string, obj interface{}, _ bool) {
+	if r.AddFunc != nil {
+		r.AddFunc(cluster, obj)
+	}
+}
+
+func (r ClusterEventHandlerFuncs) OnUpdate(cluster string, oldObj, newObj interface{}) {
+	if r.UpdateFunc != nil {
+		r.UpdateFunc(cluster, oldObj, newObj)
+	}
+}
+
+func (r ClusterEventHandlerFuncs) OnDelete(cluster string, obj interface{}) {
+	if r.DeleteFunc != nil {
+		r.DeleteFunc(cluster, obj)
+	}
+}
+
+// Informer watches a resource of all clusters through the clusterpedia watch api.
+type Informer interface {
+	// AddEventHandler adds a handler to the informer, the handler receives the add
+	// notifications of the objects that are already in the cache.
+	AddEventHandler(handler ClusterEventHandler)
+
+	Run(stopCh <-chan struct{})
+	HasSynced() bool
+
+	GetIndexer() cache.Indexer
+	Lister() Lister
+}
+
+// KeyFunc is the key of the objects in the cache: `<cluster>/<namespace>/<name>` or `<cluster>/<name>`.
+func KeyFunc(obj interface{}) (string, error) {
+	if key, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return key.Key, nil
+	}
+	if key, ok := obj.(cache.ExplicitKey); ok {
+		return string(key), nil
+	}
+
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return "", fmt.Errorf("object has no meta: %v", err)
+	}
+	if clusterpediaclient.ClusterName(m) == "" {
+		return "", fmt.Errorf("object %s/%s has no cluster", m.GetNamespace(), m.GetName())
+	}
+	return clusterpediaclient.KeyOf(m).String(), nil
+}
+
+// ClusterOf returns the cluster of the object, including the object in cache.DeletedFinalStateUnknown.
+func ClusterOf(obj interface{}) string {
+	if deleted, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = deleted.Obj
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return clusterpediaclient.ClusterName(m)
+}
+
+func clusterIndexFunc(obj interface{}) ([]string, error) {
+	return []string{ClusterOf(obj)}, nil
+}
+
+func clusterNamespaceIndexFunc(obj interface{}) ([]string, error) {
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	return []string{clusterpediaclient.ClusterName(m) + "/" + m.GetNamespace()}, nil
+}
+
+type informer struct {
+	resource   schema.GroupResource
+	indexer    cache.Indexer
+	controller cache.Controller
+
+	lock     sync.RWMutex
+	handlers []ClusterEventHandler
+}
+
+// NewFilteredInformer returns an informer of the resource,
+// the search options filter the objects watched from clusterpedia.
+func NewFilteredInformer(client dynamic.Interface, gvr schema.GroupVersionResource, resyncPeriod time.Duration, search *clusterpediaclient.SearchOptions) (Informer, error) {
+	var listOptions metav1.ListOptions
+	if search != nil {
+		var err error
+		if listOptions, err = search.ListOptions(); err != nil {
+			return nil, err
+		}
+	}
+
+	tweak := func(options *metav1.ListOptions) {
+		options.LabelSelector = listOptions.LabelSelector
+		options.FieldSelector = listOptions.FieldSelector
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			tweak(&options)
+			return client.Resource(gvr).List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			tweak(&options)
+			return client.Resource(gvr).Watch(context.TODO(), options)
+		},
+	}
+
+	informer := &informer{
+		resource: gvr.GroupResource(),
+		indexer: cache.NewIndexer(KeyFunc, cache.Indexers{
+			ClusterIndex:          clusterIndexFunc,
+			ClusterNamespaceIndex: clusterNamespaceIndexFunc,
+		}),
+	}
+
+	// The key of the objects must contain the cluster,
+	// so the informer is built with the queue instead of cache.SharedIndexInformer.
+	fifo := cache.NewDeltaFIFOWithOptions(cache.DeltaFIFOOptions{
+		KeyFunction:           KeyFunc,
+		KnownObjects:          informer.indexer,
+		EmitDeltaTypeReplaced: true,
+	})
+	informer.controller = cache.New(&cache.Config{
+		Queue:            fifo,
+		ListerWatcher:    lw,
+		ObjectType:       &unstructured.Unstructured{},
+		FullResyncPeriod: resyncPeriod,
+		RetryOnError:     false,
+
+		Process: func(obj interface{}, isInInitialList bool) error {
+			if deltas, ok := obj.(cache.Deltas); ok {
+				return informer.processDeltas(deltas, isInInitialList)
+			}
+			return errors.New("object given as Process argument is not Deltas")
+		},
+	})
+	return informer, nil
+}
+
+func (informer *informer) AddEventHandler(handler ClusterEventHandler) {
+	informer.lock.Lock()
+	defer informer.lock.Unlock()
+
+	informer.handlers = append(informer.handlers, handler)
+	for _, obj := range informer.indexer.List() {
+		handler.OnAdd(ClusterOf(obj), obj, false)
+	}
+}
+
+func (informer *informer) Run(stopCh <-chan struct{}) {
+	informer.controller.Run(stopCh)
+}
+
+func (informer *informer) HasSynced() bool {
+	return informer.controller.HasSynced()
+}
+
+func (informer *informer) GetIndexer() cache.Indexer {
+	return informer.indexer
+}
+
+func (informer *informer) Lister() Lister {
+	return NewLister(informer.indexer, informer.resource)
+}
+
+// processDeltas updates the indexer and notifies the handlers,
+// the handlers are called synchronously in the order they are added.
+func (informer *informer) processDeltas(deltas cache.Deltas, isInInitialList bool) error {
+	informer.lock.RLock()
+	defer informer.lock.RUnlock()
+
+	// from oldest to newest
+	for _, d := range deltas {
+		obj := d.Object
+		cluster := ClusterOf(obj)
+
+		switch d.Type {
+		case cache.Sync, cache.Replaced, cache.Added, cache.Updated:
+			if old, exists, err := informer.indexer.Get(obj); err == nil && exists {
+				if err := informer.indexer.Update(obj); err != nil {
+					return err
+				}
+				for _, handler := range informer.handlers {
+					handler.OnUpdate(cluster, old, obj)
+				}
+			} else {
+				if err := informer.indexer.Add(obj); err != nil {
+					return err
+				}
+				for _, handler := range informer.handlers {
+					handler.OnAdd(cluster, obj, isInInitialList)
+				}
+			}
+		case cache.Deleted:
+			if err := informer.indexer.Delete(obj); err != nil {
+				return err
+			}
+			for _, handler := range informer.handlers {
+				handler.OnDelete(cluster, obj)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package informer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func newPod(cluster, namespace, name string) *unstructured.Unstructured {
+	pod := &unstructured.Unstructured{}
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	pod.SetNamespace(namespace)
+	pod.SetName(name)
+	pod.SetAnnotations(map[string]string{internal.ShadowAnnotationClusterName: cluster})
+	return pod
+}
+
+type recorder struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (r *recorder) handler() ClusterEventHandler {
+	record := func(event, cluster string, obj interface{}) {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.events = append(r.events, event+" "+cluster+"/"+obj.(*unstructured.Unstructured).GetName())
+	}
+	return ClusterEventHandlerFuncs{
+		AddFunc:    func(cluster string, obj interface{}) { record("add", cluster, obj) },
+		UpdateFunc: func(cluster string, _, obj interface{}) { record("update", cluster, obj) },
+		DeleteFunc: func(cluster string, obj interface{}) { record("delete", cluster, obj) },
+	}
+}
+
+func (r *recorder) has(event string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, e := range r.events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSharedInformerFactory(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{podsGVR: "PodList"})
+	// the same pod in different clusters, which can not be stored by the object tracker of the fake client
+	client.PrependReactor("list", "pods", func(clienttesting.Action) (bool, runtime.Object, error) {
+		list := &unstructured.UnstructuredList{}
+		list.SetAPIVersion("v1")
+		list.SetKind("PodList")
+		list.Items = []unstructured.Unstructured{*newPod("cluster-1", "default", "pod-1"), *newPod("cluster-2", "default", "pod-1")}
+		return true, list, nil
+	})
+	watcher := watch.NewFake()
+	client.PrependWatchReactor("pods", clienttesting.DefaultWatchReactor(watcher, nil))
+
+	factory := NewSharedInformerFactory(client, 0)
+	informer, err := factory.ForResource(podsGVR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := &recorder{}
+	informer.AddEventHandler(events.handler())
+
+	stopCh := make(chan struct{})
+	defer factory.Shutdown()
+	defer close(stopCh)
+
+	factory.Start(stopCh)
+	for gvr, synced := range factory.WaitForCacheSync(stopCh) {
+		if !synced {
+			t.Fatalf("informer of %s is not synced", gvr)
+		}
+	}
+
+	lister := informer.Lister()
+	pods, err := lister.List(labels.Everything())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods) != 2 {
+		t.Errorf("List() = %d pods, want 2", len(pods))
+	}
+	if pods, _ := lister.Cluster("cluster-1").Namespace("default").List(nil); len(pods) != 1 {
+		t.Errorf("cluster-1 List() = %d pods, want 1", len(pods))
+	}
+	if _, err := lister.Cluster("cluster-2").Namespace("default").Get("pod-1"); err != nil {
+		t.Errorf("cluster-2 Get() error = %v", err)
+	}
+	if _, err := lister.Cluster("cluster-3").Namespace("default").Get("pod-1"); !errors.IsNotFound(err) {
+		t.Errorf("cluster-3 Get() error = %v, want not found", err)
+	}
+
+	watcher.Add(newPod("cluster-3", "default", "pod-2"))
+	watcher.Delete(newPod("cluster-1", "default", "pod-1"))
+
+	for _, event := range []string{"add cluster-1/pod-1", "add cluster-2/pod-1", "add cluster-3/pod-2", "delete cluster-1/pod-1"} {
+		if err := wait.PollUntilContextTimeout(context.TODO(), 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+			return events.has(event), nil
+		}); err != nil {
+			t.Errorf("missing event %q", event)
+		}
+	}
+	if _, err := lister.Cluster("cluster-2").Namespace("default").Get("pod-1"); err != nil {
+		t.Errorf("the pod of cluster-2 should not be deleted, Get() error = %v", err)
+	}
+}
+
+func TestKeyFunc(t *testing.T) {
+	key, err := KeyFunc(newPod("cluster-1", "default", "pod-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "cluster-1/default/pod-1" {
+		t.Errorf("KeyFunc() = %q, want %q", key, "cluster-1/default/pod-1")
+	}
+
+	if _, err := KeyFunc(newPod("", "default", "pod-1")); err == nil {
+		t.Error("KeyFunc() should return an error for the object without cluster")
+	}
+}
@@ -0,0 +1,122 @@
+package informer
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Lister lists the objects of all clusters from the cache of the informer.
+type Lister interface {
+	// List lists the objects of all clusters.
+	List(selector labels.Selector) ([]*unstructured.Unstructured, error)
+
+	// Cluster returns a lister that only lists the objects of the cluster.
+	Cluster(cluster string) ClusterLister
+}
+
+// ClusterLister lists the objects of a cluster.
+type ClusterLister interface {
+	List(selector labels.Selector) ([]*unstructured.Unstructured, error)
+
+	// Get gets the cluster-scoped object.
+	Get(name string) (*unstructured.Unstructured, error)
+
+	Namespace(namespace string) NamespaceLister
+}
+
+// NamespaceLister lists the objects of a namespace in a cluster.
+type NamespaceLister interface {
+	List(selector labels.Selector) ([]*unstructured.Unstructured, error)
+	Get(name string) (*unstructured.Unstructured, error)
+}
+
+// NewLister returns a Lister over the indexer of the informer.
+func NewLister(indexer cache.Indexer, resource schema.GroupResource) Lister {
+	return &lister{indexer: indexer, resource: resource}
+}
+
+type lister struct {
+	indexer  cache.Indexer
+	resource schema.GroupResource
+}
+
+func (l *lister) List(selector labels.Selector) (ret []*unstructured.Unstructured, err error) {
+	err = cache.ListAll(l.indexer, selector, func(obj interface{}) {
+		ret = append(ret, obj.(*unstructured.Unstructured))
+	})
+	return ret, err
+}
+
+func (l *lister) Cluster(cluster string) ClusterLister {
+	return &clusterLister{indexer: l.indexer, resource: l.resource, cluster: cluster}
+}
+
+type clusterLister struct {
+	indexer  cache.Indexer
+	resource schema.GroupResource
+	cluster  string
+}
+
+func (l *clusterLister) List(selector labels.Selector) ([]*unstructured.Unstructured, error) {
+	objs, err := l.indexer.ByIndex(ClusterIndex, l.cluster)
+	if err != nil {
+		return nil, err
+	}
+	return filter(objs, selector), nil
+}
+
+func (l *clusterLister) Get(name string) (*unstructured.Unstructured, error) {
+	return get(l.indexer, l.resource, l.cluster+"/"+name, name)
+}
+
+func (l *clusterLister) Namespace(namespace string) NamespaceLister {
+	return &namespaceLister{indexer: l.indexer, resource: l.resource, cluster: l.cluster, namespace: namespace}
+}
+
+type namespaceLister struct {
+	indexer   cache.Indexer
+	resource  schema.GroupResource
+	cluster   string
+	namespace string
+}
+
+func (l *namespaceLister) List(selector labels.Selector) ([]*unstructured.Unstructured, error) {
+	objs, err := l.indexer.ByIndex(ClusterNamespaceIndex, l.cluster+"/"+l.namespace)
+	if err != nil {
+		return nil, err
+	}
+	return filter(objs, selector), nil
+}
+
+func (l *namespaceLister) Get(name string) (*unstructured.Unstructured, error) {
+	return get(l.indexer, l.resource, l.cluster+"/"+l.namespace+"/"+name, name)
+}
+
+func get(indexer cache.Indexer, resource schema.GroupResource, key, name string) (*unstructured.Unstructured, error) {
+	obj, exists, err := indexer.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(resource, name)
+	}
+	return obj.(*unstructured.Unstructured), nil
+}
+
+func filter(objs []interface{}, selector labels.Selector) []*unstructured.Unstructured {
+	if selector == nil {
+		selector = labels.Everything()
+	}
+
+	ret := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		u := obj.(*unstructured.Unstructured)
+		if selector.Matches(labels.Set(u.GetLabels())) {
+			ret = append(ret, u)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,278 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+// SearchOptions is a typed builder for the search labels of clusterpedia,
+// it generates the `metav1.ListOptions` used by kube clients created with `ConfigFor`.
+//
+//	opts, err := client.NewSearchOptions().
+//		Clusters("cluster-1", "cluster-2").
+//		Namespaces("kube-system").
+//		OrderBy("created_at", true).
+//		Limit(10).
+//		ListOptions()
+type SearchOptions struct {
+	labelSelector string
+	fieldSelector string
+
+	orderBy      []internal.OrderBy
+	searchLabels map[string][]string
+	errs         []error
+}
+
+func NewSearchOptions() *SearchOptions {
+	return &SearchOptions{searchLabels: make(map[string][]string)}
+}
+
+// Clone returns a copy of the search options, changes to the copy do not affect the original.
+func (o *SearchOptions) Clone() *SearchOptions {
+	out := &SearchOptions{
+		labelSelector: o.labelSelector,
+		fieldSelector: o.fieldSelector,
+		orderBy:       append([]internal.OrderBy(nil), o.orderBy...),
+		searchLabels:  make(map[string][]string, len(o.searchLabels)),
+		errs:          append([]error(nil), o.errs...),
+	}
+	for key, values := range o.searchLabels {
+		out.searchLabels[key] = append([]string(nil), values...)
+	}
+	return out
+}
+
+func (o *SearchOptions) set(key string, values ...string) *SearchOptions {
+	if len(values) == 0 {
+		delete(o.searchLabels, key)
+		return o
+	}
+	o.searchLabels[key] = values
+	return o
+}
+
+// LabelSelector sets the native label selector of the resources, it is merged with the search labels.
+func (o *SearchOptions) LabelSelector(selector string) *SearchOptions {
+	o.labelSelector = selector
+	return o
+}
+
+// FieldSelector sets the field selector, clusterpedia supports the enhanced field selector syntax.
+func (o *SearchOptions) FieldSelector(selector string) *SearchOptions {
+	o.fieldSelector = selector
+	return o
+}
+
+func (o *SearchOptions) Clusters(clusters ...string) *SearchOptions {
+	return o.set(internal.SearchLabelClusters, clusters...)
+}
+
+func (o *SearchOptions) Namespaces(namespaces ...string) *SearchOptions {
+	return o.set(internal.SearchLabelNamespaces, namespaces...)
+}
+
+func (o *SearchOptions) Names(names ...string) *SearchOptions {
+	return o.set(internal.SearchLabelNames, names...)
+}
+
+// OrderBy appends a sort field, it can be called multiple times to sort by multiple fields.
+//
+// The values of a label selector are unordered, so multiple sort fields can only be passed by `Params`.
+func (o *SearchOptions) OrderBy(field string, desc bool) *SearchOptions {
+	o.orderBy = append(o.orderBy, internal.OrderBy{Field: field, Desc: desc})
+	return o
+}
+
+func (o *SearchOptions) OwnerUID(uid string, seniority int) *SearchOptions {
+	o.set(internal.SearchLabelOwnerUID, uid)
+	return o.ownerSeniority(seniority)
+}
+
+// OwnerName searches by the owner name, the group resource of the owner is optional.
+func (o *SearchOptions) OwnerName(name string, gr schema.GroupResource, seniority int) *SearchOptions {
+	o.set(internal.SearchLabelOwnerName, name)
+	if gr.Empty() {
+		o.set(internal.SearchLabelOwnerGroupResource)
+	} else {
+		o.set(internal.SearchLabelOwnerGroupResource, gr.String())
+	}
+	return o.ownerSeniority(seniority)
+}
+
+func (o *SearchOptions) ownerSeniority(seniority int) *SearchOptions {
+	if seniority == 0 {
+		return o.set(internal.SearchLabelOwnerSeniority)
+	}
+	return o.set(internal.SearchLabelOwnerSeniority, strconv.Itoa(seniority))
+}
+
+// Since limits the creation time of resources, a zero time removes the limit.
+func (o *SearchOptions) Since(t time.Time) *SearchOptions {
+	if t.IsZero() {
+		return o.set(internal.SearchLabelSince)
+	}
+	return o.set(internal.SearchLabelSince, strconv.FormatInt(t.Unix(), 10))
+}
+
+// Before limits the creation time of resources, a zero time removes the limit.
+func (o *SearchOptions) Before(t time.Time) *SearchOptions {
+	if t.IsZero() {
+		return o.set(internal.SearchLabelBefore)
+	}
+	return o.set(internal.SearchLabelBefore, strconv.FormatInt(t.Unix(), 10))
+}
+
+func (o *SearchOptions) Limit(limit int64) *SearchOptions {
+	if limit < 0 {
+		o.errs = append(o.errs, fmt.Errorf("limit can not be negative: %d", limit))
+		return o
+	}
+	if limit == 0 {
+		return o.set(internal.SearchLabelLimit)
+	}
+	return o.set(internal.SearchLabelLimit, strconv.FormatInt(limit, 10))
+}
+
+func (o *SearchOptions) Offset(offset int64) *SearchOptions {
+	if offset < 0 {
+		o.errs = append(o.errs, fmt.Errorf("offset can not be negative: %d", offset))
+		return o
+	}
+	if offset == 0 {
+		return o.set(internal.SearchLabelOffset)
+	}
+	return o.set(internal.SearchLabelOffset, strconv.FormatInt(offset, 10))
+}
+
+func (o *SearchOptions) WithContinue(enabled bool) *SearchOptions {
+	return o.set(internal.SearchLabelWithContinue, strconv.FormatBool(enabled))
+}
+
+func (o *SearchOptions) WithRemainingCount(enabled bool) *SearchOptions {
+	return o.set(internal.SearchLabelWithRemainingCount, strconv.FormatBool(enabled))
+}
+
+func (o *SearchOptions) InjectEvents(enabled bool) *SearchOptions {
+	return o.set(internal.SearchLabelInjectEvents, strconv.FormatBool(enabled))
+}
+
+func (o *SearchOptions) Dedup(enabled bool) *SearchOptions {
+	return o.set(internal.SearchLabelDedup, strconv.FormatBool(enabled))
+}
+
+func (o *SearchOptions) CountOnly(enabled bool) *SearchOptions {
+	return o.set(internal.SearchLabelCountOnly, strconv.FormatBool(enabled))
+}
+
+// SearchLabel sets a search label which is not covered by the builder,
+// such as the search labels provided by the storage layer.
+func (o *SearchOptions) SearchLabel(key string, values ...string) *SearchOptions {
+	if !strings.Contains(key, "clusterpedia.io") {
+		o.errs = append(o.errs, fmt.Errorf("search label %q must contain the 'clusterpedia.io' domain", key))
+		return o
+	}
+	return o.set(key, values...)
+}
+
+// ListOptions returns the list options with all search labels merged into the label selector.
+func (o *SearchOptions) ListOptions() (metav1.ListOptions, error) {
+	if len(o.orderBy) > 1 {
+		return metav1.ListOptions{}, errors.New("multiple sort fields can not be passed by the label selector, use Params instead")
+	}
+
+	searchLabels := o.searchLabels
+	if len(o.orderBy) == 1 {
+		field := o.orderBy[0].Field
+		if o.orderBy[0].Desc {
+			field += "_desc"
+		}
+
+		searchLabels = make(map[string][]string, len(o.searchLabels)+1)
+		for key, values := range o.searchLabels {
+			searchLabels[key] = values
+		}
+		searchLabels[internal.SearchLabelOrderBy] = []string{field}
+	}
+
+	selector, err := o.selector(searchLabels)
+	if err != nil {
+		return metav1.ListOptions{}, err
+	}
+	return metav1.ListOptions{
+		LabelSelector: selector,
+		FieldSelector: o.fieldSelector,
+	}, nil
+}
+
+// Params returns the search options as url query parameters, which can be used with a rest client.
+// Unlike `ListOptions`, the order of the sort fields is preserved.
+func (o *SearchOptions) Params() (url.Values, error) {
+	selector, err := o.selector(o.searchLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	if selector != "" {
+		params.Set("labelSelector", selector)
+	}
+	if o.fieldSelector != "" {
+		params.Set("fieldSelector", o.fieldSelector)
+	}
+	if len(o.orderBy) != 0 {
+		fields := make([]string, 0, len(o.orderBy))
+		for _, orderby := range o.orderBy {
+			if orderby.Desc {
+				fields = append(fields, orderby.Field+" desc")
+				continue
+			}
+			fields = append(fields, orderby.Field)
+		}
+		params.Set("orderby", strings.Join(fields, ","))
+	}
+	return params, nil
+}
+
+func (o *SearchOptions) selector(searchLabels map[string][]string) (string, error) {
+	if len(o.errs) != 0 {
+		return "", o.errs[0]
+	}
+
+	selector, err := labels.Parse(o.labelSelector)
+	if err != nil {
+		return "", fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	keys := make([]string, 0, len(searchLabels))
+	for key := range searchLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		values := searchLabels[key]
+		op := selection.Equals
+		if len(values) > 1 {
+			op = selection.In
+		}
+
+		requirement, err := labels.NewRequirement(key, op, values)
+		if err != nil {
+			return "", fmt.Errorf("invalid search label %q: %w", key, err)
+		}
+		selector = selector.Add(*requirement)
+	}
+	return selector.String(), nil
+}
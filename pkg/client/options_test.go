@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+)
+
+func TestSearchOptionsListOptions(t *testing.T) {
+	since := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	opts, err := NewSearchOptions().
+		LabelSelector("app=nginx").
+		Clusters("cluster-1", "cluster-2").
+		Namespaces("default").
+		OrderBy("name", false).
+		OrderBy("created_at", true).
+		OwnerName("nginx", schema.GroupResource{Group: "apps", Resource: "deployments"}, 1).
+		Since(since).
+		Limit(10).
+		WithRemainingCount(true).
+		Params()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// decode the options as the apiserver does
+	var internalOpts internal.ListOptions
+	query := map[string][]string{"labelSelector": {opts.Get("labelSelector")}, "orderby": {opts.Get("orderby")}}
+	if err := scheme.ParameterCodec.DecodeParameters(query, v1beta1.SchemeGroupVersion, &internalOpts); err != nil {
+		t.Fatalf("failed to decode list options: %v", err)
+	}
+
+	if got := internalOpts.ClusterNames; len(got) != 2 {
+		t.Errorf("expected two clusters, but got %v", got)
+	}
+	if got := internalOpts.Namespaces; len(got) != 1 || got[0] != "default" {
+		t.Errorf("expected namespaces [default], but got %v", got)
+	}
+	expectedOrderBy := []internal.OrderBy{{Field: "name"}, {Field: "created_at", Desc: true}}
+	if len(internalOpts.OrderBy) != 2 || internalOpts.OrderBy[0] != expectedOrderBy[0] || internalOpts.OrderBy[1] != expectedOrderBy[1] {
+		t.Errorf("expected orderby %v, but got %v", expectedOrderBy, internalOpts.OrderBy)
+	}
+	if internalOpts.OwnerName != "nginx" || internalOpts.OwnerGroupResource.String() != "deployments.apps" || internalOpts.OwnerSeniority != 1 {
+		t.Errorf("unexpected owner: %s %s %d", internalOpts.OwnerName, internalOpts.OwnerGroupResource, internalOpts.OwnerSeniority)
+	}
+	if internalOpts.Since == nil || !internalOpts.Since.Time.Equal(since) {
+		t.Errorf("expected since %v, but got %v", since, internalOpts.Since)
+	}
+	if internalOpts.Limit != 10 {
+		t.Errorf("expected limit 10, but got %d", internalOpts.Limit)
+	}
+	if internalOpts.WithRemainingCount == nil || !*internalOpts.WithRemainingCount {
+		t.Errorf("expected with remaining count")
+	}
+	if internalOpts.LabelSelector == nil || internalOpts.LabelSelector.String() != "app=nginx" {
+		t.Errorf("expected label selector 'app=nginx', but got %v", internalOpts.LabelSelector)
+	}
+}
+
+func TestSearchOptionsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *SearchOptions
+	}{
+		{"negative limit", NewSearchOptions().Limit(-1)},
+		{"negative offset", NewSearchOptions().Offset(-1)},
+		{"invalid label selector", NewSearchOptions().LabelSelector("a=(b")},
+		{"search label without domain", NewSearchOptions().SearchLabel("fuzzy-name", "a")},
+		{"multiple orderby in label selector", NewSearchOptions().OrderBy("name", false).OrderBy("namespace", false)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := test.options.ListOptions(); err == nil {
+				t.Errorf("expected error, but got nil")
+			}
+		})
+	}
+}
+
+func TestPagerEachListItem(t *testing.T) {
+	var total = 7
+	list := func(_ context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+		offset, _ := strconv.Atoi(opts.Continue)
+		result := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+		for i := offset; i < total && int64(i-offset) < opts.Limit; i++ {
+			obj := unstructured.Unstructured{}
+			obj.SetName(strconv.Itoa(i))
+			obj.SetAnnotations(map[string]string{internal.ShadowAnnotationClusterName: "cluster-" + strconv.Itoa(i%2)})
+			result.Items = append(result.Items, obj)
+		}
+		if next := offset + int(opts.Limit); next < total {
+			result.SetContinue(strconv.Itoa(next))
+		}
+		return result, nil
+	}
+
+	var names []string
+	all := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+	err := NewPager(list, NewSearchOptions().Clusters("cluster-0", "cluster-1"), 3).EachListItem(context.TODO(), func(obj runtime.Object) error {
+		u := obj.(*unstructured.Unstructured)
+		names = append(names, u.GetName())
+		all.Items = append(all.Items, *u)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != total {
+		t.Fatalf("expected %d items, but got %v", total, names)
+	}
+
+	clusters, err := Clusters(all)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clusters) != 2 || clusters[0] != "cluster-0" || clusters[1] != "cluster-1" {
+		t.Errorf("unexpected clusters: %v", clusters)
+	}
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const DefaultPageSize = 500
+
+// ListFunc lists one page of the resources, such as `dynamicClient.Resource(gvr).List`.
+type ListFunc func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error)
+
+// Pager iterates over the pages of the search results.
+//
+// The continue token returned by clusterpedia is stable only for the same search options,
+// the pager fixes the search options and only advances the continue token.
+// The pages are listed with `SearchOptions.ListOptions`, so at most one sort field is allowed.
+type Pager struct {
+	list     ListFunc
+	options  *SearchOptions
+	pageSize int64
+}
+
+func NewPager(list ListFunc, options *SearchOptions, pageSize int64) *Pager {
+	if options == nil {
+		options = NewSearchOptions()
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Pager{list: list, options: options, pageSize: pageSize}
+}
+
+// EachPage calls fn for every page, iteration stops when fn returns an error or no more pages remain.
+func (p *Pager) EachPage(ctx context.Context, fn func(page runtime.Object) error) error {
+	// the offset of the search labels is the continue token of clusterpedia,
+	// the pager always starts at the offset specified by the search options.
+	opts, err := p.options.Clone().Limit(0).WithContinue(true).ListOptions()
+	if err != nil {
+		return err
+	}
+	opts.Limit = p.pageSize
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		page, err := p.list(ctx, opts)
+		if err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+
+		list, err := meta.ListAccessor(page)
+		if err != nil {
+			return err
+		}
+		if list.GetContinue() == "" {
+			return nil
+		}
+		if list.GetContinue() == opts.Continue {
+			return errors.New("the continue token of the next page is not changed")
+		}
+		opts.Continue = list.GetContinue()
+	}
+}
+
+// EachListItem calls fn for every item in all pages.
+func (p *Pager) EachListItem(ctx context.Context, fn func(obj runtime.Object) error) error {
+	return p.EachPage(ctx, func(page runtime.Object) error {
+		return meta.EachListItem(page, fn)
+	})
+}
@@ -0,0 +1,51 @@
+package componentconfig
+
+import (
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Configuration is a component configuration file, which is applied to the flags of the component.
+type Configuration interface {
+	kind() string
+	flagValues() flagValues
+}
+
+var (
+	_ Configuration = &ClusterSynchroManagerConfiguration{}
+	_ Configuration = &APIServerConfiguration{}
+)
+
+func (c *ClusterSynchroManagerConfiguration) kind() string {
+	return ClusterSynchroManagerConfigurationKind
+}
+
+func (c *APIServerConfiguration) kind() string { return APIServerConfigurationKind }
+
+// Load loads the configuration file, the apiVersion and the kind must match the configuration,
+// and the unknown and the duplicate fields are rejected.
+func Load(file string, config Configuration) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	if typeMeta.APIVersion != SchemeGroupVersion.String() {
+		return fmt.Errorf("%s: unsupported apiVersion %q, the supported apiVersion is %s", file, typeMeta.APIVersion, SchemeGroupVersion)
+	}
+	if typeMeta.Kind != config.kind() {
+		return fmt.Errorf("%s: unexpected kind %q, the kind must be %s", file, typeMeta.Kind, config.kind())
+	}
+
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package componentconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `apiVersion: config.clusterpedia.io/v1alpha1
+kind: ClusterSynchroManagerConfiguration
+workerNumber: 10
+resourceSync:
+  driftCheck:
+    interval: 1h
+`,
+		},
+		{
+			name: "unknown field",
+			content: `apiVersion: config.clusterpedia.io/v1alpha1
+kind: ClusterSynchroManagerConfiguration
+workerNumbr: 10
+`,
+			wantErr: "unknown field",
+		},
+		{
+			name: "duplicate field",
+			content: `apiVersion: config.clusterpedia.io/v1alpha1
+kind: ClusterSynchroManagerConfiguration
+workerNumber: 10
+workerNumber: 20
+`,
+			wantErr: "already set",
+		},
+		{
+			name: "unsupported apiVersion",
+			content: `apiVersion: config.clusterpedia.io/v1
+kind: ClusterSynchroManagerConfiguration
+`,
+			wantErr: "unsupported apiVersion",
+		},
+		{
+			name: "unexpected kind",
+			content: `apiVersion: config.clusterpedia.io/v1alpha1
+kind: APIServerConfiguration
+`,
+			wantErr: "unexpected kind",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var config ClusterSynchroManagerConfiguration
+			err := Load(writeConfig(t, test.content), &config)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if config.WorkerNumber == nil || *config.WorkerNumber != 10 {
+					t.Errorf("workerNumber = %v, want 10", config.WorkerNumber)
+				}
+				if d := config.ResourceSync.DriftCheck.Interval; d == nil || d.Duration != time.Hour {
+					t.Errorf("driftCheck.interval = %v, want 1h", d)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyToFlags(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	workers := fs.Int("worker-number", 5, "")
+	sharding := fs.String("sharding-name", "", "")
+	interval := fs.Duration("drift-check-interval", 0, "")
+	resources := fs.StringSlice("drift-check-resources", nil, "")
+	featureGates := fs.String("feature-gates", "", "")
+	if err := fs.Parse([]string{"--sharding-name=cli"}); err != nil {
+		t.Fatal(err)
+	}
+
+	config := writeConfig(t, `apiVersion: config.clusterpedia.io/v1alpha1
+kind: ClusterSynchroManagerConfiguration
+workerNumber: 10
+shardingName: file
+resourceSync:
+  driftCheck:
+    interval: 30m
+    resources: ["deployments.apps", "pods"]
+featureGates:
+  PruneManagedFields: true
+`)
+	options := &Options{ConfigFile: config}
+	if err := options.ApplyTo(&ClusterSynchroManagerConfiguration{}, fs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *workers != 10 {
+		t.Errorf("worker-number = %d, want 10", *workers)
+	}
+	if *sharding != "cli" {
+		t.Errorf("sharding-name = %q, the flag set on the command line must take precedence", *sharding)
+	}
+	if *interval != 30*time.Minute {
+		t.Errorf("drift-check-interval = %v, want 30m", *interval)
+	}
+	if want := []string{"deployments.apps", "pods"}; !reflect.DeepEqual(*resources, want) {
+		t.Errorf("drift-check-resources = %v, want %v", *resources, want)
+	}
+	if *featureGates != "PruneManagedFields=true" {
+		t.Errorf("feature-gates = %q", *featureGates)
+	}
+}
+
+func TestApplyToUndefinedFlag(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	config := writeConfig(t, `apiVersion: config.clusterpedia.io/v1alpha1
+kind: ClusterSynchroManagerConfiguration
+workerNumber: 10
+`)
+	err := (&Options{ConfigFile: config}).ApplyTo(&ClusterSynchroManagerConfiguration{}, fs)
+	if err == nil || !strings.Contains(err.Error(), "--worker-number") {
+		t.Errorf("error = %v, want the undefined flag error", err)
+	}
+}
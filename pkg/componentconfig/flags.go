@@ -0,0 +1,230 @@
+package componentconfig
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type flagValue struct {
+	name  string
+	value string
+}
+
+// flagValues are the values of the flags set by the configuration file in order,
+// a list flag has a value for each item.
+type flagValues []flagValue
+
+func (v *flagValues) addString(name, value string) {
+	if value != "" {
+		*v = append(*v, flagValue{name: name, value: value})
+	}
+}
+
+func (v *flagValues) addBool(name string, value *bool) {
+	if value != nil {
+		*v = append(*v, flagValue{name: name, value: strconv.FormatBool(*value)})
+	}
+}
+
+func (v *flagValues) addDuration(name string, value *metav1.Duration) {
+	if value != nil {
+		*v = append(*v, flagValue{name: name, value: value.Duration.String()})
+	}
+}
+
+func (v *flagValues) addStrings(name string, values []string) {
+	for _, value := range values {
+		*v = append(*v, flagValue{name: name, value: value})
+	}
+}
+
+func (v *flagValues) addBoolMap(name string, values map[string]bool) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		*v = append(*v, flagValue{name: name, value: key + "=" + strconv.FormatBool(values[key])})
+	}
+}
+
+func addNumber[T int | int32 | int64 | float32 | float64](v *flagValues, name string, value *T) {
+	if value != nil {
+		*v = append(*v, flagValue{name: name, value: fmt.Sprint(*value)})
+	}
+}
+
+// ApplyToFlags sets the flags with the values of the configuration file,
+// the flags set on the command line are not overridden.
+func ApplyToFlags(config Configuration, fs *pflag.FlagSet) error {
+	var changed []string
+	fs.Visit(func(f *pflag.Flag) { changed = append(changed, f.Name) })
+	explicit := make(map[string]bool, len(changed))
+	for _, name := range changed {
+		explicit[name] = true
+	}
+
+	for _, v := range config.flagValues() {
+		if explicit[v.name] {
+			continue
+		}
+		if fs.Lookup(v.name) == nil {
+			return fmt.Errorf("flag --%s of the configuration is not defined", v.name)
+		}
+		if err := fs.Set(v.name, v.value); err != nil {
+			return fmt.Errorf("invalid value %q for --%s: %w", v.value, v.name, err)
+		}
+	}
+	return nil
+}
+
+func (c *ClusterSynchroManagerConfiguration) flagValues() flagValues {
+	var v flagValues
+	if cc := c.ClientConnection; cc != nil {
+		v.addString("kubeconfig", cc.Kubeconfig)
+		v.addString("master", cc.Master)
+		v.addString("kube-api-content-type", cc.ContentType)
+		addNumber(&v, "kube-api-qps", cc.QPS)
+		addNumber(&v, "kube-api-burst", cc.Burst)
+	}
+	if le := c.LeaderElection; le != nil {
+		v.addBool("leader-elect", le.LeaderElect)
+		v.addDuration("leader-elect-lease-duration", le.LeaseDuration)
+		v.addDuration("leader-elect-renew-deadline", le.RenewDeadline)
+		v.addDuration("leader-elect-retry-period", le.RetryPeriod)
+		v.addString("leader-elect-resource-lock", le.ResourceLock)
+		v.addString("leader-elect-resource-name", le.ResourceName)
+		v.addString("leader-elect-resource-namespace", le.ResourceNamespace)
+	}
+	addNumber(&v, "worker-number", c.WorkerNumber)
+	v.addString("sharding-name", c.ShardingName)
+	v.addStorage(c.Storage)
+
+	if rs := c.ResourceSync; rs != nil {
+		addNumber(&v, "page-size", rs.PageSize)
+		v.addDuration("terminated-pods-ttl", rs.TerminatedPodsTTL)
+		v.addDuration("completed-jobs-ttl", rs.CompletedJobsTTL)
+		v.addDuration("storage-timeout", rs.StorageTimeout)
+		v.addDuration("verification-relist-interval", rs.VerificationRelistInterval)
+		v.addDuration("watch-bookmark-interval", rs.WatchBookmarkInterval)
+		if md := rs.MassDeletion; md != nil {
+			addNumber(&v, "mass-deletion-threshold", md.Threshold)
+			v.addDuration("mass-deletion-window", md.Window)
+			addNumber(&v, "mass-deletion-min-deletions", md.MinDeletions)
+		}
+		if dc := rs.DriftCheck; dc != nil {
+			v.addDuration("drift-check-interval", dc.Interval)
+			v.addStrings("drift-check-resources", dc.Resources)
+			v.addBool("drift-check-repair", dc.Repair)
+		}
+	}
+	if hc := c.HealthCheck; hc != nil {
+		v.addString("health-check-strategy", hc.Strategy)
+		v.addString("health-check-probe-resource", hc.ProbeResource)
+	}
+	if t := c.Transport; t != nil {
+		v.addDuration("cluster-http2-read-idle-timeout", t.HTTP2ReadIdleTimeout)
+		v.addDuration("cluster-http2-ping-timeout", t.HTTP2PingTimeout)
+		v.addDuration("cluster-idle-conn-timeout", t.IdleConnTimeout)
+		v.addDuration("cluster-tcp-keepalive-idle", t.TCPKeepAliveIdle)
+		v.addDuration("cluster-tcp-keepalive-interval", t.TCPKeepAliveInterval)
+		addNumber(&v, "cluster-tcp-keepalive-count", t.TCPKeepAliveCount)
+		v.addDuration("cluster-watch-idle-timeout", t.WatchIdleTimeout)
+	}
+	v.addString("configuration-name", c.ConfigurationName)
+
+	if r := c.Retention; r != nil {
+		v.addDuration("retention-interval", r.Interval)
+		v.addStrings("retention-policy", r.Policies)
+	}
+	if gc := c.OrphanGC; gc != nil {
+		v.addDuration("orphan-gc-interval", gc.Interval)
+		v.addDuration("orphan-gc-grace-period", gc.GracePeriod)
+		addNumber(&v, "orphan-gc-qps", gc.QPS)
+	}
+	if cdc := c.CDC; cdc != nil {
+		v.addString("cdc-sink", cdc.Sink)
+		v.addStrings("cdc-kafka-brokers", cdc.KafkaBrokers)
+		v.addString("cdc-kafka-topic", cdc.KafkaTopic)
+		v.addString("cdc-nats-url", cdc.NATSURL)
+		v.addString("cdc-nats-subject", cdc.NATSSubject)
+		v.addStrings("cdc-metadata-only-resources", cdc.MetadataOnlyResources)
+	}
+	if b := c.Backup; b != nil {
+		v.addString("backup-endpoint", b.Endpoint)
+		v.addString("backup-region", b.Region)
+		v.addString("backup-bucket", b.Bucket)
+		v.addString("backup-prefix", b.Prefix)
+		v.addBool("backup-insecure", b.Insecure)
+		v.addDuration("backup-interval", b.Interval)
+		v.addStrings("backup-excluded-resources", b.ExcludedResources)
+	}
+	v.addBoolMap("feature-gates", c.FeatureGates)
+	return v
+}
+
+func (c *APIServerConfiguration) flagValues() flagValues {
+	var v flagValues
+	v.addString("kubeconfig", c.Kubeconfig)
+	addNumber(&v, "max-requests-inflight", c.MaxRequestsInFlight)
+	addNumber(&v, "max-mutating-requests-inflight", c.MaxMutatingRequestsInFlight)
+	if ss := c.SecureServing; ss != nil {
+		v.addString("bind-address", ss.BindAddress)
+		addNumber(&v, "secure-port", ss.SecurePort)
+		v.addString("cert-dir", ss.CertDir)
+		v.addString("tls-cert-file", ss.TLSCertFile)
+		v.addString("tls-private-key-file", ss.TLSPrivateKeyFile)
+	}
+	v.addStorage(c.Storage)
+
+	if rs := c.ResourceServer; rs != nil {
+		v.addBool("allow-forward-unsync-resource-request", rs.AllowForwardUnsyncResourceRequest)
+		v.addBool("allow-pediacluster-config-for-proxy-request", rs.AllowPediaClusterConfigForProxyRequest)
+		v.addStrings("allowed-proxy-subresources", rs.AllowedProxySubresources)
+		v.addBool("enable-proxy-path-for-forward-request", rs.EnableProxyPathForForwardRequest)
+		v.addDuration("read-your-writes-window", rs.ReadYourWritesWindow)
+	}
+	if ae := c.APIExplorer; ae != nil {
+		v.addBool("enable-api-explorer", ae.Enabled)
+		v.addString("api-explorer-assets-url", ae.AssetsURL)
+	}
+	if qd := c.QueryDegradation; qd != nil {
+		v.addBool("enable-query-degradation", qd.Enabled)
+		addNumber(&v, "query-deep-page-offset", qd.DeepPageOffset)
+		v.addDuration("query-latency-slo", qd.LatencySLO)
+		v.addDuration("query-latency-window", qd.LatencyWindow)
+	}
+	if lp := c.ListPolicy; lp != nil {
+		v.addStrings("list-cluster-required-groups", lp.ClusterRequiredGroups)
+		addNumber(&v, "list-default-limit", lp.DefaultLimit)
+		v.addString("list-default-orderby", lp.DefaultOrderBy)
+		addNumber(&v, "list-max-limit", lp.MaxLimit)
+	}
+	if pa := c.PublicAccess; pa != nil {
+		v.addStrings("public-access-clusters", pa.Clusters)
+		v.addStrings("public-access-namespaces", pa.Namespaces)
+		v.addStrings("public-access-resources", pa.Resources)
+	}
+	if sd := c.Shutdown; sd != nil {
+		v.addDuration("shutdown-delay-duration", sd.DelayDuration)
+		v.addDuration("shutdown-grace-period", sd.GracePeriod)
+		v.addDuration("shutdown-watch-grace-period", sd.WatchGracePeriod)
+	}
+	v.addString("external-metrics-config", c.ExternalMetricsConfig)
+	v.addString("federation-config", c.FederationConfig)
+	v.addString("configuration-name", c.ConfigurationName)
+	v.addBoolMap("feature-gates", c.FeatureGates)
+	return v
+}
+
+func (v *flagValues) addStorage(storage *StorageConfiguration) {
+	if storage != nil {
+		v.addString("storage-name", storage.Name)
+		v.addString("storage-config", storage.ConfigPath)
+	}
+}
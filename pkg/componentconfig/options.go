@@ -0,0 +1,36 @@
+package componentconfig
+
+import (
+	"github.com/spf13/pflag"
+)
+
+type Options struct {
+	// ConfigFile is the component configuration file applied to the flags
+	ConfigFile string
+
+	// ValidateOnly validates the flags and the configuration file and exits without running the component
+	ValidateOnly bool
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ConfigFile, "config", o.ConfigFile, ""+
+		"The versioned configuration file of the component, the fields which are not set keep the defaults of the flags, "+
+		"and the flags set on the command line take precedence over the fields.")
+	fs.BoolVar(&o.ValidateOnly, "validate-config", o.ValidateOnly, ""+
+		"Validate the flags and the configuration file, and exit without running the component.")
+}
+
+// ApplyTo loads the configuration file and applies it to the flags, nothing is applied if the file is not set.
+func (o *Options) ApplyTo(config Configuration, fs *pflag.FlagSet) error {
+	if o.ConfigFile == "" {
+		return nil
+	}
+	if err := Load(o.ConfigFile, config); err != nil {
+		return err
+	}
+	return ApplyToFlags(config, fs)
+}
@@ -0,0 +1,245 @@
+package componentconfig
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	GroupName = "config.clusterpedia.io"
+	Version   = "v1alpha1"
+
+	ClusterSynchroManagerConfigurationKind = "ClusterSynchroManagerConfiguration"
+	APIServerConfigurationKind             = "APIServerConfiguration"
+)
+
+// SchemeGroupVersion is the version of the component configuration files
+var SchemeGroupVersion = metav1.GroupVersion{Group: GroupName, Version: Version}
+
+// ClusterSynchroManagerConfiguration is the configuration file of the clustersynchro manager.
+//
+// The fields which are not set keep the defaults of the flags, and the flags set on the command line
+// take precedence over the fields. For example, the sharding and the drift checks:
+//
+//	apiVersion: config.clusterpedia.io/v1alpha1
+//	kind: ClusterSynchroManagerConfiguration
+//	shardingName: shard-a
+//	storage:
+//	  name: sharding
+//	  configPath: /etc/clusterpedia/storage/sharding.yaml
+//	resourceSync:
+//	  verificationRelistInterval: 168h
+//	  driftCheck:
+//	    interval: 24h
+//	    resources: ["deployments.apps", "pods"]
+//	    repair: true
+//	featureGates:
+//	  PruneManagedFields: true
+type ClusterSynchroManagerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	ClientConnection *ClientConnectionConfiguration `json:"clientConnection,omitempty"`
+	LeaderElection   *LeaderElectionConfiguration   `json:"leaderElection,omitempty"`
+
+	WorkerNumber *int   `json:"workerNumber,omitempty"`
+	ShardingName string `json:"shardingName,omitempty"`
+
+	Storage      *StorageConfiguration      `json:"storage,omitempty"`
+	ResourceSync *ResourceSyncConfiguration `json:"resourceSync,omitempty"`
+	HealthCheck  *HealthCheckConfiguration  `json:"healthCheck,omitempty"`
+	Transport    *TransportConfiguration    `json:"transport,omitempty"`
+
+	// ConfigurationName is the ClusterpediaConfiguration whose settings, such as the prune rules, are applied at runtime
+	ConfigurationName string `json:"configurationName,omitempty"`
+
+	Retention *RetentionConfiguration `json:"retention,omitempty"`
+	OrphanGC  *OrphanGCConfiguration  `json:"orphanGC,omitempty"`
+	CDC       *CDCConfiguration       `json:"cdc,omitempty"`
+	Backup    *BackupConfiguration    `json:"backup,omitempty"`
+
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+type ClientConnectionConfiguration struct {
+	Kubeconfig  string   `json:"kubeconfig,omitempty"`
+	Master      string   `json:"master,omitempty"`
+	ContentType string   `json:"contentType,omitempty"`
+	QPS         *float32 `json:"qps,omitempty"`
+	Burst       *int32   `json:"burst,omitempty"`
+}
+
+type LeaderElectionConfiguration struct {
+	LeaderElect       *bool            `json:"leaderElect,omitempty"`
+	LeaseDuration     *metav1.Duration `json:"leaseDuration,omitempty"`
+	RenewDeadline     *metav1.Duration `json:"renewDeadline,omitempty"`
+	RetryPeriod       *metav1.Duration `json:"retryPeriod,omitempty"`
+	ResourceLock      string           `json:"resourceLock,omitempty"`
+	ResourceName      string           `json:"resourceName,omitempty"`
+	ResourceNamespace string           `json:"resourceNamespace,omitempty"`
+}
+
+type StorageConfiguration struct {
+	Name       string `json:"name,omitempty"`
+	ConfigPath string `json:"configPath,omitempty"`
+}
+
+type ResourceSyncConfiguration struct {
+	PageSize                   *int64                     `json:"pageSize,omitempty"`
+	TerminatedPodsTTL          *metav1.Duration           `json:"terminatedPodsTTL,omitempty"`
+	CompletedJobsTTL           *metav1.Duration           `json:"completedJobsTTL,omitempty"`
+	StorageTimeout             *metav1.Duration           `json:"storageTimeout,omitempty"`
+	VerificationRelistInterval *metav1.Duration           `json:"verificationRelistInterval,omitempty"`
+	WatchBookmarkInterval      *metav1.Duration           `json:"watchBookmarkInterval,omitempty"`
+	MassDeletion               *MassDeletionConfiguration `json:"massDeletion,omitempty"`
+	DriftCheck                 *DriftCheckConfiguration   `json:"driftCheck,omitempty"`
+}
+
+type MassDeletionConfiguration struct {
+	Threshold    *float64         `json:"threshold,omitempty"`
+	Window       *metav1.Duration `json:"window,omitempty"`
+	MinDeletions *int             `json:"minDeletions,omitempty"`
+}
+
+type DriftCheckConfiguration struct {
+	Interval  *metav1.Duration `json:"interval,omitempty"`
+	Resources []string         `json:"resources,omitempty"`
+	Repair    *bool            `json:"repair,omitempty"`
+}
+
+type HealthCheckConfiguration struct {
+	Strategy      string `json:"strategy,omitempty"`
+	ProbeResource string `json:"probeResource,omitempty"`
+}
+
+// TransportConfiguration tunes the connections to the member clusters
+type TransportConfiguration struct {
+	HTTP2ReadIdleTimeout *metav1.Duration `json:"http2ReadIdleTimeout,omitempty"`
+	HTTP2PingTimeout     *metav1.Duration `json:"http2PingTimeout,omitempty"`
+	IdleConnTimeout      *metav1.Duration `json:"idleConnTimeout,omitempty"`
+	TCPKeepAliveIdle     *metav1.Duration `json:"tcpKeepAliveIdle,omitempty"`
+	TCPKeepAliveInterval *metav1.Duration `json:"tcpKeepAliveInterval,omitempty"`
+	TCPKeepAliveCount    *int             `json:"tcpKeepAliveCount,omitempty"`
+	WatchIdleTimeout     *metav1.Duration `json:"watchIdleTimeout,omitempty"`
+}
+
+type RetentionConfiguration struct {
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	Policies []string         `json:"policies,omitempty"`
+}
+
+type OrphanGCConfiguration struct {
+	Interval    *metav1.Duration `json:"interval,omitempty"`
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+	QPS         *float32         `json:"qps,omitempty"`
+}
+
+type CDCConfiguration struct {
+	Sink         string   `json:"sink,omitempty"`
+	KafkaBrokers []string `json:"kafkaBrokers,omitempty"`
+	KafkaTopic   string   `json:"kafkaTopic,omitempty"`
+	NATSURL      string   `json:"natsURL,omitempty"`
+	NATSSubject  string   `json:"natsSubject,omitempty"`
+
+	MetadataOnlyResources []string `json:"metadataOnlyResources,omitempty"`
+}
+
+type BackupConfiguration struct {
+	Endpoint string           `json:"endpoint,omitempty"`
+	Region   string           `json:"region,omitempty"`
+	Bucket   string           `json:"bucket,omitempty"`
+	Prefix   string           `json:"prefix,omitempty"`
+	Insecure *bool            `json:"insecure,omitempty"`
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	ExcludedResources []string `json:"excludedResources,omitempty"`
+}
+
+// APIServerConfiguration is the configuration file of the clusterpedia apiserver.
+//
+// The fields which are not set keep the defaults of the flags, and the flags set on the command line
+// take precedence over the fields. The authentication, the authorization and the audit of the generic server
+// are still configured by the flags. For example, the routing of the requests and the list policy:
+//
+//	apiVersion: config.clusterpedia.io/v1alpha1
+//	kind: APIServerConfiguration
+//	storage:
+//	  name: internal
+//	  configPath: /etc/clusterpedia/storage/internalstorage-config.yaml
+//	resourceServer:
+//	  allowForwardUnsyncResourceRequest: true
+//	  readYourWritesWindow: 30s
+//	listPolicy:
+//	  defaultLimit: 500
+//	  maxLimit: 5000
+type APIServerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Kubeconfig                  string `json:"kubeconfig,omitempty"`
+	MaxRequestsInFlight         *int   `json:"maxRequestsInFlight,omitempty"`
+	MaxMutatingRequestsInFlight *int   `json:"maxMutatingRequestsInFlight,omitempty"`
+
+	SecureServing *SecureServingConfiguration `json:"secureServing,omitempty"`
+	Storage       *StorageConfiguration       `json:"storage,omitempty"`
+
+	ResourceServer   *ResourceServerConfiguration   `json:"resourceServer,omitempty"`
+	APIExplorer      *APIExplorerConfiguration      `json:"apiExplorer,omitempty"`
+	QueryDegradation *QueryDegradationConfiguration `json:"queryDegradation,omitempty"`
+	ListPolicy       *ListPolicyConfiguration       `json:"listPolicy,omitempty"`
+	PublicAccess     *PublicAccessConfiguration     `json:"publicAccess,omitempty"`
+	Shutdown         *ShutdownConfiguration         `json:"shutdown,omitempty"`
+
+	// ExternalMetricsConfig and FederationConfig are the files of the external metrics and the federation
+	ExternalMetricsConfig string `json:"externalMetricsConfig,omitempty"`
+	FederationConfig      string `json:"federationConfig,omitempty"`
+
+	// ConfigurationName is the ClusterpediaConfiguration whose settings are applied at runtime
+	ConfigurationName string `json:"configurationName,omitempty"`
+
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+type SecureServingConfiguration struct {
+	BindAddress       string `json:"bindAddress,omitempty"`
+	SecurePort        *int   `json:"securePort,omitempty"`
+	CertDir           string `json:"certDir,omitempty"`
+	TLSCertFile       string `json:"tlsCertFile,omitempty"`
+	TLSPrivateKeyFile string `json:"tlsPrivateKeyFile,omitempty"`
+}
+
+type ResourceServerConfiguration struct {
+	AllowForwardUnsyncResourceRequest      *bool            `json:"allowForwardUnsyncResourceRequest,omitempty"`
+	AllowPediaClusterConfigForProxyRequest *bool            `json:"allowPediaClusterConfigForProxyRequest,omitempty"`
+	AllowedProxySubresources               []string         `json:"allowedProxySubresources,omitempty"`
+	EnableProxyPathForForwardRequest       *bool            `json:"enableProxyPathForForwardRequest,omitempty"`
+	ReadYourWritesWindow                   *metav1.Duration `json:"readYourWritesWindow,omitempty"`
+}
+
+type APIExplorerConfiguration struct {
+	Enabled   *bool  `json:"enabled,omitempty"`
+	AssetsURL string `json:"assetsURL,omitempty"`
+}
+
+type QueryDegradationConfiguration struct {
+	Enabled        *bool            `json:"enabled,omitempty"`
+	DeepPageOffset *int64           `json:"deepPageOffset,omitempty"`
+	LatencySLO     *metav1.Duration `json:"latencySLO,omitempty"`
+	LatencyWindow  *metav1.Duration `json:"latencyWindow,omitempty"`
+}
+
+type ListPolicyConfiguration struct {
+	ClusterRequiredGroups []string `json:"clusterRequiredGroups,omitempty"`
+	DefaultLimit          *int64   `json:"defaultLimit,omitempty"`
+	DefaultOrderBy        string   `json:"defaultOrderBy,omitempty"`
+	MaxLimit              *int64   `json:"maxLimit,omitempty"`
+}
+
+type PublicAccessConfiguration struct {
+	Clusters   []string `json:"clusters,omitempty"`
+	Namespaces []string `json:"namespaces,omitempty"`
+	Resources  []string `json:"resources,omitempty"`
+}
+
+type ShutdownConfiguration struct {
+	DelayDuration    *metav1.Duration `json:"delayDuration,omitempty"`
+	GracePeriod      *metav1.Duration `json:"gracePeriod,omitempty"`
+	WatchGracePeriod *metav1.Duration `json:"watchGracePeriod,omitempty"`
+}
@@ -7,6 +7,8 @@ import (
 	http "net/http"
 
 	clusterv1alpha2 "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/typed/cluster/v1alpha2"
+	configv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/typed/config/v1alpha1"
+	operatorv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/typed/operator/v1alpha1"
 	policyv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/typed/policy/v1alpha1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
@@ -16,14 +18,18 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	ClusterV1alpha2() clusterv1alpha2.ClusterV1alpha2Interface
+	ConfigV1alpha1() configv1alpha1.ConfigV1alpha1Interface
+	OperatorV1alpha1() operatorv1alpha1.OperatorV1alpha1Interface
 	PolicyV1alpha1() policyv1alpha1.PolicyV1alpha1Interface
 }
 
 // Clientset contains the clients for groups.
 type Clientset struct {
 	*discovery.DiscoveryClient
-	clusterV1alpha2 *clusterv1alpha2.ClusterV1alpha2Client
-	policyV1alpha1  *policyv1alpha1.PolicyV1alpha1Client
+	clusterV1alpha2  *clusterv1alpha2.ClusterV1alpha2Client
+	configV1alpha1   *configv1alpha1.ConfigV1alpha1Client
+	operatorV1alpha1 *operatorv1alpha1.OperatorV1alpha1Client
+	policyV1alpha1   *policyv1alpha1.PolicyV1alpha1Client
 }
 
 // ClusterV1alpha2 retrieves the ClusterV1alpha2Client
@@ -31,6 +37,16 @@ func (c *Clientset) ClusterV1alpha2() clusterv1alpha2.ClusterV1alpha2Interface {
 	return c.clusterV1alpha2
 }
 
+// ConfigV1alpha1 retrieves the ConfigV1alpha1Client
+func (c *Clientset) ConfigV1alpha1() configv1alpha1.ConfigV1alpha1Interface {
+	return c.configV1alpha1
+}
+
+// OperatorV1alpha1 retrieves the OperatorV1alpha1Client
+func (c *Clientset) OperatorV1alpha1() operatorv1alpha1.OperatorV1alpha1Interface {
+	return c.operatorV1alpha1
+}
+
 // PolicyV1alpha1 retrieves the PolicyV1alpha1Client
 func (c *Clientset) PolicyV1alpha1() policyv1alpha1.PolicyV1alpha1Interface {
 	return c.policyV1alpha1
@@ -84,6 +100,14 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
+	cs.configV1alpha1, err = configv1alpha1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	cs.operatorV1alpha1, err = operatorv1alpha1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 	cs.policyV1alpha1, err = policyv1alpha1.NewForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
 		return nil, err
@@ -110,6 +134,8 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.clusterV1alpha2 = clusterv1alpha2.New(c)
+	cs.configV1alpha1 = configv1alpha1.New(c)
+	cs.operatorV1alpha1 = operatorv1alpha1.New(c)
 	cs.policyV1alpha1 = policyv1alpha1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
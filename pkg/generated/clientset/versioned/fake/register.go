@@ -4,6 +4,8 @@ package fake
 
 import (
 	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	operatorv1alpha1 "github.com/clusterpedia-io/api/operator/v1alpha1"
 	policyv1alpha1 "github.com/clusterpedia-io/api/policy/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -17,6 +19,8 @@ var codecs = serializer.NewCodecFactory(scheme)
 
 var localSchemeBuilder = runtime.SchemeBuilder{
 	clusterv1alpha2.AddToScheme,
+	configv1alpha1.AddToScheme,
+	operatorv1alpha1.AddToScheme,
 	policyv1alpha1.AddToScheme,
 }
 
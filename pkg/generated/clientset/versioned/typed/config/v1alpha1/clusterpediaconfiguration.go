@@ -0,0 +1,54 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	scheme "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterpediaConfigurationsGetter has a method to return a ClusterpediaConfigurationInterface.
+// A group's client should implement this interface.
+type ClusterpediaConfigurationsGetter interface {
+	ClusterpediaConfigurations() ClusterpediaConfigurationInterface
+}
+
+// ClusterpediaConfigurationInterface has methods to work with ClusterpediaConfiguration resources.
+type ClusterpediaConfigurationInterface interface {
+	Create(ctx context.Context, clusterpediaConfiguration *configv1alpha1.ClusterpediaConfiguration, opts v1.CreateOptions) (*configv1alpha1.ClusterpediaConfiguration, error)
+	Update(ctx context.Context, clusterpediaConfiguration *configv1alpha1.ClusterpediaConfiguration, opts v1.UpdateOptions) (*configv1alpha1.ClusterpediaConfiguration, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*configv1alpha1.ClusterpediaConfiguration, error)
+	List(ctx context.Context, opts v1.ListOptions) (*configv1alpha1.ClusterpediaConfigurationList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *configv1alpha1.ClusterpediaConfiguration, err error)
+	ClusterpediaConfigurationExpansion
+}
+
+// clusterpediaConfigurations implements ClusterpediaConfigurationInterface
+type clusterpediaConfigurations struct {
+	*gentype.ClientWithList[*configv1alpha1.ClusterpediaConfiguration, *configv1alpha1.ClusterpediaConfigurationList]
+}
+
+// newClusterpediaConfigurations returns a ClusterpediaConfigurations
+func newClusterpediaConfigurations(c *ConfigV1alpha1Client) *clusterpediaConfigurations {
+	return &clusterpediaConfigurations{
+		gentype.NewClientWithList[*configv1alpha1.ClusterpediaConfiguration, *configv1alpha1.ClusterpediaConfigurationList](
+			"clusterpediaconfigurations",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *configv1alpha1.ClusterpediaConfiguration { return &configv1alpha1.ClusterpediaConfiguration{} },
+			func() *configv1alpha1.ClusterpediaConfigurationList {
+				return &configv1alpha1.ClusterpediaConfigurationList{}
+			},
+		),
+	}
+}
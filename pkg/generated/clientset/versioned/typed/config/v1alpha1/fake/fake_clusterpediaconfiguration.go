@@ -0,0 +1,36 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	configv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/typed/config/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterpediaConfigurations implements ClusterpediaConfigurationInterface
+type fakeClusterpediaConfigurations struct {
+	*gentype.FakeClientWithList[*v1alpha1.ClusterpediaConfiguration, *v1alpha1.ClusterpediaConfigurationList]
+	Fake *FakeConfigV1alpha1
+}
+
+func newFakeClusterpediaConfigurations(fake *FakeConfigV1alpha1) configv1alpha1.ClusterpediaConfigurationInterface {
+	return &fakeClusterpediaConfigurations{
+		gentype.NewFakeClientWithList[*v1alpha1.ClusterpediaConfiguration, *v1alpha1.ClusterpediaConfigurationList](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("clusterpediaconfigurations"),
+			v1alpha1.SchemeGroupVersion.WithKind("ClusterpediaConfiguration"),
+			func() *v1alpha1.ClusterpediaConfiguration { return &v1alpha1.ClusterpediaConfiguration{} },
+			func() *v1alpha1.ClusterpediaConfigurationList { return &v1alpha1.ClusterpediaConfigurationList{} },
+			func(dst, src *v1alpha1.ClusterpediaConfigurationList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ClusterpediaConfigurationList) []*v1alpha1.ClusterpediaConfiguration {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ClusterpediaConfigurationList, items []*v1alpha1.ClusterpediaConfiguration) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,54 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	operatorv1alpha1 "github.com/clusterpedia-io/api/operator/v1alpha1"
+	scheme "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterpediasGetter has a method to return a ClusterpediaInterface.
+// A group's client should implement this interface.
+type ClusterpediasGetter interface {
+	Clusterpedias(namespace string) ClusterpediaInterface
+}
+
+// ClusterpediaInterface has methods to work with Clusterpedia resources.
+type ClusterpediaInterface interface {
+	Create(ctx context.Context, clusterpedia *operatorv1alpha1.Clusterpedia, opts v1.CreateOptions) (*operatorv1alpha1.Clusterpedia, error)
+	Update(ctx context.Context, clusterpedia *operatorv1alpha1.Clusterpedia, opts v1.UpdateOptions) (*operatorv1alpha1.Clusterpedia, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterpedia *operatorv1alpha1.Clusterpedia, opts v1.UpdateOptions) (*operatorv1alpha1.Clusterpedia, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*operatorv1alpha1.Clusterpedia, error)
+	List(ctx context.Context, opts v1.ListOptions) (*operatorv1alpha1.ClusterpediaList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *operatorv1alpha1.Clusterpedia, err error)
+	ClusterpediaExpansion
+}
+
+// clusterpedias implements ClusterpediaInterface
+type clusterpedias struct {
+	*gentype.ClientWithList[*operatorv1alpha1.Clusterpedia, *operatorv1alpha1.ClusterpediaList]
+}
+
+// newClusterpedias returns a Clusterpedias
+func newClusterpedias(c *OperatorV1alpha1Client, namespace string) *clusterpedias {
+	return &clusterpedias{
+		gentype.NewClientWithList[*operatorv1alpha1.Clusterpedia, *operatorv1alpha1.ClusterpediaList](
+			"clusterpedias",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *operatorv1alpha1.Clusterpedia { return &operatorv1alpha1.Clusterpedia{} },
+			func() *operatorv1alpha1.ClusterpediaList { return &operatorv1alpha1.ClusterpediaList{} },
+		),
+	}
+}
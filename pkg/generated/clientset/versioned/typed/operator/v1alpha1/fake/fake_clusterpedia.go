@@ -0,0 +1,36 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/clusterpedia-io/api/operator/v1alpha1"
+	operatorv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/typed/operator/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterpedias implements ClusterpediaInterface
+type fakeClusterpedias struct {
+	*gentype.FakeClientWithList[*v1alpha1.Clusterpedia, *v1alpha1.ClusterpediaList]
+	Fake *FakeOperatorV1alpha1
+}
+
+func newFakeClusterpedias(fake *FakeOperatorV1alpha1, namespace string) operatorv1alpha1.ClusterpediaInterface {
+	return &fakeClusterpedias{
+		gentype.NewFakeClientWithList[*v1alpha1.Clusterpedia, *v1alpha1.ClusterpediaList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("clusterpedias"),
+			v1alpha1.SchemeGroupVersion.WithKind("Clusterpedia"),
+			func() *v1alpha1.Clusterpedia { return &v1alpha1.Clusterpedia{} },
+			func() *v1alpha1.ClusterpediaList { return &v1alpha1.ClusterpediaList{} },
+			func(dst, src *v1alpha1.ClusterpediaList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ClusterpediaList) []*v1alpha1.Clusterpedia {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ClusterpediaList, items []*v1alpha1.Clusterpedia) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
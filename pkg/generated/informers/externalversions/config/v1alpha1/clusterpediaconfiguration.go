@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	apiconfigv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	versioned "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/internalinterfaces"
+	configv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/config/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterpediaConfigurationInformer provides access to a shared informer and lister for
+// ClusterpediaConfigurations.
+type ClusterpediaConfigurationInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() configv1alpha1.ClusterpediaConfigurationLister
+}
+
+type clusterpediaConfigurationInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterpediaConfigurationInformer constructs a new informer for ClusterpediaConfiguration type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterpediaConfigurationInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterpediaConfigurationInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterpediaConfigurationInformer constructs a new informer for ClusterpediaConfiguration type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterpediaConfigurationInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ConfigV1alpha1().ClusterpediaConfigurations().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ConfigV1alpha1().ClusterpediaConfigurations().Watch(context.TODO(), options)
+			},
+		},
+		&apiconfigv1alpha1.ClusterpediaConfiguration{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterpediaConfigurationInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterpediaConfigurationInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterpediaConfigurationInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apiconfigv1alpha1.ClusterpediaConfiguration{}, f.defaultInformer)
+}
+
+func (f *clusterpediaConfigurationInformer) Lister() configv1alpha1.ClusterpediaConfigurationLister {
+	return configv1alpha1.NewClusterpediaConfigurationLister(f.Informer().GetIndexer())
+}
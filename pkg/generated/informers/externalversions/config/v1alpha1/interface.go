@@ -0,0 +1,29 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	internalinterfaces "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// ClusterpediaConfigurations returns a ClusterpediaConfigurationInformer.
+	ClusterpediaConfigurations() ClusterpediaConfigurationInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// ClusterpediaConfigurations returns a ClusterpediaConfigurationInformer.
+func (v *version) ClusterpediaConfigurations() ClusterpediaConfigurationInformer {
+	return &clusterpediaConfigurationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
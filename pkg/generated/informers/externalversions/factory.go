@@ -9,7 +9,9 @@ import (
 
 	versioned "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
 	cluster "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/cluster"
+	config "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/config"
 	internalinterfaces "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/internalinterfaces"
+	operator "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/operator"
 	policy "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/policy"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -240,6 +242,8 @@ type SharedInformerFactory interface {
 	InformerFor(obj runtime.Object, newFunc internalinterfaces.NewInformerFunc) cache.SharedIndexInformer
 
 	Cluster() cluster.Interface
+	Config() config.Interface
+	Operator() operator.Interface
 	Policy() policy.Interface
 }
 
@@ -247,6 +251,14 @@ func (f *sharedInformerFactory) Cluster() cluster.Interface {
 	return cluster.New(f, f.namespace, f.tweakListOptions)
 }
 
+func (f *sharedInformerFactory) Config() config.Interface {
+	return config.New(f, f.namespace, f.tweakListOptions)
+}
+
+func (f *sharedInformerFactory) Operator() operator.Interface {
+	return operator.New(f, f.namespace, f.tweakListOptions)
+}
+
 func (f *sharedInformerFactory) Policy() policy.Interface {
 	return policy.New(f, f.namespace, f.tweakListOptions)
 }
@@ -6,7 +6,9 @@ import (
 	fmt "fmt"
 
 	v1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
-	v1alpha1 "github.com/clusterpedia-io/api/policy/v1alpha1"
+	v1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	operatorv1alpha1 "github.com/clusterpedia-io/api/operator/v1alpha1"
+	policyv1alpha1 "github.com/clusterpedia-io/api/policy/v1alpha1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -43,10 +45,18 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case v1alpha2.SchemeGroupVersion.WithResource("pediaclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Cluster().V1alpha2().PediaClusters().Informer()}, nil
 
+		// Group=config.clusterpedia.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("clusterpediaconfigurations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha1().ClusterpediaConfigurations().Informer()}, nil
+
+		// Group=operator.clusterpedia.io, Version=v1alpha1
+	case operatorv1alpha1.SchemeGroupVersion.WithResource("clusterpedias"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1alpha1().Clusterpedias().Informer()}, nil
+
 		// Group=policy.clusterpedia.io, Version=v1alpha1
-	case v1alpha1.SchemeGroupVersion.WithResource("clusterimportpolicies"):
+	case policyv1alpha1.SchemeGroupVersion.WithResource("clusterimportpolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().ClusterImportPolicies().Informer()}, nil
-	case v1alpha1.SchemeGroupVersion.WithResource("pediaclusterlifecycles"):
+	case policyv1alpha1.SchemeGroupVersion.WithResource("pediaclusterlifecycles"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().PediaClusterLifecycles().Informer()}, nil
 
 	}
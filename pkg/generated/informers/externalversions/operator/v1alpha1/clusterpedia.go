@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	apioperatorv1alpha1 "github.com/clusterpedia-io/api/operator/v1alpha1"
+	versioned "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/internalinterfaces"
+	operatorv1alpha1 "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/operator/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterpediaInformer provides access to a shared informer and lister for
+// Clusterpedias.
+type ClusterpediaInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() operatorv1alpha1.ClusterpediaLister
+}
+
+type clusterpediaInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewClusterpediaInformer constructs a new informer for Clusterpedia type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterpediaInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterpediaInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterpediaInformer constructs a new informer for Clusterpedia type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterpediaInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.OperatorV1alpha1().Clusterpedias(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.OperatorV1alpha1().Clusterpedias(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&apioperatorv1alpha1.Clusterpedia{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterpediaInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterpediaInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterpediaInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apioperatorv1alpha1.Clusterpedia{}, f.defaultInformer)
+}
+
+func (f *clusterpediaInformer) Lister() operatorv1alpha1.ClusterpediaLister {
+	return operatorv1alpha1.NewClusterpediaLister(f.Informer().GetIndexer())
+}
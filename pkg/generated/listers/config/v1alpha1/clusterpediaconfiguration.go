@@ -0,0 +1,32 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterpediaConfigurationLister helps list ClusterpediaConfigurations.
+// All objects returned here must be treated as read-only.
+type ClusterpediaConfigurationLister interface {
+	// List lists all ClusterpediaConfigurations in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*configv1alpha1.ClusterpediaConfiguration, err error)
+	// Get retrieves the ClusterpediaConfiguration from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*configv1alpha1.ClusterpediaConfiguration, error)
+	ClusterpediaConfigurationListerExpansion
+}
+
+// clusterpediaConfigurationLister implements the ClusterpediaConfigurationLister interface.
+type clusterpediaConfigurationLister struct {
+	listers.ResourceIndexer[*configv1alpha1.ClusterpediaConfiguration]
+}
+
+// NewClusterpediaConfigurationLister returns a new ClusterpediaConfigurationLister.
+func NewClusterpediaConfigurationLister(indexer cache.Indexer) ClusterpediaConfigurationLister {
+	return &clusterpediaConfigurationLister{listers.New[*configv1alpha1.ClusterpediaConfiguration](indexer, configv1alpha1.Resource("clusterpediaconfiguration"))}
+}
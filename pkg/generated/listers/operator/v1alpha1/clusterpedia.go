@@ -0,0 +1,54 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	operatorv1alpha1 "github.com/clusterpedia-io/api/operator/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterpediaLister helps list Clusterpedias.
+// All objects returned here must be treated as read-only.
+type ClusterpediaLister interface {
+	// List lists all Clusterpedias in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*operatorv1alpha1.Clusterpedia, err error)
+	// Clusterpedias returns an object that can list and get Clusterpedias.
+	Clusterpedias(namespace string) ClusterpediaNamespaceLister
+	ClusterpediaListerExpansion
+}
+
+// clusterpediaLister implements the ClusterpediaLister interface.
+type clusterpediaLister struct {
+	listers.ResourceIndexer[*operatorv1alpha1.Clusterpedia]
+}
+
+// NewClusterpediaLister returns a new ClusterpediaLister.
+func NewClusterpediaLister(indexer cache.Indexer) ClusterpediaLister {
+	return &clusterpediaLister{listers.New[*operatorv1alpha1.Clusterpedia](indexer, operatorv1alpha1.Resource("clusterpedia"))}
+}
+
+// Clusterpedias returns an object that can list and get Clusterpedias.
+func (s *clusterpediaLister) Clusterpedias(namespace string) ClusterpediaNamespaceLister {
+	return clusterpediaNamespaceLister{listers.NewNamespaced[*operatorv1alpha1.Clusterpedia](s.ResourceIndexer, namespace)}
+}
+
+// ClusterpediaNamespaceLister helps list and get Clusterpedias.
+// All objects returned here must be treated as read-only.
+type ClusterpediaNamespaceLister interface {
+	// List lists all Clusterpedias in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*operatorv1alpha1.Clusterpedia, err error)
+	// Get retrieves the Clusterpedia from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*operatorv1alpha1.Clusterpedia, error)
+	ClusterpediaNamespaceListerExpansion
+}
+
+// clusterpediaNamespaceLister implements the ClusterpediaNamespaceLister
+// interface.
+type clusterpediaNamespaceLister struct {
+	listers.ResourceIndexer[*operatorv1alpha1.Clusterpedia]
+}
@@ -0,0 +1,11 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ClusterpediaListerExpansion allows custom methods to be added to
+// ClusterpediaLister.
+type ClusterpediaListerExpansion interface{}
+
+// ClusterpediaNamespaceListerExpansion allows custom methods to be added to
+// ClusterpediaNamespaceLister.
+type ClusterpediaNamespaceListerExpansion interface{}
@@ -13,92 +13,167 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterAuthentication":          schema_clusterpedia_io_api_cluster_v1alpha2_ClusterAuthentication(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterAuthenticationSource":    schema_clusterpedia_io_api_cluster_v1alpha2_ClusterAuthenticationSource(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterGroupResources":          schema_clusterpedia_io_api_cluster_v1alpha2_ClusterGroupResources(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterGroupResourcesStatus":    schema_clusterpedia_io_api_cluster_v1alpha2_ClusterGroupResourcesStatus(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterResourceStatus":          schema_clusterpedia_io_api_cluster_v1alpha2_ClusterResourceStatus(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterResourceSyncCondition":   schema_clusterpedia_io_api_cluster_v1alpha2_ClusterResourceSyncCondition(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterSpec":                    schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSpec(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterStatus":                  schema_clusterpedia_io_api_cluster_v1alpha2_ClusterStatus(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterSyncResources":           schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSyncResources(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterSyncResourcesList":       schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSyncResourcesList(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterSyncResourcesSpec":       schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSyncResourcesSpec(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.PediaCluster":                   schema_clusterpedia_io_api_cluster_v1alpha2_PediaCluster(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.PediaClusterList":               schema_clusterpedia_io_api_cluster_v1alpha2_PediaClusterList(ref),
-		"github.com/clusterpedia-io/api/cluster/v1alpha2.SecretKeySelector":              schema_clusterpedia_io_api_cluster_v1alpha2_SecretKeySelector(ref),
-		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResource":         schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResource(ref),
-		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceList":     schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceList(ref),
-		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType":     schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceType(ref),
-		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ListOptions":                schema_clusterpedia_io_api_clusterpedia_v1beta1_ListOptions(ref),
-		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.Resources":                  schema_clusterpedia_io_api_clusterpedia_v1beta1_Resources(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.BaseReferenceResourceTemplate":   schema_clusterpedia_io_api_policy_v1alpha1_BaseReferenceResourceTemplate(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.ClusterImportPolicy":             schema_clusterpedia_io_api_policy_v1alpha1_ClusterImportPolicy(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.ClusterImportPolicyList":         schema_clusterpedia_io_api_policy_v1alpha1_ClusterImportPolicyList(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.ClusterImportPolicySpec":         schema_clusterpedia_io_api_policy_v1alpha1_ClusterImportPolicySpec(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.ClusterImportPolicyStatus":       schema_clusterpedia_io_api_policy_v1alpha1_ClusterImportPolicyStatus(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.DependentResource":               schema_clusterpedia_io_api_policy_v1alpha1_DependentResource(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.IntendReferenceResourceTemplate": schema_clusterpedia_io_api_policy_v1alpha1_IntendReferenceResourceTemplate(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.PediaClusterLifecycle":           schema_clusterpedia_io_api_policy_v1alpha1_PediaClusterLifecycle(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.PediaClusterLifecycleList":       schema_clusterpedia_io_api_policy_v1alpha1_PediaClusterLifecycleList(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.PediaClusterLifecycleSpec":       schema_clusterpedia_io_api_policy_v1alpha1_PediaClusterLifecycleSpec(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.PediaClusterLifecycleStatus":     schema_clusterpedia_io_api_policy_v1alpha1_PediaClusterLifecycleStatus(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.Policy":                          schema_clusterpedia_io_api_policy_v1alpha1_Policy(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.ReferenceResourceTemplate":       schema_clusterpedia_io_api_policy_v1alpha1_ReferenceResourceTemplate(ref),
-		"github.com/clusterpedia-io/api/policy/v1alpha1.SourceType":                      schema_clusterpedia_io_api_policy_v1alpha1_SourceType(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                                  schema_pkg_apis_meta_v1_APIGroup(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                              schema_pkg_apis_meta_v1_APIGroupList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                               schema_pkg_apis_meta_v1_APIResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResourceList":                           schema_pkg_apis_meta_v1_APIResourceList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIVersions":                               schema_pkg_apis_meta_v1_APIVersions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ApplyOptions":                              schema_pkg_apis_meta_v1_ApplyOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Condition":                                 schema_pkg_apis_meta_v1_Condition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.CreateOptions":                             schema_pkg_apis_meta_v1_CreateOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.DeleteOptions":                             schema_pkg_apis_meta_v1_DeleteOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                                  schema_pkg_apis_meta_v1_Duration(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldSelectorRequirement":                  schema_pkg_apis_meta_v1_FieldSelectorRequirement(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldsV1":                                  schema_pkg_apis_meta_v1_FieldsV1(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GetOptions":                                schema_pkg_apis_meta_v1_GetOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind":                                 schema_pkg_apis_meta_v1_GroupKind(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupResource":                             schema_pkg_apis_meta_v1_GroupResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersion":                              schema_pkg_apis_meta_v1_GroupVersion(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionForDiscovery":                  schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionKind":                          schema_pkg_apis_meta_v1_GroupVersionKind(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionResource":                      schema_pkg_apis_meta_v1_GroupVersionResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.InternalEvent":                             schema_pkg_apis_meta_v1_InternalEvent(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":                             schema_pkg_apis_meta_v1_LabelSelector(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement":                  schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.List":                                      schema_pkg_apis_meta_v1_List(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                                  schema_pkg_apis_meta_v1_ListMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ListOptions":                               schema_pkg_apis_meta_v1_ListOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ManagedFieldsEntry":                        schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                                 schema_pkg_apis_meta_v1_MicroTime(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                                schema_pkg_apis_meta_v1_ObjectMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference":                            schema_pkg_apis_meta_v1_OwnerReference(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadata":                     schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadataList":                 schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Patch":                                     schema_pkg_apis_meta_v1_Patch(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PatchOptions":                              schema_pkg_apis_meta_v1_PatchOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Preconditions":                             schema_pkg_apis_meta_v1_Preconditions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.RootPaths":                                 schema_pkg_apis_meta_v1_RootPaths(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ServerAddressByClientCIDR":                 schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Status":                                    schema_pkg_apis_meta_v1_Status(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause":                               schema_pkg_apis_meta_v1_StatusCause(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails":                             schema_pkg_apis_meta_v1_StatusDetails(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Table":                                     schema_pkg_apis_meta_v1_Table(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableColumnDefinition":                     schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableOptions":                              schema_pkg_apis_meta_v1_TableOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRow":                                  schema_pkg_apis_meta_v1_TableRow(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRowCondition":                         schema_pkg_apis_meta_v1_TableRowCondition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                                      schema_pkg_apis_meta_v1_Time(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp":                                 schema_pkg_apis_meta_v1_Timestamp(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                                  schema_pkg_apis_meta_v1_TypeMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.UpdateOptions":                             schema_pkg_apis_meta_v1_UpdateOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                                schema_pkg_apis_meta_v1_WatchEvent(ref),
-		"k8s.io/apimachinery/pkg/runtime.RawExtension":                                   schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
-		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                                       schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
-		"k8s.io/apimachinery/pkg/runtime.Unknown":                                        schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
-		"k8s.io/apimachinery/pkg/version.Info":                                           schema_k8sio_apimachinery_pkg_version_Info(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterAuthentication":                schema_clusterpedia_io_api_cluster_v1alpha2_ClusterAuthentication(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterAuthenticationSource":          schema_clusterpedia_io_api_cluster_v1alpha2_ClusterAuthenticationSource(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterConnectionStatus":              schema_clusterpedia_io_api_cluster_v1alpha2_ClusterConnectionStatus(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterGroupResources":                schema_clusterpedia_io_api_cluster_v1alpha2_ClusterGroupResources(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterGroupResourcesStatus":          schema_clusterpedia_io_api_cluster_v1alpha2_ClusterGroupResourcesStatus(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterPruneConfiguration":            schema_clusterpedia_io_api_cluster_v1alpha2_ClusterPruneConfiguration(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterResourceStatus":                schema_clusterpedia_io_api_cluster_v1alpha2_ClusterResourceStatus(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterResourceSyncCondition":         schema_clusterpedia_io_api_cluster_v1alpha2_ClusterResourceSyncCondition(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterSpec":                          schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSpec(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterStatus":                        schema_clusterpedia_io_api_cluster_v1alpha2_ClusterStatus(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterSyncResources":                 schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSyncResources(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterSyncResourcesList":             schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSyncResourcesList(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterSyncResourcesSpec":             schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSyncResourcesSpec(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.OwnedResourcesFilter":                 schema_clusterpedia_io_api_cluster_v1alpha2_OwnedResourcesFilter(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.PediaCluster":                         schema_clusterpedia_io_api_cluster_v1alpha2_PediaCluster(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.PediaClusterList":                     schema_clusterpedia_io_api_cluster_v1alpha2_PediaClusterList(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.ResourcePruneConfiguration":           schema_clusterpedia_io_api_cluster_v1alpha2_ResourcePruneConfiguration(ref),
+		"github.com/clusterpedia-io/api/cluster/v1alpha2.SecretKeySelector":                    schema_clusterpedia_io_api_cluster_v1alpha2_SecretKeySelector(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.Aggregation":                      schema_clusterpedia_io_api_clusterpedia_v1beta1_Aggregation(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.AggregationGroup":                 schema_clusterpedia_io_api_clusterpedia_v1beta1_AggregationGroup(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ApplyDiff":                        schema_clusterpedia_io_api_clusterpedia_v1beta1_ApplyDiff(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ApplyDiffResult":                  schema_clusterpedia_io_api_clusterpedia_v1beta1_ApplyDiffResult(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ApplyDiffSpec":                    schema_clusterpedia_io_api_clusterpedia_v1beta1_ApplyDiffSpec(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ApplyDiffStatus":                  schema_clusterpedia_io_api_clusterpedia_v1beta1_ApplyDiffStatus(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.Archive":                          schema_clusterpedia_io_api_clusterpedia_v1beta1_Archive(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.Archives":                         schema_clusterpedia_io_api_clusterpedia_v1beta1_Archives(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGet":                          schema_clusterpedia_io_api_clusterpedia_v1beta1_BulkGet(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetObjectReference":           schema_clusterpedia_io_api_clusterpedia_v1beta1_BulkGetObjectReference(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetResult":                    schema_clusterpedia_io_api_clusterpedia_v1beta1_BulkGetResult(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetSpec":                      schema_clusterpedia_io_api_clusterpedia_v1beta1_BulkGetSpec(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetStatus":                    schema_clusterpedia_io_api_clusterpedia_v1beta1_BulkGetStatus(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ClusterOverview":                  schema_clusterpedia_io_api_clusterpedia_v1beta1_ClusterOverview(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ClusterScale":                     schema_clusterpedia_io_api_clusterpedia_v1beta1_ClusterScale(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResource":               schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResource(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceGroup":          schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceGroup(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceList":           schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceList(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceNamespaceGroup": schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceNamespaceGroup(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType":           schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceType(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.FieldChange":                      schema_clusterpedia_io_api_clusterpedia_v1beta1_FieldChange(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.FleetClusters":                    schema_clusterpedia_io_api_clusterpedia_v1beta1_FleetClusters(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.FleetOverview":                    schema_clusterpedia_io_api_clusterpedia_v1beta1_FleetOverview(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ListOptions":                      schema_clusterpedia_io_api_clusterpedia_v1beta1_ListOptions(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.MetadataKey":                      schema_clusterpedia_io_api_clusterpedia_v1beta1_MetadataKey(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.MetadataKeys":                     schema_clusterpedia_io_api_clusterpedia_v1beta1_MetadataKeys(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.NamespaceCluster":                 schema_clusterpedia_io_api_clusterpedia_v1beta1_NamespaceCluster(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.NamespaceInventory":               schema_clusterpedia_io_api_clusterpedia_v1beta1_NamespaceInventory(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.NamespaceInventoryItem":           schema_clusterpedia_io_api_clusterpedia_v1beta1_NamespaceInventoryItem(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ReplicaSummaries":                 schema_clusterpedia_io_api_clusterpedia_v1beta1_ReplicaSummaries(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ReplicaSummary":                   schema_clusterpedia_io_api_clusterpedia_v1beta1_ReplicaSummary(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceChange":                   schema_clusterpedia_io_api_clusterpedia_v1beta1_ResourceChange(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceHistory":                  schema_clusterpedia_io_api_clusterpedia_v1beta1_ResourceHistory(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceRevision":                 schema_clusterpedia_io_api_clusterpedia_v1beta1_ResourceRevision(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceSnapshot":                 schema_clusterpedia_io_api_clusterpedia_v1beta1_ResourceSnapshot(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceStorageUsage":             schema_clusterpedia_io_api_clusterpedia_v1beta1_ResourceStorageUsage(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.Resources":                        schema_clusterpedia_io_api_clusterpedia_v1beta1_Resources(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.SchemaValidation":                 schema_clusterpedia_io_api_clusterpedia_v1beta1_SchemaValidation(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.SchemaValidationResult":           schema_clusterpedia_io_api_clusterpedia_v1beta1_SchemaValidationResult(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.SchemaValidationSpec":             schema_clusterpedia_io_api_clusterpedia_v1beta1_SchemaValidationSpec(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.SchemaValidationStatus":           schema_clusterpedia_io_api_clusterpedia_v1beta1_SchemaValidationStatus(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.SpecHash":                         schema_clusterpedia_io_api_clusterpedia_v1beta1_SpecHash(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.SpecHashes":                       schema_clusterpedia_io_api_clusterpedia_v1beta1_SpecHashes(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.StorageUsage":                     schema_clusterpedia_io_api_clusterpedia_v1beta1_StorageUsage(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.SyncLagBucket":                    schema_clusterpedia_io_api_clusterpedia_v1beta1_SyncLagBucket(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.Tombstone":                        schema_clusterpedia_io_api_clusterpedia_v1beta1_Tombstone(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta1.Tombstones":                       schema_clusterpedia_io_api_clusterpedia_v1beta1_Tombstones(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResource":               schema_clusterpedia_io_api_clusterpedia_v1beta2_CollectionResource(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResourceGroup":          schema_clusterpedia_io_api_clusterpedia_v1beta2_CollectionResourceGroup(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResourceList":           schema_clusterpedia_io_api_clusterpedia_v1beta2_CollectionResourceList(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResourceNamespaceGroup": schema_clusterpedia_io_api_clusterpedia_v1beta2_CollectionResourceNamespaceGroup(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResourceType":           schema_clusterpedia_io_api_clusterpedia_v1beta2_CollectionResourceType(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta2.ListOptions":                      schema_clusterpedia_io_api_clusterpedia_v1beta2_ListOptions(ref),
+		"github.com/clusterpedia-io/api/clusterpedia/v1beta2.Resources":                        schema_clusterpedia_io_api_clusterpedia_v1beta2_Resources(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.ClusterpediaConfiguration":             schema_clusterpedia_io_api_config_v1alpha1_ClusterpediaConfiguration(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.ClusterpediaConfigurationList":         schema_clusterpedia_io_api_config_v1alpha1_ClusterpediaConfigurationList(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.ClusterpediaConfigurationSpec":         schema_clusterpedia_io_api_config_v1alpha1_ClusterpediaConfigurationSpec(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.EnrichmentConfiguration":               schema_clusterpedia_io_api_config_v1alpha1_EnrichmentConfiguration(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.FilterConfiguration":                   schema_clusterpedia_io_api_config_v1alpha1_FilterConfiguration(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.FilterFunction":                        schema_clusterpedia_io_api_config_v1alpha1_FilterFunction(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.ListConfiguration":                     schema_clusterpedia_io_api_config_v1alpha1_ListConfiguration(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.MappedResource":                        schema_clusterpedia_io_api_config_v1alpha1_MappedResource(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.MaskingConfiguration":                  schema_clusterpedia_io_api_config_v1alpha1_MaskingConfiguration(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.MaskingRule":                           schema_clusterpedia_io_api_config_v1alpha1_MaskingRule(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.PruneConfiguration":                    schema_clusterpedia_io_api_config_v1alpha1_PruneConfiguration(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.ResourceMappingRule":                   schema_clusterpedia_io_api_config_v1alpha1_ResourceMappingRule(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.ResourcePruneConfiguration":            schema_clusterpedia_io_api_config_v1alpha1_ResourcePruneConfiguration(ref),
+		"github.com/clusterpedia-io/api/config/v1alpha1.RetentionConfiguration":                schema_clusterpedia_io_api_config_v1alpha1_RetentionConfiguration(ref),
+		"github.com/clusterpedia-io/api/operator/v1alpha1.ClusterSynchroManagerSpec":           schema_clusterpedia_io_api_operator_v1alpha1_ClusterSynchroManagerSpec(ref),
+		"github.com/clusterpedia-io/api/operator/v1alpha1.Clusterpedia":                        schema_clusterpedia_io_api_operator_v1alpha1_Clusterpedia(ref),
+		"github.com/clusterpedia-io/api/operator/v1alpha1.ClusterpediaList":                    schema_clusterpedia_io_api_operator_v1alpha1_ClusterpediaList(ref),
+		"github.com/clusterpedia-io/api/operator/v1alpha1.ClusterpediaSpec":                    schema_clusterpedia_io_api_operator_v1alpha1_ClusterpediaSpec(ref),
+		"github.com/clusterpedia-io/api/operator/v1alpha1.ClusterpediaStatus":                  schema_clusterpedia_io_api_operator_v1alpha1_ClusterpediaStatus(ref),
+		"github.com/clusterpedia-io/api/operator/v1alpha1.ComponentSpec":                       schema_clusterpedia_io_api_operator_v1alpha1_ComponentSpec(ref),
+		"github.com/clusterpedia-io/api/operator/v1alpha1.ComponentStatus":                     schema_clusterpedia_io_api_operator_v1alpha1_ComponentStatus(ref),
+		"github.com/clusterpedia-io/api/operator/v1alpha1.StorageSpec":                         schema_clusterpedia_io_api_operator_v1alpha1_StorageSpec(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.BaseReferenceResourceTemplate":         schema_clusterpedia_io_api_policy_v1alpha1_BaseReferenceResourceTemplate(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.ClusterImportPolicy":                   schema_clusterpedia_io_api_policy_v1alpha1_ClusterImportPolicy(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.ClusterImportPolicyList":               schema_clusterpedia_io_api_policy_v1alpha1_ClusterImportPolicyList(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.ClusterImportPolicySpec":               schema_clusterpedia_io_api_policy_v1alpha1_ClusterImportPolicySpec(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.ClusterImportPolicyStatus":             schema_clusterpedia_io_api_policy_v1alpha1_ClusterImportPolicyStatus(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.DependentResource":                     schema_clusterpedia_io_api_policy_v1alpha1_DependentResource(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.IntendReferenceResourceTemplate":       schema_clusterpedia_io_api_policy_v1alpha1_IntendReferenceResourceTemplate(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.PediaClusterLifecycle":                 schema_clusterpedia_io_api_policy_v1alpha1_PediaClusterLifecycle(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.PediaClusterLifecycleList":             schema_clusterpedia_io_api_policy_v1alpha1_PediaClusterLifecycleList(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.PediaClusterLifecycleSpec":             schema_clusterpedia_io_api_policy_v1alpha1_PediaClusterLifecycleSpec(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.PediaClusterLifecycleStatus":           schema_clusterpedia_io_api_policy_v1alpha1_PediaClusterLifecycleStatus(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.Policy":                                schema_clusterpedia_io_api_policy_v1alpha1_Policy(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.ReferenceResourceTemplate":             schema_clusterpedia_io_api_policy_v1alpha1_ReferenceResourceTemplate(ref),
+		"github.com/clusterpedia-io/api/policy/v1alpha1.SourceType":                            schema_clusterpedia_io_api_policy_v1alpha1_SourceType(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                                        schema_pkg_apis_meta_v1_APIGroup(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                                    schema_pkg_apis_meta_v1_APIGroupList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                                     schema_pkg_apis_meta_v1_APIResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResourceList":                                 schema_pkg_apis_meta_v1_APIResourceList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIVersions":                                     schema_pkg_apis_meta_v1_APIVersions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ApplyOptions":                                    schema_pkg_apis_meta_v1_ApplyOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Condition":                                       schema_pkg_apis_meta_v1_Condition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.CreateOptions":                                   schema_pkg_apis_meta_v1_CreateOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.DeleteOptions":                                   schema_pkg_apis_meta_v1_DeleteOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                                        schema_pkg_apis_meta_v1_Duration(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldSelectorRequirement":                        schema_pkg_apis_meta_v1_FieldSelectorRequirement(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldsV1":                                        schema_pkg_apis_meta_v1_FieldsV1(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GetOptions":                                      schema_pkg_apis_meta_v1_GetOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind":                                       schema_pkg_apis_meta_v1_GroupKind(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupResource":                                   schema_pkg_apis_meta_v1_GroupResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersion":                                    schema_pkg_apis_meta_v1_GroupVersion(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionForDiscovery":                        schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionKind":                                schema_pkg_apis_meta_v1_GroupVersionKind(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionResource":                            schema_pkg_apis_meta_v1_GroupVersionResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.InternalEvent":                                   schema_pkg_apis_meta_v1_InternalEvent(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":                                   schema_pkg_apis_meta_v1_LabelSelector(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement":                        schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.List":                                            schema_pkg_apis_meta_v1_List(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                                        schema_pkg_apis_meta_v1_ListMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ListOptions":                                     schema_pkg_apis_meta_v1_ListOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ManagedFieldsEntry":                              schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                                       schema_pkg_apis_meta_v1_MicroTime(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                                      schema_pkg_apis_meta_v1_ObjectMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference":                                  schema_pkg_apis_meta_v1_OwnerReference(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadata":                           schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadataList":                       schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Patch":                                           schema_pkg_apis_meta_v1_Patch(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PatchOptions":                                    schema_pkg_apis_meta_v1_PatchOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Preconditions":                                   schema_pkg_apis_meta_v1_Preconditions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.RootPaths":                                       schema_pkg_apis_meta_v1_RootPaths(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ServerAddressByClientCIDR":                       schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Status":                                          schema_pkg_apis_meta_v1_Status(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause":                                     schema_pkg_apis_meta_v1_StatusCause(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails":                                   schema_pkg_apis_meta_v1_StatusDetails(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Table":                                           schema_pkg_apis_meta_v1_Table(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableColumnDefinition":                           schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableOptions":                                    schema_pkg_apis_meta_v1_TableOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRow":                                        schema_pkg_apis_meta_v1_TableRow(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRowCondition":                               schema_pkg_apis_meta_v1_TableRowCondition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                                            schema_pkg_apis_meta_v1_Time(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp":                                       schema_pkg_apis_meta_v1_Timestamp(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                                        schema_pkg_apis_meta_v1_TypeMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.UpdateOptions":                                   schema_pkg_apis_meta_v1_UpdateOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                                      schema_pkg_apis_meta_v1_WatchEvent(ref),
+		"k8s.io/apimachinery/pkg/runtime.RawExtension":                                         schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
+		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                                             schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
+		"k8s.io/apimachinery/pkg/runtime.Unknown":                                              schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
+		"k8s.io/apimachinery/pkg/version.Info":                                                 schema_k8sio_apimachinery_pkg_version_Info(ref),
 	}
 }
 
@@ -169,6 +244,48 @@ func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterAuthenticationSource(ref
 	}
 }
 
+func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterConnectionStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterConnectionStatus is measured by the health checks of the cluster, the values are summarized over the recent health checks.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"latencyP50": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LatencyP50 is the median round-trip latency of the health checks",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"latencyP90": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"latencyP99": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"clockSkew": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClockSkew is the estimated offset of the cluster clock from the clock of the clustersynchro manager, it is positive if the cluster clock is ahead. The skew is estimated from the `Date` headers of the responses, so its resolution is one second.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"lastMeasureTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterGroupResources(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -224,10 +341,26 @@ func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterGroupResources(ref commo
 							},
 						},
 					},
+					"skipOwnedResources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SkipOwnedResources skips syncing the resources owned by the specified kinds, eg. the pods owned by jobs, which can be derived from their owners.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/cluster/v1alpha2.OwnedResourcesFilter"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"group", "resources"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/cluster/v1alpha2.OwnedResourcesFilter"},
 	}
 }
 
@@ -266,6 +399,68 @@ func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterGroupResourcesStatus(ref
 	}
 }
 
+func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterPruneConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"managedFields": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ManagedFields prunes the `metadata.managedFields` of the objects.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"lastAppliedConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastAppliedConfiguration prunes the `kubectl.kubernetes.io/last-applied-configuration` annotation of the objects.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"fields": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Fields are the paths of the fields pruned from the objects in addition to the fields of the ClusterpediaConfiguration, formatted as the dot-separated field names, e.g. `status.images`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources override the prune configuration for the resources of the cluster.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/cluster/v1alpha2.ResourcePruneConfiguration"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/cluster/v1alpha2.ResourcePruneConfiguration"},
+	}
+}
+
 func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterResourceStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -447,7 +642,8 @@ func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSpec(ref common.Referenc
 					},
 					"syncResources": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "SyncResources are synchronized in addition to the resources of the SyncProfile and the SyncResourcesRefName.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
@@ -464,24 +660,43 @@ func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSpec(ref common.Referenc
 							Format: "",
 						},
 					},
+					"syncCustomResourceReferences": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncCustomResourceReferences syncs the resources referenced by the synced custom resources, they are found by the reference fields of the CRD schema, e.g. `secretRef` and `configMapRef`, and the `clusterpedia.io/referenced-resources` annotation of the CRD.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"syncResourcesRefName": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
+					"syncProfile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncProfile is the name of the built-in sync profile whose curated resources and prune configuration are used for the cluster, the Prune of the cluster overrides the prune configuration of the profile.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"shardingName": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
+					"prune": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Prune overrides the prune configuration of the ClusterpediaConfiguration for the cluster, the change applies to the objects when they are synchronized again.",
+							Ref:         ref("github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterPruneConfiguration"),
+						},
+					},
 				},
-				Required: []string{"syncResources"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterAuthentication", "github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterGroupResources"},
+			"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterAuthentication", "github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterGroupResources", "github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterPruneConfiguration"},
 	}
 }
 
@@ -535,11 +750,16 @@ func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterStatus(ref common.Refere
 							Format: "",
 						},
 					},
+					"connection": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterConnectionStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterGroupResourcesStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
+			"github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterConnectionStatus", "github.com/clusterpedia-io/api/cluster/v1alpha2.ClusterGroupResourcesStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
 	}
 }
 
@@ -659,6 +879,42 @@ func schema_clusterpedia_io_api_cluster_v1alpha2_ClusterSyncResourcesSpec(ref co
 	}
 }
 
+func schema_clusterpedia_io_api_cluster_v1alpha2_OwnedResourcesFilter(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resource is the resource of the group, `*` matches all resources of the group.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ownerKinds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerKinds are the kinds of the owners, formatted as `<kind>` or `<kind>.<group>`, eg. `Job.batch`. The resources with any owner reference of the kinds are not synced.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"resource", "ownerKinds"},
+			},
+		},
+	}
+}
+
 func schema_clusterpedia_io_api_cluster_v1alpha2_PediaCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -753,6 +1009,72 @@ func schema_clusterpedia_io_api_cluster_v1alpha2_PediaClusterList(ref common.Ref
 	}
 }
 
+func schema_clusterpedia_io_api_cluster_v1alpha2_ResourcePruneConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Group is the group of the resource, the empty group is the core group.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the version of the resource, the empty version matches all versions.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"managedFields": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"lastAppliedConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"fields": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"resource"},
+			},
+		},
+	}
+}
+
 func schema_clusterpedia_io_api_cluster_v1alpha2_SecretKeySelector(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -781,11 +1103,12 @@ func schema_clusterpedia_io_api_cluster_v1alpha2_SecretKeySelector(ref common.Re
 	}
 }
 
-func schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_Aggregation(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Type: []string{"object"},
+				Description: "Aggregation groups the objects of a resource or a collection resource by the keys and counts the objects of each group, e.g. the pods per cluster per phase, without exporting the objects to roll them up on the client side.",
+				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
 					"kind": {
 						SchemaProps: spec.SchemaProps{
@@ -801,69 +1124,104 @@ func schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResource(ref comm
 							Format:      "",
 						},
 					},
-					"metadata": {
+					"resource": {
 						SchemaProps: spec.SchemaProps{
-							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							Ref: ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
 						},
 					},
-					"resourceTypes": {
+					"collectionResource": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "CollectionResource is the name of the aggregated collection resource.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"groupBy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GroupBy are the keys of the groups, which are `cluster`, `namespace`, `label:<key>`, `field:<path>`, and `resource` for the collection resources.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
 									},
 								},
 							},
 						},
 					},
-					"items": {
+					"groups": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "Groups are sorted by the count in descending order.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Ref: ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.AggregationGroup"),
 									},
 								},
 							},
 						},
 					},
-					"continue": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"remainingItemCount": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int64",
-						},
-					},
 				},
-				Required: []string{"resourceTypes"},
+				Required: []string{"groupBy", "groups"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.AggregationGroup", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"},
 	}
 }
 
-func schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_AggregationGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"values": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Values are the values of the group by keys in order, the value is empty if the objects don't have the label or the field.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count is the number of the objects in the group.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"values", "count"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ApplyDiff(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ApplyDiff compares a manifest against the stored objects in the clusters, and returns what would change if the manifest is applied to the clusters.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
 					"apiVersion": {
@@ -873,278 +1231,540 @@ func schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceList(ref
 							Format:      "",
 						},
 					},
-					"metadata": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
 							Default: map[string]interface{}{},
-							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.ApplyDiffSpec"),
 						},
 					},
-					"items": {
+					"status": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Default: map[string]interface{}{},
-										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResource"),
-									},
-								},
-							},
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.ApplyDiffStatus"),
 						},
 					},
 				},
-				Required: []string{"items"},
+				Required: []string{"spec"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResource", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ApplyDiffSpec", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.ApplyDiffStatus"},
 	}
 }
 
-func schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceType(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ApplyDiffResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"group": {
+					"cluster": {
 						SchemaProps: spec.SchemaProps{
 							Default: "",
 							Type:    []string{"string"},
 							Format:  "",
 						},
 					},
-					"version": {
+					"exists": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Description: "Exists is false if the object does not exist in the cluster, and it would be created.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"kind": {
+					"changed": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Changed is true if the object would be changed by applying the manifest.",
+							Default:     false,
+							Type:        []string{"boolean"},
+							Format:      "",
 						},
 					},
-					"resource": {
+					"patch": {
 						SchemaProps: spec.SchemaProps{
-							Default: "",
-							Type:    []string{"string"},
-							Format:  "",
+							Description: "Patch is the JSON merge patch from the stored object to the applied object.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+					"error": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Status"),
 						},
 					},
 				},
-				Required: []string{"group", "version", "resource"},
+				Required: []string{"cluster", "exists", "changed"},
 			},
 		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Status", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 	}
 }
 
-func schema_clusterpedia_io_api_clusterpedia_v1beta1_ListOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ApplyDiffSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"kind": {
+					"clusters": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Clusters are the clusters to compare with, all clusters are compared if it is empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"apiVersion": {
+					"object": {
 						SchemaProps: spec.SchemaProps{
-							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
-							Type:        []string{"string"},
-							Format:      "",
+							Description: "Object is the manifest to be applied.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
-					"labelSelector": {
+				},
+				Required: []string{"object"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ApplyDiffStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"results": {
 						SchemaProps: spec.SchemaProps{
-							Description: "A selector to restrict the list of returned objects by their labels. Defaults to everything.",
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.ApplyDiffResult"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ApplyDiffResult"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_Archive(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID identifies the archive of the resource in the cluster, which is used to restore the archived object.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"fieldSelector": {
+					"namespace": {
 						SchemaProps: spec.SchemaProps{
-							Description: "A selector to restrict the list of returned objects by their fields. Defaults to everything.",
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is the reason the object is archived, `Deleted` or `Purged`.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"watch": {
+					"archivedAt": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Watch for changes to the described resources and return them as a stream of add, update, and remove notifications. Specify resourceVersion.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "ArchivedAt is the time the object is archived.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
-					"allowWatchBookmarks": {
+					"object": {
 						SchemaProps: spec.SchemaProps{
-							Description: "allowWatchBookmarks requests watch events with type \"BOOKMARK\". Servers that do not implement bookmarks may ignore this flag and bookmarks are sent at the server's discretion. Clients should not assume bookmarks are returned at any specific interval, nor may they assume the server will send any BOOKMARK event during a session. If this is not a watch, this field is ignored.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Object is the archived object with the storage version of the resource.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
-					"resourceVersion": {
+				},
+				Required: []string{"id", "name", "reason", "archivedAt", "object"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_Archives(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Archives lists the objects of a cluster archived to the object storage, which are deleted from the cluster or purged by the retention policies, the archived objects can be restored to the storage.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "resourceVersion sets a constraint on what resource versions a request may be served from. See https://kubernetes.io/docs/reference/using-api/api-concepts/#resource-versions for details.\n\nDefaults to unset",
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"resourceVersionMatch": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Description: "resourceVersionMatch determines how resourceVersion is applied to list calls. It is highly recommended that resourceVersionMatch be set for list calls where resourceVersion is set See https://kubernetes.io/docs/reference/using-api/api-concepts/#resource-versions for details.\n\nDefaults to unset",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"timeoutSeconds": {
+					"cluster": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Timeout for the list/watch call. This limits the duration of the call, regardless of any activity or inactivity.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
-					"limit": {
+					"resource": {
 						SchemaProps: spec.SchemaProps{
-							Description: "limit is a maximum number of responses to return for a list call. If more items exist, the server will set the `continue` field on the list metadata to a value that can be used with the same initial query to retrieve the next set of results. Setting a limit may return fewer than the requested amount of items (up to zero items) in the event all requested objects are filtered out and clients should only use the presence of the continue field to determine whether more results are available. Servers may choose not to support the limit argument and will return all of the available results. If limit is specified and the continue field is empty, clients may assume that no more results are available. This field is not supported if watch is true.\n\nThe server guarantees that the objects returned when using continue will be identical to issuing a single list call without a limit - that is, no objects created, modified, or deleted after the first request is issued will be included in any subsequent continued requests. This is sometimes referred to as a consistent snapshot, and ensures that a client that is using limit to receive smaller chunks of a very large result can ensure they see all possible objects. If objects are updated during a chunked list the version of the object that was present at the time the first list result was calculated is returned.",
-							Type:        []string{"integer"},
-							Format:      "int64",
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
 						},
 					},
-					"continue": {
+					"items": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The continue option should be set when retrieving more results from the server. Since this value is server defined, clients may only use the continue value from a previous query result with identical query parameters (except for the value of continue) and the server may reject a continue value it does not recognize. If the specified continue value is no longer valid whether due to expiration (generally five to fifteen minutes) or a configuration change on the server, the server will respond with a 410 ResourceExpired error together with a continue token. If the client needs a consistent list, it must restart their list without the continue field. Otherwise, the client may send another list request with the token received with the 410 error, the server will respond with a list starting from the next key, but from the latest snapshot, which is inconsistent from the previous list results - objects that are created, modified, or deleted after the first list request will be included in the response, as long as their keys are after the \"next key\".\n\nThis field is not supported when watch is true. Clients may start a watch from the last resourceVersion value returned by the server and not miss any modifications.",
-							Type:        []string{"string"},
-							Format:      "",
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.Archive"),
+									},
+								},
+							},
 						},
 					},
-					"sendInitialEvents": {
+				},
+				Required: []string{"cluster", "resource", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.Archive"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_BulkGet(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BulkGet fetches multiple objects of the clusters in one request, the results in the status are in the same order as the objects in the spec.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "`sendInitialEvents=true` may be set together with `watch=true`. In that case, the watch stream will begin with synthetic events to produce the current state of objects in the collection. Once all such events have been sent, a synthetic \"Bookmark\" event  will be sent. The bookmark will report the ResourceVersion (RV) corresponding to the set of objects, and be marked with `\"k8s.io/initial-events-end\": \"true\"` annotation. Afterwards, the watch stream will proceed as usual, sending watch events corresponding to changes (subsequent to the RV) to objects watched.\n\nWhen `sendInitialEvents` option is set, we require `resourceVersionMatch` option to also be set. The semantic of the watch request is as following: - `resourceVersionMatch` = NotOlderThan\n  is interpreted as \"data at least as new as the provided `resourceVersion`\"\n  and the bookmark event is send when the state is synced\n  to a `resourceVersion` at least as fresh as the one provided by the ListOptions.\n  If `resourceVersion` is unset, this is interpreted as \"consistent read\" and the\n  bookmark event is send when the state is synced at least to the moment\n  when request started being processed.\n- `resourceVersionMatch` set to any other value or unset\n  Invalid error is returned.\n\nDefaults to true if `resourceVersion=\"\"` or `resourceVersion=\"0\"` (for backward compatibility reasons) and to false otherwise.",
-							Type:        []string{"boolean"},
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"names": {
+					"apiVersion": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"clusters": {
+					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetSpec"),
 						},
 					},
-					"namespaces": {
+					"status": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetStatus"),
 						},
 					},
-					"orderby": {
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetSpec", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetStatus"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_BulkGetObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BulkGetObjectReference refers to an object by its cluster, namespace and name, or by its uid.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Cluster is required when getting the object by name.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"ownerUID": {
+					"group": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-					"ownerName": {
+					"version": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
-					"since": {
+					"resource": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
 						},
 					},
-					"before": {
+					"namespace": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-					"ownerGR": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-					"ownerSeniority": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int32",
-						},
-					},
-					"injectEvents": {
+					"uid": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "UID gets the object by its uid instead of its name, the cluster and namespace are optional to narrow the search.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"withContinue": {
+				},
+				Required: []string{"version", "resource"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_BulkGetResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"object": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Ref: ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 						},
 					},
-					"withRemainingCount": {
+					"error": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Description: "Error is the reason why the object can not be got, eg. NotFound.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Status"),
 						},
 					},
-					"onlyMetadata": {
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Status", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_BulkGetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"objects": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"boolean"},
-							Format: "",
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetObjectReference"),
+									},
+								},
+							},
 						},
 					},
-					"urlQuery": {
+				},
+				Required: []string{"objects"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetObjectReference"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_BulkGetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"results": {
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"object"},
-							AdditionalProperties: &spec.SchemaOrBool{
-								Allows: true,
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Type: []string{"array"},
-										Items: &spec.SchemaOrArray{
-											Schema: &spec.Schema{
-												SchemaProps: spec.SchemaProps{
-													Default: "",
-													Type:    []string{"string"},
-													Format:  "",
-												},
-											},
-										},
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetResult"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"urlQuery"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.BulkGetResult"},
 	}
 }
 
-func schema_clusterpedia_io_api_clusterpedia_v1beta1_Resources(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ClusterOverview(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"health": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Health is one of Healthy, Unhealthy and Unknown.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"syncedResources": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"objects": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"storageBytes": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"lastSyncedTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSyncedTime is the last time the objects of the cluster were synced to the storage.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"name", "health", "syncedResources", "objects"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ClusterScale(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterScale is the scale of the workload in a cluster, like the scale subresource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replicas is the desired replicas.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"currentReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CurrentReplicas is the observed replicas.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"readyReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadyReplicas is the replicas which are ready.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector is the label selector of the pods, in the string form.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cluster", "replicas"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
@@ -1164,6 +1784,3578 @@ func schema_clusterpedia_io_api_clusterpedia_v1beta1_Resources(ref common.Refere
 							Format:      "",
 						},
 					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"resourceTypes": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
+									},
+								},
+							},
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+									},
+								},
+							},
+						},
+					},
+					"groups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Groups are the items grouped by the `groupBy` query, the items are moved into the groups.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceGroup"),
+									},
+								},
+							},
+						},
+					},
+					"continue": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"remainingItemCount": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+				},
+				Required: []string{"resourceTypes"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceGroup", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CollectionResourceGroup is the items of a cluster, the cluster-scoped items are in the items and the namespaced items are grouped by the namespace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+									},
+								},
+							},
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceNamespaceGroup"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"cluster"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceNamespaceGroup", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResource"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResource", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceNamespaceGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"namespace"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_CollectionResourceType(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"group", "version", "resource"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_FieldChange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the path of the changed field, e.g. `spec.replicas` or `metadata.labels['app']`.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"old": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Old and New are the JSON values of the scalar field, they are omitted if the field is absent or not a scalar.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"new": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"path"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_FleetClusters(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FleetClusters counts the clusters by health, which is the `Ready` condition of the cluster.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"total": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"healthy": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"unhealthy": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"unknown": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+				},
+				Required: []string{"total", "healthy", "unhealthy", "unknown"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_FleetOverview(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FleetOverview summarizes all clusters of the installation, the kubectl plugin and the UIs show the overview without listing the clusters and the resources.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusters": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.FleetClusters"),
+						},
+					},
+					"syncedResources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncedResources is the number of the resources being synchronized in all clusters, a resource synchronized in multiple clusters is counted once for each cluster.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"objects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Objects is the number of the objects stored for all clusters.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"storageBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StorageBytes is the estimated size of the stored objects, it is not set if the storage layer doesn't estimate the size.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"syncLag": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SyncLag is the distribution of the time since the objects of each cluster were last synced to the storage, the clusters which have not synced any object are not included.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.SyncLagBucket"),
+									},
+								},
+							},
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.ClusterOverview"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"clusters", "syncedResources", "objects", "syncLag", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ClusterOverview", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.FleetClusters", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.SyncLagBucket"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ListOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"labelSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A selector to restrict the list of returned objects by their labels. Defaults to everything.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fieldSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A selector to restrict the list of returned objects by their fields. Defaults to everything.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"watch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Watch for changes to the described resources and return them as a stream of add, update, and remove notifications. Specify resourceVersion.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"allowWatchBookmarks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "allowWatchBookmarks requests watch events with type \"BOOKMARK\". Servers that do not implement bookmarks may ignore this flag and bookmarks are sent at the server's discretion. Clients should not assume bookmarks are returned at any specific interval, nor may they assume the server will send any BOOKMARK event during a session. If this is not a watch, this field is ignored.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"resourceVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceVersion sets a constraint on what resource versions a request may be served from. See https://kubernetes.io/docs/reference/using-api/api-concepts/#resource-versions for details.\n\nDefaults to unset",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourceVersionMatch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceVersionMatch determines how resourceVersion is applied to list calls. It is highly recommended that resourceVersionMatch be set for list calls where resourceVersion is set See https://kubernetes.io/docs/reference/using-api/api-concepts/#resource-versions for details.\n\nDefaults to unset",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout for the list/watch call. This limits the duration of the call, regardless of any activity or inactivity.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "limit is a maximum number of responses to return for a list call. If more items exist, the server will set the `continue` field on the list metadata to a value that can be used with the same initial query to retrieve the next set of results. Setting a limit may return fewer than the requested amount of items (up to zero items) in the event all requested objects are filtered out and clients should only use the presence of the continue field to determine whether more results are available. Servers may choose not to support the limit argument and will return all of the available results. If limit is specified and the continue field is empty, clients may assume that no more results are available. This field is not supported if watch is true.\n\nThe server guarantees that the objects returned when using continue will be identical to issuing a single list call without a limit - that is, no objects created, modified, or deleted after the first request is issued will be included in any subsequent continued requests. This is sometimes referred to as a consistent snapshot, and ensures that a client that is using limit to receive smaller chunks of a very large result can ensure they see all possible objects. If objects are updated during a chunked list the version of the object that was present at the time the first list result was calculated is returned.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"continue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The continue option should be set when retrieving more results from the server. Since this value is server defined, clients may only use the continue value from a previous query result with identical query parameters (except for the value of continue) and the server may reject a continue value it does not recognize. If the specified continue value is no longer valid whether due to expiration (generally five to fifteen minutes) or a configuration change on the server, the server will respond with a 410 ResourceExpired error together with a continue token. If the client needs a consistent list, it must restart their list without the continue field. Otherwise, the client may send another list request with the token received with the 410 error, the server will respond with a list starting from the next key, but from the latest snapshot, which is inconsistent from the previous list results - objects that are created, modified, or deleted after the first list request will be included in the response, as long as their keys are after the \"next key\".\n\nThis field is not supported when watch is true. Clients may start a watch from the last resourceVersion value returned by the server and not miss any modifications.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sendInitialEvents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "`sendInitialEvents=true` may be set together with `watch=true`. In that case, the watch stream will begin with synthetic events to produce the current state of objects in the collection. Once all such events have been sent, a synthetic \"Bookmark\" event  will be sent. The bookmark will report the ResourceVersion (RV) corresponding to the set of objects, and be marked with `\"k8s.io/initial-events-end\": \"true\"` annotation. Afterwards, the watch stream will proceed as usual, sending watch events corresponding to changes (subsequent to the RV) to objects watched.\n\nWhen `sendInitialEvents` option is set, we require `resourceVersionMatch` option to also be set. The semantic of the watch request is as following: - `resourceVersionMatch` = NotOlderThan\n  is interpreted as \"data at least as new as the provided `resourceVersion`\"\n  and the bookmark event is send when the state is synced\n  to a `resourceVersion` at least as fresh as the one provided by the ListOptions.\n  If `resourceVersion` is unset, this is interpreted as \"consistent read\" and the\n  bookmark event is send when the state is synced at least to the moment\n  when request started being processed.\n- `resourceVersionMatch` set to any other value or unset\n  Invalid error is returned.\n\nDefaults to true if `resourceVersion=\"\"` or `resourceVersion=\"0\"` (for backward compatibility reasons) and to false otherwise.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"names": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names is a comma-separated list of resource names to match. The same as the search label `search.clusterpedia.io/names`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterNames is a comma-separated list of member clusters to search in. The same as the search label `search.clusterpedia.io/clusters`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces is a comma-separated list of namespaces to search in. The same as the search label `search.clusterpedia.io/namespaces`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"orderby": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OrderBy is a comma-separated list of sort fields, a field followed by ' desc' is sorted in descending order, eg. 'cluster,created_at desc'. The order of the fields is preserved, unlike the search label `search.clusterpedia.io/orderby`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ownerUID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerUID searches the resources owned by the resource with this uid, it requires exactly one cluster. The same as the search label `search.clusterpedia.io/owner-uid`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ownerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerName searches the resources owned by the resource with this name, it requires exactly one cluster. The same as the search label `search.clusterpedia.io/owner-name`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"since": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Since limits the creation time of resources to be at or after this time, in RFC3339, '2006-01-02 15:04:05', '2006-01-02' or unix timestamp(s or ms) format. The same as the search label `search.clusterpedia.io/since`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"before": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Before limits the creation time of resources to be before this time, in the same formats as Since. The same as the search label `search.clusterpedia.io/before`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ownerGR": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerGroupResource is the group resource of the owner when searching by OwnerName, eg. 'deployments.apps'. The same as the search label `search.clusterpedia.io/owner-gr`.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ownerSeniority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerSeniority is the number of ownership levels between the owner and the resources, eg. 1 searches the pods of a deployment through its replicasets. The same as the search label `search.clusterpedia.io/owner-seniority`.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"injectEvents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InjectEvents injects the events of the resources into the annotation `shadow.clusterpedia.io/events`. The same as the search label `search.clusterpedia.io/inject-events`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"withContinue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WithContinue returns the continue token for the next page when the limit is reached. The same as the search label `search.clusterpedia.io/with-continue`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"withRemainingCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WithRemainingCount returns the count of the remaining items. The same as the search label `search.clusterpedia.io/with-remaining-count`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"onlyMetadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OnlyMetadata only returns the metadata of the resources.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"dedup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Dedup groups the identical resources replicated to multiple clusters into a single result, the clusters of the result are set into the annotation `shadow.clusterpedia.io/dedup-clusters`. The same as the search label `search.clusterpedia.io/dedup`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"countOnly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CountOnly only returns the count of the matched resources as the remainingItemCount of an empty list, the limit and the continue are ignored. The same as the search label `search.clusterpedia.io/count-only`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"resourceScope": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceScope limits the collection resource to the cluster-scoped or the namespaced resources, the values are 'Cluster' and 'Namespaced', empty lists both. It is only for the collection resources.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"groupBy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GroupBy groups the items of the collection resource into the groups of the response, 'cluster' groups them by the cluster and then the namespace, and the items are sorted by the cluster and the namespace if the orderby is not set. It is only for the collection resources.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"urlQuery": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type: []string{"array"},
+										Items: &spec.SchemaOrArray{
+											Schema: &spec.Schema{
+												SchemaProps: spec.SchemaProps{
+													Default: "",
+													Type:    []string{"string"},
+													Format:  "",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"urlQuery"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_MetadataKey(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count is the number of the objects with the key.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"values": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Values is the cardinality of the values of the key.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"key", "count", "values"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_MetadataKeys(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MetadataKeys is the inventory of the label and annotation keys observed in the objects of a resource.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cluster is empty if the keys are observed in all clusters.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
+						},
+					},
+					"objects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Objects is the number of the observed objects.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.MetadataKey"),
+									},
+								},
+							},
+						},
+					},
+					"annotations": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.MetadataKey"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"resource", "objects"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.MetadataKey"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_NamespaceCluster(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"objects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Objects is the number of the objects in the namespace of the cluster.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"name", "objects"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_NamespaceInventory(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NamespaceInventory lists the namespaces of all clusters with the clusters each exists in.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.NamespaceInventoryItem"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.NamespaceInventoryItem"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_NamespaceInventoryItem(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"objects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Objects is the number of the objects in the namespace of all clusters.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"clusters": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.NamespaceCluster"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name", "objects", "clusters"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.NamespaceCluster"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ReplicaSummaries(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ReplicaSummaries lists the desired and ready replicas of the workloads in the clusters, autoscaling dashboards read the replicas without loading the full objects.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.ReplicaSummary"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"resource", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.ReplicaSummary"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ReplicaSummary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ReplicaSummary aggregates the replicas of the workloads with the same namespace and name in the clusters.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"desiredReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DesiredReplicas is the sum of the desired replicas in the clusters.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"readyReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadyReplicas is the sum of the ready replicas in the clusters.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"clusters": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.ClusterScale"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name", "desiredReplicas", "readyReplicas", "clusters"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ClusterScale"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ResourceChange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resourceVersion": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"previousResourceVersion": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time is the time the change is recorded.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"fields": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.FieldChange"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"resourceVersion", "previousResourceVersion", "time", "fields"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.FieldChange", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ResourceHistory(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceHistory lists the field-level changes between the consecutive versions of an object in a cluster, change reviews read the changed fields without storing the full revisions.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"changes": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceChange"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"cluster", "resource", "name", "changes"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceChange"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ResourceRevision(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"resourceVersion": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"previousResourceVersion": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time is the time the revision is recorded.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"resourceVersion", "previousResourceVersion", "time"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ResourceSnapshot(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ResourceSnapshot is the stored row of an object in a cluster read in one read-only transaction, the mismatches between the member cluster and the storage are debugged without accessing the storage directly.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"objectKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObjectKind is the kind of the object, the kind of the snapshot is ResourceSnapshot.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"uid": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"resourceVersion": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"ownerUID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerUID is the uid of the controller of the object.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"specHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SpecHash is the hash of the normalized object stored with the object, it is empty if the object is stored before the hash is introduced or is encrypted.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"formatVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FormatVersion is the storage format of the stored row.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"compression": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Compression, Encoding and Encryption are how the stored payload is compressed, encoded and encrypted, they are empty if the payload is the object in json.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"encoding": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"encryption": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"storedBytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StoredBytes is the size of the stored payload.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"object": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Object is the stored object decoded from the payload, with the storage version of the resource.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+					"eventResourceVersions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EventResourceVersions are the resource versions of the events of the object keyed by the events.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"createdTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CreatedTime is the creation time of the object, SyncedTime is the last time the row is written by the synchro.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"syncedTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"deletedTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletedTime is the deletion timestamp of the object.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"removedTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovedTime is the time the object is deleted from the cluster, the row is kept as a tombstone.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"revisions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Revisions are the versions of the object recorded by the storage sorted by the time in ascending order, they are empty if the storage doesn't record the changes of the objects.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceRevision"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"cluster", "resource", "name", "objectKind", "uid", "resourceVersion", "formatVersion", "storedBytes", "object", "createdTime", "syncedTime"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceRevision", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_ResourceStorageUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
+						},
+					},
+					"objects": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+					"bytes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bytes is the estimated size of the stored objects, it is not set if the storage layer doesn't estimate the size.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"cluster", "resource", "objects"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_Resources(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_SchemaValidation(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SchemaValidation validates the stored custom resources in the clusters against the schema of a CRD, and returns the objects which would fail the validation, e.g. before the new version of the CRD is rolled out to the clusters.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.SchemaValidationSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.SchemaValidationStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.SchemaValidationSpec", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.SchemaValidationStatus"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_SchemaValidationResult(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"errors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Errors are the validation errors of the object.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"cluster", "name", "errors"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_SchemaValidationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"crd": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CRD is the CustomResourceDefinition whose schema validates the objects, e.g. the new version of the CRD.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+					"crdName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CRDName is the name of the CustomResourceDefinition synchronized from the ReferenceCluster, whose schema validates the objects if the CRD is not set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"referenceCluster": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the version of the CRD whose schema validates the objects, it is the storage version of the CRD by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"objectVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObjectVersion is the version of the stored objects to be validated, it is the Version by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Clusters are the clusters whose objects are validated, the objects of all clusters are validated if it is empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_SchemaValidationStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"validated": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Validated is the number of the validated objects.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"invalid": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Invalid is the number of the objects which fail the validation.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"results": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Results are the objects which fail the validation, sorted by the cluster, the namespace and the name.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.SchemaValidationResult"),
+									},
+								},
+							},
+						},
+					},
+					"truncated": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Truncated is true if the results are truncated, the Invalid is the number of all invalid objects.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"validated", "invalid"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.SchemaValidationResult"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_SpecHash(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"hash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Hash is the hash of the object without the status and the fields set by the cluster.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"differs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Differs is true if the hash is different from the reference.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cluster", "hash"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_SpecHashes(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SpecHashes lists the spec hashes of an object in the clusters, drift detection tools find the clusters where the object differs from the reference hash.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"reference": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reference is the hash that the spec hashes are compared with.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.SpecHash"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"resource", "name", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.SpecHash"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_StorageUsage(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "StorageUsage lists the number and the size of the stored objects of each resource in each cluster, the capacity of the storage is planned without accessing the storage directly.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"collectedTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CollectedTime is the time when the usage is collected from the storage.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceStorageUsage"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"collectedTime", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.ResourceStorageUsage", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_SyncLagBucket(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"lessThan": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LessThan is the upper bound of the bucket, the last bucket has no upper bound.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"clusters": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int64",
+						},
+					},
+				},
+				Required: []string{"clusters"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_Tombstone(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"removedAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemovedAt is the time the object is deleted from the cluster.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"object": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Object is the deleted object with the storage version of the resource.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+				},
+				Required: []string{"name", "removedAt", "object"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta1_Tombstones(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Tombstones lists the objects deleted from a cluster which are kept by the storage, incident investigations find what was running in the cluster before the objects were deleted.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta1.Tombstone"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"cluster", "resource", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta1.CollectionResourceType", "github.com/clusterpedia-io/api/clusterpedia/v1beta1.Tombstone"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta2_CollectionResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"resourceTypes": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResourceType"),
+									},
+								},
+							},
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+									},
+								},
+							},
+						},
+					},
+					"groups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Groups are the items grouped by the `groupBy` query, the items are moved into the groups.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResourceGroup"),
+									},
+								},
+							},
+						},
+					},
+					"continue": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"remainingItemCount": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+				},
+				Required: []string{"resourceTypes"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResourceGroup", "github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResourceType", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta2_CollectionResourceGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CollectionResourceGroup is the items of a cluster, the cluster-scoped items are in the items and the namespaced items are grouped by the namespace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cluster": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+									},
+								},
+							},
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResourceNamespaceGroup"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"cluster"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResourceNamespaceGroup", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta2_CollectionResourceList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResource"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/clusterpedia/v1beta2.CollectionResource", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta2_CollectionResourceNamespaceGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"namespace"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta2_CollectionResourceType(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"group", "version", "resource"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta2_ListOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ListOptions is the query options of searching the resources.\n\nUnlike v1beta1, the search conditions are only specified by the query parameters, the search labels `search.clusterpedia.io/*` in the label selector are not supported.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"labelSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A selector to restrict the list of returned objects by their labels. Defaults to everything.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fieldSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "A selector to restrict the list of returned objects by their fields. Defaults to everything.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"watch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Watch for changes to the described resources and return them as a stream of add, update, and remove notifications. Specify resourceVersion.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"allowWatchBookmarks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "allowWatchBookmarks requests watch events with type \"BOOKMARK\". Servers that do not implement bookmarks may ignore this flag and bookmarks are sent at the server's discretion. Clients should not assume bookmarks are returned at any specific interval, nor may they assume the server will send any BOOKMARK event during a session. If this is not a watch, this field is ignored.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"resourceVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceVersion sets a constraint on what resource versions a request may be served from. See https://kubernetes.io/docs/reference/using-api/api-concepts/#resource-versions for details.\n\nDefaults to unset",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resourceVersionMatch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "resourceVersionMatch determines how resourceVersion is applied to list calls. It is highly recommended that resourceVersionMatch be set for list calls where resourceVersion is set See https://kubernetes.io/docs/reference/using-api/api-concepts/#resource-versions for details.\n\nDefaults to unset",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeoutSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout for the list/watch call. This limits the duration of the call, regardless of any activity or inactivity.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"limit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "limit is a maximum number of responses to return for a list call. If more items exist, the server will set the `continue` field on the list metadata to a value that can be used with the same initial query to retrieve the next set of results. Setting a limit may return fewer than the requested amount of items (up to zero items) in the event all requested objects are filtered out and clients should only use the presence of the continue field to determine whether more results are available. Servers may choose not to support the limit argument and will return all of the available results. If limit is specified and the continue field is empty, clients may assume that no more results are available. This field is not supported if watch is true.\n\nThe server guarantees that the objects returned when using continue will be identical to issuing a single list call without a limit - that is, no objects created, modified, or deleted after the first request is issued will be included in any subsequent continued requests. This is sometimes referred to as a consistent snapshot, and ensures that a client that is using limit to receive smaller chunks of a very large result can ensure they see all possible objects. If objects are updated during a chunked list the version of the object that was present at the time the first list result was calculated is returned.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"continue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The continue option should be set when retrieving more results from the server. Since this value is server defined, clients may only use the continue value from a previous query result with identical query parameters (except for the value of continue) and the server may reject a continue value it does not recognize. If the specified continue value is no longer valid whether due to expiration (generally five to fifteen minutes) or a configuration change on the server, the server will respond with a 410 ResourceExpired error together with a continue token. If the client needs a consistent list, it must restart their list without the continue field. Otherwise, the client may send another list request with the token received with the 410 error, the server will respond with a list starting from the next key, but from the latest snapshot, which is inconsistent from the previous list results - objects that are created, modified, or deleted after the first list request will be included in the response, as long as their keys are after the \"next key\".\n\nThis field is not supported when watch is true. Clients may start a watch from the last resourceVersion value returned by the server and not miss any modifications.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"sendInitialEvents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "`sendInitialEvents=true` may be set together with `watch=true`. In that case, the watch stream will begin with synthetic events to produce the current state of objects in the collection. Once all such events have been sent, a synthetic \"Bookmark\" event  will be sent. The bookmark will report the ResourceVersion (RV) corresponding to the set of objects, and be marked with `\"k8s.io/initial-events-end\": \"true\"` annotation. Afterwards, the watch stream will proceed as usual, sending watch events corresponding to changes (subsequent to the RV) to objects watched.\n\nWhen `sendInitialEvents` option is set, we require `resourceVersionMatch` option to also be set. The semantic of the watch request is as following: - `resourceVersionMatch` = NotOlderThan\n  is interpreted as \"data at least as new as the provided `resourceVersion`\"\n  and the bookmark event is send when the state is synced\n  to a `resourceVersion` at least as fresh as the one provided by the ListOptions.\n  If `resourceVersion` is unset, this is interpreted as \"consistent read\" and the\n  bookmark event is send when the state is synced at least to the moment\n  when request started being processed.\n- `resourceVersionMatch` set to any other value or unset\n  Invalid error is returned.\n\nDefaults to true if `resourceVersion=\"\"` or `resourceVersion=\"0\"` (for backward compatibility reasons) and to false otherwise.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"names": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names are the resource names to match, the query parameter can be repeated.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"clusters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterNames are the member clusters to search in, the query parameter can be repeated.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"namespaces": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespaces are the namespaces to search in, the query parameter can be repeated.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"orderby": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OrderByFields are the sort fields in order, a field followed by ' desc' is sorted in descending order, the query parameter can be repeated, eg. 'orderby=cluster&orderby=created_at%20desc'.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"ownerUID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerUID searches the resources owned by the resource with this uid, it requires exactly one cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ownerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerName searches the resources owned by the resource with this name, it requires exactly one cluster.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ownerGR": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerGroupResource is the group resource of the owner when searching by OwnerName, eg. 'deployments.apps'.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"ownerSeniority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OwnerSeniority is the number of ownership levels between the owner and the resources, eg. 1 searches the pods of a deployment through its replicasets.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"since": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Since limits the creation time of resources to be at or after this time, in RFC3339 format.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"before": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Before limits the creation time of resources to be before this time, in RFC3339 format.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"injectEvents": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InjectEvents injects the events of the resources into the annotation `shadow.clusterpedia.io/events`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"withContinue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WithContinue returns the continue token for the next page when the limit is reached, default is true.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"withRemainingCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WithRemainingCount returns the count of the remaining items.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"onlyMetadata": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OnlyMetadata only returns the metadata of the resources.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"dedup": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Dedup groups the identical resources replicated to multiple clusters into a single result, the clusters of the result are set into the annotation `shadow.clusterpedia.io/dedup-clusters`.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"countOnly": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CountOnly only returns the count of the matched resources as the remainingItemCount of an empty list, the limit and the continue are ignored.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"resourceScope": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceScope limits the collection resource to the cluster-scoped or the namespaced resources, the values are 'Cluster' and 'Namespaced', empty lists both. It is only for the collection resources.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"groupBy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GroupBy groups the items of the collection resource into the groups of the response, 'cluster' groups them by the cluster and then the namespace, and the items are sorted by the cluster and the namespace if the orderby is not set. It is only for the collection resources.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"urlQuery": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type: []string{"array"},
+										Items: &spec.SchemaOrArray{
+											Schema: &spec.Schema{
+												SchemaProps: spec.SchemaProps{
+													Default: "",
+													Type:    []string{"string"},
+													Format:  "",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"urlQuery"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_clusterpedia_io_api_clusterpedia_v1beta2_Resources(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_ClusterpediaConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterpediaConfiguration holds the settings which can be tuned at runtime, the components watch the configuration named by their `--configuration-name` flag and apply the changes without restarting. The unset fields fall back to the flags and the feature gates of the components.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/config/v1alpha1.ClusterpediaConfigurationSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/config/v1alpha1.ClusterpediaConfigurationSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_ClusterpediaConfigurationList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/config/v1alpha1.ClusterpediaConfiguration"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/config/v1alpha1.ClusterpediaConfiguration", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_ClusterpediaConfigurationSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"list": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List is the server-side defaults and guardrails of the list requests, it is applied by the apiserver.",
+							Ref:         ref("github.com/clusterpedia-io/api/config/v1alpha1.ListConfiguration"),
+						},
+					},
+					"prune": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Prune is the fields pruned from the objects before they are saved, it is applied by the clustersynchro manager.",
+							Ref:         ref("github.com/clusterpedia-io/api/config/v1alpha1.PruneConfiguration"),
+						},
+					},
+					"retention": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retention is how long the terminated objects are retained, it is applied by the clustersynchro manager.",
+							Ref:         ref("github.com/clusterpedia-io/api/config/v1alpha1.RetentionConfiguration"),
+						},
+					},
+					"enrichment": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Enrichment is the cluster metadata stamped on the objects before they are saved, it is applied by the clustersynchro manager.",
+							Ref:         ref("github.com/clusterpedia-io/api/config/v1alpha1.EnrichmentConfiguration"),
+						},
+					},
+					"masking": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Masking is the sensitive fields redacted from the objects, the rules of the `Storage` stage are applied by the clustersynchro manager before the objects are saved, and the rules of the `Serving` stage are applied by the apiserver before the objects are returned.",
+							Ref:         ref("github.com/clusterpedia-io/api/config/v1alpha1.MaskingConfiguration"),
+						},
+					},
+					"filters": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Filters are the WASM functions which filter and transform the listed objects, they are applied by the apiserver to the lists requested with the `search.clusterpedia.io/filter=<name>` search label.",
+							Ref:         ref("github.com/clusterpedia-io/api/config/v1alpha1.FilterConfiguration"),
+						},
+					},
+					"resourceMappings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResourceMappings map the custom resources of the member clusters to the resources they are stored and served as, e.g. the forked custom resource of a vendored group is treated as the upstream resource, so that it is queried uniformly across the clusters. They are applied by the clustersynchro manager when the resources of the clusters are negotiated, the first rule matching the resource is applied.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/config/v1alpha1.ResourceMappingRule"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/config/v1alpha1.EnrichmentConfiguration", "github.com/clusterpedia-io/api/config/v1alpha1.FilterConfiguration", "github.com/clusterpedia-io/api/config/v1alpha1.ListConfiguration", "github.com/clusterpedia-io/api/config/v1alpha1.MaskingConfiguration", "github.com/clusterpedia-io/api/config/v1alpha1.PruneConfiguration", "github.com/clusterpedia-io/api/config/v1alpha1.ResourceMappingRule", "github.com/clusterpedia-io/api/config/v1alpha1.RetentionConfiguration"},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_EnrichmentConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"clusterLabels": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterLabels are the keys of the PediaCluster labels stamped on the objects as the `cluster-labels.shadow.clusterpedia.io/<name>` annotations, the name is the label key without the prefix, e.g. `region` of `topology.kubernetes.io/region`. The annotations can be queried by the label selector, e.g. 'cluster-labels.shadow.clusterpedia.io/region=us-east-1'. The change applies to the objects when they are synchronized again.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_FilterConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"functions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Functions are the filter functions selected by their names",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/config/v1alpha1.FilterFunction"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/config/v1alpha1.FilterFunction"},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_FilterFunction(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the value of the `search.clusterpedia.io/filter` search label which selects the function",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"module": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Module is the WebAssembly binary of the function. The module can't import any host function, and it exports the `memory`, the `alloc(size i32) i32` function allocating the input, and the `filter(ptr i32, size i32) i64` function which is called with the JSON of each listed object. The `filter` returns 0 to drop the object, 1 to keep it, or the pointer shifted left by 32 bits and ORed with the size of the JSON of the transformed object.",
+							Type:        []string{"string"},
+							Format:      "byte",
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout is the time limit of running the function over the objects of a list request, it is 1s by default.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"memoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemoryLimit is the memory limit of the function, it is 16Mi by default and is rounded up to the 64Ki pages.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+				Required: []string{"name", "module"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_ListConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"defaultLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultLimit is the limit of the list requests that don't specify the limit, 0 means no default limit.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxLimit": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxLimit is the maximum limit of the list requests, 0 means no maximum limit.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"defaultOrderBy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultOrderBy is the order of the list requests that don't specify the orderby, formatted as the orderby query, e.g. 'namespace,name desc'.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterRequiredGroups": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterRequiredGroups are the api groups whose resources can only be listed with the clusters specified, 'core' is the core group.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_MappedResource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+				},
+				Required: []string{"group", "resource"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_MaskingConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replacement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Replacement replaces the string values of the masked fields, it is `REDACTED` by default, which is also a valid base64 value of the bytes fields, e.g. the data of the secrets.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rules are the masking rules of the resources, the fields matched by any of the rules are masked.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/config/v1alpha1.MaskingRule"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/config/v1alpha1.MaskingRule"},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_MaskingRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Group is the group of the resource, the empty group is the core group and `*` matches all groups.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the version of the resource, the empty version matches all versions.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resource is the resource, `*` matches all resources of the group.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"stage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Stage is when the fields are masked, it is `Storage` by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"fields": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Fields are the paths of the masked fields, formatted as the dot-separated field names, e.g. `spec.token`. The string values of the fields and the string values nested in them are replaced.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"annotations": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Annotations are the keys of the masked annotations",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"envNames": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvNames are the regular expressions of the names of the environment variables of the containers, the values of the matched environment variables are masked, e.g. `(?i)token|password|secret`. The containers of the pods and the pod templates of the workloads are masked.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"envValues": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvValues are the regular expressions of the values of the environment variables of the containers, the matched values are masked, e.g. `^eyJ` for the JWT tokens.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"resource"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_PruneConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"managedFields": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ManagedFields prunes the `metadata.managedFields` of the objects, it overrides the PruneManagedFields feature gate.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"lastAppliedConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastAppliedConfiguration prunes the `kubectl.kubernetes.io/last-applied-configuration` annotation of the objects, it overrides the PruneLastAppliedConfiguration feature gate.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"fields": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Fields are the paths of the fields pruned from the objects, formatted as the dot-separated field names, e.g. `status.images`. The dots in the field names are escaped by `\\`, e.g. `metadata.annotations.example\\.io/note`.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources override the prune configuration for the resources, their unset fields fall back to the configuration, and their fields are pruned in addition to the fields of the configuration.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/config/v1alpha1.ResourcePruneConfiguration"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/config/v1alpha1.ResourcePruneConfiguration"},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_ResourceMappingRule(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"from": {
+						SchemaProps: spec.SchemaProps{
+							Description: "From is the resource of the member clusters, the empty version matches all versions.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/clusterpedia-io/api/config/v1alpha1.MappedResource"),
+						},
+					},
+					"to": {
+						SchemaProps: spec.SchemaProps{
+							Description: "To is the resource which the objects are stored and served as, the empty version keeps the version of the member clusters. The kind of the objects is kept, only the group and the version of the objects are changed.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("github.com/clusterpedia-io/api/config/v1alpha1.MappedResource"),
+						},
+					},
+				},
+				Required: []string{"from", "to"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/config/v1alpha1.MappedResource"},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_ResourcePruneConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Group is the group of the resource, the empty group is the core group.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the version of the resource, the empty version matches all versions.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"managedFields": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"lastAppliedConfiguration": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"fields": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"resource"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_config_v1alpha1_RetentionConfiguration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"terminatedPodsTTL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TerminatedPodsTTL is how long the terminated pods are retained, 0 means they are retained until they are deleted. It overrides the `--terminated-pods-ttl` flag, the change applies to the pods when they are synchronized again.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"completedJobsTTL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CompletedJobsTTL is how long the completed jobs are retained, 0 means they are retained until they are deleted. It overrides the `--completed-jobs-ttl` flag, the change applies to the jobs when they are synchronized again.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_clusterpedia_io_api_operator_v1alpha1_ClusterSynchroManagerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image overrides the image of the component, the version is not applied to the overridden image.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"featureGates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FeatureGates are the feature gates of the component.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: false,
+										Type:    []string{"boolean"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"extraArgs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ExtraArgs are appended to the command line arguments of the component, eg. '--v=3'.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"shards": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Shards are the sharding names of the clustersynchro managers, a clustersynchro manager is deployed for each shard and syncs the clusters with the same sharding name. The clustersynchro manager without a sharding name is always deployed for the clusters without a sharding name.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_operator_v1alpha1_Clusterpedia(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Clusterpedia declares an installation of clusterpedia in its namespace, the operator manages the deployments of the components and the storage config of the installation.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/operator/v1alpha1.ClusterpediaSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/operator/v1alpha1.ClusterpediaStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/operator/v1alpha1.ClusterpediaSpec", "github.com/clusterpedia-io/api/operator/v1alpha1.ClusterpediaStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_clusterpedia_io_api_operator_v1alpha1_ClusterpediaList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterpediaList contains a list of Clusterpedia",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/operator/v1alpha1.Clusterpedia"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/operator/v1alpha1.Clusterpedia", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_clusterpedia_io_api_operator_v1alpha1_ClusterpediaSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the version of the components, eg. 'v0.9.0'. The components are upgraded in order when the version is changed, the clustersynchro managers first, then the apiserver and the controller manager.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"imageRepository": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImageRepository is the repository of the images of the components, the image of a component is '<imageRepository>/<component>:<version>'.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceAccountName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountName is the service account of the components, the operator creates it if it does not exist, the permissions are bound by the installation manifests.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"storage": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/operator/v1alpha1.StorageSpec"),
+						},
+					},
+					"apiserver": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/operator/v1alpha1.ComponentSpec"),
+						},
+					},
+					"clusterSynchroManager": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/operator/v1alpha1.ClusterSynchroManagerSpec"),
+						},
+					},
+					"controllerManager": {
+						SchemaProps: spec.SchemaProps{
+							Default: map[string]interface{}{},
+							Ref:     ref("github.com/clusterpedia-io/api/operator/v1alpha1.ComponentSpec"),
+						},
+					},
+				},
+				Required: []string{"version", "storage"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/operator/v1alpha1.ClusterSynchroManagerSpec", "github.com/clusterpedia-io/api/operator/v1alpha1.ComponentSpec", "github.com/clusterpedia-io/api/operator/v1alpha1.StorageSpec"},
+	}
+}
+
+func schema_clusterpedia_io_api_operator_v1alpha1_ClusterpediaStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version is the version that all of the components have been rolled out to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"type",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"components": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/clusterpedia-io/api/operator/v1alpha1.ComponentStatus"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/clusterpedia-io/api/operator/v1alpha1.ComponentStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition"},
+	}
+}
+
+func schema_clusterpedia_io_api_operator_v1alpha1_ComponentSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image overrides the image of the component, the version is not applied to the overridden image.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"featureGates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FeatureGates are the feature gates of the component.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: false,
+										Type:    []string{"boolean"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"extraArgs": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ExtraArgs are appended to the command line arguments of the component, eg. '--v=3'.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_operator_v1alpha1_ComponentStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the deployment of the component.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"component": {
+						SchemaProps: spec.SchemaProps{
+							Default: "",
+							Type:    []string{"string"},
+							Format:  "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+					"updatedReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+					"availableReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Default: 0,
+							Type:    []string{"integer"},
+							Format:  "int32",
+						},
+					},
+				},
+				Required: []string{"name", "component"},
+			},
+		},
+	}
+}
+
+func schema_clusterpedia_io_api_operator_v1alpha1_StorageSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the storage layer, eg. 'internal'.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"config": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Config is the content of the storage config file, the operator saves it into a secret mounted by the apiserver and the clustersynchro managers. Config and ConfigSecretName are mutually exclusive.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"configSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConfigSecretName is the name of an existing secret, whose key 'storage-config.yaml' is the storage config file.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"envSecretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvSecretName is the name of an existing secret, whose keys are set as the environment variables of the apiserver and the clustersynchro managers, eg. 'DB_PASSWORD'.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -0,0 +1,288 @@
+package kubeapiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/api/clusterpedia/fields"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	clusterpediastorage "github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const AggregationPath = "/aggregation"
+
+// AggregationHandler serves the counts of the objects grouped by the keys, e.g. the pods per cluster per phase,
+// so that the rollups are not computed on the client side from the exported objects.
+//
+// The resource is specified by the `group`, `version` and `resource` queries, or the collection resource by the
+// `collectionResource` query, and the keys by the `groupBy` query, e.g. `groupBy=cluster,field:status.phase`.
+// The objects are filtered by the search queries of the list, and the `limit` query limits the number of the groups.
+//
+// The storage groups the objects if it is a ResourceAggregator, otherwise the objects of the resource are listed
+// and grouped in memory.
+type AggregationHandler struct {
+	rest        *RESTManager
+	discovery   *discovery.DiscoveryManager
+	collections map[string]clusterpediastorage.CollectionResourceStorage
+}
+
+func NewAggregationHandler(rest *RESTManager, discovery *discovery.DiscoveryManager, collections map[string]clusterpediastorage.CollectionResourceStorage) *AggregationHandler {
+	return &AggregationHandler{rest: rest, discovery: discovery, collections: collections}
+}
+
+func (h *AggregationHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "aggregation"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	query := req.URL.Query()
+	keys, groupBy, err := parseGroupBy(query["groupBy"])
+	if err != nil {
+		responsewriters.ErrorNegotiated(apierrors.NewBadRequest(err.Error()), Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	version, ok := request.VersionFrom(req.Context())
+	if !ok {
+		version = v1beta1.SchemeGroupVersion
+	}
+	opts := &internal.ListOptions{}
+	if err := clusterpediascheme.DecodeListOptions(query, version, opts); err != nil {
+		responsewriters.ErrorNegotiated(apierrors.NewBadRequest(err.Error()), Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+	cluster := request.ClusterNameValue(req.Context())
+	if cluster != "" {
+		opts.ClusterNames = []string{cluster}
+	}
+
+	result := &v1beta1.Aggregation{GroupBy: keys}
+	result.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("Aggregation"))
+	if name := query.Get("collectionResource"); name != "" {
+		result.CollectionResource = name
+		result.Groups, err = h.aggregateCollectionResource(req.Context(), name, opts, groupBy)
+	} else {
+		gvr := schema.GroupVersionResource{Group: query.Get("group"), Version: query.Get("version"), Resource: query.Get("resource")}
+		if gvr.Version == "" || gvr.Resource == "" {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewBadRequest("version and resource queries or collectionResource query are required"),
+				Codecs, schema.GroupVersion{}, w, req,
+			)
+			return
+		}
+
+		result.Resource = &v1beta1.CollectionResourceType{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+		result.Groups, err = h.aggregateResource(req.Context(), gvr, cluster, opts, groupBy)
+	}
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, result, false)
+}
+
+// parseGroupBy parses the keys of the `groupBy` queries, each query may have multiple keys separated by the comma.
+func parseGroupBy(values []string) ([]string, []clusterpediastorage.GroupBy, error) {
+	var (
+		keys    []string
+		groupBy []clusterpediastorage.GroupBy
+	)
+	for _, value := range values {
+		for _, key := range strings.Split(value, ",") {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				continue
+			}
+
+			typ, arg, _ := strings.Cut(key, ":")
+			by := clusterpediastorage.GroupBy{Type: clusterpediastorage.GroupByType(typ)}
+			switch by.Type {
+			case clusterpediastorage.GroupByCluster, clusterpediastorage.GroupByNamespace, clusterpediastorage.GroupByResource:
+				if arg != "" {
+					return nil, nil, fmt.Errorf("invalid group by key %q, %s has no argument", key, typ)
+				}
+			case clusterpediastorage.GroupByLabel:
+				if arg == "" {
+					return nil, nil, fmt.Errorf("invalid group by key %q, the label key is required, e.g. label:app", key)
+				}
+				by.Label = arg
+			case clusterpediastorage.GroupByField:
+				requirement, err := fields.NewRequirement(arg, selection.Exists, nil)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid group by key %q: %w", key, err)
+				}
+				for _, f := range requirement.Fields() {
+					if f.IsList() {
+						return nil, nil, fmt.Errorf("invalid group by key %q, the list field is not supported", key)
+					}
+					by.Fields = append(by.Fields, f.Name())
+				}
+			default:
+				return nil, nil, fmt.Errorf("unsupported group by key %q, supported keys are cluster, namespace, label:<key>, field:<path> and resource", key)
+			}
+			keys, groupBy = append(keys, key), append(groupBy, by)
+		}
+	}
+	if len(groupBy) == 0 {
+		return nil, nil, fmt.Errorf("groupBy query is required")
+	}
+	return keys, groupBy, nil
+}
+
+func (h *AggregationHandler) aggregateCollectionResource(ctx context.Context, name string, opts *internal.ListOptions, groupBy []clusterpediastorage.GroupBy) ([]v1beta1.AggregationGroup, error) {
+	storage, ok := h.collections[name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: internal.GroupName, Resource: "collectionresources"}, name)
+	}
+	aggregator, ok := storage.(clusterpediastorage.ResourceAggregator)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "collectionresources"}, "aggregate")
+	}
+
+	groups, err := aggregator.Aggregate(ctx, opts, groupBy)
+	if err != nil {
+		return nil, clusterpediastorage.InterpretListError(err, schema.GroupResource{Group: internal.GroupName, Resource: "collectionresources"})
+	}
+	return convertAggregationGroups(groups), nil
+}
+
+func (h *AggregationHandler) aggregateResource(ctx context.Context, gvr schema.GroupVersionResource, cluster string, opts *internal.ListOptions, groupBy []clusterpediastorage.GroupBy) ([]v1beta1.AggregationGroup, error) {
+	if !h.discovery.ResourceEnabled(cluster, gvr) {
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), "")
+	}
+	storage, scope, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		return nil, err
+	}
+
+	if aggregator, ok := storage.Storage.(clusterpediastorage.ResourceAggregator); ok {
+		groups, err := aggregator.Aggregate(ctx, opts, groupBy)
+		if err != nil {
+			return nil, clusterpediastorage.InterpretListError(err, gvr.GroupResource())
+		}
+		return convertAggregationGroups(groups), nil
+	}
+
+	for _, by := range groupBy {
+		if by.Type == clusterpediastorage.GroupByResource {
+			return nil, apierrors.NewBadRequest("only the collection resources can be grouped by the resource")
+		}
+	}
+
+	listOpts := *opts
+	listOpts.Limit, listOpts.Continue = 0, ""
+	list := storage.NewList()
+	if err := storage.Storage.List(ctx, list, &listOpts); err != nil {
+		return nil, clusterpediastorage.InterpretListError(err, gvr.GroupResource())
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	counter := newGroupCounter()
+	for _, obj := range objs {
+		versioned, err := scope.Convertor.ConvertToVersion(obj, gvr.GroupVersion())
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(versioned)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		counter.observe(groupValues(utils.ExtractClusterName(obj), content, groupBy))
+	}
+	return counter.groups(opts.Limit), nil
+}
+
+// groupValues returns the values of the group by keys of the object, the resource key is not supported.
+func groupValues(cluster string, content map[string]interface{}, groupBy []clusterpediastorage.GroupBy) []string {
+	values := make([]string, 0, len(groupBy))
+	for _, by := range groupBy {
+		var value string
+		switch by.Type {
+		case clusterpediastorage.GroupByCluster:
+			value = cluster
+		case clusterpediastorage.GroupByNamespace:
+			value, _, _ = unstructured.NestedString(content, "metadata", "namespace")
+		case clusterpediastorage.GroupByLabel:
+			value, _, _ = unstructured.NestedString(content, "metadata", "labels", by.Label)
+		case clusterpediastorage.GroupByField:
+			if field, found, _ := unstructured.NestedFieldNoCopy(content, by.Fields...); found && field != nil {
+				if s, ok := field.(string); ok {
+					value = s
+				} else if data, err := json.Marshal(field); err == nil {
+					value = string(data)
+				}
+			}
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+type groupCounter struct {
+	counts map[string]*v1beta1.AggregationGroup
+}
+
+func newGroupCounter() *groupCounter {
+	return &groupCounter{counts: make(map[string]*v1beta1.AggregationGroup)}
+}
+
+func (c *groupCounter) observe(values []string) {
+	key := strings.Join(values, "\x00")
+	group, ok := c.counts[key]
+	if !ok {
+		group = &v1beta1.AggregationGroup{Values: values}
+		c.counts[key] = group
+	}
+	group.Count++
+}
+
+// groups returns the groups sorted by the count in descending order, and then by the values.
+func (c *groupCounter) groups(limit int64) []v1beta1.AggregationGroup {
+	groups := make([]v1beta1.AggregationGroup, 0, len(c.counts))
+	for _, group := range c.counts {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return strings.Join(groups[i].Values, "\x00") < strings.Join(groups[j].Values, "\x00")
+	})
+	if limit > 0 && int64(len(groups)) > limit {
+		groups = groups[:limit]
+	}
+	return groups
+}
+
+func convertAggregationGroups(groups []clusterpediastorage.AggregationGroup) []v1beta1.AggregationGroup {
+	result := make([]v1beta1.AggregationGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, v1beta1.AggregationGroup{Values: group.Values, Count: group.Count})
+	}
+	return result
+}
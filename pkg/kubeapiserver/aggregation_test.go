@@ -0,0 +1,88 @@
+package kubeapiserver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	clusterpediastorage "github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestParseGroupBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		keys     []string
+		expected []clusterpediastorage.GroupBy
+		wantErr  bool
+	}{
+		{
+			name:   "multiple keys",
+			values: []string{"cluster, namespace", "label:app", "field:status.phase"},
+			keys:   []string{"cluster", "namespace", "label:app", "field:status.phase"},
+			expected: []clusterpediastorage.GroupBy{
+				{Type: clusterpediastorage.GroupByCluster},
+				{Type: clusterpediastorage.GroupByNamespace},
+				{Type: clusterpediastorage.GroupByLabel, Label: "app"},
+				{Type: clusterpediastorage.GroupByField, Fields: []string{"status", "phase"}},
+			},
+		},
+		{
+			name:     "resource",
+			values:   []string{"resource"},
+			keys:     []string{"resource"},
+			expected: []clusterpediastorage.GroupBy{{Type: clusterpediastorage.GroupByResource}},
+		},
+		{name: "empty", values: []string{""}, wantErr: true},
+		{name: "unsupported key", values: []string{"name"}, wantErr: true},
+		{name: "label without key", values: []string{"label"}, wantErr: true},
+		{name: "cluster with argument", values: []string{"cluster:a"}, wantErr: true},
+		{name: "list field", values: []string{"field:spec.containers[].image"}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keys, groupBy, err := parseGroupBy(test.values)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseGroupBy() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(keys, test.keys) {
+				t.Errorf("parseGroupBy() keys = %v, want %v", keys, test.keys)
+			}
+			if !reflect.DeepEqual(groupBy, test.expected) {
+				t.Errorf("parseGroupBy() groupBy = %v, want %v", groupBy, test.expected)
+			}
+		})
+	}
+}
+
+func TestGroupCounter(t *testing.T) {
+	groupBy := []clusterpediastorage.GroupBy{
+		{Type: clusterpediastorage.GroupByCluster},
+		{Type: clusterpediastorage.GroupByField, Fields: []string{"status", "phase"}},
+	}
+	pod := func(phase string) map[string]interface{} {
+		return map[string]interface{}{"status": map[string]interface{}{"phase": phase}}
+	}
+
+	counter := newGroupCounter()
+	counter.observe(groupValues("cluster-1", pod("Running"), groupBy))
+	counter.observe(groupValues("cluster-2", pod("Running"), groupBy))
+	counter.observe(groupValues("cluster-1", pod("Running"), groupBy))
+	counter.observe(groupValues("cluster-1", pod("Pending"), groupBy))
+	counter.observe(groupValues("cluster-1", map[string]interface{}{}, groupBy))
+
+	expected := []v1beta1.AggregationGroup{
+		{Values: []string{"cluster-1", "Running"}, Count: 2},
+		{Values: []string{"cluster-1", ""}, Count: 1},
+		{Values: []string{"cluster-1", "Pending"}, Count: 1},
+	}
+	if groups := counter.groups(3); !reflect.DeepEqual(groups, expected) {
+		t.Errorf("groups() = %v, want %v", groups, expected)
+	}
+	if groups := counter.groups(0); len(groups) != 4 {
+		t.Errorf("groups() without limit = %v, want 4 groups", groups)
+	}
+}
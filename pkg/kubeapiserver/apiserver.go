@@ -22,12 +22,18 @@ import (
 	"k8s.io/component-base/tracing"
 	"k8s.io/component-base/version"
 
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/externalmetrics"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/federation"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/listpolicy"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/registry/clusterpedia/collectionresources"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/wasmfilter"
 	informers "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/features"
 	proxyrest "github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/resourcerest/proxy"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	"github.com/clusterpedia-io/clusterpedia/pkg/utils/filters"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/masking"
 )
 
 var (
@@ -74,6 +80,10 @@ type ExtraConfig struct {
 	AllowedProxySubresources          map[schema.GroupResource]sets.Set[string]
 	EnableProxyPathForForwardRequest  bool
 	AllowForwardUnsyncResourceRequest bool
+
+	// ReadYourWritesWindow is how long the reads with the read-your-writes consistency are forwarded to the cluster
+	// after its resource is written through the proxy
+	ReadYourWritesWindow time.Duration
 }
 
 type Config struct {
@@ -82,6 +92,15 @@ type Config struct {
 	StorageFactory           storage.StorageFactory
 	InformerFactory          informers.SharedInformerFactory
 	InitialAPIGroupResources []*restmapper.APIGroupResources
+	ListPolicy               *listpolicy.Policy
+	ExternalMetrics          *externalmetrics.Config
+	Federation               *federation.Federation
+
+	// Masker masks the sensitive fields of the served objects
+	Masker *masking.Masker
+
+	// Filters are the WASM filter functions selected by the list requests
+	Filters *wasmfilter.Registry
 
 	ExtraConfig *ExtraConfig
 }
@@ -96,6 +115,11 @@ func (c *Config) Complete() CompletedConfig {
 		StorageFactory:           c.StorageFactory,
 		InformerFactory:          c.InformerFactory,
 		InitialAPIGroupResources: c.InitialAPIGroupResources,
+		ListPolicy:               c.ListPolicy,
+		ExternalMetrics:          c.ExternalMetrics,
+		Federation:               c.Federation,
+		Masker:                   c.Masker,
+		Filters:                  c.Filters,
 		ExtraConfig:              c.ExtraConfig,
 	}
 
@@ -112,6 +136,11 @@ type completedConfig struct {
 	StorageFactory           storage.StorageFactory
 	InformerFactory          informers.SharedInformerFactory
 	InitialAPIGroupResources []*restmapper.APIGroupResources
+	ListPolicy               *listpolicy.Policy
+	ExternalMetrics          *externalmetrics.Config
+	Federation               *federation.Federation
+	Masker                   *masking.Masker
+	Filters                  *wasmfilter.Registry
 	ExtraConfig              *ExtraConfig
 }
 
@@ -141,7 +170,7 @@ func (c completedConfig) New(delegationTarget genericapiserver.DelegationTarget)
 		delegate = http.NotFoundHandler()
 	}
 
-	restManager := NewRESTManager(c.GenericConfig.Serializer, runtime.ContentTypeJSON, c.StorageFactory, c.InitialAPIGroupResources)
+	restManager := NewRESTManager(c.GenericConfig.Serializer, runtime.ContentTypeJSON, c.StorageFactory, c.InitialAPIGroupResources, c.ListPolicy, c.Federation, c.Masker, c.Filters)
 	discoveryManager := discovery.NewDiscoveryManager(c.GenericConfig.Serializer, restManager, delegate)
 
 	var secretLister corev1listers.SecretNamespaceLister
@@ -152,7 +181,8 @@ func (c completedConfig) New(delegationTarget genericapiserver.DelegationTarget)
 	clusterInformer := c.InformerFactory.Cluster().V1alpha2().PediaClusters()
 	connector := proxyrest.NewProxyConnector(clusterInformer.Lister(), secretLister, c.ExtraConfig.AllowPediaClusterConfigReuse, c.ExtraConfig.ExtraProxyRequestHeaderPrefixes)
 
-	methodSet := sets.New("GET")
+	// POST is used by the bulk get and apply diff requests, DELETE is used by the tombstone purge and the collection deletion requests
+	methodSet := sets.New("GET", "POST", "DELETE")
 	for _, rest := range proxyrest.GetSubresourceRESTs(connector) {
 		allows := c.ExtraConfig.AllowedProxySubresources[rest.ParentGroupResource()]
 		if allows == nil || !allows.Has(rest.Subresource()) {
@@ -190,12 +220,14 @@ func (c completedConfig) New(delegationTarget genericapiserver.DelegationTarget)
 	genericserver.Handler.NonGoRestfulMux.Handle("/api", discoveryHandler)
 	genericserver.Handler.NonGoRestfulMux.Handle("/apis", discoveryHandler)
 
+	proxyWrites := newProxyWrites(c.ExtraConfig.ReadYourWritesWindow)
 	resourceHandler := &ResourceHandler{
 		allowForwardUnsyncResourceRequest: c.ExtraConfig.AllowForwardUnsyncResourceRequest,
 		minRequestTimeout:                 time.Duration(c.GenericConfig.MinRequestTimeout) * time.Second,
 
 		delegate:      delegate,
-		proxy:         proxy,
+		proxy:         proxyWrites.WrapProxyHandler(proxy),
+		proxyWrites:   proxyWrites,
 		rest:          restManager,
 		discovery:     discoveryManager,
 		clusterLister: c.InformerFactory.Cluster().V1alpha2().PediaClusters().Lister(),
@@ -203,6 +235,62 @@ func (c completedConfig) New(delegationTarget genericapiserver.DelegationTarget)
 
 	genericserver.Handler.NonGoRestfulMux.HandlePrefix("/api/", resourceHandler)
 	genericserver.Handler.NonGoRestfulMux.HandlePrefix("/apis/", resourceHandler)
+	genericserver.Handler.NonGoRestfulMux.Handle(BulkGetPath, &BulkGetHandler{
+		rest:      restManager,
+		discovery: discoveryManager,
+	})
+	genericserver.Handler.NonGoRestfulMux.Handle(ApplyDiffPath, &ApplyDiffHandler{
+		rest:          restManager,
+		discovery:     discoveryManager,
+		clusterLister: clusterInformer.Lister(),
+	})
+	genericserver.Handler.NonGoRestfulMux.Handle(SchemaValidationPath, &SchemaValidationHandler{
+		rest:          restManager,
+		discovery:     discoveryManager,
+		clusterLister: clusterInformer.Lister(),
+	})
+	genericserver.Handler.NonGoRestfulMux.Handle(MetadataKeysPath, NewMetadataKeysHandler(restManager, discoveryManager))
+	genericserver.Handler.NonGoRestfulMux.Handle(SpecHashesPath, NewSpecHashesHandler(restManager, discoveryManager))
+	genericserver.Handler.NonGoRestfulMux.Handle(HistoryPath, NewHistoryHandler(restManager, discoveryManager))
+	genericserver.Handler.NonGoRestfulMux.Handle(SnapshotPath, NewSnapshotHandler(restManager, discoveryManager))
+	genericserver.Handler.NonGoRestfulMux.Handle(TombstonesPath, NewTombstonesHandler(restManager, discoveryManager))
+	genericserver.Handler.NonGoRestfulMux.Handle(ArchivesPath, NewArchivesHandler(restManager, discoveryManager))
+	genericserver.Handler.NonGoRestfulMux.Handle(DeleteCollectionPath, NewDeleteCollectionHandler(restManager, discoveryManager))
+	genericserver.Handler.NonGoRestfulMux.Handle(ExportPath, NewExportHandler(restManager, discoveryManager))
+	genericserver.Handler.NonGoRestfulMux.Handle(ReplicasPath, NewReplicasHandler(restManager, discoveryManager))
+
+	_, collections, err := collectionresources.NewStorages(c.StorageFactory)
+	if err != nil {
+		return nil, nil, err
+	}
+	genericserver.Handler.NonGoRestfulMux.Handle(AggregationPath, NewAggregationHandler(restManager, discoveryManager, collections))
+	genericserver.Handler.NonGoRestfulMux.Handle(SubscriptionPath, NewSubscriptionHandler(restManager, discoveryManager))
+	if c.ExternalMetrics != nil {
+		externalMetrics := NewExternalMetricsHandler(restManager, discoveryManager, c.ExternalMetrics)
+		genericserver.Handler.NonGoRestfulMux.Handle(externalmetrics.Path, externalMetrics)
+		genericserver.Handler.NonGoRestfulMux.HandlePrefix(externalmetrics.Path+"/", externalMetrics)
+	}
+
+	namespaceInventory := NewNamespaceInventory(c.StorageFactory, clusterInformer.Lister())
+	genericserver.Handler.NonGoRestfulMux.Handle(NamespaceInventoryPath, namespaceInventory)
+	genericserver.AddPostStartHookOrDie("start-namespace-inventory", func(context genericapiserver.PostStartHookContext) error {
+		go namespaceInventory.Run(context)
+		return nil
+	})
+
+	fleetOverview := NewFleetOverview(c.StorageFactory, clusterInformer.Lister())
+	genericserver.Handler.NonGoRestfulMux.Handle(FleetOverviewPath, fleetOverview)
+	genericserver.AddPostStartHookOrDie("start-fleet-overview", func(context genericapiserver.PostStartHookContext) error {
+		go fleetOverview.Run(context)
+		return nil
+	})
+
+	storageUsage := NewStorageUsage(c.StorageFactory, clusterInformer.Lister())
+	genericserver.Handler.NonGoRestfulMux.Handle(StorageUsagePath, storageUsage)
+	genericserver.AddPostStartHookOrDie("start-storage-usage", func(context genericapiserver.PostStartHookContext) error {
+		go storageUsage.Run(context)
+		return nil
+	})
 
 	_ = NewClusterResourceController(restManager, discoveryManager, clusterInformer)
 	return genericserver, methods, nil
@@ -0,0 +1,120 @@
+package kubeapiserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apiserver/pkg/registry/rest"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	clientrest "k8s.io/client-go/rest"
+	"k8s.io/component-base/version"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/api/clusterpedia/install"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/openapi"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/registry/clusterpedia/resources"
+	"github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type fakeStorageFactory struct {
+	storage.StorageFactory
+}
+
+func (fakeStorageFactory) GetSupportedRequestVerbs() []string {
+	return []string{"get", "list", "watch"}
+}
+
+func (fakeStorageFactory) GetCollectionResources(ctx context.Context) ([]*internal.CollectionResource, error) {
+	return nil, nil
+}
+
+// newTestResourcesServer serves the kube resource server through the resources API
+// in the same way as the clusterpedia apiserver
+func newTestResourcesServer(t *testing.T) http.Handler {
+	config := NewDefaultConfig()
+	config.GenericConfig.ExternalAddress = "127.0.0.1:443"
+	config.GenericConfig.LoopbackClientConfig = &clientrest.Config{}
+	config.StorageFactory = fakeStorageFactory{}
+	config.InformerFactory = informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	config.ExtraConfig = &ExtraConfig{}
+	kubeServer, methods, err := config.Complete().New(genericapiserver.NewEmptyDelegate())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	metav1.AddToGroupVersion(scheme, schema.GroupVersion{Version: "v1"})
+	scheme.AddUnversionedTypes(schema.GroupVersion{Group: "", Version: "v1"},
+		&metav1.Status{},
+		&metav1.APIVersions{},
+		&metav1.APIGroupList{},
+		&metav1.APIGroup{},
+		&metav1.APIResourceList{},
+	)
+	codecs := serializer.NewCodecFactory(scheme)
+
+	genericConfig := genericapiserver.NewRecommendedConfig(codecs)
+	genericConfig.EffectiveVersion = version.DefaultKubeEffectiveVersion()
+	genericConfig.OpenAPIV3Config = openapi.NewV3Config(scheme)
+	genericConfig.ExternalAddress = "127.0.0.1:443"
+	genericConfig.LoopbackClientConfig = &clientrest.Config{}
+	server, err := genericConfig.Complete().New("clusterpedia-apiserver", genericapiserver.NewEmptyDelegate())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(v1beta1.SchemeGroupVersion.Group, scheme, runtime.NewParameterCodec(scheme), codecs)
+	apiGroupInfo.VersionedResourcesStorageMap["v1beta1"] = map[string]rest.Storage{
+		"resources": resources.NewREST(kubeServer.Handler, methods),
+	}
+	if err := server.InstallAPIGroup(&apiGroupInfo); err != nil {
+		t.Fatal(err)
+	}
+	return server.Handler
+}
+
+func TestResourcesAPIMethods(t *testing.T) {
+	server := newTestResourcesServer(t)
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		message string
+	}{
+		{
+			name:    "purge tombstones",
+			method:  http.MethodDelete,
+			path:    "/apis/clusterpedia.io/v1beta1/resources/clusters/cluster-1/tombstones",
+			message: "version and resource queries are required",
+		},
+		{
+			name:    "delete collection",
+			method:  http.MethodDelete,
+			path:    "/apis/clusterpedia.io/v1beta1/resources/clusters/cluster-1/deletecollection",
+			message: "version and resource queries are required",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, nil))
+
+			// the request is rejected by the handler rather than by the resources API
+			if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), test.message) {
+				t.Errorf("%s %s = %d %s, want %d %q", test.method, test.path, recorder.Code, recorder.Body.String(), http.StatusBadRequest, test.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package kubeapiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	clusterlister "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	clusterpediastorage "github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const ApplyDiffPath = "/applydiff"
+
+// ApplyDiffHandler compares a manifest against the stored objects in the clusters,
+// so that clients can check what would change across the fleet before rolling out the manifest.
+//
+// The manifest is applied to the stored object like a JSON merge patch, except that the lists of objects
+// with the `name` field, such as containers and volumes, are merged by the name like a strategic merge patch.
+// The status and the fields maintained by the server are ignored.
+type ApplyDiffHandler struct {
+	rest          *RESTManager
+	discovery     *discovery.DiscoveryManager
+	clusterLister clusterlister.PediaClusterLister
+}
+
+func (h *ApplyDiffHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "applydiff"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	diff := &v1beta1.ApplyDiff{}
+	if err := decodeRequestObject(req, v1beta1.SchemeGroupVersion.WithKind("ApplyDiff"), diff); err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	results, err := h.diff(req.Context(), diff.Spec)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	diff.Status.Results = results
+	diff.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("ApplyDiff"))
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, diff, false)
+}
+
+func (h *ApplyDiffHandler) diff(ctx context.Context, spec v1beta1.ApplyDiffSpec) ([]v1beta1.ApplyDiffResult, error) {
+	manifest := &unstructured.Unstructured{}
+	if err := manifest.UnmarshalJSON(spec.Object.Raw); err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid spec.object: %v", err))
+	}
+	if manifest.GetName() == "" {
+		return nil, apierrors.NewBadRequest("spec.object: name is required")
+	}
+
+	gvk := manifest.GroupVersionKind()
+	gvr, ok := h.rest.ResourceFor(gvk)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("the server could not find the resource of %s", gvk))
+	}
+	storage, scope, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := manifest.GetNamespace()
+	if !storage.Storage.GetStorageConfig().Namespaced {
+		namespace = ""
+	} else if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+
+	clusters := spec.Clusters
+	if len(clusters) == 0 {
+		if cluster := request.ClusterNameValue(ctx); cluster != "" {
+			clusters = []string{cluster}
+		} else {
+			pediaclusters, err := h.clusterLister.List(labels.Everything())
+			if err != nil {
+				return nil, apierrors.NewInternalError(err)
+			}
+			for _, cluster := range pediaclusters {
+				clusters = append(clusters, cluster.Name)
+			}
+			sort.Strings(clusters)
+		}
+	}
+
+	opts := &internal.ListOptions{ClusterNames: clusters, Names: []string{manifest.GetName()}}
+	if namespace != "" {
+		opts.Namespaces = []string{namespace}
+	}
+	list := storage.NewList()
+	if err := storage.Storage.List(ctx, list, opts); err != nil {
+		return nil, clusterpediastorage.InterpretListError(err, gvr.GroupResource())
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	stored := make(map[string]map[string]interface{}, len(objs))
+	for _, obj := range objs {
+		versioned, err := scope.Convertor.ConvertToVersion(obj, gvr.GroupVersion())
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(versioned)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		stored[utils.ExtractClusterName(obj)] = content
+	}
+
+	applied := manifest.DeepCopy().Object
+	if namespace != "" {
+		_ = unstructured.SetNestedField(applied, namespace, "metadata", "namespace")
+	} else {
+		unstructured.RemoveNestedField(applied, "metadata", "namespace")
+	}
+	pruneServerFields(applied)
+
+	results := make([]v1beta1.ApplyDiffResult, 0, len(clusters))
+	for _, cluster := range clusters {
+		result := v1beta1.ApplyDiffResult{Cluster: cluster}
+		if !h.discovery.ResourceEnabled(cluster, gvr) {
+			status := apierrors.NewNotFound(gvr.GroupResource(), manifest.GetName()).Status()
+			status.Message = fmt.Sprintf("the resource %s is not synchronized in the cluster %s", gvr.GroupResource(), cluster)
+			result.Error = &status
+			results = append(results, result)
+			continue
+		}
+
+		var patch map[string]interface{}
+		if obj, ok := stored[cluster]; ok {
+			pruneServerFields(obj)
+			patch = mergePatch(obj, applyManifest(obj, applied))
+			result.Exists = true
+		} else {
+			patch = applied
+		}
+
+		if len(patch) != 0 {
+			raw, err := json.Marshal(patch)
+			if err != nil {
+				return nil, apierrors.NewInternalError(err)
+			}
+			result.Changed = true
+			result.Patch = runtime.RawExtension{Raw: raw}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// pruneServerFields removes the status and the fields maintained by the server and clusterpedia,
+// which are not expected to be compared.
+func pruneServerFields(obj map[string]interface{}) {
+	delete(obj, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink"} {
+		unstructured.RemoveNestedField(obj, "metadata", field)
+	}
+
+	annotations, _, _ := unstructured.NestedStringMap(obj, "metadata", "annotations")
+	for key := range annotations {
+		if strings.HasPrefix(key, "shadow.clusterpedia.io/") {
+			delete(annotations, key)
+		}
+	}
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(obj, "metadata", "annotations")
+	} else {
+		_ = unstructured.SetNestedStringMap(obj, annotations, "metadata", "annotations")
+	}
+}
+
+// applyManifest returns the object after the manifest is applied to the stored object.
+func applyManifest(stored, manifest map[string]interface{}) map[string]interface{} {
+	applied := runtime.DeepCopyJSON(stored)
+	for key, value := range manifest {
+		if value == nil {
+			delete(applied, key)
+			continue
+		}
+		applied[key] = applyValue(applied[key], value)
+	}
+	return applied
+}
+
+func applyValue(stored, manifest interface{}) interface{} {
+	switch manifest := manifest.(type) {
+	case map[string]interface{}:
+		if stored, ok := stored.(map[string]interface{}); ok {
+			return applyManifest(stored, manifest)
+		}
+	case []interface{}:
+		if stored, ok := stored.([]interface{}); ok {
+			if merged, ok := applyNamedList(stored, manifest); ok {
+				return merged
+			}
+		}
+	}
+	return runtime.DeepCopyJSONValue(manifest)
+}
+
+// applyNamedList merges the lists of the objects by their names,
+// false is returned if any item of the lists has no name.
+func applyNamedList(stored, manifest []interface{}) ([]interface{}, bool) {
+	storedItems := make(map[string]map[string]interface{}, len(stored))
+	for _, item := range stored {
+		name, obj, ok := namedItem(item)
+		if !ok {
+			return nil, false
+		}
+		storedItems[name] = obj
+	}
+
+	merged := make([]interface{}, 0, len(manifest))
+	for _, item := range manifest {
+		name, obj, ok := namedItem(item)
+		if !ok {
+			return nil, false
+		}
+		if storedItem, ok := storedItems[name]; ok {
+			merged = append(merged, applyManifest(storedItem, obj))
+		} else {
+			merged = append(merged, runtime.DeepCopyJSONValue(obj))
+		}
+	}
+	return merged, true
+}
+
+func namedItem(item interface{}) (string, map[string]interface{}, bool) {
+	obj, ok := item.(map[string]interface{})
+	if !ok {
+		return "", nil, false
+	}
+	name, ok := obj["name"].(string)
+	return name, obj, ok && name != ""
+}
+
+// mergePatch returns the JSON merge patch from the original object to the modified object.
+func mergePatch(original, modified map[string]interface{}) map[string]interface{} {
+	patch := make(map[string]interface{})
+	for key, value := range modified {
+		originalValue, ok := original[key]
+		if !ok {
+			patch[key] = value
+			continue
+		}
+
+		originalMap, isMap := originalValue.(map[string]interface{})
+		modifiedMap, isModifiedMap := value.(map[string]interface{})
+		if isMap && isModifiedMap {
+			if p := mergePatch(originalMap, modifiedMap); len(p) != 0 {
+				patch[key] = p
+			}
+			continue
+		}
+		if !reflect.DeepEqual(originalValue, value) {
+			patch[key] = value
+		}
+	}
+	for key := range original {
+		if _, ok := modified[key]; !ok {
+			patch[key] = nil
+		}
+	}
+	return patch
+}
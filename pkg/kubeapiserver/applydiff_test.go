@@ -0,0 +1,85 @@
+package kubeapiserver
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func toMap(t *testing.T, data string) map[string]interface{} {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &obj); err != nil {
+		t.Fatal(err)
+	}
+	return obj
+}
+
+func TestApplyDiff(t *testing.T) {
+	stored := `{
+		"apiVersion": "apps/v1",
+		"kind": "Deployment",
+		"metadata": {
+			"name": "nginx", "namespace": "default", "resourceVersion": "10", "uid": "1234",
+			"annotations": {"shadow.clusterpedia.io/cluster-name": "cluster-1"},
+			"labels": {"app": "nginx", "team": "a"}
+		},
+		"spec": {
+			"replicas": 3,
+			"template": {"spec": {"containers": [
+				{"name": "nginx", "image": "nginx:1.24", "imagePullPolicy": "IfNotPresent"},
+				{"name": "sidecar", "image": "envoy:1.0"}
+			]}}
+		},
+		"status": {"replicas": 3}
+	}`
+
+	tests := []struct {
+		name     string
+		manifest string
+		expected string
+	}{
+		{
+			name: "unchanged",
+			manifest: `{
+				"apiVersion": "apps/v1", "kind": "Deployment",
+				"metadata": {"name": "nginx", "namespace": "default", "labels": {"app": "nginx"}},
+				"spec": {"template": {"spec": {"containers": [{"name": "nginx", "image": "nginx:1.24"}, {"name": "sidecar", "image": "envoy:1.0"}]}}}
+			}`,
+			expected: `{}`,
+		},
+		{
+			name: "changed",
+			manifest: `{
+				"apiVersion": "apps/v1", "kind": "Deployment",
+				"metadata": {"name": "nginx", "namespace": "default", "labels": {"team": null}},
+				"spec": {"replicas": 5, "template": {"spec": {"containers": [{"name": "nginx", "image": "nginx:1.25"}]}}}
+			}`,
+			expected: `{
+				"metadata": {"labels": {"team": null}},
+				"spec": {"replicas": 5, "template": {"spec": {"containers": [{"name": "nginx", "image": "nginx:1.25", "imagePullPolicy": "IfNotPresent"}]}}}
+			}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj, manifest := toMap(t, stored), toMap(t, test.manifest)
+			pruneServerFields(obj)
+			pruneServerFields(manifest)
+
+			patch := mergePatch(obj, applyManifest(obj, manifest))
+			if expected := toMap(t, test.expected); !reflect.DeepEqual(patch, expected) {
+				t.Errorf("patch = %v, want %v", patch, expected)
+			}
+		})
+	}
+}
+
+func TestPruneServerFields(t *testing.T) {
+	obj := toMap(t, `{"metadata": {"name": "nginx", "uid": "1234", "annotations": {"shadow.clusterpedia.io/cluster-name": "cluster-1"}}, "status": {}}`)
+	pruneServerFields(obj)
+
+	if expected := toMap(t, `{"metadata": {"name": "nginx"}}`); !reflect.DeepEqual(obj, expected) {
+		t.Errorf("pruneServerFields() = %v, want %v", obj, expected)
+	}
+}
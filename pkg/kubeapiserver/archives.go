@@ -0,0 +1,172 @@
+package kubeapiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/masking"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const ArchivesPath = "/archives"
+
+// ArchivesHandler serves the objects of a cluster archived to the object storage by the archive storage,
+// which are deleted from the cluster or purged by the retention policies.
+//
+// The cluster is specified by the path, e.g. `/apis/clusterpedia.io/v1beta1/resources/clusters/<cluster>/archives`,
+// and the resource is specified by the `group`, `version` and `resource` queries.
+// The archives are listed by `GET`, and are filtered by the `namespace`, `name`, `archivedAfter` and `archivedBefore` queries,
+// the archived object of the `id` query is restored to the storage by `POST`.
+type ArchivesHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+}
+
+func NewArchivesHandler(rest *RESTManager, discovery *discovery.DiscoveryManager) *ArchivesHandler {
+	return &ArchivesHandler{rest: rest, discovery: discovery}
+}
+
+func (h *ArchivesHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet, http.MethodPost:
+	default:
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "archives"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	query := req.URL.Query()
+	gvr := schema.GroupVersionResource{Group: query.Get("group"), Version: query.Get("version"), Resource: query.Get("resource")}
+	if gvr.Version == "" || gvr.Resource == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("version and resource queries are required"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	cluster := request.ClusterNameValue(req.Context())
+	if cluster == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("the archives are only served in the path of a cluster"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	opts, err := parseArchiveOptions(query)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	if !h.discovery.ResourceEnabled(cluster, gvr) {
+		responsewriters.ErrorNegotiated(apierrors.NewNotFound(gvr.GroupResource(), opts.Name), Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	resourceStorage, _, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	archiveStorage, ok := resourceStorage.Storage.(storage.ResourceArchiveStorage)
+	if !ok {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(gvr.GroupResource(), "archives"), Codecs, gvr.GroupVersion(), w, req,
+		)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		archives, err := archiveStorage.ListArchives(req.Context(), cluster, opts)
+		if err != nil {
+			responsewriters.ErrorNegotiated(storage.InterpretListError(err, gvr.GroupResource()), Codecs, gvr.GroupVersion(), w, req)
+			return
+		}
+
+		result, err := buildArchives(archives, resourceStorage.Masker.Rules(gvr, configv1alpha1.MaskingStageServing))
+		if err != nil {
+			responsewriters.ErrorNegotiated(apierrors.NewInternalError(err), Codecs, gvr.GroupVersion(), w, req)
+			return
+		}
+		result.Cluster = cluster
+		result.Resource = v1beta1.CollectionResourceType{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+		responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, result, false)
+	case http.MethodPost:
+		id := query.Get("id")
+		if id == "" {
+			responsewriters.ErrorNegotiated(apierrors.NewBadRequest("id query is required to restore the archived object"), Codecs, schema.GroupVersion{}, w, req)
+			return
+		}
+		if err := archiveStorage.RestoreArchive(req.Context(), cluster, id); err != nil {
+			responsewriters.ErrorNegotiated(storage.InterpretGetError(err, gvr.GroupResource(), id), Codecs, gvr.GroupVersion(), w, req)
+			return
+		}
+		writeSuccessStatus(w, req, fmt.Sprintf("the archived %s %q is restored", gvr.GroupResource(), id))
+	}
+}
+
+func parseArchiveOptions(query url.Values) (storage.ArchiveOptions, error) {
+	opts := storage.ArchiveOptions{Namespace: query.Get("namespace"), Name: query.Get("name")}
+	for key, into := range map[string]*time.Time{"archivedAfter": &opts.ArchivedAfter, "archivedBefore": &opts.ArchivedBefore} {
+		value := query.Get(key)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return opts, apierrors.NewBadRequest(fmt.Sprintf("invalid %s query, it should be in RFC3339: %v", key, err))
+		}
+		*into = t
+	}
+	return opts, nil
+}
+
+// buildArchives builds the archives whose objects are masked by the serving rules
+func buildArchives(archives []storage.Archive, rules *masking.Rules) (*v1beta1.Archives, error) {
+	result := &v1beta1.Archives{Items: make([]v1beta1.Archive, 0, len(archives))}
+	result.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("Archives"))
+
+	for _, archive := range archives {
+		object := archive.Object
+		if rules != nil {
+			content := make(map[string]interface{})
+			if err := json.Unmarshal(object, &content); err != nil {
+				return nil, err
+			}
+			rules.Mask(content)
+
+			var err error
+			if object, err = json.Marshal(content); err != nil {
+				return nil, err
+			}
+		}
+
+		result.Items = append(result.Items, v1beta1.Archive{
+			ID:         archive.ID,
+			Namespace:  archive.Namespace,
+			Name:       archive.Name,
+			Reason:     string(archive.Reason),
+			ArchivedAt: metav1.NewTime(archive.ArchivedAt),
+			Object:     runtime.RawExtension{Raw: object},
+		})
+	}
+	return result, nil
+}
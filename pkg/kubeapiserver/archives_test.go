@@ -0,0 +1,94 @@
+package kubeapiserver
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/masking"
+)
+
+func TestParseArchiveOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected storage.ArchiveOptions
+		wantErr  bool
+	}{
+		{
+			name:     "namespace and name",
+			query:    "namespace=default&name=web",
+			expected: storage.ArchiveOptions{Namespace: "default", Name: "web"},
+		},
+		{
+			name:  "archived time",
+			query: "archivedAfter=2024-01-01T00:00:00Z&archivedBefore=2024-01-02T00:00:00Z",
+			expected: storage.ArchiveOptions{
+				ArchivedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				ArchivedBefore: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{name: "invalid time", query: "archivedBefore=yesterday", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts, err := parseArchiveOptions(query)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseArchiveOptions() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && (opts.Namespace != test.expected.Namespace || opts.Name != test.expected.Name ||
+				!opts.ArchivedAfter.Equal(test.expected.ArchivedAfter) || !opts.ArchivedBefore.Equal(test.expected.ArchivedBefore)) {
+				t.Errorf("parseArchiveOptions() = %+v, want %+v", opts, test.expected)
+			}
+		})
+	}
+}
+
+func TestBuildArchives(t *testing.T) {
+	masker := &masking.Masker{}
+	if err := masker.Update(&configv1alpha1.MaskingConfiguration{Rules: []configv1alpha1.MaskingRule{
+		{Resource: "configmaps", Stage: configv1alpha1.MaskingStageServing, Fields: []string{"data"}},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	rules := masker.Rules(corev1.SchemeGroupVersion.WithResource("configmaps"), configv1alpha1.MaskingStageServing)
+
+	archivedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	archives := []storage.Archive{{
+		ID:         "default/settings/1704067200000000000",
+		Namespace:  "default",
+		Name:       "settings",
+		Reason:     storage.ArchiveReasonPurged,
+		ArchivedAt: archivedAt,
+		Object:     []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"default"},"data":{"password":"secret"}}`),
+	}}
+	result, err := buildArchives(archives, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Kind != "Archives" || len(result.Items) != 1 {
+		t.Fatalf("buildArchives() = %+v", result)
+	}
+
+	archive := result.Items[0]
+	if archive.ID != archives[0].ID || archive.Reason != "Purged" || !archive.ArchivedAt.Time.Equal(archivedAt) {
+		t.Errorf("buildArchives() returns the archive %+v", archive)
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(archive.Object.Raw, &object); err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]interface{}{"password": masking.DefaultReplacement}; !reflect.DeepEqual(object["data"], expected) {
+		t.Errorf("the archived object is not masked, data = %v", object["data"])
+	}
+}
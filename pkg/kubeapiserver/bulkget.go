@@ -0,0 +1,249 @@
+package kubeapiserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/api/clusterpedia/fields"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	clusterpediastorage "github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const (
+	BulkGetPath = "/bulkget"
+
+	// MaxBulkGetObjects is the max number of the objects in a bulk get request
+	MaxBulkGetObjects = 1000
+)
+
+// BulkGetHandler fetches multiple objects of the clusters in one request.
+//
+// The references of the same resource, cluster and namespace are fetched with one list request to the storage,
+// so fetching hundreds of objects costs a few storage queries instead of hundreds of round trips.
+type BulkGetHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+}
+
+type bulkGetKey struct {
+	gvr       schema.GroupVersionResource
+	cluster   string
+	namespace string
+	byUID     bool
+}
+
+type bulkGetObjectKey struct {
+	cluster   string
+	namespace string
+	name      string
+}
+
+func (h *BulkGetHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "bulkget"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	bulk, err := decodeBulkGet(req)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	// the cluster in the path is the default cluster of the references
+	if cluster := request.ClusterNameValue(req.Context()); cluster != "" {
+		for i := range bulk.Spec.Objects {
+			if bulk.Spec.Objects[i].Cluster == "" {
+				bulk.Spec.Objects[i].Cluster = cluster
+			}
+		}
+	}
+
+	bulk.Status.Results = h.get(req.Context(), bulk.Spec.Objects)
+	bulk.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("BulkGet"))
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, bulk, false)
+}
+
+// decodeRequestObject decodes the body of the request into the object,
+// the kind is optional in the body.
+func decodeRequestObject(req *http.Request, gvk schema.GroupVersionKind, into runtime.Object) error {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+
+	obj, _, err := clusterpediascheme.Codecs.UniversalDeserializer().Decode(body, &gvk, into)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	if obj != into {
+		return apierrors.NewBadRequest(fmt.Sprintf("unexpected object kind: %s", obj.GetObjectKind().GroupVersionKind()))
+	}
+	return nil
+}
+
+func decodeBulkGet(req *http.Request) (*v1beta1.BulkGet, error) {
+	bulk := &v1beta1.BulkGet{}
+	if err := decodeRequestObject(req, v1beta1.SchemeGroupVersion.WithKind("BulkGet"), bulk); err != nil {
+		return nil, err
+	}
+
+	if len(bulk.Spec.Objects) > MaxBulkGetObjects {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("too many objects, the max number of the objects is %d", MaxBulkGetObjects))
+	}
+	for i, ref := range bulk.Spec.Objects {
+		switch {
+		case ref.Version == "" || ref.Resource == "":
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("spec.objects[%d]: version and resource are required", i))
+		case ref.Name == "" && ref.UID == "":
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("spec.objects[%d]: one of name or uid is required", i))
+		case ref.UID == "" && ref.Cluster == "":
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("spec.objects[%d]: cluster is required when getting the object by name", i))
+		}
+	}
+	return bulk, nil
+}
+
+func (h *BulkGetHandler) get(ctx context.Context, refs []v1beta1.BulkGetObjectReference) []v1beta1.BulkGetResult {
+	results := make([]v1beta1.BulkGetResult, len(refs))
+
+	var keys []bulkGetKey
+	groups := make(map[bulkGetKey][]int)
+	for i, ref := range refs {
+		key := bulkGetKey{
+			gvr:       schema.GroupVersionResource{Group: ref.Group, Version: ref.Version, Resource: ref.Resource},
+			cluster:   ref.Cluster,
+			namespace: ref.Namespace,
+			byUID:     ref.UID != "",
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	for _, key := range keys {
+		indexes := groups[key]
+		objs, err := h.list(ctx, key, refs, indexes)
+		if err != nil {
+			for _, i := range indexes {
+				results[i].Error = statusFor(err)
+			}
+			continue
+		}
+
+		byName := make(map[bulkGetObjectKey]runtime.Object, len(objs))
+		byUID := make(map[types.UID]runtime.Object, len(objs))
+		for _, obj := range objs {
+			m, err := meta.Accessor(obj)
+			if err != nil {
+				continue
+			}
+			byName[bulkGetObjectKey{utils.ExtractClusterName(obj), m.GetNamespace(), m.GetName()}] = obj
+			byUID[m.GetUID()] = obj
+		}
+
+		for _, i := range indexes {
+			ref := refs[i]
+
+			var obj runtime.Object
+			if key.byUID {
+				obj = byUID[ref.UID]
+			} else {
+				obj = byName[bulkGetObjectKey{ref.Cluster, ref.Namespace, ref.Name}]
+			}
+
+			if obj == nil {
+				name := ref.Name
+				if name == "" {
+					name = string(ref.UID)
+				}
+				results[i].Error = statusFor(apierrors.NewNotFound(key.gvr.GroupResource(), name))
+				continue
+			}
+			results[i].Object = runtime.RawExtension{Object: obj}
+		}
+	}
+	return results
+}
+
+// list lists the objects of the references in the group from the storage,
+// the objects are converted to the version of the group.
+func (h *BulkGetHandler) list(ctx context.Context, key bulkGetKey, refs []v1beta1.BulkGetObjectReference, indexes []int) ([]runtime.Object, error) {
+	if !h.discovery.ResourceEnabled(key.cluster, key.gvr) {
+		return nil, apierrors.NewNotFound(key.gvr.GroupResource(), "")
+	}
+
+	storage, scope, err := h.rest.GetResourceStorage(key.gvr)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &internal.ListOptions{}
+	if key.cluster != "" {
+		opts.ClusterNames = []string{key.cluster}
+	}
+	if key.namespace != "" {
+		opts.Namespaces = []string{key.namespace}
+	}
+	if key.byUID {
+		uids := make([]string, 0, len(indexes))
+		for _, i := range indexes {
+			uids = append(uids, string(refs[i].UID))
+		}
+		selector, err := fields.Parse(fmt.Sprintf("metadata.uid in (%s)", strings.Join(uids, ",")))
+		if err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+		opts.EnhancedFieldSelector = selector
+	} else {
+		for _, i := range indexes {
+			opts.Names = append(opts.Names, refs[i].Name)
+		}
+	}
+
+	list := storage.NewList()
+	if err := storage.Storage.List(ctx, list, opts); err != nil {
+		return nil, clusterpediastorage.InterpretListError(err, key.gvr.GroupResource())
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	for i, obj := range objs {
+		if objs[i], err = scope.Convertor.ConvertToVersion(obj, key.gvr.GroupVersion()); err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+	}
+	return objs, nil
+}
+
+func statusFor(err error) *metav1.Status {
+	if status, ok := err.(apierrors.APIStatus); ok {
+		s := status.Status()
+		return &s
+	}
+	s := apierrors.NewInternalError(err).Status()
+	return &s
+}
@@ -0,0 +1,67 @@
+package kubeapiserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeBulkGet(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		expectedErr bool
+		expected    int
+	}{
+		{
+			name:     "without type meta",
+			body:     `{"spec":{"objects":[{"cluster":"cluster-1","version":"v1","resource":"pods","namespace":"default","name":"pod-1"}]}}`,
+			expected: 1,
+		},
+		{
+			name:     "with type meta",
+			body:     `{"apiVersion":"clusterpedia.io/v1beta1","kind":"BulkGet","spec":{"objects":[{"group":"apps","version":"v1","resource":"deployments","uid":"1234"}]}}`,
+			expected: 1,
+		},
+		{
+			name:        "missing resource",
+			body:        `{"spec":{"objects":[{"cluster":"cluster-1","version":"v1","name":"pod-1"}]}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "missing name and uid",
+			body:        `{"spec":{"objects":[{"cluster":"cluster-1","version":"v1","resource":"pods"}]}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "missing cluster",
+			body:        `{"spec":{"objects":[{"version":"v1","resource":"pods","name":"pod-1"}]}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "too many objects",
+			body:        `{"spec":{"objects":[` + strings.Repeat(`{"cluster":"c","version":"v1","resource":"pods","name":"p"},`, MaxBulkGetObjects) + `{"cluster":"c","version":"v1","resource":"pods","name":"p"}]}}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, BulkGetPath, strings.NewReader(test.body))
+			bulk, err := decodeBulkGet(req)
+			if test.expectedErr {
+				if err == nil {
+					t.Error("decodeBulkGet() should return an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(bulk.Spec.Objects) != test.expected {
+				t.Errorf("decodeBulkGet() = %d objects, want %d", len(bulk.Spec.Objects), test.expected)
+			}
+		})
+	}
+}
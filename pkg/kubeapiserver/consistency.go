@@ -0,0 +1,251 @@
+package kubeapiserver
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+// ConsistencyQuery selects the consistency of the reads from the storage, the reads are eventually consistent by default.
+//
+//	consistency=eventual                 the stored objects are returned however stale they are
+//	consistency=bounded-staleness:<N>    the reads fail if the stored objects may lag behind the clusters for more than N seconds
+//	consistency=read-your-writes         the reads of the resources written through the proxy are forwarded to the cluster
+//	                                     until the writes are synchronized
+const ConsistencyQuery = "consistency"
+
+const (
+	EventualConsistency         = "eventual"
+	BoundedStalenessConsistency = "bounded-staleness"
+	ReadYourWritesConsistency   = "read-your-writes"
+)
+
+// unboundedStaleness is the staleness of the resources which may never be synchronized,
+// e.g. the cluster or the resource has never been synchronized.
+const unboundedStaleness = time.Duration(math.MaxInt64)
+
+type consistency struct {
+	level        string
+	maxStaleness time.Duration
+}
+
+func parseConsistency(value string) (consistency, error) {
+	level, staleness, hasStaleness := strings.Cut(value, ":")
+	switch level {
+	case "", EventualConsistency, ReadYourWritesConsistency:
+		if hasStaleness {
+			return consistency{}, fmt.Errorf("invalid consistency %q, only %s accepts the staleness", value, BoundedStalenessConsistency)
+		}
+		if level == "" {
+			level = EventualConsistency
+		}
+		return consistency{level: level}, nil
+	case BoundedStalenessConsistency:
+		seconds, err := strconv.ParseInt(staleness, 10, 64)
+		if err != nil || seconds <= 0 {
+			return consistency{}, fmt.Errorf("invalid consistency %q, the staleness must be a positive number of seconds, e.g. %s:30", value, BoundedStalenessConsistency)
+		}
+		return consistency{level: level, maxStaleness: time.Duration(seconds) * time.Second}, nil
+	}
+	return consistency{}, fmt.Errorf("unsupported consistency %q, supported consistencies are %s, %s:<seconds> and %s",
+		value, EventualConsistency, BoundedStalenessConsistency, ReadYourWritesConsistency)
+}
+
+// resourceStaleness estimates how long the stored objects of the resource may lag behind the cluster by the sync status,
+// they are not stale while the cluster and the storage are healthy and the resource is being synchronized.
+// The reason of the staleness is returned if the objects may be stale.
+func resourceStaleness(cluster *clusterv1alpha2.PediaCluster, gr schema.GroupResource, now time.Time) (time.Duration, string) {
+	since := func(t metav1.Time) time.Duration {
+		if t.IsZero() {
+			return unboundedStaleness
+		}
+		return now.Sub(t.Time)
+	}
+
+	healthy := meta.FindStatusCondition(cluster.Status.Conditions, clusterv1alpha2.ClusterHealthyCondition)
+	if healthy == nil {
+		return unboundedStaleness, "the cluster has not been synchronized"
+	}
+	if healthy.Status != metav1.ConditionTrue {
+		return since(healthy.LastTransitionTime), fmt.Sprintf("the cluster is unhealthy, reason: %s", healthy.Reason)
+	}
+	if storage := meta.FindStatusCondition(cluster.Status.Conditions, clusterv1alpha2.StorageHealthyCondition); storage != nil && storage.Status != metav1.ConditionTrue {
+		return since(storage.LastTransitionTime), fmt.Sprintf("the storage is unhealthy, reason: %s", storage.Reason)
+	}
+
+	var (
+		found     bool
+		staleness time.Duration
+		reason    string
+	)
+	for _, group := range cluster.Status.SyncResources {
+		if group.Group != gr.Group {
+			continue
+		}
+		for _, resource := range group.Resources {
+			if resource.Name != gr.Resource {
+				continue
+			}
+			for _, cond := range resource.SyncConditions {
+				found = true
+				switch {
+				case cond.InitialListPhase:
+					return unboundedStaleness, "the resource is in the initial list"
+				case cond.Status != clusterv1alpha2.ResourceSyncStatusSyncing:
+					if s := since(cond.LastTransitionTime); s > staleness {
+						staleness, reason = s, fmt.Sprintf("the resource is %s, reason: %s", cond.Status, cond.Reason)
+					}
+				}
+			}
+		}
+	}
+	if !found {
+		return unboundedStaleness, "the resource has not been synchronized"
+	}
+	return staleness, reason
+}
+
+// proxyWrites records the last time the resources of the clusters are written through the proxy,
+// the records expire after the window, which is expected to be longer than the lag of the synchronization.
+type proxyWrites struct {
+	window time.Duration
+
+	lock   sync.Mutex
+	writes map[proxyWriteKey]time.Time
+}
+
+type proxyWriteKey struct {
+	cluster  string
+	resource schema.GroupResource
+}
+
+func newProxyWrites(window time.Duration) *proxyWrites {
+	return &proxyWrites{window: window, writes: make(map[proxyWriteKey]time.Time)}
+}
+
+func (w *proxyWrites) record(cluster string, gr schema.GroupResource, now time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	// remove the expired records, the records are few since they are only kept in the window
+	for key, written := range w.writes {
+		if now.Sub(written) > w.window {
+			delete(w.writes, key)
+		}
+	}
+	w.writes[proxyWriteKey{cluster: cluster, resource: gr}] = now
+}
+
+// pendingClusters returns the clusters whose resource was written in the window, the cluster is not filtered if it is empty.
+func (w *proxyWrites) pendingClusters(cluster string, gr schema.GroupResource, now time.Time) []string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var clusters []string
+	for key, written := range w.writes {
+		if key.resource == gr && (cluster == "" || key.cluster == cluster) && now.Sub(written) <= w.window {
+			clusters = append(clusters, key.cluster)
+		}
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+// WrapProxyHandler records the successful writes of the resources through the proxy.
+func (w *proxyWrites) WrapProxyHandler(proxy http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requestInfo, ok := genericrequest.RequestInfoFrom(req.Context())
+		if !ok || !requestInfo.IsResourceRequest || requestInfo.Subresource != "" || !isWriteVerb(requestInfo.Verb) {
+			proxy.ServeHTTP(rw, req)
+			return
+		}
+
+		recorder := &statusRecorder{ResponseWriter: rw, status: http.StatusOK}
+		proxy.ServeHTTP(recorder, req)
+
+		cluster := request.ClusterNameValue(req.Context())
+		if cluster != "" && recorder.status < http.StatusMultipleChoices {
+			w.record(cluster, schema.GroupResource{Group: requestInfo.APIGroup, Resource: requestInfo.Resource}, time.Now())
+		}
+	})
+}
+
+func isWriteVerb(verb string) bool {
+	switch verb {
+	case "create", "update", "patch", "delete", "deletecollection":
+		return true
+	}
+	return false
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// consistencyDecision is how a read with the consistency is served
+type consistencyDecision int
+
+const (
+	serveFromStorage consistencyDecision = iota
+	forwardToCluster
+)
+
+// enforceConsistency decides how the read of the resource in the clusters is served with the consistency,
+// an error is returned if the consistency can not be satisfied.
+func enforceConsistency(c consistency, writes *proxyWrites, clusterName string, clusters []*clusterv1alpha2.PediaCluster, gr schema.GroupResource, now time.Time) (consistencyDecision, error) {
+	switch c.level {
+	case BoundedStalenessConsistency:
+		for _, cluster := range clusters {
+			staleness, reason := resourceStaleness(cluster, gr, now)
+			if staleness <= c.maxStaleness {
+				continue
+			}
+
+			lag := "unknown"
+			if staleness != unboundedStaleness {
+				lag = staleness.Truncate(time.Second).String()
+			}
+			return serveFromStorage, apierrors.NewServiceUnavailable(fmt.Sprintf(
+				"the %s of cluster %s may be stale for %s, which exceeds the bounded staleness %s: %s",
+				gr, cluster.Name, lag, c.maxStaleness, reason,
+			))
+		}
+	case ReadYourWritesConsistency:
+		if writes == nil {
+			return serveFromStorage, nil
+		}
+
+		pending := writes.pendingClusters(clusterName, gr, now)
+		if len(pending) == 0 {
+			return serveFromStorage, nil
+		}
+		if clusterName != "" {
+			return forwardToCluster, nil
+		}
+		return serveFromStorage, apierrors.NewServiceUnavailable(fmt.Sprintf(
+			"the %s written through the proxy to clusters %s may not be synchronized yet, read them from the specified cluster",
+			gr, strings.Join(pending, ","),
+		))
+	}
+	return serveFromStorage, nil
+}
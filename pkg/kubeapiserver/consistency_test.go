@@ -0,0 +1,152 @@
+package kubeapiserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+var deploymentsGR = schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+func TestParseConsistency(t *testing.T) {
+	tests := []struct {
+		value     string
+		expected  consistency
+		wantError bool
+	}{
+		{value: "", expected: consistency{level: EventualConsistency}},
+		{value: "eventual", expected: consistency{level: EventualConsistency}},
+		{value: "read-your-writes", expected: consistency{level: ReadYourWritesConsistency}},
+		{value: "bounded-staleness:30", expected: consistency{level: BoundedStalenessConsistency, maxStaleness: 30 * time.Second}},
+		{value: "bounded-staleness", wantError: true},
+		{value: "bounded-staleness:0", wantError: true},
+		{value: "eventual:30", wantError: true},
+		{value: "strong", wantError: true},
+	}
+	for _, test := range tests {
+		c, err := parseConsistency(test.value)
+		if test.wantError {
+			if err == nil {
+				t.Errorf("%q: expected an error", test.value)
+			}
+			continue
+		}
+		if err != nil || c != test.expected {
+			t.Errorf("%q: expected %v, got %v, %v", test.value, test.expected, c, err)
+		}
+	}
+}
+
+func newSyncedCluster(name string, now time.Time, resourceStatus string) *clusterv1alpha2.PediaCluster {
+	return &clusterv1alpha2.PediaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: clusterv1alpha2.ClusterStatus{
+			Conditions: []metav1.Condition{
+				{Type: clusterv1alpha2.ClusterHealthyCondition, Status: metav1.ConditionTrue, Reason: clusterv1alpha2.ClusterHealthyReason},
+			},
+			SyncResources: []clusterv1alpha2.ClusterGroupResourcesStatus{{
+				Group: "apps",
+				Resources: []clusterv1alpha2.ClusterResourceStatus{{
+					Name: "deployments",
+					SyncConditions: []clusterv1alpha2.ClusterResourceSyncCondition{{
+						Version:            "v1",
+						Status:             resourceStatus,
+						Reason:             "WatchFailed",
+						LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+					}},
+				}},
+			}},
+		},
+	}
+}
+
+func TestResourceStaleness(t *testing.T) {
+	now := time.Now()
+
+	if staleness, _ := resourceStaleness(newSyncedCluster("cluster-1", now, clusterv1alpha2.ResourceSyncStatusSyncing), deploymentsGR, now); staleness != 0 {
+		t.Errorf("expected the syncing resource is not stale, got %s", staleness)
+	}
+	if staleness, _ := resourceStaleness(newSyncedCluster("cluster-1", now, clusterv1alpha2.ResourceSyncStatusError), deploymentsGR, now); staleness != time.Minute {
+		t.Errorf("expected the resource is stale since the sync is failed, got %s", staleness)
+	}
+	if staleness, _ := resourceStaleness(newSyncedCluster("cluster-1", now, clusterv1alpha2.ResourceSyncStatusSyncing), schema.GroupResource{Resource: "pods"}, now); staleness != unboundedStaleness {
+		t.Errorf("expected the unsynchronized resource is unboundedly stale, got %s", staleness)
+	}
+
+	cluster := newSyncedCluster("cluster-1", now, clusterv1alpha2.ResourceSyncStatusSyncing)
+	cluster.Status.Conditions = append(cluster.Status.Conditions, metav1.Condition{
+		Type:               clusterv1alpha2.StorageHealthyCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             clusterv1alpha2.StorageUnavailableReason,
+		LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Minute)),
+	})
+	if staleness, _ := resourceStaleness(cluster, deploymentsGR, now); staleness != 2*time.Minute {
+		t.Errorf("expected the resource is stale since the storage is unhealthy, got %s", staleness)
+	}
+}
+
+func TestEnforceBoundedStaleness(t *testing.T) {
+	now := time.Now()
+	clusters := []*clusterv1alpha2.PediaCluster{
+		newSyncedCluster("cluster-1", now, clusterv1alpha2.ResourceSyncStatusSyncing),
+		newSyncedCluster("cluster-2", now, clusterv1alpha2.ResourceSyncStatusError),
+	}
+
+	bounded := consistency{level: BoundedStalenessConsistency, maxStaleness: 30 * time.Second}
+	if _, err := enforceConsistency(bounded, nil, "cluster-1", clusters[:1], deploymentsGR, now); err != nil {
+		t.Errorf("expected the fresh cluster is read, got %v", err)
+	}
+	if _, err := enforceConsistency(bounded, nil, "", clusters, deploymentsGR, now); !apierrors.IsServiceUnavailable(err) {
+		t.Errorf("expected the stale cluster is not read, got %v", err)
+	}
+
+	bounded.maxStaleness = 2 * time.Minute
+	if _, err := enforceConsistency(bounded, nil, "", clusters, deploymentsGR, now); err != nil {
+		t.Errorf("expected the staleness is in the bound, got %v", err)
+	}
+}
+
+func TestEnforceReadYourWrites(t *testing.T) {
+	writes := newProxyWrites(30 * time.Second)
+	proxy := writes.WrapProxyHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+
+	write := func(verb, method string) {
+		req := httptest.NewRequest(method, "/apis/apps/v1/namespaces/default/deployments", nil)
+		ctx := genericrequest.WithRequestInfo(req.Context(), &genericrequest.RequestInfo{
+			IsResourceRequest: true, Verb: verb, APIGroup: "apps", APIVersion: "v1", Resource: "deployments",
+		})
+		proxy.ServeHTTP(httptest.NewRecorder(), req.WithContext(request.WithClusterName(ctx, "cluster-1")))
+	}
+	write("delete", http.MethodDelete)
+	if pending := writes.pendingClusters("", deploymentsGR, time.Now()); len(pending) != 0 {
+		t.Fatalf("expected the failed write is not recorded, got %v", pending)
+	}
+	write("create", http.MethodPost)
+
+	readYourWrites := consistency{level: ReadYourWritesConsistency}
+	if decision, err := enforceConsistency(readYourWrites, writes, "cluster-1", nil, deploymentsGR, time.Now()); err != nil || decision != forwardToCluster {
+		t.Errorf("expected the read is forwarded to the written cluster, got %v, %v", decision, err)
+	}
+	if decision, err := enforceConsistency(readYourWrites, writes, "cluster-2", nil, deploymentsGR, time.Now()); err != nil || decision != serveFromStorage {
+		t.Errorf("expected the read of the other cluster is served from the storage, got %v, %v", decision, err)
+	}
+	if _, err := enforceConsistency(readYourWrites, writes, "", nil, deploymentsGR, time.Now()); !apierrors.IsServiceUnavailable(err) {
+		t.Errorf("expected the read of all clusters is rejected, got %v", err)
+	}
+	if decision, err := enforceConsistency(readYourWrites, writes, "cluster-1", nil, deploymentsGR, time.Now().Add(time.Minute)); err != nil || decision != serveFromStorage {
+		t.Errorf("expected the read is served from the storage after the window, got %v, %v", decision, err)
+	}
+}
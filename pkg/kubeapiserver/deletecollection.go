@@ -0,0 +1,107 @@
+package kubeapiserver
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const DeleteCollectionPath = "/deletecollection"
+
+// DeleteCollectionHandler deletes the stored objects of a resource in a cluster in one statement of the storage,
+// e.g. the stale objects left in the storage, it doesn't delete the objects from the cluster.
+//
+// The cluster is specified by the path, e.g. `/apis/clusterpedia.io/v1beta1/resources/clusters/<cluster>/deletecollection`,
+// the resource is specified by the `group`, `version` and `resource` queries, and the objects are selected by
+// the `namespace` and `labelSelector` queries, at least one of them is required to avoid deleting all objects by mistake.
+// The objects still existing in the cluster are stored again when they are updated or the resource is relisted.
+type DeleteCollectionHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+}
+
+func NewDeleteCollectionHandler(rest *RESTManager, discovery *discovery.DiscoveryManager) *DeleteCollectionHandler {
+	return &DeleteCollectionHandler{rest: rest, discovery: discovery}
+}
+
+func (h *DeleteCollectionHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodDelete {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "deletecollection"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	query := req.URL.Query()
+	gvr := schema.GroupVersionResource{Group: query.Get("group"), Version: query.Get("version"), Resource: query.Get("resource")}
+	if gvr.Version == "" || gvr.Resource == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("version and resource queries are required"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	cluster := request.ClusterNameValue(req.Context())
+	if cluster == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("the collection is only deleted in the path of a cluster"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	opts, err := parseDeleteCollectionOptions(cluster, query)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	if !h.discovery.ResourceEnabled(cluster, gvr) {
+		responsewriters.ErrorNegotiated(apierrors.NewNotFound(gvr.GroupResource(), ""), Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	resourceStorage, _, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	deleter, ok := resourceStorage.Storage.(storage.CollectionDeleter)
+	if !ok {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(gvr.GroupResource(), "deletecollection"), Codecs, gvr.GroupVersion(), w, req,
+		)
+		return
+	}
+
+	deleted, err := deleter.DeleteCollection(req.Context(), opts)
+	if err != nil {
+		responsewriters.ErrorNegotiated(storage.InterpretListError(err, gvr.GroupResource()), Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	writeSuccessStatus(w, req, fmt.Sprintf("%d %s are deleted from the storage", deleted, gvr.GroupResource()))
+}
+
+func parseDeleteCollectionOptions(cluster string, query url.Values) (storage.DeleteCollectionOptions, error) {
+	opts := storage.DeleteCollectionOptions{Clusters: []string{cluster}, Namespaces: query["namespace"]}
+	if value := query.Get("labelSelector"); value != "" {
+		selector, err := labels.Parse(value)
+		if err != nil {
+			return opts, apierrors.NewBadRequest(fmt.Sprintf("invalid labelSelector query: %v", err))
+		}
+		opts.LabelSelector = selector
+	}
+	if len(opts.Namespaces) == 0 && opts.LabelSelector == nil {
+		return opts, apierrors.NewBadRequest("namespace or labelSelector query is required")
+	}
+	return opts, nil
+}
@@ -0,0 +1,49 @@
+package kubeapiserver
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseDeleteCollectionOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		namespaces []string
+		selector   string
+		wantErr    bool
+	}{
+		{name: "namespaces", query: "namespace=ci&namespace=dev", namespaces: []string{"ci", "dev"}},
+		{name: "label selector", query: "labelSelector=app%3Dweb,!tier", selector: "app=web,!tier"},
+		{name: "namespace and label selector", query: "namespace=ci&labelSelector=app%3Dweb", namespaces: []string{"ci"}, selector: "app=web"},
+		{name: "no selectors", query: "group=batch&version=v1&resource=jobs", wantErr: true},
+		{name: "invalid label selector", query: "labelSelector=app%3D%3D%3D", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts, err := parseDeleteCollectionOptions("cluster-1", query)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseDeleteCollectionOptions() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(opts.Clusters, []string{"cluster-1"}) || !reflect.DeepEqual(opts.Namespaces, test.namespaces) {
+				t.Errorf("parseDeleteCollectionOptions() clusters = %v, namespaces = %v, want [cluster-1] and %v", opts.Clusters, opts.Namespaces, test.namespaces)
+			}
+			selector := ""
+			if opts.LabelSelector != nil {
+				selector = opts.LabelSelector.String()
+			}
+			if selector != test.selector {
+				t.Errorf("parseDeleteCollectionOptions() label selector = %q, want %q", selector, test.selector)
+			}
+		})
+	}
+}
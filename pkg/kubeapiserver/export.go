@@ -0,0 +1,311 @@
+package kubeapiserver
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"sigs.k8s.io/yaml"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	clusterpediastorage "github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const ExportPath = "/export"
+
+const (
+	ExportFormatTar       = "tar"
+	ExportFormatKustomize = "kustomize"
+
+	// clusterScopedDir is the directory of the cluster scoped objects in the bundle
+	clusterScopedDir = "_cluster"
+)
+
+// clusterMetadataFields are the metadata fields set by the cluster, they are removed from the exported manifests,
+// and the owner references are removed too, because the owners may be deleted with the objects.
+var clusterMetadataFields = []string{
+	"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp", "deletionGracePeriodSeconds",
+	"managedFields", "selfLink", "ownerReferences",
+}
+
+// ExportHandler serves the objects matched by the search queries as a bundle of the cleaned manifests,
+// so that the stored state can be applied to the other clusters for the disaster recovery or the cloning.
+//
+// The resources are specified by the `group`, `version` and `resource` queries, or by the `resources` query in
+// the form of `[<group>/]<version>/<resource>` which can be repeated, and the objects are filtered by the search
+// queries of the list. The bundle is a gzipped tarball whose manifests are laid out as
+// `<cluster>/<namespace>/<resource>.<group>/<name>.yaml`, the cluster scoped objects are in the `_cluster` directory,
+// and the `format=kustomize` query adds the `kustomization.yaml` of the manifests to the directory of each cluster.
+//
+// The status, the metadata set by the cluster, the shadow annotations of clusterpedia and the cluster IPs of
+// the services are removed from the manifests, and the fields are masked by the serving rules of the masking.
+type ExportHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+}
+
+func NewExportHandler(rest *RESTManager, discovery *discovery.DiscoveryManager) *ExportHandler {
+	return &ExportHandler{rest: rest, discovery: discovery}
+}
+
+func (h *ExportHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "export"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	query := req.URL.Query()
+	gvrs, err := parseExportResources(query.Get("group"), query.Get("version"), query.Get("resource"), query["resources"])
+	if err != nil {
+		responsewriters.ErrorNegotiated(apierrors.NewBadRequest(err.Error()), Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+	format := query.Get("format")
+	switch format {
+	case "":
+		format = ExportFormatTar
+	case ExportFormatTar, ExportFormatKustomize:
+	default:
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest(fmt.Sprintf("unsupported format %q, supported formats are tar and kustomize", format)),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	version, ok := request.VersionFrom(req.Context())
+	if !ok {
+		version = v1beta1.SchemeGroupVersion
+	}
+	opts := &internal.ListOptions{}
+	if err := clusterpediascheme.DecodeListOptions(query, version, opts); err != nil {
+		responsewriters.ErrorNegotiated(apierrors.NewBadRequest(err.Error()), Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+	cluster := request.ClusterNameValue(req.Context())
+	if cluster != "" {
+		opts.ClusterNames = []string{cluster}
+	}
+	opts.Limit, opts.Continue = 0, ""
+
+	bundle := newManifestBundle()
+	for _, gvr := range gvrs {
+		if err := h.exportResource(req.Context(), bundle, gvr, cluster, opts); err != nil {
+			responsewriters.ErrorNegotiated(err, Codecs, gvr.GroupVersion(), w, req)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", `attachment; filename="clusterpedia-export.tar.gz"`)
+	w.WriteHeader(http.StatusOK)
+	// the response can't be changed to an error after the status is written
+	_ = bundle.write(w, format == ExportFormatKustomize)
+}
+
+func (h *ExportHandler) exportResource(ctx context.Context, bundle *manifestBundle, gvr schema.GroupVersionResource, cluster string, opts *internal.ListOptions) error {
+	if !h.discovery.ResourceEnabled(cluster, gvr) {
+		return apierrors.NewNotFound(gvr.GroupResource(), "")
+	}
+	storage, scope, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		return err
+	}
+
+	list := storage.NewList()
+	if err := storage.Storage.List(ctx, list, opts); err != nil {
+		return clusterpediastorage.InterpretListError(err, gvr.GroupResource())
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+
+	rules := storage.Masker.Rules(gvr, configv1alpha1.MaskingStageServing)
+	for _, obj := range objs {
+		versioned, err := scope.Convertor.ConvertToVersion(obj, gvr.GroupVersion())
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(versioned)
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		if kind, _ := content["kind"].(string); kind == "" {
+			content["apiVersion"], content["kind"] = gvr.GroupVersion().String(), scope.Kind.Kind
+		}
+
+		cleanManifest(content)
+		rules.Mask(content)
+		if err := bundle.add(utils.ExtractClusterName(obj), gvr, content); err != nil {
+			return apierrors.NewInternalError(err)
+		}
+	}
+	return nil
+}
+
+// parseExportResources parses the resource of the `group`, `version` and `resource` queries
+// and the resources of the `resources` queries, each query may have multiple resources separated by the comma.
+func parseExportResources(group, version, resource string, values []string) ([]schema.GroupVersionResource, error) {
+	var gvrs []schema.GroupVersionResource
+	if version != "" || resource != "" {
+		if version == "" || resource == "" {
+			return nil, fmt.Errorf("both version and resource queries are required")
+		}
+		gvrs = append(gvrs, schema.GroupVersionResource{Group: group, Version: version, Resource: resource})
+	}
+
+	for _, value := range values {
+		for _, r := range strings.Split(value, ",") {
+			if r = strings.TrimSpace(r); r == "" {
+				continue
+			}
+
+			parts := strings.Split(r, "/")
+			if len(parts) == 2 {
+				parts = append([]string{""}, parts...)
+			}
+			if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+				return nil, fmt.Errorf("invalid resource %q, it should be in the form of [<group>/]<version>/<resource>", r)
+			}
+			gvrs = append(gvrs, schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]})
+		}
+	}
+	if len(gvrs) == 0 {
+		return nil, fmt.Errorf("version and resource queries or resources query are required")
+	}
+	return gvrs, nil
+}
+
+// cleanManifest removes the status, the metadata set by the cluster and the shadow annotations of clusterpedia,
+// so the object can be created by applying the manifest.
+func cleanManifest(object map[string]interface{}) {
+	delete(object, "status")
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		for _, field := range clusterMetadataFields {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			for key := range annotations {
+				if strings.Contains(key, "shadow.clusterpedia.io/") {
+					delete(annotations, key)
+				}
+			}
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+
+	// the cluster IPs are allocated by the cluster, except the headless services
+	if apiVersion, _ := object["apiVersion"].(string); apiVersion == "v1" && object["kind"] == "Service" {
+		if clusterIP, _, _ := unstructured.NestedString(object, "spec", "clusterIP"); clusterIP != "None" {
+			unstructured.RemoveNestedField(object, "spec", "clusterIP")
+			unstructured.RemoveNestedField(object, "spec", "clusterIPs")
+		}
+	}
+}
+
+// manifestBundle holds the manifests of the exported objects by their paths in the bundle
+type manifestBundle struct {
+	files map[string][]byte
+
+	// resources are the paths of the manifests in the directory of each cluster
+	resources map[string][]string
+}
+
+func newManifestBundle() *manifestBundle {
+	return &manifestBundle{files: make(map[string][]byte), resources: make(map[string][]string)}
+}
+
+func (b *manifestBundle) add(cluster string, gvr schema.GroupVersionResource, object map[string]interface{}) error {
+	u := &unstructured.Unstructured{Object: object}
+	namespace := u.GetNamespace()
+	if namespace == "" {
+		namespace = clusterScopedDir
+	}
+	resource := gvr.Resource
+	if gvr.Group != "" {
+		resource += "." + gvr.Group
+	}
+
+	manifest, err := yaml.Marshal(object)
+	if err != nil {
+		return err
+	}
+	file := path.Join(namespace, resource, u.GetName()+".yaml")
+	if _, ok := b.files[path.Join(cluster, file)]; !ok {
+		b.resources[cluster] = append(b.resources[cluster], file)
+	}
+	b.files[path.Join(cluster, file)] = manifest
+	return nil
+}
+
+// write writes the bundle as a gzipped tarball, the kustomization of the manifests is written to
+// the directory of each cluster if kustomize is true.
+func (b *manifestBundle) write(w io.Writer, kustomize bool) error {
+	files := b.files
+	if kustomize {
+		files = make(map[string][]byte, len(b.files)+len(b.resources))
+		for name, data := range b.files {
+			files[name] = data
+		}
+		for cluster, resources := range b.resources {
+			sort.Strings(resources)
+			kustomization, err := yaml.Marshal(map[string]interface{}{
+				"apiVersion": "kustomize.config.k8s.io/v1beta1",
+				"kind":       "Kustomization",
+				"resources":  resources,
+			})
+			if err != nil {
+				return err
+			}
+			files[path.Join(cluster, "kustomization.yaml")] = kustomization
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	now := time.Now()
+	for _, name := range names {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), ModTime: now, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
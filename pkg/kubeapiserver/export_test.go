@@ -0,0 +1,191 @@
+package kubeapiserver
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseExportResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		group    string
+		version  string
+		resource string
+		values   []string
+		expected []schema.GroupVersionResource
+		wantErr  bool
+	}{
+		{
+			name:     "resource queries",
+			group:    "apps",
+			version:  "v1",
+			resource: "deployments",
+			expected: []schema.GroupVersionResource{{Group: "apps", Version: "v1", Resource: "deployments"}},
+		},
+		{
+			name:   "resources queries",
+			values: []string{"apps/v1/deployments, v1/configmaps", "v1/services"},
+			expected: []schema.GroupVersionResource{
+				{Group: "apps", Version: "v1", Resource: "deployments"},
+				{Version: "v1", Resource: "configmaps"},
+				{Version: "v1", Resource: "services"},
+			},
+		},
+		{name: "missing version", resource: "deployments", wantErr: true},
+		{name: "invalid resource", values: []string{"deployments"}, wantErr: true},
+		{name: "no resources", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gvrs, err := parseExportResources(test.group, test.version, test.resource, test.values)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseExportResources() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && !reflect.DeepEqual(gvrs, test.expected) {
+				t.Errorf("parseExportResources() = %v, want %v", gvrs, test.expected)
+			}
+		})
+	}
+}
+
+func TestCleanManifest(t *testing.T) {
+	service := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]interface{}{
+			"name": "web", "namespace": "default", "uid": "7c4a", "resourceVersion": "100",
+			"annotations": map[string]interface{}{"shadow.clusterpedia.io/cluster-name": "cluster-1", "team": "a"},
+		},
+		"spec":   map[string]interface{}{"clusterIP": "10.0.0.1", "clusterIPs": []interface{}{"10.0.0.1"}, "type": "ClusterIP"},
+		"status": map[string]interface{}{"loadBalancer": map[string]interface{}{}},
+	}
+	cleanManifest(service)
+
+	expected := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]interface{}{
+			"name": "web", "namespace": "default",
+			"annotations": map[string]interface{}{"team": "a"},
+		},
+		"spec": map[string]interface{}{"type": "ClusterIP"},
+	}
+	if !reflect.DeepEqual(service, expected) {
+		t.Errorf("cleanManifest() = %v, want %v", service, expected)
+	}
+
+	headless := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": "db"},
+		"spec":       map[string]interface{}{"clusterIP": "None"},
+	}
+	cleanManifest(headless)
+	if headless["spec"].(map[string]interface{})["clusterIP"] != "None" {
+		t.Errorf("the cluster IP of the headless service should be kept, got %v", headless)
+	}
+}
+
+func TestManifestBundle(t *testing.T) {
+	bundle := newManifestBundle()
+	for _, obj := range []struct {
+		cluster string
+		gvr     schema.GroupVersionResource
+		object  map[string]interface{}
+	}{
+		{
+			cluster: "cluster-1",
+			gvr:     schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+			object:  map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": map[string]interface{}{"name": "web", "namespace": "default"}},
+		},
+		{
+			cluster: "cluster-1",
+			gvr:     schema.GroupVersionResource{Version: "v1", Resource: "namespaces"},
+			object:  map[string]interface{}{"apiVersion": "v1", "kind": "Namespace", "metadata": map[string]interface{}{"name": "default"}},
+		},
+		{
+			cluster: "cluster-2",
+			gvr:     schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+			object:  map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": map[string]interface{}{"name": "web", "namespace": "default"}},
+		},
+	} {
+		if err := bundle.add(obj.cluster, obj.gvr, obj.object); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifests := []string{
+		"cluster-1/_cluster/namespaces/default.yaml",
+		"cluster-1/default/deployments.apps/web.yaml",
+		"cluster-2/default/deployments.apps/web.yaml",
+	}
+	files := readBundle(t, bundle, false)
+	if names := sortedKeys(files); !reflect.DeepEqual(names, manifests) {
+		t.Errorf("the files of the tar bundle = %v, want %v", names, manifests)
+	}
+	if manifest := files["cluster-1/default/deployments.apps/web.yaml"]; !strings.Contains(manifest, "kind: Deployment") {
+		t.Errorf("unexpected manifest %q", manifest)
+	}
+
+	files = readBundle(t, bundle, true)
+	if len(files) != len(manifests)+2 {
+		t.Errorf("the kustomization of each cluster should be written, got %v", sortedKeys(files))
+	}
+	var kustomization struct {
+		Kind      string   `json:"kind"`
+		Resources []string `json:"resources"`
+	}
+	if err := yaml.Unmarshal([]byte(files["cluster-1/kustomization.yaml"]), &kustomization); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"_cluster/namespaces/default.yaml", "default/deployments.apps/web.yaml"}; kustomization.Kind != "Kustomization" ||
+		!reflect.DeepEqual(kustomization.Resources, expected) {
+		t.Errorf("unexpected kustomization %+v", kustomization)
+	}
+}
+
+func readBundle(t *testing.T, bundle *manifestBundle, kustomize bool) map[string]string {
+	var buf bytes.Buffer
+	if err := bundle.write(&buf, kustomize); err != nil {
+		t.Fatal(err)
+	}
+	gr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string]string)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(data)
+	}
+}
+
+func sortedKeys(files map[string]string) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
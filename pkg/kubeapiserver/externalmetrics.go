@@ -0,0 +1,138 @@
+package kubeapiserver
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/externalmetrics"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	clusterpediastorage "github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// ExternalMetricsHandler serves the external metrics api with the metrics aggregated from the resources in clusterpedia,
+// the value of a metric is the number of the objects that match the metric and the label selector of the request.
+type ExternalMetricsHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+	config    *externalmetrics.Config
+}
+
+func NewExternalMetricsHandler(rest *RESTManager, discovery *discovery.DiscoveryManager, config *externalmetrics.Config) *ExternalMetricsHandler {
+	return &ExternalMetricsHandler{rest: rest, discovery: discovery, config: config}
+}
+
+func (h *ExternalMetricsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	gv := externalmetrics.SchemeGroupVersion
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: gv.Group}, req.Method), Codecs, gv, w, req,
+		)
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, externalmetrics.Path), "/")
+	if path == "" {
+		responsewriters.WriteRawJSON(http.StatusOK, h.resourceList(), w)
+		return
+	}
+
+	// namespaces/<namespace>/<metric>
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[0] != "namespaces" {
+		responsewriters.ErrorNegotiated(apierrors.NewNotFound(schema.GroupResource{Group: gv.Group}, path), Codecs, gv, w, req)
+		return
+	}
+	namespace, name := parts[1], parts[2]
+	metric, ok := h.config.Get(name)
+	if !ok {
+		responsewriters.ErrorNegotiated(apierrors.NewNotFound(schema.GroupResource{Group: gv.Group, Resource: name}, name), Codecs, gv, w, req)
+		return
+	}
+
+	selector, err := labels.Parse(req.URL.Query().Get("labelSelector"))
+	if err != nil {
+		responsewriters.ErrorNegotiated(apierrors.NewBadRequest("invalid labelSelector query: "+err.Error()), Codecs, gv, w, req)
+		return
+	}
+
+	count, err := h.count(req.Context(), metric, namespace, selector)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, gv, w, req)
+		return
+	}
+
+	list := &externalmetrics.ExternalMetricValueList{
+		TypeMeta: metav1.TypeMeta{APIVersion: gv.String(), Kind: "ExternalMetricValueList"},
+		Items: []externalmetrics.ExternalMetricValue{
+			{
+				MetricName:   name,
+				MetricLabels: map[string]string{},
+				Timestamp:    metav1.NewTime(time.Now()),
+				Value:        *resource.NewQuantity(count, resource.DecimalSI),
+			},
+		},
+	}
+	responsewriters.WriteRawJSON(http.StatusOK, list, w)
+}
+
+func (h *ExternalMetricsHandler) resourceList() *metav1.APIResourceList {
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{APIVersion: "v1", Kind: "APIResourceList"},
+		GroupVersion: externalmetrics.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{},
+	}
+	for _, metric := range h.config.Metrics {
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:       metric.Name,
+			Namespaced: true,
+			Kind:       "ExternalMetricValueList",
+			Verbs:      metav1.Verbs{"get"},
+		})
+	}
+	return list
+}
+
+// count counts the objects of all clusters that match the metric and the selector of the request.
+func (h *ExternalMetricsHandler) count(ctx context.Context, metric externalmetrics.Metric, namespace string, selector labels.Selector) (int64, error) {
+	gvr := metric.GroupVersionResource()
+	if !h.discovery.ResourceEnabled("", gvr) {
+		return 0, apierrors.NewNotFound(schema.GroupResource{Group: externalmetrics.SchemeGroupVersion.Group, Resource: metric.Name}, metric.Name)
+	}
+	storage, _, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		return 0, err
+	}
+
+	// the metric has been validated
+	labelSelector, fieldSelector, _ := metric.Selectors()
+	if requirements, selectable := selector.Requirements(); selectable {
+		labelSelector = labelSelector.Add(requirements...)
+	}
+
+	opts := &internal.ListOptions{
+		ClusterNames:          metric.Clusters,
+		EnhancedFieldSelector: fieldSelector,
+		OnlyMetadata:          true,
+	}
+	opts.LabelSelector = labelSelector
+	if !metric.AllNamespaces {
+		opts.Namespaces = []string{namespace}
+	}
+
+	list := storage.NewList()
+	if err := storage.Storage.List(ctx, list, opts); err != nil {
+		return 0, clusterpediastorage.InterpretListError(err, gvr.GroupResource())
+	}
+	return int64(meta.LenList(list)), nil
+}
@@ -0,0 +1,47 @@
+package kubeapiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/externalmetrics"
+)
+
+func TestExternalMetricsHandler(t *testing.T) {
+	handler := NewExternalMetricsHandler(nil, nil, &externalmetrics.Config{
+		Metrics: []externalmetrics.Metric{{Name: "fleet-pending-pods", Version: "v1", Resource: "pods"}},
+	})
+
+	tests := []struct {
+		path         string
+		expectedCode int
+	}{
+		{path: externalmetrics.Path, expectedCode: http.StatusOK},
+		{path: externalmetrics.Path + "/namespaces/default/unknown", expectedCode: http.StatusNotFound},
+		{path: externalmetrics.Path + "/namespaces/default", expectedCode: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+			if w.Code != test.expectedCode {
+				t.Fatalf("status code = %d, want %d", w.Code, test.expectedCode)
+			}
+			if test.expectedCode != http.StatusOK {
+				return
+			}
+
+			var list metav1.APIResourceList
+			if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+				t.Fatal(err)
+			}
+			if list.GroupVersion != externalmetrics.SchemeGroupVersion.String() || len(list.APIResources) != 1 || list.APIResources[0].Name != "fleet-pending-pods" {
+				t.Errorf("unexpected resource list: %+v", list)
+			}
+		})
+	}
+}
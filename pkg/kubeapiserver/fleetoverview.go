@@ -0,0 +1,197 @@
+package kubeapiserver
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/klog/v2"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	clusterlister "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	FleetOverviewPath = "/fleetoverview"
+
+	fleetOverviewResyncPeriod = time.Minute
+)
+
+// syncLagBuckets are the upper bounds of the sync lag buckets, the last bucket has no upper bound.
+var syncLagBuckets = []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute, time.Hour, 6 * time.Hour}
+
+// FleetOverview summarizes the clusters and their stored objects.
+//
+// The stored objects of each cluster are summarized by the storage periodically,
+// the health of the clusters is read from the cluster lister when the overview is requested.
+type FleetOverview struct {
+	storageFactory storage.StorageFactory
+	clusterLister  clusterlister.PediaClusterLister
+
+	lock      sync.Mutex
+	summaries atomic.Pointer[map[string]*storage.ClusterSummary]
+}
+
+func NewFleetOverview(storageFactory storage.StorageFactory, clusterLister clusterlister.PediaClusterLister) *FleetOverview {
+	return &FleetOverview{
+		storageFactory: storageFactory,
+		clusterLister:  clusterLister,
+	}
+}
+
+func (f *FleetOverview) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, f.resync, fleetOverviewResyncPeriod)
+}
+
+func (f *FleetOverview) resync(ctx context.Context) {
+	clusters, err := f.clusterLister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list clusters for the fleet overview")
+		return
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var last map[string]*storage.ClusterSummary
+	if p := f.summaries.Load(); p != nil {
+		last = *p
+	}
+	summaries := make(map[string]*storage.ClusterSummary, len(clusters))
+	for _, cluster := range clusters {
+		summary, err := storage.SummarizeCluster(ctx, f.storageFactory, cluster.Name)
+		if err != nil {
+			// keep the last summary of the cluster
+			klog.ErrorS(err, "Failed to summarize the cluster", "cluster", cluster.Name)
+			summary = last[cluster.Name]
+		}
+		if summary != nil {
+			summaries[cluster.Name] = summary
+		}
+	}
+	f.summaries.Store(&summaries)
+}
+
+func buildFleetOverview(clusters []*clusterv1alpha2.PediaCluster, summaries map[string]*storage.ClusterSummary, now time.Time) *v1beta1.FleetOverview {
+	overview := &v1beta1.FleetOverview{
+		SyncLag: make([]v1beta1.SyncLagBucket, len(syncLagBuckets)+1),
+		Items:   make([]v1beta1.ClusterOverview, 0, len(clusters)),
+	}
+	overview.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("FleetOverview"))
+	for i, le := range syncLagBuckets {
+		overview.SyncLag[i].LessThan = &metav1.Duration{Duration: le}
+	}
+
+	for _, cluster := range clusters {
+		item := v1beta1.ClusterOverview{
+			Name:            cluster.Name,
+			Health:          clusterHealth(cluster),
+			SyncedResources: syncedResources(cluster),
+		}
+		switch item.Health {
+		case v1beta1.ClusterHealthy:
+			overview.Clusters.Healthy++
+		case v1beta1.ClusterUnhealthy:
+			overview.Clusters.Unhealthy++
+		default:
+			overview.Clusters.Unknown++
+		}
+		overview.Clusters.Total++
+		overview.SyncedResources += item.SyncedResources
+
+		if summary := summaries[cluster.Name]; summary != nil {
+			item.Objects = summary.Objects
+			item.StorageBytes = summary.Bytes
+			overview.Objects += summary.Objects
+			overview.StorageBytes += summary.Bytes
+
+			if !summary.LastSyncedTime.IsZero() {
+				item.LastSyncedTime = &metav1.Time{Time: summary.LastSyncedTime}
+
+				lag := now.Sub(summary.LastSyncedTime)
+				bucket := sort.Search(len(syncLagBuckets), func(i int) bool { return lag < syncLagBuckets[i] })
+				overview.SyncLag[bucket].Clusters++
+			}
+		}
+		overview.Items = append(overview.Items, item)
+	}
+	sort.Slice(overview.Items, func(i, j int) bool { return overview.Items[i].Name < overview.Items[j].Name })
+	return overview
+}
+
+func clusterHealth(cluster *clusterv1alpha2.PediaCluster) string {
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, clusterv1alpha2.ReadyCondition)
+	if condition == nil {
+		return v1beta1.ClusterUnknown
+	}
+
+	switch condition.Status {
+	case metav1.ConditionTrue:
+		return v1beta1.ClusterHealthy
+	case metav1.ConditionFalse:
+		return v1beta1.ClusterUnhealthy
+	}
+	return v1beta1.ClusterUnknown
+}
+
+func syncedResources(cluster *clusterv1alpha2.PediaCluster) int64 {
+	var count int64
+	for _, group := range cluster.Status.SyncResources {
+		for _, resource := range group.Resources {
+			for _, cond := range resource.SyncConditions {
+				if cond.Status == clusterv1alpha2.ResourceSyncStatusSyncing {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+func (f *FleetOverview) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "fleetoverview"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	clusters, err := f.clusterLister.List(labels.Everything())
+	if err != nil {
+		responsewriters.ErrorNegotiated(apierrors.NewInternalError(err), Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	summaries := f.summaries.Load()
+	if summaries == nil {
+		// the summaries are not built yet
+		f.resync(req.Context())
+		summaries = f.summaries.Load()
+	}
+	if summaries == nil {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewServiceUnavailable("the fleet overview is not ready"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	overview := buildFleetOverview(clusters, *summaries, time.Now())
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, overview, false)
+}
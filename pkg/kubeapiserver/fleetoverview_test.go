@@ -0,0 +1,67 @@
+package kubeapiserver
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestBuildFleetOverview(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	newCluster := func(name string, ready metav1.ConditionStatus, statuses ...string) *clusterv1alpha2.PediaCluster {
+		cluster := &clusterv1alpha2.PediaCluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if ready != "" {
+			cluster.Status.Conditions = []metav1.Condition{{Type: clusterv1alpha2.ReadyCondition, Status: ready}}
+		}
+		resource := clusterv1alpha2.ClusterResourceStatus{Name: "pods"}
+		for _, status := range statuses {
+			resource.SyncConditions = append(resource.SyncConditions, clusterv1alpha2.ClusterResourceSyncCondition{Status: status})
+		}
+		cluster.Status.SyncResources = []clusterv1alpha2.ClusterGroupResourcesStatus{{Resources: []clusterv1alpha2.ClusterResourceStatus{resource}}}
+		return cluster
+	}
+
+	clusters := []*clusterv1alpha2.PediaCluster{
+		newCluster("cluster-2", metav1.ConditionFalse, clusterv1alpha2.ResourceSyncStatusError),
+		newCluster("cluster-1", metav1.ConditionTrue, clusterv1alpha2.ResourceSyncStatusSyncing, clusterv1alpha2.ResourceSyncStatusSyncing),
+		newCluster("cluster-3", ""),
+	}
+	summaries := map[string]*storage.ClusterSummary{
+		"cluster-1": {Objects: 10, Bytes: 1000, LastSyncedTime: now.Add(-30 * time.Second)},
+		"cluster-2": {Objects: 5, Bytes: 500, LastSyncedTime: now.Add(-2 * time.Hour)},
+		"cluster-3": {},
+	}
+
+	overview := buildFleetOverview(clusters, summaries, now)
+	if expected := (v1beta1.FleetClusters{Total: 3, Healthy: 1, Unhealthy: 1, Unknown: 1}); overview.Clusters != expected {
+		t.Errorf("clusters = %v, want %v", overview.Clusters, expected)
+	}
+	if overview.SyncedResources != 2 || overview.Objects != 15 || overview.StorageBytes != 1500 {
+		t.Errorf("synced resources = %d, objects = %d, storage bytes = %d", overview.SyncedResources, overview.Objects, overview.StorageBytes)
+	}
+
+	var lags []int64
+	for _, bucket := range overview.SyncLag {
+		lags = append(lags, bucket.Clusters)
+	}
+	if expected := []int64{1, 0, 0, 0, 1, 0}; !reflect.DeepEqual(lags, expected) {
+		t.Errorf("sync lag = %v, want %v", lags, expected)
+	}
+	if last := overview.SyncLag[len(overview.SyncLag)-1]; last.LessThan != nil {
+		t.Errorf("the last sync lag bucket has upper bound %v", last.LessThan)
+	}
+
+	var names []string
+	for _, item := range overview.Items {
+		names = append(names, item.Name+"/"+item.Health)
+	}
+	if expected := []string{"cluster-1/Healthy", "cluster-2/Unhealthy", "cluster-3/Unknown"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("items = %v, want %v", names, expected)
+	}
+}
@@ -0,0 +1,110 @@
+package kubeapiserver
+
+import (
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const HistoryPath = "/history"
+
+// HistoryHandler serves the field-level changes between the consecutive versions of an object in a cluster,
+// so that the change reviews don't require the full revisions of the object.
+//
+// The cluster is specified by the path, e.g. `/apis/clusterpedia.io/v1beta1/resources/clusters/<cluster>/history`,
+// and the object is specified by the `group`, `version`, `resource`, `namespace` and `name` queries.
+type HistoryHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+}
+
+func NewHistoryHandler(rest *RESTManager, discovery *discovery.DiscoveryManager) *HistoryHandler {
+	return &HistoryHandler{rest: rest, discovery: discovery}
+}
+
+func (h *HistoryHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "history"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	query := req.URL.Query()
+	gvr := schema.GroupVersionResource{Group: query.Get("group"), Version: query.Get("version"), Resource: query.Get("resource")}
+	namespace, name := query.Get("namespace"), query.Get("name")
+	if gvr.Version == "" || gvr.Resource == "" || name == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("version, resource and name queries are required"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	cluster := request.ClusterNameValue(req.Context())
+	if cluster == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("the history is only served in the path of a cluster"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	if !h.discovery.ResourceEnabled(cluster, gvr) {
+		responsewriters.ErrorNegotiated(apierrors.NewNotFound(gvr.GroupResource(), name), Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	resourceStorage, _, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	getter, ok := resourceStorage.Storage.(storage.ResourceHistoryGetter)
+	if !ok {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(gvr.GroupResource(), "history"), Codecs, gvr.GroupVersion(), w, req,
+		)
+		return
+	}
+
+	changes, err := getter.GetHistory(req.Context(), cluster, namespace, name)
+	if err != nil {
+		responsewriters.ErrorNegotiated(storage.InterpretGetError(err, gvr.GroupResource(), name), Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+
+	result := buildResourceHistory(changes)
+	result.Cluster, result.Namespace, result.Name = cluster, namespace, name
+	result.Resource = v1beta1.CollectionResourceType{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, result, false)
+}
+
+func buildResourceHistory(changes []storage.ResourceChange) *v1beta1.ResourceHistory {
+	result := &v1beta1.ResourceHistory{Changes: make([]v1beta1.ResourceChange, 0, len(changes))}
+	result.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("ResourceHistory"))
+
+	for _, change := range changes {
+		fields := make([]v1beta1.FieldChange, 0, len(change.Fields))
+		for _, field := range change.Fields {
+			fields = append(fields, v1beta1.FieldChange{Path: field.Path, Old: field.Old, New: field.New})
+		}
+		result.Changes = append(result.Changes, v1beta1.ResourceChange{
+			ResourceVersion:         change.ResourceVersion,
+			PreviousResourceVersion: change.PreviousResourceVersion,
+			Time:                    metav1.NewTime(change.Time),
+			Fields:                  fields,
+		})
+	}
+	return result
+}
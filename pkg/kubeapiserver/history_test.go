@@ -0,0 +1,36 @@
+package kubeapiserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestBuildResourceHistory(t *testing.T) {
+	now := time.Now()
+	result := buildResourceHistory([]storage.ResourceChange{
+		{
+			ResourceVersion: "2", PreviousResourceVersion: "1", Time: now,
+			Fields: []storage.FieldChange{{Path: "spec.replicas", Old: "1", New: "3"}},
+		},
+	})
+
+	if kind := result.GetObjectKind().GroupVersionKind().Kind; kind != "ResourceHistory" {
+		t.Errorf("kind = %s, want ResourceHistory", kind)
+	}
+	if len(result.Changes) != 1 {
+		t.Fatalf("changes = %v, want 1 change", result.Changes)
+	}
+	change := result.Changes[0]
+	if change.ResourceVersion != "2" || change.PreviousResourceVersion != "1" || !change.Time.Time.Equal(now) {
+		t.Errorf("change = %+v", change)
+	}
+	if len(change.Fields) != 1 || change.Fields[0].Path != "spec.replicas" || change.Fields[0].Old != "1" || change.Fields[0].New != "3" {
+		t.Errorf("fields = %+v", change.Fields)
+	}
+
+	if empty := buildResourceHistory(nil); empty.Changes == nil {
+		t.Error("changes of the empty history should not be nil")
+	}
+}
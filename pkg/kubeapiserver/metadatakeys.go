@@ -0,0 +1,219 @@
+package kubeapiserver
+
+import (
+	"context"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/resourcerest"
+	clusterpediastorage "github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const (
+	MetadataKeysPath = "/metadatakeys"
+
+	// metadataKeysTTL is the period in which the inventory of a resource is reused
+	metadataKeysTTL = time.Minute
+)
+
+// MetadataKeysHandler serves the inventory of the label and annotation keys of a resource,
+// so that UIs can offer autocompletion when building the selectors, and admins can find the keys
+// with high cardinality values.
+//
+// The resource is specified by the `group`, `version` and `resource` queries, and the cluster is
+// specified by the path, the keys of all clusters are returned without the cluster.
+// The inventory is built from the metadata of the stored objects, and cached for a while.
+type MetadataKeysHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+
+	lock  sync.Mutex
+	cache map[metadataKeysCacheKey]*metadataKeysCacheEntry
+}
+
+type metadataKeysCacheKey struct {
+	gvr     schema.GroupVersionResource
+	cluster string
+
+	// tenant is the tenant of the request user if the storage restricts the reads by the tenants,
+	// so the inventory built from the objects visible to a tenant is not served to the other tenants.
+	tenant string
+}
+
+func newMetadataKeysCacheKey(ctx context.Context, storage clusterpediastorage.ResourceStorage, gvr schema.GroupVersionResource, cluster string) metadataKeysCacheKey {
+	key := metadataKeysCacheKey{gvr: gvr, cluster: cluster}
+	if resolver, ok := storage.(clusterpediastorage.ResourceTenantResolver); ok {
+		key.tenant = resolver.TenantFor(ctx)
+	}
+	return key
+}
+
+type metadataKeysCacheEntry struct {
+	keys      *v1beta1.MetadataKeys
+	expiredAt time.Time
+}
+
+func NewMetadataKeysHandler(rest *RESTManager, discovery *discovery.DiscoveryManager) *MetadataKeysHandler {
+	return &MetadataKeysHandler{
+		rest:      rest,
+		discovery: discovery,
+		cache:     make(map[metadataKeysCacheKey]*metadataKeysCacheEntry),
+	}
+}
+
+func (h *MetadataKeysHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "metadatakeys"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	query := req.URL.Query()
+	gvr := schema.GroupVersionResource{Group: query.Get("group"), Version: query.Get("version"), Resource: query.Get("resource")}
+	if gvr.Version == "" || gvr.Resource == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("version and resource queries are required"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	keys, err := h.get(req.Context(), gvr, request.ClusterNameValue(req.Context()))
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, keys, false)
+}
+
+func (h *MetadataKeysHandler) get(ctx context.Context, gvr schema.GroupVersionResource, cluster string) (*v1beta1.MetadataKeys, error) {
+	if !h.discovery.ResourceEnabled(cluster, gvr) {
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), "")
+	}
+	storage, _, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		return nil, err
+	}
+	key := newMetadataKeysCacheKey(ctx, storage.Storage, gvr, cluster)
+
+	h.lock.Lock()
+	entry, ok := h.cache[key]
+	h.lock.Unlock()
+	if ok && time.Now().Before(entry.expiredAt) {
+		return entry.keys, nil
+	}
+
+	keys, err := h.build(ctx, storage, gvr, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	now := time.Now()
+	for k, entry := range h.cache {
+		if now.After(entry.expiredAt) {
+			delete(h.cache, k)
+		}
+	}
+	h.cache[key] = &metadataKeysCacheEntry{keys: keys, expiredAt: now.Add(metadataKeysTTL)}
+	return keys, nil
+}
+
+func (h *MetadataKeysHandler) build(ctx context.Context, storage *resourcerest.RESTStorage, gvr schema.GroupVersionResource, cluster string) (*v1beta1.MetadataKeys, error) {
+	opts := &internal.ListOptions{OnlyMetadata: true}
+	if cluster != "" {
+		opts.ClusterNames = []string{cluster}
+	}
+	list := storage.NewList()
+	if err := storage.Storage.List(ctx, list, opts); err != nil {
+		return nil, clusterpediastorage.InterpretListError(err, gvr.GroupResource())
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	labels, annotations := newKeyInventory(), newKeyInventory()
+	for _, obj := range objs {
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		labels.observe(m.GetLabels())
+		annotations.observe(m.GetAnnotations())
+	}
+
+	keys := &v1beta1.MetadataKeys{
+		Cluster: cluster,
+		Resource: v1beta1.CollectionResourceType{
+			Group:    gvr.Group,
+			Version:  gvr.Version,
+			Resource: gvr.Resource,
+		},
+		Objects:     int64(len(objs)),
+		Labels:      labels.keys(),
+		Annotations: annotations.keys(),
+	}
+	keys.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("MetadataKeys"))
+	return keys, nil
+}
+
+type keyStats struct {
+	count int64
+
+	// the hashes of the values, so the large annotation values are not kept in memory
+	values map[uint64]struct{}
+}
+
+type keyInventory map[string]*keyStats
+
+func newKeyInventory() keyInventory {
+	return make(keyInventory)
+}
+
+func (inventory keyInventory) observe(kvs map[string]string) {
+	for key, value := range kvs {
+		stats, ok := inventory[key]
+		if !ok {
+			stats = &keyStats{values: make(map[uint64]struct{})}
+			inventory[key] = stats
+		}
+		stats.count++
+
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(value))
+		stats.values[hash.Sum64()] = struct{}{}
+	}
+}
+
+// keys returns the keys sorted by the number of the objects with the key
+func (inventory keyInventory) keys() []v1beta1.MetadataKey {
+	keys := make([]v1beta1.MetadataKey, 0, len(inventory))
+	for key, stats := range inventory {
+		keys = append(keys, v1beta1.MetadataKey{Key: key, Count: stats.count, Values: int64(len(stats.values))})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Count != keys[j].Count {
+			return keys[i].Count > keys[j].Count
+		}
+		return keys[i].Key < keys[j].Key
+	})
+	return keys
+}
@@ -0,0 +1,59 @@
+package kubeapiserver
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestKeyInventory(t *testing.T) {
+	inventory := newKeyInventory()
+	inventory.observe(map[string]string{"app": "nginx", "pod-template-hash": "1"})
+	inventory.observe(map[string]string{"app": "nginx", "pod-template-hash": "2"})
+	inventory.observe(map[string]string{"app": "redis", "tier": "cache"})
+	inventory.observe(nil)
+
+	expected := []v1beta1.MetadataKey{
+		{Key: "app", Count: 3, Values: 2},
+		{Key: "pod-template-hash", Count: 2, Values: 2},
+		{Key: "tier", Count: 1, Values: 1},
+	}
+	if keys := inventory.keys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("keys() = %v, want %v", keys, expected)
+	}
+}
+
+type tenantResourceStorage struct {
+	storage.ResourceStorage
+}
+
+func (tenantResourceStorage) TenantFor(ctx context.Context) string {
+	requester, _ := genericrequest.UserFrom(ctx)
+	return requester.GetName()
+}
+
+func TestNewMetadataKeysCacheKey(t *testing.T) {
+	gvr := corev1.SchemeGroupVersion.WithResource("pods")
+	alice := genericrequest.WithUser(context.TODO(), &user.DefaultInfo{Name: "alice"})
+	bob := genericrequest.WithUser(context.TODO(), &user.DefaultInfo{Name: "bob"})
+
+	// the inventory is shared by the requests if the storage doesn't restrict the reads
+	if newMetadataKeysCacheKey(alice, nil, gvr, "cluster-1") != newMetadataKeysCacheKey(bob, nil, gvr, "cluster-1") {
+		t.Error("expected the same cache key of the unrestricted storage")
+	}
+
+	rs := tenantResourceStorage{}
+	if key := newMetadataKeysCacheKey(alice, rs, gvr, "cluster-1"); key.tenant != "alice" {
+		t.Errorf("cache key tenant = %q, want %q", key.tenant, "alice")
+	}
+	if newMetadataKeysCacheKey(alice, rs, gvr, "cluster-1") == newMetadataKeysCacheKey(bob, rs, gvr, "cluster-1") {
+		t.Error("expected the different cache keys of the tenants")
+	}
+}
@@ -0,0 +1,132 @@
+package kubeapiserver
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/klog/v2"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	clusterlister "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	NamespaceInventoryPath = "/namespaceinventory"
+
+	namespaceInventoryResyncPeriod = 30 * time.Second
+)
+
+// NamespaceInventory maintains the namespaces of all clusters with the clusters each exists in,
+// and the number of the objects in each namespace.
+//
+// The namespaces of each cluster are counted by the storage periodically, the inventory is rebuilt
+// from the latest counts of the clusters, so the requests are served from the memory.
+type NamespaceInventory struct {
+	storageFactory storage.StorageFactory
+	clusterLister  clusterlister.PediaClusterLister
+
+	lock   sync.Mutex
+	counts map[string]map[string]int64
+
+	inventory atomic.Pointer[v1beta1.NamespaceInventory]
+}
+
+func NewNamespaceInventory(storageFactory storage.StorageFactory, clusterLister clusterlister.PediaClusterLister) *NamespaceInventory {
+	return &NamespaceInventory{
+		storageFactory: storageFactory,
+		clusterLister:  clusterLister,
+		counts:         make(map[string]map[string]int64),
+	}
+}
+
+func (n *NamespaceInventory) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, n.resync, namespaceInventoryResyncPeriod)
+}
+
+func (n *NamespaceInventory) resync(ctx context.Context) {
+	clusters, err := n.clusterLister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list clusters for the namespace inventory")
+		return
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	counts := make(map[string]map[string]int64, len(clusters))
+	for _, cluster := range clusters {
+		namespaces, err := storage.CountNamespaces(ctx, n.storageFactory, cluster.Name)
+		if err != nil {
+			// keep the last counts of the cluster
+			klog.ErrorS(err, "Failed to count the namespaces", "cluster", cluster.Name)
+			namespaces = n.counts[cluster.Name]
+		}
+		if namespaces != nil {
+			counts[cluster.Name] = namespaces
+		}
+	}
+	n.counts = counts
+	n.inventory.Store(buildNamespaceInventory(counts))
+}
+
+func buildNamespaceInventory(counts map[string]map[string]int64) *v1beta1.NamespaceInventory {
+	items := make(map[string]*v1beta1.NamespaceInventoryItem)
+	for cluster, namespaces := range counts {
+		for namespace, count := range namespaces {
+			item, ok := items[namespace]
+			if !ok {
+				item = &v1beta1.NamespaceInventoryItem{Name: namespace}
+				items[namespace] = item
+			}
+			item.Objects += count
+			item.Clusters = append(item.Clusters, v1beta1.NamespaceCluster{Name: cluster, Objects: count})
+		}
+	}
+
+	inventory := &v1beta1.NamespaceInventory{Items: make([]v1beta1.NamespaceInventoryItem, 0, len(items))}
+	inventory.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("NamespaceInventory"))
+	for _, item := range items {
+		sort.Slice(item.Clusters, func(i, j int) bool { return item.Clusters[i].Name < item.Clusters[j].Name })
+		inventory.Items = append(inventory.Items, *item)
+	}
+	sort.Slice(inventory.Items, func(i, j int) bool { return inventory.Items[i].Name < inventory.Items[j].Name })
+	return inventory
+}
+
+func (n *NamespaceInventory) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "namespaceinventory"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	inventory := n.inventory.Load()
+	if inventory == nil {
+		// the inventory is not built yet
+		n.resync(req.Context())
+		inventory = n.inventory.Load()
+	}
+	if inventory == nil {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewServiceUnavailable("the namespace inventory is not ready"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, inventory, false)
+}
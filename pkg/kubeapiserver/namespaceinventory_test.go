@@ -0,0 +1,24 @@
+package kubeapiserver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+)
+
+func TestBuildNamespaceInventory(t *testing.T) {
+	inventory := buildNamespaceInventory(map[string]map[string]int64{
+		"cluster-2": {"default": 3, "kube-system": 10},
+		"cluster-1": {"default": 2, "empty": 0},
+	})
+
+	expected := []v1beta1.NamespaceInventoryItem{
+		{Name: "default", Objects: 5, Clusters: []v1beta1.NamespaceCluster{{Name: "cluster-1", Objects: 2}, {Name: "cluster-2", Objects: 3}}},
+		{Name: "empty", Objects: 0, Clusters: []v1beta1.NamespaceCluster{{Name: "cluster-1", Objects: 0}}},
+		{Name: "kube-system", Objects: 10, Clusters: []v1beta1.NamespaceCluster{{Name: "cluster-2", Objects: 10}}},
+	}
+	if !reflect.DeepEqual(inventory.Items, expected) {
+		t.Errorf("buildNamespaceInventory() = %v, want %v", inventory.Items, expected)
+	}
+}
@@ -3,6 +3,7 @@ package kubeapiserver
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -23,11 +24,14 @@ type Options struct {
 
 	EnableProxyPathForForwardRequest  bool
 	AllowForwardUnsyncResourceRequest bool
+
+	ReadYourWritesWindow time.Duration
 }
 
 func NewOptions() *Options {
 	return &Options{
 		ExtraProxyRequestHeaderPrefixes: []string{proxyrest.DefaultProxyRequestHeaderPrefix},
+		ReadYourWritesWindow:            30 * time.Second,
 	}
 }
 
@@ -59,6 +63,10 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 		"Allow forwarding requests for unsynchronized resource types."+
 		"By default, only requests for resource types configured in PediaCluster can be forwarded.",
 	)
+	fs.DurationVar(&o.ReadYourWritesWindow, "read-your-writes-window", o.ReadYourWritesWindow, ""+
+		"The duration after a resource of a cluster is written through the proxy, in which the reads of the resource "+
+		"with the read-your-writes consistency are forwarded to the cluster. It should be longer than the lag of the synchronization.",
+	)
 }
 
 var supportedProxyCoreSubresources = map[string][]string{
@@ -134,5 +142,6 @@ func (o *Options) Config() (*ExtraConfig, error) {
 		AllowedProxySubresources:          subresources,
 		EnableProxyPathForForwardRequest:  o.EnableProxyPathForForwardRequest,
 		AllowForwardUnsyncResourceRequest: o.AllowForwardUnsyncResourceRequest,
+		ReadYourWritesWindow:              o.ReadYourWritesWindow,
 	}, nil
 }
@@ -0,0 +1,192 @@
+package kubeapiserver
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	clusterpediastorage "github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const ReplicasPath = "/replicas"
+
+// ReplicasHandler serves the scale of the workloads in the clusters and the sum of their desired and ready replicas,
+// so that the autoscaling dashboards don't parse the full objects.
+//
+// The workloads are specified by the `group`, `version` and `resource` queries,
+// and can be filtered by the `namespace` and `name` queries.
+type ReplicasHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+}
+
+func NewReplicasHandler(rest *RESTManager, discovery *discovery.DiscoveryManager) *ReplicasHandler {
+	return &ReplicasHandler{rest: rest, discovery: discovery}
+}
+
+func (h *ReplicasHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "replicas"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	query := req.URL.Query()
+	gvr := schema.GroupVersionResource{Group: query.Get("group"), Version: query.Get("version"), Resource: query.Get("resource")}
+	if gvr.Version == "" || gvr.Resource == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("version and resource queries are required"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	scales, err := h.listScales(req.Context(), gvr, request.ClusterNameValue(req.Context()), query.Get("namespace"), query.Get("name"))
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+
+	result := buildReplicaSummaries(scales)
+	result.Resource = v1beta1.CollectionResourceType{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, result, false)
+}
+
+type workloadScale struct {
+	namespace string
+	name      string
+	scale     v1beta1.ClusterScale
+}
+
+func (h *ReplicasHandler) listScales(ctx context.Context, gvr schema.GroupVersionResource, cluster, namespace, name string) ([]workloadScale, error) {
+	if !h.discovery.ResourceEnabled(cluster, gvr) {
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+	}
+	storage, scope, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &internal.ListOptions{}
+	if cluster != "" {
+		opts.ClusterNames = []string{cluster}
+	}
+	if namespace != "" {
+		opts.Namespaces = []string{namespace}
+	}
+	if name != "" {
+		opts.Names = []string{name}
+	}
+	list := storage.NewList()
+	if err := storage.Storage.List(ctx, list, opts); err != nil {
+		return nil, clusterpediastorage.InterpretListError(err, gvr.GroupResource())
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	scales := make([]workloadScale, 0, len(objs))
+	for _, obj := range objs {
+		versioned, err := scope.Convertor.ConvertToVersion(obj, gvr.GroupVersion())
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(versioned)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+
+		scale, ok := extractScale(content)
+		if !ok {
+			continue
+		}
+		scale.Cluster = utils.ExtractClusterName(obj)
+
+		u := &unstructured.Unstructured{Object: content}
+		scales = append(scales, workloadScale{namespace: u.GetNamespace(), name: u.GetName(), scale: scale})
+	}
+	return scales, nil
+}
+
+// extractScale extracts the scale of the workload like the scale subresource,
+// daemonsets are scaled by the scheduled nodes, and the other workloads by the `spec.replicas`.
+func extractScale(content map[string]interface{}) (v1beta1.ClusterScale, bool) {
+	var scale v1beta1.ClusterScale
+	if replicas, found, _ := unstructured.NestedInt64(content, "spec", "replicas"); found {
+		scale.Replicas = int32(replicas)
+		current, _, _ := unstructured.NestedInt64(content, "status", "replicas")
+		ready, _, _ := unstructured.NestedInt64(content, "status", "readyReplicas")
+		scale.CurrentReplicas, scale.ReadyReplicas = int32(current), int32(ready)
+	} else if desired, found, _ := unstructured.NestedInt64(content, "status", "desiredNumberScheduled"); found {
+		scale.Replicas = int32(desired)
+		current, _, _ := unstructured.NestedInt64(content, "status", "currentNumberScheduled")
+		ready, _, _ := unstructured.NestedInt64(content, "status", "numberReady")
+		scale.CurrentReplicas, scale.ReadyReplicas = int32(current), int32(ready)
+	} else {
+		return scale, false
+	}
+
+	// the selector of the scale subresource is in the string form
+	if selector, found, _ := unstructured.NestedString(content, "status", "selector"); found {
+		scale.Selector = selector
+	} else if selector, found, _ := unstructured.NestedMap(content, "spec", "selector"); found {
+		var labelSelector metav1.LabelSelector
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(selector, &labelSelector); err == nil {
+			if s, err := metav1.LabelSelectorAsSelector(&labelSelector); err == nil {
+				scale.Selector = s.String()
+			}
+		}
+	}
+	return scale, true
+}
+
+// buildReplicaSummaries aggregates the scales of the workloads with the same namespace and name.
+func buildReplicaSummaries(scales []workloadScale) *v1beta1.ReplicaSummaries {
+	result := &v1beta1.ReplicaSummaries{Items: []v1beta1.ReplicaSummary{}}
+	result.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("ReplicaSummaries"))
+
+	summaries := make(map[string]*v1beta1.ReplicaSummary)
+	for _, s := range scales {
+		key := s.namespace + "/" + s.name
+		summary, ok := summaries[key]
+		if !ok {
+			summary = &v1beta1.ReplicaSummary{Namespace: s.namespace, Name: s.name}
+			summaries[key] = summary
+		}
+		summary.DesiredReplicas += s.scale.Replicas
+		summary.ReadyReplicas += s.scale.ReadyReplicas
+		summary.Clusters = append(summary.Clusters, s.scale)
+	}
+
+	for _, summary := range summaries {
+		sort.Slice(summary.Clusters, func(i, j int) bool {
+			return summary.Clusters[i].Cluster < summary.Clusters[j].Cluster
+		})
+		result.Items = append(result.Items, *summary)
+	}
+	sort.Slice(result.Items, func(i, j int) bool {
+		if result.Items[i].Namespace != result.Items[j].Namespace {
+			return result.Items[i].Namespace < result.Items[j].Namespace
+		}
+		return result.Items[i].Name < result.Items[j].Name
+	})
+	return result
+}
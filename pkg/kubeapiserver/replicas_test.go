@@ -0,0 +1,83 @@
+package kubeapiserver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+)
+
+func TestExtractScale(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  map[string]interface{}
+		expected *v1beta1.ClusterScale
+	}{
+		{
+			name: "deployment",
+			content: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"replicas": int64(3),
+					"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "nginx"}},
+				},
+				"status": map[string]interface{}{"replicas": int64(3), "readyReplicas": int64(2)},
+			},
+			expected: &v1beta1.ClusterScale{Replicas: 3, CurrentReplicas: 3, ReadyReplicas: 2, Selector: "app=nginx"},
+		},
+		{
+			name: "daemonset",
+			content: map[string]interface{}{
+				"status": map[string]interface{}{"desiredNumberScheduled": int64(5), "currentNumberScheduled": int64(4), "numberReady": int64(4)},
+			},
+			expected: &v1beta1.ClusterScale{Replicas: 5, CurrentReplicas: 4, ReadyReplicas: 4},
+		},
+		{
+			name: "custom resource with scale selector",
+			content: map[string]interface{}{
+				"spec":   map[string]interface{}{"replicas": int64(2)},
+				"status": map[string]interface{}{"selector": "app=web"},
+			},
+			expected: &v1beta1.ClusterScale{Replicas: 2, Selector: "app=web"},
+		},
+		{
+			name:    "not scalable",
+			content: map[string]interface{}{"data": map[string]interface{}{"key": "value"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scale, ok := extractScale(test.content)
+			if ok != (test.expected != nil) {
+				t.Fatalf("extractScale() ok = %v, want %v", ok, test.expected != nil)
+			}
+			if ok && !reflect.DeepEqual(scale, *test.expected) {
+				t.Errorf("extractScale() = %v, want %v", scale, *test.expected)
+			}
+		})
+	}
+}
+
+func TestBuildReplicaSummaries(t *testing.T) {
+	scales := []workloadScale{
+		{namespace: "default", name: "web", scale: v1beta1.ClusterScale{Cluster: "cluster-2", Replicas: 3, ReadyReplicas: 1}},
+		{namespace: "default", name: "api", scale: v1beta1.ClusterScale{Cluster: "cluster-1", Replicas: 2, ReadyReplicas: 2}},
+		{namespace: "default", name: "web", scale: v1beta1.ClusterScale{Cluster: "cluster-1", Replicas: 2, ReadyReplicas: 2}},
+	}
+
+	expected := []v1beta1.ReplicaSummary{
+		{
+			Namespace: "default", Name: "api", DesiredReplicas: 2, ReadyReplicas: 2,
+			Clusters: []v1beta1.ClusterScale{{Cluster: "cluster-1", Replicas: 2, ReadyReplicas: 2}},
+		},
+		{
+			Namespace: "default", Name: "web", DesiredReplicas: 5, ReadyReplicas: 3,
+			Clusters: []v1beta1.ClusterScale{
+				{Cluster: "cluster-1", Replicas: 2, ReadyReplicas: 2},
+				{Cluster: "cluster-2", Replicas: 3, ReadyReplicas: 1},
+			},
+		},
+	}
+	if result := buildReplicaSummaries(scales); !reflect.DeepEqual(result.Items, expected) {
+		t.Errorf("buildReplicaSummaries() = %v, want %v", result.Items, expected)
+	}
+}
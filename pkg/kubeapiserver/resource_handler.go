@@ -33,6 +33,7 @@ type ResourceHandler struct {
 	minRequestTimeout                 time.Duration
 	delegate                          http.Handler
 	proxy                             http.Handler
+	proxyWrites                       *proxyWrites
 
 	rest          *RESTManager
 	discovery     *discovery.DiscoveryManager
@@ -136,6 +137,16 @@ func (r *ResourceHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	switch storage := storage.(type) {
 	case *resourcerest.RESTStorage:
+		forward, err := r.enforceConsistency(req, clusterName, gvr.GroupResource())
+		if err != nil {
+			responsewriters.ErrorNegotiated(err, Codecs, gvr.GroupVersion(), w, req)
+			return
+		}
+		if forward {
+			r.proxy.ServeHTTP(w, req)
+			return
+		}
+
 		var handler http.Handler
 		switch requestInfo.Verb {
 		case "get":
@@ -185,6 +196,56 @@ func (r *ResourceHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// enforceConsistency returns true if the read should be forwarded to the cluster to satisfy the consistency,
+// the consistency query is removed from the request query.
+func (r *ResourceHandler) enforceConsistency(req *http.Request, clusterName string, gr schema.GroupResource) (bool, error) {
+	query := request.RequestQueryFrom(req.Context())
+	value := query.Get(ConsistencyQuery)
+	if value == "" {
+		return false, nil
+	}
+	query.Del(ConsistencyQuery)
+
+	c, err := parseConsistency(value)
+	if err != nil {
+		return false, apierrors.NewBadRequest(err.Error())
+	}
+
+	var clusters []*clusterv1alpha2.PediaCluster
+	if c.level == BoundedStalenessConsistency {
+		if clusters, err = r.consistencyClusters(clusterName, query.Get("clusters")); err != nil {
+			return false, apierrors.NewInternalError(err)
+		}
+	}
+
+	decision, err := enforceConsistency(c, r.proxyWrites, clusterName, clusters, gr, time.Now())
+	return decision == forwardToCluster, err
+}
+
+// consistencyClusters returns the clusters which are read by the request, all clusters are read if none is specified.
+func (r *ResourceHandler) consistencyClusters(clusterName string, clusterNames string) ([]*clusterv1alpha2.PediaCluster, error) {
+	if clusterName == "" && clusterNames == "" {
+		return r.clusterLister.List(labels.Everything())
+	}
+
+	names := strings.Split(clusterNames, ",")
+	if clusterName != "" {
+		names = []string{clusterName}
+	}
+	clusters := make([]*clusterv1alpha2.PediaCluster, 0, len(names))
+	for _, name := range names {
+		cluster, err := r.clusterLister.Get(strings.TrimSpace(name))
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
+}
+
 func checkClusterAndWarning(ctx context.Context, cluster *clusterv1alpha2.PediaCluster) {
 	if cluster == nil {
 		return
@@ -0,0 +1,93 @@
+package resourcerest
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+)
+
+// OriginUIDAnnotations are the annotations that record the uid of the resource template
+// which the resources are propagated from, the resources with the same origin uid are identical.
+var OriginUIDAnnotations = []string{
+	"resourcetemplate.karmada.io/uid",
+}
+
+// dedupList groups the identical resources replicated to multiple clusters into the first of them,
+// and sets the clusters of the group into the `shadow.clusterpedia.io/dedup-clusters` annotation.
+//
+// The resources are identical if they have the same origin uid, or the same spec hash.
+// The deduplication is applied to a page of the list, so the remaining item count
+// is still the count of the resources before deduplication.
+func dedupList(list runtime.Object) error {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	var (
+		deduped  []runtime.Object
+		clusters [][]string
+		indexes  = make(map[string]int, len(items))
+	)
+	for _, item := range items {
+		key, err := dedupKey(item)
+		if err != nil {
+			return err
+		}
+
+		cluster := clusterOf(item)
+		if i, ok := indexes[key]; ok {
+			clusters[i] = append(clusters[i], cluster)
+			continue
+		}
+		indexes[key] = len(deduped)
+		deduped = append(deduped, item)
+		clusters = append(clusters, []string{cluster})
+	}
+
+	for i, item := range deduped {
+		m, err := meta.Accessor(item)
+		if err != nil {
+			return err
+		}
+
+		annotations := m.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[internal.ShadowAnnotationDedupClusters] = strings.Join(clusters[i], ",")
+		m.SetAnnotations(annotations)
+	}
+	return meta.SetList(list, deduped)
+}
+
+func clusterOf(obj runtime.Object) string {
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return m.GetAnnotations()[internal.ShadowAnnotationClusterName]
+}
+
+func dedupKey(obj runtime.Object) (string, error) {
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return "", err
+	}
+	for _, annotation := range OriginUIDAnnotations {
+		if uid := m.GetAnnotations()[annotation]; uid != "" {
+			return "uid:" + uid, nil
+		}
+	}
+
+	hash, err := utils.SpecHash(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash %s/%s: %w", m.GetNamespace(), m.GetName(), err)
+	}
+	return "hash:" + hash, nil
+}
@@ -0,0 +1,77 @@
+package resourcerest
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+func newConfigMap(cluster, name, uid string, data map[string]string, annotations map[string]string) corev1.ConfigMap {
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[internal.ShadowAnnotationClusterName] = cluster
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            name,
+			UID:             types.UID(uid),
+			ResourceVersion: uid,
+			Annotations:     annotations,
+		},
+		Data: data,
+	}
+}
+
+func TestDedupList(t *testing.T) {
+	list := &corev1.ConfigMapList{Items: []corev1.ConfigMap{
+		newConfigMap("cluster-1", "same", "1", map[string]string{"key": "value"}, nil),
+		newConfigMap("cluster-2", "same", "2", map[string]string{"key": "value"}, nil),
+		newConfigMap("cluster-3", "same", "3", map[string]string{"key": "changed"}, nil),
+		newConfigMap("cluster-1", "propagated", "4", map[string]string{"key": "value"}, map[string]string{"resourcetemplate.karmada.io/uid": "origin"}),
+		newConfigMap("cluster-2", "propagated", "5", map[string]string{"key": "drifted"}, map[string]string{"resourcetemplate.karmada.io/uid": "origin"}),
+	}}
+
+	if err := dedupList(list); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"cluster-1,cluster-2", "cluster-3", "cluster-1,cluster-2"}
+	if len(list.Items) != len(expected) {
+		t.Fatalf("dedupList() = %d items, want %d", len(list.Items), len(expected))
+	}
+	for i, item := range list.Items {
+		if clusters := item.Annotations[internal.ShadowAnnotationDedupClusters]; clusters != expected[i] {
+			t.Errorf("items[%d] clusters = %q, want %q", i, clusters, expected[i])
+		}
+	}
+}
+
+func TestDedupUnstructuredList(t *testing.T) {
+	newObj := func(cluster, uid string) unstructured.Unstructured {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("example.io/v1")
+		obj.SetKind("Foo")
+		obj.SetName("foo")
+		obj.SetUID(types.UID(uid))
+		obj.SetAnnotations(map[string]string{internal.ShadowAnnotationClusterName: cluster})
+		_ = unstructured.SetNestedField(obj.Object, "ready", "status", "phase")
+		return obj
+	}
+
+	list := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{newObj("cluster-1", "1"), newObj("cluster-2", "2")}}
+	if err := dedupList(list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("dedupList() = %d items, want 1", len(list.Items))
+	}
+	if clusters := list.Items[0].GetAnnotations()[internal.ShadowAnnotationDedupClusters]; clusters != "cluster-1,cluster-2" {
+		t.Errorf("clusters = %q, want %q", clusters, "cluster-1,cluster-2")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,15 +14,20 @@ import (
 	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
 	genericfeatures "k8s.io/apiserver/pkg/features"
 	"k8s.io/apiserver/pkg/registry/rest"
-	storeerr "k8s.io/apiserver/pkg/storage/errors"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	internal "github.com/clusterpedia-io/api/clusterpedia"
 	"github.com/clusterpedia-io/api/clusterpedia/scheme"
 	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/federation"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/listpolicy"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/wasmfilter"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/features"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/printers"
+	runtimescheme "github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/masking"
 	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
 )
 
@@ -39,6 +45,18 @@ type RESTStorage struct {
 
 	Storage        storage.ResourceStorage
 	TableConvertor rest.TableConvertor
+
+	// ListPolicy enforces the server-side defaults and limits on the list options
+	ListPolicy *listpolicy.Policy
+
+	// Federation merges the resources of the remote clusterpedia instances into the lists of all clusters
+	Federation *federation.Federation
+
+	// Masker masks the sensitive fields of the served objects
+	Masker *masking.Masker
+
+	// Filters are the WASM filter functions selected by the `search.clusterpedia.io/filter` search label
+	Filters *wasmfilter.Registry
 }
 
 var _ rest.Storage = &RESTStorage{}
@@ -69,15 +87,39 @@ func (s *RESTStorage) Get(ctx context.Context, name string, _ *metav1.GetOptions
 
 	obj := s.New()
 	if err := s.Storage.Get(ctx, clusterName, requestInfo.Namespace, name, obj); err != nil {
-		return nil, storeerr.InterpretGetError(err, s.DefaultQualifiedResource, name)
+		return nil, storage.InterpretGetError(err, s.DefaultQualifiedResource, name)
+	}
+	if err := s.mask(requestInfo, obj); err != nil {
+		return nil, err
 	}
 	return obj, nil
 }
 
+// mask masks the sensitive fields of the object or the list by the serving rules of the requested resource,
+// the typed objects are masked in the version of the storage.
+func (s *RESTStorage) mask(requestInfo *genericrequest.RequestInfo, obj runtime.Object) error {
+	gvr := schema.GroupVersionResource{Group: requestInfo.APIGroup, Version: requestInfo.APIVersion, Resource: requestInfo.Resource}
+	return s.Masker.Rules(gvr, configv1alpha1.MaskingStageServing).MaskObject(obj, runtimescheme.LegacyResourceScheme, s.StorageGVR.GroupVersion())
+}
+
+// filter runs the filter function selected by the list options over the masked objects of the list,
+// so the functions can't select the objects by the masked values.
+func (s *RESTStorage) filter(ctx context.Context, options *internal.ListOptions, objs runtime.Object) error {
+	name := wasmfilter.FilterName(options)
+	if name == "" {
+		return nil
+	}
+	return s.Filters.Filter(ctx, name, objs, runtimescheme.LegacyResourceScheme, s.StorageGVR.GroupVersion())
+}
+
 func (s *RESTStorage) resolveListOptions(ctx context.Context, requestInfo *genericrequest.RequestInfo) (string, *internal.ListOptions, error) {
 	options := &internal.ListOptions{}
 	query := request.RequestQueryFrom(ctx)
-	if err := scheme.ParameterCodec.DecodeParameters(query, v1beta1.SchemeGroupVersion, options); err != nil {
+	version, ok := request.VersionFrom(ctx)
+	if !ok {
+		version = v1beta1.SchemeGroupVersion
+	}
+	if err := scheme.DecodeListOptions(query, version, options); err != nil {
 		return "", nil, apierrors.NewBadRequest(err.Error())
 	}
 
@@ -93,6 +135,10 @@ func (s *RESTStorage) resolveListOptions(ctx context.Context, requestInfo *gener
 		return "", nil, apierrors.NewBadRequest("If searching by owner uid or name, then the cluster must be specified")
 	}
 
+	if err := s.ListPolicy.Apply(requestInfo.APIGroup, options); err != nil {
+		return "", nil, err
+	}
+
 	if options.WithRemainingCount == nil {
 		if enabled := utilfeature.DefaultFeatureGate.Enabled(genericfeatures.RemainingItemCount); enabled {
 			options.WithRemainingCount = &enabled
@@ -117,9 +163,25 @@ func (s *RESTStorage) List(ctx context.Context, _ *metainternalversion.ListOptio
 	if err != nil {
 		return nil, err
 	}
+	federatedPath, federated := s.federatedPath(ctx, requestInfo, options)
+	if options.CountOnly {
+		list, err := s.count(ctx, requestInfo, options)
+		if err != nil || !federated {
+			return list, err
+		}
+
+		listMeta, err := meta.ListAccessor(list)
+		if err != nil {
+			return nil, err
+		}
+		count := *listMeta.GetRemainingItemCount() + s.Federation.Count(ctx, federatedPath, request.RequestQueryFrom(ctx), s.NewMemoryListFunc)
+		listMeta.SetRemainingItemCount(&count)
+		return list, nil
+	}
 
 	var objs runtime.Object
-	if utilfeature.DefaultFeatureGate.Enabled(features.NotConvertToMemoryVersion) {
+	// the lists of the remote instances are decoded into the memory version
+	if utilfeature.DefaultFeatureGate.Enabled(features.NotConvertToMemoryVersion) && !federated {
 		// Using the version of the resource storaged in the storage layer can avoid extra version conversions
 		// between the decoded and encoded response data and the memory version.
 		//
@@ -143,11 +205,85 @@ func (s *RESTStorage) List(ctx context.Context, _ *metainternalversion.ListOptio
 		objs = s.NewMemoryListFunc()
 	}
 	if err := s.Storage.List(ctx, objs, options); err != nil {
-		return nil, storeerr.InterpretListError(err, s.DefaultQualifiedResource)
+		return nil, storage.InterpretListError(err, s.DefaultQualifiedResource)
+	}
+
+	if federated {
+		if err := s.Federation.ListInto(ctx, federatedPath, request.RequestQueryFrom(ctx), objs, s.NewMemoryListFunc); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.Dedup {
+		if err := dedupList(objs); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.mask(requestInfo, objs); err != nil {
+		return nil, err
+	}
+	if err := s.filter(ctx, options, objs); err != nil {
+		return nil, err
 	}
 	return objs, nil
 }
 
+// federatedPath returns the path of the list in the remote clusterpedia instances,
+// only the first page of the list of all clusters is federated, the continue token pages the local storage.
+func (s *RESTStorage) federatedPath(ctx context.Context, requestInfo *genericrequest.RequestInfo, options *internal.ListOptions) (string, bool) {
+	if !s.Federation.Enabled(ctx) || request.ClusterNameValue(ctx) != "" || options.Continue != "" {
+		return "", false
+	}
+
+	version, ok := request.VersionFrom(ctx)
+	if !ok {
+		version = v1beta1.SchemeGroupVersion
+	}
+	return federation.ResourcesPath(version.Version, requestInfo.Path), true
+}
+
+// count returns an empty list whose remaining item count is the count of the matched objects, the objects are
+// listed and counted if the storage is not a ResourceCounter, or they are deduplicated or filtered.
+func (s *RESTStorage) count(ctx context.Context, requestInfo *genericrequest.RequestInfo, options *internal.ListOptions) (runtime.Object, error) {
+	list := s.NewMemoryListFunc()
+
+	var count int64
+	if counter, ok := s.Storage.(storage.ResourceCounter); ok && !options.Dedup && wasmfilter.FilterName(options) == "" {
+		c, err := counter.Count(ctx, options)
+		if err != nil {
+			return nil, storage.InterpretListError(err, s.DefaultQualifiedResource)
+		}
+		count = c
+	} else {
+		options.Limit, options.Continue, options.WithContinue, options.WithRemainingCount = 0, "", nil, nil
+		objs := s.NewMemoryListFunc()
+		if err := s.Storage.List(ctx, objs, options); err != nil {
+			return nil, storage.InterpretListError(err, s.DefaultQualifiedResource)
+		}
+		if options.Dedup {
+			if err := dedupList(objs); err != nil {
+				return nil, err
+			}
+		}
+		if wasmfilter.FilterName(options) != "" {
+			if err := s.mask(requestInfo, objs); err != nil {
+				return nil, err
+			}
+			if err := s.filter(ctx, options, objs); err != nil {
+				return nil, err
+			}
+		}
+		count = int64(meta.LenList(objs))
+	}
+
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return nil, err
+	}
+	listMeta.SetRemainingItemCount(&count)
+	return list, nil
+}
+
 func (s *RESTStorage) Watch(ctx context.Context, _ *metainternalversion.ListOptions) (watch.Interface, error) {
 	requestInfo, ok := genericrequest.RequestInfoFrom(ctx)
 	if !ok {
@@ -162,6 +298,9 @@ func (s *RESTStorage) Watch(ctx context.Context, _ *metainternalversion.ListOpti
 	if apierrors.IsMethodNotSupported(err) {
 		return nil, apierrors.NewMethodNotSupported(s.DefaultQualifiedResource, "watch")
 	}
+	if err != nil {
+		return nil, storage.InterpretListError(err, s.DefaultQualifiedResource)
+	}
 	return inter, nil
 }
 
@@ -0,0 +1,44 @@
+package resourcerest
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+func TestResolveListOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		kind         string
+		onlyMetadata bool
+	}{
+		{name: "full objects"},
+		{name: "metadata", kind: "PartialObjectMetadataList", onlyMetadata: true},
+		{name: "table", kind: "Table"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := request.WithRequestQuery(context.Background(), url.Values{})
+			if test.kind != "" {
+				ctx = request.WithExtraMediaTypeKind(ctx, test.kind)
+			}
+
+			s := &RESTStorage{}
+			kind, options, err := s.resolveListOptions(ctx, &genericrequest.RequestInfo{Resource: "configmaps"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kind != test.kind {
+				t.Errorf("resolveListOptions() returns the kind %q, want %q", kind, test.kind)
+			}
+			if options.OnlyMetadata != test.onlyMetadata {
+				t.Errorf("OnlyMetadata = %v, want %v", options.OnlyMetadata, test.onlyMetadata)
+			}
+		})
+	}
+}
@@ -2,11 +2,13 @@ package kubeapiserver
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -16,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer/protobuf"
 	"k8s.io/apimachinery/pkg/runtime/serializer/versioning"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/apiserver/pkg/endpoints/handlers"
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/client-go/restmapper"
@@ -26,6 +29,9 @@ import (
 	printersinternal "k8s.io/kubernetes/pkg/printers/internalversion"
 	printerstorage "k8s.io/kubernetes/pkg/printers/storage"
 
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/federation"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/listpolicy"
+	"github.com/clusterpedia-io/clusterpedia/pkg/apiserver/wasmfilter"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/printers"
 	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/resourcerest"
@@ -33,6 +39,7 @@ import (
 	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
 	unstructuredscheme "github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme/unstructured"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/masking"
 )
 
 // toDiscoveryKubeVerb maps an action.Verb to the logical kube verb, used for discovery
@@ -65,9 +72,14 @@ type RESTManager struct {
 	resourceRESTInfos atomic.Value // map[schema.GroupVersionResource]resourceRESTInfo
 
 	requestVerbs metav1.Verbs
+
+	listPolicy *listpolicy.Policy
+	federation *federation.Federation
+	masker     *masking.Masker
+	filters    *wasmfilter.Registry
 }
 
-func NewRESTManager(serializer runtime.NegotiatedSerializer, storageMediaType string, storageFactory storage.StorageFactory, initialAPIGroupResources []*restmapper.APIGroupResources) *RESTManager {
+func NewRESTManager(serializer runtime.NegotiatedSerializer, storageMediaType string, storageFactory storage.StorageFactory, initialAPIGroupResources []*restmapper.APIGroupResources, listPolicy *listpolicy.Policy, federation *federation.Federation, masker *masking.Masker, filters *wasmfilter.Registry) *RESTManager {
 	requestVerbs := storageFactory.GetSupportedRequestVerbs()
 
 	apiresources := make(map[schema.GroupResource]metav1.APIResource)
@@ -96,7 +108,7 @@ func NewRESTManager(serializer runtime.NegotiatedSerializer, storageMediaType st
 					continue
 				}
 
-				resource.Verbs = requestVerbs
+				resource.Verbs = storage.SupportedRequestVerbs(storageFactory, gr.WithVersion(version.Version))
 				apiresources[gr] = resource
 			}
 		}
@@ -109,6 +121,10 @@ func NewRESTManager(serializer runtime.NegotiatedSerializer, storageMediaType st
 		equivalentResourceRegistry: runtime.NewEquivalentResourceRegistry(),
 		requestVerbs:               requestVerbs,
 		subresources:               make(map[schema.GroupResource]map[string]resourceRESTInfo),
+		listPolicy:                 listPolicy,
+		federation:                 federation,
+		masker:                     masker,
+		filters:                    filters,
 	}
 
 	manager.resources.Store(apiresources)
@@ -203,6 +219,30 @@ func (m *RESTManager) GetResourceREST(gvr schema.GroupVersionResource, subresour
 	return info.APIResource, info.RequestScope, info.Storage, true
 }
 
+// GetResourceStorage returns the storage and the request scope of the resource.
+func (m *RESTManager) GetResourceStorage(gvr schema.GroupVersionResource) (*resourcerest.RESTStorage, *handlers.RequestScope, error) {
+	_, scope, rest, ok := m.GetResourceREST(gvr, "")
+	if !ok {
+		return nil, nil, apierrors.NewNotFound(gvr.GroupResource(), "")
+	}
+	storage, ok := rest.(*resourcerest.RESTStorage)
+	if !ok {
+		return nil, nil, apierrors.NewMethodNotSupported(gvr.GroupResource(), "get")
+	}
+	return storage, scope, nil
+}
+
+// ResourceFor returns the resource of the kind.
+func (m *RESTManager) ResourceFor(gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool) {
+	infos := m.resourceRESTInfos.Load().(map[schema.GroupVersionResource]resourceRESTInfo)
+	for gvr, info := range infos {
+		if gvr.GroupVersion() == gvk.GroupVersion() && info.APIResource.Kind == gvk.Kind {
+			return gvr, true
+		}
+	}
+	return schema.GroupVersionResource{}, false
+}
+
 func (m *RESTManager) LoadResources(infos ResourceInfoMap) map[schema.GroupResource]discovery.ResourceDiscoveryAPI {
 	apigroups := m.groups.Load().(map[string]metav1.APIGroup)
 	apiresources := m.resources.Load().(map[schema.GroupResource]metav1.APIResource)
@@ -238,12 +278,15 @@ func (m *RESTManager) LoadResources(infos ResourceInfoMap) map[schema.GroupResou
 			for version := range info.Versions {
 				versions = append(versions, schema.GroupVersion{Group: gr.Group, Version: version})
 			}
+			sort.Slice(versions, func(i, j int) bool {
+				return version.CompareKubeAwareVersionStrings(versions[i].Version, versions[j].Version) > 0
+			})
 		}
 
 		resource, hasResource := apiresources[gr]
 		if !hasResource {
 			resource = metav1.APIResource{Name: gr.Resource, Namespaced: info.Namespaced, Kind: info.Kind}
-			resource.Verbs = m.requestVerbs
+			resource.Verbs = m.resourceVerbs(gr, versions)
 			addedAPIResources[gr] = resource
 		}
 
@@ -289,6 +332,15 @@ func (m *RESTManager) LoadResources(infos ResourceInfoMap) map[schema.GroupResou
 	return apis
 }
 
+// resourceVerbs returns the request verbs of the resource supported by the storage,
+// the versions of a resource are stored by the same storage, so the verbs of the preferred version are used.
+func (m *RESTManager) resourceVerbs(gr schema.GroupResource, versions []schema.GroupVersion) metav1.Verbs {
+	if len(versions) == 0 {
+		return m.requestVerbs
+	}
+	return storage.SupportedRequestVerbs(m.storageFactory, gr.WithVersion(versions[0].Version))
+}
+
 func (m *RESTManager) addAPIGroupsLocked(addedGroups map[string]metav1.APIGroup) {
 	apigroups := m.groups.Load().(map[string]metav1.APIGroup)
 
@@ -406,7 +458,11 @@ func (m *RESTManager) genLegacyResourceRESTStorage(gvr schema.GroupVersionResour
 			return obj
 		},
 
-		Storage: resourceStorage,
+		Storage:    resourceStorage,
+		ListPolicy: m.listPolicy,
+		Federation: m.federation,
+		Masker:     m.masker,
+		Filters:    m.filters,
 	}, nil
 }
 
@@ -434,7 +490,11 @@ func (m *RESTManager) genUnstructuredRESTStorage(gvr schema.GroupVersionResource
 			return obj
 		},
 
-		Storage: resourceStorage,
+		Storage:    resourceStorage,
+		ListPolicy: m.listPolicy,
+		Federation: m.federation,
+		Masker:     m.masker,
+		Filters:    m.filters,
 	}, nil
 }
 
@@ -0,0 +1,235 @@
+package kubeapiserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	clusterlister "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+	clusterpediastorage "github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+)
+
+const (
+	SchemaValidationPath = "/schemavalidation"
+
+	// MaxSchemaValidationResults limits the invalid objects returned, the number of all invalid objects is still counted
+	MaxSchemaValidationResults = 1000
+
+	// the stored objects are listed by pages to bound the memory of validating a large resource
+	schemaValidationPageSize = 500
+)
+
+var crdGVR = apiextensions.SchemeGroupVersion.WithResource("customresourcedefinitions").GroupResource().WithVersion("v1")
+
+// SchemaValidationHandler validates the stored custom resources against the schema of a CRD, so that the objects
+// which would fail the validation of the new version of the CRD are found before the CRD is upgraded across the fleet.
+//
+// The CRD is posted in the request, or is the CRD synchronized from the reference cluster, e.g. the canary cluster
+// where the new version of the CRD is rolled out. The OpenAPI schema of the CRD is validated,
+// and the validation rules written in CEL are not evaluated.
+type SchemaValidationHandler struct {
+	rest          *RESTManager
+	discovery     *discovery.DiscoveryManager
+	clusterLister clusterlister.PediaClusterLister
+}
+
+func (h *SchemaValidationHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "schemavalidation"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	schemaValidation := &v1beta1.SchemaValidation{}
+	if err := decodeRequestObject(req, v1beta1.SchemeGroupVersion.WithKind("SchemaValidation"), schemaValidation); err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	status, err := h.validate(req.Context(), schemaValidation.Spec)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	schemaValidation.Status = *status
+	schemaValidation.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("SchemaValidation"))
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, schemaValidation, false)
+}
+
+func (h *SchemaValidationHandler) validate(ctx context.Context, spec v1beta1.SchemaValidationSpec) (*v1beta1.SchemaValidationStatus, error) {
+	crd, err := h.crd(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, version, err := schemaValidator(crd, spec.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: spec.ObjectVersion, Resource: crd.Spec.Names.Plural}
+	if gvr.Version == "" {
+		gvr.Version = version
+	}
+	if !h.discovery.ResourceEnabled("", gvr) {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("the resource %s is not synchronized", gvr))
+	}
+	storage, _, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &v1beta1.SchemaValidationStatus{}
+	opts := &internal.ListOptions{ClusterNames: spec.Clusters}
+	withContinue := true
+	opts.Limit, opts.WithContinue = schemaValidationPageSize, &withContinue
+	for {
+		list := storage.NewList()
+		if err := storage.Storage.List(ctx, list, opts); err != nil {
+			return nil, clusterpediastorage.InterpretListError(err, gvr.GroupResource())
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+
+		for _, obj := range objs {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			if err != nil {
+				return nil, apierrors.NewInternalError(err)
+			}
+
+			status.Validated++
+			errs := validation.ValidateCustomResource(nil, content, validator)
+			if len(errs) == 0 {
+				continue
+			}
+
+			status.Invalid++
+			if len(status.Results) == MaxSchemaValidationResults {
+				status.Truncated = true
+				continue
+			}
+
+			objMeta, err := meta.Accessor(obj)
+			if err != nil {
+				return nil, apierrors.NewInternalError(err)
+			}
+			result := v1beta1.SchemaValidationResult{
+				Cluster:   utils.ExtractClusterName(obj),
+				Namespace: objMeta.GetNamespace(),
+				Name:      objMeta.GetName(),
+				Errors:    make([]string, 0, len(errs)),
+			}
+			for _, err := range errs {
+				result.Errors = append(result.Errors, err.Error())
+			}
+			status.Results = append(status.Results, result)
+		}
+
+		listMeta, err := meta.ListAccessor(list)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		if opts.Continue = listMeta.GetContinue(); opts.Continue == "" {
+			break
+		}
+	}
+
+	sort.Slice(status.Results, func(i, j int) bool {
+		a, b := status.Results[i], status.Results[j]
+		if a.Cluster != b.Cluster {
+			return a.Cluster < b.Cluster
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return status, nil
+}
+
+// schemaValidator returns the validator of the schema of the CRD version, the storage version is used if the version is empty.
+func schemaValidator(crd *apiextensions.CustomResourceDefinition, version string) (validation.SchemaValidator, string, error) {
+	if version == "" {
+		var err error
+		if version, err = apiextensions.GetCRDStorageVersion(crd); err != nil {
+			return nil, "", apierrors.NewBadRequest(err.Error())
+		}
+	}
+	crdValidation, err := apiextensions.GetSchemaForVersion(crd, version)
+	if err != nil {
+		return nil, "", apierrors.NewBadRequest(err.Error())
+	}
+	if crdValidation == nil || crdValidation.OpenAPIV3Schema == nil {
+		return nil, "", apierrors.NewBadRequest(fmt.Sprintf("the version %s of the CRD %s has no schema", version, crd.Name))
+	}
+	validator, _, err := validation.NewSchemaValidator(crdValidation.OpenAPIV3Schema)
+	if err != nil {
+		return nil, "", apierrors.NewBadRequest(fmt.Sprintf("invalid schema of the CRD %s: %v", crd.Name, err))
+	}
+	return validator, version, nil
+}
+
+// crd returns the posted CRD or the CRD synchronized from the reference cluster in the internal version.
+func (h *SchemaValidationHandler) crd(ctx context.Context, spec v1beta1.SchemaValidationSpec) (*apiextensions.CustomResourceDefinition, error) {
+	var obj runtime.Object
+	switch {
+	case len(spec.CRD.Raw) != 0:
+		decoded, _, err := scheme.LegacyResourceCodecs.UniversalDecoder(apiextensions.SchemeGroupVersion).Decode(spec.CRD.Raw, nil, nil)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid spec.crd: %v", err))
+		}
+		obj = decoded
+	case spec.CRDName != "":
+		cluster := spec.ReferenceCluster
+		if cluster == "" {
+			return nil, apierrors.NewBadRequest("spec.referenceCluster is required with spec.crdName")
+		}
+		if _, err := h.clusterLister.Get(cluster); err != nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("the reference cluster %s is not found", cluster))
+		}
+		if !h.discovery.ResourceEnabled(cluster, crdGVR) {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("the CRDs are not synchronized in the reference cluster %s", cluster))
+		}
+		storage, _, err := h.rest.GetResourceStorage(crdGVR)
+		if err != nil {
+			return nil, err
+		}
+
+		stored := storage.New()
+		if err := storage.Storage.Get(ctx, cluster, "", spec.CRDName, stored); err != nil {
+			return nil, clusterpediastorage.InterpretGetError(err, crdGVR.GroupResource(), spec.CRDName)
+		}
+		if obj, err = scheme.LegacyResourceScheme.ConvertToVersion(stored, apiextensions.SchemeGroupVersion); err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+	default:
+		return nil, apierrors.NewBadRequest("spec.crd or spec.crdName is required")
+	}
+
+	crd, ok := obj.(*apiextensions.CustomResourceDefinition)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("unexpected object kind of the CRD: %s", obj.GetObjectKind().GroupVersionKind()))
+	}
+	return crd, nil
+}
@@ -0,0 +1,80 @@
+package kubeapiserver
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+)
+
+const testCRD = `{
+	"apiVersion": "apiextensions.k8s.io/v1",
+	"kind": "CustomResourceDefinition",
+	"metadata": {"name": "foos.example.io"},
+	"spec": {
+		"group": "example.io",
+		"scope": "Namespaced",
+		"names": {"plural": "foos", "singular": "foo", "kind": "Foo", "listKind": "FooList"},
+		"versions": [
+			{
+				"name": "v1", "served": true, "storage": true,
+				"schema": {"openAPIV3Schema": {"type": "object", "properties": {
+					"spec": {"type": "object", "properties": {"size": {"type": "integer"}}}
+				}}}
+			},
+			{
+				"name": "v2", "served": true, "storage": false,
+				"schema": {"openAPIV3Schema": {"type": "object", "properties": {
+					"spec": {"type": "object", "required": ["size"], "properties": {"size": {"type": "integer", "minimum": 1}}}
+				}}}
+			}
+		]
+	}
+}`
+
+func TestSchemaValidator(t *testing.T) {
+	h := &SchemaValidationHandler{}
+	crd, err := h.crd(context.Background(), v1beta1.SchemaValidationSpec{CRD: runtime.RawExtension{Raw: []byte(testCRD)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objects := []map[string]interface{}{
+		{"spec": map[string]interface{}{"size": int64(3)}},
+		{"spec": map[string]interface{}{"size": int64(0)}},
+		{"spec": map[string]interface{}{}},
+	}
+
+	tests := []struct {
+		version         string
+		expectedVersion string
+		expectedInvalid []bool
+	}{
+		{version: "", expectedVersion: "v1", expectedInvalid: []bool{false, false, false}},
+		{version: "v2", expectedVersion: "v2", expectedInvalid: []bool{false, true, true}},
+	}
+	for _, test := range tests {
+		validator, version, err := schemaValidator(crd, test.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != test.expectedVersion {
+			t.Errorf("version = %s, want %s", version, test.expectedVersion)
+		}
+		for i, obj := range objects {
+			if invalid := len(validation.ValidateCustomResource(nil, obj, validator)) != 0; invalid != test.expectedInvalid[i] {
+				t.Errorf("version %s: object %d invalid = %v, want %v", version, i, invalid, test.expectedInvalid[i])
+			}
+		}
+	}
+
+	if _, _, err := schemaValidator(crd, "v3"); err == nil {
+		t.Errorf("schemaValidator() of the unknown version should fail")
+	}
+	if _, err := h.crd(context.Background(), v1beta1.SchemaValidationSpec{}); err == nil {
+		t.Errorf("crd() without the CRD should fail")
+	}
+}
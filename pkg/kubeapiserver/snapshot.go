@@ -0,0 +1,131 @@
+package kubeapiserver
+
+import (
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const SnapshotPath = "/snapshot"
+
+// SnapshotHandler serves the stored row of an object in a cluster, including the raw payload, the metadata columns,
+// the resource versions, the timestamps and the revisions, for debugging the mismatches between the member cluster
+// and clusterpedia. It only reads the storage, and the raw payload of the encrypted objects is decrypted,
+// so the path is expected to be only granted to the administrators.
+//
+// The cluster is specified by the path, e.g. `/apis/clusterpedia.io/v1beta1/resources/clusters/<cluster>/snapshot`,
+// and the object is specified by the `group`, `version`, `resource`, `namespace` and `name` queries.
+type SnapshotHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+}
+
+func NewSnapshotHandler(rest *RESTManager, discovery *discovery.DiscoveryManager) *SnapshotHandler {
+	return &SnapshotHandler{rest: rest, discovery: discovery}
+}
+
+func (h *SnapshotHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "snapshot"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	query := req.URL.Query()
+	gvr := schema.GroupVersionResource{Group: query.Get("group"), Version: query.Get("version"), Resource: query.Get("resource")}
+	namespace, name := query.Get("namespace"), query.Get("name")
+	if gvr.Version == "" || gvr.Resource == "" || name == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("version, resource and name queries are required"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	cluster := request.ClusterNameValue(req.Context())
+	if cluster == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("the snapshot is only served in the path of a cluster"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	if !h.discovery.ResourceEnabled(cluster, gvr) {
+		responsewriters.ErrorNegotiated(apierrors.NewNotFound(gvr.GroupResource(), name), Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	resourceStorage, _, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	getter, ok := resourceStorage.Storage.(storage.ResourceSnapshotGetter)
+	if !ok {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(gvr.GroupResource(), "snapshot"), Codecs, gvr.GroupVersion(), w, req,
+		)
+		return
+	}
+
+	snapshot, err := getter.GetSnapshot(req.Context(), cluster, namespace, name)
+	if err != nil {
+		responsewriters.ErrorNegotiated(storage.InterpretGetError(err, gvr.GroupResource(), name), Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+
+	result := buildResourceSnapshot(snapshot)
+	result.Cluster, result.Namespace, result.Name = cluster, namespace, name
+	result.Resource = v1beta1.CollectionResourceType{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, result, false)
+}
+
+func buildResourceSnapshot(snapshot *storage.ResourceSnapshot) *v1beta1.ResourceSnapshot {
+	result := &v1beta1.ResourceSnapshot{
+		ObjectKind:            snapshot.Kind,
+		UID:                   snapshot.UID,
+		OwnerUID:              snapshot.OwnerUID,
+		ResourceVersion:       snapshot.ResourceVersion,
+		SpecHash:              snapshot.SpecHash,
+		FormatVersion:         snapshot.FormatVersion,
+		Compression:           snapshot.Compression,
+		Encoding:              snapshot.Encoding,
+		Encryption:            snapshot.Encryption,
+		StoredBytes:           snapshot.StoredBytes,
+		Object:                runtime.RawExtension{Raw: snapshot.Object},
+		EventResourceVersions: snapshot.EventResourceVersions,
+		CreatedTime:           metav1.NewTime(snapshot.CreatedTime),
+		SyncedTime:            metav1.NewTime(snapshot.SyncedTime),
+	}
+	result.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("ResourceSnapshot"))
+
+	if !snapshot.DeletedTime.IsZero() {
+		deleted := metav1.NewTime(snapshot.DeletedTime)
+		result.DeletedTime = &deleted
+	}
+	if !snapshot.RemovedTime.IsZero() {
+		removed := metav1.NewTime(snapshot.RemovedTime)
+		result.RemovedTime = &removed
+	}
+	for _, revision := range snapshot.Revisions {
+		result.Revisions = append(result.Revisions, v1beta1.ResourceRevision{
+			ResourceVersion:         revision.ResourceVersion,
+			PreviousResourceVersion: revision.PreviousResourceVersion,
+			Time:                    metav1.NewTime(revision.Time),
+		})
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package kubeapiserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestBuildResourceSnapshot(t *testing.T) {
+	now := time.Now()
+	result := buildResourceSnapshot(&storage.ResourceSnapshot{
+		Kind:            "Deployment",
+		UID:             "uid-1",
+		ResourceVersion: "2",
+		FormatVersion:   2,
+		StoredBytes:     128,
+		Object:          []byte(`{"kind":"Deployment"}`),
+		CreatedTime:     now,
+		SyncedTime:      now,
+		RemovedTime:     now,
+		Revisions:       []storage.ResourceRevision{{ResourceVersion: "2", PreviousResourceVersion: "1", Time: now}},
+	})
+
+	if kind := result.GetObjectKind().GroupVersionKind().Kind; kind != "ResourceSnapshot" {
+		t.Errorf("kind = %s, want ResourceSnapshot", kind)
+	}
+	if result.UID != "uid-1" || result.ResourceVersion != "2" || result.StoredBytes != 128 || string(result.Object.Raw) != `{"kind":"Deployment"}` {
+		t.Errorf("snapshot = %+v", result)
+	}
+	if result.DeletedTime != nil {
+		t.Errorf("deletedTime of the object without the deletion timestamp should be nil, got %v", result.DeletedTime)
+	}
+	if result.RemovedTime == nil || !result.RemovedTime.Time.Equal(now) {
+		t.Errorf("removedTime = %v, want %v", result.RemovedTime, now)
+	}
+	if len(result.Revisions) != 1 || result.Revisions[0].PreviousResourceVersion != "1" || !result.Revisions[0].Time.Time.Equal(now) {
+		t.Errorf("revisions = %+v", result.Revisions)
+	}
+}
@@ -0,0 +1,168 @@
+package kubeapiserver
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const SpecHashesPath = "/spechashes"
+
+// SpecHashesHandler serves the spec hashes of an object in the clusters, so that the drift detection
+// tools can find the clusters where the object differs without loading the objects.
+//
+// The object is specified by the `group`, `version`, `resource`, `namespace` and `name` queries,
+// and the reference is specified by the `hash` query or by the hash of the object in the `referenceCluster`.
+// With `onlyDiffers=true`, only the clusters where the object differs from the reference are returned.
+type SpecHashesHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+}
+
+func NewSpecHashesHandler(rest *RESTManager, discovery *discovery.DiscoveryManager) *SpecHashesHandler {
+	return &SpecHashesHandler{rest: rest, discovery: discovery}
+}
+
+func (h *SpecHashesHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "spechashes"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	query := req.URL.Query()
+	gvr := schema.GroupVersionResource{Group: query.Get("group"), Version: query.Get("version"), Resource: query.Get("resource")}
+	namespace, name := query.Get("namespace"), query.Get("name")
+	if gvr.Version == "" || gvr.Resource == "" || name == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("version, resource and name queries are required"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	var onlyDiffers bool
+	if value := query.Get("onlyDiffers"); value != "" {
+		var err error
+		if onlyDiffers, err = strconv.ParseBool(value); err != nil {
+			responsewriters.ErrorNegotiated(apierrors.NewBadRequest("invalid onlyDiffers query: "+err.Error()), Codecs, schema.GroupVersion{}, w, req)
+			return
+		}
+	}
+
+	cluster := request.ClusterNameValue(req.Context())
+	referenceCluster := query.Get("referenceCluster")
+	var clusters []string
+	if cluster != "" {
+		clusters = append(clusters, cluster)
+		if referenceCluster != "" && referenceCluster != cluster {
+			clusters = append(clusters, referenceCluster)
+		}
+	}
+
+	hashes, err := h.listSpecHashes(req.Context(), gvr, clusters, namespace, name)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+
+	reference := query.Get("hash")
+	if reference == "" && referenceCluster != "" {
+		if reference = hashes[referenceCluster]; reference == "" {
+			responsewriters.ErrorNegotiated(
+				apierrors.NewNotFound(gvr.GroupResource(), name), Codecs, gvr.GroupVersion(), w, req,
+			)
+			return
+		}
+	}
+	if cluster != "" && referenceCluster != cluster {
+		// the reference cluster is only listed for the reference hash
+		delete(hashes, referenceCluster)
+	}
+
+	result := buildSpecHashes(hashes, reference, onlyDiffers)
+	result.Resource = v1beta1.CollectionResourceType{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+	result.Namespace, result.Name = namespace, name
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, result, false)
+}
+
+// listSpecHashes lists the spec hashes stored with the objects,
+// or hashes the objects if the storage doesn't store the spec hashes.
+func (h *SpecHashesHandler) listSpecHashes(ctx context.Context, gvr schema.GroupVersionResource, clusters []string, namespace, name string) (map[string]string, error) {
+	for _, cluster := range clusters {
+		if !h.discovery.ResourceEnabled(cluster, gvr) {
+			return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+		}
+	}
+	if len(clusters) == 0 && !h.discovery.ResourceEnabled("", gvr) {
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), name)
+	}
+
+	resourceStorage, _, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		return nil, err
+	}
+	if lister, ok := resourceStorage.Storage.(storage.SpecHashLister); ok {
+		hashes, err := lister.ListSpecHashes(ctx, clusters, namespace, name)
+		if err != nil {
+			return nil, storage.InterpretListError(err, gvr.GroupResource())
+		}
+		return hashes, nil
+	}
+
+	opts := &internal.ListOptions{ClusterNames: clusters, Names: []string{name}}
+	if namespace != "" {
+		opts.Namespaces = []string{namespace}
+	}
+	list := resourceStorage.NewList()
+	if err := resourceStorage.Storage.List(ctx, list, opts); err != nil {
+		return nil, storage.InterpretListError(err, gvr.GroupResource())
+	}
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	hashes := make(map[string]string, len(objs))
+	for _, obj := range objs {
+		hash, err := utils.SpecHash(obj)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		hashes[utils.ExtractClusterName(obj)] = hash
+	}
+	return hashes, nil
+}
+
+func buildSpecHashes(hashes map[string]string, reference string, onlyDiffers bool) *v1beta1.SpecHashes {
+	result := &v1beta1.SpecHashes{Reference: reference, Items: []v1beta1.SpecHash{}}
+	result.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("SpecHashes"))
+
+	for cluster, hash := range hashes {
+		differs := reference != "" && hash != reference
+		if onlyDiffers && !differs {
+			continue
+		}
+		result.Items = append(result.Items, v1beta1.SpecHash{Cluster: cluster, Hash: hash, Differs: differs})
+	}
+	sort.Slice(result.Items, func(i, j int) bool {
+		return result.Items[i].Cluster < result.Items[j].Cluster
+	})
+	return result
+}
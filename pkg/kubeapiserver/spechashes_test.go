@@ -0,0 +1,49 @@
+package kubeapiserver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+)
+
+func TestBuildSpecHashes(t *testing.T) {
+	hashes := map[string]string{"cluster-3": "b", "cluster-1": "a", "cluster-2": "a"}
+
+	tests := []struct {
+		name        string
+		reference   string
+		onlyDiffers bool
+		expected    []v1beta1.SpecHash
+	}{
+		{
+			name: "without reference",
+			expected: []v1beta1.SpecHash{
+				{Cluster: "cluster-1", Hash: "a"}, {Cluster: "cluster-2", Hash: "a"}, {Cluster: "cluster-3", Hash: "b"},
+			},
+		},
+		{
+			name:      "with reference",
+			reference: "a",
+			expected: []v1beta1.SpecHash{
+				{Cluster: "cluster-1", Hash: "a"}, {Cluster: "cluster-2", Hash: "a"}, {Cluster: "cluster-3", Hash: "b", Differs: true},
+			},
+		},
+		{
+			name:        "only differs",
+			reference:   "b",
+			onlyDiffers: true,
+			expected: []v1beta1.SpecHash{
+				{Cluster: "cluster-1", Hash: "a", Differs: true}, {Cluster: "cluster-2", Hash: "a", Differs: true},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := buildSpecHashes(hashes, test.reference, test.onlyDiffers)
+			if !reflect.DeepEqual(result.Items, test.expected) {
+				t.Errorf("buildSpecHashes() = %v, want %v", result.Items, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package kubeapiserver
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	clusterlister "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	StorageUsagePath = "/storageusage"
+
+	// the usage is collected by scanning the stored objects, so it is collected less often than the fleet overview
+	storageUsageResyncPeriod = 5 * time.Minute
+)
+
+var (
+	storageObjects = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage",
+			Name:           "objects",
+			Help:           "The number of the stored objects of the resource in the cluster.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "group", "version", "resource"},
+	)
+
+	storageBytes = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage",
+			Name:           "bytes",
+			Help:           "The estimated size in bytes of the stored objects of the resource in the cluster, it is 0 if the storage layer doesn't estimate the size.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "group", "version", "resource"},
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(storageObjects, storageBytes)
+}
+
+// StorageUsage collects the number and the size of the stored objects of each resource in each cluster
+// periodically, the usage is exported as metrics and served by the StorageUsagePath.
+type StorageUsage struct {
+	storageFactory storage.StorageFactory
+	clusterLister  clusterlister.PediaClusterLister
+
+	lock  sync.Mutex
+	usage atomic.Pointer[v1beta1.StorageUsage]
+}
+
+func NewStorageUsage(storageFactory storage.StorageFactory, clusterLister clusterlister.PediaClusterLister) *StorageUsage {
+	return &StorageUsage{
+		storageFactory: storageFactory,
+		clusterLister:  clusterLister,
+	}
+}
+
+func (s *StorageUsage) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, s.resync, storageUsageResyncPeriod)
+}
+
+func (s *StorageUsage) resync(ctx context.Context) {
+	clusters, err := s.clusterLister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list clusters for the storage usage")
+		return
+	}
+	names := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		names = append(names, cluster.Name)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	usages, err := storage.CollectResourceUsages(ctx, s.storageFactory, names)
+	if err != nil {
+		// keep the last usage
+		klog.ErrorS(err, "Failed to collect the storage usage")
+		return
+	}
+
+	storageObjects.Reset()
+	storageBytes.Reset()
+	for _, usage := range usages {
+		gvr := usage.Resource
+		storageObjects.WithLabelValues(usage.Cluster, gvr.Group, gvr.Version, gvr.Resource).Set(float64(usage.Objects))
+		storageBytes.WithLabelValues(usage.Cluster, gvr.Group, gvr.Version, gvr.Resource).Set(float64(usage.Bytes))
+	}
+	s.usage.Store(buildStorageUsage(usages, time.Now()))
+}
+
+func buildStorageUsage(usages []storage.ResourceUsage, now time.Time) *v1beta1.StorageUsage {
+	storageUsage := &v1beta1.StorageUsage{
+		CollectedTime: metav1.NewTime(now),
+		Items:         make([]v1beta1.ResourceStorageUsage, 0, len(usages)),
+	}
+	storageUsage.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("StorageUsage"))
+	for _, usage := range usages {
+		storageUsage.Items = append(storageUsage.Items, v1beta1.ResourceStorageUsage{
+			Cluster: usage.Cluster,
+			Resource: v1beta1.CollectionResourceType{
+				Group:    usage.Resource.Group,
+				Version:  usage.Resource.Version,
+				Resource: usage.Resource.Resource,
+			},
+			Objects: usage.Objects,
+			Bytes:   usage.Bytes,
+		})
+	}
+
+	sort.Slice(storageUsage.Items, func(i, j int) bool {
+		a, b := storageUsage.Items[i], storageUsage.Items[j]
+		if a.Cluster != b.Cluster {
+			return a.Cluster < b.Cluster
+		}
+		if a.Resource.Group != b.Resource.Group {
+			return a.Resource.Group < b.Resource.Group
+		}
+		if a.Resource.Resource != b.Resource.Resource {
+			return a.Resource.Resource < b.Resource.Resource
+		}
+		return a.Resource.Version < b.Resource.Version
+	})
+	return storageUsage
+}
+
+// ServeHTTP serves the last collected usage, the usage can be filtered by the `cluster` query.
+func (s *StorageUsage) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "storageusage"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	usage := s.usage.Load()
+	if usage == nil {
+		// the usage is not collected yet
+		s.resync(req.Context())
+		usage = s.usage.Load()
+	}
+	if usage == nil {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewServiceUnavailable("the storage usage is not ready"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	if cluster := req.URL.Query().Get("cluster"); cluster != "" {
+		filtered := &v1beta1.StorageUsage{TypeMeta: usage.TypeMeta, CollectedTime: usage.CollectedTime, Items: []v1beta1.ResourceStorageUsage{}}
+		for _, item := range usage.Items {
+			if item.Cluster == cluster {
+				filtered.Items = append(filtered.Items, item)
+			}
+		}
+		usage = filtered
+	}
+
+	responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, usage, false)
+}
@@ -0,0 +1,39 @@
+package kubeapiserver
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestBuildStorageUsage(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	usages := []storage.ResourceUsage{
+		{Cluster: "cluster-2", Resource: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, Objects: 3, Bytes: 300},
+		{Cluster: "cluster-1", Resource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, Objects: 2},
+		{Cluster: "cluster-1", Resource: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, Objects: 10, Bytes: 1000},
+	}
+
+	usage := buildStorageUsage(usages, now)
+	if usage.Kind != "StorageUsage" || !usage.CollectedTime.Time.Equal(now) {
+		t.Errorf("unexpected storage usage %v", usage)
+	}
+
+	expected := []v1beta1.ResourceStorageUsage{
+		{Cluster: "cluster-1", Resource: v1beta1.CollectionResourceType{Version: "v1", Resource: "pods"}, Objects: 10, Bytes: 1000},
+		{Cluster: "cluster-1", Resource: v1beta1.CollectionResourceType{Group: "apps", Version: "v1", Resource: "deployments"}, Objects: 2},
+		{Cluster: "cluster-2", Resource: v1beta1.CollectionResourceType{Version: "v1", Resource: "pods"}, Objects: 3, Bytes: 300},
+	}
+	if len(usage.Items) != len(expected) {
+		t.Fatalf("items = %v, want %v", usage.Items, expected)
+	}
+	for i := range expected {
+		if usage.Items[i] != expected[i] {
+			t.Errorf("items[%d] = %v, want %v", i, usage.Items[i], expected[i])
+		}
+	}
+}
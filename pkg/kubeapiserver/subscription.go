@@ -0,0 +1,391 @@
+package kubeapiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/endpoints/handlers"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/resourcerest"
+	clusterpediastorage "github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const (
+	SubscriptionPath = "/subscription"
+
+	// subscriptionHeartbeatInterval is the interval of the comments sent to keep the idle stream alive through the proxies
+	subscriptionHeartbeatInterval = 30 * time.Second
+
+	// subscriptionPollInterval is the interval of listing the resource whose storage doesn't support watch
+	subscriptionPollInterval = 5 * time.Second
+)
+
+// SubscriptionHandler streams the changes of the objects as Server-Sent Events in JSON,
+// for the web UIs which can't easily consume the watch of the kubernetes api in the browsers.
+//
+// The resources are specified by the `resources` query in the form of `<group>/<version>/<resource>`,
+// or `<version>/<resource>` for the core group, e.g. `resources=v1/pods,apps/v1/deployments`.
+// The objects are filtered by the `clusters`, `namespaces` and `labelSelector` queries, and the cluster
+// of the path. The stream ends after the `timeoutSeconds` query if it is set, which can be used for long polling.
+//
+// The current objects are sent as the ADDED events at first. The resource whose storage doesn't support watch
+// is listed periodically, and the changes are detected by the resource versions of the objects.
+type SubscriptionHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+}
+
+func NewSubscriptionHandler(rest *RESTManager, discovery *discovery.DiscoveryManager) *SubscriptionHandler {
+	return &SubscriptionHandler{rest: rest, discovery: discovery}
+}
+
+// subscriptionEvent is the data of the server-sent event, the type is also sent as the event name.
+type subscriptionEvent struct {
+	Type     watch.EventType                `json:"type"`
+	Cluster  string                         `json:"cluster,omitempty"`
+	Resource v1beta1.CollectionResourceType `json:"resource"`
+	Object   interface{}                    `json:"object"`
+}
+
+func (h *SubscriptionHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "subscription"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		responsewriters.ErrorNegotiated(apierrors.NewInternalError(fmt.Errorf("unable to stream the events")), Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	query := req.URL.Query()
+	gvrs, err := parseSubscriptionResources(query["resources"])
+	if err != nil {
+		responsewriters.ErrorNegotiated(apierrors.NewBadRequest(err.Error()), Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+	var timeout time.Duration
+	if value := query.Get("timeoutSeconds"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds <= 0 {
+			responsewriters.ErrorNegotiated(apierrors.NewBadRequest(fmt.Sprintf("invalid timeoutSeconds %q", value)), Codecs, schema.GroupVersion{}, w, req)
+			return
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	version, ok := request.VersionFrom(req.Context())
+	if !ok {
+		version = v1beta1.SchemeGroupVersion
+	}
+	opts := &internal.ListOptions{}
+	if err := clusterpediascheme.DecodeListOptions(query, version, opts); err != nil {
+		responsewriters.ErrorNegotiated(apierrors.NewBadRequest(err.Error()), Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+	cluster := request.ClusterNameValue(req.Context())
+	if cluster != "" {
+		opts.ClusterNames = []string{cluster}
+	}
+
+	sources := make([]*subscriptionSource, 0, len(gvrs))
+	for _, gvr := range gvrs {
+		source, err := h.newSource(gvr, cluster, opts)
+		if err != nil {
+			responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{}, w, req)
+			return
+		}
+		sources = append(sources, source)
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	if timeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	events := make(chan subscriptionEvent)
+	for _, source := range sources {
+		go source.run(ctx, events)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(subscriptionHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event := <-events:
+			if err := writeServerSentEvent(w, event); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// parseSubscriptionResources parses the resources of the `resources` queries,
+// each query may have multiple resources separated by the comma.
+func parseSubscriptionResources(values []string) ([]schema.GroupVersionResource, error) {
+	seen := sets.New[schema.GroupVersionResource]()
+	var gvrs []schema.GroupVersionResource
+	for _, value := range values {
+		for _, resource := range strings.Split(value, ",") {
+			resource = strings.TrimSpace(resource)
+			if resource == "" {
+				continue
+			}
+
+			var gvr schema.GroupVersionResource
+			switch parts := strings.Split(resource, "/"); len(parts) {
+			case 2:
+				gvr.Version, gvr.Resource = parts[0], parts[1]
+			case 3:
+				gvr.Group, gvr.Version, gvr.Resource = parts[0], parts[1], parts[2]
+			}
+			if gvr.Version == "" || gvr.Resource == "" {
+				return nil, fmt.Errorf("invalid resource %q, it should be <group>/<version>/<resource> or <version>/<resource>", resource)
+			}
+			if !seen.Has(gvr) {
+				seen.Insert(gvr)
+				gvrs = append(gvrs, gvr)
+			}
+		}
+	}
+	if len(gvrs) == 0 {
+		return nil, fmt.Errorf("resources query is required")
+	}
+	return gvrs, nil
+}
+
+func writeServerSentEvent(w http.ResponseWriter, event subscriptionEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+type subscriptionSource struct {
+	gvr     schema.GroupVersionResource
+	storage *resourcerest.RESTStorage
+	scope   *handlers.RequestScope
+	opts    *internal.ListOptions
+
+	clusters   sets.Set[string]
+	namespaces sets.Set[string]
+}
+
+func (h *SubscriptionHandler) newSource(gvr schema.GroupVersionResource, cluster string, opts *internal.ListOptions) (*subscriptionSource, error) {
+	if !h.discovery.ResourceEnabled(cluster, gvr) {
+		return nil, apierrors.NewNotFound(gvr.GroupResource(), "")
+	}
+	storage, scope, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceOpts := *opts
+	sourceOpts.Limit, sourceOpts.Continue, sourceOpts.ResourceVersion = 0, "", ""
+	return &subscriptionSource{
+		gvr:        gvr,
+		storage:    storage,
+		scope:      scope,
+		opts:       &sourceOpts,
+		clusters:   sets.New(opts.ClusterNames...),
+		namespaces: sets.New(opts.Namespaces...),
+	}, nil
+}
+
+// run sends the events of the source until the context is done,
+// the error of the source is sent as the ERROR event and the source stops.
+func (s *subscriptionSource) run(ctx context.Context, events chan<- subscriptionEvent) {
+	watcher, err := s.storage.Storage.Watch(ctx, s.opts)
+	if apierrors.IsMethodNotSupported(err) {
+		err = s.poll(ctx, events)
+	} else if err == nil {
+		err = s.watch(ctx, watcher, events)
+	}
+	if err != nil && ctx.Err() == nil {
+		status := responsewriters.ErrorToAPIStatus(clusterpediastorage.InterpretListError(err, s.gvr.GroupResource()))
+		s.send(ctx, events, subscriptionEvent{Type: watch.Error, Resource: s.resource(), Object: status})
+	}
+}
+
+func (s *subscriptionSource) watch(ctx context.Context, watcher watch.Interface, events chan<- subscriptionEvent) error {
+	defer watcher.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-watcher.ResultChan():
+			if !ok {
+				return fmt.Errorf("the watch of %s is closed", s.gvr.GroupResource())
+			}
+			if e.Type == watch.Error {
+				return apierrors.FromObject(e.Object)
+			}
+			if e.Type == watch.Bookmark {
+				continue
+			}
+
+			event, ok, err := s.event(e.Type, e.Object)
+			if err != nil {
+				return err
+			}
+			if ok && !s.send(ctx, events, event) {
+				return nil
+			}
+		}
+	}
+}
+
+// poll lists the objects periodically, and sends the changes detected by the resource versions of the objects.
+func (s *subscriptionSource) poll(ctx context.Context, events chan<- subscriptionEvent) error {
+	type known struct {
+		resourceVersion string
+		event           subscriptionEvent
+	}
+	objects := make(map[string]known)
+
+	ticker := time.NewTicker(subscriptionPollInterval)
+	defer ticker.Stop()
+	for {
+		list := s.storage.NewList()
+		if err := s.storage.Storage.List(ctx, list, s.opts); err != nil {
+			return err
+		}
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+
+		current := make(map[string]known, len(objs))
+		for _, obj := range objs {
+			accessor, err := meta.Accessor(obj)
+			if err != nil {
+				return err
+			}
+			key := strings.Join([]string{utils.ExtractClusterName(obj), accessor.GetNamespace(), accessor.GetName()}, "/")
+
+			last, existed := objects[key]
+			if existed && last.resourceVersion == accessor.GetResourceVersion() {
+				current[key] = last
+				continue
+			}
+
+			eventType := watch.Added
+			if existed {
+				eventType = watch.Modified
+			}
+			event, ok, err := s.event(eventType, obj)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			current[key] = known{resourceVersion: accessor.GetResourceVersion(), event: event}
+			if !s.send(ctx, events, event) {
+				return nil
+			}
+		}
+		for key, last := range objects {
+			if _, ok := current[key]; ok {
+				continue
+			}
+			last.event.Type = watch.Deleted
+			if !s.send(ctx, events, last.event) {
+				return nil
+			}
+		}
+		objects = current
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// event converts the object to the requested version, false is returned if the object is not matched by the filters,
+// which may not be applied by the watch of the storage.
+func (s *subscriptionSource) event(eventType watch.EventType, obj runtime.Object) (subscriptionEvent, bool, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return subscriptionEvent{}, false, err
+	}
+	cluster := utils.ExtractClusterName(obj)
+	if s.clusters.Len() != 0 && !s.clusters.Has(cluster) {
+		return subscriptionEvent{}, false, nil
+	}
+	if s.namespaces.Len() != 0 && !s.namespaces.Has(accessor.GetNamespace()) {
+		return subscriptionEvent{}, false, nil
+	}
+	if s.opts.LabelSelector != nil && !s.opts.LabelSelector.Matches(labels.Set(accessor.GetLabels())) {
+		return subscriptionEvent{}, false, nil
+	}
+
+	versioned, err := s.scope.Convertor.ConvertToVersion(obj, s.gvr.GroupVersion())
+	if err != nil {
+		return subscriptionEvent{}, false, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(versioned)
+	if err != nil {
+		return subscriptionEvent{}, false, err
+	}
+	return subscriptionEvent{
+		Type:     eventType,
+		Cluster:  cluster,
+		Resource: s.resource(),
+		Object:   content,
+	}, true, nil
+}
+
+func (s *subscriptionSource) resource() v1beta1.CollectionResourceType {
+	return v1beta1.CollectionResourceType{Group: s.gvr.Group, Version: s.gvr.Version, Resource: s.gvr.Resource}
+}
+
+func (s *subscriptionSource) send(ctx context.Context, events chan<- subscriptionEvent, event subscriptionEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- event:
+		return true
+	}
+}
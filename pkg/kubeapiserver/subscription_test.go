@@ -0,0 +1,64 @@
+package kubeapiserver
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+)
+
+func TestParseSubscriptionResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected []schema.GroupVersionResource
+		wantErr  bool
+	}{
+		{
+			name:   "core and named groups",
+			values: []string{"v1/pods, apps/v1/deployments", "v1/pods"},
+			expected: []schema.GroupVersionResource{
+				{Version: "v1", Resource: "pods"},
+				{Group: "apps", Version: "v1", Resource: "deployments"},
+			},
+		},
+		{name: "empty", values: []string{""}, wantErr: true},
+		{name: "missing version", values: []string{"pods"}, wantErr: true},
+		{name: "too many parts", values: []string{"apps/v1/deployments/scale"}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gvrs, err := parseSubscriptionResources(test.values)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseSubscriptionResources() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && !reflect.DeepEqual(gvrs, test.expected) {
+				t.Errorf("parseSubscriptionResources() = %v, want %v", gvrs, test.expected)
+			}
+		})
+	}
+}
+
+func TestWriteServerSentEvent(t *testing.T) {
+	w := httptest.NewRecorder()
+	event := subscriptionEvent{
+		Type:     watch.Added,
+		Cluster:  "cluster-1",
+		Resource: v1beta1.CollectionResourceType{Version: "v1", Resource: "pods"},
+		Object:   map[string]interface{}{"metadata": map[string]interface{}{"name": "pod-1"}},
+	}
+	if err := writeServerSentEvent(w, event); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "event: ADDED\n" +
+		`data: {"type":"ADDED","cluster":"cluster-1","resource":{"group":"","version":"v1","resource":"pods"},"object":{"metadata":{"name":"pod-1"}}}` +
+		"\n\n"
+	if got := w.Body.String(); got != expected {
+		t.Errorf("writeServerSentEvent() = %q, want %q", got, expected)
+	}
+}
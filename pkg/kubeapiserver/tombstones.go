@@ -0,0 +1,229 @@
+package kubeapiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/kubeapiserver/discovery"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/masking"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+const TombstonesPath = "/tombstones"
+
+// TombstonesHandler serves the tombstones of the objects deleted from a cluster, which are kept by the soft delete of the storage.
+//
+// The cluster is specified by the path, e.g. `/apis/clusterpedia.io/v1beta1/resources/clusters/<cluster>/tombstones`,
+// and the resource is specified by the `group`, `version` and `resource` queries.
+// The tombstones are listed by `GET`, and are filtered by the `namespace`, `name`, `removedAfter` and `removedBefore` queries,
+// the object of the `namespace` and `name` queries is restored by `POST`, and the filtered tombstones are purged by `DELETE`.
+//
+// The tombstones work as the recycle bin of the clusters: the objects are kept within the tombstone retention of the storage,
+// and `GET` with the `export=true` query returns the manifests of the filtered objects as a `v1` List,
+// which can be applied to recover the objects deleted from the cluster by mistake.
+type TombstonesHandler struct {
+	rest      *RESTManager
+	discovery *discovery.DiscoveryManager
+}
+
+func NewTombstonesHandler(rest *RESTManager, discovery *discovery.DiscoveryManager) *TombstonesHandler {
+	return &TombstonesHandler{rest: rest, discovery: discovery}
+}
+
+func (h *TombstonesHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet, http.MethodPost, http.MethodDelete:
+	default:
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(schema.GroupResource{Group: internal.GroupName, Resource: "tombstones"}, req.Method),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+
+	query := req.URL.Query()
+	gvr := schema.GroupVersionResource{Group: query.Get("group"), Version: query.Get("version"), Resource: query.Get("resource")}
+	if gvr.Version == "" || gvr.Resource == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("version and resource queries are required"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	cluster := request.ClusterNameValue(req.Context())
+	if cluster == "" {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewBadRequest("the tombstones are only served in the path of a cluster"),
+			Codecs, schema.GroupVersion{}, w, req,
+		)
+		return
+	}
+	opts, err := parseTombstoneOptions(query)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, schema.GroupVersion{}, w, req)
+		return
+	}
+
+	if !h.discovery.ResourceEnabled(cluster, gvr) {
+		responsewriters.ErrorNegotiated(apierrors.NewNotFound(gvr.GroupResource(), opts.Name), Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	resourceStorage, _, err := h.rest.GetResourceStorage(gvr)
+	if err != nil {
+		responsewriters.ErrorNegotiated(err, Codecs, gvr.GroupVersion(), w, req)
+		return
+	}
+	tombstoneStorage, ok := resourceStorage.Storage.(storage.ResourceTombstoneStorage)
+	if !ok {
+		responsewriters.ErrorNegotiated(
+			apierrors.NewMethodNotSupported(gvr.GroupResource(), "tombstones"), Codecs, gvr.GroupVersion(), w, req,
+		)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		export := false
+		if value := query.Get("export"); value != "" {
+			if export, err = strconv.ParseBool(value); err != nil {
+				responsewriters.ErrorNegotiated(apierrors.NewBadRequest(fmt.Sprintf("invalid export query: %v", err)), Codecs, schema.GroupVersion{}, w, req)
+				return
+			}
+		}
+
+		tombstones, err := tombstoneStorage.ListTombstones(req.Context(), cluster, opts)
+		if err != nil {
+			responsewriters.ErrorNegotiated(storage.InterpretListError(err, gvr.GroupResource()), Codecs, gvr.GroupVersion(), w, req)
+			return
+		}
+
+		rules := resourceStorage.Masker.Rules(gvr, configv1alpha1.MaskingStageServing)
+		if export {
+			manifests, err := exportTombstones(tombstones, rules)
+			if err != nil {
+				responsewriters.ErrorNegotiated(apierrors.NewInternalError(err), Codecs, gvr.GroupVersion(), w, req)
+				return
+			}
+			responsewriters.WriteObjectNegotiated(scheme.LegacyResourceCodecs, negotiation.DefaultEndpointRestrictions, corev1.SchemeGroupVersion, w, req, http.StatusOK, manifests, false)
+			return
+		}
+
+		result, err := buildTombstones(tombstones, rules)
+		if err != nil {
+			responsewriters.ErrorNegotiated(apierrors.NewInternalError(err), Codecs, gvr.GroupVersion(), w, req)
+			return
+		}
+		result.Cluster = cluster
+		result.Resource = v1beta1.CollectionResourceType{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+		responsewriters.WriteObjectNegotiated(clusterpediascheme.Codecs, negotiation.DefaultEndpointRestrictions, v1beta1.SchemeGroupVersion, w, req, http.StatusOK, result, false)
+	case http.MethodPost:
+		if opts.Name == "" {
+			responsewriters.ErrorNegotiated(apierrors.NewBadRequest("name query is required to restore the object"), Codecs, schema.GroupVersion{}, w, req)
+			return
+		}
+		if err := tombstoneStorage.RestoreTombstone(req.Context(), cluster, opts.Namespace, opts.Name); err != nil {
+			responsewriters.ErrorNegotiated(storage.InterpretGetError(err, gvr.GroupResource(), opts.Name), Codecs, gvr.GroupVersion(), w, req)
+			return
+		}
+		writeSuccessStatus(w, req, fmt.Sprintf("%s %q is restored", gvr.GroupResource(), opts.Name))
+	case http.MethodDelete:
+		purged, err := tombstoneStorage.PurgeTombstones(req.Context(), cluster, opts)
+		if err != nil {
+			responsewriters.ErrorNegotiated(storage.InterpretListError(err, gvr.GroupResource()), Codecs, gvr.GroupVersion(), w, req)
+			return
+		}
+		writeSuccessStatus(w, req, fmt.Sprintf("%d tombstones of %s are purged", purged, gvr.GroupResource()))
+	}
+}
+
+func parseTombstoneOptions(query url.Values) (storage.TombstoneOptions, error) {
+	opts := storage.TombstoneOptions{Namespace: query.Get("namespace"), Name: query.Get("name")}
+	for key, into := range map[string]*time.Time{"removedAfter": &opts.RemovedAfter, "removedBefore": &opts.RemovedBefore} {
+		value := query.Get(key)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return opts, apierrors.NewBadRequest(fmt.Sprintf("invalid %s query, it should be in RFC3339: %v", key, err))
+		}
+		*into = t
+	}
+	return opts, nil
+}
+
+// buildTombstones builds the tombstones whose objects are masked by the serving rules
+func buildTombstones(tombstones []storage.Tombstone, rules *masking.Rules) (*v1beta1.Tombstones, error) {
+	result := &v1beta1.Tombstones{Items: make([]v1beta1.Tombstone, 0, len(tombstones))}
+	result.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("Tombstones"))
+
+	for _, tombstone := range tombstones {
+		object := tombstone.Object
+		if rules != nil {
+			content := make(map[string]interface{})
+			if err := json.Unmarshal(object, &content); err != nil {
+				return nil, err
+			}
+			rules.Mask(content)
+
+			var err error
+			if object, err = json.Marshal(content); err != nil {
+				return nil, err
+			}
+		}
+
+		result.Items = append(result.Items, v1beta1.Tombstone{
+			Namespace: tombstone.Namespace,
+			Name:      tombstone.Name,
+			RemovedAt: metav1.NewTime(tombstone.RemovedAt),
+			Object:    runtime.RawExtension{Raw: object},
+		})
+	}
+	return result, nil
+}
+
+// exportTombstones builds the cleaned manifests of the deleted objects, so the objects can be recreated by applying the manifests.
+func exportTombstones(tombstones []storage.Tombstone, rules *masking.Rules) (*corev1.List, error) {
+	list := &corev1.List{Items: make([]runtime.RawExtension, 0, len(tombstones))}
+	list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("List"))
+
+	for _, tombstone := range tombstones {
+		object := make(map[string]interface{})
+		if err := json.Unmarshal(tombstone.Object, &object); err != nil {
+			return nil, err
+		}
+
+		cleanManifest(object)
+		rules.Mask(object)
+
+		raw, err := json.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Raw: raw})
+	}
+	return list, nil
+}
+
+func writeSuccessStatus(w http.ResponseWriter, req *http.Request, message string) {
+	status := &metav1.Status{Status: metav1.StatusSuccess, Code: http.StatusOK, Message: message}
+	responsewriters.WriteObjectNegotiated(Codecs, negotiation.DefaultEndpointRestrictions, schema.GroupVersion{Version: "v1"}, w, req, http.StatusOK, status, false)
+}
@@ -0,0 +1,110 @@
+package kubeapiserver
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/masking"
+)
+
+func TestParseTombstoneOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected storage.TombstoneOptions
+		wantErr  bool
+	}{
+		{
+			name:     "namespace and name",
+			query:    "namespace=default&name=web",
+			expected: storage.TombstoneOptions{Namespace: "default", Name: "web"},
+		},
+		{
+			name:  "removed time",
+			query: "removedAfter=2024-01-01T00:00:00Z&removedBefore=2024-01-02T00:00:00Z",
+			expected: storage.TombstoneOptions{
+				RemovedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				RemovedBefore: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{name: "invalid time", query: "removedAfter=yesterday", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts, err := parseTombstoneOptions(query)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseTombstoneOptions() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && (opts.Namespace != test.expected.Namespace || opts.Name != test.expected.Name ||
+				!opts.RemovedAfter.Equal(test.expected.RemovedAfter) || !opts.RemovedBefore.Equal(test.expected.RemovedBefore)) {
+				t.Errorf("parseTombstoneOptions() = %+v, want %+v", opts, test.expected)
+			}
+		})
+	}
+}
+
+func TestExportTombstones(t *testing.T) {
+	masker := &masking.Masker{}
+	if err := masker.Update(&configv1alpha1.MaskingConfiguration{Rules: []configv1alpha1.MaskingRule{
+		{Resource: "configmaps", Stage: configv1alpha1.MaskingStageServing, Fields: []string{"data"}},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	rules := masker.Rules(corev1.SchemeGroupVersion.WithResource("configmaps"), configv1alpha1.MaskingStageServing)
+
+	tombstones := []storage.Tombstone{{
+		Namespace: "default",
+		Name:      "settings",
+		Object: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"default",` +
+			`"uid":"7c4a","resourceVersion":"100","creationTimestamp":"2024-01-01T00:00:00Z","managedFields":[{"manager":"kubectl"}],` +
+			`"ownerReferences":[{"apiVersion":"v1","kind":"Pod","name":"web","uid":"1b2c"}],"labels":{"app":"web"},` +
+			`"annotations":{"shadow.clusterpedia.io/cluster-name":"cluster-1","cluster-labels.shadow.clusterpedia.io/region":"us"}},` +
+			`"data":{"password":"secret"}}`),
+	}}
+	list, err := exportTombstones(tombstones, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("exportTombstones() returns %d items, want 1", len(list.Items))
+	}
+
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(list.Items[0].Raw, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "settings", "namespace": "default", "labels": map[string]interface{}{"app": "web"}},
+		"data":       map[string]interface{}{"password": masking.DefaultReplacement},
+	}
+	if !reflect.DeepEqual(manifest, expected) {
+		t.Errorf("exportTombstones() = %v, want %v", manifest, expected)
+	}
+
+	// the manifests are served as a v1 List
+	data, err := runtime.Encode(scheme.LegacyResourceCodecs.LegacyCodec(corev1.SchemeGroupVersion), list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest["apiVersion"] != "v1" || manifest["kind"] != "List" {
+		t.Errorf("the exported manifests are encoded as %s/%s, want v1/List", manifest["apiVersion"], manifest["kind"])
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/prometheus/exporter-toolkit/web"
+	"k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/component-base/metrics"
 	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/klog/v2"
@@ -19,6 +20,10 @@ type Config struct {
 
 	TLSConfig           string
 	DisableGZIPEncoding bool
+
+	// HealthChecks and ReadyChecks are served on /healthz and /readyz, the ping check is served if they are empty
+	HealthChecks []healthz.HealthChecker
+	ReadyChecks  []healthz.HealthChecker
 }
 
 func Run(config Config) {
@@ -48,6 +53,8 @@ func buildMetricsServer(config Config) *http.ServeMux {
 			DisableCompression: config.DisableGZIPEncoding,
 		}),
 	)
+	healthz.InstallHandler(mux, config.HealthChecks...)
+	healthz.InstallReadyzHandler(mux, config.ReadyChecks...)
 	// add profiler
 	pprof.RegisterProfileHandler(mux)
 	// Add index
@@ -60,6 +67,10 @@ func buildMetricsServer(config Config) *http.ServeMux {
 				Text:    "Metrics",
 				Address: "/metrics",
 			},
+			{
+				Text:    "Health",
+				Address: "/healthz",
+			},
 		},
 	}
 	landingPage, err := web.NewLandingPage(landingConfig)
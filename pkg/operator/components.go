@@ -0,0 +1,330 @@
+package operator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	operatorv1alpha1 "github.com/clusterpedia-io/api/operator/v1alpha1"
+)
+
+const (
+	// ClusterpediaLabel is the name of the Clusterpedia which the objects are managed by.
+	ClusterpediaLabel = "operator.clusterpedia.io/clusterpedia"
+	// ComponentLabel is the component of the deployment, eg. 'apiserver'.
+	ComponentLabel = "operator.clusterpedia.io/component"
+
+	// SpecHashAnnotation is the hash of the desired object, the object is updated only when the hash is changed,
+	// so the fields defaulted by the kube-apiserver are not compared.
+	SpecHashAnnotation = "operator.clusterpedia.io/spec-hash"
+	// StorageConfigHashAnnotation is the hash of the storage config in the pod template,
+	// the pods are recreated when the storage config is changed.
+	StorageConfigHashAnnotation = "operator.clusterpedia.io/storage-config-hash"
+	// VersionAnnotation is the version of the component which the deployment is rolled out to.
+	VersionAnnotation = "operator.clusterpedia.io/version"
+
+	StorageConfigKey = "storage-config.yaml"
+
+	defaultImageRepository    = "ghcr.io/clusterpedia-io/clusterpedia"
+	defaultServiceAccountName = "clusterpedia"
+	defaultStorageName        = "internal"
+
+	storageConfigVolume    = "storage-config"
+	storageConfigMountPath = "/etc/clusterpedia/storage"
+)
+
+// component is a deployment of a clusterpedia component
+type component struct {
+	// name is the name of the deployment
+	name string
+
+	component string
+	shard     string
+	spec      operatorv1alpha1.ComponentSpec
+}
+
+// components returns the components in the order of upgrade,
+// the clustersynchro managers may migrate the storage schema, so they are upgraded first.
+func components(clusterpedia *operatorv1alpha1.Clusterpedia) []component {
+	spec := &clusterpedia.Spec
+	components := []component{{
+		name:      clusterpedia.Name + "-" + operatorv1alpha1.ClusterSynchroManagerComponent,
+		component: operatorv1alpha1.ClusterSynchroManagerComponent,
+		spec:      spec.ClusterSynchroManager.ComponentSpec,
+	}}
+	for _, shard := range spec.ClusterSynchroManager.Shards {
+		components = append(components, component{
+			name:      fmt.Sprintf("%s-%s-%s", clusterpedia.Name, operatorv1alpha1.ClusterSynchroManagerComponent, shard),
+			component: operatorv1alpha1.ClusterSynchroManagerComponent,
+			shard:     shard,
+			spec:      spec.ClusterSynchroManager.ComponentSpec,
+		})
+	}
+	return append(components,
+		component{
+			name:      clusterpedia.Name + "-" + operatorv1alpha1.APIServerComponent,
+			component: operatorv1alpha1.APIServerComponent,
+			spec:      spec.APIServer,
+		},
+		component{
+			name:      clusterpedia.Name + "-" + operatorv1alpha1.ControllerManagerComponent,
+			component: operatorv1alpha1.ControllerManagerComponent,
+			spec:      spec.ControllerManager,
+		},
+	)
+}
+
+func validate(clusterpedia *operatorv1alpha1.Clusterpedia) error {
+	spec := &clusterpedia.Spec
+	if spec.Version == "" {
+		return errors.New("version is required")
+	}
+	if spec.Storage.Config != "" && spec.Storage.ConfigSecretName != "" {
+		return errors.New("storage config and storage config secret name are mutually exclusive")
+	}
+
+	shards := make(map[string]bool, len(spec.ClusterSynchroManager.Shards))
+	for _, shard := range spec.ClusterSynchroManager.Shards {
+		if shard == "" {
+			return errors.New("the shard name of clustersynchro manager is empty")
+		}
+		if shards[shard] {
+			return fmt.Errorf("the shard name %q of clustersynchro manager is duplicated", shard)
+		}
+		shards[shard] = true
+	}
+	return nil
+}
+
+func storageConfigSecretName(clusterpedia *operatorv1alpha1.Clusterpedia) string {
+	if clusterpedia.Spec.Storage.ConfigSecretName != "" {
+		return clusterpedia.Spec.Storage.ConfigSecretName
+	}
+	return clusterpedia.Name + "-storage-config"
+}
+
+func apiserverServiceName(clusterpedia *operatorv1alpha1.Clusterpedia) string {
+	return clusterpedia.Name + "-" + operatorv1alpha1.APIServerComponent
+}
+
+func serviceAccountName(clusterpedia *operatorv1alpha1.Clusterpedia) string {
+	if clusterpedia.Spec.ServiceAccountName != "" {
+		return clusterpedia.Spec.ServiceAccountName
+	}
+	return defaultServiceAccountName
+}
+
+func labelsFor(clusterpedia *operatorv1alpha1.Clusterpedia, component string) map[string]string {
+	labels := map[string]string{ClusterpediaLabel: clusterpedia.Name}
+	if component != "" {
+		labels[ComponentLabel] = component
+	}
+	return labels
+}
+
+func ownerReferences(clusterpedia *operatorv1alpha1.Clusterpedia) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		*metav1.NewControllerRef(clusterpedia, operatorv1alpha1.SchemeGroupVersion.WithKind("Clusterpedia")),
+	}
+}
+
+// image returns the image of the component, the image of the overridden image is not changed with the version.
+func (c *component) image(clusterpedia *operatorv1alpha1.Clusterpedia) string {
+	if c.spec.Image != "" {
+		return c.spec.Image
+	}
+
+	repository := clusterpedia.Spec.ImageRepository
+	if repository == "" {
+		repository = defaultImageRepository
+	}
+	return fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(repository, "/"), c.component, clusterpedia.Spec.Version)
+}
+
+func (c *component) usesStorage() bool {
+	return c.component != operatorv1alpha1.ControllerManagerComponent
+}
+
+func (c *component) args(clusterpedia *operatorv1alpha1.Clusterpedia) []string {
+	var args []string
+	if c.usesStorage() {
+		storageName := clusterpedia.Spec.Storage.Name
+		if storageName == "" {
+			storageName = defaultStorageName
+		}
+		args = append(args,
+			"--storage-name="+storageName,
+			"--storage-config="+path.Join(storageConfigMountPath, StorageConfigKey),
+		)
+	}
+
+	switch c.component {
+	case operatorv1alpha1.APIServerComponent:
+		args = append(args, "--secure-port=443")
+	case operatorv1alpha1.ClusterSynchroManagerComponent:
+		if c.shard != "" {
+			args = append(args, "--sharding-name="+c.shard)
+		}
+	}
+
+	if len(c.spec.FeatureGates) != 0 {
+		gates := make([]string, 0, len(c.spec.FeatureGates))
+		for gate, enabled := range c.spec.FeatureGates {
+			gates = append(gates, fmt.Sprintf("%s=%t", gate, enabled))
+		}
+		sort.Strings(gates)
+		args = append(args, "--feature-gates="+strings.Join(gates, ","))
+	}
+	return append(args, c.spec.ExtraArgs...)
+}
+
+// deployment returns the desired deployment of the component,
+// storageConfigHash is the hash of the storage config which the pods are recreated with.
+func (c *component) deployment(clusterpedia *operatorv1alpha1.Clusterpedia, storageConfigHash string) *appsv1.Deployment {
+	labels := labelsFor(clusterpedia, c.component)
+	selector := map[string]string{ClusterpediaLabel: clusterpedia.Name, "app": c.name}
+	podLabels := map[string]string{"app": c.name}
+	for key, value := range labels {
+		podLabels[key] = value
+	}
+
+	container := corev1.Container{
+		Name:    c.component,
+		Image:   c.image(clusterpedia),
+		Command: append([]string{"/usr/local/bin/" + c.component}, c.args(clusterpedia)...),
+	}
+	podAnnotations := map[string]string{}
+	var volumes []corev1.Volume
+	if c.usesStorage() {
+		container.VolumeMounts = []corev1.VolumeMount{{
+			Name:      storageConfigVolume,
+			MountPath: storageConfigMountPath,
+			ReadOnly:  true,
+		}}
+		if name := clusterpedia.Spec.Storage.EnvSecretName; name != "" {
+			container.EnvFrom = []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+			}}
+		}
+		volumes = []corev1.Volume{{
+			Name: storageConfigVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: storageConfigSecretName(clusterpedia),
+					Items:      []corev1.KeyToPath{{Key: StorageConfigKey, Path: StorageConfigKey}},
+				},
+			},
+		}}
+		podAnnotations[StorageConfigHashAnnotation] = storageConfigHash
+	}
+
+	replicas := c.spec.Replicas
+	if replicas == nil {
+		one := int32(1)
+		replicas = &one
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            c.name,
+			Namespace:       clusterpedia.Namespace,
+			Labels:          labels,
+			Annotations:     map[string]string{VersionAnnotation: clusterpedia.Spec.Version},
+			OwnerReferences: ownerReferences(clusterpedia),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: podAnnotations,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: serviceAccountName(clusterpedia),
+					Containers:         []corev1.Container{container},
+					Volumes:            volumes,
+				},
+			},
+		},
+	}
+}
+
+func apiserverService(clusterpedia *operatorv1alpha1.Clusterpedia) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            apiserverServiceName(clusterpedia),
+			Namespace:       clusterpedia.Namespace,
+			Labels:          labelsFor(clusterpedia, operatorv1alpha1.APIServerComponent),
+			OwnerReferences: ownerReferences(clusterpedia),
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{
+				Port:       443,
+				Protocol:   corev1.ProtocolTCP,
+				TargetPort: intstr.FromInt32(443),
+			}},
+			Selector: map[string]string{"app": clusterpedia.Name + "-" + operatorv1alpha1.APIServerComponent},
+		},
+	}
+}
+
+// storageConfigSecret returns the secret of the inline storage config,
+// nil is returned if the storage config is provided by an existing secret.
+func storageConfigSecret(clusterpedia *operatorv1alpha1.Clusterpedia) *corev1.Secret {
+	if clusterpedia.Spec.Storage.ConfigSecretName != "" {
+		return nil
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            storageConfigSecretName(clusterpedia),
+			Namespace:       clusterpedia.Namespace,
+			Labels:          labelsFor(clusterpedia, ""),
+			OwnerReferences: ownerReferences(clusterpedia),
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: map[string]string{StorageConfigKey: clusterpedia.Spec.Storage.Config},
+	}
+}
+
+func serviceAccount(clusterpedia *operatorv1alpha1.Clusterpedia) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            serviceAccountName(clusterpedia),
+			Namespace:       clusterpedia.Namespace,
+			Labels:          labelsFor(clusterpedia, ""),
+			OwnerReferences: ownerReferences(clusterpedia),
+		},
+	}
+}
+
+func hashOf(obj interface{}) string {
+	data, _ := json.Marshal(obj)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// isRolledOut returns true when all of the replicas of the deployment are updated and available.
+func isRolledOut(deployment *appsv1.Deployment) bool {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.Replicas == replicas
+}
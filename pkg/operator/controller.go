@@ -0,0 +1,455 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	operatorv1alpha1 "github.com/clusterpedia-io/api/operator/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/controller"
+	clientset "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
+	operatorinformers "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/operator/v1alpha1"
+	operatorlister "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/operator/v1alpha1"
+)
+
+// Controller reconciles the Clusterpedia into the deployments of the components,
+// the service of the apiserver and the secret of the storage config.
+//
+// The kube informers must only watch the objects with the ClusterpediaLabel.
+type Controller struct {
+	runLock sync.Mutex
+	stopCh  <-chan struct{}
+
+	client     clientset.Interface
+	kubeClient kubernetes.Interface
+
+	clusterpediaLister   operatorlister.ClusterpediaLister
+	deploymentLister     appslisters.DeploymentLister
+	serviceLister        corelisters.ServiceLister
+	secretLister         corelisters.SecretLister
+	serviceAccountLister corelisters.ServiceAccountLister
+
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(
+	client clientset.Interface,
+	kubeClient kubernetes.Interface,
+	clusterpediaInformer operatorinformers.ClusterpediaInformer,
+	deploymentInformer appsinformers.DeploymentInformer,
+	serviceInformer coreinformers.ServiceInformer,
+	secretInformer coreinformers.SecretInformer,
+	serviceAccountInformer coreinformers.ServiceAccountInformer,
+	queue workqueue.RateLimitingInterface,
+) (*Controller, error) {
+	controller := &Controller{
+		client:     client,
+		kubeClient: kubeClient,
+
+		clusterpediaLister:   clusterpediaInformer.Lister(),
+		deploymentLister:     deploymentInformer.Lister(),
+		serviceLister:        serviceInformer.Lister(),
+		secretLister:         secretInformer.Lister(),
+		serviceAccountLister: serviceAccountInformer.Lister(),
+
+		queue: queue,
+	}
+
+	if _, err := clusterpediaInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: controller.enqueue,
+			UpdateFunc: func(older, newer interface{}) {
+				oldObj := older.(*operatorv1alpha1.Clusterpedia)
+				newObj := newer.(*operatorv1alpha1.Clusterpedia)
+				if oldObj.ResourceVersion != newObj.ResourceVersion &&
+					newObj.DeletionTimestamp.IsZero() && equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec) {
+					return
+				}
+				controller.enqueue(newer)
+			},
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// the changes of the managed objects are reconciled by their Clusterpedia
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueByManagedObject,
+		UpdateFunc: func(_, newer interface{}) { controller.enqueueByManagedObject(newer) },
+		DeleteFunc: controller.enqueueByManagedObject,
+	}
+	for _, informer := range []cache.SharedIndexInformer{
+		deploymentInformer.Informer(),
+		serviceInformer.Informer(),
+		secretInformer.Informer(),
+		serviceAccountInformer.Informer(),
+	} {
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return nil, err
+		}
+	}
+	return controller, nil
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *Controller) enqueueByManagedObject(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		klog.Warningf("meta.Accessor managed object<%T> failed: %v", obj, err)
+		return
+	}
+
+	if name := metaobj.GetLabels()[ClusterpediaLabel]; name != "" {
+		c.queue.Add(metaobj.GetNamespace() + "/" + name)
+	}
+}
+
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
+	c.runLock.Lock()
+	defer c.runLock.Unlock()
+	if c.stopCh != nil {
+		return
+	}
+
+	klog.InfoS("Clusterpedia Operator Controller is running", "workers", workers)
+	c.stopCh = stopCh
+
+	var waitGroup sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			c.worker()
+		}()
+	}
+
+	<-c.stopCh
+
+	c.queue.ShutDown()
+	waitGroup.Wait()
+}
+
+func (c *Controller) worker() {
+	for c.processNextClusterpedia() {
+		select {
+		case <-c.stopCh:
+			return
+		default:
+		}
+	}
+}
+
+func (c *Controller) processNextClusterpedia() (continued bool) {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+	continued = true
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key.(string))
+	if err != nil {
+		klog.ErrorS(err, "failed to split clusterpedia key", "key", key)
+		return
+	}
+
+	clusterpedia, err := c.clusterpediaLister.Clusterpedias(namespace).Get(name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "failed to get clusterpedia from lister", "clusterpedia", key)
+		}
+		// the managed objects are collected by their owner references
+		return
+	}
+
+	clusterpedia = clusterpedia.DeepCopy()
+	result := c.reconcile(context.TODO(), clusterpedia)
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		newer, err := c.clusterpediaLister.Clusterpedias(namespace).Get(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if equality.Semantic.DeepEqual(newer.Status, clusterpedia.Status) {
+			return nil
+		}
+
+		newer = newer.DeepCopy()
+		newer.Status = clusterpedia.Status
+		_, err = c.client.OperatorV1alpha1().Clusterpedias(namespace).UpdateStatus(context.TODO(), newer, metav1.UpdateOptions{})
+		return err
+	}); err != nil {
+		klog.ErrorS(err, "failed to update clusterpedia status", "clusterpedia", key)
+	}
+
+	if result.Requeue() {
+		if c.queue.NumRequeues(key) <= result.MaxRetryCount() {
+			c.queue.AddRateLimited(key)
+		}
+	} else {
+		c.queue.Forget(key)
+	}
+	return
+}
+
+func (c *Controller) reconcile(ctx context.Context, clusterpedia *operatorv1alpha1.Clusterpedia) controller.Result {
+	if !clusterpedia.DeletionTimestamp.IsZero() {
+		return controller.NoRequeueResult
+	}
+
+	status := &clusterpedia.Status
+	status.ObservedGeneration = clusterpedia.Generation
+	if err := validate(clusterpedia); err != nil {
+		meta.SetStatusCondition(&status.Conditions, newCondition(operatorv1alpha1.ReadyCondition, metav1.ConditionFalse, operatorv1alpha1.InvalidConfigReason, err.Error()))
+		meta.RemoveStatusCondition(&status.Conditions, operatorv1alpha1.ProgressingCondition)
+		return controller.NoRequeueResult
+	}
+
+	if err := c.reconcileObjects(ctx, clusterpedia); err != nil {
+		klog.ErrorS(err, "failed to reconcile clusterpedia", "clusterpedia", klog.KObj(clusterpedia))
+		meta.SetStatusCondition(&status.Conditions, newCondition(operatorv1alpha1.ReadyCondition, metav1.ConditionFalse, operatorv1alpha1.ReconcileErrorReason, err.Error()))
+		return controller.RequeueResult(math.MaxInt)
+	}
+	return controller.NoRequeueResult
+}
+
+func (c *Controller) reconcileObjects(ctx context.Context, clusterpedia *operatorv1alpha1.Clusterpedia) error {
+	if err := c.ensureServiceAccount(ctx, clusterpedia); err != nil {
+		return err
+	}
+
+	storageConfigHash, err := c.reconcileStorageConfig(ctx, clusterpedia)
+	if err != nil {
+		return err
+	}
+
+	if err := c.applyService(ctx, apiserverService(clusterpedia)); err != nil {
+		return err
+	}
+
+	version := clusterpedia.Spec.Version
+	status := &clusterpedia.Status
+	status.Components = nil
+
+	// the components are upgraded in order, the version of a component is not changed
+	// until the previous components have been rolled out to the new version.
+	var blocked, upgrading bool
+	desired := sets.New[string]()
+	for _, component := range components(clusterpedia) {
+		desired.Insert(component.name)
+
+		current, err := c.deploymentLister.Deployments(clusterpedia.Namespace).Get(component.name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err != nil {
+			current = nil
+		}
+
+		deployment := current
+		if current == nil || current.Annotations[VersionAnnotation] == version || !blocked {
+			if deployment, err = c.applyDeployment(ctx, component.deployment(clusterpedia, storageConfigHash), current); err != nil {
+				return err
+			}
+		}
+
+		componentVersion := deployment.Annotations[VersionAnnotation]
+		if componentVersion != version {
+			upgrading = true
+		}
+		if componentVersion != version || !isRolledOut(deployment) {
+			blocked = true
+		}
+		status.Components = append(status.Components, operatorv1alpha1.ComponentStatus{
+			Name:              component.name,
+			Component:         component.component,
+			Version:           componentVersion,
+			Replicas:          deployment.Status.Replicas,
+			UpdatedReplicas:   deployment.Status.UpdatedReplicas,
+			AvailableReplicas: deployment.Status.AvailableReplicas,
+		})
+	}
+
+	if err := c.deleteStaleDeployments(ctx, clusterpedia, desired); err != nil {
+		return err
+	}
+
+	switch {
+	case !blocked:
+		status.Version = version
+		meta.SetStatusCondition(&status.Conditions, newCondition(operatorv1alpha1.ReadyCondition, metav1.ConditionTrue, operatorv1alpha1.ReadyReason, ""))
+		meta.SetStatusCondition(&status.Conditions, newCondition(operatorv1alpha1.ProgressingCondition, metav1.ConditionFalse, operatorv1alpha1.AvailableReason, ""))
+	case upgrading:
+		meta.SetStatusCondition(&status.Conditions, newCondition(operatorv1alpha1.ReadyCondition, metav1.ConditionFalse, operatorv1alpha1.NotReadyReason, "the components are not rolled out"))
+		meta.SetStatusCondition(&status.Conditions, newCondition(operatorv1alpha1.ProgressingCondition, metav1.ConditionTrue, operatorv1alpha1.UpgradingReason,
+			fmt.Sprintf("upgrading the components from %s to %s", status.Version, version)))
+	default:
+		meta.SetStatusCondition(&status.Conditions, newCondition(operatorv1alpha1.ReadyCondition, metav1.ConditionFalse, operatorv1alpha1.NotReadyReason, "the components are not rolled out"))
+		meta.SetStatusCondition(&status.Conditions, newCondition(operatorv1alpha1.ProgressingCondition, metav1.ConditionTrue, operatorv1alpha1.RolloutReason, ""))
+	}
+	return nil
+}
+
+func (c *Controller) ensureServiceAccount(ctx context.Context, clusterpedia *operatorv1alpha1.Clusterpedia) error {
+	desired := serviceAccount(clusterpedia)
+	if _, err := c.serviceAccountLister.ServiceAccounts(desired.Namespace).Get(desired.Name); err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	// the service account may be created by the installation manifests without the ClusterpediaLabel
+	_, err := c.kubeClient.CoreV1().ServiceAccounts(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// reconcileStorageConfig applies the secret of the inline storage config and returns the hash of the storage config.
+func (c *Controller) reconcileStorageConfig(ctx context.Context, clusterpedia *operatorv1alpha1.Clusterpedia) (string, error) {
+	if desired := storageConfigSecret(clusterpedia); desired != nil {
+		if err := c.applySecret(ctx, desired); err != nil {
+			return "", err
+		}
+		return hashOf(clusterpedia.Spec.Storage.Config), nil
+	}
+
+	// the existing secret is not watched, its changes are applied when the Clusterpedia is resynced
+	secret, err := c.kubeClient.CoreV1().Secrets(clusterpedia.Namespace).Get(ctx, clusterpedia.Spec.Storage.ConfigSecretName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get the storage config secret: %w", err)
+	}
+	config, ok := secret.Data[StorageConfigKey]
+	if !ok {
+		return "", fmt.Errorf("the storage config secret %s has no key %s", secret.Name, StorageConfigKey)
+	}
+	return hashOf(string(config)), nil
+}
+
+func (c *Controller) applyDeployment(ctx context.Context, desired, current *appsv1.Deployment) (*appsv1.Deployment, error) {
+	hash := hashOf(desired)
+	desired.Annotations[SpecHashAnnotation] = hash
+	if current == nil {
+		klog.InfoS("create deployment", "deployment", klog.KObj(desired))
+		return c.kubeClient.AppsV1().Deployments(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+	}
+	if current.Annotations[SpecHashAnnotation] == hash {
+		return current, nil
+	}
+
+	klog.InfoS("update deployment", "deployment", klog.KObj(desired))
+	updated := current.DeepCopy()
+	updated.Labels = desired.Labels
+	updated.Annotations = desired.Annotations
+	updated.OwnerReferences = desired.OwnerReferences
+	updated.Spec = desired.Spec
+	return c.kubeClient.AppsV1().Deployments(desired.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+}
+
+func (c *Controller) applyService(ctx context.Context, desired *corev1.Service) error {
+	hash := hashOf(desired)
+	desired.Annotations = map[string]string{SpecHashAnnotation: hash}
+
+	current, err := c.serviceLister.Services(desired.Namespace).Get(desired.Name)
+	if apierrors.IsNotFound(err) {
+		_, err = c.kubeClient.CoreV1().Services(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil || current.Annotations[SpecHashAnnotation] == hash {
+		return err
+	}
+
+	// the cluster ips are allocated by the kube-apiserver, so only the ports and the selector are updated
+	updated := current.DeepCopy()
+	updated.Labels = desired.Labels
+	updated.Annotations = desired.Annotations
+	updated.OwnerReferences = desired.OwnerReferences
+	updated.Spec.Ports = desired.Spec.Ports
+	updated.Spec.Selector = desired.Spec.Selector
+	_, err = c.kubeClient.CoreV1().Services(desired.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *Controller) applySecret(ctx context.Context, desired *corev1.Secret) error {
+	hash := hashOf(desired)
+	desired.Annotations = map[string]string{SpecHashAnnotation: hash}
+
+	current, err := c.secretLister.Secrets(desired.Namespace).Get(desired.Name)
+	if apierrors.IsNotFound(err) {
+		_, err = c.kubeClient.CoreV1().Secrets(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil || current.Annotations[SpecHashAnnotation] == hash {
+		return err
+	}
+
+	updated := current.DeepCopy()
+	updated.Labels = desired.Labels
+	updated.Annotations = desired.Annotations
+	updated.OwnerReferences = desired.OwnerReferences
+	updated.Data = nil
+	updated.StringData = desired.StringData
+	_, err = c.kubeClient.CoreV1().Secrets(desired.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// deleteStaleDeployments deletes the deployments of the removed shards.
+func (c *Controller) deleteStaleDeployments(ctx context.Context, clusterpedia *operatorv1alpha1.Clusterpedia, desired sets.Set[string]) error {
+	deployments, err := c.deploymentLister.Deployments(clusterpedia.Namespace).List(labels.SelectorFromSet(labelsFor(clusterpedia, "")))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, deployment := range deployments {
+		if desired.Has(deployment.Name) || !metav1.IsControlledBy(deployment, clusterpedia) {
+			continue
+		}
+
+		klog.InfoS("delete stale deployment", "deployment", klog.KObj(deployment))
+		if err := c.kubeClient.AppsV1().Deployments(deployment.Namespace).Delete(ctx, deployment.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func newCondition(conditionType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
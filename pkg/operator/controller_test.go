@@ -0,0 +1,244 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+
+	operatorv1alpha1 "github.com/clusterpedia-io/api/operator/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/fake"
+	"github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions"
+)
+
+type testController struct {
+	*Controller
+
+	kubeClient          *kubefake.Clientset
+	kubeInformerFactory kubeinformers.SharedInformerFactory
+}
+
+func newTestController(t *testing.T) *testController {
+	client, kubeClient := fake.NewSimpleClientset(), kubefake.NewSimpleClientset()
+	// the generation of the deployment is increased when its spec is changed, like the kube-apiserver
+	kubeClient.PrependReactor("update", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		action.(clienttesting.UpdateAction).GetObject().(*appsv1.Deployment).Generation++
+		return false, nil, nil
+	})
+	informerFactory := externalversions.NewSharedInformerFactory(client, 0)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+
+	controller, err := NewController(client, kubeClient,
+		informerFactory.Operator().V1alpha1().Clusterpedias(),
+		kubeInformerFactory.Apps().V1().Deployments(),
+		kubeInformerFactory.Core().V1().Services(),
+		kubeInformerFactory.Core().V1().Secrets(),
+		kubeInformerFactory.Core().V1().ServiceAccounts(),
+		workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testController{Controller: controller, kubeClient: kubeClient, kubeInformerFactory: kubeInformerFactory}
+}
+
+// sync adds the created objects into the listers, the deployments in rolledOut are rolled out.
+func (c *testController) sync(t *testing.T, rolledOut ...string) {
+	secrets, err := c.kubeClient.CoreV1().Secrets("clusterpedia-system").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range secrets.Items {
+		if err := c.kubeInformerFactory.Core().V1().Secrets().Informer().GetIndexer().Update(&secrets.Items[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	services, err := c.kubeClient.CoreV1().Services("clusterpedia-system").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range services.Items {
+		if err := c.kubeInformerFactory.Core().V1().Services().Informer().GetIndexer().Update(&services.Items[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deployments, err := c.kubeClient.AppsV1().Deployments("clusterpedia-system").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indexer := c.kubeInformerFactory.Apps().V1().Deployments().Informer().GetIndexer()
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		for _, name := range rolledOut {
+			if deployment.Name == name {
+				replicas := *deployment.Spec.Replicas
+				deployment.Status = appsv1.DeploymentStatus{ObservedGeneration: deployment.Generation, Replicas: replicas, UpdatedReplicas: replicas, AvailableReplicas: replicas}
+			}
+		}
+		if err := indexer.Update(deployment); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func (c *testController) versions(t *testing.T) map[string]string {
+	deployments, err := c.kubeClient.AppsV1().Deployments("clusterpedia-system").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions := make(map[string]string)
+	for _, deployment := range deployments.Items {
+		versions[deployment.Name] = deployment.Annotations[VersionAnnotation]
+	}
+	return versions
+}
+
+func newClusterpedia(version string) *operatorv1alpha1.Clusterpedia {
+	return &operatorv1alpha1.Clusterpedia{
+		ObjectMeta: metav1.ObjectMeta{Name: "clusterpedia", Namespace: "clusterpedia-system", UID: "uid"},
+		Spec: operatorv1alpha1.ClusterpediaSpec{
+			Version: version,
+			Storage: operatorv1alpha1.StorageSpec{Config: "type: postgres"},
+		},
+	}
+}
+
+func TestReconcileUpgrade(t *testing.T) {
+	c := newTestController(t)
+	clusterpedia := newClusterpedia("v0.8.0")
+
+	const (
+		synchro    = "clusterpedia-clustersynchro-manager"
+		apiserver  = "clusterpedia-apiserver"
+		controller = "clusterpedia-controller-manager"
+	)
+	c.reconcile(context.TODO(), clusterpedia)
+	if versions := c.versions(t); len(versions) != 3 || versions[synchro] != "v0.8.0" || versions[apiserver] != "v0.8.0" || versions[controller] != "v0.8.0" {
+		t.Fatalf("expected the components are installed, got %v", versions)
+	}
+	if cond := meta.FindStatusCondition(clusterpedia.Status.Conditions, operatorv1alpha1.ProgressingCondition); cond == nil || cond.Reason != operatorv1alpha1.RolloutReason {
+		t.Fatalf("expected the components are rolling out, got %v", cond)
+	}
+
+	c.sync(t, synchro, apiserver, controller)
+	c.reconcile(context.TODO(), clusterpedia)
+	if !meta.IsStatusConditionTrue(clusterpedia.Status.Conditions, operatorv1alpha1.ReadyCondition) || clusterpedia.Status.Version != "v0.8.0" {
+		t.Fatalf("expected the clusterpedia is ready, got %v", clusterpedia.Status)
+	}
+
+	clusterpedia.Spec.Version = "v0.9.0"
+	c.reconcile(context.TODO(), clusterpedia)
+	if versions := c.versions(t); versions[synchro] != "v0.9.0" || versions[apiserver] != "v0.8.0" || versions[controller] != "v0.8.0" {
+		t.Fatalf("expected only the clustersynchro manager is upgraded first, got %v", versions)
+	}
+	if cond := meta.FindStatusCondition(clusterpedia.Status.Conditions, operatorv1alpha1.ProgressingCondition); cond == nil || cond.Reason != operatorv1alpha1.UpgradingReason {
+		t.Fatalf("expected the clusterpedia is upgrading, got %v", cond)
+	}
+
+	c.sync(t, synchro)
+	c.reconcile(context.TODO(), clusterpedia)
+	if versions := c.versions(t); versions[apiserver] != "v0.9.0" || versions[controller] != "v0.8.0" {
+		t.Fatalf("expected the apiserver is upgraded after the clustersynchro manager, got %v", versions)
+	}
+
+	c.sync(t, synchro, apiserver)
+	c.reconcile(context.TODO(), clusterpedia)
+	if versions := c.versions(t); versions[controller] != "v0.9.0" {
+		t.Fatalf("expected the controller manager is upgraded after the apiserver, got %v", versions)
+	}
+	if clusterpedia.Status.Version != "v0.8.0" {
+		t.Errorf("expected the status version is not changed until all components are rolled out, got %s", clusterpedia.Status.Version)
+	}
+
+	c.sync(t, synchro, apiserver, controller)
+	c.reconcile(context.TODO(), clusterpedia)
+	if !meta.IsStatusConditionTrue(clusterpedia.Status.Conditions, operatorv1alpha1.ReadyCondition) || clusterpedia.Status.Version != "v0.9.0" {
+		t.Errorf("expected the clusterpedia is upgraded, got %v", clusterpedia.Status)
+	}
+}
+
+func TestReconcileShards(t *testing.T) {
+	c := newTestController(t)
+	clusterpedia := newClusterpedia("v0.9.0")
+	clusterpedia.Spec.ClusterSynchroManager.Shards = []string{"shard-1", "shard-2"}
+
+	c.reconcile(context.TODO(), clusterpedia)
+	c.sync(t)
+	if versions := c.versions(t); len(versions) != 5 {
+		t.Fatalf("expected a clustersynchro manager for each shard, got %v", versions)
+	}
+
+	deployment, err := c.kubeClient.AppsV1().Deployments("clusterpedia-system").Get(context.TODO(), "clusterpedia-clustersynchro-manager-shard-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if command := deployment.Spec.Template.Spec.Containers[0].Command; command[len(command)-1] != "--sharding-name=shard-1" {
+		t.Errorf("expected the sharding name of the clustersynchro manager, got %v", command)
+	}
+
+	clusterpedia.Spec.ClusterSynchroManager.Shards = []string{"shard-1"}
+	c.reconcile(context.TODO(), clusterpedia)
+	if versions := c.versions(t); len(versions) != 4 {
+		t.Fatalf("expected the clustersynchro manager of the removed shard is deleted, got %v", versions)
+	}
+}
+
+func TestReconcileInvalidConfig(t *testing.T) {
+	c := newTestController(t)
+	clusterpedia := newClusterpedia("v0.9.0")
+	clusterpedia.Spec.Storage.ConfigSecretName = "storage-config"
+
+	if result := c.reconcile(context.TODO(), clusterpedia); result.Requeue() {
+		t.Error("expected the invalid clusterpedia is not requeued")
+	}
+	if cond := meta.FindStatusCondition(clusterpedia.Status.Conditions, operatorv1alpha1.ReadyCondition); cond == nil || cond.Reason != operatorv1alpha1.InvalidConfigReason {
+		t.Errorf("expected the invalid config condition, got %v", cond)
+	}
+	if versions := c.versions(t); len(versions) != 0 {
+		t.Errorf("expected no components are installed, got %v", versions)
+	}
+}
+
+func TestComponentArgs(t *testing.T) {
+	clusterpedia := newClusterpedia("v0.9.0")
+	clusterpedia.Spec.APIServer = operatorv1alpha1.ComponentSpec{
+		FeatureGates: map[string]bool{"RemainingItemCount": false, "AllowRawSQLQuery": true},
+		ExtraArgs:    []string{"-v=3"},
+	}
+
+	for _, component := range components(clusterpedia) {
+		if component.component != operatorv1alpha1.APIServerComponent {
+			continue
+		}
+
+		expected := []string{
+			"--storage-name=internal",
+			"--storage-config=/etc/clusterpedia/storage/storage-config.yaml",
+			"--secure-port=443",
+			"--feature-gates=AllowRawSQLQuery=true,RemainingItemCount=false",
+			"-v=3",
+		}
+		args := component.args(clusterpedia)
+		if len(args) != len(expected) {
+			t.Fatalf("expected args %v, got %v", expected, args)
+		}
+		for i := range expected {
+			if args[i] != expected[i] {
+				t.Errorf("expected args %v, got %v", expected, args)
+			}
+		}
+		if image := component.image(clusterpedia); image != "ghcr.io/clusterpedia-io/clusterpedia/apiserver:v0.9.0" {
+			t.Errorf("unexpected image %s", image)
+		}
+	}
+}
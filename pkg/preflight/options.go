@@ -0,0 +1,97 @@
+package preflight
+
+import (
+	"errors"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	clientset "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	cliflag "k8s.io/component-base/cli/flag"
+
+	crdclientset "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
+	storageoptions "github.com/clusterpedia-io/clusterpedia/pkg/storage/options"
+)
+
+// Options are the options of the check command of the components.
+type Options struct {
+	Master     string
+	Kubeconfig string
+
+	Storage *storageoptions.StorageOptions
+
+	// Namespace is the namespace of the secrets referenced by the PediaClusters
+	Namespace string
+
+	Timeout      time.Duration
+	SkipClusters bool
+	Output       string
+}
+
+func NewOptions(namespace string) *Options {
+	return &Options{
+		Storage:   storageoptions.NewStorageOptions(),
+		Namespace: namespace,
+		Timeout:   10 * time.Second,
+		Output:    "text",
+	}
+}
+
+func (o *Options) Flags() cliflag.NamedFlagSets {
+	var fss cliflag.NamedFlagSets
+
+	o.Storage.AddFlags(fss.FlagSet("storage"))
+
+	fs := fss.FlagSet("check")
+	fs.StringVar(&o.Master, "master", o.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig).")
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to kubeconfig file with authorization and master location information.")
+	fs.StringVar(&o.Namespace, "namespace", o.Namespace, "The namespace of the secrets referenced by the PediaClusters.")
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "The timeout of each check.")
+	fs.BoolVar(&o.SkipClusters, "skip-clusters", o.SkipClusters, "Skip checking the reachability of the PediaClusters.")
+	fs.StringVarP(&o.Output, "output", "o", o.Output, "The output format of the report, text or json.")
+	return fss
+}
+
+func (o *Options) Validate() error {
+	errs := o.Storage.Validate()
+	if o.Timeout <= 0 {
+		errs = append(errs, errors.New("--timeout must be positive"))
+	}
+	if o.Output != "text" && o.Output != "json" {
+		errs = append(errs, errors.New("--output must be text or json"))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (o *Options) Config(component Component, userAgent string) (*Config, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	kubeconfig, err := clientcmd.BuildConfigFromFlags(o.Master, o.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig = restclient.AddUserAgent(kubeconfig, userAgent)
+
+	client, err := clientset.NewForConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	crdclient, err := crdclientset.NewForConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{
+		Component:     component,
+		Client:        client,
+		CRDClient:     crdclient,
+		StorageName:   o.Storage.Name,
+		StorageConfig: o.Storage.ConfigPath,
+		Namespace:     o.Namespace,
+		Timeout:       o.Timeout,
+		SkipClusters:  o.SkipClusters,
+	}, nil
+}
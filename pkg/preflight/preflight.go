@@ -0,0 +1,214 @@
+// Package preflight checks whether a component of clusterpedia is ready to roll out,
+// including the storage, the rbac in the hub cluster and the reachability of the PediaClusters.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	clientset "k8s.io/client-go/kubernetes"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	crdclientset "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+)
+
+// Component describes what a component requires to run.
+type Component struct {
+	Name string
+
+	// Writable is true if the component writes the resources to the storage
+	Writable bool
+
+	// Permissions are the permissions of the component in the hub cluster,
+	// the namespace of the permission is defaulted to the namespace of the config if it is `-`.
+	Permissions []authorizationv1.ResourceAttributes
+}
+
+type Config struct {
+	Component
+
+	Client    clientset.Interface
+	CRDClient crdclientset.Interface
+
+	StorageName   string
+	StorageConfig string
+
+	Namespace    string
+	Timeout      time.Duration
+	SkipClusters bool
+}
+
+// Run runs the checks of the component and returns the readiness report.
+func Run(ctx context.Context, config *Config) *Report {
+	report := newReport(config.Name)
+	checkStorage(ctx, config, report)
+	checkPermissions(ctx, config, report)
+	if !config.SkipClusters {
+		checkClusters(ctx, config, report)
+	}
+	return report
+}
+
+func checkStorage(ctx context.Context, config *Config, report *Report) {
+	factory, err := storage.NewStorageFactory(config.StorageName, config.StorageConfig)
+	if err != nil {
+		report.fail("storage connectivity", err)
+		return
+	}
+	defer func() {
+		_ = factory.Shutdown()
+	}()
+
+	checker, ok := factory.(storage.StorageChecker)
+	if !ok {
+		message := fmt.Sprintf("storage %s doesn't support the checks", config.StorageName)
+		report.warn("storage connectivity", message)
+		report.warn("storage schema", message)
+		report.warn("storage privileges", message)
+		return
+	}
+
+	withTimeout := func(check func(context.Context) error) error {
+		ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+		return check(ctx)
+	}
+
+	if err := withTimeout(checker.Ping); err != nil {
+		report.fail("storage connectivity", err)
+		return
+	}
+	report.pass("storage connectivity", "")
+	report.check("storage schema", withTimeout(checker.CheckSchema))
+	report.check("storage privileges", withTimeout(func(ctx context.Context) error {
+		return checker.CheckPrivileges(ctx, config.Writable)
+	}))
+}
+
+func checkPermissions(ctx context.Context, config *Config, report *Report) {
+	var denied []string
+	for _, permission := range config.Permissions {
+		attributes := permission
+		if attributes.Namespace == "-" {
+			attributes.Namespace = config.Namespace
+		}
+
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+		}
+		ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+		review, err := config.Client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		cancel()
+		if err != nil {
+			report.fail("hub rbac", err)
+			return
+		}
+		if !review.Status.Allowed {
+			denied = append(denied, describePermission(attributes))
+		}
+	}
+
+	if len(denied) != 0 {
+		report.fail("hub rbac", fmt.Errorf("missing permissions: %s", strings.Join(denied, ", ")))
+		return
+	}
+	report.pass("hub rbac", fmt.Sprintf("%d permissions are allowed", len(config.Permissions)))
+}
+
+func describePermission(attributes authorizationv1.ResourceAttributes) string {
+	resource := attributes.Resource
+	if attributes.Group != "" {
+		resource += "." + attributes.Group
+	}
+	if attributes.Subresource != "" {
+		resource += "/" + attributes.Subresource
+	}
+	if attributes.Namespace != "" {
+		resource = attributes.Namespace + "/" + resource
+	}
+	if attributes.Name != "" {
+		resource += "/" + attributes.Name
+	}
+	return attributes.Verb + " " + resource
+}
+
+func checkClusters(ctx context.Context, config *Config, report *Report) {
+	listCtx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	clusters, err := config.CRDClient.ClusterV1alpha2().PediaClusters().List(listCtx, metav1.ListOptions{})
+	if err != nil {
+		report.fail("pediaclusters", err)
+		return
+	}
+	if len(clusters.Items) == 0 {
+		report.warn("pediaclusters", "no PediaCluster is registered")
+		return
+	}
+
+	var secretLister listersv1.SecretNamespaceLister
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		check := "cluster " + cluster.Name
+
+		if cluster.Spec.AuthenticationFrom != nil && secretLister == nil {
+			if secretLister, err = listSecrets(listCtx, config); err != nil {
+				report.fail(check, fmt.Errorf("failed to list the authentication secrets: %w", err))
+				continue
+			}
+		}
+
+		version, err := checkCluster(cluster, secretLister, config.Timeout)
+		if err != nil {
+			report.fail(check, err)
+			continue
+		}
+		report.pass(check, "kubernetes "+version)
+	}
+}
+
+func checkCluster(cluster *clusterv1alpha2.PediaCluster, secretLister listersv1.SecretNamespaceLister, timeout time.Duration) (string, error) {
+	restConfig, err := utils.BuildClusterRestConfig(cluster, secretLister)
+	if err != nil {
+		return "", err
+	}
+	restConfig.Timeout = timeout
+
+	client, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return "", err
+	}
+	version, err := client.ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("cluster is unreachable: %w", err)
+	}
+	return version.GitVersion, nil
+}
+
+func listSecrets(ctx context.Context, config *Config) (listersv1.SecretNamespaceLister, error) {
+	if config.Namespace == "" {
+		return nil, errors.New("the namespace of the secrets is required")
+	}
+
+	secrets, err := config.Client.CoreV1().Secrets(config.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for i := range secrets.Items {
+		if err := indexer.Add(&secrets.Items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return listersv1.NewSecretLister(indexer).Secrets(config.Namespace), nil
+}
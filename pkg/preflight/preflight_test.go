@@ -0,0 +1,129 @@
+package preflight
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	crdfake "github.com/clusterpedia-io/clusterpedia/pkg/generated/clientset/versioned/fake"
+)
+
+func newTestConfig(denied string, clusters ...runtime.Object) *Config {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Resource != denied
+		return true, review, nil
+	})
+
+	return &Config{
+		Component: Component{
+			Name: "test",
+			Permissions: []authorizationv1.ResourceAttributes{
+				{Verb: "list", Group: clusterv1alpha2.GroupName, Resource: "pediaclusters"},
+				{Verb: "list", Resource: "secrets", Namespace: "-"},
+			},
+		},
+		Client:      client,
+		CRDClient:   crdfake.NewSimpleClientset(clusters...),
+		StorageName: "memory",
+		Namespace:   "clusterpedia-system",
+		Timeout:     5 * time.Second,
+	}
+}
+
+func newCluster(name, apiserver string) *clusterv1alpha2.PediaCluster {
+	return &clusterv1alpha2.PediaCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       clusterv1alpha2.ClusterSpec{APIServer: apiserver, TokenData: []byte("token")},
+	}
+}
+
+func resultOf(report *Report, check string) Result {
+	for _, result := range report.Results {
+		if result.Check == check {
+			return result
+		}
+	}
+	return Result{}
+}
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(w).Encode(version.Info{GitVersion: "v1.30.0"})
+	}))
+	defer server.Close()
+
+	config := newTestConfig("", newCluster("cluster-1", server.URL), newCluster("cluster-2", "https://127.0.0.1:1"))
+	report := Run(context.TODO(), config)
+
+	if report.Ready {
+		t.Error("the report should not be ready with an unreachable cluster")
+	}
+	if result := resultOf(report, "storage connectivity"); result.Status != StatusWarning {
+		t.Errorf("storage connectivity = %+v, want warning for the storage without checks", result)
+	}
+	if result := resultOf(report, "hub rbac"); result.Status != StatusPass {
+		t.Errorf("hub rbac = %+v, want pass", result)
+	}
+	if result := resultOf(report, "cluster cluster-1"); result.Status != StatusPass || result.Message != "kubernetes v1.30.0" {
+		t.Errorf("cluster-1 = %+v, want pass", result)
+	}
+	if result := resultOf(report, "cluster cluster-2"); result.Status != StatusFail {
+		t.Errorf("cluster-2 = %+v, want fail", result)
+	}
+}
+
+func TestCheckPermissions(t *testing.T) {
+	config := newTestConfig("secrets")
+	report := newReport("test")
+	checkPermissions(context.TODO(), config, report)
+
+	result := resultOf(report, "hub rbac")
+	if result.Status != StatusFail || !strings.Contains(result.Message, "list clusterpedia-system/secrets") {
+		t.Errorf("hub rbac = %+v, want the denied secrets in the defaulted namespace", result)
+	}
+	if report.Ready {
+		t.Error("the report should not be ready with the missing permissions")
+	}
+}
+
+func TestReportPrint(t *testing.T) {
+	report := newReport("test")
+	report.pass("storage connectivity", "")
+	report.warn("pediaclusters", "no PediaCluster is registered")
+
+	var buf bytes.Buffer
+	if err := report.Print(&buf, "text"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "pediaclusters         Warning  no PediaCluster is registered") ||
+		!strings.HasSuffix(buf.String(), "test is ready\n") {
+		t.Errorf("Print() text =\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := report.Print(&buf, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Ready || len(decoded.Results) != 2 {
+		t.Errorf("Print() json = %s", buf.String())
+	}
+}
@@ -0,0 +1,82 @@
+package preflight
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+type Status string
+
+const (
+	StatusPass    Status = "Pass"
+	StatusWarning Status = "Warning"
+	StatusFail    Status = "Fail"
+)
+
+// Result is the result of a check.
+type Result struct {
+	Check   string `json:"check"`
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Report is the readiness report of a component,
+// the component is ready to roll out if none of the checks fails.
+type Report struct {
+	Component string   `json:"component"`
+	Ready     bool     `json:"ready"`
+	Results   []Result `json:"results"`
+}
+
+func newReport(component string) *Report {
+	return &Report{Component: component, Ready: true}
+}
+
+func (r *Report) pass(check, message string) {
+	r.Results = append(r.Results, Result{Check: check, Status: StatusPass, Message: message})
+}
+
+func (r *Report) warn(check, message string) {
+	r.Results = append(r.Results, Result{Check: check, Status: StatusWarning, Message: message})
+}
+
+func (r *Report) fail(check string, err error) {
+	r.Ready = false
+	r.Results = append(r.Results, Result{Check: check, Status: StatusFail, Message: err.Error()})
+}
+
+// check records the result of the check by the error.
+func (r *Report) check(check string, err error) {
+	if err != nil {
+		r.fail(check, err)
+		return
+	}
+	r.pass(check, "")
+}
+
+// Print prints the report as a table, or as json if the output is `json`.
+func (r *Report) Print(w io.Writer, output string) error {
+	if output == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tSTATUS\tMESSAGE")
+	for _, result := range r.Results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Check, result.Status, result.Message)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if r.Ready {
+		_, err := fmt.Fprintf(w, "\n%s is ready\n", r.Component)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%s is not ready\n", r.Component)
+	return err
+}
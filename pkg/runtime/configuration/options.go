@@ -0,0 +1,20 @@
+package configuration
+
+import (
+	"github.com/spf13/pflag"
+)
+
+type Options struct {
+	// Name is the ClusterpediaConfiguration watched by the component, empty means the configuration is not watched.
+	Name string
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Name, "configuration-name", o.Name, ""+
+		"The name of the ClusterpediaConfiguration whose settings are applied at runtime, "+
+		"the settings override the flags and the feature gates. Empty means the configuration is not watched.")
+}
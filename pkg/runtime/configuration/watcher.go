@@ -0,0 +1,71 @@
+package configuration
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	configinformers "github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions/config/v1alpha1"
+)
+
+// Handler is called with the spec of the configuration when it is changed,
+// the spec is nil when the configuration is deleted. The spec must not be modified.
+type Handler func(spec *configv1alpha1.ClusterpediaConfigurationSpec)
+
+// Watcher watches the ClusterpediaConfiguration by its name and calls the handlers when it is changed,
+// the handlers are added before the informer is started.
+type Watcher struct {
+	name string
+
+	lock     sync.RWMutex
+	handlers []Handler
+}
+
+func NewWatcher(name string, informer configinformers.ClusterpediaConfigurationInformer) *Watcher {
+	watcher := &Watcher{name: name}
+	if _, err := informer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			return err == nil && key == name
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc: watcher.update,
+			UpdateFunc: func(_, newObj interface{}) {
+				watcher.update(newObj)
+			},
+			DeleteFunc: func(_ interface{}) {
+				klog.InfoS("ClusterpediaConfiguration is deleted, the settings fall back to the flags", "name", name)
+				watcher.handle(nil)
+			},
+		},
+	}); err != nil {
+		klog.ErrorS(err, "error when adding event handler to informer")
+	}
+	return watcher
+}
+
+func (w *Watcher) AddHandler(handler Handler) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.handlers = append(w.handlers, handler)
+}
+
+func (w *Watcher) update(obj interface{}) {
+	config, ok := obj.(*configv1alpha1.ClusterpediaConfiguration)
+	if !ok {
+		return
+	}
+
+	klog.InfoS("ClusterpediaConfiguration is changed", "name", w.name, "generation", config.Generation)
+	w.handle(config.Spec.DeepCopy())
+}
+
+func (w *Watcher) handle(spec *configv1alpha1.ClusterpediaConfigurationSpec) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	for _, handler := range w.handlers {
+		handler(spec)
+	}
+}
@@ -29,6 +29,7 @@ type ResourcesInterface interface {
 	GetGroupResourcesAsSyncResources(group string) *clusterv1alpha2.ClusterGroupResources
 
 	AttachAllCustomResourcesToSyncResources(resources []clusterv1alpha2.ClusterGroupResources) []clusterv1alpha2.ClusterGroupResources
+	GetCustomResourceReferences(resource schema.GroupResource) []schema.GroupResource
 }
 
 func (c *DynamicDiscoveryManager) enableMutationHandler() {
@@ -298,6 +299,7 @@ func (c *DynamicDiscoveryManager) updateCustomResource(crd *apiextensionsv1.Cust
 		}
 	}
 	sortVersionByKubeAwareVersion(versions)
+	references := customResourceReferences(crd)
 
 	var updated bool
 	defer func() {
@@ -317,6 +319,10 @@ func (c *DynamicDiscoveryManager) updateCustomResource(crd *apiextensionsv1.Cust
 	if c.updateResourceLocked(groupResource, apiResource, versions) {
 		updated = true
 	}
+	if !references.Equal(c.customResourceReferences[groupResource]) {
+		c.customResourceReferences[groupResource] = references
+		updated = true
+	}
 }
 
 func (c *DynamicDiscoveryManager) removeCustomResource(crd *apiextensionsv1.CustomResourceDefinition) {
@@ -327,6 +333,7 @@ func (c *DynamicDiscoveryManager) removeCustomResource(crd *apiextensionsv1.Cust
 	defer c.cacheLock.Unlock()
 
 	c.removeResourceLocked(groupResource)
+	delete(c.customResourceReferences, groupResource)
 	for gr := range c.resourceVersions {
 		if gr.Group == groupResource.Group {
 			return
@@ -524,6 +531,22 @@ func (c *DynamicDiscoveryManager) AttachAllCustomResourcesToSyncResources(resour
 	return syncResources
 }
 
+// GetCustomResourceReferences returns the sorted resources referenced by the custom resource,
+// the resource can be singular or plural.
+func (c *DynamicDiscoveryManager) GetCustomResourceReferences(resource schema.GroupResource) []schema.GroupResource {
+	c.cacheLock.RLock()
+	defer c.cacheLock.RUnlock()
+
+	resource.Resource = strings.ToLower(resource.Resource)
+	if plural := c.singularToPlural[resource]; !plural.Empty() {
+		resource = plural
+	}
+
+	references := c.customResourceReferences[resource].UnsortedList()
+	sortGroupResources(references)
+	return references
+}
+
 func sortGroupResources(resources []schema.GroupResource) {
 	sort.Slice(resources, func(i, j int) bool {
 		left, right := resources[i], resources[j]
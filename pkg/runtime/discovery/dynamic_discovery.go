@@ -51,6 +51,9 @@ type DynamicDiscoveryManager struct {
 	pluralToSingular map[schema.GroupResource]schema.GroupResource
 	singularToPlural map[schema.GroupResource]schema.GroupResource
 
+	// the resources referenced by the custom resources
+	customResourceReferences map[schema.GroupResource]sets.Set[schema.GroupResource]
+
 	dirty                   atomic.Bool
 	enabledMutationHandler  atomic.Bool
 	resourceMutationHandler func()
@@ -99,6 +102,8 @@ func NewDynamicDiscoveryManager(name string, config *rest.Config) (*DynamicDisco
 		pluralToSingular: make(map[schema.GroupResource]schema.GroupResource),
 		singularToPlural: make(map[schema.GroupResource]schema.GroupResource),
 
+		customResourceReferences: make(map[schema.GroupResource]sets.Set[schema.GroupResource]),
+
 		lastdone: make(chan struct{}),
 	}
 	close(manager.lastdone)
@@ -0,0 +1,80 @@
+package discovery
+
+import (
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ReferencedResourcesAnnotation lists the resources referenced by the custom resource in addition to
+// the known reference fields of the CRD schema, the resources are separated by commas and formatted as
+// `<resource>.<group>`, e.g. `secrets,certificates.cert-manager.io`.
+const ReferencedResourcesAnnotation = "clusterpedia.io/referenced-resources"
+
+// referenceFields are the known names of the fields referencing the kube resources,
+// they are used by the kube resources and followed by most of the custom resources.
+var referenceFields = map[string]schema.GroupResource{
+	"secretref":             {Resource: "secrets"},
+	"secretkeyref":          {Resource: "secrets"},
+	"secretname":            {Resource: "secrets"},
+	"imagepullsecrets":      {Resource: "secrets"},
+	"configmapref":          {Resource: "configmaps"},
+	"configmapkeyref":       {Resource: "configmaps"},
+	"configmapname":         {Resource: "configmaps"},
+	"serviceaccountname":    {Resource: "serviceaccounts"},
+	"serviceaccountref":     {Resource: "serviceaccounts"},
+	"persistentvolumeclaim": {Resource: "persistentvolumeclaims"},
+	"claimname":             {Resource: "persistentvolumeclaims"},
+	"serviceref":            {Resource: "services"},
+	"servicename":           {Resource: "services"},
+}
+
+// customResourceReferences returns the resources referenced by the custom resource, they are found by the known
+// reference fields of the schemas of the served versions and the ReferencedResourcesAnnotation of the CRD.
+func customResourceReferences(crd *apiextensionsv1.CustomResourceDefinition) sets.Set[schema.GroupResource] {
+	references := sets.New[schema.GroupResource]()
+	for _, version := range crd.Spec.Versions {
+		if version.Served && version.Schema != nil {
+			collectSchemaReferences(version.Schema.OpenAPIV3Schema, references)
+		}
+	}
+
+	for _, resource := range strings.Split(crd.Annotations[ReferencedResourcesAnnotation], ",") {
+		if resource = strings.TrimSpace(resource); resource != "" {
+			references.Insert(schema.ParseGroupResource(resource))
+		}
+	}
+
+	// the custom resource doesn't reference itself
+	references.Delete(schema.GroupResource{Group: crd.Spec.Group, Resource: crd.Status.AcceptedNames.Plural})
+	return references
+}
+
+func collectSchemaReferences(props *apiextensionsv1.JSONSchemaProps, references sets.Set[schema.GroupResource]) {
+	if props == nil {
+		return
+	}
+
+	for name, property := range props.Properties {
+		if gr, ok := referenceFields[strings.ToLower(name)]; ok {
+			references.Insert(gr)
+		}
+		collectSchemaReferences(&property, references)
+	}
+	if props.Items != nil {
+		collectSchemaReferences(props.Items.Schema, references)
+		for i := range props.Items.JSONSchemas {
+			collectSchemaReferences(&props.Items.JSONSchemas[i], references)
+		}
+	}
+	if props.AdditionalProperties != nil {
+		collectSchemaReferences(props.AdditionalProperties.Schema, references)
+	}
+	for _, schemas := range [][]apiextensionsv1.JSONSchemaProps{props.AllOf, props.OneOf, props.AnyOf} {
+		for i := range schemas {
+			collectSchemaReferences(&schemas[i], references)
+		}
+	}
+}
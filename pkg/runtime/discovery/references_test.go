@@ -0,0 +1,81 @@
+package discovery
+
+import (
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCustomResourceReferences(t *testing.T) {
+	object := func(properties map[string]apiextensionsv1.JSONSchemaProps) apiextensionsv1.JSONSchemaProps {
+		return apiextensionsv1.JSONSchemaProps{Type: "object", Properties: properties}
+	}
+	spec := object(map[string]apiextensionsv1.JSONSchemaProps{
+		"secretRef": object(map[string]apiextensionsv1.JSONSchemaProps{"name": {Type: "string"}}),
+		"sources": {
+			Type: "array",
+			Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+				Schema: &apiextensionsv1.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"configMapKeyRef": object(nil),
+					},
+				},
+			},
+		},
+		"name": {Type: "string"},
+	})
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				ReferencedResourcesAnnotation: "certificates.cert-manager.io, issuers.cert-manager.io,,foos.example.io",
+			},
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.io",
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:   "v1",
+					Served: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type:       "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{"spec": spec},
+						},
+					},
+				},
+				{
+					Name:   "v1alpha1",
+					Served: false,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"serviceAccountName": {Type: "string"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			AcceptedNames: apiextensionsv1.CustomResourceDefinitionNames{Plural: "foos"},
+		},
+	}
+
+	references := customResourceReferences(crd).UnsortedList()
+	sortGroupResources(references)
+	expected := []schema.GroupResource{
+		{Resource: "configmaps"},
+		{Resource: "secrets"},
+		{Group: "cert-manager.io", Resource: "certificates"},
+		{Group: "cert-manager.io", Resource: "issuers"},
+	}
+	if !reflect.DeepEqual(references, expected) {
+		t.Errorf("expected references %v, but got %v", expected, references)
+	}
+}
@@ -58,6 +58,13 @@ type Config struct {
 	// even if paging is specified APIServer will return all resources for performance,
 	// then it will skip Reflector's streaming memory optimization.
 	ForcePaginatedList bool
+
+	// ResumeResourceVersion resumes the first watch from the resource version instead of the list,
+	// the Queue must implement the ResumableStore.
+	ResumeResourceVersion string
+
+	// RelistHandler is notified after the resource is relisted
+	RelistHandler RelistHandler
 }
 
 type controller struct {
@@ -96,6 +103,8 @@ func (c *controller) Run(stopCh <-chan struct{}) {
 	r.WatchListPageSize = c.config.WatchListPageSize
 	r.ForcePaginatedList = c.config.ForcePaginatedList
 	r.StreamHandleForPaginatedList = c.config.StreamHandleForPaginatedList
+	r.resumeResourceVersion = c.config.ResumeResourceVersion
+	r.relistHandler = c.config.RelistHandler
 
 	c.reflectorMutex.Lock()
 	c.reflector = r
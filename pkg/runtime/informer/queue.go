@@ -46,3 +46,28 @@ func (queue *queueWithExtraStore) Replace(list []interface{}, rv string) error {
 	_ = queue.extra.Replace(list, rv)
 	return queue.Queue.Replace(list, rv)
 }
+
+// restoreDetectingQueue detects the restore of the cluster before the list is replaced into the queue,
+// so the replaced objects are compared with the reset versions.
+type restoreDetectingQueue struct {
+	cache.Queue
+	informer *resourceVersionInformer
+}
+
+var _ cache.Queue = &restoreDetectingQueue{}
+
+func (queue *restoreDetectingQueue) Replace(list []interface{}, resourceVersion string) error {
+	queue.informer.detectRestore(resourceVersion)
+	return queue.Queue.Replace(list, resourceVersion)
+}
+
+// Resume replaces the queue with the keys of the storage without the restore detection,
+// the resource version of the resumed watch is older than the stored resource versions of the updated objects.
+func (queue *restoreDetectingQueue) Resume(resourceVersion string) error {
+	keys := queue.informer.storage.ListKeys()
+	list := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, cache.ExplicitKey(key))
+	}
+	return queue.Queue.Replace(list, resourceVersion)
+}
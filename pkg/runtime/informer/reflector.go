@@ -76,6 +76,9 @@ type Reflector struct {
 	// isLastSyncResourceVersionUnavailable is true if the previous list or watch request with
 	// lastSyncResourceVersion failed with an "expired" or "too large resource version" error.
 	isLastSyncResourceVersionUnavailable bool
+	// isWatchExpired is true if the last watch failed with the "too old resource version" error,
+	// the relist recovers the resource from lastSyncResourceVersion before it falls back to the full relist.
+	isWatchExpired bool
 	// lastSyncResourceVersionMutex guards read/write access to lastSyncResourceVersion
 	lastSyncResourceVersionMutex sync.RWMutex
 	// WatchListPageSize is the requested chunk size of initial and resync watch lists.
@@ -100,8 +103,19 @@ type Reflector struct {
 
 	// Whether the initialization of the List and the replacing of the store has been completed.
 	hasInitializedSynced atomic.Bool
+
+	// resumeResourceVersion resumes the first watch from the resource version instead of the list
+	resumeResourceVersion string
+
+	// relistHandler is notified after the resource is relisted
+	relistHandler RelistHandler
 }
 
+// RelistHandler is notified after the resource is relisted by the reflector, the initial list is not notified.
+// full is true if the resource is relisted from the latest resource version by a consistent read,
+// otherwise the resource is listed not older than the last synced resource version, e.g. the expired watch is recovered.
+type RelistHandler func(full bool)
+
 // ResourceVersionUpdater is an interface that allows store implementation to
 // track the current resource version of the reflector. This is especially
 // important if storage bookmarks are enabled.
@@ -111,6 +125,12 @@ type ResourceVersionUpdater interface {
 	UpdateResourceVersion(resourceVersion string)
 }
 
+// ResumableStore is a store which can be treated as synced at the resource version
+// with its known objects, so the watch is resumed from the resource version without the list.
+type ResumableStore interface {
+	Resume(resourceVersion string) error
+}
+
 // The WatchErrorHandler is called whenever ListAndWatch drops the
 // connection with an error. After calling this handler, the informer
 // will backoff and retry.
@@ -262,7 +282,18 @@ func (r *Reflector) HasInitializedSynced() bool {
 func (r *Reflector) ListAndWatch(stopCh <-chan struct{}) error {
 	klog.V(3).Infof("Listing and watching %v from %s", r.expectedTypeName, r.name)
 
-	err := r.list(stopCh)
+	var err error
+	if store, ok := r.store.(ResumableStore); ok && r.resumeResourceVersion != "" {
+		// the watch is resumed only once, if the resource version is expired, the resource is listed by the next ListAndWatch
+		resourceVersion := r.resumeResourceVersion
+		r.resumeResourceVersion = ""
+		klog.V(2).Infof("%s: resume watching %v from the resource version %s", r.name, r.expectedTypeName, resourceVersion)
+		if err = store.Resume(resourceVersion); err == nil {
+			r.setLastSyncResourceVersion(resourceVersion)
+		}
+	} else {
+		err = r.list(stopCh)
+	}
 	if err != nil {
 		return err
 	}
@@ -321,6 +352,9 @@ func (r *Reflector) ListAndWatch(stopCh <-chan struct{}) error {
 		start := r.clock.Now()
 		w, err := r.listerWatcher.Watch(options)
 		if err != nil {
+			if isExpiredError(err) {
+				r.setIsWatchExpired(true)
+			}
 			// If this is "connection refused" error, it means that most likely apiserver is not responsive.
 			// It doesn't make sense to re-list all objects because most likely we will be able to restart
 			// watch where we ended.
@@ -346,6 +380,7 @@ func (r *Reflector) ListAndWatch(stopCh <-chan struct{}) error {
 					// has a semantic that it returns data at least as fresh as provided RV.
 					// So first try to LIST with setting RV to resource version of last observed object.
 					klog.V(4).Infof("%s: watch of %v closed with: %v", r.name, r.expectedTypeName, err)
+					r.setIsWatchExpired(true)
 				case apierrors.IsTooManyRequests(err):
 					klog.V(2).Infof("%s: watch of %v returned 429 - backing off", r.name, r.expectedTypeName)
 					<-r.initConnBackoffManager.Backoff().C()
@@ -366,7 +401,18 @@ func (r *Reflector) ListAndWatch(stopCh <-chan struct{}) error {
 // the resource version can be used for further progress notification (aka. watch).
 func (r *Reflector) list(stopCh <-chan struct{}) error {
 	var resourceVersion string
+	relist := r.LastSyncResourceVersion() != ""
 	options := metav1.ListOptions{ResourceVersion: r.relistResourceVersion()}
+	full := options.ResourceVersion == ""
+
+	// The expired watch is recovered by the list not older than the last synced resource version,
+	// which is served by the watch cache of the member cluster without the pagination,
+	// and doesn't fail with the "too old resource version" error as the paginated list of the exact resource version.
+	// The listed objects are compared with the stored versions, so only the changed objects are handled.
+	recovering := relist && !full && r.IsWatchExpired()
+	if recovering {
+		options.ResourceVersionMatch = metav1.ResourceVersionMatchNotOlderThan
+	}
 
 	initTrace := trace.New("Reflector ListAndWatch", trace.Field{Key: "name", Value: r.name})
 	defer initTrace.LogIfLong(10 * time.Second)
@@ -384,10 +430,15 @@ func (r *Reflector) list(stopCh <-chan struct{}) error {
 		}()
 		// Attempt to gather list in chunks, if supported by listerWatcher, if not, the first
 		// list request will return the full response.
-		pager := clspager.New(clspager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
-			return r.listerWatcher.List(opts)
-		}))
+		newPager := func() *clspager.ListPager {
+			return clspager.New(clspager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+				return r.listerWatcher.List(opts)
+			}))
+		}
+		pager := newPager()
 		switch {
+		case recovering && !r.ForcePaginatedList:
+			pager.PageSize = 0
 		case r.WatchListPageSize != 0:
 			pager.PageSize = r.WatchListPageSize
 		case r.paginatedResult:
@@ -426,6 +477,15 @@ func (r *Reflector) list(stopCh <-chan struct{}) error {
 			// the reflector makes forward progress.
 
 			options := metav1.ListOptions{ResourceVersion: r.relistResourceVersion()}
+			if recovering {
+				klog.V(2).Infof("%s: failed to recover %v from the resource version %s, fall back to the full relist: %v",
+					r.name, r.expectedTypeName, r.LastSyncResourceVersion(), err)
+				// the unpaginated recovery list falls back to the paginated full relist
+				if pager = newPager(); r.WatchListPageSize != 0 {
+					pager.PageSize = r.WatchListPageSize
+				}
+			}
+			full = true
 			if r.StreamHandleForPaginatedList {
 				list, itemKeys, paginatedResult, err = r.listWithResultStream(context.Background(), pager, options)
 			} else {
@@ -491,7 +551,12 @@ func (r *Reflector) list(stopCh <-chan struct{}) error {
 
 	initTrace.Step("SyncWith done")
 	r.setLastSyncResourceVersion(resourceVersion)
+	r.setIsWatchExpired(false)
 	initTrace.Step("Resource version updated")
+
+	if relist && r.relistHandler != nil {
+		r.relistHandler(full)
+	}
 	return nil
 }
 
@@ -668,6 +733,19 @@ func (r *Reflector) setIsLastSyncResourceVersionUnavailable(isUnavailable bool)
 	r.isLastSyncResourceVersionUnavailable = isUnavailable
 }
 
+// IsWatchExpired returns true if the last watch failed with the "too old resource version" error and the resource is not relisted yet.
+func (r *Reflector) IsWatchExpired() bool {
+	r.lastSyncResourceVersionMutex.RLock()
+	defer r.lastSyncResourceVersionMutex.RUnlock()
+	return r.isWatchExpired
+}
+
+func (r *Reflector) setIsWatchExpired(expired bool) {
+	r.lastSyncResourceVersionMutex.Lock()
+	defer r.lastSyncResourceVersionMutex.Unlock()
+	r.isWatchExpired = expired
+}
+
 func isExpiredError(err error) bool {
 	// In Kubernetes 1.17 and earlier, the api server returns both apierrors.StatusReasonExpired and
 	// apierrors.StatusReasonGone for HTTP 410 (Gone) status code responses. In 1.18 the kube server is more consistent
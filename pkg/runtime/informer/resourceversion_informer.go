@@ -4,11 +4,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/storage"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
 )
 
 type ResourceVersionInformer interface {
 	Run(stopCh <-chan struct{})
 	HasSynced() bool
+
+	// HandledResourceVersion returns the resource version whose events have been handled by the handler,
+	// it is empty if any event is still queued in the informer.
+	HandledResourceVersion() string
 }
 
 type resourceVersionInformer struct {
@@ -16,9 +21,16 @@ type resourceVersionInformer struct {
 	storage       *ResourceVersionStorage
 	handler       ResourceEventHandler
 	controller    cache.Controller
+	queue         cache.Queue
 	listerWatcher cache.ListerWatcher
+
+	restoreHandler RestoreHandler
 }
 
+// RestoreHandler is called when the resource version of the list is older than the stored resource versions,
+// it means that the etcd of the cluster may be restored from a backup.
+type RestoreHandler func(listResourceVersion string, storedResourceVersion uint64)
+
 type InformerConfig struct {
 	cache.ListerWatcher
 	Storage *ResourceVersionStorage
@@ -28,6 +40,17 @@ type InformerConfig struct {
 	ErrorHandler  WatchErrorHandler
 	ExtraStore    ExtraStore
 
+	// RestoreHandler is notified after the storage is reset for the full re-sync
+	RestoreHandler RestoreHandler
+
+	// ResumeResourceVersion resumes the watch from the resource version instead of the initial list,
+	// the objects of the storage are treated as synced at the resource version.
+	// It is ignored with the ExtraStore, which is built from the listed objects.
+	ResumeResourceVersion string
+
+	// RelistHandler is notified after the resource is relisted, e.g. the expired watch is recovered
+	RelistHandler RelistHandler
+
 	WatchListPageSize            int64
 	ForcePaginatedList           bool
 	StreamHandleForPaginatedList bool
@@ -44,6 +67,8 @@ func NewResourceVersionInformer(name string, config InformerConfig) ResourceVers
 		listerWatcher: config.ListerWatcher,
 		storage:       config.Storage,
 		handler:       config.Handler,
+
+		restoreHandler: config.RestoreHandler,
 	}
 
 	var queue cache.Queue = cache.NewDeltaFIFOWithOptions(cache.DeltaFIFOOptions{
@@ -51,9 +76,13 @@ func NewResourceVersionInformer(name string, config InformerConfig) ResourceVers
 		KnownObjects:          informer.storage,
 		EmitDeltaTypeReplaced: true,
 	})
+	resumeResourceVersion := config.ResumeResourceVersion
 	if config.ExtraStore != nil {
 		queue = &queueWithExtraStore{Queue: queue, extra: config.ExtraStore}
+		resumeResourceVersion = ""
 	}
+	queue = &restoreDetectingQueue{Queue: queue, informer: informer}
+	informer.queue = queue
 
 	informer.controller = NewNamedController(informer.name,
 		&Config{
@@ -69,6 +98,8 @@ func NewResourceVersionInformer(name string, config InformerConfig) ResourceVers
 			WatchListPageSize:            config.WatchListPageSize,
 			ForcePaginatedList:           config.ForcePaginatedList,
 			StreamHandleForPaginatedList: config.StreamHandleForPaginatedList,
+			ResumeResourceVersion:        resumeResourceVersion,
+			RelistHandler:                config.RelistHandler,
 		},
 	)
 	return informer
@@ -82,6 +113,16 @@ func (informer *resourceVersionInformer) Run(stopCh <-chan struct{}) {
 	informer.controller.Run(stopCh)
 }
 
+func (informer *resourceVersionInformer) HandledResourceVersion() string {
+	// the resource version is set after the events are queued, so it is read before the queue is checked,
+	// and the queue is locked while the popped deltas are handled.
+	resourceVersion := informer.controller.LastSyncResourceVersion()
+	if len(informer.queue.ListKeys()) != 0 {
+		return ""
+	}
+	return resourceVersion
+}
+
 func (informer *resourceVersionInformer) HandleDeltas(deltas cache.Deltas, isInInitialList bool) error {
 	for _, d := range deltas {
 		switch d.Type {
@@ -127,6 +168,30 @@ func (informer *resourceVersionInformer) HandleDeltas(deltas cache.Deltas, isInI
 	return nil
 }
 
+// detectRestore compares the resource version of the list with the stored resource versions.
+//
+// The resource version of the cluster goes backwards when the etcd is restored from a backup,
+// the stored versions are no longer comparable with the versions of the cluster,
+// so they are reset and all listed objects are rewritten, the objects missing from the list are deleted by the replace.
+func (informer *resourceVersionInformer) detectRestore(listResourceVersion string) {
+	version, err := versioner.ParseResourceVersion(listResourceVersion)
+	if err != nil || version == 0 {
+		return
+	}
+
+	stored := informer.storage.MaxResourceVersion()
+	if version >= stored {
+		return
+	}
+
+	klog.InfoS("The resource version of the list is older than the stored resource versions, the cluster may be restored, reset the stored versions for the full re-sync",
+		"name", informer.name, "listResourceVersion", listResourceVersion, "storedResourceVersion", stored)
+	informer.storage.ResetVersions()
+	if informer.restoreHandler != nil {
+		informer.restoreHandler(listResourceVersion, stored)
+	}
+}
+
 var versioner storage.Versioner = storage.APIObjectVersioner{}
 
 func compareResourceVersion(obj interface{}, rv string) int {
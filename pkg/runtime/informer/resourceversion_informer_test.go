@@ -0,0 +1,281 @@
+package informer
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+type recordingHandler struct {
+	added, updated, deleted, synced []string
+}
+
+func (h *recordingHandler) OnAdd(obj interface{}, _ bool) {
+	h.added = append(h.added, obj.(*corev1.Pod).Name)
+}
+
+func (h *recordingHandler) OnUpdate(_, obj interface{}, _ bool) {
+	h.updated = append(h.updated, obj.(*corev1.Pod).Name)
+}
+
+func (h *recordingHandler) OnDelete(obj interface{}, _ bool) {
+	// the tombstone of the pod missing from the list holds the key
+	key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	h.deleted = append(h.deleted, key)
+}
+
+func (h *recordingHandler) OnSync(obj interface{}) {
+	h.synced = append(h.synced, obj.(*corev1.Pod).Name)
+}
+
+func newPod(name, resourceVersion string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, ResourceVersion: resourceVersion}}
+}
+
+func TestDetectRestore(t *testing.T) {
+	storage := NewResourceVersionStorage()
+	for _, pod := range []*corev1.Pod{newPod("a", "100"), newPod("b", "200"), newPod("c", "150")} {
+		if err := storage.Add(pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := &recordingHandler{}
+	var restored uint64
+	informer := &resourceVersionInformer{
+		name:    "test",
+		storage: storage,
+		handler: handler,
+		restoreHandler: func(_ string, stored uint64) {
+			restored = stored
+		},
+	}
+	queue := &restoreDetectingQueue{
+		Queue: cache.NewDeltaFIFOWithOptions(cache.DeltaFIFOOptions{
+			KeyFunction:           cache.DeletionHandlingMetaNamespaceKeyFunc,
+			KnownObjects:          storage,
+			EmitDeltaTypeReplaced: true,
+		}),
+		informer: informer,
+	}
+
+	// the list is not older than the stored versions
+	if err := queue.Replace([]interface{}{newPod("a", "100"), newPod("b", "200"), newPod("c", "150")}, "200"); err != nil {
+		t.Fatal(err)
+	}
+	drain(t, queue, informer)
+	if restored != 0 || len(handler.synced) != 3 {
+		t.Fatalf("restored = %d, synced = %v, want no restore is detected", restored, handler.synced)
+	}
+
+	// the etcd is restored, the pod a is rolled back with the older version and the pod c is missing
+	handler = &recordingHandler{}
+	informer.handler = handler
+	if err := queue.Replace([]interface{}{newPod("a", "40"), newPod("b", "50")}, "60"); err != nil {
+		t.Fatal(err)
+	}
+	drain(t, queue, informer)
+	if restored != 200 {
+		t.Fatalf("restored = %d, want the restore is detected with the stored version 200", restored)
+	}
+	if len(handler.updated) != 2 || len(handler.deleted) != 1 || handler.deleted[0] != "default/c" || len(handler.synced) != 0 {
+		t.Fatalf("updated = %v, deleted = %v, synced = %v, want all listed pods are rewritten and the pod c is deleted",
+			handler.updated, handler.deleted, handler.synced)
+	}
+	if version, _, _ := storage.Get(newPod("a", "")); version != "40" {
+		t.Fatalf("the version of the pod a is %q, want 40", version)
+	}
+}
+
+func drain(t *testing.T, queue cache.Queue, informer *resourceVersionInformer) {
+	t.Helper()
+	for len(queue.ListKeys()) != 0 {
+		if _, err := queue.Pop(func(obj interface{}, isInInitialList bool) error {
+			return informer.HandleDeltas(obj.(cache.Deltas), isInInitialList)
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+type lockedHandler struct {
+	lock sync.Mutex
+	recordingHandler
+}
+
+func (h *lockedHandler) OnAdd(obj interface{}, isInInitialList bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.recordingHandler.OnAdd(obj, isInInitialList)
+}
+
+func (h *lockedHandler) OnUpdate(old, obj interface{}, isInInitialList bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.recordingHandler.OnUpdate(old, obj, isInInitialList)
+}
+
+func (h *lockedHandler) OnDelete(obj interface{}, isInInitialList bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.recordingHandler.OnDelete(obj, isInInitialList)
+}
+
+func (h *lockedHandler) OnSync(obj interface{}) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.recordingHandler.OnSync(obj)
+}
+
+func TestResumeWatch(t *testing.T) {
+	storage := NewResourceVersionStorage()
+	for _, pod := range []*corev1.Pod{newPod("a", "100"), newPod("b", "200")} {
+		if err := storage.Add(pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var lists atomic.Int32
+	watchResourceVersions := make(chan string, 1)
+	watcher := watch.NewFake()
+	handler := &lockedHandler{}
+	informer := NewResourceVersionInformer("test", InformerConfig{
+		ListerWatcher: &cache.ListWatch{
+			ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+				lists.Add(1)
+				return &corev1.PodList{ListMeta: metav1.ListMeta{ResourceVersion: "300"}}, nil
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				watchResourceVersions <- options.ResourceVersion
+				return watcher, nil
+			},
+		},
+		Storage:               storage,
+		ExampleObject:         &corev1.Pod{},
+		Handler:               handler,
+		ResumeResourceVersion: "250",
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go informer.Run(stopCh)
+
+	if rv := <-watchResourceVersions; rv != "250" {
+		t.Fatalf("the watch is started from %q, want it is resumed from the bookmark 250", rv)
+	}
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		t.Fatal("the resumed informer is not synced")
+	}
+
+	watcher.Modify(newPod("a", "260"))
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return informer.HandledResourceVersion() == "260", nil
+	}); err != nil {
+		t.Fatalf("the handled resource version is %q, want 260", informer.HandledResourceVersion())
+	}
+
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	if lists.Load() != 0 {
+		t.Fatalf("the resource is listed %d times, want the list is skipped", lists.Load())
+	}
+	if len(handler.updated) != 1 || handler.updated[0] != "a" || len(handler.deleted) != 0 || len(handler.added) != 0 {
+		t.Fatalf("added = %v, updated = %v, deleted = %v, want only the pod a is updated, the stored pods are kept",
+			handler.added, handler.updated, handler.deleted)
+	}
+}
+
+func TestRecoverExpiredWatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		recovered  bool
+		wantLists  []metav1.ListOptions
+		wantRelist bool
+	}{
+		{
+			name:      "recovered from the last synced resource version",
+			recovered: true,
+			wantLists: []metav1.ListOptions{
+				{ResourceVersion: "0", Limit: 500},
+				{ResourceVersion: "100", ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan},
+			},
+		},
+		{
+			name: "fall back to the full relist",
+			wantLists: []metav1.ListOptions{
+				{ResourceVersion: "0", Limit: 500},
+				{ResourceVersion: "100", ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan},
+				{ResourceVersion: "", Limit: 500},
+			},
+			wantRelist: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lock sync.Mutex
+			var lists []metav1.ListOptions
+			watches := make(chan *watch.FakeWatcher, 2)
+			relists := make(chan bool, 1)
+			informer := NewResourceVersionInformer("test", InformerConfig{
+				ListerWatcher: &cache.ListWatch{
+					ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+						lock.Lock()
+						defer lock.Unlock()
+						lists = append(lists, options)
+						switch len(lists) {
+						case 1:
+							return &corev1.PodList{ListMeta: metav1.ListMeta{ResourceVersion: "100"}}, nil
+						case 2:
+							if !tt.recovered {
+								return nil, apierrors.NewResourceExpired("too old resource version: 100 (150)")
+							}
+						}
+						return &corev1.PodList{ListMeta: metav1.ListMeta{ResourceVersion: "200"}}, nil
+					},
+					WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+						watcher := watch.NewFake()
+						watches <- watcher
+						return watcher, nil
+					},
+				},
+				Storage:       NewResourceVersionStorage(),
+				ExampleObject: &corev1.Pod{},
+				Handler:       &lockedHandler{},
+				RelistHandler: func(full bool) { relists <- full },
+			})
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			go informer.Run(stopCh)
+
+			watcher := <-watches
+			watcher.Error(&apierrors.NewResourceExpired("too old resource version: 100 (150)").ErrStatus)
+
+			select {
+			case full := <-relists:
+				if full != tt.wantRelist {
+					t.Fatalf("the relist is notified with full = %v, want %v", full, tt.wantRelist)
+				}
+			case <-time.After(wait.ForeverTestTimeout):
+				t.Fatal("the expired watch is not relisted")
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			if !reflect.DeepEqual(lists, tt.wantLists) {
+				t.Fatalf("lists = %+v, want %+v", lists, tt.wantLists)
+			}
+		})
+	}
+}
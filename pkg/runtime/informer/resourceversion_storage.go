@@ -83,3 +83,37 @@ func (c *ResourceVersionStorage) Replace(versions map[string]interface{}) error
 	c.cacheStorage.Replace(versions, "")
 	return nil
 }
+
+// MaxResourceVersion returns the largest resource version in the storage,
+// the versions that cannot be parsed are ignored.
+func (c *ResourceVersionStorage) MaxResourceVersion() uint64 {
+	var max uint64
+	for _, key := range c.cacheStorage.ListKeys() {
+		version, exists := c.cacheStorage.Get(key)
+		if !exists {
+			continue
+		}
+		if v, err := versioner.ParseResourceVersion(version.(string)); err == nil && v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// ResetVersions clears the resource versions of all keys,
+// so that the objects are treated as outdated and updated on the next list.
+func (c *ResourceVersionStorage) ResetVersions() {
+	for _, key := range c.cacheStorage.ListKeys() {
+		c.cacheStorage.Update(key, "")
+	}
+}
+
+// UpdateVersion sets the resource version of the key, the key is added if it doesn't exist.
+func (c *ResourceVersionStorage) UpdateVersion(key, version string) {
+	c.cacheStorage.Update(key, version)
+}
+
+// DeleteKey deletes the key, so that the object is added on the next list.
+func (c *ResourceVersionStorage) DeleteKey(key string) {
+	c.cacheStorage.Delete(key)
+}
@@ -137,6 +137,11 @@ func (g *ResourceConfigFactory) NewLegacyResourceConfig(gr schema.GroupResource,
 	if err != nil {
 		return nil, err
 	}
+	codecConfig.StorageMediaType = runtime.ContentTypeProtobuf
+	protobufCodec, _, err := serverstorage.NewStorageCodec(codecConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	return &resourceconfig.ResourceConfig{
 		Namespaced:    namespaced,
@@ -145,5 +150,6 @@ func (g *ResourceConfigFactory) NewLegacyResourceConfig(gr schema.GroupResource,
 		StorageResource: chosenStorageResource.WithVersion(storageVersion.Version),
 		MemoryResource:  gr.WithVersion(memoryVersion.Version),
 		Codec:           codec,
+		ProtobufCodec:   protobufCodec,
 	}, nil
 }
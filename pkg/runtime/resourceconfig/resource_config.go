@@ -13,4 +13,8 @@ type ResourceConfig struct {
 	MemoryResource  schema.GroupVersionResource
 
 	Codec runtime.Codec
+
+	// ProtobufCodec encodes the objects in protobuf for the storages which store the objects in protobuf,
+	// it is nil for the custom resources which can only be encoded in json.
+	ProtobufCodec runtime.Codec
 }
@@ -0,0 +1,152 @@
+package archivestorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/objectstore"
+)
+
+const (
+	archivesDir   = "archives"
+	archiveSuffix = ".json"
+
+	// emptySegment is the key segment of the core group and the cluster-scoped objects,
+	// which is not a valid group or namespace.
+	emptySegment = "-"
+)
+
+// archiver writes the archives into the object storage by the keys of
+// `<prefix>/archives/<cluster>/<group>/<version>/<resource>/<namespace>/<name>/<archived unix nano>.json`,
+// the ID of an archive is the `<namespace>/<name>/<archived unix nano>` part of the key.
+type archiver struct {
+	store  objectstore.Store
+	prefix string
+	now    func() time.Time
+}
+
+type archivedObject struct {
+	Reason     storage.ArchiveReason `json:"reason"`
+	ArchivedAt time.Time             `json:"archivedAt"`
+	Object     json.RawMessage       `json:"object"`
+}
+
+func segment(s string) string {
+	if s == "" {
+		return emptySegment
+	}
+	return s
+}
+
+func (a *archiver) resourcePrefix(cluster string, gvr schema.GroupVersionResource) string {
+	return path.Join(a.prefix, archivesDir, cluster, segment(gvr.Group), gvr.Version, gvr.Resource) + "/"
+}
+
+func (a *archiver) archive(ctx context.Context, cluster string, gvr schema.GroupVersionResource, namespace, name string, reason storage.ArchiveReason, object []byte) error {
+	archivedAt := a.now().UTC()
+	data, err := json.Marshal(archivedObject{Reason: reason, ArchivedAt: archivedAt, Object: object})
+	if err != nil {
+		return err
+	}
+
+	// the zero padded timestamp keeps the keys of an object sorted by the archived time
+	id := path.Join(segment(namespace), name, fmt.Sprintf("%019d", archivedAt.UnixNano()))
+	return a.store.Put(ctx, a.resourcePrefix(cluster, gvr)+id+archiveSuffix, data)
+}
+
+func (a *archiver) list(ctx context.Context, cluster string, gvr schema.GroupVersionResource, opts storage.ArchiveOptions) ([]storage.Archive, error) {
+	prefix := a.resourcePrefix(cluster, gvr)
+	listPrefix := prefix
+	if opts.Namespace != "" {
+		listPrefix += opts.Namespace + "/"
+		if opts.Name != "" {
+			listPrefix += opts.Name + "/"
+		}
+	}
+	keys, err := a.store.List(ctx, listPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var archives []storage.Archive
+	for _, key := range keys {
+		id := strings.TrimSuffix(strings.TrimPrefix(key, prefix), archiveSuffix)
+		namespace, name, archivedAt, err := parseID(id)
+		if err != nil {
+			continue
+		}
+		if opts.Name != "" && name != opts.Name {
+			continue
+		}
+		if !opts.ArchivedAfter.IsZero() && !archivedAt.After(opts.ArchivedAfter) {
+			continue
+		}
+		if !opts.ArchivedBefore.IsZero() && !archivedAt.Before(opts.ArchivedBefore) {
+			continue
+		}
+
+		archived, err := a.get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if archived == nil {
+			continue
+		}
+		archives = append(archives, storage.Archive{
+			ID:         id,
+			Namespace:  namespace,
+			Name:       name,
+			Reason:     archived.Reason,
+			ArchivedAt: archived.ArchivedAt,
+			Object:     archived.Object,
+		})
+	}
+
+	sort.SliceStable(archives, func(i, j int) bool {
+		return archives[i].ArchivedAt.After(archives[j].ArchivedAt)
+	})
+	return archives, nil
+}
+
+// getByID returns nil if the archive is not found, the id should be validated by parseID.
+func (a *archiver) getByID(ctx context.Context, cluster string, gvr schema.GroupVersionResource, id string) (*archivedObject, error) {
+	return a.get(ctx, a.resourcePrefix(cluster, gvr)+id+archiveSuffix)
+}
+
+func (a *archiver) get(ctx context.Context, key string) (*archivedObject, error) {
+	data, err := a.store.Get(ctx, key)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	archived := &archivedObject{}
+	if err := json.Unmarshal(data, archived); err != nil {
+		return nil, fmt.Errorf("invalid archive %s: %w", key, err)
+	}
+	return archived, nil
+}
+
+func parseID(id string) (namespace, name string, archivedAt time.Time, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[0] == ".." || parts[1] == ".." {
+		return "", "", time.Time{}, fmt.Errorf("invalid archive id %q", id)
+	}
+	nano, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("invalid archive id %q", id)
+	}
+
+	if parts[0] != emptySegment {
+		namespace = parts[0]
+	}
+	return namespace, parts[1], time.Unix(0, nano).UTC(), nil
+}
@@ -0,0 +1,56 @@
+package archivestorage
+
+import (
+	"fmt"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/objectstore"
+)
+
+const defaultPrefix = "clusterpedia"
+
+// Config is the config of the archive storage, which archives the objects deleted from the backend storage
+// to the S3 compatible object storage, including the objects purged by the retention policies.
+//
+// The objects are archived by the writes through the archive storage, so the clustersynchro manager should use
+// the archive storage, and the apiserver should use it to browse and restore the archived objects.
+type Config struct {
+	Backend BackendConfig `yaml:"backend"`
+
+	// S3 is the S3 compatible object storage of the archives, the credentials are loaded from
+	// the environment variables of AWS or MinIO, or from the IAM role of the instance.
+	S3 objectstore.S3Config `yaml:"s3"`
+
+	// Prefix is the key prefix of the archives in the bucket, default is `clusterpedia`.
+	Prefix string `yaml:"prefix"`
+}
+
+type BackendConfig struct {
+	// Name is the name of the registered storage, such as internal.
+	Name string `yaml:"name"`
+
+	// ConfigPath is the path of the config of the storage.
+	ConfigPath string `yaml:"configPath"`
+}
+
+func (cfg *Config) validate() error {
+	if cfg.Backend.Name == "" {
+		return fmt.Errorf("the backend storage is required")
+	}
+	if cfg.Backend.Name == StorageName {
+		return fmt.Errorf("the %s storage can not be nested", StorageName)
+	}
+	if cfg.S3.Endpoint == "" {
+		return fmt.Errorf("the endpoint of the object storage is required")
+	}
+	if cfg.S3.Bucket == "" {
+		return fmt.Errorf("the bucket of the object storage is required")
+	}
+	return nil
+}
+
+func (cfg *Config) prefix() string {
+	if cfg.Prefix == "" {
+		return defaultPrefix
+	}
+	return cfg.Prefix
+}
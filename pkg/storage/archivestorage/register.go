@@ -0,0 +1,52 @@
+package archivestorage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/configor"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/objectstore"
+)
+
+const (
+	StorageName = "archive"
+)
+
+func init() {
+	storage.RegisterStorageFactoryFunc(StorageName, NewStorageFactory)
+}
+
+func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
+	if configPath == "" {
+		return nil, fmt.Errorf("configPath should not be empty")
+	}
+
+	cfg := &Config{}
+	if err := configor.Load(cfg, configPath); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	store, err := objectstore.NewS3Store(cfg.S3)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := storage.NewStorageFactory(cfg.Backend.Name, cfg.Backend.ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("backend storage: %w", err)
+	}
+	return newStorageFactory(cfg, backend, store), nil
+}
+
+func newStorageFactory(cfg *Config, backend storage.StorageFactory, store objectstore.Store) *StorageFactory {
+	return &StorageFactory{
+		backend:  backend,
+		archiver: &archiver{store: store, prefix: cfg.prefix(), now: time.Now},
+		storages: make(map[schema.GroupVersionResource]*ResourceStorage),
+	}
+}
@@ -0,0 +1,291 @@
+package archivestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+)
+
+// archiveListLimit is the page size of listing the objects archived before the purges and the collection deletions
+const archiveListLimit = 500
+
+type ResourceStorage struct {
+	factory *StorageFactory
+	backend storage.ResourceStorage
+	config  *storage.ResourceStorageConfig
+	gvr     schema.GroupVersionResource
+}
+
+var (
+	_ storage.ResourceStorage          = &ResourceStorage{}
+	_ storage.ResourceArchiveStorage   = &ResourceStorage{}
+	_ storage.ResourceCounter          = &ResourceStorage{}
+	_ storage.ResourceAggregator       = &ResourceStorage{}
+	_ storage.SpecHashLister           = &ResourceStorage{}
+	_ storage.CollectionDeleter        = &ResourceStorage{}
+	_ storage.ResourcePatcher          = &ResourceStorage{}
+	_ storage.ResourceHistoryGetter    = &ResourceStorage{}
+	_ storage.ResourceSnapshotGetter   = &ResourceStorage{}
+	_ storage.ResourceTombstoneStorage = &ResourceStorage{}
+	_ storage.ResourceTenantResolver   = &ResourceStorage{}
+)
+
+func (s *ResourceStorage) GetStorageConfig() *storage.ResourceStorageConfig {
+	return s.backend.GetStorageConfig()
+}
+
+func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, obj runtime.Object) error {
+	return s.backend.Get(ctx, cluster, namespace, name, obj)
+}
+
+func (s *ResourceStorage) List(ctx context.Context, listObj runtime.Object, opts *internal.ListOptions) error {
+	return s.backend.List(ctx, listObj, opts)
+}
+
+func (s *ResourceStorage) Watch(ctx context.Context, options *internal.ListOptions) (watch.Interface, error) {
+	return s.backend.Watch(ctx, options)
+}
+
+func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) error {
+	return s.backend.Create(ctx, cluster, obj)
+}
+
+func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) error {
+	return s.backend.Update(ctx, cluster, obj)
+}
+
+// UpdateWithPatch patches the object in the backend storage, the object is fully updated if the backend doesn't support the patch.
+func (s *ResourceStorage) UpdateWithPatch(ctx context.Context, cluster string, obj runtime.Object, patch storage.ResourcePatch) error {
+	if patcher, ok := s.backend.(storage.ResourcePatcher); ok {
+		return patcher.UpdateWithPatch(ctx, cluster, obj, patch)
+	}
+	return s.backend.Update(ctx, cluster, obj)
+}
+
+func (s *ResourceStorage) ConvertDeletedObject(obj interface{}) (runtime.Object, error) {
+	return s.backend.ConvertDeletedObject(obj)
+}
+
+// Delete archives the stored object before deleting it, since the deleted object may only have the metadata,
+// see the ConvertDeletedObject of the storages.
+func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) error {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+
+	stored := &unstructured.Unstructured{}
+	err = s.backend.Get(ctx, cluster, metaobj.GetNamespace(), metaobj.GetName(), stored)
+	switch {
+	case err == nil:
+		if err := s.archive(ctx, cluster, stored, storage.ArchiveReasonDeleted); err != nil {
+			return err
+		}
+	case !storage.IsNotFound(err):
+		return err
+	}
+	return s.backend.Delete(ctx, cluster, obj)
+}
+
+func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event *corev1.Event) error {
+	return s.backend.RecordEvent(ctx, cluster, event)
+}
+
+func (s *ResourceStorage) archive(ctx context.Context, cluster string, obj runtime.Object, reason storage.ArchiveReason) error {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	data, err := runtime.Encode(s.config.Codec, obj)
+	if err != nil {
+		return err
+	}
+
+	if err := s.factory.archiver.archive(ctx, cluster, s.gvr, metaobj.GetNamespace(), metaobj.GetName(), reason, data); err != nil {
+		return storage.NewUnavailableError(fmt.Sprintf("%s/%s", metaobj.GetNamespace(), metaobj.GetName()),
+			fmt.Errorf("failed to archive the object: %w", err))
+	}
+	return nil
+}
+
+// archiveList archives the stored objects listed by the options page by page.
+func (s *ResourceStorage) archiveList(ctx context.Context, opts *internal.ListOptions, reason storage.ArchiveReason, filter func(cluster string, obj *unstructured.Unstructured) bool) error {
+	opts.Limit = archiveListLimit
+	for {
+		list := &unstructured.UnstructuredList{}
+		if err := s.backend.List(ctx, list, opts); err != nil {
+			return err
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			cluster := utils.ExtractClusterName(obj)
+			if cluster == "" && len(opts.ClusterNames) == 1 {
+				cluster = opts.ClusterNames[0]
+			}
+			if cluster == "" {
+				return fmt.Errorf("the cluster of %s/%s is unknown", obj.GetNamespace(), obj.GetName())
+			}
+			if filter != nil && !filter(cluster, obj) {
+				continue
+			}
+			if err := s.archive(ctx, cluster, obj, reason); err != nil {
+				return err
+			}
+		}
+
+		if list.GetContinue() == "" || len(list.Items) == 0 {
+			return nil
+		}
+		opts.Continue = list.GetContinue()
+	}
+}
+
+func (s *ResourceStorage) archivePurged(ctx context.Context, purge storage.PurgeOptions) error {
+	before := metav1.NewTime(purge.CreatedBefore)
+	opts := &internal.ListOptions{ClusterNames: purge.Clusters, Before: &before}
+	excluded := sets.New(purge.ExcludedClusters...)
+
+	// the backends may not filter the objects by the created time
+	return s.archiveList(ctx, opts, storage.ArchiveReasonPurged, func(cluster string, obj *unstructured.Unstructured) bool {
+		return !excluded.Has(cluster) && obj.GetCreationTimestamp().Time.Before(purge.CreatedBefore)
+	})
+}
+
+// DeleteCollection archives the objects matched by the options before deleting them.
+func (s *ResourceStorage) DeleteCollection(ctx context.Context, opts storage.DeleteCollectionOptions) (int64, error) {
+	deleter, ok := s.backend.(storage.CollectionDeleter)
+	if !ok {
+		return 0, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "deletecollection")
+	}
+
+	listOpts := &internal.ListOptions{
+		ListOptions:  metainternalversion.ListOptions{LabelSelector: opts.LabelSelector},
+		ClusterNames: opts.Clusters,
+		Namespaces:   opts.Namespaces,
+	}
+	if err := s.archiveList(ctx, listOpts, storage.ArchiveReasonDeleted, nil); err != nil {
+		return 0, err
+	}
+	return deleter.DeleteCollection(ctx, opts)
+}
+
+func (s *ResourceStorage) ListArchives(ctx context.Context, cluster string, opts storage.ArchiveOptions) ([]storage.Archive, error) {
+	archives, err := s.factory.archiver.list(ctx, cluster, s.gvr, opts)
+	if err != nil {
+		return nil, storage.NewUnavailableError(s.gvr.String(), err)
+	}
+	return archives, nil
+}
+
+// RestoreArchive creates the archived object, or updates the stored object with the archived object.
+func (s *ResourceStorage) RestoreArchive(ctx context.Context, cluster, id string) error {
+	if _, _, _, err := parseID(id); err != nil {
+		return storage.NewInvalidQueryError(id, err)
+	}
+	archived, err := s.factory.archiver.getByID(ctx, cluster, s.gvr, id)
+	if err != nil {
+		return storage.NewUnavailableError(id, err)
+	}
+	if archived == nil {
+		return storage.NewNotFoundError(id, errors.New("the archive is not found"))
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(archived.Object); err != nil {
+		return err
+	}
+	err = s.backend.Create(ctx, cluster, obj)
+	if storage.IsConflict(err) {
+		return s.backend.Update(ctx, cluster, obj)
+	}
+	return err
+}
+
+func (s *ResourceStorage) Count(ctx context.Context, opts *internal.ListOptions) (int64, error) {
+	counter, ok := s.backend.(storage.ResourceCounter)
+	if !ok {
+		return 0, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "count")
+	}
+	return counter.Count(ctx, opts)
+}
+
+func (s *ResourceStorage) Aggregate(ctx context.Context, opts *internal.ListOptions, groupBy []storage.GroupBy) ([]storage.AggregationGroup, error) {
+	aggregator, ok := s.backend.(storage.ResourceAggregator)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "aggregate")
+	}
+	return aggregator.Aggregate(ctx, opts, groupBy)
+}
+
+func (s *ResourceStorage) ListSpecHashes(ctx context.Context, clusters []string, namespace, name string) (map[string]string, error) {
+	lister, ok := s.backend.(storage.SpecHashLister)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "list spec hashes")
+	}
+	return lister.ListSpecHashes(ctx, clusters, namespace, name)
+}
+
+func (s *ResourceStorage) TenantFor(ctx context.Context) string {
+	resolver, ok := s.backend.(storage.ResourceTenantResolver)
+	if !ok {
+		return ""
+	}
+	return resolver.TenantFor(ctx)
+}
+
+func (s *ResourceStorage) GetHistory(ctx context.Context, cluster, namespace, name string) ([]storage.ResourceChange, error) {
+	getter, ok := s.backend.(storage.ResourceHistoryGetter)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "history")
+	}
+	return getter.GetHistory(ctx, cluster, namespace, name)
+}
+
+func (s *ResourceStorage) GetSnapshot(ctx context.Context, cluster, namespace, name string) (*storage.ResourceSnapshot, error) {
+	getter, ok := s.backend.(storage.ResourceSnapshotGetter)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "snapshot")
+	}
+	return getter.GetSnapshot(ctx, cluster, namespace, name)
+}
+
+func (s *ResourceStorage) ListTombstones(ctx context.Context, cluster string, opts storage.TombstoneOptions) ([]storage.Tombstone, error) {
+	tombstones, ok := s.backend.(storage.ResourceTombstoneStorage)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "tombstones")
+	}
+	return tombstones.ListTombstones(ctx, cluster, opts)
+}
+
+func (s *ResourceStorage) RestoreTombstone(ctx context.Context, cluster, namespace, name string) error {
+	tombstones, ok := s.backend.(storage.ResourceTombstoneStorage)
+	if !ok {
+		return apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "tombstones")
+	}
+	return tombstones.RestoreTombstone(ctx, cluster, namespace, name)
+}
+
+// PurgeTombstones doesn't archive the objects of the tombstones, since they are archived when they are deleted.
+func (s *ResourceStorage) PurgeTombstones(ctx context.Context, cluster string, opts storage.TombstoneOptions) (int64, error) {
+	tombstones, ok := s.backend.(storage.ResourceTombstoneStorage)
+	if !ok {
+		return 0, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "tombstones")
+	}
+	return tombstones.PurgeTombstones(ctx, cluster, opts)
+}
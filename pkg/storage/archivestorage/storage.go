@@ -0,0 +1,127 @@
+package archivestorage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// StorageFactory archives the objects deleted from the backend storage to the object storage.
+//
+// The objects are archived before they are deleted, so the deletions fail and are retried if the objects can't be archived.
+// The retention purges the objects without loading them, so the objects of the resource storages created by the factory
+// are listed and archived before the purges, the purges of the other resources are rejected.
+type StorageFactory struct {
+	backend  storage.StorageFactory
+	archiver *archiver
+
+	lock     sync.RWMutex
+	storages map[schema.GroupVersionResource]*ResourceStorage
+}
+
+var (
+	_ storage.StorageFactory             = &StorageFactory{}
+	_ storage.HealthChecker              = &StorageFactory{}
+	_ storage.ResourceRequestVerbsGetter = &StorageFactory{}
+	_ storage.ResourcePurger             = &StorageFactory{}
+)
+
+func (f *StorageFactory) GetSupportedRequestVerbs() []string {
+	return f.backend.GetSupportedRequestVerbs()
+}
+
+func (f *StorageFactory) GetResourceRequestVerbs(gvr schema.GroupVersionResource) ([]string, bool) {
+	if getter, ok := f.backend.(storage.ResourceRequestVerbsGetter); ok {
+		return getter.GetResourceRequestVerbs(gvr)
+	}
+	return nil, false
+}
+
+func (f *StorageFactory) HealthCheck(ctx context.Context) error {
+	return storage.CheckHealth(ctx, f.backend)
+}
+
+func (f *StorageFactory) Readiness(ctx context.Context) error {
+	return storage.CheckReadiness(ctx, f.backend)
+}
+
+func (f *StorageFactory) PrepareCluster(cluster string) error {
+	return f.backend.PrepareCluster(cluster)
+}
+
+func (f *StorageFactory) GetResourceVersions(ctx context.Context, cluster string) (map[schema.GroupVersionResource]storage.ClusterResourceVersions, error) {
+	return f.backend.GetResourceVersions(ctx, cluster)
+}
+
+func (f *StorageFactory) GetCollectionResources(ctx context.Context) ([]*internal.CollectionResource, error) {
+	return f.backend.GetCollectionResources(ctx)
+}
+
+func (f *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
+	backend, err := f.backend.NewResourceStorage(config)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &ResourceStorage{
+		factory: f,
+		backend: backend,
+		config:  config,
+		gvr:     config.StorageResource,
+	}
+	f.lock.Lock()
+	f.storages[rs.gvr] = rs
+	f.lock.Unlock()
+	return rs, nil
+}
+
+func (f *StorageFactory) NewCollectionResourceStorage(cr *internal.CollectionResource) (storage.CollectionResourceStorage, error) {
+	return f.backend.NewCollectionResourceStorage(cr)
+}
+
+// CleanCluster and CleanClusterResource don't archive the objects, since the cluster or the resource is no longer synchronized.
+func (f *StorageFactory) CleanCluster(ctx context.Context, cluster string) error {
+	return f.backend.CleanCluster(ctx, cluster)
+}
+
+func (f *StorageFactory) CleanClusterResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) error {
+	return f.backend.CleanClusterResource(ctx, cluster, gvr)
+}
+
+// PurgeResources archives the objects which will be purged before purging them.
+// The objects created before the retention window and stored between the archiving and the purging
+// are purged without being archived, e.g. the objects of a cluster which is synchronized again.
+func (f *StorageFactory) PurgeResources(ctx context.Context, opts storage.PurgeOptions) (int64, error) {
+	purger, ok := f.backend.(storage.ResourcePurger)
+	if !ok {
+		return 0, fmt.Errorf("the backend storage doesn't support purging the resources")
+	}
+
+	f.lock.RLock()
+	var storages []*ResourceStorage
+	for gvr, rs := range f.storages {
+		if gvr.GroupResource() == opts.Resource {
+			storages = append(storages, rs)
+		}
+	}
+	f.lock.RUnlock()
+	if len(storages) == 0 {
+		return 0, fmt.Errorf("the objects of %s can't be archived before purging, the resource is not synchronized", opts.Resource)
+	}
+
+	for _, rs := range storages {
+		if err := rs.archivePurged(ctx, opts); err != nil {
+			return 0, err
+		}
+	}
+	return purger.PurgeResources(ctx, opts)
+}
+
+func (f *StorageFactory) Shutdown() error {
+	return f.backend.Shutdown()
+}
@@ -0,0 +1,226 @@
+package archivestorage
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/storagetesting"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
+)
+
+type memoryStore struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+	fail    bool
+}
+
+func (s *memoryStore) Put(_ context.Context, key string, data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.fail {
+		return errors.New("unavailable")
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.objects[key], nil
+}
+
+func (s *memoryStore) List(_ context.Context, prefix string) ([]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var keys []string
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func newPod(cluster, namespace, name string, created time.Time) *unstructured.Unstructured {
+	pod := storagetesting.NewPod(namespace, name)
+	pod.SetCreationTimestamp(metav1.NewTime(created))
+	utils.InjectClusterName(pod, cluster)
+	return pod
+}
+
+func newTestStorage(t *testing.T, pods ...*unstructured.Unstructured) (*storagetesting.StorageFactory, *memoryStore, *StorageFactory, storage.ResourceStorage) {
+	backend := storagetesting.NewStorageFactory()
+	for _, pod := range pods {
+		backend.Put(utils.ExtractClusterName(pod), pod)
+	}
+	store := &memoryStore{objects: make(map[string][]byte)}
+
+	factory := newStorageFactory(&Config{}, backend, store)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	factory.archiver.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	rs, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: resourceconfig.ResourceConfig{
+		StorageResource: storagetesting.PodsGVR,
+		Codec:           unstructured.UnstructuredJSONScheme,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return backend, store, factory, rs
+}
+
+func deleted(namespace, name string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+func TestArchiveDeleted(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	backend, store, _, rs := newTestStorage(t, newPod("cluster-1", "default", "nginx", created))
+	ctx := context.Background()
+
+	store.fail = true
+	if err := rs.Delete(ctx, "cluster-1", deleted("default", "nginx")); err == nil {
+		t.Fatal("expected an error when the object can't be archived")
+	}
+	if len(backend.Objects()) != 1 {
+		t.Fatal("expected the object not to be deleted when it can't be archived")
+	}
+
+	store.fail = false
+	if err := rs.Delete(ctx, "cluster-1", deleted("default", "nginx")); err != nil {
+		t.Fatal(err)
+	}
+	if len(backend.Objects()) != 0 {
+		t.Fatal("expected the object to be deleted")
+	}
+	// the objects which are not stored are deleted without being archived
+	if err := rs.Delete(ctx, "cluster-1", deleted("default", "redis")); err != nil {
+		t.Fatal(err)
+	}
+
+	archives, err := rs.(storage.ResourceArchiveStorage).ListArchives(ctx, "cluster-1", storage.ArchiveOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archives) != 1 {
+		t.Fatalf("expected 1 archive, got %d", len(archives))
+	}
+	archive := archives[0]
+	if archive.Namespace != "default" || archive.Name != "nginx" || archive.Reason != storage.ArchiveReasonDeleted {
+		t.Errorf("unexpected archive %+v", archive)
+	}
+	// the stored object is archived instead of the deleted object which only has the metadata
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(archive.Object); err != nil {
+		t.Fatal(err)
+	}
+	if obj.GetKind() != "Pod" || !obj.GetCreationTimestamp().Time.Equal(created) {
+		t.Errorf("unexpected archived object %s", archive.Object)
+	}
+}
+
+func TestArchivePurged(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	old, recent := now.Add(-48*time.Hour), now.Add(-time.Hour)
+	backend, _, factory, rs := newTestStorage(t,
+		newPod("cluster-1", "default", "old", old),
+		newPod("cluster-1", "default", "recent", recent),
+		newPod("cluster-2", "default", "old", old),
+	)
+	ctx := context.Background()
+
+	opts := storage.PurgeOptions{Resource: storagetesting.PodsGVR.GroupResource(), ExcludedClusters: []string{"cluster-2"}, CreatedBefore: now.Add(-24 * time.Hour)}
+	purged, err := factory.PurgeResources(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purged != 1 || len(backend.Objects()) != 2 {
+		t.Fatalf("expected 1 object to be purged, got %d", purged)
+	}
+
+	archiver := rs.(storage.ResourceArchiveStorage)
+	for cluster, expected := range map[string]int{"cluster-1": 1, "cluster-2": 0} {
+		archives, err := archiver.ListArchives(ctx, cluster, storage.ArchiveOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(archives) != expected {
+			t.Fatalf("expected %d archives of %s, got %d", expected, cluster, len(archives))
+		}
+		if expected != 0 && (archives[0].Name != "old" || archives[0].Reason != storage.ArchiveReasonPurged) {
+			t.Errorf("unexpected archive %+v", archives[0])
+		}
+	}
+
+	// the objects of the other resources can't be archived
+	opts.Resource = schema.GroupResource{Group: "apps", Resource: "deployments"}
+	if _, err := factory.PurgeResources(ctx, opts); err == nil {
+		t.Error("expected an error when the resource storage is not created")
+	}
+}
+
+func TestRestoreArchive(t *testing.T) {
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	backend, _, _, rs := newTestStorage(t,
+		newPod("cluster-1", "default", "nginx", created),
+		newPod("cluster-1", "kube-system", "nginx", created),
+	)
+	ctx := context.Background()
+	archiver := rs.(storage.ResourceArchiveStorage)
+
+	for _, namespace := range []string{"default", "kube-system", "default"} {
+		if namespace == "default" {
+			if err := rs.Create(ctx, "cluster-1", newPod("cluster-1", namespace, "nginx", created)); err != nil && !storage.IsConflict(err) {
+				t.Fatal(err)
+			}
+		}
+		if err := rs.Delete(ctx, "cluster-1", deleted(namespace, "nginx")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archives, err := archiver.ListArchives(ctx, "cluster-1", storage.ArchiveOptions{Namespace: "default", Name: "nginx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archives) != 2 || !archives[0].ArchivedAt.After(archives[1].ArchivedAt) {
+		t.Fatalf("expected 2 archives sorted by the archived time in descending order, got %+v", archives)
+	}
+	filtered, err := archiver.ListArchives(ctx, "cluster-1", storage.ArchiveOptions{Name: "nginx", ArchivedBefore: archives[0].ArchivedAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 2 {
+		t.Fatalf("expected 2 archives archived before the latest one, got %d", len(filtered))
+	}
+
+	if err := archiver.RestoreArchive(ctx, "cluster-1", archives[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if backend.Object("cluster-1", "default", "nginx") == nil {
+		t.Error("expected the archived object to be restored")
+	}
+
+	if err := archiver.RestoreArchive(ctx, "cluster-1", "default/nginx/1"); !storage.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if err := archiver.RestoreArchive(ctx, "cluster-1", "../../nginx/1"); err == nil {
+		t.Error("expected an error of the invalid id")
+	}
+}
@@ -0,0 +1,85 @@
+package cachestorage
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// cache is the key-value cache of the responses
+type cache interface {
+	// counters returns the values of the counters, the value is 0 if the counter doesn't exist.
+	counters(ctx context.Context, keys ...string) ([]int64, error)
+	incr(ctx context.Context, key string) error
+
+	// get returns false if the key doesn't exist.
+	get(ctx context.Context, key string) ([]byte, bool, error)
+	set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	ping(ctx context.Context) error
+	close() error
+}
+
+type redisCache struct {
+	client *redis.Client
+}
+
+var _ cache = &redisCache{}
+
+func newRedisCache(cfg RedisConfig) *redisCache {
+	return &redisCache{client: redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})}
+}
+
+func (c *redisCache) counters(ctx context.Context, keys ...string) ([]int64, error) {
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	counters := make([]int64, len(values))
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if counters[i], err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	return counters, nil
+}
+
+func (c *redisCache) incr(ctx context.Context, key string) error {
+	return c.client.Incr(ctx, key).Err()
+}
+
+func (c *redisCache) get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *redisCache) set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *redisCache) ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+func (c *redisCache) close() error {
+	return c.client.Close()
+}
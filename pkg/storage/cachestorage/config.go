@@ -0,0 +1,86 @@
+package cachestorage
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultKeyPrefix    = "clusterpedia:cache:"
+	defaultTTL          = 30 * time.Second
+	defaultMaxEntrySize = 1 << 20
+)
+
+// Config is the config of the cache storage, which serves the Get and List requests of the backend storage
+// from the Redis cache, so that the read-heavy dashboards do not hit the database for the same queries.
+//
+// The cache is invalidated by the writes through the cache storage, so both the apiserver and the clustersynchro manager
+// should use the cache storage, otherwise the cached objects are only refreshed after the TTL.
+type Config struct {
+	Backend BackendConfig `yaml:"backend"`
+	Redis   RedisConfig   `yaml:"redis"`
+
+	// TTL bounds the time the cached responses are served, default is 30s.
+	TTL time.Duration `yaml:"ttl"`
+
+	// MaxEntrySize is the max size of the cached responses in bytes, the larger responses are not cached, default is 1MiB.
+	MaxEntrySize int `yaml:"maxEntrySize"`
+}
+
+type BackendConfig struct {
+	// Name is the name of the registered storage, such as internal.
+	Name string `yaml:"name"`
+
+	// ConfigPath is the path of the config of the storage.
+	ConfigPath string `yaml:"configPath"`
+}
+
+type RedisConfig struct {
+	Addr     string `yaml:"addr" env:"REDIS_ADDR"`
+	Username string `yaml:"username" env:"REDIS_USERNAME"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD"`
+	DB       int    `yaml:"db"`
+
+	// KeyPrefix is the prefix of all keys written by the cache, default is `clusterpedia:cache:`.
+	KeyPrefix string `yaml:"keyPrefix"`
+}
+
+func (cfg *Config) validate() error {
+	if cfg.Backend.Name == "" {
+		return fmt.Errorf("the backend storage is required")
+	}
+	if cfg.Backend.Name == StorageName {
+		return fmt.Errorf("the %s storage can not be nested", StorageName)
+	}
+	if cfg.Redis.Addr == "" {
+		return fmt.Errorf("the address of redis is required")
+	}
+	if cfg.TTL < 0 {
+		return fmt.Errorf("ttl should not be negative")
+	}
+	if cfg.MaxEntrySize < 0 {
+		return fmt.Errorf("maxEntrySize should not be negative")
+	}
+	return nil
+}
+
+func (cfg *Config) ttl() time.Duration {
+	if cfg.TTL == 0 {
+		return defaultTTL
+	}
+	return cfg.TTL
+}
+
+func (cfg *Config) maxEntrySize() int {
+	if cfg.MaxEntrySize == 0 {
+		return defaultMaxEntrySize
+	}
+	return cfg.MaxEntrySize
+}
+
+func (cfg *Config) keyPrefix() string {
+	if cfg.Redis.KeyPrefix == "" {
+		return defaultKeyPrefix
+	}
+	return cfg.Redis.KeyPrefix
+}
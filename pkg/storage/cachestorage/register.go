@@ -0,0 +1,58 @@
+package cachestorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/configor"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	StorageName = "cache"
+)
+
+func init() {
+	storage.RegisterStorageFactoryFunc(StorageName, NewStorageFactory)
+}
+
+func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
+	if configPath == "" {
+		return nil, fmt.Errorf("configPath should not be empty")
+	}
+
+	cfg := &Config{}
+	if err := configor.Load(cfg, configPath); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	backend, err := storage.NewStorageFactory(cfg.Backend.Name, cfg.Backend.ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("backend storage: %w", err)
+	}
+
+	cache := newRedisCache(cfg.Redis)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := cache.ping(ctx); err != nil {
+		// the requests are served by the backend storage until redis is available
+		klog.ErrorS(err, "Failed to connect to redis", "addr", cfg.Redis.Addr)
+	}
+	return newStorageFactory(cfg, backend, cache), nil
+}
+
+func newStorageFactory(cfg *Config, backend storage.StorageFactory, cache cache) *StorageFactory {
+	return &StorageFactory{
+		backend:      backend,
+		cache:        cache,
+		keyPrefix:    cfg.keyPrefix(),
+		ttl:          cfg.ttl(),
+		maxEntrySize: cfg.maxEntrySize(),
+	}
+}
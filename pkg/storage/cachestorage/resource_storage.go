@@ -0,0 +1,320 @@
+package cachestorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type ResourceStorage struct {
+	factory *StorageFactory
+	backend storage.ResourceStorage
+	gvr     schema.GroupVersionResource
+}
+
+var (
+	_ storage.ResourceStorage          = &ResourceStorage{}
+	_ storage.ResourceCounter          = &ResourceStorage{}
+	_ storage.ResourceAggregator       = &ResourceStorage{}
+	_ storage.SpecHashLister           = &ResourceStorage{}
+	_ storage.CollectionDeleter        = &ResourceStorage{}
+	_ storage.ResourcePatcher          = &ResourceStorage{}
+	_ storage.ResourceHistoryGetter    = &ResourceStorage{}
+	_ storage.ResourceSnapshotGetter   = &ResourceStorage{}
+	_ storage.ResourceTombstoneStorage = &ResourceStorage{}
+	_ storage.ResourceTenantResolver   = &ResourceStorage{}
+)
+
+func (s *ResourceStorage) GetStorageConfig() *storage.ResourceStorageConfig {
+	return s.backend.GetStorageConfig()
+}
+
+type getRequest struct {
+	Type      string
+	Cluster   string
+	Namespace string
+	Name      string
+}
+
+func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, obj runtime.Object) error {
+	request := getRequest{Type: fmt.Sprintf("%T", obj), Cluster: cluster, Namespace: namespace, Name: name}
+	return s.readThrough(ctx, request, obj, func() error {
+		return s.backend.Get(ctx, cluster, namespace, name, obj)
+	})
+}
+
+type listRequest struct {
+	Type    string
+	Options internal.ListOptions
+
+	// the selectors are interfaces, they are keyed by the strings
+	LabelSelector         string
+	FieldSelector         string
+	EnhancedFieldSelector string
+	ExtraLabelSelector    string
+}
+
+func newListRequest(listObj runtime.Object, opts *internal.ListOptions) listRequest {
+	request := listRequest{Type: fmt.Sprintf("%T", listObj), Options: *opts}
+	if opts.LabelSelector != nil {
+		request.LabelSelector = opts.LabelSelector.String()
+	}
+	if opts.FieldSelector != nil {
+		request.FieldSelector = opts.FieldSelector.String()
+	}
+	if opts.EnhancedFieldSelector != nil {
+		request.EnhancedFieldSelector = opts.EnhancedFieldSelector.String()
+	}
+	if opts.ExtraLabelSelector != nil {
+		request.ExtraLabelSelector = opts.ExtraLabelSelector.String()
+	}
+	request.Options.LabelSelector, request.Options.FieldSelector = nil, nil
+	request.Options.EnhancedFieldSelector, request.Options.ExtraLabelSelector = nil, nil
+	return request
+}
+
+func (s *ResourceStorage) List(ctx context.Context, listObj runtime.Object, opts *internal.ListOptions) error {
+	return s.readThrough(ctx, newListRequest(listObj, opts), listObj, func() error {
+		return s.backend.List(ctx, listObj, opts)
+	})
+}
+
+// readThrough serves the request from the cache, or loads the object by the backend storage and caches it.
+func (s *ResourceStorage) readThrough(ctx context.Context, request interface{}, obj runtime.Object, load func() error) error {
+	key, err := s.factory.entryKey(ctx, s.gvr, request)
+	if err != nil {
+		klog.V(4).ErrorS(err, "Failed to read the cache", "resource", s.gvr)
+		return load()
+	}
+
+	data, ok, err := s.factory.cache.get(ctx, key)
+	if err != nil {
+		klog.V(4).ErrorS(err, "Failed to read the cache", "resource", s.gvr)
+	}
+	if ok {
+		err := decode(data, obj)
+		if err == nil {
+			return nil
+		}
+		klog.ErrorS(err, "Failed to decode the cached response", "resource", s.gvr, "key", key)
+	}
+
+	if err := load(); err != nil {
+		return err
+	}
+	if data, err = encode(obj); err != nil || len(data) > s.factory.maxEntrySize {
+		return nil
+	}
+	if err := s.factory.cache.set(ctx, key, data, s.factory.ttl); err != nil {
+		klog.V(4).ErrorS(err, "Failed to write the cache", "resource", s.gvr)
+	}
+	return nil
+}
+
+// cachedList is the cached response of the unstructured list, the list returned by the storage may have no kind,
+// which is required by the json decoding of the unstructured list.
+type cachedList struct {
+	Object map[string]interface{}
+	Items  []map[string]interface{}
+}
+
+func encode(obj runtime.Object) ([]byte, error) {
+	list, ok := obj.(*unstructured.UnstructuredList)
+	if !ok {
+		return json.Marshal(obj)
+	}
+
+	cached := cachedList{Object: list.Object, Items: make([]map[string]interface{}, 0, len(list.Items))}
+	for _, item := range list.Items {
+		cached.Items = append(cached.Items, item.Object)
+	}
+	return json.Marshal(cached)
+}
+
+// decode decodes the cached response into a new object first, so obj is not changed if the decoding fails.
+func decode(data []byte, obj runtime.Object) error {
+	if list, ok := obj.(*unstructured.UnstructuredList); ok {
+		var cached cachedList
+		if err := json.Unmarshal(data, &cached); err != nil {
+			return err
+		}
+		list.Object = cached.Object
+		list.Items = make([]unstructured.Unstructured, 0, len(cached.Items))
+		for _, item := range cached.Items {
+			list.Items = append(list.Items, unstructured.Unstructured{Object: item})
+		}
+		return nil
+	}
+
+	value := reflect.ValueOf(obj)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+	decoded := reflect.New(value.Elem().Type())
+	if err := json.Unmarshal(data, decoded.Interface()); err != nil {
+		return err
+	}
+	value.Elem().Set(decoded.Elem())
+	return nil
+}
+
+func (s *ResourceStorage) Watch(ctx context.Context, options *internal.ListOptions) (watch.Interface, error) {
+	return s.backend.Watch(ctx, options)
+}
+
+// invalidate increases the generation of the resource after the writes,
+// so the responses cached before the writes are not served again.
+func (s *ResourceStorage) invalidate(ctx context.Context) {
+	s.factory.invalidate(ctx, s.factory.generationKey(s.gvr))
+}
+
+func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) error {
+	if err := s.backend.Create(ctx, cluster, obj); err != nil {
+		return err
+	}
+	s.invalidate(ctx)
+	return nil
+}
+
+func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) error {
+	if err := s.backend.Update(ctx, cluster, obj); err != nil {
+		return err
+	}
+	s.invalidate(ctx)
+	return nil
+}
+
+// UpdateWithPatch patches the object in the backend storage, the object is fully updated if the backend doesn't support the patch.
+func (s *ResourceStorage) UpdateWithPatch(ctx context.Context, cluster string, obj runtime.Object, patch storage.ResourcePatch) error {
+	patcher, ok := s.backend.(storage.ResourcePatcher)
+	if !ok {
+		return s.Update(ctx, cluster, obj)
+	}
+	if err := patcher.UpdateWithPatch(ctx, cluster, obj, patch); err != nil {
+		return err
+	}
+	s.invalidate(ctx)
+	return nil
+}
+
+func (s *ResourceStorage) ConvertDeletedObject(obj interface{}) (runtime.Object, error) {
+	return s.backend.ConvertDeletedObject(obj)
+}
+
+func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) error {
+	if err := s.backend.Delete(ctx, cluster, obj); err != nil {
+		return err
+	}
+	s.invalidate(ctx)
+	return nil
+}
+
+func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event *corev1.Event) error {
+	if err := s.backend.RecordEvent(ctx, cluster, event); err != nil {
+		return err
+	}
+	// the events may be injected into the responses
+	s.invalidate(ctx)
+	return nil
+}
+
+func (s *ResourceStorage) DeleteCollection(ctx context.Context, opts storage.DeleteCollectionOptions) (int64, error) {
+	deleter, ok := s.backend.(storage.CollectionDeleter)
+	if !ok {
+		return 0, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "deletecollection")
+	}
+
+	n, err := deleter.DeleteCollection(ctx, opts)
+	if n > 0 {
+		s.invalidate(ctx)
+	}
+	return n, err
+}
+
+func (s *ResourceStorage) Count(ctx context.Context, opts *internal.ListOptions) (int64, error) {
+	counter, ok := s.backend.(storage.ResourceCounter)
+	if !ok {
+		return 0, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "count")
+	}
+	return counter.Count(ctx, opts)
+}
+
+func (s *ResourceStorage) Aggregate(ctx context.Context, opts *internal.ListOptions, groupBy []storage.GroupBy) ([]storage.AggregationGroup, error) {
+	aggregator, ok := s.backend.(storage.ResourceAggregator)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "aggregate")
+	}
+	return aggregator.Aggregate(ctx, opts, groupBy)
+}
+
+func (s *ResourceStorage) ListSpecHashes(ctx context.Context, clusters []string, namespace, name string) (map[string]string, error) {
+	lister, ok := s.backend.(storage.SpecHashLister)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "list spec hashes")
+	}
+	return lister.ListSpecHashes(ctx, clusters, namespace, name)
+}
+
+func (s *ResourceStorage) TenantFor(ctx context.Context) string {
+	resolver, ok := s.backend.(storage.ResourceTenantResolver)
+	if !ok {
+		return ""
+	}
+	return resolver.TenantFor(ctx)
+}
+
+func (s *ResourceStorage) GetHistory(ctx context.Context, cluster, namespace, name string) ([]storage.ResourceChange, error) {
+	getter, ok := s.backend.(storage.ResourceHistoryGetter)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "history")
+	}
+	return getter.GetHistory(ctx, cluster, namespace, name)
+}
+
+func (s *ResourceStorage) GetSnapshot(ctx context.Context, cluster, namespace, name string) (*storage.ResourceSnapshot, error) {
+	getter, ok := s.backend.(storage.ResourceSnapshotGetter)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "snapshot")
+	}
+	return getter.GetSnapshot(ctx, cluster, namespace, name)
+}
+
+func (s *ResourceStorage) ListTombstones(ctx context.Context, cluster string, opts storage.TombstoneOptions) ([]storage.Tombstone, error) {
+	tombstones, ok := s.backend.(storage.ResourceTombstoneStorage)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "tombstones")
+	}
+	return tombstones.ListTombstones(ctx, cluster, opts)
+}
+
+func (s *ResourceStorage) RestoreTombstone(ctx context.Context, cluster, namespace, name string) error {
+	tombstones, ok := s.backend.(storage.ResourceTombstoneStorage)
+	if !ok {
+		return apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "tombstones")
+	}
+	if err := tombstones.RestoreTombstone(ctx, cluster, namespace, name); err != nil {
+		return err
+	}
+	s.invalidate(ctx)
+	return nil
+}
+
+func (s *ResourceStorage) PurgeTombstones(ctx context.Context, cluster string, opts storage.TombstoneOptions) (int64, error) {
+	tombstones, ok := s.backend.(storage.ResourceTombstoneStorage)
+	if !ok {
+		return 0, apierrors.NewMethodNotSupported(s.gvr.GroupResource(), "tombstones")
+	}
+	return tombstones.PurgeTombstones(ctx, cluster, opts)
+}
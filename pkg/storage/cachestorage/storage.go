@@ -0,0 +1,171 @@
+package cachestorage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// StorageFactory serves the Get and List requests of the backend storage from the cache.
+//
+// The cached responses are keyed by the epoch of the cache and the generation of the resource, the writes of a resource
+// increase its generation and the cleanups of the clusters increase the epoch, so the cached responses read before
+// the writes are never served again, and they are expired by the TTL.
+// The errors of the cache are not returned, the requests are served by the backend storage instead.
+type StorageFactory struct {
+	backend storage.StorageFactory
+	cache   cache
+
+	keyPrefix    string
+	ttl          time.Duration
+	maxEntrySize int
+}
+
+var (
+	_ storage.StorageFactory             = &StorageFactory{}
+	_ storage.HealthChecker              = &StorageFactory{}
+	_ storage.ResourceRequestVerbsGetter = &StorageFactory{}
+	_ storage.ResourcePurger             = &StorageFactory{}
+)
+
+func (f *StorageFactory) GetSupportedRequestVerbs() []string {
+	return f.backend.GetSupportedRequestVerbs()
+}
+
+func (f *StorageFactory) GetResourceRequestVerbs(gvr schema.GroupVersionResource) ([]string, bool) {
+	if getter, ok := f.backend.(storage.ResourceRequestVerbsGetter); ok {
+		return getter.GetResourceRequestVerbs(gvr)
+	}
+	return nil, false
+}
+
+// HealthCheck only checks the backend storage, since the requests are served by the backend storage
+// when the cache is unavailable.
+func (f *StorageFactory) HealthCheck(ctx context.Context) error {
+	if err := f.cache.ping(ctx); err != nil {
+		klog.ErrorS(err, "The cache is unavailable, the requests are served by the backend storage")
+	}
+	return storage.CheckHealth(ctx, f.backend)
+}
+
+func (f *StorageFactory) Readiness(ctx context.Context) error {
+	return storage.CheckReadiness(ctx, f.backend)
+}
+
+func (f *StorageFactory) PrepareCluster(cluster string) error {
+	return f.backend.PrepareCluster(cluster)
+}
+
+func (f *StorageFactory) GetResourceVersions(ctx context.Context, cluster string) (map[schema.GroupVersionResource]storage.ClusterResourceVersions, error) {
+	return f.backend.GetResourceVersions(ctx, cluster)
+}
+
+func (f *StorageFactory) GetCollectionResources(ctx context.Context) ([]*internal.CollectionResource, error) {
+	return f.backend.GetCollectionResources(ctx)
+}
+
+func (f *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
+	backend, err := f.backend.NewResourceStorage(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ResourceStorage{
+		factory: f,
+		backend: backend,
+		gvr:     config.StorageResource,
+	}, nil
+}
+
+// NewCollectionResourceStorage returns the collection resource storage of the backend storage, which is not cached.
+func (f *StorageFactory) NewCollectionResourceStorage(cr *internal.CollectionResource) (storage.CollectionResourceStorage, error) {
+	return f.backend.NewCollectionResourceStorage(cr)
+}
+
+func (f *StorageFactory) CleanCluster(ctx context.Context, cluster string) error {
+	if err := f.backend.CleanCluster(ctx, cluster); err != nil {
+		return err
+	}
+	f.invalidate(ctx, f.epochKey())
+	return nil
+}
+
+func (f *StorageFactory) CleanClusterResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) error {
+	if err := f.backend.CleanClusterResource(ctx, cluster, gvr); err != nil {
+		return err
+	}
+	f.invalidate(ctx, f.generationKey(gvr))
+	return nil
+}
+
+func (f *StorageFactory) PurgeResources(ctx context.Context, opts storage.PurgeOptions) (int64, error) {
+	purger, ok := f.backend.(storage.ResourcePurger)
+	if !ok {
+		return 0, fmt.Errorf("the backend storage doesn't support purging the resources")
+	}
+
+	n, err := purger.PurgeResources(ctx, opts)
+	if n > 0 {
+		// all versions of the resource are purged
+		f.invalidate(ctx, f.epochKey())
+	}
+	return n, err
+}
+
+func (f *StorageFactory) Shutdown() error {
+	return errors.Join(f.backend.Shutdown(), f.cache.close())
+}
+
+func (f *StorageFactory) epochKey() string {
+	return f.keyPrefix + "epoch"
+}
+
+func (f *StorageFactory) generationKey(gvr schema.GroupVersionResource) string {
+	return f.keyPrefix + "generation:" + resourcePath(gvr)
+}
+
+func resourcePath(gvr schema.GroupVersionResource) string {
+	return gvr.Group + "/" + gvr.Version + "/" + gvr.Resource
+}
+
+// invalidate increases the counter, so the cached responses keyed by the counter are never served again.
+func (f *StorageFactory) invalidate(ctx context.Context, key string) {
+	if err := f.cache.incr(ctx, key); err != nil {
+		klog.ErrorS(err, "Failed to invalidate the cache, the cached responses are served until they are expired", "key", key)
+	}
+}
+
+// entryKey returns the key of the cached response of the request, the request is keyed with the user of the context,
+// since the responses of the backend storage may be filtered by the users, e.g. the row level security.
+func (f *StorageFactory) entryKey(ctx context.Context, gvr schema.GroupVersionResource, request interface{}) (string, error) {
+	counters, err := f.cache.counters(ctx, f.epochKey(), f.generationKey(gvr))
+	if err != nil {
+		return "", err
+	}
+
+	var user struct {
+		Name   string
+		Groups []string
+	}
+	if info, ok := genericrequest.UserFrom(ctx); ok {
+		user.Name, user.Groups = info.GetName(), info.GetGroups()
+	}
+	data, err := json.Marshal([]interface{}{user, request})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return f.keyPrefix + "entry:" + resourcePath(gvr) + ":" + strconv.FormatInt(counters[0], 10) + ":" +
+		strconv.FormatInt(counters[1], 10) + ":" + hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,215 @@
+package cachestorage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/storagetesting"
+)
+
+// memoryCache is the cache in memory, the entries are never expired
+type memoryCache struct {
+	lock    sync.Mutex
+	values  map[string][]byte
+	counter map[string]int64
+	fail    bool
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: make(map[string][]byte), counter: make(map[string]int64)}
+}
+
+func (c *memoryCache) counters(_ context.Context, keys ...string) ([]int64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.fail {
+		return nil, errors.New("unavailable")
+	}
+	counters := make([]int64, 0, len(keys))
+	for _, key := range keys {
+		counters = append(counters, c.counter[key])
+	}
+	return counters, nil
+}
+
+func (c *memoryCache) incr(_ context.Context, key string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.fail {
+		return errors.New("unavailable")
+	}
+	c.counter[key]++
+	return nil
+}
+
+func (c *memoryCache) get(_ context.Context, key string) ([]byte, bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	value, ok := c.values[key]
+	return value, ok, nil
+}
+
+func (c *memoryCache) set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.values[key] = value
+	return nil
+}
+
+func (c *memoryCache) ping(context.Context) error { return nil }
+func (c *memoryCache) close() error               { return nil }
+
+func newPod(namespace, name, app string) *unstructured.Unstructured {
+	pod := storagetesting.NewPod(namespace, name)
+	pod.SetLabels(map[string]string{"app": app})
+	return pod
+}
+
+func newTestStorage(t *testing.T) (*storagetesting.StorageFactory, *memoryCache, *StorageFactory, storage.ResourceStorage) {
+	backend := storagetesting.NewStorageFactory()
+	backend.Put("cluster-1", newPod("default", "nginx", "nginx"))
+	backend.Put("cluster-1", newPod("default", "redis", "redis"))
+	memory := newMemoryCache()
+	factory := newStorageFactory(&Config{}, backend, memory)
+	rs, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: resourceconfig.ResourceConfig{StorageResource: storagetesting.PodsGVR}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return backend, memory, factory, rs
+}
+
+func TestReadThrough(t *testing.T) {
+	backend, _, _, rs := newTestStorage(t)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		pod := &unstructured.Unstructured{}
+		if err := rs.Get(ctx, "cluster-1", "default", "nginx", pod); err != nil {
+			t.Fatal(err)
+		}
+		if pod.GetName() != "nginx" {
+			t.Fatalf("unexpected pod: %v", pod)
+		}
+	}
+	if backend.Reads() != 1 {
+		t.Errorf("expected the second get to be served by the cache, got %d reads", backend.Reads())
+	}
+
+	// the errors are not cached
+	for i := 0; i < 2; i++ {
+		if err := rs.Get(ctx, "cluster-1", "default", "mysql", &unstructured.Unstructured{}); !storage.IsNotFound(err) {
+			t.Fatalf("expected a not found error, got %v", err)
+		}
+	}
+	if backend.Reads() != 3 {
+		t.Errorf("expected the not found objects to be read from the backend, got %d reads", backend.Reads())
+	}
+
+	opts := &internal.ListOptions{}
+	opts.LabelSelector = labels.SelectorFromSet(labels.Set{"app": "nginx"})
+	for i := 0; i < 2; i++ {
+		list := &unstructured.UnstructuredList{}
+		if err := rs.List(ctx, list, opts); err != nil {
+			t.Fatal(err)
+		}
+		if len(list.Items) != 1 {
+			t.Fatalf("unexpected pods: %v", list.Items)
+		}
+	}
+	if backend.Reads() != 4 {
+		t.Errorf("expected the second list to be served by the cache, got %d reads", backend.Reads())
+	}
+
+	// the different selectors and users are cached separately
+	opts.LabelSelector = labels.SelectorFromSet(labels.Set{"app": "redis"})
+	if err := rs.List(ctx, &unstructured.UnstructuredList{}, opts); err != nil {
+		t.Fatal(err)
+	}
+	userCtx := genericrequest.WithUser(ctx, &user.DefaultInfo{Name: "tenant-1"})
+	if err := rs.Get(userCtx, "cluster-1", "default", "nginx", &unstructured.Unstructured{}); err != nil {
+		t.Fatal(err)
+	}
+	if backend.Reads() != 6 {
+		t.Errorf("expected the other selector and user to be read from the backend, got %d reads", backend.Reads())
+	}
+}
+
+func TestInvalidation(t *testing.T) {
+	backend, _, factory, rs := newTestStorage(t)
+	ctx := context.Background()
+
+	get := func() string {
+		pod := &unstructured.Unstructured{}
+		if err := rs.Get(ctx, "cluster-1", "default", "nginx", pod); err != nil {
+			t.Fatal(err)
+		}
+		return pod.GetLabels()["app"]
+	}
+	if app := get(); app != "nginx" {
+		t.Fatalf("unexpected app %s", app)
+	}
+
+	if err := rs.Update(ctx, "cluster-1", newPod("default", "nginx", "web")); err != nil {
+		t.Fatal(err)
+	}
+	if app := get(); app != "web" {
+		t.Errorf("expected the cache to be invalidated by the update, got %s", app)
+	}
+
+	if err := factory.CleanCluster(ctx, "cluster-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.Get(ctx, "cluster-1", "default", "nginx", &unstructured.Unstructured{}); !storage.IsNotFound(err) {
+		t.Errorf("expected the cache to be invalidated by the cleanup, got %v", err)
+	}
+	if backend.Reads() != 3 {
+		t.Errorf("unexpected reads %d", backend.Reads())
+	}
+}
+
+func TestCacheUnavailable(t *testing.T) {
+	backend, memory, _, rs := newTestStorage(t)
+	memory.fail = true
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := rs.Get(ctx, "cluster-1", "default", "nginx", &unstructured.Unstructured{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rs.Update(ctx, "cluster-1", newPod("default", "nginx", "web")); err != nil {
+		t.Errorf("expected the write not to fail when the cache is unavailable, got %v", err)
+	}
+	if backend.Reads() != 2 {
+		t.Errorf("expected the reads to be served by the backend, got %d reads", backend.Reads())
+	}
+}
+
+func TestOptionalInterfaces(t *testing.T) {
+	_, _, _, rs := newTestStorage(t)
+
+	counter, ok := rs.(storage.ResourceCounter)
+	if !ok {
+		t.Fatal("expected the cache storage to be a ResourceCounter")
+	}
+	if _, err := counter.Count(context.Background(), &internal.ListOptions{}); err == nil {
+		t.Error("expected an error when the backend doesn't count the objects")
+	}
+
+	// the object is fully updated if the backend doesn't support the patch
+	patcher := rs.(storage.ResourcePatcher)
+	if err := patcher.UpdateWithPatch(context.Background(), "cluster-1", newPod("default", "nginx", "web"), storage.ResourcePatch{}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,162 @@
+package clickhousestorage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxExceptionSize bounds the size of the exception read from the responses of the failed queries
+const maxExceptionSize = 4 << 10
+
+// client sends the queries to the HTTP interface of ClickHouse, the values of the queries are bound by the query parameters,
+// eg. `{cluster:String}` is bound by `param_cluster`, so they are never interpolated into the queries.
+type client struct {
+	endpoint string
+	username string
+	password string
+	http     *http.Client
+}
+
+// Exception is the error returned by ClickHouse.
+type Exception struct {
+	Code    int
+	Message string
+}
+
+func (e *Exception) Error() string {
+	return fmt.Sprintf("clickhouse: code %d: %s", e.Code, e.Message)
+}
+
+// do sends the query with the parameters and the settings, the query is sent in the url if the body is not nil.
+func (c *client) do(ctx context.Context, query string, params map[string]string, settings url.Values, body io.Reader) (io.ReadCloser, error) {
+	values := url.Values{}
+	for key, value := range settings {
+		values[key] = value
+	}
+	for name, value := range params {
+		values.Set("param_"+name, value)
+	}
+	if body == nil {
+		body = strings.NewReader(query)
+	} else {
+		values.Set("query", query)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/?"+values.Encode(), body)
+	if err != nil {
+		return nil, err
+	}
+	if c.username != "" {
+		req.Header.Set("X-ClickHouse-User", c.username)
+	}
+	if c.password != "" {
+		req.Header.Set("X-ClickHouse-Key", c.password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, readException(resp)
+	}
+	return resp.Body, nil
+}
+
+func readException(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxExceptionSize))
+	exception := &Exception{Message: strings.TrimSpace(string(data))}
+	if code, err := strconv.Atoi(resp.Header.Get("X-ClickHouse-Exception-Code")); err == nil {
+		exception.Code = code
+	}
+	if exception.Message == "" {
+		exception.Message = resp.Status
+	}
+	return exception
+}
+
+func (c *client) exec(ctx context.Context, query string, params map[string]string) error {
+	body, err := c.do(ctx, query, params, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	_, err = io.Copy(io.Discard, body)
+	return err
+}
+
+// insert inserts the rows in the JSONEachRow format.
+func (c *client) insert(ctx context.Context, table string, settings url.Values, rows ...interface{}) error {
+	var buffer strings.Builder
+	encoder := json.NewEncoder(&buffer)
+	for _, row := range rows {
+		if err := encoder.Encode(row); err != nil {
+			return err
+		}
+	}
+
+	body, err := c.do(ctx, "INSERT INTO "+table+" FORMAT JSONEachRow", nil, settings, strings.NewReader(buffer.String()))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	_, err = io.Copy(io.Discard, body)
+	return err
+}
+
+// query selects the rows in the JSONEachRow format, and decodes each row by the decode function.
+func (c *client) query(ctx context.Context, query string, params map[string]string, decode func(row []byte) error) error {
+	body, err := c.do(ctx, query+" FORMAT JSONEachRow", params, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	// the rows contain the whole objects
+	scanner.Buffer(make([]byte, 64<<10), 64<<20)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := decode(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// count selects the count of the rows matched by the conditions
+func (c *client) count(ctx context.Context, query string, params map[string]string) (int64, error) {
+	var count int64
+	err := c.query(ctx, query, params, func(row []byte) error {
+		var result struct {
+			Count json.Number `json:"count"`
+		}
+		if err := json.Unmarshal(row, &result); err != nil {
+			return err
+		}
+		var err error
+		count, err = result.Count.Int64()
+		return err
+	})
+	return count, err
+}
+
+// quoteIdentifier quotes the identifier with the backquotes.
+func quoteIdentifier(name string) string {
+	return "`" + strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(name) + "`"
+}
+
+// quoteString quotes the string as a literal of ClickHouse, it is used to bind the elements of the array parameters.
+func quoteString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
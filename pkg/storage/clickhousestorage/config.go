@@ -0,0 +1,107 @@
+package clickhousestorage
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	defaultDatabase = "clusterpedia"
+	defaultTimeout  = 30 * time.Second
+)
+
+// Config is the config of the ClickHouse storage, which stores the resources in ClickHouse by its HTTP interface.
+//
+// The storage is intended for the high write rates and the aggregation queries of the resources such as the events and the pods
+// of hundreds of clusters, the objects are written by the inserts and deduplicated by the ReplacingMergeTree.
+type Config struct {
+	// Endpoint is the url of the HTTP interface of ClickHouse, eg. 'http://clickhouse:8123'.
+	Endpoint string `yaml:"endpoint" env:"CLICKHOUSE_ENDPOINT" required:"true"`
+
+	// Database is the database of the resources table, default is clusterpedia.
+	Database string `yaml:"database" env:"CLICKHOUSE_DATABASE"`
+
+	Username string `yaml:"username" env:"CLICKHOUSE_USERNAME"`
+	Password string `yaml:"password" env:"CLICKHOUSE_PASSWORD"`
+
+	CAFile   string `yaml:"caFile"`
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+
+	// Timeout bounds each request to ClickHouse, default is 30s.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// DisableAsyncInsert writes each object by a synchronous insert, by default the inserts are batched by the server
+	// with async_insert and the writes wait for the batches to be flushed, so the many small inserts don't create many parts.
+	DisableAsyncInsert bool `yaml:"disableAsyncInsert"`
+
+	// SkipMigration skips creating the database and the table at startup, eg. they are created by the administrators.
+	SkipMigration bool `yaml:"skipMigration" env:"DB_SKIP_MIGRATION"`
+}
+
+func (cfg *Config) validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("the endpoint of clickhouse is required")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return fmt.Errorf("the scheme of the endpoint should be http or https, but got %q", endpoint.Scheme)
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("timeout should not be negative")
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return errors.New("both certFile and keyFile are required")
+	}
+	return nil
+}
+
+func (cfg *Config) database() string {
+	if cfg.Database == "" {
+		return defaultDatabase
+	}
+	return cfg.Database
+}
+
+func (cfg *Config) timeout() time.Duration {
+	if cfg.Timeout == 0 {
+		return defaultTimeout
+	}
+	return cfg.Timeout
+}
+
+func (cfg *Config) genHTTPClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.CAFile != "" || cfg.CertFile != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.CAFile != "" {
+			ca, err := os.ReadFile(cfg.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read CA file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(ca) {
+				return nil, errors.New("unable to add CA to cert pool")
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if cfg.CertFile != "" {
+			cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &http.Client{Transport: transport, Timeout: cfg.timeout()}, nil
+}
@@ -0,0 +1,60 @@
+package clickhousestorage
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// the error codes of ClickHouse, https://github.com/ClickHouse/ClickHouse/blob/master/src/Common/ErrorCodes.cpp
+const (
+	codeCannotParseInput          = 27
+	codeNumberOfArgumentsMismatch = 42
+	codeIllegalTypeOfArgument     = 43
+	codeUnknownIdentifier         = 47
+	codeTypeMismatch              = 53
+	codeSyntaxError               = 62
+	codeTooLargeArraySize         = 128
+	codeTooLargeStringSize        = 131
+	codeTimeoutExceeded           = 159
+	codeTooManySimultaneousQuery  = 202
+	codeSocketTimeout             = 209
+	codeNetworkError              = 210
+	codeMemoryLimitExceeded       = 241
+	codeTableIsReadOnly           = 242
+	codeTooManyParts              = 252
+	codeAllConnectionTriesFailed  = 279
+	codeQueryWasCancelled         = 394
+	codeUnknownQueryParameter     = 456
+	codeBadQueryParameter         = 457
+)
+
+// InterpretClickHouseError maps the errors of ClickHouse to the storage errors.
+func InterpretClickHouseError(key string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var exception *Exception
+	if errors.As(err, &exception) {
+		switch exception.Code {
+		case codeTimeoutExceeded, codeTooManySimultaneousQuery, codeSocketTimeout, codeNetworkError,
+			codeMemoryLimitExceeded, codeTableIsReadOnly, codeTooManyParts, codeAllConnectionTriesFailed, codeQueryWasCancelled:
+			return storage.NewUnavailableError(key, err)
+		case codeTooLargeStringSize, codeTooLargeArraySize:
+			return storage.NewTooLargeError(key, err)
+		case codeUnknownIdentifier, codeSyntaxError, codeCannotParseInput, codeTypeMismatch, codeIllegalTypeOfArgument,
+			codeNumberOfArgumentsMismatch, codeUnknownQueryParameter, codeBadQueryParameter:
+			return storage.NewInvalidQueryError(key, err)
+		}
+		return err
+	}
+
+	var netError net.Error
+	if errors.As(err, &netError) || errors.Is(err, context.DeadlineExceeded) {
+		return storage.NewUnavailableError(key, err)
+	}
+	return err
+}
@@ -0,0 +1,267 @@
+package clickhousestorage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	createdAtFormat = "2006-01-02 15:04:05.000"
+	syncedAtFormat  = "2006-01-02 15:04:05.000000"
+)
+
+// orderByColumns are the expressions of the supported orderby fields
+var orderByColumns = map[string]string{
+	"cluster":          "cluster",
+	"namespace":        "namespace",
+	"name":             "name",
+	"created_at":       "created_at",
+	"resource_version": "toUInt64OrZero(resource_version)",
+}
+
+// whereClause builds the conditions of the queries, the values are bound by the query parameters.
+type whereClause struct {
+	conditions []string
+	params     map[string]string
+}
+
+func newWhereClause() *whereClause {
+	return &whereClause{params: make(map[string]string)}
+}
+
+// bind binds the value and returns its placeholder, the value of the String is escaped
+// since the query parameters are parsed in the escaped format.
+func (w *whereClause) bind(typ, value string) string {
+	name := "p" + strconv.Itoa(len(w.params))
+	if typ == "String" {
+		value = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`).Replace(value)
+	}
+	w.params[name] = value
+	return "{" + name + ":" + typ + "}"
+}
+
+func (w *whereClause) bindStrings(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, quoteString(value))
+	}
+	return w.bind("Array(String)", "["+strings.Join(quoted, ",")+"]")
+}
+
+func (w *whereClause) bindTime(t time.Time) string {
+	return w.bind("DateTime64(3, 'UTC')", t.UTC().Format(createdAtFormat))
+}
+
+func (w *whereClause) add(condition string) {
+	w.conditions = append(w.conditions, condition)
+}
+
+// in adds the condition that the column is one of the values
+func (w *whereClause) in(column string, values []string) {
+	switch len(values) {
+	case 0:
+	case 1:
+		w.add(column + " = " + w.bind("String", values[0]))
+	default:
+		w.add("has(" + w.bindStrings(values) + ", " + column + ")")
+	}
+}
+
+func (w *whereClause) String() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conditions, " AND ")
+}
+
+func newResourceWhereClause(gvr schema.GroupVersionResource) *whereClause {
+	w := newWhereClause()
+	w.add("`group` = " + w.bind("String", gvr.Group))
+	w.add("version = " + w.bind("String", gvr.Version))
+	w.add("resource = " + w.bind("String", gvr.Resource))
+	w.add("deleted = 0")
+	return w
+}
+
+// labelValue returns the conditions of the existence and the value of the label
+func (w *whereClause) labelValue(key string) (string, string) {
+	placeholder := w.bind("String", key)
+	return "mapContains(labels, " + placeholder + ")", "labels[" + placeholder + "]"
+}
+
+// jsonValue returns the conditions of the existence and the value of the field of the object,
+// the value of the scalar field other than the string is its json, eg. `3` and `true`.
+func (w *whereClause) jsonValue(fields ...string) (string, string) {
+	placeholders := make([]string, 0, len(fields))
+	for _, field := range fields {
+		placeholders = append(placeholders, w.bind("String", field))
+	}
+	path := "object, " + strings.Join(placeholders, ", ")
+	return "JSONHas(" + path + ")",
+		"if(JSONType(" + path + ") = 'String', JSONExtractString(" + path + "), JSONExtractRaw(" + path + "))"
+}
+
+// requirement adds the condition of the requirement of the selectors,
+// the requirements of the unsupported operators are ignored as the default storage layer.
+func (w *whereClause) requirement(has, value string, operator selection.Operator, values []string) {
+	switch operator {
+	case selection.Exists:
+		w.add(has)
+	case selection.DoesNotExist:
+		w.add("NOT " + has)
+	case selection.Equals, selection.DoubleEquals:
+		w.add("(" + has + " AND " + value + " = " + w.bind("String", values[0]) + ")")
+	case selection.NotEquals:
+		w.add("(NOT " + has + " OR " + value + " != " + w.bind("String", values[0]) + ")")
+	case selection.In:
+		w.add("(" + has + " AND has(" + w.bindStrings(values) + ", " + value + "))")
+	case selection.NotIn:
+		w.add("(NOT " + has + " OR NOT has(" + w.bindStrings(values) + ", " + value + "))")
+	}
+}
+
+func (w *whereClause) labelSelector(selector labels.Selector) {
+	if selector == nil {
+		return
+	}
+	requirements, selectable := selector.Requirements()
+	if !selectable {
+		return
+	}
+	for _, requirement := range requirements {
+		has, value := w.labelValue(requirement.Key())
+		w.requirement(has, value, requirement.Operator(), requirement.Values().List())
+	}
+}
+
+func (w *whereClause) extraLabelSelector(selector labels.Selector) {
+	if selector == nil {
+		return
+	}
+	requirements, selectable := selector.Requirements()
+	if !selectable {
+		return
+	}
+	for _, requirement := range requirements {
+		switch {
+		case requirement.Key() == internal.SearchLabelFuzzyName:
+			for _, name := range requirement.Values().List() {
+				w.add("position(name, " + w.bind("String", strings.TrimSpace(name)) + ") > 0")
+			}
+		case strings.HasPrefix(requirement.Key(), internal.ShadowAnnotationClusterLabelPrefix):
+			// the cluster labels are stamped on the objects as the annotations by the enrichment
+			has, value := w.jsonValue("metadata", "annotations", requirement.Key())
+			w.requirement(has, value, requirement.Operator(), requirement.Values().List())
+		}
+	}
+}
+
+func (w *whereClause) fieldSelector(opts *internal.ListOptions) error {
+	if opts.EnhancedFieldSelector == nil {
+		return nil
+	}
+	requirements, selectable := opts.EnhancedFieldSelector.Requirements()
+	if !selectable {
+		return nil
+	}
+	for _, requirement := range requirements {
+		var fields []string
+		for _, f := range requirement.Fields() {
+			if f.IsList() {
+				return storage.NewInvalidQueryError(f.Path().String(), fmt.Errorf("the list field is not supported by the %s storage", StorageName))
+			}
+			fields = append(fields, f.Name())
+		}
+		has, value := w.jsonValue(fields...)
+		w.requirement(has, value, requirement.Operator(), requirement.Values().List())
+	}
+	return nil
+}
+
+// listOptions adds the conditions of the list options, the limit, the continue and the order are not included.
+func (w *whereClause) listOptions(opts *internal.ListOptions) error {
+	if opts.OwnerName != "" || (opts.OwnerUID != "" && (len(opts.ClusterNames) != 1 || opts.OwnerSeniority != 0)) {
+		return storage.NewInvalidQueryError("", fmt.Errorf("the owner name, the owner seniority and the owner without a cluster are not supported by the %s storage", StorageName))
+	}
+
+	w.in("cluster", opts.ClusterNames)
+	w.in("namespace", opts.Namespaces)
+	w.in("name", opts.Names)
+	if opts.OwnerUID != "" {
+		w.add("owner_uid = " + w.bind("String", opts.OwnerUID))
+	}
+	if opts.Since != nil {
+		w.add("created_at >= " + w.bindTime(opts.Since.Time))
+	}
+	if opts.Before != nil {
+		w.add("created_at < " + w.bindTime(opts.Before.Time))
+	}
+	w.labelSelector(opts.LabelSelector)
+	w.extraLabelSelector(opts.ExtraLabelSelector)
+	return w.fieldSelector(opts)
+}
+
+// pagination returns the order, the limit and the offset of the list, the continue is the offset as the default storage layer.
+func pagination(opts *internal.ListOptions) (string, int64, error) {
+	var orderBy []string
+	for _, order := range opts.OrderBy {
+		column, ok := orderByColumns[order.Field]
+		if !ok {
+			return "", 0, storage.NewInvalidQueryError(order.Field, fmt.Errorf("orderby %s is not supported by the %s storage", order.Field, StorageName))
+		}
+		if order.Desc {
+			column += " DESC"
+		}
+		orderBy = append(orderBy, column)
+	}
+
+	var clause string
+	if len(orderBy) != 0 {
+		clause = " ORDER BY " + strings.Join(orderBy, ", ")
+	}
+
+	var offset int64
+	if opts.Continue != "" {
+		var err error
+		if offset, err = strconv.ParseInt(opts.Continue, 10, 64); err != nil || offset < 0 {
+			return "", 0, storage.NewInvalidQueryError(opts.Continue, fmt.Errorf("invalid continue: %s", opts.Continue))
+		}
+	}
+	if opts.Limit > 0 {
+		clause += fmt.Sprintf(" LIMIT %d OFFSET %d", opts.Limit, offset)
+	} else if offset > 0 {
+		clause += fmt.Sprintf(" OFFSET %d", offset)
+	}
+	return clause, offset, nil
+}
+
+// groupByExpression returns the expression of the group by key in the string
+func (w *whereClause) groupByExpression(groupBy storage.GroupBy) (string, error) {
+	switch groupBy.Type {
+	case storage.GroupByCluster:
+		return "cluster", nil
+	case storage.GroupByNamespace:
+		return "namespace", nil
+	case storage.GroupByLabel:
+		_, value := w.labelValue(groupBy.Label)
+		return value, nil
+	case storage.GroupByField:
+		_, value := w.jsonValue(groupBy.Fields...)
+		return value, nil
+	}
+	return "", storage.NewInvalidQueryError(string(groupBy.Type), fmt.Errorf("group by %s is not supported by the %s storage", groupBy.Type, StorageName))
+}
+
+func formatCreatedAt(t metav1.Time) string {
+	return t.UTC().Format(createdAtFormat)
+}
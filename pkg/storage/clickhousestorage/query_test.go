@@ -0,0 +1,99 @@
+package clickhousestorage
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/api/clusterpedia/fields"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var pods = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func TestWhereClauseListOptions(t *testing.T) {
+	selector, err := labels.Parse("app=nginx,!legacy,tier in (backend,frontend)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &internal.ListOptions{ClusterNames: []string{"cluster-1"}, Namespaces: []string{"default", "kube-system"}}
+	opts.LabelSelector = selector
+
+	w := newResourceWhereClause(pods)
+	if err := w.listOptions(opts); err != nil {
+		t.Fatal(err)
+	}
+	expected := " WHERE `group` = {p0:String} AND version = {p1:String} AND resource = {p2:String} AND deleted = 0" +
+		" AND cluster = {p3:String} AND has({p4:Array(String)}, namespace)" +
+		" AND (mapContains(labels, {p5:String}) AND labels[{p5:String}] = {p6:String})" +
+		" AND NOT mapContains(labels, {p7:String})" +
+		" AND (mapContains(labels, {p8:String}) AND has({p9:Array(String)}, labels[{p8:String}]))"
+	if w.String() != expected {
+		t.Errorf("String() = %s\nwant %s", w.String(), expected)
+	}
+	for name, value := range map[string]string{"p0": "", "p1": "v1", "p2": "pods", "p3": "cluster-1", "p4": "['default','kube-system']", "p9": "['backend','frontend']"} {
+		if w.params[name] != value {
+			t.Errorf("param %s = %q, want %q", name, w.params[name], value)
+		}
+	}
+}
+
+func TestWhereClauseFieldSelector(t *testing.T) {
+	selector, err := fields.Parse("status.phase=Running")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := newWhereClause()
+	if err := w.fieldSelector(&internal.ListOptions{EnhancedFieldSelector: selector}); err != nil {
+		t.Fatal(err)
+	}
+	path := "object, {p0:String}, {p1:String}"
+	expected := " WHERE (JSONHas(" + path + ") AND if(JSONType(" + path + ") = 'String', JSONExtractString(" + path + "), JSONExtractRaw(" + path + ")) = {p2:String})"
+	if w.String() != expected {
+		t.Errorf("String() = %s\nwant %s", w.String(), expected)
+	}
+	if w.params["p0"] != "status" || w.params["p1"] != "phase" || w.params["p2"] != "Running" {
+		t.Errorf("unexpected params: %v", w.params)
+	}
+}
+
+func TestWhereClauseBind(t *testing.T) {
+	w := newWhereClause()
+	if placeholder := w.bind("String", "a\\b\tc"); placeholder != "{p0:String}" {
+		t.Errorf("bind() = %s", placeholder)
+	}
+	if w.params["p0"] != `a\\b\tc` {
+		t.Errorf("expected the escaped value, got %q", w.params["p0"])
+	}
+
+	w.bindStrings([]string{"it's", `a\b`})
+	if w.params["p1"] != `['it\'s','a\\b']` {
+		t.Errorf("expected the quoted array, got %q", w.params["p1"])
+	}
+}
+
+func TestPagination(t *testing.T) {
+	opts := &internal.ListOptions{OrderBy: []internal.OrderBy{{Field: "namespace"}, {Field: "resource_version", Desc: true}}}
+	opts.Limit, opts.Continue = 10, "20"
+	clause, offset, err := pagination(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clause != " ORDER BY namespace, toUInt64OrZero(resource_version) DESC LIMIT 10 OFFSET 20" || offset != 20 {
+		t.Errorf("pagination() = %q, %d", clause, offset)
+	}
+
+	if _, _, err := pagination(&internal.ListOptions{OrderBy: []internal.OrderBy{{Field: "kind"}}}); !storage.IsInvalidQuery(err) {
+		t.Errorf("expected an invalid query error for the unsupported orderby, got %v", err)
+	}
+	for _, token := range []string{"-1", "token"} {
+		opts := &internal.ListOptions{}
+		opts.Continue = token
+		if _, _, err := pagination(opts); !storage.IsInvalidQuery(err) {
+			t.Errorf("expected an invalid query error for the continue %q, got %v", token, err)
+		}
+	}
+}
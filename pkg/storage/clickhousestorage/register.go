@@ -0,0 +1,73 @@
+package clickhousestorage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jinzhu/configor"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	StorageName = "clickhouse"
+)
+
+func init() {
+	storage.RegisterStorageFactoryFunc(StorageName, NewStorageFactory)
+}
+
+func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
+	if configPath == "" {
+		return nil, fmt.Errorf("configPath should not be empty")
+	}
+
+	cfg := &Config{}
+	if err := configor.Load(cfg, configPath); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	httpClient, err := cfg.genHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	factory := newStorageFactory(cfg, &client{
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		username: cfg.Username,
+		password: cfg.Password,
+		http:     httpClient,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout())
+	defer cancel()
+	if err := factory.HealthCheck(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to clickhouse: %w", err)
+	}
+
+	if cfg.SkipMigration {
+		return factory, nil
+	}
+	if err := factory.migrate(ctx); err != nil {
+		return nil, err
+	}
+	return factory, nil
+}
+
+func newStorageFactory(cfg *Config, client *client) *StorageFactory {
+	settings := url.Values{}
+	if !cfg.DisableAsyncInsert {
+		settings.Set("async_insert", "1")
+		settings.Set("wait_for_async_insert", "1")
+	}
+	return &StorageFactory{
+		client:   client,
+		database: cfg.database(),
+		table:    quoteIdentifier(cfg.database()) + "." + quoteIdentifier(resourcesTable),
+		settings: settings,
+	}
+}
@@ -0,0 +1,377 @@
+package clickhousestorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// ResourceStorage stores the objects of a resource in the resources table of ClickHouse.
+//
+// Each write inserts a row of the object without reading the stored row, so Create and Update are the same,
+// and Delete inserts a row marked as deleted. The objects are returned with the resource versions of the member clusters.
+type ResourceStorage struct {
+	client   *client
+	table    string
+	settings url.Values
+	config   storage.ResourceStorageConfig
+}
+
+var _ storage.ResourceStorage = &ResourceStorage{}
+var _ storage.ResourceCounter = &ResourceStorage{}
+var _ storage.ResourceAggregator = &ResourceStorage{}
+
+// row is a row of the resources table in the JSONEachRow format
+type row struct {
+	Group           string            `json:"group"`
+	Version         string            `json:"version"`
+	Resource        string            `json:"resource"`
+	Kind            string            `json:"kind"`
+	Cluster         string            `json:"cluster"`
+	Namespace       string            `json:"namespace"`
+	Name            string            `json:"name"`
+	UID             string            `json:"uid"`
+	OwnerUID        string            `json:"owner_uid"`
+	ResourceVersion string            `json:"resource_version"`
+	Labels          map[string]string `json:"labels"`
+	Object          string            `json:"object"`
+	CreatedAt       string            `json:"created_at"`
+	SyncedAt        string            `json:"synced_at"`
+	Deleted         uint8             `json:"deleted"`
+}
+
+func (s *ResourceStorage) GetStorageConfig() *storage.ResourceStorageConfig {
+	config := s.config
+	return &config
+}
+
+func (s *ResourceStorage) genRow(cluster string, obj runtime.Object) (*row, error) {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := s.config.Codec.Encode(obj, &buffer); err != nil {
+		return nil, err
+	}
+
+	var ownerUID string
+	if owner := metav1.GetControllerOfNoCopy(metaobj); owner != nil {
+		ownerUID = string(owner.UID)
+	}
+	gvr := s.config.StorageResource
+	return &row{
+		Group:           gvr.Group,
+		Version:         gvr.Version,
+		Resource:        gvr.Resource,
+		Kind:            obj.GetObjectKind().GroupVersionKind().Kind,
+		Cluster:         cluster,
+		Namespace:       metaobj.GetNamespace(),
+		Name:            metaobj.GetName(),
+		UID:             string(metaobj.GetUID()),
+		OwnerUID:        ownerUID,
+		ResourceVersion: metaobj.GetResourceVersion(),
+		Labels:          metaobj.GetLabels(),
+		Object:          buffer.String(),
+		CreatedAt:       formatCreatedAt(metaobj.GetCreationTimestamp()),
+		SyncedAt:        time.Now().UTC().Format(syncedAtFormat),
+	}, nil
+}
+
+func (s *ResourceStorage) objectKey(cluster, namespace, name string) string {
+	return strings.Join([]string{s.config.StorageResource.String(), cluster, namespace, name}, "/")
+}
+
+func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		return fmt.Errorf("%s: kind is required", gvk)
+	}
+	return s.write(ctx, cluster, obj)
+}
+
+func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) error {
+	return s.write(ctx, cluster, obj)
+}
+
+func (s *ResourceStorage) write(ctx context.Context, cluster string, obj runtime.Object) error {
+	r, err := s.genRow(cluster, obj)
+	if err != nil {
+		return err
+	}
+	if r.Labels == nil {
+		r.Labels = map[string]string{}
+	}
+	if err := s.client.insert(ctx, s.table, s.settings, r); err != nil {
+		return InterpretClickHouseError(s.objectKey(cluster, r.Namespace, r.Name), err)
+	}
+	return nil
+}
+
+func (s *ResourceStorage) ConvertDeletedObject(obj interface{}) (runtime.Object, error) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}, nil
+}
+
+// Delete inserts the row marked as deleted, which replaces the rows of the object when the parts are merged.
+func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) error {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+
+	gvr := s.config.StorageResource
+	r := &row{
+		Group:     gvr.Group,
+		Version:   gvr.Version,
+		Resource:  gvr.Resource,
+		Cluster:   cluster,
+		Namespace: metaobj.GetNamespace(),
+		Name:      metaobj.GetName(),
+		Labels:    map[string]string{},
+		CreatedAt: formatCreatedAt(metav1.Time{}),
+		SyncedAt:  time.Now().UTC().Format(syncedAtFormat),
+		Deleted:   1,
+	}
+	if err := s.client.insert(ctx, s.table, s.settings, r); err != nil {
+		return InterpretClickHouseError(s.objectKey(cluster, r.Namespace, r.Name), err)
+	}
+	return nil
+}
+
+// RecordEvent is a no-op, the events of the resources are not stored by the clickhouse storage.
+func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event *corev1.Event) error {
+	return nil
+}
+
+func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, into runtime.Object) error {
+	key := s.objectKey(cluster, namespace, name)
+
+	w := newResourceWhereClause(s.config.StorageResource)
+	w.add("cluster = " + w.bind("String", cluster))
+	w.add("namespace = " + w.bind("String", namespace))
+	w.add("name = " + w.bind("String", name))
+
+	var object []byte
+	query := "SELECT object FROM " + s.table + " FINAL" + w.String() + " LIMIT 1"
+	if err := s.client.query(ctx, query, w.params, func(data []byte) error {
+		var err error
+		object, err = decodeObject(data)
+		return err
+	}); err != nil {
+		return InterpretClickHouseError(key, err)
+	}
+	if object == nil {
+		return storage.NewNotFoundError(key, errors.New("the object does not exist"))
+	}
+
+	obj, _, err := s.config.Codec.Decode(object, nil, into)
+	if err != nil {
+		return err
+	}
+	if obj != into {
+		return fmt.Errorf("failed to decode resource, into is %T", into)
+	}
+	return nil
+}
+
+func decodeObject(data []byte) ([]byte, error) {
+	var result struct {
+		Object string `json:"object"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return []byte(result.Object), nil
+}
+
+func (s *ResourceStorage) listWhereClause(opts *internal.ListOptions) (*whereClause, error) {
+	w := newResourceWhereClause(s.config.StorageResource)
+	if err := w.listOptions(opts); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (s *ResourceStorage) List(ctx context.Context, listObject runtime.Object, opts *internal.ListOptions) error {
+	w, err := s.listWhereClause(opts)
+	if err != nil {
+		return err
+	}
+	clause, offset, err := pagination(opts)
+	if err != nil {
+		return err
+	}
+
+	var objects [][]byte
+	query := "SELECT object FROM " + s.table + " FINAL" + w.String() + clause
+	if err := s.client.query(ctx, query, w.params, func(data []byte) error {
+		object, err := decodeObject(data)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, object)
+		return nil
+	}); err != nil {
+		return InterpretClickHouseError(s.config.StorageResource.String(), err)
+	}
+
+	list, err := meta.ListAccessor(listObject)
+	if err != nil {
+		return err
+	}
+	if opts.WithContinue != nil && *opts.WithContinue {
+		if int64(len(objects)) == opts.Limit {
+			list.SetContinue(strconv.FormatInt(offset+opts.Limit, 10))
+		}
+	}
+	if opts.WithRemainingCount != nil && *opts.WithRemainingCount {
+		amount, err := s.client.count(ctx, "SELECT count() AS count FROM "+s.table+" FINAL"+w.String(), w.params)
+		if err != nil {
+			return InterpretClickHouseError(s.config.StorageResource.String(), err)
+		}
+		// keep `amount = offset + len(objects) + remain` as the default storage layer
+		remain := amount - offset - int64(len(objects))
+		list.SetRemainingItemCount(&remain)
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+
+	if unstructuredList, ok := listObject.(*unstructured.UnstructuredList); ok {
+		unstructuredList.Items = make([]unstructured.Unstructured, 0, len(objects))
+		for _, object := range objects {
+			obj, _, err := s.config.Codec.Decode(object, nil, &unstructured.Unstructured{})
+			if err != nil {
+				return err
+			}
+			uObj, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("the decoded object is not *unstructured.Unstructured")
+			}
+			unstructuredList.Items = append(unstructuredList.Items, *uObj)
+		}
+		return nil
+	}
+
+	listPtr, err := meta.GetItemsPtr(listObject)
+	if err != nil {
+		return err
+	}
+	v, err := conversion.EnforcePtr(listPtr)
+	if err != nil || v.Kind() != reflect.Slice {
+		return fmt.Errorf("need ptr to slice: %v", err)
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(objects), len(objects))
+	expected := reflect.New(v.Type().Elem()).Interface().(runtime.Object)
+	for i, object := range objects {
+		obj, _, err := s.config.Codec.Decode(object, nil, expected.DeepCopyObject())
+		if err != nil {
+			return err
+		}
+		slice.Index(i).Set(reflect.ValueOf(obj).Elem())
+	}
+	v.Set(slice)
+	return nil
+}
+
+func (s *ResourceStorage) Watch(_ context.Context, _ *internal.ListOptions) (watch.Interface, error) {
+	return nil, apierrors.NewMethodNotSupported(s.config.StorageResource.GroupResource(), "watch")
+}
+
+func (s *ResourceStorage) Count(ctx context.Context, opts *internal.ListOptions) (int64, error) {
+	w, err := s.listWhereClause(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := s.client.count(ctx, "SELECT count() AS count FROM "+s.table+" FINAL"+w.String(), w.params)
+	if err != nil {
+		return 0, InterpretClickHouseError(s.config.StorageResource.String(), err)
+	}
+	return count, nil
+}
+
+func (s *ResourceStorage) Aggregate(ctx context.Context, opts *internal.ListOptions, groupBy []storage.GroupBy) ([]storage.AggregationGroup, error) {
+	if len(groupBy) == 0 {
+		return nil, storage.NewInvalidQueryError("", errors.New("group by is required"))
+	}
+
+	w, err := s.listWhereClause(opts)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(groupBy))
+	columns := make([]string, 0, len(groupBy))
+	for i, by := range groupBy {
+		expression, err := w.groupByExpression(by)
+		if err != nil {
+			return nil, err
+		}
+		key := "g" + strconv.Itoa(i)
+		keys = append(keys, key)
+		columns = append(columns, "toString("+expression+") AS "+key)
+	}
+
+	query := "SELECT " + strings.Join(columns, ", ") + ", count() AS count FROM " + s.table + " FINAL" + w.String() +
+		" GROUP BY " + strings.Join(keys, ", ") + " ORDER BY count DESC, " + strings.Join(keys, ", ")
+	if opts.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", opts.Limit)
+	}
+
+	var groups []storage.AggregationGroup
+	if err := s.client.query(ctx, query, w.params, func(data []byte) error {
+		var result map[string]json.RawMessage
+		if err := json.Unmarshal(data, &result); err != nil {
+			return err
+		}
+
+		group := storage.AggregationGroup{Values: make([]string, len(keys))}
+		for i, key := range keys {
+			if err := json.Unmarshal(result[key], &group.Values[i]); err != nil {
+				return err
+			}
+		}
+		var count json.Number
+		if err := json.Unmarshal(result["count"], &count); err != nil {
+			return err
+		}
+		var err error
+		group.Count, err = count.Int64()
+		groups = append(groups, group)
+		return err
+	}); err != nil {
+		return nil, InterpretClickHouseError(s.config.StorageResource.String(), err)
+	}
+	return groups, nil
+}
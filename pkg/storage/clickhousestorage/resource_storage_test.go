@@ -0,0 +1,225 @@
+package clickhousestorage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// fakeServer records the requests to the HTTP interface and responds by the respond function
+type fakeServer struct {
+	sync.Mutex
+	queries []string
+	params  []map[string]string
+	bodies  []string
+
+	respond func(w http.ResponseWriter, query string)
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		query, body = string(body), nil
+	}
+	params := make(map[string]string)
+	for key := range r.URL.Query() {
+		if name, ok := strings.CutPrefix(key, "param_"); ok {
+			params[name] = r.URL.Query().Get(key)
+		}
+	}
+
+	f.Lock()
+	f.queries = append(f.queries, query)
+	f.params = append(f.params, params)
+	f.bodies = append(f.bodies, string(body))
+	f.Unlock()
+	if f.respond != nil {
+		f.respond(w, query)
+	}
+}
+
+func newTestFactory(t *testing.T, fake *fakeServer) *StorageFactory {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return newStorageFactory(&Config{}, &client{endpoint: server.URL, http: server.Client()})
+}
+
+func newTestResourceStorage(t *testing.T, fake *fakeServer) *ResourceStorage {
+	factory := newTestFactory(t, fake)
+	rs, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: resourceconfig.ResourceConfig{
+		StorageResource: corev1.SchemeGroupVersion.WithResource("pods"),
+		Codec:           scheme.LegacyResourceCodecs.LegacyCodec(corev1.SchemeGroupVersion),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs.(*ResourceStorage)
+}
+
+func newTestPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "default", UID: types.UID("uid-" + name), ResourceVersion: "10",
+			Labels:          map[string]string{"app": "nginx"},
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs", UID: "uid-rs", Controller: &[]bool{true}[0]}},
+		},
+	}
+}
+
+func TestResourceStorageWrite(t *testing.T) {
+	fake := &fakeServer{}
+	rs := newTestResourceStorage(t, fake)
+	ctx := context.Background()
+
+	if err := rs.Create(ctx, "cluster-1", newTestPod("pod-1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.Delete(ctx, "cluster-1", newTestPod("pod-1")); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.queries) != 2 {
+		t.Fatalf("expected 2 inserts, got %v", fake.queries)
+	}
+
+	var created, deleted row
+	if err := json.Unmarshal([]byte(fake.bodies[0]), &created); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(fake.bodies[1]), &deleted); err != nil {
+		t.Fatal(err)
+	}
+	if fake.queries[0] != "INSERT INTO `clusterpedia`.`resources` FORMAT JSONEachRow" {
+		t.Errorf("unexpected insert: %s", fake.queries[0])
+	}
+	if created.Cluster != "cluster-1" || created.Kind != "Pod" || created.OwnerUID != "uid-rs" || created.ResourceVersion != "10" ||
+		created.Labels["app"] != "nginx" || created.Deleted != 0 || !strings.Contains(created.Object, `"name":"pod-1"`) {
+		t.Errorf("unexpected created row: %+v", created)
+	}
+	if deleted.Name != "pod-1" || deleted.Deleted != 1 || deleted.Object != "" {
+		t.Errorf("unexpected deleted row: %+v", deleted)
+	}
+}
+
+func TestResourceStorageList(t *testing.T) {
+	fake := &fakeServer{respond: func(w http.ResponseWriter, query string) {
+		if strings.HasPrefix(query, "SELECT count()") {
+			_, _ = io.WriteString(w, `{"count":"3"}`+"\n")
+			return
+		}
+		for _, name := range []string{"pod-1", "pod-2"} {
+			object, _ := json.Marshal(newTestPod(name))
+			data, _ := json.Marshal(map[string]string{"object": string(object)})
+			_, _ = w.Write(append(data, '\n'))
+		}
+	}}
+	rs := newTestResourceStorage(t, fake)
+
+	opts := &internal.ListOptions{ClusterNames: []string{"cluster-1"}}
+	opts.Limit, opts.WithContinue, opts.WithRemainingCount = 2, &[]bool{true}[0], &[]bool{true}[0]
+	list := &corev1.PodList{}
+	if err := rs.List(context.Background(), list, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 || list.Items[1].Name != "pod-2" {
+		t.Fatalf("unexpected pods: %v", list.Items)
+	}
+	if list.Continue != "2" || list.RemainingItemCount == nil || *list.RemainingItemCount != 1 {
+		t.Errorf("unexpected continue %q and remaining count %v", list.Continue, list.RemainingItemCount)
+	}
+	if !strings.Contains(fake.queries[0], "FINAL WHERE") || !strings.HasSuffix(fake.queries[0], "LIMIT 2 OFFSET 0 FORMAT JSONEachRow") {
+		t.Errorf("unexpected list query: %s", fake.queries[0])
+	}
+	if fake.params[0]["p3"] != "cluster-1" {
+		t.Errorf("expected the cluster to be bound, got %v", fake.params[0])
+	}
+}
+
+func TestResourceStorageGet(t *testing.T) {
+	fake := &fakeServer{}
+	rs := newTestResourceStorage(t, fake)
+
+	if err := rs.Get(context.Background(), "cluster-1", "default", "pod-1", &corev1.Pod{}); !storage.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestResourceStorageAggregate(t *testing.T) {
+	fake := &fakeServer{respond: func(w http.ResponseWriter, query string) {
+		_, _ = io.WriteString(w, `{"g0":"cluster-1","g1":"Running","count":"5"}`+"\n"+`{"g0":"cluster-2","g1":"","count":"2"}`+"\n")
+	}}
+	rs := newTestResourceStorage(t, fake)
+
+	groups, err := rs.Aggregate(context.Background(), &internal.ListOptions{}, []storage.GroupBy{
+		{Type: storage.GroupByCluster},
+		{Type: storage.GroupByField, Fields: []string{"status", "phase"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 || groups[0].Values[1] != "Running" || groups[0].Count != 5 || groups[1].Values[0] != "cluster-2" {
+		t.Errorf("unexpected groups: %+v", groups)
+	}
+	if !strings.Contains(fake.queries[0], "GROUP BY g0, g1 ORDER BY count DESC, g0, g1") {
+		t.Errorf("unexpected aggregation query: %s", fake.queries[0])
+	}
+
+	if _, err := rs.Aggregate(context.Background(), &internal.ListOptions{}, []storage.GroupBy{{Type: storage.GroupByResource}}); !storage.IsInvalidQuery(err) {
+		t.Errorf("expected an invalid query error for grouping by the resource, got %v", err)
+	}
+}
+
+func TestStorageFactoryGetResourceVersions(t *testing.T) {
+	fake := &fakeServer{respond: func(w http.ResponseWriter, query string) {
+		_, _ = io.WriteString(w, `{"group":"","version":"v1","resource":"pods","namespace":"default","name":"pod-1","resource_version":"10"}`+"\n"+
+			`{"group":"","version":"v1","resource":"namespaces","namespace":"","name":"default","resource_version":"3"}`+"\n")
+	}}
+	factory := newTestFactory(t, fake)
+
+	versions, err := factory.GetResourceVersions(context.Background(), "cluster-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if versions[corev1.SchemeGroupVersion.WithResource("pods")].Resources["default/pod-1"] != "10" ||
+		versions[corev1.SchemeGroupVersion.WithResource("namespaces")].Resources["default"] != "3" {
+		t.Errorf("unexpected resource versions: %v", versions)
+	}
+}
+
+func TestInterpretClickHouseError(t *testing.T) {
+	fake := &fakeServer{respond: func(w http.ResponseWriter, query string) {
+		code := "62"
+		if strings.HasPrefix(query, "DELETE") {
+			code = "159"
+		}
+		w.Header().Set("X-ClickHouse-Exception-Code", code)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = io.WriteString(w, "Code: "+code+". DB::Exception")
+	}}
+	factory := newTestFactory(t, fake)
+
+	if err := factory.HealthCheck(context.Background()); !storage.IsInvalidQuery(err) {
+		t.Errorf("expected an invalid query error, got %v", err)
+	}
+	if err := factory.CleanCluster(context.Background(), "cluster-1"); !storage.IsUnavailable(err) {
+		t.Errorf("expected an unavailable error, got %v", err)
+	}
+	if err := InterpretClickHouseError("key", &Exception{Code: 60, Message: "unknown table"}); storage.ErrorCodeOf(err) != "" {
+		t.Errorf("expected the unknown error, got %v", err)
+	}
+}
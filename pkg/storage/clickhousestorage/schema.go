@@ -0,0 +1,59 @@
+package clickhousestorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const resourcesTable = "resources"
+
+// the table keeps one row of each object for each write, the rows of an object are deduplicated by the ReplacingMergeTree
+// which keeps the row with the latest synced_at, and the deleted objects are kept as the rows marked by the deleted column,
+// so the queries read the table with FINAL and exclude the deleted rows.
+//
+// The lightweight deletes, which clean the clusters and purge the resources, are supported since ClickHouse 23.3.
+const createResourcesTable = `CREATE TABLE IF NOT EXISTS %s (
+	` + "`group`" + ` LowCardinality(String),
+	version LowCardinality(String),
+	resource LowCardinality(String),
+	kind LowCardinality(String),
+	cluster LowCardinality(String),
+	namespace String,
+	name String,
+	uid String,
+	owner_uid String,
+	resource_version String,
+	labels Map(String, String),
+	object String CODEC(ZSTD(3)),
+	created_at DateTime64(3, 'UTC'),
+	synced_at DateTime64(6, 'UTC'),
+	deleted UInt8
+) ENGINE = ReplacingMergeTree(synced_at)
+ORDER BY (` + "`group`" + `, version, resource, cluster, namespace, name)`
+
+func (s *StorageFactory) migrate(ctx context.Context) error {
+	if err := s.client.exec(ctx, "CREATE DATABASE IF NOT EXISTS "+quoteIdentifier(s.database), nil); err != nil {
+		return fmt.Errorf("failed to create the database %s: %w", s.database, err)
+	}
+	if err := s.client.exec(ctx, fmt.Sprintf(createResourcesTable, s.table), nil); err != nil {
+		return fmt.Errorf("failed to create the table %s: %w", s.table, err)
+	}
+	return nil
+}
+
+// tableExists checks whether the resources table is created
+func (s *StorageFactory) tableExists(ctx context.Context) (bool, error) {
+	var exists bool
+	err := s.client.query(ctx, "EXISTS TABLE "+s.table, nil, func(row []byte) error {
+		var result struct {
+			Result json.Number `json:"result"`
+		}
+		if err := json.Unmarshal(row, &result); err != nil {
+			return err
+		}
+		exists = result.Result == "1"
+		return nil
+	})
+	return exists, err
+}
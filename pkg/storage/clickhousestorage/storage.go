@@ -0,0 +1,158 @@
+package clickhousestorage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type StorageFactory struct {
+	client   *client
+	database string
+
+	// table is the quoted name of the resources table
+	table string
+
+	// settings are the settings of the inserts
+	settings url.Values
+}
+
+var _ storage.StorageFactory = &StorageFactory{}
+var _ storage.ResourcePurger = &StorageFactory{}
+var _ storage.HealthChecker = &StorageFactory{}
+
+func (s *StorageFactory) GetSupportedRequestVerbs() []string {
+	return []string{"get", "list"}
+}
+
+func (s *StorageFactory) PrepareCluster(cluster string) error {
+	return nil
+}
+
+func (s *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
+	return &ResourceStorage{
+		client:   s.client,
+		table:    s.table,
+		settings: s.settings,
+		config:   *config,
+	}, nil
+}
+
+func (s *StorageFactory) NewCollectionResourceStorage(cr *internal.CollectionResource) (storage.CollectionResourceStorage, error) {
+	return nil, errors.New("collection resources are not supported by the clickhouse storage")
+}
+
+func (s *StorageFactory) GetCollectionResources(ctx context.Context) ([]*internal.CollectionResource, error) {
+	return nil, nil
+}
+
+func (s *StorageFactory) GetResourceVersions(ctx context.Context, cluster string) (map[schema.GroupVersionResource]storage.ClusterResourceVersions, error) {
+	w := newWhereClause()
+	w.add("cluster = " + w.bind("String", cluster))
+	w.add("deleted = 0")
+
+	resourceversions := make(map[schema.GroupVersionResource]storage.ClusterResourceVersions)
+	query := "SELECT `group`, version, resource, namespace, name, resource_version FROM " + s.table + " FINAL" + w.String()
+	if err := s.client.query(ctx, query, w.params, func(data []byte) error {
+		var r row
+		if err := json.Unmarshal(data, &r); err != nil {
+			return err
+		}
+
+		gvr := schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+		versions, ok := resourceversions[gvr]
+		if !ok {
+			versions = storage.ClusterResourceVersions{
+				Resources: make(map[string]interface{}),
+				Events:    make(map[string]interface{}),
+			}
+			resourceversions[gvr] = versions
+		}
+
+		key := r.Name
+		if r.Namespace != "" {
+			key = r.Namespace + "/" + r.Name
+		}
+		versions.Resources[key] = r.ResourceVersion
+		return nil
+	}); err != nil {
+		return nil, InterpretClickHouseError(cluster, err)
+	}
+	return resourceversions, nil
+}
+
+func (s *StorageFactory) CleanCluster(ctx context.Context, cluster string) error {
+	w := newWhereClause()
+	w.add("cluster = " + w.bind("String", cluster))
+	if err := s.client.exec(ctx, "DELETE FROM "+s.table+w.String(), w.params); err != nil {
+		return InterpretClickHouseError(cluster, err)
+	}
+	return nil
+}
+
+func (s *StorageFactory) CleanClusterResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) error {
+	w := newWhereClause()
+	w.add("`group` = " + w.bind("String", gvr.Group))
+	w.add("version = " + w.bind("String", gvr.Version))
+	w.add("resource = " + w.bind("String", gvr.Resource))
+	w.add("cluster = " + w.bind("String", cluster))
+	if err := s.client.exec(ctx, "DELETE FROM "+s.table+w.String(), w.params); err != nil {
+		return InterpretClickHouseError(gvr.String(), err)
+	}
+	return nil
+}
+
+// PurgeResources deletes the rows of the objects created before the time, including the rows marked as deleted,
+// the lightweight deletes don't return the number of the deleted rows, so the rows are counted before the deletion.
+func (s *StorageFactory) PurgeResources(ctx context.Context, opts storage.PurgeOptions) (int64, error) {
+	w := newWhereClause()
+	w.add("`group` = " + w.bind("String", opts.Resource.Group))
+	w.add("resource = " + w.bind("String", opts.Resource.Resource))
+	w.in("cluster", opts.Clusters)
+	if len(opts.ExcludedClusters) != 0 {
+		w.add("NOT has(" + w.bindStrings(opts.ExcludedClusters) + ", cluster)")
+	}
+	w.add("created_at < " + w.bindTime(opts.CreatedBefore))
+
+	count, err := s.client.count(ctx, "SELECT count() AS count FROM "+s.table+" FINAL"+w.String()+" AND deleted = 0", w.params)
+	if err != nil {
+		return 0, InterpretClickHouseError(opts.Resource.String(), err)
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	if err := s.client.exec(ctx, "DELETE FROM "+s.table+w.String(), w.params); err != nil {
+		return 0, InterpretClickHouseError(opts.Resource.String(), err)
+	}
+	return count, nil
+}
+
+func (s *StorageFactory) HealthCheck(ctx context.Context) error {
+	if err := s.client.exec(ctx, "SELECT 1", nil); err != nil {
+		return InterpretClickHouseError("", err)
+	}
+	return nil
+}
+
+func (s *StorageFactory) Readiness(ctx context.Context) error {
+	exists, err := s.tableExists(ctx)
+	if err != nil {
+		return InterpretClickHouseError(s.table, err)
+	}
+	if !exists {
+		return fmt.Errorf("the table %s is not created", s.table)
+	}
+	return nil
+}
+
+func (s *StorageFactory) Shutdown() error {
+	s.client.http.CloseIdleConnections()
+	return nil
+}
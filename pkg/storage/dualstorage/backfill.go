@@ -0,0 +1,255 @@
+package dualstorage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	backfillListLimit = 500
+
+	// resourceStorageWaitTimeout is the time to wait for the resource synchro to create the resource storage,
+	// the resources without the resource storage are backfilled in the next period.
+	resourceStorageWaitTimeout = time.Minute
+)
+
+type clusterResource struct {
+	cluster string
+	gvr     schema.GroupVersionResource
+}
+
+// backfillState serializes the writes to the secondary storage of a cluster resource
+// between the resource synchro and the backfill.
+type backfillState struct {
+	lock sync.Mutex
+
+	// written records the keys written by the resource synchro during the backfill,
+	// the objects listed by the backfill are older than them and are skipped.
+	written sets.Set[string]
+
+	// failed records whether the writes to the secondary storage failed during the backfill.
+	failed bool
+
+	caughtUp bool
+}
+
+func (f *StorageFactory) scheduleBackfill(cluster string) {
+	select {
+	case f.backfills <- cluster:
+	default:
+		// the cluster is backfilled by the next period
+	}
+}
+
+// runBackfill backfills the resources one cluster at a time to limit the load of the storages.
+func (f *StorageFactory) runBackfill(period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case cluster := <-f.backfills:
+			f.backfillCluster(f.ctx, cluster)
+		case <-ticker.C:
+			for _, cluster := range f.pendingClusters() {
+				f.backfillCluster(f.ctx, cluster)
+			}
+		}
+	}
+}
+
+// pendingClusters returns the clusters which have not caught up.
+func (f *StorageFactory) pendingClusters() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	pendings := sets.New[string]()
+	for cluster, caughtUp := range f.clusters {
+		if !caughtUp {
+			pendings.Insert(cluster)
+		}
+	}
+	for cr, state := range f.states {
+		if _, ok := f.clusters[cr.cluster]; !ok {
+			continue
+		}
+
+		state.lock.Lock()
+		if !state.caughtUp {
+			pendings.Insert(cr.cluster)
+		}
+		state.lock.Unlock()
+	}
+	return sets.List(pendings)
+}
+
+// backfillCluster copies the resources of the cluster which are missing or stale in the secondary storage
+// from the primary storage, and deletes the resources which are not in the primary storage.
+func (f *StorageFactory) backfillCluster(ctx context.Context, cluster string) {
+	primaryVersions, err := f.primary.GetResourceVersions(ctx, cluster)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the resource versions from the primary storage", "cluster", cluster)
+		return
+	}
+	secondaryVersions, err := f.secondary.GetResourceVersions(ctx, cluster)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the resource versions from the secondary storage", "cluster", cluster)
+		return
+	}
+
+	caughtUp := true
+	for gvr, versions := range primaryVersions {
+		s := f.waitResourceStorage(ctx, gvr)
+		if s == nil {
+			klog.V(2).InfoS("The resource storage is not created, skip the backfill", "cluster", cluster, "resource", gvr)
+			caughtUp = false
+			continue
+		}
+
+		if err := f.backfillResource(ctx, s, cluster, versions.Resources, secondaryVersions[gvr].Resources); err != nil {
+			klog.ErrorS(err, "Failed to backfill the resources to the secondary storage", "cluster", cluster, "resource", gvr)
+			caughtUp = false
+		}
+	}
+
+	for gvr := range secondaryVersions {
+		if _, ok := primaryVersions[gvr]; ok {
+			continue
+		}
+		if err := f.secondary.CleanClusterResource(ctx, cluster, gvr); err != nil {
+			klog.ErrorS(err, "Failed to clean the resources from the secondary storage", "cluster", cluster, "resource", gvr)
+			caughtUp = false
+			continue
+		}
+
+		f.lock.Lock()
+		delete(f.states, clusterResource{cluster: cluster, gvr: gvr})
+		f.lock.Unlock()
+	}
+
+	f.lock.Lock()
+	if _, ok := f.clusters[cluster]; ok {
+		f.clusters[cluster] = caughtUp
+	}
+	f.lock.Unlock()
+	if caughtUp {
+		klog.InfoS("The secondary storage has caught up with the primary storage", "cluster", cluster)
+	}
+}
+
+func (f *StorageFactory) waitResourceStorage(ctx context.Context, gvr schema.GroupVersionResource) *ResourceStorage {
+	var s *ResourceStorage
+	_ = wait.PollUntilContextTimeout(ctx, time.Second, resourceStorageWaitTimeout, true, func(context.Context) (bool, error) {
+		s = f.resourceStorage(gvr)
+		return s != nil, nil
+	})
+	return s
+}
+
+func (f *StorageFactory) backfillResource(ctx context.Context, s *ResourceStorage, cluster string, primaryRVs, secondaryRVs map[string]interface{}) (err error) {
+	gvr := s.primary.GetStorageConfig().StorageResource
+	state := f.state(cluster, gvr)
+	state.lock.Lock()
+	state.written, state.failed = sets.New[string](), false
+	state.lock.Unlock()
+
+	defer func() {
+		state.lock.Lock()
+		state.caughtUp = err == nil && !state.failed
+		state.written = nil
+		state.lock.Unlock()
+	}()
+
+	stales := make(map[string]interface{}, len(secondaryRVs))
+	for key, rv := range secondaryRVs {
+		stales[key] = rv
+	}
+	unlisted := sets.KeySet(primaryRVs)
+
+	withContinue := true
+	opts := &internal.ListOptions{
+		ClusterNames: []string{cluster},
+		OrderBy:      []internal.OrderBy{{Field: "namespace"}, {Field: "name"}},
+		WithContinue: &withContinue,
+	}
+	opts.Limit = backfillListLimit
+	for {
+		list := &unstructured.UnstructuredList{}
+		if err := s.primary.List(ctx, list, opts); err != nil {
+			if storage.IsInvalidQuery(err) && len(opts.OrderBy) != 0 && opts.Continue == "" {
+				// the storage does not support ordering, the objects are ordered natively, such as etcd
+				opts.OrderBy = nil
+				continue
+			}
+			return err
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			key, _ := cache.MetaNamespaceKeyFunc(obj)
+			unlisted.Delete(key)
+
+			rv, stored := stales[key]
+			delete(stales, key)
+			if stored && fmt.Sprint(rv) == obj.GetResourceVersion() {
+				continue
+			}
+			if err := state.backfill(key, func() error { return s.upsertSecondary(ctx, cluster, obj) }); err != nil {
+				return err
+			}
+		}
+
+		if list.GetContinue() == "" {
+			break
+		}
+		opts.Continue = list.GetContinue()
+	}
+
+	for key := range stales {
+		if _, ok := primaryRVs[key]; ok {
+			// the object may be skipped by the pagination of the primary storage
+			continue
+		}
+
+		namespace, name, _ := cache.SplitMetaNamespaceKey(key)
+		obj := &unstructured.Unstructured{}
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		if err := state.backfill(key, func() error { return s.deleteSecondary(ctx, cluster, obj) }); err != nil {
+			return err
+		}
+	}
+
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	if unlisted = unlisted.Difference(state.written); unlisted.Len() != 0 {
+		return fmt.Errorf("%d objects are not listed from the primary storage", unlisted.Len())
+	}
+	return nil
+}
+
+// backfill writes the object listed from the primary storage to the secondary storage,
+// unless the newer object has been written by the resource synchro.
+func (state *backfillState) backfill(key string, write func() error) error {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	if state.written.Has(key) {
+		return nil
+	}
+	return write()
+}
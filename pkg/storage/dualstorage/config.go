@@ -0,0 +1,66 @@
+package dualstorage
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ReadsPrimary   = "primary"
+	ReadsSecondary = "secondary"
+
+	defaultBackfillPeriod = 10 * time.Minute
+)
+
+// Config is the config of the dual storage, which writes to both the primary and the secondary storage,
+// it is used to migrate the resources from the primary storage to the secondary storage without resyncing the clusters.
+type Config struct {
+	Primary   BackendConfig `yaml:"primary"`
+	Secondary BackendConfig `yaml:"secondary"`
+
+	// Reads is the storage serving the reads, primary or secondary, default is primary.
+	// The reads are switched to the secondary storage after the secondary storage catches up.
+	Reads string `yaml:"reads" env:"DUAL_STORAGE_READS"`
+
+	// BackfillPeriod is the period to backfill the resources which failed to be written to the secondary storage,
+	// default is 10m.
+	BackfillPeriod time.Duration `yaml:"backfillPeriod"`
+}
+
+type BackendConfig struct {
+	// Name is the name of the registered storage, such as internal.
+	Name string `yaml:"name"`
+
+	// ConfigPath is the path of the config of the storage.
+	ConfigPath string `yaml:"configPath"`
+}
+
+func (cfg *Config) validate() error {
+	if cfg.Primary.Name == "" || cfg.Secondary.Name == "" {
+		return fmt.Errorf("both the primary and the secondary storage are required")
+	}
+	if cfg.Primary.Name == StorageName || cfg.Secondary.Name == StorageName {
+		return fmt.Errorf("the %s storage can not be nested", StorageName)
+	}
+
+	switch cfg.Reads {
+	case "", ReadsPrimary, ReadsSecondary:
+	default:
+		return fmt.Errorf("reads should be %s or %s, but got %s", ReadsPrimary, ReadsSecondary, cfg.Reads)
+	}
+	if cfg.BackfillPeriod < 0 {
+		return fmt.Errorf("backfillPeriod should not be negative")
+	}
+	return nil
+}
+
+func (cfg *Config) readsSecondary() bool {
+	return cfg.Reads == ReadsSecondary
+}
+
+func (cfg *Config) backfillPeriod() time.Duration {
+	if cfg.BackfillPeriod == 0 {
+		return defaultBackfillPeriod
+	}
+	return cfg.BackfillPeriod
+}
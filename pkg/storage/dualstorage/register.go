@@ -0,0 +1,45 @@
+package dualstorage
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/configor"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	StorageName = "dual"
+)
+
+func init() {
+	storage.RegisterStorageFactoryFunc(StorageName, NewStorageFactory)
+}
+
+func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
+	if configPath == "" {
+		return nil, fmt.Errorf("configPath should not be empty")
+	}
+
+	cfg := &Config{}
+	if err := configor.Load(cfg, configPath); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	primary, err := storage.NewStorageFactory(cfg.Primary.Name, cfg.Primary.ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("primary storage: %w", err)
+	}
+	secondary, err := storage.NewStorageFactory(cfg.Secondary.Name, cfg.Secondary.ConfigPath)
+	if err != nil {
+		_ = primary.Shutdown()
+		return nil, fmt.Errorf("secondary storage: %w", err)
+	}
+
+	factory := newStorageFactory(primary, secondary, cfg.readsSecondary())
+	go factory.runBackfill(cfg.backfillPeriod())
+	return factory, nil
+}
@@ -0,0 +1,271 @@
+package dualstorage
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type ResourceStorage struct {
+	factory *StorageFactory
+
+	primary   storage.ResourceStorage
+	secondary storage.ResourceStorage
+	reader    storage.ResourceStorage
+}
+
+var (
+	_ storage.ResourceStorage          = &ResourceStorage{}
+	_ storage.ResourceCounter          = &ResourceStorage{}
+	_ storage.ResourceAggregator       = &ResourceStorage{}
+	_ storage.SpecHashLister           = &ResourceStorage{}
+	_ storage.CollectionDeleter        = &ResourceStorage{}
+	_ storage.ResourcePatcher          = &ResourceStorage{}
+	_ storage.ResourceHistoryGetter    = &ResourceStorage{}
+	_ storage.ResourceSnapshotGetter   = &ResourceStorage{}
+	_ storage.ResourceTombstoneStorage = &ResourceStorage{}
+	_ storage.ResourceTenantResolver   = &ResourceStorage{}
+)
+
+func (s *ResourceStorage) GetStorageConfig() *storage.ResourceStorageConfig {
+	return s.primary.GetStorageConfig()
+}
+
+func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, obj runtime.Object) error {
+	return s.reader.Get(ctx, cluster, namespace, name, obj)
+}
+
+func (s *ResourceStorage) List(ctx context.Context, listObj runtime.Object, opts *internal.ListOptions) error {
+	return s.reader.List(ctx, listObj, opts)
+}
+
+func (s *ResourceStorage) Watch(ctx context.Context, options *internal.ListOptions) (watch.Interface, error) {
+	return s.reader.Watch(ctx, options)
+}
+
+func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) error {
+	if err := s.primary.Create(ctx, cluster, obj); err != nil {
+		return err
+	}
+
+	s.writeSecondary(cluster, obj, func() error {
+		return s.upsertSecondary(ctx, cluster, obj)
+	})
+	return nil
+}
+
+func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) error {
+	if err := s.primary.Update(ctx, cluster, obj); err != nil {
+		return err
+	}
+
+	s.writeSecondary(cluster, obj, func() error {
+		return s.upsertSecondary(ctx, cluster, obj)
+	})
+	return nil
+}
+
+// UpdateWithPatch patches the object in the primary storage, the object is fully updated if the primary storage
+// doesn't support the patch. The object is fully written to the secondary storage, which may not have the previous object of the patch.
+func (s *ResourceStorage) UpdateWithPatch(ctx context.Context, cluster string, obj runtime.Object, patch storage.ResourcePatch) error {
+	if patcher, ok := s.primary.(storage.ResourcePatcher); ok {
+		if err := patcher.UpdateWithPatch(ctx, cluster, obj, patch); err != nil {
+			return err
+		}
+	} else if err := s.primary.Update(ctx, cluster, obj); err != nil {
+		return err
+	}
+
+	s.writeSecondary(cluster, obj, func() error {
+		return s.upsertSecondary(ctx, cluster, obj)
+	})
+	return nil
+}
+
+func (s *ResourceStorage) ConvertDeletedObject(obj interface{}) (runtime.Object, error) {
+	return s.primary.ConvertDeletedObject(obj)
+}
+
+func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) error {
+	if err := s.primary.Delete(ctx, cluster, obj); err != nil {
+		return err
+	}
+
+	s.writeSecondary(cluster, obj, func() error {
+		return s.deleteSecondary(ctx, cluster, obj)
+	})
+	return nil
+}
+
+func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event *corev1.Event) error {
+	if err := s.primary.RecordEvent(ctx, cluster, event); err != nil {
+		return err
+	}
+
+	if err := s.secondary.RecordEvent(ctx, cluster, event); err != nil {
+		klog.ErrorS(err, "Failed to record the event to the secondary storage", "cluster", cluster,
+			"resource", s.primary.GetStorageConfig().StorageResource, "event", klog.KObj(event))
+	}
+	return nil
+}
+
+// DeleteCollection deletes the objects from the primary storage and then from the secondary storage,
+// the clusters are backfilled again if the objects can't be deleted from the secondary storage.
+func (s *ResourceStorage) DeleteCollection(ctx context.Context, opts storage.DeleteCollectionOptions) (int64, error) {
+	gvr := s.primary.GetStorageConfig().StorageResource
+	deleter, ok := s.primary.(storage.CollectionDeleter)
+	if !ok {
+		return 0, apierrors.NewMethodNotSupported(gvr.GroupResource(), "deletecollection")
+	}
+	n, err := deleter.DeleteCollection(ctx, opts)
+	if err != nil || n == 0 {
+		return n, err
+	}
+
+	secondary, ok := s.secondary.(storage.CollectionDeleter)
+	if !ok {
+		s.factory.backfillAgain(opts.Clusters, gvr)
+		return n, nil
+	}
+	if _, err := secondary.DeleteCollection(ctx, opts); err != nil {
+		klog.ErrorS(err, "Failed to delete the collection from the secondary storage, it will be backfilled from the primary storage",
+			"clusters", opts.Clusters, "resource", gvr)
+		s.factory.backfillAgain(opts.Clusters, gvr)
+	}
+	return n, nil
+}
+
+func (s *ResourceStorage) Count(ctx context.Context, opts *internal.ListOptions) (int64, error) {
+	counter, ok := s.primary.(storage.ResourceCounter)
+	if !ok {
+		return 0, apierrors.NewMethodNotSupported(s.primary.GetStorageConfig().StorageResource.GroupResource(), "count")
+	}
+	return counter.Count(ctx, opts)
+}
+
+func (s *ResourceStorage) Aggregate(ctx context.Context, opts *internal.ListOptions, groupBy []storage.GroupBy) ([]storage.AggregationGroup, error) {
+	aggregator, ok := s.primary.(storage.ResourceAggregator)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.primary.GetStorageConfig().StorageResource.GroupResource(), "aggregate")
+	}
+	return aggregator.Aggregate(ctx, opts, groupBy)
+}
+
+func (s *ResourceStorage) ListSpecHashes(ctx context.Context, clusters []string, namespace, name string) (map[string]string, error) {
+	lister, ok := s.primary.(storage.SpecHashLister)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.primary.GetStorageConfig().StorageResource.GroupResource(), "list spec hashes")
+	}
+	return lister.ListSpecHashes(ctx, clusters, namespace, name)
+}
+
+func (s *ResourceStorage) TenantFor(ctx context.Context) string {
+	resolver, ok := s.primary.(storage.ResourceTenantResolver)
+	if !ok {
+		return ""
+	}
+	return resolver.TenantFor(ctx)
+}
+
+func (s *ResourceStorage) GetHistory(ctx context.Context, cluster, namespace, name string) ([]storage.ResourceChange, error) {
+	getter, ok := s.primary.(storage.ResourceHistoryGetter)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.primary.GetStorageConfig().StorageResource.GroupResource(), "history")
+	}
+	return getter.GetHistory(ctx, cluster, namespace, name)
+}
+
+func (s *ResourceStorage) GetSnapshot(ctx context.Context, cluster, namespace, name string) (*storage.ResourceSnapshot, error) {
+	getter, ok := s.primary.(storage.ResourceSnapshotGetter)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.primary.GetStorageConfig().StorageResource.GroupResource(), "snapshot")
+	}
+	return getter.GetSnapshot(ctx, cluster, namespace, name)
+}
+
+func (s *ResourceStorage) ListTombstones(ctx context.Context, cluster string, opts storage.TombstoneOptions) ([]storage.Tombstone, error) {
+	tombstones, ok := s.primary.(storage.ResourceTombstoneStorage)
+	if !ok {
+		return nil, apierrors.NewMethodNotSupported(s.primary.GetStorageConfig().StorageResource.GroupResource(), "tombstones")
+	}
+	return tombstones.ListTombstones(ctx, cluster, opts)
+}
+
+// RestoreTombstone restores the object in the primary storage, and writes the restored object to the secondary storage.
+func (s *ResourceStorage) RestoreTombstone(ctx context.Context, cluster, namespace, name string) error {
+	tombstones, ok := s.primary.(storage.ResourceTombstoneStorage)
+	if !ok {
+		return apierrors.NewMethodNotSupported(s.primary.GetStorageConfig().StorageResource.GroupResource(), "tombstones")
+	}
+	if err := tombstones.RestoreTombstone(ctx, cluster, namespace, name); err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	s.writeSecondary(cluster, obj, func() error {
+		if err := s.primary.Get(ctx, cluster, namespace, name, obj); err != nil {
+			return err
+		}
+		return s.upsertSecondary(ctx, cluster, obj)
+	})
+	return nil
+}
+
+// PurgeTombstones only purges the tombstones of the primary storage, which serves the tombstones.
+func (s *ResourceStorage) PurgeTombstones(ctx context.Context, cluster string, opts storage.TombstoneOptions) (int64, error) {
+	tombstones, ok := s.primary.(storage.ResourceTombstoneStorage)
+	if !ok {
+		return 0, apierrors.NewMethodNotSupported(s.primary.GetStorageConfig().StorageResource.GroupResource(), "tombstones")
+	}
+	return tombstones.PurgeTombstones(ctx, cluster, opts)
+}
+
+// upsertSecondary creates or updates the object in the secondary storage,
+// the object may not have been backfilled to the secondary storage yet.
+func (s *ResourceStorage) upsertSecondary(ctx context.Context, cluster string, obj runtime.Object) error {
+	err := s.secondary.Update(ctx, cluster, obj)
+	if storage.IsNotFound(err) {
+		return s.secondary.Create(ctx, cluster, obj)
+	}
+	return err
+}
+
+func (s *ResourceStorage) deleteSecondary(ctx context.Context, cluster string, obj runtime.Object) error {
+	if err := s.secondary.Delete(ctx, cluster, obj); err != nil && !storage.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// writeSecondary writes the object to the secondary storage, the writes of a cluster resource are serialized
+// with the backfill, so that the backfill does not overwrite the newer object written by the resource synchro.
+//
+// The failed write is not returned, the cluster resource is backfilled again from the primary storage.
+func (s *ResourceStorage) writeSecondary(cluster string, obj runtime.Object, write func() error) {
+	gvr := s.primary.GetStorageConfig().StorageResource
+	key, _ := cache.MetaNamespaceKeyFunc(obj)
+
+	state := s.factory.state(cluster, gvr)
+	state.lock.Lock()
+	defer state.lock.Unlock()
+
+	if state.written != nil {
+		state.written.Insert(key)
+	}
+	if err := write(); err != nil {
+		state.failed, state.caughtUp = true, false
+		klog.ErrorS(err, "Failed to write to the secondary storage, it will be backfilled from the primary storage",
+			"cluster", cluster, "resource", gvr, "key", key)
+	}
+}
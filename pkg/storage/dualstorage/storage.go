@@ -0,0 +1,237 @@
+package dualstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// StorageFactory writes the resources to both the primary and the secondary storage,
+// and serves the reads from one of them.
+//
+// The primary storage is the source of truth, the errors of the secondary storage are not returned to
+// the resource synchros, instead the resources are backfilled from the primary storage.
+type StorageFactory struct {
+	primary        storage.StorageFactory
+	secondary      storage.StorageFactory
+	readsSecondary bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lock      sync.Mutex
+	resources map[schema.GroupVersionResource]*ResourceStorage
+	states    map[clusterResource]*backfillState
+	clusters  map[string]bool // cluster -> whether the secondary storage has caught up
+	backfills chan string
+}
+
+var (
+	_ storage.StorageFactory = &StorageFactory{}
+	_ storage.HealthChecker  = &StorageFactory{}
+)
+
+func newStorageFactory(primary, secondary storage.StorageFactory, readsSecondary bool) *StorageFactory {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &StorageFactory{
+		primary:        primary,
+		secondary:      secondary,
+		readsSecondary: readsSecondary,
+
+		ctx:       ctx,
+		cancel:    cancel,
+		resources: make(map[schema.GroupVersionResource]*ResourceStorage),
+		states:    make(map[clusterResource]*backfillState),
+		clusters:  make(map[string]bool),
+		backfills: make(chan string, 100),
+	}
+}
+
+func (f *StorageFactory) reader() storage.StorageFactory {
+	if f.readsSecondary {
+		return f.secondary
+	}
+	return f.primary
+}
+
+func (f *StorageFactory) GetSupportedRequestVerbs() []string {
+	return f.reader().GetSupportedRequestVerbs()
+}
+
+func (f *StorageFactory) GetResourceRequestVerbs(gvr schema.GroupVersionResource) ([]string, bool) {
+	if getter, ok := f.reader().(storage.ResourceRequestVerbsGetter); ok {
+		return getter.GetResourceRequestVerbs(gvr)
+	}
+	return nil, false
+}
+
+// HealthCheck checks both storages, since the resources are written to both of them.
+func (f *StorageFactory) HealthCheck(ctx context.Context) error {
+	if err := storage.CheckHealth(ctx, f.primary); err != nil {
+		return fmt.Errorf("primary storage: %w", err)
+	}
+	if err := storage.CheckHealth(ctx, f.secondary); err != nil {
+		return fmt.Errorf("secondary storage: %w", err)
+	}
+	return nil
+}
+
+func (f *StorageFactory) Readiness(ctx context.Context) error {
+	if err := storage.CheckReadiness(ctx, f.primary); err != nil {
+		return fmt.Errorf("primary storage: %w", err)
+	}
+	if err := storage.CheckReadiness(ctx, f.secondary); err != nil {
+		return fmt.Errorf("secondary storage: %w", err)
+	}
+	return nil
+}
+
+// PrepareCluster prepares the cluster in both storages, and backfills the resources of the cluster
+// to the secondary storage in the background.
+func (f *StorageFactory) PrepareCluster(cluster string) error {
+	if err := f.primary.PrepareCluster(cluster); err != nil {
+		return err
+	}
+	if err := f.secondary.PrepareCluster(cluster); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	if _, ok := f.clusters[cluster]; !ok {
+		f.clusters[cluster] = false
+	}
+	f.lock.Unlock()
+	f.scheduleBackfill(cluster)
+	return nil
+}
+
+// GetResourceVersions returns the resource versions of the primary storage,
+// so that the resource synchros always write the resources missing from the primary storage.
+func (f *StorageFactory) GetResourceVersions(ctx context.Context, cluster string) (map[schema.GroupVersionResource]storage.ClusterResourceVersions, error) {
+	return f.primary.GetResourceVersions(ctx, cluster)
+}
+
+func (f *StorageFactory) GetCollectionResources(ctx context.Context) ([]*internal.CollectionResource, error) {
+	return f.reader().GetCollectionResources(ctx)
+}
+
+func (f *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
+	primary, err := f.primary.NewResourceStorage(config)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := f.secondary.NewResourceStorage(config)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &ResourceStorage{
+		factory:   f,
+		primary:   primary,
+		secondary: secondary,
+		reader:    primary,
+	}
+	if f.readsSecondary {
+		s.reader = secondary
+	}
+
+	f.lock.Lock()
+	f.resources[config.StorageResource] = s
+	f.lock.Unlock()
+	return s, nil
+}
+
+func (f *StorageFactory) NewCollectionResourceStorage(cr *internal.CollectionResource) (storage.CollectionResourceStorage, error) {
+	return f.reader().NewCollectionResourceStorage(cr)
+}
+
+func (f *StorageFactory) CleanCluster(ctx context.Context, cluster string) error {
+	if err := f.primary.CleanCluster(ctx, cluster); err != nil {
+		return err
+	}
+	if err := f.secondary.CleanCluster(ctx, cluster); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.clusters, cluster)
+	for cr := range f.states {
+		if cr.cluster == cluster {
+			delete(f.states, cr)
+		}
+	}
+	return nil
+}
+
+func (f *StorageFactory) CleanClusterResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) error {
+	if err := f.primary.CleanClusterResource(ctx, cluster, gvr); err != nil {
+		return err
+	}
+	if err := f.secondary.CleanClusterResource(ctx, cluster, gvr); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.states, clusterResource{cluster: cluster, gvr: gvr})
+	return nil
+}
+
+func (f *StorageFactory) Shutdown() error {
+	f.cancel()
+	return errors.Join(f.primary.Shutdown(), f.secondary.Shutdown())
+}
+
+func (f *StorageFactory) resourceStorage(gvr schema.GroupVersionResource) *ResourceStorage {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.resources[gvr]
+}
+
+// backfillAgain backfills the resource of the clusters again, the resource of all clusters is backfilled if clusters is empty.
+func (f *StorageFactory) backfillAgain(clusters []string, gvr schema.GroupVersionResource) {
+	f.lock.Lock()
+	if len(clusters) == 0 {
+		for cluster := range f.clusters {
+			clusters = append(clusters, cluster)
+		}
+	}
+	states := make([]*backfillState, 0, len(clusters))
+	for _, cluster := range clusters {
+		cr := clusterResource{cluster: cluster, gvr: gvr}
+		if f.states[cr] == nil {
+			f.states[cr] = &backfillState{}
+		}
+		states = append(states, f.states[cr])
+	}
+	f.lock.Unlock()
+
+	for _, state := range states {
+		state.lock.Lock()
+		state.failed, state.caughtUp = true, false
+		state.lock.Unlock()
+	}
+	for _, cluster := range clusters {
+		f.scheduleBackfill(cluster)
+	}
+}
+
+func (f *StorageFactory) state(cluster string, gvr schema.GroupVersionResource) *backfillState {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	cr := clusterResource{cluster: cluster, gvr: gvr}
+	state := f.states[cr]
+	if state == nil {
+		state = &backfillState{}
+		f.states[cr] = state
+	}
+	return state
+}
@@ -0,0 +1,207 @@
+package dualstorage
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/storagetesting"
+)
+
+func newFakeStorageFactory(orderable bool) *storagetesting.StorageFactory {
+	f := storagetesting.NewStorageFactory()
+	f.Unorderable = !orderable
+	return f
+}
+
+func newPod(name, rv string) *unstructured.Unstructured {
+	obj := storagetesting.NewPod("default", name)
+	obj.SetResourceVersion(rv)
+	return obj
+}
+
+func newTestStorageFactory(t *testing.T, orderable bool) (*StorageFactory, *storagetesting.StorageFactory, *storagetesting.StorageFactory, *ResourceStorage) {
+	primary, secondary := newFakeStorageFactory(orderable), newFakeStorageFactory(orderable)
+	factory := newStorageFactory(primary, secondary, false)
+	t.Cleanup(factory.cancel)
+
+	s, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{
+		ResourceConfig: resourceconfig.ResourceConfig{StorageResource: storagetesting.PodsGVR},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := factory.PrepareCluster("cluster-1"); err != nil {
+		t.Fatal(err)
+	}
+	return factory, primary, secondary, s.(*ResourceStorage)
+}
+
+func TestBackfillCluster(t *testing.T) {
+	for _, orderable := range []bool{true, false} {
+		factory, primary, secondary, _ := newTestStorageFactory(t, orderable)
+		primary.Put("cluster-1", newPod("pod-1", "1"))
+		primary.Put("cluster-1", newPod("pod-2", "2"))
+		secondary.Put("cluster-1", newPod("pod-2", "1"))
+		secondary.Put("cluster-1", newPod("pod-3", "1"))
+
+		factory.backfillCluster(context.TODO(), "cluster-1")
+
+		if obj := secondary.Object("cluster-1", "default", "pod-1"); obj == nil {
+			t.Errorf("expected pod-1 to be backfilled")
+		}
+		if obj := secondary.Object("cluster-1", "default", "pod-2"); obj == nil || obj.GetResourceVersion() != "2" {
+			t.Errorf("expected pod-2 to be updated, got %v", obj)
+		}
+		if obj := secondary.Object("cluster-1", "default", "pod-3"); obj != nil {
+			t.Errorf("expected pod-3 to be deleted")
+		}
+		if pendings := factory.pendingClusters(); len(pendings) != 0 {
+			t.Errorf("expected the secondary storage to catch up, pending clusters: %v", pendings)
+		}
+	}
+}
+
+func TestBackfillSkipsWrittenObjects(t *testing.T) {
+	factory, primary, secondary, s := newTestStorageFactory(t, true)
+	primary.Put("cluster-1", newPod("pod-1", "1"))
+
+	state := factory.state("cluster-1", storagetesting.PodsGVR)
+	state.written = sets.New[string]()
+	s.writeSecondary("cluster-1", newPod("pod-1", "2"), func() error {
+		secondary.Put("cluster-1", newPod("pod-1", "2"))
+		return nil
+	})
+	if err := state.backfill("default/pod-1", func() error {
+		secondary.Put("cluster-1", newPod("pod-1", "1"))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if obj := secondary.Object("cluster-1", "default", "pod-1"); obj.GetResourceVersion() != "2" {
+		t.Errorf("expected the object written by the resource synchro, got resource version %s", obj.GetResourceVersion())
+	}
+}
+
+func TestWriteSecondaryFailed(t *testing.T) {
+	factory, primary, secondary, s := newTestStorageFactory(t, true)
+	factory.backfillCluster(context.TODO(), "cluster-1")
+	if pendings := factory.pendingClusters(); len(pendings) != 0 {
+		t.Fatalf("expected no pending clusters, got %v", pendings)
+	}
+
+	secondary.FailWrites = true
+	if err := s.Create(context.TODO(), "cluster-1", newPod("pod-1", "1")); err != nil {
+		t.Fatalf("expected the error of the secondary storage is not returned, got %v", err)
+	}
+	if primary.Object("cluster-1", "default", "pod-1") == nil {
+		t.Fatal("expected pod-1 to be created in the primary storage")
+	}
+	if pendings := factory.pendingClusters(); len(pendings) != 1 {
+		t.Fatalf("expected the cluster to be backfilled, got %v", pendings)
+	}
+
+	secondary.FailWrites = false
+	factory.backfillCluster(context.TODO(), "cluster-1")
+	if secondary.Object("cluster-1", "default", "pod-1") == nil {
+		t.Error("expected pod-1 to be backfilled")
+	}
+	if pendings := factory.pendingClusters(); len(pendings) != 0 {
+		t.Errorf("expected no pending clusters, got %v", pendings)
+	}
+}
+
+func TestOptionalInterfaces(t *testing.T) {
+	_, _, _, s := newTestStorageFactory(t, true)
+
+	var rs storage.ResourceStorage = s
+	for name, ok := range map[string]bool{
+		"ResourceCounter":          isType[storage.ResourceCounter](rs),
+		"ResourceAggregator":       isType[storage.ResourceAggregator](rs),
+		"CollectionDeleter":        isType[storage.CollectionDeleter](rs),
+		"ResourcePatcher":          isType[storage.ResourcePatcher](rs),
+		"SpecHashLister":           isType[storage.SpecHashLister](rs),
+		"ResourceHistoryGetter":    isType[storage.ResourceHistoryGetter](rs),
+		"ResourceTombstoneStorage": isType[storage.ResourceTombstoneStorage](rs),
+		"ResourceSnapshotGetter":   isType[storage.ResourceSnapshotGetter](rs),
+	} {
+		if !ok {
+			t.Errorf("expected the dual storage to implement %s", name)
+		}
+	}
+
+	// the primary storage doesn't count the objects
+	if _, err := s.Count(context.TODO(), &internal.ListOptions{}); !apierrors.IsMethodNotSupported(err) {
+		t.Errorf("expected the method not supported error, got %v", err)
+	}
+}
+
+func isType[T any](obj interface{}) bool {
+	_, ok := obj.(T)
+	return ok
+}
+
+func TestUpdateWithPatch(t *testing.T) {
+	_, primary, secondary, s := newTestStorageFactory(t, true)
+	primary.Put("cluster-1", newPod("pod-1", "1"))
+	primary.Put("cluster-1", newPod("pod-2", "1"))
+	secondary.Put("cluster-1", newPod("pod-1", "1"))
+
+	for _, name := range []string{"pod-1", "pod-2"} {
+		patch := storage.ResourcePatch{PreviousResourceVersion: "1", ChangedFields: []string{"metadata"}}
+		if err := s.UpdateWithPatch(context.TODO(), "cluster-1", newPod(name, "2"), patch); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, f := range []*storagetesting.StorageFactory{primary, secondary} {
+			if obj := f.Object("cluster-1", "default", name); obj == nil || obj.GetResourceVersion() != "2" {
+				t.Errorf("expected %s to be updated in both storages, got %v", name, obj)
+			}
+		}
+	}
+}
+
+func TestDeleteCollection(t *testing.T) {
+	factory, primary, secondary, s := newTestStorageFactory(t, true)
+	for _, f := range []*storagetesting.StorageFactory{primary, secondary} {
+		f.Put("cluster-1", newPod("pod-1", "1"))
+		f.Put("cluster-1", newPod("pod-2", "1"))
+	}
+	factory.backfillCluster(context.TODO(), "cluster-1")
+
+	n, err := s.DeleteCollection(context.TODO(), storage.DeleteCollectionOptions{Clusters: []string{"cluster-1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 objects to be deleted, got %d", n)
+	}
+	if secondary.Object("cluster-1", "default", "pod-1") != nil {
+		t.Error("expected the objects to be deleted from the secondary storage")
+	}
+
+	primary.Put("cluster-1", newPod("pod-3", "1"))
+	secondary.Put("cluster-1", newPod("pod-3", "1"))
+	secondary.FailWrites = true
+	if _, err := s.DeleteCollection(context.TODO(), storage.DeleteCollectionOptions{Clusters: []string{"cluster-1"}}); err != nil {
+		t.Fatalf("expected the error of the secondary storage is not returned, got %v", err)
+	}
+	if pendings := factory.pendingClusters(); len(pendings) != 1 {
+		t.Fatalf("expected the cluster to be backfilled, got %v", pendings)
+	}
+
+	secondary.FailWrites = false
+	factory.backfillCluster(context.TODO(), "cluster-1")
+	if secondary.Object("cluster-1", "default", "pod-3") != nil {
+		t.Error("expected pod-3 to be deleted by the backfill")
+	}
+	if pendings := factory.pendingClusters(); len(pendings) != 0 {
+		t.Errorf("expected no pending clusters, got %v", pendings)
+	}
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericstorage "k8s.io/apiserver/pkg/storage"
+	storeerr "k8s.io/apiserver/pkg/storage/errors"
+)
+
+// ErrorCode is the code of the error returned by the storage layer,
+// the clients can branch on the code instead of the message of the storage component.
+type ErrorCode string
+
+const (
+	ErrCodeNotFound     ErrorCode = "NotFound"
+	ErrCodeConflict     ErrorCode = "Conflict"
+	ErrCodeTooLarge     ErrorCode = "TooLarge"
+	ErrCodeUnavailable  ErrorCode = "Unavailable"
+	ErrCodeInvalidQuery ErrorCode = "InvalidQuery"
+)
+
+type StorageError struct {
+	Code ErrorCode
+	Key  string
+	Err  error
+}
+
+func (e *StorageError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("storage error %s: %v", e.Code, e.Err)
+	}
+	return fmt.Sprintf("storage error %s, key: %s: %v", e.Code, e.Key, e.Err)
+}
+
+func (e *StorageError) Unwrap() error {
+	return e.Err
+}
+
+func NewNotFoundError(key string, err error) error {
+	return &StorageError{Code: ErrCodeNotFound, Key: key, Err: err}
+}
+
+func NewConflictError(key string, err error) error {
+	return &StorageError{Code: ErrCodeConflict, Key: key, Err: err}
+}
+
+func NewTooLargeError(key string, err error) error {
+	return &StorageError{Code: ErrCodeTooLarge, Key: key, Err: err}
+}
+
+// NewUnavailableError returns an error of the unavailable storage,
+// the error is recoverable and the operation can be retried.
+func NewUnavailableError(key string, err error) error {
+	return NewRecoverableException(&StorageError{Code: ErrCodeUnavailable, Key: key, Err: err})
+}
+
+func NewInvalidQueryError(key string, err error) error {
+	return &StorageError{Code: ErrCodeInvalidQuery, Key: key, Err: err}
+}
+
+// ErrorCodeOf returns the code of the storage error, the errors of `k8s.io/apiserver/pkg/storage`
+// and the recoverable exceptions are also recognized, it returns an empty code for the unknown errors.
+func ErrorCodeOf(err error) ErrorCode {
+	var storageErr *StorageError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &storageErr):
+		return storageErr.Code
+	case genericstorage.IsNotFound(err):
+		return ErrCodeNotFound
+	case genericstorage.IsExist(err), genericstorage.IsConflict(err):
+		return ErrCodeConflict
+	case genericstorage.IsUnreachable(err), IsRecoverableException(err):
+		return ErrCodeUnavailable
+	}
+	return ""
+}
+
+func IsNotFound(err error) bool {
+	return ErrorCodeOf(err) == ErrCodeNotFound
+}
+
+func IsConflict(err error) bool {
+	return ErrorCodeOf(err) == ErrCodeConflict
+}
+
+func IsTooLarge(err error) bool {
+	return ErrorCodeOf(err) == ErrCodeTooLarge
+}
+
+func IsUnavailable(err error) bool {
+	return ErrorCodeOf(err) == ErrCodeUnavailable
+}
+
+func IsInvalidQuery(err error) bool {
+	return ErrorCodeOf(err) == ErrCodeInvalidQuery
+}
+
+// InterpretGetError converts the storage error of getting an object into the API error.
+func InterpretGetError(err error, qualifiedResource schema.GroupResource, name string) error {
+	if apiErr := interpretStorageError(err, qualifiedResource, name); apiErr != nil {
+		return apiErr
+	}
+	return storeerr.InterpretGetError(err, qualifiedResource, name)
+}
+
+// InterpretListError converts the storage error of listing objects into the API error.
+func InterpretListError(err error, qualifiedResource schema.GroupResource) error {
+	if apiErr := interpretStorageError(err, qualifiedResource, ""); apiErr != nil {
+		return apiErr
+	}
+	return storeerr.InterpretListError(err, qualifiedResource)
+}
+
+func interpretStorageError(err error, qualifiedResource schema.GroupResource, name string) error {
+	var storageErr *StorageError
+	if !errors.As(err, &storageErr) {
+		return nil
+	}
+
+	switch storageErr.Code {
+	case ErrCodeNotFound:
+		return apierrors.NewNotFound(qualifiedResource, name)
+	case ErrCodeConflict:
+		return apierrors.NewConflict(qualifiedResource, name, storageErr.Err)
+	case ErrCodeTooLarge:
+		return apierrors.NewRequestEntityTooLargeError(storageErr.Error())
+	case ErrCodeUnavailable:
+		return apierrors.NewServiceUnavailable(storageErr.Error())
+	case ErrCodeInvalidQuery:
+		return apierrors.NewBadRequest(storageErr.Error())
+	}
+	return nil
+}
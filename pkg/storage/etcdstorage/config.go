@@ -0,0 +1,69 @@
+package etcdstorage
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	defaultPrefix      = "/clusterpedia"
+	defaultDialTimeout = 5 * time.Second
+)
+
+type Config struct {
+	Endpoints []string `yaml:"endpoints" env:"ETCD_ENDPOINTS" required:"true"`
+
+	// Prefix is the prefix of all keys written by clusterpedia, default is /clusterpedia.
+	Prefix string `yaml:"prefix" env:"ETCD_PREFIX"`
+
+	Username string `yaml:"username" env:"ETCD_USERNAME"`
+	Password string `yaml:"password" env:"ETCD_PASSWORD"`
+
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+	CAFile   string `yaml:"caFile"`
+
+	DialTimeout time.Duration `yaml:"dialTimeout"`
+}
+
+func (cfg *Config) keyPrefix() string {
+	if cfg.Prefix == "" {
+		return defaultPrefix
+	}
+	return path.Clean("/" + strings.TrimSpace(cfg.Prefix))
+}
+
+func (cfg *Config) genClientConfig() (clientv3.Config, error) {
+	if len(cfg.Endpoints) == 0 {
+		return clientv3.Config{}, errors.New("etcd endpoints are required")
+	}
+
+	config := clientv3.Config{
+		Endpoints:   cfg.Endpoints,
+		Username:    cfg.Username,
+		Password:    cfg.Password,
+		DialTimeout: cfg.DialTimeout,
+	}
+	if config.DialTimeout == 0 {
+		config.DialTimeout = defaultDialTimeout
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" || cfg.CAFile != "" {
+		tlsInfo := transport.TLSInfo{
+			CertFile:      cfg.CertFile,
+			KeyFile:       cfg.KeyFile,
+			TrustedCAFile: cfg.CAFile,
+		}
+		tlsConfig, err := tlsInfo.ClientConfig()
+		if err != nil {
+			return clientv3.Config{}, err
+		}
+		config.TLS = tlsConfig
+	}
+	return config, nil
+}
@@ -0,0 +1,37 @@
+package etcdstorage
+
+import (
+	"context"
+	"errors"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// InterpretEtcdError maps the errors of etcd to the storage errors.
+func InterpretEtcdError(key string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch rpctypes.Error(err) {
+	case rpctypes.ErrRequestTooLarge:
+		return storage.NewTooLargeError(key, err)
+	case rpctypes.ErrNoLeader, rpctypes.ErrLeaderChanged, rpctypes.ErrNotCapable, rpctypes.ErrStopped,
+		rpctypes.ErrTimeout, rpctypes.ErrTimeoutDueToLeaderFail, rpctypes.ErrTimeoutDueToConnectionLost,
+		rpctypes.ErrTooManyRequests:
+		return storage.NewUnavailableError(key, err)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return storage.NewUnavailableError(key, err)
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return storage.NewUnavailableError(key, err)
+	}
+	return err
+}
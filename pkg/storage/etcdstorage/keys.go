@@ -0,0 +1,89 @@
+package etcdstorage
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// keyLayout lays out the keys of the objects under the per-GVR and per-cluster prefixes:
+//
+//	<prefix>/resources/<group>/<version>/<resource>/<cluster>/<namespace>/<name>
+//
+// so the objects of a resource in all clusters are listed and watched by one prefix,
+// and the objects of a resource in a cluster are deleted by one prefix.
+// The namespace is empty for the cluster-scoped objects, and the core group is written as `core`.
+//
+// The resources stored for a cluster are recorded by the marker keys,
+// so the cluster can be cleaned and its resource versions can be loaded without scanning all resources:
+//
+//	<prefix>/clusters/<cluster>/<group>/<version>/<resource>
+type keyLayout struct {
+	prefix string
+}
+
+const coreGroup = "core"
+
+func gvrPath(gvr schema.GroupVersionResource) string {
+	group := gvr.Group
+	if group == "" {
+		group = coreGroup
+	}
+	return group + "/" + gvr.Version + "/" + gvr.Resource
+}
+
+func parseGVRPath(path string) (schema.GroupVersionResource, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid resource path: %s", path)
+	}
+
+	group := parts[0]
+	if group == coreGroup {
+		group = ""
+	}
+	return schema.GroupVersionResource{Group: group, Version: parts[1], Resource: parts[2]}, nil
+}
+
+func (l keyLayout) resourcePrefix(gvr schema.GroupVersionResource) string {
+	return l.prefix + "/resources/" + gvrPath(gvr) + "/"
+}
+
+func (l keyLayout) clusterResourcePrefix(gvr schema.GroupVersionResource, cluster string) string {
+	return l.resourcePrefix(gvr) + cluster + "/"
+}
+
+func (l keyLayout) objectKey(gvr schema.GroupVersionResource, cluster, namespace, name string) string {
+	return l.clusterResourcePrefix(gvr, cluster) + namespace + "/" + name
+}
+
+// parseObjectKey returns the cluster, namespace and name of the object key under the resource prefix.
+func (l keyLayout) parseObjectKey(gvr schema.GroupVersionResource, key string) (cluster, namespace, name string, err error) {
+	prefix := l.resourcePrefix(gvr)
+	if !strings.HasPrefix(key, prefix) {
+		return "", "", "", fmt.Errorf("key %s is not under the resource prefix %s", key, prefix)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(key, prefix), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid object key: %s", key)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func (l keyLayout) clusterMarkerPrefix(cluster string) string {
+	return l.prefix + "/clusters/" + cluster + "/"
+}
+
+func (l keyLayout) clusterMarkerKey(cluster string, gvr schema.GroupVersionResource) string {
+	return l.clusterMarkerPrefix(cluster) + gvrPath(gvr)
+}
+
+func (l keyLayout) parseClusterMarkerKey(cluster string, key string) (schema.GroupVersionResource, error) {
+	prefix := l.clusterMarkerPrefix(cluster)
+	if !strings.HasPrefix(key, prefix) {
+		return schema.GroupVersionResource{}, fmt.Errorf("key %s is not under the cluster prefix %s", key, prefix)
+	}
+	return parseGVRPath(strings.TrimPrefix(key, prefix))
+}
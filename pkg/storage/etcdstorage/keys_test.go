@@ -0,0 +1,66 @@
+package etcdstorage
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestKeyLayout(t *testing.T) {
+	keys := keyLayout{prefix: "/clusterpedia"}
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	tests := []struct {
+		gvr       schema.GroupVersionResource
+		cluster   string
+		namespace string
+		name      string
+		key       string
+	}{
+		{pods, "cluster-1", "default", "pod-1", "/clusterpedia/resources/core/v1/pods/cluster-1/default/pod-1"},
+		{deployments, "cluster-1", "kube-system", "coredns", "/clusterpedia/resources/apps/v1/deployments/cluster-1/kube-system/coredns"},
+		{schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}, "cluster-2", "", "default", "/clusterpedia/resources/core/v1/namespaces/cluster-2//default"},
+	}
+	for _, test := range tests {
+		key := keys.objectKey(test.gvr, test.cluster, test.namespace, test.name)
+		if key != test.key {
+			t.Errorf("objectKey() = %s, want %s", key, test.key)
+		}
+
+		cluster, namespace, name, err := keys.parseObjectKey(test.gvr, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cluster != test.cluster || namespace != test.namespace || name != test.name {
+			t.Errorf("parseObjectKey(%s) = %s, %s, %s", key, cluster, namespace, name)
+		}
+
+		marker := keys.clusterMarkerKey(test.cluster, test.gvr)
+		gvr, err := keys.parseClusterMarkerKey(test.cluster, marker)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gvr != test.gvr {
+			t.Errorf("parseClusterMarkerKey(%s) = %s, want %s", marker, gvr, test.gvr)
+		}
+	}
+
+	if _, _, _, err := keys.parseObjectKey(pods, "/clusterpedia/resources/apps/v1/deployments/cluster-1/default/nginx"); err == nil {
+		t.Error("expected an error for the key of the other resource")
+	}
+}
+
+func TestConfigKeyPrefix(t *testing.T) {
+	tests := map[string]string{
+		"":               "/clusterpedia",
+		"clusterpedia":   "/clusterpedia",
+		"/registry/cp/":  "/registry/cp",
+		" /registry/cp ": "/registry/cp",
+	}
+	for prefix, expected := range tests {
+		if got := (&Config{Prefix: prefix}).keyPrefix(); got != expected {
+			t.Errorf("keyPrefix(%q) = %s, want %s", prefix, got, expected)
+		}
+	}
+}
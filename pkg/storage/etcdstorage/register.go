@@ -0,0 +1,51 @@
+package etcdstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jinzhu/configor"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	StorageName = "etcd"
+)
+
+func init() {
+	storage.RegisterStorageFactoryFunc(StorageName, NewStorageFactory)
+}
+
+func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
+	if configPath == "" {
+		return nil, fmt.Errorf("configPath should not be empty")
+	}
+
+	cfg := &Config{}
+	if err := configor.Load(cfg, configPath); err != nil {
+		return nil, err
+	}
+
+	clientConfig, err := cfg.genClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := clientv3.New(clientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create etcd client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), clientConfig.DialTimeout)
+	defer cancel()
+	if _, err := client.Get(ctx, cfg.keyPrefix(), clientv3.WithCountOnly()); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to etcd: %w", err)
+	}
+
+	return &StorageFactory{
+		client: client,
+		keys:   keyLayout{prefix: cfg.keyPrefix()},
+	}, nil
+}
@@ -0,0 +1,458 @@
+package etcdstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// listBatchSize is the number of the keys read from etcd in one range request,
+// the objects are filtered in batches until the limit of the list is reached.
+const listBatchSize = 500
+
+// ResourceStorage stores the objects of a resource in etcd.
+//
+// The objects are stored with the resource versions of the member clusters, which are loaded by GetResourceVersions,
+// and the objects returned by Get, List and Watch carry the modification revisions of etcd as the resource versions,
+// so the clients can list and watch the resource with the resource versions returned by the storage.
+type ResourceStorage struct {
+	client  *clientv3.Client
+	keys    keyLayout
+	markers *sync.Map
+	config  storage.ResourceStorageConfig
+}
+
+var _ storage.ResourceStorage = &ResourceStorage{}
+
+func (s *ResourceStorage) GetStorageConfig() *storage.ResourceStorageConfig {
+	config := s.config
+	return &config
+}
+
+func (s *ResourceStorage) objectKey(cluster string, obj runtime.Object) (string, error) {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return "", err
+	}
+	return s.keys.objectKey(s.config.StorageResource, cluster, metaobj.GetNamespace(), metaobj.GetName()), nil
+}
+
+// ensureClusterMarker records that the resource is stored for the cluster.
+func (s *ResourceStorage) ensureClusterMarker(ctx context.Context, cluster string) error {
+	marker := s.keys.clusterMarkerKey(cluster, s.config.StorageResource)
+	if _, ok := s.markers.Load(marker); ok {
+		return nil
+	}
+
+	if _, err := s.client.Put(ctx, marker, ""); err != nil {
+		return InterpretEtcdError(marker, err)
+	}
+	s.markers.Store(marker, struct{}{})
+	return nil
+}
+
+func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		return fmt.Errorf("%s: kind is required", gvk)
+	}
+
+	key, err := s.objectKey(cluster, obj)
+	if err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	if err := s.config.Codec.Encode(obj, &buffer); err != nil {
+		return err
+	}
+
+	if err := s.ensureClusterMarker(ctx, cluster); err != nil {
+		return err
+	}
+	resp, err := s.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(clientv3.OpPut(key, buffer.String())).
+		Commit()
+	if err != nil {
+		return InterpretEtcdError(key, err)
+	}
+	if !resp.Succeeded {
+		return storage.NewConflictError(key, errors.New("the object already exists"))
+	}
+	return nil
+}
+
+func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) error {
+	key, err := s.objectKey(cluster, obj)
+	if err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	if err := s.config.Codec.Encode(obj, &buffer); err != nil {
+		return err
+	}
+
+	resp, err := s.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), ">", 0)).
+		Then(clientv3.OpPut(key, buffer.String())).
+		Commit()
+	if err != nil {
+		return InterpretEtcdError(key, err)
+	}
+	if !resp.Succeeded {
+		return storage.NewNotFoundError(key, errors.New("the object does not exist"))
+	}
+	return nil
+}
+
+func (s *ResourceStorage) ConvertDeletedObject(obj interface{}) (runtime.Object, error) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}, nil
+}
+
+func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) error {
+	key, err := s.objectKey(cluster, obj)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.client.Delete(ctx, key); err != nil {
+		return InterpretEtcdError(key, err)
+	}
+	return nil
+}
+
+// RecordEvent is a no-op, the events of the resources are not stored by the etcd storage.
+func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event *corev1.Event) error {
+	return nil
+}
+
+func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, into runtime.Object) error {
+	key := s.keys.objectKey(s.config.StorageResource, cluster, namespace, name)
+	resp, err := s.client.Get(ctx, key)
+	if err != nil {
+		return InterpretEtcdError(key, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return storage.NewNotFoundError(key, errors.New("the object does not exist"))
+	}
+
+	obj, err := s.decode(resp.Kvs[0], into)
+	if err != nil {
+		return err
+	}
+	if obj != into {
+		return fmt.Errorf("failed to decode resource, into is %T", into)
+	}
+	return nil
+}
+
+// decode decodes the stored object into the object, and sets the modification revision as the resource version.
+func (s *ResourceStorage) decode(kv *mvccpb.KeyValue, into runtime.Object) (runtime.Object, error) {
+	obj, _, err := s.config.Codec.Decode(kv.Value, nil, into)
+	if err != nil {
+		return nil, err
+	}
+	if err := accessor.SetResourceVersion(obj, strconv.FormatInt(kv.ModRevision, 10)); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+var accessor = meta.NewAccessor()
+
+// listRange returns the key range of the list, the range is narrowed by the only cluster and namespace,
+// and starts after the continue key.
+func (s *ResourceStorage) listRange(opts *internal.ListOptions) (string, string, error) {
+	prefix := s.keys.resourcePrefix(s.config.StorageResource)
+	if len(opts.ClusterNames) == 1 {
+		prefix += opts.ClusterNames[0] + "/"
+		if len(opts.Namespaces) == 1 {
+			prefix += opts.Namespaces[0] + "/"
+		}
+	}
+	start, end := prefix, clientv3.GetPrefixRangeEnd(prefix)
+
+	if opts.Continue != "" {
+		key, err := decodeContinue(opts.Continue)
+		if err != nil {
+			return "", "", err
+		}
+		key = s.keys.resourcePrefix(s.config.StorageResource) + key
+		if !strings.HasPrefix(key, prefix) {
+			return "", "", storage.NewInvalidQueryError(opts.Continue, errors.New("the continue token is not in the range of the list"))
+		}
+		start = key + "\x00"
+	}
+	return start, end, nil
+}
+
+// the continue token is the last listed key relative to the resource prefix
+func encodeContinue(relativeKey string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(relativeKey))
+}
+
+func decodeContinue(token string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", storage.NewInvalidQueryError(token, fmt.Errorf("invalid continue token: %w", err))
+	}
+	return string(key), nil
+}
+
+func (s *ResourceStorage) List(ctx context.Context, listObject runtime.Object, opts *internal.ListOptions) error {
+	predicate, err := newListPredicate(opts)
+	if err != nil {
+		return err
+	}
+	start, end, err := s.listRange(opts)
+	if err != nil {
+		return err
+	}
+
+	var (
+		kvs         []*mvccpb.KeyValue
+		revision    int64
+		continueKey string
+	)
+	for {
+		getOpts := []clientv3.OpOption{clientv3.WithRange(end), clientv3.WithLimit(listBatchSize)}
+		if revision != 0 {
+			// all batches are read at the same revision
+			getOpts = append(getOpts, clientv3.WithRev(revision))
+		}
+		resp, err := s.client.Get(ctx, start, getOpts...)
+		if err != nil {
+			return InterpretEtcdError(start, err)
+		}
+		if revision == 0 {
+			revision = resp.Header.Revision
+		}
+
+		for i, kv := range resp.Kvs {
+			matched, err := predicate.matchKeyValue(s.keys, s.config.StorageResource, kv)
+			if err != nil {
+				return err
+			}
+			if !matched {
+				continue
+			}
+
+			kvs = append(kvs, kv)
+			if opts.Limit > 0 && int64(len(kvs)) == opts.Limit {
+				if i < len(resp.Kvs)-1 || resp.More {
+					continueKey = strings.TrimPrefix(string(kv.Key), s.keys.resourcePrefix(s.config.StorageResource))
+				}
+				break
+			}
+		}
+		if (opts.Limit > 0 && int64(len(kvs)) == opts.Limit) || !resp.More || len(resp.Kvs) == 0 {
+			break
+		}
+		start = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+	}
+
+	list, err := meta.ListAccessor(listObject)
+	if err != nil {
+		return err
+	}
+	list.SetResourceVersion(strconv.FormatInt(revision, 10))
+	if continueKey != "" && opts.WithContinue != nil && *opts.WithContinue {
+		list.SetContinue(encodeContinue(continueKey))
+	}
+	if len(kvs) == 0 {
+		return nil
+	}
+
+	if unstructuredList, ok := listObject.(*unstructured.UnstructuredList); ok {
+		unstructuredList.Items = make([]unstructured.Unstructured, 0, len(kvs))
+		for _, kv := range kvs {
+			obj, err := s.decode(kv, &unstructured.Unstructured{})
+			if err != nil {
+				return err
+			}
+			uObj, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("the decoded object is not *unstructured.Unstructured")
+			}
+			unstructuredList.Items = append(unstructuredList.Items, *uObj)
+		}
+		return nil
+	}
+
+	listPtr, err := meta.GetItemsPtr(listObject)
+	if err != nil {
+		return err
+	}
+	v, err := conversion.EnforcePtr(listPtr)
+	if err != nil || v.Kind() != reflect.Slice {
+		return fmt.Errorf("need ptr to slice: %v", err)
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(kvs), len(kvs))
+	expected := reflect.New(v.Type().Elem()).Interface().(runtime.Object)
+	for i, kv := range kvs {
+		obj, err := s.decode(kv, expected.DeepCopyObject())
+		if err != nil {
+			return err
+		}
+		slice.Index(i).Set(reflect.ValueOf(obj).Elem())
+	}
+	v.Set(slice)
+	return nil
+}
+
+// newWatchObject returns the object of the memory version to decode the watched object,
+// the same as the objects of the watch cache of the memory storage.
+func (s *ResourceStorage) newWatchObject(data []byte) runtime.Object {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(data, &typeMeta); err != nil || typeMeta.Kind == "" {
+		return &unstructured.Unstructured{}
+	}
+
+	gk := typeMeta.GroupVersionKind().GroupKind()
+	if !scheme.LegacyResourceScheme.IsGroupRegistered(gk.Group) {
+		return &unstructured.Unstructured{}
+	}
+	obj, err := scheme.LegacyResourceScheme.New(s.config.MemoryResource.GroupVersion().WithKind(gk.Kind))
+	if err != nil {
+		return &unstructured.Unstructured{}
+	}
+	return obj
+}
+
+// listPredicate filters the objects by the list options in memory, since etcd only selects the keys by the range.
+type listPredicate struct {
+	clusters   sets.Set[string]
+	namespaces sets.Set[string]
+	names      sets.Set[string]
+
+	labels labels.Selector
+
+	since  *metav1.Time
+	before *metav1.Time
+
+	ownerUID string
+}
+
+func newListPredicate(opts *internal.ListOptions) (*listPredicate, error) {
+	unsupported := func(option string) error {
+		return storage.NewInvalidQueryError("", fmt.Errorf("%s is not supported by the %s storage", option, StorageName))
+	}
+	switch {
+	case len(opts.OrderBy) != 0:
+		return nil, unsupported("orderby")
+	case opts.EnhancedFieldSelector != nil && !opts.EnhancedFieldSelector.Empty():
+		return nil, unsupported("the field selector")
+	case opts.OwnerName != "":
+		return nil, unsupported("the owner name")
+	case opts.OwnerUID != "" && (len(opts.ClusterNames) != 1 || opts.OwnerSeniority != 0):
+		return nil, unsupported("the owner seniority or the owner without a cluster")
+	}
+
+	predicate := &listPredicate{
+		since:    opts.Since,
+		before:   opts.Before,
+		ownerUID: opts.OwnerUID,
+	}
+	if len(opts.ClusterNames) != 0 {
+		predicate.clusters = sets.New(opts.ClusterNames...)
+	}
+	if len(opts.Namespaces) != 0 {
+		predicate.namespaces = sets.New(opts.Namespaces...)
+	}
+	if len(opts.Names) != 0 {
+		predicate.names = sets.New(opts.Names...)
+	}
+	if opts.LabelSelector != nil && !opts.LabelSelector.Empty() {
+		predicate.labels = opts.LabelSelector
+	}
+	return predicate, nil
+}
+
+func (p *listPredicate) matchKeyValue(keys keyLayout, gvr schema.GroupVersionResource, kv *mvccpb.KeyValue) (bool, error) {
+	cluster, namespace, name, err := keys.parseObjectKey(gvr, string(kv.Key))
+	if err != nil {
+		return false, err
+	}
+	if !p.matchKey(cluster, namespace, name) {
+		return false, nil
+	}
+	if !p.needsMetadata() {
+		return true, nil
+	}
+
+	var metadata metav1.PartialObjectMetadata
+	if err := json.Unmarshal(kv.Value, &metadata); err != nil {
+		return false, err
+	}
+	return p.matchMetadata(&metadata.ObjectMeta), nil
+}
+
+func (p *listPredicate) matchKey(cluster, namespace, name string) bool {
+	if p.clusters != nil && !p.clusters.Has(cluster) {
+		return false
+	}
+	if p.namespaces != nil && !p.namespaces.Has(namespace) {
+		return false
+	}
+	if p.names != nil && !p.names.Has(name) {
+		return false
+	}
+	return true
+}
+
+func (p *listPredicate) needsMetadata() bool {
+	return p.labels != nil || p.since != nil || p.before != nil || p.ownerUID != ""
+}
+
+func (p *listPredicate) matchMetadata(metadata *metav1.ObjectMeta) bool {
+	if p.labels != nil && !p.labels.Matches(labels.Set(metadata.Labels)) {
+		return false
+	}
+	if p.since != nil && metadata.CreationTimestamp.Before(p.since) {
+		return false
+	}
+	if p.before != nil && !metadata.CreationTimestamp.Before(p.before) {
+		return false
+	}
+	if p.ownerUID != "" {
+		owner := metav1.GetControllerOfNoCopy(metadata)
+		if owner == nil || string(owner.UID) != p.ownerUID {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package etcdstorage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestListPredicate(t *testing.T) {
+	keys := keyLayout{prefix: "/clusterpedia"}
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	podKV := func(cluster, namespace, name string, podLabels string) *mvccpb.KeyValue {
+		value := `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"` + name + `","namespace":"` + namespace +
+			`","creationTimestamp":"` + created.Format(time.RFC3339) + `","labels":{` + podLabels + `},` +
+			`"ownerReferences":[{"apiVersion":"apps/v1","kind":"ReplicaSet","name":"rs","uid":"uid-1","controller":true}]}}`
+		return &mvccpb.KeyValue{Key: []byte(keys.objectKey(pods, cluster, namespace, name)), Value: []byte(value)}
+	}
+
+	tests := []struct {
+		name    string
+		opts    *internal.ListOptions
+		kv      *mvccpb.KeyValue
+		matched bool
+	}{
+		{"empty", &internal.ListOptions{}, podKV("cluster-1", "default", "pod-1", ""), true},
+		{"cluster", &internal.ListOptions{ClusterNames: []string{"cluster-2"}}, podKV("cluster-1", "default", "pod-1", ""), false},
+		{"namespace", &internal.ListOptions{Namespaces: []string{"default", "kube-system"}}, podKV("cluster-1", "default", "pod-1", ""), true},
+		{"name", &internal.ListOptions{Names: []string{"pod-2"}}, podKV("cluster-1", "default", "pod-1", ""), false},
+		{"labels", newLabelOptions("app=nginx"), podKV("cluster-1", "default", "pod-1", `"app":"nginx"`), true},
+		{"labels not matched", newLabelOptions("app=nginx"), podKV("cluster-1", "default", "pod-1", `"app":"redis"`), false},
+		{"since", &internal.ListOptions{Since: &metav1.Time{Time: created}}, podKV("cluster-1", "default", "pod-1", ""), true},
+		{"before", &internal.ListOptions{Before: &metav1.Time{Time: created}}, podKV("cluster-1", "default", "pod-1", ""), false},
+		{"owner", &internal.ListOptions{ClusterNames: []string{"cluster-1"}, OwnerUID: "uid-1"}, podKV("cluster-1", "default", "pod-1", ""), true},
+		{"other owner", &internal.ListOptions{ClusterNames: []string{"cluster-1"}, OwnerUID: "uid-2"}, podKV("cluster-1", "default", "pod-1", ""), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			predicate, err := newListPredicate(test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			matched, err := predicate.matchKeyValue(keys, pods, test.kv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if matched != test.matched {
+				t.Errorf("matchKeyValue() = %v, want %v", matched, test.matched)
+			}
+		})
+	}
+
+	for _, opts := range []*internal.ListOptions{
+		{OrderBy: []internal.OrderBy{{Field: "name"}}},
+		{OwnerName: "rs"},
+		{OwnerUID: "uid-1"},
+	} {
+		if _, err := newListPredicate(opts); !storage.IsInvalidQuery(err) {
+			t.Errorf("expected an invalid query error for %+v, got %v", opts, err)
+		}
+	}
+}
+
+func newLabelOptions(selector string) *internal.ListOptions {
+	opts := &internal.ListOptions{}
+	opts.LabelSelector = labels.SelectorFromSet(labels.Set(mustParseLabels(selector)))
+	return opts
+}
+
+func mustParseLabels(selector string) map[string]string {
+	set, err := labels.ConvertSelectorToLabelsMap(selector)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+func TestListRange(t *testing.T) {
+	s := &ResourceStorage{
+		keys:   keyLayout{prefix: "/clusterpedia"},
+		config: storage.ResourceStorageConfig{ResourceConfig: resourceconfig.ResourceConfig{StorageResource: schema.GroupVersionResource{Version: "v1", Resource: "pods"}}},
+	}
+
+	start, end, err := s.listRange(&internal.ListOptions{ClusterNames: []string{"cluster-1"}, Namespaces: []string{"default"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start != "/clusterpedia/resources/core/v1/pods/cluster-1/default/" || end != "/clusterpedia/resources/core/v1/pods/cluster-1/default0" {
+		t.Errorf("listRange() = %s, %s", start, end)
+	}
+
+	opts := &internal.ListOptions{ClusterNames: []string{"cluster-1"}}
+	opts.Continue = encodeContinue("cluster-1/default/pod-1")
+	start, _, err = s.listRange(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start != "/clusterpedia/resources/core/v1/pods/cluster-1/default/pod-1\x00" {
+		t.Errorf("listRange() with continue starts at %q", start)
+	}
+
+	opts.ClusterNames = []string{"cluster-2"}
+	if _, _, err := s.listRange(opts); !storage.IsInvalidQuery(err) {
+		t.Errorf("expected an invalid query error for the continue out of range, got %v", err)
+	}
+}
+
+func TestDecodeWatchObject(t *testing.T) {
+	s := &ResourceStorage{
+		config: storage.ResourceStorageConfig{ResourceConfig: resourceconfig.ResourceConfig{
+			StorageResource: corev1.SchemeGroupVersion.WithResource("pods"),
+			MemoryResource:  schema.GroupVersionResource{Version: "__internal", Resource: "pods"},
+			Codec:           scheme.LegacyResourceCodecs.LegacyCodec(corev1.SchemeGroupVersion),
+		}},
+	}
+
+	data := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod-1","namespace":"default","resourceVersion":"100"}}`)
+	obj, err := s.decode(&mvccpb.KeyValue{Value: data, ModRevision: 7}, s.newWatchObject(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rv, err := accessor.ResourceVersion(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rv != "7" {
+		t.Errorf("expected the modification revision as the resource version, got %s", rv)
+	}
+	if name, _ := accessor.Name(obj); name != "pod-1" {
+		t.Errorf("expected the decoded pod, got %#v", obj)
+	}
+}
+
+func TestInterpretEtcdError(t *testing.T) {
+	if err := InterpretEtcdError("key", rpctypes.ErrGRPCRequestTooLarge); !storage.IsTooLarge(err) {
+		t.Errorf("expected a too large error, got %v", err)
+	}
+	if err := InterpretEtcdError("key", rpctypes.ErrGRPCNoLeader); !storage.IsUnavailable(err) {
+		t.Errorf("expected an unavailable error, got %v", err)
+	}
+	if err := InterpretEtcdError("key", errors.New("unknown")); storage.ErrorCodeOf(err) != "" {
+		t.Errorf("expected the unknown error, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package etcdstorage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type StorageFactory struct {
+	client *clientv3.Client
+	keys   keyLayout
+
+	// markers caches the cluster marker keys which have been written
+	markers sync.Map // cluster marker key -> struct{}
+}
+
+var _ storage.StorageFactory = &StorageFactory{}
+
+func (s *StorageFactory) GetSupportedRequestVerbs() []string {
+	return []string{"get", "list", "watch"}
+}
+
+func (s *StorageFactory) PrepareCluster(cluster string) error {
+	return nil
+}
+
+func (s *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
+	return &ResourceStorage{
+		client:  s.client,
+		keys:    s.keys,
+		markers: &s.markers,
+		config:  *config,
+	}, nil
+}
+
+func (s *StorageFactory) NewCollectionResourceStorage(cr *internal.CollectionResource) (storage.CollectionResourceStorage, error) {
+	return nil, errors.New("collection resources are not supported by the etcd storage")
+}
+
+func (s *StorageFactory) GetCollectionResources(ctx context.Context) ([]*internal.CollectionResource, error) {
+	return nil, nil
+}
+
+// clusterResources returns the resources stored for the cluster by the marker keys.
+func (s *StorageFactory) clusterResources(ctx context.Context, cluster string) ([]schema.GroupVersionResource, error) {
+	prefix := s.keys.clusterMarkerPrefix(cluster)
+	resp, err := s.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, InterpretEtcdError(prefix, err)
+	}
+
+	gvrs := make([]schema.GroupVersionResource, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		gvr, err := s.keys.parseClusterMarkerKey(cluster, string(kv.Key))
+		if err != nil {
+			return nil, err
+		}
+		gvrs = append(gvrs, gvr)
+	}
+	return gvrs, nil
+}
+
+func (s *StorageFactory) GetResourceVersions(ctx context.Context, cluster string) (map[schema.GroupVersionResource]storage.ClusterResourceVersions, error) {
+	gvrs, err := s.clusterResources(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceversions := make(map[schema.GroupVersionResource]storage.ClusterResourceVersions, len(gvrs))
+	for _, gvr := range gvrs {
+		prefix := s.keys.clusterResourcePrefix(gvr, cluster)
+		resp, err := s.client.Get(ctx, prefix, clientv3.WithPrefix())
+		if err != nil {
+			return nil, InterpretEtcdError(prefix, err)
+		}
+
+		versions := storage.ClusterResourceVersions{
+			Resources: make(map[string]interface{}, len(resp.Kvs)),
+			Events:    make(map[string]interface{}),
+		}
+		for _, kv := range resp.Kvs {
+			_, namespace, name, err := s.keys.parseObjectKey(gvr, string(kv.Key))
+			if err != nil {
+				return nil, err
+			}
+
+			var metadata metav1.PartialObjectMetadata
+			if err := json.Unmarshal(kv.Value, &metadata); err != nil {
+				return nil, err
+			}
+
+			key := name
+			if namespace != "" {
+				key = namespace + "/" + name
+			}
+			versions.Resources[key] = metadata.ResourceVersion
+		}
+		resourceversions[gvr] = versions
+	}
+	return resourceversions, nil
+}
+
+func (s *StorageFactory) CleanCluster(ctx context.Context, cluster string) error {
+	gvrs, err := s.clusterResources(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, gvr := range gvrs {
+		if err := s.CleanClusterResource(ctx, cluster, gvr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *StorageFactory) CleanClusterResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) error {
+	prefix := s.keys.clusterResourcePrefix(gvr, cluster)
+	if _, err := s.client.Delete(ctx, prefix, clientv3.WithPrefix()); err != nil {
+		return InterpretEtcdError(prefix, err)
+	}
+
+	// the marker is deleted after the objects, so the objects can be cleaned again if the deletion fails
+	marker := s.keys.clusterMarkerKey(cluster, gvr)
+	s.markers.Delete(marker)
+	if _, err := s.client.Delete(ctx, marker); err != nil {
+		return InterpretEtcdError(marker, err)
+	}
+	return nil
+}
+
+func (s *StorageFactory) Shutdown() error {
+	return s.client.Close()
+}
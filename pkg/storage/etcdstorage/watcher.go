@@ -0,0 +1,197 @@
+package etcdstorage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	utilwatch "github.com/clusterpedia-io/clusterpedia/pkg/utils/watch"
+)
+
+// Watch watches the objects natively by the watch stream of etcd,
+// the resource version of the watch is the revision of etcd returned by List.
+//
+// If the resource version is empty or 0, the current objects are sent as the ADDED events first.
+func (s *ResourceStorage) Watch(ctx context.Context, options *internal.ListOptions) (watch.Interface, error) {
+	opts := *options
+	// the order and the continue of the list options are not used by the watch
+	opts.OrderBy, opts.Continue = nil, ""
+	predicate, err := newListPredicate(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var revision int64
+	if rv := opts.ResourceVersion; rv != "" && rv != "0" {
+		revision, err = strconv.ParseInt(rv, 10, 64)
+		if err != nil || revision < 0 {
+			return nil, storage.NewInvalidQueryError(rv, fmt.Errorf("invalid resource version: %s", rv))
+		}
+	}
+
+	prefix, _, err := s.listRange(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w := &watcher{
+		storage:   s,
+		predicate: predicate,
+		result:    make(chan watch.Event, 100),
+		cancel:    cancel,
+	}
+	go w.run(ctx, prefix, revision)
+	return w, nil
+}
+
+type watcher struct {
+	storage   *ResourceStorage
+	predicate *listPredicate
+
+	result chan watch.Event
+	cancel context.CancelFunc
+}
+
+func (w *watcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *watcher) Stop() {
+	w.cancel()
+}
+
+func (w *watcher) send(ctx context.Context, event watch.Event) bool {
+	select {
+	case w.result <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (w *watcher) run(ctx context.Context, prefix string, revision int64) {
+	defer close(w.result)
+	defer w.cancel()
+
+	client := w.storage.client
+	if revision == 0 {
+		resp, err := client.Get(ctx, prefix, clientv3.WithPrefix())
+		if err != nil {
+			w.send(ctx, utilwatch.NewErrorEvent(apierrors.NewInternalError(InterpretEtcdError(prefix, err))))
+			return
+		}
+		for _, kv := range resp.Kvs {
+			event, ok, err := w.convert(watch.Added, kv, nil)
+			if err != nil {
+				w.send(ctx, utilwatch.NewErrorEvent(apierrors.NewInternalError(err)))
+				return
+			}
+			if ok && !w.send(ctx, event) {
+				return
+			}
+		}
+		revision = resp.Header.Revision
+	}
+
+	watchChan := client.Watch(clientv3.WithRequireLeader(ctx), prefix,
+		clientv3.WithPrefix(), clientv3.WithRev(revision+1), clientv3.WithPrevKV())
+	for resp := range watchChan {
+		if err := resp.Err(); err != nil {
+			if resp.CompactRevision != 0 {
+				err = apierrors.NewResourceExpired(fmt.Sprintf("too old resource version: %d (%d)", revision, resp.CompactRevision))
+			} else {
+				err = apierrors.NewInternalError(InterpretEtcdError(prefix, err))
+			}
+			w.send(ctx, utilwatch.NewErrorEvent(err))
+			return
+		}
+
+		for _, ev := range resp.Events {
+			var (
+				event watch.Event
+				ok    bool
+				err   error
+			)
+			switch {
+			case ev.Type == clientv3.EventTypeDelete:
+				event, ok, err = w.convert(watch.Deleted, ev.PrevKv, ev.Kv)
+			case ev.IsCreate():
+				event, ok, err = w.convert(watch.Added, ev.Kv, nil)
+			default:
+				event, ok, err = w.convertModified(ev.Kv, ev.PrevKv)
+			}
+			if err != nil {
+				w.send(ctx, utilwatch.NewErrorEvent(apierrors.NewInternalError(err)))
+				return
+			}
+			if ok && !w.send(ctx, event) {
+				return
+			}
+		}
+	}
+}
+
+// convertModified converts the modification to the event of the watch,
+// the object is added to or deleted from the watch if it starts or stops matching the predicate.
+func (w *watcher) convertModified(kv, prevKv *mvccpb.KeyValue) (watch.Event, bool, error) {
+	matched, err := w.match(kv)
+	if err != nil {
+		return watch.Event{}, false, err
+	}
+	prevMatched := true
+	if prevKv != nil {
+		if prevMatched, err = w.match(prevKv); err != nil {
+			return watch.Event{}, false, err
+		}
+	}
+
+	switch {
+	case matched && prevMatched:
+		return w.decodeEvent(watch.Modified, kv.Value, kv.ModRevision)
+	case matched:
+		return w.decodeEvent(watch.Added, kv.Value, kv.ModRevision)
+	case prevMatched && prevKv != nil:
+		return w.decodeEvent(watch.Deleted, prevKv.Value, kv.ModRevision)
+	}
+	return watch.Event{}, false, nil
+}
+
+// convert converts the key value to the event if it matches the predicate,
+// the deleted object is the previous key value with the revision of the deletion.
+func (w *watcher) convert(eventType watch.EventType, kv *mvccpb.KeyValue, deleted *mvccpb.KeyValue) (watch.Event, bool, error) {
+	if kv == nil {
+		// the previous key value is not available
+		return watch.Event{}, false, nil
+	}
+
+	matched, err := w.match(kv)
+	if err != nil || !matched {
+		return watch.Event{}, false, err
+	}
+
+	revision := kv.ModRevision
+	if deleted != nil {
+		revision = deleted.ModRevision
+	}
+	return w.decodeEvent(eventType, kv.Value, revision)
+}
+
+func (w *watcher) match(kv *mvccpb.KeyValue) (bool, error) {
+	return w.predicate.matchKeyValue(w.storage.keys, w.storage.config.StorageResource, kv)
+}
+
+func (w *watcher) decodeEvent(eventType watch.EventType, data []byte, revision int64) (watch.Event, bool, error) {
+	obj, err := w.storage.decode(&mvccpb.KeyValue{Value: data, ModRevision: revision}, w.storage.newWatchObject(data))
+	if err != nil {
+		return watch.Event{}, false, err
+	}
+	return watch.Event{Type: eventType, Object: obj}, true, nil
+}
@@ -0,0 +1,243 @@
+package gc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// cleanTimeout bounds the cleaning of each orphan
+const cleanTimeout = 5 * time.Minute
+
+const (
+	orphanTypeCluster  = "cluster"
+	orphanTypeResource = "resource"
+)
+
+var (
+	orphans = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage_gc",
+			Name:           "orphans",
+			Help:           "Number of the orphaned clusters and resources found by the last scan of the storage.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"type"},
+	)
+
+	cleanedOrphansTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage_gc",
+			Name:           "cleaned_orphans_total",
+			Help:           "Number of the orphaned clusters and resources cleaned from the storage.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"type"},
+	)
+
+	cleanedObjectsTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage_gc",
+			Name:           "cleaned_objects_total",
+			Help:           "Number of the orphaned objects cleaned from the storage.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	cleanFailuresTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage_gc",
+			Name:           "clean_failures_total",
+			Help:           "Number of the failed cleanings of the orphaned clusters and resources.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"type"},
+	)
+
+	lastScanTimestamp = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage_gc",
+			Name:           "last_scan_timestamp_seconds",
+			Help:           "The unix timestamp of the last scan of the storage.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(orphans, cleanedOrphansTotal, cleanedObjectsTotal, cleanFailuresTotal, lastScanTimestamp)
+}
+
+type Config struct {
+	Interval    time.Duration
+	GracePeriod time.Duration
+	QPS         float32
+}
+
+// ClusterResourcesLister lists the clusters of the PediaClusters and the storage resources of each cluster,
+// the resources of a cluster are nil if they are unknown, e.g. the resources of the cluster are not negotiated yet,
+// and false is returned if the PediaClusters are not listed yet.
+type ClusterResourcesLister func() (map[string]sets.Set[schema.GroupVersionResource], bool)
+
+// orphan is a cluster or a resource of a cluster in the storage which doesn't exist in any PediaCluster,
+// the resource is empty for the orphaned cluster.
+type orphan struct {
+	cluster  string
+	resource schema.GroupVersionResource
+}
+
+func (o orphan) orphanType() string {
+	if o.resource.Empty() {
+		return orphanTypeCluster
+	}
+	return orphanTypeResource
+}
+
+// Collector deletes the objects of the orphaned clusters and resources from the storage periodically.
+type Collector struct {
+	factory   storage.StorageFactory
+	collector storage.ResourceUsageCollector
+	lister    ClusterResourcesLister
+	config    Config
+	limiter   flowcontrol.RateLimiter
+
+	// candidates are the orphans found by the scans and the time when they are found first
+	candidates map[orphan]time.Time
+	now        func() time.Time
+}
+
+// NewCollector returns an error if the storage doesn't support listing the clusters and the resources in the storage.
+func NewCollector(factory storage.StorageFactory, lister ClusterResourcesLister, config *Config) (*Collector, error) {
+	collector, ok := factory.(storage.ResourceUsageCollector)
+	if !ok {
+		return nil, fmt.Errorf("the storage doesn't support the orphan garbage collector")
+	}
+	return &Collector{
+		factory:    factory,
+		collector:  collector,
+		lister:     lister,
+		config:     *config,
+		limiter:    flowcontrol.NewTokenBucketRateLimiter(config.QPS, 1),
+		candidates: make(map[orphan]time.Time),
+		now:        time.Now,
+	}, nil
+}
+
+// Run collects the orphans until the ctx is done, it should only be run by the leader.
+func (c *Collector) Run(ctx context.Context) {
+	klog.InfoS("Start the orphan garbage collector", "interval", c.config.Interval, "gracePeriod", c.config.GracePeriod)
+	wait.UntilWithContext(ctx, c.collect, c.config.Interval)
+}
+
+func (c *Collector) collect(ctx context.Context) {
+	clusters, ok := c.lister()
+	if !ok {
+		klog.V(2).InfoS("Skip the orphan scan, the PediaClusters are not listed yet")
+		return
+	}
+
+	usages, err := c.collector.CollectResourceUsages(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Failed to scan the storage for the orphans")
+		return
+	}
+
+	now := c.now()
+	found, objects := findOrphans(clusters, usages)
+	for o := range c.candidates {
+		if _, ok := found[o]; !ok {
+			delete(c.candidates, o)
+		}
+	}
+
+	counts := map[string]int{orphanTypeCluster: 0, orphanTypeResource: 0}
+	var expired []orphan
+	for o := range found {
+		counts[o.orphanType()]++
+
+		firstSeen, ok := c.candidates[o]
+		if !ok {
+			c.candidates[o] = now
+			continue
+		}
+		if now.Sub(firstSeen) >= c.config.GracePeriod {
+			expired = append(expired, o)
+		}
+	}
+	for typ, count := range counts {
+		orphans.WithLabelValues(typ).Set(float64(count))
+	}
+	lastScanTimestamp.Set(float64(now.Unix()))
+
+	sort.Slice(expired, func(i, j int) bool {
+		if expired[i].cluster != expired[j].cluster {
+			return expired[i].cluster < expired[j].cluster
+		}
+		return expired[i].resource.String() < expired[j].resource.String()
+	})
+	for _, o := range expired {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return
+		}
+		if err := c.clean(ctx, o); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			klog.ErrorS(err, "Failed to clean the orphan", "cluster", o.cluster, "resource", o.resource)
+			cleanFailuresTotal.WithLabelValues(o.orphanType()).Inc()
+			continue
+		}
+
+		delete(c.candidates, o)
+		cleanedOrphansTotal.WithLabelValues(o.orphanType()).Inc()
+		cleanedObjectsTotal.Add(float64(objects[o]))
+		klog.InfoS("Cleaned the orphan from the storage", "cluster", o.cluster, "resource", o.resource, "objects", objects[o])
+	}
+}
+
+func (c *Collector) clean(ctx context.Context, o orphan) error {
+	ctx, cancel := context.WithTimeout(ctx, cleanTimeout)
+	defer cancel()
+
+	if o.resource.Empty() {
+		return c.factory.CleanCluster(ctx, o.cluster)
+	}
+	return c.factory.CleanClusterResource(ctx, o.cluster, o.resource)
+}
+
+// findOrphans returns the orphans in the usages and their numbers of objects,
+// the resources of the orphaned clusters are cleaned with the clusters.
+func findOrphans(clusters map[string]sets.Set[schema.GroupVersionResource], usages []storage.ResourceUsage) (map[orphan]struct{}, map[orphan]int64) {
+	found := make(map[orphan]struct{})
+	objects := make(map[orphan]int64)
+	for _, usage := range usages {
+		resources, ok := clusters[usage.Cluster]
+		switch {
+		case !ok:
+			o := orphan{cluster: usage.Cluster}
+			found[o] = struct{}{}
+			objects[o] += usage.Objects
+		case resources != nil && !resources.Has(usage.Resource):
+			o := orphan{cluster: usage.Cluster, resource: usage.Resource}
+			found[o] = struct{}{}
+			objects[o] += usage.Objects
+		}
+	}
+	return found, objects
+}
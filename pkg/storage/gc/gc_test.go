@@ -0,0 +1,133 @@
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var (
+	podsGVR        = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	deploymentsGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+)
+
+type fakeStorageFactory struct {
+	storage.StorageFactory
+
+	usages []storage.ResourceUsage
+
+	cleanedClusters  []string
+	cleanedResources []orphan
+}
+
+func (f *fakeStorageFactory) CollectResourceUsages(_ context.Context) ([]storage.ResourceUsage, error) {
+	return f.usages, nil
+}
+
+func (f *fakeStorageFactory) CleanCluster(_ context.Context, cluster string) error {
+	f.cleanedClusters = append(f.cleanedClusters, cluster)
+	return nil
+}
+
+func (f *fakeStorageFactory) CleanClusterResource(_ context.Context, cluster string, gvr schema.GroupVersionResource) error {
+	f.cleanedResources = append(f.cleanedResources, orphan{cluster: cluster, resource: gvr})
+	return nil
+}
+
+func TestCollector(t *testing.T) {
+	factory := &fakeStorageFactory{usages: []storage.ResourceUsage{
+		{Cluster: "cluster-1", Resource: podsGVR, Objects: 10},
+		{Cluster: "cluster-1", Resource: deploymentsGVR, Objects: 2},
+		{Cluster: "cluster-2", Resource: podsGVR, Objects: 5},
+		{Cluster: "cluster-3", Resource: podsGVR, Objects: 1},
+		{Cluster: "removed", Resource: podsGVR, Objects: 3},
+		{Cluster: "removed", Resource: deploymentsGVR, Objects: 1},
+	}}
+
+	synced := false
+	lister := func() (map[string]sets.Set[schema.GroupVersionResource], bool) {
+		return map[string]sets.Set[schema.GroupVersionResource]{
+			"cluster-1": sets.New(podsGVR),
+			// the resources of cluster-2 are not negotiated yet
+			"cluster-2": nil,
+			"cluster-3": sets.New(podsGVR),
+		}, synced
+	}
+
+	collector, err := NewCollector(factory, lister, &Config{Interval: time.Minute, GracePeriod: 10 * time.Minute, QPS: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	collector.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	collector.collect(ctx)
+	if len(collector.candidates) != 0 {
+		t.Fatalf("the orphans should not be found before the PediaClusters are synced")
+	}
+
+	synced = true
+	collector.collect(ctx)
+	if len(collector.candidates) != 2 || len(factory.cleanedClusters) != 0 || len(factory.cleanedResources) != 0 {
+		t.Fatalf("the orphans should be kept within the grace period, candidates: %v", collector.candidates)
+	}
+
+	now = now.Add(5 * time.Minute)
+	collector.collect(ctx)
+	if len(factory.cleanedClusters) != 0 || len(factory.cleanedResources) != 0 {
+		t.Fatalf("the orphans should be kept within the grace period")
+	}
+
+	now = now.Add(5 * time.Minute)
+	collector.collect(ctx)
+	if len(factory.cleanedClusters) != 1 || factory.cleanedClusters[0] != "removed" {
+		t.Errorf("cleaned clusters = %v, want [removed]", factory.cleanedClusters)
+	}
+	if want := (orphan{cluster: "cluster-1", resource: deploymentsGVR}); len(factory.cleanedResources) != 1 || factory.cleanedResources[0] != want {
+		t.Errorf("cleaned resources = %v, want [%v]", factory.cleanedResources, want)
+	}
+	if len(collector.candidates) != 0 {
+		t.Errorf("the cleaned orphans should be removed from the candidates: %v", collector.candidates)
+	}
+}
+
+func TestCollectorForgetsAdoptedOrphans(t *testing.T) {
+	factory := &fakeStorageFactory{usages: []storage.ResourceUsage{{Cluster: "cluster-1", Resource: podsGVR, Objects: 1}}}
+
+	clusters := map[string]sets.Set[schema.GroupVersionResource]{}
+	lister := func() (map[string]sets.Set[schema.GroupVersionResource], bool) { return clusters, true }
+
+	collector, err := NewCollector(factory, lister, &Config{Interval: time.Minute, GracePeriod: time.Minute, QPS: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	collector.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	collector.collect(ctx)
+	if len(collector.candidates) != 1 {
+		t.Fatalf("candidates = %v, want the orphaned cluster", collector.candidates)
+	}
+
+	// the PediaCluster of the cluster is created before the grace period expires
+	clusters["cluster-1"] = sets.New(podsGVR)
+	now = now.Add(time.Minute)
+	collector.collect(ctx)
+	if len(collector.candidates) != 0 || len(factory.cleanedClusters) != 0 {
+		t.Errorf("the adopted cluster should not be cleaned, candidates: %v, cleaned: %v", collector.candidates, factory.cleanedClusters)
+	}
+}
+
+func TestNewCollector(t *testing.T) {
+	type unsupportedStorageFactory struct{ storage.StorageFactory }
+	if _, err := NewCollector(unsupportedStorageFactory{}, nil, &Config{QPS: 1}); err == nil {
+		t.Errorf("NewCollector() should fail if the storage can't collect the resource usages")
+	}
+}
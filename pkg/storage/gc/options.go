@@ -0,0 +1,51 @@
+package gc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type Options struct {
+	Interval    time.Duration
+	GracePeriod time.Duration
+	QPS         float32
+}
+
+func NewOptions() *Options {
+	return &Options{GracePeriod: 10 * time.Minute, QPS: 1}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&o.Interval, "orphan-gc-interval", o.Interval, ""+
+		"The interval of scanning the storage for the objects of the clusters and the resources that no longer exist in any PediaCluster, "+
+		"e.g. the objects left by a crash during cleaning the cluster. The orphaned objects are deleted by the garbage collector. "+
+		"0 disables the garbage collector.")
+	fs.DurationVar(&o.GracePeriod, "orphan-gc-grace-period", o.GracePeriod, ""+
+		"The time which the objects are kept orphaned across the scans before they are deleted, "+
+		"so the objects of the clusters and the resources being synchronized are not deleted before the status of the PediaClusters are updated.")
+	fs.Float32Var(&o.QPS, "orphan-gc-qps", o.QPS, "The maximum number of the clusters and the resources cleaned by the garbage collector per second")
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.Interval < 0 {
+		errs = append(errs, fmt.Errorf("orphan-gc-interval must not be negative"))
+	}
+	if o.GracePeriod < 0 {
+		errs = append(errs, fmt.Errorf("orphan-gc-grace-period must not be negative"))
+	}
+	if o.QPS <= 0 {
+		errs = append(errs, fmt.Errorf("orphan-gc-qps must be greater than 0"))
+	}
+	return errs
+}
+
+// Config returns nil if the garbage collector is disabled.
+func (o *Options) Config() *Config {
+	if o.Interval == 0 {
+		return nil
+	}
+	return &Config{Interval: o.Interval, GracePeriod: o.GracePeriod, QPS: o.QPS}
+}
@@ -0,0 +1,53 @@
+package grpcstorage
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	defaultDialTimeout    = 10 * time.Second
+	defaultMaxMessageSize = 64 << 20
+)
+
+// Config is the config of the grpc storage, which stores the resources by an out-of-process storage plugin.
+//
+// The connection to the plugin is not encrypted, the plugin is expected to run beside the component,
+// such as a sidecar container listening on a unix socket.
+type Config struct {
+	// Address is the target of the plugin, eg. 'unix:///var/run/clusterpedia/storage.sock' or 'localhost:9090'.
+	Address string `yaml:"address" env:"GRPC_STORAGE_ADDRESS"`
+
+	// DialTimeout is the timeout to connect to the plugin when the storage is initialized, default is 10s.
+	DialTimeout time.Duration `yaml:"dialTimeout"`
+
+	// MaxMessageSize is the max size in bytes of the messages received from the plugin, default is 64MiB.
+	MaxMessageSize int `yaml:"maxMessageSize"`
+}
+
+func (cfg *Config) validate() error {
+	if cfg.Address == "" {
+		return errors.New("the address of the storage plugin is required")
+	}
+	if cfg.DialTimeout < 0 {
+		return errors.New("dialTimeout should not be negative")
+	}
+	if cfg.MaxMessageSize < 0 {
+		return errors.New("maxMessageSize should not be negative")
+	}
+	return nil
+}
+
+func (cfg *Config) dialTimeout() time.Duration {
+	if cfg.DialTimeout == 0 {
+		return defaultDialTimeout
+	}
+	return cfg.DialTimeout
+}
+
+func (cfg *Config) maxMessageSize() int {
+	if cfg.MaxMessageSize == 0 {
+		return defaultMaxMessageSize
+	}
+	return cfg.MaxMessageSize
+}
@@ -0,0 +1,77 @@
+package grpcstorage
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	clusterpediascheme "github.com/clusterpedia-io/api/clusterpedia/scheme"
+	"github.com/clusterpedia-io/api/clusterpedia/v1beta1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/grpcstorage/storagepb"
+)
+
+func toGroupVersionResource(gvr schema.GroupVersionResource) *storagepb.GroupVersionResource {
+	return &storagepb.GroupVersionResource{Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource}
+}
+
+func fromGroupVersionResource(gvr *storagepb.GroupVersionResource) schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: gvr.GetGroup(), Version: gvr.GetVersion(), Resource: gvr.GetResource()}
+}
+
+// encodeListOptions encodes the list options as the query of clusterpedia.io/v1beta1 ListOptions.
+func encodeListOptions(opts *internal.ListOptions) (string, error) {
+	options := *opts
+	// the label selectors are required by the conversion of the list options
+	if options.LabelSelector == nil {
+		options.LabelSelector = labels.Everything()
+	}
+	if options.ExtraLabelSelector == nil {
+		options.ExtraLabelSelector = labels.Everything()
+	}
+
+	query, err := clusterpediascheme.ParameterCodec.EncodeParameters(&options, v1beta1.SchemeGroupVersion)
+	if err != nil {
+		return "", err
+	}
+	return query.Encode(), nil
+}
+
+func decodeListOptions(rawQuery string) (*internal.ListOptions, error) {
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts internal.ListOptions
+	if err := clusterpediascheme.DecodeListOptions(query, v1beta1.SchemeGroupVersion, &opts); err != nil {
+		return nil, err
+	}
+	return &opts, nil
+}
+
+var collectionResourceCodec = clusterpediascheme.Codecs.LegacyCodec(v1beta1.SchemeGroupVersion)
+
+// encodeCollectionResource encodes the collection resource in JSON of clusterpedia.io/v1beta1.
+func encodeCollectionResource(cr *internal.CollectionResource) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := collectionResourceCodec.Encode(cr, &buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func decodeCollectionResource(data []byte) (*internal.CollectionResource, error) {
+	obj, _, err := collectionResourceCodec.Decode(data, nil, &internal.CollectionResource{})
+	if err != nil {
+		return nil, err
+	}
+	cr, ok := obj.(*internal.CollectionResource)
+	if !ok {
+		return nil, fmt.Errorf("the decoded object is %T, not a collection resource", obj)
+	}
+	return cr, nil
+}
@@ -0,0 +1,59 @@
+package grpcstorage
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// InterpretStatusError maps the status errors returned by the storage plugin to the storage errors.
+func InterpretStatusError(key string, err error) error {
+	s, ok := status.FromError(err)
+	if err == nil || !ok {
+		return err
+	}
+
+	cause := errors.New(s.Message())
+	switch s.Code() {
+	case codes.NotFound:
+		return storage.NewNotFoundError(key, cause)
+	case codes.AlreadyExists, codes.Aborted:
+		return storage.NewConflictError(key, cause)
+	case codes.ResourceExhausted:
+		return storage.NewTooLargeError(key, cause)
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return storage.NewUnavailableError(key, cause)
+	case codes.InvalidArgument:
+		return storage.NewInvalidQueryError(key, cause)
+	}
+	return err
+}
+
+// toStatusError maps the storage errors to the status errors returned by the storage plugin,
+// it is the inverse of InterpretStatusError.
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Unknown
+	switch storage.ErrorCodeOf(err) {
+	case storage.ErrCodeNotFound:
+		code = codes.NotFound
+	case storage.ErrCodeConflict:
+		code = codes.AlreadyExists
+	case storage.ErrCodeTooLarge:
+		code = codes.ResourceExhausted
+	case storage.ErrCodeUnavailable:
+		code = codes.Unavailable
+	case storage.ErrCodeInvalidQuery:
+		code = codes.InvalidArgument
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,63 @@
+package grpcstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jinzhu/configor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/grpcstorage/storagepb"
+)
+
+const (
+	StorageName = "grpc"
+)
+
+func init() {
+	storage.RegisterStorageFactoryFunc(StorageName, NewStorageFactory)
+}
+
+func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
+	if configPath == "" {
+		return nil, fmt.Errorf("configPath should not be empty")
+	}
+
+	cfg := &Config{}
+	if err := configor.Load(cfg, configPath); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return newStorageFactory(cfg)
+}
+
+func newStorageFactory(cfg *Config) (*StorageFactory, error) {
+	conn, err := grpc.NewClient(cfg.Address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(cfg.maxMessageSize())),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the client of the storage plugin: %w", err)
+	}
+	client := storagepb.NewStoragePluginClient(conn)
+
+	// the supported verbs are requested once, which also checks the connection to the plugin
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.dialTimeout())
+	defer cancel()
+	resp, err := client.GetSupportedRequestVerbs(ctx, &storagepb.GetSupportedRequestVerbsRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to connect to the storage plugin: %w", err)
+	}
+
+	return &StorageFactory{
+		conn:    conn,
+		client:  client,
+		verbs:   resp.Verbs,
+		timeout: cfg.dialTimeout(),
+	}, nil
+}
@@ -0,0 +1,212 @@
+package grpcstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/grpcstorage/storagepb"
+)
+
+// ResourceStorage stores the objects of a resource by the storage plugin,
+// the objects are sent to the plugin in JSON of the storage version of the resource.
+type ResourceStorage struct {
+	client   storagepb.StoragePluginClient
+	config   storage.ResourceStorageConfig
+	resource *storagepb.ResourceConfig
+}
+
+var _ storage.ResourceStorage = &ResourceStorage{}
+
+func (s *ResourceStorage) GetStorageConfig() *storage.ResourceStorageConfig {
+	config := s.config
+	return &config
+}
+
+func (s *ResourceStorage) encode(obj runtime.Object) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := s.config.Codec.Encode(obj, &buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (s *ResourceStorage) objectKey(cluster string, obj runtime.Object) string {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return cluster
+	}
+	if metaobj.GetNamespace() == "" {
+		return cluster + "/" + metaobj.GetName()
+	}
+	return cluster + "/" + metaobj.GetNamespace() + "/" + metaobj.GetName()
+}
+
+func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		return fmt.Errorf("%s: kind is required", gvk)
+	}
+
+	data, err := s.encode(obj)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Create(ctx, &storagepb.WriteRequest{Config: s.resource, Cluster: cluster, Object: data})
+	return InterpretStatusError(s.objectKey(cluster, obj), err)
+}
+
+func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) error {
+	data, err := s.encode(obj)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Update(ctx, &storagepb.WriteRequest{Config: s.resource, Cluster: cluster, Object: data})
+	return InterpretStatusError(s.objectKey(cluster, obj), err)
+}
+
+func (s *ResourceStorage) ConvertDeletedObject(obj interface{}) (runtime.Object, error) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}, nil
+}
+
+// Delete only sends the metadata of the object, since the object may be converted by ConvertDeletedObject.
+func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) error {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(&metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metaobj.GetNamespace(), Name: metaobj.GetName(), UID: metaobj.GetUID()},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Delete(ctx, &storagepb.WriteRequest{Config: s.resource, Cluster: cluster, Object: data})
+	return InterpretStatusError(s.objectKey(cluster, obj), err)
+}
+
+func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event *corev1.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.RecordEvent(ctx, &storagepb.RecordEventRequest{Config: s.resource, Cluster: cluster, Event: data})
+	return InterpretStatusError(s.objectKey(cluster, event), err)
+}
+
+func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, into runtime.Object) error {
+	resp, err := s.client.Get(ctx, &storagepb.GetRequest{Config: s.resource, Cluster: cluster, Namespace: namespace, Name: name})
+	if err != nil {
+		return InterpretStatusError(cluster+"/"+namespace+"/"+name, err)
+	}
+
+	obj, _, err := s.config.Codec.Decode(resp.Object, nil, into)
+	if err != nil {
+		return err
+	}
+	if obj != into {
+		return fmt.Errorf("failed to decode resource, into is %T", into)
+	}
+	return nil
+}
+
+func (s *ResourceStorage) List(ctx context.Context, listObject runtime.Object, opts *internal.ListOptions) error {
+	query, err := encodeListOptions(opts)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.List(ctx, &storagepb.ListRequest{Config: s.resource, ListOptions: query})
+	if err != nil {
+		return InterpretStatusError(s.config.StorageResource.String(), err)
+	}
+
+	list, err := meta.ListAccessor(listObject)
+	if err != nil {
+		return err
+	}
+	list.SetResourceVersion(resp.ResourceVersion)
+	list.SetContinue(resp.Continue)
+	list.SetRemainingItemCount(resp.RemainingItemCount)
+	if len(resp.Objects) == 0 {
+		return nil
+	}
+
+	if unstructuredList, ok := listObject.(*unstructured.UnstructuredList); ok {
+		unstructuredList.Items = make([]unstructured.Unstructured, 0, len(resp.Objects))
+		for _, data := range resp.Objects {
+			obj, _, err := s.config.Codec.Decode(data, nil, &unstructured.Unstructured{})
+			if err != nil {
+				return err
+			}
+			uObj, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("the decoded object is not *unstructured.Unstructured")
+			}
+			unstructuredList.Items = append(unstructuredList.Items, *uObj)
+		}
+		return nil
+	}
+
+	listPtr, err := meta.GetItemsPtr(listObject)
+	if err != nil {
+		return err
+	}
+	v, err := conversion.EnforcePtr(listPtr)
+	if err != nil || v.Kind() != reflect.Slice {
+		return fmt.Errorf("need ptr to slice: %v", err)
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(resp.Objects), len(resp.Objects))
+	expected := reflect.New(v.Type().Elem()).Interface().(runtime.Object)
+	for i, data := range resp.Objects {
+		obj, _, err := s.config.Codec.Decode(data, nil, expected.DeepCopyObject())
+		if err != nil {
+			return err
+		}
+		slice.Index(i).Set(reflect.ValueOf(obj).Elem())
+	}
+	v.Set(slice)
+	return nil
+}
+
+// newWatchObject returns the object of the memory version to decode the watched object,
+// the same as the objects of the watch cache of the memory storage.
+func (s *ResourceStorage) newWatchObject(data []byte) runtime.Object {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(data, &typeMeta); err != nil || typeMeta.Kind == "" {
+		return &unstructured.Unstructured{}
+	}
+
+	gk := typeMeta.GroupVersionKind().GroupKind()
+	if !scheme.LegacyResourceScheme.IsGroupRegistered(gk.Group) {
+		return &unstructured.Unstructured{}
+	}
+	obj, err := scheme.LegacyResourceScheme.New(s.config.MemoryResource.GroupVersion().WithKind(gk.Kind))
+	if err != nil {
+		return &unstructured.Unstructured{}
+	}
+	return obj
+}
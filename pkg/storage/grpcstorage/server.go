@@ -0,0 +1,342 @@
+package grpcstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/grpcstorage/storagepb"
+)
+
+// Server serves a storage layer as the storage plugin, so the storage layers written in Go
+// can also run out of process by registering the server to a grpc server:
+//
+//	storagepb.RegisterStoragePluginServer(grpcServer, grpcstorage.NewServer(storageFactory))
+type Server struct {
+	storagepb.UnimplementedStoragePluginServer
+
+	storage       storage.StorageFactory
+	configFactory *resourceconfigfactory.ResourceConfigFactory
+
+	lock        sync.Mutex
+	resources   map[schema.GroupVersionResource]storage.ResourceStorage
+	collections map[string]storage.CollectionResourceStorage
+}
+
+var _ storagepb.StoragePluginServer = &Server{}
+
+func NewServer(storageFactory storage.StorageFactory) *Server {
+	return &Server{
+		storage:       storageFactory,
+		configFactory: resourceconfigfactory.New(),
+		resources:     make(map[schema.GroupVersionResource]storage.ResourceStorage),
+		collections:   make(map[string]storage.CollectionResourceStorage),
+	}
+}
+
+// resourceStorage returns the resource storage of the storage resource, the storage is created once.
+func (s *Server) resourceStorage(resource *storagepb.ResourceConfig) (storage.ResourceStorage, error) {
+	if resource == nil || resource.StorageResource == nil {
+		return nil, status.Error(codes.InvalidArgument, "the config of the resource is required")
+	}
+	gvr := fromGroupVersionResource(resource.StorageResource)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if resourceStorage, ok := s.resources[gvr]; ok {
+		return resourceStorage, nil
+	}
+
+	config, err := s.configFactory.NewConfig(gvr, resource.Namespaced)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if resource.GroupResource != nil {
+		config.GroupResource = fromGroupVersionResource(resource.GroupResource).GroupResource()
+	}
+	resourceStorage, err := s.storage.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	s.resources[gvr] = resourceStorage
+	return resourceStorage, nil
+}
+
+func (s *Server) collectionResourceStorage(ctx context.Context, name string) (storage.CollectionResourceStorage, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if collectionStorage, ok := s.collections[name]; ok {
+		return collectionStorage, nil
+	}
+
+	crs, err := s.storage.GetCollectionResources(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	for _, cr := range crs {
+		if cr.Name != name {
+			continue
+		}
+
+		collectionStorage, err := s.storage.NewCollectionResourceStorage(cr)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		s.collections[name] = collectionStorage
+		return collectionStorage, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "collection resource %s is not found", name)
+}
+
+func (s *Server) GetSupportedRequestVerbs(_ context.Context, req *storagepb.GetSupportedRequestVerbsRequest) (*storagepb.GetSupportedRequestVerbsResponse, error) {
+	if req.Resource != nil {
+		verbs := storage.SupportedRequestVerbs(s.storage, fromGroupVersionResource(req.Resource))
+		return &storagepb.GetSupportedRequestVerbsResponse{Verbs: verbs}, nil
+	}
+	return &storagepb.GetSupportedRequestVerbsResponse{Verbs: s.storage.GetSupportedRequestVerbs()}, nil
+}
+
+func (s *Server) PrepareCluster(_ context.Context, req *storagepb.ClusterRequest) (*storagepb.Empty, error) {
+	return &storagepb.Empty{}, toStatusError(s.storage.PrepareCluster(req.Cluster))
+}
+
+func (s *Server) GetResourceVersions(ctx context.Context, req *storagepb.ClusterRequest) (*storagepb.GetResourceVersionsResponse, error) {
+	resourceversions, err := s.storage.GetResourceVersions(ctx, req.Cluster)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	toStrings := func(versions map[string]interface{}) map[string]string {
+		strs := make(map[string]string, len(versions))
+		for key, rv := range versions {
+			if str, ok := rv.(string); ok {
+				strs[key] = str
+			} else {
+				strs[key] = fmt.Sprint(rv)
+			}
+		}
+		return strs
+	}
+
+	resp := &storagepb.GetResourceVersionsResponse{}
+	for gvr, versions := range resourceversions {
+		resp.ResourceVersions = append(resp.ResourceVersions, &storagepb.ResourceVersions{
+			Resource:  toGroupVersionResource(gvr),
+			Resources: toStrings(versions.Resources),
+			Events:    toStrings(versions.Events),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) CleanCluster(ctx context.Context, req *storagepb.ClusterRequest) (*storagepb.Empty, error) {
+	return &storagepb.Empty{}, toStatusError(s.storage.CleanCluster(ctx, req.Cluster))
+}
+
+func (s *Server) CleanClusterResource(ctx context.Context, req *storagepb.CleanClusterResourceRequest) (*storagepb.Empty, error) {
+	err := s.storage.CleanClusterResource(ctx, req.Cluster, fromGroupVersionResource(req.Resource))
+	return &storagepb.Empty{}, toStatusError(err)
+}
+
+func (s *Server) GetCollectionResources(ctx context.Context, _ *storagepb.GetCollectionResourcesRequest) (*storagepb.GetCollectionResourcesResponse, error) {
+	crs, err := s.storage.GetCollectionResources(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &storagepb.GetCollectionResourcesResponse{}
+	for _, cr := range crs {
+		data, err := encodeCollectionResource(cr)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.CollectionResources = append(resp.CollectionResources, data)
+	}
+	return resp, nil
+}
+
+func (s *Server) GetCollectionResource(ctx context.Context, req *storagepb.GetCollectionResourceRequest) (*storagepb.GetCollectionResourceResponse, error) {
+	opts, err := decodeListOptions(req.ListOptions)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	collectionStorage, err := s.collectionResourceStorage(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	cr, err := collectionStorage.Get(ctx, opts)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	data, err := encodeCollectionResource(cr)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &storagepb.GetCollectionResourceResponse{CollectionResource: data}, nil
+}
+
+func (s *Server) PrepareResource(_ context.Context, req *storagepb.PrepareResourceRequest) (*storagepb.Empty, error) {
+	_, err := s.resourceStorage(req.Config)
+	return &storagepb.Empty{}, err
+}
+
+func (s *Server) Get(ctx context.Context, req *storagepb.GetRequest) (*storagepb.GetResponse, error) {
+	resourceStorage, err := s.resourceStorage(req.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := resourceStorage.Get(ctx, req.Cluster, req.Namespace, req.Name, obj); err != nil {
+		return nil, toStatusError(err)
+	}
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &storagepb.GetResponse{Object: data}, nil
+}
+
+func (s *Server) List(ctx context.Context, req *storagepb.ListRequest) (*storagepb.ListResponse, error) {
+	resourceStorage, err := s.resourceStorage(req.Config)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := decodeListOptions(req.ListOptions)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	list := &unstructured.UnstructuredList{}
+	if err := resourceStorage.List(ctx, list, opts); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	resp := &storagepb.ListResponse{
+		ResourceVersion:    list.GetResourceVersion(),
+		Continue:           list.GetContinue(),
+		RemainingItemCount: list.GetRemainingItemCount(),
+	}
+	for i := range list.Items {
+		data, err := list.Items[i].MarshalJSON()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Objects = append(resp.Objects, data)
+	}
+	return resp, nil
+}
+
+func (s *Server) Watch(req *storagepb.WatchRequest, stream storagepb.StoragePlugin_WatchServer) error {
+	resourceStorage, err := s.resourceStorage(req.Config)
+	if err != nil {
+		return err
+	}
+	opts, err := decodeListOptions(req.ListOptions)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	watcher, err := resourceStorage.Watch(stream.Context(), opts)
+	if err != nil {
+		return toStatusError(err)
+	}
+	defer watcher.Stop()
+
+	codec := resourceStorage.GetStorageConfig().Codec
+	for {
+		select {
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+
+			var buffer bytes.Buffer
+			if event.Type == watch.Error {
+				err = json.NewEncoder(&buffer).Encode(event.Object)
+			} else {
+				err = codec.Encode(event.Object, &buffer)
+			}
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			if err := stream.Send(&storagepb.WatchEvent{Type: string(event.Type), Object: buffer.Bytes()}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// decodeObject decodes the object by the codec of the resource storage,
+// the decoded object is the object of the memory version which is written by the resource storage.
+func decodeObject(resourceStorage storage.ResourceStorage, data []byte) (runtime.Object, error) {
+	obj, _, err := resourceStorage.GetStorageConfig().Codec.Decode(data, nil, nil)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return obj, nil
+}
+
+func (s *Server) Create(ctx context.Context, req *storagepb.WriteRequest) (*storagepb.Empty, error) {
+	resourceStorage, err := s.resourceStorage(req.Config)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := decodeObject(resourceStorage, req.Object)
+	if err != nil {
+		return nil, err
+	}
+	return &storagepb.Empty{}, toStatusError(resourceStorage.Create(ctx, req.Cluster, obj))
+}
+
+func (s *Server) Update(ctx context.Context, req *storagepb.WriteRequest) (*storagepb.Empty, error) {
+	resourceStorage, err := s.resourceStorage(req.Config)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := decodeObject(resourceStorage, req.Object)
+	if err != nil {
+		return nil, err
+	}
+	return &storagepb.Empty{}, toStatusError(resourceStorage.Update(ctx, req.Cluster, obj))
+}
+
+func (s *Server) Delete(ctx context.Context, req *storagepb.WriteRequest) (*storagepb.Empty, error) {
+	resourceStorage, err := s.resourceStorage(req.Config)
+	if err != nil {
+		return nil, err
+	}
+	obj := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(req.Object, obj); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &storagepb.Empty{}, toStatusError(resourceStorage.Delete(ctx, req.Cluster, obj))
+}
+
+func (s *Server) RecordEvent(ctx context.Context, req *storagepb.RecordEventRequest) (*storagepb.Empty, error) {
+	resourceStorage, err := s.resourceStorage(req.Config)
+	if err != nil {
+		return nil, err
+	}
+	event := &corev1.Event{}
+	if err := json.Unmarshal(req.Event, event); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &storagepb.Empty{}, toStatusError(resourceStorage.RecordEvent(ctx, req.Cluster, event))
+}
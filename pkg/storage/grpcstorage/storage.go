@@ -0,0 +1,169 @@
+package grpcstorage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/grpcstorage/storagepb"
+)
+
+// StorageFactory implements the storage layer over the StoragePlugin service of an out-of-process plugin,
+// so the storage can be implemented in any language without being built with clusterpedia.
+type StorageFactory struct {
+	conn   *grpc.ClientConn
+	client storagepb.StoragePluginClient
+	verbs  []string
+
+	// timeout bounds the requests of the resource verbs, which are cached by the resource
+	timeout       time.Duration
+	resourceVerbs sync.Map // map[schema.GroupVersionResource][]string
+}
+
+var (
+	_ storage.StorageFactory             = &StorageFactory{}
+	_ storage.ResourceRequestVerbsGetter = &StorageFactory{}
+	_ storage.HealthChecker              = &StorageFactory{}
+)
+
+func (s *StorageFactory) GetSupportedRequestVerbs() []string {
+	return s.verbs
+}
+
+func (s *StorageFactory) GetResourceRequestVerbs(gvr schema.GroupVersionResource) ([]string, bool) {
+	if verbs, ok := s.resourceVerbs.Load(gvr); ok {
+		return verbs.([]string), true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	resp, err := s.client.GetSupportedRequestVerbs(ctx, &storagepb.GetSupportedRequestVerbsRequest{Resource: toGroupVersionResource(gvr)})
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the supported verbs of the resource from the storage plugin", "gvr", gvr)
+		return nil, false
+	}
+	s.resourceVerbs.Store(gvr, resp.Verbs)
+	return resp.Verbs, true
+}
+
+// HealthCheck requests the supported verbs from the storage plugin,
+// which is served by the plugin without reaching its storage.
+func (s *StorageFactory) HealthCheck(ctx context.Context) error {
+	if _, err := s.client.GetSupportedRequestVerbs(ctx, &storagepb.GetSupportedRequestVerbsRequest{}); err != nil {
+		return fmt.Errorf("the storage plugin is unavailable: %w", err)
+	}
+	return nil
+}
+
+func (s *StorageFactory) Readiness(ctx context.Context) error {
+	return s.HealthCheck(ctx)
+}
+
+func (s *StorageFactory) PrepareCluster(cluster string) error {
+	_, err := s.client.PrepareCluster(context.TODO(), &storagepb.ClusterRequest{Cluster: cluster})
+	return InterpretStatusError(cluster, err)
+}
+
+func (s *StorageFactory) GetResourceVersions(ctx context.Context, cluster string) (map[schema.GroupVersionResource]storage.ClusterResourceVersions, error) {
+	resp, err := s.client.GetResourceVersions(ctx, &storagepb.ClusterRequest{Cluster: cluster})
+	if err != nil {
+		return nil, InterpretStatusError(cluster, err)
+	}
+
+	resourceversions := make(map[schema.GroupVersionResource]storage.ClusterResourceVersions, len(resp.ResourceVersions))
+	for _, rvs := range resp.ResourceVersions {
+		versions := storage.ClusterResourceVersions{
+			Resources: make(map[string]interface{}, len(rvs.Resources)),
+			Events:    make(map[string]interface{}, len(rvs.Events)),
+		}
+		for key, rv := range rvs.Resources {
+			versions.Resources[key] = rv
+		}
+		for key, rv := range rvs.Events {
+			versions.Events[key] = rv
+		}
+		resourceversions[fromGroupVersionResource(rvs.Resource)] = versions
+	}
+	return resourceversions, nil
+}
+
+func (s *StorageFactory) GetCollectionResources(ctx context.Context) ([]*internal.CollectionResource, error) {
+	resp, err := s.client.GetCollectionResources(ctx, &storagepb.GetCollectionResourcesRequest{})
+	if err != nil {
+		return nil, InterpretStatusError("", err)
+	}
+
+	crs := make([]*internal.CollectionResource, 0, len(resp.CollectionResources))
+	for _, data := range resp.CollectionResources {
+		cr, err := decodeCollectionResource(data)
+		if err != nil {
+			return nil, err
+		}
+		crs = append(crs, cr)
+	}
+	return crs, nil
+}
+
+func (s *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
+	resource := &storagepb.ResourceConfig{
+		Namespaced:      config.Namespaced,
+		GroupResource:   toGroupVersionResource(config.GroupResource.WithVersion("")),
+		StorageResource: toGroupVersionResource(config.StorageResource),
+		MemoryResource:  toGroupVersionResource(config.MemoryResource),
+	}
+	if _, err := s.client.PrepareResource(context.TODO(), &storagepb.PrepareResourceRequest{Config: resource}); err != nil {
+		return nil, fmt.Errorf("failed to prepare the resource %s: %w", config.StorageResource, InterpretStatusError("", err))
+	}
+
+	return &ResourceStorage{
+		client:   s.client,
+		config:   *config,
+		resource: resource,
+	}, nil
+}
+
+func (s *StorageFactory) NewCollectionResourceStorage(cr *internal.CollectionResource) (storage.CollectionResourceStorage, error) {
+	return &CollectionResourceStorage{client: s.client, name: cr.Name}, nil
+}
+
+func (s *StorageFactory) CleanCluster(ctx context.Context, cluster string) error {
+	_, err := s.client.CleanCluster(ctx, &storagepb.ClusterRequest{Cluster: cluster})
+	return InterpretStatusError(cluster, err)
+}
+
+func (s *StorageFactory) CleanClusterResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) error {
+	_, err := s.client.CleanClusterResource(ctx, &storagepb.CleanClusterResourceRequest{
+		Cluster:  cluster,
+		Resource: toGroupVersionResource(gvr),
+	})
+	return InterpretStatusError(cluster, err)
+}
+
+func (s *StorageFactory) Shutdown() error {
+	return s.conn.Close()
+}
+
+type CollectionResourceStorage struct {
+	client storagepb.StoragePluginClient
+	name   string
+}
+
+func (s *CollectionResourceStorage) Get(ctx context.Context, opts *internal.ListOptions) (*internal.CollectionResource, error) {
+	query, err := encodeListOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.GetCollectionResource(ctx, &storagepb.GetCollectionResourceRequest{Name: s.name, ListOptions: query})
+	if err != nil {
+		return nil, InterpretStatusError(s.name, err)
+	}
+	return decodeCollectionResource(resp.CollectionResource)
+}
@@ -0,0 +1,206 @@
+package grpcstorage
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/grpcstorage/storagepb"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/storagetesting"
+)
+
+var widgetsGVR = schema.GroupVersionResource{Group: "example.io", Version: "v1", Resource: "widgets"}
+
+// fakeStorageFactory records the list options sent to the plugin, and the events are not watchable
+type fakeStorageFactory struct {
+	*storagetesting.StorageFactory
+
+	listOpts *internal.ListOptions
+	watcher  *watch.FakeWatcher
+}
+
+func (f *fakeStorageFactory) GetSupportedRequestVerbs() []string {
+	return []string{"get", "list", "watch"}
+}
+
+func (f *fakeStorageFactory) GetResourceRequestVerbs(gvr schema.GroupVersionResource) ([]string, bool) {
+	if gvr.Resource == "events" {
+		return []string{"get", "list"}, true
+	}
+	return nil, false
+}
+
+func (f *fakeStorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
+	resourceStorage, err := f.StorageFactory.NewResourceStorage(config)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeResourceStorage{ResourceStorage: resourceStorage, factory: f}, nil
+}
+
+type fakeResourceStorage struct {
+	storage.ResourceStorage
+
+	factory *fakeStorageFactory
+}
+
+func (s *fakeResourceStorage) List(ctx context.Context, listObj runtime.Object, opts *internal.ListOptions) error {
+	s.factory.listOpts = opts
+	if err := s.ResourceStorage.List(ctx, listObj, opts); err != nil {
+		return err
+	}
+	listObj.(*unstructured.UnstructuredList).SetResourceVersion("10")
+	return nil
+}
+
+func (s *fakeResourceStorage) Watch(context.Context, *internal.ListOptions) (watch.Interface, error) {
+	return s.factory.watcher, nil
+}
+
+func newTestStorage(t *testing.T) (*fakeStorageFactory, *StorageFactory) {
+	fake := &fakeStorageFactory{StorageFactory: storagetesting.NewStorageFactory(), watcher: watch.NewFake()}
+
+	socket := filepath.Join(t.TempDir(), "storage.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	storagepb.RegisterStoragePluginServer(server, NewServer(fake))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	factory, err := newStorageFactory(&Config{Address: "unix://" + socket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = factory.Shutdown() })
+	return fake, factory
+}
+
+func newWidget(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.io/v1")
+	obj.SetKind("Widget")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetResourceVersion("1")
+	return obj
+}
+
+func newWidgetStorage(t *testing.T, factory *StorageFactory) storage.ResourceStorage {
+	config, err := resourceconfigfactory.New().NewConfig(widgetsGVR, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resourceStorage, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resourceStorage
+}
+
+func TestResourceStorage(t *testing.T) {
+	fake, factory := newTestStorage(t)
+	if verbs := factory.GetSupportedRequestVerbs(); !reflect.DeepEqual(verbs, []string{"get", "list", "watch"}) {
+		t.Errorf("unexpected supported verbs %v", verbs)
+	}
+	eventsGVR := schema.GroupVersionResource{Version: "v1", Resource: "events"}
+	if verbs := storage.SupportedRequestVerbs(factory, eventsGVR); !reflect.DeepEqual(verbs, []string{"get", "list"}) {
+		t.Errorf("unexpected supported verbs of events %v", verbs)
+	}
+	if verbs := storage.SupportedRequestVerbs(factory, widgetsGVR); !reflect.DeepEqual(verbs, []string{"get", "list", "watch"}) {
+		t.Errorf("unexpected supported verbs of widgets %v", verbs)
+	}
+
+	ctx := context.TODO()
+	if err := storage.CheckReadiness(ctx, factory); err != nil {
+		t.Errorf("expected the storage plugin is ready, got %v", err)
+	}
+
+	resourceStorage := newWidgetStorage(t, factory)
+	if err := resourceStorage.Create(ctx, "cluster-1", newWidget("widget-1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := resourceStorage.Create(ctx, "cluster-1", newWidget("widget-1")); !storage.IsConflict(err) {
+		t.Errorf("expected the conflict error, got %v", err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := resourceStorage.Get(ctx, "cluster-1", "default", "widget-1", obj); err != nil {
+		t.Fatal(err)
+	}
+	if obj.GetName() != "widget-1" || obj.GetKind() != "Widget" {
+		t.Errorf("unexpected object %v", obj)
+	}
+	if err := resourceStorage.Get(ctx, "cluster-2", "default", "widget-1", obj); !storage.IsNotFound(err) {
+		t.Errorf("expected the not found error, got %v", err)
+	}
+
+	list := &unstructured.UnstructuredList{}
+	opts := &internal.ListOptions{ClusterNames: []string{"cluster-1"}, Namespaces: []string{"default"}}
+	if err := resourceStorage.List(ctx, list, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.GetResourceVersion() != "10" {
+		t.Errorf("unexpected list %v", list)
+	}
+	if !reflect.DeepEqual(fake.listOpts.ClusterNames, opts.ClusterNames) || !reflect.DeepEqual(fake.listOpts.Namespaces, opts.Namespaces) {
+		t.Errorf("expected the list options are sent to the plugin, got %v", fake.listOpts)
+	}
+
+	versions, err := factory.GetResourceVersions(ctx, "cluster-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rv := versions[widgetsGVR].Resources["default/widget-1"]; rv != "1" {
+		t.Errorf("unexpected resource versions %v", versions)
+	}
+
+	deleted, err := resourceStorage.ConvertDeletedObject(newWidget("widget-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := resourceStorage.Delete(ctx, "cluster-1", deleted); err != nil {
+		t.Fatal(err)
+	}
+	if err := resourceStorage.Get(ctx, "cluster-1", "default", "widget-1", obj); !storage.IsNotFound(err) {
+		t.Errorf("expected the object is deleted, got %v", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	fake, factory := newTestStorage(t)
+	resourceStorage := newWidgetStorage(t, factory)
+
+	watcher, err := resourceStorage.Watch(context.TODO(), &internal.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+
+	go fake.watcher.Add(newWidget("widget-1"))
+	event := <-watcher.ResultChan()
+	if event.Type != watch.Added {
+		t.Fatalf("expected the added event, got %v", event)
+	}
+	if obj, ok := event.Object.(*unstructured.Unstructured); !ok || obj.GetName() != "widget-1" {
+		t.Errorf("unexpected object %v", event.Object)
+	}
+
+	go fake.watcher.Stop()
+	if _, ok := <-watcher.ResultChan(); ok {
+		t.Error("expected the watch is closed when the plugin stops the watch")
+	}
+}
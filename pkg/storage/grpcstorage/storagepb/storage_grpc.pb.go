@@ -0,0 +1,702 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: pkg/storage/grpcstorage/storagepb/storage.proto
+
+package storagepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	StoragePlugin_GetSupportedRequestVerbs_FullMethodName = "/clusterpedia.storage.v1alpha1.StoragePlugin/GetSupportedRequestVerbs"
+	StoragePlugin_PrepareCluster_FullMethodName           = "/clusterpedia.storage.v1alpha1.StoragePlugin/PrepareCluster"
+	StoragePlugin_GetResourceVersions_FullMethodName      = "/clusterpedia.storage.v1alpha1.StoragePlugin/GetResourceVersions"
+	StoragePlugin_CleanCluster_FullMethodName             = "/clusterpedia.storage.v1alpha1.StoragePlugin/CleanCluster"
+	StoragePlugin_CleanClusterResource_FullMethodName     = "/clusterpedia.storage.v1alpha1.StoragePlugin/CleanClusterResource"
+	StoragePlugin_GetCollectionResources_FullMethodName   = "/clusterpedia.storage.v1alpha1.StoragePlugin/GetCollectionResources"
+	StoragePlugin_GetCollectionResource_FullMethodName    = "/clusterpedia.storage.v1alpha1.StoragePlugin/GetCollectionResource"
+	StoragePlugin_PrepareResource_FullMethodName          = "/clusterpedia.storage.v1alpha1.StoragePlugin/PrepareResource"
+	StoragePlugin_Get_FullMethodName                      = "/clusterpedia.storage.v1alpha1.StoragePlugin/Get"
+	StoragePlugin_List_FullMethodName                     = "/clusterpedia.storage.v1alpha1.StoragePlugin/List"
+	StoragePlugin_Watch_FullMethodName                    = "/clusterpedia.storage.v1alpha1.StoragePlugin/Watch"
+	StoragePlugin_Create_FullMethodName                   = "/clusterpedia.storage.v1alpha1.StoragePlugin/Create"
+	StoragePlugin_Update_FullMethodName                   = "/clusterpedia.storage.v1alpha1.StoragePlugin/Update"
+	StoragePlugin_Delete_FullMethodName                   = "/clusterpedia.storage.v1alpha1.StoragePlugin/Delete"
+	StoragePlugin_RecordEvent_FullMethodName              = "/clusterpedia.storage.v1alpha1.StoragePlugin/RecordEvent"
+)
+
+// StoragePluginClient is the client API for StoragePlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StoragePlugin is the storage layer served by an out-of-process plugin,
+// the grpc storage layer of clusterpedia implements the StorageFactory and the ResourceStorage over it.
+//
+// The objects are encoded in JSON of their storage versions, the collection resources are encoded in JSON of
+// clusterpedia.io/v1beta1, and the list options are the query parameters of clusterpedia.io/v1beta1 ListOptions.
+//
+// The errors are returned with the status codes:
+//
+//	NOT_FOUND for the objects that do not exist,
+//	ALREADY_EXISTS for the conflicts,
+//	RESOURCE_EXHAUSTED for the objects that are too large,
+//	UNAVAILABLE for the recoverable errors of the storage,
+//	INVALID_ARGUMENT for the unsupported or invalid list options.
+type StoragePluginClient interface {
+	GetSupportedRequestVerbs(ctx context.Context, in *GetSupportedRequestVerbsRequest, opts ...grpc.CallOption) (*GetSupportedRequestVerbsResponse, error)
+	PrepareCluster(ctx context.Context, in *ClusterRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetResourceVersions(ctx context.Context, in *ClusterRequest, opts ...grpc.CallOption) (*GetResourceVersionsResponse, error)
+	CleanCluster(ctx context.Context, in *ClusterRequest, opts ...grpc.CallOption) (*Empty, error)
+	CleanClusterResource(ctx context.Context, in *CleanClusterResourceRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetCollectionResources(ctx context.Context, in *GetCollectionResourcesRequest, opts ...grpc.CallOption) (*GetCollectionResourcesResponse, error)
+	GetCollectionResource(ctx context.Context, in *GetCollectionResourceRequest, opts ...grpc.CallOption) (*GetCollectionResourceResponse, error)
+	// PrepareResource is called before the objects of the resource are stored or read.
+	PrepareResource(ctx context.Context, in *PrepareResourceRequest, opts ...grpc.CallOption) (*Empty, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Watch sends the events of the objects until the stream is canceled.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (StoragePlugin_WatchClient, error)
+	Create(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*Empty, error)
+	Update(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*Empty, error)
+	Delete(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*Empty, error)
+	RecordEvent(ctx context.Context, in *RecordEventRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type storagePluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStoragePluginClient(cc grpc.ClientConnInterface) StoragePluginClient {
+	return &storagePluginClient{cc}
+}
+
+func (c *storagePluginClient) GetSupportedRequestVerbs(ctx context.Context, in *GetSupportedRequestVerbsRequest, opts ...grpc.CallOption) (*GetSupportedRequestVerbsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSupportedRequestVerbsResponse)
+	err := c.cc.Invoke(ctx, StoragePlugin_GetSupportedRequestVerbs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) PrepareCluster(ctx context.Context, in *ClusterRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StoragePlugin_PrepareCluster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) GetResourceVersions(ctx context.Context, in *ClusterRequest, opts ...grpc.CallOption) (*GetResourceVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResourceVersionsResponse)
+	err := c.cc.Invoke(ctx, StoragePlugin_GetResourceVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) CleanCluster(ctx context.Context, in *ClusterRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StoragePlugin_CleanCluster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) CleanClusterResource(ctx context.Context, in *CleanClusterResourceRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StoragePlugin_CleanClusterResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) GetCollectionResources(ctx context.Context, in *GetCollectionResourcesRequest, opts ...grpc.CallOption) (*GetCollectionResourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCollectionResourcesResponse)
+	err := c.cc.Invoke(ctx, StoragePlugin_GetCollectionResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) GetCollectionResource(ctx context.Context, in *GetCollectionResourceRequest, opts ...grpc.CallOption) (*GetCollectionResourceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCollectionResourceResponse)
+	err := c.cc.Invoke(ctx, StoragePlugin_GetCollectionResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) PrepareResource(ctx context.Context, in *PrepareResourceRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StoragePlugin_PrepareResource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, StoragePlugin_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, StoragePlugin_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (StoragePlugin_WatchClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StoragePlugin_ServiceDesc.Streams[0], StoragePlugin_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storagePluginWatchClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StoragePlugin_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type storagePluginWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *storagePluginWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storagePluginClient) Create(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StoragePlugin_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) Update(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StoragePlugin_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) Delete(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StoragePlugin_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storagePluginClient) RecordEvent(ctx context.Context, in *RecordEventRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StoragePlugin_RecordEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StoragePluginServer is the server API for StoragePlugin service.
+// All implementations must embed UnimplementedStoragePluginServer
+// for forward compatibility
+//
+// StoragePlugin is the storage layer served by an out-of-process plugin,
+// the grpc storage layer of clusterpedia implements the StorageFactory and the ResourceStorage over it.
+//
+// The objects are encoded in JSON of their storage versions, the collection resources are encoded in JSON of
+// clusterpedia.io/v1beta1, and the list options are the query parameters of clusterpedia.io/v1beta1 ListOptions.
+//
+// The errors are returned with the status codes:
+//
+//	NOT_FOUND for the objects that do not exist,
+//	ALREADY_EXISTS for the conflicts,
+//	RESOURCE_EXHAUSTED for the objects that are too large,
+//	UNAVAILABLE for the recoverable errors of the storage,
+//	INVALID_ARGUMENT for the unsupported or invalid list options.
+type StoragePluginServer interface {
+	GetSupportedRequestVerbs(context.Context, *GetSupportedRequestVerbsRequest) (*GetSupportedRequestVerbsResponse, error)
+	PrepareCluster(context.Context, *ClusterRequest) (*Empty, error)
+	GetResourceVersions(context.Context, *ClusterRequest) (*GetResourceVersionsResponse, error)
+	CleanCluster(context.Context, *ClusterRequest) (*Empty, error)
+	CleanClusterResource(context.Context, *CleanClusterResourceRequest) (*Empty, error)
+	GetCollectionResources(context.Context, *GetCollectionResourcesRequest) (*GetCollectionResourcesResponse, error)
+	GetCollectionResource(context.Context, *GetCollectionResourceRequest) (*GetCollectionResourceResponse, error)
+	// PrepareResource is called before the objects of the resource are stored or read.
+	PrepareResource(context.Context, *PrepareResourceRequest) (*Empty, error)
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Watch sends the events of the objects until the stream is canceled.
+	Watch(*WatchRequest, StoragePlugin_WatchServer) error
+	Create(context.Context, *WriteRequest) (*Empty, error)
+	Update(context.Context, *WriteRequest) (*Empty, error)
+	Delete(context.Context, *WriteRequest) (*Empty, error)
+	RecordEvent(context.Context, *RecordEventRequest) (*Empty, error)
+	mustEmbedUnimplementedStoragePluginServer()
+}
+
+// UnimplementedStoragePluginServer must be embedded to have forward compatible implementations.
+type UnimplementedStoragePluginServer struct {
+}
+
+func (UnimplementedStoragePluginServer) GetSupportedRequestVerbs(context.Context, *GetSupportedRequestVerbsRequest) (*GetSupportedRequestVerbsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupportedRequestVerbs not implemented")
+}
+func (UnimplementedStoragePluginServer) PrepareCluster(context.Context, *ClusterRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareCluster not implemented")
+}
+func (UnimplementedStoragePluginServer) GetResourceVersions(context.Context, *ClusterRequest) (*GetResourceVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourceVersions not implemented")
+}
+func (UnimplementedStoragePluginServer) CleanCluster(context.Context, *ClusterRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanCluster not implemented")
+}
+func (UnimplementedStoragePluginServer) CleanClusterResource(context.Context, *CleanClusterResourceRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanClusterResource not implemented")
+}
+func (UnimplementedStoragePluginServer) GetCollectionResources(context.Context, *GetCollectionResourcesRequest) (*GetCollectionResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollectionResources not implemented")
+}
+func (UnimplementedStoragePluginServer) GetCollectionResource(context.Context, *GetCollectionResourceRequest) (*GetCollectionResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollectionResource not implemented")
+}
+func (UnimplementedStoragePluginServer) PrepareResource(context.Context, *PrepareResourceRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareResource not implemented")
+}
+func (UnimplementedStoragePluginServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedStoragePluginServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedStoragePluginServer) Watch(*WatchRequest, StoragePlugin_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedStoragePluginServer) Create(context.Context, *WriteRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedStoragePluginServer) Update(context.Context, *WriteRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedStoragePluginServer) Delete(context.Context, *WriteRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedStoragePluginServer) RecordEvent(context.Context, *RecordEventRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordEvent not implemented")
+}
+func (UnimplementedStoragePluginServer) mustEmbedUnimplementedStoragePluginServer() {}
+
+// UnsafeStoragePluginServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StoragePluginServer will
+// result in compilation errors.
+type UnsafeStoragePluginServer interface {
+	mustEmbedUnimplementedStoragePluginServer()
+}
+
+func RegisterStoragePluginServer(s grpc.ServiceRegistrar, srv StoragePluginServer) {
+	s.RegisterService(&StoragePlugin_ServiceDesc, srv)
+}
+
+func _StoragePlugin_GetSupportedRequestVerbs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSupportedRequestVerbsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).GetSupportedRequestVerbs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_GetSupportedRequestVerbs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).GetSupportedRequestVerbs(ctx, req.(*GetSupportedRequestVerbsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_PrepareCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).PrepareCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_PrepareCluster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).PrepareCluster(ctx, req.(*ClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_GetResourceVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).GetResourceVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_GetResourceVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).GetResourceVersions(ctx, req.(*ClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_CleanCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).CleanCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_CleanCluster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).CleanCluster(ctx, req.(*ClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_CleanClusterResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanClusterResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).CleanClusterResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_CleanClusterResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).CleanClusterResource(ctx, req.(*CleanClusterResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_GetCollectionResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).GetCollectionResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_GetCollectionResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).GetCollectionResources(ctx, req.(*GetCollectionResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_GetCollectionResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).GetCollectionResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_GetCollectionResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).GetCollectionResource(ctx, req.(*GetCollectionResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_PrepareResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).PrepareResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_PrepareResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).PrepareResource(ctx, req.(*PrepareResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StoragePluginServer).Watch(m, &storagePluginWatchServer{ServerStream: stream})
+}
+
+type StoragePlugin_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type storagePluginWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *storagePluginWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StoragePlugin_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).Create(ctx, req.(*WriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).Update(ctx, req.(*WriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).Delete(ctx, req.(*WriteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoragePlugin_RecordEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServer).RecordEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePlugin_RecordEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServer).RecordEvent(ctx, req.(*RecordEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StoragePlugin_ServiceDesc is the grpc.ServiceDesc for StoragePlugin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StoragePlugin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "clusterpedia.storage.v1alpha1.StoragePlugin",
+	HandlerType: (*StoragePluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSupportedRequestVerbs",
+			Handler:    _StoragePlugin_GetSupportedRequestVerbs_Handler,
+		},
+		{
+			MethodName: "PrepareCluster",
+			Handler:    _StoragePlugin_PrepareCluster_Handler,
+		},
+		{
+			MethodName: "GetResourceVersions",
+			Handler:    _StoragePlugin_GetResourceVersions_Handler,
+		},
+		{
+			MethodName: "CleanCluster",
+			Handler:    _StoragePlugin_CleanCluster_Handler,
+		},
+		{
+			MethodName: "CleanClusterResource",
+			Handler:    _StoragePlugin_CleanClusterResource_Handler,
+		},
+		{
+			MethodName: "GetCollectionResources",
+			Handler:    _StoragePlugin_GetCollectionResources_Handler,
+		},
+		{
+			MethodName: "GetCollectionResource",
+			Handler:    _StoragePlugin_GetCollectionResource_Handler,
+		},
+		{
+			MethodName: "PrepareResource",
+			Handler:    _StoragePlugin_PrepareResource_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _StoragePlugin_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _StoragePlugin_List_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _StoragePlugin_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _StoragePlugin_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _StoragePlugin_Delete_Handler,
+		},
+		{
+			MethodName: "RecordEvent",
+			Handler:    _StoragePlugin_RecordEvent_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _StoragePlugin_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/storage/grpcstorage/storagepb/storage.proto",
+}
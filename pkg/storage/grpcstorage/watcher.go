@@ -0,0 +1,113 @@
+package grpcstorage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/grpcstorage/storagepb"
+	utilwatch "github.com/clusterpedia-io/clusterpedia/pkg/utils/watch"
+)
+
+// Watch watches the objects by the event stream of the storage plugin,
+// the errors of the plugin after the stream is established are sent as the ERROR events.
+func (s *ResourceStorage) Watch(ctx context.Context, options *internal.ListOptions) (watch.Interface, error) {
+	query, err := encodeListOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := s.client.Watch(ctx, &storagepb.WatchRequest{Config: s.resource, ListOptions: query})
+	if err != nil {
+		cancel()
+		return nil, InterpretStatusError(s.config.StorageResource.String(), err)
+	}
+
+	w := &watcher{
+		storage: s,
+		result:  make(chan watch.Event, 100),
+		cancel:  cancel,
+	}
+	go w.run(ctx, stream)
+	return w, nil
+}
+
+type watcher struct {
+	storage *ResourceStorage
+
+	result chan watch.Event
+	cancel context.CancelFunc
+}
+
+func (w *watcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *watcher) Stop() {
+	w.cancel()
+}
+
+func (w *watcher) send(ctx context.Context, event watch.Event) bool {
+	select {
+	case w.result <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (w *watcher) run(ctx context.Context, stream storagepb.StoragePlugin_WatchClient) {
+	defer close(w.result)
+	defer w.cancel()
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return
+			}
+			err = InterpretStatusError(w.storage.config.StorageResource.String(), err)
+			if storage.IsInvalidQuery(err) {
+				err = apierrors.NewBadRequest(err.Error())
+			} else {
+				err = apierrors.NewInternalError(err)
+			}
+			w.send(ctx, utilwatch.NewErrorEvent(err))
+			return
+		}
+
+		event, err := w.decodeEvent(ev)
+		if err != nil {
+			w.send(ctx, utilwatch.NewErrorEvent(apierrors.NewInternalError(err)))
+			return
+		}
+		if !w.send(ctx, event) {
+			return
+		}
+	}
+}
+
+func (w *watcher) decodeEvent(ev *storagepb.WatchEvent) (watch.Event, error) {
+	eventType := watch.EventType(ev.Type)
+	if eventType == watch.Error {
+		status := &metav1.Status{}
+		if err := json.Unmarshal(ev.Object, status); err != nil {
+			return watch.Event{}, err
+		}
+		return watch.Event{Type: eventType, Object: status}, nil
+	}
+
+	obj, _, err := w.storage.config.Codec.Decode(ev.Object, nil, w.storage.newWatchObject(ev.Object))
+	if err != nil {
+		return watch.Event{}, err
+	}
+	return watch.Event{Type: eventType, Object: obj}, nil
+}
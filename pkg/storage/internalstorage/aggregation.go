@@ -0,0 +1,159 @@
+package internalstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var (
+	_ storage.ResourceAggregator = &ResourceStorage{}
+	_ storage.ResourceAggregator = &CollectionResourceStorage{}
+)
+
+func (s *ResourceStorage) Aggregate(ctx context.Context, opts *internal.ListOptions, groupBy []storage.GroupBy) ([]storage.AggregationGroup, error) {
+	db, done, err := s.tenants.begin(ctx, s.replicas.reader(s.db))
+	if err != nil {
+		return nil, InterpretDBError(s.groupResource.String(), err)
+	}
+	defer done()
+
+	if err := s.validateCompressedQuery(opts); err != nil {
+		return nil, err
+	}
+	if db, err = s.isolation.tables(db, opts.ClusterNames); err != nil {
+		return nil, InterpretDBError(s.groupResource.String(), err)
+	}
+
+	aggregateOpts := *opts
+	aggregateOpts.Limit, aggregateOpts.Continue, aggregateOpts.OrderBy, aggregateOpts.WithRemainingCount = 0, "", nil, nil
+	query := excludeTombstones(db.Model(&Resource{}).Where(s.gvrKeyMap()), s.softDelete)
+	_, _, query, err = applyListOptionsToResourceQuery(db, query, &aggregateOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := aggregate(query, groupBy, opts.Limit, false)
+	if err != nil {
+		return nil, InterpretDBError(s.groupResource.String(), err)
+	}
+	return groups, nil
+}
+
+func (s *CollectionResourceStorage) Aggregate(ctx context.Context, opts *internal.ListOptions, groupBy []storage.GroupBy) ([]storage.AggregationGroup, error) {
+	db, done, err := s.tenants.begin(ctx, s.replicas.reader(s.db))
+	if err != nil {
+		return nil, InterpretDBError(s.collectionResource.Name, err)
+	}
+	defer done()
+
+	query, _, err := s.query(db, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregateOpts := *opts
+	aggregateOpts.Limit, aggregateOpts.Continue, aggregateOpts.OrderBy, aggregateOpts.WithRemainingCount = 0, "", nil, nil
+	_, _, query, err = applyListOptionsToCollectionResourceQuery(query, &aggregateOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := aggregate(query, groupBy, opts.Limit, true)
+	if err != nil {
+		return nil, InterpretDBError(s.collectionResource.Name, err)
+	}
+	return groups, nil
+}
+
+// aggregate groups the rows of the query by the keys, the group resource key selects both the group and the resource columns.
+func aggregate(query *gorm.DB, groupBy []storage.GroupBy, limit int64, collection bool) ([]storage.AggregationGroup, error) {
+	if len(groupBy) == 0 {
+		return nil, apierrors.NewBadRequest("the group by keys are required")
+	}
+
+	var (
+		selects []string
+		columns []string
+		vars    []interface{}
+
+		// the number of the selected columns of each key
+		widths []int
+	)
+	addColumn := func(expr interface{}) {
+		column := fmt.Sprintf("group_%d", len(columns))
+		selects, columns, vars = append(selects, "? AS "+column), append(columns, column), append(vars, expr)
+	}
+	for _, by := range groupBy {
+		switch by.Type {
+		case storage.GroupByCluster:
+			addColumn(clause.Column{Name: "cluster"})
+		case storage.GroupByNamespace:
+			addColumn(clause.Column{Name: "namespace"})
+		case storage.GroupByLabel:
+			addColumn(JSONValue("object", "metadata", "labels", by.Label))
+		case storage.GroupByField:
+			addColumn(JSONValue("object", by.Fields...))
+		case storage.GroupByResource:
+			if !collection {
+				return nil, apierrors.NewBadRequest("only the collection resources can be grouped by the resource")
+			}
+			addColumn(clause.Column{Name: "group"})
+			addColumn(clause.Column{Name: "resource"})
+			widths = append(widths, 2)
+			continue
+		default:
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("unsupported group by type %q", by.Type))
+		}
+		widths = append(widths, 1)
+	}
+
+	query = query.Select(strings.Join(selects, ", ")+", COUNT(*) AS objects", vars...).
+		Group(strings.Join(columns, ", ")).
+		Order("objects DESC, " + strings.Join(columns, ", "))
+	if limit > 0 {
+		query = query.Limit(int(limit))
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []storage.AggregationGroup
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, 0, len(columns)+1)
+	for i := range values {
+		dest = append(dest, &values[i])
+	}
+	var count int64
+	dest = append(dest, &count)
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		group := storage.AggregationGroup{Values: make([]string, 0, len(groupBy)), Count: count}
+		var i int
+		for _, width := range widths {
+			if width == 2 {
+				group.Values = append(group.Values, schema.GroupResource{Group: values[i].String, Resource: values[i+1].String}.String())
+			} else {
+				group.Values = append(group.Values, values[i].String)
+			}
+			i += width
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
@@ -0,0 +1,79 @@
+package internalstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestAggregate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Resource: "pods"}, true)
+	require.NoError(err)
+	rs := newTestResourceStorage(db, corev1.SchemeGroupVersion.WithResource("pods"))
+	rs.config = storage.ResourceStorageConfig{ResourceConfig: *config}
+
+	newPod := func(name, app string, phase corev1.PodPhase) *corev1.Pod {
+		pod := &corev1.Pod{
+			TypeMeta:   metav1.TypeMeta{Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name), ResourceVersion: "1"},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		if app != "" {
+			pod.Labels = map[string]string{"app": app}
+		}
+		return pod
+	}
+	require.NoError(rs.Create(context.Background(), "cluster-1", newPod("pod-1", "nginx", corev1.PodRunning)))
+	require.NoError(rs.Create(context.Background(), "cluster-1", newPod("pod-2", "nginx", corev1.PodPending)))
+	require.NoError(rs.Create(context.Background(), "cluster-1", newPod("pod-3", "", corev1.PodRunning)))
+	require.NoError(rs.Create(context.Background(), "cluster-2", newPod("pod-4", "nginx", corev1.PodRunning)))
+
+	groups, err := rs.Aggregate(context.Background(), &internal.ListOptions{}, []storage.GroupBy{
+		{Type: storage.GroupByCluster},
+		{Type: storage.GroupByField, Fields: []string{"status", "phase"}},
+	})
+	require.NoError(err)
+	assert.Equal([]storage.AggregationGroup{
+		{Values: []string{"cluster-1", "Running"}, Count: 2},
+		{Values: []string{"cluster-1", "Pending"}, Count: 1},
+		{Values: []string{"cluster-2", "Running"}, Count: 1},
+	}, groups)
+
+	// the groups are limited after sorted by the count
+	groups, err = rs.Aggregate(context.Background(), &internal.ListOptions{ListOptions: metainternal.ListOptions{Limit: 1}}, []storage.GroupBy{
+		{Type: storage.GroupByLabel, Label: "app"},
+	})
+	require.NoError(err)
+	assert.Equal([]storage.AggregationGroup{{Values: []string{"nginx"}, Count: 3}}, groups)
+
+	_, err = rs.Aggregate(context.Background(), &internal.ListOptions{}, []storage.GroupBy{{Type: storage.GroupByResource}})
+	assert.Error(err)
+
+	collection := NewCollectionResourceStorage(db, &internal.CollectionResource{
+		ObjectMeta:    metav1.ObjectMeta{Name: "workloads"},
+		ResourceTypes: []internal.CollectionResourceType{{Group: "", Resource: "pods"}},
+	})
+	groups, err = collection.Aggregate(context.Background(), &internal.ListOptions{ClusterNames: []string{"cluster-2"}}, []storage.GroupBy{
+		{Type: storage.GroupByResource}, {Type: storage.GroupByNamespace},
+	})
+	require.NoError(err)
+	assert.Equal([]storage.AggregationGroup{{Values: []string{"pods", "default"}, Count: 1}}, groups)
+}
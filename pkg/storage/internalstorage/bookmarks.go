@@ -0,0 +1,76 @@
+package internalstorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// the table named by the default naming strategy of gorm
+const watchBookmarksTable = "watch_bookmarks"
+
+var _ storage.WatchBookmarkStorage = &StorageFactory{}
+
+// WatchBookmark is the resource version of the watch bookmark of a resource of the cluster,
+// the watch of the resource is resumed from it after the restarts of the clustersynchro manager.
+type WatchBookmark struct {
+	ID uint `gorm:"primaryKey"`
+
+	Cluster  string `gorm:"size:253;not null;uniqueIndex:uni_watch_bookmarks_cluster_resource,length:100"`
+	Group    string `gorm:"size:63;not null;uniqueIndex:uni_watch_bookmarks_cluster_resource"`
+	Version  string `gorm:"size:15;not null;uniqueIndex:uni_watch_bookmarks_cluster_resource"`
+	Resource string `gorm:"size:63;not null;uniqueIndex:uni_watch_bookmarks_cluster_resource"`
+
+	ResourceVersion string    `gorm:"size:30;not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+}
+
+func (s *StorageFactory) GetWatchBookmarks(ctx context.Context, cluster string) (map[schema.GroupVersionResource]string, error) {
+	if !s.watchBookmarks {
+		return nil, nil
+	}
+
+	var bookmarks []WatchBookmark
+	if err := s.db.WithContext(ctx).Where(map[string]interface{}{"cluster": cluster}).Find(&bookmarks).Error; err != nil {
+		return nil, InterpretDBError(cluster, err)
+	}
+	versions := make(map[schema.GroupVersionResource]string, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		gvr := schema.GroupVersionResource{Group: bookmark.Group, Version: bookmark.Version, Resource: bookmark.Resource}
+		versions[gvr] = bookmark.ResourceVersion
+	}
+	return versions, nil
+}
+
+func (s *StorageFactory) SaveWatchBookmark(ctx context.Context, cluster string, gvr schema.GroupVersionResource, resourceVersion string) error {
+	if !s.watchBookmarks {
+		return nil
+	}
+
+	bookmark := &WatchBookmark{
+		Cluster:         cluster,
+		Group:           gvr.Group,
+		Version:         gvr.Version,
+		Resource:        gvr.Resource,
+		ResourceVersion: resourceVersion,
+	}
+	err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cluster"}, {Name: "group"}, {Name: "version"}, {Name: "resource"}},
+		DoUpdates: clause.AssignmentColumns([]string{"resource_version", "updated_at"}),
+	}).Create(bookmark).Error
+	return InterpretDBError(fmt.Sprintf("%s/%s", cluster, gvr), err)
+}
+
+// deleteWatchBookmarks deletes the bookmarks with the stored objects, otherwise the watches of the resources
+// are resumed from the bookmarks and the deleted objects are never synchronized again.
+func (s *StorageFactory) deleteWatchBookmarks(ctx context.Context, keys map[string]interface{}) error {
+	if !s.watchBookmarks {
+		return nil
+	}
+	return s.db.WithContext(ctx).Where(keys).Delete(&WatchBookmark{}).Error
+}
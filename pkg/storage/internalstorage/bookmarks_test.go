@@ -0,0 +1,53 @@
+package internalstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestStorageFactory_WatchBookmarksWithSQLite(t *testing.T) {
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+	require.NoError(db.AutoMigrate(&WatchBookmark{}))
+
+	ctx := context.Background()
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	disabled := &StorageFactory{db: db}
+	require.NoError(disabled.SaveWatchBookmark(ctx, "cluster-1", pods, "10"))
+	bookmarks, err := disabled.GetWatchBookmarks(ctx, "cluster-1")
+	require.NoError(err)
+	require.Empty(bookmarks)
+
+	factory := &StorageFactory{db: db, watchBookmarks: true}
+	require.NoError(factory.SaveWatchBookmark(ctx, "cluster-1", pods, "10"))
+	require.NoError(factory.SaveWatchBookmark(ctx, "cluster-1", pods, "20"))
+	require.NoError(factory.SaveWatchBookmark(ctx, "cluster-1", deployments, "15"))
+	require.NoError(factory.SaveWatchBookmark(ctx, "cluster-2", pods, "5"))
+
+	bookmarks, err = factory.GetWatchBookmarks(ctx, "cluster-1")
+	require.NoError(err)
+	require.Equal(map[schema.GroupVersionResource]string{pods: "20", deployments: "15"}, bookmarks)
+
+	// the bookmark is deleted with the stored objects of the resource
+	require.NoError(factory.CleanClusterResource(ctx, "cluster-1", pods))
+	bookmarks, err = factory.GetWatchBookmarks(ctx, "cluster-1")
+	require.NoError(err)
+	require.Equal(map[schema.GroupVersionResource]string{deployments: "15"}, bookmarks)
+
+	require.NoError(factory.CleanCluster(ctx, "cluster-1"))
+	bookmarks, err = factory.GetWatchBookmarks(ctx, "cluster-1")
+	require.NoError(err)
+	require.Empty(bookmarks)
+
+	bookmarks, err = factory.GetWatchBookmarks(ctx, "cluster-2")
+	require.NoError(err)
+	require.Equal(map[schema.GroupVersionResource]string{pods: "5"}, bookmarks)
+}
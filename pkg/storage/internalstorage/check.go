@@ -0,0 +1,79 @@
+package internalstorage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var (
+	_ storage.StorageChecker = &StorageFactory{}
+	_ storage.HealthChecker  = &StorageFactory{}
+)
+
+func (s *StorageFactory) Ping(ctx context.Context) error {
+	db, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return db.PingContext(ctx)
+}
+
+func (s *StorageFactory) CheckSchema(ctx context.Context) error {
+	plan, err := s.PlanSchemaMigration(ctx)
+	if err != nil {
+		return err
+	}
+	if len(plan.Incompatibilities) != 0 {
+		return fmt.Errorf("%s, migrate the storage by the init-storage command", strings.Join(plan.Incompatibilities, "; "))
+	}
+	return nil
+}
+
+func (s *StorageFactory) CheckPrivileges(ctx context.Context, writable bool) error {
+	// the privileges are checked when the statements are planned,
+	// so the statements which match no rows are enough, and they are rolled back anyway.
+	statements := []string{"SELECT id FROM resources WHERE 1 = 0"}
+	if writable {
+		statements = append(statements,
+			"INSERT INTO resources SELECT * FROM resources WHERE 1 = 0",
+			"UPDATE resources SET id = id WHERE 1 = 0",
+			"DELETE FROM resources WHERE 1 = 0",
+		)
+	}
+
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	defer tx.Rollback()
+
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return fmt.Errorf("%q: %w", statement, err)
+		}
+	}
+	return nil
+}
+
+func (s *StorageFactory) HealthCheck(ctx context.Context) error {
+	return s.Ping(ctx)
+}
+
+// Readiness checks the schema until it is compatible with the current version,
+// the schema is not changed back by the running components, so it is only pinged after that.
+func (s *StorageFactory) Readiness(ctx context.Context) error {
+	if err := s.Ping(ctx); err != nil {
+		return err
+	}
+	if s.schemaReady.Load() {
+		return nil
+	}
+	if err := s.CheckSchema(ctx); err != nil {
+		return err
+	}
+	s.schemaReady.Store(true)
+	return nil
+}
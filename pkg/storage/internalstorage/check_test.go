@@ -0,0 +1,60 @@
+package internalstorage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSchema(t *testing.T) {
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	factory := &StorageFactory{db: db}
+	assert.NoError(t, factory.CheckSchema(context.TODO()))
+
+	require.NoError(t, db.Migrator().DropColumn(&Resource{}, "owner_uid"))
+	assert.ErrorContains(t, factory.CheckSchema(context.TODO()), "owner_uid")
+
+	require.NoError(t, db.Migrator().DropTable(&Resource{}))
+	assert.ErrorContains(t, factory.CheckSchema(context.TODO()), "doesn't exist")
+}
+
+func TestReadiness(t *testing.T) {
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	factory := &StorageFactory{db: db}
+	require.NoError(t, db.Migrator().DropColumn(&Resource{}, "owner_uid"))
+	assert.NoError(t, factory.HealthCheck(context.TODO()))
+	assert.ErrorContains(t, factory.Readiness(context.TODO()), "owner_uid")
+
+	require.NoError(t, db.Migrator().AutoMigrate(&Resource{}))
+	assert.NoError(t, factory.Readiness(context.TODO()))
+}
+
+func TestCheckPrivileges(t *testing.T) {
+	db, mock, err := newMockedPostgresDB()
+	require.NoError(t, err)
+	factory := &StorageFactory{db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT id FROM resources WHERE 1 = 0")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	assert.NoError(t, factory.CheckPrivileges(context.TODO(), false))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT id FROM resources WHERE 1 = 0")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO resources SELECT * FROM resources WHERE 1 = 0")).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+	assert.ErrorIs(t, factory.CheckPrivileges(context.TODO(), true), assert.AnError)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
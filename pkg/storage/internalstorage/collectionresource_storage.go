@@ -26,12 +26,27 @@ const (
 
 type CollectionResourceStorage struct {
 	db         *gorm.DB
+	tenants    *tenantRoles
 	typesQuery *gorm.DB
 
+	// softDelete excludes the tombstones of the deleted objects
+	softDelete bool
+
+	// isolation reads the resources of the clusters from their own schemas, nil if it is disabled
+	isolation *clusterIsolation
+
+	// replicas serve the reads, nil if no read replica is configured
+	replicas *readReplicas
+
+	// view is the materialized view of the collection resource, nil if the collection resource isn't materialized
+	view *materializedView
+
 	collectionResource *internal.CollectionResource
 }
 
-func NewCollectionResourceStorage(db *gorm.DB, cr *internal.CollectionResource) storage.CollectionResourceStorage {
+var _ storage.CollectionResourceStorage = &CollectionResourceStorage{}
+
+func NewCollectionResourceStorage(db *gorm.DB, cr *internal.CollectionResource) *CollectionResourceStorage {
 	storage := &CollectionResourceStorage{db: db, collectionResource: cr.DeepCopy()}
 	if len(cr.ResourceTypes) == 0 {
 		return storage
@@ -61,13 +76,22 @@ func NewCollectionResourceStorage(db *gorm.DB, cr *internal.CollectionResource)
 	return storage
 }
 
-func (s *CollectionResourceStorage) query(ctx context.Context, opts *internal.ListOptions) (*gorm.DB, ObjectList, error) {
+func (s *CollectionResourceStorage) query(db *gorm.DB, opts *internal.ListOptions) (*gorm.DB, ObjectList, error) {
 	var result ObjectList = &ResourceList{}
 	if opts.OnlyMetadata {
 		result = &ResourceMetadataList{}
 	}
 
-	query := s.db.WithContext(ctx).Model(&Resource{})
+	// the tombstones are excluded from the view, and the rows of the view are in the resource types of the collection resource
+	if s.view.fresh() {
+		return db.Table(s.view.name).Model(&Resource{}), result, nil
+	}
+
+	db, err := s.isolation.tables(db, opts.ClusterNames)
+	if err != nil {
+		return nil, nil, InterpretDBError(s.collectionResource.Name, err)
+	}
+	query := excludeTombstones(db.Model(&Resource{}), s.softDelete)
 	if s.typesQuery != nil {
 		return query.Where(s.typesQuery), result, nil
 	}
@@ -104,7 +128,13 @@ func (s *CollectionResourceStorage) Get(ctx context.Context, opts *internal.List
 	ctx, span := tracing.Start(ctx, "GetCollectionResource from internalstorage")
 	defer span.End(500 * time.Millisecond)
 
-	query, list, err := s.query(ctx, opts)
+	db, done, err := s.tenants.begin(ctx, s.replicas.reader(s.db))
+	if err != nil {
+		return nil, InterpretDBError(s.collectionResource.Name, err)
+	}
+	defer done()
+
+	query, list, err := s.query(db, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -257,5 +287,12 @@ func parseGroupVersionResource(gvr string) (schema.GroupVersionResource, error)
 }
 
 func applyListOptionsToCollectionResourceQuery(query *gorm.DB, opts *internal.ListOptions) (int64, *int64, *gorm.DB, error) {
+	// the cluster-scoped objects are saved without the namespace
+	switch opts.ResourceScope {
+	case internal.ResourceScopeCluster:
+		query = query.Where("namespace = ?", "")
+	case internal.ResourceScopeNamespaced:
+		query = query.Where("namespace <> ?", "")
+	}
 	return applyListOptionsToQuery(query, opts, nil)
 }
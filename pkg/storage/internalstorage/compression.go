@@ -0,0 +1,256 @@
+package internalstorage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+// compressedStorageFormat is the storage format of the compressed or encoded rows,
+// the object column only keeps the metadata and the full object is in the compressed object column.
+const compressedStorageFormat = 3
+
+type compressor struct {
+	compress   func(data []byte) ([]byte, error)
+	decompress func(data []byte) ([]byte, error)
+}
+
+// compressors are the supported compression algorithms
+var compressors = map[string]compressor{
+	"gzip": {compress: gzipCompress, decompress: gzipDecompress},
+}
+
+func gzipCompress(data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func gzipDecompress(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+func (cfg *CompressionConfig) validate() error {
+	if cfg == nil {
+		return nil
+	}
+	if _, ok := compressors[cfg.algorithm()]; !ok {
+		return fmt.Errorf("compression: unsupported algorithm %q, only gzip is supported", cfg.Algorithm)
+	}
+	for i, resource := range cfg.Resources {
+		if schema.ParseGroupResource(resource).Resource == "" {
+			return fmt.Errorf("compression.resources[%d]: resource is required", i)
+		}
+	}
+	return nil
+}
+
+// compressedResources returns the compression algorithm of the compressed resources.
+func (cfg *CompressionConfig) compressedResources() map[schema.GroupResource]string {
+	if cfg == nil || len(cfg.Resources) == 0 {
+		return nil
+	}
+	resources := make(map[schema.GroupResource]string, len(cfg.Resources))
+	for _, resource := range cfg.Resources {
+		resources[schema.ParseGroupResource(resource)] = cfg.algorithm()
+	}
+	return resources
+}
+
+func (cfg *CompressionConfig) algorithm() string {
+	if cfg.Algorithm == "" {
+		return "gzip"
+	}
+	return cfg.Algorithm
+}
+
+// projectObject returns the projection of the object which only keeps the type meta and the metadata
+// without the managed fields, so the compressed or encoded objects can still be queried by the metadata.
+// The last applied configuration is also removed from the projection of the encrypted objects,
+// because it contains the full object.
+func projectObject(data []byte, encrypted bool) (JSON, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	projection := make(map[string]interface{}, 3)
+	for _, field := range []string{"apiVersion", "kind", "metadata"} {
+		if value, ok := object[field]; ok {
+			projection[field] = value
+		}
+	}
+	if metadata, ok := projection["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "managedFields")
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok && encrypted {
+			delete(annotations, lastAppliedConfigurationAnnotation)
+		}
+	}
+	return json.Marshal(projection)
+}
+
+// storedObject is the object column and the compressed object column of a row.
+type storedObject struct {
+	Object           JSON
+	CompressedObject []byte
+	Compression      string
+	Encoding         string
+	Encryption       string
+
+	// the key of the row is authenticated with the encrypted object
+	Cluster   string
+	Group     string
+	Version   string
+	Resource  string
+	Namespace string
+	Name      string
+}
+
+// storedObjectColumns are the columns of the storedObject
+var storedObjectColumns = []string{"object", "compressed_object", "compression", "encoding", "encryption",
+	"cluster", "group", "version", "resource", "namespace", "name"}
+
+// additionalData returns the key of the row which is authenticated by the encryption,
+// the fields are separated by the NUL which is not allowed in them.
+func (o storedObject) additionalData() []byte {
+	return []byte(strings.Join([]string{o.Cluster, o.Group, o.Version, o.Resource, o.Namespace, o.Name}, "\x00"))
+}
+
+// raw returns the full object in the encoding of the row.
+func (o storedObject) raw() ([]byte, error) {
+	if o.Compression == "" && o.Encoding == "" && o.Encryption == "" {
+		return o.Object, nil
+	}
+
+	data := o.CompressedObject
+	if o.Encryption != "" {
+		decrypted, err := decryptObject(o.Encryption, data, o.additionalData())
+		if err != nil {
+			return nil, err
+		}
+		data = decrypted
+	}
+	if o.Compression == "" {
+		return data, nil
+	}
+	c, ok := compressors[o.Compression]
+	if !ok {
+		return nil, fmt.Errorf("the object is compressed by the unsupported algorithm %q", o.Compression)
+	}
+	return c.decompress(data)
+}
+
+// decode returns the full object encoded in json.
+func (o storedObject) decode() ([]byte, error) {
+	data, err := o.raw()
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(o.Encoding, data)
+}
+
+// object returns the full object which is converted by the codec of the resource,
+// the object encoded in protobuf is decoded by the codec without being converted to json.
+func (o storedObject) object() (Object, error) {
+	data, err := o.raw()
+	if err != nil {
+		return nil, err
+	}
+	switch o.Encoding {
+	case "":
+		return Bytes(data), nil
+	case protobufEncoding:
+		return ProtobufBytes(data), nil
+	default:
+		return nil, fmt.Errorf("the object is encoded in the unsupported encoding %q", o.Encoding)
+	}
+}
+
+// encodeObject returns the stored object of the object in the cluster, data is the object encoded in json.
+func (s *ResourceStorage) encodeObject(cluster string, metaobj metav1.Object, obj runtime.Object, data []byte) (storedObject, error) {
+	stored := storedObject{
+		Object:    data,
+		Cluster:   cluster,
+		Group:     s.config.StorageResource.Group,
+		Version:   s.config.StorageResource.Version,
+		Resource:  s.config.StorageResource.Resource,
+		Namespace: metaobj.GetNamespace(),
+		Name:      metaobj.GetName(),
+	}
+	payload := data
+	if s.protobuf {
+		if encoded, err := s.encodeProtobuf(obj); err != nil {
+			// fall back to json, e.g. the object is unstructured
+			klog.V(4).InfoS("Failed to encode the object in protobuf, store it in json", "resource", s.groupResource, "error", err)
+		} else {
+			payload, stored.Encoding = encoded, protobufEncoding
+		}
+	}
+	if s.compression == "" && stored.Encoding == "" && s.encryption == nil {
+		return stored, nil
+	}
+
+	projection, err := projectObject(data, s.encryption != nil)
+	if err != nil {
+		return stored, err
+	}
+	stored.Object = projection
+	if s.compression != "" {
+		if payload, err = compressors[s.compression].compress(payload); err != nil {
+			return stored, err
+		}
+		stored.Compression = s.compression
+	}
+	if s.encryption != nil {
+		if payload, err = s.encryption.encrypt(payload, stored.additionalData()); err != nil {
+			return stored, fmt.Errorf("failed to encrypt the object: %w", err)
+		}
+		stored.Encryption = s.encryption.provider
+	}
+	stored.CompressedObject = payload
+	return stored, nil
+}
+
+// formatVersion returns the storage format of the stored object
+func (o storedObject) formatVersion() int {
+	if o.CompressedObject == nil {
+		return CurrentStorageFormat
+	}
+	return compressedStorageFormat
+}
+
+// validateCompressedQuery rejects the field selectors out of the metadata for the compressed, encoded or encrypted resources,
+// because only the metadata of the objects is kept in the json of the object column.
+func (s *ResourceStorage) validateCompressedQuery(opts *internal.ListOptions) error {
+	if (s.compression == "" && !s.protobuf && s.encryption == nil) || opts.EnhancedFieldSelector == nil {
+		return nil
+	}
+	requirements, _ := opts.EnhancedFieldSelector.Requirements()
+	for _, requirement := range requirements {
+		if fields := requirement.Fields(); len(fields) != 0 && fields[0].Name() != "metadata" {
+			return apierrors.NewBadRequest(fmt.Sprintf("the objects of %s are compressed, encoded in protobuf or encrypted, they can only be selected by the fields of the metadata", s.groupResource))
+		}
+	}
+	return nil
+}
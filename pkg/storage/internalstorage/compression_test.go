@@ -0,0 +1,93 @@
+package internalstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/api/clusterpedia/fields"
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestCompressionConfig_validate(t *testing.T) {
+	require.NoError(t, (*CompressionConfig)(nil).validate())
+	require.NoError(t, (&CompressionConfig{Resources: []string{"pods", "replicasets.apps"}}).validate())
+	require.Error(t, (&CompressionConfig{Algorithm: "zstd", Resources: []string{"pods"}}).validate())
+	require.Error(t, (&CompressionConfig{Resources: []string{".apps"}}).validate())
+
+	resources := (&CompressionConfig{Resources: []string{"pods", "replicasets.apps"}}).compressedResources()
+	require.Equal(t, map[schema.GroupResource]string{
+		{Resource: "pods"}:                       "gzip",
+		{Group: "apps", Resource: "replicasets"}: "gzip",
+	}, resources)
+}
+
+func TestResourceStorage_CompressionWithSQLite(t *testing.T) {
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+
+	factory := &StorageFactory{db: db, compressions: map[schema.GroupResource]string{{Resource: "pods"}: "gzip"}}
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Resource: "pods"}, true)
+	require.NoError(err)
+	s, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+	require.NoError(err)
+	rs := s.(*ResourceStorage)
+
+	ctx := context.Background()
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "web", ResourceVersion: "1", Labels: map[string]string{"app": "web"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1"},
+	}
+	require.NoError(rs.Create(ctx, "cluster-1", pod))
+
+	var stored Resource
+	require.NoError(db.First(&stored).Error)
+	require.Equal("gzip", stored.Compression)
+	require.Equal(compressedStorageFormat, stored.FormatVersion)
+	require.NotEmpty(stored.CompressedObject)
+	require.NotContains(string(stored.Object), "node-1")
+	require.NotContains(string(stored.Object), "managedFields")
+
+	// the objects are decompressed when they are read
+	got := &corev1.Pod{}
+	require.NoError(rs.Get(ctx, "cluster-1", "default", "web", got))
+	require.Equal("node-1", got.Spec.NodeName)
+	require.Len(got.ManagedFields, 1)
+
+	pod.ResourceVersion = "2"
+	pod.Spec.NodeName = "node-2"
+	require.NoError(rs.Update(ctx, "cluster-1", pod))
+
+	list := &corev1.PodList{}
+	selector, err := fields.Parse("metadata.name=web")
+	require.NoError(err)
+	require.NoError(rs.List(ctx, list, &internal.ListOptions{EnhancedFieldSelector: selector}))
+	require.Len(list.Items, 1)
+	require.Equal("node-2", list.Items[0].Spec.NodeName)
+
+	selector, err = fields.Parse("spec.nodeName=node-2")
+	require.NoError(err)
+	require.Error(rs.List(ctx, &corev1.PodList{}, &internal.ListOptions{EnhancedFieldSelector: selector}))
+
+	// the rows are outdated after the compression of the resource is changed
+	versions, err := factory.GetResourceVersions(ctx, "cluster-1")
+	require.NoError(err)
+	require.Equal("2", versions[corev1.SchemeGroupVersion.WithResource("pods")].Resources["default/web"])
+	factory.compressions = nil
+	versions, err = factory.GetResourceVersions(ctx, "cluster-1")
+	require.NoError(err)
+	require.Equal("", versions[corev1.SchemeGroupVersion.WithResource("pods")].Resources["default/web"])
+}
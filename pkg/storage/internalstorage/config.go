@@ -5,16 +5,16 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
-	"net"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/go-sql-driver/mysql"
-	"github.com/jackc/pgx/v5"
 	"gopkg.in/natefinch/lumberjack.v2"
 	"gorm.io/gorm/logger"
-	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 )
 
 const (
@@ -22,6 +22,11 @@ const (
 	defaultMaxOpenConns     = 40
 	defaultConnMaxLifetime  = time.Hour
 	databasePasswordEnvName = "DB_PASSWORD"
+
+	defaultSQLiteBusyTimeout = 5 * time.Second
+
+	// SkipMigrationEnvName overrides the skipMigration of the config
+	SkipMigrationEnvName = storage.SkipMigrationEnvName
 )
 
 type Config struct {
@@ -36,11 +41,82 @@ type Config struct {
 	Password string `env:"DB_PASSWORD"`
 	Database string `env:"DB_DATABASE"`
 
+	// Credentials fetches the user and the password from a secrets backend,
+	// they override the user and the password of the config and the DSN.
+	Credentials *CredentialsConfig `yaml:"credentials"`
+
+	// SkipMigration skips migrating the tables at startup, so the components can use the users
+	// without the privileges to change the tables, the tables are migrated by the init-storage command.
+	SkipMigration bool `yaml:"skipMigration" env:"DB_SKIP_MIGRATION"`
+
+	// RecordFieldChanges records the changed fields between the consecutive versions of the objects,
+	// the paths of the changed fields and the old and new values of the scalar fields are served by the history API.
+	// It should be enabled for both the apiserver and the clustersynchro manager.
+	RecordFieldChanges bool `yaml:"recordFieldChanges"`
+
+	// SoftDelete keeps the objects deleted from the clusters as the tombstones instead of deleting the rows,
+	// the tombstones are hidden from the queries and are listed, restored or purged by the tombstones API.
+	// It should be enabled for both the apiserver and the clustersynchro manager.
+	SoftDelete bool `yaml:"softDelete"`
+
+	// WatchBookmarks persists the resource versions of the watch bookmarks of the resources, so the clustersynchro manager
+	// resumes the watches from the bookmarks instead of relisting the resources after the restarts.
+	// It should be enabled for the clustersynchro manager.
+	WatchBookmarks bool `yaml:"watchBookmarks"`
+
+	// TombstoneRetention is how long the tombstones of the soft delete are kept, 0 means they are kept until they are purged.
+	// The expired tombstones are hidden from the tombstones API, and are purged when the objects of the same resource
+	// are deleted from the cluster.
+	TombstoneRetention time.Duration `yaml:"tombstoneRetention"`
+
+	// Compression compresses the objects of the resources, it should be enabled after all components are upgraded,
+	// because the compressed objects can't be read by the previous versions.
+	Compression *CompressionConfig `yaml:"compression"`
+
+	// Protobuf stores the objects of the built-in resources in protobuf to cut the storage size and the decoding cost of the lists,
+	// the custom resources are still stored in json. Like the compression, only the metadata is kept in the json of the object column,
+	// and it should be enabled after all components are upgraded.
+	Protobuf bool `yaml:"protobuf"`
+
+	// Encryption encrypts the objects of the secrets and the configured resources at rest with the envelope encryption,
+	// only the metadata is kept in the json of the object column, and it should be enabled after all components are upgraded.
+	Encryption *EncryptionConfig `yaml:"encryption"`
+
+	// ClusterIsolation stores the resources of each cluster in its own schema of postgres or its own database of mysql,
+	// so a cluster is cleaned by dropping its schema instead of deleting its rows from the table shared by all clusters.
+	// It should be enabled for both the apiserver and the clustersynchro manager on an empty storage.
+	ClusterIsolation *ClusterIsolationConfig `yaml:"clusterIsolation"`
+
+	// Partitioning partitions the resources table of postgres by the clusters, so a cluster is cleaned by dropping its partition,
+	// and the queries of the clusters only scan their partitions. The existing resources table is converted by the migration.
+	// It should be enabled for both the apiserver and the clustersynchro manager.
+	Partitioning *PartitioningConfig `yaml:"partitioning"`
+
+	// Normalization normalizes the json of the stored objects, so the spec hashes and the recorded field changes of the same objects
+	// are stable across the clusters whose apiservers set the different defaults. The objects stored before it is enabled are
+	// normalized when they are updated. It should be enabled for the clustersynchro manager.
+	Normalization *NormalizationConfig `yaml:"normalization"`
+
+	// ReadReplicas serve the get and list queries of the apiserver, the resource synchros still write to the primary,
+	// and the queries fall back to the primary when the replication lags of all replicas exceed the max lag.
+	ReadReplicas *ReadReplicasConfig `yaml:"readReplicas"`
+
+	// MaterializedViews backs the heavy collection resources with the materialized views of postgres refreshed at the interval,
+	// the collection resources are queried from the views instead of the resources table when the views are fresh enough.
+	// It should be enabled for the apiserver, whose user refreshes the views, so the user must own the views.
+	MaterializedViews *MaterializedViewsConfig `yaml:"materializedViews"`
+
+	// IndexAdvisor suggests or creates the indexes of the json paths of the field selectors and the order by fields
+	// which are frequently used by the list queries.
+	IndexAdvisor *IndexAdvisorConfig `yaml:"indexAdvisor"`
+
 	SSLMode      string `yaml:"sslMode"`
 	CertFile     string `yaml:"sslCertFile"`
 	KeyFile      string `yaml:"sslKeyFile"`
 	RootCertFile string `yaml:"sslRootCertFile"`
 
+	// ConnPool and the level and the slow threshold of the Log are reloaded without restarting the components
+	// when the config file is changed or the process receives SIGHUP.
 	ConnPool ConnPoolConfig `yaml:"connPool"`
 
 	MySQL    *MySQLConfig    `yaml:"mysql"`
@@ -52,6 +128,121 @@ type Config struct {
 	Metrics MetricsConfig `yaml:"metrics"`
 }
 
+// CompressionConfig compresses the stored objects of the resources, the objects are decompressed when they are read.
+//
+// Only the metadata of the compressed objects is kept in the json of the object column,
+// so the compressed resources can't be queried by the fields out of the metadata.
+type CompressionConfig struct {
+	// Algorithm is the compression algorithm, only `gzip` is supported, and it is `gzip` by default.
+	Algorithm string `yaml:"algorithm"`
+
+	// Resources are the compressed resources in the form of `<resource>[.<group>]`, e.g. `pods`, `replicasets.apps`.
+	Resources []string `yaml:"resources"`
+}
+
+// EncryptionConfig encrypts the stored objects of the resources by AES-GCM with the data keys,
+// and the data keys are encrypted by the keys of the key file or the KMS v2 plugin.
+//
+// Only the metadata without the last applied configuration is kept in plaintext in the json of the object column,
+// so the encrypted resources can't be queried by the fields out of the metadata.
+type EncryptionConfig struct {
+	// Resources are the encrypted resources in the form of `<resource>[.<group>]`, and they are `secrets` by default.
+	Resources []string `yaml:"resources"`
+
+	// KeyFile is the yaml file of the base64 encoded 32 bytes keys, the first key encrypts the data keys,
+	// and all keys decrypt them, so the keys are rotated by prepending the new key.
+	KeyFile string `yaml:"keyFile"`
+
+	// KMS encrypts the data keys by the KMS v2 plugin, it is mutually exclusive with the key file.
+	KMS *KMSConfig `yaml:"kms"`
+}
+
+// ClusterIsolationConfig isolates the resources of the clusters by the schemas, the schema of a cluster is created
+// when the cluster is prepared, so the clustersynchro manager needs the privilege to create and drop the schemas.
+//
+// The changes recorded by the recordFieldChanges are still stored in the shared table,
+// and the row level security can't be enabled with the isolation.
+type ClusterIsolationConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// SchemaPrefix is the prefix of the schemas of the clusters, it is `clusterpedia_` by default,
+	// the schemas must be distinct from the schemas of the other instances which share the same database server.
+	SchemaPrefix string `yaml:"schemaPrefix"`
+}
+
+// PartitioningConfig partitions the resources table by the list of the clusters, the partition of a cluster is created
+// when the cluster is prepared, so the clustersynchro manager needs the privilege to create and drop the tables.
+//
+// Unlike the cluster isolation, the resources of all clusters are still read from the resources table,
+// and the indexes of the resources table are shared by the partitions.
+type PartitioningConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// NormalizationConfig normalizes the json of the stored objects by sorting the keys of the objects.
+//
+// The defaulted fields are only stripped from the objects of the built-in resources, so the objects of a resource
+// in different clusters are stored in the same form whether the fields are set by the users or by the defaulting.
+// The stripped fields are not served by the apiserver, and the objects encoded in protobuf keep the defaulted fields,
+// only their spec hashes are computed from the normalized json.
+type NormalizationConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// StripDefaults strips the fields whose values equal the defaults of the built-in resources.
+	StripDefaults bool `yaml:"stripDefaults"`
+}
+
+// MaterializedViewsConfig materializes the resources of the collection resources, the views are created by the migration
+// and are refreshed concurrently, so the queries of the views are not blocked by the refreshes.
+//
+// It is only supported by postgres without the cluster isolation and the row level security, which isn't applied to the views.
+// The views read from the read replicas lag behind the primary by the replication lag in addition to the stale tolerance.
+type MaterializedViewsConfig struct {
+	// Collections are the names of the materialized collection resources, e.g. `workloads`,
+	// the `any` collection resource is not supported, because its resource types are specified by the queries.
+	Collections []string `yaml:"collections"`
+
+	// RefreshInterval is the interval of refreshing the views, it is 30s by default.
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+
+	// StaleTolerance is how long after the last refresh the views still serve the queries, the queries fall back to
+	// the resources table when the views are staler than it. The views are not created if it is 0.
+	StaleTolerance time.Duration `yaml:"staleTolerance"`
+}
+
+// ReadReplicasConfig routes the reads of the apiserver to the read replicas of postgres or mysql,
+// the replicas share the user, the password, the database, the tls and the connection pool settings with the primary.
+type ReadReplicasConfig struct {
+	Replicas []ReplicaConfig `yaml:"replicas"`
+
+	// MaxLag is the max replication lag of the replicas which serve the reads, it is 10s by default.
+	MaxLag time.Duration `yaml:"maxLag"`
+
+	// CheckInterval is the interval of checking the replication lags of the replicas, it is 5s by default.
+	CheckInterval time.Duration `yaml:"checkInterval"`
+}
+
+type ReplicaConfig struct {
+	// DSN overrides the DSN of the primary, the host and the port are ignored if it is set.
+	DSN string `yaml:"dsn"`
+
+	Host string `yaml:"host"`
+
+	// Port is the port of the primary if it is empty
+	Port string `yaml:"port"`
+}
+
+type KMSConfig struct {
+	// Name is the name of the KMS plugin, it is recorded with the encrypted objects.
+	Name string `yaml:"name"`
+
+	// Endpoint is the unix socket of the KMS plugin, e.g. `unix:///var/run/kms-plugin/socket.sock`.
+	Endpoint string `yaml:"endpoint"`
+
+	// Timeout is the timeout of the calls to the KMS plugin, it is 3s by default.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
 type LogConfig struct {
 	Stdout                    bool               `yaml:"stdout"`
 	Level                     string             `yaml:"level"`
@@ -64,6 +255,10 @@ type LogConfig struct {
 type MetricsConfig struct {
 	Disable                bool          `yaml:"disable"`
 	DBStatsRefreshInterval time.Duration `yaml:"dbStatsRefreshInterval" default:"15s"`
+
+	// DisableOperationMetrics disables the metrics of the operations of the resource storages,
+	// which are labeled by the clusters and the resources, while the metrics of the connection pool are kept.
+	DisableOperationMetrics bool `yaml:"disableOperationMetrics"`
 }
 
 type MySQLConfig struct {
@@ -88,10 +283,45 @@ type MySQLConfig struct {
 	RejectReadOnly          *bool `yaml:"rejectReadOnly"`          // Reject read-only connections
 
 	RecoverableErrNumbers []int `yaml:"recoverableErrNumbers"`
+
+	// IndexedLabels are the hot label keys, e.g. `app`, `team`, which are materialized as the virtual generated columns
+	// with the indexes, the equality selectors of the keys are queried by the indexes instead of scanning the json of the objects.
+	// They can't be used with the cluster isolation.
+	IndexedLabels []string `yaml:"indexedLabels"`
 }
 
 type PostgresConfig struct {
 	RecoverableErrCodes []string `yaml:"recoverableErrCodes"`
+
+	RowLevelSecurity *RowLevelSecurityConfig `yaml:"rowLevelSecurity"`
+}
+
+// RowLevelSecurityConfig restricts the resources which can be read by the tenants with the row level security of postgres.
+//
+// The rows of the resources are visible to the postgres role of a tenant only if the cluster is assigned to the tenant,
+// the requests of the apiserver are switched to the role of the tenant of the request user,
+// and the BI tools which access the database directly with the role of a tenant are also restricted.
+// The roles must exist and the user of clusterpedia must be a member of them.
+type RowLevelSecurityConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	Tenants []TenantConfig `yaml:"tenants"`
+
+	// DefaultRole is the role of the requests whose user doesn't belong to any tenant,
+	// the requests are not restricted if it is empty.
+	DefaultRole string `yaml:"defaultRole"`
+}
+
+type TenantConfig struct {
+	// Role is the postgres role of the tenant
+	Role string `yaml:"role"`
+
+	// Users and Groups are the request users which belong to the tenant
+	Users  []string `yaml:"users"`
+	Groups []string `yaml:"groups"`
+
+	// Clusters are the clusters whose resources are visible to the tenant, `*` means all clusters
+	Clusters []string `yaml:"clusters"`
 }
 
 type ConnPoolConfig struct {
@@ -127,7 +357,38 @@ func (cfg *Config) LoggerConfig() (logger.Config, error) {
 	}, nil
 }
 
+// rowLevelSecurity returns the config of the row level security if it is enabled.
+func (cfg *Config) rowLevelSecurity() *RowLevelSecurityConfig {
+	if cfg.Postgres == nil || cfg.Postgres.RowLevelSecurity == nil || !cfg.Postgres.RowLevelSecurity.Enabled {
+		return nil
+	}
+	return cfg.Postgres.RowLevelSecurity
+}
+
+func (cfg *Config) validateRowLevelSecurity() error {
+	rls := cfg.rowLevelSecurity()
+	if rls == nil {
+		return nil
+	}
+	if cfg.Type != "postgres" {
+		return errors.New("row level security is only supported by postgres")
+	}
+	return rls.validate()
+}
+
+func (cfg *Config) indexedLabels() []string {
+	if cfg.Type != "mysql" || cfg.MySQL == nil {
+		return nil
+	}
+	return cfg.MySQL.IndexedLabels
+}
+
 func (cfg *Config) getConnPoolConfig() (ConnPoolConfig, error) {
+	if cfg.isSQLiteInMemory() {
+		// the database is dropped when its only connection is closed
+		return ConnPoolConfig{MaxIdleConns: 1, MaxOpenConns: 1}, nil
+	}
+
 	connPool := ConnPoolConfig{
 		MaxIdleConns:    cfg.ConnPool.MaxIdleConns,
 		MaxOpenConns:    cfg.ConnPool.MaxOpenConns,
@@ -150,166 +411,34 @@ func (cfg *Config) getConnPoolConfig() (ConnPoolConfig, error) {
 	return connPool, nil
 }
 
-func (cfg *Config) genMySQLConfig() (*mysql.Config, error) {
-	if cfg.DSN != "" {
-		mysqlConfig, err := mysql.ParseDSN(cfg.DSN)
-		if err != nil {
-			return nil, err
-		}
-		if mysqlConfig.Passwd == "" {
-			mysqlConfig.Passwd = os.Getenv(databasePasswordEnvName)
-		}
-		mysqlConfig.ParseTime = true
-		return mysqlConfig, nil
-	}
-
-	if cfg.Database == "" {
-		return nil, errors.New("mysql: database name is required")
-	}
-
-	tlsConfig, err := configTLS(cfg.Host, cfg.SSLMode, cfg.RootCertFile, cfg.CertFile, cfg.KeyFile)
-	if err != nil {
-		return nil, err
-	}
-	if err := mysql.RegisterTLSConfig(cfg.SSLMode, tlsConfig); err != nil {
-		return nil, err
-	}
-
-	mysqlconfig := mysql.NewConfig()
-	mysqlconfig.User = cfg.User
-	mysqlconfig.Passwd = cfg.Password
-	mysqlconfig.Addr = net.JoinHostPort(cfg.Host, cfg.Port)
-	mysqlconfig.DBName = cfg.Database
-	mysqlconfig.TLSConfig = cfg.SSLMode
-	mysqlconfig.Params = cfg.Params
-	if cfg.MySQL == nil {
-		// https://github.com/go-gorm/gorm/issues/958
-		// https://github.com/go-sql-driver/mysql/issues/9
-		// mysqlconfig.ParseTime defaults to true
-		mysqlconfig.ParseTime = true
-		return mysqlconfig, nil
-	}
-
-	if cfg.MySQL.ServerPubKey != nil {
-		mysqlconfig.ServerPubKey = *cfg.MySQL.ServerPubKey
-	}
-	if cfg.MySQL.DialTimeout != nil {
-		mysqlconfig.Timeout = *cfg.MySQL.DialTimeout
-	}
-	if cfg.MySQL.ReadTimeout != nil {
-		mysqlconfig.ReadTimeout = *cfg.MySQL.ReadTimeout
-	}
-	if cfg.MySQL.WriteTimeout != nil {
-		mysqlconfig.WriteTimeout = *cfg.MySQL.WriteTimeout
-	}
-	if cfg.MySQL.MaxAllowedPacket != nil {
-		mysqlconfig.MaxAllowedPacket = *cfg.MySQL.MaxAllowedPacket
-	}
-	if cfg.MySQL.MultiStatements != nil {
-		mysqlconfig.MultiStatements = *cfg.MySQL.MultiStatements
-	}
-	if cfg.MySQL.AllowAllFiles != nil {
-		mysqlconfig.AllowAllFiles = *cfg.MySQL.AllowAllFiles
-	}
-	if cfg.MySQL.AllowCleartextPasswords != nil {
-		mysqlconfig.AllowCleartextPasswords = *cfg.MySQL.AllowCleartextPasswords
-	}
-	if cfg.MySQL.AllowNativePasswords != nil {
-		mysqlconfig.AllowNativePasswords = *cfg.MySQL.AllowNativePasswords
-	}
-	if cfg.MySQL.AllowOldPasswords != nil {
-		mysqlconfig.AllowOldPasswords = *cfg.MySQL.AllowOldPasswords
-	}
-	if cfg.MySQL.CheckConnLiveness != nil {
-		mysqlconfig.CheckConnLiveness = *cfg.MySQL.CheckConnLiveness
-	}
-	if cfg.MySQL.ClientFoundRows != nil {
-		mysqlconfig.ClientFoundRows = *cfg.MySQL.ClientFoundRows
-	}
-	if cfg.MySQL.ColumnsWithAlias != nil {
-		mysqlconfig.ColumnsWithAlias = *cfg.MySQL.ColumnsWithAlias
-	}
-	if cfg.MySQL.InterpolateParams != nil {
-		mysqlconfig.InterpolateParams = *cfg.MySQL.InterpolateParams
-	}
-	if cfg.MySQL.ParseTime != nil && !*cfg.MySQL.ParseTime {
-		klog.Warningln("Mysql query param parseTime=false has been ignored, and set to true")
-	}
-	mysqlconfig.ParseTime = true
-	if cfg.MySQL.RejectReadOnly != nil {
-		mysqlconfig.RejectReadOnly = *cfg.MySQL.RejectReadOnly
-	}
-	return mysqlconfig, nil
-}
-
-func (cfg *Config) genPostgresConfig() (*pgx.ConnConfig, error) {
+// genSQLiteDSN returns the DSN of the config, or generates the DSN of the database file,
+// the database file is opened in the WAL mode with a busy timeout,
+// so the reads of the apiserver are not blocked by the writes of the synchros.
+func (cfg *Config) genSQLiteDSN() (string, error) {
 	if cfg.DSN != "" {
-		if !strings.Contains(cfg.DSN, "password") && os.Getenv(databasePasswordEnvName) != "" {
-			cfg.DSN = cfg.DSN + fmt.Sprintf(" password=%s", os.Getenv(databasePasswordEnvName))
-		}
-		return pgx.ParseConfig(cfg.DSN)
+		return cfg.DSN, nil
 	}
-
 	if cfg.Database == "" {
-		return nil, errors.New("postgres: database name is required")
+		return "", errors.New("sqlite: dsn or database is required")
 	}
 
-	var names []string
-	if cfg.Host != "" {
-		names = append(names, fmt.Sprintf("host=%s", cfg.Host))
-	}
-	if cfg.Port != "" {
-		names = append(names, fmt.Sprintf("port=%s", cfg.Port))
-	}
-	if cfg.User != "" {
-		names = append(names, fmt.Sprintf("user=%s", cfg.User))
-	}
-	if cfg.Password != "" {
-		names = append(names, fmt.Sprintf("password=%s", cfg.Password))
-	}
-	if cfg.Database != "" {
-		names = append(names, fmt.Sprintf("dbname=%s", cfg.Database))
-	}
-	if cfg.SSLMode != "" {
-		names = append(names, fmt.Sprintf("sslmode=%s", cfg.SSLMode))
-	}
-	if cfg.CertFile != "" {
-		names = append(names, fmt.Sprintf("sslcert=%s", cfg.CertFile))
-	}
-	if cfg.KeyFile != "" {
-		names = append(names, fmt.Sprintf("sslcert=%s", cfg.KeyFile))
-	}
-	if cfg.RootCertFile != "" {
-		names = append(names, fmt.Sprintf("sslrootcert=%s", cfg.RootCertFile))
-	}
+	params := url.Values{}
+	params.Set("_journal_mode", "WAL")
+	params.Set("_busy_timeout", strconv.Itoa(int(defaultSQLiteBusyTimeout.Milliseconds())))
 	for key, value := range cfg.Params {
-		names = append(names, fmt.Sprintf("%s=%s", key, value))
+		params.Set(key, value)
 	}
-	dns := strings.Join(names, " ")
-	return pgx.ParseConfig(dns)
+	return fmt.Sprintf("file:%s?%s", cfg.Database, params.Encode()), nil
 }
 
-func (cfg *Config) genSQLiteDSN() (string, error) {
-	if cfg.DSN == "" {
-		return "", errors.New("sqlite: dsn is required")
-	}
-	return cfg.DSN, nil
-}
-
-func (cfg *Config) addMysqlErrorNumbers() {
-	if cfg.MySQL != nil {
-		for _, errCode := range cfg.MySQL.RecoverableErrNumbers {
-			recoverableMysqlErrNumbers.Store(errCode, struct{}{})
-		}
-	}
-}
-
-func (cfg *Config) addPostgresErrorCodes() {
-	if cfg.Postgres != nil {
-		for _, errCode := range cfg.Postgres.RecoverableErrCodes {
-			recoverablePostgresErrCodes.Store(errCode, struct{}{})
-		}
+// isSQLiteInMemory returns true if the sqlite database is in memory,
+// every connection opens a new in-memory database unless the cache is shared.
+func (cfg *Config) isSQLiteInMemory() bool {
+	if cfg.Type != "sqlite" && cfg.Type != "sqlite3" {
+		return false
 	}
+	dsn, _ := cfg.genSQLiteDSN()
+	return strings.Contains(dsn, ":memory:") || strings.Contains(dsn, "mode=memory")
 }
 
 func configTLS(host, sslmode, sslrootcert, sslcert, sslkey string) (*tls.Config, error) {
@@ -319,13 +448,13 @@ func configTLS(host, sslmode, sslrootcert, sslcert, sslkey string) (*tls.Config,
 		return nil, nil
 
 	case "skip-verify", "allow", "prefer":
+		// the client certificates are still loaded
 		tlsConfig.InsecureSkipVerify = true
-		return tlsConfig, nil
 
 	case "require":
 		if sslrootcert != "" {
 			tlsConfig.InsecureSkipVerify = true
-			return tlsConfig, nil
+			break
 		}
 
 		fallthrough
@@ -0,0 +1,371 @@
+package internalstorage
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	defaultCredentialsRefreshInterval = 5 * time.Minute
+
+	defaultVaultKubernetesAuthPath = "kubernetes"
+	serviceAccountTokenFile        = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// CredentialsConfig fetches the user and the password of the database from a secrets backend
+// instead of the plain text in the config file.
+//
+// The credentials are fetched before a new connection of the pool is established,
+// so the rotated credentials are used by the new connections without restarting the components,
+// use connPool.connMaxLifetime to recycle the connections with the old credentials.
+type CredentialsConfig struct {
+	// Vault reads the credentials from HashiCorp Vault,
+	// both the kv secrets engine and the database secrets engine are supported.
+	Vault *VaultCredentialsConfig `yaml:"vault"`
+
+	// File reads the credentials from the files, e.g. the files mounted by the Secrets Store CSI Driver
+	// from AWS Secrets Manager, Azure Key Vault or GCP Secret Manager.
+	File *FileCredentialsConfig `yaml:"file"`
+
+	// RefreshInterval is the max duration the fetched credentials are cached, default is 5m.
+	// The credentials of vault are refreshed earlier if their lease expires.
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+}
+
+type VaultCredentialsConfig struct {
+	// Address is the address of vault, default is the VAULT_ADDR env
+	Address string `yaml:"address"`
+
+	// Path is the api path of the secret, e.g. `secret/data/clusterpedia` or `database/creds/clusterpedia`
+	Path string `yaml:"path"`
+
+	// Namespace is the vault enterprise namespace
+	Namespace string `yaml:"namespace"`
+
+	// CACertFile is used to verify the certificate of vault
+	CACertFile string `yaml:"caCertFile"`
+
+	// TokenFile is the file of the vault token, the VAULT_TOKEN env is used if it is empty
+	// and the kubernetes auth is not configured.
+	TokenFile string `yaml:"tokenFile"`
+
+	// KubernetesRole logs in vault with the service account token of the pod by the kubernetes auth method
+	KubernetesRole string `yaml:"kubernetesRole"`
+
+	// KubernetesAuthPath is the mount path of the kubernetes auth method, default is `kubernetes`
+	KubernetesAuthPath string `yaml:"kubernetesAuthPath"`
+
+	// UsernameKey and PasswordKey are the keys of the secret data, default are `username` and `password`
+	UsernameKey string `yaml:"usernameKey"`
+	PasswordKey string `yaml:"passwordKey"`
+}
+
+type FileCredentialsConfig struct {
+	// UsernameFile is optional, the user of the config is used if it is empty
+	UsernameFile string `yaml:"usernameFile"`
+	PasswordFile string `yaml:"passwordFile"`
+}
+
+func (cfg *CredentialsConfig) validate() error {
+	switch {
+	case cfg.Vault != nil && cfg.File != nil:
+		return errors.New("credentials: only one of vault and file can be configured")
+	case cfg.Vault != nil:
+		if cfg.Vault.Path == "" {
+			return errors.New("credentials: vault path is required")
+		}
+	case cfg.File != nil:
+		if cfg.File.PasswordFile == "" {
+			return errors.New("credentials: passwordFile is required")
+		}
+	default:
+		return errors.New("credentials: one of vault and file is required")
+	}
+	if cfg.RefreshInterval < 0 {
+		return errors.New("credentials: refreshInterval must be non-negative")
+	}
+	return nil
+}
+
+type credentials struct {
+	username string
+	password string
+
+	// leaseDuration is the lease of the dynamic credentials, zero means no lease
+	leaseDuration time.Duration
+}
+
+type credentialsFetcher interface {
+	fetch(ctx context.Context) (credentials, error)
+}
+
+// credentialsProvider caches the credentials fetched from the secrets backend,
+// the credentials are fetched again after the refresh interval or the half of their lease.
+type credentialsProvider struct {
+	fetcher         credentialsFetcher
+	refreshInterval time.Duration
+
+	// user is used if the secrets backend only provides the password
+	user string
+
+	lock      sync.Mutex
+	cached    credentials
+	expiresAt time.Time
+	now       func() time.Time
+}
+
+func newCredentialsProvider(cfg *Config) (*credentialsProvider, error) {
+	if cfg.Credentials == nil {
+		return nil, nil
+	}
+	if err := cfg.Credentials.validate(); err != nil {
+		return nil, err
+	}
+
+	var fetcher credentialsFetcher
+	if cfg.Credentials.Vault != nil {
+		vault, err := newVaultFetcher(cfg.Credentials.Vault)
+		if err != nil {
+			return nil, err
+		}
+		fetcher = vault
+	} else {
+		fetcher = &fileFetcher{config: cfg.Credentials.File}
+	}
+
+	refreshInterval := cfg.Credentials.RefreshInterval
+	if refreshInterval == 0 {
+		refreshInterval = defaultCredentialsRefreshInterval
+	}
+	return &credentialsProvider{
+		fetcher:         fetcher,
+		refreshInterval: refreshInterval,
+		user:            cfg.User,
+		now:             time.Now,
+	}, nil
+}
+
+// Get returns the user and the password, they are fetched from the secrets backend if the cache expires.
+func (p *credentialsProvider) Get(ctx context.Context) (string, string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.now()
+	if p.cached.password != "" && now.Before(p.expiresAt) {
+		return p.cached.username, p.cached.password, nil
+	}
+
+	creds, err := p.fetcher.fetch(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch the database credentials: %w", err)
+	}
+	if creds.username == "" {
+		creds.username = p.user
+	}
+	if creds.username != p.cached.username || creds.password != p.cached.password {
+		klog.InfoS("database credentials are refreshed", "user", creds.username)
+	}
+
+	ttl := p.refreshInterval
+	if creds.leaseDuration > 0 && creds.leaseDuration/2 < ttl {
+		ttl = creds.leaseDuration / 2
+	}
+	p.cached, p.expiresAt = creds, now.Add(ttl)
+	return creds.username, creds.password, nil
+}
+
+type fileFetcher struct {
+	config *FileCredentialsConfig
+}
+
+func (f *fileFetcher) fetch(_ context.Context) (credentials, error) {
+	var creds credentials
+	if f.config.UsernameFile != "" {
+		username, err := readSecretFile(f.config.UsernameFile)
+		if err != nil {
+			return credentials{}, err
+		}
+		creds.username = username
+	}
+
+	password, err := readSecretFile(f.config.PasswordFile)
+	if err != nil {
+		return credentials{}, err
+	}
+	creds.password = password
+	return creds, nil
+}
+
+func readSecretFile(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return "", fmt.Errorf("secret file %s is empty", file)
+	}
+	return value, nil
+}
+
+type vaultFetcher struct {
+	config *VaultCredentialsConfig
+	client *http.Client
+
+	address string
+}
+
+func newVaultFetcher(config *VaultCredentialsConfig) (*vaultFetcher, error) {
+	address := config.Address
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		return nil, errors.New("credentials: vault address is required")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.CACertFile != "" {
+		pem, err := os.ReadFile(config.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("credentials: failed to append the ca cert of vault: %s", config.CACertFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &vaultFetcher{
+		config:  config,
+		client:  &http.Client{Transport: transport, Timeout: 30 * time.Second},
+		address: strings.TrimSuffix(address, "/"),
+	}, nil
+}
+
+type vaultResponse struct {
+	LeaseDuration int                    `json:"lease_duration"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+func (f *vaultFetcher) fetch(ctx context.Context) (credentials, error) {
+	token, err := f.token(ctx)
+	if err != nil {
+		return credentials{}, err
+	}
+
+	resp, err := f.do(ctx, http.MethodGet, strings.TrimPrefix(f.config.Path, "/"), token, nil)
+	if err != nil {
+		return credentials{}, err
+	}
+
+	data := resp.Data
+	// the secret of the kv secrets engine v2 is nested in `data.data`
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		data = nested
+	}
+
+	usernameKey, passwordKey := f.config.UsernameKey, f.config.PasswordKey
+	if usernameKey == "" {
+		usernameKey = "username"
+	}
+	if passwordKey == "" {
+		passwordKey = "password"
+	}
+
+	password, _ := data[passwordKey].(string)
+	if password == "" {
+		return credentials{}, fmt.Errorf("vault secret %s has no %q", f.config.Path, passwordKey)
+	}
+	username, _ := data[usernameKey].(string)
+	return credentials{
+		username:      username,
+		password:      password,
+		leaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+	}, nil
+}
+
+func (f *vaultFetcher) token(ctx context.Context) (string, error) {
+	if f.config.KubernetesRole != "" {
+		jwt, err := readSecretFile(serviceAccountTokenFile)
+		if err != nil {
+			return "", err
+		}
+
+		authPath := f.config.KubernetesAuthPath
+		if authPath == "" {
+			authPath = defaultVaultKubernetesAuthPath
+		}
+		body, err := json.Marshal(map[string]string{"role": f.config.KubernetesRole, "jwt": jwt})
+		if err != nil {
+			return "", err
+		}
+		resp, err := f.do(ctx, http.MethodPost, "auth/"+strings.Trim(authPath, "/")+"/login", "", body)
+		if err != nil {
+			return "", err
+		}
+		if resp.Auth == nil || resp.Auth.ClientToken == "" {
+			return "", errors.New("vault kubernetes login returns no client token")
+		}
+		return resp.Auth.ClientToken, nil
+	}
+
+	if f.config.TokenFile != "" {
+		return readSecretFile(f.config.TokenFile)
+	}
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+	return "", errors.New("vault token is required, set tokenFile, kubernetesRole or the VAULT_TOKEN env")
+}
+
+func (f *vaultFetcher) do(ctx context.Context, method, path, token string, body []byte) (*vaultResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, method, f.address+"/v1/"+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if f.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", f.config.Namespace)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var vaultResp vaultResponse
+	if len(data) != 0 {
+		if err := json.Unmarshal(data, &vaultResp); err != nil {
+			return nil, fmt.Errorf("vault %s %s: failed to decode the response: %w", method, path, err)
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault %s %s: %s %s", method, path, resp.Status, strings.Join(vaultResp.Errors, ", "))
+	}
+	return &vaultResp, nil
+}
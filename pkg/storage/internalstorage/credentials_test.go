@@ -0,0 +1,173 @@
+package internalstorage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileCredentials(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password")
+	if err := os.WriteFile(passwordFile, []byte("password-1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := newCredentialsProvider(&Config{
+		User: "clusterpedia",
+		Credentials: &CredentialsConfig{
+			File:            &FileCredentialsConfig{PasswordFile: passwordFile},
+			RefreshInterval: time.Minute,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+
+	user, password, err := provider.Get(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != "clusterpedia" || password != "password-1" {
+		t.Errorf("Get() = %q, %q, want %q, %q", user, password, "clusterpedia", "password-1")
+	}
+
+	// the password is rotated
+	if err := os.WriteFile(passwordFile, []byte("password-2"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, password, _ := provider.Get(context.TODO()); password != "password-1" {
+		t.Errorf("Get() before refresh = %q, want the cached %q", password, "password-1")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, password, _ := provider.Get(context.TODO()); password != "password-2" {
+		t.Errorf("Get() after refresh = %q, want %q", password, "password-2")
+	}
+}
+
+func TestVaultCredentials(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		response map[string]interface{}
+		lease    time.Duration
+	}{
+		{
+			name: "kv v2",
+			path: "/v1/secret/data/clusterpedia",
+			response: map[string]interface{}{
+				"data": map[string]interface{}{
+					"data": map[string]interface{}{"username": "user-1", "password": "password-1"},
+				},
+			},
+		},
+		{
+			name: "database",
+			path: "/v1/database/creds/clusterpedia",
+			response: map[string]interface{}{
+				"lease_duration": 60,
+				"data":           map[string]interface{}{"username": "user-1", "password": "password-1"},
+			},
+			lease: time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.Header.Get("X-Vault-Token") != "token" {
+					w.WriteHeader(http.StatusForbidden)
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{"permission denied"}})
+					return
+				}
+				if req.URL.Path != test.path {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(test.response)
+			}))
+			defer server.Close()
+
+			tokenFile := filepath.Join(t.TempDir(), "token")
+			if err := os.WriteFile(tokenFile, []byte("token"), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			fetcher, err := newVaultFetcher(&VaultCredentialsConfig{
+				Address:   server.URL,
+				Path:      test.path[len("/v1/"):],
+				TokenFile: tokenFile,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			creds, err := fetcher.fetch(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if creds.username != "user-1" || creds.password != "password-1" || creds.leaseDuration != test.lease {
+				t.Errorf("fetch() = %+v", creds)
+			}
+
+			if err := os.WriteFile(tokenFile, []byte("invalid"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := fetcher.fetch(context.TODO()); err == nil {
+				t.Error("fetch() with the invalid token should return an error")
+			}
+		})
+	}
+}
+
+func TestCredentialsLease(t *testing.T) {
+	fetcher := &fakeFetcher{creds: credentials{username: "user", password: "password-1", leaseDuration: time.Minute}}
+	provider := &credentialsProvider{fetcher: fetcher, refreshInterval: time.Hour}
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+
+	if _, _, err := provider.Get(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	fetcher.creds.password = "password-2"
+	now = now.Add(40 * time.Second)
+	if _, password, _ := provider.Get(context.TODO()); password != "password-2" {
+		t.Errorf("Get() after the half of the lease = %q, want %q", password, "password-2")
+	}
+}
+
+type fakeFetcher struct {
+	creds credentials
+}
+
+func (f *fakeFetcher) fetch(_ context.Context) (credentials, error) {
+	return f.creds, nil
+}
+
+func TestCredentialsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config CredentialsConfig
+		valid  bool
+	}{
+		{"empty", CredentialsConfig{}, false},
+		{"both", CredentialsConfig{Vault: &VaultCredentialsConfig{Path: "a"}, File: &FileCredentialsConfig{PasswordFile: "b"}}, false},
+		{"vault without path", CredentialsConfig{Vault: &VaultCredentialsConfig{}}, false},
+		{"file without password", CredentialsConfig{File: &FileCredentialsConfig{UsernameFile: "a"}}, false},
+		{"vault", CredentialsConfig{Vault: &VaultCredentialsConfig{Path: "a"}}, true},
+		{"file", CredentialsConfig{File: &FileCredentialsConfig{PasswordFile: "b"}}, true},
+	}
+	for _, test := range tests {
+		if err := test.config.validate(); (err == nil) != test.valid {
+			t.Errorf("%s: validate() error = %v, valid %v", test.name, err, test.valid)
+		}
+	}
+}
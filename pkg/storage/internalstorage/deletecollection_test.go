@@ -0,0 +1,80 @@
+package internalstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestResourceStorage_DeleteCollectionWithSQLite(t *testing.T) {
+	for _, softDelete := range []bool{false, true} {
+		t.Run(map[bool]string{false: "delete", true: "soft delete"}[softDelete], func(t *testing.T) {
+			require := require.New(t)
+
+			db, cleanup, err := newSQLiteDB()
+			require.NoError(err)
+			defer cleanup()
+			require.NoError(db.AutoMigrate(&ResourceChange{}))
+
+			factory := &StorageFactory{db: db, softDelete: softDelete, recordChanges: !softDelete}
+			config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Resource: "pods"}, true)
+			require.NoError(err)
+			s, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+			require.NoError(err)
+			rs := s.(*ResourceStorage)
+
+			ctx := context.Background()
+			objects := []struct {
+				cluster string
+				pod     *corev1.Pod
+			}{
+				{"cluster-1", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: "job-1", Labels: map[string]string{"app": "job"}}}},
+				{"cluster-1", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: "job-2", Labels: map[string]string{"app": "job"}}}},
+				{"cluster-1", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: "web", Labels: map[string]string{"app": "web"}}}},
+				{"cluster-1", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job-3", Labels: map[string]string{"app": "job"}}}},
+				{"cluster-2", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: "job-1", Labels: map[string]string{"app": "job"}}}},
+			}
+			for _, object := range objects {
+				object.pod.Kind, object.pod.ResourceVersion = "Pod", "1"
+				require.NoError(rs.Create(ctx, object.cluster, object.pod))
+			}
+
+			selector, err := labels.Parse("app=job")
+			require.NoError(err)
+			deleted, err := rs.DeleteCollection(ctx, storage.DeleteCollectionOptions{
+				Clusters: []string{"cluster-1"}, Namespaces: []string{"ci"}, LabelSelector: selector,
+			})
+			require.NoError(err)
+			require.EqualValues(2, deleted)
+
+			list := &corev1.PodList{}
+			opts := &internal.ListOptions{OrderBy: []internal.OrderBy{{Field: "cluster"}, {Field: "name"}}}
+			require.NoError(rs.List(ctx, list, opts))
+			var names []string
+			for _, pod := range list.Items {
+				names = append(names, pod.Namespace+"/"+pod.Name)
+			}
+			require.Equal([]string{"default/job-3", "ci/web", "ci/job-1"}, names)
+
+			if softDelete {
+				tombstones, err := rs.ListTombstones(ctx, "cluster-1", storage.TombstoneOptions{Namespace: "ci"})
+				require.NoError(err)
+				require.Len(tombstones, 2)
+			}
+
+			// the tombstones are not deleted again
+			deleted, err = rs.DeleteCollection(ctx, storage.DeleteCollectionOptions{Namespaces: []string{"ci"}})
+			require.NoError(err)
+			require.EqualValues(2, deleted)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+//go:build !no_mysql
+
+package internalstorage
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-sql-driver/mysql"
+	gmysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var recoverableMysqlErrNumbers sync.Map
+
+func init() {
+	recoverableMysqlErrNumbers.Store(uint16(1053), struct{}{}) // ER_SERVER_SHUTDOWN: Server shutdown in progress
+	recoverableMysqlErrNumbers.Store(uint16(1205), struct{}{}) // Error 1205: Lock wait timeout exceeded; try restarting transaction.
+	recoverableMysqlErrNumbers.Store(uint16(1290), struct{}{}) // Error 1290: The MySQL server is running with the --read-only option so it cannot execute this statement.
+
+	registerDatabaseDriver(databaseDriver{open: openMySQLDialector, interpretError: InterpretMysqlError, jsonValue: mysqlJSONValue}, "mysql")
+}
+
+// mysqlJSONValue casts the json value to JSON, except for MariaDB whose JSON is an alias of LONGTEXT
+func mysqlJSONValue(dialector gorm.Dialector, data string) clause.Expr {
+	if d, ok := dialector.(*gmysql.Dialector); ok && !strings.Contains(d.ServerVersion, "MariaDB") {
+		return gorm.Expr("CAST(? AS JSON)", data)
+	}
+	return gorm.Expr("?", data)
+}
+
+func openMySQLDialector(cfg *Config, credentials *credentialsProvider) (_ gorm.Dialector, closers []io.Closer, _ error) {
+	mysqlConfig, err := cfg.genMySQLConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsReloader, err := newMySQLTLSReloader(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tlsReloader != nil {
+		closers = append(closers, tlsReloader)
+	}
+
+	if credentials != nil || tlsReloader != nil {
+		err := mysqlConfig.Apply(mysql.BeforeConnect(func(ctx context.Context, config *mysql.Config) error {
+			if tlsReloader != nil {
+				config.TLS = tlsReloader.Get()
+			}
+			if credentials != nil {
+				user, password, err := credentials.Get(ctx)
+				if err != nil {
+					return err
+				}
+				config.User, config.Passwd = user, password
+			}
+			return nil
+		}))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	connector, err := mysql.NewConnector(mysqlConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg.addMysqlErrorNumbers()
+	return gmysql.New(gmysql.Config{Conn: sql.OpenDB(connector)}), closers, nil
+}
+
+func (cfg *Config) genMySQLConfig() (*mysql.Config, error) {
+	if cfg.DSN != "" {
+		mysqlConfig, err := mysql.ParseDSN(cfg.DSN)
+		if err != nil {
+			return nil, err
+		}
+		if mysqlConfig.Passwd == "" {
+			mysqlConfig.Passwd = os.Getenv(databasePasswordEnvName)
+		}
+		mysqlConfig.ParseTime = true
+		return mysqlConfig, nil
+	}
+
+	if cfg.Database == "" {
+		return nil, errors.New("mysql: database name is required")
+	}
+
+	tlsConfig, err := configTLS(cfg.Host, cfg.SSLMode, cfg.RootCertFile, cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := mysql.RegisterTLSConfig(cfg.SSLMode, tlsConfig); err != nil {
+		return nil, err
+	}
+
+	mysqlconfig := mysql.NewConfig()
+	mysqlconfig.User = cfg.User
+	mysqlconfig.Passwd = cfg.Password
+	mysqlconfig.Addr = net.JoinHostPort(cfg.Host, cfg.Port)
+	mysqlconfig.DBName = cfg.Database
+	mysqlconfig.TLSConfig = cfg.SSLMode
+	mysqlconfig.Params = cfg.Params
+	if cfg.MySQL == nil {
+		// https://github.com/go-gorm/gorm/issues/958
+		// https://github.com/go-sql-driver/mysql/issues/9
+		// mysqlconfig.ParseTime defaults to true
+		mysqlconfig.ParseTime = true
+		return mysqlconfig, nil
+	}
+
+	if cfg.MySQL.ServerPubKey != nil {
+		mysqlconfig.ServerPubKey = *cfg.MySQL.ServerPubKey
+	}
+	if cfg.MySQL.DialTimeout != nil {
+		mysqlconfig.Timeout = *cfg.MySQL.DialTimeout
+	}
+	if cfg.MySQL.ReadTimeout != nil {
+		mysqlconfig.ReadTimeout = *cfg.MySQL.ReadTimeout
+	}
+	if cfg.MySQL.WriteTimeout != nil {
+		mysqlconfig.WriteTimeout = *cfg.MySQL.WriteTimeout
+	}
+	if cfg.MySQL.MaxAllowedPacket != nil {
+		mysqlconfig.MaxAllowedPacket = *cfg.MySQL.MaxAllowedPacket
+	}
+	if cfg.MySQL.MultiStatements != nil {
+		mysqlconfig.MultiStatements = *cfg.MySQL.MultiStatements
+	}
+	if cfg.MySQL.AllowAllFiles != nil {
+		mysqlconfig.AllowAllFiles = *cfg.MySQL.AllowAllFiles
+	}
+	if cfg.MySQL.AllowCleartextPasswords != nil {
+		mysqlconfig.AllowCleartextPasswords = *cfg.MySQL.AllowCleartextPasswords
+	}
+	if cfg.MySQL.AllowNativePasswords != nil {
+		mysqlconfig.AllowNativePasswords = *cfg.MySQL.AllowNativePasswords
+	}
+	if cfg.MySQL.AllowOldPasswords != nil {
+		mysqlconfig.AllowOldPasswords = *cfg.MySQL.AllowOldPasswords
+	}
+	if cfg.MySQL.CheckConnLiveness != nil {
+		mysqlconfig.CheckConnLiveness = *cfg.MySQL.CheckConnLiveness
+	}
+	if cfg.MySQL.ClientFoundRows != nil {
+		mysqlconfig.ClientFoundRows = *cfg.MySQL.ClientFoundRows
+	}
+	if cfg.MySQL.ColumnsWithAlias != nil {
+		mysqlconfig.ColumnsWithAlias = *cfg.MySQL.ColumnsWithAlias
+	}
+	if cfg.MySQL.InterpolateParams != nil {
+		mysqlconfig.InterpolateParams = *cfg.MySQL.InterpolateParams
+	}
+	if cfg.MySQL.ParseTime != nil && !*cfg.MySQL.ParseTime {
+		klog.Warningln("Mysql query param parseTime=false has been ignored, and set to true")
+	}
+	mysqlconfig.ParseTime = true
+	if cfg.MySQL.RejectReadOnly != nil {
+		mysqlconfig.RejectReadOnly = *cfg.MySQL.RejectReadOnly
+	}
+	return mysqlconfig, nil
+}
+
+func (cfg *Config) addMysqlErrorNumbers() {
+	if cfg.MySQL != nil {
+		for _, errCode := range cfg.MySQL.RecoverableErrNumbers {
+			recoverableMysqlErrNumbers.Store(uint16(errCode), struct{}{})
+		}
+	}
+}
+
+// newMySQLTLSReloader returns nil if the certificate files are not configured.
+func newMySQLTLSReloader(cfg *Config) (*tlsReloader[*tls.Config], error) {
+	files := cfg.certificateFiles()
+	if cfg.DSN != "" || len(files) == 0 {
+		return nil, nil
+	}
+	return newTLSReloader(files, func() (*tls.Config, error) {
+		return configTLS(cfg.Host, cfg.SSLMode, cfg.RootCertFile, cfg.CertFile, cfg.KeyFile)
+	})
+}
+
+func InterpretMysqlError(key string, err error) error {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return err
+	}
+
+	_, ok := recoverableMysqlErrNumbers.Load(mysqlErr.Number)
+	if ok {
+		return storage.NewUnavailableError(key, err)
+	}
+
+	switch mysqlErr.Number {
+	case 1062:
+		return storage.NewConflictError(key, err)
+	case 1040:
+		// klog.Error("too many connections")
+	case 1153, 1406: // ER_NET_PACKET_TOO_LARGE, ER_DATA_TOO_LONG
+		return storage.NewTooLargeError(key, err)
+	case 1054, 1064, 1305: // ER_BAD_FIELD_ERROR, ER_PARSE_ERROR, ER_SP_DOES_NOT_EXIST
+		return storage.NewInvalidQueryError(key, err)
+	}
+	return err
+}
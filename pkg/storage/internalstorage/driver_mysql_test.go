@@ -0,0 +1,140 @@
+//go:build !no_mysql
+
+package internalstorage
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func commonNameOf(t *testing.T, config *tls.Config) string {
+	if config == nil || len(config.Certificates) == 0 {
+		t.Fatal("tls config has no client certificate")
+	}
+	cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert.Subject.CommonName
+}
+
+func TestMySQLTLSReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeCertificate(t, certFile, keyFile, "cert-1")
+
+	reloader, err := newMySQLTLSReloader(&Config{Host: "127.0.0.1", SSLMode: "skip-verify", CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reloader.Close()
+
+	if name := commonNameOf(t, reloader.Get()); name != "cert-1" {
+		t.Fatalf("Get() certificate = %q, want %q", name, "cert-1")
+	}
+
+	writeCertificate(t, certFile, keyFile, "cert-2")
+	deadline := time.Now().Add(5 * time.Second)
+	for commonNameOf(t, reloader.Get()) != "cert-2" {
+		if time.Now().After(deadline) {
+			t.Fatal("the certificate is not reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTLSReloaderKeepsPrevious(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeCertificate(t, certFile, keyFile, "cert-1")
+
+	reloader, err := newMySQLTLSReloader(&Config{Host: "127.0.0.1", SSLMode: "skip-verify", CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reloader.Close()
+
+	reloader.lock.Lock()
+	reloader.stale = true
+	reloader.lock.Unlock()
+	if err := os.WriteFile(keyFile, []byte("invalid"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if name := commonNameOf(t, reloader.Get()); name != "cert-1" {
+		t.Errorf("Get() with the invalid files = %q, want the previous %q", name, "cert-1")
+	}
+}
+
+func TestGetMySQLDriver(t *testing.T) {
+	if _, err := getDatabaseDriver("mysql"); err != nil {
+		t.Errorf("driver mysql should be compiled in by default: %v", err)
+	}
+}
+
+func TestMySQLJSONValue(t *testing.T) {
+	sql := mysqlDBs["8.0.27"].ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&Resource{}).Where("id = 1").Update("object", JSON(`{"metadata":{"name":"foo"}}`))
+	})
+	if expected := `CAST('{"metadata":{"name":"foo"}}' AS JSON)`; !strings.Contains(sql, expected) {
+		t.Errorf("the json value is %s, want %s", sql, expected)
+	}
+}
+
+func TestInterpretMysqlError(t *testing.T) {
+	testInterpretDBError(t, []interpretDBErrorTest{
+		{"mysql duplicate entry", &mysql.MySQLError{Number: 1062}, storage.ErrCodeConflict, http.StatusConflict},
+		{"mysql data too long", &mysql.MySQLError{Number: 1406}, storage.ErrCodeTooLarge, http.StatusRequestEntityTooLarge},
+		{"mysql read only", &mysql.MySQLError{Number: 1290}, storage.ErrCodeUnavailable, http.StatusServiceUnavailable},
+		{"mysql parse error", &mysql.MySQLError{Number: 1064}, storage.ErrCodeInvalidQuery, http.StatusBadRequest},
+	})
+
+	if code := storage.ErrorCodeOf(InterpretDBError("", &mysql.MySQLError{Number: 1045})); code != "" {
+		t.Errorf("ErrorCodeOf() = %q for the unknown error, want empty", code)
+	}
+}
@@ -0,0 +1,170 @@
+//go:build !no_postgres
+
+package internalstorage
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/stdlib"
+	gpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var recoverablePostgresErrCodes sync.Map
+
+func init() {
+	recoverablePostgresErrCodes.Store(pgerrcode.AdminShutdown, struct{}{})
+
+	registerDatabaseDriver(databaseDriver{open: openPostgresDialector, interpretError: InterpretPostgresError}, "postgres")
+}
+
+func openPostgresDialector(cfg *Config, credentials *credentialsProvider) (_ gorm.Dialector, closers []io.Closer, _ error) {
+	pgconfig, err := cfg.genPostgresConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsReloader, err := newPostgresTLSReloader(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tlsReloader != nil {
+		closers = append(closers, tlsReloader)
+	}
+
+	var options []stdlib.OptionOpenDB
+	if credentials != nil || tlsReloader != nil {
+		options = append(options, stdlib.OptionBeforeConnect(func(ctx context.Context, config *pgx.ConnConfig) error {
+			if tlsReloader != nil {
+				// the config is a shallow copy, so the fallbacks are replaced rather than modified
+				tlsConfig := tlsReloader.Get()
+				config.TLSConfig, config.Fallbacks = tlsConfig.TLSConfig, tlsConfig.Fallbacks
+			}
+			if credentials != nil {
+				user, password, err := credentials.Get(ctx)
+				if err != nil {
+					return err
+				}
+				config.User, config.Password = user, password
+			}
+			return nil
+		}))
+	}
+
+	cfg.addPostgresErrorCodes()
+	return gpostgres.New(gpostgres.Config{Conn: stdlib.OpenDB(*pgconfig, options...)}), closers, nil
+}
+
+func (cfg *Config) genPostgresConfig() (*pgx.ConnConfig, error) {
+	if cfg.DSN != "" {
+		if !strings.Contains(cfg.DSN, "password") && os.Getenv(databasePasswordEnvName) != "" {
+			cfg.DSN = cfg.DSN + fmt.Sprintf(" password=%s", os.Getenv(databasePasswordEnvName))
+		}
+		return pgx.ParseConfig(cfg.DSN)
+	}
+
+	if cfg.Database == "" {
+		return nil, errors.New("postgres: database name is required")
+	}
+
+	var names []string
+	if cfg.Host != "" {
+		names = append(names, fmt.Sprintf("host=%s", cfg.Host))
+	}
+	if cfg.Port != "" {
+		names = append(names, fmt.Sprintf("port=%s", cfg.Port))
+	}
+	if cfg.User != "" {
+		names = append(names, fmt.Sprintf("user=%s", cfg.User))
+	}
+	if cfg.Password != "" {
+		names = append(names, fmt.Sprintf("password=%s", cfg.Password))
+	}
+	if cfg.Database != "" {
+		names = append(names, fmt.Sprintf("dbname=%s", cfg.Database))
+	}
+	if cfg.SSLMode != "" {
+		names = append(names, fmt.Sprintf("sslmode=%s", cfg.SSLMode))
+	}
+	if cfg.CertFile != "" {
+		names = append(names, fmt.Sprintf("sslcert=%s", cfg.CertFile))
+	}
+	if cfg.KeyFile != "" {
+		names = append(names, fmt.Sprintf("sslkey=%s", cfg.KeyFile))
+	}
+	if cfg.RootCertFile != "" {
+		names = append(names, fmt.Sprintf("sslrootcert=%s", cfg.RootCertFile))
+	}
+	for key, value := range cfg.Params {
+		names = append(names, fmt.Sprintf("%s=%s", key, value))
+	}
+	dns := strings.Join(names, " ")
+	return pgx.ParseConfig(dns)
+}
+
+func (cfg *Config) addPostgresErrorCodes() {
+	if cfg.Postgres != nil {
+		for _, errCode := range cfg.Postgres.RecoverableErrCodes {
+			recoverablePostgresErrCodes.Store(errCode, struct{}{})
+		}
+	}
+}
+
+// postgresTLSConfig is the tls configs of the host and the fallbacks parsed by pgx
+type postgresTLSConfig struct {
+	TLSConfig *tls.Config
+	Fallbacks []*pgconn.FallbackConfig
+}
+
+// newPostgresTLSReloader returns nil if the certificate files are not configured.
+func newPostgresTLSReloader(cfg *Config) (*tlsReloader[postgresTLSConfig], error) {
+	files := cfg.certificateFiles()
+	if cfg.DSN != "" || len(files) == 0 {
+		return nil, nil
+	}
+	return newTLSReloader(files, func() (postgresTLSConfig, error) {
+		config, err := cfg.genPostgresConfig()
+		if err != nil {
+			return postgresTLSConfig{}, err
+		}
+		return postgresTLSConfig{TLSConfig: config.TLSConfig, Fallbacks: config.Fallbacks}, nil
+	})
+}
+
+func InterpretPostgresError(key string, err error) error {
+	if pgconn.Timeout(err) {
+		return storage.NewUnavailableError(key, err)
+	}
+
+	var pgError *pgconn.PgError
+	if !errors.As(err, &pgError) {
+		return err
+	}
+
+	_, ok := recoverablePostgresErrCodes.Load(pgError.Code)
+	if ok {
+		return storage.NewUnavailableError(key, err)
+	}
+
+	switch pgError.Code {
+	case pgerrcode.UniqueViolation:
+		return storage.NewConflictError(key, err)
+	case pgerrcode.ProgramLimitExceeded, pgerrcode.StringDataRightTruncationDataException:
+		return storage.NewTooLargeError(key, err)
+	case pgerrcode.SyntaxError, pgerrcode.UndefinedColumn, pgerrcode.UndefinedFunction, pgerrcode.InvalidTextRepresentation:
+		return storage.NewInvalidQueryError(key, err)
+	}
+	return err
+}
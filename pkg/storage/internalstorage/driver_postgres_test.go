@@ -0,0 +1,38 @@
+//go:build !no_postgres
+
+package internalstorage
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestGetPostgresDriver(t *testing.T) {
+	if _, err := getDatabaseDriver("postgres"); err != nil {
+		t.Errorf("driver postgres should be compiled in by default: %v", err)
+	}
+}
+
+func TestInterpretPostgresError(t *testing.T) {
+	testInterpretDBError(t, []interpretDBErrorTest{
+		{"postgres unique violation", &pgconn.PgError{Code: pgerrcode.UniqueViolation}, storage.ErrCodeConflict, http.StatusConflict},
+		{"postgres program limit exceeded", &pgconn.PgError{Code: pgerrcode.ProgramLimitExceeded}, storage.ErrCodeTooLarge, http.StatusRequestEntityTooLarge},
+		{"postgres admin shutdown", &pgconn.PgError{Code: pgerrcode.AdminShutdown}, storage.ErrCodeUnavailable, http.StatusServiceUnavailable},
+		{"postgres undefined column", &pgconn.PgError{Code: pgerrcode.UndefinedColumn}, storage.ErrCodeInvalidQuery, http.StatusBadRequest},
+	})
+}
+
+func TestTLSReloaderNotConfigured(t *testing.T) {
+	reloader, err := newPostgresTLSReloader(&Config{Type: "postgres", Database: "clusterpedia"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloader != nil {
+		t.Error("the reloader should be nil without the certificate files")
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !no_sqlite
+
+package internalstorage
+
+import (
+	"errors"
+	"io"
+
+	"github.com/mattn/go-sqlite3"
+	gsqlite "gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func init() {
+	registerDatabaseDriver(databaseDriver{open: openSQLiteDialector, interpretError: InterpretSQLiteError}, "sqlite", "sqlite3")
+}
+
+func openSQLiteDialector(cfg *Config, credentials *credentialsProvider) (gorm.Dialector, []io.Closer, error) {
+	if credentials != nil {
+		return nil, nil, errors.New("credentials are not supported by sqlite")
+	}
+
+	dsn, err := cfg.genSQLiteDSN()
+	if err != nil {
+		return nil, nil, err
+	}
+	return gsqlite.Open(dsn), nil, nil
+}
+
+func InterpretSQLiteError(key string, err error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	switch sqliteErr.Code {
+	case sqlite3.ErrBusy, sqlite3.ErrLocked:
+		// the database is locked by other writers longer than the busy timeout
+		return storage.NewUnavailableError(key, err)
+	case sqlite3.ErrTooBig:
+		return storage.NewTooLargeError(key, err)
+	case sqlite3.ErrConstraint:
+		switch sqliteErr.ExtendedCode {
+		case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+			return storage.NewConflictError(key, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,34 @@
+//go:build !no_sqlite
+
+package internalstorage
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestGetSQLiteDriver(t *testing.T) {
+	for _, typ := range []string{"sqlite", "sqlite3"} {
+		if _, err := getDatabaseDriver(typ); err != nil {
+			t.Errorf("driver %s should be compiled in by default: %v", typ, err)
+		}
+	}
+
+	delete(databaseDrivers, "sqlite3")
+	defer func() { databaseDrivers["sqlite3"] = databaseDrivers["sqlite"] }()
+	if _, err := getDatabaseDriver("sqlite3"); err == nil || !strings.Contains(err.Error(), "no_sqlite") {
+		t.Errorf("the error of the excluded driver should name the build tag: %v", err)
+	}
+}
+
+func TestInterpretSQLiteError(t *testing.T) {
+	testInterpretDBError(t, []interpretDBErrorTest{
+		{"sqlite busy", sqlite3.Error{Code: sqlite3.ErrBusy}, storage.ErrCodeUnavailable, http.StatusServiceUnavailable},
+		{"sqlite unique constraint", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}, storage.ErrCodeConflict, http.StatusConflict},
+	})
+}
@@ -0,0 +1,59 @@
+package internalstorage
+
+import (
+	"fmt"
+	"io"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// databaseDriver opens the dialector and interprets the errors of a storage type.
+//
+// The drivers are compiled in by default, and are excluded by the build tags `no_mysql`, `no_postgres` and `no_sqlite`,
+// so the minimal images don't link the clients of the unused databases, e.g. the cgo-free images are built without sqlite.
+// The dialect specific code which depends on the clients, e.g. the json values of MySQL, is only in the drivers,
+// the drivers are registered by the dialect names of their dialectors.
+type databaseDriver struct {
+	open           func(cfg *Config, credentials *credentialsProvider) (gorm.Dialector, []io.Closer, error)
+	interpretError func(key string, err error) error
+
+	// jsonValue returns the expression of the json value written to the json columns,
+	// the value is written as it is if jsonValue is nil.
+	jsonValue func(dialector gorm.Dialector, data string) clause.Expr
+}
+
+var (
+	databaseDrivers = make(map[string]databaseDriver)
+
+	// errorInterpreters are in the order of the registrations, so the errors are interpreted deterministically
+	errorInterpreters []func(key string, err error) error
+)
+
+// driverBuildTags are the build tags excluding the drivers of the storage types
+var driverBuildTags = map[string]string{
+	"mysql":    "no_mysql",
+	"postgres": "no_postgres",
+	"sqlite":   "no_sqlite",
+	"sqlite3":  "no_sqlite",
+}
+
+func registerDatabaseDriver(driver databaseDriver, types ...string) {
+	for _, typ := range types {
+		if _, ok := databaseDrivers[typ]; ok {
+			panic(fmt.Sprintf("database driver %s has been registered", typ))
+		}
+		databaseDrivers[typ] = driver
+	}
+	errorInterpreters = append(errorInterpreters, driver.interpretError)
+}
+
+func getDatabaseDriver(typ string) (databaseDriver, error) {
+	if driver, ok := databaseDrivers[typ]; ok {
+		return driver, nil
+	}
+	if tag, ok := driverBuildTags[typ]; ok {
+		return databaseDriver{}, fmt.Errorf("storage type %s is not supported, the binary is built with the %s build tag", typ, tag)
+	}
+	return databaseDriver{}, fmt.Errorf("not support storage type: %s", typ)
+}
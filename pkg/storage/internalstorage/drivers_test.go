@@ -0,0 +1,23 @@
+package internalstorage
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestGetDatabaseDriver(t *testing.T) {
+	if _, err := getDatabaseDriver("oracle"); err == nil || !strings.Contains(err.Error(), "not support storage type") {
+		t.Errorf("unexpected error of the unknown storage type: %v", err)
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	sql := postgresDB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&Resource{}).Where("id = 1").Update("object", JSON(`{"metadata":{"name":"foo"}}`))
+	})
+	if expected := `'{"metadata":{"name":"foo"}}'`; !strings.Contains(sql, expected) {
+		t.Errorf("the json value is %s, want %s", sql, expected)
+	}
+}
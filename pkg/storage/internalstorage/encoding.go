@@ -0,0 +1,98 @@
+package internalstorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimejson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+)
+
+// protobufEncoding is the encoding of the objects stored in protobuf, the objects are stored in json if the encoding is empty.
+//
+// The objects in protobuf are in the storage version of the resources, they are decoded by the codecs of the resources
+// without being converted to json, and are converted to json by the legacy scheme when the json is required.
+const protobufEncoding = "protobuf"
+
+var legacyJSONSerializer = runtimejson.NewSerializerWithOptions(
+	runtimejson.DefaultMetaFactory, scheme.LegacyResourceScheme, scheme.LegacyResourceScheme, runtimejson.SerializerOptions{},
+)
+
+// encodingFor returns the encoding of the objects of the resource,
+// only the built-in resources are stored in protobuf.
+func (s *StorageFactory) encodingFor(gr schema.GroupResource) string {
+	if s.protobuf && scheme.LegacyResourceScheme.IsGroupRegistered(gr.Group) {
+		return protobufEncoding
+	}
+	return ""
+}
+
+func (s *ResourceStorage) encodeProtobuf(obj runtime.Object) ([]byte, error) {
+	if _, ok := obj.(runtime.Unstructured); ok {
+		return nil, fmt.Errorf("%T can't be encoded in protobuf", obj)
+	}
+
+	var buffer bytes.Buffer
+	if err := s.config.ProtobufCodec.Encode(obj, &buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// decodeJSON returns the object in json, the object in protobuf is converted to json.
+func decodeJSON(encoding string, data []byte) ([]byte, error) {
+	switch encoding {
+	case "":
+		return data, nil
+	case protobufEncoding:
+		obj, _, err := scheme.LegacyResourceCodecs.UniversalDeserializer().Decode(data, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return runtime.Encode(legacyJSONSerializer, obj)
+	default:
+		return nil, fmt.Errorf("the object is encoded in the unsupported encoding %q", encoding)
+	}
+}
+
+// ProtobufBytes is the object encoded in protobuf
+type ProtobufBytes []byte
+
+func (bytes ProtobufBytes) ConvertToUnstructured() (*unstructured.Unstructured, error) {
+	data, err := decodeJSON(protobufEncoding, bytes)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (bytes ProtobufBytes) ConvertTo(codec runtime.Codec, object runtime.Object) (runtime.Object, error) {
+	if _, ok := object.(runtime.Unstructured); ok {
+		// the objects in protobuf can't be decoded into the unstructured objects
+		data, err := decodeJSON(protobufEncoding, bytes)
+		if err != nil {
+			return nil, err
+		}
+		return Bytes(data).ConvertTo(codec, object)
+	}
+	obj, _, err := codec.Decode(bytes, nil, object)
+	return obj, err
+}
+
+func (bytes ProtobufBytes) GetResourceType() ResourceType {
+	return ResourceType{}
+}
+
+func (bytes ProtobufBytes) GetEvents() []*corev1.Event {
+	return nil
+}
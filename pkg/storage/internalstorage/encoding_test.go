@@ -0,0 +1,86 @@
+package internalstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestResourceStorage_ProtobufWithSQLite(t *testing.T) {
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+
+	factory := &StorageFactory{db: db, protobuf: true}
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Resource: "pods"}, true)
+	require.NoError(err)
+	s, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+	require.NoError(err)
+	rs := s.(*ResourceStorage)
+
+	ctx := context.Background()
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", ResourceVersion: "1"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+	}
+	require.NoError(rs.Create(ctx, "cluster-1", pod))
+
+	var stored Resource
+	require.NoError(db.First(&stored).Error)
+	require.Equal(protobufEncoding, stored.Encoding)
+	require.Equal(compressedStorageFormat, stored.FormatVersion)
+	require.NotContains(string(stored.Object), "node-1")
+	require.Contains(string(stored.Object), `"name":"web"`)
+
+	// the objects in protobuf are decoded into the typed and the unstructured objects
+	got := &corev1.Pod{}
+	require.NoError(rs.Get(ctx, "cluster-1", "default", "web", got))
+	require.Equal("node-1", got.Spec.NodeName)
+
+	list := &corev1.PodList{}
+	require.NoError(rs.List(ctx, list, &internal.ListOptions{}))
+	require.Len(list.Items, 1)
+	require.Equal("node-1", list.Items[0].Spec.NodeName)
+
+	uList := &unstructured.UnstructuredList{}
+	uList.SetAPIVersion("v1")
+	require.NoError(rs.List(ctx, uList, &internal.ListOptions{}))
+	require.Len(uList.Items, 1)
+	nodeName, _, err := unstructured.NestedString(uList.Items[0].Object, "spec", "nodeName")
+	require.NoError(err)
+	require.Equal("node-1", nodeName)
+
+	data, err := stored.storedObject().decode()
+	require.NoError(err)
+	require.Contains(string(data), `"nodeName":"node-1"`)
+
+	// the rows are outdated after the encoding of the resource is changed
+	versions, err := factory.GetResourceVersions(ctx, "cluster-1")
+	require.NoError(err)
+	require.Equal("1", versions[corev1.SchemeGroupVersion.WithResource("pods")].Resources["default/web"])
+	factory.protobuf = false
+	versions, err = factory.GetResourceVersions(ctx, "cluster-1")
+	require.NoError(err)
+	require.Equal("", versions[corev1.SchemeGroupVersion.WithResource("pods")].Resources["default/web"])
+
+	// the custom resources fall back to json
+	crdConfig, err := resourceconfigfactory.New().NewUnstructuredConfig(schema.GroupVersionResource{Group: "example.io", Version: "v1", Resource: "foos"}, true)
+	require.NoError(err)
+	factory.protobuf = true
+	s, err = factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *crdConfig})
+	require.NoError(err)
+	require.False(s.(*ResourceStorage).protobuf)
+	require.Equal("", factory.encodingFor(schema.GroupResource{Group: "example.io", Resource: "foos"}))
+}
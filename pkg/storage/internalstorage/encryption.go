@@ -0,0 +1,355 @@
+package internalstorage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apiserver/pkg/storage/value/encrypt/envelope/kmsv2"
+	kmsservice "k8s.io/kms/pkg/service"
+	"k8s.io/utils/lru"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	keyFileEncryptionProvider   = "keyfile"
+	kmsEncryptionProviderPrefix = "kms/"
+
+	lastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+	defaultKMSTimeout = 3 * time.Second
+
+	// the data key is rotated after it encrypts dataKeyMaxUses objects or after dataKeyTTL,
+	// so the key encryption keys are not called for every object.
+	dataKeyMaxUses = 1 << 20
+	dataKeyTTL     = time.Hour
+
+	// dataKeyCacheSize is the number of the decrypted data keys cached for the reads
+	dataKeyCacheSize = 1000
+)
+
+// envelopes are the envelope encryptions keyed by the provider recorded in the encryption column,
+// the encrypted objects are decrypted by the envelopes when they are read.
+var envelopes sync.Map // map[string]*envelope
+
+func registerEnvelope(e *envelope) {
+	envelopes.Store(e.provider, e)
+}
+
+func decryptObject(provider string, data, additionalData []byte) ([]byte, error) {
+	e, ok := envelopes.Load(provider)
+	if !ok {
+		return nil, fmt.Errorf("the object is encrypted by the unconfigured provider %q", provider)
+	}
+	return e.(*envelope).decrypt(data, additionalData)
+}
+
+func (cfg *EncryptionConfig) validate() error {
+	if cfg == nil {
+		return nil
+	}
+	if (cfg.KeyFile == "") == (cfg.KMS == nil) {
+		return fmt.Errorf("encryption: exactly one of keyFile and kms is required")
+	}
+	if cfg.KMS != nil && (cfg.KMS.Name == "" || cfg.KMS.Endpoint == "") {
+		return fmt.Errorf("encryption.kms: name and endpoint are required")
+	}
+	for i, resource := range cfg.Resources {
+		if schema.ParseGroupResource(resource).Resource == "" {
+			return fmt.Errorf("encryption.resources[%d]: resource is required", i)
+		}
+	}
+	return nil
+}
+
+// encryptions returns the envelope encryptions of the encrypted resources, the secrets are encrypted by default.
+// The envelope is registered to decrypt the objects, the returned closer closes the connection to the kms plugin.
+func (cfg *EncryptionConfig) encryptions() (map[schema.GroupResource]*envelope, io.Closer, error) {
+	if cfg == nil {
+		return nil, nil, nil
+	}
+	e, closer, err := cfg.newEnvelope()
+	if err != nil {
+		return nil, nil, err
+	}
+	registerEnvelope(e)
+
+	resources := cfg.Resources
+	if len(resources) == 0 {
+		resources = []string{"secrets"}
+	}
+	encryptions := make(map[schema.GroupResource]*envelope, len(resources))
+	for _, resource := range resources {
+		encryptions[schema.ParseGroupResource(resource)] = e
+	}
+	return encryptions, closer, nil
+}
+
+// encryptionFor returns the provider which encrypts the objects of the resource, it is empty if the resource is not encrypted.
+func (s *StorageFactory) encryptionFor(gr schema.GroupResource) string {
+	if e := s.encryptions[gr]; e != nil {
+		return e.provider
+	}
+	return ""
+}
+
+// newEnvelope returns the envelope encryption of the config, the returned closer closes the connection to the kms plugin.
+func (cfg *EncryptionConfig) newEnvelope() (*envelope, io.Closer, error) {
+	if cfg.KeyFile != "" {
+		keys, err := loadKeyFile(cfg.KeyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		return newEnvelope(keyFileEncryptionProvider, keys), nil, nil
+	}
+
+	timeout := cfg.KMS.Timeout
+	if timeout == 0 {
+		timeout = defaultKMSTimeout
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	service, err := kmsv2.NewGRPCService(ctx, cfg.KMS.Endpoint, cfg.KMS.Name, timeout)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return newEnvelope(kmsEncryptionProviderPrefix+cfg.KMS.Name, &kmsKeys{service: service}), cancelCloser(cancel), nil
+}
+
+type cancelCloser context.CancelFunc
+
+func (c cancelCloser) Close() error {
+	c()
+	return nil
+}
+
+// keyEncrypter encrypts and decrypts the data keys of the envelopes
+type keyEncrypter interface {
+	encrypt(ctx context.Context, key []byte) (*envelopeHeader, error)
+	decrypt(ctx context.Context, header *envelopeHeader) ([]byte, error)
+}
+
+// envelopeHeader is the encrypted data key of the encrypted objects
+type envelopeHeader struct {
+	KeyID       string            `json:"keyID"`
+	Key         []byte            `json:"key"`
+	Annotations map[string][]byte `json:"annotations,omitempty"`
+}
+
+// envelope encrypts the objects by AES-GCM with the data keys, and the data keys are encrypted by the key encrypter.
+//
+// The encrypted object is formatted as the length of the header in 4 bytes, the header encoded in json,
+// the nonce and the ciphertext, the data key is reused by the objects until it is rotated.
+// The key of the row is authenticated as the additional data, so the encrypted object can't be decrypted
+// after it is copied to the other rows.
+type envelope struct {
+	provider string
+	keys     keyEncrypter
+
+	lock      sync.Mutex
+	aead      cipher.AEAD
+	header    []byte
+	uses      int
+	expiresAt time.Time
+
+	// cache is the decrypted data keys keyed by their headers
+	cache *lru.Cache
+}
+
+func newEnvelope(provider string, keys keyEncrypter) *envelope {
+	return &envelope{provider: provider, keys: keys, cache: lru.New(dataKeyCacheSize)}
+}
+
+// dataKey returns the data key for the encryption, it is rotated if it is used up or expired.
+func (e *envelope) dataKey() (cipher.AEAD, []byte, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.aead == nil || e.uses >= dataKeyMaxUses || time.Now().After(e.expiresAt) {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, nil, err
+		}
+		header, err := e.keys.encrypt(context.Background(), key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encrypt the data key: %w", err)
+		}
+		encodedHeader, err := json.Marshal(header)
+		if err != nil {
+			return nil, nil, err
+		}
+		aead, err := newAESGCM(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		e.aead, e.header, e.uses, e.expiresAt = aead, encodedHeader, 0, time.Now().Add(dataKeyTTL)
+		e.cache.Add(string(encodedHeader), aead)
+	}
+	e.uses++
+	return e.aead, e.header, nil
+}
+
+func (e *envelope) encrypt(data, additionalData []byte) ([]byte, error) {
+	aead, header, err := e.dataKey()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 4, 4+len(header)+aead.NonceSize()+len(data)+aead.Overhead())
+	binary.BigEndian.PutUint32(out, uint32(len(header)))
+	out = append(out, header...)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, data, additionalData), nil
+}
+
+func (e *envelope) decrypt(data, additionalData []byte) ([]byte, error) {
+	if len(data) < 4 || uint32(len(data)-4) < binary.BigEndian.Uint32(data) {
+		return nil, fmt.Errorf("the encrypted object is malformed")
+	}
+	length := binary.BigEndian.Uint32(data)
+	header, data := data[4:4+length], data[4+length:]
+
+	var aead cipher.AEAD
+	if cached, ok := e.cache.Get(string(header)); ok {
+		aead = cached.(cipher.AEAD)
+	} else {
+		var decoded envelopeHeader
+		if err := json.Unmarshal(header, &decoded); err != nil {
+			return nil, fmt.Errorf("the header of the encrypted object is malformed: %w", err)
+		}
+		key, err := e.keys.decrypt(context.Background(), &decoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the data key: %w", err)
+		}
+		if aead, err = newAESGCM(key); err != nil {
+			return nil, err
+		}
+		e.cache.Add(string(header), aead)
+	}
+
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("the encrypted object is malformed")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// keyFile is the file of the key encryption keys
+//
+//	keys:
+//	- name: key-2
+//	  secret: <base64 encoded 32 bytes>
+//	- name: key-1
+//	  secret: <base64 encoded 32 bytes>
+type keyFile struct {
+	Keys []struct {
+		Name   string `json:"name"`
+		Secret string `json:"secret"`
+	} `json:"keys"`
+}
+
+// fileKeys encrypts the data keys by the first key and decrypts them by the key named in the header,
+// so the keys are rotated by prepending the new key.
+type fileKeys struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+func loadKeyFile(path string) (*fileKeys, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: failed to read the key file: %w", err)
+	}
+	var file keyFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("encryption: failed to parse the key file: %w", err)
+	}
+	if len(file.Keys) == 0 {
+		return nil, fmt.Errorf("encryption: the key file has no keys")
+	}
+
+	keys := &fileKeys{primary: file.Keys[0].Name, keys: make(map[string]cipher.AEAD, len(file.Keys))}
+	for i, key := range file.Keys {
+		if key.Name == "" {
+			return nil, fmt.Errorf("encryption: keys[%d]: name is required", i)
+		}
+		if _, ok := keys.keys[key.Name]; ok {
+			return nil, fmt.Errorf("encryption: keys[%d]: duplicate key %q", i, key.Name)
+		}
+		secret, err := base64.StdEncoding.DecodeString(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("encryption: keys[%d]: the secret must be base64 encoded: %w", i, err)
+		}
+		if len(secret) != 32 {
+			return nil, fmt.Errorf("encryption: keys[%d]: the secret must be 32 bytes, got %d bytes", i, len(secret))
+		}
+		if keys.keys[key.Name], err = newAESGCM(secret); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func (k *fileKeys) encrypt(_ context.Context, key []byte) (*envelopeHeader, error) {
+	aead := k.keys[k.primary]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return &envelopeHeader{KeyID: k.primary, Key: aead.Seal(nonce, nonce, key, nil)}, nil
+}
+
+func (k *fileKeys) decrypt(_ context.Context, header *envelopeHeader) ([]byte, error) {
+	aead, ok := k.keys[header.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("the key %q is not in the key file", header.KeyID)
+	}
+	if len(header.Key) < aead.NonceSize() {
+		return nil, fmt.Errorf("the encrypted data key is malformed")
+	}
+	return aead.Open(nil, header.Key[:aead.NonceSize()], header.Key[aead.NonceSize():], nil)
+}
+
+// kmsKeys encrypts and decrypts the data keys by the KMS v2 plugin
+type kmsKeys struct {
+	service kmsservice.Service
+}
+
+func (k *kmsKeys) encrypt(ctx context.Context, key []byte) (*envelopeHeader, error) {
+	resp, err := k.service.Encrypt(ctx, string(uuid.NewUUID()), key)
+	if err != nil {
+		return nil, err
+	}
+	return &envelopeHeader{KeyID: resp.KeyID, Key: resp.Ciphertext, Annotations: resp.Annotations}, nil
+}
+
+func (k *kmsKeys) decrypt(ctx context.Context, header *envelopeHeader) ([]byte, error) {
+	return k.service.Decrypt(ctx, string(uuid.NewUUID()), &kmsservice.DecryptRequest{
+		Ciphertext:  header.Key,
+		KeyID:       header.KeyID,
+		Annotations: header.Annotations,
+	})
+}
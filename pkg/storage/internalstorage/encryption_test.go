@@ -0,0 +1,171 @@
+package internalstorage
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kmsservice "k8s.io/kms/pkg/service"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func writeKeyFile(t *testing.T, names ...string) string {
+	content := "keys:\n"
+	for _, name := range names {
+		secret := make([]byte, 32)
+		copy(secret, name)
+		content += "- name: " + name + "\n  secret: " + base64.StdEncoding.EncodeToString(secret) + "\n"
+	}
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestResourceStorage_EncryptionWithSQLite(t *testing.T) {
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+
+	cfg := &EncryptionConfig{KeyFile: writeKeyFile(t, "key-1")}
+	require.NoError(cfg.validate())
+	encryptions, _, err := cfg.encryptions()
+	require.NoError(err)
+
+	factory := &StorageFactory{db: db, encryptions: encryptions}
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Resource: "secrets"}, true)
+	require.NoError(err)
+	s, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+	require.NoError(err)
+	rs := s.(*ResourceStorage)
+
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "token", ResourceVersion: "1",
+			Annotations: map[string]string{lastAppliedConfigurationAnnotation: `{"data":{"token":"c2VjcmV0LXRva2Vu"}}`},
+		},
+		Data: map[string][]byte{"token": []byte("secret-token")},
+	}
+	require.NoError(rs.Create(ctx, "cluster-1", secret))
+
+	var stored Resource
+	require.NoError(db.First(&stored).Error)
+	require.Equal(keyFileEncryptionProvider, stored.Encryption)
+	require.Equal("", stored.SpecHash)
+	require.Contains(string(stored.Object), `"name":"token"`)
+	for _, plaintext := range []string{"secret-token", "c2VjcmV0LXRva2Vu", lastAppliedConfigurationAnnotation} {
+		require.NotContains(string(stored.Object), plaintext)
+		require.NotContains(string(stored.CompressedObject), plaintext)
+	}
+
+	got := &corev1.Secret{}
+	require.NoError(rs.Get(ctx, "cluster-1", "default", "token", got))
+	require.Equal("secret-token", string(got.Data["token"]))
+
+	list := &corev1.SecretList{}
+	require.NoError(rs.List(ctx, list, &internal.ListOptions{}))
+	require.Len(list.Items, 1)
+	require.Equal("secret-token", string(list.Items[0].Data["token"]))
+
+	// the encrypted object copied to the other row can't be decrypted
+	other := secret.DeepCopy()
+	other.Name = "other"
+	require.NoError(rs.Create(ctx, "cluster-1", other))
+	require.NoError(db.Model(&Resource{}).Where(map[string]interface{}{"name": "other"}).
+		Update("compressed_object", stored.CompressedObject).Error)
+	require.Error(rs.Get(ctx, "cluster-1", "default", "other", &corev1.Secret{}))
+	require.NoError(db.Where(map[string]interface{}{"name": "other"}).Delete(&Resource{}).Error)
+
+	// the objects encrypted by the previous keys are decrypted after the keys are rotated
+	cfg.KeyFile = writeKeyFile(t, "key-2", "key-1")
+	encryptions, _, err = cfg.encryptions()
+	require.NoError(err)
+	factory.encryptions = encryptions
+	s, err = factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+	require.NoError(err)
+	got = &corev1.Secret{}
+	require.NoError(s.Get(ctx, "cluster-1", "default", "token", got))
+	require.Equal("secret-token", string(got.Data["token"]))
+
+	// the rows are outdated after the encryption of the resource is changed
+	versions, err := factory.GetResourceVersions(ctx, "cluster-1")
+	require.NoError(err)
+	require.Equal("1", versions[corev1.SchemeGroupVersion.WithResource("secrets")].Resources["default/token"])
+	factory.encryptions = nil
+	versions, err = factory.GetResourceVersions(ctx, "cluster-1")
+	require.NoError(err)
+	require.Equal("", versions[corev1.SchemeGroupVersion.WithResource("secrets")].Resources["default/token"])
+}
+
+type fakeKMSService struct {
+	kmsservice.Service
+	calls int
+}
+
+func (s *fakeKMSService) Encrypt(_ context.Context, _ string, data []byte) (*kmsservice.EncryptResponse, error) {
+	s.calls++
+	ciphertext := make([]byte, len(data))
+	for i := range data {
+		ciphertext[i] = data[i] ^ 0xff
+	}
+	return &kmsservice.EncryptResponse{Ciphertext: ciphertext, KeyID: "kms-key-1"}, nil
+}
+
+func (s *fakeKMSService) Decrypt(_ context.Context, _ string, req *kmsservice.DecryptRequest) ([]byte, error) {
+	s.calls++
+	data := make([]byte, len(req.Ciphertext))
+	for i := range req.Ciphertext {
+		data[i] = req.Ciphertext[i] ^ 0xff
+	}
+	return data, nil
+}
+
+func TestEnvelope_KMS(t *testing.T) {
+	require := require.New(t)
+
+	service := &fakeKMSService{}
+	e := newEnvelope(kmsEncryptionProviderPrefix+"fake", &kmsKeys{service: service})
+	additionalData := []byte("cluster-1")
+	first, err := e.encrypt([]byte("secret-token"), additionalData)
+	require.NoError(err)
+	second, err := e.encrypt([]byte("secret-token"), additionalData)
+	require.NoError(err)
+	require.NotEqual(first, second)
+	require.Equal(1, service.calls, "the data key should be reused")
+
+	// the data keys are decrypted by the kms plugin once and then cached
+	decrypting := newEnvelope(e.provider, e.keys)
+	for _, data := range [][]byte{first, second} {
+		plaintext, err := decrypting.decrypt(data, additionalData)
+		require.NoError(err)
+		require.Equal("secret-token", string(plaintext))
+	}
+	require.Equal(2, service.calls)
+
+	// the object can't be decrypted with the key of the other row
+	_, err = decrypting.decrypt(first, []byte("cluster-2"))
+	require.Error(err)
+
+	first[len(first)-1] ^= 0xff
+	_, err = decrypting.decrypt(first, additionalData)
+	require.Error(err)
+}
+
+func TestEncryptionConfig_Validate(t *testing.T) {
+	require.Error(t, (&EncryptionConfig{}).validate())
+	require.Error(t, (&EncryptionConfig{KeyFile: "keys.yaml", KMS: &KMSConfig{Name: "kms", Endpoint: "unix:///kms.sock"}}).validate())
+	require.Error(t, (&EncryptionConfig{KMS: &KMSConfig{Name: "kms"}}).validate())
+	require.NoError(t, (&EncryptionConfig{KMS: &KMSConfig{Name: "kms", Endpoint: "unix:///kms.sock"}}).validate())
+}
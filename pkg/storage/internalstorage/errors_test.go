@@ -0,0 +1,45 @@
+package internalstorage
+
+import (
+	"net/http"
+	"testing"
+
+	"gorm.io/gorm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type interpretDBErrorTest struct {
+	name   string
+	err    error
+	code   storage.ErrorCode
+	status int32
+}
+
+// testInterpretDBError checks the storage error codes and the api statuses of the database errors,
+// the errors of the databases are tested with their drivers.
+func testInterpretDBError(t *testing.T, tests []interpretDBErrorTest) {
+	pods := schema.GroupResource{Resource: "pods"}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := InterpretDBError("cluster-1/pod-1", test.err)
+			if code := storage.ErrorCodeOf(err); code != test.code {
+				t.Fatalf("ErrorCodeOf() = %q, want %q", code, test.code)
+			}
+
+			apiErr := storage.InterpretGetError(err, pods, "pod-1")
+			statusErr, ok := apiErr.(apierrors.APIStatus)
+			if !ok || statusErr.Status().Code != test.status {
+				t.Fatalf("InterpretGetError() = %v, want the status %d", apiErr, test.status)
+			}
+		})
+	}
+}
+
+func TestInterpretDBError(t *testing.T) {
+	testInterpretDBError(t, []interpretDBErrorTest{
+		{"record not found", gorm.ErrRecordNotFound, storage.ErrCodeNotFound, http.StatusNotFound},
+	})
+}
@@ -0,0 +1,131 @@
+package internalstorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// ignoredFieldChanges are the fields which change with every version of the object,
+// they are not recorded to keep the changes compact.
+var ignoredFieldChanges = map[string]bool{
+	"metadata.resourceVersion": true,
+	"metadata.managedFields":   true,
+}
+
+var plainFieldKey = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// diffFields returns the changed fields between the two versions of the object sorted by the path,
+// the maps are compared field by field and the lists are compared as a whole.
+func diffFields(old, new map[string]interface{}) []storage.FieldChange {
+	var changes []storage.FieldChange
+	diffMaps("", old, new, &changes)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func diffMaps(prefix string, old, new map[string]interface{}, changes *[]storage.FieldChange) {
+	keys := make(map[string]struct{}, len(old)+len(new))
+	for key := range old {
+		keys[key] = struct{}{}
+	}
+	for key := range new {
+		keys[key] = struct{}{}
+	}
+
+	for key := range keys {
+		path := fieldPath(prefix, key)
+		if ignoredFieldChanges[path] {
+			continue
+		}
+
+		oldValue, oldFound := old[key]
+		newValue, newFound := new[key]
+		oldMap, oldIsMap := oldValue.(map[string]interface{})
+		newMap, newIsMap := newValue.(map[string]interface{})
+		switch {
+		case oldIsMap && newIsMap:
+			diffMaps(path, oldMap, newMap, changes)
+		case oldFound != newFound || !reflect.DeepEqual(oldValue, newValue):
+			*changes = append(*changes, storage.FieldChange{
+				Path: path,
+				Old:  scalarValue(oldValue),
+				New:  scalarValue(newValue),
+			})
+		}
+	}
+}
+
+// fieldPath joins the key to the path, the key which isn't a plain identifier is quoted, e.g. `metadata.labels['app.kubernetes.io/name']`.
+func fieldPath(prefix, key string) string {
+	if !plainFieldKey.MatchString(key) {
+		return prefix + "['" + strings.ReplaceAll(key, "'", `\'`) + "']"
+	}
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// scalarValue returns the JSON value of the scalar, it is empty for the absent, the map and the list values.
+func scalarValue(value interface{}) string {
+	switch value.(type) {
+	case nil, map[string]interface{}, []interface{}:
+		return ""
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// patchedJSONObject returns the expression of the object column which sets the changed top-level fields to their values in the data,
+// and removes the fields absent from the data, false is returned if the fields can't be patched by the json functions of the database.
+func patchedJSONObject(dialect string, data []byte, fields []string) (clause.Expr, bool, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return clause.Expr{}, false, err
+	}
+
+	fields = slices.Sorted(slices.Values(fields))
+	expr, vars := "?", []interface{}{clause.Column{Name: "object"}}
+	for _, field := range fields {
+		// the field is written as the literal of the json path
+		if !plainFieldKey.MatchString(field) {
+			return clause.Expr{}, false, nil
+		}
+
+		value, ok := values[field]
+		switch {
+		case dialect == "postgres" && ok:
+			expr = fmt.Sprintf("jsonb_set(%s, '{%s}', CAST(? AS jsonb))", expr, field)
+		case dialect == "postgres":
+			expr = fmt.Sprintf("(%s - '%s')", expr, field)
+		case dialect == "mysql" && ok:
+			expr = fmt.Sprintf("JSON_SET(%s, '$.%s', CAST(? AS JSON))", expr, field)
+		case dialect == "mysql":
+			expr = fmt.Sprintf("JSON_REMOVE(%s, '$.%s')", expr, field)
+		case dialect == "sqlite" && ok:
+			expr = fmt.Sprintf("json_set(%s, '$.%s', json(?))", expr, field)
+		case dialect == "sqlite":
+			expr = fmt.Sprintf("json_remove(%s, '$.%s')", expr, field)
+		default:
+			return clause.Expr{}, false, nil
+		}
+		if ok {
+			vars = append(vars, string(value))
+		}
+	}
+	return clause.Expr{SQL: expr, Vars: vars}, true, nil
+}
@@ -0,0 +1,78 @@
+package internalstorage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// The storage format of the rows is increased when the rows are written in a different way.
+//
+// The rows are readable within the compatibility window [CurrentStorageFormat-1, CurrentStorageFormat+1],
+// so the components of the adjacent versions can share the storage during the rolling upgrade:
+//   - the rows in the previous format are rewritten lazily when the objects are synchronized again,
+//     the clustersynchro manager treats them as outdated, so they are rewritten after it is upgraded.
+//   - the rows in the next format are written by the upgraded clustersynchro manager,
+//     so a new format must be readable by the version of the previous format.
+//
+// The rows out of the window must be rewritten by an intermediate version before the upgrade.
+//
+// Formats:
+//
+//	1: the rows written before the format is recorded, the spec hash may be empty
+//	2: the spec hash is always recorded
+//	3: the object may be compressed or encoded in protobuf, only the metadata is kept in the object column of these rows,
+//	   the rows of the other resources are still written in the format 2
+const (
+	CurrentStorageFormat = 2
+
+	minReadableStorageFormat = CurrentStorageFormat - 1
+	maxReadableStorageFormat = CurrentStorageFormat + 1
+)
+
+// unreadableStorageFormats returns the number of the rows in each storage format which is out of the compatibility window.
+func unreadableStorageFormats(db *gorm.DB) (map[int]int64, error) {
+	if !db.Migrator().HasColumn(&Resource{}, "format_version") {
+		return nil, nil
+	}
+
+	var formats []struct {
+		FormatVersion int
+		Count         int64
+	}
+	result := db.Model(&Resource{}).Select("format_version", "COUNT(*) AS count").
+		Where("format_version < ? OR format_version > ?", minReadableStorageFormat, maxReadableStorageFormat).
+		Group("format_version").Find(&formats)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[int]int64, len(formats))
+	for _, format := range formats {
+		counts[format.FormatVersion] = format.Count
+	}
+	return counts, nil
+}
+
+// checkStorageFormats returns an error if any row is out of the compatibility window.
+func checkStorageFormats(db *gorm.DB) error {
+	counts, err := unreadableStorageFormats(db)
+	if err != nil || len(counts) == 0 {
+		return err
+	}
+
+	formats := make([]int, 0, len(counts))
+	for format := range counts {
+		formats = append(formats, format)
+	}
+	sort.Ints(formats)
+
+	stored := make([]string, 0, len(formats))
+	for _, format := range formats {
+		stored = append(stored, fmt.Sprintf("%d resources in the format %d", counts[format], format))
+	}
+	return fmt.Errorf("storage has %s, which are out of the readable formats [%d, %d], upgrade through the adjacent versions to rewrite them",
+		strings.Join(stored, ", "), minReadableStorageFormat, maxReadableStorageFormat)
+}
@@ -0,0 +1,48 @@
+package internalstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetResourceVersionsOfOutdatedFormat(t *testing.T) {
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	for name, format := range map[string]int{"legacy": 1, "current": CurrentStorageFormat} {
+		require.NoError(t, db.Create(&Resource{
+			Cluster: "cluster-1", Group: gvr.Group, Version: gvr.Version, Resource: gvr.Resource, Kind: "Deployment",
+			Namespace: "default", Name: name, ResourceVersion: "100", Object: []byte("{}"), FormatVersion: format,
+		}).Error)
+	}
+
+	factory := &StorageFactory{db: db}
+	versions, err := factory.GetResourceVersions(context.TODO(), "cluster-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"default/legacy": "", "default/current": "100"}, versions[gvr].Resources)
+}
+
+func TestCheckStorageFormats(t *testing.T) {
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	require.NoError(t, db.Create(&Resource{Cluster: "cluster-1", Name: "previous", Object: []byte("{}"), FormatVersion: minReadableStorageFormat}).Error)
+	require.NoError(t, checkStorageFormats(db))
+
+	require.NoError(t, db.Create(&Resource{Cluster: "cluster-1", Name: "future-1", Object: []byte("{}"), FormatVersion: maxReadableStorageFormat + 1}).Error)
+	require.NoError(t, db.Create(&Resource{Cluster: "cluster-1", Name: "future-2", Object: []byte("{}"), FormatVersion: maxReadableStorageFormat + 1}).Error)
+	err = checkStorageFormats(db)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 resources in the format 4")
+
+	plan, err := (&StorageFactory{db: db}).PlanSchemaMigration(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2 resources are stored in the format 4, which is out of the readable formats [1, 3]"}, plan.Incompatibilities)
+}
@@ -0,0 +1,175 @@
+package internalstorage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// the table named by the default naming strategy of gorm
+const resourceChangesTable = "resource_changes"
+
+var _ storage.ResourceHistoryGetter = &ResourceStorage{}
+
+// ResourceChange is the field-level changes between the consecutive versions of an object,
+// it is recorded by the updates of the object if the `recordFieldChanges` is enabled,
+// and it is deleted with the object.
+type ResourceChange struct {
+	ID uint `gorm:"primaryKey"`
+
+	Group    string `gorm:"size:63;not null;index:idx_resource_changes_object"`
+	Version  string `gorm:"size:15;not null;index:idx_resource_changes_object"`
+	Resource string `gorm:"size:63;not null;index:idx_resource_changes_object"`
+
+	Cluster   string    `gorm:"size:253;not null;index:idx_resource_changes_object,length:100;index:idx_resource_changes_cluster"`
+	Namespace string    `gorm:"size:253;not null;index:idx_resource_changes_object,length:50"`
+	Name      string    `gorm:"size:253;not null;index:idx_resource_changes_object,length:100"`
+	UID       types.UID `gorm:"size:36;not null"`
+
+	ResourceVersion         string `gorm:"size:30;not null"`
+	PreviousResourceVersion string `gorm:"size:30;not null"`
+
+	// Fields are the changed fields encoded in json, e.g. [{"path": "spec.replicas", "old": "1", "new": "2"}]
+	Fields JSON `gorm:"not null"`
+
+	ChangedAt time.Time `gorm:"not null"`
+}
+
+type fieldChange struct {
+	Path string `json:"path"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+func encodeFieldChanges(changes []storage.FieldChange) (JSON, error) {
+	fields := make([]fieldChange, 0, len(changes))
+	for _, change := range changes {
+		fields = append(fields, fieldChange{Path: change.Path, Old: change.Old, New: change.New})
+	}
+	return json.Marshal(fields)
+}
+
+func decodeFieldChanges(data JSON) ([]storage.FieldChange, error) {
+	var fields []fieldChange
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	changes := make([]storage.FieldChange, 0, len(fields))
+	for _, field := range fields {
+		changes = append(changes, storage.FieldChange{Path: field.Path, Old: field.Old, New: field.New})
+	}
+	return changes, nil
+}
+
+// updateWithChanges updates the object and records the changed fields from the stored version in a transaction,
+// the changes are not recorded if the object is not stored or is replaced by an object with a different uid.
+func (s *ResourceStorage) updateWithChanges(ctx context.Context, cluster, namespace, name string, data []byte, updatedResource map[string]interface{}) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		table := s.isolation.table(tx, cluster)
+
+		var stored []Resource
+		if result := table.Model(&Resource{}).Select(append([]string{"uid", "resource_version"}, storedObjectColumns...)).
+			Where(s.resourceKeyMap(cluster, namespace, name)).Limit(1).Find(&stored); result.Error != nil {
+			return result.Error
+		}
+
+		if result := table.Model(&Resource{}).Where(s.resourceKeyMap(cluster, namespace, name)).Updates(updatedResource); result.Error != nil {
+			return result.Error
+		}
+
+		if len(stored) == 0 || stored[0].UID != updatedResource["uid"].(types.UID) {
+			return nil
+		}
+
+		storedData, err := stored[0].storedObject().decode()
+		if err != nil {
+			return err
+		}
+		var old, new map[string]interface{}
+		if err := json.Unmarshal(storedData, &old); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &new); err != nil {
+			return err
+		}
+		changes := diffFields(old, new)
+		if len(changes) == 0 {
+			return nil
+		}
+		if s.encryption != nil {
+			// only the paths of the changed fields are recorded for the encrypted objects
+			for i := range changes {
+				changes[i].Old, changes[i].New = "", ""
+			}
+		}
+		fields, err := encodeFieldChanges(changes)
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&ResourceChange{
+			Group:                   s.config.StorageResource.Group,
+			Version:                 s.config.StorageResource.Version,
+			Resource:                s.config.StorageResource.Resource,
+			Cluster:                 cluster,
+			Namespace:               namespace,
+			Name:                    name,
+			UID:                     stored[0].UID,
+			ResourceVersion:         updatedResource["resource_version"].(string),
+			PreviousResourceVersion: stored[0].ResourceVersion,
+			Fields:                  fields,
+			ChangedAt:               time.Now().UTC(),
+		}).Error
+	})
+}
+
+func (s *ResourceStorage) GetHistory(ctx context.Context, cluster, namespace, name string) ([]storage.ResourceChange, error) {
+	if !s.recordChanges {
+		return nil, apierrors.NewMethodNotSupported(s.groupResource, "history")
+	}
+
+	// the object is read by the role of the tenant to check if it is visible to the request,
+	// because the row level security only restricts the resources.
+	db, done, err := s.tenants.begin(ctx, s.db)
+	if err != nil {
+		return nil, InterpretResourceDBError(cluster, namespace+"/"+name, err)
+	}
+	table, err := s.isolation.tables(db, []string{cluster})
+	if err != nil {
+		done()
+		return nil, InterpretResourceDBError(cluster, namespace+"/"+name, err)
+	}
+	var ids []uint
+	result := table.Model(&Resource{}).Select("id").Where(s.resourceKeyMap(cluster, namespace, name)).Where("removed_at IS NULL").First(&ids)
+	done()
+	if result.Error != nil {
+		return nil, InterpretResourceDBError(cluster, namespace+"/"+name, result.Error)
+	}
+
+	var records []ResourceChange
+	result = s.db.WithContext(ctx).Where(s.resourceKeyMap(cluster, namespace, name)).Order("changed_at").Order("id").Find(&records)
+	if result.Error != nil {
+		return nil, InterpretResourceDBError(cluster, namespace+"/"+name, result.Error)
+	}
+
+	changes := make([]storage.ResourceChange, 0, len(records))
+	for _, record := range records {
+		fields, err := decodeFieldChanges(record.Fields)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, storage.ResourceChange{
+			ResourceVersion:         record.ResourceVersion,
+			PreviousResourceVersion: record.PreviousResourceVersion,
+			Time:                    record.ChangedAt,
+			Fields:                  fields,
+		})
+	}
+	return changes, nil
+}
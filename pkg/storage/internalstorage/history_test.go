@@ -0,0 +1,99 @@
+package internalstorage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestDiffFields(t *testing.T) {
+	old := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": "1",
+			"labels":          map[string]interface{}{"app.kubernetes.io/name": "web", "tier": "frontend"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"template": map[string]interface{}{"containers": []interface{}{"nginx:1.24"}},
+			"paused":   false,
+		},
+	}
+	new := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": "2",
+			"labels":          map[string]interface{}{"app.kubernetes.io/name": "api", "tier": "frontend"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"template": map[string]interface{}{"containers": []interface{}{"nginx:1.25"}},
+			"strategy": "Recreate",
+		},
+	}
+
+	expected := []storage.FieldChange{
+		{Path: "metadata.labels['app.kubernetes.io/name']", Old: `"web"`, New: `"api"`},
+		{Path: "spec.paused", Old: "false"},
+		{Path: "spec.replicas", Old: "1", New: "3"},
+		{Path: "spec.strategy", New: `"Recreate"`},
+		{Path: "spec.template.containers"},
+	}
+	if changes := diffFields(old, new); !reflect.DeepEqual(changes, expected) {
+		t.Errorf("diffFields() = %v, want %v", changes, expected)
+	}
+
+	if changes := diffFields(old, old); len(changes) != 0 {
+		t.Errorf("diffFields() of the same object = %v, want empty", changes)
+	}
+}
+
+func TestResourceStorage_GetHistoryWithSQLite(t *testing.T) {
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+	require.NoError(db.AutoMigrate(&ResourceChange{}))
+
+	rs := newTestResourceStorage(db, corev1.SchemeGroupVersion.WithResource("pods"))
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Resource: "pods"}, true)
+	require.NoError(err)
+	rs.config = storage.ResourceStorageConfig{ResourceConfig: *config}
+	rs.recordChanges = true
+
+	ctx := context.Background()
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "uid-1", ResourceVersion: "1"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+	}
+	require.NoError(rs.Create(ctx, "cluster-1", pod))
+
+	pod.ResourceVersion = "2"
+	pod.Spec.NodeName = "node-2"
+	require.NoError(rs.Update(ctx, "cluster-1", pod))
+
+	// the updates without changes are not recorded
+	pod.ResourceVersion = "3"
+	require.NoError(rs.Update(ctx, "cluster-1", pod))
+
+	changes, err := rs.GetHistory(ctx, "cluster-1", "default", "web")
+	require.NoError(err)
+	require.Len(changes, 1)
+	require.Equal("2", changes[0].ResourceVersion)
+	require.Equal("1", changes[0].PreviousResourceVersion)
+	require.Equal([]storage.FieldChange{{Path: "spec.nodeName", Old: `"node-1"`, New: `"node-2"`}}, changes[0].Fields)
+
+	// the changes are deleted with the object
+	require.NoError(rs.Delete(ctx, "cluster-1", pod))
+	var count int64
+	require.NoError(db.Model(&ResourceChange{}).Count(&count).Error)
+	require.Zero(count)
+}
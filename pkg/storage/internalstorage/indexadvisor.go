@@ -0,0 +1,299 @@
+package internalstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	advisedIndexPrefix = "idx_advised_"
+
+	defaultIndexAdvisorInterval   = 10 * time.Minute
+	defaultIndexAdvisorMinQueries = 100
+	defaultIndexAdvisorMaxIndexes = 10
+)
+
+// IndexAdvisorConfig tracks the shapes of the list queries, that are the json paths of the field selectors
+// and the order by fields, and suggests or creates the indexes of the frequently queried ones.
+//
+// It is only supported by postgres without the cluster isolation, the indexed labels of mysql are configured by mysql.indexedLabels.
+type IndexAdvisorConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// AutoCreate creates the suggested indexes concurrently, otherwise the statements of the indexes are only logged.
+	// The user of the apiserver needs the privilege to create the indexes on the resources table.
+	AutoCreate bool `yaml:"autoCreate"`
+
+	// Interval is the interval of the evaluations of the tracked query shapes, it is 10m by default.
+	Interval time.Duration `yaml:"interval"`
+
+	// MinQueries is the number of the queries of a shape within an interval to advise its index, it is 100 by default.
+	MinQueries int `yaml:"minQueries"`
+
+	// MaxIndexes is the max number of the indexes created by the advisor, it is 10 by default.
+	MaxIndexes int `yaml:"maxIndexes"`
+}
+
+func (c *IndexAdvisorConfig) validate(dbType string, isolation *ClusterIsolationConfig) error {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	if dbType != "postgres" {
+		return errors.New("index advisor is only supported by postgres")
+	}
+	if isolation != nil && isolation.Enabled {
+		return errors.New("index advisor can't be used with the cluster isolation")
+	}
+	if c.Interval < 0 || c.MinQueries < 0 || c.MaxIndexes < 0 {
+		return errors.New("interval, minQueries and maxIndexes of the index advisor must be greater than or equal to 0")
+	}
+	return nil
+}
+
+// advisedIndex is an index advised for a json path of the field selectors or an order by field
+type advisedIndex struct {
+	name       string
+	expression string
+
+	// keys is the json path of the object, the json path is written as the literals
+	// by the queries after the index is created, so that the planner can match it.
+	keys []string
+}
+
+// advisedJSONPaths are the json paths of the object with the created indexes, the key is the joined keys of the path
+var advisedJSONPaths atomic.Pointer[map[string]struct{}]
+
+func jsonPathKey(keys []string) string {
+	return strings.Join(keys, "\x00")
+}
+
+// advisedJSONPath returns whether the json path of the column has an index created by the advisor
+func advisedJSONPath(column string, keys []string) bool {
+	paths := advisedJSONPaths.Load()
+	if paths == nil || column != "object" {
+		return false
+	}
+	_, ok := (*paths)[jsonPathKey(keys)]
+	return ok
+}
+
+func advisedIndexName(expression string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(expression))
+	return fmt.Sprintf("%s%08x", advisedIndexPrefix, hash.Sum32())
+}
+
+// jsonPathIndex returns the index of the value of the json path, the paths of the labels and the annotations
+// are queried by the GIN indexes, see jsonIndexes.
+func jsonPathIndex(keys []string) (advisedIndex, bool) {
+	if len(keys) == 0 || indexedJSONObject("object", keys[:len(keys)-1]) {
+		return advisedIndex{}, false
+	}
+
+	var builder strings.Builder
+	writePostgresJSONObject(&builder, "object", keys[:len(keys)-1])
+	writeString(&builder, " ->> "+quotePostgresLiteral(keys[len(keys)-1]))
+	expression := "(" + builder.String() + ")"
+	return advisedIndex{name: advisedIndexName(expression), expression: expression, keys: keys}, true
+}
+
+// orderByIndex returns the index of the order by field of the resources, the fields without the indexes are advised,
+// the other fields are ordered by the indexes of the resource type.
+func orderByIndex(field string) (advisedIndex, bool) {
+	switch field {
+	case "created_at":
+	case "resource_version":
+		field = "(CAST(resource_version as decimal))"
+	default:
+		return advisedIndex{}, false
+	}
+	expression := `"group", "version", "resource", ` + field
+	return advisedIndex{name: advisedIndexName(expression), expression: expression}, true
+}
+
+// indexAdvisor counts the query shapes of the list queries, and advises the indexes of the shapes
+// which are queried more than the min queries within an interval.
+type indexAdvisor struct {
+	db     *gorm.DB
+	config IndexAdvisorConfig
+
+	lock    sync.Mutex
+	counts  map[string]int
+	indexes map[string]advisedIndex
+	// advised are the indexes which are suggested or exist
+	advised map[string]bool
+	created int
+
+	stopCh chan struct{}
+}
+
+// queryIndexAdvisor records the query shapes of the list queries, it is nil if the advisor is disabled
+var queryIndexAdvisor atomic.Pointer[indexAdvisor]
+
+func newIndexAdvisor(db *gorm.DB, config *IndexAdvisorConfig) *indexAdvisor {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+
+	advisor := &indexAdvisor{
+		db:      db,
+		config:  *config,
+		counts:  make(map[string]int),
+		indexes: make(map[string]advisedIndex),
+		advised: make(map[string]bool),
+		stopCh:  make(chan struct{}),
+	}
+	if advisor.config.Interval == 0 {
+		advisor.config.Interval = defaultIndexAdvisorInterval
+	}
+	if advisor.config.MinQueries == 0 {
+		advisor.config.MinQueries = defaultIndexAdvisorMinQueries
+	}
+	if advisor.config.MaxIndexes == 0 {
+		advisor.config.MaxIndexes = defaultIndexAdvisorMaxIndexes
+	}
+	return advisor
+}
+
+func (a *indexAdvisor) Start() {
+	queryIndexAdvisor.Store(a)
+	go wait.Until(a.advise, a.config.Interval, a.stopCh)
+}
+
+func (a *indexAdvisor) Close() error {
+	queryIndexAdvisor.CompareAndSwap(a, nil)
+	close(a.stopCh)
+	return nil
+}
+
+// recordQueryShape records the json paths of the equality field selectors and the order by fields of a list query
+func recordQueryShape(jsonPaths [][]string, orderBy []string) {
+	advisor := queryIndexAdvisor.Load()
+	if advisor == nil {
+		return
+	}
+
+	var indexes []advisedIndex
+	for _, keys := range jsonPaths {
+		if index, ok := jsonPathIndex(keys); ok {
+			indexes = append(indexes, index)
+		}
+	}
+	for _, field := range orderBy {
+		if index, ok := orderByIndex(field); ok {
+			indexes = append(indexes, index)
+		}
+	}
+	advisor.record(indexes...)
+}
+
+func (a *indexAdvisor) record(indexes ...advisedIndex) {
+	if len(indexes) == 0 {
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for _, index := range indexes {
+		if a.advised[index.name] {
+			continue
+		}
+		a.counts[index.name]++
+		a.indexes[index.name] = index
+	}
+}
+
+// hotIndexes returns the indexes of the query shapes queried more than the min queries, and resets the counts
+func (a *indexAdvisor) hotIndexes() []advisedIndex {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var hot []advisedIndex
+	for name, count := range a.counts {
+		if count >= a.config.MinQueries {
+			hot = append(hot, a.indexes[name])
+		}
+	}
+	a.counts, a.indexes = make(map[string]int), make(map[string]advisedIndex)
+	sort.Slice(hot, func(i, j int) bool { return hot[i].name < hot[j].name })
+	return hot
+}
+
+func (a *indexAdvisor) advise() {
+	for _, index := range a.hotIndexes() {
+		a.adviseIndex(index)
+	}
+}
+
+func (a *indexAdvisor) adviseIndex(index advisedIndex) {
+	statement := index.statement()
+	if a.db.Migrator().HasIndex(&Resource{}, index.name) {
+		a.markAdvised(index, true)
+		return
+	}
+
+	if !a.config.AutoCreate || a.created >= a.config.MaxIndexes {
+		klog.InfoS("Suggest the index for the frequently queried shape of the resources", "index", index.name, "statement", statement)
+		a.markAdvised(index, false)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-a.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	defer cancel()
+
+	klog.InfoS("Create the index for the frequently queried shape of the resources", "index", index.name, "statement", statement)
+	if err := a.db.WithContext(ctx).Exec(statement).Error; err != nil {
+		// the invalid index left by the failed concurrent creation must be dropped before it is recreated
+		klog.ErrorS(err, "Failed to create the advised index, it will be retried", "index", index.name)
+		_ = a.db.Exec(fmt.Sprintf(`DROP INDEX CONCURRENTLY IF EXISTS "%s"`, index.name)).Error
+		return
+	}
+	a.created++
+	a.markAdvised(index, true)
+}
+
+// markAdvised stops counting the query shape of the index, and queries the json path by the literals if the index exists
+func (a *indexAdvisor) markAdvised(index advisedIndex, exists bool) {
+	a.lock.Lock()
+	a.advised[index.name] = true
+	delete(a.indexes, index.name)
+	a.lock.Unlock()
+
+	if !exists || index.keys == nil {
+		return
+	}
+	for {
+		old := advisedJSONPaths.Load()
+		paths := map[string]struct{}{jsonPathKey(index.keys): {}}
+		if old != nil {
+			for path := range *old {
+				paths[path] = struct{}{}
+			}
+		}
+		if advisedJSONPaths.CompareAndSwap(old, &paths) {
+			return
+		}
+	}
+}
+
+func (index advisedIndex) statement() string {
+	return fmt.Sprintf(`CREATE INDEX CONCURRENTLY IF NOT EXISTS "%s" ON "%s" (%s)`, index.name, resourcesTable, index.expression)
+}
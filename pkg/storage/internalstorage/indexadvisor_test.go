@@ -0,0 +1,68 @@
+package internalstorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestIndexAdvisorConfigValidate(t *testing.T) {
+	config := &IndexAdvisorConfig{Enabled: true}
+	assert.NoError(t, config.validate("postgres", nil))
+	assert.Error(t, config.validate("mysql", nil))
+	assert.Error(t, config.validate("postgres", &ClusterIsolationConfig{Enabled: true}))
+	assert.Error(t, (&IndexAdvisorConfig{Enabled: true, MinQueries: -1}).validate("postgres", nil))
+	assert.NoError(t, (&IndexAdvisorConfig{}).validate("mysql", nil))
+}
+
+func TestAdvisedIndexStatement(t *testing.T) {
+	index, ok := jsonPathIndex([]string{"spec", "nodeName"})
+	assert.True(t, ok)
+	assert.Equal(t, `CREATE INDEX CONCURRENTLY IF NOT EXISTS "`+index.name+`" ON "resources" (("object" -> 'spec' ->> 'nodeName'))`, index.statement())
+
+	// the labels are queried by the GIN index
+	_, ok = jsonPathIndex([]string{"metadata", "labels", "app"})
+	assert.False(t, ok)
+
+	index, ok = orderByIndex("resource_version")
+	assert.True(t, ok)
+	assert.Equal(t, `CREATE INDEX CONCURRENTLY IF NOT EXISTS "`+index.name+`" ON "resources" ("group", "version", "resource", (CAST(resource_version as decimal)))`, index.statement())
+
+	_, ok = orderByIndex("name")
+	assert.False(t, ok)
+}
+
+func TestIndexAdvisorHotIndexes(t *testing.T) {
+	advisor := newIndexAdvisor(nil, &IndexAdvisorConfig{Enabled: true, MinQueries: 2})
+	queryIndexAdvisor.Store(advisor)
+	t.Cleanup(func() { queryIndexAdvisor.Store(nil) })
+
+	recordQueryShape([][]string{{"spec", "nodeName"}, {"metadata", "labels", "app"}}, []string{"created_at"})
+	recordQueryShape([][]string{{"spec", "nodeName"}}, []string{"name"})
+
+	nodeName, _ := jsonPathIndex([]string{"spec", "nodeName"})
+	hot := advisor.hotIndexes()
+	if assert.Len(t, hot, 1) {
+		assert.Equal(t, nodeName.name, hot[0].name)
+	}
+	assert.Empty(t, advisor.hotIndexes(), "the counts are reset by each evaluation")
+
+	advisor.markAdvised(nodeName, false)
+	recordQueryShape([][]string{{"spec", "nodeName"}}, nil)
+	recordQueryShape([][]string{{"spec", "nodeName"}}, nil)
+	assert.Empty(t, advisor.hotIndexes(), "the advised index is not counted")
+	assert.False(t, advisedJSONPath("object", []string{"spec", "nodeName"}), "the suggested index doesn't exist")
+}
+
+func TestAdvisedJSONPathQuery(t *testing.T) {
+	advisor := newIndexAdvisor(nil, &IndexAdvisorConfig{Enabled: true})
+	index, _ := jsonPathIndex([]string{"spec", "nodeName"})
+	advisor.markAdvised(index, true)
+	t.Cleanup(func() { advisedJSONPaths.Store(nil) })
+
+	sql := postgresDB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&Resource{}).Where(JSONQuery("object", "spec", "nodeName").Equal("node-1")).Find(&Resource{})
+	})
+	assert.Equal(t, `SELECT * FROM "resources" WHERE "object" -> 'spec' ->> 'nodeName' = 'node-1'`, sql)
+}
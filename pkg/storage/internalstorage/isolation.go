@@ -0,0 +1,178 @@
+package internalstorage
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSchemaPrefix = "clusterpedia_"
+
+	// maxSchemaLength is the max length of the identifiers of postgres, the max length of mysql is 64
+	maxSchemaLength = 63
+
+	// maxSchemaPrefixLength leaves the room of the cluster name in the schema
+	maxSchemaPrefixLength = 30
+)
+
+var schemaPrefixRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func (c *ClusterIsolationConfig) validate(dbType string) error {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	if dbType != "postgres" && dbType != "mysql" {
+		return errors.New("cluster isolation is only supported by postgres and mysql")
+	}
+	if c.SchemaPrefix != "" && (len(c.SchemaPrefix) > maxSchemaPrefixLength || !schemaPrefixRegexp.MatchString(c.SchemaPrefix)) {
+		return fmt.Errorf("invalid schema prefix %q of cluster isolation, it must consist of lower case letters, digits and '_' "+
+			"and start with a letter, and it must be no more than %d characters", c.SchemaPrefix, maxSchemaPrefixLength)
+	}
+	return nil
+}
+
+// clusterIsolation stores the resources of each cluster in the resources table of its own schema of postgres
+// or its own database of mysql, so the rows of a cluster are dropped with its schema when the cluster is cleaned.
+//
+// The schema of a cluster is created and migrated when the cluster is prepared, the objects of the cluster are
+// written to its table directly, and the tables of the read clusters are combined by UNION ALL.
+// A nil clusterIsolation means the resources of all clusters are stored in the shared resources table.
+type clusterIsolation struct {
+	prefix string
+}
+
+func newClusterIsolation(config *ClusterIsolationConfig) *clusterIsolation {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+
+	prefix := config.SchemaPrefix
+	if prefix == "" {
+		prefix = defaultSchemaPrefix
+	}
+	return &clusterIsolation{prefix: prefix}
+}
+
+// schema returns the schema of the cluster
+func (c *clusterIsolation) schema(cluster string) string {
+	return clusterIdentifier(c.prefix, cluster)
+}
+
+// clusterIdentifier returns the identifier of the cluster with the prefix, the cluster name is truncated to fit the identifier,
+// and the hash of the name keeps the identifiers of the clusters distinct.
+func clusterIdentifier(prefix, cluster string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, cluster)
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(cluster))
+	suffix := fmt.Sprintf("_%08x", hash.Sum32())
+	if limit := maxSchemaLength - len(prefix) - len(suffix); len(name) > limit {
+		name = name[:limit]
+	}
+	return prefix + name + suffix
+}
+
+func schemaResourcesTable(schema string) string {
+	return schema + "." + resourcesTable
+}
+
+// table returns the db bound to the resources table of the cluster, the db is returned as it is if the isolation is disabled.
+// The schema of the cluster must be prepared, it is used to write the objects of the cluster.
+func (c *clusterIsolation) table(db *gorm.DB, cluster string) *gorm.DB {
+	if c == nil {
+		return db
+	}
+	return db.Table(schemaResourcesTable(c.schema(cluster))).Session(&gorm.Session{})
+}
+
+// tables returns the db bound to the resources tables of the clusters, they are all prepared clusters if clusters is empty.
+// The db is returned as it is if the isolation is disabled, and nothing is read from it if no cluster is prepared.
+func (c *clusterIsolation) tables(db *gorm.DB, clusters []string) (*gorm.DB, error) {
+	if c == nil {
+		return db, nil
+	}
+
+	schemas, err := c.schemas(db, clusters)
+	if err != nil {
+		return nil, err
+	}
+	switch len(schemas) {
+	case 0:
+		return db.Where("1 = 0").Session(&gorm.Session{}), nil
+	case 1:
+		return db.Table(schemaResourcesTable(schemas[0])).Session(&gorm.Session{}), nil
+	}
+
+	// the columns are selected explicitly, because the columns added by the migrations
+	// may be in the different order in the tables created before and after the migrations.
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&Resource{}); err != nil {
+		return nil, err
+	}
+	columns := make([]string, 0, len(stmt.Schema.DBNames))
+	for _, column := range stmt.Schema.DBNames {
+		columns = append(columns, stmt.Quote(column))
+	}
+
+	selects := make([]string, 0, len(schemas))
+	for _, schema := range schemas {
+		selects = append(selects, fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), stmt.Quote(schemaResourcesTable(schema))))
+	}
+	union := db.Session(&gorm.Session{NewDB: true}).Raw(strings.Join(selects, " UNION ALL "))
+	return db.Table("(?) AS "+resourcesTable, union).Session(&gorm.Session{}), nil
+}
+
+// schemas returns the existing schemas of the clusters, they are all schemas of the clusters if clusters is empty.
+func (c *clusterIsolation) schemas(db *gorm.DB, clusters []string) ([]string, error) {
+	query := db.Session(&gorm.Session{NewDB: true}).Table("information_schema.schemata")
+	if len(clusters) != 0 {
+		names := make([]string, 0, len(clusters))
+		for _, cluster := range clusters {
+			names = append(names, c.schema(cluster))
+		}
+		query = query.Where("schema_name IN ?", names)
+	} else {
+		query = query.Where("schema_name LIKE ?", strings.ReplaceAll(c.prefix, "_", `\_`)+"%")
+	}
+
+	var schemas []string
+	if err := query.Order("schema_name").Pluck("schema_name", &schemas).Error; err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+// prepare creates the schema of the cluster and migrates its resources table.
+func (c *clusterIsolation) prepare(db *gorm.DB, cluster string) error {
+	schema := c.schema(cluster)
+	statement := "CREATE SCHEMA IF NOT EXISTS ?"
+	if db.Dialector.Name() == "mysql" {
+		statement = "CREATE DATABASE IF NOT EXISTS ?"
+	}
+	if err := db.Exec(statement, gorm.Expr(db.Statement.Quote(schema))).Error; err != nil {
+		return err
+	}
+	if err := c.table(db, cluster).AutoMigrate(&Resource{}); err != nil {
+		return err
+	}
+	return migrateJSONIndexes(db, schemaResourcesTable(schema))
+}
+
+// drop drops the schema of the cluster with all of its resources.
+func (c *clusterIsolation) drop(db *gorm.DB, cluster string) error {
+	statement := "DROP SCHEMA IF EXISTS ? CASCADE"
+	if db.Dialector.Name() == "mysql" {
+		statement = "DROP DATABASE IF EXISTS ?"
+	}
+	return db.Exec(statement, gorm.Expr(db.Statement.Quote(c.schema(cluster)))).Error
+}
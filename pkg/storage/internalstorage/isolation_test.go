@@ -0,0 +1,120 @@
+package internalstorage
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestClusterIsolationSchema(t *testing.T) {
+	isolation := newClusterIsolation(&ClusterIsolationConfig{Enabled: true})
+	if schema := isolation.schema("cluster-1"); !strings.HasPrefix(schema, "clusterpedia_cluster_1_") {
+		t.Errorf("unexpected schema %q", schema)
+	}
+	if isolation.schema("a-b") == isolation.schema("a.b") {
+		t.Error("the schemas of the clusters should be distinct")
+	}
+
+	long := strings.Repeat("cluster-", 30)
+	if schema := isolation.schema(long); len(schema) > maxSchemaLength || schema == isolation.schema(long+"1") {
+		t.Errorf("the schema %q should be truncated and distinct", schema)
+	}
+
+	if newClusterIsolation(&ClusterIsolationConfig{}) != nil || newClusterIsolation(nil) != nil {
+		t.Error("the cluster isolation should be disabled")
+	}
+}
+
+func TestClusterIsolationConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		dbType  string
+		config  *ClusterIsolationConfig
+		wantErr bool
+	}{
+		{name: "disabled", dbType: "sqlite", config: &ClusterIsolationConfig{}},
+		{name: "postgres", dbType: "postgres", config: &ClusterIsolationConfig{Enabled: true, SchemaPrefix: "cp_"}},
+		{name: "sqlite", dbType: "sqlite", config: &ClusterIsolationConfig{Enabled: true}, wantErr: true},
+		{name: "invalid prefix", dbType: "mysql", config: &ClusterIsolationConfig{Enabled: true, SchemaPrefix: "cp-"}, wantErr: true},
+		{name: "long prefix", dbType: "mysql", config: &ClusterIsolationConfig{Enabled: true, SchemaPrefix: strings.Repeat("c", 31)}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.config.validate(test.dbType); (err != nil) != test.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestClusterIsolationTables(t *testing.T) {
+	isolation := newClusterIsolation(&ClusterIsolationConfig{Enabled: true, SchemaPrefix: "cp_"})
+	schema1, schema2 := isolation.schema("cluster-1"), isolation.schema("cluster-2")
+
+	tests := []struct {
+		name     string
+		clusters []string
+		args     []driver.Value
+		schemas  []string
+		expected string
+	}{
+		{
+			name:     "no cluster",
+			clusters: []string{"cluster-1"},
+			args:     []driver.Value{schema1},
+			expected: `SELECT * FROM "resources" WHERE 1 = 0 AND "resources"."group" = 'apps'`,
+		},
+		{
+			name:     "one cluster",
+			clusters: []string{"cluster-1"},
+			args:     []driver.Value{schema1},
+			schemas:  []string{schema1},
+			expected: `SELECT * FROM "` + schema1 + `"."resources" WHERE "resources"."group" = 'apps'`,
+		},
+		{
+			name:     "all clusters",
+			args:     []driver.Value{`cp\_%`},
+			schemas:  []string{schema1, schema2},
+			expected: `SELECT * FROM (SELECT "id", "group"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := newMockedPostgresDB()
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows := sqlmock.NewRows([]string{"schema_name"})
+			for _, schema := range test.schemas {
+				rows.AddRow(schema)
+			}
+			mock.ExpectQuery(`SELECT "schema_name" FROM "information_schema"."schemata"`).WithArgs(test.args...).WillReturnRows(rows)
+
+			tables, err := isolation.tables(db, test.clusters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			query := tables.Session(&gorm.Session{DryRun: true}).Model(&Resource{}).Where(map[string]interface{}{"group": "apps"}).Find(&[]Resource{})
+			sql := db.Dialector.Explain(query.Statement.SQL.String(), query.Statement.Vars...)
+			if !strings.HasPrefix(sql, test.expected) {
+				t.Errorf("expected sql: %q, but got: %q", test.expected, sql)
+			}
+			if len(test.schemas) > 1 {
+				for _, schema := range test.schemas {
+					if !strings.Contains(sql, `FROM "`+schema+`"."resources"`) {
+						t.Errorf("the resources of the schema %s should be read, got %q", schema, sql)
+					}
+				}
+				if !strings.Contains(sql, " UNION ALL ") || !strings.HasSuffix(sql, `) AS resources WHERE "resources"."group" = 'apps'`) {
+					t.Errorf("the tables should be combined, got %q", sql)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package internalstorage
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -96,6 +97,13 @@ func (jsonQuery *JSONQueryExpression) writeJSONKey(builder clause.Builder) {
 }
 
 func (jsonQuery *JSONQueryExpression) writePostgresJSONKey(builder clause.Builder) {
+	if advisedJSONPath(jsonQuery.column, jsonQuery.keys) {
+		// the literals can be matched with the expression of the index created by the index advisor
+		writePostgresJSONObject(builder, jsonQuery.column, jsonQuery.keys[:len(jsonQuery.keys)-1])
+		writeString(builder, " ->> "+quotePostgresLiteral(jsonQuery.keys[len(jsonQuery.keys)-1]))
+		return
+	}
+
 	builder.WriteQuoted(jsonQuery.column)
 	for _, key := range jsonQuery.keys[0 : len(jsonQuery.keys)-1] {
 		writeString(builder, " -> ")
@@ -126,6 +134,13 @@ func (jsonQuery *JSONQueryExpression) Build(builder clause.Builder) {
 		dialector := stmt.Dialector.Name()
 		switch dialector {
 		case "mysql", "sqlite3", "sqlite":
+			if dialector == "mysql" && !jsonQuery.not && len(jsonQuery.values) != 0 {
+				if column, ok := jsonQuery.labelColumn(); ok {
+					jsonQuery.writeLabelColumn(builder, column)
+					return
+				}
+			}
+
 			if jsonQuery.not && len(jsonQuery.values) != 0 {
 				writeString(builder, "(")
 				defer func() {
@@ -169,6 +184,11 @@ func (jsonQuery *JSONQueryExpression) Build(builder clause.Builder) {
 				builder.AddVar(builder, jsonQuery.values)
 			}
 		case "postgres":
+			if !jsonQuery.not && indexedJSONObject(jsonQuery.column, jsonQuery.keys[:len(jsonQuery.keys)-1]) {
+				jsonQuery.writePostgresContainment(builder)
+				return
+			}
+
 			if jsonQuery.not && len(jsonQuery.values) != 0 {
 				writeString(builder, "(")
 				defer func() {
@@ -207,6 +227,80 @@ func (jsonQuery *JSONQueryExpression) Build(builder clause.Builder) {
 	}
 }
 
+// labelColumn returns the generated column of the label if the key is an indexed label of mysql
+func (jsonQuery *JSONQueryExpression) labelColumn() (string, bool) {
+	if jsonQuery.column != "object" || len(jsonQuery.keys) != 3 || jsonQuery.keys[0] != "metadata" || jsonQuery.keys[1] != "labels" {
+		return "", false
+	}
+	return labelColumn(jsonQuery.keys[2])
+}
+
+// writeLabelColumn queries the values of the label by the index of its generated column
+func (jsonQuery *JSONQueryExpression) writeLabelColumn(builder clause.Builder, column string) {
+	builder.WriteQuoted(column)
+	if len(jsonQuery.values) == 1 {
+		writeString(builder, " = ")
+		builder.AddVar(builder, jsonQuery.values[0])
+		return
+	}
+	writeString(builder, " IN ")
+	builder.AddVar(builder, jsonQuery.values)
+}
+
+// writePostgresContainment queries the key of the json object by the existence and the containment operators,
+// which can use the GIN index on the json object, see jsonIndexes.
+func (jsonQuery *JSONQueryExpression) writePostgresContainment(builder clause.Builder) {
+	objectKeys, key := jsonQuery.keys[:len(jsonQuery.keys)-1], jsonQuery.keys[len(jsonQuery.keys)-1]
+	if len(jsonQuery.values) == 0 {
+		writePostgresJSONObject(builder, jsonQuery.column, objectKeys)
+		writeString(builder, " ? ")
+		builder.AddVar(builder, key)
+		return
+	}
+
+	if len(jsonQuery.values) > 1 {
+		writeString(builder, "(")
+		defer writeString(builder, ")")
+	}
+	for i, value := range jsonQuery.values {
+		if i > 0 {
+			writeString(builder, " OR ")
+		}
+		contained, _ := json.Marshal(map[string]string{key: value})
+
+		writePostgresJSONObject(builder, jsonQuery.column, objectKeys)
+		writeString(builder, " @> ")
+		builder.AddVar(builder, string(contained))
+		writeString(builder, "::jsonb")
+	}
+}
+
+// JSONValueExpression is the value of the json key as a string, it is NULL if the key does not exist.
+type JSONValueExpression struct {
+	query JSONQueryExpression
+}
+
+func JSONValue(column string, keys ...string) *JSONValueExpression {
+	return &JSONValueExpression{query: JSONQueryExpression{column: column, keys: keys}}
+}
+
+func (jsonValue *JSONValueExpression) Build(builder clause.Builder) {
+	if len(jsonValue.query.keys) == 0 {
+		return
+	}
+
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		switch stmt.Dialector.Name() {
+		case "mysql":
+			jsonValue.query.writeJSONKeyWithJSON_UNQUOTE(builder)
+		case "sqlite3", "sqlite":
+			jsonValue.query.writeJSONKeyWithCAST_TO_TEXT(builder)
+		case "postgres":
+			jsonValue.query.writePostgresJSONKey(builder)
+		}
+	}
+}
+
 func writeString(builder clause.Writer, str string) {
 	_, _ = builder.WriteString(str)
 }
@@ -0,0 +1,96 @@
+package internalstorage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// jsonIndex is a GIN index of Postgres on a json object of the resources table,
+// the containment and the existence queries of the keys of the object can use it.
+type jsonIndex struct {
+	name   string
+	column string
+	keys   []string
+}
+
+// jsonIndexes are the GIN indexes on the labels and the annotations, the label selectors and
+// the cluster labels stamped as the annotations are queried by them.
+var jsonIndexes = []jsonIndex{
+	{name: "idx_object_labels", column: "object", keys: []string{"metadata", "labels"}},
+	{name: "idx_object_annotations", column: "object", keys: []string{"metadata", "annotations"}},
+}
+
+// indexedJSONObject returns whether the json object at the keys of the column is indexed
+func indexedJSONObject(column string, keys []string) bool {
+	for _, index := range jsonIndexes {
+		if index.column == column && slices.Equal(index.keys, keys) {
+			return true
+		}
+	}
+	return false
+}
+
+// writePostgresJSONObject writes the keys as the literals instead of the parameters,
+// otherwise the expression can't be matched with the expression of the index by the planner.
+func writePostgresJSONObject(builder clause.Writer, column string, keys []string) {
+	writeString(builder, `"`+column+`"`)
+	for _, key := range keys {
+		writeString(builder, " -> "+quotePostgresLiteral(key))
+	}
+}
+
+func quotePostgresLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func (index jsonIndex) expression() string {
+	var builder strings.Builder
+	writePostgresJSONObject(&builder, index.column, index.keys)
+	return builder.String()
+}
+
+// migrateJSONIndexes creates the GIN indexes on the json objects of the resources table when the storage is Postgres.
+//
+// The indexes are created concurrently, so that the writes of the resources are not blocked on the large tables,
+// except the indexes of the partitioned table, which can't be created concurrently.
+func migrateJSONIndexes(db *gorm.DB, table string) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	partitioned, err := isPartitionedTable(db, table)
+	if err != nil {
+		return err
+	}
+	for _, index := range missingJSONIndexes(db, table) {
+		if err := createJSONIndex(db, table, index, !partitioned); err != nil {
+			return fmt.Errorf("failed to create the index %s: %w", index.name, err)
+		}
+	}
+	return nil
+}
+
+func missingJSONIndexes(db *gorm.DB, table string) []jsonIndex {
+	migrator := db.Table(table).Session(&gorm.Session{}).Migrator()
+
+	var missing []jsonIndex
+	for _, index := range jsonIndexes {
+		if !migrator.HasIndex(&Resource{}, index.name) {
+			missing = append(missing, index)
+		}
+	}
+	return missing
+}
+
+func createJSONIndex(db *gorm.DB, table string, index jsonIndex, concurrently bool) error {
+	statement := "CREATE INDEX CONCURRENTLY IF NOT EXISTS ? ON ? USING GIN ((%s))"
+	if !concurrently {
+		statement = "CREATE INDEX IF NOT EXISTS ? ON ? USING GIN ((%s))"
+	}
+	return db.Exec(fmt.Sprintf(statement, index.expression()),
+		gorm.Expr(db.Statement.Quote(index.name)), gorm.Expr(db.Statement.Quote(table))).Error
+}
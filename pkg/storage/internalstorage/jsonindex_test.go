@@ -0,0 +1,44 @@
+package internalstorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCreateJSONIndex(t *testing.T) {
+	recorder := &statementRecorder{}
+	dryRun := postgresDB.Session(&gorm.Session{DryRun: true, Logger: recorder})
+
+	for _, table := range []string{resourcesTable, schemaResourcesTable("clusterpedia_cluster_1")} {
+		for _, index := range jsonIndexes {
+			require.NoError(t, createJSONIndex(dryRun, table, index, true))
+		}
+	}
+	assert.Equal(t, []string{
+		`CREATE INDEX CONCURRENTLY IF NOT EXISTS "idx_object_labels" ON "resources" USING GIN (("object" -> 'metadata' -> 'labels'))`,
+		`CREATE INDEX CONCURRENTLY IF NOT EXISTS "idx_object_annotations" ON "resources" USING GIN (("object" -> 'metadata' -> 'annotations'))`,
+		`CREATE INDEX CONCURRENTLY IF NOT EXISTS "idx_object_labels" ON "clusterpedia_cluster_1"."resources" USING GIN (("object" -> 'metadata' -> 'labels'))`,
+		`CREATE INDEX CONCURRENTLY IF NOT EXISTS "idx_object_annotations" ON "clusterpedia_cluster_1"."resources" USING GIN (("object" -> 'metadata' -> 'annotations'))`,
+	}, recorder.statements)
+}
+
+func TestMigrateJSONIndexesSkipsOtherDatabases(t *testing.T) {
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	require.NoError(t, migrateJSONIndexes(db, resourcesTable))
+	for _, index := range jsonIndexes {
+		assert.False(t, db.Migrator().HasIndex(&Resource{}, index.name))
+	}
+}
+
+func TestIndexedJSONObject(t *testing.T) {
+	assert.True(t, indexedJSONObject("object", []string{"metadata", "labels"}))
+	assert.True(t, indexedJSONObject("object", []string{"metadata", "annotations"}))
+	assert.False(t, indexedJSONObject("object", []string{"metadata"}))
+	assert.False(t, indexedJSONObject("object", []string{"spec", "selector", "matchLabels"}))
+}
@@ -0,0 +1,147 @@
+package internalstorage
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	labelColumnPrefix          = "label_"
+	maxLabelColumnSanitizedLen = 40
+)
+
+var labelColumnInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// labelColumns are the generated columns of the indexed labels of mysql, the key is the label key,
+// the equality selectors of the keys are queried by the indexes of the columns instead of the json of the objects.
+var labelColumns atomic.Pointer[map[string]string]
+
+func (c *MySQLConfig) validateIndexedLabels(dbType string, isolation *ClusterIsolationConfig) error {
+	if c == nil || len(c.IndexedLabels) == 0 {
+		return nil
+	}
+	if dbType != "mysql" {
+		return errors.New("indexed labels are only supported by mysql")
+	}
+	if isolation != nil && isolation.Enabled {
+		return errors.New("indexed labels can't be used with the cluster isolation")
+	}
+	for _, key := range c.IndexedLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return fmt.Errorf("invalid indexed label %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// labelColumnName returns the generated column of the label key, the key is sanitized and suffixed
+// with its hash, so that the keys which are sanitized to the same name are distinct.
+func labelColumnName(key string) string {
+	name := labelColumnInvalidChars.ReplaceAllString(strings.ToLower(key), "_")
+	if len(name) > maxLabelColumnSanitizedLen {
+		name = name[:maxLabelColumnSanitizedLen]
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return fmt.Sprintf("%s%s_%08x", labelColumnPrefix, name, hash.Sum32())
+}
+
+func labelIndexName(column string) string {
+	return "idx_" + column
+}
+
+// labelColumn returns the generated column of the indexed label
+func labelColumn(key string) (string, bool) {
+	columns := labelColumns.Load()
+	if columns == nil {
+		return "", false
+	}
+	column, ok := (*columns)[key]
+	return column, ok
+}
+
+// migrateLabelColumns adds the generated columns and their indexes of the indexed labels to the resources table,
+// the columns are virtual, so the rows are not rewritten and the values are only stored in the indexes.
+func migrateLabelColumns(db *gorm.DB, keys []string) error {
+	for _, key := range keys {
+		if err := migrateLabelColumn(db, key); err != nil {
+			return fmt.Errorf("failed to migrate the column of the indexed label %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func migrateLabelColumn(db *gorm.DB, key string) error {
+	column := labelColumnName(key)
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&Resource{}, column) {
+		if err := addLabelColumn(db, key); err != nil {
+			return err
+		}
+	}
+	if !migrator.HasIndex(&Resource{}, labelIndexName(column)) {
+		return createLabelIndex(db, key)
+	}
+	return nil
+}
+
+func addLabelColumn(db *gorm.DB, key string) error {
+	// the label keys are qualified names, so the json path can be written as a literal
+	path := fmt.Sprintf(`$."metadata"."labels"."%s"`, key)
+	return db.Exec(fmt.Sprintf("ALTER TABLE ? ADD COLUMN ? VARCHAR(%d) GENERATED ALWAYS AS (JSON_UNQUOTE(JSON_EXTRACT(`object`, '%s'))) VIRTUAL",
+		validation.LabelValueMaxLength, path),
+		gorm.Expr(db.Statement.Quote(resourcesTable)), gorm.Expr(db.Statement.Quote(labelColumnName(key)))).Error
+}
+
+func createLabelIndex(db *gorm.DB, key string) error {
+	column := labelColumnName(key)
+	return db.Exec("CREATE INDEX ? ON ? (?)",
+		gorm.Expr(db.Statement.Quote(labelIndexName(column))), gorm.Expr(db.Statement.Quote(resourcesTable)), gorm.Expr(db.Statement.Quote(column))).Error
+}
+
+// setupLabelColumns uses the generated columns of the indexed labels for the queries,
+// the columns which are not migrated yet are not used.
+func setupLabelColumns(db *gorm.DB, keys []string) {
+	columns := make(map[string]string, len(keys))
+	for _, key := range keys {
+		column := labelColumnName(key)
+		if !db.Migrator().HasColumn(&Resource{}, column) {
+			klog.Warningf("The column %s of the indexed label %s doesn't exist, the label is queried by the json of the objects", column, key)
+			continue
+		}
+		columns[key] = column
+	}
+	labelColumns.Store(&columns)
+}
+
+// planLabelColumns adds the missing generated columns and indexes of the indexed labels to the plan
+func planLabelColumns(plan *storage.SchemaMigrationPlan, db, dryRun *gorm.DB, keys []string) error {
+	migrator := db.Migrator()
+	for _, key := range keys {
+		column := labelColumnName(key)
+		hasColumn := migrator.HasColumn(&Resource{}, column)
+		if !hasColumn {
+			plan.Incompatibilities = append(plan.Incompatibilities, fmt.Sprintf("column %s.%s of the indexed label %s doesn't exist", resourcesTable, column, key))
+			if err := addLabelColumn(dryRun, key); err != nil {
+				return err
+			}
+		}
+		if !hasColumn || !migrator.HasIndex(&Resource{}, labelIndexName(column)) {
+			plan.Incompatibilities = append(plan.Incompatibilities, fmt.Sprintf("index %s of table %s doesn't exist", labelIndexName(column), resourcesTable))
+			if err := createLabelIndex(dryRun, key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package internalstorage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/labels"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+func TestLabelColumnName(t *testing.T) {
+	app := labelColumnName("app")
+	assert.True(t, strings.HasPrefix(app, "label_app_"), app)
+	assert.Equal(t, app, labelColumnName("app"))
+
+	// the keys sanitized to the same name are distinct
+	assert.NotEqual(t, labelColumnName("example.io/team"), labelColumnName("example.io_team"))
+
+	long := labelColumnName(strings.Repeat("a", 200) + "/" + strings.Repeat("b", 63))
+	assert.LessOrEqual(t, len(labelIndexName(long)), 64)
+}
+
+func TestValidateIndexedLabels(t *testing.T) {
+	config := &MySQLConfig{IndexedLabels: []string{"app", "app.kubernetes.io/name"}}
+	assert.NoError(t, config.validateIndexedLabels("mysql", nil))
+	assert.Error(t, config.validateIndexedLabels("postgres", nil))
+	assert.Error(t, config.validateIndexedLabels("mysql", &ClusterIsolationConfig{Enabled: true}))
+
+	invalid := &MySQLConfig{IndexedLabels: []string{`app"`}}
+	assert.Error(t, invalid.validateIndexedLabels("mysql", nil))
+}
+
+func TestPlanLabelColumns(t *testing.T) {
+	for version, db := range mysqlDBs {
+		t.Run(version, func(t *testing.T) {
+			recorder := &statementRecorder{}
+			dryRun := db.Session(&gorm.Session{DryRun: true, Logger: recorder})
+
+			require.NoError(t, addLabelColumn(dryRun, "app"))
+			require.NoError(t, createLabelIndex(dryRun, "app"))
+
+			column := labelColumnName("app")
+			assert.Equal(t, []string{
+				"ALTER TABLE `resources` ADD COLUMN `" + column + "` VARCHAR(63) GENERATED ALWAYS AS (JSON_UNQUOTE(JSON_EXTRACT(`object`, '$.\"metadata\".\"labels\".\"app\"'))) VIRTUAL",
+				"CREATE INDEX `idx_" + column + "` ON `resources` (`" + column + "`)",
+			}, recorder.statements)
+		})
+	}
+}
+
+func TestApplyListOptionsToQuery_IndexedLabels(t *testing.T) {
+	columns := map[string]string{"app": labelColumnName("app")}
+	labelColumns.Store(&columns)
+	defer labelColumns.Store(nil)
+
+	column := "`" + labelColumnName("app") + "`"
+	tests := []struct {
+		name          string
+		labelSelector string
+		expected      expected
+	}{
+		{
+			"equal",
+			"app=web",
+			expected{
+				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' @> '{"app":"web"}'::jsonb`,
+				"SELECT * FROM `resources` WHERE " + column + " = 'web'",
+				"",
+			},
+		},
+		{
+			"in",
+			"app in (api,web)",
+			expected{
+				`SELECT * FROM "resources" WHERE ("object" -> 'metadata' -> 'labels' @> '{"app":"api"}'::jsonb OR "object" -> 'metadata' -> 'labels' @> '{"app":"web"}'::jsonb)`,
+				"SELECT * FROM `resources` WHERE " + column + " IN ('api','web')",
+				"",
+			},
+		},
+		{
+			"not equal",
+			"app!=web",
+			expected{
+				`SELECT * FROM "resources" WHERE ("object" -> 'metadata' -> 'labels' ->> 'app' IS NULL OR "object" -> 'metadata' -> 'labels' ->> 'app' != 'web')`,
+				"SELECT * FROM `resources` WHERE (JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"app\"') IS NULL OR JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"app\"')) != 'web')",
+				"",
+			},
+		},
+		{
+			"not indexed",
+			"team=infra",
+			expected{
+				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' @> '{"team":"infra"}'::jsonb`,
+				"SELECT * FROM `resources` WHERE JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"team\"')) = 'infra'",
+				"",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		selector, err := labels.Parse(test.labelSelector)
+		require.NoError(t, err)
+		listOptions := &internal.ListOptions{}
+		listOptions.LabelSelector = selector
+		testApplyListOptionsToQuery(t, test.name, listOptions, test.expected)
+	}
+}
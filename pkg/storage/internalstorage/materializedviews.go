@@ -0,0 +1,243 @@
+package internalstorage
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+const (
+	collectionViewPrefix = resourcesTable + "_collection_"
+
+	// collectionViewComment prefixes the hash of the definition in the comment of the view,
+	// the view is recreated when the definition is changed, e.g. the columns are added by the migrations.
+	collectionViewComment = "clusterpedia definition "
+
+	defaultMaterializedViewRefreshInterval = 30 * time.Second
+)
+
+func (c *MaterializedViewsConfig) validate(dbType string, isolation *ClusterIsolationConfig) error {
+	if c == nil || len(c.Collections) == 0 {
+		return nil
+	}
+	if dbType != "postgres" {
+		return errors.New("materialized views are only supported by postgres")
+	}
+	if isolation != nil && isolation.Enabled {
+		return errors.New("materialized views can't be used with the cluster isolation")
+	}
+	if c.RefreshInterval < 0 || c.StaleTolerance < 0 {
+		return errors.New("refreshInterval and staleTolerance of the materialized views must be greater than or equal to 0")
+	}
+	for i, name := range c.Collections {
+		if name == CollectionResourceAny {
+			return fmt.Errorf("materializedViews.collections[%d]: the resource types of collection resource %s are specified by the queries", i, name)
+		}
+		if !slices.ContainsFunc(collectionResources, func(cr internal.CollectionResource) bool { return cr.Name == name }) {
+			return fmt.Errorf("materializedViews.collections[%d]: unknown collection resource %q", i, name)
+		}
+	}
+	return nil
+}
+
+func (c *MaterializedViewsConfig) enabled() bool {
+	return c != nil && len(c.Collections) != 0 && c.StaleTolerance > 0
+}
+
+func collectionView(collection string) string {
+	return collectionViewPrefix + collection
+}
+
+// materializedView is the materialized view of the resources of a collection resource
+type materializedView struct {
+	name           string
+	staleTolerance time.Duration
+
+	// refreshedAt is the start time of the last successful refresh, the view isn't fresh before it is refreshed
+	refreshedAt atomic.Pointer[time.Time]
+}
+
+// fresh returns whether the view is refreshed within the stale tolerance, it is false for a nil view.
+func (v *materializedView) fresh() bool {
+	if v == nil {
+		return false
+	}
+	refreshedAt := v.refreshedAt.Load()
+	return refreshedAt != nil && time.Since(*refreshedAt) <= v.staleTolerance
+}
+
+func (v *materializedView) refresh(ctx context.Context, db *gorm.DB) {
+	refreshedAt := time.Now()
+	statement := fmt.Sprintf("REFRESH MATERIALIZED VIEW CONCURRENTLY %s", db.Statement.Quote(v.name))
+	if err := db.WithContext(ctx).Exec(statement).Error; err != nil {
+		if ctx.Err() == nil {
+			klog.ErrorS(err, "Failed to refresh the materialized view, the collection resource is queried from the resources table", "view", v.name)
+		}
+		return
+	}
+	v.refreshedAt.Store(&refreshedAt)
+}
+
+// materializedViews refreshes the materialized views of the heavy collection resources at the interval,
+// the queries of the collection resources read the views when they are fresh, otherwise they fall back to
+// the live queries of the resources table. A nil materializedViews means no collection resource is materialized.
+type materializedViews struct {
+	db       *gorm.DB
+	interval time.Duration
+	views    map[string]*materializedView
+
+	cancel context.CancelFunc
+}
+
+func newMaterializedViews(db *gorm.DB, config *MaterializedViewsConfig) *materializedViews {
+	if !config.enabled() {
+		return nil
+	}
+
+	views := &materializedViews{db: db, interval: config.RefreshInterval, views: make(map[string]*materializedView)}
+	if views.interval == 0 {
+		views.interval = defaultMaterializedViewRefreshInterval
+	}
+	for _, collection := range config.Collections {
+		views.views[collection] = &materializedView{name: collectionView(collection), staleTolerance: config.StaleTolerance}
+	}
+	return views
+}
+
+// view returns the materialized view of the collection resource, nil if the collection resource isn't materialized.
+func (v *materializedViews) view(collection string) *materializedView {
+	if v == nil {
+		return nil
+	}
+	return v.views[collection]
+}
+
+func (v *materializedViews) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	for _, view := range v.views {
+		go wait.UntilWithContext(ctx, func(ctx context.Context) { view.refresh(ctx, v.db) }, v.interval)
+	}
+}
+
+func (v *materializedViews) Close() error {
+	if v.cancel != nil {
+		v.cancel()
+	}
+	return nil
+}
+
+// collectionViewDefinition returns the query of the materialized view of the collection resource,
+// the columns are selected explicitly, so the definition is changed when the columns are added by the migrations.
+func collectionViewDefinition(db *gorm.DB, collection string, softDelete bool) (string, error) {
+	index := slices.IndexFunc(collectionResources, func(cr internal.CollectionResource) bool { return cr.Name == collection })
+	if index == -1 {
+		return "", fmt.Errorf("unknown collection resource %q", collection)
+	}
+	typesQuery := NewCollectionResourceStorage(db, &collectionResources[index]).typesQuery
+	if typesQuery == nil {
+		return "", fmt.Errorf("collection resource %s has no resource types", collection)
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&Resource{}); err != nil {
+		return "", err
+	}
+	columns := make([]string, 0, len(stmt.Schema.DBNames))
+	for _, column := range stmt.Schema.DBNames {
+		columns = append(columns, stmt.Quote(column))
+	}
+
+	query := excludeTombstones(db.Session(&gorm.Session{DryRun: true, NewDB: true}).Table(resourcesTable), softDelete).
+		Select(strings.Join(columns, ", ")).Where(typesQuery).Find(&[]Resource{})
+	return db.Dialector.Explain(query.Statement.SQL.String(), query.Statement.Vars...), nil
+}
+
+// migrateMaterializedViews creates the materialized views of the collection resources, the views with the changed definitions
+// are recreated, and the views of the collection resources which are not materialized any more are dropped.
+func migrateMaterializedViews(db *gorm.DB, config *MaterializedViewsConfig, softDelete bool) error {
+	var collections []string
+	if config.enabled() {
+		collections = config.Collections
+	}
+
+	var existing []string
+	if err := db.Raw("SELECT matviewname FROM pg_matviews WHERE schemaname = current_schema() AND matviewname LIKE ?",
+		strings.ReplaceAll(collectionViewPrefix, "_", `\_`)+"%").Scan(&existing).Error; err != nil {
+		return err
+	}
+	for _, view := range existing {
+		if !slices.ContainsFunc(collections, func(collection string) bool { return collectionView(collection) == view }) {
+			if err := db.Exec(fmt.Sprintf("DROP MATERIALIZED VIEW IF EXISTS %s", db.Statement.Quote(view))).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, collection := range collections {
+		definition, err := collectionViewDefinition(db, collection, softDelete)
+		if err != nil {
+			return err
+		}
+
+		var comment sql.NullString
+		if err := db.Raw("SELECT obj_description(to_regclass(?), 'pg_class')", collectionView(collection)).Scan(&comment).Error; err != nil {
+			return err
+		}
+		if comment.String == collectionViewComment+definitionHash(definition) {
+			continue
+		}
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			return createCollectionView(tx, collection, definition)
+		}); err != nil {
+			return fmt.Errorf("failed to create the materialized view of collection resource %s: %w", collection, err)
+		}
+	}
+	return nil
+}
+
+func definitionHash(definition string) string {
+	hash := sha256.Sum256([]byte(definition))
+	return hex.EncodeToString(hash[:8])
+}
+
+// createCollectionView recreates the materialized view of the collection resource, the unique index on the ids
+// is required to refresh the view concurrently without blocking the queries of the view.
+func createCollectionView(db *gorm.DB, collection, definition string) error {
+	view := db.Statement.Quote(collectionView(collection))
+	statements := []string{
+		fmt.Sprintf("DROP MATERIALIZED VIEW IF EXISTS %s", view),
+		fmt.Sprintf("CREATE MATERIALIZED VIEW %s AS %s", view, definition),
+		fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s)", db.Statement.Quote("uni_"+collectionView(collection)+"_id"), view, db.Statement.Quote("id")),
+		fmt.Sprintf("COMMENT ON MATERIALIZED VIEW %s IS %s", view, quotePostgresLiteral(collectionViewComment+definitionHash(definition))),
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropCollectionViews drops the materialized views of the collection resources, which depend on the resources table.
+func dropCollectionViews(db *gorm.DB) error {
+	for _, cr := range collectionResources {
+		if err := db.Exec(fmt.Sprintf("DROP MATERIALIZED VIEW IF EXISTS %s", db.Statement.Quote(collectionView(cr.Name)))).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package internalstorage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+func TestMaterializedViewsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbType    string
+		config    *MaterializedViewsConfig
+		isolation *ClusterIsolationConfig
+		wantErr   bool
+	}{
+		{name: "disabled", dbType: "mysql", config: &MaterializedViewsConfig{}},
+		{name: "postgres", dbType: "postgres", config: &MaterializedViewsConfig{Collections: []string{"workloads"}, StaleTolerance: time.Minute}},
+		{name: "mysql", dbType: "mysql", config: &MaterializedViewsConfig{Collections: []string{"workloads"}}, wantErr: true},
+		{name: "any", dbType: "postgres", config: &MaterializedViewsConfig{Collections: []string{"any"}}, wantErr: true},
+		{name: "unknown", dbType: "postgres", config: &MaterializedViewsConfig{Collections: []string{"foo"}}, wantErr: true},
+		{name: "negative", dbType: "postgres", config: &MaterializedViewsConfig{Collections: []string{"workloads"}, StaleTolerance: -time.Second}, wantErr: true},
+		{
+			name: "cluster isolation", dbType: "postgres", config: &MaterializedViewsConfig{Collections: []string{"workloads"}},
+			isolation: &ClusterIsolationConfig{Enabled: true}, wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.config.validate(test.dbType, test.isolation); (err != nil) != test.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateCollectionView(t *testing.T) {
+	definition, err := collectionViewDefinition(postgresDB, CollectionResourceWorkloads, true)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(definition, `SELECT "id", "group", `), definition)
+	assert.True(t, strings.HasSuffix(definition, `FROM "resources" WHERE removed_at IS NULL AND `+
+		`(("resources"."group" = 'apps' AND "resources"."resource" = 'deployments') OR ("resources"."group" = 'apps' AND "resources"."resource" = 'daemonsets') `+
+		`OR ("resources"."group" = 'apps' AND "resources"."resource" = 'statefulsets'))`), definition)
+
+	recorder := &statementRecorder{}
+	dryRun := postgresDB.Session(&gorm.Session{DryRun: true, Logger: recorder})
+	require.NoError(t, createCollectionView(dryRun, CollectionResourceWorkloads, definition))
+	assert.Equal(t, []string{
+		`DROP MATERIALIZED VIEW IF EXISTS "resources_collection_workloads"`,
+		`CREATE MATERIALIZED VIEW "resources_collection_workloads" AS ` + definition,
+		`CREATE UNIQUE INDEX "uni_resources_collection_workloads_id" ON "resources_collection_workloads" ("id")`,
+		`COMMENT ON MATERIALIZED VIEW "resources_collection_workloads" IS 'clusterpedia definition ` + definitionHash(definition) + `'`,
+	}, recorder.statements)
+}
+
+func TestCollectionResourceStorageMaterializedView(t *testing.T) {
+	views := newMaterializedViews(postgresDB, &MaterializedViewsConfig{Collections: []string{CollectionResourceWorkloads}, StaleTolerance: time.Minute})
+	require.Nil(t, views.view(CollectionResourceKubeResources))
+	view := views.view(CollectionResourceWorkloads)
+	require.NotNil(t, view)
+
+	storage := NewCollectionResourceStorage(postgresDB, &collectionResources[1])
+	storage.view = view
+	listSQL := func() string {
+		query, _, err := storage.query(postgresDB, &internal.ListOptions{})
+		require.NoError(t, err)
+		query = query.Session(&gorm.Session{DryRun: true}).Find(&[]Resource{})
+		return postgresDB.Dialector.Explain(query.Statement.SQL.String(), query.Statement.Vars...)
+	}
+
+	// the view isn't read before it is refreshed
+	assert.True(t, strings.HasPrefix(listSQL(), `SELECT * FROM "resources" WHERE`))
+
+	refreshedAt := time.Now()
+	view.refreshedAt.Store(&refreshedAt)
+	assert.Equal(t, `SELECT * FROM "resources_collection_workloads"`, listSQL())
+
+	refreshedAt = time.Now().Add(-2 * time.Minute)
+	view.refreshedAt.Store(&refreshedAt)
+	assert.True(t, strings.HasPrefix(listSQL(), `SELECT * FROM "resources" WHERE`))
+}
@@ -0,0 +1,188 @@
+package internalstorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var _ storage.SchemaMigrationPlanner = &StorageFactory{}
+
+func (s *StorageFactory) models() []interface{} {
+	models := []interface{}{&Resource{}}
+	if s.tenants != nil {
+		models = append(models, &TenantCluster{})
+	}
+	if s.recordChanges {
+		models = append(models, &ResourceChange{})
+	}
+	if s.watchBookmarks {
+		models = append(models, &WatchBookmark{})
+	}
+	return models
+}
+
+// PlanSchemaMigration compares the tables with the models, the statements are generated
+// by the migrator of gorm in the dry run mode, so they are the same as the statements of the auto migration.
+func (s *StorageFactory) PlanSchemaMigration(ctx context.Context) (*storage.SchemaMigrationPlan, error) {
+	db := s.db.WithContext(ctx)
+	recorder := &statementRecorder{}
+	dryRun := db.Session(&gorm.Session{DryRun: true, Logger: recorder})
+
+	plan := &storage.SchemaMigrationPlan{}
+	for _, model := range s.models() {
+		if err := planTableMigration(plan, db, dryRun, model, ""); err != nil {
+			return nil, err
+		}
+	}
+	if err := planStorageFormats(plan, db, ""); err != nil {
+		return nil, err
+	}
+	if err := planJSONIndexes(plan, db, dryRun, resourcesTable); err != nil {
+		return nil, err
+	}
+	if err := planLabelColumns(plan, db, dryRun, s.indexedLabels); err != nil {
+		return nil, err
+	}
+	if s.partitioning {
+		if err := planResourcesPartitioning(plan, db, dryRun); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.isolation != nil {
+		schemas, err := s.isolation.schemas(db, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, schema := range schemas {
+			table := schemaResourcesTable(schema)
+			clusterDB := db.Table(table).Session(&gorm.Session{})
+			if err := planTableMigration(plan, clusterDB, dryRun.Table(table).Session(&gorm.Session{}), &Resource{}, table); err != nil {
+				return nil, err
+			}
+			if err := planStorageFormats(plan, clusterDB, table); err != nil {
+				return nil, err
+			}
+			if err := planJSONIndexes(plan, db, dryRun, table); err != nil {
+				return nil, err
+			}
+		}
+	}
+	plan.Statements = recorder.statements
+	return plan, nil
+}
+
+// planTableMigration adds the incompatibilities of the table with the model and the statements to migrate it to the plan,
+// the table is the table of the model if it is empty.
+func planTableMigration(plan *storage.SchemaMigrationPlan, db, dryRun *gorm.DB, model interface{}, table string) error {
+	migrator, planner := db.Migrator(), dryRun.Migrator()
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	if table == "" {
+		table = stmt.Schema.Table
+	}
+
+	if !migrator.HasTable(model) {
+		plan.Incompatibilities = append(plan.Incompatibilities, fmt.Sprintf("table %s doesn't exist", table))
+		return planner.CreateTable(model)
+	}
+
+	columnTypes, err := migrator.ColumnTypes(model)
+	if err != nil {
+		return err
+	}
+	columns := make(map[string]gorm.ColumnType, len(columnTypes))
+	for _, column := range columnTypes {
+		columns[column.Name()] = column
+	}
+
+	for _, name := range stmt.Schema.DBNames {
+		if _, ok := columns[name]; ok {
+			delete(columns, name)
+			continue
+		}
+		plan.Incompatibilities = append(plan.Incompatibilities, fmt.Sprintf("column %s.%s doesn't exist", table, name))
+		if err := planner.AddColumn(model, name); err != nil {
+			return err
+		}
+	}
+
+	// the unknown columns may be added by a newer version,
+	// the rows can't be inserted if the columns are required.
+	for name, column := range columns {
+		nullable, _ := column.Nullable()
+		if _, hasDefault := column.DefaultValue(); !nullable && !hasDefault {
+			plan.Incompatibilities = append(plan.Incompatibilities,
+				fmt.Sprintf("column %s.%s is unknown to the current version and is required, it must be dropped or have a default value", table, name))
+		}
+	}
+
+	for _, index := range stmt.Schema.ParseIndexes() {
+		if migrator.HasIndex(model, index.Name) {
+			continue
+		}
+		plan.Incompatibilities = append(plan.Incompatibilities, fmt.Sprintf("index %s of table %s doesn't exist", index.Name, table))
+		if err := planner.CreateIndex(model, index.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planStorageFormats adds the rows of the resources table which are out of the readable formats to the plan
+func planStorageFormats(plan *storage.SchemaMigrationPlan, db *gorm.DB, table string) error {
+	counts, err := unreadableStorageFormats(db)
+	if err != nil {
+		return err
+	}
+	in := ""
+	if table != "" {
+		in = " of table " + table
+	}
+	for format, count := range counts {
+		plan.Incompatibilities = append(plan.Incompatibilities,
+			fmt.Sprintf("%d resources%s are stored in the format %d, which is out of the readable formats [%d, %d]", count, in, format, minReadableStorageFormat, maxReadableStorageFormat))
+	}
+	return nil
+}
+
+// planJSONIndexes adds the missing GIN indexes on the json objects of the resources table to the plan
+func planJSONIndexes(plan *storage.SchemaMigrationPlan, db, dryRun *gorm.DB, table string) error {
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	partitioned, err := isPartitionedTable(db, table)
+	if err != nil {
+		return err
+	}
+	for _, index := range missingJSONIndexes(db, table) {
+		plan.Incompatibilities = append(plan.Incompatibilities, fmt.Sprintf("index %s of table %s doesn't exist", index.name, table))
+		if err := createJSONIndex(dryRun, table, index, !partitioned); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statementRecorder is a logger of gorm which records the statements.
+type statementRecorder struct {
+	statements []string
+}
+
+func (r *statementRecorder) LogMode(logger.LogLevel) logger.Interface      { return r }
+func (r *statementRecorder) Info(context.Context, string, ...interface{})  {}
+func (r *statementRecorder) Warn(context.Context, string, ...interface{})  {}
+func (r *statementRecorder) Error(context.Context, string, ...interface{}) {}
+
+func (r *statementRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
@@ -0,0 +1,43 @@
+package internalstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanSchemaMigration(t *testing.T) {
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(t, err)
+	defer cleanup()
+
+	factory := &StorageFactory{db: db}
+	plan, err := factory.PlanSchemaMigration(context.TODO())
+	require.NoError(t, err)
+	assert.Empty(t, plan.Incompatibilities)
+	assert.Empty(t, plan.Statements)
+
+	// sqlite drops the indexes when dropping the column
+	require.NoError(t, db.Migrator().DropColumn(&Resource{}, "owner_uid"))
+	for _, index := range []string{"uni_group_version_resource_cluster_namespace_name", "idx_group_version_resource_namespace_name", "idx_group_version_resource_name"} {
+		require.NoError(t, db.Migrator().CreateIndex(&Resource{}, index))
+	}
+	require.NoError(t, db.Exec("ALTER TABLE resources ADD COLUMN future text NOT NULL").Error)
+
+	plan, err = factory.PlanSchemaMigration(context.TODO())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"column resources.owner_uid doesn't exist",
+		"column resources.future is unknown to the current version and is required, it must be dropped or have a default value",
+		"index idx_cluster of table resources doesn't exist",
+	}, plan.Incompatibilities)
+	assert.Equal(t, []string{
+		"ALTER TABLE `resources` ADD `owner_uid` text NOT NULL DEFAULT \"\"",
+		"CREATE INDEX `idx_cluster` ON `resources`(`cluster`)",
+	}, plan.Statements)
+
+	// the storage is not changed by the plan
+	assert.False(t, db.Migrator().HasColumn(&Resource{}, "owner_uid"))
+}
@@ -0,0 +1,246 @@
+package internalstorage
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+)
+
+// jsonNormalizer normalizes the json of the stored objects, the keys of the objects are sorted,
+// and the fields set by the defaulting of the apiservers are stripped if stripDefaults is set.
+// A nil jsonNormalizer means the objects are stored as they are encoded by the codecs.
+type jsonNormalizer struct {
+	stripDefaults bool
+}
+
+func newJSONNormalizer(config *NormalizationConfig) *jsonNormalizer {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+	return &jsonNormalizer{stripDefaults: config.StripDefaults}
+}
+
+// strippingDefaults returns whether the defaulted fields are stripped,
+// the stripped fields of a top-level field may depend on the other top-level fields.
+func (n *jsonNormalizer) strippingDefaults() bool {
+	return n != nil && n.stripDefaults
+}
+
+// normalize returns the normalized json of the object, the data is returned as it is if the normalization is disabled.
+func (n *jsonNormalizer) normalize(data []byte) ([]byte, error) {
+	if n == nil {
+		return data, nil
+	}
+
+	// the integers are decoded as int64 to keep their precision
+	var content map[string]interface{}
+	if err := utiljson.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	if n.stripDefaults {
+		if err := stripDefaultedFields(content); err != nil {
+			return nil, err
+		}
+	}
+
+	// the keys of the maps are sorted by json.Marshal
+	return json.Marshal(content)
+}
+
+// unstructuredPath is the path of a field in the unstructured content, the elements are the keys of the maps and the indexes of the slices
+type unstructuredPath []interface{}
+
+// stripDefaultedFields strips the scalar fields whose values are set by the defaulting functions of the built-in resources,
+// the objects of the resources without the defaulting functions in the legacy scheme, e.g. the custom resources, are kept.
+//
+// The defaults are computed from the skeleton of the object whose scalar fields are all removed, and the values equal to
+// the defaults are stripped. Because some defaults depend on the other fields, the stripped object is defaulted again,
+// and the stripped fields that are not restored to their values are put back, so defaulting the normalized object
+// still results in the original object.
+func stripDefaultedFields(content map[string]interface{}) error {
+	apiVersion, _ := content["apiVersion"].(string)
+	kind, _ := content["kind"].(string)
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil || kind == "" {
+		return nil
+	}
+	gvk := gv.WithKind(kind)
+	if !scheme.LegacyResourceScheme.Recognizes(gvk) {
+		return nil
+	}
+
+	skeleton := runtime.DeepCopyJSON(content)
+	removeScalarFields(skeleton)
+	defaults, err := defaultedContent(gvk, skeleton)
+	if err != nil {
+		return err
+	}
+
+	stripped := make(map[string]unstructuredPath)
+	walkScalarFields(content, nil, func(path unstructuredPath, value interface{}) {
+		if defaulted, ok := lookupField(defaults, path); ok && reflect.DeepEqual(defaulted, value) {
+			stripped[pathKey(path)] = path
+		}
+	})
+	if len(stripped) == 0 {
+		return nil
+	}
+
+	original := runtime.DeepCopyJSON(content)
+	for _, path := range stripped {
+		removeField(content, path)
+	}
+	removeEmptyMaps(content, original)
+
+	restored, err := defaultedContent(gvk, runtime.DeepCopyJSON(content))
+	if err != nil {
+		return err
+	}
+	for _, path := range stripped {
+		value, _ := lookupField(original, path)
+		if defaulted, ok := lookupField(restored, path); !ok || !reflect.DeepEqual(defaulted, value) {
+			setField(content, original, path)
+		}
+	}
+	return nil
+}
+
+// defaultedContent returns the unstructured content of the object defaulted by the legacy scheme
+func defaultedContent(gvk schema.GroupVersionKind, content map[string]interface{}) (map[string]interface{}, error) {
+	obj, err := scheme.LegacyResourceScheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, obj); err != nil {
+		return nil, err
+	}
+	scheme.LegacyResourceScheme.Default(obj)
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
+
+// removeScalarFields removes the scalar fields of the maps, the maps and the slices are kept as the skeleton
+func removeScalarFields(content map[string]interface{}) {
+	for key, value := range content {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			removeScalarFields(value)
+		case []interface{}:
+			for _, item := range value {
+				if item, ok := item.(map[string]interface{}); ok {
+					removeScalarFields(item)
+				}
+			}
+		default:
+			delete(content, key)
+		}
+	}
+}
+
+// walkScalarFields calls fn with the scalar fields of the maps, the scalar items of the slices are not stripped
+func walkScalarFields(content map[string]interface{}, path unstructuredPath, fn func(unstructuredPath, interface{})) {
+	for key, value := range content {
+		keyPath := append(path[:len(path):len(path)], key)
+		switch value := value.(type) {
+		case map[string]interface{}:
+			walkScalarFields(value, keyPath, fn)
+		case []interface{}:
+			for i, item := range value {
+				if item, ok := item.(map[string]interface{}); ok {
+					walkScalarFields(item, append(keyPath[:len(keyPath):len(keyPath)], i), fn)
+				}
+			}
+		case nil:
+		default:
+			fn(keyPath, value)
+		}
+	}
+}
+
+// removeEmptyMaps removes the maps emptied by the stripping, the maps that are empty in the original object are kept
+func removeEmptyMaps(content, original map[string]interface{}) {
+	for key, value := range content {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			originalValue, _ := original[key].(map[string]interface{})
+			removeEmptyMaps(value, originalValue)
+			if len(value) == 0 && len(originalValue) != 0 {
+				delete(content, key)
+			}
+		case []interface{}:
+			originalValue, _ := original[key].([]interface{})
+			for i, item := range value {
+				item, ok := item.(map[string]interface{})
+				if !ok || i >= len(originalValue) {
+					continue
+				}
+				originalItem, _ := originalValue[i].(map[string]interface{})
+				removeEmptyMaps(item, originalItem)
+			}
+		}
+	}
+}
+
+func pathKey(path unstructuredPath) string {
+	data, _ := json.Marshal(path)
+	return string(data)
+}
+
+func lookupField(content interface{}, path unstructuredPath) (interface{}, bool) {
+	for _, element := range path {
+		switch element := element.(type) {
+		case string:
+			m, ok := content.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if content, ok = m[element]; !ok {
+				return nil, false
+			}
+		case int:
+			s, ok := content.([]interface{})
+			if !ok || element >= len(s) {
+				return nil, false
+			}
+			content = s[element]
+		}
+	}
+	return content, true
+}
+
+func removeField(content map[string]interface{}, path unstructuredPath) {
+	parent, ok := lookupField(content, path[:len(path)-1])
+	if !ok {
+		return
+	}
+	if m, ok := parent.(map[string]interface{}); ok {
+		delete(m, path[len(path)-1].(string))
+	}
+}
+
+// setField sets the field of the path to its value in the original object, the maps removed from the path are recreated
+func setField(content, original map[string]interface{}, path unstructuredPath) {
+	var current interface{} = content
+	for i, element := range path {
+		last := i == len(path)-1
+		switch element := element.(type) {
+		case string:
+			m := current.(map[string]interface{})
+			if last {
+				m[element], _ = lookupField(original, path)
+				return
+			}
+			if _, ok := m[element]; !ok {
+				m[element] = make(map[string]interface{})
+			}
+			current = m[element]
+		case int:
+			// the slices are never removed by the stripping
+			current = current.([]interface{})[element]
+		}
+	}
+}
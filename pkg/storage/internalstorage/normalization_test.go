@@ -0,0 +1,73 @@
+package internalstorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONNormalizer(t *testing.T) {
+	// the pods of the clusters whose apiservers set the different defaults
+	defaulted := `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo","namespace":"default"},
+		"spec":{"containers":[{"name":"nginx","image":"nginx:1.25","imagePullPolicy":"IfNotPresent",
+		"ports":[{"containerPort":80,"protocol":"TCP"}],
+		"terminationMessagePath":"/dev/termination-log","terminationMessagePolicy":"File"}],
+		"restartPolicy":"Always","dnsPolicy":"ClusterFirst","terminationGracePeriodSeconds":30,
+		"schedulerName":"default-scheduler","securityContext":{},"enableServiceLinks":true}}`
+	plain := `{"kind":"Pod","apiVersion":"v1","metadata":{"namespace":"default","name":"foo"},
+		"spec":{"containers":[{"image":"nginx:1.25","name":"nginx","ports":[{"containerPort":80}]}],"securityContext":{}}}`
+
+	tests := []struct {
+		name       string
+		normalizer *jsonNormalizer
+		data       string
+		expected   string
+	}{
+		{
+			name:     "disabled",
+			data:     `{"kind":"Pod","apiVersion":"v1"}`,
+			expected: `{"kind":"Pod","apiVersion":"v1"}`,
+		},
+		{
+			name:       "sort keys",
+			normalizer: newJSONNormalizer(&NormalizationConfig{Enabled: true}),
+			data:       `{"kind":"Pod","metadata":{"name":"foo","labels":{"b":"1","a":"2"}},"apiVersion":"v1","spec":{"priority":9007199254740993}}`,
+			expected:   `{"apiVersion":"v1","kind":"Pod","metadata":{"labels":{"a":"2","b":"1"},"name":"foo"},"spec":{"priority":9007199254740993}}`,
+		},
+		{
+			name:       "strip the defaults",
+			normalizer: newJSONNormalizer(&NormalizationConfig{Enabled: true, StripDefaults: true}),
+			data:       defaulted,
+			expected: `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo","namespace":"default"},` +
+				`"spec":{"containers":[{"image":"nginx:1.25","name":"nginx","ports":[{"containerPort":80}]}],"securityContext":{}}}`,
+		},
+		{
+			name:       "keep the values different from the defaults",
+			normalizer: newJSONNormalizer(&NormalizationConfig{Enabled: true, StripDefaults: true}),
+			data:       `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo"},"spec":{"containers":[{"name":"nginx","image":"nginx","imagePullPolicy":"IfNotPresent"}],"restartPolicy":"Never"}}`,
+			expected:   `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo"},"spec":{"containers":[{"image":"nginx","imagePullPolicy":"IfNotPresent","name":"nginx"}],"restartPolicy":"Never"}}`,
+		},
+		{
+			name:       "keep the defaults of the custom resources",
+			normalizer: newJSONNormalizer(&NormalizationConfig{Enabled: true, StripDefaults: true}),
+			data:       `{"apiVersion":"example.io/v1","kind":"Foo","metadata":{"name":"foo"},"spec":{"restartPolicy":"Always"}}`,
+			expected:   `{"apiVersion":"example.io/v1","kind":"Foo","metadata":{"name":"foo"},"spec":{"restartPolicy":"Always"}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			normalized, err := test.normalizer.normalize([]byte(test.data))
+			require.NoError(t, err)
+			require.Equal(t, test.expected, string(normalized))
+		})
+	}
+
+	t.Run("stable across the defaulting", func(t *testing.T) {
+		normalizer := newJSONNormalizer(&NormalizationConfig{Enabled: true, StripDefaults: true})
+		fromDefaulted, err := normalizer.normalize([]byte(defaulted))
+		require.NoError(t, err)
+		fromPlain, err := normalizer.normalize([]byte(plain))
+		require.NoError(t, err)
+		require.Equal(t, string(fromPlain), string(fromDefaulted))
+	})
+}
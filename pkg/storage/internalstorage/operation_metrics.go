@@ -0,0 +1,110 @@
+package internalstorage
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	operationGet              = "get"
+	operationList             = "list"
+	operationCount            = "count"
+	operationCreate           = "create"
+	operationUpdate           = "update"
+	operationPatch            = "patch"
+	operationDelete           = "delete"
+	operationDeleteCollection = "delete_collection"
+	operationRecordEvent      = "record_event"
+)
+
+var operationLabels = []string{"operation", "cluster", "group", "version", "resource"}
+
+var (
+	operationDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage",
+			Name:           "operation_duration_seconds",
+			Help:           "The latency of the operations of the resource storages.",
+			StabilityLevel: metrics.ALPHA,
+			Buckets:        []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		operationLabels,
+	)
+
+	operationErrorsTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage",
+			Name:           "operation_errors_total",
+			Help:           "Number of the failed operations of the resource storages by the code of the storage errors.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		append([]string{"code"}, operationLabels...),
+	)
+
+	operationObjects = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage",
+			Name:           "operation_objects",
+			Help:           "Number of the objects read or written by the succeeded operations of the resource storages, e.g. the batch sizes of the lists and the collection deletions.",
+			StabilityLevel: metrics.ALPHA,
+			Buckets:        metrics.ExponentialBuckets(1, 4, 9),
+		},
+		operationLabels,
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(operationDuration, operationErrorsTotal, operationObjects)
+}
+
+// operationMetrics records the metrics of the operations of a resource storage, nil if the metrics are disabled.
+type operationMetrics struct {
+	gvr schema.GroupVersionResource
+}
+
+func newOperationMetrics(enabled bool, gvr schema.GroupVersionResource) *operationMetrics {
+	if !enabled {
+		return nil
+	}
+	return &operationMetrics{gvr: gvr}
+}
+
+// observe records the operation started at the time, the cluster is empty if the operation is across the clusters.
+// The not found errors are not counted as the errors, they are expected by the gets and the updates of the synchros.
+func (m *operationMetrics) observe(operation, cluster string, start time.Time, objects int64, err error) {
+	if m == nil {
+		return
+	}
+
+	values := []string{operation, cluster, m.gvr.Group, m.gvr.Version, m.gvr.Resource}
+	operationDuration.WithLabelValues(values...).Observe(time.Since(start).Seconds())
+	if err == nil {
+		operationObjects.WithLabelValues(values...).Observe(float64(objects))
+		return
+	}
+
+	code := storage.ErrorCodeOf(err)
+	switch code {
+	case storage.ErrCodeNotFound:
+		return
+	case "":
+		code = "Unknown"
+	}
+	operationErrorsTotal.WithLabelValues(append([]string{string(code)}, values...)...).Inc()
+}
+
+// operationCluster returns the cluster label of the operation on the clusters.
+func operationCluster(clusters []string) string {
+	if len(clusters) == 1 {
+		return clusters[0]
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package internalstorage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/component-base/metrics/testutil"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestOperationMetrics(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	m := newOperationMetrics(true, gvr)
+
+	m.observe(operationList, operationCluster([]string{"cluster-1"}), time.Now(), 20, nil)
+	m.observe(operationGet, "cluster-1", time.Now(), 1, storage.NewNotFoundError("cluster-1/default/nginx", errors.New("not found")))
+	m.observe(operationCreate, "cluster-1", time.Now(), 1, storage.NewConflictError("cluster-1/default/nginx", errors.New("duplicated")))
+	m.observe(operationCreate, "cluster-1", time.Now(), 1, errors.New("unknown"))
+
+	list := []string{operationList, "cluster-1", "apps", "v1", "deployments"}
+	objects, err := testutil.GetHistogramMetricValue(operationObjects.WithLabelValues(list...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objects != 20 {
+		t.Errorf("the listed objects = %v, want 20", objects)
+	}
+	if count, _ := testutil.GetHistogramMetricCount(operationDuration.WithLabelValues(list...)); count != 1 {
+		t.Errorf("the observed lists = %d, want 1", count)
+	}
+
+	get := []string{string(storage.ErrCodeNotFound), operationGet, "cluster-1", "apps", "v1", "deployments"}
+	if errs, _ := testutil.GetCounterMetricValue(operationErrorsTotal.WithLabelValues(get...)); errs != 0 {
+		t.Errorf("the not found errors should not be counted, got %v", errs)
+	}
+	for _, code := range []string{string(storage.ErrCodeConflict), "Unknown"} {
+		create := []string{code, operationCreate, "cluster-1", "apps", "v1", "deployments"}
+		if errs, _ := testutil.GetCounterMetricValue(operationErrorsTotal.WithLabelValues(create...)); errs != 1 {
+			t.Errorf("the %s errors of the creates = %v, want 1", code, errs)
+		}
+	}
+
+	if cluster := operationCluster([]string{"cluster-1", "cluster-2"}); cluster != "" {
+		t.Errorf("the operation across the clusters should not be labeled by the cluster, got %q", cluster)
+	}
+
+	// the disabled metrics are nil and ignore the operations
+	newOperationMetrics(false, gvr).observe(operationGet, "cluster-1", time.Now(), 1, nil)
+}
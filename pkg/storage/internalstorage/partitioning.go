@@ -0,0 +1,167 @@
+package internalstorage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// partitionedResourcesTable is the partitioned table which the rows of the resources table are copied to,
+// it replaces the resources table at the end of the migration.
+const partitionedResourcesTable = "resources_partitioned"
+
+func (c *PartitioningConfig) validate(dbType string, isolation *ClusterIsolationConfig) error {
+	if !c.enabled() {
+		return nil
+	}
+	if dbType != "postgres" {
+		return errors.New("partitioning is only supported by postgres")
+	}
+	if isolation != nil && isolation.Enabled {
+		return errors.New("partitioning can't be used with the cluster isolation")
+	}
+	return nil
+}
+
+func (c *PartitioningConfig) enabled() bool {
+	return c != nil && c.Enabled
+}
+
+// resourcesPartition returns the partition of the cluster in the resources table,
+// the cluster name is truncated and hashed like the schema of the cluster isolation.
+func resourcesPartition(cluster string) string {
+	return clusterIdentifier(resourcesTable+"_", cluster)
+}
+
+// isPartitionedTable returns whether the table of postgres is partitioned, it is false if the table doesn't exist.
+func isPartitionedTable(db *gorm.DB, table string) (bool, error) {
+	var count int64
+	err := db.Session(&gorm.Session{NewDB: true}).Raw("SELECT count(*) FROM pg_partitioned_table WHERE partrelid = to_regclass(?)", table).Scan(&count).Error
+	return count > 0, err
+}
+
+// migrateResourcesPartitioning converts the resources table to the table partitioned by the list of the clusters.
+//
+// The rows are copied to the partitions of their clusters in a transaction, which blocks the writes of the resources,
+// so the existing large table should be migrated by the init-storage command before the components are upgraded.
+func migrateResourcesPartitioning(db *gorm.DB) error {
+	partitioned, err := isPartitionedTable(db, resourcesTable)
+	if err != nil || partitioned {
+		return err
+	}
+
+	sequence, clusters, err := unpartitionedResources(db)
+	if err != nil {
+		return err
+	}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		// the materialized views depending on the resources table are recreated by migrateMaterializedViews
+		if err := dropCollectionViews(tx); err != nil {
+			return err
+		}
+		return partitionResources(tx, sequence, clusters)
+	}); err != nil {
+		return fmt.Errorf("failed to partition the resources table: %w", err)
+	}
+	return nil
+}
+
+// planResourcesPartitioning adds the statements of converting the resources table to the partitioned table to the plan
+func planResourcesPartitioning(plan *storage.SchemaMigrationPlan, db, dryRun *gorm.DB) error {
+	// the resources table is created by the previous statements of the plan
+	sequence, clusters := resourcesTable+"_id_seq", []string(nil)
+	if db.Migrator().HasTable(&Resource{}) {
+		partitioned, err := isPartitionedTable(db, resourcesTable)
+		if err != nil || partitioned {
+			return err
+		}
+		if sequence, clusters, err = unpartitionedResources(db); err != nil {
+			return err
+		}
+	}
+	plan.Incompatibilities = append(plan.Incompatibilities, fmt.Sprintf("table %s is not partitioned by the clusters", resourcesTable))
+	return partitionResources(dryRun, sequence, clusters)
+}
+
+// unpartitionedResources returns the sequence of the ids and the clusters of the unpartitioned resources table
+func unpartitionedResources(db *gorm.DB) (string, []string, error) {
+	var sequence sql.NullString
+	if err := db.Raw("SELECT pg_get_serial_sequence(?, ?)", resourcesTable, "id").Scan(&sequence).Error; err != nil {
+		return "", nil, err
+	}
+	if !sequence.Valid {
+		return "", nil, fmt.Errorf("the id of table %s has no sequence", resourcesTable)
+	}
+
+	var clusters []string
+	if err := db.Model(&Resource{}).Distinct("cluster").Order("cluster").Pluck("cluster", &clusters).Error; err != nil {
+		return "", nil, err
+	}
+	return sequence.String, clusters, nil
+}
+
+// partitionResources replaces the resources table with the table partitioned by the clusters,
+// the partitions of the clusters are created and the rows are copied to them, then the sequence
+// of the ids is transferred to the partitioned table before the resources table is dropped.
+//
+// The primary key of the partitioned table must include the cluster, and the indexes are recreated
+// on the partitioned table, they are created on the partitions of the clusters prepared later too.
+func partitionResources(db *gorm.DB, sequence string, clusters []string) error {
+	quote := db.Statement.Quote
+	resources, partitioned := quote(resourcesTable), quote(partitionedResourcesTable)
+
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING STORAGE) PARTITION BY LIST (%s)", partitioned, resources, quote("cluster")),
+	}
+	for _, cluster := range clusters {
+		statements = append(statements, createPartitionStatement(db, partitionedResourcesTable, cluster))
+	}
+	statements = append(statements,
+		fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", partitioned, resources),
+		fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s.%s", sequence, partitioned, quote("id")),
+		fmt.Sprintf("DROP TABLE %s", resources),
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", partitioned, resources),
+		fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s, %s)", resources, quote("id"), quote("cluster")),
+	)
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&Resource{}); err != nil {
+		return err
+	}
+	for _, index := range stmt.Schema.ParseIndexes() {
+		if err := db.Migrator().CreateIndex(&Resource{}, index.Name); err != nil {
+			return err
+		}
+	}
+	for _, index := range jsonIndexes {
+		if err := createJSONIndex(db, resourcesTable, index, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createPartitionStatement(db *gorm.DB, table, cluster string) string {
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES IN (%s)",
+		db.Statement.Quote(resourcesPartition(cluster)), db.Statement.Quote(table), quotePostgresLiteral(cluster))
+}
+
+// createResourcesPartition creates the partition of the cluster in the partitioned resources table,
+// the indexes of the partitioned table are created on the partition.
+func createResourcesPartition(db *gorm.DB, cluster string) error {
+	return db.Exec(createPartitionStatement(db, resourcesTable, cluster)).Error
+}
+
+// dropResourcesPartition drops the partition of the cluster with all of its resources.
+func dropResourcesPartition(db *gorm.DB, cluster string) error {
+	return db.Exec("DROP TABLE IF EXISTS ?", gorm.Expr(db.Statement.Quote(resourcesPartition(cluster)))).Error
+}
@@ -0,0 +1,94 @@
+package internalstorage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestPartitioningConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		dbType    string
+		config    *PartitioningConfig
+		isolation *ClusterIsolationConfig
+		wantErr   bool
+	}{
+		{name: "disabled", dbType: "mysql", config: &PartitioningConfig{}},
+		{name: "postgres", dbType: "postgres", config: &PartitioningConfig{Enabled: true}},
+		{name: "mysql", dbType: "mysql", config: &PartitioningConfig{Enabled: true}, wantErr: true},
+		{
+			name: "cluster isolation", dbType: "postgres", config: &PartitioningConfig{Enabled: true},
+			isolation: &ClusterIsolationConfig{Enabled: true}, wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.config.validate(test.dbType, test.isolation); (err != nil) != test.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourcesPartition(t *testing.T) {
+	if partition := resourcesPartition("cluster-1"); !strings.HasPrefix(partition, "resources_cluster_1_") {
+		t.Errorf("unexpected partition %q", partition)
+	}
+
+	long := strings.Repeat("cluster-", 30)
+	if partition := resourcesPartition(long); len(partition) > maxSchemaLength || partition == resourcesPartition(long+"1") {
+		t.Errorf("the partition %q should be truncated and distinct", partition)
+	}
+}
+
+func TestPartitionResources(t *testing.T) {
+	recorder := &statementRecorder{}
+	dryRun := postgresDB.Session(&gorm.Session{DryRun: true, Logger: recorder})
+
+	require.NoError(t, partitionResources(dryRun, "public.resources_id_seq", []string{"cluster-1", "cluster-2"}))
+	assert.Equal(t, []string{
+		`CREATE TABLE "resources_partitioned" (LIKE "resources" INCLUDING DEFAULTS INCLUDING STORAGE) PARTITION BY LIST ("cluster")`,
+		`CREATE TABLE IF NOT EXISTS "` + resourcesPartition("cluster-1") + `" PARTITION OF "resources_partitioned" FOR VALUES IN ('cluster-1')`,
+		`CREATE TABLE IF NOT EXISTS "` + resourcesPartition("cluster-2") + `" PARTITION OF "resources_partitioned" FOR VALUES IN ('cluster-2')`,
+		`INSERT INTO "resources_partitioned" SELECT * FROM "resources"`,
+		`ALTER SEQUENCE public.resources_id_seq OWNED BY "resources_partitioned"."id"`,
+		`DROP TABLE "resources"`,
+		`ALTER TABLE "resources_partitioned" RENAME TO "resources"`,
+		`ALTER TABLE "resources" ADD PRIMARY KEY ("id", "cluster")`,
+	}, recorder.statements[:8])
+
+	indexes := recorder.statements[8:]
+	assert.Contains(t, indexes, `CREATE INDEX IF NOT EXISTS "idx_cluster" ON "resources" ("cluster")`)
+	assert.Contains(t, indexes, `CREATE INDEX IF NOT EXISTS "idx_object_labels" ON "resources" USING GIN (("object" -> 'metadata' -> 'labels'))`)
+	for _, statement := range indexes {
+		assert.NotContains(t, statement, "CONCURRENTLY", "the indexes of the partitioned table can't be created concurrently")
+	}
+}
+
+func TestResourcesPartitionOfCluster(t *testing.T) {
+	recorder := &statementRecorder{}
+	dryRun := postgresDB.Session(&gorm.Session{DryRun: true, Logger: recorder})
+
+	partition := resourcesPartition("cluster-1")
+	require.NoError(t, createResourcesPartition(dryRun, "cluster-1"))
+	require.NoError(t, dropResourcesPartition(dryRun, "cluster-1"))
+	assert.Equal(t, []string{
+		`CREATE TABLE IF NOT EXISTS "` + partition + `" PARTITION OF "resources" FOR VALUES IN ('cluster-1')`,
+		`DROP TABLE IF EXISTS "` + partition + `"`,
+	}, recorder.statements)
+}
+
+func TestMigrateResourcesPartitioningSkipsPartitionedTable(t *testing.T) {
+	db, mock, err := newMockedPostgresDB()
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM pg_partitioned_table`).WithArgs(resourcesTable).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	require.NoError(t, migrateResourcesPartitioning(db))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
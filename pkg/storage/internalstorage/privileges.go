@@ -0,0 +1,175 @@
+package internalstorage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	readerPolicy = "clusterpedia_reader"
+	writerPolicy = "clusterpedia_writer"
+)
+
+var _ storage.PrivilegeGranter = &StorageFactory{}
+
+// GrantPrivileges grants the reader to read the resources and the writer to write the resources,
+// neither of them can change the tables, so the components should skip the migration.
+func (s *StorageFactory) GrantPrivileges(ctx context.Context, users storage.DatabaseUsers, printOnly bool) ([]string, error) {
+	var statements []string
+	switch s.db.Dialector.Name() {
+	case "postgres":
+		statements = postgresGrantStatements(users, s.tenants)
+		if s.recordChanges {
+			statements = append(statements, postgresChangesGrantStatements(users)...)
+		}
+		if s.watchBookmarks {
+			statements = append(statements, postgresBookmarksGrantStatements(users)...)
+		}
+	case "mysql":
+		statements = mysqlGrantStatements(users, s.db.Migrator().CurrentDatabase())
+		if s.recordChanges {
+			statements = append(statements, mysqlChangesGrantStatements(users, s.db.Migrator().CurrentDatabase())...)
+		}
+		if s.watchBookmarks {
+			statements = append(statements, mysqlBookmarksGrantStatements(users, s.db.Migrator().CurrentDatabase())...)
+		}
+	default:
+		return nil, fmt.Errorf("granting privileges is not supported by %s", s.db.Dialector.Name())
+	}
+
+	if printOnly {
+		return statements, nil
+	}
+	for _, statement := range statements {
+		if err := s.db.WithContext(ctx).Exec(statement).Error; err != nil {
+			return nil, fmt.Errorf("failed to execute %q: %w", statement, err)
+		}
+	}
+	return statements, nil
+}
+
+func postgresGrantStatements(users storage.DatabaseUsers, tenants *tenantRoles) []string {
+	var statements []string
+	if users.Reader != "" {
+		reader := quotePostgresIdentifier(users.Reader)
+		statements = append(statements, fmt.Sprintf("GRANT SELECT ON %s TO %s", resourcesTable, reader))
+
+		if tenants != nil {
+			// the reader reads all of the resources unless it is switched to the role of a tenant
+			statements = append(statements,
+				fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", readerPolicy, resourcesTable),
+				fmt.Sprintf("CREATE POLICY %s ON %s FOR SELECT TO %s USING (true)", readerPolicy, resourcesTable, reader),
+			)
+			for _, role := range tenants.roles {
+				statements = append(statements, fmt.Sprintf("GRANT %s TO %s", quotePostgresIdentifier(role), reader))
+			}
+		}
+	}
+
+	if users.Writer != "" {
+		writer := quotePostgresIdentifier(users.Writer)
+		statements = append(statements,
+			fmt.Sprintf("GRANT SELECT, INSERT, UPDATE, DELETE ON %s TO %s", resourcesTable, writer),
+			fmt.Sprintf("GRANT USAGE, SELECT ON SEQUENCE %s_id_seq TO %s", resourcesTable, writer),
+		)
+
+		if tenants != nil {
+			// the row level security also applies to the writer, which is not the owner of the table
+			statements = append(statements,
+				fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", writerPolicy, resourcesTable),
+				fmt.Sprintf("CREATE POLICY %s ON %s TO %s USING (true) WITH CHECK (true)", writerPolicy, resourcesTable, writer),
+			)
+		}
+	}
+	return statements
+}
+
+func mysqlGrantStatements(users storage.DatabaseUsers, database string) []string {
+	table := fmt.Sprintf("%s.%s", quoteMySQLIdentifier(database), quoteMySQLIdentifier(resourcesTable))
+
+	var statements []string
+	if users.Reader != "" {
+		statements = append(statements, fmt.Sprintf("GRANT SELECT ON %s TO %s", table, mysqlAccount(users.Reader)))
+	}
+	if users.Writer != "" {
+		statements = append(statements, fmt.Sprintf("GRANT SELECT, INSERT, UPDATE, DELETE ON %s TO %s", table, mysqlAccount(users.Writer)))
+	}
+	return statements
+}
+
+// postgresChangesGrantStatements grants the reader to read the changes of the resources,
+// and the writer to record the changes and delete them with the resources.
+func postgresChangesGrantStatements(users storage.DatabaseUsers) []string {
+	var statements []string
+	if users.Reader != "" {
+		statements = append(statements, fmt.Sprintf("GRANT SELECT ON %s TO %s", resourceChangesTable, quotePostgresIdentifier(users.Reader)))
+	}
+	if users.Writer != "" {
+		writer := quotePostgresIdentifier(users.Writer)
+		statements = append(statements,
+			fmt.Sprintf("GRANT SELECT, INSERT, DELETE ON %s TO %s", resourceChangesTable, writer),
+			fmt.Sprintf("GRANT USAGE, SELECT ON SEQUENCE %s_id_seq TO %s", resourceChangesTable, writer),
+		)
+	}
+	return statements
+}
+
+func mysqlChangesGrantStatements(users storage.DatabaseUsers, database string) []string {
+	table := fmt.Sprintf("%s.%s", quoteMySQLIdentifier(database), quoteMySQLIdentifier(resourceChangesTable))
+
+	var statements []string
+	if users.Reader != "" {
+		statements = append(statements, fmt.Sprintf("GRANT SELECT ON %s TO %s", table, mysqlAccount(users.Reader)))
+	}
+	if users.Writer != "" {
+		statements = append(statements, fmt.Sprintf("GRANT SELECT, INSERT, DELETE ON %s TO %s", table, mysqlAccount(users.Writer)))
+	}
+	return statements
+}
+
+// postgresBookmarksGrantStatements grants the writer to save the watch bookmarks and delete them with the resources,
+// the reader doesn't read them.
+func postgresBookmarksGrantStatements(users storage.DatabaseUsers) []string {
+	if users.Writer == "" {
+		return nil
+	}
+	writer := quotePostgresIdentifier(users.Writer)
+	return []string{
+		fmt.Sprintf("GRANT SELECT, INSERT, UPDATE, DELETE ON %s TO %s", watchBookmarksTable, writer),
+		fmt.Sprintf("GRANT USAGE, SELECT ON SEQUENCE %s_id_seq TO %s", watchBookmarksTable, writer),
+	}
+}
+
+func mysqlBookmarksGrantStatements(users storage.DatabaseUsers, database string) []string {
+	if users.Writer == "" {
+		return nil
+	}
+	table := fmt.Sprintf("%s.%s", quoteMySQLIdentifier(database), quoteMySQLIdentifier(watchBookmarksTable))
+	return []string{fmt.Sprintf("GRANT SELECT, INSERT, UPDATE, DELETE ON %s TO %s", table, mysqlAccount(users.Writer))}
+}
+
+// quotePostgresIdentifier quotes the identifier like pgx.Identifier.Sanitize,
+// so the statements are generated without linking the postgres driver.
+func quotePostgresIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(name, "\x00", ""), `"`, `""`) + `"`
+}
+
+func quoteMySQLIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// mysqlAccount returns the account of the user formatted as `<user>@<host>` or `<user>`,
+// the user without host matches any host.
+func mysqlAccount(user string) string {
+	host := "%"
+	if i := strings.LastIndex(user, "@"); i >= 0 {
+		user, host = user[:i], user[i+1:]
+	}
+	quote := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return quote(user) + "@" + quote(host)
+}
@@ -0,0 +1,45 @@
+package internalstorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestPostgresGrantStatements(t *testing.T) {
+	users := storage.DatabaseUsers{Reader: "reader", Writer: "writer"}
+	assert.Equal(t, []string{
+		`GRANT SELECT ON resources TO "reader"`,
+		`GRANT SELECT, INSERT, UPDATE, DELETE ON resources TO "writer"`,
+		`GRANT USAGE, SELECT ON SEQUENCE resources_id_seq TO "writer"`,
+	}, postgresGrantStatements(users, nil))
+
+	tenants := newTenantRoles(&RowLevelSecurityConfig{
+		Enabled:     true,
+		Tenants:     []TenantConfig{{Role: "tenant_a"}},
+		DefaultRole: "guest",
+	})
+	assert.Equal(t, []string{
+		`GRANT SELECT ON resources TO "reader"`,
+		`DROP POLICY IF EXISTS clusterpedia_reader ON resources`,
+		`CREATE POLICY clusterpedia_reader ON resources FOR SELECT TO "reader" USING (true)`,
+		`GRANT "tenant_a" TO "reader"`,
+		`GRANT "guest" TO "reader"`,
+		`GRANT SELECT, INSERT, UPDATE, DELETE ON resources TO "writer"`,
+		`GRANT USAGE, SELECT ON SEQUENCE resources_id_seq TO "writer"`,
+		`DROP POLICY IF EXISTS clusterpedia_writer ON resources`,
+		`CREATE POLICY clusterpedia_writer ON resources TO "writer" USING (true) WITH CHECK (true)`,
+	}, postgresGrantStatements(users, tenants))
+
+	assert.Equal(t, []string{`GRANT SELECT ON resources TO "reader"`}, postgresGrantStatements(storage.DatabaseUsers{Reader: "reader"}, nil))
+}
+
+func TestMySQLGrantStatements(t *testing.T) {
+	users := storage.DatabaseUsers{Reader: "reader", Writer: "writer@10.0.0.%"}
+	assert.Equal(t, []string{
+		"GRANT SELECT ON `clusterpedia`.`resources` TO 'reader'@'%'",
+		"GRANT SELECT, INSERT, UPDATE, DELETE ON `clusterpedia`.`resources` TO 'writer'@'10.0.0.%'",
+	}, mysqlGrantStatements(users, "clusterpedia"))
+}
@@ -2,18 +2,14 @@ package internalstorage
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 
-	"github.com/go-sql-driver/mysql"
-	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jinzhu/configor"
 	"gopkg.in/natefinch/lumberjack.v2"
-	gmysql "gorm.io/driver/mysql"
-	gpostgres "gorm.io/driver/postgres"
-	gsqlite "gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
@@ -38,39 +34,60 @@ func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
 	if err := configor.Load(cfg, configPath); err != nil {
 		return nil, err
 	}
+	if err := cfg.validateRowLevelSecurity(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Compression.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Encryption.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ClusterIsolation.validate(cfg.Type); err != nil {
+		return nil, err
+	}
+	if err := cfg.Partitioning.validate(cfg.Type, cfg.ClusterIsolation); err != nil {
+		return nil, err
+	}
+	if err := cfg.ReadReplicas.validate(cfg.Type); err != nil {
+		return nil, err
+	}
+	if err := cfg.MySQL.validateIndexedLabels(cfg.Type, cfg.ClusterIsolation); err != nil {
+		return nil, err
+	}
+	if err := cfg.IndexAdvisor.validate(cfg.Type, cfg.ClusterIsolation); err != nil {
+		return nil, err
+	}
+	if err := cfg.MaterializedViews.validate(cfg.Type, cfg.ClusterIsolation); err != nil {
+		return nil, err
+	}
+	if cfg.MaterializedViews.enabled() && cfg.rowLevelSecurity() != nil {
+		return nil, errors.New("materialized views can't be enabled with the row level security")
+	}
+	if cfg.ClusterIsolation != nil && cfg.ClusterIsolation.Enabled && cfg.rowLevelSecurity() != nil {
+		return nil, errors.New("cluster isolation can't be enabled with the row level security")
+	}
+	if cfg.Partitioning.enabled() && cfg.IndexAdvisor != nil && cfg.IndexAdvisor.Enabled {
+		// the advised indexes are created concurrently, which is not supported by the partitioned table
+		return nil, errors.New("index advisor can't be used with the partitioning")
+	}
+	if cfg.TombstoneRetention < 0 {
+		return nil, fmt.Errorf("tombstoneRetention must be greater than or equal to 0")
+	}
 
-	var dialector gorm.Dialector
-	switch cfg.Type {
-	case "mysql":
-		mysqlConfig, err := cfg.genMySQLConfig()
-		if err != nil {
-			return nil, err
-		}
-
-		connector, err := mysql.NewConnector(mysqlConfig)
-		if err != nil {
-			return nil, err
-		}
+	credentials, err := newCredentialsProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-		cfg.addMysqlErrorNumbers()
-		dialector = gmysql.New(gmysql.Config{Conn: sql.OpenDB(connector)})
-	case "postgres":
-		pgconfig, err := cfg.genPostgresConfig()
-		if err != nil {
-			return nil, err
-		}
+	// closers are closed when the storage factory is shut down
+	var closers []io.Closer
 
-		cfg.addPostgresErrorCodes()
-		dialector = gpostgres.New(gpostgres.Config{Conn: stdlib.OpenDB(*pgconfig)})
-	case "sqlite", "sqlite3":
-		dsn, err := cfg.genSQLiteDSN()
-		if err != nil {
-			return nil, err
-		}
-		dialector = gsqlite.Open(dsn)
-	default:
-		return nil, fmt.Errorf("not support storage type: %s", cfg.Type)
+	dialector, dialectorClosers, err := openDialector(cfg, credentials)
+	if err != nil {
+		return nil, err
 	}
+	closers = append(closers, dialectorClosers...)
 
 	logger, err := newLogger(cfg)
 	if err != nil {
@@ -104,11 +121,186 @@ func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
 	sqlDB.SetMaxOpenConns(connPool.MaxOpenConns)
 	sqlDB.SetConnMaxLifetime(connPool.ConnMaxLifetime)
 
-	if err := db.AutoMigrate(&Resource{}); err != nil {
+	replicas, err := openReadReplicas(cfg, credentials, logger, connPool)
+	if err != nil {
+		return nil, err
+	}
+	sqlDBs := []*sql.DB{sqlDB}
+	if replicas != nil {
+		closers = append(closers, replicas)
+		for _, replica := range replicas.replicas {
+			replicaDB, err := replica.db.DB()
+			if err != nil {
+				return nil, err
+			}
+			sqlDBs = append(sqlDBs, replicaDB)
+		}
+	}
+
+	rls := cfg.rowLevelSecurity()
+	isolation := newClusterIsolation(cfg.ClusterIsolation)
+	if !cfg.SkipMigration {
+		if err := db.AutoMigrate(&Resource{}); err != nil {
+			return nil, err
+		}
+		if cfg.Partitioning.enabled() {
+			if err := migrateResourcesPartitioning(db); err != nil {
+				return nil, err
+			}
+		}
+		if err := migrateJSONIndexes(db, resourcesTable); err != nil {
+			return nil, err
+		}
+		if err := migrateLabelColumns(db, cfg.indexedLabels()); err != nil {
+			return nil, err
+		}
+		if cfg.Type == "postgres" {
+			if err := migrateMaterializedViews(db, cfg.MaterializedViews, cfg.SoftDelete); err != nil {
+				return nil, err
+			}
+		}
+		if isolation != nil {
+			if err := migrateClusterSchemas(db, isolation); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.RecordFieldChanges {
+			if err := db.AutoMigrate(&ResourceChange{}); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.WatchBookmarks {
+			if err := db.AutoMigrate(&WatchBookmark{}); err != nil {
+				return nil, err
+			}
+		}
+
+		if rls != nil {
+			if err := setupRowLevelSecurity(db, rls); err != nil {
+				return nil, fmt.Errorf("failed to set up row level security: %w", err)
+			}
+		}
+	}
+	if err := checkStorageFormats(db); err != nil {
 		return nil, err
 	}
+	setupLabelColumns(db, cfg.indexedLabels())
+	tenants := newTenantRoles(rls)
+
+	encryptions, closer, err := cfg.Encryption.encryptions()
+	if err != nil {
+		return nil, err
+	}
+	if closer != nil {
+		closers = append(closers, closer)
+	}
+
+	if advisor := newIndexAdvisor(db, cfg.IndexAdvisor); advisor != nil {
+		advisor.Start()
+		closers = append(closers, advisor)
+	}
+	views := newMaterializedViews(db, cfg.MaterializedViews)
+	if views != nil {
+		views.Start()
+		closers = append(closers, views)
+	}
+
+	reloadable, _ := logger.(*reloadableLogger)
+	reloader, err := newConfigReloader(configPath, cfg, sqlDBs, reloadable)
+	if err != nil {
+		return nil, err
+	}
+	closers = append(closers, reloader)
+
+	return &StorageFactory{
+		db:                 db,
+		tenants:            tenants,
+		isolation:          isolation,
+		partitioning:       cfg.Partitioning.enabled(),
+		replicas:           replicas,
+		views:              views,
+		recordChanges:      cfg.RecordFieldChanges,
+		softDelete:         cfg.SoftDelete,
+		watchBookmarks:     cfg.WatchBookmarks,
+		tombstoneRetention: cfg.TombstoneRetention,
+		compressions:       cfg.Compression.compressedResources(),
+		protobuf:           cfg.Protobuf,
+		normalizer:         newJSONNormalizer(cfg.Normalization),
+		indexedLabels:      cfg.indexedLabels(),
+		encryptions:        encryptions,
+		operationMetrics:   !cfg.Metrics.Disable && !cfg.Metrics.DisableOperationMetrics,
+		closers:            closers,
+	}, nil
+}
+
+// openDialector opens the dialector of the database, the returned closers are closed when the storage factory is shut down.
+func openDialector(cfg *Config, credentials *credentialsProvider) (gorm.Dialector, []io.Closer, error) {
+	driver, err := getDatabaseDriver(cfg.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+	return driver.open(cfg, credentials)
+}
+
+// openReadReplicas opens the read replicas with the logger and the connection pool of the primary,
+// nil is returned if no replica is configured.
+func openReadReplicas(cfg *Config, credentials *credentialsProvider, logger logger.Interface, connPool ConnPoolConfig) (*readReplicas, error) {
+	if cfg.ReadReplicas == nil || len(cfg.ReadReplicas.Replicas) == 0 {
+		return nil, nil
+	}
+
+	var closers []io.Closer
+	replicas := make([]*readReplica, 0, len(cfg.ReadReplicas.Replicas))
+	for i, replicaConfig := range cfg.ReadReplicas.Replicas {
+		// the dsn may contain the password, so the replica is named by its index
+		name := replicaConfig.Host
+		if replicaConfig.DSN != "" {
+			name = fmt.Sprintf("replica-%d", i)
+		}
+
+		dialector, dialectorClosers, err := openDialector(cfg.replicaConfig(replicaConfig), credentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the read replica %s: %w", name, err)
+		}
+		closers = append(closers, dialectorClosers...)
 
-	return &StorageFactory{db}, nil
+		db, err := gorm.Open(dialector, &gorm.Config{SkipDefaultTransaction: true, Logger: logger})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the read replica %s: %w", name, err)
+		}
+		if err := db.Use(NewGormTrace(false)); err != nil {
+			return nil, err
+		}
+
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxIdleConns(connPool.MaxIdleConns)
+		sqlDB.SetMaxOpenConns(connPool.MaxOpenConns)
+		sqlDB.SetConnMaxLifetime(connPool.ConnMaxLifetime)
+		replicas = append(replicas, &readReplica{name: name, db: db})
+	}
+	return newReadReplicas(cfg.ReadReplicas, replicas, closers), nil
+}
+
+// migrateClusterSchemas migrates the resources tables of the existing schemas of the clusters,
+// the schemas of the new clusters are migrated when they are prepared.
+func migrateClusterSchemas(db *gorm.DB, isolation *clusterIsolation) error {
+	schemas, err := isolation.schemas(db, nil)
+	if err != nil {
+		return err
+	}
+	for _, schema := range schemas {
+		table := schemaResourcesTable(schema)
+		if err := db.Table(table).AutoMigrate(&Resource{}); err != nil {
+			return fmt.Errorf("failed to migrate the resources of the schema %s: %w", schema, err)
+		}
+		if err := migrateJSONIndexes(db, table); err != nil {
+			return fmt.Errorf("failed to migrate the resources of the schema %s: %w", schema, err)
+		}
+	}
+	return nil
 }
 
 func newLogger(cfg *Config) (logger.Interface, error) {
@@ -138,5 +330,5 @@ func newLogger(cfg *Config) (logger.Interface, error) {
 		logWriter = lumberjackLogger
 	}
 
-	return logger.New(log.New(logWriter, "", log.LstdFlags), loggerConfig), nil
+	return newReloadableLogger(log.New(logWriter, "", log.LstdFlags), loggerConfig), nil
 }
@@ -0,0 +1,180 @@
+package internalstorage
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jinzhu/configor"
+	"gorm.io/gorm/logger"
+	"k8s.io/klog/v2"
+)
+
+// reloadableLogger is the logger of gorm whose config is replaced when the config of the storage is reloaded.
+type reloadableLogger struct {
+	writer  logger.Writer
+	current atomic.Value // logger.Interface
+}
+
+func newReloadableLogger(writer logger.Writer, config logger.Config) *reloadableLogger {
+	l := &reloadableLogger{writer: writer}
+	l.update(config)
+	return l
+}
+
+func (l *reloadableLogger) get() logger.Interface {
+	return l.current.Load().(logger.Interface)
+}
+
+func (l *reloadableLogger) update(config logger.Config) {
+	l.current.Store(logger.New(l.writer, config))
+}
+
+func (l *reloadableLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return l.get().LogMode(level)
+}
+
+func (l *reloadableLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	l.get().Info(ctx, msg, data...)
+}
+
+func (l *reloadableLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	l.get().Warn(ctx, msg, data...)
+}
+
+func (l *reloadableLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	l.get().Error(ctx, msg, data...)
+}
+
+func (l *reloadableLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	l.get().Trace(ctx, begin, fc, err)
+}
+
+// configReloader reloads the config file of the storage after it is changed or the process receives SIGHUP,
+// the connection pool and the log of the database are updated in place, so the components are not restarted
+// and the informers of the clusters are kept.
+//
+// The other fields of the config still require restarting the components, and the log
+// can't be enabled by the reload if it is disabled at startup.
+type configReloader struct {
+	path   string
+	sqlDBs []*sql.DB
+	logger *reloadableLogger
+
+	watcher *fsnotify.Watcher
+	signals chan os.Signal
+	stop    chan struct{}
+	done    chan struct{}
+
+	lock         sync.Mutex
+	connPool     ConnPoolConfig
+	loggerConfig logger.Config
+}
+
+// newConfigReloader watches the directory of the config file, because the config mounted from the configmap
+// is replaced by swapping the symlinks. The sqlDBs are the primary and the read replicas, they share the connection pool config.
+// The logger is nil if the log is disabled.
+func newConfigReloader(path string, cfg *Config, sqlDBs []*sql.DB, l *reloadableLogger) (*configReloader, error) {
+	r := &configReloader{
+		path:    path,
+		sqlDBs:  sqlDBs,
+		logger:  l,
+		signals: make(chan os.Signal, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	var err error
+	if r.connPool, err = cfg.getConnPoolConfig(); err != nil {
+		return nil, err
+	}
+	if r.loggerConfig, err = cfg.LoggerConfig(); err != nil {
+		return nil, err
+	}
+
+	if r.watcher, err = fsnotify.NewWatcher(); err != nil {
+		return nil, err
+	}
+	if err := r.watcher.Add(filepath.Dir(path)); err != nil {
+		r.watcher.Close()
+		return nil, err
+	}
+	signal.Notify(r.signals, syscall.SIGHUP)
+
+	go r.run()
+	return r, nil
+}
+
+func (r *configReloader) run() {
+	defer close(r.done)
+	for {
+		select {
+		case <-r.stop:
+			return
+		case _, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			klog.ErrorS(err, "failed to watch the config file of the storage", "path", r.path)
+			continue
+		case <-r.signals:
+		}
+
+		if err := r.reload(); err != nil {
+			klog.ErrorS(err, "failed to reload the config of the storage, keep using the previous config", "path", r.path)
+		}
+	}
+}
+
+// reload applies the connection pool and the log of the config file if they are changed.
+func (r *configReloader) reload() error {
+	cfg := &Config{}
+	if err := configor.Load(cfg, r.path); err != nil {
+		return err
+	}
+	connPool, err := cfg.getConnPoolConfig()
+	if err != nil {
+		return err
+	}
+	loggerConfig, err := cfg.LoggerConfig()
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if connPool != r.connPool {
+		for _, sqlDB := range r.sqlDBs {
+			sqlDB.SetMaxIdleConns(connPool.MaxIdleConns)
+			sqlDB.SetMaxOpenConns(connPool.MaxOpenConns)
+			sqlDB.SetConnMaxLifetime(connPool.ConnMaxLifetime)
+		}
+		r.connPool = connPool
+		klog.InfoS("the connection pool of the storage is reloaded", "maxIdleConns", connPool.MaxIdleConns,
+			"maxOpenConns", connPool.MaxOpenConns, "connMaxLifetime", connPool.ConnMaxLifetime)
+	}
+	if r.logger != nil && loggerConfig != r.loggerConfig {
+		r.logger.update(loggerConfig)
+		r.loggerConfig = loggerConfig
+		klog.InfoS("the log of the storage is reloaded", "level", loggerConfig.LogLevel, "slowThreshold", loggerConfig.SlowThreshold)
+	}
+	return nil
+}
+
+func (r *configReloader) Close() error {
+	signal.Stop(r.signals)
+	close(r.stop)
+	err := r.watcher.Close()
+	<-r.done
+	return err
+}
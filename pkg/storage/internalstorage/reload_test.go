@@ -0,0 +1,77 @@
+package internalstorage
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gsqlite "gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestConfigReloader(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "internalstorage-config.yaml")
+	writeConfig := func(config string) {
+		if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig("type: sqlite\ndatabase: clusterpedia.db\nconnPool:\n  maxOpenConns: 10\nlog:\n  level: Silent\n")
+
+	cfg := &Config{}
+	cfg.Type, cfg.ConnPool.MaxOpenConns, cfg.Log = "sqlite", 10, &LogConfig{Level: "Silent"}
+	loggerConfig, err := cfg.LoggerConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	gormLogger := newReloadableLogger(log.New(&buf, "", 0), loggerConfig)
+
+	db, err := gorm.Open(gsqlite.Open(filepath.Join(dir, "clusterpedia.db")), &gorm.Config{Logger: gormLogger})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	reloader, err := newConfigReloader(path, cfg, []*sql.DB{sqlDB}, gormLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reloader.Close()
+
+	gormLogger.Info(context.TODO(), "silent")
+	if buf.Len() != 0 {
+		t.Errorf("the log should be silent, got %q", buf.String())
+	}
+
+	writeConfig("type: sqlite\ndatabase: clusterpedia.db\nconnPool:\n  maxOpenConns: 20\nlog:\n  level: Info\n")
+	if err := reloader.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if max := sqlDB.Stats().MaxOpenConnections; max != 20 {
+		t.Errorf("the max open connections should be reloaded to 20, got %d", max)
+	}
+	gormLogger.Info(context.TODO(), "reloaded")
+	if !strings.Contains(buf.String(), "reloaded") {
+		t.Errorf("the log level should be reloaded to Info, got %q", buf.String())
+	}
+
+	// the previous config is kept if the config is invalid
+	writeConfig("type: sqlite\nconnPool:\n  maxOpenConns: 1\n  maxIdleConns: 2\n")
+	if err := reloader.reload(); err == nil {
+		t.Error("reload() should fail if the config is invalid")
+	}
+	if max := sqlDB.Stats().MaxOpenConnections; max != 20 {
+		t.Errorf("the max open connections should be kept, got %d", max)
+	}
+}
@@ -0,0 +1,245 @@
+package internalstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+const (
+	defaultReplicaMaxLag        = 10 * time.Second
+	defaultReplicaCheckInterval = 5 * time.Second
+)
+
+func (c *ReadReplicasConfig) validate(dbType string) error {
+	if c == nil || len(c.Replicas) == 0 {
+		return nil
+	}
+	if dbType != "postgres" && dbType != "mysql" {
+		return errors.New("read replicas are only supported by postgres and mysql")
+	}
+	for i, replica := range c.Replicas {
+		if replica.DSN == "" && replica.Host == "" {
+			return fmt.Errorf("the dsn or the host of the read replica %d is required", i)
+		}
+	}
+	if c.MaxLag < 0 || c.CheckInterval < 0 {
+		return errors.New("maxLag and checkInterval of the read replicas must be greater than or equal to 0")
+	}
+	return nil
+}
+
+// replicaConfig returns the config of the read replica, which shares the settings except the address with the primary.
+func (cfg *Config) replicaConfig(replica ReplicaConfig) *Config {
+	config := *cfg
+	if replica.DSN != "" {
+		config.DSN = replica.DSN
+		return &config
+	}
+
+	config.DSN, config.Host = "", replica.Host
+	if replica.Port != "" {
+		config.Port = replica.Port
+	}
+	return &config
+}
+
+// readReplicas routes the reads of the apiserver to the read replicas in turn,
+// the replicas whose replication lags exceed the max lag are skipped until they catch up,
+// and the reads fall back to the primary when no replica is available.
+// A nil readReplicas means all reads go to the primary.
+type readReplicas struct {
+	maxLag   time.Duration
+	interval time.Duration
+	replicas []*readReplica
+	next     atomic.Uint32
+
+	// lag returns the replication lag of the replica, it is replaced by the tests
+	lag func(ctx context.Context, db *gorm.DB) (time.Duration, error)
+
+	// closers are the tls reloaders of the replicas
+	closers []io.Closer
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type readReplica struct {
+	name      string
+	db        *gorm.DB
+	available atomic.Bool
+}
+
+// newReadReplicas checks the lags of the replicas before returning, so the reads are routed by the lags from the start.
+func newReadReplicas(config *ReadReplicasConfig, replicas []*readReplica, closers []io.Closer) *readReplicas {
+	r := &readReplicas{
+		maxLag:   config.MaxLag,
+		interval: config.CheckInterval,
+		replicas: replicas,
+		lag:      replicationLag,
+		closers:  closers,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if r.maxLag == 0 {
+		r.maxLag = defaultReplicaMaxLag
+	}
+	if r.interval == 0 {
+		r.interval = defaultReplicaCheckInterval
+	}
+	for _, replica := range replicas {
+		// the replicas are assumed available, so the replicas unavailable at startup are logged by the first check
+		replica.available.Store(true)
+	}
+
+	r.check()
+	go r.run()
+	return r
+}
+
+func (r *readReplicas) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.check()
+		}
+	}
+}
+
+func (r *readReplicas) check() {
+	for _, replica := range r.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), r.interval)
+		lag, err := r.lag(ctx, replica.db)
+		cancel()
+
+		available := err == nil && lag <= r.maxLag
+		if replica.available.Swap(available) == available {
+			continue
+		}
+		switch {
+		case available:
+			klog.InfoS("the read replica is available", "replica", replica.name, "lag", lag)
+		case err != nil:
+			klog.ErrorS(err, "the read replica is unavailable, the reads fall back to the other replicas or the primary", "replica", replica.name)
+		default:
+			klog.InfoS("the read replica lags behind the primary, the reads fall back to the other replicas or the primary",
+				"replica", replica.name, "lag", lag, "maxLag", r.maxLag)
+		}
+	}
+}
+
+// reader returns the db of the next available replica, or the primary if no replica is available.
+func (r *readReplicas) reader(primary *gorm.DB) *gorm.DB {
+	if r == nil {
+		return primary
+	}
+
+	n := uint32(len(r.replicas))
+	start := r.next.Add(1)
+	for i := uint32(0); i < n; i++ {
+		if replica := r.replicas[(start+i)%n]; replica.available.Load() {
+			return replica.db
+		}
+	}
+	return primary
+}
+
+func (r *readReplicas) Close() error {
+	close(r.stop)
+	<-r.done
+
+	var errs []error
+	for _, replica := range r.replicas {
+		sqlDB, err := replica.db.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to close the read replica %s: %w", replica.name, err))
+		}
+	}
+	for _, closer := range r.closers {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// replicationLag returns the time that the replica lags behind the primary.
+func replicationLag(ctx context.Context, db *gorm.DB) (time.Duration, error) {
+	switch db.Dialector.Name() {
+	case "postgres":
+		// the replay timestamp is the time of the last replayed transaction,
+		// so the replica that has replayed all received wal is not lagging even if the primary is idle
+		var seconds float64
+		err := db.WithContext(ctx).Raw(`SELECT CASE
+	WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`).Scan(&seconds).Error
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	case "mysql":
+		return mysqlReplicationLag(ctx, db)
+	default:
+		return 0, fmt.Errorf("the replication lag of %s is not supported", db.Dialector.Name())
+	}
+}
+
+// mysqlReplicationLag reads the `Seconds_Behind_Source` of the replica status, it is `Seconds_Behind_Master` before mysql 8.0.22.
+// The replica without the replica status is regarded as not lagging, e.g. the reader endpoint of the managed databases.
+func mysqlReplicationLag(ctx context.Context, db *gorm.DB) (time.Duration, error) {
+	rows, err := db.WithContext(ctx).Raw("SHOW REPLICA STATUS").Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return 0, rows.Err()
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+
+	for i, column := range columns {
+		if column != "Seconds_Behind_Source" && column != "Seconds_Behind_Master" {
+			continue
+		}
+		if !values[i].Valid {
+			return 0, errors.New("the replication of the replica is stopped")
+		}
+		seconds, err := strconv.ParseInt(values[i].String, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, errors.New("the replication lag is not found in the replica status")
+}
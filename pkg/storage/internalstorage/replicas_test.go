@@ -0,0 +1,114 @@
+package internalstorage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestReadReplicasReader(t *testing.T) {
+	primary := &gorm.DB{}
+	if db := (*readReplicas)(nil).reader(primary); db != primary {
+		t.Error("the reads should go to the primary without replicas")
+	}
+
+	replica1, replica2 := &readReplica{name: "replica-1", db: &gorm.DB{}}, &readReplica{name: "replica-2", db: &gorm.DB{}}
+	lags := map[*gorm.DB]time.Duration{replica1.db: time.Second, replica2.db: time.Second}
+	var lagErr error
+	replicas := &readReplicas{
+		maxLag:   10 * time.Second,
+		interval: time.Second,
+		replicas: []*readReplica{replica1, replica2},
+		lag: func(_ context.Context, db *gorm.DB) (time.Duration, error) {
+			if db == replica2.db && lagErr != nil {
+				return 0, lagErr
+			}
+			return lags[db], nil
+		},
+	}
+
+	replicas.check()
+	readers := map[*gorm.DB]int{}
+	for i := 0; i < 4; i++ {
+		readers[replicas.reader(primary)]++
+	}
+	if readers[replica1.db] != 2 || readers[replica2.db] != 2 {
+		t.Errorf("the reads should be routed to the replicas in turn, got %v", readers)
+	}
+
+	lags[replica1.db] = time.Minute
+	lagErr = errors.New("connection refused")
+	replicas.check()
+	if db := replicas.reader(primary); db != primary {
+		t.Error("the reads should fall back to the primary when the replicas are lagging or unavailable")
+	}
+
+	lags[replica1.db] = 0
+	replicas.check()
+	for i := 0; i < 2; i++ {
+		if db := replicas.reader(primary); db != replica1.db {
+			t.Error("the reads should be routed to the replica which catches up")
+		}
+	}
+}
+
+func TestReplicationLag(t *testing.T) {
+	db, mock, err := newMockedPostgresDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(`SELECT CASE`).WillReturnRows(sqlmock.NewRows([]string{"case"}).AddRow(1.5))
+	if lag, err := replicationLag(context.Background(), db); err != nil || lag != 1500*time.Millisecond {
+		t.Errorf("unexpected postgres lag %v, err: %v", lag, err)
+	}
+
+	db, mock, err = newMockedMySQLDB("8.0.33")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery("SHOW REPLICA STATUS").WillReturnRows(
+		sqlmock.NewRows([]string{"Replica_IO_State", "Seconds_Behind_Source"}).AddRow("Waiting for source to send event", "3"))
+	if lag, err := replicationLag(context.Background(), db); err != nil || lag != 3*time.Second {
+		t.Errorf("unexpected mysql lag %v, err: %v", lag, err)
+	}
+
+	mock.ExpectQuery("SHOW REPLICA STATUS").WillReturnRows(
+		sqlmock.NewRows([]string{"Replica_IO_State", "Seconds_Behind_Source"}).AddRow("", nil))
+	if _, err := replicationLag(context.Background(), db); err == nil {
+		t.Error("the stopped replication should be an error")
+	}
+
+	mock.ExpectQuery("SHOW REPLICA STATUS").WillReturnRows(sqlmock.NewRows([]string{"Replica_IO_State", "Seconds_Behind_Source"}))
+	if lag, err := replicationLag(context.Background(), db); err != nil || lag != 0 {
+		t.Errorf("the replica without the replica status should not be lagging, lag: %v, err: %v", lag, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReadReplicasConfig(t *testing.T) {
+	config := &ReadReplicasConfig{Replicas: []ReplicaConfig{{Host: "replica"}}}
+	if err := config.validate("sqlite"); err == nil {
+		t.Error("read replicas should not be supported by sqlite")
+	}
+	if err := config.validate("postgres"); err != nil {
+		t.Error(err)
+	}
+	if err := (&ReadReplicasConfig{Replicas: []ReplicaConfig{{Port: "5432"}}}).validate("postgres"); err == nil {
+		t.Error("the address of the replica should be required")
+	}
+
+	cfg := &Config{Type: "postgres", Host: "primary", Port: "5432", User: "clusterpedia", Database: "clusterpedia"}
+	replica := cfg.replicaConfig(ReplicaConfig{Host: "replica"})
+	if replica.Host != "replica" || replica.Port != "5432" || replica.User != "clusterpedia" || cfg.Host != "primary" {
+		t.Errorf("unexpected replica config %+v", replica)
+	}
+	if replica := cfg.replicaConfig(ReplicaConfig{DSN: "host=replica"}); replica.DSN != "host=replica" {
+		t.Errorf("the dsn of the replica should override the primary, got %q", replica.DSN)
+	}
+}
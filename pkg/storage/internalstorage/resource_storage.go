@@ -7,12 +7,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
-	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,15 +32,52 @@ import (
 	internal "github.com/clusterpedia-io/api/clusterpedia"
 	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
 )
 
 type ResourceStorage struct {
 	groupResource schema.GroupResource
 
-	db     *gorm.DB
-	config storage.ResourceStorageConfig
+	db      *gorm.DB
+	tenants *tenantRoles
+	config  storage.ResourceStorageConfig
+
+	// isolation stores the resources of each cluster in its own schema, nil if it is disabled
+	isolation *clusterIsolation
+
+	// replicas serve the reads of the apiserver, nil if no read replica is configured
+	replicas *readReplicas
+
+	// recordChanges records the changed fields of the updates
+	recordChanges bool
+
+	// softDelete keeps the deleted objects as the tombstones
+	softDelete bool
+
+	// tombstoneRetention is how long the tombstones are kept, 0 if they are kept until they are purged
+	tombstoneRetention time.Duration
+
+	// tombstonesPurgedAt is the last time the expired tombstones of the clusters are purged
+	tombstonesPurgedAt sync.Map
+
+	// compression is the compression algorithm of the objects, empty if the objects are not compressed
+	compression string
+
+	// protobuf stores the objects in protobuf, it is only set for the built-in resources
+	protobuf bool
+
+	// normalizer normalizes the json of the stored objects, nil if the normalization is disabled
+	normalizer *jsonNormalizer
+
+	// encryption encrypts the objects, nil if the objects are not encrypted
+	encryption *envelope
+
+	// metrics records the metrics of the operations, nil if the metrics are disabled
+	metrics *operationMetrics
 }
 
+var _ storage.ResourceCounter = &ResourceStorage{}
+
 func (s *ResourceStorage) GetStorageConfig() *storage.ResourceStorageConfig {
 	config := s.config
 	return &config
@@ -64,7 +102,9 @@ func (s *ResourceStorage) resourceKeyMap(cluster, namespace, name string) map[st
 	}
 }
 
-func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) error {
+func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) (err error) {
+	defer func(start time.Time) { s.metrics.observe(operationCreate, cluster, start, 1, err) }(time.Now())
+
 	gvk := obj.GetObjectKind().GroupVersionKind()
 	if gvk.Kind == "" {
 		return fmt.Errorf("%s: kind is required", gvk)
@@ -80,8 +120,16 @@ func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtim
 		ownerUID = owner.UID
 	}
 
-	var buffer bytes.Buffer
-	if err := s.config.Codec.Encode(obj, &buffer); err != nil {
+	data, err := s.encodeJSON(obj)
+	if err != nil {
+		return err
+	}
+	specHash, err := s.specHash(data)
+	if err != nil {
+		return err
+	}
+	stored, err := s.encodeObject(cluster, metaobj, obj, data)
+	if err != nil {
 		return err
 	}
 
@@ -96,26 +144,74 @@ func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtim
 		Version:         s.config.StorageResource.Version,
 		Kind:            gvk.Kind,
 		ResourceVersion: metaobj.GetResourceVersion(),
-		Object:          buffer.Bytes(),
+		Object:          stored.Object,
+		SpecHash:        specHash,
+		FormatVersion:   stored.formatVersion(),
 		CreatedAt:       metaobj.GetCreationTimestamp().Time,
+
+		CompressedObject: stored.CompressedObject,
+		Compression:      stored.Compression,
+		Encoding:         stored.Encoding,
+		Encryption:       stored.Encryption,
 	}
 	if deletedAt := metaobj.GetDeletionTimestamp(); deletedAt != nil {
 		resource.DeletedAt = sql.NullTime{Time: deletedAt.Time, Valid: true}
 	}
 
-	result := s.db.WithContext(ctx).Create(&resource)
-	return InterpretResourceDBError(cluster, metaobj.GetName(), result.Error)
+	if !s.softDelete {
+		result := s.isolation.table(s.db.WithContext(ctx), cluster).Create(&resource)
+		return InterpretResourceDBError(cluster, metaobj.GetName(), result.Error)
+	}
+
+	// the tombstone is deleted in the same transaction, so that it is kept if the object fails to be created
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.deleteTombstone(tx, cluster, resource.Namespace, resource.Name); err != nil {
+			return err
+		}
+		return s.isolation.table(tx, cluster).Create(&resource).Error
+	})
+	return InterpretResourceDBError(cluster, metaobj.GetName(), err)
 }
 
-func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) error {
-	metaobj, err := meta.Accessor(obj)
+func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) (err error) {
+	defer func(start time.Time) { s.metrics.observe(operationUpdate, cluster, start, 1, err) }(time.Now())
+
+	update, err := s.newResourceUpdate(cluster, obj)
 	if err != nil {
 		return err
 	}
+	return s.update(ctx, cluster, update)
+}
 
-	var buffer bytes.Buffer
-	if err := s.config.Codec.Encode(obj, &buffer); err != nil {
-		return err
+// resourceUpdate is the updated columns of the object
+type resourceUpdate struct {
+	metaobj metav1.Object
+
+	// data is the object encoded in json, stored is the object encoded for the storage
+	data   []byte
+	stored storedObject
+
+	columns map[string]interface{}
+}
+
+func (s *ResourceStorage) newResourceUpdate(cluster string, obj runtime.Object) (*resourceUpdate, error) {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.encodeJSON(obj)
+	if err != nil {
+		return nil, err
+	}
+	specHash, err := s.specHash(data)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.encodeObject(cluster, metaobj, obj, data)
+	if err != nil {
+		return nil, err
 	}
 
 	var ownerUID types.UID
@@ -129,19 +225,99 @@ func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtim
 		"owner_uid":        ownerUID,
 		"uid":              metaobj.GetUID(),
 		"resource_version": metaobj.GetResourceVersion(),
-		"object":           datatypes.JSON(buffer.Bytes()),
+		"object":           stored.Object,
+		"spec_hash":        specHash,
+		"format_version":   stored.formatVersion(),
 		"created_at":       metaobj.GetCreationTimestamp().Time,
+
+		"compressed_object": stored.CompressedObject,
+		"compression":       stored.Compression,
+		"encoding":          stored.Encoding,
+		"encryption":        stored.Encryption,
 	}
 	if deletedAt := metaobj.GetDeletionTimestamp(); deletedAt != nil {
 		updatedResource["deleted_at"] = sql.NullTime{Time: deletedAt.Time, Valid: true}
 	}
+	return &resourceUpdate{metaobj: metaobj, data: data, stored: stored, columns: updatedResource}, nil
+}
+
+func (s *ResourceStorage) update(ctx context.Context, cluster string, update *resourceUpdate) error {
+	metaobj := update.metaobj
+	if s.recordChanges {
+		err := s.updateWithChanges(ctx, cluster, metaobj.GetNamespace(), metaobj.GetName(), update.data, update.columns)
+		return InterpretResourceDBError(cluster, metaobj.GetName(), err)
+	}
 
-	result := s.db.WithContext(ctx).Model(&Resource{}).
+	result := s.isolation.table(s.db.WithContext(ctx), cluster).Model(&Resource{}).
 		Where(s.resourceKeyMap(cluster, metaobj.GetNamespace(), metaobj.GetName())).
-		Updates(updatedResource)
+		Updates(update.columns)
 	return InterpretResourceDBError(cluster, metaobj.GetName(), result.Error)
 }
 
+var _ storage.ResourcePatcher = &ResourceStorage{}
+
+// UpdateWithPatch sets the changed top-level fields of the json object by the json functions of the database,
+// and the other columns are updated as the Update. The object is fully updated if it is compressed, encoded or encrypted,
+// or the changes are recorded or the defaulted fields are stripped, which are computed from the whole object.
+func (s *ResourceStorage) UpdateWithPatch(ctx context.Context, cluster string, obj runtime.Object, patch storage.ResourcePatch) (err error) {
+	defer func(start time.Time) { s.metrics.observe(operationPatch, cluster, start, 1, err) }(time.Now())
+
+	update, err := s.newResourceUpdate(cluster, obj)
+	if err != nil {
+		return err
+	}
+	if s.recordChanges || update.stored.CompressedObject != nil || s.normalizer.strippingDefaults() || patch.PreviousResourceVersion == "" {
+		return s.update(ctx, cluster, update)
+	}
+	object, ok, err := patchedJSONObject(s.db.Dialector.Name(), update.data, patch.ChangedFields)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return s.update(ctx, cluster, update)
+	}
+
+	metaobj := update.metaobj
+	columns := maps.Clone(update.columns)
+	columns["object"] = object
+	result := s.isolation.table(s.db.WithContext(ctx), cluster).Model(&Resource{}).
+		Where(s.resourceKeyMap(cluster, metaobj.GetNamespace(), metaobj.GetName())).
+		Where(map[string]interface{}{
+			"resource_version": patch.PreviousResourceVersion,
+			"format_version":   CurrentStorageFormat,
+			"compression":      "",
+			"encoding":         "",
+			"encryption":       "",
+		}).
+		Updates(columns)
+	if result.Error != nil {
+		return InterpretResourceDBError(cluster, metaobj.GetName(), result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// the stored object is not at the previous resource version or is compressed by the previous configs
+		return s.update(ctx, cluster, update)
+	}
+	return nil
+}
+
+// encodeJSON encodes the object in json by the codec, and the json is normalized if the normalization is enabled.
+func (s *ResourceStorage) encodeJSON(obj runtime.Object) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := s.config.Codec.Encode(obj, &buffer); err != nil {
+		return nil, err
+	}
+	return s.normalizer.normalize(buffer.Bytes())
+}
+
+// specHash returns the spec hash of the object, the hash is not stored for the encrypted objects,
+// because the hash of the small secrets can be brute-forced, they are hashed when the spec hashes are listed.
+func (s *ResourceStorage) specHash(data []byte) (string, error) {
+	if s.encryption != nil {
+		return "", nil
+	}
+	return utils.SpecHashFromJSON(data)
+}
+
 func (s *ResourceStorage) ConvertDeletedObject(obj interface{}) (runtime.Object, error) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -159,38 +335,132 @@ func (s *ResourceStorage) ConvertDeletedObject(obj interface{}) (runtime.Object,
 }
 
 func (s *ResourceStorage) deleteObject(cluster, namespace, name string) *gorm.DB {
-	return s.db.Model(&Resource{}).Where(s.resourceKeyMap(cluster, namespace, name)).Delete(&Resource{})
+	return s.isolation.table(s.db, cluster).Model(&Resource{}).Where(s.resourceKeyMap(cluster, namespace, name)).Delete(&Resource{})
 }
 
-func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) error {
+func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) (err error) {
+	defer func(start time.Time) { s.metrics.observe(operationDelete, cluster, start, 1, err) }(time.Now())
+
 	metaobj, err := meta.Accessor(obj)
 	if err != nil {
 		return err
 	}
 
+	if s.softDelete {
+		result := s.removeObject(ctx, cluster, metaobj.GetNamespace(), metaobj.GetName())
+		if result.Error != nil {
+			return InterpretResourceDBError(cluster, metaobj.GetName(), result.Error)
+		}
+		s.purgeExpiredTombstones(ctx, cluster)
+		return nil
+	}
+
 	if result := s.deleteObject(cluster, metaobj.GetNamespace(), metaobj.GetName()); result.Error != nil {
 		return InterpretResourceDBError(cluster, metaobj.GetName(), result.Error)
 	}
+	if s.recordChanges {
+		result := s.db.WithContext(ctx).Where(s.resourceKeyMap(cluster, metaobj.GetNamespace(), metaobj.GetName())).Delete(&ResourceChange{})
+		return InterpretResourceDBError(cluster, metaobj.GetName(), result.Error)
+	}
 	return nil
 }
 
-func (s *ResourceStorage) genGetObjectQuery(ctx context.Context, cluster, namespace, name string) *gorm.DB {
-	return s.db.WithContext(ctx).Model(&Resource{}).Select("object").Where(s.resourceKeyMap(cluster, namespace, name))
+var _ storage.CollectionDeleter = &ResourceStorage{}
+
+// DeleteCollection deletes the stored objects matched by the options, the objects are marked as the tombstones by the soft delete.
+// The objects still existing in the clusters are stored again when they are updated or the resources are relisted.
+func (s *ResourceStorage) DeleteCollection(ctx context.Context, opts storage.DeleteCollectionOptions) (deleted int64, err error) {
+	defer func(start time.Time) {
+		s.metrics.observe(operationDeleteCollection, operationCluster(opts.Clusters), start, deleted, err)
+	}(time.Now())
+
+	if s.isolation == nil {
+		return s.deleteCollection(s.db.WithContext(ctx), opts)
+	}
+
+	schemas, err := s.isolation.schemas(s.db.WithContext(ctx), opts.Clusters)
+	if err != nil {
+		return 0, InterpretDBError(s.groupResource.String(), err)
+	}
+	for _, schema := range schemas {
+		count, err := s.deleteCollection(s.db.WithContext(ctx).Table(schemaResourcesTable(schema)), opts)
+		deleted += count
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
 }
 
-func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, into runtime.Object) error {
+func (s *ResourceStorage) deleteCollection(db *gorm.DB, opts storage.DeleteCollectionOptions) (int64, error) {
+	var deleted int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		query := excludeTombstones(tx.Model(&Resource{}).Where(s.gvrKeyMap()), s.softDelete)
+		listOpts := &internal.ListOptions{ClusterNames: opts.Clusters, Namespaces: opts.Namespaces}
+		listOpts.LabelSelector = opts.LabelSelector
+		_, _, query, err := applyListOptionsToQuery(query, listOpts, nil)
+		if err != nil {
+			return err
+		}
+		query = query.Session(&gorm.Session{})
+
+		if s.softDelete {
+			result := query.UpdateColumn("removed_at", sql.NullTime{Time: time.Now().UTC(), Valid: true})
+			deleted = result.RowsAffected
+			return result.Error
+		}
+
+		if s.recordChanges {
+			var resources []Resource
+			if err := query.Select("cluster", "namespace", "name").Find(&resources).Error; err != nil {
+				return err
+			}
+			for _, resource := range resources {
+				if err := tx.Where(s.resourceKeyMap(resource.Cluster, resource.Namespace, resource.Name)).Delete(&ResourceChange{}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		result := query.Delete(&Resource{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, InterpretDBError(s.groupResource.String(), err)
+}
+
+func (s *ResourceStorage) genGetObjectQuery(db *gorm.DB, cluster, namespace, name string) *gorm.DB {
+	return excludeTombstones(db.Model(&Resource{}).Select(storedObjectColumns).Where(s.resourceKeyMap(cluster, namespace, name)), s.softDelete)
+}
+
+func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, into runtime.Object) (err error) {
+	defer func(start time.Time) { s.metrics.observe(operationGet, cluster, start, 1, err) }(time.Now())
+
 	ctx, span := tracing.Start(ctx, "Get from internalstorage",
 		attribute.String("storage resource", s.config.StorageResource.String()),
 		attribute.String("target type", fmt.Sprintf("%T", into)),
 	)
 
-	var objects [][]byte
-	if result := s.genGetObjectQuery(ctx, cluster, namespace, name).First(&objects); result.Error != nil {
+	db, done, err := s.tenants.begin(ctx, s.replicas.reader(s.db))
+	if err != nil {
+		return InterpretResourceDBError(cluster, namespace+"/"+name, err)
+	}
+	defer done()
+	if db, err = s.isolation.tables(db, []string{cluster}); err != nil {
+		return InterpretResourceDBError(cluster, namespace+"/"+name, err)
+	}
+
+	var objects []storedObject
+	if result := s.genGetObjectQuery(db, cluster, namespace, name).First(&objects); result.Error != nil {
 		return InterpretResourceDBError(cluster, namespace+"/"+name, result.Error)
 	}
+	object, err := objects[0].object()
+	if err != nil {
+		return err
+	}
 
 	span.AddEvent("About to decode object")
-	obj, _, err := s.config.Codec.Decode(objects[0], nil, into)
+	obj, err := object.ConvertTo(s.config.Codec, into)
 	if err != nil {
 		return err
 	}
@@ -200,7 +470,7 @@ func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name stri
 	return nil
 }
 
-func (s *ResourceStorage) genListObjectsQuery(ctx context.Context, opts *internal.ListOptions) (int64, *int64, *gorm.DB, ObjectList, error) {
+func (s *ResourceStorage) genListObjectsQuery(db *gorm.DB, opts *internal.ListOptions) (int64, *int64, *gorm.DB, ObjectList, error) {
 	var result ObjectList
 	switch {
 	case opts.OnlyMetadata && opts.InjectEvents:
@@ -213,20 +483,38 @@ func (s *ResourceStorage) genListObjectsQuery(ctx context.Context, opts *interna
 		result = &BytesList{}
 	}
 
-	db := s.db.WithContext(ctx)
-	query := db.Model(&Resource{}).Where(s.gvrKeyMap())
+	if err := s.validateCompressedQuery(opts); err != nil {
+		return 0, nil, nil, nil, err
+	}
+	db, err := s.isolation.tables(db, opts.ClusterNames)
+	if err != nil {
+		return 0, nil, nil, nil, InterpretDBError(s.groupResource.String(), err)
+	}
+
+	query := excludeTombstones(db.Model(&Resource{}).Where(s.gvrKeyMap()), s.softDelete)
 	offset, amount, query, err := applyListOptionsToResourceQuery(db, query, opts)
 	return offset, amount, query, result, err
 }
 
-func (s *ResourceStorage) List(ctx context.Context, listObject runtime.Object, opts *internal.ListOptions) error {
+func (s *ResourceStorage) List(ctx context.Context, listObject runtime.Object, opts *internal.ListOptions) (err error) {
+	var listed int64
+	defer func(start time.Time) {
+		s.metrics.observe(operationList, operationCluster(opts.ClusterNames), start, listed, err)
+	}(time.Now())
+
 	ctx, span := tracing.Start(ctx, "List from internalstorage",
 		attribute.String("storage resource", s.config.StorageResource.String()),
 		attribute.String("target type", fmt.Sprintf("%T", listObject)),
 	)
 	defer span.End(500 * time.Millisecond)
 
-	offset, amount, query, result, err := s.genListObjectsQuery(ctx, opts)
+	db, done, err := s.tenants.begin(ctx, s.replicas.reader(s.db))
+	if err != nil {
+		return InterpretDBError(s.groupResource.String(), err)
+	}
+	defer done()
+
+	offset, amount, query, result, err := s.genListObjectsQuery(db, opts)
 	if err != nil {
 		return err
 	}
@@ -235,6 +523,7 @@ func (s *ResourceStorage) List(ctx context.Context, listObject runtime.Object, o
 		return InterpretDBError(s.groupResource.String(), err)
 	}
 	objects := result.Items()
+	listed = int64(len(objects))
 
 	list, err := meta.ListAccessor(listObject)
 	if err != nil {
@@ -330,7 +619,102 @@ func (s *ResourceStorage) Watch(_ context.Context, _ *internal.ListOptions) (wat
 
 var codec = scheme.LegacyResourceCodecs.LegacyCodec(corev1.SchemeGroupVersion)
 
-func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event *corev1.Event) error {
+func (s *ResourceStorage) Count(ctx context.Context, opts *internal.ListOptions) (_ int64, err error) {
+	defer func(start time.Time) {
+		s.metrics.observe(operationCount, operationCluster(opts.ClusterNames), start, 0, err)
+	}(time.Now())
+
+	db, done, err := s.tenants.begin(ctx, s.replicas.reader(s.db))
+	if err != nil {
+		return 0, InterpretDBError(s.groupResource.String(), err)
+	}
+	defer done()
+
+	if err := s.validateCompressedQuery(opts); err != nil {
+		return 0, err
+	}
+	if db, err = s.isolation.tables(db, opts.ClusterNames); err != nil {
+		return 0, InterpretDBError(s.groupResource.String(), err)
+	}
+
+	countOpts := *opts
+	countOpts.Limit, countOpts.Continue, countOpts.OrderBy, countOpts.WithRemainingCount = 0, "", nil, nil
+	query := excludeTombstones(db.Model(&Resource{}).Where(s.gvrKeyMap()), s.softDelete)
+	_, _, query, err = applyListOptionsToResourceQuery(db, query, &countOpts)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, InterpretDBError(s.groupResource.String(), err)
+	}
+	return count, nil
+}
+
+func (s *ResourceStorage) ListSpecHashes(ctx context.Context, clusters []string, namespace, name string) (map[string]string, error) {
+	db, done, err := s.tenants.begin(ctx, s.db)
+	if err != nil {
+		return nil, InterpretDBError("", err)
+	}
+	defer done()
+	if db, err = s.isolation.tables(db, clusters); err != nil {
+		return nil, InterpretDBError("", err)
+	}
+
+	query := db.Model(&Resource{}).
+		Where(s.gvrKeyMap()).Where(map[string]interface{}{"namespace": namespace, "name": name})
+	query = excludeTombstones(query, s.softDelete)
+	if len(clusters) != 0 {
+		query = query.Where("cluster IN ?", clusters)
+	}
+
+	var resources []Resource
+	if result := query.Select("cluster", "spec_hash").Find(&resources); result.Error != nil {
+		return nil, InterpretDBError("", result.Error)
+	}
+
+	hashes := make(map[string]string, len(resources))
+	var unhashed []string
+	for _, resource := range resources {
+		hashes[resource.Cluster] = resource.SpecHash
+		if resource.SpecHash == "" {
+			unhashed = append(unhashed, resource.Cluster)
+		}
+	}
+	if len(unhashed) == 0 {
+		return hashes, nil
+	}
+
+	// the objects stored before the spec hash is introduced are hashed from their content
+	resources = nil
+	result := excludeTombstones(db.Model(&Resource{}), s.softDelete).Select(storedObjectColumns).
+		Where(s.gvrKeyMap()).Where(map[string]interface{}{"namespace": namespace, "name": name}).
+		Where("cluster IN ?", unhashed).Find(&resources)
+	if result.Error != nil {
+		return nil, InterpretDBError("", result.Error)
+	}
+	for _, resource := range resources {
+		data, err := resource.storedObject().decode()
+		if err != nil {
+			return nil, err
+		}
+		// the objects decoded from protobuf are not normalized
+		if data, err = s.normalizer.normalize(data); err != nil {
+			return nil, err
+		}
+		hash, err := utils.SpecHashFromJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		hashes[resource.Cluster] = hash
+	}
+	return hashes, nil
+}
+
+func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event *corev1.Event) (err error) {
+	defer func(start time.Time) { s.metrics.observe(operationRecordEvent, cluster, start, 1, err) }(time.Now())
+
 	if event.InvolvedObject.UID == "" {
 		return errors.New("invalid event: involedObject.UID is empty")
 	}
@@ -341,7 +725,7 @@ func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event
 	}
 	key, _ := cache.MetaNamespaceKeyFunc(event)
 
-	if err := s.db.WithContext(ctx).Model(&Resource{}).Where(
+	if err := s.isolation.table(s.db.WithContext(ctx), cluster).Model(&Resource{}).Where(
 		map[string]interface{}{"cluster": cluster, "uid": event.InvolvedObject.UID},
 	).UpdateColumns(map[string]interface{}{
 		"events":                  JSONUpdate("events", string(event.UID), buffer.Bytes()),
@@ -353,9 +737,18 @@ func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event
 }
 
 func (s *ResourceStorage) GetResourceEvents(ctx context.Context, cluster, namespace, name string) ([]*corev1.Event, error) {
-	var data []EventsBytes
+	db, done, err := s.tenants.begin(ctx, s.replicas.reader(s.db))
+	if err != nil {
+		return nil, InterpretResourceDBError(cluster, namespace+"/"+name, err)
+	}
+	defer done()
+	if db, err = s.isolation.tables(db, []string{cluster}); err != nil {
+		return nil, InterpretResourceDBError(cluster, namespace+"/"+name, err)
+	}
 
-	result := s.db.WithContext(ctx).Model(&Resource{}).Select("events").Where(s.resourceKeyMap(cluster, namespace, name)).First(&data)
+	var data []EventsBytes
+	query := excludeTombstones(db.Model(&Resource{}), s.softDelete)
+	result := query.Select("events").Where(s.resourceKeyMap(cluster, namespace, name)).First(&data)
 	if result.Error != nil {
 		return nil, InterpretResourceDBError(cluster, namespace+"/"+name, result.Error)
 	}
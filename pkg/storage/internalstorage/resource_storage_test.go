@@ -12,8 +12,10 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 
 	internal "github.com/clusterpedia-io/api/clusterpedia"
 	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig"
@@ -202,8 +204,8 @@ func TestResourceStorage_genGetObjectQuery(t *testing.T) {
 			"",
 			"",
 			expected{
-				`SELECT "object" FROM "resources" WHERE "resources"."cluster" = '' AND "resources"."group" = '' AND "resources"."name" = '' AND "resources"."namespace" = '' AND "resources"."resource" = '' AND "resources"."version" = '' ORDER BY "resources"."id" LIMIT 1`,
-				"SELECT `object` FROM `resources` WHERE `resources`.`cluster` = '' AND `resources`.`group` = '' AND `resources`.`name` = '' AND `resources`.`namespace` = '' AND `resources`.`resource` = '' AND `resources`.`version` = '' ORDER BY `resources`.`id` LIMIT 1",
+				`SELECT "object","compressed_object","compression","encoding","encryption","cluster","group","version","resource","namespace","name" FROM "resources" WHERE "resources"."cluster" = '' AND "resources"."group" = '' AND "resources"."name" = '' AND "resources"."namespace" = '' AND "resources"."resource" = '' AND "resources"."version" = '' ORDER BY "resources"."id" LIMIT 1`,
+				"SELECT `object`,`compressed_object`,`compression`,`encoding`,`encryption`,`cluster`,`group`,`version`,`resource`,`namespace`,`name` FROM `resources` WHERE `resources`.`cluster` = '' AND `resources`.`group` = '' AND `resources`.`name` = '' AND `resources`.`namespace` = '' AND `resources`.`resource` = '' AND `resources`.`version` = '' ORDER BY `resources`.`id` LIMIT 1",
 				"",
 			},
 		},
@@ -214,8 +216,8 @@ func TestResourceStorage_genGetObjectQuery(t *testing.T) {
 			"ns-1",
 			"resource-1",
 			expected{
-				`SELECT "object" FROM "resources" WHERE "resources"."cluster" = 'cluster-1' AND "resources"."group" = 'apps' AND "resources"."name" = 'resource-1' AND "resources"."namespace" = 'ns-1' AND "resources"."resource" = 'deployments' AND "resources"."version" = 'v1' ORDER BY "resources"."id" LIMIT 1`,
-				"SELECT `object` FROM `resources` WHERE `resources`.`cluster` = 'cluster-1' AND `resources`.`group` = 'apps' AND `resources`.`name` = 'resource-1' AND `resources`.`namespace` = 'ns-1' AND `resources`.`resource` = 'deployments' AND `resources`.`version` = 'v1' ORDER BY `resources`.`id` LIMIT 1",
+				`SELECT "object","compressed_object","compression","encoding","encryption","cluster","group","version","resource","namespace","name" FROM "resources" WHERE "resources"."cluster" = 'cluster-1' AND "resources"."group" = 'apps' AND "resources"."name" = 'resource-1' AND "resources"."namespace" = 'ns-1' AND "resources"."resource" = 'deployments' AND "resources"."version" = 'v1' ORDER BY "resources"."id" LIMIT 1`,
+				"SELECT `object`,`compressed_object`,`compression`,`encoding`,`encryption`,`cluster`,`group`,`version`,`resource`,`namespace`,`name` FROM `resources` WHERE `resources`.`cluster` = 'cluster-1' AND `resources`.`group` = 'apps' AND `resources`.`name` = 'resource-1' AND `resources`.`namespace` = 'ns-1' AND `resources`.`resource` = 'deployments' AND `resources`.`version` = 'v1' ORDER BY `resources`.`id` LIMIT 1",
 				"",
 			},
 		},
@@ -224,7 +226,7 @@ func TestResourceStorage_genGetObjectQuery(t *testing.T) {
 		t.Run(fmt.Sprintf("%s postgres", test.name), func(t *testing.T) {
 			postgreSQL := postgresDB.ToSQL(func(tx *gorm.DB) *gorm.DB {
 				rs := newTestResourceStorage(tx, test.resource)
-				return rs.genGetObjectQuery(context.TODO(), test.cluster, test.namespace, test.resourceName).First(interface{}(nil))
+				return rs.genGetObjectQuery(rs.db.WithContext(context.TODO()), test.cluster, test.namespace, test.resourceName).First(interface{}(nil))
 			})
 
 			if postgreSQL != test.expected.postgres {
@@ -236,7 +238,7 @@ func TestResourceStorage_genGetObjectQuery(t *testing.T) {
 			t.Run(fmt.Sprintf("%s mysql-%s", test.name, version), func(t *testing.T) {
 				mysqlSQL := mysqlDBs[version].ToSQL(func(tx *gorm.DB) *gorm.DB {
 					rs := newTestResourceStorage(tx, test.resource)
-					return rs.genGetObjectQuery(context.TODO(), test.cluster, test.namespace, test.resourceName).First(interface{}(nil))
+					return rs.genGetObjectQuery(rs.db.WithContext(context.TODO()), test.cluster, test.namespace, test.resourceName).First(interface{}(nil))
 				})
 
 				if mysqlSQL != test.expected.mysql {
@@ -270,7 +272,7 @@ func TestResourceStorage_genListObjectQuery(t *testing.T) {
 			postgreSQL, err := toSQL(postgresDB.Session(&gorm.Session{DryRun: true}), test.listOptions,
 				func(db *gorm.DB, options *internal.ListOptions) (*gorm.DB, error) {
 					rs := newTestResourceStorage(db, test.resource)
-					_, _, query, _, err := rs.genListObjectsQuery(context.TODO(), options)
+					_, _, query, _, err := rs.genListObjectsQuery(rs.db.WithContext(context.TODO()), options)
 					return query, err
 				},
 			)
@@ -286,7 +288,7 @@ func TestResourceStorage_genListObjectQuery(t *testing.T) {
 				mysqlSQL, err := toSQL(mysqlDBs[version].Session(&gorm.Session{DryRun: true}), test.listOptions,
 					func(db *gorm.DB, options *internal.ListOptions) (*gorm.DB, error) {
 						rs := newTestResourceStorage(db, test.resource)
-						_, _, query, _, err := rs.genListObjectsQuery(context.TODO(), options)
+						_, _, query, _, err := rs.genListObjectsQuery(rs.db.WithContext(context.TODO()), options)
 						return query, err
 					},
 				)
@@ -463,6 +465,170 @@ func TestResourceStorage_Update(t *testing.T) {
 	require.Len(resourcesAfterUpdates, 1)
 	assert.NotEmpty(resourcesAfterUpdates[0].Object)
 	assert.NotEqual(resourcesAfterUpdates[0].Object, resourcesAfterCreation[0].Object)
+	assert.NotEmpty(resourcesAfterUpdates[0].SpecHash)
+	assert.NotEqual(resourcesAfterUpdates[0].SpecHash, resourcesAfterCreation[0].SpecHash)
+}
+
+func TestResourceStorage_UpdateWithPatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Group: appsv1.SchemeGroupVersion.Group, Resource: "deployments"}, true)
+	require.NoError(err)
+	rs := newTestResourceStorage(db, appsv1.SchemeGroupVersion.WithResource("deployments"))
+	rs.config = storage.ResourceStorageConfig{ResourceConfig: *config}
+
+	get := func() (*appsv1.Deployment, Resource) {
+		var resource Resource
+		require.NoError(db.Where(map[string]interface{}{"cluster": "cluster-1", "name": "foo"}).First(&resource).Error)
+		obj, err := resource.ConvertTo(rs.config.Codec, &appsv1.Deployment{})
+		require.NoError(err)
+		return obj.(*appsv1.Deployment), resource
+	}
+
+	ctx := context.Background()
+	require.NoError(rs.Create(ctx, "cluster-1", newTestDeployment("foobar", "foo", "1", 1)))
+
+	// only the changed fields are set, the unchanged spec is kept as it is
+	patch := storage.ResourcePatch{PreviousResourceVersion: "1", ChangedFields: []string{"metadata", "status"}}
+	updated := newTestDeployment("foobar", "foo", "2", 3)
+	updated.Status.ReadyReplicas = 1
+	require.NoError(rs.UpdateWithPatch(ctx, "cluster-1", updated, patch))
+	deployment, resource := get()
+	assert.Equal("2", resource.ResourceVersion)
+	assert.Equal("2", deployment.ResourceVersion)
+	assert.EqualValues(1, deployment.Status.ReadyReplicas)
+	assert.EqualValues(1, *deployment.Spec.Replicas)
+
+	// the stored object isn't at the previous resource version, it is fully updated
+	updated = newTestDeployment("foobar", "foo", "3", 3)
+	updated.Status.ReadyReplicas = 3
+	require.NoError(rs.UpdateWithPatch(ctx, "cluster-1", updated, patch))
+	deployment, resource = get()
+	assert.Equal("3", resource.ResourceVersion)
+	assert.EqualValues(3, deployment.Status.ReadyReplicas)
+	assert.EqualValues(3, *deployment.Spec.Replicas)
+}
+
+func TestPatchedJSONObject(t *testing.T) {
+	// the patch of sqlite is tested by the TestResourceStorage_UpdateWithPatch
+	data := []byte(`{"metadata":{"name":"foo"},"status":{"phase":"Running"}}`)
+	tests := []struct {
+		dialect  string
+		fields   []string
+		expected string
+	}{
+		{
+			dialect:  "postgres",
+			fields:   []string{"status", "spec"},
+			expected: `jsonb_set(("object" - 'spec'), '{status}', CAST('{"phase":"Running"}' AS jsonb))`,
+		},
+		{
+			dialect:  "mysql",
+			fields:   []string{"status", "spec"},
+			expected: "JSON_SET(JSON_REMOVE(`object`, '$.spec'), '$.status', CAST('{\"phase\":\"Running\"}' AS JSON))",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.dialect, func(t *testing.T) {
+			expr, ok, err := patchedJSONObject(test.dialect, data, test.fields)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			db := postgresDB
+			if test.dialect == "mysql" {
+				for _, mysqlDB := range mysqlDBs {
+					db = mysqlDB
+				}
+			}
+			sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return tx.Model(&Resource{}).Where("id = 1").Update("object", expr)
+			})
+			assert.Contains(t, sql, test.expected)
+		})
+	}
+
+	_, ok, err := patchedJSONObject("postgres", data, []string{"metadata['name']"})
+	require.NoError(t, err)
+	assert.False(t, ok, "the field out of the plain keys can't be patched")
+}
+
+func TestResourceStorage_ListSpecHashes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Group: appsv1.SchemeGroupVersion.Group, Resource: "deployments"}, true)
+	require.NoError(err)
+	rs := newTestResourceStorage(db, appsv1.SchemeGroupVersion.WithResource("deployments"))
+	rs.config = storage.ResourceStorageConfig{ResourceConfig: *config}
+
+	require.NoError(rs.Create(context.Background(), "cluster-1", newTestDeployment("foobar", "foo", "1", 1)))
+	require.NoError(rs.Create(context.Background(), "cluster-2", newTestDeployment("foobar", "foo", "2", 1)))
+	require.NoError(rs.Create(context.Background(), "cluster-3", newTestDeployment("foobar", "foo", "3", 3)))
+
+	// the object stored before the spec hash is introduced
+	require.NoError(db.Model(&Resource{}).Where(map[string]interface{}{"cluster": "cluster-2"}).Update("spec_hash", "").Error)
+
+	hashes, err := rs.ListSpecHashes(context.Background(), nil, "foobar", "foo")
+	require.NoError(err)
+	require.Len(hashes, 3)
+	assert.NotEmpty(hashes["cluster-1"])
+	assert.Equal(hashes["cluster-1"], hashes["cluster-2"])
+	assert.NotEqual(hashes["cluster-1"], hashes["cluster-3"])
+
+	hashes, err = rs.ListSpecHashes(context.Background(), []string{"cluster-3"}, "foobar", "foo")
+	require.NoError(err)
+	assert.Len(hashes, 1)
+}
+
+func TestResourceStorage_Count(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Group: appsv1.SchemeGroupVersion.Group, Resource: "deployments"}, true)
+	require.NoError(err)
+	rs := newTestResourceStorage(db, appsv1.SchemeGroupVersion.WithResource("deployments"))
+	rs.config = storage.ResourceStorageConfig{ResourceConfig: *config}
+
+	require.NoError(rs.Create(context.Background(), "cluster-1", newTestDeployment("ns-1", "foo", "1", 1)))
+	require.NoError(rs.Create(context.Background(), "cluster-1", newTestDeployment("ns-2", "foo", "1", 1)))
+	require.NoError(rs.Create(context.Background(), "cluster-2", newTestDeployment("ns-1", "bar", "1", 1)))
+
+	// the resources of the other gvr are not counted
+	pods := newTestResourceStorage(db, corev1.SchemeGroupVersion.WithResource("pods"))
+	require.NoError(db.Create(&Resource{
+		Group: "", Version: "v1", Resource: "pods", Kind: "Pod",
+		Cluster: "cluster-1", Namespace: "ns-1", Name: "pod", Object: []byte("{}"),
+	}).Error)
+
+	count, err := rs.Count(context.Background(), &internal.ListOptions{})
+	require.NoError(err)
+	assert.EqualValues(3, count)
+
+	count, err = rs.Count(context.Background(), &internal.ListOptions{ClusterNames: []string{"cluster-1"}})
+	require.NoError(err)
+	assert.EqualValues(2, count)
+
+	// the limit is ignored
+	count, err = rs.Count(context.Background(), &internal.ListOptions{ListOptions: metainternal.ListOptions{Limit: 1}, Namespaces: []string{"ns-1"}})
+	require.NoError(err)
+	assert.EqualValues(2, count)
+
+	count, err = pods.Count(context.Background(), &internal.ListOptions{})
+	require.NoError(err)
+	assert.EqualValues(1, count)
 }
 
 func newTestResourceStorage(db *gorm.DB, storageResource schema.GroupVersionResource) *ResourceStorage {
@@ -475,3 +641,12 @@ func newTestResourceStorage(db *gorm.DB, storageResource schema.GroupVersionReso
 		},
 	}
 }
+
+// newTestDeployment returns a deployment whose UID is derived from its namespace and name.
+func newTestDeployment(namespace, name, resourceVersion string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(namespace + "/" + name), ResourceVersion: resourceVersion},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+}
@@ -0,0 +1,197 @@
+package internalstorage
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var _ storage.ResourceTenantResolver = &ResourceStorage{}
+
+const (
+	tenantIsolationPolicy = "clusterpedia_tenant_isolation"
+
+	// the tables named by the default naming strategy of gorm
+	resourcesTable      = "resources"
+	tenantClustersTable = "tenant_clusters"
+)
+
+// TenantCluster is a cluster assigned to the postgres role of a tenant,
+// the row level security policy of the resources is based on it.
+type TenantCluster struct {
+	Role    string `gorm:"primaryKey;size:63"`
+	Cluster string `gorm:"primaryKey;size:253"`
+}
+
+func (cfg *RowLevelSecurityConfig) validate() error {
+	roles := make(map[string]struct{}, len(cfg.Tenants))
+	for i, tenant := range cfg.Tenants {
+		if tenant.Role == "" {
+			return fmt.Errorf("rowLevelSecurity.tenants[%d]: role is required", i)
+		}
+		if _, ok := roles[tenant.Role]; ok {
+			return fmt.Errorf("rowLevelSecurity.tenants[%d]: duplicate role %q", i, tenant.Role)
+		}
+		roles[tenant.Role] = struct{}{}
+	}
+	return nil
+}
+
+// rowLevelSecuritySQL returns the statements that enable the row level security of the resources
+// and grant the roles of the tenants to read them.
+func rowLevelSecuritySQL(cfg *RowLevelSecurityConfig) []string {
+	resources, tenantClusters := resourcesTable, tenantClustersTable
+	statements := []string{
+		fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", resources),
+		fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", tenantIsolationPolicy, resources),
+		fmt.Sprintf("CREATE POLICY %s ON %s FOR SELECT USING (EXISTS (SELECT 1 FROM %s WHERE %s.role = current_user AND %s.cluster IN (%s.cluster, '*')))",
+			tenantIsolationPolicy, resources, tenantClusters, tenantClusters, tenantClusters, resources),
+	}
+
+	roles := make([]string, 0, len(cfg.Tenants)+1)
+	for _, tenant := range cfg.Tenants {
+		roles = append(roles, tenant.Role)
+	}
+	if cfg.DefaultRole != "" {
+		roles = append(roles, cfg.DefaultRole)
+	}
+	for _, role := range roles {
+		statements = append(statements, fmt.Sprintf("GRANT SELECT ON %s, %s TO %s", resources, tenantClusters, quotePostgresIdentifier(role)))
+	}
+	return statements
+}
+
+// setupRowLevelSecurity assigns the clusters to the tenants and creates the row level security policy of the resources.
+// The policy doesn't restrict the owner of the table, so the clustersynchro manager is not affected.
+func setupRowLevelSecurity(db *gorm.DB, cfg *RowLevelSecurityConfig) error {
+	if err := db.AutoMigrate(&TenantCluster{}); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&TenantCluster{}).Error; err != nil {
+			return err
+		}
+
+		var clusters []TenantCluster
+		for _, tenant := range cfg.Tenants {
+			for _, cluster := range tenant.Clusters {
+				clusters = append(clusters, TenantCluster{Role: tenant.Role, Cluster: cluster})
+			}
+		}
+		if len(clusters) != 0 {
+			if err := tx.Create(&clusters).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, statement := range rowLevelSecuritySQL(cfg) {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// tenantRoles maps the users of the requests to the postgres roles of the tenants.
+type tenantRoles struct {
+	// roles are all of the roles of the tenants, including the default role
+	roles []string
+
+	users       map[string]string
+	groups      map[string]string
+	defaultRole string
+}
+
+func newTenantRoles(cfg *RowLevelSecurityConfig) *tenantRoles {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+
+	roles := &tenantRoles{
+		users:       make(map[string]string),
+		groups:      make(map[string]string),
+		defaultRole: cfg.DefaultRole,
+	}
+	for _, tenant := range cfg.Tenants {
+		roles.roles = append(roles.roles, tenant.Role)
+		for _, user := range tenant.Users {
+			roles.users[user] = tenant.Role
+		}
+		for _, group := range tenant.Groups {
+			if _, ok := roles.groups[group]; !ok {
+				roles.groups[group] = tenant.Role
+			}
+		}
+	}
+	if cfg.DefaultRole != "" {
+		roles.roles = append(roles.roles, cfg.DefaultRole)
+	}
+	return roles
+}
+
+// roleFor returns the role of the tenant of the request user,
+// the user is matched before the groups.
+func (r *tenantRoles) roleFor(ctx context.Context) string {
+	if r == nil {
+		return ""
+	}
+
+	user, ok := genericrequest.UserFrom(ctx)
+	if !ok {
+		return r.defaultRole
+	}
+	if role, ok := r.users[user.GetName()]; ok {
+		return role
+	}
+	for _, group := range user.GetGroups() {
+		if role, ok := r.groups[group]; ok {
+			return role
+		}
+	}
+	return r.defaultRole
+}
+
+// begin returns the db for the read of the request, the reads are restricted by the row level security
+// after switching to the role of the tenant, done must be called after the reads to release the connection.
+func (r *tenantRoles) begin(ctx context.Context, db *gorm.DB) (_ *gorm.DB, done func(), _ error) {
+	db = db.WithContext(ctx)
+	role := r.roleFor(ctx)
+	if role == "" {
+		return db, func() {}, nil
+	}
+
+	// `SET LOCAL` only works in a transaction, and the role is reset when the transaction ends
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, nil, tx.Error
+	}
+	if err := setLocalRole(tx, role); err != nil {
+		tx.Rollback()
+		return nil, nil, err
+	}
+	return tx, func() { tx.Rollback() }, nil
+}
+
+// restrict switches the transaction to the role of the tenant of the request user,
+// the transaction is not changed if no role is matched.
+func (r *tenantRoles) restrict(ctx context.Context, tx *gorm.DB) error {
+	if role := r.roleFor(ctx); role != "" {
+		return setLocalRole(tx, role)
+	}
+	return nil
+}
+
+func setLocalRole(tx *gorm.DB, role string) error {
+	return tx.Exec("SET LOCAL ROLE " + quotePostgresIdentifier(role)).Error
+}
+
+// TenantFor returns the role of the tenant of the request user, it is empty if the row level security is disabled.
+func (s *ResourceStorage) TenantFor(ctx context.Context) string {
+	return s.tenants.roleFor(ctx)
+}
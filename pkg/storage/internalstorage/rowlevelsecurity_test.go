@@ -0,0 +1,176 @@
+package internalstorage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestTenantRolesRoleFor(t *testing.T) {
+	roles := newTenantRoles(&RowLevelSecurityConfig{
+		Enabled: true,
+		Tenants: []TenantConfig{
+			{Role: "tenant_a", Users: []string{"alice"}, Groups: []string{"team-a"}},
+			{Role: "tenant_b", Groups: []string{"team-b", "team-a"}},
+		},
+		DefaultRole: "guest",
+	})
+
+	tests := []struct {
+		name     string
+		user     user.Info
+		expected string
+	}{
+		{name: "user", user: &user.DefaultInfo{Name: "alice", Groups: []string{"team-b"}}, expected: "tenant_a"},
+		{name: "group", user: &user.DefaultInfo{Name: "bob", Groups: []string{"team-b"}}, expected: "tenant_b"},
+		{name: "first tenant of the group", user: &user.DefaultInfo{Name: "bob", Groups: []string{"team-a"}}, expected: "tenant_a"},
+		{name: "default role", user: &user.DefaultInfo{Name: "carol"}, expected: "guest"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := genericrequest.WithUser(context.TODO(), test.user)
+			assert.Equal(t, test.expected, roles.roleFor(ctx))
+			assert.Equal(t, test.expected, (&ResourceStorage{tenants: roles}).TenantFor(ctx))
+		})
+	}
+
+	assert.Equal(t, "guest", roles.roleFor(context.TODO()))
+	assert.Nil(t, newTenantRoles(&RowLevelSecurityConfig{Tenants: []TenantConfig{{Role: "tenant_a"}}}))
+	assert.Equal(t, "", (*tenantRoles)(nil).roleFor(context.TODO()))
+}
+
+func TestRowLevelSecurityConfigValidate(t *testing.T) {
+	assert.NoError(t, (&RowLevelSecurityConfig{Enabled: true, Tenants: []TenantConfig{{Role: "a"}, {Role: "b"}}}).validate())
+	assert.Error(t, (&RowLevelSecurityConfig{Enabled: true, Tenants: []TenantConfig{{Role: ""}}}).validate())
+	assert.Error(t, (&RowLevelSecurityConfig{Enabled: true, Tenants: []TenantConfig{{Role: "a"}, {Role: "a"}}}).validate())
+
+	cfg := &Config{Type: "mysql", Postgres: &PostgresConfig{RowLevelSecurity: &RowLevelSecurityConfig{Enabled: true}}}
+	assert.Error(t, cfg.validateRowLevelSecurity())
+	cfg.Type = "postgres"
+	assert.NoError(t, cfg.validateRowLevelSecurity())
+}
+
+func TestRowLevelSecuritySQL(t *testing.T) {
+	statements := rowLevelSecuritySQL(&RowLevelSecurityConfig{
+		Enabled:     true,
+		Tenants:     []TenantConfig{{Role: "tenant_a", Clusters: []string{"cluster-1"}}},
+		DefaultRole: "guest",
+	})
+	assert.Equal(t, []string{
+		"ALTER TABLE resources ENABLE ROW LEVEL SECURITY",
+		"DROP POLICY IF EXISTS clusterpedia_tenant_isolation ON resources",
+		"CREATE POLICY clusterpedia_tenant_isolation ON resources FOR SELECT USING (EXISTS (SELECT 1 FROM tenant_clusters WHERE tenant_clusters.role = current_user AND tenant_clusters.cluster IN (resources.cluster, '*')))",
+		`GRANT SELECT ON resources, tenant_clusters TO "tenant_a"`,
+		`GRANT SELECT ON resources, tenant_clusters TO "guest"`,
+	}, statements)
+}
+
+func TestTenantRolesBegin(t *testing.T) {
+	db, mock, err := newMockedPostgresDB()
+	require.NoError(t, err)
+
+	roles := newTenantRoles(&RowLevelSecurityConfig{
+		Enabled: true,
+		Tenants: []TenantConfig{{Role: "tenant_a", Users: []string{"alice"}}},
+	})
+
+	// the requests without the role of a tenant are not switched
+	tx, done, err := roles.begin(context.TODO(), db)
+	require.NoError(t, err)
+	assert.Equal(t, db.Statement.ConnPool, tx.Statement.ConnPool)
+	done()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SET LOCAL ROLE "tenant_a"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	ctx := genericrequest.WithUser(context.TODO(), &user.DefaultInfo{Name: "alice"})
+	_, done, err = roles.begin(ctx, db)
+	require.NoError(t, err)
+	done()
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantRolesRestrictReads(t *testing.T) {
+	roles := newTenantRoles(&RowLevelSecurityConfig{
+		Enabled: true,
+		Tenants: []TenantConfig{{Role: "tenant_a", Users: []string{"alice"}}},
+	})
+	ctx := genericrequest.WithUser(context.TODO(), &user.DefaultInfo{Name: "alice"})
+
+	tests := []struct {
+		name    string
+		queries int
+		read    func(factory *StorageFactory, rs *ResourceStorage) error
+	}{
+		{
+			name:    "resource versions",
+			queries: 1,
+			read: func(factory *StorageFactory, _ *ResourceStorage) error {
+				_, err := factory.GetResourceVersions(ctx, "cluster-1")
+				return err
+			},
+		},
+		{
+			name:    "count namespaces",
+			queries: 2,
+			read: func(factory *StorageFactory, _ *ResourceStorage) error {
+				_, err := factory.CountNamespaces(ctx, "cluster-1")
+				return err
+			},
+		},
+		{
+			name:    "summarize cluster",
+			queries: 1,
+			read: func(factory *StorageFactory, _ *ResourceStorage) error {
+				_, err := factory.SummarizeCluster(ctx, "cluster-1")
+				return err
+			},
+		},
+		{
+			name:    "collect resource usages",
+			queries: 1,
+			read: func(factory *StorageFactory, _ *ResourceStorage) error {
+				_, err := factory.CollectResourceUsages(ctx)
+				return err
+			},
+		},
+		{
+			name:    "resource events",
+			queries: 1,
+			read: func(_ *StorageFactory, rs *ResourceStorage) error {
+				_, err := rs.GetResourceEvents(ctx, "cluster-1", "default", "web")
+				if storage.IsNotFound(err) {
+					return nil
+				}
+				return err
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := newMockedPostgresDB()
+			require.NoError(t, err)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`SET LOCAL ROLE "tenant_a"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+			for i := 0; i < test.queries; i++ {
+				mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"objects"}))
+			}
+			mock.ExpectRollback()
+
+			factory := &StorageFactory{db: db, tenants: roles}
+			rs := &ResourceStorage{db: db, tenants: roles}
+			require.NoError(t, test.read(factory, rs))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package internalstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var _ storage.ResourceSnapshotGetter = &ResourceStorage{}
+
+// snapshotTxOptions read the row and the revisions of the object from the same snapshot of the database,
+// sqlite ignores the options, its transactions are always serializable.
+var snapshotTxOptions = &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+// GetSnapshot reads the object from the primary instead of the read replicas,
+// so the snapshot is the row written by the clustersynchro manager.
+func (s *ResourceStorage) GetSnapshot(ctx context.Context, cluster, namespace, name string) (*storage.ResourceSnapshot, error) {
+	var resources []Resource
+	var records []ResourceChange
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the revisions are only read after the object is read by the role of the tenant,
+		// because the row level security only restricts the resources.
+		if err := s.tenants.restrict(ctx, tx); err != nil {
+			return err
+		}
+		table, err := s.isolation.tables(tx, []string{cluster})
+		if err != nil {
+			return err
+		}
+		if err := table.Model(&Resource{}).Where(s.resourceKeyMap(cluster, namespace, name)).First(&resources).Error; err != nil {
+			return err
+		}
+		if !s.recordChanges {
+			return nil
+		}
+		return tx.Where(s.resourceKeyMap(cluster, namespace, name)).Order("changed_at").Order("id").Find(&records).Error
+	}, snapshotTxOptions)
+	if err != nil {
+		return nil, InterpretResourceDBError(cluster, namespace+"/"+name, err)
+	}
+
+	resource := resources[0]
+	object, err := resource.storedObject().decode()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &storage.ResourceSnapshot{
+		Kind:            resource.Kind,
+		UID:             resource.UID,
+		OwnerUID:        resource.OwnerUID,
+		ResourceVersion: resource.ResourceVersion,
+		SpecHash:        resource.SpecHash,
+		FormatVersion:   resource.FormatVersion,
+		Compression:     resource.Compression,
+		Encoding:        resource.Encoding,
+		Encryption:      resource.Encryption,
+		StoredBytes:     int64(len(resource.Object) + len(resource.CompressedObject)),
+		Object:          object,
+		CreatedTime:     resource.CreatedAt,
+		SyncedTime:      resource.SyncedAt,
+		DeletedTime:     resource.DeletedAt.Time,
+		RemovedTime:     resource.RemovedAt.Time,
+	}
+	if len(resource.EventResourceVersions) != 0 {
+		snapshot.EventResourceVersions = make(map[string]string, len(resource.EventResourceVersions))
+		for key, version := range resource.EventResourceVersions {
+			// the resource versions may be cast to the json numbers by the databases
+			switch version := version.(type) {
+			case string:
+				snapshot.EventResourceVersions[key] = version
+			case float64:
+				snapshot.EventResourceVersions[key] = strconv.FormatFloat(version, 'f', -1, 64)
+			default:
+				snapshot.EventResourceVersions[key] = fmt.Sprint(version)
+			}
+		}
+	}
+	if s.recordChanges {
+		snapshot.Revisions = make([]storage.ResourceRevision, 0, len(records))
+		for _, record := range records {
+			snapshot.Revisions = append(snapshot.Revisions, storage.ResourceRevision{
+				ResourceVersion:         record.ResourceVersion,
+				PreviousResourceVersion: record.PreviousResourceVersion,
+				Time:                    record.ChangedAt,
+			})
+		}
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,82 @@
+package internalstorage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestResourceStorage_GetSnapshotWithSQLite(t *testing.T) {
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+	require.NoError(db.AutoMigrate(&ResourceChange{}))
+
+	rs := newTestResourceStorage(db, corev1.SchemeGroupVersion.WithResource("pods"))
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Resource: "pods"}, true)
+	require.NoError(err)
+	rs.config = storage.ResourceStorageConfig{ResourceConfig: *config}
+	rs.recordChanges = true
+
+	ctx := context.Background()
+	_, err = rs.GetSnapshot(ctx, "cluster-1", "default", "web")
+	require.True(storage.IsNotFound(err), "the snapshot of the absent object should be not found, got %v", err)
+
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "uid-1", ResourceVersion: "1"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+	}
+	require.NoError(rs.Create(ctx, "cluster-1", pod))
+	pod.ResourceVersion = "2"
+	pod.Spec.NodeName = "node-2"
+	require.NoError(rs.Update(ctx, "cluster-1", pod))
+	require.NoError(rs.RecordEvent(ctx, "cluster-1", &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: "web.1", UID: "event-1", ResourceVersion: "12345678"},
+		InvolvedObject: corev1.ObjectReference{UID: "uid-1"},
+	}))
+
+	snapshot, err := rs.GetSnapshot(ctx, "cluster-1", "default", "web")
+	require.NoError(err)
+	require.Equal("Pod", snapshot.Kind)
+	require.EqualValues("uid-1", snapshot.UID)
+	require.Equal("2", snapshot.ResourceVersion)
+	require.Equal(CurrentStorageFormat, snapshot.FormatVersion)
+	require.NotEmpty(snapshot.SpecHash)
+	require.Positive(snapshot.StoredBytes)
+	require.False(snapshot.SyncedTime.IsZero())
+	require.True(snapshot.RemovedTime.IsZero())
+	require.Equal(map[string]string{"default/web.1": "12345678"}, snapshot.EventResourceVersions)
+
+	var object corev1.Pod
+	require.NoError(json.Unmarshal(snapshot.Object, &object))
+	require.Equal("node-2", object.Spec.NodeName)
+
+	require.Equal([]string{"2"}, revisionVersions(snapshot.Revisions))
+	require.Equal("1", snapshot.Revisions[0].PreviousResourceVersion)
+
+	// the tombstone is also returned
+	rs.softDelete = true
+	require.NoError(rs.Delete(ctx, "cluster-1", pod))
+	snapshot, err = rs.GetSnapshot(ctx, "cluster-1", "default", "web")
+	require.NoError(err)
+	require.False(snapshot.RemovedTime.IsZero())
+}
+
+func revisionVersions(revisions []storage.ResourceRevision) []string {
+	versions := make([]string, 0, len(revisions))
+	for _, revision := range revisions {
+		versions = append(versions, revision.ResourceVersion)
+	}
+	return versions
+}
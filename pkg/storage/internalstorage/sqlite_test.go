@@ -0,0 +1,109 @@
+package internalstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/api/clusterpedia/fields"
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestResourceStorage_ListWithSQLite(t *testing.T) {
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+
+	rs := newTestResourceStorage(db, corev1.SchemeGroupVersion.WithResource("pods"))
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Resource: "pods"}, true)
+	require.NoError(err)
+	rs.config = storage.ResourceStorageConfig{ResourceConfig: *config}
+
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-1", Labels: map[string]string{"app": "web", "tier": "frontend"}},
+			Spec: corev1.PodSpec{NodeName: "node-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-2", Labels: map[string]string{"app": "web"}},
+			Spec: corev1.PodSpec{NodeName: "node-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "dns", Labels: map[string]string{"app": "dns"}},
+			Spec: corev1.PodSpec{NodeName: "node-1"}},
+	}
+	for _, pod := range pods {
+		pod.Kind = "Pod"
+		require.NoError(rs.Create(context.Background(), "cluster-1", pod))
+	}
+
+	tests := []struct {
+		name          string
+		labelSelector string
+		fieldSelector string
+		expected      []string
+	}{
+		{"label equal", "app=web", "", []string{"web-1", "web-2"}},
+		{"label in", "app in (web,dns),tier", "", []string{"web-1"}},
+		{"label not exist", "!tier", "", []string{"dns", "web-2"}},
+		{"label not equal", "tier!=frontend", "", []string{"dns", "web-2"}},
+		{"field equal", "", "spec.nodeName=node-1", []string{"dns", "web-1"}},
+		{"field notin", "app=web", "spec.nodeName notin (node-1)", []string{"web-2"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := &internal.ListOptions{OrderBy: []internal.OrderBy{{Field: "name"}}}
+			if test.labelSelector != "" {
+				selector, err := labels.Parse(test.labelSelector)
+				require.NoError(err)
+				opts.LabelSelector = selector
+			}
+			if test.fieldSelector != "" {
+				selector, err := fields.Parse(test.fieldSelector)
+				require.NoError(err)
+				opts.EnhancedFieldSelector = selector
+			}
+
+			list := &corev1.PodList{}
+			require.NoError(rs.List(context.Background(), list, opts))
+			var names []string
+			for _, pod := range list.Items {
+				names = append(names, pod.Name)
+			}
+			require.Equal(test.expected, names)
+		})
+	}
+}
+
+func TestGenSQLiteDSN(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		dsn      string
+		inMemory bool
+	}{
+		{"dsn", Config{Type: "sqlite", DSN: "test.db"}, "test.db", false},
+		{"database", Config{Type: "sqlite", Database: "/var/lib/clusterpedia/clusterpedia.db"},
+			"file:/var/lib/clusterpedia/clusterpedia.db?_busy_timeout=5000&_journal_mode=WAL", false},
+		{"database with params", Config{Type: "sqlite3", Database: "clusterpedia.db", Params: map[string]string{"_journal_mode": "DELETE", "_synchronous": "NORMAL"}},
+			"file:clusterpedia.db?_busy_timeout=5000&_journal_mode=DELETE&_synchronous=NORMAL", false},
+		{"in memory", Config{Type: "sqlite", Database: ":memory:"}, "file::memory:?_busy_timeout=5000&_journal_mode=WAL", true},
+		{"shared in memory", Config{Type: "sqlite", DSN: "file:clusterpedia?mode=memory&cache=shared"}, "file:clusterpedia?mode=memory&cache=shared", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dsn, err := test.config.genSQLiteDSN()
+			require.NoError(t, err)
+			require.Equal(t, test.dsn, dsn)
+			require.Equal(t, test.inMemory, test.config.isSQLiteInMemory())
+		})
+	}
+
+	if _, err := (&Config{Type: "sqlite"}).genSQLiteDSN(); err == nil {
+		t.Error("genSQLiteDSN() should fail without the dsn and the database")
+	}
+}
@@ -2,10 +2,16 @@ package internalstorage
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
 
 	internal "github.com/clusterpedia-io/api/clusterpedia"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
@@ -13,6 +19,56 @@ import (
 
 type StorageFactory struct {
 	db *gorm.DB
+
+	// tenants restricts the reads of the requests by the row level security, nil if it is disabled
+	tenants *tenantRoles
+
+	// isolation stores the resources of each cluster in its own schema, nil if it is disabled
+	isolation *clusterIsolation
+
+	// partitioning partitions the resources table by the clusters
+	partitioning bool
+
+	// replicas serve the reads of the apiserver, nil if no read replica is configured
+	replicas *readReplicas
+
+	// views are the materialized views of the collection resources, nil if no collection resource is materialized
+	views *materializedViews
+
+	// recordChanges records the changed fields of the updates of the objects
+	recordChanges bool
+
+	// softDelete keeps the deleted objects as the tombstones
+	softDelete bool
+
+	// watchBookmarks persists the watch bookmarks of the resources
+	watchBookmarks bool
+
+	// tombstoneRetention is how long the tombstones are kept, 0 if they are kept until they are purged
+	tombstoneRetention time.Duration
+
+	// compressions are the compression algorithms of the compressed resources
+	compressions map[schema.GroupResource]string
+
+	// protobuf stores the objects of the built-in resources in protobuf
+	protobuf bool
+
+	// normalizer normalizes the json of the stored objects, nil if the normalization is disabled
+	normalizer *jsonNormalizer
+
+	// indexedLabels are the label keys of mysql which are materialized as the generated columns
+	indexedLabels []string
+
+	// encryptions are the envelope encryptions of the encrypted resources
+	encryptions map[schema.GroupResource]*envelope
+
+	// operationMetrics records the metrics of the operations of the resource storages
+	operationMetrics bool
+
+	closers []io.Closer
+
+	// schemaReady is set once the schema is checked by the readiness
+	schemaReady atomic.Bool
 }
 
 func (s *StorageFactory) GetSupportedRequestVerbs() []string {
@@ -23,23 +79,51 @@ func (s *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfi
 	return &ResourceStorage{
 		groupResource: config.StorageResource.GroupResource(),
 
-		db:     s.db,
-		config: *config,
+		db:        s.db,
+		tenants:   s.tenants,
+		isolation: s.isolation,
+		replicas:  s.replicas,
+		config:    *config,
+
+		recordChanges:      s.recordChanges,
+		softDelete:         s.softDelete,
+		tombstoneRetention: s.tombstoneRetention,
+		compression:        s.compressions[config.StorageResource.GroupResource()],
+		protobuf:           s.protobuf && config.ProtobufCodec != nil,
+		normalizer:         s.normalizer,
+		encryption:         s.encryptions[config.StorageResource.GroupResource()],
+		metrics:            newOperationMetrics(s.operationMetrics, config.StorageResource),
 	}, nil
 }
 
 func (s *StorageFactory) NewCollectionResourceStorage(cr *internal.CollectionResource) (storage.CollectionResourceStorage, error) {
 	for i := range collectionResources {
 		if collectionResources[i].Name == cr.Name {
-			return NewCollectionResourceStorage(s.db, cr), nil
+			storage := NewCollectionResourceStorage(s.db, cr)
+			storage.tenants = s.tenants
+			storage.isolation = s.isolation
+			storage.replicas = s.replicas
+			storage.softDelete = s.softDelete
+			storage.view = s.views.view(cr.Name)
+			return storage, nil
 		}
 	}
 	return nil, fmt.Errorf("not support collection resource: %s", cr.Name)
 }
 
 func (s *StorageFactory) GetResourceVersions(ctx context.Context, cluster string) (map[schema.GroupVersionResource]storage.ClusterResourceVersions, error) {
+	db, done, err := s.tenants.begin(ctx, s.db)
+	if err != nil {
+		return nil, InterpretDBError(cluster, err)
+	}
+	defer done()
+	if db, err = s.isolation.tables(db, []string{cluster}); err != nil {
+		return nil, InterpretDBError(cluster, err)
+	}
+
 	var resources []Resource
-	result := s.db.WithContext(ctx).Select("group", "version", "resource", "namespace", "name", "resource_version", "event_resource_versions").
+	query := excludeTombstones(db, s.softDelete)
+	result := query.Select("group", "version", "resource", "namespace", "name", "resource_version", "format_version", "compression", "encoding", "encryption", "event_resource_versions").
 		Where(map[string]interface{}{"cluster": cluster}).
 		Find(&resources)
 	if result.Error != nil {
@@ -62,7 +146,21 @@ func (s *StorageFactory) GetResourceVersions(ctx context.Context, cluster string
 		if resource.Namespace != "" {
 			key = resource.Namespace + "/" + resource.Name
 		}
-		versions.Resources[key] = resource.ResourceVersion
+		if resource.FormatVersion < CurrentStorageFormat {
+			// the rows in the previous format are outdated, they are rewritten when the objects are synchronized
+			versions.Resources[key] = ""
+		} else if resource.Compression != s.compressions[gvr.GroupResource()] {
+			// the rows are rewritten when the compression of the resource is changed
+			versions.Resources[key] = ""
+		} else if resource.Encoding != s.encodingFor(gvr.GroupResource()) {
+			// the rows are rewritten when the encoding of the resource is changed
+			versions.Resources[key] = ""
+		} else if resource.Encryption != s.encryptionFor(gvr.GroupResource()) {
+			// the rows are rewritten when the encryption of the resource is changed
+			versions.Resources[key] = ""
+		} else {
+			versions.Resources[key] = resource.ResourceVersion
+		}
 		for k, v := range resource.EventResourceVersions {
 			versions.Events[k] = v
 		}
@@ -70,19 +168,206 @@ func (s *StorageFactory) GetResourceVersions(ctx context.Context, cluster string
 	return resourceversions, nil
 }
 
+func (s *StorageFactory) CountNamespaces(ctx context.Context, cluster string) (map[string]int64, error) {
+	var namespaces []struct {
+		Namespace string
+		Count     int64
+	}
+	db, done, err := s.tenants.begin(ctx, s.db)
+	if err != nil {
+		return nil, InterpretDBError(cluster, err)
+	}
+	defer done()
+	if db, err = s.isolation.tables(db, []string{cluster}); err != nil {
+		return nil, InterpretDBError(cluster, err)
+	}
+	result := excludeTombstones(db.Model(&Resource{}), s.softDelete).Select("namespace", "COUNT(*) AS count").
+		Where(map[string]interface{}{"cluster": cluster}).Where("namespace <> ?", "").
+		Group("namespace").Find(&namespaces)
+	if result.Error != nil {
+		return nil, InterpretDBError(cluster, result.Error)
+	}
+
+	var names []string
+	result = excludeTombstones(db.Model(&Resource{}), s.softDelete).
+		Where(map[string]interface{}{"cluster": cluster, "group": "", "version": "v1", "resource": "namespaces"}).
+		Pluck("name", &names)
+	if result.Error != nil {
+		return nil, InterpretDBError(cluster, result.Error)
+	}
+
+	counts := make(map[string]int64, len(names))
+	for _, name := range names {
+		counts[name] = 0
+	}
+	for _, namespace := range namespaces {
+		counts[namespace.Namespace] = namespace.Count
+	}
+	return counts, nil
+}
+
+func (s *StorageFactory) SummarizeCluster(ctx context.Context, cluster string) (*storage.ClusterSummary, error) {
+	bytes := s.sumObjectBytes()
+	var result struct {
+		Objects int64
+		Bytes   sql.NullInt64
+	}
+	db, done, err := s.tenants.begin(ctx, s.db)
+	if err != nil {
+		return nil, InterpretDBError(cluster, err)
+	}
+	defer done()
+	if db, err = s.isolation.tables(db, []string{cluster}); err != nil {
+		return nil, InterpretDBError(cluster, err)
+	}
+	query := excludeTombstones(db.Model(&Resource{}), s.softDelete).Select("COUNT(*) AS objects", bytes+" AS bytes").
+		Where(map[string]interface{}{"cluster": cluster}).Scan(&result)
+	if query.Error != nil {
+		return nil, InterpretDBError(cluster, query.Error)
+	}
+
+	summary := &storage.ClusterSummary{Objects: result.Objects, Bytes: result.Bytes.Int64}
+	if summary.Objects == 0 {
+		return summary, nil
+	}
+
+	var syncedAt []time.Time
+	query = excludeTombstones(db.Model(&Resource{}), s.softDelete).Where(map[string]interface{}{"cluster": cluster}).
+		Order("synced_at DESC").Limit(1).Pluck("synced_at", &syncedAt)
+	if query.Error != nil {
+		return nil, InterpretDBError(cluster, query.Error)
+	}
+	if len(syncedAt) != 0 {
+		summary.LastSyncedTime = syncedAt[0]
+	}
+	return summary, nil
+}
+
+// sumObjectBytes returns the expression of the estimated size of the objects, the size is estimated
+// by the stored size of the column in postgres, and by the length of the json in the other databases.
+func (s *StorageFactory) sumObjectBytes() string {
+	switch s.db.Dialector.Name() {
+	case "postgres":
+		return "SUM(pg_column_size(object))"
+	case "mysql", "sqlite":
+		return "SUM(LENGTH(object))"
+	}
+	return "0"
+}
+
+func (s *StorageFactory) CollectResourceUsages(ctx context.Context) ([]storage.ResourceUsage, error) {
+	groupBy := []clause.Column{{Name: "cluster"}, {Name: "group"}, {Name: "version"}, {Name: "resource"}}
+	columns := []clause.Column{{Name: "cluster"}, {Name: "group"}, {Name: "version"}, {Name: "resource"},
+		{Name: "COUNT(*) AS objects", Raw: true},
+		{Name: s.sumObjectBytes() + " AS bytes", Raw: true},
+	}
+
+	var results []struct {
+		Cluster  string
+		Group    string
+		Version  string
+		Resource string
+		Objects  int64
+		Bytes    sql.NullInt64
+	}
+	db, done, err := s.tenants.begin(ctx, s.db)
+	if err != nil {
+		return nil, InterpretDBError("", err)
+	}
+	defer done()
+	if db, err = s.isolation.tables(db, nil); err != nil {
+		return nil, InterpretDBError("", err)
+	}
+	query := db.Model(&Resource{}).
+		Clauses(clause.Select{Columns: columns}, clause.GroupBy{Columns: groupBy}).Scan(&results)
+	if query.Error != nil {
+		return nil, InterpretDBError("", query.Error)
+	}
+
+	usages := make([]storage.ResourceUsage, 0, len(results))
+	for _, result := range results {
+		usages = append(usages, storage.ResourceUsage{
+			Cluster:  result.Cluster,
+			Resource: schema.GroupVersionResource{Group: result.Group, Version: result.Version, Resource: result.Resource},
+			Objects:  result.Objects,
+			Bytes:    result.Bytes.Int64,
+		})
+	}
+	return usages, nil
+}
+
 func (s *StorageFactory) CleanCluster(ctx context.Context, cluster string) error {
-	result := s.db.WithContext(ctx).Where(map[string]interface{}{"cluster": cluster}).Delete(&Resource{})
-	return InterpretDBError(cluster, result.Error)
+	var err error
+	switch {
+	case s.isolation != nil:
+		// the resources of the cluster are dropped with its schema rather than deleted row by row
+		err = s.isolation.drop(s.db.WithContext(ctx), cluster)
+	case s.partitioning:
+		err = dropResourcesPartition(s.db.WithContext(ctx), cluster)
+	default:
+		err = s.db.WithContext(ctx).Where(map[string]interface{}{"cluster": cluster}).Delete(&Resource{}).Error
+	}
+	if err == nil && s.recordChanges {
+		err = s.db.WithContext(ctx).Where(map[string]interface{}{"cluster": cluster}).Delete(&ResourceChange{}).Error
+	}
+	if err == nil {
+		err = s.deleteWatchBookmarks(ctx, map[string]interface{}{"cluster": cluster})
+	}
+	return InterpretDBError(cluster, err)
 }
 
 func (s *StorageFactory) CleanClusterResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) error {
-	result := s.db.WithContext(ctx).Where(map[string]interface{}{
+	keys := map[string]interface{}{
 		"cluster":  cluster,
 		"group":    gvr.Group,
 		"version":  gvr.Version,
 		"resource": gvr.Resource,
-	}).Delete(&Resource{})
-	return InterpretDBError(fmt.Sprintf("%s/%s", cluster, gvr), result.Error)
+	}
+	db, err := s.isolation.tables(s.db.WithContext(ctx), []string{cluster})
+	if err != nil {
+		return InterpretDBError(fmt.Sprintf("%s/%s", cluster, gvr), err)
+	}
+	result := db.Where(keys).Delete(&Resource{})
+	if result.Error == nil && s.recordChanges {
+		result = s.db.WithContext(ctx).Where(keys).Delete(&ResourceChange{})
+	}
+	err = result.Error
+	if err == nil {
+		err = s.deleteWatchBookmarks(ctx, keys)
+	}
+	return InterpretDBError(fmt.Sprintf("%s/%s", cluster, gvr), err)
+}
+
+func (s *StorageFactory) PurgeResources(ctx context.Context, opts storage.PurgeOptions) (int64, error) {
+	if s.isolation == nil {
+		return purgeResources(s.db.WithContext(ctx), opts)
+	}
+
+	schemas, err := s.isolation.schemas(s.db.WithContext(ctx), opts.Clusters)
+	if err != nil {
+		return 0, InterpretDBError(opts.Resource.String(), err)
+	}
+	var purged int64
+	for _, schema := range schemas {
+		count, err := purgeResources(s.db.WithContext(ctx).Table(schemaResourcesTable(schema)), opts)
+		purged += count
+		if err != nil {
+			return purged, err
+		}
+	}
+	return purged, nil
+}
+
+func purgeResources(db *gorm.DB, opts storage.PurgeOptions) (int64, error) {
+	query := db.Where(map[string]interface{}{"group": opts.Resource.Group, "resource": opts.Resource.Resource})
+	if len(opts.Clusters) != 0 {
+		query = query.Where("cluster IN ?", opts.Clusters)
+	}
+	if len(opts.ExcludedClusters) != 0 {
+		query = query.Where("cluster NOT IN ?", opts.ExcludedClusters)
+	}
+	result := query.Where("created_at < ?", opts.CreatedBefore.UTC()).Delete(&Resource{})
+	return result.RowsAffected, InterpretDBError(opts.Resource.String(), result.Error)
 }
 
 func (s *StorageFactory) GetCollectionResources(ctx context.Context) ([]*internal.CollectionResource, error) {
@@ -93,11 +378,25 @@ func (s *StorageFactory) GetCollectionResources(ctx context.Context) ([]*interna
 	return crs, nil
 }
 
+// PrepareCluster creates the schema of the cluster if the cluster isolation is enabled,
+// or the partition of the cluster if the partitioning is enabled
 func (s *StorageFactory) PrepareCluster(cluster string) error {
+	switch {
+	case s.isolation != nil:
+		return InterpretDBError(cluster, s.isolation.prepare(s.db, cluster))
+	case s.partitioning:
+		return InterpretDBError(cluster, createResourcesPartition(s.db, cluster))
+	}
 	return nil
 }
 
 func (s *StorageFactory) Shutdown() error {
+	for _, closer := range s.closers {
+		if err := closer.Close(); err != nil {
+			klog.ErrorS(err, "failed to close the watcher of the storage factory")
+		}
+	}
+
 	db, err := s.db.DB()
 	if err != nil {
 		return err
@@ -1,15 +1,21 @@
 package internalstorage
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	gmysql "gorm.io/driver/mysql"
 	gpostgres "gorm.io/driver/postgres"
 	gsqlite "gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 )
 
 var (
@@ -93,3 +99,167 @@ func TestMain(m *testing.M) {
 
 	os.Exit(m.Run())
 }
+
+func TestStorageFactory_CountNamespaces(t *testing.T) {
+	db, cleanup, err := newSQLiteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	resources := []Resource{
+		{Group: "", Version: "v1", Resource: "namespaces", Kind: "Namespace", Cluster: "cluster-1", Name: "default"},
+		{Group: "", Version: "v1", Resource: "namespaces", Kind: "Namespace", Cluster: "cluster-1", Name: "empty"},
+		{Group: "", Version: "v1", Resource: "pods", Kind: "Pod", Cluster: "cluster-1", Namespace: "default", Name: "pod-1"},
+		{Group: "", Version: "v1", Resource: "pods", Kind: "Pod", Cluster: "cluster-1", Namespace: "default", Name: "pod-2"},
+		{Group: "apps", Version: "v1", Resource: "deployments", Kind: "Deployment", Cluster: "cluster-1", Namespace: "kube-system", Name: "coredns"},
+		{Group: "", Version: "v1", Resource: "pods", Kind: "Pod", Cluster: "cluster-2", Namespace: "default", Name: "pod-1"},
+	}
+	for i := range resources {
+		resources[i].Object = []byte("{}")
+	}
+	if err := db.Create(&resources).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := (&StorageFactory{db: db}).CountNamespaces(context.TODO(), "cluster-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]int64{"default": 2, "empty": 0, "kube-system": 1}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("CountNamespaces() = %v, want %v", counts, expected)
+	}
+}
+
+func TestStorageFactory_SummarizeCluster(t *testing.T) {
+	db, cleanup, err := newSQLiteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	syncedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	resources := []Resource{
+		{Group: "", Version: "v1", Resource: "pods", Kind: "Pod", Cluster: "cluster-1", Namespace: "default", Name: "pod-1", Object: []byte(`{"a":1}`)},
+		{Group: "", Version: "v1", Resource: "pods", Kind: "Pod", Cluster: "cluster-1", Namespace: "default", Name: "pod-2", Object: []byte(`{}`)},
+		{Group: "", Version: "v1", Resource: "pods", Kind: "Pod", Cluster: "cluster-2", Namespace: "default", Name: "pod-1", Object: []byte(`{}`)},
+	}
+	if err := db.Create(&resources).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&Resource{}).Where("cluster = ?", "cluster-1").UpdateColumn("synced_at", syncedAt).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Model(&Resource{}).Where("cluster = ? AND name = ?", "cluster-1", "pod-2").UpdateColumn("synced_at", syncedAt.Add(time.Hour)).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	factory := &StorageFactory{db: db}
+	summary, err := factory.SummarizeCluster(context.TODO(), "cluster-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Objects != 2 || summary.Bytes != 9 || !summary.LastSyncedTime.Equal(syncedAt.Add(time.Hour)) {
+		t.Errorf("SummarizeCluster() = %+v", summary)
+	}
+
+	summary, err = factory.SummarizeCluster(context.TODO(), "cluster-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Objects != 0 || summary.Bytes != 0 || !summary.LastSyncedTime.IsZero() {
+		t.Errorf("SummarizeCluster() of the empty cluster = %+v", summary)
+	}
+}
+
+func TestStorageFactory_CollectResourceUsages(t *testing.T) {
+	db, cleanup, err := newSQLiteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	resources := []Resource{
+		{Group: "", Version: "v1", Resource: "pods", Kind: "Pod", Cluster: "cluster-1", Namespace: "default", Name: "pod-1", Object: []byte(`{"a":1}`)},
+		{Group: "", Version: "v1", Resource: "pods", Kind: "Pod", Cluster: "cluster-1", Namespace: "default", Name: "pod-2", Object: []byte(`{}`)},
+		{Group: "apps", Version: "v1", Resource: "deployments", Kind: "Deployment", Cluster: "cluster-1", Namespace: "default", Name: "deploy-1", Object: []byte(`{}`)},
+		{Group: "", Version: "v1", Resource: "pods", Kind: "Pod", Cluster: "cluster-2", Namespace: "default", Name: "pod-1", Object: []byte(`{}`)},
+	}
+	if err := db.Create(&resources).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	factory := &StorageFactory{db: db}
+	usages, err := factory.CollectResourceUsages(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]storage.ResourceUsage{
+		"cluster-1/pods":        {Cluster: "cluster-1", Resource: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, Objects: 2, Bytes: 9},
+		"cluster-1/deployments": {Cluster: "cluster-1", Resource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, Objects: 1, Bytes: 2},
+		"cluster-2/pods":        {Cluster: "cluster-2", Resource: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, Objects: 1, Bytes: 2},
+	}
+	if len(usages) != len(expected) {
+		t.Fatalf("CollectResourceUsages() = %+v", usages)
+	}
+	for _, usage := range usages {
+		if usage != expected[usage.Cluster+"/"+usage.Resource.Resource] {
+			t.Errorf("unexpected usage %+v", usage)
+		}
+	}
+}
+
+func TestStorageFactory_PurgeResources(t *testing.T) {
+	db, cleanup, err := newSQLiteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	now := time.Now()
+	old, recent := now.Add(-48*time.Hour), now.Add(-time.Hour)
+	resources := []Resource{
+		{Group: "batch", Version: "v1", Resource: "jobs", Kind: "Job", Cluster: "cluster-1", Namespace: "default", Name: "old", CreatedAt: old},
+		{Group: "batch", Version: "v1", Resource: "jobs", Kind: "Job", Cluster: "cluster-1", Namespace: "default", Name: "recent", CreatedAt: recent},
+		{Group: "batch", Version: "v1", Resource: "jobs", Kind: "Job", Cluster: "cluster-2", Namespace: "default", Name: "old", CreatedAt: old},
+		{Group: "", Version: "v1", Resource: "pods", Kind: "Pod", Cluster: "cluster-1", Namespace: "default", Name: "old", CreatedAt: old},
+	}
+	for i := range resources {
+		resources[i].Object = []byte("{}")
+	}
+	if err := db.Create(&resources).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	factory := &StorageFactory{db: db}
+	jobs := schema.GroupResource{Group: "batch", Resource: "jobs"}
+	purged, err := factory.PurgeResources(context.TODO(), storage.PurgeOptions{
+		Resource: jobs, ExcludedClusters: []string{"cluster-2"}, CreatedBefore: now.Add(-24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purged != 1 {
+		t.Errorf("PurgeResources() = %d, want 1", purged)
+	}
+
+	purged, err = factory.PurgeResources(context.TODO(), storage.PurgeOptions{
+		Resource: jobs, Clusters: []string{"cluster-2"}, CreatedBefore: now.Add(-24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purged != 1 {
+		t.Errorf("PurgeResources() of cluster-2 = %d, want 1", purged)
+	}
+
+	var names []string
+	if err := db.Model(&Resource{}).Order("resource, name").Pluck("resource || '/' || name", &names).Error; err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"jobs/recent", "pods/old"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("the remaining objects = %v, want %v", names, expected)
+	}
+}
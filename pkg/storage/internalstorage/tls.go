@@ -0,0 +1,106 @@
+package internalstorage
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/klog/v2"
+)
+
+// tlsReloader watches the certificate files of the database connections and rebuilds the tls config
+// after they are changed, the new connections of the pool use the rebuilt tls config,
+// so the rotation of the certificates doesn't require restarting the components.
+//
+// The directories of the files are watched, because the files mounted from the secrets
+// are replaced by swapping the symlinks.
+type tlsReloader[T any] struct {
+	load    func() (T, error)
+	watcher *fsnotify.Watcher
+
+	lock    sync.Mutex
+	current T
+	stale   bool
+}
+
+func newTLSReloader[T any](files []string, load func() (T, error)) (*tlsReloader[T], error) {
+	current, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	dirs := make(map[string]struct{})
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if _, ok := dirs[dir]; ok {
+			continue
+		}
+		dirs[dir] = struct{}{}
+
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+
+	reloader := &tlsReloader[T]{load: load, watcher: watcher, current: current}
+	go reloader.watch()
+	return reloader, nil
+}
+
+func (r *tlsReloader[T]) watch() {
+	for {
+		select {
+		case _, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			r.lock.Lock()
+			r.stale = true
+			r.lock.Unlock()
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			klog.ErrorS(err, "failed to watch the certificate files of the database")
+		}
+	}
+}
+
+// Get returns the tls config, it is rebuilt if the certificate files are changed.
+// The previous tls config is returned if the files are invalid, e.g. the files are partially updated.
+func (r *tlsReloader[T]) Get() T {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.stale {
+		return r.current
+	}
+
+	current, err := r.load()
+	if err != nil {
+		klog.ErrorS(err, "failed to reload the certificates of the database, keep using the previous certificates")
+		return r.current
+	}
+	klog.InfoS("the certificates of the database are reloaded")
+	r.current, r.stale = current, false
+	return r.current
+}
+
+func (r *tlsReloader[T]) Close() error {
+	return r.watcher.Close()
+}
+
+func (cfg *Config) certificateFiles() []string {
+	var files []string
+	for _, file := range []string{cfg.CertFile, cfg.KeyFile, cfg.RootCertFile} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
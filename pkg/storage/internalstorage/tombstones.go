@@ -0,0 +1,191 @@
+package internalstorage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gorm.io/gorm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var _ storage.ResourceTombstoneStorage = &ResourceStorage{}
+
+// tombstonesPurgeInterval is the minimum interval of purging the expired tombstones of a cluster
+const tombstonesPurgeInterval = time.Minute
+
+// excludeTombstones excludes the tombstones of the deleted objects from the query if the soft delete is enabled.
+func excludeTombstones(query *gorm.DB, softDelete bool) *gorm.DB {
+	if !softDelete {
+		return query
+	}
+	return query.Where("removed_at IS NULL")
+}
+
+// removeObject marks the object as a tombstone, the tombstones are not changed.
+func (s *ResourceStorage) removeObject(ctx context.Context, cluster, namespace, name string) *gorm.DB {
+	return s.isolation.table(s.db.WithContext(ctx), cluster).Model(&Resource{}).
+		Where(s.resourceKeyMap(cluster, namespace, name)).Where("removed_at IS NULL").
+		UpdateColumn("removed_at", sql.NullTime{Time: time.Now().UTC(), Valid: true})
+}
+
+// deleteTombstone deletes the tombstone of the object before it is created again,
+// because the tombstone has the same unique key as the object.
+func (s *ResourceStorage) deleteTombstone(db *gorm.DB, cluster, namespace, name string) error {
+	return s.isolation.table(db, cluster).
+		Where(s.resourceKeyMap(cluster, namespace, name)).Where("removed_at IS NOT NULL").
+		Delete(&Resource{}).Error
+}
+
+// tombstonesExpiredBefore returns the time before which the tombstones are expired, zero if the tombstones don't expire.
+func (s *ResourceStorage) tombstonesExpiredBefore() time.Time {
+	if s.tombstoneRetention <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-s.tombstoneRetention)
+}
+
+// purgeExpiredTombstones purges the expired tombstones of the cluster at most once per tombstonesPurgeInterval,
+// the failure is only logged because the tombstones are purged again when the next object is deleted.
+func (s *ResourceStorage) purgeExpiredTombstones(ctx context.Context, cluster string) {
+	expiredBefore := s.tombstonesExpiredBefore()
+	if expiredBefore.IsZero() {
+		return
+	}
+
+	now := time.Now()
+	if last, ok := s.tombstonesPurgedAt.Load(cluster); ok && now.Sub(last.(time.Time)) < tombstonesPurgeInterval {
+		return
+	}
+	s.tombstonesPurgedAt.Store(cluster, now)
+
+	purged, err := s.PurgeTombstones(ctx, cluster, storage.TombstoneOptions{RemovedBefore: expiredBefore})
+	if err != nil {
+		klog.ErrorS(err, "Failed to purge the expired tombstones", "cluster", cluster, "resource", s.groupResource)
+		return
+	}
+	if purged != 0 {
+		klog.V(4).InfoS("Purged the expired tombstones", "cluster", cluster, "resource", s.groupResource, "count", purged)
+	}
+}
+
+func (s *ResourceStorage) tombstonesQuery(db *gorm.DB, cluster string, opts storage.TombstoneOptions) *gorm.DB {
+	keys := s.gvrKeyMap()
+	keys["cluster"] = cluster
+	if opts.Namespace != "" {
+		keys["namespace"] = opts.Namespace
+	}
+	if opts.Name != "" {
+		keys["name"] = opts.Name
+	}
+
+	query := db.Model(&Resource{}).Where(keys).Where("removed_at IS NOT NULL")
+	if !opts.RemovedAfter.IsZero() {
+		query = query.Where("removed_at > ?", opts.RemovedAfter.UTC())
+	}
+	if !opts.RemovedBefore.IsZero() {
+		query = query.Where("removed_at < ?", opts.RemovedBefore.UTC())
+	}
+	return query
+}
+
+func (s *ResourceStorage) ListTombstones(ctx context.Context, cluster string, opts storage.TombstoneOptions) ([]storage.Tombstone, error) {
+	if !s.softDelete {
+		return nil, apierrors.NewMethodNotSupported(s.groupResource, "tombstones")
+	}
+
+	db, done, err := s.tenants.begin(ctx, s.db)
+	if err != nil {
+		return nil, InterpretDBError(cluster, err)
+	}
+	defer done()
+	if db, err = s.isolation.tables(db, []string{cluster}); err != nil {
+		return nil, InterpretDBError(cluster, err)
+	}
+
+	// the expired tombstones are hidden before they are purged
+	if expiredBefore := s.tombstonesExpiredBefore(); expiredBefore.After(opts.RemovedAfter) {
+		opts.RemovedAfter = expiredBefore
+	}
+
+	var resources []Resource
+	result := s.tombstonesQuery(db, cluster, opts).Select(append([]string{"removed_at"}, storedObjectColumns...)).
+		Order("removed_at DESC").Find(&resources)
+	if result.Error != nil {
+		return nil, InterpretDBError(cluster, result.Error)
+	}
+
+	tombstones := make([]storage.Tombstone, 0, len(resources))
+	for _, resource := range resources {
+		object, err := resource.storedObject().decode()
+		if err != nil {
+			return nil, err
+		}
+		tombstones = append(tombstones, storage.Tombstone{
+			Namespace: resource.Namespace,
+			Name:      resource.Name,
+			RemovedAt: resource.RemovedAt.Time,
+			Object:    object,
+		})
+	}
+	return tombstones, nil
+}
+
+func (s *ResourceStorage) RestoreTombstone(ctx context.Context, cluster, namespace, name string) error {
+	if !s.softDelete {
+		return apierrors.NewMethodNotSupported(s.groupResource, "tombstones")
+	}
+
+	db, err := s.isolation.tables(s.db.WithContext(ctx), []string{cluster})
+	if err != nil {
+		return InterpretResourceDBError(cluster, namespace+"/"+name, err)
+	}
+	query := db.Model(&Resource{}).
+		Where(s.resourceKeyMap(cluster, namespace, name)).Where("removed_at IS NOT NULL")
+	if expiredBefore := s.tombstonesExpiredBefore(); !expiredBefore.IsZero() {
+		query = query.Where("removed_at > ?", expiredBefore.UTC())
+	}
+	result := query.UpdateColumn("removed_at", sql.NullTime{})
+	if result.Error != nil {
+		return InterpretResourceDBError(cluster, namespace+"/"+name, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return InterpretResourceDBError(cluster, namespace+"/"+name, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+func (s *ResourceStorage) PurgeTombstones(ctx context.Context, cluster string, opts storage.TombstoneOptions) (int64, error) {
+	if !s.softDelete {
+		return 0, apierrors.NewMethodNotSupported(s.groupResource, "tombstones")
+	}
+
+	var purged int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		table, err := s.isolation.tables(tx, []string{cluster})
+		if err != nil {
+			return err
+		}
+
+		if s.recordChanges {
+			// the changes of the objects are kept with the tombstones
+			var resources []Resource
+			if result := s.tombstonesQuery(table, cluster, opts).Select("namespace", "name").Find(&resources); result.Error != nil {
+				return result.Error
+			}
+			for _, resource := range resources {
+				if err := tx.Where(s.resourceKeyMap(cluster, resource.Namespace, resource.Name)).Delete(&ResourceChange{}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		result := s.tombstonesQuery(table, cluster, opts).Delete(&Resource{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, InterpretDBError(cluster, err)
+}
@@ -0,0 +1,142 @@
+package internalstorage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestResourceStorage_TombstonesWithSQLite(t *testing.T) {
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+
+	factory := &StorageFactory{db: db, softDelete: true}
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Resource: "pods"}, true)
+	require.NoError(err)
+	s, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+	require.NoError(err)
+	rs := s.(*ResourceStorage)
+
+	ctx := context.Background()
+	pods := []*corev1.Pod{
+		{TypeMeta: metav1.TypeMeta{Kind: "Pod"}, ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", ResourceVersion: "1"}},
+		{TypeMeta: metav1.TypeMeta{Kind: "Pod"}, ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api", ResourceVersion: "1"}},
+	}
+	for _, pod := range pods {
+		require.NoError(rs.Create(ctx, "cluster-1", pod))
+	}
+	before := time.Now()
+	require.NoError(rs.Delete(ctx, "cluster-1", pods[0]))
+
+	// the tombstones are hidden from the queries
+	err = rs.Get(ctx, "cluster-1", "default", "web", &corev1.Pod{})
+	require.True(storage.IsNotFound(err), "Get() of the tombstone error = %v", err)
+	list := &corev1.PodList{}
+	require.NoError(rs.List(ctx, list, &internal.ListOptions{}))
+	require.Len(list.Items, 1)
+	versions, err := factory.GetResourceVersions(ctx, "cluster-1")
+	require.NoError(err)
+	require.Len(versions[corev1.SchemeGroupVersion.WithResource("pods")].Resources, 1)
+
+	tombstones, err := rs.ListTombstones(ctx, "cluster-1", storage.TombstoneOptions{})
+	require.NoError(err)
+	require.Len(tombstones, 1)
+	require.Equal("web", tombstones[0].Name)
+	require.False(tombstones[0].RemovedAt.Before(before.Add(-time.Second)))
+	require.Contains(string(tombstones[0].Object), `"name":"web"`)
+
+	tombstones, err = rs.ListTombstones(ctx, "cluster-1", storage.TombstoneOptions{RemovedBefore: before.Add(-time.Hour)})
+	require.NoError(err)
+	require.Empty(tombstones)
+
+	// the object is restored
+	require.NoError(rs.RestoreTombstone(ctx, "cluster-1", "default", "web"))
+	require.NoError(rs.Get(ctx, "cluster-1", "default", "web", &corev1.Pod{}))
+	err = rs.RestoreTombstone(ctx, "cluster-1", "default", "web")
+	require.True(storage.IsNotFound(err), "RestoreTombstone() of the existing object error = %v", err)
+
+	// the tombstone is kept if the object fails to be created again
+	require.NoError(rs.Delete(ctx, "cluster-1", pods[0]))
+	require.NoError(db.Callback().Create().Before("gorm:create").Register("test:fail_create", func(tx *gorm.DB) {
+		_ = tx.AddError(errors.New("failed to create"))
+	}))
+	require.Error(rs.Create(ctx, "cluster-1", pods[0]))
+	require.NoError(db.Callback().Create().Remove("test:fail_create"))
+	tombstones, err = rs.ListTombstones(ctx, "cluster-1", storage.TombstoneOptions{Name: "web"})
+	require.NoError(err)
+	require.Len(tombstones, 1)
+
+	// the tombstone is replaced by the object created again
+	require.NoError(rs.Create(ctx, "cluster-1", pods[0]))
+	require.NoError(rs.Get(ctx, "cluster-1", "default", "web", &corev1.Pod{}))
+
+	require.NoError(rs.Delete(ctx, "cluster-1", pods[0]))
+	require.NoError(rs.Delete(ctx, "cluster-1", pods[1]))
+	purged, err := rs.PurgeTombstones(ctx, "cluster-1", storage.TombstoneOptions{Name: "web"})
+	require.NoError(err)
+	require.Equal(int64(1), purged)
+
+	var names []string
+	require.NoError(db.Model(&Resource{}).Order("name").Pluck("name", &names).Error)
+	require.Equal([]string{"api"}, names)
+}
+
+func TestResourceStorage_TombstoneRetentionWithSQLite(t *testing.T) {
+	require := require.New(t)
+
+	db, cleanup, err := newSQLiteDB()
+	require.NoError(err)
+	defer cleanup()
+
+	factory := &StorageFactory{db: db, softDelete: true, tombstoneRetention: time.Hour}
+	config, err := resourceconfigfactory.New().NewLegacyResourceConfig(schema.GroupResource{Resource: "pods"}, true)
+	require.NoError(err)
+	s, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+	require.NoError(err)
+	rs := s.(*ResourceStorage)
+
+	ctx := context.Background()
+	pods := []*corev1.Pod{
+		{TypeMeta: metav1.TypeMeta{Kind: "Pod"}, ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", ResourceVersion: "1"}},
+		{TypeMeta: metav1.TypeMeta{Kind: "Pod"}, ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api", ResourceVersion: "1"}},
+	}
+	for _, pod := range pods {
+		require.NoError(rs.Create(ctx, "cluster-1", pod))
+	}
+	require.NoError(rs.Delete(ctx, "cluster-1", pods[0]))
+	require.NoError(db.Model(&Resource{}).Where("name = ?", "web").
+		UpdateColumn("removed_at", time.Now().Add(-2*time.Hour).UTC()).Error)
+
+	// the expired tombstones are hidden
+	tombstones, err := rs.ListTombstones(ctx, "cluster-1", storage.TombstoneOptions{})
+	require.NoError(err)
+	require.Empty(tombstones)
+	err = rs.RestoreTombstone(ctx, "cluster-1", "default", "web")
+	require.True(storage.IsNotFound(err), "RestoreTombstone() of the expired tombstone error = %v", err)
+
+	// the expired tombstones are purged when the next object is deleted after the purge interval
+	rs.tombstonesPurgedAt.Store("cluster-1", time.Now().Add(-tombstonesPurgeInterval))
+	require.NoError(rs.Delete(ctx, "cluster-1", pods[1]))
+	var names []string
+	require.NoError(db.Model(&Resource{}).Order("name").Pluck("name", &names).Error)
+	require.Equal([]string{"api"}, names)
+
+	tombstones, err = rs.ListTombstones(ctx, "cluster-1", storage.TombstoneOptions{})
+	require.NoError(err)
+	require.Len(tombstones, 1)
+	require.Equal("api", tombstones[0].Name)
+}
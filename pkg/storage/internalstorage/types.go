@@ -1,15 +1,16 @@
 package internalstorage
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"time"
 
-	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	gormschema "gorm.io/gorm/schema"
@@ -52,30 +53,111 @@ func (rt ResourceType) GroupVersionResource() schema.GroupVersionResource {
 	}
 }
 
-type JSONMap datatypes.JSONMap
+// JSON is the json column, the values are written by the json value of the database driver,
+// e.g. `CAST(? AS JSON)` for MySQL.
+//
+// It is used instead of gorm.io/datatypes, which links the mysql driver even if it is excluded by the no_mysql build tag.
+type JSON json.RawMessage
+
+func (j JSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+func (j *JSON) Scan(val interface{}) error {
+	if val == nil {
+		*j = JSON("null")
+		return nil
+	}
+	switch v := val.(type) {
+	case []byte:
+		*j = append(JSON(nil), v...)
+	case string:
+		*j = JSON(v)
+	default:
+		return fmt.Errorf("failed to unmarshal JSON value: %v", val)
+	}
+	return nil
+}
+
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return json.RawMessage(j).MarshalJSON()
+}
+
+func (j *JSON) UnmarshalJSON(b []byte) error {
+	return (*json.RawMessage)(j).UnmarshalJSON(b)
+}
+
+func (JSON) GormDataType() string {
+	return "json"
+}
+
+func (JSON) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
+	return jsonDBDataType(db)
+}
+
+func (j JSON) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if len(j) == 0 {
+		return gorm.Expr("NULL")
+	}
+	return jsonValue(db, string(j))
+}
+
+type JSONMap map[string]interface{}
 
 func (m JSONMap) Value() (driver.Value, error) {
-	return datatypes.JSONMap(m).Value()
+	if m == nil {
+		return nil, nil
+	}
+	data, err := m.MarshalJSON()
+	return string(data), err
 }
 
 func (m *JSONMap) Scan(val interface{}) error {
-	return (*datatypes.JSONMap)(m).Scan(val)
+	if val == nil {
+		*m = make(JSONMap)
+		return nil
+	}
+	var data []byte
+	switch v := val.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("failed to unmarshal JSON value: %v", val)
+	}
+
+	t := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&t)
+	*m = t
+	return err
 }
 
 func (m JSONMap) MarshalJSON() ([]byte, error) {
-	return (datatypes.JSONMap)(m).MarshalJSON()
+	if m == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(map[string]interface{}(m))
 }
 
 func (m *JSONMap) UnmarshalJSON(b []byte) error {
-	return (*datatypes.JSONMap)(m).UnmarshalJSON(b)
+	t := map[string]interface{}{}
+	err := json.Unmarshal(b, &t)
+	*m = t
+	return err
 }
 
 func (m JSONMap) GormDataType() string {
-	return datatypes.JSONMap(m).GormDataType()
+	return "jsonmap"
 }
 
 func (m JSONMap) GormDBDataType(db *gorm.DB, field *gormschema.Field) string {
-	return datatypes.JSONMap(m).GormDBDataType(db, field)
+	return jsonDBDataType(db)
 }
 
 func (m JSONMap) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
@@ -84,7 +166,26 @@ func (m JSONMap) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
 		// rather than having both the string 'null' and actual NULL values
 		return gorm.Expr("NULL")
 	}
-	return datatypes.JSONMap(m).GormValue(ctx, db)
+	data, _ := m.MarshalJSON()
+	return jsonValue(db, string(data))
+}
+
+func jsonDBDataType(db *gorm.DB) string {
+	switch db.Dialector.Name() {
+	case "sqlite", "mysql":
+		return "JSON"
+	case "postgres":
+		return "JSONB"
+	}
+	return ""
+}
+
+// jsonValue returns the expression of the json value by the driver of the dialect
+func jsonValue(db *gorm.DB, data string) clause.Expr {
+	if driver, ok := databaseDrivers[db.Dialector.Name()]; ok && driver.jsonValue != nil {
+		return driver.jsonValue(db.Dialector, data)
+	}
+	return gorm.Expr("?", data)
 }
 
 type Resource struct {
@@ -102,7 +203,24 @@ type Resource struct {
 	UID             types.UID `gorm:"size:36;not null"`
 	ResourceVersion string    `gorm:"size:30;not null"`
 
-	Object datatypes.JSON `gorm:"not null"`
+	Object JSON `gorm:"not null"`
+
+	// CompressedObject is the full object compressed by the Compression algorithm or encoded in the Encoding,
+	// the Object only keeps the metadata of the compressed or encoded object.
+	CompressedObject []byte
+	Compression      string `gorm:"size:15;not null;default:''"`
+
+	// Encoding is the encoding of the CompressedObject, it is empty if the object is encoded in json.
+	Encoding string `gorm:"size:15;not null;default:''"`
+
+	// Encryption is the provider which encrypts the CompressedObject, it is empty if the object is not encrypted.
+	Encryption string `gorm:"size:63;not null;default:''"`
+
+	// SpecHash is the hash of the normalized object, the objects stored before it is introduced have an empty hash.
+	SpecHash string `gorm:"size:64;not null;default:''"`
+
+	// FormatVersion is the storage format of the row, the rows stored before it is introduced are in the format 1.
+	FormatVersion int `gorm:"not null;default:1"`
 
 	// Since MySQL doesn't allow setting default values for JSON fields, we can only avoid using NOT NULL and DEFAULT.
 	Events                JSONMap
@@ -111,6 +229,10 @@ type Resource struct {
 	CreatedAt time.Time `gorm:"not null"`
 	SyncedAt  time.Time `gorm:"not null;autoUpdateTime"`
 	DeletedAt sql.NullTime
+
+	// RemovedAt is the time the object is deleted from the cluster, the row is kept as a tombstone
+	// if the `softDelete` is enabled, and the rows of the existing objects have a null value.
+	RemovedAt sql.NullTime
 }
 
 func (res Resource) GroupVersionResource() schema.GroupVersionResource {
@@ -130,17 +252,31 @@ func (res Resource) GetResourceType() ResourceType {
 	}
 }
 
+func (res Resource) storedObject() storedObject {
+	return storedObject{
+		Object: res.Object, CompressedObject: res.CompressedObject, Compression: res.Compression, Encoding: res.Encoding, Encryption: res.Encryption,
+		Cluster: res.Cluster, Group: res.Group, Version: res.Version, Resource: res.Resource, Namespace: res.Namespace, Name: res.Name,
+	}
+}
+
 func (res Resource) ConvertToUnstructured() (*unstructured.Unstructured, error) {
+	data, err := res.storedObject().decode()
+	if err != nil {
+		return nil, err
+	}
 	obj := &unstructured.Unstructured{}
-	if err := json.Unmarshal(res.Object, obj); err != nil {
+	if err := json.Unmarshal(data, obj); err != nil {
 		return nil, err
 	}
 	return obj, nil
 }
 
 func (res Resource) ConvertTo(codec runtime.Codec, object runtime.Object) (runtime.Object, error) {
-	obj, _, err := codec.Decode(res.Object, nil, object)
-	return obj, err
+	obj, err := res.storedObject().object()
+	if err != nil {
+		return nil, err
+	}
+	return obj.ConvertTo(codec, object)
 }
 
 func (res Resource) GetEvents() []*corev1.Event {
@@ -150,7 +286,7 @@ func (res Resource) GetEvents() []*corev1.Event {
 type ResourceMetadata struct {
 	ResourceType `gorm:"embedded"`
 
-	Metadata datatypes.JSON
+	Metadata JSON
 }
 
 func (data ResourceMetadata) ConvertToUnstructured() (*unstructured.Unstructured, error) {
@@ -208,14 +344,14 @@ func (data ResourceMetadata) GetEvents() []*corev1.Event {
 	return nil
 }
 
-type Bytes datatypes.JSON
+type Bytes JSON
 
 func (bytes *Bytes) Scan(data any) error {
-	return (*datatypes.JSON)(bytes).Scan(data)
+	return (*JSON)(bytes).Scan(data)
 }
 
 func (bytes Bytes) Value() (driver.Value, error) {
-	return (datatypes.JSON)(bytes).Value()
+	return (JSON)(bytes).Value()
 }
 
 func (bytes Bytes) ConvertToUnstructured() (*unstructured.Unstructured, error) {
@@ -285,31 +421,38 @@ func (list ResourceMetadataList) Items() []Object {
 	return objects
 }
 
-type BytesList []Bytes
+// BytesList is the list of the Bytes or the ProtobufBytes of the objects
+type BytesList []Object
 
 func (list *BytesList) From(db *gorm.DB) error {
-	if result := db.Select("object").Find(list); result.Error != nil {
+	var objects []storedObject
+	if result := db.Select(storedObjectColumns).Find(&objects); result.Error != nil {
 		return result.Error
 	}
+
+	*list = make(BytesList, 0, len(objects))
+	for _, object := range objects {
+		obj, err := object.object()
+		if err != nil {
+			return err
+		}
+		*list = append(*list, obj)
+	}
 	return nil
 }
 
 func (list BytesList) Items() []Object {
-	objects := make([]Object, 0, len(list))
-	for _, object := range list {
-		objects = append(objects, object)
-	}
-	return objects
+	return list
 }
 
 type EventsBytes Bytes
 
 func (bytes *EventsBytes) Scan(data any) error {
-	return (*datatypes.JSON)(bytes).Scan(data)
+	return (*JSON)(bytes).Scan(data)
 }
 
 func (bytes EventsBytes) Value() (driver.Value, error) {
-	return (datatypes.JSON)(bytes).Value()
+	return (JSON)(bytes).Value()
 }
 
 func (bytes EventsBytes) Decode() ([]*corev1.Event, error) {
@@ -380,7 +523,8 @@ func (list ResourceMetadataWithEventsList) Items() []Object {
 }
 
 type BytesWithEvents struct {
-	Object Bytes
+	// Object is the Bytes or the ProtobufBytes of the object
+	Object Object
 	Events EventsBytes
 }
 
@@ -389,8 +533,7 @@ func (bytes BytesWithEvents) ConvertToUnstructured() (*unstructured.Unstructured
 }
 
 func (bytes BytesWithEvents) ConvertTo(codec runtime.Codec, object runtime.Object) (runtime.Object, error) {
-	obj, _, err := codec.Decode(bytes.Object, nil, object)
-	return obj, err
+	return bytes.Object.ConvertTo(codec, object)
 }
 
 func (bytes BytesWithEvents) GetResourceType() ResourceType {
@@ -405,9 +548,23 @@ func (bytes BytesWithEvents) GetEvents() []*corev1.Event {
 type BytesWithEventsList []BytesWithEvents
 
 func (list *BytesWithEventsList) From(db *gorm.DB) error {
-	if result := db.Select("object", "events").Find(list); result.Error != nil {
+	var objects []struct {
+		storedObject `gorm:"embedded"`
+
+		Events EventsBytes
+	}
+	if result := db.Select(append(storedObjectColumns, "events")).Find(&objects); result.Error != nil {
 		return result.Error
 	}
+
+	*list = make(BytesWithEventsList, 0, len(objects))
+	for _, object := range objects {
+		obj, err := object.object()
+		if err != nil {
+			return err
+		}
+		*list = append(*list, BytesWithEvents{Object: obj, Events: object.Events})
+	}
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/json"
@@ -20,7 +21,7 @@ import (
 )
 
 const (
-	SearchLabelFuzzyName = "internalstorage.clusterpedia.io/fuzzy-name"
+	SearchLabelFuzzyName = internal.SearchLabelFuzzyName
 
 	// Raw query
 	URLQueryWhereSQL = "whereSQL"
@@ -30,6 +31,21 @@ const (
 	URLQueryFieldWhereSQLJSONParams = "whereSQLJSONParams"
 )
 
+// SearchLabelQueryFunc builds the query of a custom search label, the error is returned to the client as a bad request.
+type SearchLabelQueryFunc func(query *gorm.DB, requirement labels.Requirement) (*gorm.DB, error)
+
+// searchLabelQueries are the query builders of the custom search labels
+var searchLabelQueries = map[string]SearchLabelQueryFunc{}
+
+// RegisterSearchLabelQuery registers the query builder of the custom search label,
+// it should be called in the init of the extensions, the label is usually registered by `clusterpedia.RegisterSearchLabel` too.
+func RegisterSearchLabelQuery(key string, fn SearchLabelQueryFunc) {
+	if _, ok := searchLabelQueries[key]; ok {
+		panic(fmt.Sprintf("the query of the search label %q is already registered", key))
+	}
+	searchLabelQueries[key] = fn
+}
+
 type URLQueryWhereSQLParams struct {
 	// Raw query
 	WhereSQL string
@@ -259,11 +275,46 @@ func applyListOptionsToQuery(query *gorm.DB, opts *internal.ListOptions, applyFn
 						name = strings.TrimSpace(name)
 						query = query.Where("name LIKE ?", fmt.Sprintf(`%%%s%%`, name))
 					}
+				default:
+					if fn, ok := searchLabelQueries[require.Key()]; ok {
+						var err error
+						if query, err = fn(query, require); err != nil {
+							return 0, nil, nil, apierrors.NewBadRequest(err.Error())
+						}
+						continue
+					}
+
+					// the cluster labels are stamped on the objects as the annotations by the enrichment
+					if !strings.HasPrefix(require.Key(), internal.ShadowAnnotationClusterLabelPrefix) {
+						continue
+					}
+
+					values := require.Values().List()
+					jsonQuery := JSONQuery("object", "metadata", "annotations", require.Key())
+					switch require.Operator() {
+					case selection.Exists:
+						jsonQuery.Exist()
+					case selection.DoesNotExist:
+						jsonQuery.NotExist()
+					case selection.Equals, selection.DoubleEquals:
+						jsonQuery.Equal(values[0])
+					case selection.NotEquals:
+						jsonQuery.NotEqual(values[0])
+					case selection.In:
+						jsonQuery.In(values...)
+					case selection.NotIn:
+						jsonQuery.NotIn(values...)
+					default:
+						continue
+					}
+					query = query.Where(jsonQuery)
 				}
 			}
 		}
 	}
 
+	// the json paths of the equality field selectors are recorded by the index advisor
+	var jsonPaths [][]string
 	if opts.EnhancedFieldSelector != nil {
 		if requirements, selectable := opts.EnhancedFieldSelector.Requirements(); selectable {
 			for _, requirement := range requirements {
@@ -293,10 +344,12 @@ func applyListOptionsToQuery(query *gorm.DB, opts *internal.ListOptions, applyFn
 					jsonQuery.NotExist()
 				case selection.Equals, selection.DoubleEquals:
 					jsonQuery.Equal(values[0])
+					jsonPaths = append(jsonPaths, fields)
 				case selection.NotEquals:
 					jsonQuery.NotEqual(values[0])
 				case selection.In:
 					jsonQuery.In(values...)
+					jsonPaths = append(jsonPaths, fields)
 				case selection.NotIn:
 					jsonQuery.NotIn(values...)
 				default:
@@ -323,8 +376,10 @@ func applyListOptionsToQuery(query *gorm.DB, opts *internal.ListOptions, applyFn
 
 	// Due to performance reasons, the default order by is not set.
 	// https://github.com/clusterpedia-io/clusterpedia/pull/44
+	orderByFields := make([]string, 0, len(opts.OrderBy))
 	for _, orderby := range opts.OrderBy {
 		orderByField := orderby.Field
+		orderByFields = append(orderByFields, orderByField)
 		if orderByField == "resource_version" {
 			orderByField = "CAST(resource_version as decimal)"
 		}
@@ -337,6 +392,8 @@ func applyListOptionsToQuery(query *gorm.DB, opts *internal.ListOptions, applyFn
 
 		// if orderby.Field is unsupported, return invalid error?
 	}
+	recordQueryShape(jsonPaths, orderByFields)
+
 	// kube ListOptions does not specify a limit default value of 0, gorm will execute limit = 0, resulting in the return of empty data.
 	// https://github.com/go-gorm/gorm/commit/e8f48b5c155b6fbf2e1fe6a554e2280f62af21a7
 	if opts.Limit > 0 {
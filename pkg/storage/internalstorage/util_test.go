@@ -18,6 +18,7 @@ import (
 	kubefields "k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	internal "github.com/clusterpedia-io/api/clusterpedia"
@@ -289,7 +290,7 @@ func TestApplyListOptionsToQuery_LabelSelector(t *testing.T) {
 			"equal",
 			"key1=value1",
 			expected{
-				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' ->> 'key1' = 'value1'`,
+				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' @> '{"key1":"value1"}'::jsonb`,
 				"SELECT * FROM `resources` WHERE JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"key1\"')) = 'value1'",
 				"",
 			},
@@ -298,7 +299,7 @@ func TestApplyListOptionsToQuery_LabelSelector(t *testing.T) {
 			"equal with complex key",
 			"key1.io=value1",
 			expected{
-				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' ->> 'key1.io' = 'value1'`,
+				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' @> '{"key1.io":"value1"}'::jsonb`,
 				"SELECT * FROM `resources` WHERE JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"key1.io\"')) = 'value1'",
 				"",
 			},
@@ -307,7 +308,7 @@ func TestApplyListOptionsToQuery_LabelSelector(t *testing.T) {
 			"equal with empty value",
 			"key1.io=",
 			expected{
-				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' ->> 'key1.io' = ''`,
+				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' @> '{"key1.io":""}'::jsonb`,
 				"SELECT * FROM `resources` WHERE JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"key1.io\"')) = ''",
 				"",
 			},
@@ -326,7 +327,7 @@ func TestApplyListOptionsToQuery_LabelSelector(t *testing.T) {
 			"key1 in (value1, value2),key2=value2",
 
 			expected{
-				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' ->> 'key1' IN ('value1','value2') AND "object" -> 'metadata' -> 'labels' ->> 'key2' = 'value2'`,
+				`SELECT * FROM "resources" WHERE ("object" -> 'metadata' -> 'labels' @> '{"key1":"value1"}'::jsonb OR "object" -> 'metadata' -> 'labels' @> '{"key1":"value2"}'::jsonb) AND "object" -> 'metadata' -> 'labels' @> '{"key2":"value2"}'::jsonb`,
 				"SELECT * FROM `resources` WHERE JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"key1\"')) IN ('value1','value2') AND JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"key2\"')) = 'value2'",
 				"",
 			},
@@ -336,7 +337,7 @@ func TestApplyListOptionsToQuery_LabelSelector(t *testing.T) {
 			"key1 notin (value1, value2),key2=value2",
 
 			expected{
-				`SELECT * FROM "resources" WHERE ("object" -> 'metadata' -> 'labels' ->> 'key1' IS NULL OR "object" -> 'metadata' -> 'labels' ->> 'key1' NOT IN ('value1','value2')) AND "object" -> 'metadata' -> 'labels' ->> 'key2' = 'value2'`,
+				`SELECT * FROM "resources" WHERE ("object" -> 'metadata' -> 'labels' ->> 'key1' IS NULL OR "object" -> 'metadata' -> 'labels' ->> 'key1' NOT IN ('value1','value2')) AND "object" -> 'metadata' -> 'labels' @> '{"key2":"value2"}'::jsonb`,
 				"SELECT * FROM `resources` WHERE (JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"key1\"') IS NULL OR JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"key1\"')) NOT IN ('value1','value2')) AND JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"key2\"')) = 'value2'",
 				"",
 			},
@@ -345,7 +346,7 @@ func TestApplyListOptionsToQuery_LabelSelector(t *testing.T) {
 			"exist",
 			"key1.io",
 			expected{
-				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' ->> 'key1.io' IS NOT NULL`,
+				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'labels' ? 'key1.io'`,
 				"SELECT * FROM `resources` WHERE JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"labels\".\"key1.io\"')) IS NOT NULL",
 				"",
 			},
@@ -373,6 +374,94 @@ func TestApplyListOptionsToQuery_LabelSelector(t *testing.T) {
 	}
 }
 
+func TestApplyListOptionsToQuery_ClusterLabels(t *testing.T) {
+	tests := []struct {
+		name          string
+		labelSelector string
+		expected      expected
+	}{
+		{
+			"equal",
+			"cluster-labels.shadow.clusterpedia.io/region=us-east-1",
+			expected{
+				`SELECT * FROM "resources" WHERE "object" -> 'metadata' -> 'annotations' @> '{"cluster-labels.shadow.clusterpedia.io/region":"us-east-1"}'::jsonb`,
+				"SELECT * FROM `resources` WHERE JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"annotations\".\"cluster-labels.shadow.clusterpedia.io/region\"')) = 'us-east-1'",
+				"",
+			},
+		},
+		{
+			"in",
+			"cluster-labels.shadow.clusterpedia.io/env in (prod,staging)",
+			expected{
+				`SELECT * FROM "resources" WHERE ("object" -> 'metadata' -> 'annotations' @> '{"cluster-labels.shadow.clusterpedia.io/env":"prod"}'::jsonb OR "object" -> 'metadata' -> 'annotations' @> '{"cluster-labels.shadow.clusterpedia.io/env":"staging"}'::jsonb)`,
+				"SELECT * FROM `resources` WHERE JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"annotations\".\"cluster-labels.shadow.clusterpedia.io/env\"')) IN ('prod','staging')",
+				"",
+			},
+		},
+		{
+			"unknown extra label",
+			"unknown.clusterpedia.io/key=value",
+			expected{
+				`SELECT * FROM "resources"`,
+				"SELECT * FROM `resources`",
+				"",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var listOptions = &internal.ListOptions{}
+		selector, err := labels.Parse(test.labelSelector)
+		if err != nil {
+			t.Fatalf("labels.Parse() failed: %v", err)
+		}
+		listOptions.ExtraLabelSelector = selector
+
+		testApplyListOptionsToQuery(t, test.name, listOptions, test.expected)
+	}
+}
+
+func TestApplyListOptionsToQuery_CustomSearchLabel(t *testing.T) {
+	RegisterSearchLabelQuery("search.clusterpedia.io/team", func(query *gorm.DB, requirement labels.Requirement) (*gorm.DB, error) {
+		if requirement.Operator() != selection.In && requirement.Operator() != selection.Equals {
+			return nil, fmt.Errorf("only the = and in operators are supported")
+		}
+		return query.Where(JSONQuery("object", "metadata", "annotations", "example.io/team").In(requirement.Values().List()...)), nil
+	})
+
+	tests := []struct {
+		name          string
+		labelSelector string
+		expected      expected
+	}{
+		{
+			"in",
+			"search.clusterpedia.io/team in (infra,web)",
+			expected{
+				`SELECT * FROM "resources" WHERE ("object" -> 'metadata' -> 'annotations' @> '{"example.io/team":"infra"}'::jsonb OR "object" -> 'metadata' -> 'annotations' @> '{"example.io/team":"web"}'::jsonb)`,
+				"SELECT * FROM `resources` WHERE JSON_UNQUOTE(JSON_EXTRACT(`object`,'$.\"metadata\".\"annotations\".\"example.io/team\"')) IN ('infra','web')",
+				"",
+			},
+		},
+		{
+			"unsupported operator",
+			"search.clusterpedia.io/team!=web",
+			expected{"", "", "only the = and in operators are supported"},
+		},
+	}
+
+	for _, test := range tests {
+		var listOptions = &internal.ListOptions{}
+		selector, err := labels.Parse(test.labelSelector)
+		if err != nil {
+			t.Fatalf("labels.Parse() failed: %v", err)
+		}
+		listOptions.ExtraLabelSelector = selector
+
+		testApplyListOptionsToQuery(t, test.name, listOptions, test.expected)
+	}
+}
+
 func TestApplyListOptionsToQuery_EnhancedFieldSelector(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,42 @@
+package kvstorage
+
+import (
+	"errors"
+	"time"
+)
+
+const defaultOpenTimeout = 10 * time.Second
+
+// Config is the config of the embedded key-value storage, which stores the resources in a bbolt file,
+// so clusterpedia can run on the small or air-gapped environments without any database.
+//
+// The file is locked by the process which opens it, so the storage is used by a single process,
+// e.g. the binding apiserver which runs the apiserver and the clustersynchro manager together.
+type Config struct {
+	// Path is the path of the database file, it is created if it doesn't exist.
+	Path string `yaml:"path" env:"KV_PATH" required:"true"`
+
+	// OpenTimeout bounds the time to wait for the lock of the file, default is 10s.
+	OpenTimeout time.Duration `yaml:"openTimeout"`
+
+	// NoSync skips fsync after each write, the writes after the last sync may be lost when the host crashes,
+	// it speeds up the first synchronization of the clusters on the slow disks.
+	NoSync bool `yaml:"noSync"`
+}
+
+func (cfg *Config) validate() error {
+	if cfg.Path == "" {
+		return errors.New("the path of the database file is required")
+	}
+	if cfg.OpenTimeout < 0 {
+		return errors.New("openTimeout should not be negative")
+	}
+	return nil
+}
+
+func (cfg *Config) openTimeout() time.Duration {
+	if cfg.OpenTimeout == 0 {
+		return defaultOpenTimeout
+	}
+	return cfg.OpenTimeout
+}
@@ -0,0 +1,24 @@
+package kvstorage
+
+import (
+	"errors"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// InterpretBoltError maps the errors of bbolt to the storage errors.
+func InterpretBoltError(key string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, bolt.ErrValueTooLarge), errors.Is(err, bolt.ErrKeyTooLarge):
+		return storage.NewTooLargeError(key, err)
+	case errors.Is(err, bolt.ErrTimeout), errors.Is(err, bolt.ErrDatabaseNotOpen), errors.Is(err, bolt.ErrDatabaseReadOnly):
+		return storage.NewUnavailableError(key, err)
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+package kvstorage
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The objects of each resource are stored in the bucket of the resource under the resources bucket:
+//
+//	resources/<group>/<version>/<resource>/
+//		objects/<cluster>\x00<namespace>\x00<name>                          -> object
+//		namespaces/<namespace>\x00<cluster>\x00<name>                       -> object key
+//		labels/<label key>\x00<label value>\x00<cluster>\x00<namespace>\x00<name> -> object key
+//
+// The object keys are ordered by the cluster and the namespace, so the objects of a cluster or a namespace of a cluster
+// are listed by one prefix, the namespaces and the labels buckets are the secondary indexes of the objects
+// across the clusters, which are updated with the objects in the same transaction.
+// The namespace is empty for the cluster-scoped objects, and the core group is written as `core`.
+var (
+	resourcesBucket  = []byte("resources")
+	objectsBucket    = []byte("objects")
+	namespacesBucket = []byte("namespaces")
+	labelsBucket     = []byte("labels")
+)
+
+const (
+	coreGroup = "core"
+
+	// separator separates the parts of the keys, it can't be a part of the names and the labels
+	separator = "\x00"
+)
+
+func gvrPath(gvr schema.GroupVersionResource) string {
+	group := gvr.Group
+	if group == "" {
+		group = coreGroup
+	}
+	return group + "/" + gvr.Version + "/" + gvr.Resource
+}
+
+func parseGVRPath(path string) (schema.GroupVersionResource, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid resource path: %s", path)
+	}
+
+	group := parts[0]
+	if group == coreGroup {
+		group = ""
+	}
+	return schema.GroupVersionResource{Group: group, Version: parts[1], Resource: parts[2]}, nil
+}
+
+func joinKey(parts ...string) []byte {
+	return []byte(strings.Join(parts, separator))
+}
+
+func objectKey(cluster, namespace, name string) []byte {
+	return joinKey(cluster, namespace, name)
+}
+
+// clusterPrefix is the prefix of the object keys of the cluster, and the namespace of the cluster if it is not empty.
+func clusterPrefix(cluster, namespace string) []byte {
+	if namespace == "" {
+		return joinKey(cluster, "")
+	}
+	return joinKey(cluster, namespace, "")
+}
+
+func parseObjectKey(key []byte) (cluster, namespace, name string, err error) {
+	parts := bytes.Split(key, []byte(separator))
+	if len(parts) != 3 || len(parts[0]) == 0 || len(parts[2]) == 0 {
+		return "", "", "", fmt.Errorf("invalid object key: %q", key)
+	}
+	return string(parts[0]), string(parts[1]), string(parts[2]), nil
+}
+
+func namespaceIndexKey(cluster, namespace, name string) []byte {
+	return joinKey(namespace, cluster, name)
+}
+
+func namespaceIndexPrefix(namespace string) []byte {
+	return joinKey(namespace, "")
+}
+
+func labelIndexKey(key, value, cluster, namespace, name string) []byte {
+	return joinKey(key, value, cluster, namespace, name)
+}
+
+func labelIndexPrefix(key, value string) []byte {
+	return joinKey(key, value, "")
+}
@@ -0,0 +1,46 @@
+package kvstorage
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestObjectKey(t *testing.T) {
+	key := objectKey("cluster-1", "default", "pod-1")
+	cluster, namespace, name, err := parseObjectKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster != "cluster-1" || namespace != "default" || name != "pod-1" {
+		t.Errorf("parseObjectKey() = %s, %s, %s", cluster, namespace, name)
+	}
+
+	if _, _, _, err := parseObjectKey(objectKey("cluster-1", "", "")); err == nil {
+		t.Error("expected an error for the key without the name")
+	}
+
+	// the prefix of a cluster doesn't match the clusters with the same prefix
+	if bytes.HasPrefix(objectKey("cluster-10", "default", "pod-1"), clusterPrefix("cluster-1", "")) {
+		t.Error("the prefix of cluster-1 matches the objects of cluster-10")
+	}
+	if !bytes.HasPrefix(key, clusterPrefix("cluster-1", "default")) {
+		t.Error("the prefix of the namespace doesn't match the objects of the namespace")
+	}
+}
+
+func TestGVRPath(t *testing.T) {
+	for _, gvr := range []schema.GroupVersionResource{
+		{Version: "v1", Resource: "pods"},
+		{Group: "apps", Version: "v1", Resource: "deployments"},
+	} {
+		parsed, err := parseGVRPath(gvrPath(gvr))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != gvr {
+			t.Errorf("parseGVRPath() = %v, want %v", parsed, gvr)
+		}
+	}
+}
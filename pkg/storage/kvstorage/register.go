@@ -0,0 +1,49 @@
+package kvstorage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jinzhu/configor"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	StorageName = "kv"
+)
+
+func init() {
+	storage.RegisterStorageFactoryFunc(StorageName, NewStorageFactory)
+}
+
+func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
+	if configPath == "" {
+		return nil, fmt.Errorf("configPath should not be empty")
+	}
+
+	cfg := &Config{}
+	if err := configor.Load(cfg, configPath); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return newStorageFactory(cfg)
+}
+
+func newStorageFactory(cfg *Config) (*StorageFactory, error) {
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o700); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(cfg.Path, 0o600, &bolt.Options{Timeout: cfg.openTimeout(), NoSync: cfg.NoSync})
+	if err != nil {
+		if err == bolt.ErrTimeout {
+			return nil, fmt.Errorf("failed to open %s, the file is locked by another process: %w", cfg.Path, err)
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", cfg.Path, err)
+	}
+	return &StorageFactory{db: db}, nil
+}
@@ -0,0 +1,528 @@
+package kvstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	bolt "go.etcd.io/bbolt"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// ResourceStorage stores the objects of a resource in the bucket of the resource,
+// the objects are returned with the resource versions of the member clusters.
+type ResourceStorage struct {
+	db     *bolt.DB
+	config storage.ResourceStorageConfig
+}
+
+var _ storage.ResourceStorage = &ResourceStorage{}
+
+func (s *ResourceStorage) GetStorageConfig() *storage.ResourceStorageConfig {
+	config := s.config
+	return &config
+}
+
+func (s *ResourceStorage) objectKey(cluster, namespace, name string) string {
+	return gvrPath(s.config.StorageResource) + "/" + cluster + "/" + namespace + "/" + name
+}
+
+// bucket returns the bucket of the resource, it is nil if the resource is never written.
+func (s *ResourceStorage) bucket(tx *bolt.Tx) *bolt.Bucket {
+	resources := tx.Bucket(resourcesBucket)
+	if resources == nil {
+		return nil
+	}
+	return resources.Bucket([]byte(gvrPath(s.config.StorageResource)))
+}
+
+func (s *ResourceStorage) createBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
+	resources, err := tx.CreateBucketIfNotExists(resourcesBucket)
+	if err != nil {
+		return nil, err
+	}
+	bucket, err := resources.CreateBucketIfNotExists([]byte(gvrPath(s.config.StorageResource)))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range [][]byte{objectsBucket, namespacesBucket, labelsBucket} {
+		if _, err := bucket.CreateBucketIfNotExists(name); err != nil {
+			return nil, err
+		}
+	}
+	return bucket, nil
+}
+
+func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		return fmt.Errorf("%s: kind is required", gvk)
+	}
+	return s.put(cluster, obj, true)
+}
+
+func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) error {
+	return s.put(cluster, obj, false)
+}
+
+// put writes the object and updates its indexes in one transaction,
+// the object must not exist if create is true, and must exist otherwise.
+func (s *ResourceStorage) put(cluster string, obj runtime.Object, create bool) error {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	if err := s.config.Codec.Encode(obj, &buffer); err != nil {
+		return err
+	}
+
+	namespace, name := metaobj.GetNamespace(), metaobj.GetName()
+	key := s.objectKey(cluster, namespace, name)
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := s.createBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		stored := bucket.Bucket(objectsBucket).Get(objectKey(cluster, namespace, name))
+		switch {
+		case create && stored != nil:
+			return storage.NewConflictError(key, errors.New("the object already exists"))
+		case !create && stored == nil:
+			return storage.NewNotFoundError(key, errors.New("the object does not exist"))
+		}
+		if stored != nil {
+			if err := deleteIndexes(bucket, cluster, stored); err != nil {
+				return err
+			}
+		}
+
+		if err := bucket.Bucket(objectsBucket).Put(objectKey(cluster, namespace, name), buffer.Bytes()); err != nil {
+			return err
+		}
+		return putIndexes(bucket, cluster, namespace, name, metaobj.GetLabels())
+	})
+	return InterpretBoltError(key, err)
+}
+
+func putIndexes(bucket *bolt.Bucket, cluster, namespace, name string, objLabels map[string]string) error {
+	key := objectKey(cluster, namespace, name)
+	if namespace != "" {
+		if err := bucket.Bucket(namespacesBucket).Put(namespaceIndexKey(cluster, namespace, name), key); err != nil {
+			return err
+		}
+	}
+	for k, v := range objLabels {
+		if err := bucket.Bucket(labelsBucket).Put(labelIndexKey(k, v, cluster, namespace, name), key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteIndexes deletes the index entries of the stored object
+func deleteIndexes(bucket *bolt.Bucket, cluster string, stored []byte) error {
+	var metadata metav1.PartialObjectMetadata
+	if err := json.Unmarshal(stored, &metadata); err != nil {
+		return err
+	}
+
+	namespace, name := metadata.Namespace, metadata.Name
+	if namespace != "" {
+		if err := bucket.Bucket(namespacesBucket).Delete(namespaceIndexKey(cluster, namespace, name)); err != nil {
+			return err
+		}
+	}
+	for k, v := range metadata.Labels {
+		if err := bucket.Bucket(labelsBucket).Delete(labelIndexKey(k, v, cluster, namespace, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ResourceStorage) ConvertDeletedObject(obj interface{}) (runtime.Object, error) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}, nil
+}
+
+func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) error {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+
+	namespace, name := metaobj.GetNamespace(), metaobj.GetName()
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		bucket := s.bucket(tx)
+		if bucket == nil {
+			return nil
+		}
+		return deleteObject(bucket, objectKey(cluster, namespace, name))
+	})
+	return InterpretBoltError(s.objectKey(cluster, namespace, name), err)
+}
+
+// deleteObject deletes the object and its index entries
+func deleteObject(bucket *bolt.Bucket, key []byte) error {
+	cluster, _, _, err := parseObjectKey(key)
+	if err != nil {
+		return err
+	}
+
+	objects := bucket.Bucket(objectsBucket)
+	stored := objects.Get(key)
+	if stored == nil {
+		return nil
+	}
+	if err := deleteIndexes(bucket, cluster, stored); err != nil {
+		return err
+	}
+	return objects.Delete(key)
+}
+
+// RecordEvent is a no-op, the events of the resources are not stored by the kv storage.
+func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event *corev1.Event) error {
+	return nil
+}
+
+func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, into runtime.Object) error {
+	key := s.objectKey(cluster, namespace, name)
+
+	var data []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if bucket := s.bucket(tx); bucket != nil {
+			// the values are only valid in the transaction
+			data = bytes.Clone(bucket.Bucket(objectsBucket).Get(objectKey(cluster, namespace, name)))
+		}
+		return nil
+	})
+	if err != nil {
+		return InterpretBoltError(key, err)
+	}
+	if data == nil {
+		return storage.NewNotFoundError(key, errors.New("the object does not exist"))
+	}
+
+	obj, _, err := s.config.Codec.Decode(data, nil, into)
+	if err != nil {
+		return err
+	}
+	if obj != into {
+		return fmt.Errorf("failed to decode resource, into is %T", into)
+	}
+	return nil
+}
+
+// the continue token is the last listed object key
+func encodeContinue(key []byte) string {
+	return base64.RawURLEncoding.EncodeToString(key)
+}
+
+func decodeContinue(token string) ([]byte, error) {
+	key, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, storage.NewInvalidQueryError(token, fmt.Errorf("invalid continue token: %w", err))
+	}
+	return key, nil
+}
+
+func (s *ResourceStorage) List(ctx context.Context, listObject runtime.Object, opts *internal.ListOptions) error {
+	predicate, err := newListPredicate(opts)
+	if err != nil {
+		return err
+	}
+	var after []byte
+	if opts.Continue != "" {
+		if after, err = decodeContinue(opts.Continue); err != nil {
+			return err
+		}
+	}
+
+	var (
+		objects [][]byte
+		lastKey []byte
+		more    bool
+	)
+	err = s.db.View(func(tx *bolt.Tx) error {
+		bucket := s.bucket(tx)
+		if bucket == nil {
+			return nil
+		}
+
+		objectsBucket := bucket.Bucket(objectsBucket)
+		return predicate.scan(bucket, after, func(key, value []byte) (bool, error) {
+			if value == nil {
+				value = objectsBucket.Get(key)
+			}
+			if value == nil {
+				return true, nil
+			}
+			matched, err := predicate.match(key, value)
+			if err != nil || !matched {
+				return true, err
+			}
+
+			if opts.Limit > 0 && int64(len(objects)) == opts.Limit {
+				// there are more objects after the limit
+				more = true
+				return false, nil
+			}
+			// the keys and the values are only valid in the transaction
+			objects = append(objects, bytes.Clone(value))
+			lastKey = bytes.Clone(key)
+			return true, nil
+		})
+	})
+	if err != nil {
+		return InterpretBoltError(gvrPath(s.config.StorageResource), err)
+	}
+
+	list, err := meta.ListAccessor(listObject)
+	if err != nil {
+		return err
+	}
+	if more && opts.WithContinue != nil && *opts.WithContinue {
+		list.SetContinue(encodeContinue(lastKey))
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+
+	if unstructuredList, ok := listObject.(*unstructured.UnstructuredList); ok {
+		unstructuredList.Items = make([]unstructured.Unstructured, 0, len(objects))
+		for _, object := range objects {
+			obj, _, err := s.config.Codec.Decode(object, nil, &unstructured.Unstructured{})
+			if err != nil {
+				return err
+			}
+			uObj, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("the decoded object is not *unstructured.Unstructured")
+			}
+			unstructuredList.Items = append(unstructuredList.Items, *uObj)
+		}
+		return nil
+	}
+
+	listPtr, err := meta.GetItemsPtr(listObject)
+	if err != nil {
+		return err
+	}
+	v, err := conversion.EnforcePtr(listPtr)
+	if err != nil || v.Kind() != reflect.Slice {
+		return fmt.Errorf("need ptr to slice: %v", err)
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(objects), len(objects))
+	expected := reflect.New(v.Type().Elem()).Interface().(runtime.Object)
+	for i, object := range objects {
+		obj, _, err := s.config.Codec.Decode(object, nil, expected.DeepCopyObject())
+		if err != nil {
+			return err
+		}
+		slice.Index(i).Set(reflect.ValueOf(obj).Elem())
+	}
+	v.Set(slice)
+	return nil
+}
+
+func (s *ResourceStorage) Watch(_ context.Context, _ *internal.ListOptions) (watch.Interface, error) {
+	return nil, apierrors.NewMethodNotSupported(s.config.StorageResource.GroupResource(), "watch")
+}
+
+// listPredicate selects the objects by the indexes and filters them by the list options.
+type listPredicate struct {
+	clusters   sets.Set[string]
+	namespaces sets.Set[string]
+	names      sets.Set[string]
+
+	labels labels.Selector
+
+	since  *metav1.Time
+	before *metav1.Time
+
+	ownerUID string
+}
+
+func newListPredicate(opts *internal.ListOptions) (*listPredicate, error) {
+	unsupported := func(option string) error {
+		return storage.NewInvalidQueryError("", fmt.Errorf("%s is not supported by the %s storage", option, StorageName))
+	}
+	switch {
+	case len(opts.OrderBy) != 0:
+		return nil, unsupported("orderby")
+	case opts.EnhancedFieldSelector != nil && !opts.EnhancedFieldSelector.Empty():
+		return nil, unsupported("the field selector")
+	case opts.OwnerName != "":
+		return nil, unsupported("the owner name")
+	case opts.OwnerUID != "" && (len(opts.ClusterNames) != 1 || opts.OwnerSeniority != 0):
+		return nil, unsupported("the owner seniority or the owner without a cluster")
+	}
+
+	predicate := &listPredicate{
+		since:    opts.Since,
+		before:   opts.Before,
+		ownerUID: opts.OwnerUID,
+	}
+	if len(opts.ClusterNames) != 0 {
+		predicate.clusters = sets.New(opts.ClusterNames...)
+	}
+	if len(opts.Namespaces) != 0 {
+		predicate.namespaces = sets.New(opts.Namespaces...)
+	}
+	if len(opts.Names) != 0 {
+		predicate.names = sets.New(opts.Names...)
+	}
+	if opts.LabelSelector != nil && !opts.LabelSelector.Empty() {
+		predicate.labels = opts.LabelSelector
+	}
+	return predicate, nil
+}
+
+// indexedLabel returns the first requirement of the label selector which can be selected by the labels index
+func (p *listPredicate) indexedLabel() (string, []string, bool) {
+	if p.labels == nil {
+		return "", nil, false
+	}
+	requirements, _ := p.labels.Requirements()
+	for _, requirement := range requirements {
+		switch requirement.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.In:
+			return requirement.Key(), requirement.Values().List(), true
+		}
+	}
+	return "", nil, false
+}
+
+// scan visits the candidate objects after the key in the order of the object keys until visit returns false,
+// the value is nil if the object is selected by the indexes.
+//
+// The candidates are selected by the labels index if the label selector has an equality requirement,
+// by the namespaces index if the objects of the namespaces in multiple clusters are listed,
+// otherwise the objects are scanned by the prefix of the only cluster and namespace.
+func (p *listPredicate) scan(bucket *bolt.Bucket, after []byte, visit func(key, value []byte) (bool, error)) error {
+	var prefixes [][]byte
+	var index *bolt.Bucket
+	if key, values, ok := p.indexedLabel(); ok {
+		index = bucket.Bucket(labelsBucket)
+		for _, value := range values {
+			prefixes = append(prefixes, labelIndexPrefix(key, value))
+		}
+	} else if p.namespaces != nil && p.clusters.Len() != 1 {
+		index = bucket.Bucket(namespacesBucket)
+		for _, namespace := range sets.List(p.namespaces) {
+			prefixes = append(prefixes, namespaceIndexPrefix(namespace))
+		}
+	}
+
+	if index != nil {
+		var keys [][]byte
+		for _, prefix := range prefixes {
+			c := index.Cursor()
+			for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+				if after == nil || bytes.Compare(v, after) > 0 {
+					keys = append(keys, v)
+				}
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+		for _, key := range keys {
+			if next, err := visit(key, nil); err != nil || !next {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var prefix []byte
+	if p.clusters.Len() == 1 {
+		var namespace string
+		if p.namespaces.Len() == 1 {
+			namespace = sets.List(p.namespaces)[0]
+		}
+		prefix = clusterPrefix(sets.List(p.clusters)[0], namespace)
+	}
+
+	c := bucket.Bucket(objectsBucket).Cursor()
+	k, v := c.Seek(prefix)
+	if after != nil && bytes.Compare(after, prefix) >= 0 {
+		if k, v = c.Seek(after); k != nil && bytes.Equal(k, after) {
+			k, v = c.Next()
+		}
+	}
+	for ; k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if next, err := visit(k, v); err != nil || !next {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *listPredicate) match(key, value []byte) (bool, error) {
+	cluster, namespace, name, err := parseObjectKey(key)
+	if err != nil {
+		return false, err
+	}
+	if p.clusters != nil && !p.clusters.Has(cluster) {
+		return false, nil
+	}
+	if p.namespaces != nil && !p.namespaces.Has(namespace) {
+		return false, nil
+	}
+	if p.names != nil && !p.names.Has(name) {
+		return false, nil
+	}
+	if p.labels == nil && p.since == nil && p.before == nil && p.ownerUID == "" {
+		return true, nil
+	}
+
+	var metadata metav1.PartialObjectMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return false, err
+	}
+	if p.labels != nil && !p.labels.Matches(labels.Set(metadata.Labels)) {
+		return false, nil
+	}
+	if p.since != nil && metadata.CreationTimestamp.Before(p.since) {
+		return false, nil
+	}
+	if p.before != nil && !metadata.CreationTimestamp.Before(p.before) {
+		return false, nil
+	}
+	if p.ownerUID != "" {
+		owner := metav1.GetControllerOfNoCopy(&metadata)
+		if owner == nil || string(owner.UID) != p.ownerUID {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,221 @@
+package kvstorage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func newTestFactory(t *testing.T) *StorageFactory {
+	factory, err := newStorageFactory(&Config{Path: filepath.Join(t.TempDir(), "clusterpedia.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = factory.Shutdown() })
+	return factory
+}
+
+func newTestResourceStorage(t *testing.T, factory *StorageFactory) *ResourceStorage {
+	rs, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: resourceconfig.ResourceConfig{
+		StorageResource: corev1.SchemeGroupVersion.WithResource("pods"),
+		Codec:           scheme.LegacyResourceCodecs.LegacyCodec(corev1.SchemeGroupVersion),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs.(*ResourceStorage)
+}
+
+func newTestPod(namespace, name string, podLabels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: podLabels, ResourceVersion: "1"},
+	}
+}
+
+func listPodNames(t *testing.T, rs *ResourceStorage, opts *internal.ListOptions) ([]string, string) {
+	t.Helper()
+	list := &corev1.PodList{}
+	if err := rs.List(context.Background(), list, opts); err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, pod := range list.Items {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	return names, list.Continue
+}
+
+func TestResourceStorage(t *testing.T) {
+	ctx := context.Background()
+	rs := newTestResourceStorage(t, newTestFactory(t))
+
+	for cluster, pods := range map[string][]*corev1.Pod{
+		"cluster-1": {
+			newTestPod("default", "nginx-1", map[string]string{"app": "nginx"}),
+			newTestPod("default", "redis-1", map[string]string{"app": "redis"}),
+			newTestPod("kube-system", "coredns", map[string]string{"k8s-app": "kube-dns"}),
+		},
+		"cluster-2": {
+			newTestPod("default", "nginx-2", map[string]string{"app": "nginx", "tier": "frontend"}),
+		},
+	} {
+		for _, pod := range pods {
+			if err := rs.Create(ctx, cluster, pod); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := rs.Create(ctx, "cluster-1", newTestPod("default", "nginx-1", nil)); !storage.IsConflict(err) {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+	if err := rs.Update(ctx, "cluster-1", newTestPod("default", "nginx-3", nil)); !storage.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	pod := &corev1.Pod{}
+	if err := rs.Get(ctx, "cluster-2", "default", "nginx-2", pod); err != nil || pod.Labels["tier"] != "frontend" {
+		t.Fatalf("Get() = %v, %v", pod, err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     *internal.ListOptions
+		selector string
+		expected []string
+	}{
+		{"all", &internal.ListOptions{}, "", []string{"default/nginx-1", "default/redis-1", "kube-system/coredns", "default/nginx-2"}},
+		{"cluster", &internal.ListOptions{ClusterNames: []string{"cluster-2"}}, "", []string{"default/nginx-2"}},
+		{"cluster namespace", &internal.ListOptions{ClusterNames: []string{"cluster-1"}, Namespaces: []string{"kube-system"}}, "", []string{"kube-system/coredns"}},
+		{"namespace index", &internal.ListOptions{Namespaces: []string{"default"}}, "", []string{"default/nginx-1", "default/redis-1", "default/nginx-2"}},
+		{"label index", &internal.ListOptions{}, "app=nginx", []string{"default/nginx-1", "default/nginx-2"}},
+		{"label index and filter", &internal.ListOptions{}, "app in (nginx,redis),tier!=frontend", []string{"default/nginx-1", "default/redis-1"}},
+		{"label scan", &internal.ListOptions{ClusterNames: []string{"cluster-1"}}, "!app", []string{"kube-system/coredns"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.selector != "" {
+				selector, err := labels.Parse(test.selector)
+				if err != nil {
+					t.Fatal(err)
+				}
+				test.opts.LabelSelector = selector
+			}
+			names, _ := listPodNames(t, rs, test.opts)
+			if len(names) != len(test.expected) {
+				t.Fatalf("List() = %v, want %v", names, test.expected)
+			}
+			for i := range names {
+				if names[i] != test.expected[i] {
+					t.Fatalf("List() = %v, want %v", names, test.expected)
+				}
+			}
+		})
+	}
+
+	// the index entries of the old labels are replaced
+	if err := rs.Update(ctx, "cluster-1", newTestPod("default", "nginx-1", map[string]string{"app": "web"})); err != nil {
+		t.Fatal(err)
+	}
+	if err := rs.Delete(ctx, "cluster-2", newTestPod("default", "nginx-2", nil)); err != nil {
+		t.Fatal(err)
+	}
+	opts := &internal.ListOptions{}
+	opts.LabelSelector = labels.SelectorFromSet(labels.Set{"app": "nginx"})
+	if names, _ := listPodNames(t, rs, opts); len(names) != 0 {
+		t.Errorf("expected the stale index entries to be deleted, got %v", names)
+	}
+	opts.LabelSelector = labels.SelectorFromSet(labels.Set{"app": "web"})
+	if names, _ := listPodNames(t, rs, opts); len(names) != 1 {
+		t.Errorf("expected the updated index entries, got %v", names)
+	}
+}
+
+func TestResourceStorageListContinue(t *testing.T) {
+	ctx := context.Background()
+	rs := newTestResourceStorage(t, newTestFactory(t))
+	for _, name := range []string{"pod-1", "pod-2", "pod-3"} {
+		if err := rs.Create(ctx, "cluster-1", newTestPod("default", name, map[string]string{"app": "nginx"})); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, selector := range []string{"", "app=nginx"} {
+		var listed []string
+		opts := &internal.ListOptions{ClusterNames: []string{"cluster-1"}}
+		opts.Limit, opts.WithContinue = 2, &[]bool{true}[0]
+		if selector != "" {
+			opts.LabelSelector = labels.SelectorFromSet(labels.Set{"app": "nginx"})
+		}
+		for {
+			names, token := listPodNames(t, rs, opts)
+			listed = append(listed, names...)
+			if token == "" {
+				break
+			}
+			opts.Continue = token
+		}
+		if len(listed) != 3 || listed[2] != "default/pod-3" {
+			t.Errorf("expected all pods to be listed by the pages with the selector %q, got %v", selector, listed)
+		}
+	}
+
+	opts := &internal.ListOptions{}
+	opts.Continue = "!"
+	if err := rs.List(ctx, &corev1.PodList{}, opts); !storage.IsInvalidQuery(err) {
+		t.Errorf("expected an invalid query error, got %v", err)
+	}
+}
+
+func TestStorageFactoryCleanCluster(t *testing.T) {
+	ctx := context.Background()
+	factory := newTestFactory(t)
+	rs := newTestResourceStorage(t, factory)
+	for _, cluster := range []string{"cluster-1", "cluster-10", "cluster-2"} {
+		if err := rs.Create(ctx, cluster, newTestPod("default", "nginx", map[string]string{"app": "nginx"})); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	versions, err := factory.GetResourceVersions(ctx, "cluster-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rvs := versions[corev1.SchemeGroupVersion.WithResource("pods")]; len(rvs.Resources) != 1 || rvs.Resources["default/nginx"] != "1" {
+		t.Errorf("unexpected resource versions: %v", versions)
+	}
+
+	if err := factory.CleanCluster(ctx, "cluster-1"); err != nil {
+		t.Fatal(err)
+	}
+	opts := &internal.ListOptions{Namespaces: []string{"default"}}
+	opts.LabelSelector = labels.SelectorFromSet(labels.Set{"app": "nginx"})
+	if names, _ := listPodNames(t, rs, opts); len(names) != 2 {
+		t.Errorf("expected the objects and the index entries of the other clusters to be kept, got %v", names)
+	}
+
+	if err := factory.CleanClusterResource(ctx, "cluster-10", corev1.SchemeGroupVersion.WithResource("pods")); err != nil {
+		t.Fatal(err)
+	}
+	if versions, err := factory.GetResourceVersions(ctx, "cluster-10"); err != nil || len(versions) != 0 {
+		t.Errorf("expected the cluster to be cleaned, got %v, %v", versions, err)
+	}
+}
+
+func TestNewStorageFactoryLocked(t *testing.T) {
+	factory := newTestFactory(t)
+	_, err := newStorageFactory(&Config{Path: factory.db.Path(), OpenTimeout: 100 * time.Millisecond})
+	if err == nil {
+		t.Fatal("expected the locked file not to be opened")
+	}
+}
@@ -0,0 +1,144 @@
+package kvstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	bolt "go.etcd.io/bbolt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type StorageFactory struct {
+	db *bolt.DB
+}
+
+var _ storage.StorageFactory = &StorageFactory{}
+
+func (s *StorageFactory) GetSupportedRequestVerbs() []string {
+	return []string{"get", "list"}
+}
+
+func (s *StorageFactory) PrepareCluster(cluster string) error {
+	return nil
+}
+
+func (s *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
+	return &ResourceStorage{
+		db:     s.db,
+		config: *config,
+	}, nil
+}
+
+func (s *StorageFactory) NewCollectionResourceStorage(cr *internal.CollectionResource) (storage.CollectionResourceStorage, error) {
+	return nil, errors.New("collection resources are not supported by the kv storage")
+}
+
+func (s *StorageFactory) GetCollectionResources(ctx context.Context) ([]*internal.CollectionResource, error) {
+	return nil, nil
+}
+
+// forEachResource visits the buckets of the resources
+func forEachResource(tx *bolt.Tx, visit func(gvr schema.GroupVersionResource, bucket *bolt.Bucket) error) error {
+	resources := tx.Bucket(resourcesBucket)
+	if resources == nil {
+		return nil
+	}
+	return resources.ForEachBucket(func(name []byte) error {
+		gvr, err := parseGVRPath(string(name))
+		if err != nil {
+			return err
+		}
+		return visit(gvr, resources.Bucket(name))
+	})
+}
+
+func (s *StorageFactory) GetResourceVersions(ctx context.Context, cluster string) (map[schema.GroupVersionResource]storage.ClusterResourceVersions, error) {
+	resourceversions := make(map[schema.GroupVersionResource]storage.ClusterResourceVersions)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return forEachResource(tx, func(gvr schema.GroupVersionResource, bucket *bolt.Bucket) error {
+			versions := storage.ClusterResourceVersions{
+				Resources: make(map[string]interface{}),
+				Events:    make(map[string]interface{}),
+			}
+
+			prefix := clusterPrefix(cluster, "")
+			c := bucket.Bucket(objectsBucket).Cursor()
+			for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+				_, namespace, name, err := parseObjectKey(k)
+				if err != nil {
+					return err
+				}
+
+				var metadata metav1.PartialObjectMetadata
+				if err := json.Unmarshal(v, &metadata); err != nil {
+					return err
+				}
+
+				key := name
+				if namespace != "" {
+					key = namespace + "/" + name
+				}
+				versions.Resources[key] = metadata.ResourceVersion
+			}
+			if len(versions.Resources) != 0 {
+				resourceversions[gvr] = versions
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, InterpretBoltError(cluster, err)
+	}
+	return resourceversions, nil
+}
+
+func (s *StorageFactory) CleanCluster(ctx context.Context, cluster string) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return forEachResource(tx, func(_ schema.GroupVersionResource, bucket *bolt.Bucket) error {
+			return cleanClusterResource(bucket, cluster)
+		})
+	})
+	return InterpretBoltError(cluster, err)
+}
+
+func (s *StorageFactory) CleanClusterResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		resources := tx.Bucket(resourcesBucket)
+		if resources == nil {
+			return nil
+		}
+		bucket := resources.Bucket([]byte(gvrPath(gvr)))
+		if bucket == nil {
+			return nil
+		}
+		return cleanClusterResource(bucket, cluster)
+	})
+	return InterpretBoltError(gvrPath(gvr)+"/"+cluster, err)
+}
+
+// cleanClusterResource deletes the objects of the cluster and their index entries in the bucket of the resource
+func cleanClusterResource(bucket *bolt.Bucket, cluster string) error {
+	var keys [][]byte
+	prefix := clusterPrefix(cluster, "")
+	c := bucket.Bucket(objectsBucket).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		// the bucket can't be changed while it is iterated by the cursor
+		keys = append(keys, bytes.Clone(k))
+	}
+	for _, key := range keys {
+		if err := deleteObject(bucket, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *StorageFactory) Shutdown() error {
+	return s.db.Close()
+}
@@ -0,0 +1,5 @@
+//go:build !no_grpcstorage
+
+package options
+
+import _ "github.com/clusterpedia-io/clusterpedia/pkg/storage/grpcstorage"
@@ -0,0 +1,5 @@
+//go:build !no_kvstorage
+
+package options
+
+import _ "github.com/clusterpedia-io/clusterpedia/pkg/storage/kvstorage"
@@ -5,9 +5,6 @@ import (
 	"os"
 
 	"github.com/spf13/pflag"
-
-	_ "github.com/clusterpedia-io/clusterpedia/pkg/storage/internalstorage"
-	_ "github.com/clusterpedia-io/clusterpedia/pkg/storage/memorystorage"
 )
 
 type StorageOptions struct {
@@ -2,6 +2,10 @@ package storage
 
 import "fmt"
 
+// SkipMigrationEnvName is the env which skips the migrations of the storage when the storage factory is created,
+// so the storage can be inspected before the upgrade.
+const SkipMigrationEnvName = "DB_SKIP_MIGRATION"
+
 type NewStorageFactoryFunc func(configPath string) (StorageFactory, error)
 
 var storageFactoryFuncs = make(map[string]NewStorageFactoryFunc)
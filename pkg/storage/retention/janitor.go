@@ -0,0 +1,130 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// purgeTimeout bounds the purge of each policy
+const purgeTimeout = 5 * time.Minute
+
+var (
+	purgedObjectsTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage_retention",
+			Name:           "purged_objects_total",
+			Help:           "Number of the stored objects deleted by the retention policy, the cluster is empty for the policy of all clusters.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"group", "resource", "cluster"},
+	)
+
+	purgeFailuresTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage_retention",
+			Name:           "purge_failures_total",
+			Help:           "Number of the failed purges of the retention policy, the cluster is empty for the policy of all clusters.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"group", "resource", "cluster"},
+	)
+
+	lastPurgeTimestamp = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Namespace:      "clusterpedia",
+			Subsystem:      "storage_retention",
+			Name:           "last_purge_timestamp_seconds",
+			Help:           "The unix timestamp of the last purge of all the retention policies.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(purgedObjectsTotal, purgeFailuresTotal, lastPurgeTimestamp)
+}
+
+type Config struct {
+	Policies []Policy
+	Interval time.Duration
+}
+
+// Janitor purges the stored objects by the retention policies periodically.
+type Janitor struct {
+	purger   storage.ResourcePurger
+	policies []Policy
+	interval time.Duration
+}
+
+// NewJanitor returns an error if the storage doesn't support purging the stored objects.
+func NewJanitor(factory storage.StorageFactory, config *Config) (*Janitor, error) {
+	purger, ok := factory.(storage.ResourcePurger)
+	if !ok {
+		return nil, fmt.Errorf("the storage doesn't support the retention policies")
+	}
+	return &Janitor{purger: purger, policies: config.Policies, interval: config.Interval}, nil
+}
+
+// Run purges the stored objects until the ctx is done, it should only be run by the leader.
+func (j *Janitor) Run(ctx context.Context) {
+	klog.InfoS("Start the retention janitor", "policies", len(j.policies), "interval", j.interval)
+	wait.UntilWithContext(ctx, j.purge, j.interval)
+}
+
+func (j *Janitor) purge(ctx context.Context) {
+	now := time.Now()
+	for _, opts := range purgeOptions(j.policies, now) {
+		cluster := ""
+		if len(opts.Clusters) != 0 {
+			cluster = opts.Clusters[0]
+		}
+
+		purgeCtx, cancel := context.WithTimeout(ctx, purgeTimeout)
+		purged, err := j.purger.PurgeResources(purgeCtx, opts)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			klog.ErrorS(err, "Failed to purge the stored objects", "resource", opts.Resource, "cluster", cluster)
+			purgeFailuresTotal.WithLabelValues(opts.Resource.Group, opts.Resource.Resource, cluster).Inc()
+			continue
+		}
+
+		purgedObjectsTotal.WithLabelValues(opts.Resource.Group, opts.Resource.Resource, cluster).Add(float64(purged))
+		if purged != 0 {
+			klog.InfoS("Purged the stored objects", "resource", opts.Resource, "cluster", cluster, "objects", purged, "createdBefore", opts.CreatedBefore)
+		}
+	}
+	lastPurgeTimestamp.Set(float64(now.Unix()))
+}
+
+// purgeOptions returns the purge options of the policies,
+// the clusters with their own policies are excluded from the policy of all clusters for the same resource.
+func purgeOptions(policies []Policy, now time.Time) []storage.PurgeOptions {
+	options := make([]storage.PurgeOptions, 0, len(policies))
+	for _, policy := range policies {
+		opts := storage.PurgeOptions{Resource: policy.Resource, CreatedBefore: now.Add(-policy.TTL)}
+		if policy.Cluster != "" {
+			opts.Clusters = []string{policy.Cluster}
+		} else {
+			for _, p := range policies {
+				if p.Resource == policy.Resource && p.Cluster != "" {
+					opts.ExcludedClusters = append(opts.ExcludedClusters, p.Cluster)
+				}
+			}
+		}
+		options = append(options, opts)
+	}
+	return options
+}
@@ -0,0 +1,70 @@
+package retention
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type Options struct {
+	Policies []string
+	Interval time.Duration
+}
+
+func NewOptions() *Options {
+	return &Options{Interval: 10 * time.Minute}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVar(&o.Policies, "retention-policy", o.Policies, ""+
+		"The retention policy which deletes the stored objects of the resource after the ttl since they are created, "+
+		"in the form of '<resource>[.<group>][@<cluster>]=<ttl>', e.g. 'jobs.batch=168h' or 'events@cluster-1=48h'. "+
+		"The policy of a cluster overrides the policy of all clusters for the same resource. "+
+		"The purged objects are stored again when they are updated in the clusters. This flag can be repeated.")
+	fs.DurationVar(&o.Interval, "retention-interval", o.Interval, "The interval of purging the stored objects by the retention policies")
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+	if _, err := o.policies(); err != nil {
+		errs = append(errs, err)
+	}
+	if o.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("retention-interval must be greater than 0"))
+	}
+	return errs
+}
+
+// Config returns nil if no policies are set.
+func (o *Options) Config() (*Config, error) {
+	policies, err := o.policies()
+	if err != nil || len(policies) == 0 {
+		return nil, err
+	}
+	return &Config{Policies: policies, Interval: o.Interval}, nil
+}
+
+func (o *Options) policies() ([]Policy, error) {
+	type key struct {
+		resource string
+		cluster  string
+	}
+	seen := make(map[key]bool)
+
+	policies := make([]Policy, 0, len(o.Policies))
+	for _, value := range o.Policies {
+		policy, err := ParsePolicy(value)
+		if err != nil {
+			return nil, err
+		}
+
+		k := key{resource: policy.Resource.String(), cluster: policy.Cluster}
+		if seen[k] {
+			return nil, fmt.Errorf("duplicate retention policy of %s", value)
+		}
+		seen[k] = true
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
@@ -0,0 +1,60 @@
+package retention
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Policy deletes the stored objects of the resource after the ttl since they are created,
+// the policy of a cluster overrides the policy of all clusters for the same resource.
+type Policy struct {
+	Resource schema.GroupResource
+
+	// Cluster is the cluster of the policy, empty means the policy applies to all clusters
+	Cluster string
+
+	TTL time.Duration
+}
+
+func (p Policy) String() string {
+	if p.Cluster == "" {
+		return fmt.Sprintf("%s=%s", p.Resource, p.TTL)
+	}
+	return fmt.Sprintf("%s@%s=%s", p.Resource, p.Cluster, p.TTL)
+}
+
+// ParsePolicy parses the policy in the form of `<resource>[.<group>][@<cluster>]=<ttl>`,
+// e.g. `jobs.batch=168h`, `events@cluster-1=48h`.
+func ParsePolicy(value string) (Policy, error) {
+	target, ttl, ok := strings.Cut(value, "=")
+	if !ok {
+		return Policy{}, fmt.Errorf("invalid retention policy %q, it should be <resource>[.<group>][@<cluster>]=<ttl>", value)
+	}
+
+	var policy Policy
+	resource, cluster, _ := strings.Cut(strings.TrimSpace(target), "@")
+	if resource == "" {
+		return Policy{}, fmt.Errorf("invalid retention policy %q, the resource is required", value)
+	}
+	policy.Resource = schema.ParseGroupResource(resource)
+	if cluster != "" {
+		if errs := validation.IsDNS1123Subdomain(cluster); len(errs) != 0 {
+			return Policy{}, fmt.Errorf("invalid retention policy %q, invalid cluster: %s", value, strings.Join(errs, ", "))
+		}
+		policy.Cluster = cluster
+	}
+
+	duration, err := time.ParseDuration(strings.TrimSpace(ttl))
+	if err != nil {
+		return Policy{}, fmt.Errorf("invalid retention policy %q: %w", value, err)
+	}
+	if duration <= 0 {
+		return Policy{}, fmt.Errorf("invalid retention policy %q, the ttl must be greater than 0", value)
+	}
+	policy.TTL = duration
+	return policy, nil
+}
@@ -0,0 +1,76 @@
+package retention
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Policy
+		wantErr  bool
+	}{
+		{value: "jobs.batch=168h", expected: Policy{Resource: schema.GroupResource{Group: "batch", Resource: "jobs"}, TTL: 168 * time.Hour}},
+		{value: "events@cluster-1=48h", expected: Policy{Resource: schema.GroupResource{Resource: "events"}, Cluster: "cluster-1", TTL: 48 * time.Hour}},
+		{value: "events", wantErr: true},
+		{value: "=48h", wantErr: true},
+		{value: "events=0s", wantErr: true},
+		{value: "events=2d", wantErr: true},
+		{value: "events@Cluster_1=48h", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			policy, err := ParsePolicy(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParsePolicy() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && policy != test.expected {
+				t.Errorf("ParsePolicy() = %v, want %v", policy, test.expected)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	o := NewOptions()
+	if config, err := o.Config(); err != nil || config != nil {
+		t.Errorf("Config() without policies = %v, %v, want nil", config, err)
+	}
+
+	o.Policies = []string{"events=48h", "events@cluster-1=24h"}
+	if errs := o.Validate(); len(errs) != 0 {
+		t.Errorf("Validate() = %v", errs)
+	}
+
+	o.Policies = append(o.Policies, "events=72h")
+	if errs := o.Validate(); len(errs) != 1 {
+		t.Errorf("Validate() should reject the duplicate policies, got %v", errs)
+	}
+}
+
+func TestPurgeOptions(t *testing.T) {
+	now := time.Now()
+	events, jobs := schema.GroupResource{Resource: "events"}, schema.GroupResource{Group: "batch", Resource: "jobs"}
+	policies := []Policy{
+		{Resource: events, TTL: 48 * time.Hour},
+		{Resource: events, Cluster: "cluster-1", TTL: 24 * time.Hour},
+		{Resource: events, Cluster: "cluster-2", TTL: 72 * time.Hour},
+		{Resource: jobs, TTL: time.Hour},
+	}
+
+	expected := []storage.PurgeOptions{
+		{Resource: events, ExcludedClusters: []string{"cluster-1", "cluster-2"}, CreatedBefore: now.Add(-48 * time.Hour)},
+		{Resource: events, Clusters: []string{"cluster-1"}, CreatedBefore: now.Add(-24 * time.Hour)},
+		{Resource: events, Clusters: []string{"cluster-2"}, CreatedBefore: now.Add(-72 * time.Hour)},
+		{Resource: jobs, CreatedBefore: now.Add(-time.Hour)},
+	}
+	if options := purgeOptions(policies, now); !reflect.DeepEqual(options, expected) {
+		t.Errorf("purgeOptions() = %v, want %v", options, expected)
+	}
+}
@@ -0,0 +1,63 @@
+package shardingstorage
+
+import (
+	"context"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type CollectionResourceStorage struct {
+	factory            *StorageFactory
+	shards             map[string]storage.CollectionResourceStorage
+	collectionResource *internal.CollectionResource
+}
+
+var _ storage.CollectionResourceStorage = &CollectionResourceStorage{}
+
+// Get lists the shards of the clusters in order like the ResourceStorage.List,
+// the groups of the shards are concatenated since the items are grouped by the cluster first.
+func (s *CollectionResourceStorage) Get(ctx context.Context, opts *internal.ListOptions) (*internal.CollectionResource, error) {
+	targets, err := s.factory.targets(ctx, opts.ClusterNames)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &internal.CollectionResource{
+		TypeMeta:      s.collectionResource.TypeMeta,
+		ObjectMeta:    s.collectionResource.ObjectMeta,
+		ResourceTypes: s.collectionResource.ResourceTypes,
+	}
+	cont, remaining, err := paginate(targets, opts,
+		func(t target, opts *internal.ListOptions) (page, error) {
+			cr, err := s.shards[t.shard].Get(ctx, opts)
+			if err != nil {
+				return page{}, err
+			}
+			collection.Items = append(collection.Items, cr.Items...)
+			collection.Groups = append(collection.Groups, cr.Groups...)
+			return page{items: collectionItems(cr), cont: cr.Continue, remaining: cr.RemainingItemCount}, nil
+		},
+		// the remaining count of the shards not listed is unknown
+		func(t target, opts *internal.ListOptions) (int64, bool, error) {
+			return 0, false, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.Continue, collection.RemainingItemCount = cont, remaining
+	return collection, nil
+}
+
+func collectionItems(cr *internal.CollectionResource) int64 {
+	n := int64(len(cr.Items))
+	for _, group := range cr.Groups {
+		n += int64(len(group.Items))
+		for _, ns := range group.Namespaces {
+			n += int64(len(ns.Items))
+		}
+	}
+	return n
+}
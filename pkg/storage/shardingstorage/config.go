@@ -0,0 +1,114 @@
+package shardingstorage
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	defaultVirtualNodes    = 128
+	defaultRefreshInterval = 30 * time.Second
+)
+
+// Config is the config of the sharding storage, which stores the resources of each cluster in one of the shards,
+// so that the resources of a large fleet of clusters are spread over several databases.
+type Config struct {
+	Shards []ShardConfig `yaml:"shards"`
+
+	// ShardMap is the database of the shard map, which records the shard of each cluster.
+	// It is shared by the apiserver and the clustersynchro manager, so it should not be a local sqlite file.
+	ShardMap ShardMapConfig `yaml:"shardMap"`
+
+	// VirtualNodes is the number of the virtual nodes of each shard on the consistent hash ring, default is 128.
+	VirtualNodes int `yaml:"virtualNodes"`
+
+	// RefreshInterval is the interval to reload the shard map, so that the apiserver finds the shards of the new clusters,
+	// default is 30s.
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+}
+
+type ShardConfig struct {
+	// Name is the name of the shard recorded in the shard map, it should not be changed after the clusters are assigned.
+	Name string `yaml:"name"`
+
+	// Storage is the name of the registered storage of the shard, such as internal.
+	Storage string `yaml:"storage"`
+
+	// ConfigPath is the path of the config of the storage.
+	ConfigPath string `yaml:"configPath"`
+
+	// Draining stops assigning the new clusters to the shard, the clusters already assigned to the shard are kept.
+	Draining bool `yaml:"draining"`
+}
+
+type ShardMapConfig struct {
+	// Type is the type of the database, postgres, mysql or sqlite.
+	Type string `yaml:"type" env:"SHARD_MAP_DB_TYPE"`
+
+	DSN string `yaml:"dsn" env:"SHARD_MAP_DB_DSN"`
+}
+
+func (cfg *Config) validate() error {
+	if len(cfg.Shards) == 0 {
+		return fmt.Errorf("at least one shard is required")
+	}
+
+	names := sets.New[string]()
+	var assignable bool
+	for _, shard := range cfg.Shards {
+		if shard.Name == "" || shard.Storage == "" {
+			return fmt.Errorf("the name and the storage of the shard are required")
+		}
+		if shard.Storage == StorageName {
+			return fmt.Errorf("the %s storage can not be nested", StorageName)
+		}
+		if names.Has(shard.Name) {
+			return fmt.Errorf("duplicate shard %s", shard.Name)
+		}
+		names.Insert(shard.Name)
+		assignable = assignable || !shard.Draining
+	}
+	if !assignable {
+		return fmt.Errorf("all shards are draining, the new clusters can not be assigned")
+	}
+
+	switch cfg.ShardMap.Type {
+	case "postgres", "mysql", "sqlite", "sqlite3":
+	default:
+		return fmt.Errorf("the type of the shard map should be postgres, mysql or sqlite, but got %q", cfg.ShardMap.Type)
+	}
+	if cfg.ShardMap.DSN == "" {
+		return fmt.Errorf("the dsn of the shard map is required")
+	}
+	if cfg.VirtualNodes < 0 || cfg.RefreshInterval < 0 {
+		return fmt.Errorf("virtualNodes and refreshInterval should not be negative")
+	}
+	return nil
+}
+
+// assignableShards returns the shards which the new clusters can be assigned to.
+func (cfg *Config) assignableShards() []string {
+	var shards []string
+	for _, shard := range cfg.Shards {
+		if !shard.Draining {
+			shards = append(shards, shard.Name)
+		}
+	}
+	return shards
+}
+
+func (cfg *Config) virtualNodes() int {
+	if cfg.VirtualNodes == 0 {
+		return defaultVirtualNodes
+	}
+	return cfg.VirtualNodes
+}
+
+func (cfg *Config) refreshInterval() time.Duration {
+	if cfg.RefreshInterval == 0 {
+		return defaultRefreshInterval
+	}
+	return cfg.RefreshInterval
+}
@@ -0,0 +1,123 @@
+package shardingstorage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// shardContinue is the continue of the list across the shards,
+// it is the shard to continue from and the continue of the shard.
+type shardContinue struct {
+	Shard    string `json:"shard"`
+	Continue string `json:"continue,omitempty"`
+}
+
+func encodeContinue(shard, cont string) string {
+	data, _ := json.Marshal(shardContinue{Shard: shard, Continue: cont})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeContinue(token string) (shardContinue, error) {
+	var cont shardContinue
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &cont)
+	}
+	if err != nil || cont.Shard == "" {
+		return shardContinue{}, storage.NewInvalidQueryError("", errors.New("invalid continue of the sharding storage"))
+	}
+	return cont, nil
+}
+
+// page is the result of a shard, the remaining is nil if the remaining count is not requested or unknown.
+type page struct {
+	items     int64
+	cont      string
+	remaining *int64
+}
+
+// paginate lists the targets in order until the limit is reached, the shards after the continue are listed
+// from the beginning, so the objects are ordered by the list options within each shard.
+//
+// The remaining count includes the count of the targets which are not listed, it is nil if any of them can't be counted.
+func paginate(targets []target, opts *internal.ListOptions,
+	list func(t target, opts *internal.ListOptions) (page, error),
+	count func(t target, opts *internal.ListOptions) (int64, bool, error),
+) (string, *int64, error) {
+	start, cont := 0, ""
+	if opts.Continue != "" {
+		token, err := decodeContinue(opts.Continue)
+		if err != nil {
+			return "", nil, err
+		}
+		for start = 0; start < len(targets) && targets[start].shard != token.Shard; start++ {
+		}
+		if start == len(targets) {
+			return "", nil, storage.NewInvalidQueryError("", fmt.Errorf("the shard %s of the continue is not listed", token.Shard))
+		}
+		cont = token.Continue
+	}
+
+	var collected int64
+	var remaining *int64
+	addRemaining := func(n int64) {
+		if remaining == nil {
+			remaining = new(int64)
+		}
+		*remaining += n
+	}
+	countRest := func(from int) (*int64, error) {
+		if remaining == nil {
+			// the remaining count is not requested
+			return nil, nil
+		}
+		for _, t := range targets[from:] {
+			n, ok, err := count(t, opts)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, nil
+			}
+			*remaining += n
+		}
+		return remaining, nil
+	}
+
+	for i := start; i < len(targets); i++ {
+		shardOpts := *opts
+		shardOpts.ClusterNames, shardOpts.Continue = targets[i].clusters, cont
+		if opts.Limit > 0 {
+			shardOpts.Limit = opts.Limit - collected
+		}
+		cont = ""
+
+		p, err := list(targets[i], &shardOpts)
+		if err != nil {
+			return "", nil, err
+		}
+		collected += p.items
+		if p.remaining != nil {
+			addRemaining(*p.remaining)
+		}
+
+		if p.cont != "" {
+			rest, err := countRest(i + 1)
+			return encodeContinue(targets[i].shard, p.cont), rest, err
+		}
+		if opts.Limit > 0 && collected >= opts.Limit && i+1 < len(targets) {
+			var token string
+			if opts.WithContinue != nil && *opts.WithContinue {
+				token = encodeContinue(targets[i+1].shard, "")
+			}
+			rest, err := countRest(i + 1)
+			return token, rest, err
+		}
+	}
+	return "", remaining, nil
+}
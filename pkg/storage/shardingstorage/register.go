@@ -0,0 +1,58 @@
+package shardingstorage
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/configor"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	StorageName = "sharding"
+)
+
+func init() {
+	storage.RegisterStorageFactoryFunc(StorageName, NewStorageFactory)
+}
+
+func NewStorageFactory(configPath string) (storage.StorageFactory, error) {
+	if configPath == "" {
+		return nil, fmt.Errorf("configPath should not be empty")
+	}
+
+	cfg := &Config{}
+	if err := configor.Load(cfg, configPath); err != nil {
+		return nil, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	db, err := openShardMapDB(cfg.ShardMap)
+	if err != nil {
+		return nil, fmt.Errorf("shard map: %w", err)
+	}
+	shardMap := newShardMap(db, newRing(cfg.assignableShards(), cfg.virtualNodes()))
+
+	shards := make(map[string]storage.StorageFactory, len(cfg.Shards))
+	for _, shard := range cfg.Shards {
+		factory, err := storage.NewStorageFactory(shard.Storage, shard.ConfigPath)
+		if err != nil {
+			for _, factory := range shards {
+				_ = factory.Shutdown()
+			}
+			_ = shardMap.close()
+			return nil, fmt.Errorf("shard %s: %w", shard.Name, err)
+		}
+		shards[shard.Name] = factory
+	}
+
+	factory := newStorageFactory(shards, shardMap)
+	if err := shardMap.load(factory.ctx); err != nil {
+		_ = factory.Shutdown()
+		return nil, fmt.Errorf("failed to load the shard map: %w", err)
+	}
+	go shardMap.run(factory.ctx, cfg.refreshInterval())
+	return factory, nil
+}
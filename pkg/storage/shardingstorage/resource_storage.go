@@ -0,0 +1,314 @@
+package shardingstorage
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type ResourceStorage struct {
+	factory *StorageFactory
+	shards  map[string]storage.ResourceStorage
+	config  storage.ResourceStorageConfig
+}
+
+var (
+	_ storage.ResourceStorage        = &ResourceStorage{}
+	_ storage.ResourceCounter        = &ResourceStorage{}
+	_ storage.SpecHashLister         = &ResourceStorage{}
+	_ storage.ResourceAggregator     = &ResourceStorage{}
+	_ storage.CollectionDeleter      = &ResourceStorage{}
+	_ storage.ResourcePatcher        = &ResourceStorage{}
+	_ storage.ResourceTenantResolver = &ResourceStorage{}
+)
+
+func (s *ResourceStorage) GetStorageConfig() *storage.ResourceStorageConfig {
+	return &s.config
+}
+
+func (s *ResourceStorage) Get(ctx context.Context, cluster, namespace, name string, obj runtime.Object) error {
+	shard, err := s.factory.lookup(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	if shard == "" {
+		return storage.NewNotFoundError(cluster+"/"+namespace+"/"+name, nil)
+	}
+	return s.shards[shard].Get(ctx, cluster, namespace, name, obj)
+}
+
+// List lists the shards of the clusters in order, the objects are ordered by the list options within each shard.
+func (s *ResourceStorage) List(ctx context.Context, listObj runtime.Object, opts *internal.ListOptions) error {
+	targets, err := s.factory.targets(ctx, opts.ClusterNames)
+	if err != nil {
+		return err
+	}
+
+	var items []runtime.Object
+	cont, remaining, err := paginate(targets, opts,
+		func(t target, opts *internal.ListOptions) (page, error) {
+			shardList := listObj.DeepCopyObject()
+			if err := meta.SetList(shardList, nil); err != nil {
+				return page{}, err
+			}
+			if err := s.shards[t.shard].List(ctx, shardList, opts); err != nil {
+				return page{}, err
+			}
+
+			shardItems, err := meta.ExtractList(shardList)
+			if err != nil {
+				return page{}, err
+			}
+			items = append(items, shardItems...)
+
+			accessor, err := meta.ListAccessor(shardList)
+			if err != nil {
+				return page{}, err
+			}
+			return page{items: int64(len(shardItems)), cont: accessor.GetContinue(), remaining: accessor.GetRemainingItemCount()}, nil
+		},
+		func(t target, opts *internal.ListOptions) (int64, bool, error) {
+			counter, ok := s.shards[t.shard].(storage.ResourceCounter)
+			if !ok {
+				return 0, false, nil
+			}
+			countOpts := *opts
+			countOpts.ClusterNames = t.clusters
+			n, err := counter.Count(ctx, &countOpts)
+			return n, err == nil, err
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := meta.SetList(listObj, items); err != nil {
+		return err
+	}
+	accessor, err := meta.ListAccessor(listObj)
+	if err != nil {
+		return err
+	}
+	accessor.SetContinue(cont)
+	accessor.SetRemainingItemCount(remaining)
+	return nil
+}
+
+// Watch is only supported if the clusters are in the same shard.
+func (s *ResourceStorage) Watch(ctx context.Context, opts *internal.ListOptions) (watch.Interface, error) {
+	targets, err := s.factory.targets(ctx, opts.ClusterNames)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) != 1 {
+		return nil, apierrors.NewMethodNotSupported(s.config.StorageResource.GroupResource(), "watch across the shards")
+	}
+
+	shardOpts := *opts
+	shardOpts.ClusterNames = targets[0].clusters
+	return s.shards[targets[0].shard].Watch(ctx, &shardOpts)
+}
+
+func (s *ResourceStorage) writer(ctx context.Context, cluster string) (storage.ResourceStorage, error) {
+	shard, err := s.factory.assign(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return s.shards[shard], nil
+}
+
+func (s *ResourceStorage) Create(ctx context.Context, cluster string, obj runtime.Object) error {
+	writer, err := s.writer(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	return writer.Create(ctx, cluster, obj)
+}
+
+func (s *ResourceStorage) Update(ctx context.Context, cluster string, obj runtime.Object) error {
+	writer, err := s.writer(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	return writer.Update(ctx, cluster, obj)
+}
+
+// UpdateWithPatch patches the object in the shard of the cluster, the object is fully updated if the shard doesn't support the patch.
+func (s *ResourceStorage) UpdateWithPatch(ctx context.Context, cluster string, obj runtime.Object, patch storage.ResourcePatch) error {
+	writer, err := s.writer(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	if patcher, ok := writer.(storage.ResourcePatcher); ok {
+		return patcher.UpdateWithPatch(ctx, cluster, obj, patch)
+	}
+	return writer.Update(ctx, cluster, obj)
+}
+
+func (s *ResourceStorage) ConvertDeletedObject(obj interface{}) (runtime.Object, error) {
+	return s.shards[s.factory.names[0]].ConvertDeletedObject(obj)
+}
+
+func (s *ResourceStorage) Delete(ctx context.Context, cluster string, obj runtime.Object) error {
+	writer, err := s.writer(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	return writer.Delete(ctx, cluster, obj)
+}
+
+func (s *ResourceStorage) RecordEvent(ctx context.Context, cluster string, event *corev1.Event) error {
+	writer, err := s.writer(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	return writer.RecordEvent(ctx, cluster, event)
+}
+
+func (s *ResourceStorage) Count(ctx context.Context, opts *internal.ListOptions) (int64, error) {
+	targets, err := s.factory.targets(ctx, opts.ClusterNames)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, t := range targets {
+		counter, ok := s.shards[t.shard].(storage.ResourceCounter)
+		if !ok {
+			return 0, apierrors.NewMethodNotSupported(s.config.StorageResource.GroupResource(), "count")
+		}
+
+		shardOpts := *opts
+		shardOpts.ClusterNames = t.clusters
+		n, err := counter.Count(ctx, &shardOpts)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (s *ResourceStorage) DeleteCollection(ctx context.Context, opts storage.DeleteCollectionOptions) (int64, error) {
+	targets, err := s.factory.targets(ctx, opts.Clusters)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, t := range targets {
+		deleter, ok := s.shards[t.shard].(storage.CollectionDeleter)
+		if !ok {
+			return total, apierrors.NewMethodNotSupported(s.config.StorageResource.GroupResource(), "deletecollection")
+		}
+
+		shardOpts := opts
+		shardOpts.Clusters = t.clusters
+		n, err := deleter.DeleteCollection(ctx, shardOpts)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (s *ResourceStorage) ListSpecHashes(ctx context.Context, clusters []string, namespace, name string) (map[string]string, error) {
+	targets, err := s.factory.targets(ctx, clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[string]string)
+	for _, t := range targets {
+		lister, ok := s.shards[t.shard].(storage.SpecHashLister)
+		if !ok {
+			return nil, apierrors.NewMethodNotSupported(s.config.StorageResource.GroupResource(), "list spec hashes")
+		}
+
+		shardHashes, err := lister.ListSpecHashes(ctx, t.clusters, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		for cluster, hash := range shardHashes {
+			hashes[cluster] = hash
+		}
+	}
+	return hashes, nil
+}
+
+// TenantFor joins the tenants of the shards, since the shards may restrict the reads by the different tenants.
+func (s *ResourceStorage) TenantFor(ctx context.Context) string {
+	names := make([]string, 0, len(s.shards))
+	for name := range s.shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var tenants []string
+	for _, name := range names {
+		if resolver, ok := s.shards[name].(storage.ResourceTenantResolver); ok {
+			if tenant := resolver.TenantFor(ctx); tenant != "" {
+				tenants = append(tenants, name+"="+tenant)
+			}
+		}
+	}
+	return strings.Join(tenants, ",")
+}
+
+// Aggregate merges the groups of the shards, the limit is applied to the merged groups.
+func (s *ResourceStorage) Aggregate(ctx context.Context, opts *internal.ListOptions, groupBy []storage.GroupBy) ([]storage.AggregationGroup, error) {
+	targets, err := s.factory.targets(ctx, opts.ClusterNames)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups [][]storage.AggregationGroup
+	for _, t := range targets {
+		aggregator, ok := s.shards[t.shard].(storage.ResourceAggregator)
+		if !ok {
+			return nil, apierrors.NewMethodNotSupported(s.config.StorageResource.GroupResource(), "aggregate")
+		}
+
+		shardOpts := *opts
+		shardOpts.ClusterNames, shardOpts.Limit = t.clusters, 0
+		shardGroups, err := aggregator.Aggregate(ctx, &shardOpts, groupBy)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, shardGroups)
+	}
+	return mergeAggregationGroups(groups, opts.Limit), nil
+}
+
+// mergeAggregationGroups sums the counts of the same groups and sorts the groups by the count in descending order.
+func mergeAggregationGroups(groups [][]storage.AggregationGroup, limit int64) []storage.AggregationGroup {
+	var merged []storage.AggregationGroup
+	indexes := make(map[string]int)
+	for _, shardGroups := range groups {
+		for _, group := range shardGroups {
+			key := strings.Join(group.Values, "\x00")
+			if i, ok := indexes[key]; ok {
+				merged[i].Count += group.Count
+				continue
+			}
+			indexes[key] = len(merged)
+			merged = append(merged, group)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Count > merged[j].Count })
+	if limit > 0 && int64(len(merged)) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
@@ -0,0 +1,52 @@
+package shardingstorage
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"sort"
+	"strconv"
+)
+
+// ring is the consistent hash ring of the shards, a shard is placed on the ring by its virtual nodes,
+// so adding a shard only moves the clusters hashed to the new virtual nodes.
+type ring struct {
+	hashes []uint32
+	shards map[uint32]string
+}
+
+func newRing(shards []string, virtualNodes int) *ring {
+	r := &ring{shards: make(map[uint32]string, len(shards)*virtualNodes)}
+	for _, shard := range shards {
+		for i := 0; i < virtualNodes; i++ {
+			hash := hashKey(shard + "#" + strconv.Itoa(i))
+			if _, ok := r.shards[hash]; ok {
+				// the collided virtual node is owned by the first shard
+				continue
+			}
+			r.shards[hash] = shard
+			r.hashes = append(r.hashes, hash)
+		}
+	}
+	sort.Slice(r.hashes, func(i, j int) bool { return r.hashes[i] < r.hashes[j] })
+	return r
+}
+
+// get returns the shard of the first virtual node clockwise from the hash of the cluster.
+func (r *ring) get(cluster string) string {
+	if len(r.hashes) == 0 {
+		return ""
+	}
+
+	hash := hashKey(cluster)
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= hash })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.shards[r.hashes[i]]
+}
+
+// hashKey spreads the similar keys, such as the numbered cluster names, evenly over the ring.
+func hashKey(key string) uint32 {
+	sum := sha1.Sum([]byte(key))
+	return binary.BigEndian.Uint32(sum[:4])
+}
@@ -0,0 +1,162 @@
+package shardingstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"k8s.io/klog/v2"
+)
+
+// ClusterShard records the shard of a cluster, the cluster is pinned to the shard once it is assigned,
+// so the clusters are not moved when the shards are added.
+type ClusterShard struct {
+	Cluster   string `gorm:"size:253;primaryKey"`
+	Shard     string `gorm:"size:253;not null"`
+	CreatedAt time.Time
+}
+
+func (ClusterShard) TableName() string {
+	return "cluster_shards"
+}
+
+// shardMap caches the shard map stored in the database, the assignments made by the other components
+// are found by the periodic reload or by looking up the database on the cache miss.
+type shardMap struct {
+	db   *gorm.DB
+	ring *ring
+
+	lock        sync.RWMutex
+	assignments map[string]string
+}
+
+// shardMapDialectors are registered by the drivers compiled in,
+// the drivers are excluded by the build tags `no_mysql`, `no_postgres` and `no_sqlite`.
+var shardMapDialectors = make(map[string]func(dsn string) gorm.Dialector)
+
+func openShardMapDB(cfg ShardMapConfig) (*gorm.DB, error) {
+	open, ok := shardMapDialectors[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("not support the shard map type: %s", cfg.Type)
+	}
+
+	db, err := gorm.Open(open(cfg.DSN), &gorm.Config{SkipDefaultTransaction: true, Logger: logger.Discard})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.AutoMigrate(&ClusterShard{}); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func newShardMap(db *gorm.DB, ring *ring) *shardMap {
+	return &shardMap{db: db, ring: ring, assignments: make(map[string]string)}
+}
+
+func (m *shardMap) load(ctx context.Context) error {
+	var records []ClusterShard
+	if err := m.db.WithContext(ctx).Find(&records).Error; err != nil {
+		return err
+	}
+
+	assignments := make(map[string]string, len(records))
+	for _, record := range records {
+		assignments[record.Cluster] = record.Shard
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.assignments = assignments
+	return nil
+}
+
+func (m *shardMap) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.load(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				klog.ErrorS(err, "Failed to reload the shard map")
+			}
+		}
+	}
+}
+
+// lookup returns the shard of the cluster, it is empty if the cluster is not assigned.
+func (m *shardMap) lookup(ctx context.Context, cluster string) (string, error) {
+	m.lock.RLock()
+	shard, ok := m.assignments[cluster]
+	m.lock.RUnlock()
+	if ok {
+		return shard, nil
+	}
+
+	var record ClusterShard
+	result := m.db.WithContext(ctx).Where(map[string]interface{}{"cluster": cluster}).Limit(1).Find(&record)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		return "", nil
+	}
+
+	m.cache(cluster, record.Shard)
+	return record.Shard, nil
+}
+
+// assign returns the shard of the cluster, and assigns the cluster to the shard on the consistent hash ring
+// if it is not assigned. The first assignment wins if the cluster is assigned by several components at the same time.
+func (m *shardMap) assign(ctx context.Context, cluster string) (string, error) {
+	shard, err := m.lookup(ctx, cluster)
+	if err != nil || shard != "" {
+		return shard, err
+	}
+
+	record := ClusterShard{Cluster: cluster, Shard: m.ring.get(cluster)}
+	if err := m.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&record).Error; err != nil {
+		return "", err
+	}
+	if shard, err = m.lookup(ctx, cluster); err != nil {
+		return "", err
+	}
+	if shard == "" {
+		return "", fmt.Errorf("cluster %s is not assigned to any shard", cluster)
+	}
+	klog.InfoS("The cluster is assigned to the shard", "cluster", cluster, "shard", shard)
+	return shard, nil
+}
+
+func (m *shardMap) remove(ctx context.Context, cluster string) error {
+	if err := m.db.WithContext(ctx).Where(map[string]interface{}{"cluster": cluster}).Delete(&ClusterShard{}).Error; err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.assignments, cluster)
+	return nil
+}
+
+func (m *shardMap) cache(cluster, shard string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.assignments[cluster] = shard
+}
+
+func (m *shardMap) close() error {
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
@@ -0,0 +1,9 @@
+//go:build !no_mysql
+
+package shardingstorage
+
+import gmysql "gorm.io/driver/mysql"
+
+func init() {
+	shardMapDialectors["mysql"] = gmysql.Open
+}
@@ -0,0 +1,9 @@
+//go:build !no_postgres
+
+package shardingstorage
+
+import gpostgres "gorm.io/driver/postgres"
+
+func init() {
+	shardMapDialectors["postgres"] = gpostgres.Open
+}
@@ -0,0 +1,10 @@
+//go:build !no_sqlite
+
+package shardingstorage
+
+import gsqlite "gorm.io/driver/sqlite"
+
+func init() {
+	shardMapDialectors["sqlite"] = gsqlite.Open
+	shardMapDialectors["sqlite3"] = gsqlite.Open
+}
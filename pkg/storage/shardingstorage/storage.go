@@ -0,0 +1,232 @@
+package shardingstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+// StorageFactory routes the resources of each cluster to the storage of its shard,
+// and the reads of several clusters are served by the shards of the clusters in the order of the shard names.
+type StorageFactory struct {
+	shards   map[string]storage.StorageFactory
+	names    []string // sorted names of the shards
+	shardMap *shardMap
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+var (
+	_ storage.StorageFactory = &StorageFactory{}
+	_ storage.HealthChecker  = &StorageFactory{}
+)
+
+func newStorageFactory(shards map[string]storage.StorageFactory, shardMap *shardMap) *StorageFactory {
+	names := make([]string, 0, len(shards))
+	for name := range shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &StorageFactory{shards: shards, names: names, shardMap: shardMap, ctx: ctx, cancel: cancel}
+}
+
+// target is a shard and the clusters read from it, the clusters are nil if all clusters of the shard are read.
+type target struct {
+	shard    string
+	clusters []string
+}
+
+func (f *StorageFactory) shard(name string) (storage.StorageFactory, error) {
+	shard, ok := f.shards[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown shard %s in the shard map", name)
+	}
+	return shard, nil
+}
+
+// lookup returns the shard of the cluster, the shard is empty if the cluster is not assigned.
+func (f *StorageFactory) lookup(ctx context.Context, cluster string) (string, error) {
+	name, err := f.shardMap.lookup(ctx, cluster)
+	if err != nil {
+		return "", storage.NewUnavailableError(cluster, fmt.Errorf("failed to look up the shard: %w", err))
+	}
+	if name != "" {
+		if _, err := f.shard(name); err != nil {
+			return "", err
+		}
+	}
+	return name, nil
+}
+
+// assign returns the shard of the cluster, and assigns the cluster to a shard if it is not assigned.
+func (f *StorageFactory) assign(ctx context.Context, cluster string) (string, error) {
+	name, err := f.shardMap.assign(ctx, cluster)
+	if err != nil {
+		return "", storage.NewUnavailableError(cluster, fmt.Errorf("failed to assign the shard: %w", err))
+	}
+	if _, err := f.shard(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// targets groups the clusters by their shards in the order of the shard names, the clusters not assigned are skipped,
+// and all shards are the targets if the clusters are empty.
+func (f *StorageFactory) targets(ctx context.Context, clusters []string) ([]target, error) {
+	if len(clusters) == 0 {
+		targets := make([]target, 0, len(f.names))
+		for _, name := range f.names {
+			targets = append(targets, target{shard: name})
+		}
+		return targets, nil
+	}
+
+	clustersByShard := make(map[string][]string)
+	for _, cluster := range clusters {
+		name, err := f.lookup(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if name != "" {
+			clustersByShard[name] = append(clustersByShard[name], cluster)
+		}
+	}
+
+	targets := make([]target, 0, len(clustersByShard))
+	for _, name := range f.names {
+		if clusters, ok := clustersByShard[name]; ok {
+			targets = append(targets, target{shard: name, clusters: clusters})
+		}
+	}
+	return targets, nil
+}
+
+// GetSupportedRequestVerbs returns the verbs supported by all shards.
+func (f *StorageFactory) GetSupportedRequestVerbs() []string {
+	var verbs sets.Set[string]
+	for _, name := range f.names {
+		shardVerbs := sets.New(f.shards[name].GetSupportedRequestVerbs()...)
+		if verbs == nil {
+			verbs = shardVerbs
+		} else {
+			verbs = verbs.Intersection(shardVerbs)
+		}
+	}
+	return sets.List(verbs)
+}
+
+func (f *StorageFactory) HealthCheck(ctx context.Context) error {
+	for _, name := range f.names {
+		if err := storage.CheckHealth(ctx, f.shards[name]); err != nil {
+			return fmt.Errorf("shard %s: %w", name, err)
+		}
+	}
+
+	sqlDB, err := f.shardMap.db.DB()
+	if err != nil {
+		return err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("shard map: %w", err)
+	}
+	return nil
+}
+
+func (f *StorageFactory) Readiness(ctx context.Context) error {
+	for _, name := range f.names {
+		if err := storage.CheckReadiness(ctx, f.shards[name]); err != nil {
+			return fmt.Errorf("shard %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// PrepareCluster assigns the cluster to a shard and prepares the cluster in the shard.
+func (f *StorageFactory) PrepareCluster(cluster string) error {
+	name, err := f.assign(f.ctx, cluster)
+	if err != nil {
+		return err
+	}
+	return f.shards[name].PrepareCluster(cluster)
+}
+
+func (f *StorageFactory) GetResourceVersions(ctx context.Context, cluster string) (map[schema.GroupVersionResource]storage.ClusterResourceVersions, error) {
+	name, err := f.lookup(ctx, cluster)
+	if err != nil || name == "" {
+		return nil, err
+	}
+	return f.shards[name].GetResourceVersions(ctx, cluster)
+}
+
+// GetCollectionResources returns the collection resources of the first shard, the shards support the same collection resources.
+func (f *StorageFactory) GetCollectionResources(ctx context.Context) ([]*internal.CollectionResource, error) {
+	return f.shards[f.names[0]].GetCollectionResources(ctx)
+}
+
+func (f *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
+	shards := make(map[string]storage.ResourceStorage, len(f.shards))
+	for name, shard := range f.shards {
+		s, err := shard.NewResourceStorage(config)
+		if err != nil {
+			return nil, fmt.Errorf("shard %s: %w", name, err)
+		}
+		shards[name] = s
+	}
+	return &ResourceStorage{factory: f, shards: shards, config: *config}, nil
+}
+
+func (f *StorageFactory) NewCollectionResourceStorage(cr *internal.CollectionResource) (storage.CollectionResourceStorage, error) {
+	shards := make(map[string]storage.CollectionResourceStorage, len(f.shards))
+	for name, shard := range f.shards {
+		s, err := shard.NewCollectionResourceStorage(cr)
+		if err != nil {
+			return nil, fmt.Errorf("shard %s: %w", name, err)
+		}
+		shards[name] = s
+	}
+	return &CollectionResourceStorage{factory: f, shards: shards, collectionResource: cr.DeepCopy()}, nil
+}
+
+// CleanCluster cleans the cluster in its shard and removes the cluster from the shard map,
+// so the cluster may be assigned to another shard when it is imported again.
+func (f *StorageFactory) CleanCluster(ctx context.Context, cluster string) error {
+	name, err := f.lookup(ctx, cluster)
+	if err != nil || name == "" {
+		return err
+	}
+	if err := f.shards[name].CleanCluster(ctx, cluster); err != nil {
+		return err
+	}
+	return f.shardMap.remove(ctx, cluster)
+}
+
+func (f *StorageFactory) CleanClusterResource(ctx context.Context, cluster string, gvr schema.GroupVersionResource) error {
+	name, err := f.lookup(ctx, cluster)
+	if err != nil || name == "" {
+		return err
+	}
+	return f.shards[name].CleanClusterResource(ctx, cluster, gvr)
+}
+
+func (f *StorageFactory) Shutdown() error {
+	f.cancel()
+
+	errs := make([]error, 0, len(f.shards)+1)
+	for _, name := range f.names {
+		if err := f.shards[name].Shutdown(); err != nil {
+			errs = append(errs, fmt.Errorf("shard %s: %w", name, err))
+		}
+	}
+	errs = append(errs, f.shardMap.close())
+	return errors.Join(errs...)
+}
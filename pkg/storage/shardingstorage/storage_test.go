@@ -0,0 +1,156 @@
+package shardingstorage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/storagetesting"
+)
+
+func newTestStorageFactory(t *testing.T, shards ...string) (*StorageFactory, map[string]*storagetesting.StorageFactory) {
+	db, err := openShardMapDB(ShardMapConfig{Type: "sqlite", DSN: filepath.Join(t.TempDir(), "shards.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fakes := make(map[string]*storagetesting.StorageFactory, len(shards))
+	factories := make(map[string]storage.StorageFactory, len(shards))
+	for _, shard := range shards {
+		fakes[shard] = storagetesting.NewStorageFactory()
+		factories[shard] = fakes[shard]
+	}
+	factory := newStorageFactory(factories, newShardMap(db, newRing(shards, defaultVirtualNodes)))
+	t.Cleanup(func() { _ = factory.Shutdown() })
+	return factory, fakes
+}
+
+func TestRingStability(t *testing.T) {
+	before := newRing([]string{"shard-a", "shard-b", "shard-c"}, defaultVirtualNodes)
+	after := newRing([]string{"shard-a", "shard-b", "shard-c", "shard-d"}, defaultVirtualNodes)
+
+	var moved int
+	for i := 0; i < 400; i++ {
+		cluster := fmt.Sprintf("cluster-%d", i)
+		if shard := after.get(cluster); shard != before.get(cluster) {
+			if shard != "shard-d" {
+				t.Fatalf("cluster %s is moved to the existing shard %s", cluster, shard)
+			}
+			moved++
+		}
+	}
+	if moved == 0 || moved > 200 {
+		t.Errorf("moved %d of 400 clusters to the new shard", moved)
+	}
+}
+
+func TestShardMapPinsAssignments(t *testing.T) {
+	factory, _ := newTestStorageFactory(t, "shard-a")
+	ctx := context.Background()
+
+	shard, err := factory.assign(ctx, "cluster-1")
+	if err != nil || shard != "shard-a" {
+		t.Fatalf("assign() = %q, %v", shard, err)
+	}
+
+	// the shard map of another component with more shards sees the existing assignment
+	other := newShardMap(factory.shardMap.db, newRing([]string{"shard-a", "shard-b"}, defaultVirtualNodes))
+	if err := other.load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if shard, err := other.assign(ctx, "cluster-1"); err != nil || shard != "shard-a" {
+		t.Errorf("assign() of the pinned cluster = %q, %v", shard, err)
+	}
+
+	if err := factory.CleanCluster(ctx, "cluster-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if shard, err := other.lookup(ctx, "cluster-1"); err != nil || shard != "" {
+		t.Errorf("lookup() of the cleaned cluster = %q, %v", shard, err)
+	}
+}
+
+func TestListAcrossShards(t *testing.T) {
+	factory, fakes := newTestStorageFactory(t, "shard-a", "shard-b")
+	ctx := context.Background()
+
+	rs, err := factory.NewResourceStorage(&storage.ResourceStorageConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clusters := []string{"cluster-1", "cluster-2", "cluster-3", "cluster-4", "cluster-5", "cluster-6"}
+	expected := sets.New[string]()
+	for _, cluster := range clusters {
+		for i := 0; i < 3; i++ {
+			obj := &unstructured.Unstructured{}
+			obj.SetName(fmt.Sprintf("%s-pod-%d", cluster, i))
+			if err := rs.Create(ctx, cluster, obj); err != nil {
+				t.Fatal(err)
+			}
+			expected.Insert(obj.GetName())
+		}
+	}
+	if len(fakes["shard-a"].Objects()) == 0 || len(fakes["shard-b"].Objects()) == 0 {
+		t.Fatalf("the clusters are not spread over the shards")
+	}
+
+	for _, opts := range []internal.ListOptions{{}, {ClusterNames: clusters}} {
+		withContinue := true
+		opts.Limit, opts.WithContinue = 4, &withContinue
+
+		listed := sets.New[string]()
+		for pages := 0; ; pages++ {
+			if pages > len(expected) {
+				t.Fatalf("too many pages")
+			}
+
+			list := &unstructured.UnstructuredList{}
+			if err := rs.List(ctx, list, &opts); err != nil {
+				t.Fatal(err)
+			}
+			if int64(len(list.Items)) > opts.Limit {
+				t.Fatalf("listed %d objects over the limit", len(list.Items))
+			}
+			for _, obj := range list.Items {
+				if listed.Has(obj.GetName()) {
+					t.Fatalf("object %s is listed twice", obj.GetName())
+				}
+				listed.Insert(obj.GetName())
+			}
+
+			if list.GetContinue() == "" {
+				break
+			}
+			opts.Continue = list.GetContinue()
+		}
+		if !listed.Equal(expected) {
+			t.Errorf("listed %v, want %v", sets.List(listed), sets.List(expected))
+		}
+	}
+
+	list := &unstructured.UnstructuredList{}
+	if err := rs.List(ctx, list, &internal.ListOptions{ClusterNames: []string{"cluster-unknown"}}); err != nil || len(list.Items) != 0 {
+		t.Errorf("List() of the unassigned cluster = %d items, %v", len(list.Items), err)
+	}
+}
+
+func TestMergeAggregationGroups(t *testing.T) {
+	groups := mergeAggregationGroups([][]storage.AggregationGroup{
+		{{Values: []string{"a"}, Count: 2}, {Values: []string{"b"}, Count: 1}},
+		{{Values: []string{"b"}, Count: 3}, {Values: []string{"c"}, Count: 1}},
+	}, 2)
+
+	if len(groups) != 2 || groups[0].Values[0] != "b" || groups[0].Count != 4 || groups[1].Values[0] != "a" {
+		t.Errorf("mergeAggregationGroups() = %v", groups)
+	}
+}
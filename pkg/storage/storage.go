@@ -2,10 +2,14 @@ package storage
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 
 	internal "github.com/clusterpedia-io/api/clusterpedia"
@@ -18,8 +22,8 @@ type ClusterResourceVersions struct {
 }
 
 type StorageFactory interface {
-	// Currently only supports returning a union of verbs for all resources,
-	// in the future it may be necessary to return verbs depending on different resources.
+	// GetSupportedRequestVerbs returns a union of verbs for all resources,
+	// the storage returns the verbs of each resource by implementing the ResourceRequestVerbsGetter.
 	GetSupportedRequestVerbs() []string
 
 	PrepareCluster(cluster string) error
@@ -56,6 +60,485 @@ type CollectionResourceStorage interface {
 	Get(ctx context.Context, opts *internal.ListOptions) (*internal.CollectionResource, error)
 }
 
+// SpecHashLister is an optional interface of the ResourceStorage,
+// which lists the spec hashes stored with the objects without loading the objects.
+type SpecHashLister interface {
+	// ListSpecHashes returns the spec hashes of the object in the clusters, keyed by the cluster,
+	// the object of all clusters are included if the clusters is empty.
+	ListSpecHashes(ctx context.Context, clusters []string, namespace, name string) (map[string]string, error)
+}
+
+// ResourceCounter is an optional interface of the ResourceStorage,
+// which counts the objects matched by the list options without loading the objects.
+type ResourceCounter interface {
+	// Count ignores the limit, the continue and the order of the list options.
+	Count(ctx context.Context, opts *internal.ListOptions) (int64, error)
+}
+
+// CollectionDeleter is an optional interface of the ResourceStorage,
+// which deletes the stored objects matched by the options in one statement without loading the objects.
+type CollectionDeleter interface {
+	// DeleteCollection returns the number of the deleted objects.
+	DeleteCollection(ctx context.Context, opts DeleteCollectionOptions) (int64, error)
+}
+
+type DeleteCollectionOptions struct {
+	// Clusters are the clusters whose objects are deleted, the objects of all clusters are deleted if it is empty.
+	Clusters []string
+
+	// Namespaces filter the objects if it is not empty.
+	Namespaces []string
+
+	// LabelSelector filters the objects by the labels if it is not nil.
+	LabelSelector labels.Selector
+}
+
+// ResourcePatcher is an optional interface of the ResourceStorage, which updates the changed top-level fields of the stored object
+// instead of rewriting the whole object, so the writes of the status-only changes are cut down.
+type ResourcePatcher interface {
+	// UpdateWithPatch updates the stored object with the changed fields of the patch, the fields absent from the object are removed.
+	// It falls back to the full update if the stored object is not at the previous resource version of the patch or can't be patched,
+	// e.g. it is compressed, so the stored object is always the same as the object updated by the Update.
+	UpdateWithPatch(ctx context.Context, cluster string, obj runtime.Object, patch ResourcePatch) error
+}
+
+type ResourcePatch struct {
+	// PreviousResourceVersion is the resource version of the stored object which the changed fields are computed from.
+	PreviousResourceVersion string
+
+	// ChangedFields are the changed top-level fields of the object, e.g. `metadata` and `status`.
+	ChangedFields []string
+}
+
+// ResourceHistoryGetter is an optional interface of the ResourceStorage,
+// which returns the field-level changes recorded between the consecutive versions of an object.
+type ResourceHistoryGetter interface {
+	// GetHistory returns the changes of the object in the cluster sorted by the time in ascending order.
+	GetHistory(ctx context.Context, cluster, namespace, name string) ([]ResourceChange, error)
+}
+
+type ResourceChange struct {
+	ResourceVersion         string
+	PreviousResourceVersion string
+
+	// Time is the time the change is recorded by the storage.
+	Time time.Time
+
+	Fields []FieldChange
+}
+
+type FieldChange struct {
+	// Path is the path of the changed field, e.g. `spec.replicas` or `metadata.labels['app']`.
+	Path string
+
+	// Old and New are the JSON values of the scalar field, they are empty if the field is absent or not a scalar.
+	Old string
+	New string
+}
+
+// ResourceSnapshotGetter is an optional interface of the ResourceStorage,
+// which returns the stored row of an object for debugging the mismatches between the member cluster and the storage.
+type ResourceSnapshotGetter interface {
+	// GetSnapshot reads the stored row of the object in the cluster and its revisions in one read-only transaction,
+	// the row kept as the tombstone is also returned.
+	GetSnapshot(ctx context.Context, cluster, namespace, name string) (*ResourceSnapshot, error)
+}
+
+type ResourceSnapshot struct {
+	Kind            string
+	UID             types.UID
+	OwnerUID        types.UID
+	ResourceVersion string
+	SpecHash        string
+
+	// FormatVersion, Compression, Encoding and Encryption are how the object is stored.
+	FormatVersion int
+	Compression   string
+	Encoding      string
+	Encryption    string
+
+	// StoredBytes is the size of the stored payload.
+	StoredBytes int64
+
+	// Object is the stored object decoded in json with the storage version.
+	Object []byte
+
+	EventResourceVersions map[string]string
+
+	CreatedTime time.Time
+	SyncedTime  time.Time
+
+	// DeletedTime and RemovedTime are zero if the object is not deleted or not removed from the cluster.
+	DeletedTime time.Time
+	RemovedTime time.Time
+
+	// Revisions are sorted by the time in ascending order, they are nil if the changes of the objects are not recorded.
+	Revisions []ResourceRevision
+}
+
+type ResourceRevision struct {
+	ResourceVersion         string
+	PreviousResourceVersion string
+
+	// Time is the time the revision is recorded by the storage.
+	Time time.Time
+}
+
+// ResourceTombstoneStorage is an optional interface of the ResourceStorage, which keeps the deleted objects as the tombstones,
+// so that the objects deleted from the clusters can be investigated after the incidents.
+type ResourceTombstoneStorage interface {
+	// ListTombstones returns the tombstones of the cluster sorted by the removed time in descending order.
+	ListTombstones(ctx context.Context, cluster string, opts TombstoneOptions) ([]Tombstone, error)
+
+	// RestoreTombstone restores the deleted object, the restored object is deleted again
+	// when the cluster is resynchronized if the object doesn't exist in the cluster.
+	RestoreTombstone(ctx context.Context, cluster, namespace, name string) error
+
+	// PurgeTombstones deletes the tombstones of the cluster and returns the number of the purged tombstones.
+	PurgeTombstones(ctx context.Context, cluster string, opts TombstoneOptions) (int64, error)
+}
+
+type TombstoneOptions struct {
+	// Namespace and Name filter the tombstones if they are not empty.
+	Namespace string
+	Name      string
+
+	// RemovedAfter and RemovedBefore filter the tombstones by the removed time if they are not zero.
+	RemovedAfter  time.Time
+	RemovedBefore time.Time
+}
+
+type Tombstone struct {
+	Namespace string
+	Name      string
+
+	RemovedAt time.Time
+
+	// Object is the deleted object encoded in json with the storage version.
+	Object []byte
+}
+
+// ResourceArchiveStorage is an optional interface of the ResourceStorage, which archives the deleted objects
+// and the objects purged by the retention to the object storage instead of dropping them.
+type ResourceArchiveStorage interface {
+	// ListArchives returns the archives of the cluster sorted by the archived time in descending order.
+	ListArchives(ctx context.Context, cluster string, opts ArchiveOptions) ([]Archive, error)
+
+	// RestoreArchive stores the archived object again, the restored object is deleted again
+	// when the cluster is resynchronized if the object doesn't exist in the cluster.
+	RestoreArchive(ctx context.Context, cluster, id string) error
+}
+
+type ArchiveOptions struct {
+	// Namespace and Name filter the archives if they are not empty.
+	Namespace string
+	Name      string
+
+	// ArchivedAfter and ArchivedBefore filter the archives by the archived time if they are not zero.
+	ArchivedAfter  time.Time
+	ArchivedBefore time.Time
+}
+
+type ArchiveReason string
+
+const (
+	ArchiveReasonDeleted ArchiveReason = "Deleted"
+	ArchiveReasonPurged  ArchiveReason = "Purged"
+)
+
+type Archive struct {
+	// ID identifies the archive of the resource in the cluster.
+	ID string
+
+	Namespace string
+	Name      string
+
+	Reason     ArchiveReason
+	ArchivedAt time.Time
+
+	// Object is the archived object encoded in json with the storage version.
+	Object []byte
+}
+
+// ResourceAggregator is an optional interface of the ResourceStorage and the CollectionResourceStorage,
+// which groups the objects matched by the list options and counts the objects of each group without loading the objects.
+type ResourceAggregator interface {
+	// Aggregate returns the groups sorted by the count in descending order, the limit of the list options
+	// limits the number of the groups, and the continue and the order of the list options are ignored.
+	Aggregate(ctx context.Context, opts *internal.ListOptions, groupBy []GroupBy) ([]AggregationGroup, error)
+}
+
+type GroupByType string
+
+const (
+	GroupByCluster   GroupByType = "cluster"
+	GroupByNamespace GroupByType = "namespace"
+	GroupByLabel     GroupByType = "label"
+	GroupByField     GroupByType = "field"
+
+	// GroupByResource groups the objects by the group resource, it is only supported by the collection resources.
+	GroupByResource GroupByType = "resource"
+)
+
+type GroupBy struct {
+	Type GroupByType
+
+	// Label is the label key of the label type.
+	Label string
+
+	// Fields is the path of the field type, e.g. ["status", "phase"].
+	Fields []string
+}
+
+type AggregationGroup struct {
+	// Values are the values of the group by keys in order, the value is empty if the object has no label or field.
+	Values []string
+
+	Count int64
+}
+
+// ResourceTenantResolver is an optional interface of the ResourceStorage,
+// which restricts the reads of the requests to the objects visible to the tenants of the request users.
+type ResourceTenantResolver interface {
+	// TenantFor returns the tenant of the request user, the reads of the requests of the same tenant see the same objects.
+	// It returns empty if the reads of the request are not restricted.
+	TenantFor(ctx context.Context) string
+}
+
+// ResourceRequestVerbsGetter is an optional interface of the StorageFactory,
+// which returns the supported request verbs of each resource, e.g. the events may not support watch.
+type ResourceRequestVerbsGetter interface {
+	// GetResourceRequestVerbs returns the supported request verbs of the resource,
+	// false means the resource supports the verbs returned by GetSupportedRequestVerbs.
+	GetResourceRequestVerbs(gvr schema.GroupVersionResource) ([]string, bool)
+}
+
+// SupportedRequestVerbs returns the supported request verbs of the resource by the storage.
+func SupportedRequestVerbs(factory StorageFactory, gvr schema.GroupVersionResource) []string {
+	if getter, ok := factory.(ResourceRequestVerbsGetter); ok {
+		if verbs, ok := getter.GetResourceRequestVerbs(gvr); ok {
+			return verbs
+		}
+	}
+	return factory.GetSupportedRequestVerbs()
+}
+
+// NamespaceCounter is an optional interface of the StorageFactory,
+// which counts the objects in the namespaces of a cluster without loading the objects.
+type NamespaceCounter interface {
+	// CountNamespaces returns the number of the objects in each namespace of the cluster,
+	// the synchronized namespaces without objects are included with zero count.
+	CountNamespaces(ctx context.Context, cluster string) (map[string]int64, error)
+}
+
+// ClusterSummarizer is an optional interface of the StorageFactory,
+// which summarizes the stored objects of a cluster without loading the objects.
+type ClusterSummarizer interface {
+	SummarizeCluster(ctx context.Context, cluster string) (*ClusterSummary, error)
+}
+
+type ClusterSummary struct {
+	Objects int64
+
+	// Bytes is the estimated size of the stored objects, it is 0 if the size is not estimated.
+	Bytes int64
+
+	// LastSyncedTime is the last time the objects were synced to the storage, it is zero if it is unknown.
+	LastSyncedTime time.Time
+}
+
+// ResourceUsageCollector is an optional interface of the StorageFactory,
+// which collects the number and the size of the stored objects of each resource in each cluster.
+type ResourceUsageCollector interface {
+	// CollectResourceUsages collects the usages of all clusters in the storage.
+	CollectResourceUsages(ctx context.Context) ([]ResourceUsage, error)
+}
+
+type ResourceUsage struct {
+	Cluster  string
+	Resource schema.GroupVersionResource
+
+	Objects int64
+
+	// Bytes is the estimated size of the stored objects, it is 0 if the size is not estimated.
+	Bytes int64
+}
+
+// WatchBookmarkStorage is an optional interface of the StorageFactory, which persists the resource versions
+// of the watch bookmarks, so the watches of the resources are resumed from the bookmarks after the restarts
+// of the clustersynchro manager instead of relisting the resources.
+//
+// The bookmarks are deleted with the stored objects of the clusters and the resources.
+type WatchBookmarkStorage interface {
+	// GetWatchBookmarks returns the resource versions of the bookmarks of the cluster keyed by the storage resources,
+	// it returns nil if the storage doesn't persist the bookmarks.
+	GetWatchBookmarks(ctx context.Context, cluster string) (map[schema.GroupVersionResource]string, error)
+
+	// SaveWatchBookmark saves the resource version of the bookmark of the storage resource,
+	// all events before the resource version must have been saved to the storage.
+	SaveWatchBookmark(ctx context.Context, cluster string, gvr schema.GroupVersionResource, resourceVersion string) error
+}
+
+// ResourcePurger is an optional interface of the StorageFactory,
+// which deletes the stored objects of a resource for the retention without loading the objects.
+type ResourcePurger interface {
+	// PurgeResources returns the number of the deleted objects.
+	PurgeResources(ctx context.Context, opts PurgeOptions) (int64, error)
+}
+
+type PurgeOptions struct {
+	Resource schema.GroupResource
+
+	// Clusters are the clusters whose objects are purged, the objects of all clusters are purged if it is empty.
+	Clusters []string
+
+	// ExcludedClusters are the clusters whose objects are not purged, e.g. the clusters with their own retention.
+	ExcludedClusters []string
+
+	// CreatedBefore purges the objects created before the time.
+	CreatedBefore time.Time
+}
+
+// DatabaseUsers are the database users of the components.
+type DatabaseUsers struct {
+	// Reader is the user of the apiserver, which only reads the resources.
+	Reader string
+
+	// Writer is the user of the clustersynchro manager, which writes the resources.
+	Writer string
+}
+
+// PrivilegeGranter is an optional interface of the StorageFactory,
+// which grants the least privileges to the database users of the components.
+type PrivilegeGranter interface {
+	// GrantPrivileges returns the statements that grant the privileges,
+	// the statements are executed unless printOnly is true.
+	GrantPrivileges(ctx context.Context, users DatabaseUsers, printOnly bool) ([]string, error)
+}
+
+// StorageChecker is an optional interface of the StorageFactory,
+// which is used by the preflight checks to verify the storage before rollout.
+type StorageChecker interface {
+	Ping(ctx context.Context) error
+
+	// CheckSchema checks whether the tables are migrated to the schema of the current version.
+	CheckSchema(ctx context.Context) error
+
+	// CheckPrivileges checks the privileges to read the resources, and to write them if writable is true,
+	// the resources are not changed by the check.
+	CheckPrivileges(ctx context.Context, writable bool) error
+}
+
+// SchemaMigrationPlanner is an optional interface of the StorageFactory,
+// which plans the migration of the storage schema to the current version without changing the storage.
+type SchemaMigrationPlanner interface {
+	PlanSchemaMigration(ctx context.Context) (*SchemaMigrationPlan, error)
+}
+
+// HealthChecker is an optional interface of the StorageFactory,
+// which reports the health of the storage to the probes of the components and the conditions of the clusters.
+type HealthChecker interface {
+	// HealthCheck returns an error if the storage can not be reached.
+	HealthCheck(ctx context.Context) error
+
+	// Readiness returns an error if the storage can be reached but can not serve the requests yet,
+	// e.g. the schema is not migrated to the current version.
+	Readiness(ctx context.Context) error
+}
+
+type SchemaMigrationPlan struct {
+	// Incompatibilities are the differences between the storage schema and the current version
+	Incompatibilities []string
+
+	// Statements migrate the storage schema to the current version
+	Statements []string
+}
+
+// CheckHealth checks the health of the storage, the storage is always healthy if the factory is not a HealthChecker.
+func CheckHealth(ctx context.Context, factory StorageFactory) error {
+	if checker, ok := factory.(HealthChecker); ok {
+		return checker.HealthCheck(ctx)
+	}
+	return nil
+}
+
+// CheckReadiness checks the readiness of the storage, the storage is always ready if the factory is not a HealthChecker.
+func CheckReadiness(ctx context.Context, factory StorageFactory) error {
+	if checker, ok := factory.(HealthChecker); ok {
+		return checker.Readiness(ctx)
+	}
+	return nil
+}
+
+// CountNamespaces counts the objects in the namespaces of the cluster,
+// it falls back to the resource versions if the factory is not a NamespaceCounter.
+func CountNamespaces(ctx context.Context, factory StorageFactory, cluster string) (map[string]int64, error) {
+	if counter, ok := factory.(NamespaceCounter); ok {
+		return counter.CountNamespaces(ctx, cluster)
+	}
+
+	versions, err := factory.GetResourceVersions(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for gvr, rvs := range versions {
+		for key := range rvs.Resources {
+			if gvr.Group == "" && gvr.Resource == "namespaces" {
+				if _, ok := counts[key]; !ok {
+					counts[key] = 0
+				}
+				continue
+			}
+			if namespace, _, ok := strings.Cut(key, "/"); ok {
+				counts[namespace]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// CollectResourceUsages collects the usages of the resources, the ResourceUsageCollector collects the usages
+// of all clusters in the storage, including the clusters which are removed but not yet cleaned.
+// It falls back to the resource versions of the clusters if the factory is not a ResourceUsageCollector.
+func CollectResourceUsages(ctx context.Context, factory StorageFactory, clusters []string) ([]ResourceUsage, error) {
+	if collector, ok := factory.(ResourceUsageCollector); ok {
+		return collector.CollectResourceUsages(ctx)
+	}
+
+	var usages []ResourceUsage
+	for _, cluster := range clusters {
+		versions, err := factory.GetResourceVersions(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		for gvr, rvs := range versions {
+			usages = append(usages, ResourceUsage{Cluster: cluster, Resource: gvr, Objects: int64(len(rvs.Resources))})
+		}
+	}
+	return usages, nil
+}
+
+// SummarizeCluster summarizes the stored objects of the cluster,
+// it falls back to the resource versions if the factory is not a ClusterSummarizer.
+func SummarizeCluster(ctx context.Context, factory StorageFactory, cluster string) (*ClusterSummary, error) {
+	if summarizer, ok := factory.(ClusterSummarizer); ok {
+		return summarizer.SummarizeCluster(ctx, cluster)
+	}
+
+	versions, err := factory.GetResourceVersions(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ClusterSummary{}
+	for _, rvs := range versions {
+		summary.Objects += int64(len(rvs.Resources))
+	}
+	return summary, nil
+}
+
 type ResourceStorageConfig struct {
 	resourceconfig.ResourceConfig
 }
@@ -64,6 +547,10 @@ type storageRecoverableExceptionError struct {
 	error
 }
 
+func (e storageRecoverableExceptionError) Unwrap() error {
+	return e.error
+}
+
 func NewRecoverableException(err error) error {
 	return storageRecoverableExceptionError{err}
 }
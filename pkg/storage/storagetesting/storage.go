@@ -0,0 +1,321 @@
+// Package storagetesting provides the fake storage in memory for the tests of the storages
+// which wrap the other storages, e.g. the cache storage, the dual storage and the sharding storage.
+package storagetesting
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+var PodsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func NewPod(namespace, name string) *unstructured.Unstructured {
+	pod := &unstructured.Unstructured{}
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	pod.SetNamespace(namespace)
+	pod.SetName(name)
+	return pod
+}
+
+// StorageFactory stores the objects in memory, the objects are keyed by the cluster, the namespace and the name,
+// and the resource of an object is guessed from its kind.
+// The methods which are not implemented panic.
+type StorageFactory struct {
+	storage.StorageFactory
+
+	lock    sync.Mutex
+	objects map[string]*unstructured.Unstructured // cluster/namespace/name -> object
+	reads   int
+
+	// FailWrites fails the creations, the updates and the deletions
+	FailWrites bool
+
+	// Unorderable fails the lists with the order by
+	Unorderable bool
+}
+
+func NewStorageFactory() *StorageFactory {
+	return &StorageFactory{objects: make(map[string]*unstructured.Unstructured)}
+}
+
+func objectKey(cluster, namespace, name string) string {
+	return cluster + "/" + namespace + "/" + name
+}
+
+func resourceOf(obj *unstructured.Unstructured) schema.GroupVersionResource {
+	gvr, _ := meta.UnsafeGuessKindToResource(obj.GroupVersionKind())
+	return gvr
+}
+
+// Put stores the object without going through the resource storage
+func (f *StorageFactory) Put(cluster string, obj *unstructured.Unstructured) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.objects[objectKey(cluster, obj.GetNamespace(), obj.GetName())] = obj.DeepCopy()
+}
+
+// Object returns the stored object, or nil if the object is not stored
+func (f *StorageFactory) Object(cluster, namespace, name string) *unstructured.Unstructured {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if obj, ok := f.objects[objectKey(cluster, namespace, name)]; ok {
+		return obj.DeepCopy()
+	}
+	return nil
+}
+
+// Objects returns the objects of the clusters ordered by the cluster, the namespace and the name,
+// all of the objects are returned if the clusters are empty.
+func (f *StorageFactory) Objects(clusters ...string) []*unstructured.Unstructured {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var objs []*unstructured.Unstructured
+	for _, key := range f.keys(clusters) {
+		objs = append(objs, f.objects[key].DeepCopy())
+	}
+	return objs
+}
+
+// Reads returns the number of the gets and the lists of the resource storages
+func (f *StorageFactory) Reads() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.reads
+}
+
+func (f *StorageFactory) keys(clusters []string) []string {
+	filter := sets.New(clusters...)
+	var keys []string
+	for key := range f.objects {
+		cluster, _, _ := strings.Cut(key, "/")
+		if filter.Len() == 0 || filter.Has(cluster) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *StorageFactory) deleteObjects(clusters []string, match func(cluster string, obj *unstructured.Unstructured) bool) int64 {
+	var deleted int64
+	for _, key := range f.keys(clusters) {
+		cluster, _, _ := strings.Cut(key, "/")
+		if match(cluster, f.objects[key]) {
+			delete(f.objects, key)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+func (f *StorageFactory) PrepareCluster(cluster string) error { return nil }
+
+func (f *StorageFactory) GetResourceVersions(_ context.Context, cluster string) (map[schema.GroupVersionResource]storage.ClusterResourceVersions, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	versions := make(map[schema.GroupVersionResource]storage.ClusterResourceVersions)
+	for _, key := range f.keys([]string{cluster}) {
+		obj := f.objects[key]
+		gvr := resourceOf(obj)
+		if _, ok := versions[gvr]; !ok {
+			versions[gvr] = storage.ClusterResourceVersions{Resources: make(map[string]interface{}), Events: make(map[string]interface{})}
+		}
+		versions[gvr].Resources[strings.TrimPrefix(key, cluster+"/")] = obj.GetResourceVersion()
+	}
+	return versions, nil
+}
+
+func (f *StorageFactory) NewResourceStorage(config *storage.ResourceStorageConfig) (storage.ResourceStorage, error) {
+	return &ResourceStorage{factory: f, config: *config}, nil
+}
+
+func (f *StorageFactory) CleanCluster(_ context.Context, cluster string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.deleteObjects([]string{cluster}, func(string, *unstructured.Unstructured) bool { return true })
+	return nil
+}
+
+func (f *StorageFactory) CleanClusterResource(_ context.Context, cluster string, gvr schema.GroupVersionResource) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.deleteObjects([]string{cluster}, func(_ string, obj *unstructured.Unstructured) bool { return resourceOf(obj) == gvr })
+	return nil
+}
+
+func (f *StorageFactory) PurgeResources(_ context.Context, opts storage.PurgeOptions) (int64, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	excluded := sets.New(opts.ExcludedClusters...)
+	return f.deleteObjects(opts.Clusters, func(cluster string, obj *unstructured.Unstructured) bool {
+		return resourceOf(obj).GroupResource() == opts.Resource && !excluded.Has(cluster) &&
+			obj.GetCreationTimestamp().Time.Before(opts.CreatedBefore)
+	}), nil
+}
+
+func (f *StorageFactory) Shutdown() error { return nil }
+
+// ResourceStorage lists the objects of its resource ordered by the cluster, the namespace and the name,
+// the continue of the list is the offset of the next object.
+type ResourceStorage struct {
+	storage.ResourceStorage
+
+	factory *StorageFactory
+	config  storage.ResourceStorageConfig
+}
+
+func (s *ResourceStorage) GetStorageConfig() *storage.ResourceStorageConfig {
+	return &s.config
+}
+
+func (s *ResourceStorage) Get(_ context.Context, cluster, namespace, name string, obj runtime.Object) error {
+	s.factory.lock.Lock()
+	defer s.factory.lock.Unlock()
+	s.factory.reads++
+
+	key := objectKey(cluster, namespace, name)
+	stored, ok := s.factory.objects[key]
+	if !ok {
+		return storage.NewNotFoundError(key, errors.New("not found"))
+	}
+	if into, ok := obj.(*unstructured.Unstructured); ok {
+		stored.DeepCopyInto(into)
+		return nil
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(stored.DeepCopy().Object, obj)
+}
+
+func (s *ResourceStorage) List(_ context.Context, listObj runtime.Object, opts *internal.ListOptions) error {
+	if len(opts.OrderBy) != 0 && s.factory.Unorderable {
+		return storage.NewInvalidQueryError("", errors.New("order by is not supported"))
+	}
+
+	s.factory.lock.Lock()
+	defer s.factory.lock.Unlock()
+	s.factory.reads++
+
+	namespaces := sets.New(opts.Namespaces...)
+	var objs []runtime.Object
+	for _, key := range s.factory.keys(opts.ClusterNames) {
+		obj := s.factory.objects[key]
+		if resourceOf(obj) != s.config.StorageResource {
+			continue
+		}
+		if namespaces.Len() != 0 && !namespaces.Has(obj.GetNamespace()) {
+			continue
+		}
+		if opts.LabelSelector != nil && !opts.LabelSelector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		objs = append(objs, obj.DeepCopy())
+	}
+
+	offset := 0
+	if opts.Continue != "" {
+		var err error
+		if offset, err = strconv.Atoi(opts.Continue); err != nil || offset > len(objs) {
+			return storage.NewInvalidQueryError("", errors.New("invalid continue"))
+		}
+	}
+	objs = objs[offset:]
+
+	accessor, err := meta.ListAccessor(listObj)
+	if err != nil {
+		return err
+	}
+	if opts.Limit > 0 && int64(len(objs)) > opts.Limit {
+		remaining := int64(len(objs)) - opts.Limit
+		accessor.SetContinue(strconv.Itoa(offset + int(opts.Limit)))
+		accessor.SetRemainingItemCount(&remaining)
+		objs = objs[:opts.Limit]
+	}
+	return meta.SetList(listObj, objs)
+}
+
+func (s *ResourceStorage) write(cluster string, obj runtime.Object, write func(key string, obj *unstructured.Unstructured) error) error {
+	if s.factory.FailWrites {
+		return errors.New("failed to write")
+	}
+
+	object, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		object = &unstructured.Unstructured{Object: data}
+	}
+
+	s.factory.lock.Lock()
+	defer s.factory.lock.Unlock()
+	return write(objectKey(cluster, object.GetNamespace(), object.GetName()), object.DeepCopy())
+}
+
+func (s *ResourceStorage) Create(_ context.Context, cluster string, obj runtime.Object) error {
+	return s.write(cluster, obj, func(key string, obj *unstructured.Unstructured) error {
+		if _, ok := s.factory.objects[key]; ok {
+			return storage.NewConflictError(key, errors.New("existed"))
+		}
+		s.factory.objects[key] = obj
+		return nil
+	})
+}
+
+func (s *ResourceStorage) Update(_ context.Context, cluster string, obj runtime.Object) error {
+	return s.write(cluster, obj, func(key string, obj *unstructured.Unstructured) error {
+		if _, ok := s.factory.objects[key]; !ok {
+			return storage.NewNotFoundError(key, errors.New("not found"))
+		}
+		s.factory.objects[key] = obj
+		return nil
+	})
+}
+
+// Delete doesn't return the error if the object is not stored, the same as the internal storage
+func (s *ResourceStorage) Delete(_ context.Context, cluster string, obj runtime.Object) error {
+	if s.factory.FailWrites {
+		return errors.New("failed to write")
+	}
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+
+	s.factory.lock.Lock()
+	defer s.factory.lock.Unlock()
+	delete(s.factory.objects, objectKey(cluster, metaobj.GetNamespace(), metaobj.GetName()))
+	return nil
+}
+
+func (s *ResourceStorage) DeleteCollection(_ context.Context, opts storage.DeleteCollectionOptions) (int64, error) {
+	if s.factory.FailWrites {
+		return 0, errors.New("failed to write")
+	}
+
+	s.factory.lock.Lock()
+	defer s.factory.lock.Unlock()
+	namespaces := sets.New(opts.Namespaces...)
+	return s.factory.deleteObjects(opts.Clusters, func(_ string, obj *unstructured.Unstructured) bool {
+		return resourceOf(obj) == s.config.StorageResource &&
+			(namespaces.Len() == 0 || namespaces.Has(obj.GetNamespace())) &&
+			(opts.LabelSelector == nil || opts.LabelSelector.Matches(labels.Set(obj.GetLabels())))
+	}), nil
+}
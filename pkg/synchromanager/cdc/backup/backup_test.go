@@ -0,0 +1,288 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage/storagetesting"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/cdc"
+)
+
+var configMapsGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+type memoryStore struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+	failPut bool
+}
+
+func (s *memoryStore) Put(_ context.Context, key string, data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.failPut {
+		return errors.New("unavailable")
+	}
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.objects[key], nil
+}
+
+func (s *memoryStore) List(_ context.Context, prefix string) ([]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var keys []string
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func newEvent(eventType cdc.EventType, name, value string, at time.Time) *cdc.Event {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	_ = unstructured.SetNestedField(obj.Object, value, "data", "value")
+
+	event := cdc.NewEvent(eventType, "cluster-1", configMapsGVR, obj)
+	event.Time = metav1.NewTime(at)
+	return event
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.TODO()
+	store := &memoryStore{objects: make(map[string][]byte)}
+	writer := NewWriter(store, &Config{Prefix: "backup", Interval: time.Hour})
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	for _, event := range []*cdc.Event{
+		newEvent(cdc.Created, "a", "1", at(1)),
+		newEvent(cdc.Updated, "a", "2", at(2)),
+		newEvent(cdc.Created, "b", "1", at(3)),
+	} {
+		if err := writer.Publish(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the events are kept pending and shipped with the later events in the next segment
+	store.failPut = true
+	if err := writer.Ship(ctx); err == nil {
+		t.Fatal("Ship() should fail if the object storage is unavailable")
+	}
+	store.failPut = false
+	if err := writer.Publish(ctx, newEvent(cdc.Deleted, "b", "1", at(4))); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Ship(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Publish(ctx, newEvent(cdc.Updated, "a", "3", at(5))); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	segments, _ := store.List(ctx, "backup/segments/")
+	if len(segments) != 2 {
+		t.Fatalf("two segments should be shipped, got %v", segments)
+	}
+	if first, last, ok := parseSegment(segments[0]); !ok || !first.Equal(at(1)) || !last.Equal(at(4)) {
+		t.Errorf("the first segment %s should have the events from %s to %s", segments[0], at(1), at(4))
+	}
+	marker, err := ReadMarker(ctx, store, "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marker == nil || marker.LastSegment != segments[1] || !marker.LastEventTime.Equal(at(5)) {
+		t.Errorf("the marker should point to the last segment, got %+v", marker)
+	}
+
+	tests := []struct {
+		name     string
+		opts     RestoreOptions
+		events   int
+		expected map[string]string
+	}{
+		{name: "all", events: 5, expected: map[string]string{"a": "3"}},
+		{name: "until", opts: RestoreOptions{Until: at(3)}, events: 3, expected: map[string]string{"a": "2", "b": "1"}},
+		{name: "from", opts: RestoreOptions{From: at(4)}, events: 2, expected: map[string]string{"a": "3"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			factory := storagetesting.NewStorageFactory()
+			if !test.opts.From.IsZero() {
+				// the storage is restored from the full dump before the from time
+				factory.Put("cluster-1", newEvent(cdc.Created, "a", "2", at(2)).Object.(*unstructured.Unstructured))
+			}
+
+			result, err := Restore(ctx, store, "backup", factory, test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Events != test.events {
+				t.Errorf("Restore() replayed %d events, want %d", result.Events, test.events)
+			}
+
+			values := make(map[string]string)
+			for _, obj := range factory.Objects() {
+				values[obj.GetName()], _, _ = unstructured.NestedString(obj.Object, "data", "value")
+			}
+			if len(values) != len(test.expected) {
+				t.Errorf("the restored objects = %v, want %v", values, test.expected)
+			}
+			for name, value := range test.expected {
+				if values[name] != value {
+					t.Errorf("the restored objects = %v, want %v", values, test.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestRestartContinuity(t *testing.T) {
+	ctx := context.TODO()
+	store := &memoryStore{objects: make(map[string][]byte)}
+	config := &Config{Prefix: "backup", Interval: time.Hour}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	writer := NewWriter(store, config)
+	for _, event := range []*cdc.Event{
+		newEvent(cdc.Created, "a", "1", at(1)),
+		newEvent(cdc.Created, "b", "1", at(2)),
+	} {
+		if err := writer.Publish(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the clock of the restarted process is behind the last shipped event
+	writer = NewWriter(store, config)
+	for _, event := range []*cdc.Event{
+		newEvent(cdc.Updated, "a", "2", at(2).Add(-time.Second)),
+		newEvent(cdc.Deleted, "b", "1", at(3)),
+	} {
+		if err := writer.Publish(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	segments, _ := store.List(ctx, "backup/segments/")
+	if len(segments) != 2 {
+		t.Fatalf("two segments should be shipped, got %v", segments)
+	}
+	if first, _, ok := parseSegment(segments[1]); !ok || !first.After(at(2)) {
+		t.Errorf("the segment shipped after the restart should be sorted after the last segment, got %v", segments)
+	}
+	marker, err := ReadMarker(ctx, store, "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marker == nil || marker.LastSegment != segments[1] || !marker.LastEventTime.Equal(at(3)) {
+		t.Errorf("the marker should point to the segment shipped after the restart, got %+v", marker)
+	}
+
+	factory := storagetesting.NewStorageFactory()
+	result, err := Restore(ctx, store, "backup", factory, RestoreOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Events != 4 || len(factory.Objects()) != 1 {
+		t.Fatalf("Restore() replayed %d events and restored %d objects, want 4 events and 1 object", result.Events, len(factory.Objects()))
+	}
+	if value, _, _ := unstructured.NestedString(factory.Object("cluster-1", "default", "a").Object, "data", "value"); value != "2" {
+		t.Errorf("the object is restored with the value %q, want 2", value)
+	}
+}
+
+func TestExcludedResources(t *testing.T) {
+	ctx := context.TODO()
+	store := &memoryStore{objects: make(map[string][]byte)}
+	writer := NewWriter(store, &Config{Prefix: "backup", Interval: time.Hour, ExcludedResources: []schema.GroupResource{{Resource: "secrets"}}})
+	defer writer.Close()
+
+	secret := &unstructured.Unstructured{}
+	secret.SetAPIVersion("v1")
+	secret.SetKind("Secret")
+	secret.SetNamespace("default")
+	secret.SetName("token")
+	_ = unstructured.SetNestedField(secret.Object, "c2VjcmV0", "data", "token")
+
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, event := range []*cdc.Event{
+		cdc.NewEvent(cdc.Created, "cluster-1", schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, secret),
+		newEvent(cdc.Created, "a", "1", at),
+	} {
+		if err := writer.Publish(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Ship(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	segments, _ := store.List(ctx, "backup/segments/")
+	if len(segments) != 1 {
+		t.Fatalf("one segment should be shipped, got %v", segments)
+	}
+	events, err := decodeSegment(store.objects[segments[0]])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Resource != "configmaps" {
+		t.Errorf("the events of the secrets should not be shipped, got %+v", events)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	opts := NewOptions()
+	if errs := opts.Validate(); len(errs) != 0 || opts.Config() != nil {
+		t.Errorf("the backup should be disabled by default, got %v", errs)
+	}
+
+	opts.S3.Endpoint = "minio:9000"
+	if errs := opts.Validate(); len(errs) == 0 {
+		t.Error("the bucket should be required")
+	}
+	opts.S3.Bucket = "clusterpedia"
+	if errs := opts.Validate(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if config := opts.Config(); config == nil || config.Prefix != DefaultPrefix || config.S3.Bucket != "clusterpedia" ||
+		len(config.ExcludedResources) != 1 || config.ExcludedResources[0] != (schema.GroupResource{Resource: "secrets"}) {
+		t.Errorf("unexpected config %+v", config)
+	}
+
+	opts.ExcludedResources = []string{".apps"}
+	if errs := opts.Validate(); len(errs) == 0 {
+		t.Error("the resource of the excluded resources should be required")
+	}
+}
@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/objectstore"
+)
+
+const DefaultPrefix = "clusterpedia"
+
+// S3Options are the options of the S3 compatible object storage of the backup
+type S3Options struct {
+	Endpoint string
+	Bucket   string
+	Region   string
+	Insecure bool
+	Prefix   string
+}
+
+func (o *S3Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Endpoint, "backup-endpoint", o.Endpoint, ""+
+		"The endpoint of the S3 compatible object storage of the backup, e.g. s3.amazonaws.com or minio:9000. "+
+		"The credentials are loaded from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or the MINIO_ACCESS_KEY and MINIO_SECRET_KEY "+
+		"environment variables, or from the IAM role of the instance.")
+	fs.StringVar(&o.Bucket, "backup-bucket", o.Bucket, "The bucket of the backup")
+	fs.StringVar(&o.Region, "backup-region", o.Region, "The region of the bucket of the backup")
+	fs.BoolVar(&o.Insecure, "backup-insecure", o.Insecure, "Connect to the object storage of the backup by HTTP instead of HTTPS")
+	fs.StringVar(&o.Prefix, "backup-prefix", o.Prefix, "The key prefix of the segments and the marker of the backup in the bucket")
+}
+
+func (o *S3Options) Validate() []error {
+	var errs []error
+	if o.Endpoint == "" {
+		errs = append(errs, fmt.Errorf("backup-endpoint must be set"))
+	}
+	if o.Bucket == "" {
+		errs = append(errs, fmt.Errorf("backup-bucket must be set"))
+	}
+	return errs
+}
+
+func (o *S3Options) Config() objectstore.S3Config {
+	return objectstore.S3Config{Endpoint: o.Endpoint, Bucket: o.Bucket, Region: o.Region, Insecure: o.Insecure}
+}
+
+// Options are the options of the incremental backup of the change events
+type Options struct {
+	S3                S3Options
+	Interval          time.Duration
+	ExcludedResources []string
+}
+
+func NewOptions() *Options {
+	return &Options{S3: S3Options{Prefix: DefaultPrefix}, Interval: time.Minute, ExcludedResources: []string{"secrets"}}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	o.S3.AddFlags(fs)
+	fs.DurationVar(&o.Interval, "backup-interval", o.Interval, ""+
+		"The interval of shipping the change events since the last backup marker to the object storage as a segment, "+
+		"the changes within the interval are lost if the process is killed")
+	fs.StringSliceVar(&o.ExcludedResources, "backup-excluded-resources", o.ExcludedResources, ""+
+		"The resources in the form of '<resource>[.<group>]' whose change events are not shipped to the backup, "+
+		"the resources encrypted at rest by the storage should be set, so their objects are not stored in plaintext. "+
+		"The excluded resources are not restored, they are synchronized from the clusters again.")
+}
+
+func (o *Options) Validate() []error {
+	if o.S3.Endpoint == "" {
+		return nil
+	}
+
+	errs := o.S3.Validate()
+	if o.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("backup-interval must be greater than 0"))
+	}
+	for _, resource := range o.ExcludedResources {
+		if schema.ParseGroupResource(resource).Resource == "" {
+			errs = append(errs, fmt.Errorf("backup-excluded-resources: resource is required in %q", resource))
+		}
+	}
+	return errs
+}
+
+// Config returns nil if the endpoint is not set.
+func (o *Options) Config() *Config {
+	if o.S3.Endpoint == "" {
+		return nil
+	}
+	config := &Config{S3: o.S3.Config(), Prefix: o.S3.Prefix, Interval: o.Interval}
+	for _, resource := range o.ExcludedResources {
+		config.ExcludedResources = append(config.ExcludedResources, schema.ParseGroupResource(resource))
+	}
+	return config
+}
+
+type Config struct {
+	S3       objectstore.S3Config
+	Prefix   string
+	Interval time.Duration
+
+	// ExcludedResources are the resources whose events are not shipped
+	ExcludedResources []schema.GroupResource
+}
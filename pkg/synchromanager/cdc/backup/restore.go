@@ -0,0 +1,170 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/cdc"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/objectstore"
+)
+
+// maxEventSize bounds the size of each event in the segments
+const maxEventSize = 64 << 20
+
+// RestoreOptions bounds the events replayed by Restore, the zero time means no bound
+type RestoreOptions struct {
+	// From skips the events before the time, e.g. the time of the full dump which the storage is restored from
+	From time.Time
+	// Until skips the events after the time, which is the point in time to recover
+	Until time.Time
+}
+
+// RestoreResult is the number of the replayed events and the time of the last one
+type RestoreResult struct {
+	Events        int
+	LastEventTime time.Time
+}
+
+// event is the change event decoded from the segments
+type event struct {
+	Type      cdc.EventType   `json:"type"`
+	Time      metav1.Time     `json:"time"`
+	Cluster   string          `json:"cluster"`
+	Group     string          `json:"group"`
+	Version   string          `json:"version"`
+	Resource  string          `json:"resource"`
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	Object    json.RawMessage `json:"object"`
+}
+
+// Restore replays the change events of the segments in the storage in order, so the storage is recovered
+// to the point in time. The events are replayed idempotently, so the storage can be restored from a full dump
+// taken before the From time. The storage can be empty only if it was empty when the backup was enabled,
+// since the backup has no base snapshot.
+func Restore(ctx context.Context, store objectstore.Store, prefix string, factory storage.StorageFactory, opts RestoreOptions) (RestoreResult, error) {
+	var result RestoreResult
+	keys, err := store.List(ctx, path.Join(prefix, segmentsDir)+"/")
+	if err != nil {
+		return result, err
+	}
+
+	restorer := &restorer{factory: factory, configFactory: resourceconfigfactory.New(), storages: make(map[schema.GroupVersionResource]storage.ResourceStorage)}
+	for _, key := range keys {
+		first, last, ok := parseSegment(key)
+		if !ok || (!opts.From.IsZero() && last.Before(opts.From)) {
+			continue
+		}
+		if !opts.Until.IsZero() && first.After(opts.Until) {
+			break
+		}
+
+		data, err := store.Get(ctx, key)
+		if err != nil {
+			return result, err
+		}
+		events, err := decodeSegment(data)
+		if err != nil {
+			return result, fmt.Errorf("invalid segment %s: %w", key, err)
+		}
+		for _, e := range events {
+			if (!opts.From.IsZero() && e.Time.Time.Before(opts.From)) || (!opts.Until.IsZero() && e.Time.Time.After(opts.Until)) {
+				continue
+			}
+			if err := restorer.apply(ctx, e); err != nil {
+				return result, fmt.Errorf("failed to replay the event of %s %s/%s in the cluster %s in the segment %s: %w",
+					e.Resource, e.Namespace, e.Name, e.Cluster, key, err)
+			}
+			result.Events++
+			result.LastEventTime = e.Time.Time
+		}
+	}
+	return result, nil
+}
+
+func decodeSegment(data []byte) ([]event, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	var events []event
+	scanner := bufio.NewScanner(gr)
+	scanner.Buffer(nil, maxEventSize)
+	for scanner.Scan() {
+		var e event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, scanner.Err()
+}
+
+type restorer struct {
+	factory       storage.StorageFactory
+	configFactory *resourceconfigfactory.ResourceConfigFactory
+	storages      map[schema.GroupVersionResource]storage.ResourceStorage
+}
+
+func (r *restorer) resourceStorage(gvr schema.GroupVersionResource, namespaced bool) (storage.ResourceStorage, error) {
+	if resourceStorage, ok := r.storages[gvr]; ok {
+		return resourceStorage, nil
+	}
+
+	config, err := r.configFactory.NewConfig(gvr, namespaced)
+	if err != nil {
+		return nil, err
+	}
+	resourceStorage, err := r.factory.NewResourceStorage(&storage.ResourceStorageConfig{ResourceConfig: *config})
+	if err != nil {
+		return nil, err
+	}
+	r.storages[gvr] = resourceStorage
+	return resourceStorage, nil
+}
+
+func (r *restorer) apply(ctx context.Context, e event) error {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(e.Object); err != nil {
+		return err
+	}
+	gvr := schema.GroupVersionResource{Group: e.Group, Version: e.Version, Resource: e.Resource}
+	resourceStorage, err := r.resourceStorage(gvr, e.Namespace != "")
+	if err != nil {
+		return err
+	}
+
+	switch e.Type {
+	case cdc.Deleted:
+		if err := resourceStorage.Delete(ctx, e.Cluster, obj); err != nil && !storage.IsNotFound(err) {
+			return err
+		}
+		return nil
+	case cdc.Created:
+		err := resourceStorage.Create(ctx, e.Cluster, obj)
+		if storage.IsConflict(err) {
+			return resourceStorage.Update(ctx, e.Cluster, obj)
+		}
+		return err
+	default:
+		err := resourceStorage.Update(ctx, e.Cluster, obj)
+		if storage.IsNotFound(err) {
+			return resourceStorage.Create(ctx, e.Cluster, obj)
+		}
+		return err
+	}
+}
@@ -0,0 +1,273 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/cdc"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/objectstore"
+)
+
+const (
+	segmentsDir   = "segments"
+	segmentSuffix = ".jsonl.gz"
+	markerKey     = "marker.json"
+
+	// maxPendingEvents bounds the events kept in memory while the object storage is unavailable
+	maxPendingEvents = 1 << 20
+
+	// shipTimeout bounds the upload of each segment and the marker
+	shipTimeout = time.Minute
+)
+
+// Marker records the last segment shipped to the object storage
+type Marker struct {
+	LastSegment   string    `json:"lastSegment"`
+	LastEventTime time.Time `json:"lastEventTime"`
+}
+
+// Writer is the publisher of the change events which ships the events since the last backup marker
+// to the object storage as a gzipped JSON lines segment at each interval, and then moves the marker to the segment.
+//
+// The segments are named by the unix nanoseconds of their first and last events, so they are sorted by the time,
+// and the events are replayed in the order of the segments by Restore.
+//
+// The events of the excluded resources are not shipped, so the secrets and the resources encrypted
+// at rest by the storage are not stored in the object storage in plaintext.
+//
+// The writer resumes from the marker when it is restarted, the segments shipped after the restart
+// are sorted after the last segment even if the clock of the new process is behind.
+// The events still pending when the process is killed are lost, they are not shipped after the restart.
+//
+// The backup has no base snapshot, it only has the changes since the backup is enabled. So the storage
+// should be restored from a full dump taken after the backup is enabled, and then the events since the dump
+// are replayed by Restore, the full dump should be taken again after the process is killed.
+type Writer struct {
+	store    objectstore.Store
+	prefix   string
+	interval time.Duration
+	excluded sets.Set[schema.GroupResource]
+
+	lock    sync.Mutex
+	pending [][]byte
+	first   time.Time
+	last    time.Time
+
+	// shipLock serializes the shipping, the marker is nil if no segment is shipped
+	shipLock sync.Mutex
+	resumed  bool
+	marker   *Marker
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+var _ cdc.Publisher = &Writer{}
+
+func NewWriter(store objectstore.Store, config *Config) *Writer {
+	w := &Writer{
+		store:    store,
+		prefix:   config.Prefix,
+		interval: config.Interval,
+		excluded: sets.New(config.ExcludedResources...),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go func() {
+		defer close(w.done)
+
+		ctx, cancel := context.WithTimeout(context.Background(), shipTimeout)
+		if err := w.resume(ctx); err != nil {
+			klog.ErrorS(err, "Failed to read the backup marker, it will be read again before the next segment is shipped")
+		}
+		cancel()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), shipTimeout)
+			if err := w.Ship(ctx); err != nil {
+				klog.ErrorS(err, "Failed to ship the change events to the backup, they will be shipped in the next segment")
+			}
+			cancel()
+		}
+	}()
+	return w
+}
+
+// NewPublisher returns nil if the config is nil
+func NewPublisher(config *Config) (cdc.Publisher, error) {
+	if config == nil {
+		return nil, nil
+	}
+	store, err := objectstore.NewS3Store(config.S3)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriter(store, config), nil
+}
+
+// Publish buffers the event until it is shipped, the events of the excluded resources are ignored
+func (w *Writer) Publish(_ context.Context, event *cdc.Event) error {
+	if w.excluded.Has(schema.GroupResource{Group: event.Group, Resource: event.Resource}) {
+		return nil
+	}
+
+	data, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(w.pending) >= maxPendingEvents {
+		return fmt.Errorf("the backup has %d pending events, the object storage may be unavailable", len(w.pending))
+	}
+	if len(w.pending) == 0 {
+		w.first = event.Time.Time
+	}
+	w.pending = append(w.pending, data)
+	w.last = event.Time.Time
+	return nil
+}
+
+// resume reads the marker of the last shipped segment
+func (w *Writer) resume(ctx context.Context) error {
+	w.shipLock.Lock()
+	defer w.shipLock.Unlock()
+	return w.resumeLocked(ctx)
+}
+
+func (w *Writer) resumeLocked(ctx context.Context) error {
+	if w.resumed {
+		return nil
+	}
+	marker, err := ReadMarker(ctx, w.store, w.prefix)
+	if err != nil {
+		return err
+	}
+	w.marker, w.resumed = marker, true
+	return nil
+}
+
+// Ship ships the pending events as a segment and moves the marker, the events are kept pending if the segment is failed to be shipped.
+func (w *Writer) Ship(ctx context.Context) error {
+	w.shipLock.Lock()
+	defer w.shipLock.Unlock()
+
+	w.lock.Lock()
+	pending, first, last := w.pending, w.first, w.last
+	w.pending = nil
+	w.lock.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := w.resumeLocked(ctx)
+	if err == nil {
+		marker := w.segmentMarker(first, last)
+		if err = w.putSegment(ctx, marker.LastSegment, pending); err == nil {
+			w.marker = marker
+			var data []byte
+			if data, err = json.Marshal(marker); err != nil {
+				return err
+			}
+			return w.store.Put(ctx, path.Join(w.prefix, markerKey), data)
+		}
+	}
+
+	w.lock.Lock()
+	if len(w.pending) != 0 {
+		last = w.last
+	}
+	w.pending, w.first, w.last = append(pending, w.pending...), first, last
+	w.lock.Unlock()
+	return err
+}
+
+// segmentMarker returns the marker of the segment of the events, the segment is named after the last segment
+// if the events are not after the last segment, e.g. the clock of the restarted process is behind.
+func (w *Writer) segmentMarker(first, last time.Time) *Marker {
+	if w.marker != nil && !first.After(w.marker.LastEventTime) {
+		first = w.marker.LastEventTime.Add(time.Nanosecond)
+	}
+	if last.Before(first) {
+		last = first
+	}
+	key := path.Join(w.prefix, segmentsDir, fmt.Sprintf("%020d-%020d%s", first.UnixNano(), last.UnixNano(), segmentSuffix))
+	return &Marker{LastSegment: key, LastEventTime: last}
+}
+
+func (w *Writer) putSegment(ctx context.Context, key string, events [][]byte) error {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	for _, event := range events {
+		if _, err := gw.Write(append(event, '\n')); err != nil {
+			return err
+		}
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+	return w.store.Put(ctx, key, buf.Bytes())
+}
+
+// Close stops the shipping and ships the pending events
+func (w *Writer) Close() error {
+	close(w.stop)
+	<-w.done
+
+	ctx, cancel := context.WithTimeout(context.Background(), shipTimeout)
+	defer cancel()
+	return w.Ship(ctx)
+}
+
+// ReadMarker returns nil if no segment is shipped
+func ReadMarker(ctx context.Context, store objectstore.Store, prefix string) (*Marker, error) {
+	data, err := store.Get(ctx, path.Join(prefix, markerKey))
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var marker Marker
+	if err := json.Unmarshal(data, &marker); err != nil {
+		return nil, fmt.Errorf("invalid backup marker: %w", err)
+	}
+	return &marker, nil
+}
+
+// parseSegment returns the time of the first and the last events of the segment
+func parseSegment(key string) (time.Time, time.Time, bool) {
+	name := strings.TrimSuffix(path.Base(key), segmentSuffix)
+	first, last, ok := strings.Cut(name, "-")
+	if !ok || name == path.Base(key) {
+		return time.Time{}, time.Time{}, false
+	}
+	firstNano, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	lastNano, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return time.Unix(0, firstNano), time.Unix(0, lastNano), true
+}
@@ -0,0 +1,192 @@
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+type EventType string
+
+const (
+	Created EventType = "Created"
+	Updated EventType = "Updated"
+	Deleted EventType = "Deleted"
+)
+
+const lastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+var publishFailuresTotal = metrics.NewCounterVec(
+	&metrics.CounterOpts{
+		Namespace:      "clusterpedia",
+		Subsystem:      "cdc",
+		Name:           "publish_failures_total",
+		Help:           "Number of the change events failed to be published to the sink.",
+		StabilityLevel: metrics.ALPHA,
+	},
+	[]string{"sink", "cluster"},
+)
+
+func init() {
+	legacyregistry.MustRegister(publishFailuresTotal)
+}
+
+// Event is the change of a resource saved to the storage, the object is in the storage version.
+type Event struct {
+	Type      EventType   `json:"type"`
+	Time      metav1.Time `json:"time"`
+	Cluster   string      `json:"cluster"`
+	Group     string      `json:"group"`
+	Version   string      `json:"version"`
+	Resource  string      `json:"resource"`
+	Namespace string      `json:"namespace,omitempty"`
+	Name      string      `json:"name"`
+
+	ResourceVersion string         `json:"resourceVersion,omitempty"`
+	Object          runtime.Object `json:"object,omitempty"`
+}
+
+func NewEvent(eventType EventType, cluster string, gvr schema.GroupVersionResource, obj metav1.Object) *Event {
+	event := &Event{
+		Type:            eventType,
+		Time:            metav1.NewTime(time.Now()),
+		Cluster:         cluster,
+		Group:           gvr.Group,
+		Version:         gvr.Version,
+		Resource:        gvr.Resource,
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		ResourceVersion: obj.GetResourceVersion(),
+	}
+	if robj, ok := obj.(runtime.Object); ok {
+		event.Object = robj
+	}
+	return event
+}
+
+// Key is the key of the event in the sink, the events of the same object have the same key to be kept in order
+func (e *Event) Key() string {
+	return path.Join(e.Cluster, e.Group, e.Resource, e.Namespace, e.Name)
+}
+
+// Headers returns the metadata of the event, so the consumers can route the events without decoding them
+func (e *Event) Headers() map[string]string {
+	return map[string]string{
+		"clusterpedia-event-type": string(e.Type),
+		"clusterpedia-cluster":    e.Cluster,
+		"clusterpedia-group":      e.Group,
+		"clusterpedia-version":    e.Version,
+		"clusterpedia-resource":   e.Resource,
+		"clusterpedia-namespace":  e.Namespace,
+		"clusterpedia-name":       e.Name,
+	}
+}
+
+func (e *Event) Encode() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// Publisher publishes the change events to the sink, Publish should not block on the sink
+// because it is called by the resource synchros after the changes are saved to the storage.
+type Publisher interface {
+	Publish(ctx context.Context, event *Event) error
+	Close() error
+}
+
+// Publishers publishes the events to each publisher in order
+type Publishers []Publisher
+
+func (ps Publishers) Publish(ctx context.Context, event *Event) error {
+	var errs []error
+	for _, p := range ps {
+		if err := p.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (ps Publishers) Close() error {
+	var errs []error
+	for _, p := range ps {
+		if err := p.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// NewPublisher returns nil if the config is nil
+func NewPublisher(config *Config) (Publisher, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	var publisher Publisher
+	switch config.Sink {
+	case KafkaSink:
+		publisher = newKafkaPublisher(config.Kafka)
+	case NATSSink:
+		natsPublisher, err := newNATSPublisher(config.NATS)
+		if err != nil {
+			return nil, err
+		}
+		publisher = natsPublisher
+	default:
+		return nil, fmt.Errorf("unknown cdc sink %q", config.Sink)
+	}
+
+	if len(config.MetadataOnlyResources) == 0 {
+		return publisher, nil
+	}
+	return &metadataOnlyPublisher{Publisher: publisher, resources: sets.New(config.MetadataOnlyResources...)}, nil
+}
+
+// metadataOnlyPublisher publishes the events of the resources with only the metadata of the objects,
+// so the secrets and the resources encrypted at rest by the storage are not published in plaintext.
+type metadataOnlyPublisher struct {
+	Publisher
+	resources sets.Set[schema.GroupResource]
+}
+
+func (p *metadataOnlyPublisher) Publish(ctx context.Context, event *Event) error {
+	if event.Object != nil && p.resources.Has(schema.GroupResource{Group: event.Group, Resource: event.Resource}) {
+		metadataOnly := *event
+		metadataOnly.Object = metadataOnlyObject(schema.GroupVersion{Group: event.Group, Version: event.Version}, event.Object)
+		event = &metadataOnly
+	}
+	return p.Publisher.Publish(ctx, event)
+}
+
+// metadataOnlyObject returns the metadata of the object without the last applied configuration,
+// which has the full content of the object.
+func metadataOnlyObject(gv schema.GroupVersion, obj runtime.Object) runtime.Object {
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return nil
+	}
+
+	partial := meta.AsPartialObjectMetadata(metaobj)
+	partial.TypeMeta = metav1.TypeMeta{APIVersion: gv.String(), Kind: obj.GetObjectKind().GroupVersionKind().Kind}
+	if _, ok := partial.Annotations[lastAppliedConfigurationAnnotation]; ok {
+		annotations := make(map[string]string, len(partial.Annotations))
+		for key, value := range partial.Annotations {
+			if key != lastAppliedConfigurationAnnotation {
+				annotations[key] = value
+			}
+		}
+		partial.Annotations = annotations
+	}
+	return partial
+}
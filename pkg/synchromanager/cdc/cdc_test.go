@@ -0,0 +1,211 @@
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestEvent(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetNamespace("default")
+	obj.SetName("nginx")
+	obj.SetResourceVersion("10")
+
+	event := NewEvent(Created, "cluster-1", schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, obj)
+	if key := event.Key(); key != "cluster-1/apps/deployments/default/nginx" {
+		t.Errorf("Key() = %q", key)
+	}
+	if headers := event.Headers(); headers["clusterpedia-cluster"] != "cluster-1" || headers["clusterpedia-event-type"] != "Created" {
+		t.Errorf("unexpected headers %v", headers)
+	}
+
+	data, err := event.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Type            EventType                  `json:"type"`
+		Cluster         string                     `json:"cluster"`
+		Resource        string                     `json:"resource"`
+		ResourceVersion string                     `json:"resourceVersion"`
+		Object          *unstructured.Unstructured `json:"object"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Type != Created || decoded.Cluster != "cluster-1" || decoded.Resource != "deployments" || decoded.ResourceVersion != "10" {
+		t.Errorf("unexpected event %s", data)
+	}
+	if decoded.Object == nil || decoded.Object.GetName() != "nginx" || decoded.Object.GetKind() != "Deployment" {
+		t.Errorf("the object should be encoded in the event, got %s", data)
+	}
+
+	// the cluster scoped objects have no namespace in the key
+	obj.SetNamespace("")
+	if key := NewEvent(Deleted, "cluster-1", schema.GroupVersionResource{Version: "v1", Resource: "nodes"}, obj).Key(); key != "cluster-1/nodes/nginx" {
+		t.Errorf("Key() = %q", key)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		valid   bool
+		sink    string
+	}{
+		{name: "disabled", options: *NewOptions(), valid: true},
+		{name: "kafka", options: Options{Sink: KafkaSink, KafkaBrokers: []string{"kafka:9092"}, KafkaTopic: DefaultTopic}, valid: true, sink: KafkaSink},
+		{name: "kafka without brokers", options: Options{Sink: KafkaSink, KafkaTopic: DefaultTopic}},
+		{name: "nats", options: Options{Sink: NATSSink, NATSURL: "nats://nats:4222", NATSSubject: DefaultTopic}, valid: true, sink: NATSSink},
+		{name: "nats without subject", options: Options{Sink: NATSSink, NATSURL: "nats://nats:4222"}},
+		{name: "unknown sink", options: Options{Sink: "pulsar"}},
+		{name: "invalid metadata only resource", options: Options{Sink: NATSSink, NATSURL: "nats://nats:4222", NATSSubject: DefaultTopic, MetadataOnlyResources: []string{".apps"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := test.options.Validate()
+			if valid := len(errs) == 0; valid != test.valid {
+				t.Fatalf("Validate() = %v, want valid %v", errs, test.valid)
+			}
+			if !test.valid {
+				return
+			}
+
+			config := test.options.Config()
+			if test.sink == "" {
+				if config != nil {
+					t.Errorf("Config() = %v, want nil", config)
+				}
+				return
+			}
+			if config == nil || config.Sink != test.sink {
+				t.Errorf("Config() = %v, want the sink %s", config, test.sink)
+			}
+		})
+	}
+}
+
+func TestNewPublisher(t *testing.T) {
+	publisher, err := NewPublisher(nil)
+	if err != nil || publisher != nil {
+		t.Errorf("NewPublisher(nil) = %v, %v, want nil", publisher, err)
+	}
+
+	// the kafka writer connects to the brokers lazily
+	config := (&Options{Sink: KafkaSink, KafkaBrokers: []string{"127.0.0.1:9092"}, KafkaTopic: DefaultTopic, MetadataOnlyResources: []string{"secrets"}}).Config()
+	publisher, err = NewPublisher(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := publisher.(*metadataOnlyPublisher); !ok {
+		t.Errorf("NewPublisher() = %T, the secrets should be published with only the metadata", publisher)
+	}
+	if err := publisher.Close(); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := NewPublisher(&Config{Sink: "pulsar"}); err == nil {
+		t.Error("NewPublisher() should fail for the unknown sink")
+	}
+}
+
+func TestKafkaPublisherQueueFull(t *testing.T) {
+	// the publisher without the worker keeps the queued events
+	publisher := &kafkaPublisher{queue: make(chan kafka.Message, 1)}
+	event := NewEvent(Created, "cluster-1", schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "settings"},
+	})
+
+	if err := publisher.Publish(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	// the publish doesn't block the synchro when the queue is full
+	if err := publisher.Publish(context.Background(), event); !errors.Is(err, errKafkaQueueFull) {
+		t.Errorf("Publish() error = %v, want %v", err, errKafkaQueueFull)
+	}
+
+	message := <-publisher.queue
+	if string(message.Key) != "cluster-1/configmaps/default/settings" || headerValue(message.Headers, "clusterpedia-cluster") != "cluster-1" {
+		t.Errorf("unexpected message %+v", message)
+	}
+}
+
+type recordingPublisher struct {
+	events []*Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event *Event) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func TestMetadataOnlyPublisher(t *testing.T) {
+	recorder := &recordingPublisher{}
+	publisher := &metadataOnlyPublisher{Publisher: recorder, resources: sets.New(schema.GroupResource{Resource: "secrets"})}
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "token", ResourceVersion: "10",
+			Labels:      map[string]string{"app": "web"},
+			Annotations: map[string]string{lastAppliedConfigurationAnnotation: `{"data":{"token":"c2VjcmV0"}}`, "owner": "web"},
+		},
+		Data: map[string][]byte{"token": []byte("secret")},
+	}
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "settings"},
+		Data:       map[string]string{"mode": "debug"},
+	}
+	if err := publisher.Publish(context.Background(), NewEvent(Created, "cluster-1", corev1.SchemeGroupVersion.WithResource("secrets"), secret)); err != nil {
+		t.Fatal(err)
+	}
+	if err := publisher.Publish(context.Background(), NewEvent(Created, "cluster-1", corev1.SchemeGroupVersion.WithResource("configmaps"), configMap)); err != nil {
+		t.Fatal(err)
+	}
+	if len(recorder.events) != 2 {
+		t.Fatalf("%d events are published, want 2", len(recorder.events))
+	}
+
+	data, err := recorder.events[0].Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Object map[string]interface{} `json:"object"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded.Object["data"]; ok {
+		t.Errorf("the data of the secret is published, got %s", data)
+	}
+	object := &unstructured.Unstructured{Object: decoded.Object}
+	if object.GetKind() != "Secret" || object.GetName() != "token" || object.GetLabels()["app"] != "web" {
+		t.Errorf("the metadata of the secret should be published, got %s", data)
+	}
+	if annotations := object.GetAnnotations(); len(annotations) != 1 || annotations["owner"] != "web" {
+		t.Errorf("the last applied configuration of the secret is published, got %s", data)
+	}
+	if len(secret.Annotations) != 2 || len(secret.Data) != 1 {
+		t.Error("the published secret should not be modified")
+	}
+
+	if recorder.events[1].Object != configMap {
+		t.Error("the objects of the other resources should be published")
+	}
+}
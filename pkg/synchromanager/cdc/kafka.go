@@ -0,0 +1,157 @@
+package cdc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// kafkaQueueSize is the number of the events buffered for the kafka writer,
+	// the events are dropped when the queue is full because the publishes can't block the synchros.
+	kafkaQueueSize = 10000
+	kafkaBatchSize = 100
+
+	// the failed writes are retried with the backoff between kafkaWriteBackoffMin and kafkaWriteBackoffMax,
+	// the events are dropped after kafkaMaxAttempts.
+	kafkaMaxAttempts     = 10
+	kafkaWriteBackoffMin = 100 * time.Millisecond
+	kafkaWriteBackoffMax = 5 * time.Second
+)
+
+var errKafkaQueueFull = errors.New("the kafka queue is full, the change event is dropped")
+
+// kafkaPublisher queues the events and writes them to kafka synchronously in batches by a single worker,
+// so the events are written in order and the failed writes are retried without blocking the synchros.
+//
+// The delivery is at-most-once, the events are dropped when the queue is full, when the write still fails
+// after the retries, or when they are still queued after the publisher is closed.
+// The dropped events are logged and counted by the publish_failures_total metric.
+type kafkaPublisher struct {
+	writer *kafka.Writer
+	topic  string
+
+	queue   chan kafka.Message
+	closing chan struct{}
+	done    chan struct{}
+}
+
+// newKafkaPublisher returns the publisher writing the events to kafka in the background,
+// the events of an object are written to the same partition by the hash of the key.
+func newKafkaPublisher(config KafkaConfig) *kafkaPublisher {
+	p := &kafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:            kafka.TCP(config.Brokers...),
+			Topic:           config.Topic,
+			Balancer:        &kafka.Hash{},
+			RequiredAcks:    kafka.RequireAll,
+			MaxAttempts:     kafkaMaxAttempts,
+			WriteBackoffMin: kafkaWriteBackoffMin,
+			WriteBackoffMax: kafkaWriteBackoffMax,
+			BatchSize:       kafkaBatchSize,
+			BatchTimeout:    10 * time.Millisecond,
+		},
+		topic:   config.Topic,
+		queue:   make(chan kafka.Message, kafkaQueueSize),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+func (p *kafkaPublisher) Publish(_ context.Context, event *Event) error {
+	value, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	message := kafka.Message{Key: []byte(event.Key()), Value: value, Time: event.Time.Time}
+	for key, value := range event.Headers() {
+		message.Headers = append(message.Headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+
+	select {
+	case p.queue <- message:
+		return nil
+	default:
+		publishFailuresTotal.WithLabelValues(KafkaSink, event.Cluster).Inc()
+		return errKafkaQueueFull
+	}
+}
+
+func (p *kafkaPublisher) run() {
+	defer close(p.done)
+	for {
+		select {
+		case message := <-p.queue:
+			p.write(p.batch(message))
+		case <-p.closing:
+			// flush the queued events
+			for {
+				select {
+				case message := <-p.queue:
+					p.write(p.batch(message))
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// batch takes the queued messages after the first message without waiting
+func (p *kafkaPublisher) batch(first kafka.Message) []kafka.Message {
+	messages := []kafka.Message{first}
+	for len(messages) < kafkaBatchSize {
+		select {
+		case message := <-p.queue:
+			messages = append(messages, message)
+		default:
+			return messages
+		}
+	}
+	return messages
+}
+
+// write retries the failed messages by the writer, the messages are dropped if the write still fails
+func (p *kafkaPublisher) write(messages []kafka.Message) {
+	err := p.writer.WriteMessages(context.Background(), messages...)
+	if err == nil {
+		return
+	}
+
+	failed := messages
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) {
+		failed = nil
+		for i, writeErr := range writeErrs {
+			if writeErr != nil {
+				failed = append(failed, messages[i])
+			}
+		}
+	}
+	klog.ErrorS(err, "Failed to publish the change events to kafka", "topic", p.topic, "events", len(failed))
+	for _, message := range failed {
+		publishFailuresTotal.WithLabelValues(KafkaSink, headerValue(message.Headers, "clusterpedia-cluster")).Inc()
+	}
+}
+
+// Close writes the queued events and closes the writer
+func (p *kafkaPublisher) Close() error {
+	close(p.closing)
+	<-p.done
+	return p.writer.Close()
+}
+
+func headerValue(headers []kafka.Header, key string) string {
+	for _, header := range headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
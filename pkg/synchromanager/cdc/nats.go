@@ -0,0 +1,50 @@
+package cdc
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+	"k8s.io/klog/v2"
+)
+
+type natsPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// newNATSPublisher connects to the NATS server, the events are buffered by the connection while it is reconnecting.
+func newNATSPublisher(config NATSConfig) (*natsPublisher, error) {
+	conn, err := nats.Connect(config.URL,
+		nats.Name("clusterpedia-clustersynchro-manager"),
+		nats.MaxReconnects(-1),
+		nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+			klog.ErrorS(err, "NATS connection error", "url", config.URL)
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &natsPublisher{conn: conn, subject: config.Subject}, nil
+}
+
+func (p *natsPublisher) Publish(_ context.Context, event *Event) error {
+	data, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	header := nats.Header{}
+	for key, value := range event.Headers() {
+		header.Set(key, value)
+	}
+	if err := p.conn.PublishMsg(&nats.Msg{Subject: p.subject, Header: header, Data: data}); err != nil {
+		publishFailuresTotal.WithLabelValues(NATSSink, event.Cluster).Inc()
+		return err
+	}
+	return nil
+}
+
+// Close flushes the pending events
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
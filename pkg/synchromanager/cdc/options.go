@@ -0,0 +1,110 @@
+package cdc
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	KafkaSink = "kafka"
+	NATSSink  = "nats"
+
+	DefaultTopic = "clusterpedia.changes"
+)
+
+type Options struct {
+	Sink string
+
+	KafkaBrokers []string
+	KafkaTopic   string
+
+	NATSURL     string
+	NATSSubject string
+
+	MetadataOnlyResources []string
+}
+
+func NewOptions() *Options {
+	return &Options{KafkaTopic: DefaultTopic, NATSSubject: DefaultTopic, MetadataOnlyResources: []string{"secrets"}}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Sink, "cdc-sink", o.Sink, ""+
+		"The sink to which every create, update and delete of the resources saved to the storage is published as a change event, "+
+		"one of kafka and nats, empty disables the change data capture. The events are published after the changes are saved, "+
+		"the failed publishes are logged and don't block the synchronization, so the events are delivered at most once.")
+	fs.StringSliceVar(&o.KafkaBrokers, "cdc-kafka-brokers", o.KafkaBrokers, "The addresses of the Kafka brokers, in the form of '<host>:<port>'")
+	fs.StringVar(&o.KafkaTopic, "cdc-kafka-topic", o.KafkaTopic, "The Kafka topic of the change events, the events of an object have the same key '<cluster>/<group>/<resource>/<namespace>/<name>'")
+	fs.StringVar(&o.NATSURL, "cdc-nats-url", o.NATSURL, "The URL of the NATS server, e.g. nats://127.0.0.1:4222")
+	fs.StringVar(&o.NATSSubject, "cdc-nats-subject", o.NATSSubject, "The NATS subject of the change events")
+	fs.StringSliceVar(&o.MetadataOnlyResources, "cdc-metadata-only-resources", o.MetadataOnlyResources, ""+
+		"The resources in the form of '<resource>[.<group>]' whose change events only carry the metadata of the objects, "+
+		"the resources encrypted at rest by the storage should be set, so their objects are not published in plaintext.")
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+	switch o.Sink {
+	case "":
+	case KafkaSink:
+		if len(o.KafkaBrokers) == 0 {
+			errs = append(errs, fmt.Errorf("cdc-kafka-brokers must be set for the kafka sink"))
+		}
+		if o.KafkaTopic == "" {
+			errs = append(errs, fmt.Errorf("cdc-kafka-topic must be set for the kafka sink"))
+		}
+	case NATSSink:
+		if o.NATSURL == "" {
+			errs = append(errs, fmt.Errorf("cdc-nats-url must be set for the nats sink"))
+		}
+		if o.NATSSubject == "" {
+			errs = append(errs, fmt.Errorf("cdc-nats-subject must be set for the nats sink"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("cdc-sink must be one of kafka and nats"))
+	}
+	for _, resource := range o.MetadataOnlyResources {
+		if schema.ParseGroupResource(resource).Resource == "" {
+			errs = append(errs, fmt.Errorf("cdc-metadata-only-resources: resource is required in %q", resource))
+		}
+	}
+	return errs
+}
+
+// Config returns nil if the sink is not set.
+func (o *Options) Config() *Config {
+	var config *Config
+	switch o.Sink {
+	case KafkaSink:
+		config = &Config{Sink: o.Sink, Kafka: KafkaConfig{Brokers: o.KafkaBrokers, Topic: o.KafkaTopic}}
+	case NATSSink:
+		config = &Config{Sink: o.Sink, NATS: NATSConfig{URL: o.NATSURL, Subject: o.NATSSubject}}
+	default:
+		return nil
+	}
+	for _, resource := range o.MetadataOnlyResources {
+		config.MetadataOnlyResources = append(config.MetadataOnlyResources, schema.ParseGroupResource(resource))
+	}
+	return config
+}
+
+type Config struct {
+	Sink  string
+	Kafka KafkaConfig
+	NATS  NATSConfig
+
+	// MetadataOnlyResources are the resources whose events are published with only the metadata of the objects
+	MetadataOnlyResources []schema.GroupResource
+}
+
+type KafkaConfig struct {
+	Brokers []string
+	Topic   string
+}
+
+type NATSConfig struct {
+	URL     string
+	Subject string
+}
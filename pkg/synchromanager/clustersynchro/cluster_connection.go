@@ -0,0 +1,144 @@
+package clustersynchro
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+)
+
+const (
+	// connectionStatusPeriod is the minimum interval of updating the connection status,
+	// to reduce the cluster status updates.
+	connectionStatusPeriod = time.Minute
+
+	// connectionWindow is the window of the samples summarized into the connection status
+	connectionWindow = 5 * time.Minute
+
+	maxConnectionSamples = 100
+)
+
+// connectionMeasurer measures the round-trip latency and the clock skew from the requests of the health checker.
+type connectionMeasurer struct {
+	cluster string
+	usage   *clusterUsage
+
+	lock    sync.Mutex
+	samples []connectionSample
+	next    int
+}
+
+type connectionSample struct {
+	time    time.Time
+	latency time.Duration
+
+	hasSkew bool
+	skew    time.Duration
+}
+
+func newConnectionMeasurer(cluster string) *connectionMeasurer {
+	return &connectionMeasurer{
+		cluster: cluster,
+		usage:   getClusterUsage(cluster),
+		samples: make([]connectionSample, 0, maxConnectionSamples),
+	}
+}
+
+func (m *connectionMeasurer) wrap(rt http.RoundTripper) http.RoundTripper {
+	return &connectionRoundTripper{measurer: m, rt: rt}
+}
+
+func (m *connectionMeasurer) observe(start time.Time, latency time.Duration, date string) {
+	labels := map[string]string{"cluster": m.cluster}
+	m.usage.track("latency", labels, clusterHealthCheckLatency.Delete)
+	clusterHealthCheckLatency.With(labels).Observe(latency.Seconds())
+
+	sample := connectionSample{time: start, latency: latency}
+	if serverTime, err := http.ParseTime(date); err == nil {
+		// the `Date` header is truncated to the second, so the middle of the second is compared
+		// with the middle of the round trip.
+		sample.hasSkew = true
+		sample.skew = serverTime.Add(500 * time.Millisecond).Sub(start.Add(latency / 2))
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if len(m.samples) < maxConnectionSamples {
+		m.samples = append(m.samples, sample)
+		return
+	}
+	m.samples[m.next] = sample
+	m.next = (m.next + 1) % maxConnectionSamples
+}
+
+// status summarizes the samples within the connection window,
+// returns nil if there are no recent samples.
+func (m *connectionMeasurer) status(now time.Time) *clusterv1alpha2.ClusterConnectionStatus {
+	var latencies, skews []time.Duration
+	m.lock.Lock()
+	for _, sample := range m.samples {
+		if now.Sub(sample.time) > connectionWindow {
+			continue
+		}
+		latencies = append(latencies, sample.latency)
+		if sample.hasSkew {
+			skews = append(skews, sample.skew)
+		}
+	}
+	m.lock.Unlock()
+
+	if len(latencies) == 0 {
+		return nil
+	}
+
+	status := &clusterv1alpha2.ClusterConnectionStatus{
+		LatencyP50:      &metav1.Duration{Duration: percentile(latencies, 0.5).Round(time.Millisecond)},
+		LatencyP90:      &metav1.Duration{Duration: percentile(latencies, 0.9).Round(time.Millisecond)},
+		LatencyP99:      &metav1.Duration{Duration: percentile(latencies, 0.99).Round(time.Millisecond)},
+		LastMeasureTime: metav1.NewTime(now).Rfc3339Copy(),
+	}
+	if len(skews) != 0 {
+		skew := percentile(skews, 0.5)
+		status.ClockSkew = &metav1.Duration{Duration: skew.Round(time.Second)}
+
+		labels := map[string]string{"cluster": m.cluster}
+		m.usage.track("skew", labels, clusterClockSkew.Delete)
+		clusterClockSkew.With(labels).Set(skew.Seconds())
+	}
+	return status
+}
+
+// percentile returns the nearest-rank percentile of the durations, the durations are sorted in place.
+func percentile(durations []time.Duration, q float64) time.Duration {
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	rank := int(math.Ceil(q*float64(len(durations)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return durations[rank]
+}
+
+type connectionRoundTripper struct {
+	measurer *connectionMeasurer
+	rt       http.RoundTripper
+}
+
+func (rt *connectionRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := rt.rt.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	rt.measurer.observe(start, time.Since(start), resp.Header.Get("Date"))
+	return resp, nil
+}
+
+func (rt *connectionRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return rt.rt
+}
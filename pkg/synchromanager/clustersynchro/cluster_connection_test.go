@@ -0,0 +1,80 @@
+package clustersynchro
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestConnectionMeasurer(t *testing.T) {
+	registerClusterUsageMetrics()
+	defer DeleteClusterUsageMetrics("connection-test")
+
+	measurer := newConnectionMeasurer("connection-test")
+	// the `Date` header is truncated to the second, keep now in the middle of the second
+	// so that the measured skew is stable.
+	now := time.Now().Truncate(time.Second).Add(500 * time.Millisecond)
+	if status := measurer.status(now); status != nil {
+		t.Fatalf("expected no connection status without samples, got %v", status)
+	}
+
+	// the cluster clock is ten seconds ahead
+	for i := 1; i <= 10; i++ {
+		start := now.Add(-time.Duration(i) * time.Second)
+		date := start.Add(10 * time.Second).UTC().Format(http.TimeFormat)
+		measurer.observe(start, time.Duration(i)*10*time.Millisecond, date)
+	}
+	// the expired sample is not summarized
+	measurer.observe(now.Add(-2*connectionWindow), time.Second, "")
+
+	status := measurer.status(now)
+	if status == nil {
+		t.Fatal("expected the connection status")
+	}
+	if status.LatencyP50.Duration != 50*time.Millisecond {
+		t.Errorf("expected p50 latency 50ms, got %s", status.LatencyP50.Duration)
+	}
+	if status.LatencyP90.Duration != 90*time.Millisecond {
+		t.Errorf("expected p90 latency 90ms, got %s", status.LatencyP90.Duration)
+	}
+	if status.LatencyP99.Duration != 100*time.Millisecond {
+		t.Errorf("expected p99 latency 100ms, got %s", status.LatencyP99.Duration)
+	}
+	if status.ClockSkew == nil || status.ClockSkew.Duration != 10*time.Second {
+		t.Errorf("expected clock skew 10s, got %v", status.ClockSkew)
+	}
+}
+
+func TestConnectionMeasurerWrap(t *testing.T) {
+	registerClusterUsageMetrics()
+	defer DeleteClusterUsageMetrics("connection-wrap-test")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	measurer := newConnectionMeasurer("connection-wrap-test")
+	config := &rest.Config{Host: server.URL}
+	config.Wrap(measurer.wrap)
+	checker, err := newHealthChecker(config, HealthCheckConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ready, err := checker.Ready(context.TODO()); !ready {
+		t.Fatalf("expected ready, got %v", err)
+	}
+
+	status := measurer.status(time.Now())
+	if status == nil {
+		t.Fatal("expected the connection status")
+	}
+	if status.ClockSkew == nil || status.ClockSkew.Duration != -time.Minute {
+		t.Errorf("expected clock skew -1m, got %v", status.ClockSkew)
+	}
+}
@@ -2,6 +2,7 @@ package clustersynchro
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -40,7 +41,9 @@ func (s *ClusterSynchro) checkClusterHealthy() {
 
 	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
 	defer cancel()
-	if ready, err := s.healthChecker.Ready(ctx); !ready {
+	ready, err := s.healthChecker.Ready(ctx)
+	s.measureConnection()
+	if !ready {
 		// if the last status was not ConditionTrue, stop resource synchros
 		if lastReadyCondition.Status != metav1.ConditionTrue {
 			s.stopRunner()
@@ -55,6 +58,11 @@ func (s *ClusterSynchro) checkClusterHealthy() {
 		if err != nil {
 			condition.Reason = clusterv1alpha2.ClusterNotReachableReason
 			condition.Message = err.Error()
+
+			var probeErr *probeError
+			if errors.As(err, &probeErr) {
+				condition.Reason = probeErr.reason
+			}
 		}
 
 		if lastReadyCondition.Status != condition.Status || lastReadyCondition.Reason != condition.Reason || lastReadyCondition.Message != condition.Message {
@@ -90,22 +98,48 @@ func (s *ClusterSynchro) checkClusterHealthy() {
 	s.healthyCondition.Store(condition)
 }
 
-type healthChecker struct {
-	client rest.Interface
+// measureConnection summarizes the connection measurements of the health checker into the connection status,
+// the status is updated at most once every connectionStatusPeriod.
+func (s *ClusterSynchro) measureConnection() {
+	now := time.Now()
+	if now.Sub(s.lastConnectionMeasure) < connectionStatusPeriod {
+		return
+	}
+
+	// if there are no recent measurements, the last connection status is kept with its measure time
+	if status := s.connectionMeasurer.status(now); status != nil {
+		s.connectionStatus.Store(status)
+		s.lastConnectionMeasure = now
+	}
 }
 
-func newHealthChecker(config *rest.Config) (*healthChecker, error) {
+type healthChecker interface {
+	Ready(ctx context.Context) (bool, error)
+}
+
+func newHealthChecker(config *rest.Config, healthCheck HealthCheckConfig) (healthChecker, error) {
 	client, err := discovery.NewDiscoveryClientForConfig(config)
 	if err != nil {
 		return nil, err
 	}
-	return &healthChecker{
-		client: client.RESTClient(),
-	}, nil
+	checker := &readyzChecker{client: client.RESTClient()}
+
+	switch healthCheck.Strategy {
+	case "", ReadyzHealthCheck:
+		return checker, nil
+	case ResourceProbeHealthCheck:
+		return newResourceProbeChecker(config, checker, healthCheck.ProbeResource)
+	}
+	return nil, fmt.Errorf("unknown health check strategy: %s", healthCheck.Strategy)
+}
+
+// readyzChecker checks the /readyz of the cluster, and falls back to the /healthz
+type readyzChecker struct {
+	client rest.Interface
 }
 
 // TODO(iceber): resolve for more detailed error
-func (checker *healthChecker) Ready(ctx context.Context) (bool, error) {
+func (checker *readyzChecker) Ready(ctx context.Context) (bool, error) {
 	_, err := checker.client.Get().AbsPath("/readyz").DoRaw(ctx)
 	if apierrors.IsNotFound(err) {
 		_, err = checker.client.Get().AbsPath("/healthz").DoRaw(ctx)
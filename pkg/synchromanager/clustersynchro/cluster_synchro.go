@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -16,6 +18,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	metricsstore "k8s.io/kube-state-metrics/v2/pkg/metrics_store"
 
@@ -25,6 +28,7 @@ import (
 	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/informer"
 	resourceconfigfactory "github.com/clusterpedia-io/clusterpedia/pkg/runtime/resourceconfig/factory"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/cdc"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/features"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/resourcesynchro"
 	clusterpediafeature "github.com/clusterpedia-io/clusterpedia/pkg/utils/feature"
@@ -33,6 +37,63 @@ import (
 type ClusterSyncConfig struct {
 	MetricsStoreBuilder     *kubestatemetrics.MetricsStoreBuilder
 	PageSizeForResourceSync int64
+
+	// TerminatedPodsTTL and CompletedJobsTTL are the ttl of the terminated pods and jobs in the storage,
+	// 0 means they are kept until they are deleted from the cluster.
+	TerminatedPodsTTL time.Duration
+	CompletedJobsTTL  time.Duration
+
+	// MassDeletion freezes the deletions of a resource to the storage when the mass deletion is detected
+	MassDeletion resourcesynchro.MassDeletionConfig
+
+	// EventRecorder records the events of the PediaClusters, such as the changes of the synchronized resources.
+	EventRecorder record.EventRecorder
+
+	// StorageTimeout bounds each write of the resource synchros to the storage
+	StorageTimeout time.Duration
+
+	// VerificationRelistInterval is the jittered interval of the full relists of each resource
+	// to repair the divergence from the missed watch events, 0 disables the verification relists.
+	VerificationRelistInterval time.Duration
+
+	// DriftCheck compares the stored objects of the selected resources with the member cluster periodically
+	DriftCheck resourcesynchro.DriftCheckConfig
+
+	HealthCheck HealthCheckConfig
+
+	// Transport tunes the connections to the member clusters to detect the half-open connections faster
+	Transport TransportConfig
+
+	// WatchBookmarkInterval is the interval to save the handled resource versions of the watches as the bookmarks,
+	// the watches are resumed from the bookmarks after the restarts instead of the relists.
+	// It takes effect only if the storage persists the watch bookmarks, 0 disables the bookmarks.
+	WatchBookmarkInterval time.Duration
+
+	// ConfigurationName is the ClusterpediaConfiguration whose settings are applied to Settings at runtime
+	ConfigurationName string
+	// Settings overrides the prune feature gates and the ttl of the terminated objects at runtime,
+	// it is created by the manager if ConfigurationName is set.
+	Settings *resourcesynchro.Settings
+
+	// Publisher publishes the changes of the resources saved to the storage, nil disables the change data capture
+	Publisher cdc.Publisher
+}
+
+// BulkStorageTimeout bounds the storage operations on all the resources of a cluster,
+// such as loading the resource versions and cleaning the resources.
+const BulkStorageTimeout = 5 * time.Minute
+
+// the status of the cluster is updated after the synchro is closed, so it is not bound to the synchro context
+const updateStatusTimeout = 30 * time.Second
+
+func (c ClusterSyncConfig) terminatedTTLFor(gr schema.GroupResource) time.Duration {
+	switch gr {
+	case schema.GroupResource{Resource: "pods"}:
+		return c.TerminatedPodsTTL
+	case schema.GroupResource{Group: "batch", Resource: "jobs"}:
+		return c.CompletedJobsTTL
+	}
+	return 0
 }
 
 type ClusterSynchro struct {
@@ -44,7 +105,8 @@ type ClusterSynchro struct {
 	storage                storage.StorageFactory
 	resourceSynchroFactory resourcesynchro.SynchroFactory
 	syncConfig             ClusterSyncConfig
-	healthChecker          *healthChecker
+	healthChecker          healthChecker
+	connectionMeasurer     *connectionMeasurer
 	dynamicDiscovery       discovery.DynamicDiscoveryInterface
 	listerWatcherFactory   informer.DynamicListerWatcherFactory
 	eventsListerWatcher    cache.ListerWatcher
@@ -53,6 +115,10 @@ type ClusterSynchro struct {
 	closer    chan struct{}
 	closed    chan struct{}
 
+	// ctx is canceled on shutdown to cancel the in-flight storage operations
+	ctx    context.Context
+	cancel context.CancelFunc
+
 	updateStatusCh chan struct{}
 	startRunnerCh  chan struct{}
 	stopRunnerCh   chan struct{}
@@ -66,13 +132,34 @@ type ClusterSynchro struct {
 	storageResourceVersions map[schema.GroupVersionResource]storage.ClusterResourceVersions
 	storageResourceSynchros sync.Map
 
+	// watchBookmarks are the saved bookmarks to resume the watches of the storage resources,
+	// each bookmark is consumed by the first resource synchro of the resource.
+	bookmarkStorage storage.WatchBookmarkStorage
+	watchBookmarks  map[schema.GroupVersionResource]string
+
+	// consistencyChecker coordinates the verification relists of the resource synchros, nil if they are disabled
+	consistencyChecker *resourcesynchro.ConsistencyChecker
+
 	syncResources       atomic.Value // []clusterv1alpha2.ClusterGroupResources
 	setSyncResourcesCh  chan struct{}
 	resourceNegotiator  *ResourceNegotiator
 	groupResourceStatus atomic.Value // *GroupResourceStatus
 
+	// negotiatedGVRs is only accessed by the sync resources refresher
+	negotiatedGVRs      GVRSet
+	negotiatedCondition atomic.Value // metav1.Condition
+
+	deletionConfirmation atomic.Value // string
+	resyncRequest        atomic.Value // string
+	clusterLabels        atomic.Value // map[string]string
+	clusterPrune         atomic.Value // *clusterv1alpha2.ClusterPruneConfiguration
+
 	runningCondition atomic.Value // metav1.Condition
 	healthyCondition atomic.Value // metav1.Condition
+
+	// lastConnectionMeasure is only accessed by the monitor
+	lastConnectionMeasure time.Time
+	connectionStatus      atomic.Value // *clusterv1alpha2.ClusterConnectionStatus
 }
 
 type ClusterStatusUpdater interface {
@@ -82,34 +169,57 @@ type ClusterStatusUpdater interface {
 type RetryableError error
 
 func New(name string, config *rest.Config, storageFactory storage.StorageFactory, updater ClusterStatusUpdater, syncConfig ClusterSyncConfig) (*ClusterSynchro, error) {
-	dynamicDiscovery, err := discovery.NewDynamicDiscoveryManager(name, config)
+	// the original config is kept in the synchro to compare with the latest config of the cluster,
+	// the requests to the cluster are sent by the config with the usage metrics.
+	registerClusterUsageMetrics()
+	clusterConfig := rest.CopyConfig(config)
+	syncConfig.Transport.applyTo(clusterConfig, name)
+	clusterConfig.Wrap(wrapClusterUsage(name))
+
+	dynamicDiscovery, err := discovery.NewDynamicDiscoveryManager(name, clusterConfig)
 	if err != nil {
 		return nil, RetryableError(fmt.Errorf("failed to create dynamic discovery manager: %w", err))
 	}
 
-	resourceversions, err := storageFactory.GetResourceVersions(context.TODO(), name)
+	ctx, cancel := context.WithTimeout(context.Background(), BulkStorageTimeout)
+	resourceversions, err := storageFactory.GetResourceVersions(ctx, name)
+	cancel()
 	if err != nil {
 		return nil, RetryableError(fmt.Errorf("failed to get resource versions from storage: %w", err))
 	}
 
-	listWatchFactory, err := informer.NewDynamicListerWatcherFactory(config)
+	var bookmarkStorage storage.WatchBookmarkStorage
+	var bookmarks map[schema.GroupVersionResource]string
+	if s, ok := storageFactory.(storage.WatchBookmarkStorage); ok && syncConfig.WatchBookmarkInterval > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), BulkStorageTimeout)
+		bookmarks, err = s.GetWatchBookmarks(ctx, name)
+		cancel()
+		if err != nil {
+			return nil, RetryableError(fmt.Errorf("failed to get watch bookmarks from storage: %w", err))
+		}
+		bookmarkStorage = s
+	}
+
+	listWatchFactory, err := informer.NewDynamicListerWatcherFactory(clusterConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create lister watcher factory: %w", err)
 	}
 
-	checkerConfig := *config
+	checkerConfig := *clusterConfig
 	if clusterpediafeature.FeatureGate.Enabled(features.HealthCheckerWithStandaloneTCP) {
 		checkerConfig.Dial = (&net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
 		}).DialContext
 	}
-	healthChecker, err := newHealthChecker(&checkerConfig)
+	connectionMeasurer := newConnectionMeasurer(name)
+	checkerConfig.Wrap(connectionMeasurer.wrap)
+	healthChecker, err := newHealthChecker(&checkerConfig, syncConfig.HealthCheck)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a cluster health checker: %w", err)
 	}
 
-	client, err := kubernetes.NewForConfig(config)
+	client, err := kubernetes.NewForConfig(clusterConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cluster client: %w", err)
 	}
@@ -122,6 +232,7 @@ func New(name string, config *rest.Config, storageFactory storage.StorageFactory
 
 		syncConfig:           syncConfig,
 		healthChecker:        healthChecker,
+		connectionMeasurer:   connectionMeasurer,
 		dynamicDiscovery:     dynamicDiscovery,
 		listerWatcherFactory: listWatchFactory,
 		eventsListerWatcher: &cache.ListWatch{
@@ -141,7 +252,13 @@ func New(name string, config *rest.Config, storageFactory storage.StorageFactory
 		stopRunnerCh:   make(chan struct{}),
 
 		storageResourceVersions: make(map[schema.GroupVersionResource]storage.ClusterResourceVersions),
+		bookmarkStorage:         bookmarkStorage,
+		watchBookmarks:          bookmarks,
+	}
+	if syncConfig.VerificationRelistInterval > 0 || syncConfig.DriftCheck.Enabled() {
+		synchro.consistencyChecker = resourcesynchro.NewConsistencyChecker()
 	}
+	synchro.ctx, synchro.cancel = context.WithCancel(context.Background())
 
 	if factory, ok := storageFactory.(resourcesynchro.SynchroFactory); ok {
 		synchro.resourceSynchroFactory = factory
@@ -164,8 +281,10 @@ func New(name string, config *rest.Config, storageFactory storage.StorageFactory
 		name:                  name,
 		resourceConfigFactory: resourceconfigfactory.New(),
 		dynamicDiscovery:      synchro.dynamicDiscovery,
+		settings:              syncConfig.Settings,
 	}
 	synchro.groupResourceStatus.Store((*GroupResourceStatus)(nil))
+	synchro.negotiatedCondition.Store(metav1.Condition{})
 
 	synchro.syncResources.Store([]clusterv1alpha2.ClusterGroupResources(nil))
 	synchro.setSyncResourcesCh = make(chan struct{}, 1)
@@ -237,7 +356,10 @@ func (s *ClusterSynchro) Run(shutdown <-chan struct{}) {
 
 		for range s.updateStatusCh {
 			status := s.genClusterStatus()
-			if err := s.ClusterStatusUpdater.UpdateClusterStatus(context.TODO(), s.name, status); err != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), updateStatusTimeout)
+			err := s.ClusterStatusUpdater.UpdateClusterStatus(ctx, s.name, status)
+			cancel()
+			if err != nil {
 				klog.ErrorS(err, "Failed to update cluster conditions and sync resources status", "cluster", s.name, "conditions", status.Conditions)
 			}
 		}
@@ -256,6 +378,7 @@ func (s *ClusterSynchro) Shutdown(updateStatus bool) {
 	s.closeOnce.Do(func() {
 		klog.InfoS("cluster synchro is shutdowning...", "cluster", s.name)
 		close(s.closer)
+		s.cancel()
 
 		go func() {
 			timer := time.NewTicker(15 * time.Second)
@@ -311,9 +434,10 @@ func (s *ClusterSynchro) Shutdown(updateStatus bool) {
 	<-s.closed
 }
 
-func (s *ClusterSynchro) SetResources(syncResources []clusterv1alpha2.ClusterGroupResources, syncAllCustomResources bool) {
+func (s *ClusterSynchro) SetResources(syncResources []clusterv1alpha2.ClusterGroupResources, syncAllCustomResources, syncCustomResourceReferences bool) {
 	s.syncResources.Store(syncResources)
 	s.resourceNegotiator.SetSyncAllCustomResources(syncAllCustomResources)
+	s.resourceNegotiator.SetSyncCustomResourceReferences(syncCustomResourceReferences)
 
 	s.resetSyncResources()
 }
@@ -348,7 +472,8 @@ func (s *ClusterSynchro) refreshSyncResources() {
 	if syncResources == nil {
 		return
 	}
-	groupResourceStatus, storageResourceSyncConfigs := s.resourceNegotiator.NegotiateSyncResources(syncResources)
+	groupResourceStatus, storageResourceSyncConfigs, skipped := s.resourceNegotiator.NegotiateSyncResources(syncResources)
+	s.reportNegotiation(groupResourceStatus.GetGVRs(), skipped)
 
 	lastGroupResourceStatus := s.groupResourceStatus.Load().(*GroupResourceStatus)
 	deleted := groupResourceStatus.Merge(lastGroupResourceStatus)
@@ -395,6 +520,25 @@ func (s *ClusterSynchro) refreshSyncResources() {
 			if s.syncConfig.MetricsStoreBuilder != nil {
 				metricsStore = s.syncConfig.MetricsStoreBuilder.GetMetricStore(s.name, config.syncResource)
 			}
+			var driftCheck resourcesynchro.DriftCheckConfig
+			if s.syncConfig.DriftCheck.Checks(config.syncResource.GroupResource()) {
+				driftCheck = s.syncConfig.DriftCheck
+			}
+			var watchBookmark *resourcesynchro.WatchBookmarkConfig
+			if s.bookmarkStorage != nil {
+				gvr := storageGVR
+				watchBookmark = &resourcesynchro.WatchBookmarkConfig{
+					Interval: s.syncConfig.WatchBookmarkInterval,
+					Save: func(ctx context.Context, resourceVersion string) error {
+						return s.bookmarkStorage.SaveWatchBookmark(ctx, s.name, gvr, resourceVersion)
+					},
+				}
+				// the bookmark is only valid with the stored resources of the resource
+				if ok {
+					watchBookmark.ResumeResourceVersion = s.watchBookmarks[storageGVR]
+				}
+				delete(s.watchBookmarks, storageGVR)
+			}
 			var eventConfig *resourcesynchro.EventConfig
 			if config.syncEvents {
 				eventConfig = &resourcesynchro.EventConfig{
@@ -413,6 +557,19 @@ func (s *ClusterSynchro) refreshSyncResources() {
 					PageSizeForInformer:  s.syncConfig.PageSizeForResourceSync,
 					ResourceStorage:      resourceStorage,
 					Event:                eventConfig,
+					SkipOwnerKinds:       config.skipOwnerKinds,
+					TerminatedTTL:        s.syncConfig.terminatedTTLFor(config.syncResource.GroupResource()),
+					Settings:             s.syncConfig.Settings,
+					ClusterLabels:        s.ClusterLabels,
+					ClusterPrune:         s.ClusterPrune,
+					MassDeletion:         s.syncConfig.MassDeletion,
+					StorageTimeout:       s.syncConfig.StorageTimeout,
+
+					VerificationRelistInterval: s.syncConfig.VerificationRelistInterval,
+					ConsistencyChecker:         s.consistencyChecker,
+					DriftCheck:                 driftCheck,
+					Publisher:                  s.syncConfig.Publisher,
+					WatchBookmark:              watchBookmark,
 				},
 			)
 			if err != nil {
@@ -467,8 +624,11 @@ func (s *ClusterSynchro) refreshSyncResources() {
 
 		// Whether the storage resource is cleaned successfully or not, it needs to be deleted from `s.storageResourceVersions`
 		delete(s.storageResourceVersions, storageGVR)
+		delete(s.watchBookmarks, storageGVR)
 
-		err := s.storage.CleanClusterResource(context.TODO(), s.name, storageGVR)
+		ctx, cancel := context.WithTimeout(s.ctx, BulkStorageTimeout)
+		err := s.storage.CleanClusterResource(ctx, s.name, storageGVR)
+		cancel()
 		if err == nil {
 			continue
 		}
@@ -577,6 +737,12 @@ func (s *ClusterSynchro) genClusterStatus() *clusterv1alpha2.ClusterStatus {
 			s.healthyCondition.Load().(metav1.Condition),
 		},
 	}
+	if condition := s.negotiatedCondition.Load().(metav1.Condition); condition.Type != "" {
+		status.Conditions = append(status.Conditions, condition)
+	}
+	if connection, _ := s.connectionStatus.Load().(*clusterv1alpha2.ClusterConnectionStatus); connection != nil {
+		status.Connection = connection.DeepCopy()
+	}
 
 	groupResourceStatuses := s.groupResourceStatus.Load().(*GroupResourceStatus)
 	if groupResourceStatuses == nil {
@@ -623,5 +789,153 @@ func (s *ClusterSynchro) genClusterStatus() *clusterv1alpha2.ClusterStatus {
 		}
 	}
 	status.SyncResources = statuses
+	if s.syncConfig.MassDeletion.Enabled() {
+		status.Conditions = append(status.Conditions, deletionsFrozenCondition(statuses))
+	}
 	return status
 }
+
+func deletionsFrozenCondition(statuses []clusterv1alpha2.ClusterGroupResourcesStatus) metav1.Condition {
+	var frozen []string
+	for _, status := range statuses {
+		for _, resource := range status.Resources {
+			for _, cond := range resource.SyncConditions {
+				if cond.Reason == resourcesynchro.DeletionsFrozenReason {
+					frozen = append(frozen, schema.GroupVersion{Group: status.Group, Version: cond.Version}.String()+"/"+resource.Name)
+				}
+			}
+		}
+	}
+
+	if len(frozen) == 0 {
+		return metav1.Condition{
+			Type:               clusterv1alpha2.DeletionsFrozenCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             clusterv1alpha2.DeletionsNotFrozenReason,
+			LastTransitionTime: metav1.Now().Rfc3339Copy(),
+		}
+	}
+	return metav1.Condition{
+		Type:   clusterv1alpha2.DeletionsFrozenCondition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1alpha2.MassDeletionDetectedReason,
+		Message: fmt.Sprintf("the deletions of %s are frozen, confirm them by updating the annotation %s",
+			strings.Join(frozen, ", "), clusterv1alpha2.ConfirmDeletionsAnnotation),
+		LastTransitionTime: metav1.Now().Rfc3339Copy(),
+	}
+}
+
+// SetDeletionConfirmation confirms the frozen deletions of the resources when the confirmation is changed,
+// the first confirmation is only recorded.
+func (s *ClusterSynchro) SetDeletionConfirmation(confirmation string) {
+	last := s.deletionConfirmation.Swap(confirmation)
+	if last == nil || last.(string) == confirmation || confirmation == "" {
+		return
+	}
+
+	s.storageResourceSynchros.Range(func(_, value interface{}) bool {
+		if freezer, ok := value.(resourcesynchro.DeletionFreezer); ok {
+			freezer.ConfirmDeletions()
+		}
+		return true
+	})
+	s.updateStatus()
+}
+
+// SetResyncRequest resyncs the requested resource when the request is changed, the first request is only recorded,
+// so the requests are not resynced again after the restarts.
+func (s *ClusterSynchro) SetResyncRequest(value string) {
+	last := s.resyncRequest.Swap(value)
+	if last == nil || last.(string) == value || value == "" {
+		return
+	}
+
+	request, err := resourcesynchro.ParseResyncRequest(value)
+	if err != nil {
+		klog.ErrorS(err, "Invalid resync request", "cluster", s.name, "annotation", clusterv1alpha2.ResyncAnnotation)
+		return
+	}
+
+	var resynced bool
+	s.storageResourceSynchros.Range(func(_, value interface{}) bool {
+		synchro := value.(resourcesynchro.Synchro)
+		if synchro.GroupVersionResource().GroupResource() != request.Resource && synchro.StoragedGroupVersionResource().GroupResource() != request.Resource {
+			return true
+		}
+		if resyncer, ok := synchro.(resourcesynchro.Resyncer); ok {
+			resyncer.Resync(request.Namespace)
+			resynced = true
+		}
+		return true
+	})
+	if !resynced {
+		klog.InfoS("The requested resource isn't synchronized, skip the resync", "cluster", s.name, "resource", request.Resource)
+		return
+	}
+	klog.InfoS("Resync the resource", "cluster", s.name, "resource", request.Resource, "namespace", request.Namespace)
+}
+
+// SetClusterLabels sets the labels of the cluster, which are stamped on the objects when they are synchronized again.
+func (s *ClusterSynchro) SetClusterLabels(labels map[string]string) {
+	s.clusterLabels.Store(labels)
+}
+
+func (s *ClusterSynchro) ClusterLabels() map[string]string {
+	labels, _ := s.clusterLabels.Load().(map[string]string)
+	return labels
+}
+
+// SetClusterPrune sets the prune configuration of the cluster, which applies to the objects when they are synchronized again.
+func (s *ClusterSynchro) SetClusterPrune(prune *clusterv1alpha2.ClusterPruneConfiguration) {
+	s.clusterPrune.Store(prune)
+}
+
+func (s *ClusterSynchro) ClusterPrune() *clusterv1alpha2.ClusterPruneConfiguration {
+	prune, _ := s.clusterPrune.Load().(*clusterv1alpha2.ClusterPruneConfiguration)
+	return prune
+}
+
+// reportNegotiation records the diff of the synchronized resources in the condition and the event,
+// so that the resources don't silently disappear from the sync, e.g. after the CRD is deleted.
+func (s *ClusterSynchro) reportNegotiation(negotiated GVRSet, skipped map[schema.GroupResource]string) {
+	last := s.negotiatedGVRs
+	s.negotiatedGVRs = negotiated
+	if last == nil {
+		s.negotiatedCondition.Store(metav1.Condition{
+			Type:               clusterv1alpha2.SyncResourcesNegotiatedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             clusterv1alpha2.SyncResourcesNegotiatedReason,
+			Message:            fmt.Sprintf("%d resources are negotiated to sync", len(negotiated)),
+			LastTransitionTime: metav1.Now().Rfc3339Copy(),
+		})
+		return
+	}
+
+	report := diffNegotiation(last, negotiated, skipped, s.dynamicDiscovery.GetAPIResourceAndVersions)
+	if report.Empty() {
+		return
+	}
+	klog.InfoS("sync resources are changed", "cluster", s.name, "added", report.Added, "removed", report.Removed)
+
+	message := report.String()
+	s.negotiatedCondition.Store(metav1.Condition{
+		Type:               clusterv1alpha2.SyncResourcesNegotiatedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             clusterv1alpha2.SyncResourcesChangedReason,
+		Message:            message,
+		LastTransitionTime: metav1.Now().Rfc3339Copy(),
+	})
+	if recorder := s.syncConfig.EventRecorder; recorder != nil {
+		eventType := corev1.EventTypeNormal
+		if len(report.Removed) != 0 {
+			eventType = corev1.EventTypeWarning
+		}
+		ref := &corev1.ObjectReference{
+			APIVersion: clusterv1alpha2.SchemeGroupVersion.String(),
+			Kind:       "PediaCluster",
+			Name:       s.name,
+		}
+		recorder.Event(ref, eventType, clusterv1alpha2.SyncResourcesChangedReason, message)
+	}
+	s.updateStatus()
+}
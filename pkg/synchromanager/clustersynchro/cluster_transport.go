@@ -0,0 +1,163 @@
+package clustersynchro
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// TransportConfig tunes the connections to the member clusters, the zero values keep the defaults of client-go.
+//
+// The half-open connections through the load balancers, which drop the connections silently,
+// are detected by the HTTP/2 health check pings and the TCP keepalive probes,
+// and the watches on such connections are closed by the WatchIdleTimeout.
+type TransportConfig struct {
+	// HTTP2ReadIdleTimeout is the idle time after which a health check ping is sent on the HTTP/2 connection
+	HTTP2ReadIdleTimeout time.Duration
+	// HTTP2PingTimeout is the time after which the HTTP/2 connection is closed if the ping is not answered
+	HTTP2PingTimeout time.Duration
+
+	// IdleConnTimeout is the max time an idle connection is kept in the connection pool
+	IdleConnTimeout time.Duration
+
+	// TCPKeepAliveIdle is the idle time before the first TCP keepalive probe,
+	// TCPKeepAliveInterval is the interval of the probes, and the connection is closed after TCPKeepAliveCount unanswered probes.
+	TCPKeepAliveIdle     time.Duration
+	TCPKeepAliveInterval time.Duration
+	TCPKeepAliveCount    int
+
+	// WatchIdleTimeout closes the watches which receive nothing, neither the events nor the bookmarks,
+	// within the timeout, the informers restart the closed watches. 0 disables it.
+	WatchIdleTimeout time.Duration
+}
+
+// applyTo tunes the transports of the config, it must be applied before the other wrappers,
+// so that the tuned transport is the one created by client-go.
+// The TCP keepalive is set by the dialer of the config, the dialer set by the caller is kept.
+func (c TransportConfig) applyTo(config *rest.Config, cluster string) {
+	if (c.TCPKeepAliveIdle > 0 || c.TCPKeepAliveInterval > 0 || c.TCPKeepAliveCount > 0) && config.Dial == nil {
+		config.Dial = (&net.Dialer{
+			Timeout: 30 * time.Second,
+			KeepAliveConfig: net.KeepAliveConfig{
+				Enable:   true,
+				Idle:     c.TCPKeepAliveIdle,
+				Interval: c.TCPKeepAliveInterval,
+				Count:    c.TCPKeepAliveCount,
+			},
+		}).DialContext
+	}
+	if c.HTTP2ReadIdleTimeout > 0 || c.HTTP2PingTimeout > 0 || c.IdleConnTimeout > 0 {
+		config.Wrap(c.tuneTransport)
+	}
+	if c.WatchIdleTimeout > 0 {
+		usage := getClusterUsage(cluster)
+		config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return &watchIdleTimeoutRoundTripper{timeout: c.WatchIdleTimeout, usage: usage, rt: rt}
+		})
+	}
+}
+
+// tuneTransport clones the transport created by client-go with the tuned settings,
+// the transport may be shared by the clients of the same tls config, so it is not modified.
+func (c TransportConfig) tuneTransport(rt http.RoundTripper) http.RoundTripper {
+	base, ok := rt.(*http.Transport)
+	if !ok {
+		klog.V(2).InfoS("The transport to the member cluster is not tuned, it is not created by client-go", "type", rt)
+		return rt
+	}
+	_, http2Enabled := base.TLSNextProto["h2"]
+
+	transport := base.Clone()
+	transport.TLSNextProto = nil
+	if c.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = c.IdleConnTimeout
+	}
+	if !http2Enabled {
+		return transport
+	}
+
+	h2, err := http2.ConfigureTransports(transport)
+	if err != nil {
+		klog.ErrorS(err, "Failed to configure HTTP/2 of the transport to the member cluster, the transport is not tuned")
+		return rt
+	}
+	// the defaults of client-go
+	h2.ReadIdleTimeout, h2.PingTimeout = 30*time.Second, 15*time.Second
+	if c.HTTP2ReadIdleTimeout > 0 {
+		h2.ReadIdleTimeout = c.HTTP2ReadIdleTimeout
+	}
+	if c.HTTP2PingTimeout > 0 {
+		h2.PingTimeout = c.HTTP2PingTimeout
+	}
+	return transport
+}
+
+type watchIdleTimeoutRoundTripper struct {
+	timeout time.Duration
+	usage   *clusterUsage
+	rt      http.RoundTripper
+}
+
+func (rt *watchIdleTimeoutRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.rt.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	verb, resource := requestVerbAndResource(req)
+	if verb != "watch" {
+		return resp, nil
+	}
+
+	body := &idleTimeoutBody{ReadCloser: resp.Body, timeout: rt.timeout}
+	body.timer = time.AfterFunc(rt.timeout, func() {
+		klog.InfoS("Close the watch which receives nothing within the idle timeout, the connection may be half-open",
+			"cluster", rt.usage.cluster, "resource", resource, "timeout", rt.timeout)
+		rt.usage.idleWatchTimeouts(resource).Inc()
+		_ = body.close()
+	})
+	resp.Body = body
+	return resp, nil
+}
+
+func (rt *watchIdleTimeoutRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return rt.rt
+}
+
+// idleTimeoutBody closes the body if nothing is read within the timeout,
+// closing the body unblocks the read of the watch on the half-open connection.
+type idleTimeoutBody struct {
+	io.ReadCloser
+
+	timeout   time.Duration
+	timer     *time.Timer
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func (b *idleTimeoutBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.timer.Reset(b.timeout)
+	}
+	return n, err
+}
+
+func (b *idleTimeoutBody) Close() error {
+	b.timer.Stop()
+	return b.close()
+}
+
+// close is also called by the timer, so it doesn't touch the timer.
+func (b *idleTimeoutBody) close() error {
+	b.closeOnce.Do(func() {
+		b.closeErr = b.ReadCloser.Close()
+	})
+	return b.closeErr
+}
@@ -0,0 +1,87 @@
+package clustersynchro
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestWatchIdleTimeout(t *testing.T) {
+	registerClusterUsageMetrics()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// the watch on a half-open connection receives nothing
+		w.(http.Flusher).Flush()
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+
+	config := &rest.Config{Host: server.URL}
+	TransportConfig{WatchIdleTimeout: 100 * time.Millisecond}.applyTo(config, "cluster-idle")
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := client.CoreV1().Pods("").Watch(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Stop()
+
+	select {
+	case _, ok := <-watcher.ResultChan():
+		if ok {
+			t.Fatal("unexpected event")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the idle watch is not closed")
+	}
+	timeouts, err := testutil.GetCounterMetricValue(clusterIdleWatchTimeoutsTotal.WithLabelValues("cluster-idle", "pods"))
+	if err != nil || timeouts != 1 {
+		t.Errorf("idle watch timeouts = %v, %v, want 1", timeouts, err)
+	}
+	DeleteClusterUsageMetrics("cluster-idle")
+}
+
+func TestTuneTransport(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(req.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	// the transport is created by client-go in the same way
+	base := utilnet.SetTransportDefaults(&http.Transport{
+		TLSClientConfig: server.Client().Transport.(*http.Transport).TLSClientConfig,
+	})
+
+	config := TransportConfig{IdleConnTimeout: time.Minute, HTTP2ReadIdleTimeout: 10 * time.Second}
+	rt := config.tuneTransport(base)
+	transport, ok := rt.(*http.Transport)
+	if !ok || transport == base {
+		t.Fatalf("the transport is not cloned")
+	}
+	if transport.IdleConnTimeout != time.Minute {
+		t.Errorf("IdleConnTimeout = %v, want 1m", transport.IdleConnTimeout)
+	}
+
+	resp, err := (&http.Client{Transport: rt}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.ProtoMajor != 2 {
+		t.Errorf("proto = %s, the tuned transport must keep HTTP/2", resp.Proto)
+	}
+}
@@ -0,0 +1,246 @@
+package clustersynchro
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	genericrequest "k8s.io/apiserver/pkg/endpoints/request"
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+// The usage metrics record the load imposed on the member clusters by the clustersynchro manager,
+// including the requests of the informers, the discovery and the health checker,
+// and the connection measurements of the health checker.
+
+const usageSubsystem = "member_cluster"
+
+var (
+	// clusterRequestsTotal records the number of requests sent to the member clusters.
+	clusterRequestsTotal = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      usageSubsystem,
+			Name:           "requests_total",
+			Help:           "Number of requests sent to the member cluster, partitioned by verb, resource and HTTP code.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"cluster", "verb", "resource", "code"},
+	)
+
+	// clusterResponseBytesTotal records the number of bytes received from the member clusters.
+	clusterResponseBytesTotal = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      usageSubsystem,
+			Name:           "response_bytes_total",
+			Help:           "Number of bytes received from the member cluster, partitioned by verb and resource.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"cluster", "verb", "resource"},
+	)
+
+	// clusterWatches records the number of watches opened to the member clusters.
+	clusterWatches = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Namespace:      namespace,
+			Subsystem:      usageSubsystem,
+			Name:           "watches",
+			Help:           "Number of watches currently opened to the member cluster, partitioned by resource.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"cluster", "resource"},
+	)
+
+	// clusterIdleWatchTimeoutsTotal records the number of watches closed by the watch idle timeout.
+	clusterIdleWatchTimeoutsTotal = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      usageSubsystem,
+			Name:           "idle_watch_timeouts_total",
+			Help:           "Number of watches to the member cluster closed because nothing was received within the watch idle timeout, partitioned by resource.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"cluster", "resource"},
+	)
+
+	// clusterHealthCheckLatency records the round-trip latency of the health check requests to the member clusters.
+	clusterHealthCheckLatency = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Namespace:      namespace,
+			Subsystem:      usageSubsystem,
+			Name:           "health_check_latency_seconds",
+			Help:           "Round-trip latency of the health check requests to the member cluster.",
+			Buckets:        []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"cluster"},
+	)
+
+	// clusterClockSkew records the estimated clock skew of the member clusters.
+	clusterClockSkew = compbasemetrics.NewGaugeVec(
+		&compbasemetrics.GaugeOpts{
+			Namespace:      namespace,
+			Subsystem:      usageSubsystem,
+			Name:           "clock_skew_seconds",
+			Help:           "Estimated offset of the member cluster clock from the local clock, positive if the member cluster clock is ahead.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"cluster"},
+	)
+)
+
+var registerUsageOnce sync.Once
+
+func registerClusterUsageMetrics() {
+	registerUsageOnce.Do(func() {
+		legacyregistry.MustRegister(clusterRequestsTotal)
+		legacyregistry.MustRegister(clusterResponseBytesTotal)
+		legacyregistry.MustRegister(clusterWatches)
+		legacyregistry.MustRegister(clusterIdleWatchTimeoutsTotal)
+		legacyregistry.MustRegister(clusterHealthCheckLatency)
+		legacyregistry.MustRegister(clusterClockSkew)
+	})
+}
+
+var requestInfoFactory = &genericrequest.RequestInfoFactory{
+	APIPrefixes:          sets.NewString("api", "apis"),
+	GrouplessAPIPrefixes: sets.NewString("api"),
+}
+
+// clusterUsages records the label values used by each cluster, so the metrics can be deleted with the cluster.
+var clusterUsages sync.Map // cluster name -> *clusterUsage
+
+type clusterUsage struct {
+	cluster string
+
+	lock    sync.Mutex
+	deletes map[string]func()
+}
+
+func getClusterUsage(cluster string) *clusterUsage {
+	usage, _ := clusterUsages.LoadOrStore(cluster, &clusterUsage{cluster: cluster, deletes: make(map[string]func())})
+	return usage.(*clusterUsage)
+}
+
+func (u *clusterUsage) track(name string, labels map[string]string, deleteFunc func(map[string]string) bool) {
+	key := name + "/" + labels["verb"] + "/" + labels["resource"] + "/" + labels["code"]
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	if _, ok := u.deletes[key]; !ok {
+		u.deletes[key] = func() { deleteFunc(labels) }
+	}
+}
+
+func (u *clusterUsage) request(verb, resource, code string) {
+	labels := map[string]string{"cluster": u.cluster, "verb": verb, "resource": resource, "code": code}
+	u.track("requests", labels, clusterRequestsTotal.Delete)
+	clusterRequestsTotal.With(labels).Inc()
+}
+
+func (u *clusterUsage) responseBytes(verb, resource string) compbasemetrics.CounterMetric {
+	labels := map[string]string{"cluster": u.cluster, "verb": verb, "resource": resource}
+	u.track("bytes", labels, clusterResponseBytesTotal.Delete)
+	return clusterResponseBytesTotal.With(labels)
+}
+
+func (u *clusterUsage) watches(resource string) compbasemetrics.GaugeMetric {
+	labels := map[string]string{"cluster": u.cluster, "resource": resource}
+	u.track("watches", labels, clusterWatches.Delete)
+	return clusterWatches.With(labels)
+}
+
+func (u *clusterUsage) idleWatchTimeouts(resource string) compbasemetrics.CounterMetric {
+	labels := map[string]string{"cluster": u.cluster, "resource": resource}
+	u.track("idle-watch-timeouts", labels, clusterIdleWatchTimeoutsTotal.Delete)
+	return clusterIdleWatchTimeoutsTotal.With(labels)
+}
+
+// DeleteClusterUsageMetrics deletes the usage metrics of the removed cluster.
+func DeleteClusterUsageMetrics(cluster string) {
+	usage, ok := clusterUsages.LoadAndDelete(cluster)
+	if !ok {
+		return
+	}
+
+	u := usage.(*clusterUsage)
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	for _, deleteFunc := range u.deletes {
+		deleteFunc()
+	}
+}
+
+// usageRoundTripper records the requests and the received bytes of the member cluster.
+type usageRoundTripper struct {
+	usage *clusterUsage
+	rt    http.RoundTripper
+}
+
+func wrapClusterUsage(cluster string) func(http.RoundTripper) http.RoundTripper {
+	usage := getClusterUsage(cluster)
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &usageRoundTripper{usage: usage, rt: rt}
+	}
+}
+
+func (rt *usageRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	verb, resource := requestVerbAndResource(req)
+
+	resp, err := rt.rt.RoundTrip(req)
+	if err != nil {
+		rt.usage.request(verb, resource, "<error>")
+		return resp, err
+	}
+	rt.usage.request(verb, resource, strconv.Itoa(resp.StatusCode))
+
+	body := &usageBody{ReadCloser: resp.Body, bytes: rt.usage.responseBytes(verb, resource)}
+	if verb == "watch" && resp.StatusCode == http.StatusOK {
+		watches := rt.usage.watches(resource)
+		watches.Inc()
+		body.onClose = watches.Dec
+	}
+	resp.Body = body
+	return resp, nil
+}
+
+func (rt *usageRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return rt.rt
+}
+
+func requestVerbAndResource(req *http.Request) (string, string) {
+	info, err := requestInfoFactory.NewRequestInfo(req)
+	if err != nil || !info.IsResourceRequest {
+		return strings.ToLower(req.Method), ""
+	}
+	return info.Verb, schema.GroupResource{Group: info.APIGroup, Resource: info.Resource}.String()
+}
+
+type usageBody struct {
+	io.ReadCloser
+
+	bytes     compbasemetrics.CounterMetric
+	onClose   func()
+	closeOnce sync.Once
+}
+
+func (b *usageBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.bytes.Add(float64(n))
+	}
+	return n, err
+}
+
+func (b *usageBody) Close() error {
+	if b.onClose != nil {
+		b.closeOnce.Do(b.onClose)
+	}
+	return b.ReadCloser.Close()
+}
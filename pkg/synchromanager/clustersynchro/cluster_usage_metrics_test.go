@@ -0,0 +1,63 @@
+package clustersynchro
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestClusterUsageMetrics(t *testing.T) {
+	registerClusterUsageMetrics()
+
+	podList := `{"kind":"PodList","apiVersion":"v1","metadata":{"resourceVersion":"1"},"items":[]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Query().Get("watch") == "true" {
+			w.(http.Flusher).Flush()
+			<-req.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte(podList))
+	}))
+	defer server.Close()
+
+	config := &rest.Config{Host: server.URL}
+	config.Wrap(wrapClusterUsage("cluster-1"))
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.CoreV1().Pods("").List(context.TODO(), metav1.ListOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	requests, err := testutil.GetCounterMetricValue(clusterRequestsTotal.WithLabelValues("cluster-1", "list", "pods", "200"))
+	if err != nil || requests != 1 {
+		t.Errorf("requests = %v, %v, want 1", requests, err)
+	}
+	bytes, err := testutil.GetCounterMetricValue(clusterResponseBytesTotal.WithLabelValues("cluster-1", "list", "pods"))
+	if err != nil || bytes != float64(len(podList)) {
+		t.Errorf("response bytes = %v, %v, want %d", bytes, err, len(podList))
+	}
+
+	watcher, err := client.CoreV1().Pods("").Watch(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if watches, _ := testutil.GetGaugeMetricValue(clusterWatches.WithLabelValues("cluster-1", "pods")); watches != 1 {
+		t.Errorf("watches = %v, want 1", watches)
+	}
+	watcher.Stop()
+	if watches, _ := testutil.GetGaugeMetricValue(clusterWatches.WithLabelValues("cluster-1", "pods")); watches != 0 {
+		t.Errorf("watches = %v after the watch is stopped, want 0", watches)
+	}
+
+	DeleteClusterUsageMetrics("cluster-1")
+	testutil.AssertVectorCount(t, "clustersynchro_member_cluster_requests_total", map[string]string{"cluster": "cluster-1"}, 0)
+}
@@ -2,7 +2,10 @@ package clustersynchro
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"hash/fnv"
 	"maps"
 	"sync"
 	"sync/atomic"
@@ -16,7 +19,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
-	genericstorage "k8s.io/apiserver/pkg/storage"
 	"k8s.io/client-go/tools/cache"
 	compbasemetrics "k8s.io/component-base/metrics"
 	"k8s.io/klog/v2"
@@ -25,6 +27,7 @@ import (
 	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
 	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/informer"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/cdc"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/features"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/resourcesynchro"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/resourcesynchro/queue"
@@ -50,6 +53,44 @@ type resourceSynchro struct {
 	rvs     map[string]interface{}
 	rvsLock sync.Mutex
 
+	// fieldHashes are the hashes of the top-level fields of the stored objects, which are guarded by the rvsLock,
+	// the changed fields of the updated objects are patched to the storage, nil if the patch is disabled.
+	fieldHashes map[string]map[string]uint64
+
+	skipOwnerKinds resourcesynchro.OwnerKinds
+
+	// the terminated objects are deleted from the storage after the ttl,
+	// terminatedTTL is used if the ttl is not set by the settings
+	supportsTerminatedTTL bool
+	terminatedTTL         time.Duration
+	settings              *resourcesynchro.Settings
+	clusterLabels         func() map[string]string
+	clusterPrune          func() *clusterv1alpha2.ClusterPruneConfiguration
+	expirationsLock       sync.Mutex
+	expirations           map[string]time.Time
+
+	// the deletions are held by the guard when the mass deletion is detected, nil if the guard is disabled
+	massDeletion *resourcesynchro.MassDeletionGuard
+
+	// the informer is restarted by the verification relist to fully relist the resource,
+	// relist is closed to stop the running informer, and relistDone is called after the restarted informer is synced.
+	relistInterval time.Duration
+	consistency    *resourcesynchro.ConsistencyChecker
+	relistLock     sync.Mutex
+	relist         chan struct{}
+	relistDone     func()
+
+	// the stored objects are compared with the member cluster by the drift checks,
+	// the drifts are repaired by the verification relist if the repair is enabled.
+	driftCheck resourcesynchro.DriftCheckConfig
+
+	// the handled resource version of the watch is saved as the bookmark periodically,
+	// the first informer resumes the watch from the bookmark saved before the restart.
+	// skipBookmarks is set when the events are dropped, the bookmarks are not saved until the resource is relisted.
+	resumeResourceVersion string
+	bookmark              *resourcesynchro.WatchBookmarkConfig
+	skipBookmarks         atomic.Bool
+
 	eventSynchro *eventSynchro
 
 	memoryVersion schema.GroupVersion
@@ -78,6 +119,10 @@ type resourceSynchro struct {
 	runningStage string
 
 	storageMaxRetry int
+	storageTimeout  time.Duration
+
+	// publisher publishes the changes saved to the storage, nil if the change data capture is disabled
+	publisher cdc.Publisher
 }
 
 type DefaultResourceSynchroFactory struct{}
@@ -95,6 +140,12 @@ func (factory DefaultResourceSynchroFactory) NewResourceSynchro(cluster string,
 		listerWatcher: config.ListerWatcher,
 		rvs:           config.ResourceVersions,
 
+		skipOwnerKinds: config.SkipOwnerKinds,
+		expirations:    make(map[string]time.Time),
+		settings:       config.Settings,
+		clusterLabels:  config.ClusterLabels,
+		clusterPrune:   config.ClusterPrune,
+
 		// all resources saved to the queue are `runtime.Object`
 		queue: queue.NewPressureQueue(cache.MetaNamespaceKeyFunc),
 
@@ -109,6 +160,23 @@ func (factory DefaultResourceSynchroFactory) NewResourceSynchro(cluster string,
 
 		closer: make(chan struct{}),
 		closed: make(chan struct{}),
+
+		storageTimeout: config.StorageTimeout,
+		publisher:      config.Publisher,
+
+		relistInterval: config.VerificationRelistInterval,
+		consistency:    config.ConsistencyChecker,
+		driftCheck:     config.DriftCheck,
+	}
+	if _, ok := config.ResourceStorage.(storage.ResourcePatcher); ok && clusterpediafeature.FeatureGate.Enabled(features.PatchUpdateForResourceSync) {
+		synchro.fieldHashes = make(map[string]map[string]uint64)
+	}
+	if config.WatchBookmark != nil {
+		synchro.resumeResourceVersion = config.WatchBookmark.ResumeResourceVersion
+		synchro.bookmark = config.WatchBookmark
+	}
+	if synchro.storageTimeout <= 0 {
+		synchro.storageTimeout = resourcesynchro.DefaultStorageTimeout
 	}
 	synchro.isRunnableForStorage.Store(true)
 	close(synchro.runnableForStorage)
@@ -118,6 +186,15 @@ func (factory DefaultResourceSynchroFactory) NewResourceSynchro(cluster string,
 	example.SetGroupVersionKind(config.GroupVersionKind())
 	synchro.example = example
 
+	if resourcesynchro.SupportsTerminatedTTL(config.GroupVersionKind().GroupKind()) {
+		synchro.supportsTerminatedTTL = true
+		synchro.terminatedTTL = config.TerminatedTTL
+	}
+
+	if config.MassDeletion.Enabled() {
+		synchro.massDeletion = resourcesynchro.NewMassDeletionGuard(config.MassDeletion)
+	}
+
 	if config.MetricsStore != nil {
 		synchro.metricsExtraStore = config.MetricsStore
 		synchro.metricsWriter = metricsstore.NewMetricsWriter(config.MetricsStore.MetricsStore)
@@ -172,6 +249,18 @@ func (synchro *resourceSynchro) Run(shutdown <-chan struct{}) {
 		}()
 	}
 
+	// the ttl may be set by the settings at runtime
+	if synchro.supportsTerminatedTTL {
+		go wait.Until(synchro.deleteExpiredObjects, time.Minute, synchro.closer)
+	}
+
+	if synchro.relistInterval > 0 && synchro.consistency != nil {
+		go synchro.runVerificationRelists()
+	}
+	if synchro.driftCheck.Enabled() && synchro.consistency != nil {
+		go synchro.runDriftChecks()
+	}
+
 	synchro.runningStage = "running"
 	wait.Until(func() {
 		synchro.processResources()
@@ -271,12 +360,14 @@ func (synchro *resourceSynchro) Start(stopCh <-chan struct{}) {
 		default:
 		}
 
+		relist, relistDone := synchro.prepareRelist()
 		informerStopCh := make(chan struct{})
 		go func() {
 			select {
 			case <-stopCh:
 			case <-synchro.closer:
 			case <-stopForStorage:
+			case <-relist:
 			}
 			close(informerStopCh)
 		}()
@@ -304,6 +395,8 @@ func (synchro *resourceSynchro) Start(stopCh <-chan struct{}) {
 			ErrorHandler:      synchro.ErrorHandler,
 			ExtraStore:        synchro.metricsExtraStore,
 			WatchListPageSize: synchro.pageSize,
+			RestoreHandler:    synchro.onRestore,
+			RelistHandler:     synchro.onRelist,
 		}
 		if clusterpediafeature.FeatureGate.Enabled(features.StreamHandlePaginatedListForResourceSync) {
 			config.StreamHandleForPaginatedList = true
@@ -311,9 +404,21 @@ func (synchro *resourceSynchro) Start(stopCh <-chan struct{}) {
 		if clusterpediafeature.FeatureGate.Enabled(features.ForcePaginatedListForResourceSync) {
 			config.ForcePaginatedList = true
 		}
+		if config.ResumeResourceVersion, synchro.resumeResourceVersion = synchro.resumeResourceVersion, ""; config.ResumeResourceVersion == "" {
+			// the relisted resource is consistent with the member cluster
+			synchro.skipBookmarks.Store(false)
+		}
 
 		i := informer.NewResourceVersionInformer(synchro.cluster, config)
 		go func() {
+			// the list of the verification relist is not an initial sync,
+			// it has been admitted by the consistency checker when no initial syncs are running.
+			done := relistDone
+			if done == nil {
+				done = synchro.consistency.StartInitialSync()
+			}
+			defer done()
+
 			synchro.initialListPhase.Store(true)
 			if !cache.WaitForCacheSync(informerStopCh, i.HasSynced, func() bool { return !synchro.queue.HasInitialEvents() }) {
 				synchro.initialListPhase.Store(false)
@@ -321,11 +426,15 @@ func (synchro *resourceSynchro) Start(stopCh <-chan struct{}) {
 			}
 			synchro.initialListPhase.Store(false)
 
+			if bookmark := synchro.bookmark; bookmark != nil && bookmark.Save != nil && bookmark.Interval > 0 {
+				go wait.Until(synchro.saveWatchBookmark(i), bookmark.Interval, informerStopCh)
+			}
 			if synchro.eventSynchro != nil {
 				synchro.eventSynchro.Start(informerStopCh)
 			}
 		}()
 		i.Run(informerStopCh)
+		synchro.stopRelist()
 
 		// TODO(Iceber): Optimize status updates in case of storage exceptions
 		if !synchro.isRunnableForStorage.Load() {
@@ -334,29 +443,121 @@ func (synchro *resourceSynchro) Start(stopCh <-chan struct{}) {
 	}
 }
 
-const LastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+// saveWatchBookmark returns the func to save the handled resource version of the informer as the watch bookmark,
+// the bookmark is saved only if all the events before it are saved to the storage.
+func (synchro *resourceSynchro) saveWatchBookmark(i informer.ResourceVersionInformer) func() {
+	var saved string
+	return func() {
+		resourceVersion := i.HandledResourceVersion()
+		if resourceVersion == "" || resourceVersion == saved || !synchro.queue.Idle() || synchro.skipBookmarks.Load() {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(synchro.ctx, synchro.storageTimeout)
+		defer cancel()
+		if err := synchro.bookmark.Save(ctx, resourceVersion); err != nil {
+			klog.ErrorS(err, "Failed to save the watch bookmark", "cluster", synchro.cluster, "resource", synchro.storageResource)
+			return
+		}
+		saved = resourceVersion
+	}
+}
+
+// prepareRelist returns the channel to stop the informer for the verification relist,
+// and the done of the verification relist if the informer is restarted by it.
+func (synchro *resourceSynchro) prepareRelist() (<-chan struct{}, func()) {
+	synchro.relistLock.Lock()
+	defer synchro.relistLock.Unlock()
 
-func (synchro *resourceSynchro) pruneObject(obj *unstructured.Unstructured) {
-	if clusterpediafeature.FeatureGate.Enabled(features.PruneManagedFields) {
-		obj.SetManagedFields(nil)
+	synchro.relist = make(chan struct{})
+	done := synchro.relistDone
+	synchro.relistDone = nil
+	return synchro.relist, done
+}
+
+// stopRelist disables the verification relist when the informer is stopped.
+func (synchro *resourceSynchro) stopRelist() {
+	synchro.relistLock.Lock()
+	defer synchro.relistLock.Unlock()
+	synchro.relist = nil
+}
+
+// triggerRelist restarts the running informer to fully relist the resource,
+// it returns false if the informer is not running.
+func (synchro *resourceSynchro) triggerRelist(done func()) bool {
+	synchro.relistLock.Lock()
+	defer synchro.relistLock.Unlock()
+	if synchro.relist == nil {
+		return false
 	}
 
-	if clusterpediafeature.FeatureGate.Enabled(features.PruneLastAppliedConfiguration) {
-		annotations := obj.GetAnnotations()
-		if _, ok := annotations[LastAppliedConfigurationAnnotation]; ok {
-			delete(annotations, LastAppliedConfigurationAnnotation)
-			if len(annotations) == 0 {
-				annotations = nil
-			}
-			obj.SetAnnotations(annotations)
+	synchro.relistDone = done
+	close(synchro.relist)
+	synchro.relist = nil
+	return true
+}
+
+// runVerificationRelists fully relists the resource at the jittered interval,
+// the missed watch events are repaired by the diff between the list and the cached resource versions.
+// The relist is delayed until it is admitted by the consistency checker and the initial list is finished.
+func (synchro *resourceSynchro) runVerificationRelists() {
+	timer := time.NewTimer(resourcesynchro.NextVerificationRelist(synchro.relistInterval))
+	defer timer.Stop()
+	for {
+		select {
+		case <-synchro.closer:
+			return
+		case <-timer.C:
+		}
+
+		if synchro.initialListPhase.Load() {
+			timer.Reset(resourcesynchro.VerificationRelistRetryPeriod)
+			continue
+		}
+		done, ok := synchro.consistency.TryStartRelist()
+		if !ok {
+			timer.Reset(resourcesynchro.VerificationRelistRetryPeriod)
+			continue
 		}
+		if !synchro.triggerRelist(done) {
+			done()
+			timer.Reset(resourcesynchro.VerificationRelistRetryPeriod)
+			continue
+		}
+
+		klog.InfoS("Start the verification relist of the resource", "cluster", synchro.cluster, "resource", synchro.syncResource)
+		synchro.metricsWrapper.Counter(verificationRelistsCounter).Inc()
+		timer.Reset(resourcesynchro.NextVerificationRelist(synchro.relistInterval))
+	}
+}
+
+const LastAppliedConfigurationAnnotation = resourcesynchro.LastAppliedConfigurationAnnotation
+
+// pruneObject prunes the fields resolved by the settings and the prune configuration of the cluster from the object,
+// and masks the sensitive fields, so they are never saved.
+func (synchro *resourceSynchro) pruneObject(obj *unstructured.Unstructured) {
+	var clusterPrune *clusterv1alpha2.ClusterPruneConfiguration
+	if synchro.clusterPrune != nil {
+		clusterPrune = synchro.clusterPrune()
+	}
+	synchro.settings.PruneRules(synchro.syncResource, clusterPrune).Prune(obj)
+	synchro.settings.MaskingRules(synchro.syncResource).Mask(obj.Object)
+}
+
+// enrichObject stamps the cluster labels selected by the settings on the object
+func (synchro *resourceSynchro) enrichObject(obj runtime.Object) {
+	var clusterLabels map[string]string
+	if synchro.clusterLabels != nil {
+		clusterLabels = synchro.clusterLabels()
 	}
+	utils.InjectClusterLabels(obj, clusterLabels, synchro.settings.EnrichedClusterLabels())
 }
 
 func (synchro *resourceSynchro) OnAdd(obj interface{}, isInInitialList bool) {
 	if !synchro.isRunnableForStorage.Load() {
 		return
 	}
+	synchro.restoreFrozenDeletion(obj)
 
 	// `obj` will not be processed in parallel elsewhere,
 	// no deep copy is needed for now.
@@ -372,6 +573,12 @@ func (synchro *resourceSynchro) OnAdd(obj interface{}, isInInitialList bool) {
 	// https://github.com/clusterpedia-io/clusterpedia/issues/4
 	synchro.pruneObject(obj.(*unstructured.Unstructured))
 
+	if synchro.skipOwnedObject(obj.(*unstructured.Unstructured), isInInitialList) {
+		return
+	}
+	if synchro.skipExpiredObject(obj.(*unstructured.Unstructured), isInInitialList) {
+		return
+	}
 	_ = synchro.queue.Add(obj, isInInitialList)
 }
 
@@ -379,6 +586,7 @@ func (synchro *resourceSynchro) OnUpdate(_, obj interface{}, isInInitialList boo
 	if !synchro.isRunnableForStorage.Load() {
 		return
 	}
+	synchro.restoreFrozenDeletion(obj)
 
 	// `obj` will not be processed in parallel elsewhere,
 	// no deep copy is needed for now.
@@ -387,16 +595,115 @@ func (synchro *resourceSynchro) OnUpdate(_, obj interface{}, isInInitialList boo
 
 	// https://github.com/clusterpedia-io/clusterpedia/issues/4
 	synchro.pruneObject(obj.(*unstructured.Unstructured))
+
+	if synchro.skipOwnedObject(obj.(*unstructured.Unstructured), isInInitialList) {
+		return
+	}
+	if synchro.skipExpiredObject(obj.(*unstructured.Unstructured), isInInitialList) {
+		return
+	}
 	_ = synchro.queue.Update(obj, isInInitialList)
 }
 
 func (synchro *resourceSynchro) OnDelete(obj interface{}, isInInitialList bool) {
+	if !synchro.isRunnableForStorage.Load() {
+		return
+	}
+	if !synchro.guardDeletion(obj) {
+		return
+	}
+	synchro.deleteObject(obj, isInInitialList)
+}
+
+// onRestore is called when the etcd of the cluster may be restored,
+// the listed objects are rewritten and the objects missing from the list are deleted, the deletions are still guarded.
+func (synchro *resourceSynchro) onRestore(listResourceVersion string, storedResourceVersion uint64) {
+	klog.InfoS("The resource version of the cluster goes backwards, fully re-sync the resource",
+		"cluster", synchro.cluster, "resource", synchro.syncResource,
+		"listResourceVersion", listResourceVersion, "storedResourceVersion", storedResourceVersion)
+	synchro.metricsWrapper.Counter(restoresDetectedCounter).Inc()
+}
+
+func (synchro *resourceSynchro) onRelist(full bool) {
+	if !full {
+		klog.V(2).InfoS("The resource is relisted from the last synced resource version",
+			"cluster", synchro.cluster, "resource", synchro.syncResource)
+		synchro.metricsWrapper.Counter(recoveredRelistsCounter).Inc()
+		return
+	}
+	klog.InfoS("The resource is fully relisted", "cluster", synchro.cluster, "resource", synchro.syncResource)
+	synchro.metricsWrapper.Counter(fullRelistsCounter).Inc()
+}
+
+// guardDeletion returns false if the deletion is held by the mass deletion guard.
+func (synchro *resourceSynchro) guardDeletion(obj interface{}) bool {
+	if synchro.massDeletion == nil {
+		return true
+	}
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return true
+	}
+
+	synchro.rvsLock.Lock()
+	stored := len(synchro.rvs)
+	synchro.rvsLock.Unlock()
+
+	propagate, freezing := synchro.massDeletion.Delete(key, obj, stored)
+	if freezing {
+		klog.InfoS("Mass deletion is detected, the deletions are frozen until they are confirmed",
+			"cluster", synchro.cluster, "resource", synchro.syncResource, "stored", stored)
+	}
+	if !propagate {
+		count, _, _ := synchro.massDeletion.Frozen()
+		synchro.metricsWrapper.Sum(frozenDeletionsTotal, float64(count))
+	}
+	return propagate
+}
+
+func (synchro *resourceSynchro) restoreFrozenDeletion(obj interface{}) {
+	if synchro.massDeletion == nil {
+		return
+	}
+	if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+		synchro.massDeletion.Restore(key)
+		count, _, _ := synchro.massDeletion.Frozen()
+		synchro.metricsWrapper.Sum(frozenDeletionsTotal, float64(count))
+	}
+}
+
+// ConfirmDeletions propagates the frozen deletions to the storage.
+func (synchro *resourceSynchro) ConfirmDeletions() {
+	if synchro.massDeletion == nil {
+		return
+	}
+
+	frozen := synchro.massDeletion.Confirm()
+	if len(frozen) == 0 {
+		return
+	}
+	klog.InfoS("Frozen deletions are confirmed", "cluster", synchro.cluster, "resource", synchro.syncResource, "deletions", len(frozen))
+	for _, obj := range frozen {
+		synchro.deleteObject(obj, false)
+	}
+	synchro.metricsWrapper.Sum(frozenDeletionsTotal, 0)
+}
+
+// deleteObject deletes the object from the storage, it is not guarded by the mass deletion guard.
+func (synchro *resourceSynchro) deleteObject(obj interface{}, isInInitialList bool) {
 	if !synchro.isRunnableForStorage.Load() {
 		return
 	}
 	if o, ok := obj.(*unstructured.Unstructured); ok {
 		synchro.pruneObject(o)
 	}
+	if synchro.supportsTerminatedTTL {
+		if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+			synchro.expirationsLock.Lock()
+			delete(synchro.expirations, key)
+			synchro.expirationsLock.Unlock()
+		}
+	}
 
 	obj, err := synchro.storage.ConvertDeletedObject(obj)
 	if err != nil {
@@ -407,6 +714,89 @@ func (synchro *resourceSynchro) OnDelete(obj interface{}, isInInitialList bool)
 
 func (synchro *resourceSynchro) OnSync(obj interface{}) {}
 
+// skipOwnedObject returns true if the object is owned by the skipped owner kinds,
+// the object stored before it is skipped is deleted from the storage.
+func (synchro *resourceSynchro) skipOwnedObject(obj *unstructured.Unstructured, isInInitialList bool) bool {
+	if !synchro.skipOwnerKinds.Owns(obj) {
+		return false
+	}
+
+	key, _ := cache.MetaNamespaceKeyFunc(obj)
+	synchro.rvsLock.Lock()
+	_, stored := synchro.rvs[key]
+	synchro.rvsLock.Unlock()
+	if stored {
+		synchro.deleteObject(obj, isInInitialList)
+	}
+	return true
+}
+
+// skipExpiredObject returns true if the object has been terminated for longer than the ttl,
+// the object stored before it is expired is deleted from the storage.
+// The objects that are terminated but not yet expired are deleted by deleteExpiredObjects.
+func (synchro *resourceSynchro) skipExpiredObject(obj *unstructured.Unstructured, isInInitialList bool) bool {
+	ttl := synchro.getTerminatedTTL()
+	if ttl <= 0 {
+		return false
+	}
+
+	key, _ := cache.MetaNamespaceKeyFunc(obj)
+	terminatedAt, terminated := resourcesynchro.TerminatedAt(synchro.example.GetObjectKind().GroupVersionKind().GroupKind(), obj)
+	if !terminated {
+		synchro.expirationsLock.Lock()
+		delete(synchro.expirations, key)
+		synchro.expirationsLock.Unlock()
+		return false
+	}
+
+	expireAt := terminatedAt.Add(ttl)
+	if time.Now().Before(expireAt) {
+		synchro.expirationsLock.Lock()
+		synchro.expirations[key] = expireAt
+		synchro.expirationsLock.Unlock()
+		return false
+	}
+
+	synchro.rvsLock.Lock()
+	_, stored := synchro.rvs[key]
+	synchro.rvsLock.Unlock()
+	if stored {
+		synchro.deleteObject(obj, isInInitialList)
+	}
+	return true
+}
+
+// getTerminatedTTL returns the ttl of the terminated objects, 0 means the terminated objects are kept.
+func (synchro *resourceSynchro) getTerminatedTTL() time.Duration {
+	if !synchro.supportsTerminatedTTL {
+		return 0
+	}
+	return synchro.settings.TerminatedTTL(synchro.example.GetObjectKind().GroupVersionKind().GroupKind(), synchro.terminatedTTL)
+}
+
+// deleteExpiredObjects deletes the terminated objects that expired after they were stored.
+// A changed ttl applies to the objects when they are synchronized again.
+func (synchro *resourceSynchro) deleteExpiredObjects() {
+	if !synchro.isRunnableForStorage.Load() || synchro.getTerminatedTTL() <= 0 {
+		return
+	}
+
+	now := time.Now()
+	var expired []string
+	synchro.expirationsLock.Lock()
+	for key, expireAt := range synchro.expirations {
+		if !now.Before(expireAt) {
+			expired = append(expired, key)
+		}
+	}
+	synchro.expirationsLock.Unlock()
+
+	for _, key := range expired {
+		klog.V(4).InfoS("delete expired object", "cluster", synchro.cluster, "gvr", synchro.syncResource, "key", key)
+		synchro.deleteObject(cache.DeletedFinalStateUnknown{Key: key}, false)
+	}
+}
+
 func (synchro *resourceSynchro) processResources() {
 	for {
 		select {
@@ -448,6 +838,8 @@ func (synchro *resourceSynchro) handleResourceEvent(event *queue.Event) {
 			return
 		}
 		utils.InjectClusterName(obj, synchro.cluster)
+		synchro.enrichObject(obj)
+		hashes := synchro.hashFields(obj)
 
 		var metric compbasemetrics.CounterMetric
 		switch event.Action {
@@ -456,6 +848,11 @@ func (synchro *resourceSynchro) handleResourceEvent(event *queue.Event) {
 			metric = synchro.metricsWrapper.Counter(resourceAddedCounter)
 		case queue.Updated:
 			handler = synchro.updateOrCreateResource
+			if patch, ok := synchro.resourcePatch(key, hashes); ok {
+				handler = func(ctx context.Context, obj runtime.Object) error {
+					return synchro.patchOrCreateResource(ctx, obj, patch)
+				}
+			}
 			metric = synchro.metricsWrapper.Counter(resourceUpdatedCounter)
 		}
 		callback = func(obj runtime.Object) {
@@ -463,6 +860,9 @@ func (synchro *resourceSynchro) handleResourceEvent(event *queue.Event) {
 			metaobj, _ := meta.Accessor(obj)
 			synchro.rvsLock.Lock()
 			synchro.rvs[key] = metaobj.GetResourceVersion()
+			if hashes != nil {
+				synchro.fieldHashes[key] = hashes
+			}
 
 			synchro.metricsWrapper.Sum(storagedResourcesTotal, float64(len(synchro.rvs)))
 			synchro.rvsLock.Unlock()
@@ -471,6 +871,7 @@ func (synchro *resourceSynchro) handleResourceEvent(event *queue.Event) {
 		handler, callback = synchro.deleteResource, func(_ runtime.Object) {
 			synchro.rvsLock.Lock()
 			delete(synchro.rvs, key)
+			delete(synchro.fieldHashes, key)
 			synchro.metricsWrapper.Sum(storagedResourcesTotal, float64(len(synchro.rvs)))
 			synchro.rvsLock.Unlock()
 			synchro.metricsWrapper.Counter(resourceDeletedCounter).Inc()
@@ -480,11 +881,12 @@ func (synchro *resourceSynchro) handleResourceEvent(event *queue.Event) {
 	// TODO(Iceber): put the event back into the queue to retry?
 	for i := 0; ; i++ {
 		now := time.Now()
-		ctx, cancel := context.WithTimeout(synchro.ctx, 30*time.Second)
+		ctx, cancel := context.WithTimeout(synchro.ctx, synchro.storageTimeout)
 		err := handler(ctx, obj)
 		cancel()
 		if err == nil {
 			callback(obj)
+			synchro.publish(event.Action, obj)
 
 			if i != 0 && i > synchro.storageMaxRetry {
 				synchro.storageMaxRetry = i
@@ -496,6 +898,9 @@ func (synchro *resourceSynchro) handleResourceEvent(event *queue.Event) {
 				synchro.setRunnableForStorage()
 			}
 			synchro.metricsWrapper.Historgram(resourceStorageDuration).Observe(time.Since(now).Seconds())
+			if !event.ReceivedTime.IsZero() {
+				synchro.metricsWrapper.Historgram(resourceSyncLag).Observe(time.Since(event.ReceivedTime).Seconds())
+			}
 			return
 		}
 
@@ -506,6 +911,7 @@ func (synchro *resourceSynchro) handleResourceEvent(event *queue.Event) {
 		synchro.metricsWrapper.Counter(resourceFailedCounter).Inc()
 		if !storage.IsRecoverableException(err) {
 			synchro.metricsWrapper.Counter(resourceDroppedCounter).Inc()
+			synchro.skipBookmarks.Store(true)
 			klog.ErrorS(err, "Failed to storage resource", "cluster", synchro.cluster,
 				"action", event.Action, "resource", synchro.storageResource, "key", key)
 
@@ -527,6 +933,7 @@ func (synchro *resourceSynchro) handleResourceEvent(event *queue.Event) {
 				synchro.setStopForStorage()
 			}
 			synchro.queue.DiscardAndRetain(retainInQueue)
+			synchro.skipBookmarks.Store(true)
 
 			// If the data in the queue is discarded,
 			// the data in the cache will be inconsistent with the data in the `rvs`,
@@ -538,7 +945,34 @@ func (synchro *resourceSynchro) handleResourceEvent(event *queue.Event) {
 
 		//	klog.ErrorS(err, "will retry sync storage resource", "num", i, "cluster", synchro.cluster,
 		//		"action", event.Action, "resource", synchro.storageResource, "key", key)
-		time.Sleep(2 * time.Second)
+		select {
+		case <-synchro.ctx.Done():
+			return
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// publish publishes the change saved to the storage, the failures are logged and don't block the synchro
+func (synchro *resourceSynchro) publish(action queue.ActionType, obj runtime.Object) {
+	if synchro.publisher == nil {
+		return
+	}
+	metaobj, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+
+	eventType := cdc.Updated
+	switch action {
+	case queue.Added:
+		eventType = cdc.Created
+	case queue.Deleted:
+		eventType = cdc.Deleted
+	}
+	if err := synchro.publisher.Publish(synchro.ctx, cdc.NewEvent(eventType, synchro.cluster, synchro.storageResource, metaobj)); err != nil {
+		klog.ErrorS(err, "Failed to publish the change event", "cluster", synchro.cluster,
+			"action", action, "resource", synchro.storageResource, "name", metaobj.GetName())
 	}
 }
 
@@ -603,7 +1037,7 @@ func (synchro *resourceSynchro) convertToStorageVersion(obj runtime.Object) (run
 
 func (synchro *resourceSynchro) createOrUpdateResource(ctx context.Context, obj runtime.Object) error {
 	err := synchro.storage.Create(ctx, synchro.cluster, obj)
-	if genericstorage.IsExist(err) {
+	if storage.IsConflict(err) {
 		return synchro.storage.Update(ctx, synchro.cluster, obj)
 	}
 	return err
@@ -611,12 +1045,71 @@ func (synchro *resourceSynchro) createOrUpdateResource(ctx context.Context, obj
 
 func (synchro *resourceSynchro) updateOrCreateResource(ctx context.Context, obj runtime.Object) error {
 	err := synchro.storage.Update(ctx, synchro.cluster, obj)
-	if genericstorage.IsNotFound(err) {
+	if storage.IsNotFound(err) {
 		return synchro.storage.Create(ctx, synchro.cluster, obj)
 	}
 	return err
 }
 
+// patchOrCreateResource updates the changed fields of the stored object, the storage falls back to the full update
+// if the stored object isn't the version which the changed fields are computed from.
+func (synchro *resourceSynchro) patchOrCreateResource(ctx context.Context, obj runtime.Object, patch storage.ResourcePatch) error {
+	err := synchro.storage.(storage.ResourcePatcher).UpdateWithPatch(ctx, synchro.cluster, obj, patch)
+	if storage.IsNotFound(err) {
+		return synchro.storage.Create(ctx, synchro.cluster, obj)
+	}
+	return err
+}
+
+// hashFields returns the hashes of the top-level fields of the object, nil if the patch is disabled.
+func (synchro *resourceSynchro) hashFields(obj runtime.Object) map[string]uint64 {
+	object, ok := obj.(*unstructured.Unstructured)
+	if synchro.fieldHashes == nil || !ok {
+		return nil
+	}
+
+	hashes := make(map[string]uint64, len(object.Object))
+	for field, value := range object.Object {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil
+		}
+		hash := fnv.New64a()
+		_, _ = hash.Write(data)
+		hashes[field] = hash.Sum64()
+	}
+	return hashes
+}
+
+// resourcePatch returns the changed top-level fields of the object from the stored object,
+// false is returned if the fields of the stored object are unknown, e.g. the object is stored before the restart.
+func (synchro *resourceSynchro) resourcePatch(key string, hashes map[string]uint64) (storage.ResourcePatch, bool) {
+	if hashes == nil {
+		return storage.ResourcePatch{}, false
+	}
+
+	synchro.rvsLock.Lock()
+	defer synchro.rvsLock.Unlock()
+	stored, ok := synchro.fieldHashes[key]
+	resourceVersion, _ := synchro.rvs[key].(string)
+	if !ok || resourceVersion == "" {
+		return storage.ResourcePatch{}, false
+	}
+
+	patch := storage.ResourcePatch{PreviousResourceVersion: resourceVersion}
+	for field, hash := range hashes {
+		if storedHash, ok := stored[field]; !ok || storedHash != hash {
+			patch.ChangedFields = append(patch.ChangedFields, field)
+		}
+	}
+	for field := range stored {
+		if _, ok := hashes[field]; !ok {
+			patch.ChangedFields = append(patch.ChangedFields, field)
+		}
+	}
+	return patch, true
+}
+
 func (synchro *resourceSynchro) deleteResource(ctx context.Context, obj runtime.Object) error {
 	return synchro.storage.Delete(ctx, synchro.cluster, obj)
 }
@@ -636,6 +1129,14 @@ func (synchro *resourceSynchro) Status() clusterv1alpha2.ClusterResourceSyncCond
 	case clusterv1alpha2.ResourceSyncStatusPending, clusterv1alpha2.ResourceSyncStatusSyncing:
 		s.InitialListPhase = synchro.initialListPhase.Load()
 	}
+
+	if synchro.massDeletion != nil {
+		if count, frozenAt, frozen := synchro.massDeletion.Frozen(); frozen {
+			s.Reason = resourcesynchro.DeletionsFrozenReason
+			s.Message = fmt.Sprintf("mass deletion is detected, %d deletions are frozen since %s, confirm them by updating the annotation %s of the cluster",
+				count, frozenAt.Format(time.RFC3339), clusterv1alpha2.ConfirmDeletionsAnnotation)
+		}
+	}
 	return s
 }
 
@@ -667,11 +1168,14 @@ type eventSynchro struct {
 	queue   queue.EventQueue
 	rvs     map[string]interface{}
 	rvsLock sync.Mutex
-	cache   *informer.ResourceVersionStorage
+
+	skipOwnerKinds resourcesynchro.OwnerKinds
+	cache          *informer.ResourceVersionStorage
 }
 
 func newEventSynchro(cluster string, synchro *resourceSynchro, lw cache.ListerWatcher, rvs map[string]interface{}) *eventSynchro {
 	return &eventSynchro{
+		ctx:           synchro.ctx,
 		cluster:       cluster,
 		listerWatcher: lw,
 		synchro:       synchro,
@@ -770,7 +1274,9 @@ func (synchro *eventSynchro) handleResourceEvent(event *queue.Event) {
 
 	obj := event.Object.(*corev1.Event)
 	obj.SetManagedFields(nil)
-	err := synchro.synchro.storage.RecordEvent(synchro.ctx, synchro.cluster, obj)
+	ctx, cancel := context.WithTimeout(synchro.ctx, synchro.synchro.storageTimeout)
+	err := synchro.synchro.storage.RecordEvent(ctx, synchro.cluster, obj)
+	cancel()
 	if err != nil {
 		klog.ErrorS(err, "Failed to storage event", "cluster", synchro.cluster)
 		return
@@ -41,6 +41,28 @@ var (
 
 	// resourceStorageDuration records the time interval from when a resource is fetched from the queue to when it is processed.
 	resourceStorageDuration *compbasemetrics.HistogramVec
+
+	// resourceSyncLag records the time interval from when a change of the resource is received to when it is stored,
+	// it is the freshness of the resources in the storage layer.
+	resourceSyncLag *compbasemetrics.HistogramVec
+
+	// frozenDeletionsTotal records the number of deletions frozen by the mass deletion guard.
+	frozenDeletionsTotal *compbasemetrics.GaugeVec
+
+	// restoresDetectedCounter records the number of times the restore of the cluster is detected.
+	restoresDetectedCounter *compbasemetrics.CounterVec
+
+	// verificationRelistsCounter records the number of the verification relists of the resources.
+	verificationRelistsCounter *compbasemetrics.CounterVec
+
+	// fullRelistsCounter records the number of the full relists of the resources after the initial list.
+	fullRelistsCounter *compbasemetrics.CounterVec
+
+	// recoveredRelistsCounter records the number of the relists recovered from the last synced resource version.
+	recoveredRelistsCounter *compbasemetrics.CounterVec
+
+	// driftedResourcesTotal records the number of the drifted resources found by the last drift check.
+	driftedResourcesTotal *compbasemetrics.GaugeVec
 )
 
 var resourceSynchroMetrics = []interface{}{
@@ -52,6 +74,13 @@ var resourceSynchroMetrics = []interface{}{
 	resourceMaxRetryGauge,
 	resourceDroppedCounter,
 	resourceStorageDuration,
+	resourceSyncLag,
+	frozenDeletionsTotal,
+	restoresDetectedCounter,
+	verificationRelistsCounter,
+	fullRelistsCounter,
+	recoveredRelistsCounter,
+	driftedResourcesTotal,
 }
 
 var registerOnce sync.Once
@@ -139,6 +168,77 @@ func registerResourceSynchroMetrics() {
 			},
 		)
 
+		resourceSyncLag = resourcesynchro.DefaultMetricsWrapperFactory.NewHistogramVec(
+			&compbasemetrics.HistogramOpts{
+				Namespace:      namespace,
+				Subsystem:      subsystem,
+				Name:           "sync_lag_seconds",
+				Help:           "The time interval from when a change of the resource is received from the member cluster to when it is stored.",
+				StabilityLevel: compbasemetrics.ALPHA,
+				Buckets:        []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+			},
+		)
+
+		frozenDeletionsTotal = resourcesynchro.DefaultMetricsWrapperFactory.NewGaugeVec(
+			&compbasemetrics.GaugeOpts{
+				Namespace:      namespace,
+				Subsystem:      subsystem,
+				Name:           "frozen_deletions_total",
+				Help:           "Number of deletions frozen by the mass deletion guard, pending the confirmation.",
+				StabilityLevel: compbasemetrics.ALPHA,
+			},
+		)
+
+		restoresDetectedCounter = resourcesynchro.DefaultMetricsWrapperFactory.NewCounterVec(
+			&compbasemetrics.CounterOpts{
+				Namespace:      namespace,
+				Subsystem:      subsystem,
+				Name:           "restores_detected_total",
+				Help:           "Number of times the resource version of the cluster goes backwards and the resources are fully re-synced.",
+				StabilityLevel: compbasemetrics.ALPHA,
+			},
+		)
+
+		verificationRelistsCounter = resourcesynchro.DefaultMetricsWrapperFactory.NewCounterVec(
+			&compbasemetrics.CounterOpts{
+				Namespace:      namespace,
+				Subsystem:      subsystem,
+				Name:           "verification_relists_total",
+				Help:           "Number of times the resources are fully relisted to repair the divergence from the missed watch events.",
+				StabilityLevel: compbasemetrics.ALPHA,
+			},
+		)
+
+		fullRelistsCounter = resourcesynchro.DefaultMetricsWrapperFactory.NewCounterVec(
+			&compbasemetrics.CounterOpts{
+				Namespace:      namespace,
+				Subsystem:      subsystem,
+				Name:           "full_relists_total",
+				Help:           "Number of times the resources are relisted by the consistent reads from the member cluster after the initial list, e.g. the expired watch isn't recovered.",
+				StabilityLevel: compbasemetrics.ALPHA,
+			},
+		)
+
+		recoveredRelistsCounter = resourcesynchro.DefaultMetricsWrapperFactory.NewCounterVec(
+			&compbasemetrics.CounterOpts{
+				Namespace:      namespace,
+				Subsystem:      subsystem,
+				Name:           "recovered_relists_total",
+				Help:           "Number of times the resources are relisted not older than the last synced resource version, e.g. the expired watch is recovered.",
+				StabilityLevel: compbasemetrics.ALPHA,
+			},
+		)
+
+		driftedResourcesTotal = resourcesynchro.DefaultMetricsWrapperFactory.NewGaugeVec(
+			&compbasemetrics.GaugeOpts{
+				Namespace:      namespace,
+				Subsystem:      subsystem,
+				Name:           "drifted_resource_total",
+				Help:           "Number of resources which differ between the storage layer and the member cluster, found by the last drift check.",
+				StabilityLevel: compbasemetrics.ALPHA,
+			},
+		)
+
 		resourceSynchroMetrics = []interface{}{
 			storagedResourcesTotal,
 			resourceAddedCounter,
@@ -148,6 +248,13 @@ func registerResourceSynchroMetrics() {
 			resourceMaxRetryGauge,
 			resourceDroppedCounter,
 			resourceStorageDuration,
+			resourceSyncLag,
+			frozenDeletionsTotal,
+			restoresDetectedCounter,
+			verificationRelistsCounter,
+			fullRelistsCounter,
+			recoveredRelistsCounter,
+			driftedResourcesTotal,
 		}
 		for _, m := range resourceSynchroMetrics {
 			legacyregistry.MustRegister(m.(compbasemetrics.Registerable))
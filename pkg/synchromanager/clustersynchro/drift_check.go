@@ -0,0 +1,232 @@
+package clustersynchro
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/resourcesynchro"
+)
+
+const (
+	driftCheckPageSize = 500
+
+	// maxLoggedDrifts limits the keys of the drifted objects in the logs
+	maxLoggedDrifts = 10
+)
+
+// runDriftChecks compares the stored objects with a live list of the member cluster at the jittered interval.
+// The checks are admitted by the consistency checker like the verification relists,
+// so they never overlap with the initial syncs and the relists of the cluster.
+func (synchro *resourceSynchro) runDriftChecks() {
+	timer := time.NewTimer(resourcesynchro.NextVerificationRelist(synchro.driftCheck.Interval))
+	defer timer.Stop()
+	for {
+		select {
+		case <-synchro.closer:
+			return
+		case <-timer.C:
+		}
+
+		if synchro.initialListPhase.Load() || !synchro.isRunnableForStorage.Load() {
+			timer.Reset(resourcesynchro.VerificationRelistRetryPeriod)
+			continue
+		}
+		done, ok := synchro.consistency.TryStartRelist()
+		if !ok {
+			timer.Reset(resourcesynchro.VerificationRelistRetryPeriod)
+			continue
+		}
+
+		drift, err := synchro.checkDrift(synchro.ctx)
+		if err != nil {
+			done()
+			klog.ErrorS(err, "Failed to check the drift of the resource", "cluster", synchro.cluster, "resource", synchro.syncResource)
+			timer.Reset(resourcesynchro.VerificationRelistRetryPeriod)
+			continue
+		}
+		synchro.metricsWrapper.Sum(driftedResourcesTotal, float64(drift.Len()))
+
+		if drift.Len() == 0 {
+			done()
+		} else {
+			klog.InfoS("The stored resources drift from the cluster", "cluster", synchro.cluster, "resource", synchro.syncResource,
+				"phantoms", len(drift.Phantoms), "missing", len(drift.Missing), "stale", len(drift.Stale),
+				"samples", driftSamples(drift), "repair", synchro.driftCheck.Repair)
+
+			if synchro.driftCheck.Repair && synchro.repairDrift(drift, done) {
+				synchro.metricsWrapper.Counter(verificationRelistsCounter).Inc()
+			} else {
+				done()
+			}
+		}
+		timer.Reset(resourcesynchro.NextVerificationRelist(synchro.driftCheck.Interval))
+	}
+}
+
+// checkDrift lists the member cluster before the storage, so that the objects changed during the check are not drifts.
+func (synchro *resourceSynchro) checkDrift(ctx context.Context) (resourcesynchro.Drift, error) {
+	live, listResourceVersion, err := synchro.listLiveVersions(ctx)
+	if err != nil {
+		return resourcesynchro.Drift{}, err
+	}
+	stored, err := synchro.listStoredVersions(ctx, "")
+	if err != nil {
+		return resourcesynchro.Drift{}, err
+	}
+
+	drift := resourcesynchro.DiffResourceVersions(stored, live, listResourceVersion)
+	if synchro.massDeletion != nil {
+		if _, _, frozen := synchro.massDeletion.Frozen(); frozen {
+			// the objects of the frozen deletions are kept in the storage until the deletions are confirmed
+			drift.Phantoms = nil
+		}
+	}
+	return drift, nil
+}
+
+// listLiveVersions returns the resource versions of the objects of the member cluster which should be stored,
+// and the resource version of the list.
+func (synchro *resourceSynchro) listLiveVersions(ctx context.Context) (map[string]string, string, error) {
+	versions := make(map[string]string)
+	var listResourceVersion string
+	opts := metav1.ListOptions{Limit: driftCheckPageSize}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
+
+		list, err := synchro.listerWatcher.List(opts)
+		if err != nil {
+			return nil, "", err
+		}
+		listMeta, err := meta.ListAccessor(list)
+		if err != nil {
+			return nil, "", err
+		}
+		if listResourceVersion == "" {
+			listResourceVersion = listMeta.GetResourceVersion()
+		}
+
+		if err := meta.EachListItem(list, func(obj runtime.Object) error {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok || !synchro.shouldStore(u) {
+				return nil
+			}
+			key, err := cache.MetaNamespaceKeyFunc(u)
+			if err != nil {
+				return err
+			}
+			versions[key] = u.GetResourceVersion()
+			return nil
+		}); err != nil {
+			return nil, "", err
+		}
+
+		if opts.Continue = listMeta.GetContinue(); opts.Continue == "" {
+			return versions, listResourceVersion, nil
+		}
+	}
+}
+
+// shouldStore returns false if the object is skipped by the synchro, such as the owned or the expired objects.
+func (synchro *resourceSynchro) shouldStore(obj *unstructured.Unstructured) bool {
+	if synchro.skipOwnerKinds.Owns(obj) {
+		return false
+	}
+	if ttl := synchro.getTerminatedTTL(); ttl > 0 {
+		terminatedAt, terminated := resourcesynchro.TerminatedAt(synchro.example.GetObjectKind().GroupVersionKind().GroupKind(), obj)
+		if terminated && !time.Now().Before(terminatedAt.Add(ttl)) {
+			return false
+		}
+	}
+	return true
+}
+
+// listStoredVersions returns the resource versions of the stored objects, they are only the objects of the namespace if it isn't empty.
+func (synchro *resourceSynchro) listStoredVersions(ctx context.Context, namespace string) (map[string]string, error) {
+	versions := make(map[string]string)
+	withContinue := true
+	opts := &internal.ListOptions{
+		ClusterNames: []string{synchro.cluster},
+		OrderBy:      []internal.OrderBy{{Field: "namespace"}, {Field: "name"}},
+		WithContinue: &withContinue,
+		OnlyMetadata: true,
+	}
+	if namespace != "" {
+		opts.Namespaces = []string{namespace}
+	}
+	opts.Limit = driftCheckPageSize
+	for {
+		list := &unstructured.UnstructuredList{}
+		if err := synchro.storage.List(ctx, list, opts); err != nil {
+			if storage.IsInvalidQuery(err) && len(opts.OrderBy) != 0 && opts.Continue == "" {
+				// the storage does not support ordering, the objects are ordered natively, such as etcd
+				opts.OrderBy = nil
+				continue
+			}
+			return nil, err
+		}
+
+		for i := range list.Items {
+			key, err := cache.MetaNamespaceKeyFunc(&list.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			versions[key] = list.Items[i].GetResourceVersion()
+		}
+
+		if list.GetContinue() == "" {
+			return versions, nil
+		}
+		opts.Continue = list.GetContinue()
+	}
+}
+
+// repairDrift marks the drifted objects in the cached resource versions and triggers the verification relist,
+// the relist deletes the phantoms and rewrites the missing and the stale objects with the objects of the member cluster.
+// It returns false if the informer is not running, done is called when the relist is finished.
+func (synchro *resourceSynchro) repairDrift(drift resourcesynchro.Drift, done func()) bool {
+	synchro.rvsLock.Lock()
+	versions := synchro.cache
+	synchro.rvsLock.Unlock()
+	if versions == nil {
+		// the cached resource versions are rebuilt from the stored objects when the informer is started
+		return false
+	}
+
+	for _, key := range drift.Phantoms {
+		if _, exists, _ := versions.GetByKey(key); !exists {
+			versions.UpdateVersion(key, "")
+		}
+	}
+	for _, key := range drift.Missing {
+		versions.DeleteKey(key)
+	}
+	for _, key := range drift.Stale {
+		versions.UpdateVersion(key, "")
+	}
+
+	return synchro.triggerRelist(done)
+}
+
+func driftSamples(drift resourcesynchro.Drift) []string {
+	var samples []string
+	for _, keys := range [][]string{drift.Phantoms, drift.Missing, drift.Stale} {
+		for _, key := range keys {
+			if len(samples) == maxLoggedDrifts {
+				return samples
+			}
+			samples = append(samples, key)
+		}
+	}
+	return samples
+}
@@ -0,0 +1,128 @@
+package clustersynchro
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/informer"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/resourcesynchro"
+)
+
+type fakeDriftStorage struct {
+	storage.ResourceStorage
+	versions map[string]string // name -> resource version
+}
+
+func (s *fakeDriftStorage) List(_ context.Context, listObj runtime.Object, opts *internal.ListOptions) error {
+	list := listObj.(*unstructured.UnstructuredList)
+	for name, version := range s.versions {
+		obj := unstructured.Unstructured{}
+		obj.SetNamespace("default")
+		obj.SetName(name)
+		obj.SetResourceVersion(version)
+		list.Items = append(list.Items, obj)
+	}
+	return nil
+}
+
+// newLiveListerWatcher lists the objects one per page at the resource version 20
+func newLiveListerWatcher(versions map[string]string, owned string) cache.ListerWatcher {
+	var names []string
+	for name := range versions {
+		names = append(names, name)
+	}
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			offset := 0
+			if opts.Continue != "" {
+				offset, _ = strconv.Atoi(opts.Continue)
+			}
+
+			list := &unstructured.UnstructuredList{}
+			list.SetResourceVersion("20")
+			if offset < len(names) {
+				obj := unstructured.Unstructured{}
+				obj.SetNamespace("default")
+				obj.SetName(names[offset])
+				obj.SetResourceVersion(versions[names[offset]])
+				if names[offset] == owned {
+					obj.SetOwnerReferences([]metav1.OwnerReference{{Kind: "ReplicaSet", Name: "rs"}})
+				}
+				list.Items = append(list.Items, obj)
+			}
+			if offset+1 < len(names) {
+				list.SetContinue(strconv.Itoa(offset + 1))
+			}
+			return list, nil
+		},
+	}
+}
+
+func TestCheckDrift(t *testing.T) {
+	synchro := &resourceSynchro{
+		cluster: "cluster-1",
+		storage: &fakeDriftStorage{versions: map[string]string{
+			"synced": "10", "phantom": "11", "stale": "12", "created": "25",
+		}},
+		listerWatcher: newLiveListerWatcher(map[string]string{
+			"synced": "10", "stale": "15", "missing": "16", "owned": "17",
+		}, "owned"),
+		skipOwnerKinds: resourcesynchro.OwnerKinds{"ReplicaSet": {}},
+		example:        &unstructured.Unstructured{},
+	}
+
+	drift, err := synchro.checkDrift(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := resourcesynchro.Drift{
+		Phantoms: []string{"default/phantom"},
+		Missing:  []string{"default/missing"},
+		Stale:    []string{"default/stale"},
+	}
+	if !reflect.DeepEqual(drift, expected) {
+		t.Fatalf("checkDrift() = %+v, want %+v", drift, expected)
+	}
+
+	// the drifts are repaired by the relist of the running informer
+	synchro.cache = informer.NewResourceVersionStorage()
+	_ = synchro.cache.Replace(map[string]interface{}{"default/synced": "10", "default/stale": "15", "default/missing": "16"})
+	relist, _ := synchro.prepareRelist()
+
+	relisted := false
+	if !synchro.repairDrift(drift, func() { relisted = true }) {
+		t.Fatal("repairDrift() should trigger the relist of the running informer")
+	}
+	select {
+	case <-relist:
+	default:
+		t.Fatal("the informer should be stopped for the relist")
+	}
+	if _, done := synchro.prepareRelist(); done == nil {
+		t.Fatal("the restarted informer should finish the relist")
+	} else if done(); !relisted {
+		t.Error("the relist should be done by the restarted informer")
+	}
+
+	if version, exists, _ := synchro.cache.GetByKey("default/phantom"); !exists || version != "" {
+		t.Errorf("the phantom should be cached to be deleted by the relist, got %v, %v", version, exists)
+	}
+	if _, exists, _ := synchro.cache.GetByKey("default/missing"); exists {
+		t.Error("the missing object should be removed from the cache to be added by the relist")
+	}
+	if version, _, _ := synchro.cache.GetByKey("default/stale"); version != "" {
+		t.Errorf("the stale object should be outdated to be updated by the relist, got %v", version)
+	}
+	if version, _, _ := synchro.cache.GetByKey("default/synced"); version != "10" {
+		t.Errorf("the synced object should be unchanged, got %v", version)
+	}
+}
@@ -0,0 +1,120 @@
+package clustersynchro
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// The reasons why the resources are added to or removed from the sync.
+const (
+	ResourceSelectedReason   = "Selected"
+	ResourceNotFoundReason   = "ResourceNotFound"
+	VersionNotServedReason   = "VersionNotServed"
+	ResourceSkippedReason    = "Skipped"
+	ResourceUnselectedReason = "Unselected"
+)
+
+// the max number of the changes in the message, the full report is logged.
+const maxReportedChanges = 20
+
+type NegotiationChange struct {
+	schema.GroupVersionResource
+
+	Reason  string
+	Message string
+}
+
+func (c NegotiationChange) String() string {
+	resource := c.GroupVersion().String() + "/" + c.Resource
+	if c.Message == "" {
+		return resource
+	}
+	return fmt.Sprintf("%s (%s: %s)", resource, c.Reason, c.Message)
+}
+
+// NegotiationReport is the diff of the synchronized resources between two negotiations.
+type NegotiationReport struct {
+	Added   []NegotiationChange
+	Removed []NegotiationChange
+}
+
+func (r *NegotiationReport) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0
+}
+
+func (r *NegotiationReport) String() string {
+	var parts []string
+	if len(r.Removed) != 0 {
+		parts = append(parts, "removed "+joinChanges(r.Removed))
+	}
+	if len(r.Added) != 0 {
+		parts = append(parts, "added "+joinChanges(r.Added))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func joinChanges(changes []NegotiationChange) string {
+	var items []string
+	for i, change := range changes {
+		if i == maxReportedChanges {
+			items = append(items, fmt.Sprintf("and %d more", len(changes)-maxReportedChanges))
+			break
+		}
+		items = append(items, change.String())
+	}
+	return strings.Join(items, ", ")
+}
+
+// diffNegotiation compares the resources of the last and the current negotiations,
+// the removed resources are explained by the discovery of the cluster and the skipped resources.
+func diffNegotiation(last, current GVRSet, skipped map[schema.GroupResource]string,
+	lookup func(schema.GroupResource) (*metav1.APIResource, []string)) *NegotiationReport {
+	report := &NegotiationReport{}
+	for gvr := range current {
+		if _, ok := last[gvr]; !ok {
+			report.Added = append(report.Added, NegotiationChange{GroupVersionResource: gvr, Reason: ResourceSelectedReason})
+		}
+	}
+
+	for gvr := range last {
+		if _, ok := current[gvr]; ok {
+			continue
+		}
+
+		change := NegotiationChange{GroupVersionResource: gvr}
+		apiResource, versions := lookup(gvr.GroupResource())
+		reason, isSkipped := skipped[gvr.GroupResource()]
+		switch {
+		case apiResource == nil:
+			change.Reason, change.Message = ResourceNotFoundReason, "the resource is not found in the discovery of the cluster"
+		case !sets.New(versions...).Has(gvr.Version):
+			change.Reason, change.Message = VersionNotServedReason, fmt.Sprintf("the version %s is not served by the cluster", gvr.Version)
+		case isSkipped:
+			change.Reason, change.Message = ResourceSkippedReason, reason
+		default:
+			change.Reason, change.Message = ResourceUnselectedReason, "the resource is not selected by the sync resources"
+		}
+		report.Removed = append(report.Removed, change)
+	}
+
+	sortChanges := func(changes []NegotiationChange) {
+		sort.Slice(changes, func(i, j int) bool {
+			a, b := changes[i].GroupVersionResource, changes[j].GroupVersionResource
+			if a.Group != b.Group {
+				return a.Group < b.Group
+			}
+			if a.Version != b.Version {
+				return a.Version < b.Version
+			}
+			return a.Resource < b.Resource
+		})
+	}
+	sortChanges(report.Added)
+	sortChanges(report.Removed)
+	return report
+}
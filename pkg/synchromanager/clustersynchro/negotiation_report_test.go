@@ -0,0 +1,53 @@
+package clustersynchro
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestDiffNegotiation(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	crontabs := schema.GroupVersionResource{Group: "stable.example.com", Version: "v1", Resource: "crontabs"}
+	ingresses := schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingresses"}
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	leases := schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}
+	configmaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	discovery := map[schema.GroupResource][]string{
+		deployments.GroupResource(): {"v1"},
+		ingresses.GroupResource():   {"v1"},
+		pods.GroupResource():        {"v1"},
+		leases.GroupResource():      {"v1"},
+		configmaps.GroupResource():  {"v1"},
+	}
+	lookup := func(gr schema.GroupResource) (*metav1.APIResource, []string) {
+		versions, ok := discovery[gr]
+		if !ok {
+			return nil, nil
+		}
+		return &metav1.APIResource{Name: gr.Resource}, versions
+	}
+
+	last := NewGVRSet(crontabs, ingresses, pods, leases, configmaps)
+	current := NewGVRSet(pods, deployments)
+	skipped := map[schema.GroupResource]string{leases.GroupResource(): "leases are ignored by the feature gate IgnoreSyncLease"}
+
+	report := diffNegotiation(last, current, skipped, lookup)
+	assert.Equal(t, []NegotiationChange{{GroupVersionResource: deployments, Reason: ResourceSelectedReason}}, report.Added)
+	assert.Equal(t, []NegotiationChange{
+		{GroupVersionResource: configmaps, Reason: ResourceUnselectedReason, Message: "the resource is not selected by the sync resources"},
+		{GroupVersionResource: leases, Reason: ResourceSkippedReason, Message: "leases are ignored by the feature gate IgnoreSyncLease"},
+		{GroupVersionResource: ingresses, Reason: VersionNotServedReason, Message: "the version v1beta1 is not served by the cluster"},
+		{GroupVersionResource: crontabs, Reason: ResourceNotFoundReason, Message: "the resource is not found in the discovery of the cluster"},
+	}, report.Removed)
+
+	assert.Equal(t, "removed v1/configmaps (Unselected: the resource is not selected by the sync resources), "+
+		"coordination.k8s.io/v1/leases (Skipped: leases are ignored by the feature gate IgnoreSyncLease), "+
+		"networking.k8s.io/v1beta1/ingresses (VersionNotServed: the version v1beta1 is not served by the cluster), "+
+		"stable.example.com/v1/crontabs (ResourceNotFound: the resource is not found in the discovery of the cluster); added apps/v1/deployments", report.String())
+
+	assert.True(t, diffNegotiation(current, current, nil, lookup).Empty())
+}
@@ -0,0 +1,36 @@
+package clustersynchro
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// mappedResourceConvertor converts the objects of the resource of the member cluster to the mapped resource,
+// the kind of the objects is kept and only the group and the version of the objects are changed.
+type mappedResourceConvertor struct {
+	mapped schema.GroupVersion
+}
+
+var _ runtime.ObjectConvertor = mappedResourceConvertor{}
+
+func (c mappedResourceConvertor) Convert(in, out, context interface{}) error {
+	return fmt.Errorf("the mapped resource %s can only be converted by version", c.mapped)
+}
+
+func (c mappedResourceConvertor) ConvertToVersion(in runtime.Object, _ runtime.GroupVersioner) (runtime.Object, error) {
+	obj, ok := in.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("%T is not suitable for converting to the mapped resource %s", in, c.mapped)
+	}
+
+	obj = obj.DeepCopy()
+	obj.SetGroupVersionKind(c.mapped.WithKind(obj.GetKind()))
+	return obj, nil
+}
+
+func (c mappedResourceConvertor) ConvertFieldLabel(gvk schema.GroupVersionKind, label, value string) (string, string, error) {
+	return label, value, nil
+}
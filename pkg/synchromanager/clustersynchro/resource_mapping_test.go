@@ -0,0 +1,33 @@
+package clustersynchro
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestMappedResourceConvertor(t *testing.T) {
+	mapped := schema.GroupVersion{Group: "widgets.example.io", Version: "v1"}
+	convertor := mappedResourceConvertor{mapped: mapped}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("widgets.vendor.io/v1beta1")
+	obj.SetKind("Widget")
+	obj.SetName("widget")
+
+	out, err := convertor.ConvertToVersion(obj, mapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gvk := out.GetObjectKind().GroupVersionKind(); gvk != mapped.WithKind("Widget") {
+		t.Errorf("converted gvk = %s, want %s", gvk, mapped.WithKind("Widget"))
+	}
+	if obj.GetAPIVersion() != "widgets.vendor.io/v1beta1" {
+		t.Errorf("the object of the member cluster should not be changed")
+	}
+
+	if _, err := convertor.ConvertToVersion(&unstructured.UnstructuredList{}, mapped); err == nil {
+		t.Errorf("ConvertToVersion() of the list should fail")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/scheme"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/features"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/resourcesynchro"
 	clusterpediafeature "github.com/clusterpedia-io/clusterpedia/pkg/utils/feature"
 )
 
@@ -26,6 +28,11 @@ type ResourceNegotiator struct {
 	dynamicDiscovery       discovery.DynamicDiscoveryInterface
 	resourceConfigFactory  *resourceconfigfactory.ResourceConfigFactory
 	syncAllCustomResources bool
+
+	syncCustomResourceReferences bool
+
+	// settings maps the custom resources to the resources they are stored and served as, it may be nil
+	settings *resourcesynchro.Settings
 }
 
 type syncConfig struct {
@@ -34,13 +41,20 @@ type syncConfig struct {
 	convertor             runtime.ObjectConvertor
 	resourceStorageConfig *storage.ResourceStorageConfig
 	syncEvents            bool
+	skipOwnerKinds        resourcesynchro.OwnerKinds
 }
 
 func (negotiator *ResourceNegotiator) SetSyncAllCustomResources(sync bool) {
 	negotiator.syncAllCustomResources = sync
 }
 
-func (negotiator *ResourceNegotiator) NegotiateSyncResources(syncResources []clusterv1alpha2.ClusterGroupResources) (*GroupResourceStatus, map[schema.GroupVersionResource]syncConfig) {
+func (negotiator *ResourceNegotiator) SetSyncCustomResourceReferences(sync bool) {
+	negotiator.syncCustomResourceReferences = sync
+}
+
+// NegotiateSyncResources negotiates the resources to sync with the discovery of the cluster,
+// the skipped resources are returned with the reasons.
+func (negotiator *ResourceNegotiator) NegotiateSyncResources(syncResources []clusterv1alpha2.ClusterGroupResources) (*GroupResourceStatus, map[schema.GroupVersionResource]syncConfig, map[schema.GroupResource]string) {
 	var syncAllResources bool
 	var watchKubeVersion, watchAggregatorResourceTypes bool
 	for i, syncResource := range syncResources {
@@ -63,6 +77,7 @@ func (negotiator *ResourceNegotiator) NegotiateSyncResources(syncResources []clu
 					klog.InfoS("Skip resource sync", "cluster", negotiator.name, "group", syncResource.Group, "reason", "not match group")
 				} else {
 					syncResourcesByGroup.Versions = syncResource.Versions
+					syncResourcesByGroup.SkipOwnedResources = syncResource.SkipOwnedResources
 					syncResources[i] = *syncResourcesByGroup
 					if groupType == discovery.KubeResource {
 						watchKubeVersion = true
@@ -79,21 +94,28 @@ func (negotiator *ResourceNegotiator) NegotiateSyncResources(syncResources []clu
 		syncResources = negotiator.dynamicDiscovery.AttachAllCustomResourcesToSyncResources(syncResources)
 	}
 
+	if negotiator.syncCustomResourceReferences {
+		syncResources = negotiator.attachCustomResourceReferences(syncResources)
+	}
+
 	// check for changes to the kube native resource types when the cluster version changes
 	negotiator.dynamicDiscovery.WatchServerVersion(watchKubeVersion)
 	negotiator.dynamicDiscovery.WatchAggregatorResourceTypes(watchAggregatorResourceTypes)
 
 	var groupResourceStatus = NewGroupResourceStatus()
 	var storageResourceSyncConfigs = make(map[schema.GroupVersionResource]syncConfig)
+	var skipped = make(map[schema.GroupResource]string)
 	for _, groupResources := range syncResources {
 		events := sets.New(groupResources.EventsInvolvedResources...)
 		for _, resource := range groupResources.Resources {
 			syncGR := schema.GroupResource{Group: groupResources.Group, Resource: resource}
 			syncEvents := events.Has("*") || events.Has(resource)
+			skipOwnerKinds := skipOwnerKindsFor(groupResources.SkipOwnedResources, resource)
 
 			if clusterpediafeature.FeatureGate.Enabled(features.IgnoreSyncLease) {
 				// skip leases.coordination.k8s.io
 				if syncGR.String() == "leases.coordination.k8s.io" {
+					skipped[syncGR] = "leases are ignored by the feature gate IgnoreSyncLease"
 					continue
 				}
 			}
@@ -104,6 +126,7 @@ func (negotiator *ResourceNegotiator) NegotiateSyncResources(syncResources []clu
 			}
 			if !discovery.HasListAndWatchVerbs(*apiResource) {
 				klog.InfoS("Skip resource sync", "cluster", negotiator.name, "resource", resource, "reason", "not support List and Watch", "verbs", apiResource.Verbs)
+				skipped[schema.GroupResource{Group: syncGR.Group, Resource: apiResource.Name}] = "the resource doesn't support List and Watch"
 				continue
 			}
 
@@ -111,6 +134,7 @@ func (negotiator *ResourceNegotiator) NegotiateSyncResources(syncResources []clu
 			syncVersions, isLegacyResource, err := negotiateSyncVersions(syncGK, groupResources.Versions, supportedVersions)
 			if err != nil {
 				klog.InfoS("Skip resource sync", "cluster", negotiator.name, "resource", resource, "reason", err)
+				skipped[schema.GroupResource{Group: syncGR.Group, Resource: apiResource.Name}] = err.Error()
 				continue
 			}
 
@@ -118,29 +142,46 @@ func (negotiator *ResourceNegotiator) NegotiateSyncResources(syncResources []clu
 			// set syncGR.Resource to plural
 			syncGR.Resource = apiResource.Name
 
-			groupResourceStatus.addResource(syncGR, apiResource.Kind, apiResource.Namespaced)
 			for _, version := range syncVersions {
 				syncGVR := syncGR.WithVersion(version)
+
+				// the mapped custom resource is recorded in the status as the mapped resource,
+				// so that it is served as the mapped resource by the apiserver
+				statusGVR, mapped := syncGVR, false
+				if !isLegacyResource {
+					statusGVR, mapped = negotiator.settings.MapResource(syncGVR)
+				}
+				if mapped && scheme.LegacyResourceScheme.IsGroupRegistered(statusGVR.Group) {
+					klog.InfoS("Skip resource sync", "cluster", negotiator.name, "resource", syncGVR, "reason", "mapped to the built-in resource", "mappedResource", statusGVR)
+					skipped[syncGR] = fmt.Sprintf("the resource can not be mapped to the built-in resource %s", statusGVR.GroupResource())
+					continue
+				}
+				if mapped {
+					klog.V(2).InfoS("Map resource sync", "cluster", negotiator.name, "resource", syncGVR, "mappedResource", statusGVR)
+				}
+
+				statusGR := statusGVR.GroupResource()
+				groupResourceStatus.addResource(statusGR, apiResource.Kind, apiResource.Namespaced)
 				syncCondition := clusterv1alpha2.ClusterResourceSyncCondition{
-					Version: syncGVR.Version,
+					Version: statusGVR.Version,
 					Status:  clusterv1alpha2.ResourceSyncStatusPending,
 					Reason:  "SynchroCreating",
 				}
 
-				resourceConfig, err := negotiator.resourceConfigFactory.NewConfig(syncGVR, apiResource.Namespaced)
+				resourceConfig, err := negotiator.resourceConfigFactory.NewConfig(statusGVR, apiResource.Namespaced)
 				if err != nil {
 					syncCondition.Reason = "SynchroCreateFailed"
 					syncCondition.Message = fmt.Sprintf("new resource storage config failed: %s", err)
-					groupResourceStatus.addSyncCondition(syncGVR, syncCondition)
+					groupResourceStatus.addSyncCondition(statusGVR, syncCondition)
 					continue
 				}
 
 				storageGVR := resourceConfig.StorageResource
 				syncCondition.StorageVersion = storageGVR.Version
-				if syncGR != storageGVR.GroupResource() {
+				if statusGR != storageGVR.GroupResource() {
 					syncCondition.StorageResource = storageGVR.GroupResource().String()
 				}
-				groupResourceStatus.addSyncCondition(syncGVR, syncCondition)
+				groupResourceStatus.addSyncCondition(statusGVR, syncCondition)
 
 				if _, ok := storageResourceSyncConfigs[storageGVR]; ok {
 					// if resource's storage resource has been synced, not need to sync this resource.
@@ -148,9 +189,12 @@ func (negotiator *ResourceNegotiator) NegotiateSyncResources(syncResources []clu
 				}
 
 				var convertor runtime.ObjectConvertor
-				if isLegacyResource {
+				switch {
+				case isLegacyResource:
 					convertor = scheme.LegacyResourceScheme
-				} else {
+				case mapped:
+					convertor = mappedResourceConvertor{mapped: statusGVR.GroupVersion()}
+				default:
 					convertor = scheme.UnstructuredScheme
 				}
 				storageResourceSyncConfigs[storageGVR] = syncConfig{
@@ -159,11 +203,58 @@ func (negotiator *ResourceNegotiator) NegotiateSyncResources(syncResources []clu
 					resourceStorageConfig: &storage.ResourceStorageConfig{ResourceConfig: *resourceConfig},
 					convertor:             convertor,
 					syncEvents:            syncEvents,
+					skipOwnerKinds:        skipOwnerKinds,
+				}
+			}
+		}
+	}
+	return groupResourceStatus, storageResourceSyncConfigs, skipped
+}
+
+// attachCustomResourceReferences appends the resources referenced by the synced custom resources,
+// so that the related objects are queryable together with the custom resources.
+func (negotiator *ResourceNegotiator) attachCustomResourceReferences(syncResources []clusterv1alpha2.ClusterGroupResources) []clusterv1alpha2.ClusterGroupResources {
+	synced := sets.New[schema.GroupResource]()
+	for _, groupResources := range syncResources {
+		for _, resource := range groupResources.Resources {
+			synced.Insert(schema.GroupResource{Group: groupResources.Group, Resource: strings.ToLower(resource)})
+		}
+	}
+
+	attached := make([]clusterv1alpha2.ClusterGroupResources, 0, len(syncResources))
+	attached = append(attached, syncResources...)
+	for _, groupResources := range syncResources {
+		if negotiator.dynamicDiscovery.GetGroupType(groupResources.Group) != discovery.CustomResource {
+			continue
+		}
+
+		for _, resource := range groupResources.Resources {
+			for _, reference := range negotiator.dynamicDiscovery.GetCustomResourceReferences(schema.GroupResource{Group: groupResources.Group, Resource: resource}) {
+				if synced.Has(reference) {
+					continue
 				}
+				synced.Insert(reference)
+
+				klog.InfoS("Attach resource sync", "cluster", negotiator.name, "resource", reference, "reason", "referenced by the custom resource",
+					"customResource", schema.GroupResource{Group: groupResources.Group, Resource: resource})
+				attached = append(attached, clusterv1alpha2.ClusterGroupResources{Group: reference.Group, Resources: []string{reference.Resource}})
+			}
+		}
+	}
+	return attached
+}
+
+func skipOwnerKindsFor(filters []clusterv1alpha2.OwnedResourcesFilter, resource string) resourcesynchro.OwnerKinds {
+	var kinds sets.Set[string]
+	for _, filter := range filters {
+		if filter.Resource == "*" || filter.Resource == resource {
+			if kinds == nil {
+				kinds = sets.New[string]()
 			}
+			kinds.Insert(filter.OwnerKinds...)
 		}
 	}
-	return groupResourceStatus, storageResourceSyncConfigs
+	return resourcesynchro.OwnerKinds(kinds)
 }
 
 func negotiateSyncVersions(kind schema.GroupKind, wantVersions []string, supportedVersions []string) ([]string, bool, error) {
@@ -354,6 +445,19 @@ func (s *GroupResourceStatus) GetStorageGVRToSyncGVRs() map[schema.GroupVersionR
 	return gvrMap
 }
 
+func (s *GroupResourceStatus) GetGVRs() GVRSet {
+	if s.concurrentEnabled() {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+	}
+
+	gvrs := NewGVRSet()
+	for gvr := range s.syncConditions {
+		gvrs.Insert(gvr)
+	}
+	return gvrs
+}
+
 func (s *GroupResourceStatus) Merge(other *GroupResourceStatus) GVRSet {
 	if other == nil {
 		return nil
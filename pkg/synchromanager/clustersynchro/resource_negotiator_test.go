@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
@@ -449,3 +450,27 @@ func TestGroupResourceStatus_Merge(t *testing.T) {
 	expectedAddition := NewGVRSet(podGR.WithVersion("v1"), deploymentGR.WithVersion("v1beta2"))
 	assert.Equal(t, expectedAddition, addition)
 }
+
+func TestSkipOwnerKindsFor(t *testing.T) {
+	filters := []clusterv1alpha2.OwnedResourcesFilter{
+		{Resource: "pods", OwnerKinds: []string{"Job.batch"}},
+		{Resource: "*", OwnerKinds: []string{"Workflow"}},
+	}
+
+	newPod := func(apiVersion, kind string) *metav1.ObjectMeta {
+		return &metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: "owner"}}}
+	}
+
+	kinds := skipOwnerKindsFor(filters, "pods")
+	assert.True(t, kinds.Owns(newPod("batch/v1", "Job")))
+	assert.True(t, kinds.Owns(newPod("argoproj.io/v1alpha1", "Workflow")))
+	assert.False(t, kinds.Owns(newPod("example.io/v1", "Job")))
+	assert.False(t, kinds.Owns(newPod("apps/v1", "ReplicaSet")))
+	assert.False(t, kinds.Owns(&metav1.ObjectMeta{}))
+
+	kinds = skipOwnerKindsFor(filters, "configmaps")
+	assert.False(t, kinds.Owns(newPod("batch/v1", "Job")))
+	assert.True(t, kinds.Owns(newPod("argoproj.io/v1alpha1", "Workflow")))
+
+	assert.Nil(t, skipOwnerKindsFor(nil, "pods"))
+}
@@ -0,0 +1,119 @@
+package clustersynchro
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+)
+
+type HealthCheckStrategy string
+
+const (
+	// ReadyzHealthCheck checks the /readyz of the cluster
+	ReadyzHealthCheck HealthCheckStrategy = "readyz"
+
+	// ResourceProbeHealthCheck checks the /readyz, and then lists and watches the probe resource,
+	// a cluster may pass the /readyz while the credentials are invalid or the watches are broken,
+	// for example by a misconfigured webhook.
+	ResourceProbeHealthCheck HealthCheckStrategy = "resource-probe"
+)
+
+type HealthCheckConfig struct {
+	Strategy HealthCheckStrategy
+
+	// ProbeResource is the resource listed and watched by the resource probe, default is the namespaces
+	ProbeResource schema.GroupVersionResource
+}
+
+var defaultProbeResource = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+const (
+	// the watch is stopped by the server after the timeout
+	probeWatchTimeoutSeconds int64 = 10
+
+	// the watch is healthy if it is not closed or failed within the wait
+	probeWatchWait = time.Second
+)
+
+// probeError is the error of the resource probe with the reason of the healthy condition
+type probeError struct {
+	reason string
+	err    error
+}
+
+func (e *probeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *probeError) Unwrap() error {
+	return e.err
+}
+
+type resourceProbeChecker struct {
+	readyz   *readyzChecker
+	client   dynamic.Interface
+	resource schema.GroupVersionResource
+}
+
+func newResourceProbeChecker(config *rest.Config, readyz *readyzChecker, resource schema.GroupVersionResource) (*resourceProbeChecker, error) {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if resource.Empty() {
+		resource = defaultProbeResource
+	}
+	return &resourceProbeChecker{readyz: readyz, client: client, resource: resource}, nil
+}
+
+func (checker *resourceProbeChecker) Ready(ctx context.Context) (bool, error) {
+	if ready, err := checker.readyz.Ready(ctx); !ready {
+		return ready, err
+	}
+
+	resource := checker.resource.GroupResource().String()
+	list, err := checker.client.Resource(checker.resource).List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		if apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err) {
+			return false, &probeError{reason: clusterv1alpha2.ClusterUnauthorizedReason, err: fmt.Errorf("failed to list the probe resource %s: %w", resource, err)}
+		}
+		return false, fmt.Errorf("failed to list the probe resource %s: %w", resource, err)
+	}
+
+	timeout := probeWatchTimeoutSeconds
+	watcher, err := checker.client.Resource(checker.resource).Watch(ctx, metav1.ListOptions{
+		ResourceVersion: list.GetResourceVersion(),
+		TimeoutSeconds:  &timeout,
+	})
+	if err != nil {
+		return false, &probeError{reason: clusterv1alpha2.ClusterWatchFailedReason, err: fmt.Errorf("failed to watch the probe resource %s: %w", resource, err)}
+	}
+	defer watcher.Stop()
+
+	timer := time.NewTimer(probeWatchWait)
+	defer timer.Stop()
+	for {
+		select {
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return false, &probeError{reason: clusterv1alpha2.ClusterWatchFailedReason, err: fmt.Errorf("the watch of the probe resource %s is closed unexpectedly", resource)}
+			}
+			if event.Type == watch.Error {
+				return false, &probeError{reason: clusterv1alpha2.ClusterWatchFailedReason, err: fmt.Errorf("the watch of the probe resource %s failed: %w", resource, apierrors.FromObject(event.Object))}
+			}
+		case <-timer.C:
+			return true, nil
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package clustersynchro
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+)
+
+func TestResourceProbeChecker(t *testing.T) {
+	namespaceList := `{"kind":"NamespaceList","apiVersion":"v1","metadata":{"resourceVersion":"10"},"items":[]}`
+	forbidden := `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403}`
+	watchError := `{"type":"ERROR","object":{"kind":"Status","apiVersion":"v1","status":"Failure","message":"admission webhook denied the request","reason":"InternalError","code":500}}`
+
+	tests := []struct {
+		name   string
+		list   func(w http.ResponseWriter)
+		watch  func(w http.ResponseWriter, req *http.Request)
+		ready  bool
+		reason string
+	}{
+		{
+			name: "healthy",
+			list: func(w http.ResponseWriter) { _, _ = w.Write([]byte(namespaceList)) },
+			watch: func(w http.ResponseWriter, req *http.Request) {
+				w.(http.Flusher).Flush()
+				<-req.Context().Done()
+			},
+			ready: true,
+		},
+		{
+			name: "forbidden",
+			list: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(forbidden))
+			},
+			reason: clusterv1alpha2.ClusterUnauthorizedReason,
+		},
+		{
+			name: "watch failed",
+			list: func(w http.ResponseWriter) { _, _ = w.Write([]byte(namespaceList)) },
+			watch: func(w http.ResponseWriter, req *http.Request) {
+				_, _ = w.Write([]byte(watchError))
+				w.(http.Flusher).Flush()
+				<-req.Context().Done()
+			},
+			reason: clusterv1alpha2.ClusterWatchFailedReason,
+		},
+		{
+			name:   "watch closed",
+			list:   func(w http.ResponseWriter) { _, _ = w.Write([]byte(namespaceList)) },
+			watch:  func(w http.ResponseWriter, req *http.Request) {},
+			reason: clusterv1alpha2.ClusterWatchFailedReason,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case req.URL.Path == "/readyz":
+					_, _ = w.Write([]byte("ok"))
+				case req.URL.Path == "/api/v1/namespaces" && req.URL.Query().Get("watch") == "true":
+					test.watch(w, req)
+				case req.URL.Path == "/api/v1/namespaces":
+					test.list(w)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			checker, err := newHealthChecker(&rest.Config{Host: server.URL}, HealthCheckConfig{Strategy: ResourceProbeHealthCheck})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			ready, err := checker.Ready(ctx)
+			if ready != test.ready {
+				t.Fatalf("Ready() = %v, %v, want %v", ready, err, test.ready)
+			}
+
+			var probeErr *probeError
+			if test.reason != "" && (!errors.As(err, &probeErr) || probeErr.reason != test.reason) {
+				t.Fatalf("Ready() returns the error %v, want the reason %s", err, test.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package clustersynchro
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/resourcesynchro"
+)
+
+var _ resourcesynchro.Resyncer = &resourceSynchro{}
+
+// Resync rewrites the stored objects of the resource or the namespace by the verification relist,
+// the relist is delayed until it is admitted by the consistency checker like the drift checks.
+func (synchro *resourceSynchro) Resync(namespace string) {
+	go synchro.resync(namespace)
+}
+
+func (synchro *resourceSynchro) resync(namespace string) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-synchro.closer:
+			return
+		case <-timer.C:
+		}
+		timer.Reset(resourcesynchro.VerificationRelistRetryPeriod)
+
+		if synchro.initialListPhase.Load() || !synchro.isRunnableForStorage.Load() {
+			continue
+		}
+		done, ok := synchro.consistency.TryStartRelist()
+		if !ok {
+			continue
+		}
+
+		resynced, err := synchro.markResync(synchro.ctx, namespace)
+		if err != nil {
+			done()
+			klog.ErrorS(err, "Failed to resync the resource", "cluster", synchro.cluster, "resource", synchro.syncResource, "namespace", namespace)
+			continue
+		}
+		if !synchro.triggerRelist(done) {
+			done()
+			continue
+		}
+
+		klog.InfoS("Start the relist to resync the resource", "cluster", synchro.cluster, "resource", synchro.syncResource,
+			"namespace", namespace, "objects", resynced)
+		synchro.metricsWrapper.Counter(verificationRelistsCounter).Inc()
+		return
+	}
+}
+
+// markResync marks the stored and the cached objects of the namespace as stale in the cached resource versions,
+// so the relist rewrites the objects of the member cluster and deletes the others. The hashes of the fields of
+// the objects are dropped, so the objects are fully rewritten instead of being patched.
+// It returns the number of the marked objects.
+func (synchro *resourceSynchro) markResync(ctx context.Context, namespace string) (int, error) {
+	stored, err := synchro.listStoredVersions(ctx, namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	synchro.rvsLock.Lock()
+	defer synchro.rvsLock.Unlock()
+	versions := synchro.cache
+	if versions == nil {
+		// the cached resource versions are rebuilt from the stored objects when the informer is started
+		return 0, errors.New("the informer of the resource is not started")
+	}
+
+	keys := versions.ListKeys()
+	for key := range stored {
+		keys = append(keys, key)
+	}
+	marked := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if ns, _, _ := cache.SplitMetaNamespaceKey(key); namespace != "" && ns != namespace {
+			continue
+		}
+		versions.UpdateVersion(key, "")
+		delete(synchro.fieldHashes, key)
+		marked[key] = struct{}{}
+	}
+	return len(marked), nil
+}
@@ -0,0 +1,84 @@
+package clustersynchro
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/informer"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type fakeResyncStorage struct {
+	storage.ResourceStorage
+	versions map[string]string // key -> resource version
+}
+
+func (s *fakeResyncStorage) List(_ context.Context, listObj runtime.Object, opts *internal.ListOptions) error {
+	list := listObj.(*unstructured.UnstructuredList)
+	for key, version := range s.versions {
+		namespace, name, _ := cache.SplitMetaNamespaceKey(key)
+		if len(opts.Namespaces) != 0 && !slices.Contains(opts.Namespaces, namespace) {
+			continue
+		}
+		obj := unstructured.Unstructured{}
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		obj.SetResourceVersion(version)
+		list.Items = append(list.Items, obj)
+	}
+	return nil
+}
+
+func TestMarkResync(t *testing.T) {
+	newSynchro := func() *resourceSynchro {
+		synchro := &resourceSynchro{
+			cluster: "cluster-1",
+			storage: &fakeResyncStorage{versions: map[string]string{"default/synced": "10", "ci/synced": "11", "ci/phantom": "12"}},
+			cache:   informer.NewResourceVersionStorage(),
+			fieldHashes: map[string]map[string]uint64{
+				"default/synced": {"spec": 1}, "ci/synced": {"spec": 2},
+			},
+		}
+		_ = synchro.cache.Replace(map[string]interface{}{"default/synced": "10", "ci/synced": "11", "ci/cached": "13"})
+		return synchro
+	}
+
+	synchro := newSynchro()
+	marked, err := synchro.markResync(context.Background(), "ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marked != 3 {
+		t.Errorf("markResync() = %d, want 3", marked)
+	}
+	for _, key := range []string{"ci/synced", "ci/phantom", "ci/cached"} {
+		if version, exists, _ := synchro.cache.GetByKey(key); !exists || version != "" {
+			t.Errorf("%s should be outdated to be rewritten or deleted by the relist, got %v, %v", key, version, exists)
+		}
+	}
+	if version, _, _ := synchro.cache.GetByKey("default/synced"); version != "10" {
+		t.Errorf("the object out of the namespace should be unchanged, got %v", version)
+	}
+	if _, ok := synchro.fieldHashes["ci/synced"]; ok {
+		t.Error("the field hashes of the marked object should be dropped, so it is fully rewritten")
+	}
+	if _, ok := synchro.fieldHashes["default/synced"]; !ok {
+		t.Error("the field hashes of the object out of the namespace should be kept")
+	}
+
+	synchro = newSynchro()
+	if marked, err := synchro.markResync(context.Background(), ""); err != nil || marked != 4 {
+		t.Errorf("markResync() = %d, %v, want all 4 objects", marked, err)
+	}
+
+	synchro.cache = nil
+	if _, err := synchro.markResync(context.Background(), ""); err == nil {
+		t.Error("the resync should fail before the informer is started")
+	}
+}
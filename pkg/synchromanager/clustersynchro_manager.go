@@ -6,7 +6,9 @@ import (
 	"math"
 	"math/rand"
 	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,9 +34,12 @@ import (
 	"github.com/clusterpedia-io/clusterpedia/pkg/generated/informers/externalversions"
 	clusterlister "github.com/clusterpedia-io/clusterpedia/pkg/generated/listers/cluster/v1alpha2"
 	kubestatemetrics "github.com/clusterpedia-io/clusterpedia/pkg/kube_state_metrics"
+	"github.com/clusterpedia-io/clusterpedia/pkg/runtime/configuration"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/clustersynchro"
 	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/features"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/profiles"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/resourcesynchro"
 	"github.com/clusterpedia-io/clusterpedia/pkg/utils"
 	clusterpediafeature "github.com/clusterpedia-io/clusterpedia/pkg/utils/feature"
 )
@@ -65,6 +70,9 @@ type Manager struct {
 	synchroWaitGroup  wait.Group
 
 	clusterSecretsMap sync.Map
+
+	// storageHealth is the last result of MonitorStorageHealth, nil if the storage has not been checked yet
+	storageHealth atomic.Pointer[storageHealth]
 }
 
 var _ kubestatemetrics.ClusterMetricsWriterListGetter = &Manager{}
@@ -74,6 +82,12 @@ func NewManager(client kubernetes.Interface, clusterpediaClient crdclientset.Int
 	clusterinformer := factory.Cluster().V1alpha2().PediaClusters()
 	clusterSyncResourcesInformer := factory.Cluster().V1alpha2().ClusterSyncResources()
 
+	if syncConfig.ConfigurationName != "" {
+		syncConfig.Settings = &resourcesynchro.Settings{}
+		configuration.NewWatcher(syncConfig.ConfigurationName, factory.Config().V1alpha1().ClusterpediaConfigurations()).
+			AddHandler(syncConfig.Settings.Update)
+	}
+
 	manager := &Manager{
 		informerFactory:    factory,
 		clusterpediaclient: clusterpediaClient,
@@ -240,6 +254,8 @@ func (manager *Manager) updateCluster(older, newer interface{}) {
 	newObj := newer.(*clusterv1alpha2.PediaCluster)
 	if newObj.DeletionTimestamp.IsZero() &&
 		equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec) &&
+		oldObj.Annotations[clusterv1alpha2.ConfirmDeletionsAnnotation] == newObj.Annotations[clusterv1alpha2.ConfirmDeletionsAnnotation] &&
+		oldObj.Annotations[clusterv1alpha2.ResyncAnnotation] == newObj.Annotations[clusterv1alpha2.ResyncAnnotation] &&
 		oldObj.Status.ShardingName == newObj.Status.ShardingName {
 		return
 	}
@@ -423,7 +439,16 @@ func (manager *Manager) reconcileCluster(cluster *clusterv1alpha2.PediaCluster)
 	}
 
 	var warnMsg string
-	syncResources := cluster.Spec.SyncResources
+	syncResources, prune := cluster.Spec.SyncResources, cluster.Spec.Prune
+	if name := cluster.Spec.SyncProfile; name != "" {
+		profile, ok := profiles.Get(name)
+		if !ok {
+			manager.UpdateClusterAPIServerAndValidatedCondition(cluster.Name, config.Host, synchro, clusterv1alpha2.InvalidSyncResourcesReason,
+				fmt.Sprintf("unknown sync profile %q, the profiles are %s", name, strings.Join(profiles.Names(), ", ")), metav1.ConditionFalse)
+			return controller.NoRequeueResult
+		}
+		syncResources, prune = profile.Apply(syncResources, prune)
+	}
 	if refName := cluster.Spec.SyncResourcesRefName; refName != "" {
 		if ref, err := manager.clusterSyncResourcesLister.Get(refName); err != nil {
 			if !apierrors.IsNotFound(err) {
@@ -523,7 +548,11 @@ func (manager *Manager) reconcileCluster(cluster *clusterv1alpha2.PediaCluster)
 		manager.synchrolock.Unlock()
 	}
 
-	synchro.SetResources(syncResources, cluster.Spec.SyncAllCustomResources)
+	synchro.SetResources(syncResources, cluster.Spec.SyncAllCustomResources, cluster.Spec.SyncCustomResourceReferences)
+	synchro.SetClusterLabels(cluster.Labels)
+	synchro.SetClusterPrune(prune)
+	synchro.SetDeletionConfirmation(cluster.Annotations[clusterv1alpha2.ConfirmDeletionsAnnotation])
+	synchro.SetResyncRequest(cluster.Annotations[clusterv1alpha2.ResyncAnnotation])
 	return controller.NoRequeueResult
 }
 
@@ -551,9 +580,12 @@ func (manager *Manager) removeCluster(name string) error {
 		// and ensure that no more data is being synchronized to the resource storage
 		synchro.Shutdown(false)
 	}
+	clustersynchro.DeleteClusterUsageMetrics(name)
 
 	// clean cluster from storage
-	return manager.storage.CleanCluster(context.TODO(), name)
+	ctx, cancel := context.WithTimeout(context.Background(), clustersynchro.BulkStorageTimeout)
+	defer cancel()
+	return manager.storage.CleanCluster(ctx, name)
 }
 
 func (manager *Manager) UpdateClusterAPIServerAndValidatedCondition(name string, apiServerEndpoint string, synchro *clustersynchro.ClusterSynchro, reason, message string, status metav1.ConditionStatus) {
@@ -614,6 +646,9 @@ func (manager *Manager) UpdateClusterStatus(ctx context.Context, name string, st
 		if status.SyncResources != nil {
 			clusterStatus.SyncResources = status.SyncResources
 		}
+		if status.Connection != nil {
+			clusterStatus.Connection = status.Connection
+		}
 		for _, condition := range status.Conditions {
 			meta.SetStatusCondition(&clusterStatus.Conditions, condition)
 		}
@@ -634,6 +669,16 @@ func (manager *Manager) updateClusterStatus(ctx context.Context, name string, up
 		// remove deprecated conditions
 		meta.RemoveStatusCondition(&cluster.Status.Conditions, clusterv1alpha2.ClusterSynchroInitializedCondition)
 
+		condTypes := []string{
+			clusterv1alpha2.ValidatedCondition,
+			clusterv1alpha2.SynchroRunningCondition,
+			clusterv1alpha2.ClusterHealthyCondition,
+		}
+		if condition, ok := manager.storageHealthyCondition(); ok {
+			meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+			condTypes = append(condTypes, clusterv1alpha2.StorageHealthyCondition)
+		}
+
 		// TODO: need optimize?
 		readyCondition := metav1.Condition{
 			Type:    clusterv1alpha2.ReadyCondition,
@@ -641,11 +686,7 @@ func (manager *Manager) updateClusterStatus(ctx context.Context, name string, up
 			Reason:  clusterv1alpha2.ReadyReason,
 			Message: "",
 		}
-		for _, condType := range []string{
-			clusterv1alpha2.ValidatedCondition,
-			clusterv1alpha2.SynchroRunningCondition,
-			clusterv1alpha2.ClusterHealthyCondition,
-		} {
+		for _, condType := range condTypes {
 			cond := meta.FindStatusCondition(cluster.Status.Conditions, condType)
 			if cond != nil && cond.Status == metav1.ConditionTrue {
 				continue
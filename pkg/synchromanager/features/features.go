@@ -74,6 +74,13 @@ const (
 	// owner: @scydas
 	// alpha: v0.9.0
 	ClusterAuthenticationFromSecret featuregate.Feature = "ClusterAuthenticationFromSecret"
+
+	// PatchUpdateForResourceSync is a feature gate for the ClusterSynchro to update only the changed top-level fields of the stored objects,
+	// the hashes of the top-level fields of the stored objects are kept in memory to compute the changed fields.
+	// It takes effect only if the storage supports the patch.
+	//
+	// alpha: v0.9.0
+	PatchUpdateForResourceSync featuregate.Feature = "PatchUpdateForResourceSync"
 )
 
 func init() {
@@ -93,4 +100,5 @@ var defaultClusterSynchroManagerFeatureGates = map[featuregate.Feature]featurega
 	StreamHandlePaginatedListForResourceSync: {Default: false, PreRelease: featuregate.Alpha},
 	IgnoreSyncLease:                          {Default: false, PreRelease: featuregate.Alpha},
 	ClusterAuthenticationFromSecret:          {Default: false, PreRelease: featuregate.Alpha},
+	PatchUpdateForResourceSync:               {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -0,0 +1,129 @@
+package profiles
+
+import (
+	"sort"
+
+	"k8s.io/utils/ptr"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+)
+
+const (
+	Inventory = "inventory"
+	Workloads = "workloads"
+	Full      = "full"
+)
+
+// Profile is a built-in sync profile referenced by the `spec.syncProfile` of the PediaCluster,
+// it ships a curated set of resources and the prune configuration of them.
+//
+// The retention of the deleted objects is not a part of the profile,
+// it is still set by the retention policies of the clustersynchro manager.
+type Profile struct {
+	Name        string
+	Description string
+
+	SyncResources []clusterv1alpha2.ClusterGroupResources
+	Prune         *clusterv1alpha2.ClusterPruneConfiguration
+}
+
+var profiles = map[string]Profile{
+	Inventory: {
+		Name:        Inventory,
+		Description: "the nodes, namespaces and the workloads, without the status fields that change frequently",
+		SyncResources: []clusterv1alpha2.ClusterGroupResources{
+			{Group: "", Resources: []string{"nodes", "namespaces"}},
+			{Group: "apps", Resources: []string{"deployments", "statefulsets", "daemonsets"}},
+			{Group: "batch", Resources: []string{"jobs", "cronjobs"}},
+		},
+		Prune: &clusterv1alpha2.ClusterPruneConfiguration{
+			ManagedFields:            ptr.To(true),
+			LastAppliedConfiguration: ptr.To(true),
+			Resources: []clusterv1alpha2.ResourcePruneConfiguration{
+				{Group: "", Resource: "nodes", Fields: []string{"status.images", "status.conditions"}},
+			},
+		},
+	},
+	Workloads: {
+		Name:        Workloads,
+		Description: "the workloads with their pods, services, configmaps and the events of them",
+		SyncResources: []clusterv1alpha2.ClusterGroupResources{
+			{Group: "", Resources: []string{"namespaces", "pods", "services", "configmaps", "serviceaccounts", "persistentvolumeclaims"}},
+			{Group: "apps", Resources: []string{"deployments", "replicasets", "statefulsets", "daemonsets"}, EventsInvolvedResources: []string{"*"}},
+			{Group: "batch", Resources: []string{"jobs", "cronjobs"}},
+			{Group: "autoscaling", Resources: []string{"horizontalpodautoscalers"}},
+			{Group: "networking.k8s.io", Resources: []string{"ingresses"}},
+		},
+		Prune: &clusterv1alpha2.ClusterPruneConfiguration{
+			ManagedFields:            ptr.To(true),
+			LastAppliedConfiguration: ptr.To(true),
+		},
+	},
+	Full: {
+		Name:        Full,
+		Description: "all resources of the cluster, it requires the `AllowSyncAllResources` feature gate",
+		SyncResources: []clusterv1alpha2.ClusterGroupResources{
+			{Group: "*", Resources: []string{"*"}},
+		},
+		Prune: &clusterv1alpha2.ClusterPruneConfiguration{
+			ManagedFields:            ptr.To(true),
+			LastAppliedConfiguration: ptr.To(true),
+		},
+	},
+}
+
+// Get returns a copy of the profile, so the caller can modify it.
+func Get(name string) (Profile, bool) {
+	profile, ok := profiles[name]
+	if !ok {
+		return Profile{}, false
+	}
+
+	resources := make([]clusterv1alpha2.ClusterGroupResources, 0, len(profile.SyncResources))
+	for _, groupResources := range profile.SyncResources {
+		resources = append(resources, *groupResources.DeepCopy())
+	}
+	profile.SyncResources = resources
+	profile.Prune = profile.Prune.DeepCopy()
+	return profile, true
+}
+
+// Names returns the sorted names of the profiles
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Apply returns the sync resources and the prune configuration of the cluster based on the profile.
+//
+// The resources of the cluster are synchronized in addition to the resources of the profile.
+// The prune configuration of the cluster overrides the profile, the fields of them are combined,
+// and the resources of the cluster take precedence over the resources of the profile.
+func (p Profile) Apply(resources []clusterv1alpha2.ClusterGroupResources, prune *clusterv1alpha2.ClusterPruneConfiguration) (
+	[]clusterv1alpha2.ClusterGroupResources, *clusterv1alpha2.ClusterPruneConfiguration) {
+	syncResources := make([]clusterv1alpha2.ClusterGroupResources, 0, len(p.SyncResources)+len(resources))
+	syncResources = append(syncResources, p.SyncResources...)
+	syncResources = append(syncResources, resources...)
+
+	if p.Prune == nil {
+		return syncResources, prune
+	}
+	merged := p.Prune.DeepCopy()
+	if prune != nil {
+		if prune.ManagedFields != nil {
+			merged.ManagedFields = ptr.To(*prune.ManagedFields)
+		}
+		if prune.LastAppliedConfiguration != nil {
+			merged.LastAppliedConfiguration = ptr.To(*prune.LastAppliedConfiguration)
+		}
+		merged.Fields = append(merged.Fields, prune.Fields...)
+		for _, resource := range prune.Resources {
+			merged.Resources = append(merged.Resources, *resource.DeepCopy())
+		}
+	}
+	return syncResources, merged
+}
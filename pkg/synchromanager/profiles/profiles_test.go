@@ -0,0 +1,85 @@
+package profiles
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+)
+
+func TestGet(t *testing.T) {
+	for _, name := range Names() {
+		profile, ok := Get(name)
+		if !ok || profile.Name != name || len(profile.SyncResources) == 0 {
+			t.Errorf("invalid profile %q: %+v", name, profile)
+		}
+	}
+	if !reflect.DeepEqual(Names(), []string{Full, Inventory, Workloads}) {
+		t.Errorf("unexpected profiles: %v", Names())
+	}
+	if _, ok := Get("unknown"); ok {
+		t.Error("unknown profile should not be found")
+	}
+
+	profile, _ := Get(Inventory)
+	profile.SyncResources[0].Resources[0] = "secrets"
+	*profile.Prune.ManagedFields = false
+	if profile, _ := Get(Inventory); profile.SyncResources[0].Resources[0] != "nodes" || !*profile.Prune.ManagedFields {
+		t.Error("the built-in profile should not be modified by the caller")
+	}
+}
+
+func TestApply(t *testing.T) {
+	profile := Profile{
+		SyncResources: []clusterv1alpha2.ClusterGroupResources{{Group: "apps", Resources: []string{"deployments"}}},
+		Prune: &clusterv1alpha2.ClusterPruneConfiguration{
+			ManagedFields:            ptr.To(true),
+			LastAppliedConfiguration: ptr.To(true),
+			Fields:                   []string{"status.conditions"},
+			Resources: []clusterv1alpha2.ResourcePruneConfiguration{
+				{Resource: "nodes", Fields: []string{"status.images"}},
+			},
+		},
+	}
+
+	resources, prune := profile.Apply(
+		[]clusterv1alpha2.ClusterGroupResources{{Group: "", Resources: []string{"pods"}}},
+		&clusterv1alpha2.ClusterPruneConfiguration{
+			LastAppliedConfiguration: ptr.To(false),
+			Fields:                   []string{"metadata.labels"},
+			Resources: []clusterv1alpha2.ResourcePruneConfiguration{
+				{Resource: "nodes", ManagedFields: ptr.To(false)},
+			},
+		},
+	)
+
+	expectedResources := []clusterv1alpha2.ClusterGroupResources{
+		{Group: "apps", Resources: []string{"deployments"}},
+		{Group: "", Resources: []string{"pods"}},
+	}
+	if !reflect.DeepEqual(resources, expectedResources) {
+		t.Errorf("expected resources %v, but got %v", expectedResources, resources)
+	}
+
+	expectedPrune := &clusterv1alpha2.ClusterPruneConfiguration{
+		ManagedFields:            ptr.To(true),
+		LastAppliedConfiguration: ptr.To(false),
+		Fields:                   []string{"status.conditions", "metadata.labels"},
+		Resources: []clusterv1alpha2.ResourcePruneConfiguration{
+			{Resource: "nodes", Fields: []string{"status.images"}},
+			{Resource: "nodes", ManagedFields: ptr.To(false)},
+		},
+	}
+	if !reflect.DeepEqual(prune, expectedPrune) {
+		t.Errorf("expected prune %+v, but got %+v", expectedPrune, prune)
+	}
+	if len(profile.Prune.Fields) != 1 || *profile.Prune.LastAppliedConfiguration != true {
+		t.Error("the prune of the profile should not be modified")
+	}
+
+	if _, prune := profile.Apply(nil, nil); !reflect.DeepEqual(prune, profile.Prune) {
+		t.Errorf("expected the prune of the profile, but got %+v", prune)
+	}
+}
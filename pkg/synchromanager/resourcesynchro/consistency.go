@@ -0,0 +1,83 @@
+package resourcesynchro
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// VerificationRelistJitter is the max jitter factor of the verification relist interval,
+// so the resources of the clusters started together are not relisted at the same time.
+const VerificationRelistJitter = 0.2
+
+// VerificationRelistRetryPeriod is the period to retry the verification relist
+// which is not admitted by the ConsistencyChecker.
+const VerificationRelistRetryPeriod = time.Minute
+
+// NextVerificationRelist returns the jittered wait before the next verification relist.
+func NextVerificationRelist(interval time.Duration) time.Duration {
+	return wait.Jitter(interval, VerificationRelistJitter)
+}
+
+// ConsistencyChecker coordinates the verification relists of the resources of a cluster.
+//
+// The verification relist is a low-frequency full relist of a resource which repairs the divergence
+// from the missed watch events. It is admitted only when none of the resources of the cluster are
+// in the initial sync, and only one resource of the cluster is relisted at a time,
+// so the relists never add pressure on the member cluster and the storage during the initial syncs.
+//
+// A nil ConsistencyChecker admits no relists.
+type ConsistencyChecker struct {
+	lock         sync.Mutex
+	initialSyncs int
+	relisting    bool
+}
+
+func NewConsistencyChecker() *ConsistencyChecker {
+	return &ConsistencyChecker{}
+}
+
+// StartInitialSync records the initial sync of a resource, done is called when the initial sync is finished or stopped.
+func (c *ConsistencyChecker) StartInitialSync() (done func()) {
+	if c == nil {
+		return func() {}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.initialSyncs++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			c.initialSyncs--
+		})
+	}
+}
+
+// TryStartRelist admits the verification relist of a resource,
+// done is called when the relist is finished or stopped, it is nil if the relist is not admitted.
+func (c *ConsistencyChecker) TryStartRelist() (done func(), ok bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.initialSyncs > 0 || c.relisting {
+		return nil, false
+	}
+	c.relisting = true
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			c.relisting = false
+		})
+	}, true
+}
@@ -0,0 +1,46 @@
+package resourcesynchro
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConsistencyChecker(t *testing.T) {
+	checker := NewConsistencyChecker()
+
+	initialSyncDone := checker.StartInitialSync()
+	if _, ok := checker.TryStartRelist(); ok {
+		t.Fatal("the relist should not be admitted during the initial sync")
+	}
+	initialSyncDone()
+	initialSyncDone()
+
+	relistDone, ok := checker.TryStartRelist()
+	if !ok {
+		t.Fatal("the relist should be admitted after the initial sync")
+	}
+	if _, ok := checker.TryStartRelist(); ok {
+		t.Fatal("only one relist should be admitted at a time")
+	}
+	relistDone()
+	if done, ok := checker.TryStartRelist(); !ok {
+		t.Fatal("the relist should be admitted after the previous relist")
+	} else {
+		done()
+	}
+
+	var nilChecker *ConsistencyChecker
+	nilChecker.StartInitialSync()()
+	if _, ok := nilChecker.TryStartRelist(); ok {
+		t.Fatal("the nil checker should not admit the relist")
+	}
+}
+
+func TestNextVerificationRelist(t *testing.T) {
+	interval := time.Hour
+	for i := 0; i < 10; i++ {
+		if next := NextVerificationRelist(interval); next < interval || next > interval+time.Duration(float64(interval)*VerificationRelistJitter) {
+			t.Fatalf("NextVerificationRelist() = %s, want within the jitter of %s", next, interval)
+		}
+	}
+}
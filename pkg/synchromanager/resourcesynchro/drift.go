@@ -0,0 +1,104 @@
+package resourcesynchro
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DriftCheckConfig configures the checks which compare the objects stored for a resource
+// with a live list of the member cluster, the phantom objects left in the storage after
+// the network partitions are found by them.
+type DriftCheckConfig struct {
+	// Interval is the jittered interval of the checks, 0 disables the checks.
+	Interval time.Duration
+
+	// Resources are the checked resources, all resources are checked if it is empty.
+	Resources []schema.GroupResource
+
+	// Repair repairs the drifted objects by the verification relist of the resource,
+	// otherwise the drifts are only reported.
+	Repair bool
+}
+
+func (c DriftCheckConfig) Enabled() bool {
+	return c.Interval > 0
+}
+
+// Checks returns true if the drift of the resource is checked.
+func (c DriftCheckConfig) Checks(gr schema.GroupResource) bool {
+	if !c.Enabled() {
+		return false
+	}
+	if len(c.Resources) == 0 {
+		return true
+	}
+	for _, resource := range c.Resources {
+		if resource == gr {
+			return true
+		}
+	}
+	return false
+}
+
+// Drift is the difference between the stored objects and the objects of the member cluster,
+// the objects are the keys formatted as `<namespace>/<name>` or `<name>`.
+type Drift struct {
+	// Phantoms are stored, but they don't exist in the member cluster.
+	Phantoms []string
+
+	// Missing exist in the member cluster, but they are not stored.
+	Missing []string
+
+	// Stale are stored with the resource versions older than the member cluster.
+	Stale []string
+}
+
+func (d Drift) Len() int {
+	return len(d.Phantoms) + len(d.Missing) + len(d.Stale)
+}
+
+// DiffResourceVersions returns the drift between the resource versions of the stored objects and
+// the objects listed from the member cluster at the listResourceVersion, keyed by the object keys.
+//
+// The storage is expected to be listed after the member cluster, so the objects changed after the list are not drifts:
+// the objects stored with the resource versions newer than the list are not phantoms,
+// and the objects stored with the resource versions newer than the listed ones are not stale.
+// The resource versions that cannot be parsed are compared as opaque strings.
+func DiffResourceVersions(stored, live map[string]string, listResourceVersion string) Drift {
+	listed, listedErr := strconv.ParseUint(listResourceVersion, 10, 64)
+
+	var drift Drift
+	for key, storedVersion := range stored {
+		liveVersion, ok := live[key]
+		if !ok {
+			if version, err := strconv.ParseUint(storedVersion, 10, 64); err == nil && listedErr == nil && version > listed {
+				continue
+			}
+			drift.Phantoms = append(drift.Phantoms, key)
+			continue
+		}
+
+		if storedVersion == liveVersion {
+			continue
+		}
+		s, sErr := strconv.ParseUint(storedVersion, 10, 64)
+		l, lErr := strconv.ParseUint(liveVersion, 10, 64)
+		if sErr == nil && lErr == nil && s > l {
+			continue
+		}
+		drift.Stale = append(drift.Stale, key)
+	}
+	for key := range live {
+		if _, ok := stored[key]; !ok {
+			drift.Missing = append(drift.Missing, key)
+		}
+	}
+
+	sort.Strings(drift.Phantoms)
+	sort.Strings(drift.Missing)
+	sort.Strings(drift.Stale)
+	return drift
+}
@@ -0,0 +1,63 @@
+package resourcesynchro
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestDiffResourceVersions(t *testing.T) {
+	stored := map[string]string{
+		"default/synced":    "10",
+		"default/phantom":   "11",
+		"default/stale":     "12",
+		"default/updated":   "30", // updated after the list
+		"default/created":   "31", // created after the list
+		"default/opaque":    "a",
+		"default/unchanged": "b",
+	}
+	live := map[string]string{
+		"default/synced":    "10",
+		"default/stale":     "15",
+		"default/updated":   "16",
+		"default/missing":   "17",
+		"default/opaque":    "c",
+		"default/unchanged": "b",
+	}
+
+	drift := DiffResourceVersions(stored, live, "20")
+	expected := Drift{
+		Phantoms: []string{"default/phantom"},
+		Missing:  []string{"default/missing"},
+		Stale:    []string{"default/opaque", "default/stale"},
+	}
+	if !reflect.DeepEqual(drift, expected) {
+		t.Errorf("DiffResourceVersions() = %+v, want %+v", drift, expected)
+	}
+	if drift.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", drift.Len())
+	}
+
+	if drift := DiffResourceVersions(map[string]string{"default/created": "31"}, nil, ""); len(drift.Phantoms) != 1 {
+		t.Errorf("the objects should be compared without the resource version of the list: %+v", drift)
+	}
+}
+
+func TestDriftCheckConfig(t *testing.T) {
+	deployments := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	pods := schema.GroupResource{Resource: "pods"}
+
+	if (DriftCheckConfig{}).Checks(pods) {
+		t.Error("the disabled checks should check no resources")
+	}
+	if !(DriftCheckConfig{Interval: time.Hour}).Checks(pods) {
+		t.Error("the checks without the resources should check all resources")
+	}
+
+	config := DriftCheckConfig{Interval: time.Hour, Resources: []schema.GroupResource{deployments}}
+	if !config.Checks(deployments) || config.Checks(pods) {
+		t.Error("only the selected resources should be checked")
+	}
+}
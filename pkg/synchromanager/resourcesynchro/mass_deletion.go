@@ -0,0 +1,116 @@
+package resourcesynchro
+
+import (
+	"sync"
+	"time"
+)
+
+// DeletionsFrozenReason is the reason of the resource sync condition when the deletions are frozen.
+const DeletionsFrozenReason = "DeletionsFrozen"
+
+// MassDeletionConfig configures the guard against the mass deletion,
+// a mass deletion is often a symptom of the etcd restore of the member cluster or a relist bug.
+type MassDeletionConfig struct {
+	// Threshold is the percentage of the stored objects deleted within the window to freeze the deletions,
+	// 0 disables the guard.
+	Threshold float64
+
+	Window time.Duration
+
+	// MinDeletions is the minimum number of the deletions within the window to freeze the deletions,
+	// so the resources with a few objects are not frozen.
+	MinDeletions int
+}
+
+func (c MassDeletionConfig) Enabled() bool {
+	return c.Threshold > 0 && c.Window > 0
+}
+
+// DeletionFreezer is implemented by the synchros which freeze the deletions on the mass deletion.
+type DeletionFreezer interface {
+	// ConfirmDeletions propagates the frozen deletions to the storage and unfreezes the synchro.
+	ConfirmDeletions()
+}
+
+// MassDeletionGuard detects the mass deletion of a resource and holds the deletions until they are confirmed.
+//
+// The deletions are counted in a fixed window started by the first deletion, and compared with the number of
+// the objects stored at the start of the window. The deletions before the threshold is reached are not held.
+type MassDeletionGuard struct {
+	config MassDeletionConfig
+	now    func() time.Time
+
+	lock            sync.Mutex
+	windowStart     time.Time
+	windowBase      int
+	windowDeletions int
+
+	frozenAt time.Time
+	frozen   map[string]interface{} // key -> deleted object
+}
+
+func NewMassDeletionGuard(config MassDeletionConfig) *MassDeletionGuard {
+	return &MassDeletionGuard{config: config, now: time.Now}
+}
+
+// Delete records the deletion of the object, stored is the number of the stored objects.
+// It returns false if the deletion is held by the guard, and freezing is true if the deletion freezes the guard.
+func (g *MassDeletionGuard) Delete(key string, obj interface{}, stored int) (propagate bool, freezing bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.frozen != nil {
+		g.frozen[key] = obj
+		return false, false
+	}
+
+	now := g.now()
+	if g.windowStart.IsZero() || now.Sub(g.windowStart) > g.config.Window {
+		g.windowStart, g.windowBase, g.windowDeletions = now, stored, 0
+	}
+	g.windowDeletions++
+	if g.windowDeletions < g.config.MinDeletions || float64(g.windowDeletions)*100 < g.config.Threshold*float64(g.windowBase) {
+		return true, false
+	}
+
+	g.frozenAt = now
+	g.frozen = map[string]interface{}{key: obj}
+	return false, true
+}
+
+// Restore cancels the frozen deletion of the object when it appears again,
+// the guard is unfrozen if all the frozen deletions are canceled.
+func (g *MassDeletionGuard) Restore(key string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.frozen == nil {
+		return
+	}
+	delete(g.frozen, key)
+	if len(g.frozen) == 0 {
+		g.reset()
+	}
+}
+
+// Confirm unfreezes the guard and returns the frozen deletions.
+func (g *MassDeletionGuard) Confirm() map[string]interface{} {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	frozen := g.frozen
+	g.reset()
+	return frozen
+}
+
+func (g *MassDeletionGuard) reset() {
+	g.frozen, g.frozenAt = nil, time.Time{}
+	g.windowStart, g.windowBase, g.windowDeletions = time.Time{}, 0, 0
+}
+
+// Frozen returns the number of the frozen deletions and the time when the guard is frozen.
+func (g *MassDeletionGuard) Frozen() (int, time.Time, bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return len(g.frozen), g.frozenAt, g.frozen != nil
+}
@@ -0,0 +1,57 @@
+package resourcesynchro
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMassDeletionGuard(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	guard := NewMassDeletionGuard(MassDeletionConfig{Threshold: 50, Window: time.Minute, MinDeletions: 3})
+	guard.now = func() time.Time { return now }
+
+	// 2 of 10 objects are deleted, and a new window is started after the window
+	for i := 0; i < 2; i++ {
+		if propagate, _ := guard.Delete(fmt.Sprintf("default/old-%d", i), nil, 10-i); !propagate {
+			t.Fatalf("the deletion %d should be propagated", i)
+		}
+	}
+	now = now.Add(2 * time.Minute)
+
+	stored := 8
+	for i := 0; i < 3; i++ {
+		if propagate, _ := guard.Delete(fmt.Sprintf("default/pod-%d", i), nil, stored); !propagate {
+			t.Fatalf("the deletion %d should be propagated under the threshold", i)
+		}
+		stored--
+	}
+	propagate, freezing := guard.Delete("default/pod-3", nil, stored)
+	if propagate || !freezing {
+		t.Fatalf("Delete() = %v, %v, want the guard is frozen when half of the objects are deleted", propagate, freezing)
+	}
+	if propagate, freezing := guard.Delete("default/pod-4", nil, stored); propagate || freezing {
+		t.Fatalf("Delete() = %v, %v, want the deletion is held by the frozen guard", propagate, freezing)
+	}
+	if count, frozenAt, frozen := guard.Frozen(); !frozen || count != 2 || !frozenAt.Equal(now) {
+		t.Fatalf("Frozen() = %d, %v, %v, want 2 frozen deletions", count, frozenAt, frozen)
+	}
+
+	guard.Restore("default/pod-3")
+	if frozen := guard.Confirm(); len(frozen) != 1 {
+		t.Fatalf("Confirm() = %v, want the deletion of pod-4", frozen)
+	}
+	if _, _, frozen := guard.Frozen(); frozen {
+		t.Fatal("the guard should be unfrozen after the deletions are confirmed")
+	}
+
+	// the guard is unfrozen when all the frozen deletions are restored
+	guard = NewMassDeletionGuard(MassDeletionConfig{Threshold: 10, Window: time.Minute, MinDeletions: 1})
+	if propagate, freezing := guard.Delete("default/pod", nil, 1); propagate || !freezing {
+		t.Fatalf("Delete() = %v, %v, want the guard is frozen", propagate, freezing)
+	}
+	guard.Restore("default/pod")
+	if _, _, frozen := guard.Frozen(); frozen {
+		t.Fatal("the guard should be unfrozen after the objects are restored")
+	}
+}
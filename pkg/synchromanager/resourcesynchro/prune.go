@@ -0,0 +1,137 @@
+package resourcesynchro
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+)
+
+const LastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// protectedFields are the fields required by the storage and the apiserver,
+// they and their parents are never pruned.
+var protectedFields = []string{
+	"apiVersion", "kind",
+	"metadata.name", "metadata.namespace", "metadata.uid", "metadata.resourceVersion",
+	"metadata.creationTimestamp", "metadata.deletionTimestamp", "metadata.ownerReferences",
+}
+
+// PruneRules are the fields pruned from the objects of a resource before they are saved
+type PruneRules struct {
+	ManagedFields            bool
+	LastAppliedConfiguration bool
+
+	// Fields are the paths of the pruned fields, each path is the list of the field names
+	Fields [][]string
+}
+
+// PruneRules resolves the prune rules of the resource with the prune configuration of the cluster.
+//
+// The managed fields and the last applied configuration are resolved in the order of the resource of the cluster,
+// the cluster, the resource of the settings and the settings, the unset ones fall back to the feature gates.
+// The fields are the union of all of them.
+func (s *Settings) PruneRules(gvr schema.GroupVersionResource, cluster *clusterv1alpha2.ClusterPruneConfiguration) PruneRules {
+	rules := PruneRules{
+		ManagedFields:            s.PruneManagedFields(),
+		LastAppliedConfiguration: s.PruneLastAppliedConfiguration(),
+	}
+
+	var fields []string
+	if spec := s.load(); spec != nil && spec.Prune != nil {
+		fields = append(fields, spec.Prune.Fields...)
+		for _, resource := range spec.Prune.Resources {
+			if matchesResource(gvr, resource.Group, resource.Version, resource.Resource) {
+				overrideBool(&rules.ManagedFields, resource.ManagedFields)
+				overrideBool(&rules.LastAppliedConfiguration, resource.LastAppliedConfiguration)
+				fields = append(fields, resource.Fields...)
+			}
+		}
+	}
+	if cluster != nil {
+		overrideBool(&rules.ManagedFields, cluster.ManagedFields)
+		overrideBool(&rules.LastAppliedConfiguration, cluster.LastAppliedConfiguration)
+		fields = append(fields, cluster.Fields...)
+		for _, resource := range cluster.Resources {
+			if matchesResource(gvr, resource.Group, resource.Version, resource.Resource) {
+				overrideBool(&rules.ManagedFields, resource.ManagedFields)
+				overrideBool(&rules.LastAppliedConfiguration, resource.LastAppliedConfiguration)
+				fields = append(fields, resource.Fields...)
+			}
+		}
+	}
+
+	for _, field := range sets.List(sets.New(fields...)) {
+		if path := ParseFieldPath(field); path != nil {
+			rules.Fields = append(rules.Fields, path)
+		}
+	}
+	return rules
+}
+
+// Prune removes the fields of the rules from the object
+func (rules PruneRules) Prune(obj *unstructured.Unstructured) {
+	if rules.ManagedFields {
+		obj.SetManagedFields(nil)
+	}
+
+	if rules.LastAppliedConfiguration {
+		annotations := obj.GetAnnotations()
+		if _, ok := annotations[LastAppliedConfigurationAnnotation]; ok {
+			delete(annotations, LastAppliedConfigurationAnnotation)
+			if len(annotations) == 0 {
+				annotations = nil
+			}
+			obj.SetAnnotations(annotations)
+		}
+	}
+
+	for _, path := range rules.Fields {
+		unstructured.RemoveNestedField(obj.Object, path...)
+	}
+}
+
+// ParseFieldPath parses the dot-separated field path, the dots in the field names are escaped by `\`.
+// It returns nil if the path is invalid or contains the protected fields.
+func ParseFieldPath(path string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			field.WriteByte('.')
+			i++
+		case path[i] == '.':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(path[i])
+		}
+	}
+	fields = append(fields, field.String())
+
+	for _, field := range fields {
+		if field == "" {
+			return nil
+		}
+	}
+	for _, protected := range protectedFields {
+		if joined := strings.Join(fields, "."); protected == joined || strings.HasPrefix(protected, joined+".") {
+			return nil
+		}
+	}
+	return fields
+}
+
+func matchesResource(gvr schema.GroupVersionResource, group, version, resource string) bool {
+	return gvr.Group == group && gvr.Resource == resource && (version == "" || gvr.Version == version)
+}
+
+func overrideBool(value *bool, override *bool) {
+	if override != nil {
+		*value = *override
+	}
+}
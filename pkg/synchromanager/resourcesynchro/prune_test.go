@@ -0,0 +1,97 @@
+package resourcesynchro
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "status.images", want: []string{"status", "images"}},
+		{path: `metadata.annotations.example\.io/note`, want: []string{"metadata", "annotations", "example.io/note"}},
+		{path: "spec..template"},
+		{path: "metadata"},
+		{path: "metadata.name"},
+		{path: "kind"},
+	}
+	for _, test := range tests {
+		if got := ParseFieldPath(test.path); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ParseFieldPath(%q) = %v, want %v", test.path, got, test.want)
+		}
+	}
+}
+
+func TestPruneRules(t *testing.T) {
+	enabled, disabled := true, false
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	settings := &Settings{}
+	settings.Update(&configv1alpha1.ClusterpediaConfigurationSpec{
+		Prune: &configv1alpha1.PruneConfiguration{
+			ManagedFields: &disabled,
+			Fields:        []string{"status.images"},
+			Resources: []configv1alpha1.ResourcePruneConfiguration{
+				{Group: "apps", Resource: "deployments", ManagedFields: &enabled, Fields: []string{"spec.template.metadata"}},
+				{Group: "apps", Version: "v1beta1", Resource: "deployments", LastAppliedConfiguration: &disabled},
+			},
+		},
+	})
+
+	rules := settings.PruneRules(pods, nil)
+	if rules.ManagedFields || !rules.LastAppliedConfiguration || len(rules.Fields) != 1 {
+		t.Errorf("PruneRules() of pods = %+v, want the settings", rules)
+	}
+	rules = settings.PruneRules(deployments, nil)
+	if !rules.ManagedFields || !rules.LastAppliedConfiguration || len(rules.Fields) != 2 {
+		t.Errorf("PruneRules() of deployments = %+v, want the settings of the resource", rules)
+	}
+
+	cluster := &clusterv1alpha2.ClusterPruneConfiguration{
+		ManagedFields:            &disabled,
+		LastAppliedConfiguration: &disabled,
+		Resources: []clusterv1alpha2.ResourcePruneConfiguration{
+			{Group: "", Resource: "pods", ManagedFields: &enabled, Fields: []string{"spec.volumes", "status.images"}},
+		},
+	}
+	rules = settings.PruneRules(deployments, cluster)
+	if rules.ManagedFields || rules.LastAppliedConfiguration || len(rules.Fields) != 2 {
+		t.Errorf("PruneRules() of deployments = %+v, want the cluster overriding the settings", rules)
+	}
+	rules = settings.PruneRules(pods, cluster)
+	want := [][]string{{"spec", "volumes"}, {"status", "images"}}
+	if !rules.ManagedFields || rules.LastAppliedConfiguration || !reflect.DeepEqual(rules.Fields, want) {
+		t.Errorf("PruneRules() of pods = %+v, want the resource of the cluster overriding the cluster", rules)
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":          "web",
+			"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			"annotations":   map[string]interface{}{LastAppliedConfigurationAnnotation: "{}"},
+		},
+		"spec":   map[string]interface{}{"volumes": []interface{}{}, "nodeName": "node-1"},
+		"status": map[string]interface{}{"images": []interface{}{}},
+	}}
+	rules.Prune(obj)
+	expected := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":        "web",
+			"annotations": map[string]interface{}{LastAppliedConfigurationAnnotation: "{}"},
+		},
+		"spec":   map[string]interface{}{"nodeName": "node-1"},
+		"status": map[string]interface{}{},
+	}
+	if !reflect.DeepEqual(obj.Object, expected) {
+		t.Errorf("Prune() = %v, want %v", obj.Object, expected)
+	}
+}
@@ -1,5 +1,7 @@
 package queue
 
+import "time"
+
 type ActionType string
 
 const (
@@ -13,6 +15,10 @@ type Event struct {
 
 	Action ActionType
 	Object interface{}
+
+	// ReceivedTime is the time when the earliest change merged into the event is received,
+	// it is used to measure the lag between receiving and storing the change.
+	ReceivedTime time.Time
 }
 
 func (event Event) GetReputCount() int {
@@ -23,6 +29,11 @@ func pressureEvents(older *Event, newer *Event) *Event {
 	if newer == nil {
 		return older
 	}
+	if older != nil && !older.ReceivedTime.IsZero() &&
+		(newer.ReceivedTime.IsZero() || older.ReceivedTime.Before(newer.ReceivedTime)) {
+		// the merged event waits since the earliest change is received
+		newer.ReceivedTime = older.ReceivedTime
+	}
 	if older == nil || newer.Action == Deleted || older.Action == newer.Action {
 		return newer
 	}
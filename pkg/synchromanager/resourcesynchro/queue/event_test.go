@@ -2,6 +2,7 @@ package queue
 
 import (
 	"testing"
+	"time"
 )
 
 type pressureEventsTest struct {
@@ -166,3 +167,19 @@ func TestPressureEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestPressureEventsReceivedTime(t *testing.T) {
+	earlier := time.Now()
+	later := earlier.Add(time.Second)
+
+	result := pressureEvents(&Event{Action: Added, ReceivedTime: earlier}, &Event{Action: Updated, ReceivedTime: later})
+	if !result.ReceivedTime.Equal(earlier) {
+		t.Errorf("Expected the received time of the older event, but got %v", result.ReceivedTime)
+	}
+
+	// the reput event is older than the event in the queue, but received later
+	result = pressureEvents(&Event{Action: Updated, ReceivedTime: later}, &Event{Action: Updated, ReceivedTime: earlier})
+	if !result.ReceivedTime.Equal(earlier) {
+		t.Errorf("Expected the earliest received time, but got %v", result.ReceivedTime)
+	}
+}
@@ -2,6 +2,7 @@ package queue
 
 import (
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -62,7 +63,7 @@ func (q *pressurequeue) queueActionLocked(action ActionType, obj interface{}, in
 	if err != nil {
 		return err
 	}
-	q.put(key, pressureEvents(q.items[key], &Event{Action: action, Object: obj}), inited)
+	q.put(key, pressureEvents(q.items[key], &Event{Action: action, Object: obj, ReceivedTime: time.Now()}), inited)
 	return nil
 }
 
@@ -184,6 +185,13 @@ func (q *pressurequeue) Len() int {
 	return len(q.queue)
 }
 
+// Idle returns true if no events are queued or being processed
+func (q *pressurequeue) Idle() bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.items) == 0 && q.processing.Len() == 0
+}
+
 func (q *pressurequeue) DiscardAndRetain(retain int) bool {
 	q.lock.Lock()
 	defer q.lock.Unlock()
@@ -13,6 +13,7 @@ type EventQueue interface {
 	Done(event *Event) error
 
 	Len() int
+	Idle() bool
 	DiscardAndRetain(retain int) bool
 	HasInitialEvents() bool
 
@@ -1,14 +1,20 @@
 package resourcesynchro
 
 import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	metricsstore "k8s.io/kube-state-metrics/v2/pkg/metrics_store"
 
 	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
 	kubestatemetrics "github.com/clusterpedia-io/clusterpedia/pkg/kube_state_metrics"
 	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/cdc"
 )
 
 type SynchroFactory interface {
@@ -44,8 +50,62 @@ type Config struct {
 	ResourceStorage storage.ResourceStorage
 
 	Event *EventConfig
+
+	// SkipOwnerKinds skips syncing the resources owned by the kinds
+	SkipOwnerKinds OwnerKinds
+
+	// TerminatedTTL deletes the terminated pods or jobs from the storage after the ttl,
+	// it is ignored by the other resources, 0 means the terminated objects are kept.
+	TerminatedTTL time.Duration
+
+	// Settings overrides the prune feature gates and the TerminatedTTL at runtime, it may be nil
+	Settings *Settings
+
+	// ClusterLabels returns the current labels of the cluster, which are stamped on the objects
+	// by the enrichment of the Settings, it may be nil
+	ClusterLabels func() map[string]string
+
+	// ClusterPrune returns the current prune configuration of the cluster, which overrides the prune of the Settings,
+	// it may be nil
+	ClusterPrune func() *clusterv1alpha2.ClusterPruneConfiguration
+
+	// MassDeletion freezes the deletions to the storage when the mass deletion is detected
+	MassDeletion MassDeletionConfig
+
+	// StorageTimeout bounds each write of the resources and the events to the storage,
+	// DefaultStorageTimeout is used if it is 0.
+	StorageTimeout time.Duration
+
+	// VerificationRelistInterval is the jittered interval of the full relists of the resource which repair
+	// the divergence from the missed watch events, 0 disables the verification relists.
+	VerificationRelistInterval time.Duration
+	// ConsistencyChecker admits the verification relists and the drift checks when no resources of the cluster are in the initial sync
+	ConsistencyChecker *ConsistencyChecker
+
+	// DriftCheck compares the stored objects with the member cluster periodically, it is disabled if the interval is 0
+	DriftCheck DriftCheckConfig
+
+	// Publisher publishes the creates, updates and deletes saved to the storage as the change events, it may be nil
+	Publisher cdc.Publisher
+
+	// WatchBookmark resumes the watch from the persisted bookmark and persists the bookmarks, it may be nil
+	WatchBookmark *WatchBookmarkConfig
+}
+
+// WatchBookmarkConfig resumes the watch of the resource from the persisted bookmark instead of the initial list,
+// and persists the resource version of the watch as the bookmark periodically when all received events are saved to the storage.
+type WatchBookmarkConfig struct {
+	// ResumeResourceVersion is the persisted resource version, the resource is listed if it is empty
+	ResumeResourceVersion string
+
+	// Interval is the interval of persisting the bookmarks
+	Interval time.Duration
+
+	Save func(ctx context.Context, resourceVersion string) error
 }
 
+const DefaultStorageTimeout = 30 * time.Second
+
 func (c Config) GroupVersionKind() schema.GroupVersionKind {
 	return c.GroupVersionResource.GroupVersion().WithKind(c.Kind)
 }
@@ -54,3 +114,25 @@ type EventConfig struct {
 	ListerWatcher    cache.ListerWatcher
 	ResourceVersions map[string]interface{}
 }
+
+// OwnerKinds is a set of the owner kinds, formatted as `<kind>` or `<kind>.<group>`,
+// a kind without group matches the owners of any group.
+type OwnerKinds sets.Set[string]
+
+// Owns returns true if the object has an owner reference of the kinds.
+func (kinds OwnerKinds) Owns(obj metav1.Object) bool {
+	if len(kinds) == 0 {
+		return false
+	}
+
+	set := sets.Set[string](kinds)
+	for _, owner := range obj.GetOwnerReferences() {
+		if set.Has(owner.Kind) {
+			return true
+		}
+		if gv, err := schema.ParseGroupVersion(owner.APIVersion); err == nil && set.Has(owner.Kind+"."+gv.Group) {
+			return true
+		}
+	}
+	return false
+}
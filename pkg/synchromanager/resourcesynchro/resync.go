@@ -0,0 +1,63 @@
+package resourcesynchro
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Resyncer is implemented by the synchros which rewrite the stored objects on demand.
+type Resyncer interface {
+	// Resync rewrites the stored objects of the resource, or only the objects of the namespace if it isn't empty,
+	// with the objects of the member cluster, and deletes the stored objects missing from the member cluster.
+	Resync(namespace string)
+}
+
+// ResyncRequest is the request of the resync of a resource of a cluster, it is the value of the ResyncAnnotation
+// in the form of `resource=<resource>[.<group>][,namespace=<namespace>],requestedAt=<RFC3339 time>`.
+// The requested time makes the value changed when the same resource is resynced again.
+type ResyncRequest struct {
+	Resource    schema.GroupResource
+	Namespace   string
+	RequestedAt time.Time
+}
+
+func (r ResyncRequest) String() string {
+	fields := []string{"resource=" + r.Resource.String()}
+	if r.Namespace != "" {
+		fields = append(fields, "namespace="+r.Namespace)
+	}
+	fields = append(fields, "requestedAt="+r.RequestedAt.UTC().Format(time.RFC3339))
+	return strings.Join(fields, ",")
+}
+
+func ParseResyncRequest(value string) (ResyncRequest, error) {
+	var request ResyncRequest
+	for _, field := range strings.Split(value, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return request, fmt.Errorf("invalid field %q of the resync request, it must be in the form of <key>=<value>", field)
+		}
+		switch key {
+		case "resource":
+			request.Resource = schema.ParseGroupResource(value)
+		case "namespace":
+			request.Namespace = value
+		case "requestedAt":
+			requestedAt, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return request, fmt.Errorf("invalid requestedAt of the resync request: %w", err)
+			}
+			request.RequestedAt = requestedAt
+		default:
+			return request, fmt.Errorf("unknown field %q of the resync request", key)
+		}
+	}
+	if request.Resource.Resource == "" {
+		return request, errors.New("resource of the resync request is required")
+	}
+	return request, nil
+}
@@ -0,0 +1,50 @@
+package resourcesynchro
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResyncRequest(t *testing.T) {
+	requestedAt := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		value   string
+		request ResyncRequest
+		wantErr bool
+	}{
+		{
+			name:    "resource",
+			value:   "resource=pods,requestedAt=2026-10-15T08:00:00Z",
+			request: ResyncRequest{Resource: schema.GroupResource{Resource: "pods"}, RequestedAt: requestedAt},
+		},
+		{
+			name:    "namespace",
+			value:   "resource=deployments.apps,namespace=ci,requestedAt=2026-10-15T08:00:00Z",
+			request: ResyncRequest{Resource: schema.GroupResource{Group: "apps", Resource: "deployments"}, Namespace: "ci", RequestedAt: requestedAt},
+		},
+		{name: "without resource", value: "namespace=ci,requestedAt=2026-10-15T08:00:00Z", wantErr: true},
+		{name: "invalid time", value: "resource=pods,requestedAt=now", wantErr: true},
+		{name: "unknown field", value: "resource=pods,cluster=cluster-1", wantErr: true},
+		{name: "invalid field", value: "pods", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := ParseResyncRequest(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseResyncRequest() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if request != test.request {
+				t.Errorf("ParseResyncRequest() = %+v, want %+v", request, test.request)
+			}
+			if value := request.String(); value != test.value {
+				t.Errorf("String() = %q, want %q", value, test.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package resourcesynchro
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+	"github.com/clusterpedia-io/clusterpedia/pkg/synchromanager/features"
+	clusterpediafeature "github.com/clusterpedia-io/clusterpedia/pkg/utils/feature"
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/masking"
+)
+
+// Settings are the settings of the resource synchros which are updated at runtime by the ClusterpediaConfiguration,
+// the unset settings fall back to the feature gates and the flags. A nil Settings always falls back.
+type Settings struct {
+	spec   atomic.Pointer[configv1alpha1.ClusterpediaConfigurationSpec]
+	masker masking.Masker
+}
+
+// Update replaces the settings with the spec of the configuration, a nil spec clears the settings.
+func (s *Settings) Update(spec *configv1alpha1.ClusterpediaConfigurationSpec) {
+	s.spec.Store(spec)
+
+	var config *configv1alpha1.MaskingConfiguration
+	if spec != nil {
+		config = spec.Masking
+	}
+	if err := s.masker.Update(config); err != nil {
+		klog.ErrorS(err, "Failed to apply the masking settings of the ClusterpediaConfiguration, keep the previous masking rules")
+	}
+}
+
+func (s *Settings) load() *configv1alpha1.ClusterpediaConfigurationSpec {
+	if s == nil {
+		return nil
+	}
+	return s.spec.Load()
+}
+
+func (s *Settings) PruneManagedFields() bool {
+	if spec := s.load(); spec != nil && spec.Prune != nil && spec.Prune.ManagedFields != nil {
+		return *spec.Prune.ManagedFields
+	}
+	return clusterpediafeature.FeatureGate.Enabled(features.PruneManagedFields)
+}
+
+func (s *Settings) PruneLastAppliedConfiguration() bool {
+	if spec := s.load(); spec != nil && spec.Prune != nil && spec.Prune.LastAppliedConfiguration != nil {
+		return *spec.Prune.LastAppliedConfiguration
+	}
+	return clusterpediafeature.FeatureGate.Enabled(features.PruneLastAppliedConfiguration)
+}
+
+// MaskingRules returns the rules of the fields masked from the objects of the resource before they are saved,
+// nil means no fields are masked.
+func (s *Settings) MaskingRules(gvr schema.GroupVersionResource) *masking.Rules {
+	if s == nil {
+		return nil
+	}
+	return s.masker.Rules(gvr, configv1alpha1.MaskingStageStorage)
+}
+
+// EnrichedClusterLabels returns the keys of the cluster labels stamped on the objects, nil means no cluster labels are stamped.
+func (s *Settings) EnrichedClusterLabels() []string {
+	if spec := s.load(); spec != nil && spec.Enrichment != nil {
+		return spec.Enrichment.ClusterLabels
+	}
+	return nil
+}
+
+// TerminatedTTL returns the ttl of the terminated objects of the kind, the fallback is returned if it is not set.
+func (s *Settings) TerminatedTTL(kind schema.GroupKind, fallback time.Duration) time.Duration {
+	spec := s.load()
+	if spec == nil || spec.Retention == nil {
+		return fallback
+	}
+
+	var ttl *time.Duration
+	switch kind {
+	case podKind:
+		if spec.Retention.TerminatedPodsTTL != nil {
+			ttl = &spec.Retention.TerminatedPodsTTL.Duration
+		}
+	case jobKind:
+		if spec.Retention.CompletedJobsTTL != nil {
+			ttl = &spec.Retention.CompletedJobsTTL.Duration
+		}
+	}
+	if ttl == nil {
+		return fallback
+	}
+	return *ttl
+}
+
+// MapResource returns the resource which the objects of the resource of the member clusters are stored and served as,
+// false is returned if the resource is not mapped by the settings.
+func (s *Settings) MapResource(gvr schema.GroupVersionResource) (schema.GroupVersionResource, bool) {
+	spec := s.load()
+	if spec == nil {
+		return gvr, false
+	}
+
+	for _, rule := range spec.ResourceMappings {
+		if rule.From.Group != gvr.Group || !strings.EqualFold(rule.From.Resource, gvr.Resource) ||
+			(rule.From.Version != "" && rule.From.Version != gvr.Version) {
+			continue
+		}
+
+		mapped := schema.GroupVersionResource{Group: rule.To.Group, Version: rule.To.Version, Resource: strings.ToLower(rule.To.Resource)}
+		if mapped.Version == "" {
+			mapped.Version = gvr.Version
+		}
+		return mapped, mapped != gvr
+	}
+	return gvr, false
+}
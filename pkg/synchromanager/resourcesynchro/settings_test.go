@@ -0,0 +1,126 @@
+package resourcesynchro
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+)
+
+func TestSettings(t *testing.T) {
+	var settings *Settings
+	if !settings.PruneManagedFields() || !settings.PruneLastAppliedConfiguration() {
+		t.Errorf("nil Settings should fall back to the default feature gates")
+	}
+	if ttl := settings.TerminatedTTL(podKind, time.Hour); ttl != time.Hour {
+		t.Errorf("TerminatedTTL() = %v, want the fallback", ttl)
+	}
+
+	settings = &Settings{}
+	disabled := false
+	settings.Update(&configv1alpha1.ClusterpediaConfigurationSpec{
+		Prune: &configv1alpha1.PruneConfiguration{ManagedFields: &disabled},
+		Retention: &configv1alpha1.RetentionConfiguration{
+			TerminatedPodsTTL: &metav1.Duration{Duration: time.Minute},
+		},
+	})
+	if settings.PruneManagedFields() {
+		t.Errorf("PruneManagedFields() should be overridden by the settings")
+	}
+	if !settings.PruneLastAppliedConfiguration() {
+		t.Errorf("PruneLastAppliedConfiguration() should fall back to the feature gate")
+	}
+	if ttl := settings.TerminatedTTL(podKind, time.Hour); ttl != time.Minute {
+		t.Errorf("TerminatedTTL() of pods = %v, want %v", ttl, time.Minute)
+	}
+	if ttl := settings.TerminatedTTL(jobKind, time.Hour); ttl != time.Hour {
+		t.Errorf("TerminatedTTL() of jobs = %v, want the fallback", ttl)
+	}
+
+	if keys := settings.EnrichedClusterLabels(); keys != nil {
+		t.Errorf("EnrichedClusterLabels() = %v, want nil without the enrichment", keys)
+	}
+	settings.Update(&configv1alpha1.ClusterpediaConfigurationSpec{
+		Enrichment: &configv1alpha1.EnrichmentConfiguration{ClusterLabels: []string{"region"}},
+	})
+	if keys := settings.EnrichedClusterLabels(); len(keys) != 1 || keys[0] != "region" {
+		t.Errorf("EnrichedClusterLabels() = %v, want [region]", keys)
+	}
+
+	settings.Update(nil)
+	if !settings.PruneManagedFields() || settings.TerminatedTTL(podKind, 0) != 0 {
+		t.Errorf("cleared Settings should fall back to the feature gates and the flags")
+	}
+}
+
+func TestSettingsMaskingRules(t *testing.T) {
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+	var settings *Settings
+	if settings.MaskingRules(pods) != nil {
+		t.Errorf("nil Settings should mask nothing")
+	}
+
+	settings = &Settings{}
+	settings.Update(&configv1alpha1.ClusterpediaConfigurationSpec{
+		Masking: &configv1alpha1.MaskingConfiguration{Rules: []configv1alpha1.MaskingRule{
+			{Resource: "pods", EnvNames: []string{"TOKEN"}},
+			{Resource: "configmaps", Stage: configv1alpha1.MaskingStageServing, Fields: []string{"data"}},
+		}},
+	})
+	if settings.MaskingRules(pods) == nil {
+		t.Errorf("MaskingRules() of pods should be set by the settings")
+	}
+	if settings.MaskingRules(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}) != nil {
+		t.Errorf("the configmaps are only masked when they are served")
+	}
+
+	// the invalid masking settings keep the previous rules
+	settings.Update(&configv1alpha1.ClusterpediaConfigurationSpec{
+		Masking: &configv1alpha1.MaskingConfiguration{Rules: []configv1alpha1.MaskingRule{{Resource: "pods", EnvNames: []string{"("}}}},
+	})
+	if settings.MaskingRules(pods) == nil {
+		t.Errorf("MaskingRules() of pods should be kept after the invalid settings")
+	}
+}
+
+func TestSettingsMapResource(t *testing.T) {
+	widgets := schema.GroupVersionResource{Group: "widgets.vendor.io", Version: "v1", Resource: "widgets"}
+
+	var settings *Settings
+	if _, ok := settings.MapResource(widgets); ok {
+		t.Errorf("nil Settings should not map the resources")
+	}
+
+	settings = &Settings{}
+	settings.Update(&configv1alpha1.ClusterpediaConfigurationSpec{
+		ResourceMappings: []configv1alpha1.ResourceMappingRule{
+			{
+				From: configv1alpha1.MappedResource{Group: "widgets.vendor.io", Version: "v1beta1", Resource: "widgets"},
+				To:   configv1alpha1.MappedResource{Group: "widgets.example.io", Version: "v1", Resource: "widgets"},
+			},
+			{
+				From: configv1alpha1.MappedResource{Group: "widgets.vendor.io", Resource: "Widgets"},
+				To:   configv1alpha1.MappedResource{Group: "widgets.example.io", Resource: "widgets"},
+			},
+		},
+	})
+
+	tests := []struct {
+		gvr    schema.GroupVersionResource
+		mapped schema.GroupVersionResource
+		ok     bool
+	}{
+		{widgets, schema.GroupVersionResource{Group: "widgets.example.io", Version: "v1", Resource: "widgets"}, true},
+		{widgets.GroupResource().WithVersion("v1beta1"), schema.GroupVersionResource{Group: "widgets.example.io", Version: "v1", Resource: "widgets"}, true},
+		{schema.GroupVersionResource{Group: "widgets.example.io", Version: "v1", Resource: "widgets"}, schema.GroupVersionResource{Group: "widgets.example.io", Version: "v1", Resource: "widgets"}, false},
+	}
+	for _, test := range tests {
+		if mapped, ok := settings.MapResource(test.gvr); mapped != test.mapped || ok != test.ok {
+			t.Errorf("MapResource(%s) = %s, %v, want %s, %v", test.gvr, mapped, ok, test.mapped, test.ok)
+		}
+	}
+}
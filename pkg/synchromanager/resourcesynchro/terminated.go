@@ -0,0 +1,91 @@
+package resourcesynchro
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	podKind = schema.GroupKind{Kind: "Pod"}
+	jobKind = schema.GroupKind{Group: "batch", Kind: "Job"}
+)
+
+// SupportsTerminatedTTL returns true if the terminated objects of the kind can be expired.
+func SupportsTerminatedTTL(kind schema.GroupKind) bool {
+	return kind == podKind || kind == jobKind
+}
+
+// TerminatedAt returns the time when the object is terminated, only pods and jobs are supported.
+// A pod is terminated when it is succeeded or failed, and a job is terminated when it is complete or failed.
+func TerminatedAt(kind schema.GroupKind, obj *unstructured.Unstructured) (time.Time, bool) {
+	switch kind {
+	case podKind:
+		return podTerminatedAt(obj)
+	case jobKind:
+		return jobTerminatedAt(obj)
+	}
+	return time.Time{}, false
+}
+
+func podTerminatedAt(obj *unstructured.Unstructured) (time.Time, bool) {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	if phase != "Succeeded" && phase != "Failed" {
+		return time.Time{}, false
+	}
+
+	// the latest time when the containers are terminated
+	var terminatedAt time.Time
+	statuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "containerStatuses")
+	for _, status := range statuses {
+		status, ok := status.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if finishedAt, ok := parseTime(status, "state", "terminated", "finishedAt"); ok && finishedAt.After(terminatedAt) {
+			terminatedAt = finishedAt
+		}
+	}
+	if terminatedAt.IsZero() {
+		terminatedAt = obj.GetCreationTimestamp().Time
+	}
+	return terminatedAt, true
+}
+
+func jobTerminatedAt(obj *unstructured.Unstructured) (time.Time, bool) {
+	for _, condition := range []string{"Complete", "Failed"} {
+		if at := conditionTransitionTime(obj, condition); !at.IsZero() {
+			if completionTime, ok := parseTime(obj.Object, "status", "completionTime"); ok {
+				return completionTime, true
+			}
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// conditionTransitionTime returns the last transition time of the true condition.
+func conditionTransitionTime(obj *unstructured.Unstructured, conditionType string) time.Time {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]interface{})
+		if !ok || condition["type"] != conditionType || condition["status"] != "True" {
+			continue
+		}
+		if at, ok := parseTime(condition, "lastTransitionTime"); ok {
+			return at
+		}
+		return time.Time{}
+	}
+	return time.Time{}
+}
+
+func parseTime(obj map[string]interface{}, fields ...string) (time.Time, bool) {
+	value, found, err := unstructured.NestedString(obj, fields...)
+	if !found || err != nil || value == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, err == nil
+}
@@ -0,0 +1,106 @@
+package resourcesynchro
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestTerminatedAt(t *testing.T) {
+	created := "2024-01-01T00:00:00Z"
+	finished := "2024-01-01T01:00:00Z"
+
+	tests := []struct {
+		name       string
+		kind       schema.GroupKind
+		status     map[string]interface{}
+		terminated bool
+		expected   string
+	}{
+		{
+			name:   "running pod",
+			kind:   podKind,
+			status: map[string]interface{}{"phase": "Running"},
+		},
+		{
+			name: "succeeded pod",
+			kind: podKind,
+			status: map[string]interface{}{
+				"phase": "Succeeded",
+				"containerStatuses": []interface{}{
+					map[string]interface{}{"state": map[string]interface{}{"terminated": map[string]interface{}{"finishedAt": "2024-01-01T00:30:00Z"}}},
+					map[string]interface{}{"state": map[string]interface{}{"terminated": map[string]interface{}{"finishedAt": finished}}},
+				},
+			},
+			terminated: true,
+			expected:   finished,
+		},
+		{
+			name:       "failed pod without container statuses",
+			kind:       podKind,
+			status:     map[string]interface{}{"phase": "Failed"},
+			terminated: true,
+			expected:   created,
+		},
+		{
+			name: "running job",
+			kind: jobKind,
+			status: map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Complete", "status": "False", "lastTransitionTime": finished},
+				},
+			},
+		},
+		{
+			name: "complete job",
+			kind: jobKind,
+			status: map[string]interface{}{
+				"completionTime": finished,
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Complete", "status": "True", "lastTransitionTime": "2024-01-01T02:00:00Z"},
+				},
+			},
+			terminated: true,
+			expected:   finished,
+		},
+		{
+			name: "failed job",
+			kind: jobKind,
+			status: map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Failed", "status": "True", "lastTransitionTime": finished},
+				},
+			},
+			terminated: true,
+			expected:   finished,
+		},
+		{
+			name:   "unsupported kind",
+			kind:   schema.GroupKind{Group: "apps", Kind: "Deployment"},
+			status: map[string]interface{}{"phase": "Succeeded"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test", "creationTimestamp": created},
+				"status":   test.status,
+			}}
+
+			terminatedAt, terminated := TerminatedAt(test.kind, obj)
+			if terminated != test.terminated {
+				t.Fatalf("TerminatedAt() terminated = %v, want %v", terminated, test.terminated)
+			}
+			if !terminated {
+				return
+			}
+			expected, _ := time.Parse(time.RFC3339, test.expected)
+			if !terminatedAt.Equal(expected) {
+				t.Errorf("TerminatedAt() = %v, want %v", terminatedAt, expected)
+			}
+		})
+	}
+}
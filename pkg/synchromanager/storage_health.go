@@ -0,0 +1,119 @@
+package synchromanager
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/klog/v2"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+const (
+	storageHealthCheckInterval = 10 * time.Second
+	storageHealthCheckTimeout  = 5 * time.Second
+)
+
+type storageHealth struct {
+	healthErr error
+	readyErr  error
+}
+
+// MonitorStorageHealth checks the health and the readiness of the storage periodically until the ctx is done,
+// the changes of the health are updated to the StorageHealthy conditions of the synchronized clusters.
+//
+// It runs whether or not the manager is the leader, so that the probes report the storage of each replica.
+func (manager *Manager) MonitorStorageHealth(ctx context.Context) {
+	wait.UntilWithContext(ctx, manager.checkStorageHealth, storageHealthCheckInterval)
+}
+
+func (manager *Manager) checkStorageHealth(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, storageHealthCheckTimeout)
+	defer cancel()
+
+	health := &storageHealth{healthErr: storage.CheckHealth(ctx, manager.storage)}
+	health.readyErr = health.healthErr
+	if health.readyErr == nil {
+		health.readyErr = storage.CheckReadiness(ctx, manager.storage)
+	}
+
+	last := manager.storageHealth.Swap(health)
+	if last != nil && errorMessage(last.healthErr) == errorMessage(health.healthErr) {
+		return
+	}
+	if health.healthErr != nil {
+		klog.ErrorS(health.healthErr, "Storage is unhealthy")
+	} else if last != nil {
+		klog.Info("Storage is healthy again")
+	}
+
+	manager.synchrolock.RLock()
+	clusters := make([]string, 0, len(manager.synchros))
+	for name := range manager.synchros {
+		clusters = append(clusters, name)
+	}
+	manager.synchrolock.RUnlock()
+
+	// the StorageHealthy condition is set by every update of the cluster status
+	for _, name := range clusters {
+		if err := manager.updateClusterStatus(context.TODO(), name, func(*clusterv1alpha2.ClusterStatus) {}); err != nil {
+			klog.ErrorS(err, "Failed to update the storage healthy condition", "cluster", name)
+		}
+	}
+}
+
+// storageHealthyCondition returns false if the storage has not been checked yet.
+func (manager *Manager) storageHealthyCondition() (metav1.Condition, bool) {
+	health := manager.storageHealth.Load()
+	if health == nil {
+		return metav1.Condition{}, false
+	}
+
+	if health.healthErr != nil {
+		return metav1.Condition{
+			Type:    clusterv1alpha2.StorageHealthyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  clusterv1alpha2.StorageUnavailableReason,
+			Message: health.healthErr.Error(),
+		}, true
+	}
+	return metav1.Condition{
+		Type:   clusterv1alpha2.StorageHealthyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: clusterv1alpha2.StorageAvailableReason,
+	}, true
+}
+
+// StorageHealthz is the health check of the storage which reports the last result of MonitorStorageHealth.
+func (manager *Manager) StorageHealthz() healthz.HealthChecker {
+	return healthz.NamedCheck("storage", func(_ *http.Request) error {
+		if health := manager.storageHealth.Load(); health != nil {
+			return health.healthErr
+		}
+		return nil
+	})
+}
+
+// StorageReadyz is the readiness check of the storage which reports the last result of MonitorStorageHealth,
+// the storage is not ready until it is checked.
+func (manager *Manager) StorageReadyz() healthz.HealthChecker {
+	return healthz.NamedCheck("storage", func(_ *http.Request) error {
+		if health := manager.storageHealth.Load(); health != nil {
+			return health.readyErr
+		}
+		return errors.New("the storage has not been checked yet")
+	})
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,55 @@
+package synchromanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+)
+
+type fakeHealthStorage struct {
+	storage.StorageFactory
+
+	healthErr error
+	readyErr  error
+}
+
+func (s *fakeHealthStorage) HealthCheck(context.Context) error { return s.healthErr }
+func (s *fakeHealthStorage) Readiness(context.Context) error   { return s.readyErr }
+
+func TestCheckStorageHealth(t *testing.T) {
+	fake := &fakeHealthStorage{}
+	manager := &Manager{storage: fake}
+	if _, ok := manager.storageHealthyCondition(); ok {
+		t.Error("expected no condition before the storage is checked")
+	}
+	if err := manager.StorageReadyz().Check(nil); err == nil {
+		t.Error("expected the storage is not ready before it is checked")
+	}
+
+	fake.readyErr = errors.New("schema is not migrated")
+	manager.checkStorageHealth(context.TODO())
+	if err := manager.StorageHealthz().Check(nil); err != nil {
+		t.Errorf("expected the storage is healthy, got %v", err)
+	}
+	if err := manager.StorageReadyz().Check(nil); err != fake.readyErr {
+		t.Errorf("expected the readiness error, got %v", err)
+	}
+	if condition, _ := manager.storageHealthyCondition(); condition.Status != metav1.ConditionTrue {
+		t.Errorf("expected the storage healthy condition, got %v", condition)
+	}
+
+	fake.healthErr = errors.New("connection refused")
+	manager.checkStorageHealth(context.TODO())
+	if err := manager.StorageHealthz().Check(nil); err != fake.healthErr {
+		t.Errorf("expected the health error, got %v", err)
+	}
+	condition, _ := manager.storageHealthyCondition()
+	if condition.Status != metav1.ConditionFalse || condition.Reason != clusterv1alpha2.StorageUnavailableReason || condition.Message != "connection refused" {
+		t.Errorf("expected the storage unavailable condition, got %v", condition)
+	}
+}
@@ -0,0 +1,52 @@
+package synchromanager
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+)
+
+// ListClusterStorageResources lists all PediaClusters, including the clusters of the other shardings,
+// and the storage resources recorded in their status. The resources of a cluster are nil if they are not recorded yet,
+// and false is returned if the PediaClusters are not synced.
+func (manager *Manager) ListClusterStorageResources() (map[string]sets.Set[schema.GroupVersionResource], bool) {
+	if !manager.clusterInformer.HasSynced() {
+		return nil, false
+	}
+
+	clusters, err := manager.clusterlister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list the PediaClusters")
+		return nil, false
+	}
+
+	resources := make(map[string]sets.Set[schema.GroupVersionResource], len(clusters))
+	for _, cluster := range clusters {
+		resources[cluster.Name] = clusterStorageResources(cluster)
+	}
+	return resources, true
+}
+
+// clusterStorageResources returns nil if the cluster is being deleted or its resources are not negotiated,
+// the resources of the cluster are cleaned by the manager in these cases.
+func clusterStorageResources(cluster *clusterv1alpha2.PediaCluster) sets.Set[schema.GroupVersionResource] {
+	if !cluster.DeletionTimestamp.IsZero() || len(cluster.Status.SyncResources) == 0 {
+		return nil
+	}
+
+	resources := sets.New[schema.GroupVersionResource]()
+	for _, groupResources := range cluster.Status.SyncResources {
+		for _, resource := range groupResources.Resources {
+			gr := schema.GroupResource{Group: groupResources.Group, Resource: resource.Name}
+			for _, cond := range resource.SyncConditions {
+				if gvr := cond.StorageGVR(gr); !gvr.Empty() {
+					resources.Insert(gvr)
+				}
+			}
+		}
+	}
+	return resources
+}
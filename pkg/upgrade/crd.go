@@ -0,0 +1,164 @@
+package upgrade
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/kube-openapi/pkg/common"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	clusterv1alpha2 "github.com/clusterpedia-io/api/cluster/v1alpha2"
+	policyv1alpha1 "github.com/clusterpedia-io/api/policy/v1alpha1"
+	generatedopenapi "github.com/clusterpedia-io/clusterpedia/pkg/generated/openapi"
+)
+
+// crd is a CRD required by the current version.
+type crd struct {
+	name    string
+	version string
+
+	// object is used to look up the openapi definition of the kind
+	object interface{}
+
+	// manifest is the file of the CRD in the deploy directory
+	manifest string
+}
+
+var crds = []crd{
+	{
+		name:     "pediaclusters.cluster.clusterpedia.io",
+		version:  clusterv1alpha2.SchemeGroupVersion.Version,
+		object:   clusterv1alpha2.PediaCluster{},
+		manifest: "deploy/cluster.clusterpedia.io_pediaclusters.yaml",
+	},
+	{
+		name:     "clustersyncresources.cluster.clusterpedia.io",
+		version:  clusterv1alpha2.SchemeGroupVersion.Version,
+		object:   clusterv1alpha2.ClusterSyncResources{},
+		manifest: "deploy/cluster.clusterpedia.io_clustersyncresources.yaml",
+	},
+	{
+		name:     "clusterimportpolicies.policy.clusterpedia.io",
+		version:  policyv1alpha1.SchemeGroupVersion.Version,
+		object:   policyv1alpha1.ClusterImportPolicy{},
+		manifest: "deploy/policy.clusterpedia.io_clusterimportpolicies.yaml",
+	},
+	{
+		name:     "pediaclusterlifecycles.policy.clusterpedia.io",
+		version:  policyv1alpha1.SchemeGroupVersion.Version,
+		object:   policyv1alpha1.PediaClusterLifecycle{},
+		manifest: "deploy/policy.clusterpedia.io_pediaclusterlifecycles.yaml",
+	},
+}
+
+func definitionName(obj interface{}) string {
+	t := reflect.TypeOf(obj)
+	return t.PkgPath() + "." + t.Name()
+}
+
+func openAPIDefinitions() map[string]common.OpenAPIDefinition {
+	return generatedopenapi.GetOpenAPIDefinitions(func(path string) spec.Ref {
+		return spec.MustCreateRef(path)
+	})
+}
+
+// planCRD compares the installed CRD with the CRD of the current version,
+// the installed CRD is nil if it is not installed.
+func planCRD(expected crd, installed *apiextensionsv1.CustomResourceDefinition, definitions map[string]common.OpenAPIDefinition, plan *Plan) {
+	apply := fmt.Sprintf("kubectl apply --server-side -f %s", expected.manifest)
+	if installed == nil {
+		plan.incompatible("crd", fmt.Sprintf("CRD %s is not installed", expected.name))
+		plan.step(fmt.Sprintf("Install the CRD %s", expected.name), apply)
+		return
+	}
+
+	var version *apiextensionsv1.CustomResourceDefinitionVersion
+	for i := range installed.Spec.Versions {
+		if installed.Spec.Versions[i].Name == expected.version {
+			version = &installed.Spec.Versions[i]
+			break
+		}
+	}
+
+	var needsApply bool
+	switch {
+	case version == nil || !version.Served:
+		plan.incompatible("crd", fmt.Sprintf("CRD %s doesn't serve the version %s", expected.name, expected.version))
+		needsApply = true
+	case !version.Storage:
+		plan.incompatible("crd", fmt.Sprintf("the storage version of CRD %s is not %s", expected.name, expected.version))
+		needsApply = true
+	}
+
+	if version != nil && version.Schema != nil && version.Schema.OpenAPIV3Schema != nil {
+		definition := definitions[definitionName(expected.object)]
+		if missing := missingFields(definitions, definition.Schema, version.Schema.OpenAPIV3Schema, ""); len(missing) != 0 {
+			plan.incompatible("crd", fmt.Sprintf("the schema of CRD %s is missing the fields %s, they are pruned by the apiserver",
+				expected.name, strings.Join(missing, ", ")))
+			needsApply = true
+		}
+	}
+	if needsApply {
+		plan.step(fmt.Sprintf("Update the CRD %s", expected.name), apply)
+	}
+
+	var staleVersions []string
+	for _, stored := range installed.Status.StoredVersions {
+		if stored != expected.version {
+			staleVersions = append(staleVersions, stored)
+		}
+	}
+	if len(staleVersions) != 0 {
+		resource := installed.Spec.Names.Plural + "." + installed.Spec.Group
+		plan.incompatible("crd", fmt.Sprintf("the objects of CRD %s may be stored in the versions %s", expected.name, strings.Join(staleVersions, ", ")))
+		plan.step(fmt.Sprintf("Migrate the stored objects of CRD %s to the version %s", expected.name, expected.version),
+			fmt.Sprintf("kubectl get %s --all-namespaces -o json | kubectl replace -f -", resource),
+			fmt.Sprintf(`kubectl patch crd %s --subresource=status --type=merge -p '{"status":{"storedVersions":["%s"]}}'`, expected.name, expected.version),
+		)
+	}
+}
+
+// missingFields returns the fields of the expected schema which are not in the installed schema.
+func missingFields(definitions map[string]common.OpenAPIDefinition, expected spec.Schema, installed *apiextensionsv1.JSONSchemaProps, path string) []string {
+	if installed.XPreserveUnknownFields != nil && *installed.XPreserveUnknownFields {
+		return nil
+	}
+	if ref := expected.Ref.String(); ref != "" {
+		definition, ok := definitions[ref]
+		if !ok {
+			return nil
+		}
+		expected = definition.Schema
+	}
+
+	var missing []string
+	switch {
+	case len(expected.Properties) != 0:
+		for name, property := range expected.Properties {
+			if path == "" && (name == "apiVersion" || name == "kind" || name == "metadata") {
+				continue
+			}
+
+			field := name
+			if path != "" {
+				field = path + "." + name
+			}
+			child, ok := installed.Properties[name]
+			if !ok {
+				missing = append(missing, field)
+				continue
+			}
+			missing = append(missing, missingFields(definitions, property, &child, field)...)
+		}
+	case expected.Items != nil && expected.Items.Schema != nil && installed.Items != nil && installed.Items.Schema != nil:
+		missing = missingFields(definitions, *expected.Items.Schema, installed.Items.Schema, path+"[]")
+	case expected.AdditionalProperties != nil && expected.AdditionalProperties.Schema != nil &&
+		installed.AdditionalProperties != nil && installed.AdditionalProperties.Schema != nil:
+		missing = missingFields(definitions, *expected.AdditionalProperties.Schema, installed.AdditionalProperties.Schema, path+"{}")
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,73 @@
+package upgrade
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func loadCRD(t *testing.T, manifest string) *apiextensionsv1.CustomResourceDefinition {
+	data, err := os.ReadFile(filepath.Join("..", "..", manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(data, crd); err != nil {
+		t.Fatal(err)
+	}
+	return crd
+}
+
+func TestPlanDeployedCRDs(t *testing.T) {
+	definitions := openAPIDefinitions()
+	for _, expected := range crds {
+		installed := loadCRD(t, expected.manifest)
+		installed.Status.StoredVersions = []string{expected.version}
+
+		plan := &Plan{}
+		planCRD(expected, installed, definitions, plan)
+		if len(plan.Incompatibilities) != 0 || len(plan.Steps) != 0 {
+			t.Errorf("the manifest of %s is incompatible: %+v", expected.name, plan.Incompatibilities)
+		}
+	}
+}
+
+func TestPlanOutdatedCRD(t *testing.T) {
+	expected := crds[0]
+	installed := loadCRD(t, expected.manifest)
+	installed.Status.StoredVersions = []string{"v1alpha1", expected.version}
+
+	schema := installed.Spec.Versions[0].Schema.OpenAPIV3Schema
+	spec := schema.Properties["spec"]
+	delete(spec.Properties, "shardingName")
+	schema.Properties["spec"] = spec
+
+	plan := &Plan{}
+	planCRD(expected, installed, openAPIDefinitions(), plan)
+
+	if len(plan.Incompatibilities) != 2 {
+		t.Fatalf("incompatibilities = %+v, want the missing field and the stale stored version", plan.Incompatibilities)
+	}
+	if !strings.Contains(plan.Incompatibilities[0].Message, "spec.shardingName") {
+		t.Errorf("incompatibility = %q, want the missing field spec.shardingName", plan.Incompatibilities[0].Message)
+	}
+	if !strings.Contains(plan.Incompatibilities[1].Message, "v1alpha1") {
+		t.Errorf("incompatibility = %q, want the stale version v1alpha1", plan.Incompatibilities[1].Message)
+	}
+	if len(plan.Steps) != 2 || len(plan.Steps[1].Commands) != 2 {
+		t.Errorf("steps = %+v, want updating the CRD and migrating the stored objects", plan.Steps)
+	}
+}
+
+func TestPlanMissingCRD(t *testing.T) {
+	plan := &Plan{}
+	planCRD(crds[0], nil, openAPIDefinitions(), plan)
+	if len(plan.Incompatibilities) != 1 || len(plan.Steps) != 1 ||
+		plan.Steps[0].Commands[0] != "kubectl apply --server-side -f deploy/cluster.clusterpedia.io_pediaclusters.yaml" {
+		t.Errorf("plan = %+v, want installing the CRD", plan)
+	}
+}
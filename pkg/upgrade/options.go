@@ -0,0 +1,66 @@
+package upgrade
+
+import (
+	"errors"
+
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	cliflag "k8s.io/component-base/cli/flag"
+
+	storageoptions "github.com/clusterpedia-io/clusterpedia/pkg/storage/options"
+)
+
+// Options are the options of the upgrade-plan command.
+type Options struct {
+	Master     string
+	Kubeconfig string
+
+	Storage *storageoptions.StorageOptions
+
+	Output string
+}
+
+func NewOptions() *Options {
+	return &Options{Storage: storageoptions.NewStorageOptions(), Output: "text"}
+}
+
+func (o *Options) Flags() cliflag.NamedFlagSets {
+	var fss cliflag.NamedFlagSets
+
+	o.Storage.AddFlags(fss.FlagSet("storage"))
+
+	fs := fss.FlagSet("upgrade plan")
+	fs.StringVar(&o.Master, "master", o.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig).")
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to kubeconfig file with authorization and master location information.")
+	fs.StringVarP(&o.Output, "output", "o", o.Output, "The output format of the plan, text or json.")
+	return fss
+}
+
+func (o *Options) Validate() error {
+	errs := o.Storage.Validate()
+	if o.Output != "text" && o.Output != "json" {
+		errs = append(errs, errors.New("--output must be text or json"))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (o *Options) Config() (*Config, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	kubeconfig, err := clientcmd.BuildConfigFromFlags(o.Master, o.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	client, err := apiextensionsclientset.NewForConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{
+		CRDClient:     client,
+		StorageName:   o.Storage.Name,
+		StorageConfig: o.Storage.ConfigPath,
+	}, nil
+}
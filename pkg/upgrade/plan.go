@@ -0,0 +1,150 @@
+// Package upgrade inspects the storage schema and the installed CRDs, reports the incompatibilities
+// with the current version of clusterpedia and generates the plan to migrate them before the upgrade.
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/storage"
+	"github.com/clusterpedia-io/clusterpedia/pkg/version"
+)
+
+type Incompatibility struct {
+	Component string `json:"component"`
+	Message   string `json:"message"`
+}
+
+type Step struct {
+	Description string   `json:"description"`
+	Commands    []string `json:"commands,omitempty"`
+}
+
+// Plan is the migration plan to upgrade to the target version.
+type Plan struct {
+	TargetVersion     string            `json:"targetVersion"`
+	Incompatibilities []Incompatibility `json:"incompatibilities,omitempty"`
+	Warnings          []string          `json:"warnings,omitempty"`
+	Steps             []Step            `json:"steps,omitempty"`
+}
+
+func (p *Plan) incompatible(component, message string) {
+	p.Incompatibilities = append(p.Incompatibilities, Incompatibility{Component: component, Message: message})
+}
+
+func (p *Plan) step(description string, commands ...string) {
+	p.Steps = append(p.Steps, Step{Description: description, Commands: commands})
+}
+
+type Config struct {
+	CRDClient apiextensionsclientset.Interface
+
+	StorageName   string
+	StorageConfig string
+}
+
+// Run inspects the installed CRDs and the storage, neither of them is changed.
+func Run(ctx context.Context, config *Config) (*Plan, error) {
+	plan := &Plan{TargetVersion: version.Get().GitVersion}
+
+	definitions := openAPIDefinitions()
+	for _, expected := range crds {
+		installed, err := config.CRDClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, expected.name, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			installed = nil
+		}
+		planCRD(expected, installed, definitions, plan)
+	}
+
+	if err := planStorage(ctx, config, plan); err != nil {
+		return nil, err
+	}
+
+	if len(plan.Steps) != 0 {
+		plan.step(fmt.Sprintf("Roll out the clustersynchro-manager, the apiserver and the controller-manager of %s", plan.TargetVersion))
+	}
+	return plan, nil
+}
+
+func planStorage(ctx context.Context, config *Config, plan *Plan) error {
+	// the internal storage migrates the tables when the storage factory is created,
+	// the migration is skipped to inspect the tables before the upgrade.
+	if err := os.Setenv(storage.SkipMigrationEnvName, "true"); err != nil {
+		return err
+	}
+
+	factory, err := storage.NewStorageFactory(config.StorageName, config.StorageConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = factory.Shutdown()
+	}()
+
+	planner, ok := factory.(storage.SchemaMigrationPlanner)
+	if !ok {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("storage %s doesn't support planning the schema migration", config.StorageName))
+		return nil
+	}
+
+	schemaPlan, err := planner.PlanSchemaMigration(ctx)
+	if err != nil {
+		return err
+	}
+	for _, incompatibility := range schemaPlan.Incompatibilities {
+		plan.incompatible("storage", incompatibility)
+	}
+	if len(schemaPlan.Statements) != 0 {
+		statements := make([]string, 0, len(schemaPlan.Statements))
+		for _, statement := range schemaPlan.Statements {
+			statements = append(statements, statement+";")
+		}
+		plan.step("Migrate the storage schema by the init-storage command of the clustersynchro-manager, or execute the statements", statements...)
+	}
+	return nil
+}
+
+// Print prints the plan as text, or as json if the output is `json`.
+func (p *Plan) Print(w io.Writer, output string) error {
+	if output == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(p)
+	}
+
+	fmt.Fprintf(w, "Target version: %s\n", p.TargetVersion)
+	for _, warning := range p.Warnings {
+		fmt.Fprintf(w, "Warning: %s\n", warning)
+	}
+	if len(p.Incompatibilities) == 0 && len(p.Steps) == 0 {
+		_, err := fmt.Fprintln(w, "\nThe storage and the CRDs are compatible, no migration is required.")
+		return err
+	}
+
+	fmt.Fprintln(w, "\nIncompatibilities:")
+	for _, incompatibility := range p.Incompatibilities {
+		fmt.Fprintf(w, "  [%s] %s\n", incompatibility.Component, incompatibility.Message)
+	}
+
+	if len(p.Steps) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "\nMigration plan:")
+	for i, step := range p.Steps {
+		fmt.Fprintf(w, "  %d. %s\n", i+1, step.Description)
+		for _, command := range step.Commands {
+			fmt.Fprintf(w, "       %s\n", command)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -30,3 +32,39 @@ func InjectClusterName(obj runtime.Object, name string) {
 	annotations[internal.ShadowAnnotationClusterName] = name
 	m.SetAnnotations(annotations)
 }
+
+// ClusterLabelAnnotation returns the annotation of the cluster label stamped on the objects,
+// which is named by the label key without the prefix.
+func ClusterLabelAnnotation(key string) string {
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		key = key[i+1:]
+	}
+	return internal.ShadowAnnotationClusterLabelPrefix + key
+}
+
+// InjectClusterLabels stamps the cluster labels of the keys on the object,
+// the stamped annotations of the labels which are not in the keys or not in the cluster labels are removed.
+func InjectClusterLabels(obj runtime.Object, clusterLabels map[string]string, keys []string) {
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		panic(err)
+	}
+
+	annotations := m.GetAnnotations()
+	for key := range annotations {
+		if strings.HasPrefix(key, internal.ShadowAnnotationClusterLabelPrefix) {
+			delete(annotations, key)
+		}
+	}
+	for _, key := range keys {
+		value, ok := clusterLabels[key]
+		if !ok {
+			continue
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[ClusterLabelAnnotation(key)] = value
+	}
+	m.SetAnnotations(annotations)
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+func TestInjectClusterLabels(t *testing.T) {
+	clusterLabels := map[string]string{"topology.kubernetes.io/region": "us-east-1", "env": "prod", "team": "infra"}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		keys        []string
+		expected    map[string]string
+	}{
+		{
+			name:        "stamp the selected labels",
+			annotations: map[string]string{internal.ShadowAnnotationClusterName: "cluster-1"},
+			keys:        []string{"topology.kubernetes.io/region", "env", "owner"},
+			expected: map[string]string{
+				internal.ShadowAnnotationClusterName:                   "cluster-1",
+				internal.ShadowAnnotationClusterLabelPrefix + "region": "us-east-1",
+				internal.ShadowAnnotationClusterLabelPrefix + "env":    "prod",
+			},
+		},
+		{
+			name: "remove the unselected labels",
+			annotations: map[string]string{
+				internal.ShadowAnnotationClusterLabelPrefix + "env":  "prod",
+				internal.ShadowAnnotationClusterLabelPrefix + "team": "infra",
+			},
+			keys:     []string{"team"},
+			expected: map[string]string{internal.ShadowAnnotationClusterLabelPrefix + "team": "infra"},
+		},
+		{
+			name: "no keys",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}}
+			InjectClusterLabels(pod, clusterLabels, test.keys)
+			if annotations := pod.GetAnnotations(); len(annotations) != 0 || len(test.expected) != 0 {
+				if !reflect.DeepEqual(annotations, test.expected) {
+					t.Errorf("InjectClusterLabels() annotations = %v, want %v", annotations, test.expected)
+				}
+			}
+		})
+	}
+}
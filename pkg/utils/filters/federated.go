@@ -0,0 +1,16 @@
+package filters
+
+import (
+	"net/http"
+
+	"github.com/clusterpedia-io/clusterpedia/pkg/utils/request"
+)
+
+func WithFederatedRequest(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get(request.FederatedRequestHeader) != "" {
+			req = req.WithContext(request.WithFederated(req.Context()))
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,346 @@
+package masking
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+)
+
+// DefaultReplacement is the default replacement of the masked values,
+// it is also a valid base64 value, so the masked bytes fields can still be decoded.
+const DefaultReplacement = "REDACTED"
+
+// containerFields are the fields of the containers whose environment variables are masked,
+// they are found at any depth, so the pod templates of the workloads and the cronjobs are masked too.
+var containerFields = []string{"containers", "initContainers", "ephemeralContainers"}
+
+// Masker masks the objects by the masking configuration which is replaced at runtime, a nil Masker masks nothing.
+type Masker struct {
+	policy atomic.Pointer[policy]
+}
+
+// Update replaces the masking configuration, a nil configuration clears the rules.
+// The previous rules are kept if the configuration is invalid, so the sensitive values are never unmasked by mistake.
+func (m *Masker) Update(config *configv1alpha1.MaskingConfiguration) error {
+	p, err := newPolicy(config)
+	if err != nil {
+		return err
+	}
+	m.policy.Store(p)
+	return nil
+}
+
+// Rules returns the masking rules of the resource at the stage, it returns nil if no fields of the resource are masked.
+func (m *Masker) Rules(gvr schema.GroupVersionResource, stage string) *Rules {
+	if m == nil {
+		return nil
+	}
+	p := m.policy.Load()
+	if p == nil {
+		return nil
+	}
+	return p.rulesFor(gvr, stage)
+}
+
+// Validate validates the masking configuration
+func Validate(config *configv1alpha1.MaskingConfiguration) error {
+	_, err := newPolicy(config)
+	return err
+}
+
+type rule struct {
+	group, version, resource string
+	stage                    string
+	rules                    Rules
+}
+
+type policy struct {
+	rules []rule
+
+	// cache is the merged rules keyed by the resource and the stage
+	cache sync.Map
+}
+
+type cacheKey struct {
+	gvr   schema.GroupVersionResource
+	stage string
+}
+
+func newPolicy(config *configv1alpha1.MaskingConfiguration) (*policy, error) {
+	if config == nil || len(config.Rules) == 0 {
+		return nil, nil
+	}
+
+	replacement := config.Replacement
+	if replacement == "" {
+		replacement = DefaultReplacement
+	}
+
+	p := &policy{}
+	for i, r := range config.Rules {
+		if r.Resource == "" {
+			return nil, fmt.Errorf("masking.rules[%d]: resource is required", i)
+		}
+		stage := r.Stage
+		switch stage {
+		case "":
+			stage = configv1alpha1.MaskingStageStorage
+		case configv1alpha1.MaskingStageStorage, configv1alpha1.MaskingStageServing:
+		default:
+			return nil, fmt.Errorf("masking.rules[%d]: unsupported stage %q", i, r.Stage)
+		}
+
+		compiled := rule{group: r.Group, version: r.Version, resource: r.Resource, stage: stage, rules: Rules{replacement: replacement}}
+		for _, field := range r.Fields {
+			path, err := parseFieldPath(field)
+			if err != nil {
+				return nil, fmt.Errorf("masking.rules[%d].fields: %w", i, err)
+			}
+			compiled.rules.fields = append(compiled.rules.fields, path)
+		}
+		compiled.rules.annotations = sets.New(r.Annotations...)
+		for _, pattern := range r.EnvNames {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("masking.rules[%d].envNames: %w", i, err)
+			}
+			compiled.rules.envNames = append(compiled.rules.envNames, re)
+		}
+		for _, pattern := range r.EnvValues {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("masking.rules[%d].envValues: %w", i, err)
+			}
+			compiled.rules.envValues = append(compiled.rules.envValues, re)
+		}
+		p.rules = append(p.rules, compiled)
+	}
+	return p, nil
+}
+
+func (p *policy) rulesFor(gvr schema.GroupVersionResource, stage string) *Rules {
+	key := cacheKey{gvr: gvr, stage: stage}
+	if rules, ok := p.cache.Load(key); ok {
+		return rules.(*Rules)
+	}
+
+	var merged *Rules
+	for _, r := range p.rules {
+		if r.stage != stage || !r.matches(gvr) {
+			continue
+		}
+		if merged == nil {
+			merged = &Rules{replacement: r.rules.replacement, annotations: sets.New[string]()}
+		}
+		merged.fields = append(merged.fields, r.rules.fields...)
+		merged.annotations.Insert(r.rules.annotations.UnsortedList()...)
+		merged.envNames = append(merged.envNames, r.rules.envNames...)
+		merged.envValues = append(merged.envValues, r.rules.envValues...)
+	}
+	p.cache.Store(key, merged)
+	return merged
+}
+
+func (r rule) matches(gvr schema.GroupVersionResource) bool {
+	return (r.group == "*" || r.group == gvr.Group) && (r.resource == "*" || r.resource == gvr.Resource) &&
+		(r.version == "" || r.version == gvr.Version)
+}
+
+// parseFieldPath parses the dot-separated field path, the dots in the field names are escaped by `\`.
+// The type meta and the metadata can't be masked, except the annotations which are masked by the keys.
+func parseFieldPath(path string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			field.WriteByte('.')
+			i++
+		case path[i] == '.':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(path[i])
+		}
+	}
+	fields = append(fields, field.String())
+
+	for _, field := range fields {
+		if field == "" {
+			return nil, fmt.Errorf("invalid field path %q", path)
+		}
+	}
+	switch fields[0] {
+	case "apiVersion", "kind", "metadata":
+		return nil, fmt.Errorf("the field %q can't be masked, mask the annotations by their keys", path)
+	}
+	return fields, nil
+}
+
+// Rules are the fields masked from the objects of a resource at a stage
+type Rules struct {
+	replacement string
+
+	fields      [][]string
+	annotations sets.Set[string]
+	envNames    []*regexp.Regexp
+	envValues   []*regexp.Regexp
+}
+
+// Mask masks the object in place, it returns true if any field is masked. A nil Rules masks nothing.
+func (r *Rules) Mask(obj map[string]interface{}) bool {
+	if r == nil {
+		return false
+	}
+
+	var masked bool
+	for _, path := range r.fields {
+		parent, ok, _ := unstructured.NestedFieldNoCopy(obj, path[:len(path)-1]...)
+		if !ok {
+			continue
+		}
+		if fields, ok := parent.(map[string]interface{}); ok {
+			if value, ok := fields[path[len(path)-1]]; ok {
+				fields[path[len(path)-1]] = r.maskValue(value, &masked)
+			}
+		}
+	}
+
+	if r.annotations.Len() != 0 {
+		annotations, _, _ := unstructured.NestedFieldNoCopy(obj, "metadata", "annotations")
+		if annotations, ok := annotations.(map[string]interface{}); ok {
+			for key := range annotations {
+				if r.annotations.Has(key) {
+					annotations[key], masked = r.replacement, true
+				}
+			}
+		}
+	}
+
+	if len(r.envNames) != 0 || len(r.envValues) != 0 {
+		r.maskContainers(obj, &masked)
+	}
+	return masked
+}
+
+// maskValue replaces the string values nested in the value, the other scalar values are kept,
+// so the masked objects can still be decoded into the typed objects.
+func (r *Rules) maskValue(value interface{}, masked *bool) interface{} {
+	switch value := value.(type) {
+	case string:
+		*masked = true
+		return r.replacement
+	case map[string]interface{}:
+		for key, v := range value {
+			value[key] = r.maskValue(v, masked)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = r.maskValue(v, masked)
+		}
+	}
+	return value
+}
+
+// maskContainers masks the environment variables of the containers found at any depth of the object
+func (r *Rules) maskContainers(obj map[string]interface{}, masked *bool) {
+	for key, value := range obj {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			r.maskContainers(value, masked)
+		case []interface{}:
+			isContainers := false
+			for _, field := range containerFields {
+				isContainers = isContainers || key == field
+			}
+			for _, item := range value {
+				item, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if isContainers {
+					r.maskEnv(item, masked)
+				} else {
+					r.maskContainers(item, masked)
+				}
+			}
+		}
+	}
+}
+
+func (r *Rules) maskEnv(container map[string]interface{}, masked *bool) {
+	env, _ := container["env"].([]interface{})
+	for _, item := range env {
+		variable, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, ok := variable["value"].(string)
+		if !ok || value == "" {
+			continue
+		}
+		name, _ := variable["name"].(string)
+		if matchAny(r.envNames, name) || matchAny(r.envValues, value) {
+			variable["value"], *masked = r.replacement, true
+		}
+	}
+}
+
+func matchAny(patterns []*regexp.Regexp, value string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskObject masks the object or the items of the list, the typed objects are converted to the version by the convertor
+// to be masked, because the internal types have no json tags, and they are converted back if any field is masked.
+// A nil Rules masks nothing.
+func (r *Rules) MaskObject(obj runtime.Object, convertor runtime.ObjectConvertor, version schema.GroupVersion) error {
+	if r == nil {
+		return nil
+	}
+	if meta.IsListType(obj) {
+		return meta.EachListItem(obj, func(item runtime.Object) error {
+			return r.MaskObject(item, convertor, version)
+		})
+	}
+
+	if u, ok := obj.(runtime.Unstructured); ok {
+		r.Mask(u.UnstructuredContent())
+		return nil
+	}
+
+	versioned, err := convertor.ConvertToVersion(obj.DeepCopyObject(), version)
+	if err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(versioned)
+	if err != nil {
+		return err
+	}
+	if !r.Mask(content) {
+		return nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, versioned); err != nil {
+		return err
+	}
+	if reflect.TypeOf(versioned) == reflect.TypeOf(obj) {
+		reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(versioned).Elem())
+		return nil
+	}
+	return convertor.Convert(versioned, obj, nil)
+}
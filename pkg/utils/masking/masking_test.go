@@ -0,0 +1,128 @@
+package masking
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	"k8s.io/kubernetes/pkg/apis/core"
+	_ "k8s.io/kubernetes/pkg/apis/core/install"
+
+	configv1alpha1 "github.com/clusterpedia-io/api/config/v1alpha1"
+)
+
+var (
+	deployments = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	pods        = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	secrets     = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+)
+
+func newMasker(t *testing.T) *Masker {
+	masker := &Masker{}
+	err := masker.Update(&configv1alpha1.MaskingConfiguration{
+		Rules: []configv1alpha1.MaskingRule{
+			{Group: "*", Resource: "*", EnvNames: []string{"(?i)token"}, EnvValues: []string{"^eyJ"}},
+			{Group: "", Resource: "secrets", Stage: configv1alpha1.MaskingStageServing, Fields: []string{"data"}, Annotations: []string{"example.io/note"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return masker
+}
+
+func TestMaskerUpdate(t *testing.T) {
+	masker := newMasker(t)
+	for _, config := range []*configv1alpha1.MaskingConfiguration{
+		{Rules: []configv1alpha1.MaskingRule{{Group: "*"}}},
+		{Rules: []configv1alpha1.MaskingRule{{Resource: "pods", Stage: "Never"}}},
+		{Rules: []configv1alpha1.MaskingRule{{Resource: "pods", Fields: []string{"metadata.name"}}}},
+		{Rules: []configv1alpha1.MaskingRule{{Resource: "pods", EnvNames: []string{"("}}}},
+	} {
+		if err := masker.Update(config); err == nil {
+			t.Errorf("Update(%+v) should fail", config.Rules[0])
+		}
+	}
+	if masker.Rules(pods, configv1alpha1.MaskingStageStorage) == nil {
+		t.Error("the rules should be kept after the invalid configuration")
+	}
+	if masker.Rules(pods, configv1alpha1.MaskingStageServing) != nil {
+		t.Error("the pods have no serving rules")
+	}
+
+	if err := masker.Update(nil); err != nil {
+		t.Fatal(err)
+	}
+	if masker.Rules(pods, configv1alpha1.MaskingStageStorage) != nil {
+		t.Error("the rules should be cleared")
+	}
+}
+
+func TestMask(t *testing.T) {
+	rules := newMasker(t).Rules(deployments, configv1alpha1.MaskingStageStorage)
+	obj := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{
+						"name": "web",
+						"env": []interface{}{
+							map[string]interface{}{"name": "API_TOKEN", "value": "abc"},
+							map[string]interface{}{"name": "AUTH", "value": "eyJhbGciOiJIUzI1NiJ9"},
+							map[string]interface{}{"name": "MODE", "value": "debug"},
+							map[string]interface{}{"name": "DB_TOKEN", "valueFrom": map[string]interface{}{}},
+						},
+					}},
+				},
+			},
+		},
+	}
+	if !rules.Mask(obj) {
+		t.Fatal("Mask() should mask the environment variables")
+	}
+	env, _, _ := unstructured.NestedSlice(obj, "spec", "template", "spec", "containers")
+	got := env[0].(map[string]interface{})["env"]
+	expected := []interface{}{
+		map[string]interface{}{"name": "API_TOKEN", "value": DefaultReplacement},
+		map[string]interface{}{"name": "AUTH", "value": DefaultReplacement},
+		map[string]interface{}{"name": "MODE", "value": "debug"},
+		map[string]interface{}{"name": "DB_TOKEN", "valueFrom": map[string]interface{}{}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("env = %v, want %v", got, expected)
+	}
+}
+
+func TestMaskObject(t *testing.T) {
+	masker := newMasker(t)
+
+	// the internal objects are masked in the version of the storage
+	pod := &core.Pod{Spec: core.PodSpec{
+		InitContainers: []core.Container{{Name: "init", Env: []core.EnvVar{{Name: "TOKEN", Value: "abc"}}}},
+	}}
+	rules := masker.Rules(pods, configv1alpha1.MaskingStageStorage)
+	if err := rules.MaskObject(pod, legacyscheme.Scheme, corev1.SchemeGroupVersion); err != nil {
+		t.Fatal(err)
+	}
+	if value := pod.Spec.InitContainers[0].Env[0].Value; value != DefaultReplacement {
+		t.Errorf("env value = %q, want %q", value, DefaultReplacement)
+	}
+
+	list := &corev1.SecretList{Items: []corev1.Secret{{Data: map[string][]byte{"token": []byte("abc")}}}}
+	list.Items[0].SetAnnotations(map[string]string{"example.io/note": "password", "example.io/owner": "infra"})
+	rules = masker.Rules(secrets, configv1alpha1.MaskingStageServing)
+	if err := rules.MaskObject(list, legacyscheme.Scheme, corev1.SchemeGroupVersion); err != nil {
+		t.Fatal(err)
+	}
+	secret := list.Items[0]
+	if data := string(secret.Data["token"]); data == "abc" || len(data) == 0 {
+		t.Errorf("the data of the secret should be masked, got %q", data)
+	}
+	if annotations := secret.GetAnnotations(); annotations["example.io/note"] != DefaultReplacement || annotations["example.io/owner"] != "infra" {
+		t.Errorf("annotations = %v, want the masked note", annotations)
+	}
+}
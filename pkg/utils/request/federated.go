@@ -0,0 +1,20 @@
+package request
+
+import "context"
+
+// FederatedRequestHeader marks the requests from the federation of the other clusterpedia apiserver,
+// the federated requests are served by the local storage only to avoid the federation loops.
+const FederatedRequestHeader = "X-Clusterpedia-Federated"
+
+type federatedKeyType int
+
+const federatedKey federatedKeyType = iota
+
+func WithFederated(parent context.Context) context.Context {
+	return context.WithValue(parent, federatedKey, true)
+}
+
+func IsFederated(ctx context.Context) bool {
+	federated, _ := ctx.Value(federatedKey).(bool)
+	return federated
+}
@@ -0,0 +1,25 @@
+package request
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type versionKeyType int
+
+const versionKey versionKeyType = iota
+
+// WithVersion returns a copy of parent in which the version of the clusterpedia.io api is set,
+// it is used to decode the search options of the request forwarded to the resource server.
+func WithVersion(parent context.Context, version schema.GroupVersion) context.Context {
+	if version.Empty() {
+		return parent
+	}
+	return context.WithValue(parent, versionKey, version)
+}
+
+func VersionFrom(ctx context.Context) (schema.GroupVersion, bool) {
+	version, ok := ctx.Value(versionKey).(schema.GroupVersion)
+	return version, ok
+}
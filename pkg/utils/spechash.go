@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// SpecHash returns the hash of the normalized content of the object,
+// the objects replicated from the same template to different clusters have the same spec hash.
+func SpecHash(obj runtime.Object) (string, error) {
+	var content map[string]interface{}
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		content = runtime.DeepCopyJSON(u.UnstructuredContent())
+	} else {
+		var err error
+		if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+			return "", err
+		}
+	}
+	return specHash(content)
+}
+
+// SpecHashFromJSON returns the spec hash of the object encoded in json.
+func SpecHashFromJSON(data []byte) (string, error) {
+	var content map[string]interface{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return "", err
+	}
+	return specHash(content)
+}
+
+func specHash(content map[string]interface{}) (string, error) {
+	normalizeSpec(content)
+
+	// the keys of the maps are sorted by json.Marshal
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// normalizeSpec removes the fields that are set by the member clusters or by clusterpedia,
+// they are different even though the objects are replicated from the same template.
+// The type meta is also removed, because it may be omitted by the typed objects.
+func normalizeSpec(content map[string]interface{}) {
+	for _, field := range []string{"apiVersion", "kind", "status"} {
+		delete(content, field)
+	}
+
+	metadata, ok := content["metadata"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, field := range []string{"uid", "resourceVersion", "creationTimestamp", "deletionTimestamp", "generation", "managedFields", "selfLink", "ownerReferences"} {
+		delete(metadata, field)
+	}
+
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	for key := range annotations {
+		if strings.HasPrefix(key, "shadow.clusterpedia.io/") {
+			delete(annotations, key)
+		}
+	}
+	if len(annotations) == 0 {
+		delete(metadata, "annotations")
+	}
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+func TestSpecHash(t *testing.T) {
+	newPod := func(cluster, uid, image string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "default",
+				Name:            "pod-1",
+				UID:             types.UID(uid),
+				ResourceVersion: uid,
+				Annotations:     map[string]string{internal.ShadowAnnotationClusterName: cluster},
+			},
+			Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	hash, err := SpecHash(newPod("cluster-1", "1", "nginx", corev1.PodRunning))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replicated, _ := SpecHash(newPod("cluster-2", "2", "nginx", corev1.PodPending)); replicated != hash {
+		t.Errorf("the replicated object should have the same spec hash")
+	}
+	if changed, _ := SpecHash(newPod("cluster-1", "1", "redis", corev1.PodRunning)); changed == hash {
+		t.Errorf("the changed object should have a different spec hash")
+	}
+
+	// the typed object without type meta, the unstructured object and the json have the same hash
+	typed := newPod("cluster-1", "1", "nginx", corev1.PodRunning)
+	data, err := json.Marshal(typed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	typed.TypeMeta = metav1.TypeMeta{}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(typed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, get := range map[string]func() (string, error){
+		"typed":        func() (string, error) { return SpecHash(typed) },
+		"unstructured": func() (string, error) { return SpecHash(&unstructured.Unstructured{Object: content}) },
+		"json":         func() (string, error) { return SpecHashFromJSON(data) },
+	} {
+		if h, err := get(); err != nil || h != hash {
+			t.Errorf("%s spec hash = %q, %v, want %q", name, h, err, hash)
+		}
+	}
+}
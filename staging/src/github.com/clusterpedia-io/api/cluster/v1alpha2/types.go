@@ -11,6 +11,18 @@ const (
 	ClusterHealthyCondition = "ClusterHealthy"
 	ReadyCondition          = "Ready"
 
+	// SyncResourcesNegotiatedCondition records the last change of the synchronized resources,
+	// when the resources are added to or removed from the sync by the sync resources or the discovery of the cluster.
+	SyncResourcesNegotiatedCondition = "SyncResourcesNegotiated"
+
+	// DeletionsFrozenCondition is true when the deletions of the resources are frozen by the mass deletion guard,
+	// the frozen deletions are propagated to the storage after the ConfirmDeletionsAnnotation is updated.
+	DeletionsFrozenCondition = "DeletionsFrozen"
+
+	// StorageHealthyCondition is false when the storage of the clustersynchro manager is unavailable,
+	// the resources of the cluster are not synchronized to the storage until it recovers.
+	StorageHealthyCondition = "StorageHealthy"
+
 	// deprecated
 	ClusterSynchroInitializedCondition = "ClusterSynchroInitialized"
 )
@@ -30,9 +42,29 @@ const (
 	ClusterHealthyReason      = "Healthy"
 	ClusterUnhealthyReason    = "Unhealthy"
 	ClusterNotReachableReason = "NotReachable"
+	ClusterUnauthorizedReason = "Unauthorized"
+	ClusterWatchFailedReason  = "WatchFailed"
 
 	ReadyReason    = "Ready"
 	NotReadyReason = "NotReady"
+
+	SyncResourcesNegotiatedReason = "Negotiated"
+	SyncResourcesChangedReason    = "SyncResourcesChanged"
+
+	MassDeletionDetectedReason = "MassDeletionDetected"
+	DeletionsNotFrozenReason   = "NotFrozen"
+
+	StorageAvailableReason   = "Available"
+	StorageUnavailableReason = "Unavailable"
+)
+
+const (
+	// ConfirmDeletionsAnnotation confirms the frozen deletions of the cluster when its value is changed.
+	ConfirmDeletionsAnnotation = "cluster.clusterpedia.io/confirm-deletions"
+
+	// ResyncAnnotation relists a resource of the cluster and rewrites its stored objects when its value is changed,
+	// the value is in the form of `resource=<resource>[.<group>][,namespace=<namespace>],requestedAt=<RFC3339 time>`.
+	ResyncAnnotation = "cluster.clusterpedia.io/resync"
 )
 
 const (
@@ -88,17 +120,79 @@ type ClusterSpec struct {
 
 	AuthenticationFrom *ClusterAuthentication `json:"authenticationFrom,omitempty"`
 
-	// +required
+	// SyncResources are synchronized in addition to the resources of the SyncProfile and the SyncResourcesRefName.
+	// +optional
 	SyncResources []ClusterGroupResources `json:"syncResources"`
 
 	// +optional
 	SyncAllCustomResources bool `json:"syncAllCustomResources,omitempty"`
 
+	// SyncCustomResourceReferences syncs the resources referenced by the synced custom resources,
+	// they are found by the reference fields of the CRD schema, e.g. `secretRef` and `configMapRef`,
+	// and the `clusterpedia.io/referenced-resources` annotation of the CRD.
+	// +optional
+	SyncCustomResourceReferences bool `json:"syncCustomResourceReferences,omitempty"`
+
 	// +optional
 	SyncResourcesRefName string `json:"syncResourcesRefName,omitempty"`
 
+	// SyncProfile is the name of the built-in sync profile whose curated resources and prune configuration
+	// are used for the cluster, the Prune of the cluster overrides the prune configuration of the profile.
+	// +optional
+	// +kubebuilder:validation:Enum=inventory;workloads;full
+	SyncProfile string `json:"syncProfile,omitempty"`
+
 	// +optional
 	ShardingName string `json:"shardingName,omitempty"`
+
+	// Prune overrides the prune configuration of the ClusterpediaConfiguration for the cluster,
+	// the change applies to the objects when they are synchronized again.
+	// +optional
+	Prune *ClusterPruneConfiguration `json:"prune,omitempty"`
+}
+
+type ClusterPruneConfiguration struct {
+	// ManagedFields prunes the `metadata.managedFields` of the objects.
+	// +optional
+	ManagedFields *bool `json:"managedFields,omitempty"`
+
+	// LastAppliedConfiguration prunes the `kubectl.kubernetes.io/last-applied-configuration` annotation of the objects.
+	// +optional
+	LastAppliedConfiguration *bool `json:"lastAppliedConfiguration,omitempty"`
+
+	// Fields are the paths of the fields pruned from the objects in addition to the fields of the ClusterpediaConfiguration,
+	// formatted as the dot-separated field names, e.g. `status.images`.
+	// +optional
+	// +listType=set
+	Fields []string `json:"fields,omitempty"`
+
+	// Resources override the prune configuration for the resources of the cluster.
+	// +optional
+	Resources []ResourcePruneConfiguration `json:"resources,omitempty"`
+}
+
+type ResourcePruneConfiguration struct {
+	// Group is the group of the resource, the empty group is the core group.
+	// +optional
+	Group string `json:"group"`
+
+	// Version is the version of the resource, the empty version matches all versions.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
+
+	// +optional
+	ManagedFields *bool `json:"managedFields,omitempty"`
+
+	// +optional
+	LastAppliedConfiguration *bool `json:"lastAppliedConfiguration,omitempty"`
+
+	// +optional
+	// +listType=set
+	Fields []string `json:"fields,omitempty"`
 }
 
 type ClusterAuthentication struct {
@@ -141,6 +235,25 @@ type ClusterGroupResources struct {
 
 	// +optional
 	EventsInvolvedResources []string `json:"eventsInvolvedResources"`
+
+	// SkipOwnedResources skips syncing the resources owned by the specified kinds,
+	// eg. the pods owned by jobs, which can be derived from their owners.
+	// +optional
+	SkipOwnedResources []OwnedResourcesFilter `json:"skipOwnedResources,omitempty"`
+}
+
+type OwnedResourcesFilter struct {
+	// Resource is the resource of the group, `*` matches all resources of the group.
+	// +required
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
+
+	// OwnerKinds are the kinds of the owners, formatted as `<kind>` or `<kind>.<group>`, eg. `Job.batch`.
+	// The resources with any owner reference of the kinds are not synced.
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	OwnerKinds []string `json:"ownerKinds"`
 }
 
 type ClusterStatus struct {
@@ -158,6 +271,32 @@ type ClusterStatus struct {
 
 	// +optional
 	ShardingName *string `json:"shardingName,omitempty"`
+
+	// +optional
+	Connection *ClusterConnectionStatus `json:"connection,omitempty"`
+}
+
+// ClusterConnectionStatus is measured by the health checks of the cluster,
+// the values are summarized over the recent health checks.
+type ClusterConnectionStatus struct {
+	// LatencyP50 is the median round-trip latency of the health checks
+	// +optional
+	LatencyP50 *metav1.Duration `json:"latencyP50,omitempty"`
+
+	// +optional
+	LatencyP90 *metav1.Duration `json:"latencyP90,omitempty"`
+
+	// +optional
+	LatencyP99 *metav1.Duration `json:"latencyP99,omitempty"`
+
+	// ClockSkew is the estimated offset of the cluster clock from the clock of the clustersynchro manager,
+	// it is positive if the cluster clock is ahead.
+	// The skew is estimated from the `Date` headers of the responses, so its resolution is one second.
+	// +optional
+	ClockSkew *metav1.Duration `json:"clockSkew,omitempty"`
+
+	// +optional
+	LastMeasureTime metav1.Time `json:"lastMeasureTime,omitempty"`
 }
 
 type ClusterGroupResourcesStatus struct {
@@ -10,6 +10,101 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAuthentication) DeepCopyInto(out *ClusterAuthentication) {
+	*out = *in
+	if in.KubeConfig != nil {
+		in, out := &in.KubeConfig, &out.KubeConfig
+		*out = new(ClusterAuthenticationSource)
+		**out = **in
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(ClusterAuthenticationSource)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(ClusterAuthenticationSource)
+		**out = **in
+	}
+	if in.Cert != nil {
+		in, out := &in.Cert, &out.Cert
+		*out = new(ClusterAuthenticationSource)
+		**out = **in
+	}
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(ClusterAuthenticationSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAuthentication.
+func (in *ClusterAuthentication) DeepCopy() *ClusterAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAuthenticationSource) DeepCopyInto(out *ClusterAuthenticationSource) {
+	*out = *in
+	out.SecretKeySelector = in.SecretKeySelector
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAuthenticationSource.
+func (in *ClusterAuthenticationSource) DeepCopy() *ClusterAuthenticationSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAuthenticationSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConnectionStatus) DeepCopyInto(out *ClusterConnectionStatus) {
+	*out = *in
+	if in.LatencyP50 != nil {
+		in, out := &in.LatencyP50, &out.LatencyP50
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LatencyP90 != nil {
+		in, out := &in.LatencyP90, &out.LatencyP90
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LatencyP99 != nil {
+		in, out := &in.LatencyP99, &out.LatencyP99
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ClockSkew != nil {
+		in, out := &in.ClockSkew, &out.ClockSkew
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	in.LastMeasureTime.DeepCopyInto(&out.LastMeasureTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConnectionStatus.
+func (in *ClusterConnectionStatus) DeepCopy() *ClusterConnectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterGroupResources) DeepCopyInto(out *ClusterGroupResources) {
 	*out = *in
@@ -23,6 +118,18 @@ func (in *ClusterGroupResources) DeepCopyInto(out *ClusterGroupResources) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EventsInvolvedResources != nil {
+		in, out := &in.EventsInvolvedResources, &out.EventsInvolvedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkipOwnedResources != nil {
+		in, out := &in.SkipOwnedResources, &out.SkipOwnedResources
+		*out = make([]OwnedResourcesFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -59,6 +166,44 @@ func (in *ClusterGroupResourcesStatus) DeepCopy() *ClusterGroupResourcesStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPruneConfiguration) DeepCopyInto(out *ClusterPruneConfiguration) {
+	*out = *in
+	if in.ManagedFields != nil {
+		in, out := &in.ManagedFields, &out.ManagedFields
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastAppliedConfiguration != nil {
+		in, out := &in.LastAppliedConfiguration, &out.LastAppliedConfiguration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourcePruneConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPruneConfiguration.
+func (in *ClusterPruneConfiguration) DeepCopy() *ClusterPruneConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPruneConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterResourceStatus) DeepCopyInto(out *ClusterResourceStatus) {
 	*out = *in
@@ -127,6 +272,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.AuthenticationFrom != nil {
+		in, out := &in.AuthenticationFrom, &out.AuthenticationFrom
+		*out = new(ClusterAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SyncResources != nil {
 		in, out := &in.SyncResources, &out.SyncResources
 		*out = make([]ClusterGroupResources, len(*in))
@@ -134,6 +284,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(ClusterPruneConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -169,6 +324,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(ClusterConnectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -265,6 +425,27 @@ func (in *ClusterSyncResourcesSpec) DeepCopy() *ClusterSyncResourcesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnedResourcesFilter) DeepCopyInto(out *OwnedResourcesFilter) {
+	*out = *in
+	if in.OwnerKinds != nil {
+		in, out := &in.OwnerKinds, &out.OwnerKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnedResourcesFilter.
+func (in *OwnedResourcesFilter) DeepCopy() *OwnedResourcesFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnedResourcesFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PediaCluster) DeepCopyInto(out *PediaCluster) {
 	*out = *in
@@ -325,3 +506,50 @@ func (in *PediaClusterList) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePruneConfiguration) DeepCopyInto(out *ResourcePruneConfiguration) {
+	*out = *in
+	if in.ManagedFields != nil {
+		in, out := &in.ManagedFields, &out.ManagedFields
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastAppliedConfiguration != nil {
+		in, out := &in.LastAppliedConfiguration, &out.LastAppliedConfiguration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePruneConfiguration.
+func (in *ResourcePruneConfiguration) DeepCopy() *ResourcePruneConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePruneConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeySelector.
+func (in *SecretKeySelector) DeepCopy() *SecretKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
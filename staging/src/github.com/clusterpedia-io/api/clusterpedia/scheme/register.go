@@ -1,8 +1,12 @@
 package scheme
 
 import (
+	"net/url"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
 	"github.com/clusterpedia-io/api/clusterpedia/install"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 )
 
@@ -15,3 +19,19 @@ var ParameterCodec = runtime.NewParameterCodec(scheme)
 func init() {
 	install.Install(scheme)
 }
+
+// DecodeListOptions decodes the query into the internal ListOptions by the version.
+//
+// ParameterCodec skips the empty query, so the defaults of the versioned ListOptions are applied here.
+func DecodeListOptions(query url.Values, version schema.GroupVersion, into *internal.ListOptions) error {
+	if len(query) != 0 {
+		return ParameterCodec.DecodeParameters(query, version, into)
+	}
+
+	versioned, err := scheme.New(version.WithKind("ListOptions"))
+	if err != nil {
+		return err
+	}
+	scheme.Default(versioned)
+	return scheme.Convert(versioned, into, nil)
+}
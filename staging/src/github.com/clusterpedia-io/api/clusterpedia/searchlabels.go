@@ -0,0 +1,98 @@
+package clusterpedia
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// SearchLabelPrefix is the prefix of the search labels
+const SearchLabelPrefix = "search.clusterpedia.io/"
+
+var builtinSearchLabels = map[string]bool{
+	SearchLabelNames: true, SearchLabelClusters: true, SearchLabelNamespaces: true, SearchLabelOrderBy: true,
+	SearchLabelOwnerUID: true, SearchLabelOwnerName: true, SearchLabelOwnerGroupResource: true, SearchLabelOwnerSeniority: true,
+	SearchLabelInjectEvents: true, SearchLabelWithContinue: true, SearchLabelWithRemainingCount: true,
+	SearchLabelDedup: true, SearchLabelCountOnly: true, SearchLabelLimit: true, SearchLabelOffset: true,
+	SearchLabelSince: true, SearchLabelBefore: true, SearchLabelForwardRequest: true,
+}
+
+// CustomSearchLabel is a search label defined by the extensions, the label is validated when the list options are parsed
+// and is passed to the storage in the ExtraLabelSelector, so the storage layers can build the query of it with their own hooks.
+//
+// +k8s:deepcopy-gen=false
+type CustomSearchLabel struct {
+	// Key is the key of the label, it must be prefixed with `search.clusterpedia.io/`
+	Key string
+
+	// Description documents the label in the OpenAPI of the apiserver
+	Description string
+
+	// Parse validates and normalizes the values of the label, the values are passed to the storage as is if it is nil.
+	Parse func(operator selection.Operator, values []string) ([]string, error)
+}
+
+var (
+	customSearchLabelsLock sync.RWMutex
+	customSearchLabels     = map[string]CustomSearchLabel{}
+)
+
+// RegisterSearchLabel registers the custom search label, it is usually called in the init of the extensions.
+func RegisterSearchLabel(label CustomSearchLabel) error {
+	name := strings.TrimPrefix(label.Key, SearchLabelPrefix)
+	if name == label.Key || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("the custom search label %q must be in the form of `%s<key>`", label.Key, SearchLabelPrefix)
+	}
+	if builtinSearchLabels[label.Key] {
+		return fmt.Errorf("the search label %q is built-in", label.Key)
+	}
+
+	customSearchLabelsLock.Lock()
+	defer customSearchLabelsLock.Unlock()
+	if _, ok := customSearchLabels[label.Key]; ok {
+		return fmt.Errorf("the search label %q is already registered", label.Key)
+	}
+	customSearchLabels[label.Key] = label
+	return nil
+}
+
+// LookupSearchLabel returns the registered custom search label of the key
+func LookupSearchLabel(key string) (CustomSearchLabel, bool) {
+	customSearchLabelsLock.RLock()
+	defer customSearchLabelsLock.RUnlock()
+	label, ok := customSearchLabels[key]
+	return label, ok
+}
+
+// CustomSearchLabels returns the registered custom search labels sorted by the key
+func CustomSearchLabels() []CustomSearchLabel {
+	customSearchLabelsLock.RLock()
+	defer customSearchLabelsLock.RUnlock()
+	labels := make([]CustomSearchLabel, 0, len(customSearchLabels))
+	for _, label := range customSearchLabels {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Key < labels[j].Key })
+	return labels
+}
+
+// ParseRequirement parses the requirement of the custom search label with the normalized values
+func (label CustomSearchLabel) ParseRequirement(requirement labels.Requirement) (labels.Requirement, error) {
+	if label.Parse == nil {
+		return requirement, nil
+	}
+
+	values, err := label.Parse(requirement.Operator(), requirement.Values().List())
+	if err != nil {
+		return labels.Requirement{}, fmt.Errorf("Invalid Query %s: %w", label.Key, err)
+	}
+	parsed, err := labels.NewRequirement(requirement.Key(), requirement.Operator(), values)
+	if err != nil {
+		return labels.Requirement{}, fmt.Errorf("Invalid Query %s: %w", label.Key, err)
+	}
+	return *parsed, nil
+}
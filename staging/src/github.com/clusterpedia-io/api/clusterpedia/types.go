@@ -26,6 +26,8 @@ const (
 	SearchLabelInjectEvents       = "search.clusterpedia.io/inject-events"
 	SearchLabelWithContinue       = "search.clusterpedia.io/with-continue"
 	SearchLabelWithRemainingCount = "search.clusterpedia.io/with-remaining-count"
+	SearchLabelDedup              = "search.clusterpedia.io/dedup"
+	SearchLabelCountOnly          = "search.clusterpedia.io/count-only"
 
 	SearchLabelLimit  = "search.clusterpedia.io/limit"
 	SearchLabelOffset = "search.clusterpedia.io/offset"
@@ -35,9 +37,27 @@ const (
 
 	SearchLabelForwardRequest = "search.clusterpedia.io/forward"
 
+	// SearchLabelFuzzyName searches the resources whose names contain the value,
+	// the domain is kept for the compatibility with the clients of the default storage layer.
+	SearchLabelFuzzyName = "internalstorage.clusterpedia.io/fuzzy-name"
+
 	ShadowAnnotationClusterName          = "shadow.clusterpedia.io/cluster-name"
 	ShadowAnnotationGroupVersionResource = "shadow.clusterpedia.io/gvr"
 	ShadowAnnotationEvents               = "shadow.clusterpedia.io/events"
+	ShadowAnnotationDedupClusters        = "shadow.clusterpedia.io/dedup-clusters"
+
+	// ShadowAnnotationClusterLabelPrefix is the prefix of the annotations of the cluster labels stamped on the objects
+	ShadowAnnotationClusterLabelPrefix = "cluster-labels.shadow.clusterpedia.io/"
+)
+
+const (
+	// ResourceScopeCluster and ResourceScopeNamespaced limit the collection resource to the cluster-scoped
+	// or the namespaced resources by the ResourceScope of the list options.
+	ResourceScopeCluster    = "Cluster"
+	ResourceScopeNamespaced = "Namespaced"
+
+	// GroupByCluster groups the items of the collection resource by the cluster and then the namespace
+	GroupByCluster = "cluster"
 )
 
 type OrderBy struct {
@@ -79,6 +99,17 @@ type ListOptions struct {
 	// RelatedResources []schema.GroupVersionKind
 
 	OnlyMetadata bool
+
+	Dedup bool
+
+	// CountOnly only counts the matched resources into the remaining item count of the list without the items
+	CountOnly bool
+
+	// ResourceScope limits the collection resource to the cluster-scoped or the namespaced resources, empty lists both.
+	ResourceScope string
+
+	// GroupBy groups the items of the collection resource into the groups of the response, empty doesn't group them.
+	GroupBy string
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -89,6 +120,9 @@ type CollectionResource struct {
 	ResourceTypes []CollectionResourceType
 	Items         []runtime.Object
 
+	// Groups are the items grouped by the GroupBy of the list options, the Items are moved into the groups.
+	Groups []CollectionResourceGroup
+
 	Continue           string
 	RemainingItemCount *int64
 }
@@ -101,6 +135,19 @@ type CollectionResourceList struct {
 	Items []CollectionResource
 }
 
+// CollectionResourceGroup is the items of a cluster,
+// the cluster-scoped items are in the Items and the namespaced items are grouped by the namespace.
+type CollectionResourceGroup struct {
+	Cluster    string
+	Items      []runtime.Object
+	Namespaces []CollectionResourceNamespaceGroup
+}
+
+type CollectionResourceNamespaceGroup struct {
+	Namespace string
+	Items     []runtime.Object
+}
+
 type CollectionResourceType struct {
 	Group    string
 	Version  string
@@ -71,6 +71,8 @@ func Convert_v1beta1_ListOptions_To_clusterpedia_ListOptions(in *ListOptions, ou
 	out.InjectEvents = in.InjectEvents
 	out.WithContinue = in.WithContinue
 	out.WithRemainingCount = in.WithRemainingCount
+	out.Dedup = in.Dedup
+	out.CountOnly = in.CountOnly
 
 	if out.LabelSelector != nil {
 		var (
@@ -161,6 +163,18 @@ func Convert_v1beta1_ListOptions_To_clusterpedia_ListOptions(in *ListOptions, ou
 							return err
 						}
 					}
+				case clusterpedia.SearchLabelDedup:
+					if !in.Dedup && len(values) != 0 {
+						if err := runtime.Convert_Slice_string_To_bool(&values, &out.Dedup, s); err != nil {
+							return err
+						}
+					}
+				case clusterpedia.SearchLabelCountOnly:
+					if !in.CountOnly && len(values) != 0 {
+						if err := runtime.Convert_Slice_string_To_bool(&values, &out.CountOnly, s); err != nil {
+							return err
+						}
+					}
 				case clusterpedia.SearchLabelWithRemainingCount:
 					if in.WithRemainingCount == nil && len(values) != 0 {
 						if err := runtime.Convert_Slice_string_To_Pointer_bool(&values, &out.WithRemainingCount, s); err != nil {
@@ -168,7 +182,13 @@ func Convert_v1beta1_ListOptions_To_clusterpedia_ListOptions(in *ListOptions, ou
 						}
 					}
 				default:
-					if strings.Contains(require.Key(), "clusterpedia.io") {
+					if label, ok := clusterpedia.LookupSearchLabel(require.Key()); ok {
+						parsed, err := label.ParseRequirement(require)
+						if err != nil {
+							return err
+						}
+						extraLabelRequest = append(extraLabelRequest, parsed)
+					} else if strings.Contains(require.Key(), "clusterpedia.io") {
 						extraLabelRequest = append(extraLabelRequest, require)
 					} else {
 						labelRequest = append(labelRequest, require)
@@ -194,6 +214,8 @@ func Convert_v1beta1_ListOptions_To_clusterpedia_ListOptions(in *ListOptions, ou
 	}
 
 	out.OnlyMetadata = in.OnlyMetadata
+	out.ResourceScope = in.ResourceScope
+	out.GroupBy = in.GroupBy
 	return nil
 }
 
@@ -234,6 +256,10 @@ func Convert_clusterpedia_ListOptions_To_v1beta1_ListOptions(in *clusterpedia.Li
 	out.InjectEvents = in.InjectEvents
 	out.WithContinue = in.WithContinue
 	out.WithRemainingCount = in.WithRemainingCount
+	out.Dedup = in.Dedup
+	out.CountOnly = in.CountOnly
+	out.ResourceScope = in.ResourceScope
+	out.GroupBy = in.GroupBy
 	return nil
 }
 
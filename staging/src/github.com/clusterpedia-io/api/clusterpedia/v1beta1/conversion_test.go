@@ -0,0 +1,76 @@
+package v1beta1
+
+import (
+	"net/url"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+func newParameterCodec(t *testing.T) runtime.ParameterCodec {
+	scheme := runtime.NewScheme()
+	if err := internal.Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return runtime.NewParameterCodec(scheme)
+}
+
+func TestConvertSearchLabelsToListOptions(t *testing.T) {
+	codec := newParameterCodec(t)
+
+	tests := []struct {
+		name  string
+		query url.Values
+
+		dedup     bool
+		countOnly bool
+	}{
+		{
+			name:  "dedup label",
+			query: url.Values{"labelSelector": []string{"search.clusterpedia.io/dedup=true"}},
+			dedup: true,
+		},
+		{
+			name:  "dedup query parameter",
+			query: url.Values{"dedup": []string{"true"}},
+			dedup: true,
+		},
+		{
+			name:  "dedup label is disabled",
+			query: url.Values{"labelSelector": []string{"search.clusterpedia.io/dedup=false"}},
+		},
+		{
+			name:      "count-only label",
+			query:     url.Values{"labelSelector": []string{"search.clusterpedia.io/count-only=true"}},
+			countOnly: true,
+		},
+		{
+			name:      "count-only query parameter",
+			query:     url.Values{"countOnly": []string{"true"}},
+			countOnly: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var opts internal.ListOptions
+			if err := codec.DecodeParameters(test.query, SchemeGroupVersion, &opts); err != nil {
+				t.Fatal(err)
+			}
+
+			if opts.Dedup != test.dedup {
+				t.Errorf("Dedup = %v, want %v", opts.Dedup, test.dedup)
+			}
+			if opts.CountOnly != test.countOnly {
+				t.Errorf("CountOnly = %v, want %v", opts.CountOnly, test.countOnly)
+			}
+			if opts.ExtraLabelSelector != nil && !opts.ExtraLabelSelector.Empty() {
+				t.Errorf("the search labels are kept in the extra label selector: %s", opts.ExtraLabelSelector)
+			}
+		})
+	}
+}
@@ -25,6 +25,20 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CollectionResource{},
 		&CollectionResourceList{},
 		&Resources{},
+		&BulkGet{},
+		&ApplyDiff{},
+		&SchemaValidation{},
+		&MetadataKeys{},
+		&NamespaceInventory{},
+		&SpecHashes{},
+		&ResourceHistory{},
+		&ResourceSnapshot{},
+		&Tombstones{},
+		&Archives{},
+		&ReplicaSummaries{},
+		&FleetOverview{},
+		&StorageUsage{},
+		&Aggregation{},
 		&ListOptions{},
 
 		&metav1.GetOptions{},
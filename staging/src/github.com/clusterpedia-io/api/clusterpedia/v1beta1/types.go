@@ -5,6 +5,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // +k8s:conversion-gen:explicit-from=net/url.Values
@@ -14,48 +15,100 @@ import (
 type ListOptions struct {
 	metav1.ListOptions `json:",inline"`
 
+	// Names is a comma-separated list of resource names to match.
+	// The same as the search label `search.clusterpedia.io/names`.
 	// +optional
 	Names string `json:"names,omitempty"`
 
+	// ClusterNames is a comma-separated list of member clusters to search in.
+	// The same as the search label `search.clusterpedia.io/clusters`.
 	// +optional
 	ClusterNames string `json:"clusters,omitempty"`
 
+	// Namespaces is a comma-separated list of namespaces to search in.
+	// The same as the search label `search.clusterpedia.io/namespaces`.
 	// +optional
 	Namespaces string `json:"namespaces,omitempty"`
 
+	// OrderBy is a comma-separated list of sort fields, a field followed by ' desc' is sorted in descending order,
+	// eg. 'cluster,created_at desc'. The order of the fields is preserved, unlike the search label `search.clusterpedia.io/orderby`.
 	// +optional
 	OrderBy string `json:"orderby,omitempty"`
 
+	// OwnerUID searches the resources owned by the resource with this uid, it requires exactly one cluster.
+	// The same as the search label `search.clusterpedia.io/owner-uid`.
 	// +optional
 	OwnerUID string `json:"ownerUID,omitempty"`
 
+	// OwnerName searches the resources owned by the resource with this name, it requires exactly one cluster.
+	// The same as the search label `search.clusterpedia.io/owner-name`.
 	// +optional
 	OwnerName string `json:"ownerName,omitempty"`
 
+	// Since limits the creation time of resources to be at or after this time,
+	// in RFC3339, '2006-01-02 15:04:05', '2006-01-02' or unix timestamp(s or ms) format.
+	// The same as the search label `search.clusterpedia.io/since`.
 	// +optional
 	Since string `json:"since,omitempty"`
 
+	// Before limits the creation time of resources to be before this time, in the same formats as Since.
+	// The same as the search label `search.clusterpedia.io/before`.
 	// +optional
 	Before string `json:"before,omitempty"`
 
+	// OwnerGroupResource is the group resource of the owner when searching by OwnerName, eg. 'deployments.apps'.
+	// The same as the search label `search.clusterpedia.io/owner-gr`.
 	// +optional
 	OwnerGroupResource string `json:"ownerGR,omitempty"`
 
+	// OwnerSeniority is the number of ownership levels between the owner and the resources,
+	// eg. 1 searches the pods of a deployment through its replicasets.
+	// The same as the search label `search.clusterpedia.io/owner-seniority`.
 	// +optional
 	OwnerSeniority int `json:"ownerSeniority,omitempty"`
 
+	// InjectEvents injects the events of the resources into the annotation `shadow.clusterpedia.io/events`.
+	// The same as the search label `search.clusterpedia.io/inject-events`.
 	// +optional
 	InjectEvents bool `json:"injectEvents,omitempty"`
 
+	// WithContinue returns the continue token for the next page when the limit is reached.
+	// The same as the search label `search.clusterpedia.io/with-continue`.
 	// +optional
 	WithContinue *bool `json:"withContinue,omitempty"`
 
+	// WithRemainingCount returns the count of the remaining items.
+	// The same as the search label `search.clusterpedia.io/with-remaining-count`.
 	// +optional
 	WithRemainingCount *bool `json:"withRemainingCount,omitempty"`
 
+	// OnlyMetadata only returns the metadata of the resources.
 	// +optional
 	OnlyMetadata bool `json:"onlyMetadata,omitempty"`
 
+	// Dedup groups the identical resources replicated to multiple clusters into a single result,
+	// the clusters of the result are set into the annotation `shadow.clusterpedia.io/dedup-clusters`.
+	// The same as the search label `search.clusterpedia.io/dedup`.
+	// +optional
+	Dedup bool `json:"dedup,omitempty"`
+
+	// CountOnly only returns the count of the matched resources as the remainingItemCount of an empty list,
+	// the limit and the continue are ignored.
+	// The same as the search label `search.clusterpedia.io/count-only`.
+	// +optional
+	CountOnly bool `json:"countOnly,omitempty"`
+
+	// ResourceScope limits the collection resource to the cluster-scoped or the namespaced resources,
+	// the values are 'Cluster' and 'Namespaced', empty lists both. It is only for the collection resources.
+	// +optional
+	ResourceScope string `json:"resourceScope,omitempty"`
+
+	// GroupBy groups the items of the collection resource into the groups of the response, 'cluster' groups them
+	// by the cluster and then the namespace, and the items are sorted by the cluster and the namespace if the orderby is not set.
+	// It is only for the collection resources.
+	// +optional
+	GroupBy string `json:"groupBy,omitempty"`
+
 	urlQuery url.Values
 }
 
@@ -81,6 +134,10 @@ type CollectionResource struct {
 	// +optional
 	Items []runtime.RawExtension `json:"items,omitempty"`
 
+	// Groups are the items grouped by the `groupBy` query, the items are moved into the groups.
+	// +optional
+	Groups []CollectionResourceGroup `json:"groups,omitempty"`
+
 	// +optional
 	Continue string `json:"continue,omitempty"`
 
@@ -88,6 +145,25 @@ type CollectionResource struct {
 	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"`
 }
 
+// CollectionResourceGroup is the items of a cluster,
+// the cluster-scoped items are in the items and the namespaced items are grouped by the namespace.
+type CollectionResourceGroup struct {
+	Cluster string `json:"cluster"`
+
+	// +optional
+	Items []runtime.RawExtension `json:"items,omitempty"`
+
+	// +optional
+	Namespaces []CollectionResourceNamespaceGroup `json:"namespaces,omitempty"`
+}
+
+type CollectionResourceNamespaceGroup struct {
+	Namespace string `json:"namespace"`
+
+	// +optional
+	Items []runtime.RawExtension `json:"items,omitempty"`
+}
+
 type CollectionResourceType struct {
 	Group string `json:"group"`
 
@@ -111,3 +187,601 @@ type CollectionResourceList struct {
 
 	Items []CollectionResource `json:"items"`
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BulkGet fetches multiple objects of the clusters in one request,
+// the results in the status are in the same order as the objects in the spec.
+type BulkGet struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Spec BulkGetSpec `json:"spec"`
+
+	// +optional
+	Status BulkGetStatus `json:"status,omitempty"`
+}
+
+type BulkGetSpec struct {
+	Objects []BulkGetObjectReference `json:"objects"`
+}
+
+// BulkGetObjectReference refers to an object by its cluster, namespace and name, or by its uid.
+type BulkGetObjectReference struct {
+	// Cluster is required when getting the object by name.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	Version string `json:"version"`
+
+	Resource string `json:"resource"`
+
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// UID gets the object by its uid instead of its name,
+	// the cluster and namespace are optional to narrow the search.
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+}
+
+type BulkGetStatus struct {
+	// +optional
+	Results []BulkGetResult `json:"results,omitempty"`
+}
+
+type BulkGetResult struct {
+	// +optional
+	Object runtime.RawExtension `json:"object,omitempty"`
+
+	// Error is the reason why the object can not be got, eg. NotFound.
+	// +optional
+	Error *metav1.Status `json:"error,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApplyDiff compares a manifest against the stored objects in the clusters,
+// and returns what would change if the manifest is applied to the clusters.
+type ApplyDiff struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Spec ApplyDiffSpec `json:"spec"`
+
+	// +optional
+	Status ApplyDiffStatus `json:"status,omitempty"`
+}
+
+type ApplyDiffSpec struct {
+	// Clusters are the clusters to compare with, all clusters are compared if it is empty.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// Object is the manifest to be applied.
+	Object runtime.RawExtension `json:"object"`
+}
+
+type ApplyDiffStatus struct {
+	// +optional
+	Results []ApplyDiffResult `json:"results,omitempty"`
+}
+
+type ApplyDiffResult struct {
+	Cluster string `json:"cluster"`
+
+	// Exists is false if the object does not exist in the cluster, and it would be created.
+	Exists bool `json:"exists"`
+
+	// Changed is true if the object would be changed by applying the manifest.
+	Changed bool `json:"changed"`
+
+	// Patch is the JSON merge patch from the stored object to the applied object.
+	// +optional
+	Patch runtime.RawExtension `json:"patch,omitempty"`
+
+	// +optional
+	Error *metav1.Status `json:"error,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SchemaValidation validates the stored custom resources in the clusters against the schema of a CRD,
+// and returns the objects which would fail the validation, e.g. before the new version of the CRD is rolled out to the clusters.
+type SchemaValidation struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Spec SchemaValidationSpec `json:"spec"`
+
+	// +optional
+	Status SchemaValidationStatus `json:"status,omitempty"`
+}
+
+type SchemaValidationSpec struct {
+	// CRD is the CustomResourceDefinition whose schema validates the objects, e.g. the new version of the CRD.
+	// +optional
+	CRD runtime.RawExtension `json:"crd,omitempty"`
+
+	// CRDName is the name of the CustomResourceDefinition synchronized from the ReferenceCluster,
+	// whose schema validates the objects if the CRD is not set.
+	// +optional
+	CRDName string `json:"crdName,omitempty"`
+
+	// +optional
+	ReferenceCluster string `json:"referenceCluster,omitempty"`
+
+	// Version is the version of the CRD whose schema validates the objects, it is the storage version of the CRD by default.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// ObjectVersion is the version of the stored objects to be validated, it is the Version by default.
+	// +optional
+	ObjectVersion string `json:"objectVersion,omitempty"`
+
+	// Clusters are the clusters whose objects are validated, the objects of all clusters are validated if it is empty.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+type SchemaValidationStatus struct {
+	// Validated is the number of the validated objects.
+	Validated int64 `json:"validated"`
+
+	// Invalid is the number of the objects which fail the validation.
+	Invalid int64 `json:"invalid"`
+
+	// Results are the objects which fail the validation, sorted by the cluster, the namespace and the name.
+	// +optional
+	Results []SchemaValidationResult `json:"results,omitempty"`
+
+	// Truncated is true if the results are truncated, the Invalid is the number of all invalid objects.
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type SchemaValidationResult struct {
+	Cluster string `json:"cluster"`
+
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	Name string `json:"name"`
+
+	// Errors are the validation errors of the object.
+	Errors []string `json:"errors"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MetadataKeys is the inventory of the label and annotation keys observed in the objects of a resource.
+type MetadataKeys struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Cluster is empty if the keys are observed in all clusters.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	Resource CollectionResourceType `json:"resource"`
+
+	// Objects is the number of the observed objects.
+	Objects int64 `json:"objects"`
+
+	// +optional
+	Labels []MetadataKey `json:"labels,omitempty"`
+
+	// +optional
+	Annotations []MetadataKey `json:"annotations,omitempty"`
+}
+
+type MetadataKey struct {
+	Key string `json:"key"`
+
+	// Count is the number of the objects with the key.
+	Count int64 `json:"count"`
+
+	// Values is the cardinality of the values of the key.
+	Values int64 `json:"values"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceInventory lists the namespaces of all clusters with the clusters each exists in.
+type NamespaceInventory struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Items []NamespaceInventoryItem `json:"items"`
+}
+
+type NamespaceInventoryItem struct {
+	Name string `json:"name"`
+
+	// Objects is the number of the objects in the namespace of all clusters.
+	Objects int64 `json:"objects"`
+
+	Clusters []NamespaceCluster `json:"clusters"`
+}
+
+type NamespaceCluster struct {
+	Name string `json:"name"`
+
+	// Objects is the number of the objects in the namespace of the cluster.
+	Objects int64 `json:"objects"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SpecHashes lists the spec hashes of an object in the clusters,
+// drift detection tools find the clusters where the object differs from the reference hash.
+type SpecHashes struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Resource CollectionResourceType `json:"resource"`
+
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Reference is the hash that the spec hashes are compared with.
+	// +optional
+	Reference string `json:"reference,omitempty"`
+
+	Items []SpecHash `json:"items"`
+}
+
+type SpecHash struct {
+	Cluster string `json:"cluster"`
+
+	// Hash is the hash of the object without the status and the fields set by the cluster.
+	Hash string `json:"hash"`
+
+	// Differs is true if the hash is different from the reference.
+	// +optional
+	Differs bool `json:"differs,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourceHistory lists the field-level changes between the consecutive versions of an object in a cluster,
+// change reviews read the changed fields without storing the full revisions.
+type ResourceHistory struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Cluster  string                 `json:"cluster"`
+	Resource CollectionResourceType `json:"resource"`
+
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	Changes []ResourceChange `json:"changes"`
+}
+
+type ResourceChange struct {
+	ResourceVersion         string `json:"resourceVersion"`
+	PreviousResourceVersion string `json:"previousResourceVersion"`
+
+	// Time is the time the change is recorded.
+	Time metav1.Time `json:"time"`
+
+	Fields []FieldChange `json:"fields"`
+}
+
+type FieldChange struct {
+	// Path is the path of the changed field, e.g. `spec.replicas` or `metadata.labels['app']`.
+	Path string `json:"path"`
+
+	// Old and New are the JSON values of the scalar field,
+	// they are omitted if the field is absent or not a scalar.
+	// +optional
+	Old string `json:"old,omitempty"`
+	// +optional
+	New string `json:"new,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResourceSnapshot is the stored row of an object in a cluster read in one read-only transaction,
+// the mismatches between the member cluster and the storage are debugged without accessing the storage directly.
+type ResourceSnapshot struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Cluster  string                 `json:"cluster"`
+	Resource CollectionResourceType `json:"resource"`
+
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// ObjectKind is the kind of the object, the kind of the snapshot is ResourceSnapshot.
+	ObjectKind      string    `json:"objectKind"`
+	UID             types.UID `json:"uid"`
+	ResourceVersion string    `json:"resourceVersion"`
+
+	// OwnerUID is the uid of the controller of the object.
+	// +optional
+	OwnerUID types.UID `json:"ownerUID,omitempty"`
+
+	// SpecHash is the hash of the normalized object stored with the object,
+	// it is empty if the object is stored before the hash is introduced or is encrypted.
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
+
+	// FormatVersion is the storage format of the stored row.
+	FormatVersion int `json:"formatVersion"`
+
+	// Compression, Encoding and Encryption are how the stored payload is compressed, encoded and encrypted,
+	// they are empty if the payload is the object in json.
+	// +optional
+	Compression string `json:"compression,omitempty"`
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
+	// +optional
+	Encryption string `json:"encryption,omitempty"`
+
+	// StoredBytes is the size of the stored payload.
+	StoredBytes int64 `json:"storedBytes"`
+
+	// Object is the stored object decoded from the payload, with the storage version of the resource.
+	Object runtime.RawExtension `json:"object"`
+
+	// EventResourceVersions are the resource versions of the events of the object keyed by the events.
+	// +optional
+	EventResourceVersions map[string]string `json:"eventResourceVersions,omitempty"`
+
+	// CreatedTime is the creation time of the object, SyncedTime is the last time the row is written by the synchro.
+	CreatedTime metav1.Time `json:"createdTime"`
+	SyncedTime  metav1.Time `json:"syncedTime"`
+
+	// DeletedTime is the deletion timestamp of the object.
+	// +optional
+	DeletedTime *metav1.Time `json:"deletedTime,omitempty"`
+
+	// RemovedTime is the time the object is deleted from the cluster, the row is kept as a tombstone.
+	// +optional
+	RemovedTime *metav1.Time `json:"removedTime,omitempty"`
+
+	// Revisions are the versions of the object recorded by the storage sorted by the time in ascending order,
+	// they are empty if the storage doesn't record the changes of the objects.
+	// +optional
+	Revisions []ResourceRevision `json:"revisions,omitempty"`
+}
+
+type ResourceRevision struct {
+	ResourceVersion         string `json:"resourceVersion"`
+	PreviousResourceVersion string `json:"previousResourceVersion"`
+
+	// Time is the time the revision is recorded.
+	Time metav1.Time `json:"time"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Tombstones lists the objects deleted from a cluster which are kept by the storage,
+// incident investigations find what was running in the cluster before the objects were deleted.
+type Tombstones struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Cluster  string                 `json:"cluster"`
+	Resource CollectionResourceType `json:"resource"`
+
+	Items []Tombstone `json:"items"`
+}
+
+type Tombstone struct {
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// RemovedAt is the time the object is deleted from the cluster.
+	RemovedAt metav1.Time `json:"removedAt"`
+
+	// Object is the deleted object with the storage version of the resource.
+	Object runtime.RawExtension `json:"object"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Archives lists the objects of a cluster archived to the object storage, which are deleted from the cluster
+// or purged by the retention policies, the archived objects can be restored to the storage.
+type Archives struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Cluster  string                 `json:"cluster"`
+	Resource CollectionResourceType `json:"resource"`
+
+	Items []Archive `json:"items"`
+}
+
+type Archive struct {
+	// ID identifies the archive of the resource in the cluster, which is used to restore the archived object.
+	ID string `json:"id"`
+
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Reason is the reason the object is archived, `Deleted` or `Purged`.
+	Reason string `json:"reason"`
+
+	// ArchivedAt is the time the object is archived.
+	ArchivedAt metav1.Time `json:"archivedAt"`
+
+	// Object is the archived object with the storage version of the resource.
+	Object runtime.RawExtension `json:"object"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ReplicaSummaries lists the desired and ready replicas of the workloads in the clusters,
+// autoscaling dashboards read the replicas without loading the full objects.
+type ReplicaSummaries struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Resource CollectionResourceType `json:"resource"`
+
+	Items []ReplicaSummary `json:"items"`
+}
+
+// ReplicaSummary aggregates the replicas of the workloads with the same namespace and name in the clusters.
+type ReplicaSummary struct {
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// DesiredReplicas is the sum of the desired replicas in the clusters.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// ReadyReplicas is the sum of the ready replicas in the clusters.
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	Clusters []ClusterScale `json:"clusters"`
+}
+
+// ClusterScale is the scale of the workload in a cluster, like the scale subresource.
+type ClusterScale struct {
+	Cluster string `json:"cluster"`
+
+	// Replicas is the desired replicas.
+	Replicas int32 `json:"replicas"`
+
+	// CurrentReplicas is the observed replicas.
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+
+	// ReadyReplicas is the replicas which are ready.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Selector is the label selector of the pods, in the string form.
+	// +optional
+	Selector string `json:"selector,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FleetOverview summarizes all clusters of the installation,
+// the kubectl plugin and the UIs show the overview without listing the clusters and the resources.
+type FleetOverview struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Clusters FleetClusters `json:"clusters"`
+
+	// SyncedResources is the number of the resources being synchronized in all clusters,
+	// a resource synchronized in multiple clusters is counted once for each cluster.
+	SyncedResources int64 `json:"syncedResources"`
+
+	// Objects is the number of the objects stored for all clusters.
+	Objects int64 `json:"objects"`
+
+	// StorageBytes is the estimated size of the stored objects,
+	// it is not set if the storage layer doesn't estimate the size.
+	// +optional
+	StorageBytes int64 `json:"storageBytes,omitempty"`
+
+	// SyncLag is the distribution of the time since the objects of each cluster were last synced to the storage,
+	// the clusters which have not synced any object are not included.
+	SyncLag []SyncLagBucket `json:"syncLag"`
+
+	Items []ClusterOverview `json:"items"`
+}
+
+// FleetClusters counts the clusters by health, which is the `Ready` condition of the cluster.
+type FleetClusters struct {
+	Total     int64 `json:"total"`
+	Healthy   int64 `json:"healthy"`
+	Unhealthy int64 `json:"unhealthy"`
+	Unknown   int64 `json:"unknown"`
+}
+
+type SyncLagBucket struct {
+	// LessThan is the upper bound of the bucket, the last bucket has no upper bound.
+	// +optional
+	LessThan *metav1.Duration `json:"lessThan,omitempty"`
+
+	Clusters int64 `json:"clusters"`
+}
+
+const (
+	ClusterHealthy   = "Healthy"
+	ClusterUnhealthy = "Unhealthy"
+	ClusterUnknown   = "Unknown"
+)
+
+type ClusterOverview struct {
+	Name string `json:"name"`
+
+	// Health is one of Healthy, Unhealthy and Unknown.
+	Health string `json:"health"`
+
+	SyncedResources int64 `json:"syncedResources"`
+
+	Objects int64 `json:"objects"`
+
+	// +optional
+	StorageBytes int64 `json:"storageBytes,omitempty"`
+
+	// LastSyncedTime is the last time the objects of the cluster were synced to the storage.
+	// +optional
+	LastSyncedTime *metav1.Time `json:"lastSyncedTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StorageUsage lists the number and the size of the stored objects of each resource in each cluster,
+// the capacity of the storage is planned without accessing the storage directly.
+type StorageUsage struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CollectedTime is the time when the usage is collected from the storage.
+	CollectedTime metav1.Time `json:"collectedTime"`
+
+	Items []ResourceStorageUsage `json:"items"`
+}
+
+type ResourceStorageUsage struct {
+	Cluster string `json:"cluster"`
+
+	Resource CollectionResourceType `json:"resource"`
+
+	Objects int64 `json:"objects"`
+
+	// Bytes is the estimated size of the stored objects,
+	// it is not set if the storage layer doesn't estimate the size.
+	// +optional
+	Bytes int64 `json:"bytes,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Aggregation groups the objects of a resource or a collection resource by the keys and counts the objects of each group,
+// e.g. the pods per cluster per phase, without exporting the objects to roll them up on the client side.
+type Aggregation struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	Resource *CollectionResourceType `json:"resource,omitempty"`
+
+	// CollectionResource is the name of the aggregated collection resource.
+	// +optional
+	CollectionResource string `json:"collectionResource,omitempty"`
+
+	// GroupBy are the keys of the groups, which are `cluster`, `namespace`, `label:<key>`, `field:<path>`,
+	// and `resource` for the collection resources.
+	GroupBy []string `json:"groupBy"`
+
+	// Groups are sorted by the count in descending order.
+	Groups []AggregationGroup `json:"groups"`
+}
+
+type AggregationGroup struct {
+	// Values are the values of the group by keys in order,
+	// the value is empty if the objects don't have the label or the field.
+	Values []string `json:"values"`
+
+	// Count is the number of the objects in the group.
+	Count int64 `json:"count"`
+}
@@ -31,6 +31,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CollectionResourceGroup)(nil), (*clusterpedia.CollectionResourceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup(a.(*CollectionResourceGroup), b.(*clusterpedia.CollectionResourceGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*clusterpedia.CollectionResourceGroup)(nil), (*CollectionResourceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_clusterpedia_CollectionResourceGroup_To_v1beta1_CollectionResourceGroup(a.(*clusterpedia.CollectionResourceGroup), b.(*CollectionResourceGroup), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CollectionResourceList)(nil), (*clusterpedia.CollectionResourceList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CollectionResourceList_To_clusterpedia_CollectionResourceList(a.(*CollectionResourceList), b.(*clusterpedia.CollectionResourceList), scope)
 	}); err != nil {
@@ -41,6 +51,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CollectionResourceNamespaceGroup)(nil), (*clusterpedia.CollectionResourceNamespaceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup(a.(*CollectionResourceNamespaceGroup), b.(*clusterpedia.CollectionResourceNamespaceGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*clusterpedia.CollectionResourceNamespaceGroup)(nil), (*CollectionResourceNamespaceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta1_CollectionResourceNamespaceGroup(a.(*clusterpedia.CollectionResourceNamespaceGroup), b.(*CollectionResourceNamespaceGroup), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CollectionResourceType)(nil), (*clusterpedia.CollectionResourceType)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CollectionResourceType_To_clusterpedia_CollectionResourceType(a.(*CollectionResourceType), b.(*clusterpedia.CollectionResourceType), scope)
 	}); err != nil {
@@ -88,6 +108,17 @@ func autoConvert_v1beta1_CollectionResource_To_clusterpedia_CollectionResource(i
 	} else {
 		out.Items = nil
 	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]clusterpedia.CollectionResourceGroup, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Groups = nil
+	}
 	out.Continue = in.Continue
 	out.RemainingItemCount = (*int64)(unsafe.Pointer(in.RemainingItemCount))
 	return nil
@@ -112,6 +143,17 @@ func autoConvert_clusterpedia_CollectionResource_To_v1beta1_CollectionResource(i
 	} else {
 		out.Items = nil
 	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]CollectionResourceGroup, len(*in))
+		for i := range *in {
+			if err := Convert_clusterpedia_CollectionResourceGroup_To_v1beta1_CollectionResourceGroup(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Groups = nil
+	}
 	out.Continue = in.Continue
 	out.RemainingItemCount = (*int64)(unsafe.Pointer(in.RemainingItemCount))
 	return nil
@@ -122,6 +164,70 @@ func Convert_clusterpedia_CollectionResource_To_v1beta1_CollectionResource(in *c
 	return autoConvert_clusterpedia_CollectionResource_To_v1beta1_CollectionResource(in, out, s)
 }
 
+func autoConvert_v1beta1_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup(in *CollectionResourceGroup, out *clusterpedia.CollectionResourceGroup, s conversion.Scope) error {
+	out.Cluster = in.Cluster
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.Object, len(*in))
+		for i := range *in {
+			if err := runtime.Convert_runtime_RawExtension_To_runtime_Object(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]clusterpedia.CollectionResourceNamespaceGroup, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Namespaces = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup is an autogenerated conversion function.
+func Convert_v1beta1_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup(in *CollectionResourceGroup, out *clusterpedia.CollectionResourceGroup, s conversion.Scope) error {
+	return autoConvert_v1beta1_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup(in, out, s)
+}
+
+func autoConvert_clusterpedia_CollectionResourceGroup_To_v1beta1_CollectionResourceGroup(in *clusterpedia.CollectionResourceGroup, out *CollectionResourceGroup, s conversion.Scope) error {
+	out.Cluster = in.Cluster
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			if err := runtime.Convert_runtime_Object_To_runtime_RawExtension(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]CollectionResourceNamespaceGroup, len(*in))
+		for i := range *in {
+			if err := Convert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta1_CollectionResourceNamespaceGroup(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Namespaces = nil
+	}
+	return nil
+}
+
+// Convert_clusterpedia_CollectionResourceGroup_To_v1beta1_CollectionResourceGroup is an autogenerated conversion function.
+func Convert_clusterpedia_CollectionResourceGroup_To_v1beta1_CollectionResourceGroup(in *clusterpedia.CollectionResourceGroup, out *CollectionResourceGroup, s conversion.Scope) error {
+	return autoConvert_clusterpedia_CollectionResourceGroup_To_v1beta1_CollectionResourceGroup(in, out, s)
+}
+
 func autoConvert_v1beta1_CollectionResourceList_To_clusterpedia_CollectionResourceList(in *CollectionResourceList, out *clusterpedia.CollectionResourceList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -164,6 +270,48 @@ func Convert_clusterpedia_CollectionResourceList_To_v1beta1_CollectionResourceLi
 	return autoConvert_clusterpedia_CollectionResourceList_To_v1beta1_CollectionResourceList(in, out, s)
 }
 
+func autoConvert_v1beta1_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup(in *CollectionResourceNamespaceGroup, out *clusterpedia.CollectionResourceNamespaceGroup, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.Object, len(*in))
+		for i := range *in {
+			if err := runtime.Convert_runtime_RawExtension_To_runtime_Object(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup is an autogenerated conversion function.
+func Convert_v1beta1_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup(in *CollectionResourceNamespaceGroup, out *clusterpedia.CollectionResourceNamespaceGroup, s conversion.Scope) error {
+	return autoConvert_v1beta1_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup(in, out, s)
+}
+
+func autoConvert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta1_CollectionResourceNamespaceGroup(in *clusterpedia.CollectionResourceNamespaceGroup, out *CollectionResourceNamespaceGroup, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			if err := runtime.Convert_runtime_Object_To_runtime_RawExtension(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta1_CollectionResourceNamespaceGroup is an autogenerated conversion function.
+func Convert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta1_CollectionResourceNamespaceGroup(in *clusterpedia.CollectionResourceNamespaceGroup, out *CollectionResourceNamespaceGroup, s conversion.Scope) error {
+	return autoConvert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta1_CollectionResourceNamespaceGroup(in, out, s)
+}
+
 func autoConvert_v1beta1_CollectionResourceType_To_clusterpedia_CollectionResourceType(in *CollectionResourceType, out *clusterpedia.CollectionResourceType, s conversion.Scope) error {
 	out.Group = in.Group
 	out.Version = in.Version
@@ -206,6 +354,10 @@ func autoConvert_v1beta1_ListOptions_To_clusterpedia_ListOptions(in *ListOptions
 	out.WithContinue = (*bool)(unsafe.Pointer(in.WithContinue))
 	out.WithRemainingCount = (*bool)(unsafe.Pointer(in.WithRemainingCount))
 	out.OnlyMetadata = in.OnlyMetadata
+	out.Dedup = in.Dedup
+	out.CountOnly = in.CountOnly
+	out.ResourceScope = in.ResourceScope
+	out.GroupBy = in.GroupBy
 	// WARNING: in.urlQuery requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -235,6 +387,10 @@ func autoConvert_clusterpedia_ListOptions_To_v1beta1_ListOptions(in *clusterpedi
 	// WARNING: in.ExtraLabelSelector requires manual conversion: does not exist in peer-type
 	// WARNING: in.URLQuery requires manual conversion: does not exist in peer-type
 	out.OnlyMetadata = in.OnlyMetadata
+	out.Dedup = in.Dedup
+	out.CountOnly = in.CountOnly
+	out.ResourceScope = in.ResourceScope
+	out.GroupBy = in.GroupBy
 	return nil
 }
 
@@ -332,6 +488,34 @@ func autoConvert_url_Values_To_v1beta1_ListOptions(in *url.Values, out *ListOpti
 	} else {
 		out.OnlyMetadata = false
 	}
+	if values, ok := map[string][]string(*in)["dedup"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_bool(&values, &out.Dedup, s); err != nil {
+			return err
+		}
+	} else {
+		out.Dedup = false
+	}
+	if values, ok := map[string][]string(*in)["countOnly"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_bool(&values, &out.CountOnly, s); err != nil {
+			return err
+		}
+	} else {
+		out.CountOnly = false
+	}
+	if values, ok := map[string][]string(*in)["resourceScope"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_string(&values, &out.ResourceScope, s); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceScope = ""
+	}
+	if values, ok := map[string][]string(*in)["groupBy"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_string(&values, &out.GroupBy, s); err != nil {
+			return err
+		}
+	} else {
+		out.GroupBy = ""
+	}
 	// WARNING: Field urlQuery does not have json tag, skipping.
 
 	return nil
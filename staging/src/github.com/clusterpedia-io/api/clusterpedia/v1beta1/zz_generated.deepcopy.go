@@ -8,46 +8,861 @@ package v1beta1
 import (
 	url "net/url"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Aggregation) DeepCopyInto(out *Aggregation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(CollectionResourceType)
+		**out = **in
+	}
+	if in.GroupBy != nil {
+		in, out := &in.GroupBy, &out.GroupBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]AggregationGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Aggregation.
+func (in *Aggregation) DeepCopy() *Aggregation {
+	if in == nil {
+		return nil
+	}
+	out := new(Aggregation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Aggregation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AggregationGroup) DeepCopyInto(out *AggregationGroup) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AggregationGroup.
+func (in *AggregationGroup) DeepCopy() *AggregationGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(AggregationGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyDiff) DeepCopyInto(out *ApplyDiff) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyDiff.
+func (in *ApplyDiff) DeepCopy() *ApplyDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplyDiff) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyDiffResult) DeepCopyInto(out *ApplyDiffResult) {
+	*out = *in
+	in.Patch.DeepCopyInto(&out.Patch)
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		*out = new(v1.Status)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyDiffResult.
+func (in *ApplyDiffResult) DeepCopy() *ApplyDiffResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyDiffResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyDiffSpec) DeepCopyInto(out *ApplyDiffSpec) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Object.DeepCopyInto(&out.Object)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyDiffSpec.
+func (in *ApplyDiffSpec) DeepCopy() *ApplyDiffSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyDiffSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyDiffStatus) DeepCopyInto(out *ApplyDiffStatus) {
+	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]ApplyDiffResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyDiffStatus.
+func (in *ApplyDiffStatus) DeepCopy() *ApplyDiffStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyDiffStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Archive) DeepCopyInto(out *Archive) {
+	*out = *in
+	in.ArchivedAt.DeepCopyInto(&out.ArchivedAt)
+	in.Object.DeepCopyInto(&out.Object)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Archive.
+func (in *Archive) DeepCopy() *Archive {
+	if in == nil {
+		return nil
+	}
+	out := new(Archive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Archives) DeepCopyInto(out *Archives) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.Resource = in.Resource
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Archive, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Archives.
+func (in *Archives) DeepCopy() *Archives {
+	if in == nil {
+		return nil
+	}
+	out := new(Archives)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Archives) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkGet) DeepCopyInto(out *BulkGet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkGet.
+func (in *BulkGet) DeepCopy() *BulkGet {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkGet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BulkGet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkGetObjectReference) DeepCopyInto(out *BulkGetObjectReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkGetObjectReference.
+func (in *BulkGetObjectReference) DeepCopy() *BulkGetObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkGetObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkGetResult) DeepCopyInto(out *BulkGetResult) {
+	*out = *in
+	in.Object.DeepCopyInto(&out.Object)
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		*out = new(v1.Status)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkGetResult.
+func (in *BulkGetResult) DeepCopy() *BulkGetResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkGetResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkGetSpec) DeepCopyInto(out *BulkGetSpec) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]BulkGetObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkGetSpec.
+func (in *BulkGetSpec) DeepCopy() *BulkGetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkGetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkGetStatus) DeepCopyInto(out *BulkGetStatus) {
+	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]BulkGetResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkGetStatus.
+func (in *BulkGetStatus) DeepCopy() *BulkGetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkGetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOverview) DeepCopyInto(out *ClusterOverview) {
+	*out = *in
+	if in.LastSyncedTime != nil {
+		in, out := &in.LastSyncedTime, &out.LastSyncedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOverview.
+func (in *ClusterOverview) DeepCopy() *ClusterOverview {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOverview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScale) DeepCopyInto(out *ClusterScale) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScale.
+func (in *ClusterScale) DeepCopy() *ClusterScale {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScale)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CollectionResource) DeepCopyInto(out *CollectionResource) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	if in.ResourceTypes != nil {
-		in, out := &in.ResourceTypes, &out.ResourceTypes
-		*out = make([]CollectionResourceType, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.ResourceTypes != nil {
+		in, out := &in.ResourceTypes, &out.ResourceTypes
+		*out = make([]CollectionResourceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]CollectionResourceGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemainingItemCount != nil {
+		in, out := &in.RemainingItemCount, &out.RemainingItemCount
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionResource.
+func (in *CollectionResource) DeepCopy() *CollectionResource {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CollectionResource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionResourceGroup) DeepCopyInto(out *CollectionResourceGroup) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]CollectionResourceNamespaceGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionResourceGroup.
+func (in *CollectionResourceGroup) DeepCopy() *CollectionResourceGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionResourceGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionResourceList) DeepCopyInto(out *CollectionResourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CollectionResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionResourceList.
+func (in *CollectionResourceList) DeepCopy() *CollectionResourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionResourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CollectionResourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionResourceNamespaceGroup) DeepCopyInto(out *CollectionResourceNamespaceGroup) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionResourceNamespaceGroup.
+func (in *CollectionResourceNamespaceGroup) DeepCopy() *CollectionResourceNamespaceGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionResourceNamespaceGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionResourceType) DeepCopyInto(out *CollectionResourceType) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionResourceType.
+func (in *CollectionResourceType) DeepCopy() *CollectionResourceType {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionResourceType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldChange) DeepCopyInto(out *FieldChange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldChange.
+func (in *FieldChange) DeepCopy() *FieldChange {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetClusters) DeepCopyInto(out *FleetClusters) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetClusters.
+func (in *FleetClusters) DeepCopy() *FleetClusters {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetClusters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetOverview) DeepCopyInto(out *FleetOverview) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.Clusters = in.Clusters
+	if in.SyncLag != nil {
+		in, out := &in.SyncLag, &out.SyncLag
+		*out = make([]SyncLagBucket, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterOverview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetOverview.
+func (in *FleetOverview) DeepCopy() *FleetOverview {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetOverview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetOverview) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListOptions) DeepCopyInto(out *ListOptions) {
+	*out = *in
+	in.ListOptions.DeepCopyInto(&out.ListOptions)
+	if in.WithContinue != nil {
+		in, out := &in.WithContinue, &out.WithContinue
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WithRemainingCount != nil {
+		in, out := &in.WithRemainingCount, &out.WithRemainingCount
+		*out = new(bool)
+		**out = **in
+	}
+	if in.urlQuery != nil {
+		in, out := &in.urlQuery, &out.urlQuery
+		*out = make(url.Values, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListOptions.
+func (in *ListOptions) DeepCopy() *ListOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ListOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ListOptions) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataKey) DeepCopyInto(out *MetadataKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataKey.
+func (in *MetadataKey) DeepCopy() *MetadataKey {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataKeys) DeepCopyInto(out *MetadataKeys) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.Resource = in.Resource
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]MetadataKey, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]MetadataKey, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataKeys.
+func (in *MetadataKeys) DeepCopy() *MetadataKeys {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataKeys)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MetadataKeys) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceCluster) DeepCopyInto(out *NamespaceCluster) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceCluster.
+func (in *NamespaceCluster) DeepCopy() *NamespaceCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceInventory) DeepCopyInto(out *NamespaceInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceInventoryItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceInventory.
+func (in *NamespaceInventory) DeepCopy() *NamespaceInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceInventoryItem) DeepCopyInto(out *NamespaceInventoryItem) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]NamespaceCluster, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceInventoryItem.
+func (in *NamespaceInventoryItem) DeepCopy() *NamespaceInventoryItem {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceInventoryItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaSummaries) DeepCopyInto(out *ReplicaSummaries) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.Resource = in.Resource
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReplicaSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaSummaries.
+func (in *ReplicaSummaries) DeepCopy() *ReplicaSummaries {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaSummaries)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicaSummaries) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaSummary) DeepCopyInto(out *ReplicaSummary) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterScale, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaSummary.
+func (in *ReplicaSummary) DeepCopy() *ReplicaSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceChange) DeepCopyInto(out *ResourceChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]FieldChange, len(*in))
 		copy(*out, *in)
 	}
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]runtime.RawExtension, len(*in))
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceChange.
+func (in *ResourceChange) DeepCopy() *ResourceChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceHistory) DeepCopyInto(out *ResourceHistory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.Resource = in.Resource
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]ResourceChange, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.RemainingItemCount != nil {
-		in, out := &in.RemainingItemCount, &out.RemainingItemCount
-		*out = new(int64)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionResource.
-func (in *CollectionResource) DeepCopy() *CollectionResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceHistory.
+func (in *ResourceHistory) DeepCopy() *ResourceHistory {
 	if in == nil {
 		return nil
 	}
-	out := new(CollectionResource)
+	out := new(ResourceHistory)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CollectionResource) DeepCopyObject() runtime.Object {
+func (in *ResourceHistory) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -55,13 +870,48 @@ func (in *CollectionResource) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CollectionResourceList) DeepCopyInto(out *CollectionResourceList) {
+func (in *ResourceRevision) DeepCopyInto(out *ResourceRevision) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRevision.
+func (in *ResourceRevision) DeepCopy() *ResourceRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSnapshot) DeepCopyInto(out *ResourceSnapshot) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]CollectionResource, len(*in))
+	out.Resource = in.Resource
+	in.Object.DeepCopyInto(&out.Object)
+	if in.EventResourceVersions != nil {
+		in, out := &in.EventResourceVersions, &out.EventResourceVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.CreatedTime.DeepCopyInto(&out.CreatedTime)
+	in.SyncedTime.DeepCopyInto(&out.SyncedTime)
+	if in.DeletedTime != nil {
+		in, out := &in.DeletedTime, &out.DeletedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RemovedTime != nil {
+		in, out := &in.RemovedTime, &out.RemovedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]ResourceRevision, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -69,18 +919,18 @@ func (in *CollectionResourceList) DeepCopyInto(out *CollectionResourceList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionResourceList.
-func (in *CollectionResourceList) DeepCopy() *CollectionResourceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSnapshot.
+func (in *ResourceSnapshot) DeepCopy() *ResourceSnapshot {
 	if in == nil {
 		return nil
 	}
-	out := new(CollectionResourceList)
+	out := new(ResourceSnapshot)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CollectionResourceList) DeepCopyObject() runtime.Object {
+func (in *ResourceSnapshot) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -88,65 +938,181 @@ func (in *CollectionResourceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CollectionResourceType) DeepCopyInto(out *CollectionResourceType) {
+func (in *ResourceStorageUsage) DeepCopyInto(out *ResourceStorageUsage) {
 	*out = *in
+	out.Resource = in.Resource
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionResourceType.
-func (in *CollectionResourceType) DeepCopy() *CollectionResourceType {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceStorageUsage.
+func (in *ResourceStorageUsage) DeepCopy() *ResourceStorageUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(CollectionResourceType)
+	out := new(ResourceStorageUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ListOptions) DeepCopyInto(out *ListOptions) {
+func (in *Resources) DeepCopyInto(out *Resources) {
 	*out = *in
-	in.ListOptions.DeepCopyInto(&out.ListOptions)
-	if in.WithContinue != nil {
-		in, out := &in.WithContinue, &out.WithContinue
-		*out = new(bool)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
+func (in *Resources) DeepCopy() *Resources {
+	if in == nil {
+		return nil
 	}
-	if in.WithRemainingCount != nil {
-		in, out := &in.WithRemainingCount, &out.WithRemainingCount
-		*out = new(bool)
-		**out = **in
+	out := new(Resources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Resources) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.urlQuery != nil {
-		in, out := &in.urlQuery, &out.urlQuery
-		*out = make(url.Values, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaValidation) DeepCopyInto(out *SchemaValidation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaValidation.
+func (in *SchemaValidation) DeepCopy() *SchemaValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchemaValidation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaValidationResult) DeepCopyInto(out *SchemaValidationResult) {
+	*out = *in
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaValidationResult.
+func (in *SchemaValidationResult) DeepCopy() *SchemaValidationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaValidationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaValidationSpec) DeepCopyInto(out *SchemaValidationSpec) {
+	*out = *in
+	in.CRD.DeepCopyInto(&out.CRD)
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaValidationSpec.
+func (in *SchemaValidationSpec) DeepCopy() *SchemaValidationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaValidationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaValidationStatus) DeepCopyInto(out *SchemaValidationStatus) {
+	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]SchemaValidationResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListOptions.
-func (in *ListOptions) DeepCopy() *ListOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaValidationStatus.
+func (in *SchemaValidationStatus) DeepCopy() *SchemaValidationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ListOptions)
+	out := new(SchemaValidationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpecHash) DeepCopyInto(out *SpecHash) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecHash.
+func (in *SpecHash) DeepCopy() *SpecHash {
+	if in == nil {
+		return nil
+	}
+	out := new(SpecHash)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpecHashes) DeepCopyInto(out *SpecHashes) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.Resource = in.Resource
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpecHash, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecHashes.
+func (in *SpecHashes) DeepCopy() *SpecHashes {
+	if in == nil {
+		return nil
+	}
+	out := new(SpecHashes)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ListOptions) DeepCopyObject() runtime.Object {
+func (in *SpecHashes) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -154,24 +1120,102 @@ func (in *ListOptions) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Resources) DeepCopyInto(out *Resources) {
+func (in *StorageUsage) DeepCopyInto(out *StorageUsage) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	in.CollectedTime.DeepCopyInto(&out.CollectedTime)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceStorageUsage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
-func (in *Resources) DeepCopy() *Resources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageUsage.
+func (in *StorageUsage) DeepCopy() *StorageUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(Resources)
+	out := new(StorageUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Resources) DeepCopyObject() runtime.Object {
+func (in *StorageUsage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncLagBucket) DeepCopyInto(out *SyncLagBucket) {
+	*out = *in
+	if in.LessThan != nil {
+		in, out := &in.LessThan, &out.LessThan
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncLagBucket.
+func (in *SyncLagBucket) DeepCopy() *SyncLagBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncLagBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tombstone) DeepCopyInto(out *Tombstone) {
+	*out = *in
+	in.RemovedAt.DeepCopyInto(&out.RemovedAt)
+	in.Object.DeepCopyInto(&out.Object)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tombstone.
+func (in *Tombstone) DeepCopy() *Tombstone {
+	if in == nil {
+		return nil
+	}
+	out := new(Tombstone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tombstones) DeepCopyInto(out *Tombstones) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.Resource = in.Resource
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Tombstone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tombstones.
+func (in *Tombstones) DeepCopy() *Tombstones {
+	if in == nil {
+		return nil
+	}
+	out := new(Tombstones)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Tombstones) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -0,0 +1,205 @@
+package v1beta2
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	metainternal "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/clusterpedia-io/api/clusterpedia"
+	"github.com/clusterpedia-io/api/clusterpedia/fields"
+)
+
+func Convert_v1beta2_ListOptions_To_clusterpedia_ListOptions(in *ListOptions, out *clusterpedia.ListOptions, s conversion.Scope) error {
+	fieldSelector := in.FieldSelector
+	defer func() {
+		in.FieldSelector = fieldSelector
+	}()
+
+	// skip convert fieldSelector
+	in.FieldSelector = ""
+	if err := metainternal.Convert_v1_ListOptions_To_internalversion_ListOptions(&in.ListOptions, &out.ListOptions, s); err != nil {
+		return err
+	}
+
+	selector, err := fields.Parse(fieldSelector)
+	if err != nil {
+		return err
+	}
+	out.EnhancedFieldSelector = selector
+
+	out.Names = convert_Slice_string(in.Names)
+	out.ClusterNames = convert_Slice_string(in.ClusterNames)
+	out.Namespaces = convert_Slice_string(in.Namespaces)
+
+	out.OrderBy = nil
+	for _, orderby := range convert_Slice_string(in.OrderByFields) {
+		var by clusterpedia.OrderBy
+		if err := Convert_string_To_clusterpedia_OrderBy(&orderby, &by, s); err != nil {
+			return err
+		}
+		out.OrderBy = append(out.OrderBy, by)
+	}
+
+	out.OwnerUID = in.OwnerUID
+	out.OwnerName = in.OwnerName
+	out.OwnerGroupResource = schema.GroupResource{}
+	if in.OwnerGroupResource != "" {
+		out.OwnerGroupResource = schema.ParseGroupResource(in.OwnerGroupResource)
+	}
+	out.OwnerSeniority = in.OwnerSeniority
+
+	out.Since = in.Since
+	out.Before = in.Before
+	if out.Before.Before(out.Since) {
+		return errors.New("Invalid Query, Since is after Before")
+	}
+
+	if out.LabelSelector != nil {
+		var labelRequest, extraLabelRequest []labels.Requirement
+		if requirements, selectable := out.LabelSelector.Requirements(); selectable {
+			for _, require := range requirements {
+				switch label, custom := clusterpedia.LookupSearchLabel(require.Key()); {
+				case custom:
+					// the custom search labels have no query parameters
+					parsed, err := label.ParseRequirement(require)
+					if err != nil {
+						return err
+					}
+					extraLabelRequest = append(extraLabelRequest, parsed)
+				case strings.HasPrefix(require.Key(), clusterpedia.SearchLabelPrefix):
+					return fmt.Errorf("Invalid Query, the search label %s is not supported in %s, use the query parameters instead", require.Key(), SchemeGroupVersion)
+				case strings.Contains(require.Key(), "clusterpedia.io"):
+					extraLabelRequest = append(extraLabelRequest, require)
+				default:
+					labelRequest = append(labelRequest, require)
+				}
+			}
+		}
+
+		out.LabelSelector = nil
+		if len(labelRequest) != 0 {
+			out.LabelSelector = labels.NewSelector().Add(labelRequest...)
+		}
+		if len(extraLabelRequest) != 0 {
+			out.ExtraLabelSelector = labels.NewSelector().Add(extraLabelRequest...)
+		}
+	}
+	if len(in.urlQuery) > 0 {
+		// Out URLQuery will not be modified, so deepcopy is not used here.
+		out.URLQuery = in.urlQuery
+	}
+
+	out.InjectEvents = in.InjectEvents
+	out.WithContinue = in.WithContinue
+	out.WithRemainingCount = in.WithRemainingCount
+	out.OnlyMetadata = in.OnlyMetadata
+	out.Dedup = in.Dedup
+	out.CountOnly = in.CountOnly
+	out.ResourceScope = in.ResourceScope
+	out.GroupBy = in.GroupBy
+	return nil
+}
+
+func Convert_clusterpedia_ListOptions_To_v1beta2_ListOptions(in *clusterpedia.ListOptions, out *ListOptions, s conversion.Scope) error {
+	if err := metainternal.Convert_internalversion_ListOptions_To_v1_ListOptions(&in.ListOptions, &out.ListOptions, s); err != nil {
+		return err
+	}
+
+	if in.EnhancedFieldSelector != nil {
+		out.FieldSelector = in.EnhancedFieldSelector.String()
+	}
+
+	labels := in.LabelSelector.DeepCopySelector()
+	requirements, _ := in.ExtraLabelSelector.Requirements()
+	labels.Add(requirements...)
+	if err := metav1.Convert_labels_Selector_To_string(&labels, &out.ListOptions.LabelSelector, s); err != nil {
+		return err
+	}
+
+	out.Names = in.Names
+	out.ClusterNames = in.ClusterNames
+	out.Namespaces = in.Namespaces
+
+	out.OrderByFields = nil
+	for i := range in.OrderBy {
+		var orderby string
+		if err := Convert_clusterpedia_OrderBy_To_string(&in.OrderBy[i], &orderby, s); err != nil {
+			return err
+		}
+		out.OrderByFields = append(out.OrderByFields, orderby)
+	}
+
+	out.OwnerUID = in.OwnerUID
+	out.OwnerName = in.OwnerName
+	out.OwnerGroupResource = ""
+	if !in.OwnerGroupResource.Empty() {
+		out.OwnerGroupResource = in.OwnerGroupResource.String()
+	}
+	out.OwnerSeniority = in.OwnerSeniority
+
+	out.Since = in.Since
+	out.Before = in.Before
+
+	out.InjectEvents = in.InjectEvents
+	out.WithContinue = in.WithContinue
+	out.WithRemainingCount = in.WithRemainingCount
+	out.OnlyMetadata = in.OnlyMetadata
+	out.Dedup = in.Dedup
+	out.CountOnly = in.CountOnly
+	out.ResourceScope = in.ResourceScope
+	out.GroupBy = in.GroupBy
+	return nil
+}
+
+func Convert_url_Values_To_v1beta2_ListOptions(in *url.Values, out *ListOptions, s conversion.Scope) error {
+	if err := metav1.Convert_url_Values_To_v1_ListOptions(in, &out.ListOptions, s); err != nil {
+		return err
+	}
+	// Save the native query parameters for use by listoptions.
+	out.urlQuery = *in
+
+	return autoConvert_url_Values_To_v1beta2_ListOptions(in, out, s)
+}
+
+// Convert_string_To_clusterpedia_OrderBy converts the sort field, eg. 'created_at desc'.
+func Convert_string_To_clusterpedia_OrderBy(in *string, out *clusterpedia.OrderBy, s conversion.Scope) error {
+	field, desc := strings.TrimSpace(*in), false
+	if f, ok := strings.CutSuffix(field, " desc"); ok {
+		field, desc = strings.TrimSpace(f), true
+	}
+	if field == "" || strings.Contains(field, " ") {
+		return fmt.Errorf("Invalid Query OrderBy(%s)", *in)
+	}
+
+	out.Field, out.Desc = field, desc
+	return nil
+}
+
+func Convert_clusterpedia_OrderBy_To_string(in *clusterpedia.OrderBy, out *string, s conversion.Scope) error {
+	*out = in.Field
+	if in.Desc {
+		*out += " desc"
+	}
+	return nil
+}
+
+// convert_Slice_string trims the values and drops the empty values of the repeated query parameter.
+func convert_Slice_string(in []string) []string {
+	var out []string
+	for _, value := range in {
+		if value = strings.TrimSpace(value); value != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+// nolint:unused
+func compileErrorOnMissingConversion() {}
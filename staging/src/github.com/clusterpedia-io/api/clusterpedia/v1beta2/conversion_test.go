@@ -0,0 +1,181 @@
+package v1beta2
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+
+	internal "github.com/clusterpedia-io/api/clusterpedia"
+)
+
+func newParameterCodec(t *testing.T) runtime.ParameterCodec {
+	scheme := runtime.NewScheme()
+	if err := internal.Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return runtime.NewParameterCodec(scheme)
+}
+
+func TestConvertURLValuesToListOptions(t *testing.T) {
+	codec := newParameterCodec(t)
+
+	tests := []struct {
+		name  string
+		query url.Values
+
+		expectedError bool
+		names         []string
+		clusters      []string
+		orderby       []internal.OrderBy
+		owner         schema.GroupResource
+		withContinue  bool
+	}{
+		{
+			name: "repeated query parameters",
+			query: url.Values{
+				"names":    []string{"pod-1", " ", "pod-2"},
+				"clusters": []string{"cluster-1"},
+				"orderby":  []string{"cluster", "created_at desc"},
+				"ownerGR":  []string{"deployments.apps"},
+			},
+			names:        []string{"pod-1", "pod-2"},
+			clusters:     []string{"cluster-1"},
+			orderby:      []internal.OrderBy{{Field: "cluster"}, {Field: "created_at", Desc: true}},
+			owner:        schema.GroupResource{Group: "apps", Resource: "deployments"},
+			withContinue: true,
+		},
+		{
+			name:  "disable continue",
+			query: url.Values{"withContinue": []string{"false"}},
+		},
+		{
+			name:          "invalid orderby",
+			query:         url.Values{"orderby": []string{"created_at asc"}},
+			expectedError: true,
+		},
+		{
+			name:          "search labels are not supported",
+			query:         url.Values{"labelSelector": []string{"search.clusterpedia.io/clusters=cluster-1"}},
+			expectedError: true,
+		},
+		{
+			name:          "since is after before",
+			query:         url.Values{"since": []string{"2024-01-02T00:00:00Z"}, "before": []string{"2024-01-01T00:00:00Z"}},
+			expectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var opts internal.ListOptions
+			err := codec.DecodeParameters(test.query, SchemeGroupVersion, &opts)
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(opts.Names, test.names) {
+				t.Errorf("expected names %v, got %v", test.names, opts.Names)
+			}
+			if !reflect.DeepEqual(opts.ClusterNames, test.clusters) {
+				t.Errorf("expected clusters %v, got %v", test.clusters, opts.ClusterNames)
+			}
+			if !reflect.DeepEqual(opts.OrderBy, test.orderby) {
+				t.Errorf("expected orderby %v, got %v", test.orderby, opts.OrderBy)
+			}
+			if opts.OwnerGroupResource != test.owner {
+				t.Errorf("expected owner group resource %v, got %v", test.owner, opts.OwnerGroupResource)
+			}
+			if opts.WithContinue == nil || *opts.WithContinue != test.withContinue {
+				t.Errorf("expected withContinue %v, got %v", test.withContinue, opts.WithContinue)
+			}
+		})
+	}
+}
+
+func TestConvertListOptionsRoundTrip(t *testing.T) {
+	codec := newParameterCodec(t)
+
+	query := url.Values{
+		"labelSelector": []string{"app=nginx,internalstorage.clusterpedia.io/fuzzy-name=nginx"},
+		"namespaces":    []string{"default", "kube-system"},
+		"orderby":       []string{"name desc"},
+	}
+	var opts internal.ListOptions
+	if err := codec.DecodeParameters(query, SchemeGroupVersion, &opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.LabelSelector.String() != "app=nginx" {
+		t.Errorf("expected label selector app=nginx, got %s", opts.LabelSelector)
+	}
+	if opts.ExtraLabelSelector.String() != "internalstorage.clusterpedia.io/fuzzy-name=nginx" {
+		t.Errorf("expected the extra label selector, got %s", opts.ExtraLabelSelector)
+	}
+
+	encoded, err := codec.EncodeParameters(&opts, SchemeGroupVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded internal.ListOptions
+	if err := codec.DecodeParameters(encoded, SchemeGroupVersion, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Namespaces, opts.Namespaces) || !reflect.DeepEqual(decoded.OrderBy, opts.OrderBy) {
+		t.Errorf("expected %v %v, got %v %v", opts.Namespaces, opts.OrderBy, decoded.Namespaces, decoded.OrderBy)
+	}
+}
+
+func TestConvertCustomSearchLabels(t *testing.T) {
+	codec := newParameterCodec(t)
+
+	err := internal.RegisterSearchLabel(internal.CustomSearchLabel{
+		Key: "search.clusterpedia.io/team",
+		Parse: func(_ selection.Operator, values []string) ([]string, error) {
+			for i, value := range values {
+				if value == "" {
+					return nil, errors.New("team is required")
+				}
+				values[i] = strings.ToLower(value)
+			}
+			return values, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"search.clusterpedia.io/team", internal.SearchLabelClusters, "example.io/team", "search.clusterpedia.io/"} {
+		if err := internal.RegisterSearchLabel(internal.CustomSearchLabel{Key: key}); err == nil {
+			t.Errorf("RegisterSearchLabel(%q) should fail", key)
+		}
+	}
+
+	var opts internal.ListOptions
+	query := url.Values{"labelSelector": []string{"search.clusterpedia.io/team in (Infra,Web),app=web"}}
+	if err := codec.DecodeParameters(query, SchemeGroupVersion, &opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := opts.ExtraLabelSelector.String(); got != "search.clusterpedia.io/team in (infra,web)" {
+		t.Errorf("extra label selector = %q, want the normalized custom search label", got)
+	}
+	if got := opts.LabelSelector.String(); got != "app=web" {
+		t.Errorf("label selector = %q, want app=web", got)
+	}
+
+	query = url.Values{"labelSelector": []string{"search.clusterpedia.io/team="}}
+	if err := codec.DecodeParameters(query, SchemeGroupVersion, &opts); err == nil {
+		t.Error("expected the error of the custom search label")
+	}
+}
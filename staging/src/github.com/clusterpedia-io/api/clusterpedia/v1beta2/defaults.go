@@ -0,0 +1,19 @@
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&ListOptions{}, func(obj interface{}) { SetDefaults_ListOptions(obj.(*ListOptions)) })
+	return nil
+}
+
+// SetDefaults_ListOptions returns the continue token by default,
+// so that the pagination is the same as the kubernetes apiserver.
+func SetDefaults_ListOptions(obj *ListOptions) {
+	if obj.WithContinue == nil {
+		withContinue := true
+		obj.WithContinue = &withContinue
+	}
+}
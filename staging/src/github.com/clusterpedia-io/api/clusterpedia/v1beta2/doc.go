@@ -0,0 +1,8 @@
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=package
+// +k8s:conversion-gen=github.com/clusterpedia-io/api/clusterpedia
+// +k8s:defaulter-gen=TypeMeta
+// +groupName=clusterpedia.io
+
+// Package v1beta2 is the v1beta2 version of the API
+package v1beta2
@@ -0,0 +1,175 @@
+package v1beta2
+
+import (
+	"net/url"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +k8s:conversion-gen:explicit-from=net/url.Values
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ListOptions is the query options of searching the resources.
+//
+// Unlike v1beta1, the search conditions are only specified by the query parameters,
+// the search labels `search.clusterpedia.io/*` in the label selector are not supported.
+type ListOptions struct {
+	metav1.ListOptions `json:",inline"`
+
+	// Names are the resource names to match, the query parameter can be repeated.
+	// +optional
+	Names []string `json:"names,omitempty"`
+
+	// ClusterNames are the member clusters to search in, the query parameter can be repeated.
+	// +optional
+	ClusterNames []string `json:"clusters,omitempty"`
+
+	// Namespaces are the namespaces to search in, the query parameter can be repeated.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// OrderByFields are the sort fields in order, a field followed by ' desc' is sorted in descending order,
+	// the query parameter can be repeated, eg. 'orderby=cluster&orderby=created_at%20desc'.
+	// +optional
+	OrderByFields []string `json:"orderby,omitempty"`
+
+	// OwnerUID searches the resources owned by the resource with this uid, it requires exactly one cluster.
+	// +optional
+	OwnerUID string `json:"ownerUID,omitempty"`
+
+	// OwnerName searches the resources owned by the resource with this name, it requires exactly one cluster.
+	// +optional
+	OwnerName string `json:"ownerName,omitempty"`
+
+	// OwnerGroupResource is the group resource of the owner when searching by OwnerName, eg. 'deployments.apps'.
+	// +optional
+	OwnerGroupResource string `json:"ownerGR,omitempty"`
+
+	// OwnerSeniority is the number of ownership levels between the owner and the resources,
+	// eg. 1 searches the pods of a deployment through its replicasets.
+	// +optional
+	OwnerSeniority int `json:"ownerSeniority,omitempty"`
+
+	// Since limits the creation time of resources to be at or after this time, in RFC3339 format.
+	// +optional
+	Since *metav1.Time `json:"since,omitempty"`
+
+	// Before limits the creation time of resources to be before this time, in RFC3339 format.
+	// +optional
+	Before *metav1.Time `json:"before,omitempty"`
+
+	// InjectEvents injects the events of the resources into the annotation `shadow.clusterpedia.io/events`.
+	// +optional
+	InjectEvents bool `json:"injectEvents,omitempty"`
+
+	// WithContinue returns the continue token for the next page when the limit is reached, default is true.
+	// +optional
+	WithContinue *bool `json:"withContinue,omitempty"`
+
+	// WithRemainingCount returns the count of the remaining items.
+	// +optional
+	WithRemainingCount *bool `json:"withRemainingCount,omitempty"`
+
+	// OnlyMetadata only returns the metadata of the resources.
+	// +optional
+	OnlyMetadata bool `json:"onlyMetadata,omitempty"`
+
+	// Dedup groups the identical resources replicated to multiple clusters into a single result,
+	// the clusters of the result are set into the annotation `shadow.clusterpedia.io/dedup-clusters`.
+	// +optional
+	Dedup bool `json:"dedup,omitempty"`
+
+	// CountOnly only returns the count of the matched resources as the remainingItemCount of an empty list,
+	// the limit and the continue are ignored.
+	// +optional
+	CountOnly bool `json:"countOnly,omitempty"`
+
+	// ResourceScope limits the collection resource to the cluster-scoped or the namespaced resources,
+	// the values are 'Cluster' and 'Namespaced', empty lists both. It is only for the collection resources.
+	// +optional
+	ResourceScope string `json:"resourceScope,omitempty"`
+
+	// GroupBy groups the items of the collection resource into the groups of the response, 'cluster' groups them
+	// by the cluster and then the namespace, and the items are sorted by the cluster and the namespace if the orderby is not set.
+	// It is only for the collection resources.
+	// +optional
+	GroupBy string `json:"groupBy,omitempty"`
+
+	urlQuery url.Values
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type Resources struct {
+	metav1.TypeMeta `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+type CollectionResource struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	ResourceTypes []CollectionResourceType `json:"resourceTypes"`
+
+	// +optional
+	Items []runtime.RawExtension `json:"items,omitempty"`
+
+	// Groups are the items grouped by the `groupBy` query, the items are moved into the groups.
+	// +optional
+	Groups []CollectionResourceGroup `json:"groups,omitempty"`
+
+	// +optional
+	Continue string `json:"continue,omitempty"`
+
+	// +optional
+	RemainingItemCount *int64 `json:"remainingItemCount,omitempty"`
+}
+
+// CollectionResourceGroup is the items of a cluster,
+// the cluster-scoped items are in the items and the namespaced items are grouped by the namespace.
+type CollectionResourceGroup struct {
+	Cluster string `json:"cluster"`
+
+	// +optional
+	Items []runtime.RawExtension `json:"items,omitempty"`
+
+	// +optional
+	Namespaces []CollectionResourceNamespaceGroup `json:"namespaces,omitempty"`
+}
+
+type CollectionResourceNamespaceGroup struct {
+	Namespace string `json:"namespace"`
+
+	// +optional
+	Items []runtime.RawExtension `json:"items,omitempty"`
+}
+
+type CollectionResourceType struct {
+	Group string `json:"group"`
+
+	Version string `json:"version"`
+
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	Resource string `json:"resource"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+type CollectionResourceList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []CollectionResource `json:"items"`
+}
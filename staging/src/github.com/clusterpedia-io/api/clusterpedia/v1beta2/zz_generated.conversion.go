@@ -0,0 +1,528 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1beta2
+
+import (
+	url "net/url"
+	unsafe "unsafe"
+
+	clusterpedia "github.com/clusterpedia-io/api/clusterpedia"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*CollectionResource)(nil), (*clusterpedia.CollectionResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CollectionResource_To_clusterpedia_CollectionResource(a.(*CollectionResource), b.(*clusterpedia.CollectionResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*clusterpedia.CollectionResource)(nil), (*CollectionResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_clusterpedia_CollectionResource_To_v1beta2_CollectionResource(a.(*clusterpedia.CollectionResource), b.(*CollectionResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CollectionResourceGroup)(nil), (*clusterpedia.CollectionResourceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup(a.(*CollectionResourceGroup), b.(*clusterpedia.CollectionResourceGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*clusterpedia.CollectionResourceGroup)(nil), (*CollectionResourceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_clusterpedia_CollectionResourceGroup_To_v1beta2_CollectionResourceGroup(a.(*clusterpedia.CollectionResourceGroup), b.(*CollectionResourceGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CollectionResourceList)(nil), (*clusterpedia.CollectionResourceList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CollectionResourceList_To_clusterpedia_CollectionResourceList(a.(*CollectionResourceList), b.(*clusterpedia.CollectionResourceList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*clusterpedia.CollectionResourceList)(nil), (*CollectionResourceList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_clusterpedia_CollectionResourceList_To_v1beta2_CollectionResourceList(a.(*clusterpedia.CollectionResourceList), b.(*CollectionResourceList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CollectionResourceNamespaceGroup)(nil), (*clusterpedia.CollectionResourceNamespaceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup(a.(*CollectionResourceNamespaceGroup), b.(*clusterpedia.CollectionResourceNamespaceGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*clusterpedia.CollectionResourceNamespaceGroup)(nil), (*CollectionResourceNamespaceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta2_CollectionResourceNamespaceGroup(a.(*clusterpedia.CollectionResourceNamespaceGroup), b.(*CollectionResourceNamespaceGroup), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CollectionResourceType)(nil), (*clusterpedia.CollectionResourceType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CollectionResourceType_To_clusterpedia_CollectionResourceType(a.(*CollectionResourceType), b.(*clusterpedia.CollectionResourceType), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*clusterpedia.CollectionResourceType)(nil), (*CollectionResourceType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_clusterpedia_CollectionResourceType_To_v1beta2_CollectionResourceType(a.(*clusterpedia.CollectionResourceType), b.(*CollectionResourceType), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*url.Values)(nil), (*ListOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_url_Values_To_v1beta2_ListOptions(a.(*url.Values), b.(*ListOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*clusterpedia.ListOptions)(nil), (*ListOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_clusterpedia_ListOptions_To_v1beta2_ListOptions(a.(*clusterpedia.ListOptions), b.(*ListOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*clusterpedia.OrderBy)(nil), (*string)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_clusterpedia_OrderBy_To_string(a.(*clusterpedia.OrderBy), b.(*string), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*string)(nil), (*clusterpedia.OrderBy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_string_To_clusterpedia_OrderBy(a.(*string), b.(*clusterpedia.OrderBy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*url.Values)(nil), (*ListOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_url_Values_To_v1beta2_ListOptions(a.(*url.Values), b.(*ListOptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*ListOptions)(nil), (*clusterpedia.ListOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_ListOptions_To_clusterpedia_ListOptions(a.(*ListOptions), b.(*clusterpedia.ListOptions), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1beta2_CollectionResource_To_clusterpedia_CollectionResource(in *CollectionResource, out *clusterpedia.CollectionResource, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.ResourceTypes = *(*[]clusterpedia.CollectionResourceType)(unsafe.Pointer(&in.ResourceTypes))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.Object, len(*in))
+		for i := range *in {
+			if err := runtime.Convert_runtime_RawExtension_To_runtime_Object(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]clusterpedia.CollectionResourceGroup, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Groups = nil
+	}
+	out.Continue = in.Continue
+	out.RemainingItemCount = (*int64)(unsafe.Pointer(in.RemainingItemCount))
+	return nil
+}
+
+// Convert_v1beta2_CollectionResource_To_clusterpedia_CollectionResource is an autogenerated conversion function.
+func Convert_v1beta2_CollectionResource_To_clusterpedia_CollectionResource(in *CollectionResource, out *clusterpedia.CollectionResource, s conversion.Scope) error {
+	return autoConvert_v1beta2_CollectionResource_To_clusterpedia_CollectionResource(in, out, s)
+}
+
+func autoConvert_clusterpedia_CollectionResource_To_v1beta2_CollectionResource(in *clusterpedia.CollectionResource, out *CollectionResource, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.ResourceTypes = *(*[]CollectionResourceType)(unsafe.Pointer(&in.ResourceTypes))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			if err := runtime.Convert_runtime_Object_To_runtime_RawExtension(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]CollectionResourceGroup, len(*in))
+		for i := range *in {
+			if err := Convert_clusterpedia_CollectionResourceGroup_To_v1beta2_CollectionResourceGroup(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Groups = nil
+	}
+	out.Continue = in.Continue
+	out.RemainingItemCount = (*int64)(unsafe.Pointer(in.RemainingItemCount))
+	return nil
+}
+
+// Convert_clusterpedia_CollectionResource_To_v1beta2_CollectionResource is an autogenerated conversion function.
+func Convert_clusterpedia_CollectionResource_To_v1beta2_CollectionResource(in *clusterpedia.CollectionResource, out *CollectionResource, s conversion.Scope) error {
+	return autoConvert_clusterpedia_CollectionResource_To_v1beta2_CollectionResource(in, out, s)
+}
+
+func autoConvert_v1beta2_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup(in *CollectionResourceGroup, out *clusterpedia.CollectionResourceGroup, s conversion.Scope) error {
+	out.Cluster = in.Cluster
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.Object, len(*in))
+		for i := range *in {
+			if err := runtime.Convert_runtime_RawExtension_To_runtime_Object(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]clusterpedia.CollectionResourceNamespaceGroup, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Namespaces = nil
+	}
+	return nil
+}
+
+// Convert_v1beta2_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup is an autogenerated conversion function.
+func Convert_v1beta2_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup(in *CollectionResourceGroup, out *clusterpedia.CollectionResourceGroup, s conversion.Scope) error {
+	return autoConvert_v1beta2_CollectionResourceGroup_To_clusterpedia_CollectionResourceGroup(in, out, s)
+}
+
+func autoConvert_clusterpedia_CollectionResourceGroup_To_v1beta2_CollectionResourceGroup(in *clusterpedia.CollectionResourceGroup, out *CollectionResourceGroup, s conversion.Scope) error {
+	out.Cluster = in.Cluster
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			if err := runtime.Convert_runtime_Object_To_runtime_RawExtension(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]CollectionResourceNamespaceGroup, len(*in))
+		for i := range *in {
+			if err := Convert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta2_CollectionResourceNamespaceGroup(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Namespaces = nil
+	}
+	return nil
+}
+
+// Convert_clusterpedia_CollectionResourceGroup_To_v1beta2_CollectionResourceGroup is an autogenerated conversion function.
+func Convert_clusterpedia_CollectionResourceGroup_To_v1beta2_CollectionResourceGroup(in *clusterpedia.CollectionResourceGroup, out *CollectionResourceGroup, s conversion.Scope) error {
+	return autoConvert_clusterpedia_CollectionResourceGroup_To_v1beta2_CollectionResourceGroup(in, out, s)
+}
+
+func autoConvert_v1beta2_CollectionResourceList_To_clusterpedia_CollectionResourceList(in *CollectionResourceList, out *clusterpedia.CollectionResourceList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]clusterpedia.CollectionResource, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_CollectionResource_To_clusterpedia_CollectionResource(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta2_CollectionResourceList_To_clusterpedia_CollectionResourceList is an autogenerated conversion function.
+func Convert_v1beta2_CollectionResourceList_To_clusterpedia_CollectionResourceList(in *CollectionResourceList, out *clusterpedia.CollectionResourceList, s conversion.Scope) error {
+	return autoConvert_v1beta2_CollectionResourceList_To_clusterpedia_CollectionResourceList(in, out, s)
+}
+
+func autoConvert_clusterpedia_CollectionResourceList_To_v1beta2_CollectionResourceList(in *clusterpedia.CollectionResourceList, out *CollectionResourceList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CollectionResource, len(*in))
+		for i := range *in {
+			if err := Convert_clusterpedia_CollectionResource_To_v1beta2_CollectionResource(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_clusterpedia_CollectionResourceList_To_v1beta2_CollectionResourceList is an autogenerated conversion function.
+func Convert_clusterpedia_CollectionResourceList_To_v1beta2_CollectionResourceList(in *clusterpedia.CollectionResourceList, out *CollectionResourceList, s conversion.Scope) error {
+	return autoConvert_clusterpedia_CollectionResourceList_To_v1beta2_CollectionResourceList(in, out, s)
+}
+
+func autoConvert_v1beta2_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup(in *CollectionResourceNamespaceGroup, out *clusterpedia.CollectionResourceNamespaceGroup, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.Object, len(*in))
+		for i := range *in {
+			if err := runtime.Convert_runtime_RawExtension_To_runtime_Object(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta2_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup is an autogenerated conversion function.
+func Convert_v1beta2_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup(in *CollectionResourceNamespaceGroup, out *clusterpedia.CollectionResourceNamespaceGroup, s conversion.Scope) error {
+	return autoConvert_v1beta2_CollectionResourceNamespaceGroup_To_clusterpedia_CollectionResourceNamespaceGroup(in, out, s)
+}
+
+func autoConvert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta2_CollectionResourceNamespaceGroup(in *clusterpedia.CollectionResourceNamespaceGroup, out *CollectionResourceNamespaceGroup, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			if err := runtime.Convert_runtime_Object_To_runtime_RawExtension(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta2_CollectionResourceNamespaceGroup is an autogenerated conversion function.
+func Convert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta2_CollectionResourceNamespaceGroup(in *clusterpedia.CollectionResourceNamespaceGroup, out *CollectionResourceNamespaceGroup, s conversion.Scope) error {
+	return autoConvert_clusterpedia_CollectionResourceNamespaceGroup_To_v1beta2_CollectionResourceNamespaceGroup(in, out, s)
+}
+
+func autoConvert_v1beta2_CollectionResourceType_To_clusterpedia_CollectionResourceType(in *CollectionResourceType, out *clusterpedia.CollectionResourceType, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Version = in.Version
+	out.Kind = in.Kind
+	out.Resource = in.Resource
+	return nil
+}
+
+// Convert_v1beta2_CollectionResourceType_To_clusterpedia_CollectionResourceType is an autogenerated conversion function.
+func Convert_v1beta2_CollectionResourceType_To_clusterpedia_CollectionResourceType(in *CollectionResourceType, out *clusterpedia.CollectionResourceType, s conversion.Scope) error {
+	return autoConvert_v1beta2_CollectionResourceType_To_clusterpedia_CollectionResourceType(in, out, s)
+}
+
+func autoConvert_clusterpedia_CollectionResourceType_To_v1beta2_CollectionResourceType(in *clusterpedia.CollectionResourceType, out *CollectionResourceType, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Version = in.Version
+	out.Kind = in.Kind
+	out.Resource = in.Resource
+	return nil
+}
+
+// Convert_clusterpedia_CollectionResourceType_To_v1beta2_CollectionResourceType is an autogenerated conversion function.
+func Convert_clusterpedia_CollectionResourceType_To_v1beta2_CollectionResourceType(in *clusterpedia.CollectionResourceType, out *CollectionResourceType, s conversion.Scope) error {
+	return autoConvert_clusterpedia_CollectionResourceType_To_v1beta2_CollectionResourceType(in, out, s)
+}
+
+func autoConvert_v1beta2_ListOptions_To_clusterpedia_ListOptions(in *ListOptions, out *clusterpedia.ListOptions, s conversion.Scope) error {
+	// FIXME: Provide conversion function to convert v1.ListOptions to internalversion.ListOptions
+	compileErrorOnMissingConversion()
+	out.Names = *(*[]string)(unsafe.Pointer(&in.Names))
+	out.ClusterNames = *(*[]string)(unsafe.Pointer(&in.ClusterNames))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	// WARNING: in.OrderByFields requires manual conversion: does not exist in peer-type
+	out.OwnerUID = in.OwnerUID
+	out.OwnerName = in.OwnerName
+	// WARNING: in.OwnerGroupResource requires manual conversion: inconvertible types (string vs k8s.io/apimachinery/pkg/runtime/schema.GroupResource)
+	out.OwnerSeniority = in.OwnerSeniority
+	out.Since = (*v1.Time)(unsafe.Pointer(in.Since))
+	out.Before = (*v1.Time)(unsafe.Pointer(in.Before))
+	out.InjectEvents = in.InjectEvents
+	out.WithContinue = (*bool)(unsafe.Pointer(in.WithContinue))
+	out.WithRemainingCount = (*bool)(unsafe.Pointer(in.WithRemainingCount))
+	out.OnlyMetadata = in.OnlyMetadata
+	out.Dedup = in.Dedup
+	out.CountOnly = in.CountOnly
+	out.ResourceScope = in.ResourceScope
+	out.GroupBy = in.GroupBy
+	// WARNING: in.urlQuery requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_clusterpedia_ListOptions_To_v1beta2_ListOptions(in *clusterpedia.ListOptions, out *ListOptions, s conversion.Scope) error {
+	// FIXME: Provide conversion function to convert internalversion.ListOptions to v1.ListOptions
+	compileErrorOnMissingConversion()
+	out.Names = *(*[]string)(unsafe.Pointer(&in.Names))
+	out.ClusterNames = *(*[]string)(unsafe.Pointer(&in.ClusterNames))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	// WARNING: in.OrderBy requires manual conversion: does not exist in peer-type
+	out.OwnerName = in.OwnerName
+	out.OwnerUID = in.OwnerUID
+	// WARNING: in.OwnerGroupResource requires manual conversion: inconvertible types (k8s.io/apimachinery/pkg/runtime/schema.GroupResource vs string)
+	out.OwnerSeniority = in.OwnerSeniority
+	out.Since = (*v1.Time)(unsafe.Pointer(in.Since))
+	out.Before = (*v1.Time)(unsafe.Pointer(in.Before))
+	out.InjectEvents = in.InjectEvents
+	out.WithContinue = (*bool)(unsafe.Pointer(in.WithContinue))
+	out.WithRemainingCount = (*bool)(unsafe.Pointer(in.WithRemainingCount))
+	// WARNING: in.EnhancedFieldSelector requires manual conversion: does not exist in peer-type
+	// WARNING: in.ExtraLabelSelector requires manual conversion: does not exist in peer-type
+	// WARNING: in.URLQuery requires manual conversion: does not exist in peer-type
+	out.OnlyMetadata = in.OnlyMetadata
+	out.Dedup = in.Dedup
+	out.CountOnly = in.CountOnly
+	out.ResourceScope = in.ResourceScope
+	out.GroupBy = in.GroupBy
+	return nil
+}
+
+func autoConvert_url_Values_To_v1beta2_ListOptions(in *url.Values, out *ListOptions, s conversion.Scope) error {
+	// WARNING: Field ListOptions does not have json tag, skipping.
+
+	if values, ok := map[string][]string(*in)["names"]; ok && len(values) > 0 {
+		out.Names = *(*[]string)(unsafe.Pointer(&values))
+	} else {
+		out.Names = nil
+	}
+	if values, ok := map[string][]string(*in)["clusters"]; ok && len(values) > 0 {
+		out.ClusterNames = *(*[]string)(unsafe.Pointer(&values))
+	} else {
+		out.ClusterNames = nil
+	}
+	if values, ok := map[string][]string(*in)["namespaces"]; ok && len(values) > 0 {
+		out.Namespaces = *(*[]string)(unsafe.Pointer(&values))
+	} else {
+		out.Namespaces = nil
+	}
+	if values, ok := map[string][]string(*in)["orderby"]; ok && len(values) > 0 {
+		out.OrderByFields = *(*[]string)(unsafe.Pointer(&values))
+	} else {
+		out.OrderByFields = nil
+	}
+	if values, ok := map[string][]string(*in)["ownerUID"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_string(&values, &out.OwnerUID, s); err != nil {
+			return err
+		}
+	} else {
+		out.OwnerUID = ""
+	}
+	if values, ok := map[string][]string(*in)["ownerName"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_string(&values, &out.OwnerName, s); err != nil {
+			return err
+		}
+	} else {
+		out.OwnerName = ""
+	}
+	if values, ok := map[string][]string(*in)["ownerGR"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_string(&values, &out.OwnerGroupResource, s); err != nil {
+			return err
+		}
+	} else {
+		out.OwnerGroupResource = ""
+	}
+	if values, ok := map[string][]string(*in)["ownerSeniority"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_int(&values, &out.OwnerSeniority, s); err != nil {
+			return err
+		}
+	} else {
+		out.OwnerSeniority = 0
+	}
+	if values, ok := map[string][]string(*in)["since"]; ok && len(values) > 0 {
+		if err := v1.Convert_Slice_string_To_Pointer_v1_Time(&values, &out.Since, s); err != nil {
+			return err
+		}
+	} else {
+		out.Since = nil
+	}
+	if values, ok := map[string][]string(*in)["before"]; ok && len(values) > 0 {
+		if err := v1.Convert_Slice_string_To_Pointer_v1_Time(&values, &out.Before, s); err != nil {
+			return err
+		}
+	} else {
+		out.Before = nil
+	}
+	if values, ok := map[string][]string(*in)["injectEvents"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_bool(&values, &out.InjectEvents, s); err != nil {
+			return err
+		}
+	} else {
+		out.InjectEvents = false
+	}
+	if values, ok := map[string][]string(*in)["withContinue"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_Pointer_bool(&values, &out.WithContinue, s); err != nil {
+			return err
+		}
+	} else {
+		out.WithContinue = nil
+	}
+	if values, ok := map[string][]string(*in)["withRemainingCount"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_Pointer_bool(&values, &out.WithRemainingCount, s); err != nil {
+			return err
+		}
+	} else {
+		out.WithRemainingCount = nil
+	}
+	if values, ok := map[string][]string(*in)["onlyMetadata"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_bool(&values, &out.OnlyMetadata, s); err != nil {
+			return err
+		}
+	} else {
+		out.OnlyMetadata = false
+	}
+	if values, ok := map[string][]string(*in)["dedup"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_bool(&values, &out.Dedup, s); err != nil {
+			return err
+		}
+	} else {
+		out.Dedup = false
+	}
+	if values, ok := map[string][]string(*in)["countOnly"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_bool(&values, &out.CountOnly, s); err != nil {
+			return err
+		}
+	} else {
+		out.CountOnly = false
+	}
+	if values, ok := map[string][]string(*in)["resourceScope"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_string(&values, &out.ResourceScope, s); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceScope = ""
+	}
+	if values, ok := map[string][]string(*in)["groupBy"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_string(&values, &out.GroupBy, s); err != nil {
+			return err
+		}
+	} else {
+		out.GroupBy = ""
+	}
+	// WARNING: Field urlQuery does not have json tag, skipping.
+
+	return nil
+}
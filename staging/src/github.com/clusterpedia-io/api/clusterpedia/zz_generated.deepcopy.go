@@ -30,6 +30,13 @@ func (in *CollectionResource) DeepCopyInto(out *CollectionResource) {
 			}
 		}
 	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]CollectionResourceGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.RemainingItemCount != nil {
 		in, out := &in.RemainingItemCount, &out.RemainingItemCount
 		*out = new(int64)
@@ -56,6 +63,38 @@ func (in *CollectionResource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionResourceGroup) DeepCopyInto(out *CollectionResourceGroup) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.Object, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = (*in)[i].DeepCopyObject()
+			}
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]CollectionResourceNamespaceGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionResourceGroup.
+func (in *CollectionResourceGroup) DeepCopy() *CollectionResourceGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionResourceGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CollectionResourceList) DeepCopyInto(out *CollectionResourceList) {
 	*out = *in
@@ -89,6 +128,31 @@ func (in *CollectionResourceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionResourceNamespaceGroup) DeepCopyInto(out *CollectionResourceNamespaceGroup) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]runtime.Object, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				(*out)[i] = (*in)[i].DeepCopyObject()
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionResourceNamespaceGroup.
+func (in *CollectionResourceNamespaceGroup) DeepCopy() *CollectionResourceNamespaceGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionResourceNamespaceGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CollectionResourceType) DeepCopyInto(out *CollectionResourceType) {
 	*out = *in
@@ -0,0 +1,285 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:scope="Cluster",shortName=pediaconfig
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+
+// ClusterpediaConfiguration holds the settings which can be tuned at runtime,
+// the components watch the configuration named by their `--configuration-name` flag and apply the changes without restarting.
+// The unset fields fall back to the flags and the feature gates of the components.
+type ClusterpediaConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ClusterpediaConfigurationSpec `json:"spec,omitempty"`
+}
+
+type ClusterpediaConfigurationSpec struct {
+	// List is the server-side defaults and guardrails of the list requests, it is applied by the apiserver.
+	// +optional
+	List *ListConfiguration `json:"list,omitempty"`
+
+	// Prune is the fields pruned from the objects before they are saved, it is applied by the clustersynchro manager.
+	// +optional
+	Prune *PruneConfiguration `json:"prune,omitempty"`
+
+	// Retention is how long the terminated objects are retained, it is applied by the clustersynchro manager.
+	// +optional
+	Retention *RetentionConfiguration `json:"retention,omitempty"`
+
+	// Enrichment is the cluster metadata stamped on the objects before they are saved, it is applied by the clustersynchro manager.
+	// +optional
+	Enrichment *EnrichmentConfiguration `json:"enrichment,omitempty"`
+
+	// Masking is the sensitive fields redacted from the objects, the rules of the `Storage` stage are applied by
+	// the clustersynchro manager before the objects are saved, and the rules of the `Serving` stage are applied by
+	// the apiserver before the objects are returned.
+	// +optional
+	Masking *MaskingConfiguration `json:"masking,omitempty"`
+
+	// Filters are the WASM functions which filter and transform the listed objects, they are applied by the apiserver
+	// to the lists requested with the `search.clusterpedia.io/filter=<name>` search label.
+	// +optional
+	Filters *FilterConfiguration `json:"filters,omitempty"`
+
+	// ResourceMappings map the custom resources of the member clusters to the resources they are stored and served as,
+	// e.g. the forked custom resource of a vendored group is treated as the upstream resource, so that it is queried
+	// uniformly across the clusters. They are applied by the clustersynchro manager when the resources of the clusters
+	// are negotiated, the first rule matching the resource is applied.
+	// +optional
+	ResourceMappings []ResourceMappingRule `json:"resourceMappings,omitempty"`
+}
+
+type ListConfiguration struct {
+	// DefaultLimit is the limit of the list requests that don't specify the limit, 0 means no default limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DefaultLimit *int64 `json:"defaultLimit,omitempty"`
+
+	// MaxLimit is the maximum limit of the list requests, 0 means no maximum limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxLimit *int64 `json:"maxLimit,omitempty"`
+
+	// DefaultOrderBy is the order of the list requests that don't specify the orderby,
+	// formatted as the orderby query, e.g. 'namespace,name desc'.
+	// +optional
+	DefaultOrderBy *string `json:"defaultOrderBy,omitempty"`
+
+	// ClusterRequiredGroups are the api groups whose resources can only be listed with the clusters specified,
+	// 'core' is the core group.
+	// +optional
+	// +listType=set
+	ClusterRequiredGroups []string `json:"clusterRequiredGroups,omitempty"`
+}
+
+type PruneConfiguration struct {
+	// ManagedFields prunes the `metadata.managedFields` of the objects,
+	// it overrides the PruneManagedFields feature gate.
+	// +optional
+	ManagedFields *bool `json:"managedFields,omitempty"`
+
+	// LastAppliedConfiguration prunes the `kubectl.kubernetes.io/last-applied-configuration` annotation of the objects,
+	// it overrides the PruneLastAppliedConfiguration feature gate.
+	// +optional
+	LastAppliedConfiguration *bool `json:"lastAppliedConfiguration,omitempty"`
+
+	// Fields are the paths of the fields pruned from the objects, formatted as the dot-separated field names,
+	// e.g. `status.images`. The dots in the field names are escaped by `\`, e.g. `metadata.annotations.example\.io/note`.
+	// +optional
+	// +listType=set
+	Fields []string `json:"fields,omitempty"`
+
+	// Resources override the prune configuration for the resources, their unset fields fall back to the configuration,
+	// and their fields are pruned in addition to the fields of the configuration.
+	// +optional
+	Resources []ResourcePruneConfiguration `json:"resources,omitempty"`
+}
+
+type ResourcePruneConfiguration struct {
+	// Group is the group of the resource, the empty group is the core group.
+	// +optional
+	Group string `json:"group"`
+
+	// Version is the version of the resource, the empty version matches all versions.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
+
+	// +optional
+	ManagedFields *bool `json:"managedFields,omitempty"`
+
+	// +optional
+	LastAppliedConfiguration *bool `json:"lastAppliedConfiguration,omitempty"`
+
+	// +optional
+	// +listType=set
+	Fields []string `json:"fields,omitempty"`
+}
+
+type RetentionConfiguration struct {
+	// TerminatedPodsTTL is how long the terminated pods are retained, 0 means they are retained until they are deleted.
+	// It overrides the `--terminated-pods-ttl` flag, the change applies to the pods when they are synchronized again.
+	// +optional
+	TerminatedPodsTTL *metav1.Duration `json:"terminatedPodsTTL,omitempty"`
+
+	// CompletedJobsTTL is how long the completed jobs are retained, 0 means they are retained until they are deleted.
+	// It overrides the `--completed-jobs-ttl` flag, the change applies to the jobs when they are synchronized again.
+	// +optional
+	CompletedJobsTTL *metav1.Duration `json:"completedJobsTTL,omitempty"`
+}
+
+type EnrichmentConfiguration struct {
+	// ClusterLabels are the keys of the PediaCluster labels stamped on the objects as the
+	// `cluster-labels.shadow.clusterpedia.io/<name>` annotations, the name is the label key without the prefix,
+	// e.g. `region` of `topology.kubernetes.io/region`. The annotations can be queried by the label selector,
+	// e.g. 'cluster-labels.shadow.clusterpedia.io/region=us-east-1'.
+	// The change applies to the objects when they are synchronized again.
+	// +optional
+	// +listType=set
+	ClusterLabels []string `json:"clusterLabels,omitempty"`
+}
+
+const (
+	// MaskingStageStorage masks the fields before the objects are saved, so the sensitive values are never persisted
+	MaskingStageStorage = "Storage"
+
+	// MaskingStageServing masks the fields before the objects are returned by the apiserver
+	MaskingStageServing = "Serving"
+)
+
+type MaskingConfiguration struct {
+	// Replacement replaces the string values of the masked fields, it is `REDACTED` by default,
+	// which is also a valid base64 value of the bytes fields, e.g. the data of the secrets.
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+
+	// Rules are the masking rules of the resources, the fields matched by any of the rules are masked.
+	// +optional
+	Rules []MaskingRule `json:"rules,omitempty"`
+}
+
+type MaskingRule struct {
+	// Group is the group of the resource, the empty group is the core group and `*` matches all groups.
+	// +optional
+	Group string `json:"group"`
+
+	// Version is the version of the resource, the empty version matches all versions.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Resource is the resource, `*` matches all resources of the group.
+	// +required
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
+
+	// Stage is when the fields are masked, it is `Storage` by default.
+	// +optional
+	// +kubebuilder:validation:Enum=Storage;Serving
+	Stage string `json:"stage,omitempty"`
+
+	// Fields are the paths of the masked fields, formatted as the dot-separated field names, e.g. `spec.token`.
+	// The string values of the fields and the string values nested in them are replaced.
+	// +optional
+	// +listType=set
+	Fields []string `json:"fields,omitempty"`
+
+	// Annotations are the keys of the masked annotations
+	// +optional
+	// +listType=set
+	Annotations []string `json:"annotations,omitempty"`
+
+	// EnvNames are the regular expressions of the names of the environment variables of the containers,
+	// the values of the matched environment variables are masked, e.g. `(?i)token|password|secret`.
+	// The containers of the pods and the pod templates of the workloads are masked.
+	// +optional
+	// +listType=set
+	EnvNames []string `json:"envNames,omitempty"`
+
+	// EnvValues are the regular expressions of the values of the environment variables of the containers,
+	// the matched values are masked, e.g. `^eyJ` for the JWT tokens.
+	// +optional
+	// +listType=set
+	EnvValues []string `json:"envValues,omitempty"`
+}
+
+type FilterConfiguration struct {
+	// Functions are the filter functions selected by their names
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Functions []FilterFunction `json:"functions,omitempty"`
+}
+
+type FilterFunction struct {
+	// Name is the value of the `search.clusterpedia.io/filter` search label which selects the function
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Module is the WebAssembly binary of the function. The module can't import any host function, and it exports
+	// the `memory`, the `alloc(size i32) i32` function allocating the input, and the `filter(ptr i32, size i32) i64` function
+	// which is called with the JSON of each listed object. The `filter` returns 0 to drop the object, 1 to keep it,
+	// or the pointer shifted left by 32 bits and ORed with the size of the JSON of the transformed object.
+	// +required
+	// +kubebuilder:validation:Required
+	Module []byte `json:"module"`
+
+	// Timeout is the time limit of running the function over the objects of a list request, it is 1s by default.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MemoryLimit is the memory limit of the function, it is 16Mi by default and is rounded up to the 64Ki pages.
+	// +optional
+	MemoryLimit *resource.Quantity `json:"memoryLimit,omitempty"`
+}
+
+type ResourceMappingRule struct {
+	// From is the resource of the member clusters, the empty version matches all versions.
+	// +required
+	// +kubebuilder:validation:Required
+	From MappedResource `json:"from"`
+
+	// To is the resource which the objects are stored and served as, the empty version keeps the version of the member clusters.
+	// The kind of the objects is kept, only the group and the version of the objects are changed.
+	// +required
+	// +kubebuilder:validation:Required
+	To MappedResource `json:"to"`
+}
+
+type MappedResource struct {
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Group string `json:"group"`
+
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Resource string `json:"resource"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterpediaConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterpediaConfiguration `json:"items"`
+}
@@ -0,0 +1,421 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterpediaConfiguration) DeepCopyInto(out *ClusterpediaConfiguration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterpediaConfiguration.
+func (in *ClusterpediaConfiguration) DeepCopy() *ClusterpediaConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterpediaConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterpediaConfiguration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterpediaConfigurationList) DeepCopyInto(out *ClusterpediaConfigurationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterpediaConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterpediaConfigurationList.
+func (in *ClusterpediaConfigurationList) DeepCopy() *ClusterpediaConfigurationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterpediaConfigurationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterpediaConfigurationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterpediaConfigurationSpec) DeepCopyInto(out *ClusterpediaConfigurationSpec) {
+	*out = *in
+	if in.List != nil {
+		in, out := &in.List, &out.List
+		*out = new(ListConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(PruneConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(RetentionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Enrichment != nil {
+		in, out := &in.Enrichment, &out.Enrichment
+		*out = new(EnrichmentConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Masking != nil {
+		in, out := &in.Masking, &out.Masking
+		*out = new(MaskingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = new(FilterConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceMappings != nil {
+		in, out := &in.ResourceMappings, &out.ResourceMappings
+		*out = make([]ResourceMappingRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterpediaConfigurationSpec.
+func (in *ClusterpediaConfigurationSpec) DeepCopy() *ClusterpediaConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterpediaConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnrichmentConfiguration) DeepCopyInto(out *EnrichmentConfiguration) {
+	*out = *in
+	if in.ClusterLabels != nil {
+		in, out := &in.ClusterLabels, &out.ClusterLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnrichmentConfiguration.
+func (in *EnrichmentConfiguration) DeepCopy() *EnrichmentConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(EnrichmentConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterConfiguration) DeepCopyInto(out *FilterConfiguration) {
+	*out = *in
+	if in.Functions != nil {
+		in, out := &in.Functions, &out.Functions
+		*out = make([]FilterFunction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterConfiguration.
+func (in *FilterConfiguration) DeepCopy() *FilterConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterFunction) DeepCopyInto(out *FilterFunction) {
+	*out = *in
+	if in.Module != nil {
+		in, out := &in.Module, &out.Module
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MemoryLimit != nil {
+		in, out := &in.MemoryLimit, &out.MemoryLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterFunction.
+func (in *FilterFunction) DeepCopy() *FilterFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListConfiguration) DeepCopyInto(out *ListConfiguration) {
+	*out = *in
+	if in.DefaultLimit != nil {
+		in, out := &in.DefaultLimit, &out.DefaultLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxLimit != nil {
+		in, out := &in.MaxLimit, &out.MaxLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefaultOrderBy != nil {
+		in, out := &in.DefaultOrderBy, &out.DefaultOrderBy
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClusterRequiredGroups != nil {
+		in, out := &in.ClusterRequiredGroups, &out.ClusterRequiredGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListConfiguration.
+func (in *ListConfiguration) DeepCopy() *ListConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ListConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MappedResource) DeepCopyInto(out *MappedResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MappedResource.
+func (in *MappedResource) DeepCopy() *MappedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(MappedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaskingConfiguration) DeepCopyInto(out *MaskingConfiguration) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]MaskingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaskingConfiguration.
+func (in *MaskingConfiguration) DeepCopy() *MaskingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MaskingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaskingRule) DeepCopyInto(out *MaskingRule) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvNames != nil {
+		in, out := &in.EnvNames, &out.EnvNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvValues != nil {
+		in, out := &in.EnvValues, &out.EnvValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaskingRule.
+func (in *MaskingRule) DeepCopy() *MaskingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(MaskingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruneConfiguration) DeepCopyInto(out *PruneConfiguration) {
+	*out = *in
+	if in.ManagedFields != nil {
+		in, out := &in.ManagedFields, &out.ManagedFields
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastAppliedConfiguration != nil {
+		in, out := &in.LastAppliedConfiguration, &out.LastAppliedConfiguration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourcePruneConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneConfiguration.
+func (in *PruneConfiguration) DeepCopy() *PruneConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PruneConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMappingRule) DeepCopyInto(out *ResourceMappingRule) {
+	*out = *in
+	out.From = in.From
+	out.To = in.To
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMappingRule.
+func (in *ResourceMappingRule) DeepCopy() *ResourceMappingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMappingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePruneConfiguration) DeepCopyInto(out *ResourcePruneConfiguration) {
+	*out = *in
+	if in.ManagedFields != nil {
+		in, out := &in.ManagedFields, &out.ManagedFields
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastAppliedConfiguration != nil {
+		in, out := &in.LastAppliedConfiguration, &out.LastAppliedConfiguration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePruneConfiguration.
+func (in *ResourcePruneConfiguration) DeepCopy() *ResourcePruneConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePruneConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionConfiguration) DeepCopyInto(out *RetentionConfiguration) {
+	*out = *in
+	if in.TerminatedPodsTTL != nil {
+		in, out := &in.TerminatedPodsTTL, &out.TerminatedPodsTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CompletedJobsTTL != nil {
+		in, out := &in.CompletedJobsTTL, &out.CompletedJobsTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionConfiguration.
+func (in *RetentionConfiguration) DeepCopy() *RetentionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
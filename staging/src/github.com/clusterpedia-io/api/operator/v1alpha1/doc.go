@@ -0,0 +1,6 @@
+// +k8s:deepcopy-gen=package
+// +k8s:openapi-gen=true
+// +groupName=operator.clusterpedia.io
+
+// Package v1alpha1 is the v1alpha1 version of the API
+package v1alpha1
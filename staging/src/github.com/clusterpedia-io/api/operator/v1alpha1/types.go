@@ -0,0 +1,188 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ReadyCondition       = "Ready"
+	ProgressingCondition = "Progressing"
+)
+
+const (
+	ReadyReason          = "Ready"
+	NotReadyReason       = "NotReady"
+	InvalidConfigReason  = "InvalidConfig"
+	ReconcileErrorReason = "ReconcileError"
+
+	RolloutReason   = "Rollout"
+	UpgradingReason = "Upgrading"
+	AvailableReason = "Available"
+)
+
+const (
+	APIServerComponent             = "apiserver"
+	ClusterSynchroManagerComponent = "clustersynchro-manager"
+	ControllerManagerComponent     = "controller-manager"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope="Namespaced",path=clusterpedias,singular=clusterpedia
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.conditions[?(@.type == 'Ready')].status"
+// +kubebuilder:printcolumn:name="Progressing",type=string,JSONPath=".status.conditions[?(@.type == 'Progressing')].reason"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+
+// Clusterpedia declares an installation of clusterpedia in its namespace,
+// the operator manages the deployments of the components and the storage config of the installation.
+type Clusterpedia struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Spec ClusterpediaSpec `json:"spec"`
+
+	// +optional
+	Status ClusterpediaStatus `json:"status,omitempty"`
+}
+
+type ClusterpediaSpec struct {
+	// Version is the version of the components, eg. 'v0.9.0'.
+	// The components are upgraded in order when the version is changed,
+	// the clustersynchro managers first, then the apiserver and the controller manager.
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+
+	// ImageRepository is the repository of the images of the components,
+	// the image of a component is '<imageRepository>/<component>:<version>'.
+	// +optional
+	// +kubebuilder:default="ghcr.io/clusterpedia-io/clusterpedia"
+	ImageRepository string `json:"imageRepository,omitempty"`
+
+	// ServiceAccountName is the service account of the components,
+	// the operator creates it if it does not exist, the permissions are bound by the installation manifests.
+	// +optional
+	// +kubebuilder:default="clusterpedia"
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	Storage StorageSpec `json:"storage"`
+
+	// +optional
+	APIServer ComponentSpec `json:"apiserver,omitempty"`
+
+	// +optional
+	ClusterSynchroManager ClusterSynchroManagerSpec `json:"clusterSynchroManager,omitempty"`
+
+	// +optional
+	ControllerManager ComponentSpec `json:"controllerManager,omitempty"`
+}
+
+type StorageSpec struct {
+	// Name is the name of the storage layer, eg. 'internal'.
+	// +optional
+	// +kubebuilder:default="internal"
+	Name string `json:"name,omitempty"`
+
+	// Config is the content of the storage config file,
+	// the operator saves it into a secret mounted by the apiserver and the clustersynchro managers.
+	// Config and ConfigSecretName are mutually exclusive.
+	// +optional
+	Config string `json:"config,omitempty"`
+
+	// ConfigSecretName is the name of an existing secret, whose key 'storage-config.yaml' is the storage config file.
+	// +optional
+	ConfigSecretName string `json:"configSecretName,omitempty"`
+
+	// EnvSecretName is the name of an existing secret, whose keys are set as the environment variables
+	// of the apiserver and the clustersynchro managers, eg. 'DB_PASSWORD'.
+	// +optional
+	EnvSecretName string `json:"envSecretName,omitempty"`
+}
+
+type ComponentSpec struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Image overrides the image of the component, the version is not applied to the overridden image.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// FeatureGates are the feature gates of the component.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// ExtraArgs are appended to the command line arguments of the component, eg. '--v=3'.
+	// +optional
+	// +listType=atomic
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+}
+
+type ClusterSynchroManagerSpec struct {
+	ComponentSpec `json:",inline"`
+
+	// Shards are the sharding names of the clustersynchro managers,
+	// a clustersynchro manager is deployed for each shard and syncs the clusters with the same sharding name.
+	// The clustersynchro manager without a sharding name is always deployed for the clusters without a sharding name.
+	// +optional
+	// +listType=set
+	Shards []string `json:"shards,omitempty"`
+}
+
+type ClusterpediaStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Version is the version that all of the components have been rolled out to.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Components []ComponentStatus `json:"components,omitempty"`
+}
+
+type ComponentStatus struct {
+	// Name is the name of the deployment of the component.
+	// +required
+	Name string `json:"name"`
+
+	// +required
+	Component string `json:"component"`
+
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// +optional
+	Replicas int32 `json:"replicas"`
+
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterpediaList contains a list of Clusterpedia
+type ClusterpediaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Clusterpedia `json:"items"`
+}